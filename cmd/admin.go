@@ -63,6 +63,8 @@ func newAuthCommand() *cli.Command {
 			microcmdAuthUpdateLdapSimpleAuth(),
 			microcmdAuthAddSMTP(),
 			microcmdAuthUpdateSMTP(),
+			microcmdAuthAddSAML(),
+			microcmdAuthUpdateSAML(),
 			newAuthListCommand(),
 			newAuthDeleteCommand(),
 		},
@@ -0,0 +1,267 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	auth_model "gitea.dev/models/auth"
+	"gitea.dev/modules/util"
+	"gitea.dev/services/auth/source/saml"
+
+	"github.com/urfave/cli/v3"
+)
+
+func samlCLIFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:  "name",
+			Value: "",
+			Usage: "Application Name",
+		},
+		&cli.StringFlag{
+			Name:  "metadata-url",
+			Value: "",
+			Usage: "URL of the identity provider metadata, downloaded when the source is saved",
+		},
+		&cli.StringFlag{
+			Name:  "metadata-file",
+			Value: "",
+			Usage: "File containing the identity provider metadata",
+		},
+		&cli.StringFlag{
+			Name:  "sp-certificate-file",
+			Value: "",
+			Usage: "PEM file containing the service provider certificate, to sign the requests",
+		},
+		&cli.StringFlag{
+			Name:  "sp-private-key-file",
+			Value: "",
+			Usage: "PEM file containing the service provider private key, to sign the requests",
+		},
+		&cli.StringFlag{
+			Name:  "name-id-format",
+			Value: "",
+			Usage: "NameID format requested from the identity provider",
+		},
+		&cli.BoolFlag{
+			Name:  "allow-idp-initiated",
+			Usage: "Accept the responses the identity provider sends without an authentication request",
+		},
+		&cli.BoolFlag{
+			Name:  "auto-register",
+			Usage: "Create the users signing in for the first time",
+		},
+		&cli.StringFlag{
+			Name:  "icon-url",
+			Value: "",
+			Usage: "Custom icon URL for the sign-in button",
+		},
+		&cli.StringFlag{
+			Name:  "attribute-username",
+			Value: "",
+			Usage: "Attribute containing the username, the NameID is used if empty",
+		},
+		&cli.StringFlag{
+			Name:  "attribute-email",
+			Value: "",
+			Usage: "Attribute containing the email address",
+		},
+		&cli.StringFlag{
+			Name:  "attribute-full-name",
+			Value: "",
+			Usage: "Attribute containing the full name",
+		},
+		&cli.StringFlag{
+			Name:  "attribute-groups",
+			Value: "",
+			Usage: "Attribute containing the groups of the user",
+		},
+		&cli.StringFlag{
+			Name:  "admin-group",
+			Value: "",
+			Usage: "Group of the administrator users",
+		},
+		&cli.StringFlag{
+			Name:  "restricted-group",
+			Value: "",
+			Usage: "Group of the restricted users",
+		},
+		&cli.StringFlag{
+			Name:  "group-team-map",
+			Value: "",
+			Usage: "JSON mapping between groups and org teams",
+		},
+		&cli.BoolFlag{
+			Name:  "group-team-map-removal",
+			Usage: "Activate automatic team membership removal depending on groups",
+		},
+		&cli.BoolFlag{
+			Name:  "skip-local-2fa",
+			Usage: "Skip 2FA to log on.",
+		},
+		&cli.BoolFlag{
+			Name:  "active",
+			Usage: "This Authentication Source is Activated.",
+			Value: true,
+		},
+	}
+}
+
+func microcmdAuthUpdateSAML() *cli.Command {
+	return &cli.Command{
+		Name:  "update-saml",
+		Usage: "Update existing SAML authentication source",
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			return newAuthService().runUpdateSAML(ctx, cmd)
+		},
+		Flags: append(samlCLIFlags()[:1], append([]cli.Flag{&cli.Int64Flag{
+			Name:  "id",
+			Usage: "ID of authentication source",
+		}}, samlCLIFlags()[1:]...)...),
+	}
+}
+
+func microcmdAuthAddSAML() *cli.Command {
+	return &cli.Command{
+		Name:  "add-saml",
+		Usage: "Add new SAML authentication source",
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			return newAuthService().runAddSAML(ctx, cmd)
+		},
+		Flags: samlCLIFlags(),
+	}
+}
+
+func readSAMLFileFlag(c *cli.Command, name string) (string, error) {
+	data, err := os.ReadFile(c.String(name))
+	if err != nil {
+		return "", fmt.Errorf("unable to read --%s: %w", name, err)
+	}
+	return string(data), nil
+}
+
+func parseSAMLConfig(ctx context.Context, c *cli.Command, conf *saml.Source) (err error) {
+	if c.IsSet("metadata-url") {
+		conf.IdentityProviderMetadataURL = c.String("metadata-url")
+	}
+	if c.IsSet("metadata-file") {
+		if conf.IdentityProviderMetadata, err = readSAMLFileFlag(c, "metadata-file"); err != nil {
+			return err
+		}
+		conf.IdentityProviderMetadataURL = ""
+	} else if conf.IdentityProviderMetadataURL != "" {
+		if conf.IdentityProviderMetadata, err = saml.FetchIdentityProviderMetadata(ctx, conf.IdentityProviderMetadataURL); err != nil {
+			return err
+		}
+	}
+	if c.IsSet("sp-certificate-file") {
+		if conf.ServiceProviderCertificate, err = readSAMLFileFlag(c, "sp-certificate-file"); err != nil {
+			return err
+		}
+	}
+	if c.IsSet("sp-private-key-file") {
+		if conf.ServiceProviderPrivateKey, err = readSAMLFileFlag(c, "sp-private-key-file"); err != nil {
+			return err
+		}
+	}
+	if c.IsSet("name-id-format") {
+		conf.NameIDFormat = c.String("name-id-format")
+	}
+	if c.IsSet("allow-idp-initiated") {
+		conf.AllowIdPInitiated = c.Bool("allow-idp-initiated")
+	}
+	if c.IsSet("auto-register") {
+		conf.AutoRegister = c.Bool("auto-register")
+	}
+	if c.IsSet("icon-url") {
+		conf.IconURL = c.String("icon-url")
+	}
+	if c.IsSet("attribute-username") {
+		conf.AttributeUsername = c.String("attribute-username")
+	}
+	if c.IsSet("attribute-email") {
+		conf.AttributeEmail = c.String("attribute-email")
+	}
+	if c.IsSet("attribute-full-name") {
+		conf.AttributeFullName = c.String("attribute-full-name")
+	}
+	if c.IsSet("attribute-groups") {
+		conf.AttributeGroups = c.String("attribute-groups")
+	}
+	if c.IsSet("admin-group") {
+		conf.AdminGroup = c.String("admin-group")
+	}
+	if c.IsSet("restricted-group") {
+		conf.RestrictedGroup = c.String("restricted-group")
+	}
+	if c.IsSet("group-team-map") {
+		conf.GroupTeamMap = c.String("group-team-map")
+	}
+	if c.IsSet("group-team-map-removal") {
+		conf.GroupTeamMapRemoval = c.Bool("group-team-map-removal")
+	}
+	return conf.Validate()
+}
+
+func (a *authService) runAddSAML(ctx context.Context, c *cli.Command) error {
+	if err := a.initDB(ctx); err != nil {
+		return err
+	}
+
+	if !c.IsSet("name") || len(c.String("name")) == 0 {
+		return errors.New("name must be set")
+	}
+	if !c.IsSet("metadata-url") && !c.IsSet("metadata-file") {
+		return errors.New("metadata-url or metadata-file must be set")
+	}
+
+	var samlConfig saml.Source
+	if err := parseSAMLConfig(ctx, c, &samlConfig); err != nil {
+		return err
+	}
+
+	return a.createAuthSource(ctx, &auth_model.Source{
+		Type:            auth_model.SAML,
+		Name:            c.String("name"),
+		IsActive:        c.Bool("active"),
+		Cfg:             &samlConfig,
+		TwoFactorPolicy: util.Iif(c.Bool("skip-local-2fa"), "skip", ""),
+	})
+}
+
+func (a *authService) runUpdateSAML(ctx context.Context, c *cli.Command) error {
+	if !c.IsSet("id") {
+		return errors.New("--id flag is missing")
+	}
+
+	if err := a.initDB(ctx); err != nil {
+		return err
+	}
+
+	source, err := a.getAuthSourceOfType(ctx, c.Int64("id"), auth_model.SAML)
+	if err != nil {
+		return err
+	}
+	samlConfig := auth_model.MustSourceCfg[*saml.Source](source)
+
+	if err := parseSAMLConfig(ctx, c, samlConfig); err != nil {
+		return err
+	}
+
+	if c.IsSet("name") {
+		source.Name = c.String("name")
+	}
+
+	if c.IsSet("active") {
+		source.IsActive = c.Bool("active")
+	}
+
+	source.Cfg = samlConfig
+	source.TwoFactorPolicy = util.Iif(c.Bool("skip-local-2fa"), "skip", "")
+	return a.updateAuthSource(ctx, source)
+}
@@ -0,0 +1,246 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	auth_model "gitea.dev/models/auth"
+	"gitea.dev/modules/saml/samltest"
+	"gitea.dev/services/auth/source/saml"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v3"
+)
+
+func writeSAMLTestFile(t *testing.T, name, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestAddSAML(t *testing.T) {
+	idp, err := samltest.NewIdentityProvider("https://idp.example.com")
+	require.NoError(t, err)
+	metadata := string(idp.Metadata())
+	metadataFile := writeSAMLTestFile(t, "metadata.xml", metadata)
+
+	testCases := []struct {
+		name   string
+		args   []string
+		source *auth_model.Source
+		errMsg string
+	}{
+		{
+			name: "missing name",
+			args: []string{
+				"--metadata-file", metadataFile,
+			},
+			errMsg: "name must be set",
+		},
+		{
+			name: "missing metadata",
+			args: []string{
+				"--name", "corp",
+			},
+			errMsg: "metadata-url or metadata-file must be set",
+		},
+		{
+			name: "missing metadata file",
+			args: []string{
+				"--name", "corp",
+				"--metadata-file", metadataFile + ".missing",
+			},
+			errMsg: "unable to read --metadata-file: open " + metadataFile + ".missing: no such file or directory",
+		},
+		{
+			name: "valid config",
+			args: []string{
+				"--name", "corp",
+				"--metadata-file", metadataFile,
+			},
+			source: &auth_model.Source{
+				Type:     auth_model.SAML,
+				Name:     "corp",
+				IsActive: true,
+				Cfg: &saml.Source{
+					IdentityProviderMetadata: metadata,
+				},
+			},
+		},
+		{
+			name: "valid config with options",
+			args: []string{
+				"--name", "corp",
+				"--metadata-file", metadataFile,
+				"--name-id-format", "urn:oasis:names:tc:SAML:2.0:nameid-format:persistent",
+				"--allow-idp-initiated",
+				"--auto-register",
+				"--icon-url", "https://idp.example.com/icon.png",
+				"--attribute-username", "uid",
+				"--attribute-email", "mail",
+				"--attribute-full-name", "displayName",
+				"--attribute-groups", "memberOf",
+				"--admin-group", "admins",
+				"--restricted-group", "contractors",
+				"--group-team-map", `{"developers": {"org": ["team"]}}`,
+				"--group-team-map-removal",
+				"--skip-local-2fa",
+				"--active=false",
+			},
+			source: &auth_model.Source{
+				Type:     auth_model.SAML,
+				Name:     "corp",
+				IsActive: false,
+				Cfg: &saml.Source{
+					IdentityProviderMetadata: metadata,
+					NameIDFormat:             "urn:oasis:names:tc:SAML:2.0:nameid-format:persistent",
+					AllowIdPInitiated:        true,
+					AutoRegister:             true,
+					IconURL:                  "https://idp.example.com/icon.png",
+					AttributeUsername:        "uid",
+					AttributeEmail:           "mail",
+					AttributeFullName:        "displayName",
+					AttributeGroups:          "memberOf",
+					AdminGroup:               "admins",
+					RestrictedGroup:          "contractors",
+					GroupTeamMap:             `{"developers": {"org": ["team"]}}`,
+					GroupTeamMapRemoval:      true,
+				},
+				TwoFactorPolicy: "skip",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			a := &authService{
+				initDB: func(ctx context.Context) error {
+					return nil
+				},
+				createAuthSource: func(ctx context.Context, source *auth_model.Source) error {
+					assert.Equal(t, tc.source, source)
+					return nil
+				},
+			}
+
+			cmd := &cli.Command{
+				Flags:  microcmdAuthAddSAML().Flags,
+				Action: a.runAddSAML,
+			}
+
+			args := []string{"saml-test"}
+			args = append(args, tc.args...)
+
+			err := cmd.Run(t.Context(), args)
+
+			if tc.errMsg != "" {
+				assert.EqualError(t, err, tc.errMsg)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestUpdateSAML(t *testing.T) {
+	idp, err := samltest.NewIdentityProvider("https://idp.example.com")
+	require.NoError(t, err)
+	metadata := string(idp.Metadata())
+	certPEM, keyPEM, err := samltest.NewServiceProviderKeyPair()
+	require.NoError(t, err)
+	certFile := writeSAMLTestFile(t, "sp.crt", certPEM)
+	keyFile := writeSAMLTestFile(t, "sp.key", keyPEM)
+
+	testCases := []struct {
+		name       string
+		args       []string
+		authSource *auth_model.Source
+		errMsg     string
+	}{
+		{
+			name: "missing id",
+			args: []string{
+				"--name", "corp",
+			},
+			errMsg: "--id flag is missing",
+		},
+		{
+			name: "certificate without private key",
+			args: []string{
+				"--id", "1",
+				"--sp-certificate-file", certFile,
+			},
+			errMsg: "the service provider certificate and private key must be set together",
+		},
+		{
+			name: "valid config",
+			args: []string{
+				"--id", "1",
+				"--name", "new corp",
+				"--sp-certificate-file", certFile,
+				"--sp-private-key-file", keyFile,
+				"--attribute-email", "mail",
+			},
+			authSource: &auth_model.Source{
+				ID:       1,
+				Type:     auth_model.SAML,
+				Name:     "new corp",
+				IsActive: true,
+				Cfg: &saml.Source{
+					IdentityProviderMetadata:   metadata,
+					ServiceProviderCertificate: certPEM,
+					ServiceProviderPrivateKey:  keyPEM,
+					AttributeUsername:          "uid",
+					AttributeEmail:             "mail",
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			a := &authService{
+				initDB: func(ctx context.Context) error {
+					return nil
+				},
+				getAuthSourceByID: func(ctx context.Context, id int64) (*auth_model.Source, error) {
+					return &auth_model.Source{
+						ID:       1,
+						Type:     auth_model.SAML,
+						Name:     "corp",
+						IsActive: true,
+						Cfg: &saml.Source{
+							IdentityProviderMetadata: metadata,
+							AttributeUsername:        "uid",
+						},
+					}, nil
+				},
+				updateAuthSource: func(ctx context.Context, source *auth_model.Source) error {
+					assert.Equal(t, tc.authSource, source)
+					return nil
+				},
+			}
+
+			app := &cli.Command{
+				Flags:  microcmdAuthUpdateSAML().Flags,
+				Action: a.runUpdateSAML,
+			}
+			args := []string{"saml-tests"}
+			args = append(args, tc.args...)
+
+			err := app.Run(t.Context(), args)
+
+			if tc.errMsg != "" {
+				assert.EqualError(t, err, tc.errMsg)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -200,6 +200,7 @@ Gitea or set your environment appropriately.`, "")
 		GitQuarantinePath:               os.Getenv(private.GitQuarantinePath),
 		GitPushOptions:                  pushOptions(),
 		PullRequestID:                   prID,
+		PushTrigger:                     repo_module.PushTrigger(os.Getenv(repo_module.EnvPushTrigger)),
 		DeployKeyID:                     deployKeyID,
 		ActionsTaskID:                   actionsTaskID,
 		IsWiki:                          isWiki,
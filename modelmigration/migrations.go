@@ -425,6 +425,23 @@ func prepareMigrationTasks() []*migration {
 		newMigration(349, "Expand action_schedule content column", v28.ExpandActionScheduleContent),
 		newMigration(350, "Add published_unix column to release", v28.AddPublishedUnixToRelease),
 		newMigration(351, "Track transfer recipient access grants", v28.AddRecipientAccessGrantedToRepoTransfer),
+		newMigration(352, "Add actions environments and deployments", v28.AddActionsEnvironmentsAndDeployments),
+		newMigration(353, "Add action cache table", v28.AddActionCacheTable),
+		newMigration(354, "Add ruleset table", v28.AddRulesetTable),
+		newMigration(355, "Add pull merge queue entry table", v28.AddPullMergeQueueEntryTable),
+		newMigration(356, "Add issue custom field tables", v28.AddIssueCustomFieldTables),
+		newMigration(357, "Add issue types and sub-issues", v28.AddIssueTypesAndSubIssues),
+		newMigration(358, "Add project views, fields and iterations", v28.AddProjectViewsAndFields),
+		newMigration(359, "Add secret scanning patterns and alerts", v28.AddSecretScanningTables),
+		newMigration(360, "Add audit event table", v28.AddAuditEventTable),
+		newMigration(361, "Add package remote table", v28.AddPackageRemoteTable),
+		newMigration(362, "Add package attestation table", v28.AddPackageAttestationTable),
+		newMigration(363, "Add quota tables", v28.AddQuotaTables),
+		newMigration(364, "Add migration sync and foreign reference tables", v28.AddMigrationSyncTables),
+		newMigration(365, "Add ref name to repo indexer status", v28.AddRefNameToRepoIndexerStatus),
+		newMigration(366, "Add code navigation tables", v28.AddCodeNavigationTables),
+		newMigration(367, "Add SCIM tables", v28.AddSCIMTables),
+		newMigration(368, "Add unique index on the key of the action caches", v28.AddUniqueIndexToActionCache),
 	}
 	return preparedMigrations
 }
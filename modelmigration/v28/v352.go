@@ -0,0 +1,95 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v28
+
+import (
+	"context"
+
+	"gitea.dev/modelmigration/base"
+	"gitea.dev/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func AddActionsEnvironmentsAndDeployments(_ context.Context, x base.EngineMigration) error {
+	type ActionEnvironment struct {
+		ID                int64
+		RepoID            int64              `xorm:"UNIQUE(repo_name) NOT NULL"`
+		Name              string             `xorm:"VARCHAR(255) NOT NULL"`
+		LowerName         string             `xorm:"UNIQUE(repo_name) VARCHAR(255) NOT NULL"`
+		ExternalURL       string             `xorm:"TEXT"`
+		WaitTimer         int64              `xorm:"NOT NULL DEFAULT 0"`
+		ReviewerIDs       []int64            `xorm:"JSON TEXT"`
+		ReviewerTeamIDs   []int64            `xorm:"JSON TEXT"`
+		PreventSelfReview bool               `xorm:"NOT NULL DEFAULT FALSE"`
+		BranchPolicy      int                `xorm:"NOT NULL DEFAULT 0"`
+		BranchPatterns    []string           `xorm:"JSON TEXT"`
+		CreatedUnix       timeutil.TimeStamp `xorm:"created NOT NULL"`
+		UpdatedUnix       timeutil.TimeStamp `xorm:"updated"`
+	}
+
+	type ActionDeployment struct {
+		ID            int64
+		RepoID        int64 `xorm:"index NOT NULL"`
+		EnvironmentID int64 `xorm:"index NOT NULL"`
+		RunID         int64 `xorm:"index NOT NULL"`
+		JobID         int64 `xorm:"UNIQUE NOT NULL"`
+		Ref           string
+		CommitSHA     string
+		TriggerUserID int64  `xorm:"NOT NULL DEFAULT 0"`
+		URL           string `xorm:"TEXT"`
+		Status        int    `xorm:"index NOT NULL DEFAULT 0"`
+		ApprovedUnix  timeutil.TimeStamp
+		StartableUnix timeutil.TimeStamp `xorm:"index"`
+		CreatedUnix   timeutil.TimeStamp `xorm:"created NOT NULL"`
+		UpdatedUnix   timeutil.TimeStamp `xorm:"updated"`
+	}
+
+	type ActionDeploymentReview struct {
+		ID            int64
+		RepoID        int64              `xorm:"index NOT NULL"`
+		DeploymentID  int64              `xorm:"index NOT NULL"`
+		EnvironmentID int64              `xorm:"index NOT NULL"`
+		ReviewerID    int64              `xorm:"NOT NULL"`
+		State         int                `xorm:"NOT NULL"`
+		Comment       string             `xorm:"TEXT"`
+		CreatedUnix   timeutil.TimeStamp `xorm:"created NOT NULL"`
+	}
+
+	if err := x.Sync(new(ActionEnvironment), new(ActionDeployment), new(ActionDeploymentReview)); err != nil {
+		return err
+	}
+
+	type ActionRunJob struct {
+		Environment string `xorm:"VARCHAR(255) NOT NULL DEFAULT ''"`
+	}
+	if _, err := x.SyncWithOptions(xorm.SyncOptions{
+		IgnoreConstrains:  true,
+		IgnoreDropIndices: true,
+	}, new(ActionRunJob)); err != nil {
+		return err
+	}
+
+	// The environment joins the unique index of secrets and variables, so an environment can
+	// override a repository level secret or variable of the same name. Constraints are not
+	// ignored here, so that the changed unique index gets recreated.
+	type Secret struct {
+		ID            int64
+		OwnerID       int64  `xorm:"INDEX UNIQUE(owner_repo_name) NOT NULL"`
+		RepoID        int64  `xorm:"INDEX UNIQUE(owner_repo_name) NOT NULL DEFAULT 0"`
+		EnvironmentID int64  `xorm:"INDEX UNIQUE(owner_repo_name) NOT NULL DEFAULT 0"`
+		Name          string `xorm:"UNIQUE(owner_repo_name) NOT NULL"`
+	}
+	type ActionVariable struct {
+		ID            int64
+		OwnerID       int64  `xorm:"UNIQUE(owner_repo_name)"`
+		RepoID        int64  `xorm:"INDEX UNIQUE(owner_repo_name)"`
+		EnvironmentID int64  `xorm:"INDEX UNIQUE(owner_repo_name) NOT NULL DEFAULT 0"`
+		Name          string `xorm:"UNIQUE(owner_repo_name) NOT NULL"`
+	}
+	_, err := x.SyncWithOptions(xorm.SyncOptions{
+		IgnoreDropIndices: true,
+	}, new(Secret), new(ActionVariable))
+	return err
+}
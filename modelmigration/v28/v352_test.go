@@ -0,0 +1,63 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v28
+
+import (
+	"testing"
+
+	"gitea.dev/modelmigration/migrationtest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddActionsEnvironmentsAndDeployments(t *testing.T) {
+	type Secret struct {
+		ID      int64
+		OwnerID int64  `xorm:"INDEX UNIQUE(owner_repo_name) NOT NULL"`
+		RepoID  int64  `xorm:"INDEX UNIQUE(owner_repo_name) NOT NULL DEFAULT 0"`
+		Name    string `xorm:"UNIQUE(owner_repo_name) NOT NULL"`
+	}
+	type ActionVariable struct {
+		ID      int64  `xorm:"pk autoincr"`
+		OwnerID int64  `xorm:"UNIQUE(owner_repo_name)"`
+		RepoID  int64  `xorm:"INDEX UNIQUE(owner_repo_name)"`
+		Name    string `xorm:"UNIQUE(owner_repo_name) NOT NULL"`
+	}
+	type ActionRunJob struct {
+		ID int64
+	}
+
+	x, deferable := migrationtest.PrepareTestEnv(t, 0, new(Secret), new(ActionVariable), new(ActionRunJob))
+	defer deferable()
+	if x == nil || t.Failed() {
+		return
+	}
+
+	_, err := x.Insert(&Secret{RepoID: 1, Name: "TOKEN"}, &ActionVariable{RepoID: 1, Name: "TARGET"}, &ActionRunJob{})
+	require.NoError(t, err)
+
+	require.NoError(t, AddActionsEnvironmentsAndDeployments(t.Context(), x))
+
+	for _, table := range []string{"action_environment", "action_deployment", "action_deployment_review"} {
+		exist, err := x.IsTableExist(table)
+		require.NoError(t, err)
+		assert.True(t, exist, table)
+	}
+
+	var environment string
+	has, err := x.Table("action_run_job").Cols("environment").Get(&environment)
+	require.NoError(t, err)
+	assert.True(t, has)
+	assert.Empty(t, environment)
+
+	// an environment may now hold a secret and a variable with the name of a repository level one
+	_, err = x.Exec("INSERT INTO `secret` (`owner_id`, `repo_id`, `environment_id`, `name`) VALUES (0, 1, 1, 'TOKEN')")
+	require.NoError(t, err)
+	_, err = x.Exec("INSERT INTO `action_variable` (`owner_id`, `repo_id`, `environment_id`, `name`) VALUES (0, 1, 1, 'TARGET')")
+	require.NoError(t, err)
+	// but the names stay unique within a scope
+	_, err = x.Exec("INSERT INTO `secret` (`owner_id`, `repo_id`, `environment_id`, `name`) VALUES (0, 1, 1, 'TOKEN')")
+	require.Error(t, err)
+}
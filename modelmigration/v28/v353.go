@@ -0,0 +1,29 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v28
+
+import (
+	"context"
+
+	"gitea.dev/modelmigration/base"
+	"gitea.dev/modules/timeutil"
+)
+
+func AddActionCacheTable(_ context.Context, x base.EngineMigration) error {
+	type ActionCache struct {
+		ID             int64
+		RepoID         int64  `xorm:"INDEX(repo_ref) NOT NULL"`
+		Ref            string `xorm:"INDEX(repo_ref) VARCHAR(255) NOT NULL"`
+		Key            string `xorm:"'cache_key' VARCHAR(512) NOT NULL"`
+		Version        string `xorm:"VARCHAR(255) NOT NULL"`
+		RunID          int64
+		StoragePath    string
+		Size           int64              `xorm:"NOT NULL DEFAULT 0"`
+		Status         int                `xorm:"index NOT NULL"`
+		CreatedUnix    timeutil.TimeStamp `xorm:"created NOT NULL"`
+		UpdatedUnix    timeutil.TimeStamp `xorm:"updated"`
+		LastAccessUnix timeutil.TimeStamp `xorm:"index NOT NULL DEFAULT 0"`
+	}
+	return x.Sync(new(ActionCache))
+}
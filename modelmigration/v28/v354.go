@@ -0,0 +1,28 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v28
+
+import (
+	"context"
+
+	"gitea.dev/modelmigration/base"
+	"gitea.dev/modules/timeutil"
+)
+
+func AddRulesetTable(_ context.Context, x base.EngineMigration) error {
+	type Ruleset struct {
+		ID          int64              `xorm:"pk autoincr"`
+		OwnerID     int64              `xorm:"INDEX NOT NULL DEFAULT 0"`
+		RepoID      int64              `xorm:"INDEX NOT NULL DEFAULT 0"`
+		Name        string             `xorm:"NOT NULL"`
+		Target      int                `xorm:"NOT NULL DEFAULT 1"`
+		Enforcement int                `xorm:"NOT NULL DEFAULT 0"`
+		IncludeRefs []string           `xorm:"JSON TEXT"`
+		ExcludeRefs []string           `xorm:"JSON TEXT"`
+		Rules       string             `xorm:"TEXT"`
+		CreatedUnix timeutil.TimeStamp `xorm:"created"`
+		UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
+	}
+	return x.Sync(new(Ruleset))
+}
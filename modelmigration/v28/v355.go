@@ -0,0 +1,38 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v28
+
+import (
+	"context"
+
+	"gitea.dev/modelmigration/base"
+	"gitea.dev/modules/timeutil"
+)
+
+type pullMergeQueueEntry struct {
+	ID                     int64  `xorm:"pk autoincr"`
+	RepoID                 int64  `xorm:"INDEX(repo_branch) NOT NULL"`
+	BaseBranch             string `xorm:"INDEX(repo_branch) VARCHAR(255) NOT NULL"`
+	PullID                 int64  `xorm:"UNIQUE NOT NULL"`
+	DoerID                 int64  `xorm:"INDEX NOT NULL"`
+	MergeStyle             string `xorm:"varchar(30)"`
+	Message                string `xorm:"LONGTEXT"`
+	DeleteBranchAfterMerge bool
+	Status                 int                `xorm:"NOT NULL DEFAULT 0"`
+	HeadCommitID           string             `xorm:"VARCHAR(64)"`
+	BaseCommitID           string             `xorm:"VARCHAR(64)"`
+	MergeCommitID          string             `xorm:"INDEX VARCHAR(64)"`
+	Reason                 string             `xorm:"TEXT"`
+	TestedUnix             timeutil.TimeStamp `xorm:"INDEX"`
+	CreatedUnix            timeutil.TimeStamp `xorm:"created"`
+	UpdatedUnix            timeutil.TimeStamp `xorm:"updated"`
+}
+
+func (pullMergeQueueEntry) TableName() string {
+	return "pull_merge_queue_entry"
+}
+
+func AddPullMergeQueueEntryTable(_ context.Context, x base.EngineMigration) error {
+	return x.Sync(new(pullMergeQueueEntry))
+}
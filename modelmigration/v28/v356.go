@@ -0,0 +1,43 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v28
+
+import (
+	"context"
+
+	"gitea.dev/modelmigration/base"
+	"gitea.dev/modules/timeutil"
+)
+
+type issueCustomField struct {
+	ID          int64              `xorm:"pk autoincr"`
+	OwnerID     int64              `xorm:"INDEX NOT NULL DEFAULT 0"`
+	RepoID      int64              `xorm:"INDEX NOT NULL DEFAULT 0"`
+	Name        string             `xorm:"NOT NULL"`
+	Description string             `xorm:"TEXT"`
+	Type        string             `xorm:"VARCHAR(20) NOT NULL"`
+	Options     []string           `xorm:"JSON TEXT"`
+	CreatedUnix timeutil.TimeStamp `xorm:"created"`
+	UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
+}
+
+func (issueCustomField) TableName() string {
+	return "issue_custom_field"
+}
+
+type issueCustomFieldValue struct {
+	ID      int64   `xorm:"pk autoincr"`
+	IssueID int64   `xorm:"INDEX NOT NULL"`
+	FieldID int64   `xorm:"INDEX NOT NULL"`
+	Value   string  `xorm:"VARCHAR(255) NOT NULL"`
+	Number  float64 `xorm:"NOT NULL DEFAULT 0"`
+}
+
+func (issueCustomFieldValue) TableName() string {
+	return "issue_custom_field_value"
+}
+
+func AddIssueCustomFieldTables(_ context.Context, x base.EngineMigration) error {
+	return x.Sync(new(issueCustomField), new(issueCustomFieldValue))
+}
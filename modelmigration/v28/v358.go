@@ -0,0 +1,74 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v28
+
+import (
+	"context"
+
+	"gitea.dev/modelmigration/base"
+	"gitea.dev/modules/timeutil"
+)
+
+type projectView struct {
+	ID          int64              `xorm:"pk autoincr"`
+	ProjectID   int64              `xorm:"INDEX NOT NULL"`
+	Name        string             `xorm:"NOT NULL"`
+	Layout      string             `xorm:"VARCHAR(20) NOT NULL"`
+	GroupBy     string             `xorm:"VARCHAR(50) NOT NULL DEFAULT ''"`
+	SortBy      string             `xorm:"VARCHAR(50) NOT NULL DEFAULT ''"`
+	SortDesc    bool               `xorm:"NOT NULL DEFAULT false"`
+	DateFieldID int64              `xorm:"NOT NULL DEFAULT 0"`
+	Sorting     int64              `xorm:"NOT NULL DEFAULT 0"`
+	CreatedUnix timeutil.TimeStamp `xorm:"created"`
+	UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
+}
+
+func (projectView) TableName() string {
+	return "project_view"
+}
+
+type projectField struct {
+	ID          int64              `xorm:"pk autoincr"`
+	ProjectID   int64              `xorm:"INDEX NOT NULL"`
+	Name        string             `xorm:"NOT NULL"`
+	Type        string             `xorm:"VARCHAR(20) NOT NULL"`
+	Options     []string           `xorm:"JSON TEXT"`
+	CreatedUnix timeutil.TimeStamp `xorm:"created"`
+	UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
+}
+
+func (projectField) TableName() string {
+	return "project_field"
+}
+
+type projectIteration struct {
+	ID          int64              `xorm:"pk autoincr"`
+	FieldID     int64              `xorm:"INDEX NOT NULL"`
+	Title       string             `xorm:"NOT NULL"`
+	StartDate   timeutil.TimeStamp `xorm:"NOT NULL"`
+	Duration    int                `xorm:"NOT NULL"`
+	CreatedUnix timeutil.TimeStamp `xorm:"created"`
+	UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
+}
+
+func (projectIteration) TableName() string {
+	return "project_iteration"
+}
+
+type projectIssueFieldValue struct {
+	ID        int64   `xorm:"pk autoincr"`
+	ProjectID int64   `xorm:"UNIQUE(s) NOT NULL"`
+	IssueID   int64   `xorm:"UNIQUE(s) INDEX NOT NULL"`
+	FieldID   int64   `xorm:"UNIQUE(s) INDEX NOT NULL"`
+	Value     string  `xorm:"VARCHAR(255) NOT NULL"`
+	Number    float64 `xorm:"NOT NULL DEFAULT 0"`
+}
+
+func (projectIssueFieldValue) TableName() string {
+	return "project_issue_field_value"
+}
+
+func AddProjectViewsAndFields(_ context.Context, x base.EngineMigration) error {
+	return x.Sync(new(projectView), new(projectField), new(projectIteration), new(projectIssueFieldValue))
+}
@@ -0,0 +1,70 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v28
+
+import (
+	"context"
+
+	"gitea.dev/modelmigration/base"
+	"gitea.dev/modules/timeutil"
+)
+
+type secretScanningPattern struct {
+	ID             int64              `xorm:"pk autoincr"`
+	OwnerID        int64              `xorm:"INDEX NOT NULL DEFAULT 0"`
+	Name           string             `xorm:"NOT NULL"`
+	Pattern        string             `xorm:"TEXT NOT NULL"`
+	PushProtection bool               `xorm:"NOT NULL DEFAULT true"`
+	CreatedUnix    timeutil.TimeStamp `xorm:"created"`
+	UpdatedUnix    timeutil.TimeStamp `xorm:"updated"`
+}
+
+func (secretScanningPattern) TableName() string {
+	return "secret_scanning_pattern"
+}
+
+type secretScanningAlert struct {
+	ID                     int64              `xorm:"pk autoincr"`
+	RepoID                 int64              `xorm:"UNIQUE(s) NOT NULL"`
+	SecretHash             string             `xorm:"UNIQUE(s) VARCHAR(64) NOT NULL"`
+	PatternID              string             `xorm:"INDEX VARCHAR(100) NOT NULL"`
+	PatternName            string             `xorm:"NOT NULL"`
+	SecretRedacted         string             `xorm:"VARCHAR(255) NOT NULL DEFAULT ''"`
+	CommitID               string             `xorm:"VARCHAR(64) NOT NULL DEFAULT ''"`
+	Path                   string             `xorm:"TEXT"`
+	Line                   int                `xorm:"NOT NULL DEFAULT 0"`
+	State                  string             `xorm:"VARCHAR(20) INDEX NOT NULL DEFAULT 'open'"`
+	Resolution             string             `xorm:"VARCHAR(20) NOT NULL DEFAULT ''"`
+	ResolutionComment      string             `xorm:"TEXT"`
+	ResolverID             int64              `xorm:"NOT NULL DEFAULT 0"`
+	ResolvedUnix           timeutil.TimeStamp `xorm:"NOT NULL DEFAULT 0"`
+	PushProtectionBypassed bool               `xorm:"NOT NULL DEFAULT false"`
+	BypasserID             int64              `xorm:"NOT NULL DEFAULT 0"`
+	BypassedUnix           timeutil.TimeStamp `xorm:"NOT NULL DEFAULT 0"`
+	CreatedUnix            timeutil.TimeStamp `xorm:"created"`
+	UpdatedUnix            timeutil.TimeStamp `xorm:"updated"`
+}
+
+func (secretScanningAlert) TableName() string {
+	return "secret_scanning_alert"
+}
+
+type secretScanningAlertEvent struct {
+	ID          int64              `xorm:"pk autoincr"`
+	AlertID     int64              `xorm:"INDEX NOT NULL"`
+	RepoID      int64              `xorm:"INDEX NOT NULL"`
+	DoerID      int64              `xorm:"NOT NULL DEFAULT 0"`
+	Type        string             `xorm:"VARCHAR(20) NOT NULL"`
+	Reason      string             `xorm:"VARCHAR(20) NOT NULL DEFAULT ''"`
+	Comment     string             `xorm:"TEXT"`
+	CreatedUnix timeutil.TimeStamp `xorm:"created"`
+}
+
+func (secretScanningAlertEvent) TableName() string {
+	return "secret_scanning_alert_event"
+}
+
+func AddSecretScanningTables(_ context.Context, x base.EngineMigration) error {
+	return x.Sync(new(secretScanningPattern), new(secretScanningAlert), new(secretScanningAlertEvent))
+}
@@ -0,0 +1,37 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v28
+
+import (
+	"context"
+
+	"gitea.dev/modelmigration/base"
+	"gitea.dev/modules/timeutil"
+)
+
+type auditEvent struct {
+	ID          int64  `xorm:"pk autoincr"`
+	Action      string `xorm:"VARCHAR(64) INDEX NOT NULL"`
+	DoerID      int64  `xorm:"INDEX NOT NULL DEFAULT 0"`
+	DoerName    string
+	IPAddress   string
+	OwnerID     int64 `xorm:"INDEX NOT NULL DEFAULT 0"`
+	RepoID      int64 `xorm:"INDEX NOT NULL DEFAULT 0"`
+	TargetType  string
+	TargetID    int64
+	TargetName  string
+	Metadata    map[string]string  `xorm:"JSON TEXT"`
+	CreatedUnix timeutil.TimeStamp `xorm:"INDEX NOT NULL"`
+	Seq         int64              `xorm:"INDEX NOT NULL DEFAULT 0"`
+	PrevHash    string             `xorm:"VARCHAR(64)"`
+	Hash        string             `xorm:"VARCHAR(64)"`
+}
+
+func (auditEvent) TableName() string {
+	return "audit_event"
+}
+
+func AddAuditEventTable(_ context.Context, x base.EngineMigration) error {
+	return x.Sync(new(auditEvent))
+}
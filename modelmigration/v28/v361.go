@@ -0,0 +1,32 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v28
+
+import (
+	"context"
+
+	"gitea.dev/modelmigration/base"
+	"gitea.dev/modules/timeutil"
+)
+
+type packageRemote struct {
+	ID                int64              `xorm:"pk autoincr"`
+	OwnerID           int64              `xorm:"UNIQUE(s) INDEX NOT NULL DEFAULT 0"`
+	Type              string             `xorm:"UNIQUE(s) INDEX NOT NULL"`
+	Mode              string             `xorm:"VARCHAR(20) NOT NULL DEFAULT 'remote'"`
+	URL               string             `xorm:"TEXT NOT NULL"`
+	Username          string             `xorm:"NOT NULL DEFAULT ''"`
+	PasswordEncrypted string             `xorm:"TEXT"`
+	MetadataTTL       int64              `xorm:"NOT NULL DEFAULT 0"`
+	CreatedUnix       timeutil.TimeStamp `xorm:"created NOT NULL DEFAULT 0"`
+	UpdatedUnix       timeutil.TimeStamp `xorm:"updated NOT NULL DEFAULT 0"`
+}
+
+func (packageRemote) TableName() string {
+	return "package_remote"
+}
+
+func AddPackageRemoteTable(_ context.Context, x base.EngineMigration) error {
+	return x.Sync(new(packageRemote))
+}
@@ -0,0 +1,31 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v28
+
+import (
+	"context"
+
+	"gitea.dev/modelmigration/base"
+	"gitea.dev/modules/timeutil"
+)
+
+type packageAttestation struct {
+	ID          int64              `xorm:"pk autoincr"`
+	VersionID   int64              `xorm:"INDEX NOT NULL"`
+	FileID      int64              `xorm:"INDEX NOT NULL DEFAULT 0"`
+	BlobID      int64              `xorm:"INDEX NOT NULL"`
+	Type        string             `xorm:"VARCHAR(20) NOT NULL"`
+	Format      string             `xorm:"VARCHAR(20) NOT NULL DEFAULT ''"`
+	Name        string             `xorm:"NOT NULL"`
+	CreatorID   int64              `xorm:"NOT NULL DEFAULT 0"`
+	CreatedUnix timeutil.TimeStamp `xorm:"created INDEX NOT NULL"`
+}
+
+func (packageAttestation) TableName() string {
+	return "package_attestation"
+}
+
+func AddPackageAttestationTable(_ context.Context, x base.EngineMigration) error {
+	return x.Sync(new(packageAttestation))
+}
@@ -0,0 +1,82 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v28
+
+import (
+	"context"
+
+	"gitea.dev/modelmigration/base"
+	"gitea.dev/modules/timeutil"
+)
+
+type quotaRule struct {
+	ID          int64              `xorm:"pk autoincr"`
+	Name        string             `xorm:"UNIQUE NOT NULL"`
+	Limit       int64              `xorm:"NOT NULL DEFAULT -1"`
+	Subjects    []string           `xorm:"JSON TEXT"`
+	CreatedUnix timeutil.TimeStamp `xorm:"created"`
+	UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
+}
+
+func (quotaRule) TableName() string {
+	return "quota_rule"
+}
+
+type quotaUserRule struct {
+	ID     int64 `xorm:"pk autoincr"`
+	UserID int64 `xorm:"UNIQUE(s) NOT NULL"`
+	RuleID int64 `xorm:"UNIQUE(s) INDEX NOT NULL"`
+}
+
+func (quotaUserRule) TableName() string {
+	return "quota_user_rule"
+}
+
+type quotaGroup struct {
+	ID          int64              `xorm:"pk autoincr"`
+	Name        string             `xorm:"UNIQUE NOT NULL"`
+	CreatedUnix timeutil.TimeStamp `xorm:"created"`
+}
+
+func (quotaGroup) TableName() string {
+	return "quota_group"
+}
+
+type quotaGroupRule struct {
+	ID      int64 `xorm:"pk autoincr"`
+	GroupID int64 `xorm:"UNIQUE(s) NOT NULL"`
+	RuleID  int64 `xorm:"UNIQUE(s) INDEX NOT NULL"`
+}
+
+func (quotaGroupRule) TableName() string {
+	return "quota_group_rule"
+}
+
+type quotaGroupUser struct {
+	ID      int64 `xorm:"pk autoincr"`
+	GroupID int64 `xorm:"UNIQUE(s) NOT NULL"`
+	UserID  int64 `xorm:"UNIQUE(s) INDEX NOT NULL"`
+}
+
+func (quotaGroupUser) TableName() string {
+	return "quota_group_user"
+}
+
+type quotaUsage struct {
+	UserID         int64              `xorm:"pk"`
+	GitSize        int64              `xorm:"NOT NULL DEFAULT 0"`
+	LFSSize        int64              `xorm:"NOT NULL DEFAULT 0"`
+	AttachmentSize int64              `xorm:"NOT NULL DEFAULT 0"`
+	ArtifactSize   int64              `xorm:"NOT NULL DEFAULT 0"`
+	PackageSize    int64              `xorm:"NOT NULL DEFAULT 0"`
+	UpdatedUnix    timeutil.TimeStamp `xorm:"updated INDEX"`
+}
+
+func (quotaUsage) TableName() string {
+	return "quota_usage"
+}
+
+func AddQuotaTables(_ context.Context, x base.EngineMigration) error {
+	return x.Sync(new(quotaRule), new(quotaUserRule), new(quotaGroup), new(quotaGroupRule), new(quotaGroupUser), new(quotaUsage))
+}
@@ -0,0 +1,43 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v28
+
+import (
+	"context"
+
+	"gitea.dev/modelmigration/base"
+	"gitea.dev/modules/timeutil"
+)
+
+type migrationSync struct {
+	ID             int64              `xorm:"pk autoincr"`
+	RepoID         int64              `xorm:"UNIQUE NOT NULL"`
+	DoerID         int64              `xorm:"NOT NULL"`
+	PayloadContent string             `xorm:"LONGTEXT"`
+	LastSyncUnix   timeutil.TimeStamp `xorm:"NOT NULL DEFAULT 0"`
+	NextSyncUnix   timeutil.TimeStamp `xorm:"INDEX"`
+	LastError      string             `xorm:"TEXT"`
+	CreatedUnix    timeutil.TimeStamp `xorm:"created"`
+	UpdatedUnix    timeutil.TimeStamp `xorm:"updated"`
+}
+
+func (migrationSync) TableName() string {
+	return "migration_sync"
+}
+
+type foreignReference struct {
+	ID        int64  `xorm:"pk autoincr"`
+	RepoID    int64  `xorm:"UNIQUE(s) NOT NULL"`
+	Type      string `xorm:"VARCHAR(16) UNIQUE(s) NOT NULL"`
+	ForeignID int64  `xorm:"UNIQUE(s) NOT NULL"`
+	LocalID   int64  `xorm:"INDEX NOT NULL"`
+}
+
+func (foreignReference) TableName() string {
+	return "foreign_reference"
+}
+
+func AddMigrationSyncTables(_ context.Context, x base.EngineMigration) error {
+	return x.Sync(new(migrationSync), new(foreignReference))
+}
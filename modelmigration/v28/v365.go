@@ -0,0 +1,26 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v28
+
+import (
+	"context"
+
+	"gitea.dev/modelmigration/base"
+)
+
+type repoIndexerStatus struct {
+	ID          int64  `xorm:"pk autoincr"`
+	RepoID      int64  `xorm:"INDEX(s)"`
+	CommitSha   string `xorm:"VARCHAR(64)"`
+	IndexerType int    `xorm:"INDEX(s) NOT NULL DEFAULT 0"`
+	RefName     string `xorm:"VARCHAR(255) NOT NULL DEFAULT ''"`
+}
+
+func (repoIndexerStatus) TableName() string {
+	return "repo_indexer_status"
+}
+
+func AddRefNameToRepoIndexerStatus(_ context.Context, x base.EngineMigration) error {
+	return x.Sync(new(repoIndexerStatus))
+}
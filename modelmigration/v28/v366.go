@@ -0,0 +1,59 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v28
+
+import (
+	"context"
+
+	"gitea.dev/modelmigration/base"
+	"gitea.dev/modules/timeutil"
+)
+
+type codeNavIndex struct {
+	ID          int64              `xorm:"pk autoincr"`
+	RepoID      int64              `xorm:"UNIQUE(s) NOT NULL"`
+	CommitSHA   string             `xorm:"UNIQUE(s) VARCHAR(64) NOT NULL"`
+	Format      string             `xorm:"VARCHAR(10) NOT NULL"`
+	Occurrences int64              `xorm:"NOT NULL DEFAULT 0"`
+	ArtifactID  int64              `xorm:"NOT NULL DEFAULT 0"`
+	CreatedUnix timeutil.TimeStamp `xorm:"created INDEX"`
+}
+
+func (codeNavIndex) TableName() string {
+	return "code_nav_index"
+}
+
+type codeNavSymbol struct {
+	ID            int64  `xorm:"pk autoincr"`
+	RepoID        int64  `xorm:"INDEX NOT NULL"`
+	IndexID       int64  `xorm:"UNIQUE(s) NOT NULL"`
+	Key           string `xorm:"UNIQUE(s) VARCHAR(64) NOT NULL"`
+	Symbol        string `xorm:"TEXT"`
+	Documentation string `xorm:"LONGTEXT"`
+}
+
+func (codeNavSymbol) TableName() string {
+	return "code_nav_symbol"
+}
+
+type codeNavOccurrence struct {
+	ID           int64  `xorm:"pk autoincr"`
+	RepoID       int64  `xorm:"INDEX NOT NULL"`
+	IndexID      int64  `xorm:"INDEX(path) INDEX(symbol) NOT NULL"`
+	Path         string `xorm:"INDEX(path) VARCHAR(500) NOT NULL"`
+	Line         int    `xorm:"INDEX(path) NOT NULL"`
+	StartChar    int    `xorm:"NOT NULL"`
+	EndLine      int    `xorm:"NOT NULL"`
+	EndChar      int    `xorm:"NOT NULL"`
+	SymbolKey    string `xorm:"INDEX(symbol) VARCHAR(64) NOT NULL"`
+	IsDefinition bool   `xorm:"INDEX(symbol) NOT NULL DEFAULT false"`
+}
+
+func (codeNavOccurrence) TableName() string {
+	return "code_nav_occurrence"
+}
+
+func AddCodeNavigationTables(_ context.Context, x base.EngineMigration) error {
+	return x.Sync(new(codeNavIndex), new(codeNavSymbol), new(codeNavOccurrence))
+}
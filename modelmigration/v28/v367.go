@@ -0,0 +1,65 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v28
+
+import (
+	"context"
+
+	"gitea.dev/modelmigration/base"
+	"gitea.dev/modules/timeutil"
+)
+
+type scimToken struct {
+	ID             int64  `xorm:"pk autoincr"`
+	SourceID       int64  `xorm:"UNIQUE NOT NULL"`
+	TokenHash      string `xorm:"UNIQUE"`
+	TokenSalt      string
+	TokenLastEight string             `xorm:"INDEX token_last_eight"`
+	CreatedUnix    timeutil.TimeStamp `xorm:"created"`
+	UpdatedUnix    timeutil.TimeStamp `xorm:"updated"`
+}
+
+func (scimToken) TableName() string {
+	return "scim_token"
+}
+
+type scimUser struct {
+	ID          int64              `xorm:"pk autoincr"`
+	SourceID    int64              `xorm:"INDEX NOT NULL"`
+	UserID      int64              `xorm:"UNIQUE NOT NULL"`
+	ExternalID  string             `xorm:"VARCHAR(255)"`
+	CreatedUnix timeutil.TimeStamp `xorm:"created"`
+	UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
+}
+
+func (scimUser) TableName() string {
+	return "scim_user"
+}
+
+type scimGroup struct {
+	ID          int64              `xorm:"pk autoincr"`
+	SourceID    int64              `xorm:"UNIQUE(s) NOT NULL"`
+	DisplayName string             `xorm:"UNIQUE(s) VARCHAR(255) NOT NULL"`
+	ExternalID  string             `xorm:"VARCHAR(255)"`
+	CreatedUnix timeutil.TimeStamp `xorm:"created"`
+	UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
+}
+
+func (scimGroup) TableName() string {
+	return "scim_group"
+}
+
+type scimGroupMember struct {
+	ID      int64 `xorm:"pk autoincr"`
+	GroupID int64 `xorm:"UNIQUE(s) NOT NULL"`
+	UserID  int64 `xorm:"UNIQUE(s) INDEX NOT NULL"`
+}
+
+func (scimGroupMember) TableName() string {
+	return "scim_group_member"
+}
+
+func AddSCIMTables(_ context.Context, x base.EngineMigration) error {
+	return x.Sync(new(scimToken), new(scimUser), new(scimGroup), new(scimGroupMember))
+}
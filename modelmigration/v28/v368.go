@@ -0,0 +1,71 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v28
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	"gitea.dev/modelmigration/base"
+)
+
+type actionCacheKeyHash struct {
+	ID      int64
+	RepoID  int64  `xorm:"INDEX(repo_ref) NOT NULL"`
+	Ref     string `xorm:"INDEX(repo_ref) VARCHAR(255) NOT NULL"`
+	Key     string `xorm:"'cache_key' VARCHAR(512) NOT NULL"`
+	KeyHash string `xorm:"CHAR(64) NOT NULL DEFAULT ''"`
+	Version string `xorm:"VARCHAR(255) NOT NULL"`
+}
+
+func (actionCacheKeyHash) TableName() string {
+	return "action_cache"
+}
+
+type actionCacheUniqueKey struct {
+	ID      int64
+	RepoID  int64  `xorm:"INDEX(repo_ref) UNIQUE(repo_ref_key_version) NOT NULL"`
+	Ref     string `xorm:"INDEX(repo_ref) UNIQUE(repo_ref_key_version) VARCHAR(255) NOT NULL"`
+	KeyHash string `xorm:"UNIQUE(repo_ref_key_version) CHAR(64) NOT NULL DEFAULT ''"`
+	Version string `xorm:"UNIQUE(repo_ref_key_version) VARCHAR(255) NOT NULL"`
+}
+
+func (actionCacheUniqueKey) TableName() string {
+	return "action_cache"
+}
+
+// AddUniqueIndexToActionCache adds a unique index on the repository, ref, key and version of the action caches.
+// The key is indexed by its hash, the key itself is too long for an index on MySQL.
+// The duplicates reserved by concurrent jobs are removed beforehand, the newest entry is kept.
+func AddUniqueIndexToActionCache(_ context.Context, x base.EngineMigration) error {
+	if err := x.Sync(new(actionCacheKeyHash)); err != nil {
+		return err
+	}
+
+	var caches []*actionCacheKeyHash
+	if err := x.OrderBy("id DESC").Find(&caches); err != nil {
+		return err
+	}
+	seen := make(map[string]bool, len(caches))
+	for _, cache := range caches {
+		hash := sha256.Sum256([]byte(cache.Key))
+		cache.KeyHash = hex.EncodeToString(hash[:])
+		entry := fmt.Sprintf("%d\x00%s\x00%s\x00%s", cache.RepoID, cache.Ref, cache.KeyHash, cache.Version)
+		if seen[entry] {
+			// the stored content is left behind, the storage is not available to the migrations
+			if _, err := x.ID(cache.ID).Delete(new(actionCacheKeyHash)); err != nil {
+				return err
+			}
+			continue
+		}
+		seen[entry] = true
+		if _, err := x.ID(cache.ID).Cols("key_hash").Update(cache); err != nil {
+			return err
+		}
+	}
+
+	return x.Sync(new(actionCacheUniqueKey))
+}
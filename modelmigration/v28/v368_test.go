@@ -0,0 +1,48 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v28
+
+import (
+	"testing"
+
+	"gitea.dev/modelmigration/migrationtest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddUniqueIndexToActionCache(t *testing.T) {
+	type ActionCache struct {
+		ID      int64
+		RepoID  int64  `xorm:"INDEX(repo_ref) NOT NULL"`
+		Ref     string `xorm:"INDEX(repo_ref) VARCHAR(255) NOT NULL"`
+		Key     string `xorm:"'cache_key' VARCHAR(512) NOT NULL"`
+		Version string `xorm:"VARCHAR(255) NOT NULL"`
+	}
+
+	x, deferable := migrationtest.PrepareTestEnv(t, 0, new(ActionCache))
+	defer deferable()
+	if x == nil || t.Failed() {
+		return
+	}
+
+	_, err := x.Insert(
+		&ActionCache{RepoID: 1, Ref: "refs/heads/main", Key: "npm", Version: "v1"},
+		&ActionCache{RepoID: 1, Ref: "refs/heads/main", Key: "npm", Version: "v1"},
+		&ActionCache{RepoID: 1, Ref: "refs/heads/main", Key: "npm", Version: "v2"},
+	)
+	require.NoError(t, err)
+
+	require.NoError(t, AddUniqueIndexToActionCache(t.Context(), x))
+
+	var caches []*actionCacheKeyHash
+	require.NoError(t, x.OrderBy("id").Find(&caches))
+	require.Len(t, caches, 2)
+	assert.EqualValues(t, 2, caches[0].ID, "the newest duplicate is kept")
+	assert.Equal(t, "d79677188687e7b32b43de9d2365a1ff8ae6f1a9e3d494719be542394d99f7dd", caches[0].KeyHash)
+	assert.Equal(t, caches[0].KeyHash, caches[1].KeyHash)
+
+	_, err = x.Insert(&actionCacheKeyHash{RepoID: 1, Ref: "refs/heads/main", Key: "npm", KeyHash: caches[0].KeyHash, Version: "v1"})
+	assert.Error(t, err)
+}
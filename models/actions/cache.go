@@ -0,0 +1,238 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"gitea.dev/models/db"
+	"gitea.dev/modules/timeutil"
+	"gitea.dev/modules/util"
+
+	"xorm.io/builder"
+)
+
+// CacheStatus is the status of an entry of the actions/cache server
+type CacheStatus int
+
+const (
+	CacheStatusReserved CacheStatus = iota + 1 // 1, a job is uploading the cache
+	CacheStatusComplete                        // 2, the cache can be restored
+)
+
+// MaxCacheKeyLength is the longest key actions/cache accepts
+const MaxCacheKeyLength = 512
+
+// ActionCache is an entry of the cache server used by actions/cache.
+// A cache is scoped to the ref of the run which saved it, jobs of other refs can only restore it under the fallback rules.
+type ActionCache struct {
+	ID     int64
+	RepoID int64  `xorm:"INDEX(repo_ref) UNIQUE(repo_ref_key_version) NOT NULL"`
+	Ref    string `xorm:"INDEX(repo_ref) UNIQUE(repo_ref_key_version) VARCHAR(255) NOT NULL"`
+	Key    string `xorm:"'cache_key' VARCHAR(512) NOT NULL"`
+	// KeyHash is the SHA-256 of the key, the key itself is too long for the unique index on MySQL
+	KeyHash string `xorm:"UNIQUE(repo_ref_key_version) CHAR(64) NOT NULL DEFAULT ''"`
+	Version string `xorm:"UNIQUE(repo_ref_key_version) VARCHAR(255) NOT NULL"`
+	// RunID is the run which saved the cache
+	RunID       int64
+	StoragePath string
+	Size        int64       `xorm:"NOT NULL DEFAULT 0"`
+	Status      CacheStatus `xorm:"index NOT NULL"`
+
+	CreatedUnix timeutil.TimeStamp `xorm:"created NOT NULL"`
+	UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
+	// LastAccessUnix is when the cache was saved or restored the last time, the least recently used caches are evicted first
+	LastAccessUnix timeutil.TimeStamp `xorm:"index NOT NULL DEFAULT 0"`
+}
+
+func init() {
+	db.RegisterModel(new(ActionCache))
+}
+
+// CacheKeyHash returns the hash of a cache key used by the unique index of the caches
+func CacheKeyHash(key string) string {
+	hash := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(hash[:])
+}
+
+// ReserveCache inserts a cache entry for a job which is about to upload it.
+// It fails if a cache with the same key and version exists for the ref, caches are immutable once saved.
+func ReserveCache(ctx context.Context, cache *ActionCache) error {
+	cache.KeyHash = CacheKeyHash(cache.Key)
+	exist := func() (bool, error) {
+		return db.GetEngine(ctx).Where(builder.Eq{
+			"repo_id":  cache.RepoID,
+			"ref":      cache.Ref,
+			"key_hash": cache.KeyHash,
+			"version":  cache.Version,
+		}).Exist(new(ActionCache))
+	}
+	if has, err := exist(); err != nil {
+		return err
+	} else if has {
+		return util.NewAlreadyExistErrorf("cache %q already exists for %s", cache.Key, cache.Ref)
+	}
+	cache.Status = CacheStatusReserved
+	cache.LastAccessUnix = timeutil.TimeStampNow()
+	if err := db.Insert(ctx, cache); err != nil {
+		// another job may have reserved the same cache since the check, the unique index rejects the insert then
+		if has, _ := exist(); has {
+			return util.NewAlreadyExistErrorf("cache %q already exists for %s", cache.Key, cache.Ref)
+		}
+		return err
+	}
+	return nil
+}
+
+// GetCacheByRepoAndID returns a cache entry of a repository
+func GetCacheByRepoAndID(ctx context.Context, repoID, id int64) (*ActionCache, error) {
+	var cache ActionCache
+	has, err := db.GetEngine(ctx).Where("id=? AND repo_id=?", id, repoID).Get(&cache)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, fmt.Errorf("cache with id %d: %w", id, util.ErrNotExist)
+	}
+	return &cache, nil
+}
+
+// GetReservedCache returns the cache a job of the repository reserved for a ref, key and version
+func GetReservedCache(ctx context.Context, repoID int64, ref, key, version string) (*ActionCache, error) {
+	var cache ActionCache
+	has, err := db.GetEngine(ctx).Where(builder.Eq{
+		"repo_id":  repoID,
+		"ref":      ref,
+		"key_hash": CacheKeyHash(key),
+		"version":  version,
+		"status":   CacheStatusReserved,
+	}).Get(&cache)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, fmt.Errorf("reserved cache %q: %w", key, util.ErrNotExist)
+	}
+	return &cache, nil
+}
+
+// UpdateCache updates the given columns of a cache entry
+func UpdateCache(ctx context.Context, cache *ActionCache, cols ...string) error {
+	_, err := db.GetEngine(ctx).ID(cache.ID).Cols(cols...).Update(cache)
+	return err
+}
+
+// AddReservedCacheSize adds delta bytes to the size uploaded so far for a reserved cache.
+// The size is not changed and false is returned if it would go over maxSize, a negative maxSize means no limit.
+func AddReservedCacheSize(ctx context.Context, id, delta, maxSize int64) (bool, error) {
+	sess := db.GetEngine(ctx).Where("id=? AND status=?", id, CacheStatusReserved)
+	if maxSize >= 0 {
+		sess = sess.And("size + ? <= ?", delta, maxSize)
+	}
+	n, err := sess.Incr("size", delta).NoAutoTime().Update(new(ActionCache))
+	return n == 1, err
+}
+
+// FindCacheToRestore returns the cache a job should restore, following the rules of actions/cache:
+// for each ref in order, an exact match of the primary key, then the newest cache prefixed by the primary key,
+// then the newest cache prefixed by each of the restore keys in order.
+func FindCacheToRestore(ctx context.Context, repoID int64, refs []string, version, key string, restoreKeys []string) (*ActionCache, error) {
+	var caches []*ActionCache
+	if err := db.GetEngine(ctx).Where(builder.Eq{
+		"repo_id": repoID,
+		"version": version,
+		"status":  CacheStatusComplete,
+	}.And(builder.In("ref", refs))).OrderBy("created_unix DESC, id DESC").Find(&caches); err != nil {
+		return nil, err
+	}
+	if cache := matchCacheToRestore(caches, refs, key, restoreKeys); cache != nil {
+		return cache, nil
+	}
+	return nil, fmt.Errorf("cache %q: %w", key, util.ErrNotExist)
+}
+
+// matchCacheToRestore applies the fallback rules of FindCacheToRestore to caches sorted from the newest
+func matchCacheToRestore(caches []*ActionCache, refs []string, key string, restoreKeys []string) *ActionCache {
+	for _, ref := range refs {
+		for _, cache := range caches {
+			if cache.Ref == ref && cache.Key == key {
+				return cache
+			}
+		}
+		for _, prefix := range append([]string{key}, restoreKeys...) {
+			for _, cache := range caches {
+				if cache.Ref == ref && strings.HasPrefix(cache.Key, prefix) {
+					return cache
+				}
+			}
+		}
+	}
+	return nil
+}
+
+type FindCachesOptions struct {
+	db.ListOptions
+	ID     int64
+	RepoID int64
+	Ref    string
+	Key    string
+	Status CacheStatus
+	// LastAccessBefore finds the caches which have not been saved or restored since the given time
+	LastAccessBefore timeutil.TimeStamp
+	// OrderByLastAccess sorts the least recently used caches first instead of the newest first
+	OrderByLastAccess bool
+}
+
+func (opts FindCachesOptions) ToConds() builder.Cond {
+	cond := builder.NewCond()
+	if opts.ID > 0 {
+		cond = cond.And(builder.Eq{"id": opts.ID})
+	}
+	if opts.RepoID > 0 {
+		cond = cond.And(builder.Eq{"repo_id": opts.RepoID})
+	}
+	if opts.Ref != "" {
+		cond = cond.And(builder.Eq{"ref": opts.Ref})
+	}
+	if opts.Key != "" {
+		cond = cond.And(builder.Eq{"cache_key": opts.Key})
+	}
+	if opts.Status > 0 {
+		cond = cond.And(builder.Eq{"status": opts.Status})
+	}
+	if opts.LastAccessBefore > 0 {
+		cond = cond.And(builder.Lt{"last_access_unix": opts.LastAccessBefore})
+	}
+	return cond
+}
+
+func (opts FindCachesOptions) ToOrders() string {
+	if opts.OrderByLastAccess {
+		return "last_access_unix ASC, id ASC"
+	}
+	return "`id` DESC"
+}
+
+// GetCacheSizeOfRepo returns the total size of the caches of a repository, including the ones being uploaded
+func GetCacheSizeOfRepo(ctx context.Context, repoID int64) (int64, error) {
+	return db.GetEngine(ctx).Where("repo_id=?", repoID).SumInt(new(ActionCache), "size")
+}
+
+// GetRepoIDsOverCacheSize returns the repositories whose caches take more than maxSize bytes
+func GetRepoIDsOverCacheSize(ctx context.Context, maxSize int64) ([]int64, error) {
+	repoIDs := make([]int64, 0, 10)
+	return repoIDs, db.GetEngine(ctx).Table("action_cache").
+		Select("repo_id").
+		GroupBy("repo_id").
+		Having(fmt.Sprintf("SUM(size) > %d", maxSize)).
+		Find(&repoIDs)
+}
+
+// DeleteCacheByID removes a cache entry, the stored content has to be removed by the caller
+func DeleteCacheByID(ctx context.Context, id int64) error {
+	_, err := db.DeleteByID[ActionCache](ctx, id)
+	return err
+}
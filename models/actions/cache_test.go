@@ -0,0 +1,102 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"testing"
+
+	"gitea.dev/models/db"
+	"gitea.dev/models/unittest"
+	"gitea.dev/modules/util"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatchCacheToRestore(t *testing.T) {
+	// sorted from the newest as FindCacheToRestore loads them
+	caches := []*ActionCache{
+		{ID: 6, Ref: "refs/heads/main", Key: "npm-linux-new"},
+		{ID: 5, Ref: "refs/heads/feature", Key: "npm-linux-feature"},
+		{ID: 4, Ref: "refs/heads/main", Key: "npm-linux-abc"},
+		{ID: 3, Ref: "refs/heads/main", Key: "npm-windows-abc"},
+		{ID: 2, Ref: "refs/heads/feature", Key: "go-linux"},
+	}
+	refs := []string{"refs/heads/feature", "refs/heads/main"}
+
+	cases := []struct {
+		name        string
+		key         string
+		restoreKeys []string
+		expected    int64
+	}{
+		{name: "exact key of the run ref", key: "npm-linux-feature", expected: 5},
+		{name: "exact key of the fallback ref", key: "npm-linux-abc", expected: 4},
+		{name: "prefix of the key in the run ref first", key: "npm-linux", expected: 5},
+		{name: "restore key in the run ref before a newer cache of the fallback ref", key: "unknown", restoreKeys: []string{"npm-"}, expected: 5},
+		{name: "restore keys in order rather than the newest", key: "unknown", restoreKeys: []string{"go-", "npm-"}, expected: 2},
+		{name: "restore key matched in the fallback ref", key: "unknown", restoreKeys: []string{"npm-linux-a"}, expected: 4},
+		{name: "no match", key: "unknown", restoreKeys: []string{"rust-"}},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			cache := matchCacheToRestore(caches, refs, c.key, c.restoreKeys)
+			if c.expected == 0 {
+				assert.Nil(t, cache)
+				return
+			}
+			require.NotNil(t, cache)
+			assert.Equal(t, c.expected, cache.ID)
+		})
+	}
+
+	cache := matchCacheToRestore(caches, []string{"refs/heads/main"}, "npm-linux", nil)
+	require.NotNil(t, cache)
+	assert.EqualValues(t, 6, cache.ID, "a cache of another ref must not be restored")
+}
+
+func TestReserveAndRestoreCache(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+	ctx := t.Context()
+
+	cache := &ActionCache{RepoID: 4, Ref: "refs/heads/main", Key: "npm-linux-abc", Version: "v1", Size: 100}
+	require.NoError(t, ReserveCache(ctx, cache))
+	assert.Equal(t, CacheStatusReserved, cache.Status)
+
+	err := ReserveCache(ctx, &ActionCache{RepoID: 4, Ref: "refs/heads/main", Key: "npm-linux-abc", Version: "v1"})
+	assert.ErrorIs(t, err, util.ErrAlreadyExist)
+	_, err = db.GetEngine(ctx).Insert(&ActionCache{RepoID: 4, Ref: "refs/heads/main", Key: "npm-linux-abc", KeyHash: CacheKeyHash("npm-linux-abc"), Version: "v1"})
+	assert.Error(t, err, "the unique index rejects a concurrent reservation")
+	require.NoError(t, ReserveCache(ctx, &ActionCache{RepoID: 4, Ref: "refs/heads/main", Key: "npm-linux-abc", Version: "v2"}))
+
+	_, err = FindCacheToRestore(ctx, 4, []string{"refs/heads/main"}, "v1", "npm-linux-abc", nil)
+	assert.ErrorIs(t, err, util.ErrNotExist, "a reserved cache cannot be restored")
+
+	reserved, err := GetReservedCache(ctx, 4, "refs/heads/main", "npm-linux-abc", "v1")
+	require.NoError(t, err)
+	assert.Equal(t, cache.ID, reserved.ID)
+	reserved.Status = CacheStatusComplete
+	require.NoError(t, UpdateCache(ctx, reserved, "status"))
+
+	restored, err := FindCacheToRestore(ctx, 4, []string{"refs/heads/feature", "refs/heads/main"}, "v1", "npm-linux-", nil)
+	require.NoError(t, err)
+	assert.Equal(t, cache.ID, restored.ID)
+	_, err = FindCacheToRestore(ctx, 4, []string{"refs/heads/main"}, "v3", "npm-linux-abc", nil)
+	assert.ErrorIs(t, err, util.ErrNotExist, "the version has to match")
+
+	size, err := GetCacheSizeOfRepo(ctx, 4)
+	require.NoError(t, err)
+	assert.EqualValues(t, 100, size)
+	repoIDs, err := GetRepoIDsOverCacheSize(ctx, 50)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{4}, repoIDs)
+
+	caches, err := db.Find[ActionCache](ctx, FindCachesOptions{RepoID: 4, Status: CacheStatusComplete})
+	require.NoError(t, err)
+	assert.Len(t, caches, 1)
+
+	require.NoError(t, DeleteCacheByID(ctx, cache.ID))
+	_, err = GetCacheByRepoAndID(ctx, 4, cache.ID)
+	assert.ErrorIs(t, err, util.ErrNotExist)
+}
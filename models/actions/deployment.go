@@ -0,0 +1,276 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"context"
+	"fmt"
+
+	"gitea.dev/models/db"
+	user_model "gitea.dev/models/user"
+	"gitea.dev/modules/container"
+	"gitea.dev/modules/git"
+	"gitea.dev/modules/timeutil"
+	"gitea.dev/modules/util"
+
+	"xorm.io/builder"
+)
+
+// DeploymentStatus represents whether a deployment has been allowed to start.
+// The outcome of a deployment is the status of its job.
+type DeploymentStatus int
+
+const (
+	DeploymentStatusWaiting  DeploymentStatus = iota // 0, waiting for a reviewer or the wait timer
+	DeploymentStatusApproved                         // 1, the job may run or has run
+	DeploymentStatusRejected                         // 2, a reviewer or a protection rule refused the deployment
+)
+
+var deploymentStatusNames = map[DeploymentStatus]string{
+	DeploymentStatusWaiting:  "waiting",
+	DeploymentStatusApproved: "approved",
+	DeploymentStatusRejected: "rejected",
+}
+
+// String returns the string name of the DeploymentStatus
+func (s DeploymentStatus) String() string {
+	return deploymentStatusNames[s]
+}
+
+func (s DeploymentStatus) IsWaiting() bool {
+	return s == DeploymentStatusWaiting
+}
+
+func (s DeploymentStatus) IsApproved() bool {
+	return s == DeploymentStatusApproved
+}
+
+func (s DeploymentStatus) IsRejected() bool {
+	return s == DeploymentStatusRejected
+}
+
+// ActionDeployment records a job that targets an environment, it is created the first time the job is ready to start
+type ActionDeployment struct {
+	ID            int64
+	RepoID        int64              `xorm:"index NOT NULL"`
+	EnvironmentID int64              `xorm:"index NOT NULL"`
+	Environment   *ActionEnvironment `xorm:"-"`
+	RunID         int64              `xorm:"index NOT NULL"`
+	Run           *ActionRun         `xorm:"-"`
+	JobID         int64              `xorm:"UNIQUE NOT NULL"` // ActionRunJob.ID
+	Job           *ActionRunJob      `xorm:"-"`
+	Ref           string
+	CommitSHA     string
+	TriggerUserID int64            `xorm:"NOT NULL DEFAULT 0"`
+	TriggerUser   *user_model.User `xorm:"-"`
+	URL           string           `xorm:"TEXT"`
+	Status        DeploymentStatus `xorm:"index NOT NULL DEFAULT 0"`
+	// ApprovedUnix is when the deployment passed its reviews, the wait timer counts from there
+	ApprovedUnix timeutil.TimeStamp
+	// StartableUnix is when the wait timer of a reviewed deployment elapses, 0 if it has not started to wait
+	StartableUnix timeutil.TimeStamp `xorm:"index"`
+
+	CreatedUnix timeutil.TimeStamp `xorm:"created NOT NULL"`
+	UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
+}
+
+// ReviewState is the decision of a reviewer on a deployment
+type ReviewState int
+
+const (
+	ReviewStateApproved ReviewState = iota + 1 // 1
+	ReviewStateRejected                        // 2
+)
+
+// ActionDeploymentReview records the decision of a reviewer on a waiting deployment
+type ActionDeploymentReview struct {
+	ID            int64
+	RepoID        int64            `xorm:"index NOT NULL"`
+	DeploymentID  int64            `xorm:"index NOT NULL"`
+	EnvironmentID int64            `xorm:"index NOT NULL"`
+	ReviewerID    int64            `xorm:"NOT NULL"`
+	Reviewer      *user_model.User `xorm:"-"`
+	State         ReviewState      `xorm:"NOT NULL"`
+	Comment       string           `xorm:"TEXT"`
+
+	CreatedUnix timeutil.TimeStamp `xorm:"created NOT NULL"`
+}
+
+func init() {
+	db.RegisterModel(new(ActionDeployment))
+	db.RegisterModel(new(ActionDeploymentReview))
+}
+
+func (d *ActionDeployment) LoadEnvironment(ctx context.Context) error {
+	if d.Environment == nil {
+		env, err := GetEnvironmentByRepoAndID(ctx, d.RepoID, d.EnvironmentID)
+		if err != nil {
+			return err
+		}
+		d.Environment = env
+	}
+	return nil
+}
+
+func (d *ActionDeployment) LoadJob(ctx context.Context) error {
+	if d.Job == nil {
+		job, err := GetRunJobByRepoAndID(ctx, d.RepoID, d.JobID)
+		if err != nil {
+			return err
+		}
+		d.Job = job
+	}
+	return nil
+}
+
+func (d *ActionDeployment) LoadRun(ctx context.Context) error {
+	if d.Run == nil {
+		run, err := GetRunByRepoAndID(ctx, d.RepoID, d.RunID)
+		if err != nil {
+			return err
+		}
+		d.Run = run
+	}
+	return nil
+}
+
+func (d *ActionDeployment) LoadTriggerUser(ctx context.Context) (err error) {
+	if d.TriggerUser != nil {
+		return nil
+	}
+	_, d.TriggerUser, err = user_model.GetPossibleUserByID(ctx, d.TriggerUserID)
+	return err
+}
+
+// LoadAttributes loads the environment, the run, the job and the user who triggered the deployment
+func (d *ActionDeployment) LoadAttributes(ctx context.Context) error {
+	if err := d.LoadEnvironment(ctx); err != nil {
+		return err
+	}
+	if err := d.LoadRun(ctx); err != nil {
+		return err
+	}
+	if err := d.LoadJob(ctx); err != nil {
+		return err
+	}
+	return d.LoadTriggerUser(ctx)
+}
+
+// RefShortName returns the short name of the deployed branch or tag
+func (d *ActionDeployment) RefShortName() string {
+	return git.RefName(d.Ref).ShortName()
+}
+
+// GetDeploymentByJobID returns the deployment created for a job
+func GetDeploymentByJobID(ctx context.Context, jobID int64) (*ActionDeployment, error) {
+	var d ActionDeployment
+	has, err := db.GetEngine(ctx).Where("job_id=?", jobID).Get(&d)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, fmt.Errorf("deployment of job %d: %w", jobID, util.ErrNotExist)
+	}
+	return &d, nil
+}
+
+// GetDeploymentByRepoAndID returns a deployment of a repository
+func GetDeploymentByRepoAndID(ctx context.Context, repoID, id int64) (*ActionDeployment, error) {
+	var d ActionDeployment
+	has, err := db.GetEngine(ctx).Where("id=? AND repo_id=?", id, repoID).Get(&d)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, fmt.Errorf("deployment with id %d: %w", id, util.ErrNotExist)
+	}
+	return &d, nil
+}
+
+// UpdateDeployment updates the given columns of a deployment
+func UpdateDeployment(ctx context.Context, d *ActionDeployment, cols ...string) error {
+	_, err := db.GetEngine(ctx).ID(d.ID).Cols(cols...).Update(d)
+	return err
+}
+
+// UpdateDeploymentStatus changes the status of a deployment if it is still in the expected status.
+// It returns false if another request has decided on the deployment in the meantime.
+func UpdateDeploymentStatus(ctx context.Context, d *ActionDeployment, from DeploymentStatus, cols ...string) (bool, error) {
+	n, err := db.GetEngine(ctx).ID(d.ID).Where("status=?", from).Cols(append(cols, "status")...).Update(d)
+	return n == 1, err
+}
+
+type FindDeploymentsOptions struct {
+	db.ListOptions
+	RepoID        int64
+	EnvironmentID int64
+	RunID         int64
+	Statuses      []DeploymentStatus
+	// StartableBefore finds deployments whose wait timer elapsed before the given time
+	StartableBefore timeutil.TimeStamp
+}
+
+func (opts FindDeploymentsOptions) ToConds() builder.Cond {
+	cond := builder.NewCond()
+	if opts.RepoID > 0 {
+		cond = cond.And(builder.Eq{"repo_id": opts.RepoID})
+	}
+	if opts.EnvironmentID > 0 {
+		cond = cond.And(builder.Eq{"environment_id": opts.EnvironmentID})
+	}
+	if opts.RunID > 0 {
+		cond = cond.And(builder.Eq{"run_id": opts.RunID})
+	}
+	if len(opts.Statuses) > 0 {
+		cond = cond.And(builder.In("status", opts.Statuses))
+	}
+	if opts.StartableBefore > 0 {
+		cond = cond.And(builder.Gt{"startable_unix": 0}, builder.Lte{"startable_unix": opts.StartableBefore})
+	}
+	return cond
+}
+
+func (opts FindDeploymentsOptions) ToOrders() string {
+	return "`id` DESC"
+}
+
+type DeploymentList []*ActionDeployment
+
+// LoadAttributes loads the environments, runs, jobs and trigger users of the deployments
+func (deployments DeploymentList) LoadAttributes(ctx context.Context) error {
+	envIDs := make(container.Set[int64])
+	for _, d := range deployments {
+		envIDs.Add(d.EnvironmentID)
+	}
+	envs, err := db.Find[ActionEnvironment](ctx, FindEnvironmentsOptions{IDs: envIDs.Values()})
+	if err != nil {
+		return err
+	}
+	envMap := make(map[int64]*ActionEnvironment, len(envs))
+	for _, env := range envs {
+		envMap[env.ID] = env
+	}
+	for _, d := range deployments {
+		d.Environment = envMap[d.EnvironmentID]
+		if err := d.LoadRun(ctx); err != nil {
+			return err
+		}
+		if err := d.LoadJob(ctx); err != nil {
+			return err
+		}
+		if err := d.LoadTriggerUser(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// InsertDeploymentReview records a reviewer's decision on a deployment
+func InsertDeploymentReview(ctx context.Context, review *ActionDeploymentReview) error {
+	return db.Insert(ctx, review)
+}
+
+// GetDeploymentReviews returns the reviews of a deployment in the order they were made
+func GetDeploymentReviews(ctx context.Context, deploymentID int64) ([]*ActionDeploymentReview, error) {
+	reviews := make([]*ActionDeploymentReview, 0, 2)
+	return reviews, db.GetEngine(ctx).Where("deployment_id=?", deploymentID).OrderBy("id").Find(&reviews)
+}
@@ -0,0 +1,248 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"gitea.dev/models/db"
+	"gitea.dev/modules/glob"
+	"gitea.dev/modules/log"
+	"gitea.dev/modules/timeutil"
+	"gitea.dev/modules/util"
+
+	"xorm.io/builder"
+)
+
+// EnvironmentBranchPolicy restricts which branches may deploy to an environment
+type EnvironmentBranchPolicy int
+
+const (
+	EnvironmentBranchPolicyAll       EnvironmentBranchPolicy = iota // 0, every ref may deploy
+	EnvironmentBranchPolicyProtected                                // 1, only protected branches may deploy
+	EnvironmentBranchPolicyCustom                                   // 2, only branches and tags matching BranchPatterns may deploy
+)
+
+var environmentBranchPolicyNames = map[EnvironmentBranchPolicy]string{
+	EnvironmentBranchPolicyAll:       "all",
+	EnvironmentBranchPolicyProtected: "protected",
+	EnvironmentBranchPolicyCustom:    "custom",
+}
+
+// String returns the string name of the EnvironmentBranchPolicy
+func (p EnvironmentBranchPolicy) String() string {
+	return environmentBranchPolicyNames[p]
+}
+
+// EnvironmentBranchPolicyFromString returns the EnvironmentBranchPolicy of the given name
+func EnvironmentBranchPolicyFromString(s string) (EnvironmentBranchPolicy, bool) {
+	for policy, name := range environmentBranchPolicyNames {
+		if name == s {
+			return policy, true
+		}
+	}
+	return EnvironmentBranchPolicyAll, false
+}
+
+const (
+	// EnvironmentMaxWaitTimer is the longest wait timer an environment can have, in minutes (30 days)
+	EnvironmentMaxWaitTimer = 43200
+	// EnvironmentMaxReviewers is the maximum number of users and teams that can review deployments to an environment
+	EnvironmentMaxReviewers = 6
+)
+
+// ActionEnvironment represents a deployment environment of a repository.
+// Jobs target it with the `environment` key of the workflow, and its protection rules decide when they may start.
+type ActionEnvironment struct {
+	ID          int64
+	RepoID      int64  `xorm:"UNIQUE(repo_name) NOT NULL"`
+	Name        string `xorm:"VARCHAR(255) NOT NULL"`
+	LowerName   string `xorm:"UNIQUE(repo_name) VARCHAR(255) NOT NULL"`
+	ExternalURL string `xorm:"TEXT"`
+
+	// WaitTimer delays the start of a job after the deployment is approved, in minutes
+	WaitTimer int64 `xorm:"NOT NULL DEFAULT 0"`
+	// ReviewerIDs and ReviewerTeamIDs list who may approve a deployment, one approval is enough
+	ReviewerIDs     []int64 `xorm:"JSON TEXT"`
+	ReviewerTeamIDs []int64 `xorm:"JSON TEXT"`
+	// PreventSelfReview forbids the user who triggered the run to approve its deployments
+	PreventSelfReview bool `xorm:"NOT NULL DEFAULT FALSE"`
+
+	BranchPolicy   EnvironmentBranchPolicy `xorm:"NOT NULL DEFAULT 0"`
+	BranchPatterns []string                `xorm:"JSON TEXT"`
+
+	CreatedUnix timeutil.TimeStamp `xorm:"created NOT NULL"`
+	UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
+}
+
+func init() {
+	db.RegisterModel(new(ActionEnvironment))
+}
+
+// RequiresReview returns whether deployments to the environment must be approved by a reviewer
+func (env *ActionEnvironment) RequiresReview() bool {
+	return len(env.ReviewerIDs) > 0 || len(env.ReviewerTeamIDs) > 0
+}
+
+// IsProtected returns whether the environment holds jobs back in any way
+func (env *ActionEnvironment) IsProtected() bool {
+	return env.RequiresReview() || env.WaitTimer > 0 || env.BranchPolicy != EnvironmentBranchPolicyAll
+}
+
+// MatchBranchPatterns returns whether the short name of a branch or a tag matches one of the custom branch patterns
+func (env *ActionEnvironment) MatchBranchPatterns(name string) bool {
+	for _, pattern := range env.BranchPatterns {
+		g, err := glob.Compile(pattern, '/')
+		if err != nil {
+			log.Warn("Invalid branch pattern %q of environment %d: %v", pattern, env.ID, err)
+			continue
+		}
+		if g.Match(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateEnvironmentName checks that an environment name can be used in a workflow and in URLs
+func ValidateEnvironmentName(name string) error {
+	if name == "" || len(name) > 255 {
+		return util.NewInvalidArgumentErrorf("environment name must be between 1 and 255 characters")
+	}
+	if strings.ContainsAny(name, "/\\\x00") || strings.TrimSpace(name) != name {
+		return util.NewInvalidArgumentErrorf("invalid environment name %q", name)
+	}
+	return nil
+}
+
+// ValidateEnvironment checks the protection rules of an environment before it is stored
+func ValidateEnvironment(env *ActionEnvironment) error {
+	if err := ValidateEnvironmentName(env.Name); err != nil {
+		return err
+	}
+	if env.WaitTimer < 0 || env.WaitTimer > EnvironmentMaxWaitTimer {
+		return util.NewInvalidArgumentErrorf("wait timer must be between 0 and %d minutes", EnvironmentMaxWaitTimer)
+	}
+	if len(env.ReviewerIDs)+len(env.ReviewerTeamIDs) > EnvironmentMaxReviewers {
+		return util.NewInvalidArgumentErrorf("an environment can have at most %d reviewers", EnvironmentMaxReviewers)
+	}
+	if _, ok := environmentBranchPolicyNames[env.BranchPolicy]; !ok {
+		return util.NewInvalidArgumentErrorf("unknown branch policy %d", env.BranchPolicy)
+	}
+	for _, pattern := range env.BranchPatterns {
+		if _, err := glob.Compile(pattern, '/'); err != nil {
+			return util.NewInvalidArgumentErrorf("invalid branch pattern %q: %v", pattern, err)
+		}
+	}
+	return nil
+}
+
+// GetEnvironmentByRepoAndName returns the environment of a repository by its case-insensitive name
+func GetEnvironmentByRepoAndName(ctx context.Context, repoID int64, name string) (*ActionEnvironment, error) {
+	var env ActionEnvironment
+	has, err := db.GetEngine(ctx).Where("repo_id=? AND lower_name=?", repoID, strings.ToLower(name)).Get(&env)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, fmt.Errorf("environment %q: %w", name, util.ErrNotExist)
+	}
+	return &env, nil
+}
+
+// GetEnvironmentByRepoAndID returns the environment of a repository by its ID
+func GetEnvironmentByRepoAndID(ctx context.Context, repoID, id int64) (*ActionEnvironment, error) {
+	var env ActionEnvironment
+	has, err := db.GetEngine(ctx).Where("repo_id=? AND id=?", repoID, id).Get(&env)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, fmt.Errorf("environment with id %d: %w", id, util.ErrNotExist)
+	}
+	return &env, nil
+}
+
+// GetOrCreateEnvironment returns the environment of a repository by its name.
+// Like on GitHub, referencing an environment that does not exist yet in a workflow creates it without protection rules.
+func GetOrCreateEnvironment(ctx context.Context, repoID int64, name string) (*ActionEnvironment, error) {
+	env, err := GetEnvironmentByRepoAndName(ctx, repoID, name)
+	if err == nil || !errors.Is(err, util.ErrNotExist) {
+		return env, err
+	}
+	env = &ActionEnvironment{RepoID: repoID, Name: name}
+	if err := CreateEnvironment(ctx, env); err != nil {
+		return nil, err
+	}
+	return env, nil
+}
+
+// CreateEnvironment inserts a new environment for a repository
+func CreateEnvironment(ctx context.Context, env *ActionEnvironment) error {
+	if err := ValidateEnvironment(env); err != nil {
+		return err
+	}
+	env.LowerName = strings.ToLower(env.Name)
+	exist, err := db.GetEngine(ctx).Exist(&ActionEnvironment{RepoID: env.RepoID, LowerName: env.LowerName})
+	if err != nil {
+		return err
+	} else if exist {
+		return util.NewAlreadyExistErrorf("environment %q already exists", env.Name)
+	}
+	return db.Insert(ctx, env)
+}
+
+// UpdateEnvironment updates the given columns of an environment
+func UpdateEnvironment(ctx context.Context, env *ActionEnvironment, cols ...string) error {
+	if err := ValidateEnvironment(env); err != nil {
+		return err
+	}
+	env.LowerName = strings.ToLower(env.Name)
+	if slices.Contains(cols, "name") {
+		cols = append(cols, "lower_name")
+	}
+	_, err := db.GetEngine(ctx).ID(env.ID).Cols(cols...).Update(env)
+	return err
+}
+
+// DeleteEnvironment removes an environment with its deployments and its scoped variables.
+// The scoped secrets have to be removed by the caller.
+func DeleteEnvironment(ctx context.Context, env *ActionEnvironment) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		if _, err := db.GetEngine(ctx).Where("environment_id=?", env.ID).Delete(new(ActionDeploymentReview)); err != nil {
+			return err
+		}
+		if _, err := db.GetEngine(ctx).Where("environment_id=?", env.ID).Delete(new(ActionDeployment)); err != nil {
+			return err
+		}
+		if _, err := db.GetEngine(ctx).Where("environment_id=?", env.ID).Delete(new(ActionVariable)); err != nil {
+			return err
+		}
+		_, err := db.DeleteByID[ActionEnvironment](ctx, env.ID)
+		return err
+	})
+}
+
+type FindEnvironmentsOptions struct {
+	db.ListOptions
+	RepoID int64
+	IDs    []int64
+}
+
+func (opts FindEnvironmentsOptions) ToConds() builder.Cond {
+	cond := builder.NewCond()
+	if opts.RepoID > 0 {
+		cond = cond.And(builder.Eq{"repo_id": opts.RepoID})
+	}
+	if len(opts.IDs) > 0 {
+		cond = cond.And(builder.In("id", opts.IDs))
+	}
+	return cond
+}
+
+func (opts FindEnvironmentsOptions) ToOrders() string {
+	return "lower_name ASC"
+}
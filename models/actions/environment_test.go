@@ -0,0 +1,78 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"testing"
+
+	"gitea.dev/models/unittest"
+	"gitea.dev/modules/util"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestActionEnvironment_MatchBranchPatterns(t *testing.T) {
+	env := &ActionEnvironment{BranchPatterns: []string{"main", "release/*", "v*"}}
+	assert.True(t, env.MatchBranchPatterns("main"))
+	assert.True(t, env.MatchBranchPatterns("release/1.0"))
+	assert.True(t, env.MatchBranchPatterns("v1.2.3"))
+	assert.False(t, env.MatchBranchPatterns("release/1.0/hotfix"), "a single star does not cross a slash")
+	assert.False(t, env.MatchBranchPatterns("feature"))
+
+	assert.False(t, (&ActionEnvironment{}).MatchBranchPatterns("main"))
+}
+
+func TestValidateEnvironment(t *testing.T) {
+	assert.NoError(t, ValidateEnvironment(&ActionEnvironment{Name: "production"}))
+	assert.NoError(t, ValidateEnvironment(&ActionEnvironment{Name: "Staging 2", WaitTimer: EnvironmentMaxWaitTimer}))
+
+	for _, env := range []*ActionEnvironment{
+		{Name: ""},
+		{Name: "prod/eu"},
+		{Name: " prod"},
+		{Name: "prod", WaitTimer: -1},
+		{Name: "prod", WaitTimer: EnvironmentMaxWaitTimer + 1},
+		{Name: "prod", ReviewerIDs: []int64{1, 2, 3, 4}, ReviewerTeamIDs: []int64{1, 2, 3}},
+		{Name: "prod", BranchPolicy: 10},
+		{Name: "prod", BranchPolicy: EnvironmentBranchPolicyCustom, BranchPatterns: []string{"["}},
+	} {
+		assert.ErrorIs(t, ValidateEnvironment(env), util.ErrInvalidArgument, "environment %+v", env)
+	}
+}
+
+func TestEnvironmentBranchPolicyFromString(t *testing.T) {
+	for policy, name := range environmentBranchPolicyNames {
+		p, ok := EnvironmentBranchPolicyFromString(name)
+		assert.True(t, ok)
+		assert.Equal(t, policy, p)
+	}
+	_, ok := EnvironmentBranchPolicyFromString("unknown")
+	assert.False(t, ok)
+}
+
+func TestGetOrCreateEnvironment(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+	ctx := t.Context()
+
+	env, err := GetOrCreateEnvironment(ctx, 1, "Production")
+	require.NoError(t, err)
+	assert.Equal(t, "production", env.LowerName)
+	assert.False(t, env.IsProtected())
+
+	again, err := GetOrCreateEnvironment(ctx, 1, "PRODUCTION")
+	require.NoError(t, err)
+	assert.Equal(t, env.ID, again.ID, "environment names are case-insensitive")
+
+	err = CreateEnvironment(ctx, &ActionEnvironment{RepoID: 1, Name: "production"})
+	assert.ErrorIs(t, err, util.ErrAlreadyExist)
+
+	other, err := GetOrCreateEnvironment(ctx, 2, "production")
+	require.NoError(t, err)
+	assert.NotEqual(t, env.ID, other.ID, "environments belong to a single repository")
+
+	require.NoError(t, DeleteEnvironment(ctx, env))
+	_, err = GetEnvironmentByRepoAndID(ctx, 1, env.ID)
+	assert.ErrorIs(t, err, util.ErrNotExist)
+}
@@ -127,6 +127,10 @@ type ActionRunJob struct {
 	// When true, a failure of this job does not fail the overall workflow run.
 	ContinueOnError bool `xorm:"NOT NULL DEFAULT FALSE"`
 
+	// Environment is the evaluated name of the deployment environment from the job's "environment" section.
+	// A job targeting an environment only starts once the environment's protection rules allow it, see ActionDeployment.
+	Environment string `xorm:"VARCHAR(255) NOT NULL DEFAULT ''"`
+
 	Started timeutil.TimeStamp
 	Stopped timeutil.TimeStamp
 	Created timeutil.TimeStamp `xorm:"created"`
@@ -5,6 +5,7 @@ package actions
 
 import (
 	"context"
+	"errors"
 	"strings"
 	"unicode/utf8"
 
@@ -22,6 +23,7 @@ import (
 //  1. global variable, OwnerID is 0 and RepoID is 0
 //  2. org/user level variable, OwnerID is org/user ID and RepoID is 0
 //  3. repo level variable, OwnerID is 0 and RepoID is repo ID
+//  4. environment level variable, OwnerID is 0, RepoID is repo ID and EnvironmentID is the ID of one of the repo's environments
 //
 // Please note that it's not acceptable to have both OwnerID and RepoID to be non-zero,
 // or it will be complicated to find variables belonging to a specific owner.
@@ -29,14 +31,15 @@ import (
 // but it's a repo level variable, not an org/user level variable.
 // To avoid this, make it clear with {OwnerID: 0, RepoID: 1} for repo level variables.
 type ActionVariable struct {
-	ID          int64              `xorm:"pk autoincr"`
-	OwnerID     int64              `xorm:"UNIQUE(owner_repo_name)"`
-	RepoID      int64              `xorm:"INDEX UNIQUE(owner_repo_name)"`
-	Name        string             `xorm:"UNIQUE(owner_repo_name) NOT NULL"`
-	Data        string             `xorm:"LONGTEXT NOT NULL"`
-	Description string             `xorm:"TEXT"`
-	CreatedUnix timeutil.TimeStamp `xorm:"created NOT NULL"`
-	UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
+	ID            int64              `xorm:"pk autoincr"`
+	OwnerID       int64              `xorm:"UNIQUE(owner_repo_name)"`
+	RepoID        int64              `xorm:"INDEX UNIQUE(owner_repo_name)"`
+	EnvironmentID int64              `xorm:"INDEX UNIQUE(owner_repo_name) NOT NULL DEFAULT 0"`
+	Name          string             `xorm:"UNIQUE(owner_repo_name) NOT NULL"`
+	Data          string             `xorm:"LONGTEXT NOT NULL"`
+	Description   string             `xorm:"TEXT"`
+	CreatedUnix   timeutil.TimeStamp `xorm:"created NOT NULL"`
+	UpdatedUnix   timeutil.TimeStamp `xorm:"updated"`
 }
 
 const (
@@ -55,19 +58,36 @@ func InsertVariable(ctx context.Context, ownerID, repoID int64, name, data, desc
 		ownerID = 0
 	}
 
-	if utf8.RuneCountInString(data) > VariableDataMaxLength {
-		return nil, util.NewInvalidArgumentErrorf("data too long")
-	}
-
-	description = util.TruncateRunes(description, VariableDescriptionMaxLength)
-
-	variable := &ActionVariable{
+	return insertVariable(ctx, &ActionVariable{
 		OwnerID:     ownerID,
 		RepoID:      repoID,
-		Name:        strings.ToUpper(name),
+		Name:        name,
 		Data:        data,
 		Description: description,
+	})
+}
+
+// InsertEnvironmentVariable creates a variable scoped to a deployment environment of a repository
+func InsertEnvironmentVariable(ctx context.Context, repoID, environmentID int64, name, data, description string) (*ActionVariable, error) {
+	if repoID == 0 || environmentID == 0 {
+		return nil, util.NewInvalidArgumentErrorf("an environment variable needs both repoID and environmentID")
+	}
+	return insertVariable(ctx, &ActionVariable{
+		RepoID:        repoID,
+		EnvironmentID: environmentID,
+		Name:          name,
+		Data:          data,
+		Description:   description,
+	})
+}
+
+func insertVariable(ctx context.Context, variable *ActionVariable) (*ActionVariable, error) {
+	if utf8.RuneCountInString(variable.Data) > VariableDataMaxLength {
+		return nil, util.NewInvalidArgumentErrorf("data too long")
 	}
+
+	variable.Name = strings.ToUpper(variable.Name)
+	variable.Description = util.TruncateRunes(variable.Description, VariableDescriptionMaxLength)
 	return variable, db.Insert(ctx, variable)
 }
 
@@ -76,7 +96,9 @@ type FindVariablesOpts struct {
 	IDs     []int64
 	RepoID  int64
 	OwnerID int64 // it will be ignored if RepoID is set
-	Name    string
+	// EnvironmentID selects the variables of a deployment environment, 0 selects the variables outside of any environment
+	EnvironmentID int64
+	Name          string
 }
 
 func (opts FindVariablesOpts) ToConds() builder.Cond {
@@ -99,6 +121,7 @@ func (opts FindVariablesOpts) ToConds() builder.Cond {
 	} else {
 		cond = cond.And(builder.Eq{"owner_id": opts.OwnerID})
 	}
+	cond = cond.And(builder.Eq{"environment_id": opts.EnvironmentID})
 
 	if opts.Name != "" {
 		cond = cond.And(builder.Eq{"name": strings.ToUpper(opts.Name)})
@@ -169,6 +192,38 @@ func GetVariablesOfRun(ctx context.Context, run *ActionRun) (map[string]string,
 	return variables, nil
 }
 
+// GetVariablesOfJob returns the variables of the job's run, overridden by the variables of the environment the job targets
+func GetVariablesOfJob(ctx context.Context, job *ActionRunJob) (map[string]string, error) {
+	if err := job.LoadRun(ctx); err != nil {
+		return nil, err
+	}
+	variables, err := GetVariablesOfRun(ctx, job.Run)
+	if err != nil {
+		return nil, err
+	}
+	if job.Environment == "" {
+		return variables, nil
+	}
+
+	env, err := GetEnvironmentByRepoAndName(ctx, job.RepoID, job.Environment)
+	if errors.Is(err, util.ErrNotExist) {
+		return variables, nil
+	} else if err != nil {
+		return nil, err
+	}
+	envVariables, err := db.Find[ActionVariable](ctx, FindVariablesOpts{RepoID: job.RepoID, EnvironmentID: env.ID})
+	if err != nil {
+		log.Error("find variables of environment: %d, error: %v", env.ID, err)
+		return nil, err
+	}
+
+	// Level precedence: Environment > Repo > Org / User > Global
+	for _, v := range envVariables {
+		variables[v.Name] = v.Data
+	}
+	return variables, nil
+}
+
 func CountWrongRepoLevelVariables(ctx context.Context) (int64, error) {
 	var result int64
 	_, err := db.GetEngine(ctx).SQL("SELECT count(`id`) FROM `action_variable` WHERE `repo_id` > 0 AND `owner_id` > 0").Get(&result)
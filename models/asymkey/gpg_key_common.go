@@ -136,6 +136,31 @@ func ExtractSignature(s string) (*packet.Signature, error) {
 	return sig, nil
 }
 
+// ExtractBinarySignature reads a signature which is not armored
+func ExtractBinarySignature(data []byte) (*packet.Signature, error) {
+	p, err := packet.Read(bytes.NewReader(data))
+	if err != nil {
+		return nil, errors.New("Failed to read signature packet")
+	}
+	sig, ok := p.(*packet.Signature)
+	if !ok {
+		return nil, errors.New("Packet is not a signature")
+	}
+	return sig, nil
+}
+
+// VerifyDetachedSignature verifies the detached signature of the content with the key
+func VerifyDetachedSignature(sig *packet.Signature, content io.Reader, k *GPGKey) error {
+	if !sig.Hash.Available() {
+		return fmt.Errorf("unsupported hash function: %v", sig.Hash)
+	}
+	h := sig.Hash.New()
+	if _, err := io.Copy(h, content); err != nil {
+		return err
+	}
+	return verifySign(sig, h, k)
+}
+
 func TryGetKeyIDFromSignature(sig *packet.Signature) string {
 	if sig.IssuerKeyId != nil && (*sig.IssuerKeyId) != 0 {
 		return fmt.Sprintf("%016X", *sig.IssuerKeyId)
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"time"
 
+	audit_model "gitea.dev/models/audit"
 	"gitea.dev/models/db"
 	"gitea.dev/models/perm"
 	"gitea.dev/modules/timeutil"
@@ -90,7 +91,7 @@ func AddDeployKey(ctx context.Context, repoID int64, name, content string, acces
 	if accessMode != perm.AccessModeRead && accessMode != perm.AccessModeWrite {
 		return nil, util.NewInvalidArgumentErrorf("invalid access mode")
 	}
-	return db.WithTx2(ctx, func(ctx context.Context) (*DeployKey, error) {
+	key, err := db.WithTx2(ctx, func(ctx context.Context) (*DeployKey, error) {
 		pkey, exist, err := db.Get[PublicKey](ctx, builder.Eq{"fingerprint": fingerprint})
 		if err != nil {
 			return nil, err
@@ -113,6 +114,19 @@ func AddDeployKey(ctx context.Context, repoID int64, name, content string, acces
 		}
 		return addDeployKey(ctx, repoID, pkey.ID, name, fingerprint, accessMode)
 	})
+	if err != nil {
+		return nil, err
+	}
+
+	audit_model.Record(ctx, &audit_model.Event{
+		Action:     audit_model.ActionDeployKeyAdd,
+		RepoID:     repoID,
+		TargetType: audit_model.TargetDeployKey,
+		TargetID:   key.ID,
+		TargetName: key.Name,
+		Metadata:   map[string]string{"fingerprint": key.Fingerprint, "mode": key.Mode.ToString()},
+	})
+	return key, nil
 }
 
 // GetDeployKeyByID returns deploy key by given ID.
@@ -0,0 +1,40 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package audit
+
+import (
+	"context"
+	"net"
+	"net/http"
+
+	"gitea.dev/modules/httplib"
+)
+
+// Actor is the signed in user of a request, the doer of the events recorded while handling it
+type Actor struct {
+	ID   int64
+	Name string
+}
+
+type actorContextKeyType struct{}
+
+// ActorContextKey is the key of the Actor in the context of a request
+var ActorContextKey actorContextKeyType
+
+// fillFromRequest sets the doer of an event from the actor of the request when the event has none, and the address of the client
+func fillFromRequest(ctx context.Context, e *Event) {
+	if actor, ok := ctx.Value(ActorContextKey).(*Actor); ok && (e.DoerID == 0 || e.DoerID == actor.ID) {
+		e.DoerID = actor.ID
+		if e.DoerName == "" {
+			e.DoerName = actor.Name
+		}
+	}
+	if req, ok := ctx.Value(httplib.RequestContextKey).(*http.Request); ok && e.IPAddress == "" {
+		host, _, err := net.SplitHostPort(req.RemoteAddr)
+		if err != nil {
+			host = req.RemoteAddr
+		}
+		e.IPAddress = host
+	}
+}
@@ -0,0 +1,323 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package audit
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	"gitea.dev/models/db"
+	"gitea.dev/modules/globallock"
+	"gitea.dev/modules/json"
+	"gitea.dev/modules/log"
+	"gitea.dev/modules/setting"
+	"gitea.dev/modules/timeutil"
+
+	"xorm.io/builder"
+)
+
+// Action is the kind of change recorded by an event, "<target>.<change>"
+type Action string
+
+const (
+	ActionUserCreate             Action = "user.create"
+	ActionUserDelete             Action = "user.delete"
+	ActionUserPermissionsChange  Action = "user.permissions.change"
+	ActionUserPasswordChange     Action = "user.password.change"
+	ActionAccessTokenCreate      Action = "access_token.create"
+	ActionAccessTokenRegenerate  Action = "access_token.regenerate"
+	ActionAccessTokenDelete      Action = "access_token.delete"
+	ActionTwoFactorEnable        Action = "two_factor.enable"
+	ActionTwoFactorDisable       Action = "two_factor.disable"
+	ActionSecurityKeyAdd         Action = "security_key.add"
+	ActionSecurityKeyRemove      Action = "security_key.remove"
+	ActionAuthSourceCreate       Action = "auth_source.create"
+	ActionAuthSourceUpdate       Action = "auth_source.update"
+	ActionAuthSourceDelete       Action = "auth_source.delete"
+	ActionSCIMTokenGenerate      Action = "scim_token.generate"
+	ActionSCIMTokenRevoke        Action = "scim_token.revoke"
+	ActionWebhookCreate          Action = "webhook.create"
+	ActionWebhookUpdate          Action = "webhook.update"
+	ActionWebhookDelete          Action = "webhook.delete"
+	ActionOrgDelete              Action = "org.delete"
+	ActionOrgVisibilityChange    Action = "org.visibility.change"
+	ActionOrgMemberRemove        Action = "org.member.remove"
+	ActionTeamCreate             Action = "team.create"
+	ActionTeamUpdate             Action = "team.update"
+	ActionTeamDelete             Action = "team.delete"
+	ActionTeamMemberAdd          Action = "team.member.add"
+	ActionTeamMemberRemove       Action = "team.member.remove"
+	ActionTeamRepoAdd            Action = "team.repo.add"
+	ActionTeamRepoRemove         Action = "team.repo.remove"
+	ActionRepoDelete             Action = "repo.delete"
+	ActionRepoVisibilityChange   Action = "repo.visibility.change"
+	ActionRepoTransfer           Action = "repo.transfer"
+	ActionCollaboratorAdd        Action = "repo.collaborator.add"
+	ActionCollaboratorUpdate     Action = "repo.collaborator.update"
+	ActionCollaboratorRemove     Action = "repo.collaborator.remove"
+	ActionDeployKeyAdd           Action = "deploy_key.add"
+	ActionDeployKeyRemove        Action = "deploy_key.remove"
+	ActionProtectedBranchCreate  Action = "protected_branch.create"
+	ActionProtectedBranchUpdate  Action = "protected_branch.update"
+	ActionProtectedBranchDelete  Action = "protected_branch.delete"
+	ActionSecretScanningBypass   Action = "secret_scanning.bypass"
+	ActionSecretScanningResolve  Action = "secret_scanning.resolve"
+	ActionSecretScanningReopen   Action = "secret_scanning.reopen"
+	ActionSecretScanningPatterns Action = "secret_scanning.patterns.change"
+)
+
+// The types of the targets of the events
+const (
+	TargetUser                  = "user"
+	TargetOrg                   = "org"
+	TargetRepo                  = "repo"
+	TargetTeam                  = "team"
+	TargetAccessToken           = "access_token"
+	TargetTwoFactor             = "two_factor"
+	TargetSecurityKey           = "security_key"
+	TargetAuthSource            = "auth_source"
+	TargetWebhook               = "webhook"
+	TargetDeployKey             = "deploy_key"
+	TargetProtectedBranch       = "protected_branch"
+	TargetSecretScanningAlert   = "secret_scanning_alert"
+	TargetSecretScanningPattern = "secret_scanning_pattern"
+)
+
+// Event is a change recorded in the audit log. The events are chained by their hashes once they are sealed, so that
+// altering or deleting a sealed event breaks the chain.
+type Event struct {
+	ID     int64  `xorm:"pk autoincr"`
+	Action Action `xorm:"VARCHAR(64) INDEX NOT NULL"`
+	// DoerID is 0 for the changes made by Gitea itself, the name is kept as the user could be deleted or renamed
+	DoerID    int64 `xorm:"INDEX NOT NULL DEFAULT 0"`
+	DoerName  string
+	IPAddress string
+	// OwnerID is the user or the organization owning the target, RepoID the repository of the target if any
+	OwnerID    int64 `xorm:"INDEX NOT NULL DEFAULT 0"`
+	RepoID     int64 `xorm:"INDEX NOT NULL DEFAULT 0"`
+	TargetType string
+	TargetID   int64
+	TargetName string
+	Metadata   map[string]string `xorm:"JSON TEXT"`
+
+	CreatedUnix timeutil.TimeStamp `xorm:"INDEX NOT NULL"`
+
+	// Seq is the position of the event in the chain, 0 until the event is sealed
+	Seq      int64  `xorm:"INDEX NOT NULL DEFAULT 0"`
+	PrevHash string `xorm:"VARCHAR(64)"`
+	Hash     string `xorm:"VARCHAR(64)"`
+}
+
+// TableName sets the table name of the audit events
+func (Event) TableName() string {
+	return "audit_event"
+}
+
+func init() {
+	db.RegisterModel(new(Event))
+}
+
+func (e *Event) computeHash() string {
+	h := sha256.New()
+	_, _ = fmt.Fprintf(h, "%d\n%d\n%s\n%s\n%d\n%q\n%q\n%d\n%d\n%q\n%d\n%q\n%d\n",
+		e.Seq, e.ID, e.PrevHash, e.Action, e.DoerID, e.DoerName, e.IPAddress, e.OwnerID, e.RepoID, e.TargetType, e.TargetID, e.TargetName, e.CreatedUnix)
+	for _, k := range slices.Sorted(maps.Keys(e.Metadata)) {
+		_, _ = fmt.Fprintf(h, "%q=%q\n", k, e.Metadata[k])
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// Record appends an event to the audit log and writes it to the "audit" logger. The doer is the signed in user of the
+// request when the event has none. A failure is logged, it never fails the change being recorded. The events recorded
+// in a transaction are sealed after it is committed, by the next event recorded out of a transaction.
+func Record(ctx context.Context, e *Event) {
+	if !setting.Audit.Enabled {
+		return
+	}
+	fillFromRequest(ctx, e)
+	if e.RepoID != 0 && e.OwnerID == 0 {
+		// the models recording events don't always know the owner of the repository, the events are listed by owner
+		if _, err := db.GetEngine(ctx).Table("repository").Where("id = ?", e.RepoID).Cols("owner_id").Get(&e.OwnerID); err != nil {
+			log.Error("Unable to get the owner of repository %d for the audit event %s: %v", e.RepoID, e.Action, err)
+		}
+	}
+	e.CreatedUnix = timeutil.TimeStampNow()
+	if err := db.Insert(ctx, e); err != nil {
+		log.Error("Unable to record the audit event %s of %s %d: %v", e.Action, e.TargetType, e.TargetID, err)
+		return
+	}
+	writeToLogger(e)
+
+	if !db.InTransaction(ctx) {
+		if err := SealEvents(ctx); err != nil {
+			log.Error("Unable to seal the audit events: %v", err)
+		}
+	}
+}
+
+func writeToLogger(e *Event) {
+	if !log.IsLoggerEnabled("audit") {
+		return
+	}
+	bs, err := json.Marshal(map[string]any{
+		"id":          e.ID,
+		"time":        e.CreatedUnix.AsTime().UTC().Format(time.RFC3339),
+		"action":      e.Action,
+		"doer_id":     e.DoerID,
+		"doer_name":   e.DoerName,
+		"ip_address":  e.IPAddress,
+		"owner_id":    e.OwnerID,
+		"repo_id":     e.RepoID,
+		"target_type": e.TargetType,
+		"target_id":   e.TargetID,
+		"target_name": e.TargetName,
+		"metadata":    e.Metadata,
+	})
+	if err != nil {
+		log.Error("Unable to marshal the audit event %d: %v", e.ID, err)
+		return
+	}
+	log.GetLogger("audit").Log(1, &log.Event{Level: log.INFO}, "%s", bs)
+}
+
+const sealBatchSize = 100
+
+func getLastSealedEvent(ctx context.Context) (*Event, error) {
+	last := &Event{}
+	if _, err := db.GetEngine(ctx).Where("seq > 0").Desc("seq").Get(last); err != nil {
+		return nil, err
+	}
+	return last, nil
+}
+
+// SealEvents chains the events which aren't sealed yet, in the order of their IDs
+func SealEvents(ctx context.Context) error {
+	return globallock.LockAndDo(ctx, "audit_log", func(ctx context.Context) error {
+		last, err := getLastSealedEvent(ctx)
+		if err != nil {
+			return err
+		}
+		for {
+			events := make([]*Event, 0, sealBatchSize)
+			if err := db.GetEngine(ctx).Where("seq = 0").Asc("id").Limit(sealBatchSize).Find(&events); err != nil {
+				return err
+			}
+			for _, e := range events {
+				e.Seq, e.PrevHash = last.Seq+1, last.Hash
+				e.Hash = e.computeHash()
+				if _, err := db.GetEngine(ctx).ID(e.ID).Cols("seq", "prev_hash", "hash").Update(e); err != nil {
+					return err
+				}
+				last = e
+			}
+			if len(events) < sealBatchSize {
+				return nil
+			}
+		}
+	})
+}
+
+// Verification is the result of the verification of the chain of the sealed events
+type Verification struct {
+	// Verified is the number of events verified before the first invalid one
+	Verified int64
+	// FirstInvalid is the first event which was altered, or which follows deleted events
+	FirstInvalid *Event
+	// Unsealed is the number of the events which aren't sealed yet
+	Unsealed int64
+}
+
+// VerifyEvents checks the chain of the sealed events. The oldest events are deleted by the retention, the chain is
+// only checked from the oldest event left.
+func VerifyEvents(ctx context.Context) (*Verification, error) {
+	res := &Verification{}
+	var prev *Event
+	for {
+		cond := builder.Gt{"seq": 0}
+		if prev != nil {
+			cond = builder.Gt{"seq": prev.Seq}
+		}
+		events := make([]*Event, 0, setting.Database.IterateBufferSize)
+		if err := db.GetEngine(ctx).Where(cond).Asc("seq").Limit(setting.Database.IterateBufferSize).Find(&events); err != nil {
+			return nil, err
+		}
+		for _, e := range events {
+			if e.Hash != e.computeHash() || prev != nil && (e.Seq != prev.Seq+1 || e.PrevHash != prev.Hash) {
+				res.FirstInvalid = e
+				break
+			}
+			res.Verified++
+			prev = e
+		}
+		if res.FirstInvalid != nil || len(events) < setting.Database.IterateBufferSize {
+			break
+		}
+	}
+
+	var err error
+	res.Unsealed, err = db.GetEngine(ctx).Where("seq = 0").Count(new(Event))
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// DeleteEventsOlderThan deletes the sealed events older than a duration, the last sealed event is kept so that the
+// chain goes on from it
+func DeleteEventsOlderThan(ctx context.Context, olderThan time.Duration) error {
+	if err := SealEvents(ctx); err != nil {
+		return err
+	}
+	last, err := getLastSealedEvent(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = db.GetEngine(ctx).Where(builder.Lt{"created_unix": time.Now().Add(-olderThan).Unix()}).
+		And(builder.Gt{"seq": 0}).And(builder.Lt{"seq": last.Seq}).Delete(new(Event))
+	return err
+}
+
+// FindEventsOptions filters the events of the audit log, all the events of the instance by default
+type FindEventsOptions struct {
+	db.ListOptions
+	OwnerID int64
+	RepoID  int64
+	// Action matches the action itself or the actions starting with it, e.g. "team" or "team.member"
+	Action string
+	DoerID int64
+	Since  int64
+	Before int64
+}
+
+func (opts FindEventsOptions) ToConds() builder.Cond {
+	cond := builder.NewCond()
+	if opts.OwnerID != 0 {
+		cond = cond.And(builder.Eq{"owner_id": opts.OwnerID})
+	}
+	if opts.RepoID != 0 {
+		cond = cond.And(builder.Eq{"repo_id": opts.RepoID})
+	}
+	if opts.Action != "" {
+		cond = cond.And(builder.Or(builder.Eq{"action": opts.Action}, builder.Like{"action", opts.Action + ".%"}))
+	}
+	if opts.DoerID != 0 {
+		cond = cond.And(builder.Eq{"doer_id": opts.DoerID})
+	}
+	if opts.Since != 0 {
+		cond = cond.And(builder.Gte{"created_unix": opts.Since})
+	}
+	if opts.Before != 0 {
+		cond = cond.And(builder.Lt{"created_unix": opts.Before})
+	}
+	return cond
+}
+
+func (opts FindEventsOptions) ToOrders() string {
+	return "id DESC"
+}
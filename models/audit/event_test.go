@@ -0,0 +1,127 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package audit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"gitea.dev/models/db"
+	"gitea.dev/models/unittest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func recordTestEvents(t *testing.T) []*Event {
+	events := []*Event{
+		{Action: ActionTeamCreate, OwnerID: 3, TargetType: TargetTeam, TargetID: 1, TargetName: "owners"},
+		{Action: ActionTeamMemberAdd, OwnerID: 3, TargetType: TargetUser, TargetID: 2, TargetName: "user2", Metadata: map[string]string{"team": "owners"}},
+		{Action: ActionRepoVisibilityChange, OwnerID: 3, RepoID: 5, TargetType: TargetRepo, TargetID: 5, TargetName: "repo5", Metadata: map[string]string{"private": "true"}},
+		{Action: ActionAccessTokenCreate, OwnerID: 2, TargetType: TargetAccessToken, TargetID: 1, TargetName: "token"},
+	}
+	for _, e := range events {
+		Record(t.Context(), e)
+		require.NotZero(t, e.ID)
+	}
+	return events
+}
+
+func TestRecordAndVerify(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+	ctx := context.WithValue(t.Context(), ActorContextKey, &Actor{ID: 1, Name: "admin"})
+
+	Record(ctx, &Event{Action: ActionUserCreate, TargetType: TargetUser, TargetID: 10, TargetName: "new-user"})
+	recordTestEvents(t)
+
+	e := unittest.AssertExistsAndLoadBean(t, &Event{Action: ActionUserCreate})
+	assert.EqualValues(t, 1, e.DoerID)
+	assert.Equal(t, "admin", e.DoerName)
+	assert.EqualValues(t, 1, e.Seq)
+	assert.Empty(t, e.PrevHash)
+
+	v, err := VerifyEvents(t.Context())
+	require.NoError(t, err)
+	assert.EqualValues(t, 5, v.Verified)
+	assert.Nil(t, v.FirstInvalid)
+	assert.Zero(t, v.Unsealed)
+
+	// altering an event breaks the chain from it
+	_, err = db.GetEngine(t.Context()).Exec("UPDATE audit_event SET target_name = ? WHERE seq = 3", "someone-else")
+	require.NoError(t, err)
+	v, err = VerifyEvents(t.Context())
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, v.Verified)
+	require.NotNil(t, v.FirstInvalid)
+	assert.EqualValues(t, 3, v.FirstInvalid.Seq)
+
+	// so does deleting one
+	require.NoError(t, unittest.PrepareTestDatabase())
+	recordTestEvents(t)
+	_, err = db.GetEngine(t.Context()).Exec("DELETE FROM audit_event WHERE seq = 2")
+	require.NoError(t, err)
+	v, err = VerifyEvents(t.Context())
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, v.Verified)
+	require.NotNil(t, v.FirstInvalid)
+	assert.EqualValues(t, 3, v.FirstInvalid.Seq)
+}
+
+func TestRecordInTransaction(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	require.NoError(t, db.WithTx(t.Context(), func(ctx context.Context) error {
+		Record(ctx, &Event{Action: ActionTeamDelete, OwnerID: 3, TargetType: TargetTeam, TargetID: 1})
+		return nil
+	}))
+	e := unittest.AssertExistsAndLoadBean(t, &Event{Action: ActionTeamDelete})
+	assert.Zero(t, e.Seq)
+
+	// the next event seals it
+	recordTestEvents(t)
+	e = unittest.AssertExistsAndLoadBean(t, &Event{Action: ActionTeamDelete})
+	assert.EqualValues(t, 1, e.Seq)
+	v, err := VerifyEvents(t.Context())
+	require.NoError(t, err)
+	assert.EqualValues(t, 5, v.Verified)
+	assert.Nil(t, v.FirstInvalid)
+}
+
+func TestFindEvents(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+	recordTestEvents(t)
+
+	count := func(opts FindEventsOptions) int64 {
+		n, err := db.Count[Event](t.Context(), opts)
+		require.NoError(t, err)
+		return n
+	}
+	assert.EqualValues(t, 4, count(FindEventsOptions{}))
+	assert.EqualValues(t, 3, count(FindEventsOptions{OwnerID: 3}))
+	assert.EqualValues(t, 1, count(FindEventsOptions{RepoID: 5}))
+	assert.EqualValues(t, 2, count(FindEventsOptions{OwnerID: 3, Action: "team"}))
+	assert.EqualValues(t, 1, count(FindEventsOptions{Action: "team.member"}))
+	assert.EqualValues(t, 0, count(FindEventsOptions{Action: "tea"}))
+}
+
+func TestDeleteEventsOlderThan(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+	recordTestEvents(t)
+
+	require.NoError(t, DeleteEventsOlderThan(t.Context(), time.Hour))
+	unittest.AssertCount(t, &Event{}, 4)
+
+	// a negative duration puts the limit in the future, all the events are older
+	require.NoError(t, DeleteEventsOlderThan(t.Context(), -time.Hour))
+	// the last sealed event is kept to chain the next ones
+	unittest.AssertCount(t, &Event{}, 1)
+	unittest.AssertExistsAndLoadBean(t, &Event{Seq: 4})
+
+	recordTestEvents(t)
+	v, err := VerifyEvents(t.Context())
+	require.NoError(t, err)
+	assert.EqualValues(t, 5, v.Verified)
+	assert.Nil(t, v.FirstInvalid)
+}
@@ -9,6 +9,7 @@ import (
 	"encoding/hex"
 	"time"
 
+	"gitea.dev/models/audit"
 	"gitea.dev/models/db"
 	"gitea.dev/modules/timeutil"
 	"gitea.dev/modules/util"
@@ -56,8 +57,25 @@ func (t *AccessToken) setNewTokenValue() {
 // NewAccessToken creates new access token.
 func NewAccessToken(ctx context.Context, t *AccessToken) error {
 	t.setNewTokenValue()
-	_, err := db.GetEngine(ctx).Insert(t)
-	return err
+	if _, err := db.GetEngine(ctx).Insert(t); err != nil {
+		return err
+	}
+	recordAccessTokenEvent(ctx, audit.ActionAccessTokenCreate, t)
+	return nil
+}
+
+func recordAccessTokenEvent(ctx context.Context, action audit.Action, t *AccessToken) {
+	e := &audit.Event{
+		Action:     action,
+		OwnerID:    t.UID,
+		TargetType: audit.TargetAccessToken,
+		TargetID:   t.ID,
+		TargetName: t.Name,
+	}
+	if t.Scope != "" {
+		e.Metadata = map[string]string{"scope": string(t.Scope)}
+	}
+	audit.Record(ctx, e)
 }
 
 // RegenerateAccessToken regenerates the token value of an existing access token owned by userID, keeping its name and scope.
@@ -74,6 +92,7 @@ func RegenerateAccessToken(ctx context.Context, id, userID int64) (*AccessToken,
 	if _, err := db.GetEngine(ctx).ID(t.ID).Cols("token_hash", "token_salt", "token_last_eight").NoAutoTime().Update(t); err != nil {
 		return nil, err
 	}
+	recordAccessTokenEvent(ctx, audit.ActionAccessTokenRegenerate, t)
 	return t, nil
 }
 
@@ -166,5 +185,6 @@ func DeleteAccessTokenByID(ctx context.Context, id, userID int64) error {
 	} else if cnt != 1 {
 		return util.NewNotExistErrorf("access token not found")
 	}
+	recordAccessTokenEvent(ctx, audit.ActionAccessTokenDelete, &AccessToken{ID: id, UID: userID})
 	return nil
 }
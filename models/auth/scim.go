@@ -0,0 +1,269 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package auth
+
+import (
+	"context"
+	"encoding/hex"
+
+	"gitea.dev/models/db"
+	"gitea.dev/modules/timeutil"
+	"gitea.dev/modules/util"
+
+	"xorm.io/builder"
+)
+
+// SCIMToken is the bearer token the identity provider provisions the users and the groups of a source with
+type SCIMToken struct {
+	ID             int64  `xorm:"pk autoincr"`
+	SourceID       int64  `xorm:"UNIQUE NOT NULL"`
+	Token          string `xorm:"-"`
+	TokenHash      string `xorm:"UNIQUE"` // sha256 of token
+	TokenSalt      string
+	TokenLastEight string `xorm:"INDEX token_last_eight"`
+
+	CreatedUnix timeutil.TimeStamp `xorm:"created"`
+	UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
+}
+
+// SCIMUser is a user provisioned over SCIM, with the identifier the identity provider knows it by
+type SCIMUser struct {
+	ID         int64  `xorm:"pk autoincr"`
+	SourceID   int64  `xorm:"INDEX NOT NULL"`
+	UserID     int64  `xorm:"UNIQUE NOT NULL"`
+	ExternalID string `xorm:"VARCHAR(255)"`
+
+	CreatedUnix timeutil.TimeStamp `xorm:"created"`
+	UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
+}
+
+// SCIMGroup is a group provisioned over SCIM, it is mapped to organization teams by the group team map of the source
+type SCIMGroup struct {
+	ID          int64  `xorm:"pk autoincr"`
+	SourceID    int64  `xorm:"UNIQUE(s) NOT NULL"`
+	DisplayName string `xorm:"UNIQUE(s) VARCHAR(255) NOT NULL"`
+	ExternalID  string `xorm:"VARCHAR(255)"`
+
+	CreatedUnix timeutil.TimeStamp `xorm:"created"`
+	UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
+}
+
+// SCIMGroupMember is a member of a SCIM group
+type SCIMGroupMember struct {
+	ID      int64 `xorm:"pk autoincr"`
+	GroupID int64 `xorm:"UNIQUE(s) NOT NULL"`
+	UserID  int64 `xorm:"UNIQUE(s) INDEX NOT NULL"`
+}
+
+func init() {
+	db.RegisterModel(new(SCIMToken))
+	db.RegisterModel(new(SCIMUser))
+	db.RegisterModel(new(SCIMGroup))
+	db.RegisterModel(new(SCIMGroupMember))
+}
+
+// GenerateSCIMToken creates the SCIM token of the source, replacing the previous one
+func GenerateSCIMToken(ctx context.Context, sourceID int64) (*SCIMToken, error) {
+	t := &SCIMToken{SourceID: sourceID}
+	t.TokenSalt = util.CryptoRandomString(10)
+	t.Token = hex.EncodeToString(util.CryptoRandomBytes(20))
+	t.TokenHash = HashToken(t.Token, t.TokenSalt)
+	t.TokenLastEight = t.Token[len(t.Token)-8:]
+
+	return t, db.WithTx(ctx, func(ctx context.Context) error {
+		if _, err := db.GetEngine(ctx).Delete(&SCIMToken{SourceID: sourceID}); err != nil {
+			return err
+		}
+		return db.Insert(ctx, t)
+	})
+}
+
+// GetSCIMTokenBySourceID returns the SCIM token of the source, if it has one
+func GetSCIMTokenBySourceID(ctx context.Context, sourceID int64) (*SCIMToken, bool, error) {
+	return db.Get[SCIMToken](ctx, builder.Eq{"source_id": sourceID})
+}
+
+// GetSCIMTokenBySHA returns the SCIM token by its value
+func GetSCIMTokenBySHA(ctx context.Context, token string) (*SCIMToken, error) {
+	if len(token) < 8 {
+		return nil, util.NewNotExistErrorf("scim token not found")
+	}
+
+	var tokens []*SCIMToken
+	if err := db.GetEngine(ctx).Where("token_last_eight = ?", token[len(token)-8:]).Find(&tokens); err != nil {
+		return nil, err
+	}
+	for _, t := range tokens {
+		if util.CryptoConstTimeEqual(t.TokenHash, HashToken(token, t.TokenSalt)) {
+			return t, nil
+		}
+	}
+	return nil, util.NewNotExistErrorf("scim token not found")
+}
+
+// DeleteSCIMToken deletes the SCIM token of the source
+func DeleteSCIMToken(ctx context.Context, sourceID int64) error {
+	_, err := db.GetEngine(ctx).Delete(&SCIMToken{SourceID: sourceID})
+	return err
+}
+
+// DeleteSCIMData deletes the SCIM token, users and groups of the source
+func DeleteSCIMData(ctx context.Context, sourceID int64) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		groupIDs := builder.Select("id").From("scim_group").Where(builder.Eq{"source_id": sourceID})
+		if _, err := db.GetEngine(ctx).Where(builder.In("group_id", groupIDs)).Delete(new(SCIMGroupMember)); err != nil {
+			return err
+		}
+		return db.DeleteBeans(ctx, &SCIMGroup{SourceID: sourceID}, &SCIMUser{SourceID: sourceID}, &SCIMToken{SourceID: sourceID})
+	})
+}
+
+// GetSCIMUsers returns the SCIM users of the source by their user ID
+func GetSCIMUsers(ctx context.Context, sourceID int64) (map[int64]*SCIMUser, error) {
+	users := make(map[int64]*SCIMUser)
+	return users, db.GetEngine(ctx).Where("source_id = ?", sourceID).Find(&users)
+}
+
+// GetSCIMUser returns the SCIM user of the source for the user, if it is one
+func GetSCIMUser(ctx context.Context, sourceID, userID int64) (*SCIMUser, bool, error) {
+	return db.Get[SCIMUser](ctx, builder.Eq{"source_id": sourceID, "user_id": userID})
+}
+
+// SetSCIMUserExternalID records the user as provisioned by the source, with its external ID
+func SetSCIMUserExternalID(ctx context.Context, sourceID, userID int64, externalID string) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		scimUser, has, err := db.Get[SCIMUser](ctx, builder.Eq{"user_id": userID})
+		if err != nil {
+			return err
+		}
+		if !has {
+			return db.Insert(ctx, &SCIMUser{SourceID: sourceID, UserID: userID, ExternalID: externalID})
+		}
+		if scimUser.SourceID == sourceID && scimUser.ExternalID == externalID {
+			return nil
+		}
+		scimUser.SourceID, scimUser.ExternalID = sourceID, externalID
+		_, err = db.GetEngine(ctx).ID(scimUser.ID).Cols("source_id", "external_id").Update(scimUser)
+		return err
+	})
+}
+
+// GetSCIMGroupByID returns a SCIM group of the source
+func GetSCIMGroupByID(ctx context.Context, sourceID, id int64) (*SCIMGroup, error) {
+	group, has, err := db.Get[SCIMGroup](ctx, builder.Eq{"source_id": sourceID, "id": id})
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, util.NewNotExistErrorf("scim group %d not found", id)
+	}
+	return group, nil
+}
+
+// FindSCIMGroups returns the SCIM groups of the source
+func FindSCIMGroups(ctx context.Context, sourceID int64) ([]*SCIMGroup, error) {
+	var groups []*SCIMGroup
+	return groups, db.GetEngine(ctx).Where("source_id = ?", sourceID).OrderBy("id").Find(&groups)
+}
+
+func checkSCIMGroupDisplayName(ctx context.Context, group *SCIMGroup) error {
+	exist, err := db.Exist[SCIMGroup](ctx, builder.Eq{"source_id": group.SourceID, "display_name": group.DisplayName}.And(builder.Neq{"id": group.ID}))
+	if err != nil {
+		return err
+	} else if exist {
+		return util.NewAlreadyExistErrorf("scim group %q already exists", group.DisplayName)
+	}
+	return nil
+}
+
+// CreateSCIMGroup creates a SCIM group, the display names are unique in a source
+func CreateSCIMGroup(ctx context.Context, group *SCIMGroup) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		if err := checkSCIMGroupDisplayName(ctx, group); err != nil {
+			return err
+		}
+		return db.Insert(ctx, group)
+	})
+}
+
+// UpdateSCIMGroup updates the display name and the external ID of a SCIM group
+func UpdateSCIMGroup(ctx context.Context, group *SCIMGroup) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		if err := checkSCIMGroupDisplayName(ctx, group); err != nil {
+			return err
+		}
+		_, err := db.GetEngine(ctx).ID(group.ID).Cols("display_name", "external_id").Update(group)
+		return err
+	})
+}
+
+// DeleteSCIMGroup deletes a SCIM group and its members
+func DeleteSCIMGroup(ctx context.Context, group *SCIMGroup) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		return db.DeleteBeans(ctx, &SCIMGroupMember{GroupID: group.ID}, &SCIMGroup{ID: group.ID})
+	})
+}
+
+// GetSCIMGroupMemberIDs returns the IDs of the members of the groups by group ID
+func GetSCIMGroupMemberIDs(ctx context.Context, groupIDs ...int64) (map[int64][]int64, error) {
+	var members []*SCIMGroupMember
+	if err := db.GetEngine(ctx).In("group_id", groupIDs).OrderBy("id").Find(&members); err != nil {
+		return nil, err
+	}
+	memberIDs := make(map[int64][]int64, len(groupIDs))
+	for _, member := range members {
+		memberIDs[member.GroupID] = append(memberIDs[member.GroupID], member.UserID)
+	}
+	return memberIDs, nil
+}
+
+// SetSCIMGroupMembers replaces the members of a SCIM group, it returns the IDs of the added and the removed users
+func SetSCIMGroupMembers(ctx context.Context, groupID int64, userIDs []int64) (added, removed []int64, err error) {
+	err = db.WithTx(ctx, func(ctx context.Context) error {
+		memberIDs, err := GetSCIMGroupMemberIDs(ctx, groupID)
+		if err != nil {
+			return err
+		}
+		existing := make(map[int64]bool, len(memberIDs[groupID]))
+		for _, userID := range memberIDs[groupID] {
+			existing[userID] = false
+		}
+		for _, userID := range userIDs {
+			if _, ok := existing[userID]; ok {
+				existing[userID] = true
+				continue
+			}
+			existing[userID] = true
+			if err := db.Insert(ctx, &SCIMGroupMember{GroupID: groupID, UserID: userID}); err != nil {
+				return err
+			}
+			added = append(added, userID)
+		}
+		for _, userID := range memberIDs[groupID] {
+			if !existing[userID] {
+				removed = append(removed, userID)
+			}
+		}
+		if len(removed) > 0 {
+			_, err = db.GetEngine(ctx).Where("group_id = ?", groupID).In("user_id", removed).Delete(new(SCIMGroupMember))
+		}
+		return err
+	})
+	return added, removed, err
+}
+
+// DeleteUserSCIMGroupMemberships removes the user from its SCIM groups
+func DeleteUserSCIMGroupMemberships(ctx context.Context, userID int64) error {
+	_, err := db.GetEngine(ctx).Delete(&SCIMGroupMember{UserID: userID})
+	return err
+}
+
+// GetUserSCIMGroups returns the SCIM groups of the source the user is a member of
+func GetUserSCIMGroups(ctx context.Context, sourceID, userID int64) ([]*SCIMGroup, error) {
+	var groups []*SCIMGroup
+	return groups, db.GetEngine(ctx).
+		Join("INNER", "scim_group_member", "scim_group_member.group_id = scim_group.id").
+		Where("scim_group.source_id = ? AND scim_group_member.user_id = ?", sourceID, userID).
+		OrderBy("scim_group.id").
+		Find(&groups)
+}
@@ -0,0 +1,66 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package auth_test
+
+import (
+	"testing"
+
+	auth_model "gitea.dev/models/auth"
+	"gitea.dev/models/unittest"
+	"gitea.dev/modules/util"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSCIMToken(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	first, err := auth_model.GenerateSCIMToken(t.Context(), 1)
+	require.NoError(t, err)
+	second, err := auth_model.GenerateSCIMToken(t.Context(), 1)
+	require.NoError(t, err)
+
+	// generating a token replaces the previous one
+	_, err = auth_model.GetSCIMTokenBySHA(t.Context(), first.Token)
+	assert.ErrorIs(t, err, util.ErrNotExist)
+	token, err := auth_model.GetSCIMTokenBySHA(t.Context(), second.Token)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, token.SourceID)
+
+	require.NoError(t, auth_model.DeleteSCIMToken(t.Context(), 1))
+	_, has, err := auth_model.GetSCIMTokenBySourceID(t.Context(), 1)
+	require.NoError(t, err)
+	assert.False(t, has)
+}
+
+func TestSCIMGroups(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	group := &auth_model.SCIMGroup{SourceID: 1, DisplayName: "devs"}
+	require.NoError(t, auth_model.CreateSCIMGroup(t.Context(), group))
+	err := auth_model.CreateSCIMGroup(t.Context(), &auth_model.SCIMGroup{SourceID: 1, DisplayName: "devs"})
+	assert.ErrorIs(t, err, util.ErrAlreadyExist)
+	require.NoError(t, auth_model.CreateSCIMGroup(t.Context(), &auth_model.SCIMGroup{SourceID: 2, DisplayName: "devs"}))
+
+	added, removed, err := auth_model.SetSCIMGroupMembers(t.Context(), group.ID, []int64{2, 4})
+	require.NoError(t, err)
+	assert.Equal(t, []int64{2, 4}, added)
+	assert.Empty(t, removed)
+
+	added, removed, err = auth_model.SetSCIMGroupMembers(t.Context(), group.ID, []int64{4, 5})
+	require.NoError(t, err)
+	assert.Equal(t, []int64{5}, added)
+	assert.Equal(t, []int64{2}, removed)
+
+	groups, err := auth_model.GetUserSCIMGroups(t.Context(), 1, 5)
+	require.NoError(t, err)
+	require.Len(t, groups, 1)
+	assert.Equal(t, group.ID, groups[0].ID)
+
+	require.NoError(t, auth_model.DeleteSCIMData(t.Context(), 1))
+	unittest.AssertNotExistsBean(t, &auth_model.SCIMGroup{ID: group.ID})
+	unittest.AssertNotExistsBean(t, &auth_model.SCIMGroupMember{GroupID: group.ID})
+	unittest.AssertExistsAndLoadBean(t, &auth_model.SCIMGroup{SourceID: 2, DisplayName: "devs"})
+}
@@ -8,7 +8,9 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"strconv"
 
+	"gitea.dev/models/audit"
 	"gitea.dev/models/db"
 	"gitea.dev/modules/log"
 	"gitea.dev/modules/optional"
@@ -34,6 +36,7 @@ const (
 	DLDAP       // 5
 	OAuth2      // 6
 	SSPI        // 7
+	SAML        // 8
 )
 
 // String returns the string name of the LoginType
@@ -54,6 +57,7 @@ var Names = map[Type]string{
 	PAM:    "PAM",
 	OAuth2: "OAuth2",
 	SSPI:   "SPNEGO with SSPI",
+	SAML:   "SAML",
 }
 
 // Config represents login config as far as the db is concerned
@@ -96,6 +100,11 @@ type RegisterableSource interface {
 	UnregisterSource() error
 }
 
+// GroupTeamMapper configurations provide the mapping between their groups and the organization teams
+type GroupTeamMapper interface {
+	GetGroupTeamMap() (groupTeamMap string, performRemoval bool)
+}
+
 var registeredConfigs = map[Type]func() Config{}
 
 // RegisterTypeConfig register a config for a provided type, the exemplar argument only serves type inference
@@ -180,6 +189,11 @@ func (source *Source) IsSSPI() bool {
 	return source.Type == SSPI
 }
 
+// IsSAML returns true of this source is of the SAML type.
+func (source *Source) IsSAML() bool {
+	return source.Type == SAML
+}
+
 // MustSourceCfg returns the source's config as T. The registry populates Cfg from the
 // source type, so a mismatch is a programming error the caller can't recover from.
 func MustSourceCfg[T Config](source *Source) T {
@@ -215,7 +229,7 @@ func (source *Source) TwoFactorShouldSkip() bool {
 
 // CreateSource inserts a AuthSource in the DB if not already
 // existing with the given name.
-func CreateSource(ctx context.Context, source *Source) error {
+func CreateSource(ctx context.Context, source *Source) (err error) {
 	has, err := db.GetEngine(ctx).Where("name=?", source.Name).Exist(new(Source))
 	if err != nil {
 		return err
@@ -231,6 +245,11 @@ func CreateSource(ctx context.Context, source *Source) error {
 	if err != nil {
 		return err
 	}
+	defer func() {
+		if err == nil {
+			recordSourceEvent(ctx, audit.ActionAuthSourceCreate, source)
+		}
+	}()
 
 	if !source.IsActive {
 		return nil
@@ -284,6 +303,19 @@ func IsSSPIEnabled(ctx context.Context) bool {
 	return exist
 }
 
+// GetActiveSAMLSourceByName returns the active SAML source with the given name
+func GetActiveSAMLSourceByName(ctx context.Context, name string) (*Source, error) {
+	source := new(Source)
+	has, err := db.GetEngine(ctx).Where("name = ? and type = ? and is_active = ?", name, SAML, true).Get(source)
+	if err != nil {
+		return nil, err
+	}
+	if !has {
+		return nil, util.NewNotExistErrorf("saml source not found, name: %q", name)
+	}
+	return source, nil
+}
+
 // GetSourceByID returns login source by given ID.
 func GetSourceByID(ctx context.Context, id int64) (*Source, error) {
 	source := new(Source)
@@ -305,11 +337,10 @@ func GetSourceByID(ctx context.Context, id int64) (*Source, error) {
 }
 
 // UpdateSource updates a Source record in DB.
-func UpdateSource(ctx context.Context, source *Source) error {
+func UpdateSource(ctx context.Context, source *Source) (err error) {
 	var originalSource *Source
 	if source.IsOAuth2() {
 		// keep track of the original values so we can restore in case of errors while registering OAuth2 providers
-		var err error
 		if originalSource, err = GetSourceByID(ctx, source.ID); err != nil {
 			return err
 		}
@@ -326,6 +357,11 @@ func UpdateSource(ctx context.Context, source *Source) error {
 	if err != nil {
 		return err
 	}
+	defer func() {
+		if err == nil {
+			recordSourceEvent(ctx, audit.ActionAuthSourceUpdate, source)
+		}
+	}()
 
 	if !source.IsActive {
 		return nil
@@ -348,6 +384,16 @@ func UpdateSource(ctx context.Context, source *Source) error {
 	return err
 }
 
+func recordSourceEvent(ctx context.Context, action audit.Action, source *Source) {
+	audit.Record(ctx, &audit.Event{
+		Action:     action,
+		TargetType: audit.TargetAuthSource,
+		TargetID:   source.ID,
+		TargetName: source.Name,
+		Metadata:   map[string]string{"type": source.TypeName(), "active": strconv.FormatBool(source.IsActive)},
+	})
+}
+
 // ErrSourceNotExist represents a "SourceNotExist" kind of error.
 type ErrSourceNotExist struct {
 	ID int64
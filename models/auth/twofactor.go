@@ -13,6 +13,7 @@ import (
 	"encoding/hex"
 	"fmt"
 
+	"gitea.dev/models/audit"
 	"gitea.dev/models/db"
 	"gitea.dev/modules/secret"
 	"gitea.dev/modules/setting"
@@ -144,8 +145,21 @@ func (t *TwoFactor) ValidateAndConsumeTOTP(ctx context.Context, passcode string)
 
 // NewTwoFactor creates a new two-factor authentication token.
 func NewTwoFactor(ctx context.Context, t *TwoFactor) error {
-	_, err := db.GetEngine(ctx).Insert(t)
-	return err
+	if _, err := db.GetEngine(ctx).Insert(t); err != nil {
+		return err
+	}
+	recordTwoFactorEvent(ctx, audit.ActionTwoFactorEnable, t.UID, nil)
+	return nil
+}
+
+func recordTwoFactorEvent(ctx context.Context, action audit.Action, uid int64, metadata map[string]string) {
+	audit.Record(ctx, &audit.Event{
+		Action:     action,
+		OwnerID:    uid,
+		TargetType: audit.TargetTwoFactor,
+		TargetID:   uid,
+		Metadata:   metadata,
+	})
 }
 
 // UpdateTwoFactor updates a two-factor authentication token.
@@ -183,6 +197,7 @@ func DeleteTwoFactorByID(ctx context.Context, id, userID int64) error {
 	} else if cnt != 1 {
 		return ErrTwoFactorNotEnrolled{userID}
 	}
+	recordTwoFactorEvent(ctx, audit.ActionTwoFactorDisable, userID, map[string]string{"method": "totp"})
 	return nil
 }
 
@@ -208,5 +223,8 @@ func DisableTwoFactor(ctx context.Context, uid int64) (totp, webAuthn int64, err
 		webAuthn, e = db.GetEngine(ctx).Where("user_id = ?", uid).Delete(&WebAuthnCredential{})
 		return e
 	})
+	if err == nil && totp+webAuthn > 0 {
+		recordTwoFactorEvent(ctx, audit.ActionTwoFactorDisable, uid, map[string]string{"method": "all"})
+	}
 	return totp, webAuthn, err
 }
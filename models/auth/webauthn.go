@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"strings"
 
+	"gitea.dev/models/audit"
 	"gitea.dev/models/db"
 	"gitea.dev/modules/timeutil"
 	"gitea.dev/modules/util"
@@ -192,11 +193,26 @@ func CreateCredential(ctx context.Context, userID int64, name string, cred *weba
 	if err := db.Insert(ctx, c); err != nil {
 		return nil, err
 	}
+	audit.Record(ctx, &audit.Event{
+		Action:     audit.ActionSecurityKeyAdd,
+		OwnerID:    userID,
+		TargetType: audit.TargetSecurityKey,
+		TargetID:   c.ID,
+		TargetName: name,
+	})
 	return c, nil
 }
 
 // DeleteCredential will delete WebAuthnCredential
 func DeleteCredential(ctx context.Context, id, userID int64) (bool, error) {
 	had, err := db.GetEngine(ctx).ID(id).Where("user_id = ?", userID).Delete(&WebAuthnCredential{})
+	if err == nil && had > 0 {
+		audit.Record(ctx, &audit.Event{
+			Action:     audit.ActionSecurityKeyRemove,
+			OwnerID:    userID,
+			TargetType: audit.TargetSecurityKey,
+			TargetID:   id,
+		})
+	}
 	return had > 0, err
 }
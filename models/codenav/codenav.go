@@ -0,0 +1,261 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package codenav
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	"gitea.dev/models/db"
+	"gitea.dev/modules/codenav"
+	"gitea.dev/modules/optional"
+	"gitea.dev/modules/timeutil"
+	"gitea.dev/modules/util"
+
+	"xorm.io/builder"
+)
+
+// insertBatchSize is the number of rows inserted at once when an index is saved
+const insertBatchSize = 500
+
+// Index is the code navigation index of a commit, uploaded or generated with ctags
+type Index struct {
+	ID          int64          `xorm:"pk autoincr"`
+	RepoID      int64          `xorm:"UNIQUE(s) NOT NULL"`
+	CommitSHA   string         `xorm:"UNIQUE(s) VARCHAR(64) NOT NULL"`
+	Format      codenav.Format `xorm:"VARCHAR(10) NOT NULL"`
+	Occurrences int64          `xorm:"NOT NULL DEFAULT 0"`
+	// ArtifactID is the Actions artifact the index was imported from
+	ArtifactID  int64              `xorm:"NOT NULL DEFAULT 0"`
+	CreatedUnix timeutil.TimeStamp `xorm:"created INDEX"`
+}
+
+// TableName sets the table name of the code navigation indexes
+func (Index) TableName() string {
+	return "code_nav_index"
+}
+
+// Symbol is a symbol of an index with its documentation
+type Symbol struct {
+	ID      int64 `xorm:"pk autoincr"`
+	RepoID  int64 `xorm:"INDEX NOT NULL"`
+	IndexID int64 `xorm:"UNIQUE(s) NOT NULL"`
+	// Key is the SHA-256 of the symbol, the symbols are too long to be indexed
+	Key           string `xorm:"UNIQUE(s) VARCHAR(64) NOT NULL"`
+	Symbol        string `xorm:"TEXT"`
+	Documentation string `xorm:"LONGTEXT"`
+}
+
+// TableName sets the table name of the symbols of the code navigation indexes
+func (Symbol) TableName() string {
+	return "code_nav_symbol"
+}
+
+// Occurrence is an occurrence of a symbol in a file
+type Occurrence struct {
+	ID           int64  `xorm:"pk autoincr"`
+	RepoID       int64  `xorm:"INDEX NOT NULL"`
+	IndexID      int64  `xorm:"INDEX(path) INDEX(symbol) NOT NULL"`
+	Path         string `xorm:"INDEX(path) VARCHAR(500) NOT NULL"`
+	Line         int    `xorm:"INDEX(path) NOT NULL"`
+	StartChar    int    `xorm:"NOT NULL"`
+	EndLine      int    `xorm:"NOT NULL"`
+	EndChar      int    `xorm:"NOT NULL"`
+	SymbolKey    string `xorm:"INDEX(symbol) VARCHAR(64) NOT NULL"`
+	IsDefinition bool   `xorm:"INDEX(symbol) NOT NULL DEFAULT false"`
+}
+
+// TableName sets the table name of the occurrences of the symbols of the code navigation indexes
+func (Occurrence) TableName() string {
+	return "code_nav_occurrence"
+}
+
+// Range returns the range of the occurrence in its file
+func (o *Occurrence) Range() codenav.Range {
+	return codenav.Range{Line: o.Line, StartChar: o.StartChar, EndLine: o.EndLine, EndChar: o.EndChar}
+}
+
+func init() {
+	db.RegisterModel(new(Index))
+	db.RegisterModel(new(Symbol))
+	db.RegisterModel(new(Occurrence))
+}
+
+// SymbolKey returns the key of a symbol
+func SymbolKey(symbol string) string {
+	hash := sha256.Sum256([]byte(symbol))
+	return hex.EncodeToString(hash[:])
+}
+
+// GetIndex returns the index of a commit
+func GetIndex(ctx context.Context, repoID int64, commitSHA string) (*Index, error) {
+	index, has, err := db.Get[Index](ctx, builder.Eq{"repo_id": repoID, "commit_sha": commitSHA})
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, fmt.Errorf("code navigation index of commit %s: %w", commitSHA, util.ErrNotExist)
+	}
+	return index, nil
+}
+
+// ExistIndex reports whether a commit has an index
+func ExistIndex(ctx context.Context, repoID int64, commitSHA string) (bool, error) {
+	return db.Exist[Index](ctx, builder.Eq{"repo_id": repoID, "commit_sha": commitSHA})
+}
+
+func deleteIndexes(ctx context.Context, repoID int64, indexIDs []int64) error {
+	if len(indexIDs) == 0 {
+		return nil
+	}
+	for _, bean := range []any{new(Occurrence), new(Symbol)} {
+		if _, err := db.GetEngine(ctx).Where("repo_id = ?", repoID).In("index_id", indexIDs).Delete(bean); err != nil {
+			return err
+		}
+	}
+	_, err := db.GetEngine(ctx).Where("repo_id = ?", repoID).In("id", indexIDs).Delete(new(Index))
+	return err
+}
+
+// SaveIndex replaces the index of a commit, only the latest keep indexes of the repository are kept
+func SaveIndex(ctx context.Context, index *Index, parsed *codenav.Index, keep int) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		var previous []int64
+		if err := db.GetEngine(ctx).Table("code_nav_index").Cols("id").
+			Where(builder.Eq{"repo_id": index.RepoID, "commit_sha": index.CommitSHA}).Find(&previous); err != nil {
+			return err
+		}
+		if err := deleteIndexes(ctx, index.RepoID, previous); err != nil {
+			return err
+		}
+
+		index.Format = parsed.Format
+		index.Occurrences = int64(len(parsed.Occurrences))
+		if err := db.Insert(ctx, index); err != nil {
+			return err
+		}
+
+		symbols := make([]*Symbol, 0, insertBatchSize)
+		keys := make(map[string]string)
+		for _, occurrence := range parsed.Occurrences {
+			if _, ok := keys[occurrence.Symbol]; ok {
+				continue
+			}
+			keys[occurrence.Symbol] = SymbolKey(occurrence.Symbol)
+			symbols = append(symbols, &Symbol{
+				RepoID:        index.RepoID,
+				IndexID:       index.ID,
+				Key:           keys[occurrence.Symbol],
+				Symbol:        occurrence.Symbol,
+				Documentation: parsed.Documentation[occurrence.Symbol],
+			})
+			if len(symbols) == insertBatchSize {
+				if err := db.Insert(ctx, symbols); err != nil {
+					return err
+				}
+				symbols = symbols[:0]
+			}
+		}
+		if len(symbols) > 0 {
+			if err := db.Insert(ctx, symbols); err != nil {
+				return err
+			}
+		}
+
+		occurrences := make([]*Occurrence, 0, insertBatchSize)
+		for _, occurrence := range parsed.Occurrences {
+			occurrences = append(occurrences, &Occurrence{
+				RepoID:       index.RepoID,
+				IndexID:      index.ID,
+				Path:         occurrence.Path,
+				Line:         occurrence.Line,
+				StartChar:    occurrence.StartChar,
+				EndLine:      occurrence.EndLine,
+				EndChar:      occurrence.EndChar,
+				SymbolKey:    keys[occurrence.Symbol],
+				IsDefinition: occurrence.IsDefinition,
+			})
+			if len(occurrences) == insertBatchSize {
+				if err := db.Insert(ctx, occurrences); err != nil {
+					return err
+				}
+				occurrences = occurrences[:0]
+			}
+		}
+		if len(occurrences) > 0 {
+			if err := db.Insert(ctx, occurrences); err != nil {
+				return err
+			}
+		}
+
+		if keep <= 0 {
+			return nil
+		}
+		var outdated []int64
+		if err := db.GetEngine(ctx).Table("code_nav_index").Cols("id").Where("repo_id = ?", index.RepoID).
+			Desc("id").Limit(1000, keep).Find(&outdated); err != nil {
+			return err
+		}
+		return deleteIndexes(ctx, index.RepoID, outdated)
+	})
+}
+
+// DeleteIndex deletes the index of a commit
+func DeleteIndex(ctx context.Context, index *Index) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		return deleteIndexes(ctx, index.RepoID, []int64{index.ID})
+	})
+}
+
+// GetOccurrenceAt returns the innermost occurrence at a position of a file, the character is counted like in the index
+func GetOccurrenceAt(ctx context.Context, index *Index, path string, line, character int) (*Occurrence, bool, error) {
+	var candidates []*Occurrence
+	if err := db.GetEngine(ctx).Where(builder.Eq{"index_id": index.ID, "path": path}).
+		And(builder.Lte{"line": line}).And(builder.Gte{"end_line": line}).Find(&candidates); err != nil {
+		return nil, false, err
+	}
+	var found *Occurrence
+	for _, candidate := range candidates {
+		if !candidate.Range().Contains(line, character) {
+			continue
+		}
+		// the occurrences can be nested, e.g. a definition spanning a whole function
+		if found == nil || candidate.Line > found.Line || candidate.Line == found.Line && candidate.StartChar > found.StartChar {
+			found = candidate
+		}
+	}
+	return found, found != nil, nil
+}
+
+// GetSymbol returns a symbol of an index
+func GetSymbol(ctx context.Context, index *Index, key string) (*Symbol, error) {
+	symbol, has, err := db.Get[Symbol](ctx, builder.Eq{"index_id": index.ID, "key": key})
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, fmt.Errorf("code navigation symbol %s: %w", key, util.ErrNotExist)
+	}
+	return symbol, nil
+}
+
+// FindOccurrencesOptions are the options to find the occurrences of a symbol
+type FindOccurrencesOptions struct {
+	db.ListOptions
+	IndexID      int64
+	SymbolKey    string
+	IsDefinition optional.Option[bool]
+}
+
+func (opts FindOccurrencesOptions) ToConds() builder.Cond {
+	cond := builder.Eq{"index_id": opts.IndexID, "symbol_key": opts.SymbolKey}
+	if opts.IsDefinition.Has() {
+		cond["is_definition"] = opts.IsDefinition.Value()
+	}
+	return cond
+}
+
+func (opts FindOccurrencesOptions) ToOrders() string {
+	return "path, line, start_char"
+}
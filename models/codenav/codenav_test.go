@@ -0,0 +1,85 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package codenav
+
+import (
+	"testing"
+
+	"gitea.dev/models/db"
+	"gitea.dev/models/unittest"
+	"gitea.dev/modules/codenav"
+	"gitea.dev/modules/optional"
+	"gitea.dev/modules/util"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testIndex() *codenav.Index {
+	return &codenav.Index{
+		Format: codenav.FormatSCIP,
+		Occurrences: []*codenav.Occurrence{
+			{Path: "main.go", Range: codenav.Range{Line: 2, StartChar: 0, EndLine: 6, EndChar: 1}, Symbol: "main()", IsDefinition: true},
+			{Path: "main.go", Range: codenav.Range{Line: 3, StartChar: 1, EndLine: 3, EndChar: 4}, Symbol: "foo()"},
+			{Path: "foo.go", Range: codenav.Range{Line: 1, StartChar: 5, EndLine: 1, EndChar: 8}, Symbol: "foo()", IsDefinition: true},
+		},
+		Documentation: map[string]string{"foo()": "Foo does nothing"},
+	}
+}
+
+func TestSaveIndex(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	index := &Index{RepoID: 1, CommitSHA: "65f1bf27bc3bf70f64657658635e66094edbcb4d"}
+	require.NoError(t, SaveIndex(t.Context(), index, testIndex(), 0))
+	assert.EqualValues(t, 3, index.Occurrences)
+	assert.Equal(t, codenav.FormatSCIP, index.Format)
+
+	// the innermost occurrence is found
+	occurrence, has, err := GetOccurrenceAt(t.Context(), index, "main.go", 3, 2)
+	require.NoError(t, err)
+	require.True(t, has)
+	assert.Equal(t, SymbolKey("foo()"), occurrence.SymbolKey)
+	occurrence, has, err = GetOccurrenceAt(t.Context(), index, "main.go", 3, 4)
+	require.NoError(t, err)
+	require.True(t, has)
+	assert.Equal(t, SymbolKey("main()"), occurrence.SymbolKey)
+	_, has, err = GetOccurrenceAt(t.Context(), index, "main.go", 7, 0)
+	require.NoError(t, err)
+	assert.False(t, has)
+
+	symbol, err := GetSymbol(t.Context(), index, SymbolKey("foo()"))
+	require.NoError(t, err)
+	assert.Equal(t, "Foo does nothing", symbol.Documentation)
+
+	definitions, err := db.Find[Occurrence](t.Context(), FindOccurrencesOptions{IndexID: index.ID, SymbolKey: SymbolKey("foo()"), IsDefinition: optional.Some(true)})
+	require.NoError(t, err)
+	if assert.Len(t, definitions, 1) {
+		assert.Equal(t, "foo.go", definitions[0].Path)
+	}
+	occurrences, err := db.Find[Occurrence](t.Context(), FindOccurrencesOptions{IndexID: index.ID, SymbolKey: SymbolKey("foo()")})
+	require.NoError(t, err)
+	assert.Len(t, occurrences, 2)
+
+	// the index of a commit is replaced
+	replaced := &Index{RepoID: 1, CommitSHA: index.CommitSHA}
+	require.NoError(t, SaveIndex(t.Context(), replaced, testIndex(), 0))
+	_, err = GetIndex(t.Context(), 1, index.CommitSHA)
+	require.NoError(t, err)
+	unittest.AssertNotExistsBean(t, &Index{ID: index.ID})
+	unittest.AssertCount(t, &Occurrence{RepoID: 1}, 3)
+
+	// only the latest indexes are kept
+	newer := &Index{RepoID: 1, CommitSHA: "2a47ca4b614a9f5a43abbd5ad851a54a616ffee6"}
+	require.NoError(t, SaveIndex(t.Context(), newer, testIndex(), 1))
+	_, err = GetIndex(t.Context(), 1, index.CommitSHA)
+	assert.ErrorIs(t, err, util.ErrNotExist)
+	unittest.AssertCount(t, &Symbol{RepoID: 1}, 2)
+
+	require.NoError(t, DeleteIndex(t.Context(), newer))
+	has, err = ExistIndex(t.Context(), 1, newer.CommitSHA)
+	require.NoError(t, err)
+	assert.False(t, has)
+	unittest.AssertCount(t, &Occurrence{RepoID: 1}, 0)
+}
@@ -9,6 +9,7 @@ import (
 	"slices"
 	"strings"
 
+	"gitea.dev/models/audit"
 	"gitea.dev/models/db"
 	"gitea.dev/models/organization"
 	"gitea.dev/models/perm"
@@ -471,6 +472,7 @@ func UpdateProtectBranch(ctx context.Context, repo *repo_model.Repository, prote
 		if _, err = db.GetEngine(ctx).Insert(protectBranch); err != nil {
 			return fmt.Errorf("Insert: %v", err)
 		}
+		recordProtectedBranchEvent(ctx, audit.ActionProtectedBranchCreate, repo, protectBranch)
 		return nil
 	}
 
@@ -478,10 +480,22 @@ func UpdateProtectBranch(ctx context.Context, repo *repo_model.Repository, prote
 	if _, err = db.GetEngine(ctx).ID(protectBranch.ID).AllCols().Update(protectBranch); err != nil {
 		return fmt.Errorf("Update: %v", err)
 	}
+	recordProtectedBranchEvent(ctx, audit.ActionProtectedBranchUpdate, repo, protectBranch)
 
 	return nil
 }
 
+func recordProtectedBranchEvent(ctx context.Context, action audit.Action, repo *repo_model.Repository, protectBranch *ProtectedBranch) {
+	audit.Record(ctx, &audit.Event{
+		Action:     action,
+		OwnerID:    repo.OwnerID,
+		RepoID:     repo.ID,
+		TargetType: audit.TargetProtectedBranch,
+		TargetID:   protectBranch.ID,
+		TargetName: protectBranch.RuleName,
+	})
+}
+
 func UpdateProtectBranchPriorities(ctx context.Context, repo *repo_model.Repository, ids []int64) error {
 	prio := int64(1)
 	return db.WithTx(ctx, func(ctx context.Context) error {
@@ -582,16 +596,19 @@ func DeleteProtectedBranch(ctx context.Context, repo *repo_model.Repository, id
 		return err
 	}
 
-	protectedBranch := &ProtectedBranch{
-		RepoID: repo.ID,
-		ID:     id,
+	protectedBranch, err := GetProtectedBranchRuleByID(ctx, repo.ID, id)
+	if err != nil {
+		return err
+	} else if protectedBranch == nil {
+		return fmt.Errorf("delete protected branch ID(%v) failed", id)
 	}
 
-	if affected, err := db.GetEngine(ctx).Delete(protectedBranch); err != nil {
+	if affected, err := db.GetEngine(ctx).Delete(&ProtectedBranch{RepoID: repo.ID, ID: id}); err != nil {
 		return err
 	} else if affected != 1 {
 		return fmt.Errorf("delete protected branch ID(%v) failed", id)
 	}
+	recordProtectedBranchEvent(ctx, audit.ActionProtectedBranchDelete, repo, protectedBranch)
 
 	return nil
 }
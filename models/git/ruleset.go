@@ -0,0 +1,378 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package git
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"gitea.dev/models/db"
+	repo_model "gitea.dev/models/repo"
+	"gitea.dev/modules/git"
+	"gitea.dev/modules/glob"
+	"gitea.dev/modules/timeutil"
+	"gitea.dev/modules/util"
+
+	"xorm.io/builder"
+)
+
+// RulesetTarget is the kind of refs a ruleset applies to
+type RulesetTarget int
+
+const (
+	RulesetTargetBranch RulesetTarget = iota + 1 // 1
+	RulesetTargetTag                             // 2
+)
+
+var rulesetTargetNames = map[RulesetTarget]string{
+	RulesetTargetBranch: "branch",
+	RulesetTargetTag:    "tag",
+}
+
+func (t RulesetTarget) String() string {
+	return rulesetTargetNames[t]
+}
+
+// RulesetTargetFromString returns the target with the given name
+func RulesetTargetFromString(s string) (RulesetTarget, bool) {
+	for t, name := range rulesetTargetNames {
+		if name == s {
+			return t, true
+		}
+	}
+	return 0, false
+}
+
+// RulesetEnforcement tells how the violations of a ruleset are handled
+type RulesetEnforcement int
+
+const (
+	RulesetEnforcementDisabled RulesetEnforcement = iota // 0, the ruleset is not evaluated
+	RulesetEnforcementActive                             // 1, violations are rejected
+	RulesetEnforcementEvaluate                           // 2, violations are only logged
+)
+
+var rulesetEnforcementNames = map[RulesetEnforcement]string{
+	RulesetEnforcementDisabled: "disabled",
+	RulesetEnforcementActive:   "active",
+	RulesetEnforcementEvaluate: "evaluate",
+}
+
+func (e RulesetEnforcement) String() string {
+	return rulesetEnforcementNames[e]
+}
+
+// RulesetEnforcementFromString returns the enforcement with the given name
+func RulesetEnforcementFromString(s string) (RulesetEnforcement, bool) {
+	for e, name := range rulesetEnforcementNames {
+		if name == s {
+			return e, true
+		}
+	}
+	return 0, false
+}
+
+const (
+	// RulesetRefDefaultBranch can be used in the ref patterns of a ruleset to target the default branch of each repository
+	RulesetRefDefaultBranch = "~DEFAULT_BRANCH"
+	// RulesetRefAll can be used in the ref patterns of a ruleset to target all the refs
+	RulesetRefAll = "~ALL"
+)
+
+// RulesetRules are the requirements of a ruleset, a ref matched by several rulesets has to satisfy all of them
+type RulesetRules struct {
+	// Deletion prevents deleting the matched refs
+	Deletion bool `json:"deletion,omitempty"`
+	// NonFastForward prevents force pushes to the matched refs
+	NonFastForward bool `json:"non_fast_forward,omitempty"`
+	// RequiredLinearHistory prevents merge commits from being pushed
+	RequiredLinearHistory bool `json:"required_linear_history,omitempty"`
+	// RequiredStatusChecks are the glob patterns of the commit status contexts which must succeed
+	RequiredStatusChecks []string `json:"required_status_checks,omitempty"`
+	// RequiredSignatures requires the pushed commits to be signed with a verified key
+	RequiredSignatures bool `json:"required_signatures,omitempty"`
+	// BlockedFilePaths are the glob patterns of the files the pushed commits must not change
+	BlockedFilePaths []string `json:"blocked_file_paths,omitempty"`
+	// CommitMessagePattern is a regular expression the messages of the pushed commits must match
+	CommitMessagePattern string `json:"commit_message_pattern,omitempty"`
+	// MaxFileSize is the largest file in bytes the pushed commits may add, 0 means no limit
+	MaxFileSize int64 `json:"max_file_size,omitempty"`
+}
+
+// Ruleset is a set of rules applied to the branches or tags of a repository,
+// or of all the repositories of an organization when OwnerID is set instead of RepoID.
+type Ruleset struct {
+	ID          int64              `xorm:"pk autoincr"`
+	OwnerID     int64              `xorm:"INDEX NOT NULL DEFAULT 0"`
+	RepoID      int64              `xorm:"INDEX NOT NULL DEFAULT 0"`
+	Name        string             `xorm:"NOT NULL"`
+	Target      RulesetTarget      `xorm:"NOT NULL DEFAULT 1"`
+	Enforcement RulesetEnforcement `xorm:"NOT NULL DEFAULT 0"`
+	// IncludeRefs and ExcludeRefs are glob patterns of branch or tag names
+	IncludeRefs []string     `xorm:"JSON TEXT"`
+	ExcludeRefs []string     `xorm:"JSON TEXT"`
+	Rules       RulesetRules `xorm:"JSON TEXT"`
+
+	includeGlobs []glob.Glob    `xorm:"-"`
+	excludeGlobs []glob.Glob    `xorm:"-"`
+	blockedGlobs []glob.Glob    `xorm:"-"`
+	messageRegex *regexp.Regexp `xorm:"-"`
+	compileErr   error          `xorm:"-"`
+
+	CreatedUnix timeutil.TimeStamp `xorm:"created"`
+	UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
+}
+
+func init() {
+	db.RegisterModel(new(Ruleset))
+}
+
+// IsOrgLevel returns true if the ruleset applies to all the repositories of its owner
+func (rs *Ruleset) IsOrgLevel() bool {
+	return rs.RepoID == 0
+}
+
+// IsEnforced returns true if the violations of the ruleset are rejected
+func (rs *Ruleset) IsEnforced() bool {
+	return rs.Enforcement == RulesetEnforcementActive
+}
+
+func compileRefPatterns(patterns []string) ([]glob.Glob, error) {
+	globs := make([]glob.Glob, 0, len(patterns))
+	for _, pattern := range patterns {
+		if pattern == RulesetRefDefaultBranch || pattern == RulesetRefAll {
+			globs = append(globs, nil)
+			continue
+		}
+		g, err := glob.Compile(pattern, '/')
+		if err != nil {
+			return nil, fmt.Errorf("invalid ref pattern %q: %w", pattern, err)
+		}
+		globs = append(globs, g)
+	}
+	return globs, nil
+}
+
+// compile compiles the patterns of the ruleset, it is done once as the rulesets are loaded for each push
+func (rs *Ruleset) compile() error {
+	if rs.includeGlobs == nil && rs.compileErr == nil {
+		rs.compileErr = rs.compilePatterns()
+	}
+	return rs.compileErr
+}
+
+func (rs *Ruleset) compilePatterns() (err error) {
+	if rs.excludeGlobs, err = compileRefPatterns(rs.ExcludeRefs); err != nil {
+		return err
+	}
+	rs.blockedGlobs = make([]glob.Glob, 0, len(rs.Rules.BlockedFilePaths))
+	for _, pattern := range rs.Rules.BlockedFilePaths {
+		g, err := glob.Compile(strings.ToLower(pattern), '/')
+		if err != nil {
+			return fmt.Errorf("invalid blocked file path %q: %w", pattern, err)
+		}
+		rs.blockedGlobs = append(rs.blockedGlobs, g)
+	}
+	if rs.Rules.CommitMessagePattern != "" {
+		if rs.messageRegex, err = regexp.Compile(rs.Rules.CommitMessagePattern); err != nil {
+			return fmt.Errorf("invalid commit message pattern: %w", err)
+		}
+	}
+	if rs.includeGlobs, err = compileRefPatterns(rs.IncludeRefs); err != nil {
+		return err
+	}
+	return nil
+}
+
+// PatternError returns the error of the patterns of the ruleset which can't be compiled. The patterns are validated when
+// the ruleset is saved, a ruleset saved before a pattern became invalid still applies to all the refs of its target
+// and its rules fail, so it can't be bypassed.
+func (rs *Ruleset) PatternError() error {
+	return rs.compile()
+}
+
+func matchRefPatterns(patterns []string, globs []glob.Glob, name, defaultBranch string) bool {
+	for i, g := range globs {
+		switch {
+		case patterns[i] == RulesetRefAll:
+			return true
+		case patterns[i] == RulesetRefDefaultBranch:
+			if name == defaultBranch {
+				return true
+			}
+		case g.Match(name):
+			return true
+		}
+	}
+	return false
+}
+
+// Match returns true if the ruleset applies to the ref of a repository whose default branch is given,
+// a ruleset with an invalid pattern applies to all the refs of its target
+func (rs *Ruleset) Match(refName git.RefName, defaultBranch string) bool {
+	var name string
+	switch {
+	case rs.Target == RulesetTargetBranch && refName.IsBranch():
+		name = refName.BranchName()
+	case rs.Target == RulesetTargetTag && refName.IsTag():
+		name = refName.TagName()
+		defaultBranch = ""
+	default:
+		return false
+	}
+	if err := rs.compile(); err != nil {
+		return true
+	}
+	return matchRefPatterns(rs.IncludeRefs, rs.includeGlobs, name, defaultBranch) &&
+		!matchRefPatterns(rs.ExcludeRefs, rs.excludeGlobs, name, defaultBranch)
+}
+
+// IsBlockedFilePath returns true if the rules prevent changing the file, all the files are blocked if a pattern is invalid
+func (rs *Ruleset) IsBlockedFilePath(path string) bool {
+	if err := rs.compile(); err != nil {
+		return true
+	}
+	path = strings.ToLower(path)
+	for _, g := range rs.blockedGlobs {
+		if g.Match(path) {
+			return true
+		}
+	}
+	return false
+}
+
+// MatchCommitMessage returns true if the message satisfies the commit message pattern of the rules,
+// no message does if a pattern is invalid
+func (rs *Ruleset) MatchCommitMessage(message string) bool {
+	if err := rs.compile(); err != nil {
+		return false
+	}
+	if rs.messageRegex == nil {
+		return true
+	}
+	return rs.messageRegex.MatchString(message)
+}
+
+// ValidateRuleset checks the fields of a ruleset before it is saved
+func ValidateRuleset(rs *Ruleset) error {
+	rs.Name = strings.TrimSpace(rs.Name)
+	if rs.Name == "" || len(rs.Name) > 255 {
+		return util.NewInvalidArgumentErrorf("invalid ruleset name %q", rs.Name)
+	}
+	if _, ok := rulesetTargetNames[rs.Target]; !ok {
+		return util.NewInvalidArgumentErrorf("invalid ruleset target %d", rs.Target)
+	}
+	if _, ok := rulesetEnforcementNames[rs.Enforcement]; !ok {
+		return util.NewInvalidArgumentErrorf("invalid ruleset enforcement %d", rs.Enforcement)
+	}
+	if len(rs.IncludeRefs) == 0 {
+		return util.NewInvalidArgumentErrorf("a ruleset has to include at least one ref pattern")
+	}
+	if rs.Rules.MaxFileSize < 0 {
+		return util.NewInvalidArgumentErrorf("invalid max file size %d", rs.Rules.MaxFileSize)
+	}
+	rs.includeGlobs, rs.compileErr = nil, nil
+	if err := rs.compile(); err != nil {
+		rs.includeGlobs, rs.compileErr = nil, nil
+		return util.NewInvalidArgumentErrorf("%v", err)
+	}
+	return nil
+}
+
+// CreateRuleset creates a repository or organization ruleset
+func CreateRuleset(ctx context.Context, rs *Ruleset) error {
+	if err := ValidateRuleset(rs); err != nil {
+		return err
+	}
+	return db.Insert(ctx, rs)
+}
+
+// UpdateRuleset updates all the fields of a ruleset
+func UpdateRuleset(ctx context.Context, rs *Ruleset) error {
+	if err := ValidateRuleset(rs); err != nil {
+		return err
+	}
+	_, err := db.GetEngine(ctx).ID(rs.ID).AllCols().Update(rs)
+	return err
+}
+
+// DeleteRuleset deletes a ruleset
+func DeleteRuleset(ctx context.Context, rs *Ruleset) error {
+	_, err := db.DeleteByID[Ruleset](ctx, rs.ID)
+	return err
+}
+
+// GetRulesetByID returns a ruleset of a repository, or an organization ruleset of the owner when repoID is 0
+func GetRulesetByID(ctx context.Context, ownerID, repoID, id int64) (*Ruleset, error) {
+	rs, has, err := db.Get[Ruleset](ctx, builder.Eq{"id": id, "owner_id": ownerID, "repo_id": repoID})
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, fmt.Errorf("ruleset with id %d: %w", id, util.ErrNotExist)
+	}
+	return rs, nil
+}
+
+type FindRulesetsOptions struct {
+	db.ListOptions
+	// OwnerID finds the organization rulesets of an owner
+	OwnerID int64
+	// RepoID finds the rulesets of a repository
+	RepoID int64
+	Target RulesetTarget
+	// OnlyEvaluated skips the disabled rulesets
+	OnlyEvaluated bool
+}
+
+func (opts FindRulesetsOptions) ToConds() builder.Cond {
+	cond := builder.NewCond()
+	if opts.RepoID > 0 {
+		cond = cond.Or(builder.Eq{"repo_id": opts.RepoID})
+	}
+	if opts.OwnerID > 0 {
+		cond = cond.Or(builder.Eq{"owner_id": opts.OwnerID, "repo_id": 0})
+	}
+	if opts.Target > 0 {
+		cond = cond.And(builder.Eq{"target": opts.Target})
+	}
+	if opts.OnlyEvaluated {
+		cond = cond.And(builder.Neq{"enforcement": RulesetEnforcementDisabled})
+	}
+	return cond
+}
+
+func (opts FindRulesetsOptions) ToOrders() string {
+	return "repo_id ASC, id ASC"
+}
+
+// GetRulesetsForRef returns the rulesets of the repository and of its owner which apply to the ref, disabled rulesets are skipped
+func GetRulesetsForRef(ctx context.Context, repo *repo_model.Repository, refName git.RefName) ([]*Ruleset, error) {
+	var target RulesetTarget
+	switch {
+	case refName.IsBranch():
+		target = RulesetTargetBranch
+	case refName.IsTag():
+		target = RulesetTargetTag
+	default:
+		return nil, nil
+	}
+	rulesets, err := db.Find[Ruleset](ctx, FindRulesetsOptions{
+		OwnerID:       repo.OwnerID,
+		RepoID:        repo.ID,
+		Target:        target,
+		OnlyEvaluated: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	matched := make([]*Ruleset, 0, len(rulesets))
+	for _, rs := range rulesets {
+		if rs.Match(refName, repo.DefaultBranch) {
+			matched = append(matched, rs)
+		}
+	}
+	return matched, nil
+}
@@ -0,0 +1,145 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package git_test
+
+import (
+	"testing"
+
+	git_model "gitea.dev/models/git"
+	repo_model "gitea.dev/models/repo"
+	"gitea.dev/models/unittest"
+	"gitea.dev/modules/git"
+	"gitea.dev/modules/util"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRulesetMatch(t *testing.T) {
+	cases := []struct {
+		target  git_model.RulesetTarget
+		include []string
+		exclude []string
+		ref     git.RefName
+		match   bool
+	}{
+		{target: git_model.RulesetTargetBranch, include: []string{"release/*"}, ref: "refs/heads/release/1.0", match: true},
+		{target: git_model.RulesetTargetBranch, include: []string{"release/*"}, ref: "refs/heads/release/1.0/fix"},
+		{target: git_model.RulesetTargetBranch, include: []string{"release/**"}, ref: "refs/heads/release/1.0/fix", match: true},
+		{target: git_model.RulesetTargetBranch, include: []string{"release/*"}, ref: "refs/tags/release/1.0"},
+		{target: git_model.RulesetTargetBranch, include: []string{"release/*"}, exclude: []string{"release/old*"}, ref: "refs/heads/release/old-1", match: false},
+		{target: git_model.RulesetTargetBranch, include: []string{git_model.RulesetRefDefaultBranch}, ref: "refs/heads/main", match: true},
+		{target: git_model.RulesetTargetBranch, include: []string{git_model.RulesetRefDefaultBranch}, ref: "refs/heads/develop"},
+		{target: git_model.RulesetTargetBranch, include: []string{git_model.RulesetRefAll}, exclude: []string{git_model.RulesetRefDefaultBranch}, ref: "refs/heads/main"},
+		{target: git_model.RulesetTargetBranch, include: []string{git_model.RulesetRefAll}, exclude: []string{git_model.RulesetRefDefaultBranch}, ref: "refs/heads/develop", match: true},
+		{target: git_model.RulesetTargetTag, include: []string{"v*"}, ref: "refs/tags/v1.0.0", match: true},
+		{target: git_model.RulesetTargetTag, include: []string{"v*"}, ref: "refs/heads/v1"},
+		{target: git_model.RulesetTargetTag, include: []string{git_model.RulesetRefDefaultBranch}, ref: "refs/tags/main"},
+	}
+	for _, c := range cases {
+		rs := &git_model.Ruleset{Target: c.target, IncludeRefs: c.include, ExcludeRefs: c.exclude}
+		assert.Equal(t, c.match, rs.Match(c.ref, "main"), "include %v exclude %v ref %s", c.include, c.exclude, c.ref)
+	}
+}
+
+func TestRulesetRules(t *testing.T) {
+	rs := &git_model.Ruleset{
+		Target:      git_model.RulesetTargetBranch,
+		IncludeRefs: []string{"main"},
+		Rules: git_model.RulesetRules{
+			BlockedFilePaths:     []string{"*.exe", "secrets/**"},
+			CommitMessagePattern: `^(feat|fix): `,
+		},
+	}
+	assert.True(t, rs.IsBlockedFilePath("tool.EXE"))
+	assert.True(t, rs.IsBlockedFilePath("secrets/prod/key.pem"))
+	assert.False(t, rs.IsBlockedFilePath("bin/tool.exe"))
+	assert.False(t, rs.IsBlockedFilePath("README.md"))
+
+	assert.True(t, rs.MatchCommitMessage("fix: typo"))
+	assert.False(t, rs.MatchCommitMessage("typo"))
+	assert.True(t, (&git_model.Ruleset{}).MatchCommitMessage("anything"))
+	require.NoError(t, rs.PatternError())
+
+	// a ruleset whose patterns can't be compiled anymore is violated by any update of the refs of its target
+	for _, invalidate := range []func(rs *git_model.Ruleset){
+		func(rs *git_model.Ruleset) { rs.IncludeRefs = []string{"[main"} },
+		func(rs *git_model.Ruleset) { rs.ExcludeRefs = []string{"[main"} },
+		func(rs *git_model.Ruleset) { rs.Rules.BlockedFilePaths = []string{"[a"} },
+		func(rs *git_model.Ruleset) { rs.Rules.CommitMessagePattern = "(" },
+	} {
+		rs := &git_model.Ruleset{Target: git_model.RulesetTargetBranch, IncludeRefs: []string{"main"}}
+		invalidate(rs)
+		assert.Error(t, rs.PatternError())
+		assert.True(t, rs.Match("refs/heads/feature", "main"))
+		assert.False(t, rs.Match("refs/tags/v1.0", "main"))
+		assert.True(t, rs.IsBlockedFilePath("README.md"))
+		assert.False(t, rs.MatchCommitMessage("fix: typo"))
+	}
+}
+
+func TestValidateRuleset(t *testing.T) {
+	valid := func() *git_model.Ruleset {
+		return &git_model.Ruleset{Name: " main ", Target: git_model.RulesetTargetBranch, Enforcement: git_model.RulesetEnforcementActive, IncludeRefs: []string{"main"}}
+	}
+	rs := valid()
+	require.NoError(t, git_model.ValidateRuleset(rs))
+	assert.Equal(t, "main", rs.Name)
+
+	for _, invalidate := range []func(rs *git_model.Ruleset){
+		func(rs *git_model.Ruleset) { rs.Name = " " },
+		func(rs *git_model.Ruleset) { rs.Target = 0 },
+		func(rs *git_model.Ruleset) { rs.Enforcement = 5 },
+		func(rs *git_model.Ruleset) { rs.IncludeRefs = nil },
+		func(rs *git_model.Ruleset) { rs.IncludeRefs = []string{"[main"} },
+		func(rs *git_model.Ruleset) { rs.ExcludeRefs = []string{"[main"} },
+		func(rs *git_model.Ruleset) { rs.Rules.BlockedFilePaths = []string{"[a"} },
+		func(rs *git_model.Ruleset) { rs.Rules.CommitMessagePattern = "(" },
+		func(rs *git_model.Ruleset) { rs.Rules.MaxFileSize = -1 },
+	} {
+		rs := valid()
+		invalidate(rs)
+		assert.ErrorIs(t, git_model.ValidateRuleset(rs), util.ErrInvalidArgument)
+	}
+}
+
+func TestGetRulesetsForRef(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+	ctx := t.Context()
+
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 3})
+	orgRuleset := &git_model.Ruleset{OwnerID: repo.OwnerID, Name: "org", Target: git_model.RulesetTargetBranch, Enforcement: git_model.RulesetEnforcementEvaluate, IncludeRefs: []string{git_model.RulesetRefDefaultBranch}}
+	repoRuleset := &git_model.Ruleset{RepoID: repo.ID, Name: "repo", Target: git_model.RulesetTargetBranch, Enforcement: git_model.RulesetEnforcementActive, IncludeRefs: []string{"*"}}
+	disabled := &git_model.Ruleset{RepoID: repo.ID, Name: "disabled", Target: git_model.RulesetTargetBranch, Enforcement: git_model.RulesetEnforcementDisabled, IncludeRefs: []string{"*"}}
+	otherRepo := &git_model.Ruleset{RepoID: 1, Name: "other", Target: git_model.RulesetTargetBranch, Enforcement: git_model.RulesetEnforcementActive, IncludeRefs: []string{"*"}}
+	tags := &git_model.Ruleset{RepoID: repo.ID, Name: "tags", Target: git_model.RulesetTargetTag, Enforcement: git_model.RulesetEnforcementActive, IncludeRefs: []string{"*"}}
+	for _, rs := range []*git_model.Ruleset{orgRuleset, repoRuleset, disabled, otherRepo, tags} {
+		require.NoError(t, git_model.CreateRuleset(ctx, rs))
+	}
+
+	rulesets, err := git_model.GetRulesetsForRef(ctx, repo, git.RefNameFromBranch(repo.DefaultBranch))
+	require.NoError(t, err)
+	if assert.Len(t, rulesets, 2) {
+		assert.Equal(t, orgRuleset.ID, rulesets[0].ID)
+		assert.Equal(t, repoRuleset.ID, rulesets[1].ID)
+	}
+
+	rulesets, err = git_model.GetRulesetsForRef(ctx, repo, git.RefNameFromBranch("feature"))
+	require.NoError(t, err)
+	if assert.Len(t, rulesets, 1) {
+		assert.Equal(t, repoRuleset.ID, rulesets[0].ID)
+	}
+
+	rulesets, err = git_model.GetRulesetsForRef(ctx, repo, git.RefNameFromTag("v1.0"))
+	require.NoError(t, err)
+	if assert.Len(t, rulesets, 1) {
+		assert.Equal(t, tags.ID, rulesets[0].ID)
+	}
+
+	_, err = git_model.GetRulesetByID(ctx, 0, repo.ID, orgRuleset.ID)
+	assert.ErrorIs(t, err, util.ErrNotExist, "an organization ruleset is not a repository ruleset")
+	rs, err := git_model.GetRulesetByID(ctx, repo.OwnerID, 0, orgRuleset.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []string{git_model.RulesetRefDefaultBranch}, rs.IncludeRefs)
+}
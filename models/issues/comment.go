@@ -120,6 +120,11 @@ const (
 	CommentTypeUnpin // 37 unpin Issue/PullRequest
 
 	CommentTypeChangeTimeEstimate // 38 Change time estimate
+
+	CommentTypeAddSubIssue       // 39 Sub-issue added
+	CommentTypeRemoveSubIssue    // 40 Sub-issue removed
+	CommentTypeAddParentIssue    // 41 Parent issue added
+	CommentTypeRemoveParentIssue // 42 Parent issue removed
 )
 
 var commentStrings = []string{
@@ -162,6 +167,10 @@ var commentStrings = []string{
 	"pin",
 	"unpin",
 	"change_time_estimate",
+	"add_sub_issue",
+	"remove_sub_issue",
+	"add_parent_issue",
+	"remove_parent_issue",
 }
 
 func (t CommentType) String() string {
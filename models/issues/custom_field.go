@@ -0,0 +1,428 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package issues
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"gitea.dev/models/db"
+	repo_model "gitea.dev/models/repo"
+	"gitea.dev/modules/container"
+	"gitea.dev/modules/timeutil"
+	"gitea.dev/modules/util"
+
+	"xorm.io/builder"
+)
+
+// CustomFieldType is the type of the values of a custom field
+type CustomFieldType string
+
+const (
+	CustomFieldTypeText         CustomFieldType = "text"
+	CustomFieldTypeNumber       CustomFieldType = "number"
+	CustomFieldTypeDate         CustomFieldType = "date"
+	CustomFieldTypeSingleSelect CustomFieldType = "single_select"
+	CustomFieldTypeMultiSelect  CustomFieldType = "multi_select"
+	CustomFieldTypeUser         CustomFieldType = "user"
+)
+
+// CustomFieldDateLayout is the layout of the values of the date custom fields
+const CustomFieldDateLayout = "2006-01-02"
+
+const customFieldMaxValueLength = 255
+
+// IsValid returns true if the type is a known custom field type
+func (t CustomFieldType) IsValid() bool {
+	switch t {
+	case CustomFieldTypeText, CustomFieldTypeNumber, CustomFieldTypeDate,
+		CustomFieldTypeSingleSelect, CustomFieldTypeMultiSelect, CustomFieldTypeUser:
+		return true
+	}
+	return false
+}
+
+// HasOptions returns true if the values of the field are chosen from its options
+func (t CustomFieldType) HasOptions() bool {
+	return t == CustomFieldTypeSingleSelect || t == CustomFieldTypeMultiSelect
+}
+
+// IsSortable returns true if issues can be sorted by the value of the field,
+// a field which can have several values for an issue can't be sorted
+func (t CustomFieldType) IsSortable() bool {
+	return t == CustomFieldTypeNumber || t == CustomFieldTypeDate || t == CustomFieldTypeSingleSelect
+}
+
+// CustomField is the definition of a custom field of the issues and pull requests of a repository,
+// or of all the repositories of an organization when OwnerID is set instead of RepoID.
+type CustomField struct {
+	ID          int64           `xorm:"pk autoincr"`
+	OwnerID     int64           `xorm:"INDEX NOT NULL DEFAULT 0"`
+	RepoID      int64           `xorm:"INDEX NOT NULL DEFAULT 0"`
+	Name        string          `xorm:"NOT NULL"`
+	Description string          `xorm:"TEXT"`
+	Type        CustomFieldType `xorm:"VARCHAR(20) NOT NULL"`
+	// Options are the choices of the select fields, their order is the sort order of the field
+	Options []string `xorm:"JSON TEXT"`
+
+	CreatedUnix timeutil.TimeStamp `xorm:"created"`
+	UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
+}
+
+// TableName sets the table name of the custom fields
+func (CustomField) TableName() string {
+	return "issue_custom_field"
+}
+
+// IssueCustomFieldValue is a value of a custom field of an issue, a multi select field has one row per selected option
+type IssueCustomFieldValue struct {
+	ID      int64  `xorm:"pk autoincr"`
+	IssueID int64  `xorm:"INDEX NOT NULL"`
+	FieldID int64  `xorm:"INDEX NOT NULL"`
+	Value   string `xorm:"VARCHAR(255) NOT NULL"`
+	// Number is the sort key of the value: the number, the unix time of the date or the index of the option
+	Number float64 `xorm:"NOT NULL DEFAULT 0"`
+}
+
+// TableName sets the table name of the custom field values
+func (IssueCustomFieldValue) TableName() string {
+	return "issue_custom_field_value"
+}
+
+func init() {
+	db.RegisterModel(new(CustomField))
+	db.RegisterModel(new(IssueCustomFieldValue))
+}
+
+// IsOrgLevel returns true if the field applies to all the repositories of its owner
+func (f *CustomField) IsOrgLevel() bool {
+	return f.RepoID == 0
+}
+
+// NormalizeValues checks the values to be set for the field and returns them in their canonical form.
+// The users of a user field are only checked to be IDs, their existence is checked by the caller.
+func (f *CustomField) NormalizeValues(values []string) ([]*IssueCustomFieldValue, error) {
+	if len(values) > 1 && f.Type != CustomFieldTypeMultiSelect {
+		return nil, util.NewInvalidArgumentErrorf("custom field %q only accepts one value", f.Name)
+	}
+
+	res := make([]*IssueCustomFieldValue, 0, len(values))
+	seen := make(container.Set[string], len(values))
+	for _, v := range values {
+		v = strings.TrimSpace(v)
+		if v == "" {
+			continue
+		}
+		value := &IssueCustomFieldValue{FieldID: f.ID}
+		switch f.Type {
+		case CustomFieldTypeText:
+			if utf8.RuneCountInString(v) > customFieldMaxValueLength {
+				return nil, util.NewInvalidArgumentErrorf("value of custom field %q is longer than %d characters", f.Name, customFieldMaxValueLength)
+			}
+			value.Value = v
+		case CustomFieldTypeNumber:
+			n, err := strconv.ParseFloat(v, 64)
+			if err != nil || math.IsInf(n, 0) || math.IsNaN(n) {
+				return nil, util.NewInvalidArgumentErrorf("value %q of custom field %q is not a number", v, f.Name)
+			}
+			value.Value, value.Number = strconv.FormatFloat(n, 'f', -1, 64), n
+		case CustomFieldTypeDate:
+			d, err := time.Parse(CustomFieldDateLayout, v)
+			if err != nil {
+				return nil, util.NewInvalidArgumentErrorf("value %q of custom field %q is not a date formatted as %s", v, f.Name, CustomFieldDateLayout)
+			}
+			value.Value, value.Number = v, float64(d.Unix())
+		case CustomFieldTypeSingleSelect, CustomFieldTypeMultiSelect:
+			idx := slices.Index(f.Options, v)
+			if idx < 0 {
+				return nil, util.NewInvalidArgumentErrorf("value %q is not an option of custom field %q", v, f.Name)
+			}
+			value.Value, value.Number = v, float64(idx)
+		case CustomFieldTypeUser:
+			id, err := strconv.ParseInt(v, 10, 64)
+			if err != nil || id <= 0 {
+				return nil, util.NewInvalidArgumentErrorf("value %q of custom field %q is not a user ID", v, f.Name)
+			}
+			value.Value = strconv.FormatInt(id, 10)
+		default:
+			return nil, util.NewInvalidArgumentErrorf("invalid custom field type %q", f.Type)
+		}
+		if seen.Add(value.Value) {
+			res = append(res, value)
+		}
+	}
+	return res, nil
+}
+
+// ValidateCustomField checks the fields of a custom field before it is saved
+func ValidateCustomField(f *CustomField) error {
+	f.Name = strings.TrimSpace(f.Name)
+	if f.Name == "" || len(f.Name) > 255 {
+		return util.NewInvalidArgumentErrorf("invalid custom field name %q", f.Name)
+	}
+	if !f.Type.IsValid() {
+		return util.NewInvalidArgumentErrorf("invalid custom field type %q", f.Type)
+	}
+	if !f.Type.HasOptions() {
+		f.Options = nil
+		return nil
+	}
+	if len(f.Options) == 0 {
+		return util.NewInvalidArgumentErrorf("custom field %q needs at least one option", f.Name)
+	}
+	seen := make(container.Set[string], len(f.Options))
+	for i, option := range f.Options {
+		option = strings.TrimSpace(option)
+		if option == "" || utf8.RuneCountInString(option) > customFieldMaxValueLength {
+			return util.NewInvalidArgumentErrorf("invalid option %q of custom field %q", option, f.Name)
+		}
+		if !seen.Add(option) {
+			return util.NewInvalidArgumentErrorf("duplicated option %q of custom field %q", option, f.Name)
+		}
+		f.Options[i] = option
+	}
+	return nil
+}
+
+// CreateCustomField creates a repository or organization custom field
+func CreateCustomField(ctx context.Context, f *CustomField) error {
+	if err := ValidateCustomField(f); err != nil {
+		return err
+	}
+	exist, err := db.Exist[CustomField](ctx, builder.Eq{"owner_id": f.OwnerID, "repo_id": f.RepoID, "name": f.Name})
+	if err != nil {
+		return err
+	} else if exist {
+		return util.NewAlreadyExistErrorf("custom field %q already exists", f.Name)
+	}
+	return db.Insert(ctx, f)
+}
+
+// UpdateCustomField updates the name, the description and the options of a custom field, the type can't be changed.
+// The values of the removed options are deleted from the issues and the sort keys of the others follow the new order.
+func UpdateCustomField(ctx context.Context, f *CustomField) error {
+	if err := ValidateCustomField(f); err != nil {
+		return err
+	}
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		exist, err := db.Exist[CustomField](ctx, builder.Eq{"owner_id": f.OwnerID, "repo_id": f.RepoID, "name": f.Name}.And(builder.Neq{"id": f.ID}))
+		if err != nil {
+			return err
+		} else if exist {
+			return util.NewAlreadyExistErrorf("custom field %q already exists", f.Name)
+		}
+		if _, err := db.GetEngine(ctx).ID(f.ID).Cols("name", "description", "options").Update(f); err != nil {
+			return err
+		}
+		if !f.Type.HasOptions() {
+			return nil
+		}
+		if _, err := db.GetEngine(ctx).Where(builder.Eq{"field_id": f.ID}.And(builder.NotIn("value", f.Options))).
+			Delete(new(IssueCustomFieldValue)); err != nil {
+			return err
+		}
+		for i, option := range f.Options {
+			if _, err := db.GetEngine(ctx).Where(builder.Eq{"field_id": f.ID, "value": option}).
+				Cols("number").Update(&IssueCustomFieldValue{Number: float64(i)}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// DeleteCustomField deletes a custom field and its values
+func DeleteCustomField(ctx context.Context, f *CustomField) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		if _, err := db.GetEngine(ctx).Where("field_id = ?", f.ID).Delete(new(IssueCustomFieldValue)); err != nil {
+			return err
+		}
+		_, err := db.DeleteByID[CustomField](ctx, f.ID)
+		return err
+	})
+}
+
+// GetCustomFieldByID returns a custom field of a repository, or an organization custom field of the owner when repoID is 0
+func GetCustomFieldByID(ctx context.Context, ownerID, repoID, id int64) (*CustomField, error) {
+	f, has, err := db.Get[CustomField](ctx, builder.Eq{"id": id, "owner_id": ownerID, "repo_id": repoID})
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, fmt.Errorf("custom field with id %d: %w", id, util.ErrNotExist)
+	}
+	return f, nil
+}
+
+// GetCustomFieldByIDForRepo returns a custom field applying to the issues of a repository,
+// it is either a field of the repository or of its owner
+func GetCustomFieldByIDForRepo(ctx context.Context, repo *repo_model.Repository, id int64) (*CustomField, error) {
+	f, has, err := db.Get[CustomField](ctx, builder.Eq{"id": id}.And(FindCustomFieldsOptions{OwnerID: repo.OwnerID, RepoID: repo.ID}.ToConds()))
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, fmt.Errorf("custom field with id %d: %w", id, util.ErrNotExist)
+	}
+	return f, nil
+}
+
+type FindCustomFieldsOptions struct {
+	db.ListOptions
+	// OwnerID finds the organization custom fields of an owner
+	OwnerID int64
+	// RepoID finds the custom fields of a repository
+	RepoID int64
+}
+
+func (opts FindCustomFieldsOptions) ToConds() builder.Cond {
+	cond := builder.NewCond()
+	if opts.RepoID > 0 {
+		cond = cond.Or(builder.Eq{"repo_id": opts.RepoID})
+	}
+	if opts.OwnerID > 0 {
+		cond = cond.Or(builder.Eq{"owner_id": opts.OwnerID, "repo_id": 0})
+	}
+	return cond
+}
+
+func (opts FindCustomFieldsOptions) ToOrders() string {
+	return "repo_id ASC, id ASC"
+}
+
+// GetCustomFieldsForRepo returns the custom fields applying to the issues of a repository, the organization ones first
+func GetCustomFieldsForRepo(ctx context.Context, repo *repo_model.Repository) ([]*CustomField, error) {
+	return db.Find[CustomField](ctx, FindCustomFieldsOptions{
+		ListOptions: db.ListOptionsAll,
+		OwnerID:     repo.OwnerID,
+		RepoID:      repo.ID,
+	})
+}
+
+// GetIssueCustomFieldValues returns the custom field values of an issue, ordered by field and sort key
+func GetIssueCustomFieldValues(ctx context.Context, issueID int64) ([]*IssueCustomFieldValue, error) {
+	values := make([]*IssueCustomFieldValue, 0, 5)
+	return values, db.GetEngine(ctx).Where("issue_id = ?", issueID).
+		OrderBy("field_id ASC, number ASC, id ASC").Find(&values)
+}
+
+// GetIssueIDsByCustomField returns the IDs of the issues having a value for a custom field
+func GetIssueIDsByCustomField(ctx context.Context, fieldID int64) ([]int64, error) {
+	var issueIDs []int64
+	return issueIDs, db.GetEngine(ctx).Table("issue_custom_field_value").Where("field_id = ?", fieldID).
+		Distinct("issue_id").Find(&issueIDs)
+}
+
+// SetIssueCustomFieldValues replaces the values of a custom field of an issue, no values clears the field
+func SetIssueCustomFieldValues(ctx context.Context, issue *Issue, field *CustomField, values []string) error {
+	normalized, err := field.NormalizeValues(values)
+	if err != nil {
+		return err
+	}
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		if _, err := db.GetEngine(ctx).Where("issue_id = ? AND field_id = ?", issue.ID, field.ID).
+			Delete(new(IssueCustomFieldValue)); err != nil {
+			return err
+		}
+		for _, v := range normalized {
+			v.IssueID = issue.ID
+		}
+		if len(normalized) == 0 {
+			return nil
+		}
+		return db.Insert(ctx, normalized)
+	})
+}
+
+// CustomFieldFilter filters the issues having one of the values for a custom field
+type CustomFieldFilter struct {
+	FieldID int64
+	Values  []string
+}
+
+// ParseCustomFieldFilters parses the "<field id>:<value>" filters of the issue lists,
+// the values of the same field are merged as issues having any of them are matched
+func ParseCustomFieldFilters(params []string) ([]CustomFieldFilter, error) {
+	var filters []CustomFieldFilter
+	for _, param := range params {
+		idStr, value, ok := strings.Cut(param, ":")
+		if !ok {
+			return nil, util.NewInvalidArgumentErrorf("invalid custom field filter %q", param)
+		}
+		fieldID, err := strconv.ParseInt(idStr, 10, 64)
+		if err != nil || fieldID <= 0 {
+			return nil, util.NewInvalidArgumentErrorf("invalid custom field filter %q", param)
+		}
+		idx := slices.IndexFunc(filters, func(f CustomFieldFilter) bool { return f.FieldID == fieldID })
+		if idx < 0 {
+			filters = append(filters, CustomFieldFilter{FieldID: fieldID})
+			idx = len(filters) - 1
+		}
+		filters[idx].Values = append(filters[idx].Values, strings.TrimSpace(value))
+	}
+	return filters, nil
+}
+
+// NormalizeCustomFieldFilters rewrites the values of the filters in the canonical form used to store them,
+// so "1.50" matches the stored "1.5" of a number field. Values which can't be stored never match.
+func NormalizeCustomFieldFilters(fields []*CustomField, filters []CustomFieldFilter) []CustomFieldFilter {
+	res := make([]CustomFieldFilter, 0, len(filters))
+	for _, filter := range filters {
+		idx := slices.IndexFunc(fields, func(f *CustomField) bool { return f.ID == filter.FieldID })
+		if idx < 0 {
+			res = append(res, filter)
+			continue
+		}
+		normalized := CustomFieldFilter{FieldID: filter.FieldID, Values: make([]string, 0, len(filter.Values))}
+		for _, v := range filter.Values {
+			// normalize the values one by one, only the multi select fields accept several values
+			values, err := fields[idx].NormalizeValues([]string{v})
+			if err != nil || len(values) == 0 {
+				normalized.Values = append(normalized.Values, v)
+				continue
+			}
+			normalized.Values = append(normalized.Values, values[0].Value)
+		}
+		res = append(res, normalized)
+	}
+	return res
+}
+
+// CustomFieldSortPrefix is the prefix of the sort types ordering the issues by a custom field, followed by the field ID.
+// The issues are sorted by ascending values, "-desc" can be appended to sort them by descending values.
+const CustomFieldSortPrefix = "custom-field-"
+
+// CustomFieldSortType returns the sort type ordering the issues by the values of a custom field
+func CustomFieldSortType(fieldID int64, desc bool) string {
+	s := CustomFieldSortPrefix + strconv.FormatInt(fieldID, 10)
+	if desc {
+		s += "-desc"
+	}
+	return s
+}
+
+// ParseCustomFieldSortType returns the field ID and the direction of a custom field sort type
+func ParseCustomFieldSortType(sortType string) (fieldID int64, desc, ok bool) {
+	s, ok := strings.CutPrefix(sortType, CustomFieldSortPrefix)
+	if !ok {
+		return 0, false, false
+	}
+	s, desc = strings.CutSuffix(s, "-desc")
+	fieldID, err := strconv.ParseInt(s, 10, 64)
+	if err != nil || fieldID <= 0 {
+		return 0, false, false
+	}
+	return fieldID, desc, true
+}
+
+func applyCustomFieldsCondition(sess db.Session, opts *IssuesOptions) {
+	for _, filter := range opts.CustomFields {
+		sess.In("issue.id", builder.Select("issue_id").From("issue_custom_field_value").
+			Where(builder.Eq{"field_id": filter.FieldID}.And(builder.In("value", filter.Values))))
+	}
+}
@@ -0,0 +1,120 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package issues_test
+
+import (
+	"testing"
+
+	"gitea.dev/models/db"
+	issues_model "gitea.dev/models/issues"
+	repo_model "gitea.dev/models/repo"
+	"gitea.dev/models/unittest"
+	"gitea.dev/modules/util"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCustomField_NormalizeValues(t *testing.T) {
+	field := &issues_model.CustomField{Name: "severity", Type: issues_model.CustomFieldTypeSingleSelect, Options: []string{"low", "high"}}
+	values, err := field.NormalizeValues([]string{" high "})
+	require.NoError(t, err)
+	require.Len(t, values, 1)
+	assert.Equal(t, "high", values[0].Value)
+	assert.InDelta(t, 1, values[0].Number, 0)
+	_, err = field.NormalizeValues([]string{"medium"})
+	assert.ErrorIs(t, err, util.ErrInvalidArgument)
+	_, err = field.NormalizeValues([]string{"low", "high"})
+	assert.ErrorIs(t, err, util.ErrInvalidArgument)
+
+	field = &issues_model.CustomField{Name: "components", Type: issues_model.CustomFieldTypeMultiSelect, Options: []string{"api", "ui"}}
+	values, err = field.NormalizeValues([]string{"ui", "api", "ui", ""})
+	require.NoError(t, err)
+	assert.Len(t, values, 2)
+
+	field = &issues_model.CustomField{Name: "estimate", Type: issues_model.CustomFieldTypeNumber}
+	values, err = field.NormalizeValues([]string{"1.50"})
+	require.NoError(t, err)
+	assert.Equal(t, "1.5", values[0].Value)
+	_, err = field.NormalizeValues([]string{"NaN"})
+	assert.ErrorIs(t, err, util.ErrInvalidArgument)
+
+	field = &issues_model.CustomField{Name: "due version", Type: issues_model.CustomFieldTypeDate}
+	_, err = field.NormalizeValues([]string{"2026-02-30"})
+	assert.ErrorIs(t, err, util.ErrInvalidArgument)
+
+	field = &issues_model.CustomField{Name: "owner", Type: issues_model.CustomFieldTypeUser}
+	_, err = field.NormalizeValues([]string{"user2"})
+	assert.ErrorIs(t, err, util.ErrInvalidArgument)
+}
+
+func TestParseCustomFieldFilters(t *testing.T) {
+	filters, err := issues_model.ParseCustomFieldFilters([]string{"1:high", "2:a:b", "1:low"})
+	require.NoError(t, err)
+	assert.Equal(t, []issues_model.CustomFieldFilter{
+		{FieldID: 1, Values: []string{"high", "low"}},
+		{FieldID: 2, Values: []string{"a:b"}},
+	}, filters)
+
+	_, err = issues_model.ParseCustomFieldFilters([]string{"high"})
+	assert.ErrorIs(t, err, util.ErrInvalidArgument)
+
+	fieldID, desc, ok := issues_model.ParseCustomFieldSortType(issues_model.CustomFieldSortType(3, true))
+	assert.True(t, ok)
+	assert.True(t, desc)
+	assert.EqualValues(t, 3, fieldID)
+	_, _, ok = issues_model.ParseCustomFieldSortType("scope-priority")
+	assert.False(t, ok)
+}
+
+func TestIssueCustomFields(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+
+	field := &issues_model.CustomField{RepoID: repo.ID, Name: "severity", Type: issues_model.CustomFieldTypeSingleSelect, Options: []string{"low", "medium", "high"}}
+	require.NoError(t, issues_model.CreateCustomField(t.Context(), field))
+	err := issues_model.CreateCustomField(t.Context(), &issues_model.CustomField{RepoID: repo.ID, Name: "Severity", Type: issues_model.CustomFieldTypeText})
+	assert.ErrorIs(t, err, util.ErrAlreadyExist)
+
+	issue1 := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 1})
+	issue2 := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 2})
+	require.NoError(t, issues_model.SetIssueCustomFieldValues(t.Context(), issue1, field, []string{"high"}))
+	require.NoError(t, issues_model.SetIssueCustomFieldValues(t.Context(), issue2, field, []string{"low"}))
+
+	fields, err := issues_model.GetCustomFieldsForRepo(t.Context(), repo)
+	require.NoError(t, err)
+	assert.Len(t, fields, 1)
+
+	issues, err := issues_model.Issues(t.Context(), &issues_model.IssuesOptions{
+		RepoIDs:      []int64{repo.ID},
+		CustomFields: []issues_model.CustomFieldFilter{{FieldID: field.ID, Values: []string{"high", "medium"}}},
+	})
+	require.NoError(t, err)
+	require.Len(t, issues, 1)
+	assert.EqualValues(t, 1, issues[0].ID)
+
+	issues, err = issues_model.Issues(t.Context(), &issues_model.IssuesOptions{
+		RepoIDs:  []int64{repo.ID},
+		SortType: issues_model.CustomFieldSortType(field.ID, false),
+	})
+	require.NoError(t, err)
+	require.Len(t, issues, 5)
+	// the issues without value are sorted last
+	assert.EqualValues(t, 2, issues[0].ID)
+	assert.EqualValues(t, 1, issues[1].ID)
+
+	// the values of the remaining options follow their new order
+	field.Options = []string{"high", "low"}
+	require.NoError(t, issues_model.UpdateCustomField(t.Context(), field))
+	values, err := issues_model.GetIssueCustomFieldValues(t.Context(), issue2.ID)
+	require.NoError(t, err)
+	require.Len(t, values, 1)
+	assert.InDelta(t, 1, values[0].Number, 0)
+
+	require.NoError(t, issues_model.DeleteCustomField(t.Context(), field))
+	unittest.AssertNotExistsBean(t, &issues_model.IssueCustomFieldValue{FieldID: field.ID})
+	count, err := db.Count[issues_model.CustomField](t.Context(), issues_model.FindCustomFieldsOptions{RepoID: repo.ID})
+	require.NoError(t, err)
+	assert.Zero(t, count)
+}
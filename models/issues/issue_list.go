@@ -540,6 +540,10 @@ func (issues IssueList) LoadAttributes(ctx context.Context) error {
 		return fmt.Errorf("issue.loadAttributes: loadAssignees: %w", err)
 	}
 
+	if err := issues.LoadTypes(ctx); err != nil {
+		return fmt.Errorf("issue.loadAttributes: LoadTypes: %w", err)
+	}
+
 	if err := issues.LoadPullRequests(ctx); err != nil {
 		return fmt.Errorf("issue.loadAttributes: loadPullRequests: %w", err)
 	}
@@ -44,6 +44,8 @@ type IssuesOptions struct { //nolint:revive // export stutter
 	IncludedLabelNames []string
 	ExcludedLabelNames []string
 	IncludeMilestones  []string
+	CustomFields       []CustomFieldFilter // issues have one of the values of each custom field
+	TypeIDs            []int64             // issue types, db.NoConditionID alone means the issues without type
 	SortType           string
 	IssueIDs           []int64
 	UpdatedAfterUnix   int64
@@ -82,6 +84,19 @@ func applySorts(sess db.Session, sortType string, priorityRepoID int64) {
 		sess.OrderBy("COALESCE(label.exclusive_order, 2147483647) ASC").Desc("issue.id")
 		return
 	}
+	// Only the sortable custom fields can be used, they have at most one value per issue so the JOIN doesn't duplicate issues
+	if fieldID, desc, ok := ParseCustomFieldSortType(sortType); ok {
+		sess.Join("LEFT", "issue_custom_field_value", "issue.id = issue_custom_field_value.issue_id AND issue_custom_field_value.field_id = ?", fieldID)
+		// Sort the issues without a value last regardless of the direction
+		sess.OrderBy("CASE WHEN issue_custom_field_value.number IS NULL THEN 1 ELSE 0 END ASC")
+		if desc {
+			sess.Desc("issue_custom_field_value.number")
+		} else {
+			sess.Asc("issue_custom_field_value.number")
+		}
+		sess.Desc("issue.id")
+		return
+	}
 
 	switch sortType {
 	case "oldest":
@@ -228,6 +243,14 @@ func applyRepoConditions(sess db.Session, opts *IssuesOptions) {
 	}
 }
 
+func applyTypeCondition(sess db.Session, opts *IssuesOptions) {
+	if len(opts.TypeIDs) == 1 && opts.TypeIDs[0] == db.NoConditionID {
+		sess.And("issue.type_id = 0")
+	} else if len(opts.TypeIDs) > 0 {
+		sess.In("issue.type_id", opts.TypeIDs)
+	}
+}
+
 func applyConditions(sess db.Session, opts *IssuesOptions) {
 	if len(opts.IssueIDs) > 0 {
 		sess.In("issue.id", opts.IssueIDs)
@@ -279,6 +302,10 @@ func applyConditions(sess db.Session, opts *IssuesOptions) {
 
 	applyLabelsCondition(sess, opts)
 
+	applyCustomFieldsCondition(sess, opts)
+
+	applyTypeCondition(sess, opts)
+
 	if opts.Owner != nil {
 		sess.And(repo_model.UserOwnedRepoCond(opts.Owner.ID))
 	}
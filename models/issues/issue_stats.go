@@ -141,6 +141,10 @@ func applyIssuesOptions(sess db.Session, opts *IssuesOptions, issueIDs []int64)
 
 	applyLabelsCondition(sess, opts)
 
+	applyCustomFieldsCondition(sess, opts)
+
+	applyTypeCondition(sess, opts)
+
 	applyMilestoneCondition(sess, opts)
 
 	applyProjectCondition(sess, opts)
@@ -0,0 +1,274 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package issues
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"gitea.dev/models/db"
+	user_model "gitea.dev/models/user"
+	"gitea.dev/modules/timeutil"
+	"gitea.dev/modules/util"
+
+	"xorm.io/builder"
+)
+
+const (
+	// MaxSubIssues is the maximum number of sub-issues of an issue
+	MaxSubIssues = 100
+	// MaxSubIssueDepth is the maximum number of levels of a sub-issue hierarchy
+	MaxSubIssueDepth = 8
+)
+
+// IssueSubIssue represents the parent/child relationship of two issues, an issue has at most one parent
+type IssueSubIssue struct {
+	ID          int64              `xorm:"pk autoincr"`
+	ParentID    int64              `xorm:"INDEX NOT NULL"`
+	IssueID     int64              `xorm:"UNIQUE NOT NULL"`
+	Sorting     int64              `xorm:"NOT NULL DEFAULT 0"`
+	CreatedUnix timeutil.TimeStamp `xorm:"created"`
+}
+
+func init() {
+	db.RegisterModel(new(IssueSubIssue))
+}
+
+// SubIssueProgress is the progress of the sub-issues of an issue
+type SubIssueProgress struct {
+	Total  int64
+	Closed int64
+}
+
+// Percent returns the percentage of closed sub-issues
+func (p *SubIssueProgress) Percent() int {
+	if p.Total == 0 {
+		return 0
+	}
+	return int(p.Closed * 100 / p.Total)
+}
+
+// GetParentIssueID returns the ID of the parent of an issue, 0 if it has none
+func GetParentIssueID(ctx context.Context, issueID int64) (int64, error) {
+	var parentID int64
+	_, err := db.GetEngine(ctx).Table("issue_sub_issue").Where("issue_id = ?", issueID).Cols("parent_id").Get(&parentID)
+	return parentID, err
+}
+
+// GetParentIssue returns the parent of an issue
+func GetParentIssue(ctx context.Context, issueID int64) (*Issue, error) {
+	parentID, err := GetParentIssueID(ctx, issueID)
+	if err != nil {
+		return nil, err
+	} else if parentID == 0 {
+		return nil, fmt.Errorf("parent of issue %d: %w", issueID, util.ErrNotExist)
+	}
+	return GetIssueByID(ctx, parentID)
+}
+
+// GetSubIssues returns the sub-issues of an issue in their order
+func GetSubIssues(ctx context.Context, parentID int64) (IssueList, error) {
+	issues := make(IssueList, 0, 10)
+	return issues, db.GetEngine(ctx).
+		Join("INNER", "issue_sub_issue", "issue_sub_issue.issue_id = issue.id").
+		Where("issue_sub_issue.parent_id = ?", parentID).
+		OrderBy("issue_sub_issue.sorting ASC, issue_sub_issue.id ASC").
+		Find(&issues)
+}
+
+// GetSubIssueProgress returns the progress of the sub-issues of issues, the issues without sub-issues are omitted
+func GetSubIssueProgress(ctx context.Context, parentIDs ...int64) (map[int64]*SubIssueProgress, error) {
+	res := make(map[int64]*SubIssueProgress, len(parentIDs))
+	if len(parentIDs) == 0 {
+		return res, nil
+	}
+	counts := make([]struct {
+		ParentID int64
+		IsClosed bool
+		Count    int64
+	}, 0, len(parentIDs)*2)
+	if err := db.GetEngine(ctx).Table("issue_sub_issue").
+		Join("INNER", "issue", "issue.id = issue_sub_issue.issue_id").
+		In("issue_sub_issue.parent_id", parentIDs).
+		Select("issue_sub_issue.parent_id AS parent_id, issue.is_closed AS is_closed, COUNT(*) AS count").
+		GroupBy("issue_sub_issue.parent_id, issue.is_closed").
+		Find(&counts); err != nil {
+		return nil, err
+	}
+	for _, c := range counts {
+		p, ok := res[c.ParentID]
+		if !ok {
+			p = &SubIssueProgress{}
+			res[c.ParentID] = p
+		}
+		p.Total += c.Count
+		if c.IsClosed {
+			p.Closed += c.Count
+		}
+	}
+	return res, nil
+}
+
+// subIssueTreeHeight returns the number of levels of sub-issues below an issue
+func subIssueTreeHeight(ctx context.Context, issueID int64) (int, error) {
+	height := 0
+	ids := []int64{issueID}
+	for len(ids) > 0 && height <= MaxSubIssueDepth {
+		var childIDs []int64
+		if err := db.GetEngine(ctx).Table("issue_sub_issue").In("parent_id", ids).Cols("issue_id").Find(&childIDs); err != nil {
+			return 0, err
+		}
+		if len(childIDs) > 0 {
+			height++
+		}
+		ids = childIDs
+	}
+	return height, nil
+}
+
+// AddSubIssue makes an issue a sub-issue of another issue of the same owner, it is added after the other sub-issues
+func AddSubIssue(ctx context.Context, doer *user_model.User, parent, subIssue *Issue) error {
+	if parent.ID == subIssue.ID {
+		return util.NewInvalidArgumentErrorf("an issue can't be its own sub-issue")
+	}
+	if parent.IsPull || subIssue.IsPull {
+		return util.NewInvalidArgumentErrorf("pull requests can't have or be sub-issues")
+	}
+	if err := parent.LoadRepo(ctx); err != nil {
+		return err
+	}
+	if err := subIssue.LoadRepo(ctx); err != nil {
+		return err
+	}
+	if parent.Repo.OwnerID != subIssue.Repo.OwnerID {
+		return util.NewInvalidArgumentErrorf("sub-issues must belong to repositories of the same owner")
+	}
+
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		if parentID, err := GetParentIssueID(ctx, subIssue.ID); err != nil {
+			return err
+		} else if parentID != 0 {
+			return util.NewAlreadyExistErrorf("issue %d already has a parent", subIssue.ID)
+		}
+
+		count, err := db.GetEngine(ctx).Where("parent_id = ?", parent.ID).Count(new(IssueSubIssue))
+		if err != nil {
+			return err
+		} else if count >= MaxSubIssues {
+			return util.NewInvalidArgumentErrorf("an issue can't have more than %d sub-issues", MaxSubIssues)
+		}
+
+		// walk up from the parent to check the hierarchy has no cycle and is not too deep
+		depth := 1
+		for id := parent.ID; ; depth++ {
+			id, err = GetParentIssueID(ctx, id)
+			if err != nil {
+				return err
+			} else if id == 0 {
+				break
+			} else if id == subIssue.ID {
+				return util.NewInvalidArgumentErrorf("issue %d is an ancestor of issue %d", subIssue.ID, parent.ID)
+			} else if depth >= MaxSubIssueDepth {
+				return util.NewInvalidArgumentErrorf("sub-issues can't be nested more than %d levels deep", MaxSubIssueDepth)
+			}
+		}
+		height, err := subIssueTreeHeight(ctx, subIssue.ID)
+		if err != nil {
+			return err
+		}
+		if depth+height >= MaxSubIssueDepth {
+			return util.NewInvalidArgumentErrorf("sub-issues can't be nested more than %d levels deep", MaxSubIssueDepth)
+		}
+
+		var maxSorting int64
+		if _, err := db.GetEngine(ctx).Table("issue_sub_issue").Where("parent_id = ?", parent.ID).
+			Select("COALESCE(MAX(sorting), 0)").Get(&maxSorting); err != nil {
+			return err
+		}
+		if err := db.Insert(ctx, &IssueSubIssue{ParentID: parent.ID, IssueID: subIssue.ID, Sorting: maxSorting + 1}); err != nil {
+			return err
+		}
+		return createSubIssueComments(ctx, doer, parent, subIssue, true)
+	})
+}
+
+// RemoveSubIssue removes a sub-issue from its parent
+func RemoveSubIssue(ctx context.Context, doer *user_model.User, parent, subIssue *Issue) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		affected, err := db.GetEngine(ctx).Where("parent_id = ? AND issue_id = ?", parent.ID, subIssue.ID).Delete(new(IssueSubIssue))
+		if err != nil {
+			return err
+		} else if affected == 0 {
+			return fmt.Errorf("issue %d is not a sub-issue of issue %d: %w", subIssue.ID, parent.ID, util.ErrNotExist)
+		}
+		return createSubIssueComments(ctx, doer, parent, subIssue, false)
+	})
+}
+
+// ReorderSubIssues changes the order of the sub-issues of an issue, all the sub-issues must be listed
+func ReorderSubIssues(ctx context.Context, parent *Issue, subIssueIDs []int64) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		var currentIDs []int64
+		if err := db.GetEngine(ctx).Table("issue_sub_issue").Where("parent_id = ?", parent.ID).Cols("issue_id").Find(&currentIDs); err != nil {
+			return err
+		}
+		sortedIDs := slices.Clone(subIssueIDs)
+		slices.Sort(sortedIDs)
+		slices.Sort(currentIDs)
+		if !slices.Equal(sortedIDs, currentIDs) {
+			return util.NewInvalidArgumentErrorf("the order must list all the sub-issues of issue %d once", parent.ID)
+		}
+		for i, id := range subIssueIDs {
+			if _, err := db.GetEngine(ctx).Where("parent_id = ? AND issue_id = ?", parent.ID, id).
+				Cols("sorting").Update(&IssueSubIssue{Sorting: int64(i + 1)}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// DeleteCrossRepoSubIssues removes the relationships between the issues of a repository and the issues of other repositories,
+// they are no longer allowed once the repository is transferred to another owner
+func DeleteCrossRepoSubIssues(ctx context.Context, repoID int64) error {
+	repoIssueIDs := builder.Select("id").From("issue").Where(builder.Eq{"repo_id": repoID})
+	_, err := db.GetEngine(ctx).Where(
+		builder.Or(
+			builder.In("parent_id", repoIssueIDs).And(builder.NotIn("issue_id", repoIssueIDs)),
+			builder.In("issue_id", repoIssueIDs).And(builder.NotIn("parent_id", repoIssueIDs)),
+		),
+	).Delete(new(IssueSubIssue))
+	return err
+}
+
+func createSubIssueComments(ctx context.Context, doer *user_model.User, parent, subIssue *Issue, add bool) error {
+	parentType, subIssueType := CommentTypeAddSubIssue, CommentTypeAddParentIssue
+	if !add {
+		parentType, subIssueType = CommentTypeRemoveSubIssue, CommentTypeRemoveParentIssue
+	}
+	if err := parent.LoadRepo(ctx); err != nil {
+		return err
+	}
+	if err := subIssue.LoadRepo(ctx); err != nil {
+		return err
+	}
+	if _, err := CreateComment(ctx, &CreateCommentOptions{
+		Type:             parentType,
+		Doer:             doer,
+		Repo:             parent.Repo,
+		Issue:            parent,
+		DependentIssueID: subIssue.ID,
+	}); err != nil {
+		return err
+	}
+	_, err := CreateComment(ctx, &CreateCommentOptions{
+		Type:             subIssueType,
+		Doer:             doer,
+		Repo:             subIssue.Repo,
+		Issue:            subIssue,
+		DependentIssueID: parent.ID,
+	})
+	return err
+}
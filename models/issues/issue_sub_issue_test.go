@@ -0,0 +1,129 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package issues_test
+
+import (
+	"testing"
+
+	"gitea.dev/models/db"
+	issues_model "gitea.dev/models/issues"
+	"gitea.dev/models/unittest"
+	user_model "gitea.dev/models/user"
+	"gitea.dev/modules/util"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func issueListIDs(issues issues_model.IssueList) []int64 {
+	ids := make([]int64, 0, len(issues))
+	for _, issue := range issues {
+		ids = append(ids, issue.ID)
+	}
+	return ids
+}
+
+func TestSubIssueProgress_Percent(t *testing.T) {
+	assert.Equal(t, 0, (&issues_model.SubIssueProgress{}).Percent())
+	assert.Equal(t, 66, (&issues_model.SubIssueProgress{Total: 3, Closed: 2}).Percent())
+	assert.Equal(t, 100, (&issues_model.SubIssueProgress{Total: 2, Closed: 2}).Percent())
+}
+
+func TestSubIssues(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+	doer := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	getIssue := func(id int64) *issues_model.Issue {
+		return unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: id})
+	}
+	// issues 1 and 5 belong to repo1 and issues 4 and 7 to repo2, both owned by user2
+	parent := getIssue(1)
+
+	require.NoError(t, issues_model.AddSubIssue(t.Context(), doer, parent, getIssue(5)))
+	require.NoError(t, issues_model.AddSubIssue(t.Context(), doer, parent, getIssue(4)))
+	require.NoError(t, issues_model.AddSubIssue(t.Context(), doer, parent, getIssue(7)))
+	unittest.AssertExistsAndLoadBean(t, &issues_model.Comment{IssueID: 1, Type: issues_model.CommentTypeAddSubIssue, DependentIssueID: 7})
+	unittest.AssertExistsAndLoadBean(t, &issues_model.Comment{IssueID: 7, Type: issues_model.CommentTypeAddParentIssue, DependentIssueID: 1})
+
+	err := issues_model.AddSubIssue(t.Context(), doer, parent, parent)
+	assert.ErrorIs(t, err, util.ErrInvalidArgument)
+	// issue 6 belongs to a repository of another owner
+	err = issues_model.AddSubIssue(t.Context(), doer, parent, getIssue(6))
+	assert.ErrorIs(t, err, util.ErrInvalidArgument)
+	// issue 2 is a pull request
+	err = issues_model.AddSubIssue(t.Context(), doer, parent, getIssue(2))
+	assert.ErrorIs(t, err, util.ErrInvalidArgument)
+	err = issues_model.AddSubIssue(t.Context(), doer, getIssue(7), getIssue(5))
+	assert.ErrorIs(t, err, util.ErrAlreadyExist)
+	// issue 1 is the parent of issue 7
+	err = issues_model.AddSubIssue(t.Context(), doer, getIssue(7), parent)
+	assert.ErrorIs(t, err, util.ErrInvalidArgument)
+
+	parentOf7, err := issues_model.GetParentIssue(t.Context(), 7)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, parentOf7.ID)
+	_, err = issues_model.GetParentIssue(t.Context(), 1)
+	assert.ErrorIs(t, err, util.ErrNotExist)
+
+	progress, err := issues_model.GetSubIssueProgress(t.Context(), 1, 7)
+	require.NoError(t, err)
+	assert.Equal(t, map[int64]*issues_model.SubIssueProgress{1: {Total: 3, Closed: 2}}, progress)
+
+	subIssueIDs := func() []int64 {
+		subIssues, err := issues_model.GetSubIssues(t.Context(), parent.ID)
+		require.NoError(t, err)
+		return issueListIDs(subIssues)
+	}
+	assert.Equal(t, []int64{5, 4, 7}, subIssueIDs())
+	require.NoError(t, issues_model.ReorderSubIssues(t.Context(), parent, []int64{7, 5, 4}))
+	assert.Equal(t, []int64{7, 5, 4}, subIssueIDs())
+	err = issues_model.ReorderSubIssues(t.Context(), parent, []int64{7, 5})
+	assert.ErrorIs(t, err, util.ErrInvalidArgument)
+
+	require.NoError(t, issues_model.RemoveSubIssue(t.Context(), doer, parent, getIssue(7)))
+	err = issues_model.RemoveSubIssue(t.Context(), doer, parent, getIssue(7))
+	assert.ErrorIs(t, err, util.ErrNotExist)
+	assert.Equal(t, []int64{5, 4}, subIssueIDs())
+
+	// the sub-issues in repo2 are no longer allowed once repo1 moves to another owner
+	require.NoError(t, issues_model.DeleteCrossRepoSubIssues(t.Context(), 1))
+	assert.Equal(t, []int64{5}, subIssueIDs())
+}
+
+func TestIssueTypes(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+	// issue 6 belongs to repo3 of org3
+	issue := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 6})
+
+	bug := &issues_model.IssueType{OwnerID: 3, Name: " Bug ", Color: "ee0701"}
+	require.NoError(t, issues_model.CreateIssueType(t.Context(), bug))
+	assert.Equal(t, "Bug", bug.Name)
+	assert.Equal(t, "#ee0701", bug.Color)
+	err := issues_model.CreateIssueType(t.Context(), &issues_model.IssueType{OwnerID: 3, Name: "bug"})
+	assert.ErrorIs(t, err, util.ErrAlreadyExist)
+	epic := &issues_model.IssueType{OwnerID: 3, Name: "Epic", IsDisabled: true}
+	require.NoError(t, issues_model.CreateIssueType(t.Context(), epic))
+
+	err = issues_model.SetIssueType(t.Context(), issue, epic.ID)
+	assert.ErrorIs(t, err, util.ErrInvalidArgument)
+	require.NoError(t, issues_model.SetIssueType(t.Context(), issue, bug.ID))
+
+	ids, err := issues_model.GetIssueTypeIDsByNames(t.Context(), 3, []string{"BUG", "unknown"})
+	require.NoError(t, err)
+	assert.Equal(t, []int64{bug.ID}, ids)
+
+	issues, err := issues_model.Issues(t.Context(), &issues_model.IssuesOptions{RepoIDs: []int64{3}, TypeIDs: []int64{bug.ID}})
+	require.NoError(t, err)
+	assert.Equal(t, []int64{6}, issueListIDs(issues))
+	issues, err = issues_model.Issues(t.Context(), &issues_model.IssuesOptions{RepoIDs: []int64{3}, TypeIDs: []int64{db.NoConditionID}})
+	require.NoError(t, err)
+	assert.NotContains(t, issueListIDs(issues), int64(6))
+
+	count, err := db.Count[issues_model.IssueType](t.Context(), issues_model.FindIssueTypesOptions{OwnerID: 3})
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, count)
+
+	require.NoError(t, issues_model.DeleteIssueType(t.Context(), bug))
+	issue = unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 6})
+	assert.Zero(t, issue.TypeID)
+}
@@ -0,0 +1,217 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package issues
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"gitea.dev/models/db"
+	"gitea.dev/modules/container"
+	"gitea.dev/modules/label"
+	"gitea.dev/modules/timeutil"
+	"gitea.dev/modules/util"
+
+	"xorm.io/builder"
+)
+
+const issueTypeMaxNameLength = 50
+
+// IssueType is a type of the issues of the repositories of an organization, like bug, feature, task or epic
+type IssueType struct {
+	ID          int64  `xorm:"pk autoincr"`
+	OwnerID     int64  `xorm:"UNIQUE(owner_name) NOT NULL"`
+	Name        string `xorm:"NOT NULL"`
+	LowerName   string `xorm:"UNIQUE(owner_name) NOT NULL"`
+	Description string `xorm:"TEXT"`
+	Color       string `xorm:"VARCHAR(7)"`
+	// IsDisabled types can't be set on issues anymore, the issues having them keep them
+	IsDisabled  bool               `xorm:"NOT NULL DEFAULT false"`
+	CreatedUnix timeutil.TimeStamp `xorm:"created"`
+	UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
+}
+
+func init() {
+	db.RegisterModel(new(IssueType))
+}
+
+// ValidateIssueType checks the fields of an issue type before it is saved
+func ValidateIssueType(t *IssueType) error {
+	t.Name = strings.TrimSpace(t.Name)
+	if t.Name == "" || utf8.RuneCountInString(t.Name) > issueTypeMaxNameLength {
+		return util.NewInvalidArgumentErrorf("issue type name must be between 1 and %d characters", issueTypeMaxNameLength)
+	}
+	t.LowerName = strings.ToLower(t.Name)
+	if t.Color != "" {
+		color, err := label.NormalizeColor(t.Color)
+		if err != nil {
+			return util.NewInvalidArgumentErrorf("invalid issue type color %q", t.Color)
+		}
+		t.Color = color
+	}
+	return nil
+}
+
+func issueTypeNameExists(ctx context.Context, t *IssueType) (bool, error) {
+	return db.GetEngine(ctx).Where(builder.Eq{"owner_id": t.OwnerID, "lower_name": t.LowerName}.And(builder.Neq{"id": t.ID})).Exist(new(IssueType))
+}
+
+// CreateIssueType creates an issue type for an organization
+func CreateIssueType(ctx context.Context, t *IssueType) error {
+	if err := ValidateIssueType(t); err != nil {
+		return err
+	}
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		if exists, err := issueTypeNameExists(ctx, t); err != nil {
+			return err
+		} else if exists {
+			return util.NewAlreadyExistErrorf("issue type %q already exists", t.Name)
+		}
+		return db.Insert(ctx, t)
+	})
+}
+
+// UpdateIssueType updates the name, description, color and state of an issue type
+func UpdateIssueType(ctx context.Context, t *IssueType) error {
+	if err := ValidateIssueType(t); err != nil {
+		return err
+	}
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		if exists, err := issueTypeNameExists(ctx, t); err != nil {
+			return err
+		} else if exists {
+			return util.NewAlreadyExistErrorf("issue type %q already exists", t.Name)
+		}
+		_, err := db.GetEngine(ctx).ID(t.ID).Cols("name", "lower_name", "description", "color", "is_disabled").Update(t)
+		return err
+	})
+}
+
+// DeleteIssueType deletes an issue type, the issues having it are left without type
+func DeleteIssueType(ctx context.Context, t *IssueType) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		if _, err := db.GetEngine(ctx).Where("type_id = ?", t.ID).Cols("type_id").NoAutoTime().Update(&Issue{TypeID: 0}); err != nil {
+			return err
+		}
+		_, err := db.DeleteByID[IssueType](ctx, t.ID)
+		return err
+	})
+}
+
+// ClearIssueTypesByRepoID removes the types of the issues of a repository, they belong to its previous owner once it is transferred
+func ClearIssueTypesByRepoID(ctx context.Context, repoID int64) error {
+	_, err := db.GetEngine(ctx).Where("repo_id = ? AND type_id > 0", repoID).Cols("type_id").NoAutoTime().Update(&Issue{TypeID: 0})
+	return err
+}
+
+// GetIssueIDsByType returns the IDs of the issues having a type
+func GetIssueIDsByType(ctx context.Context, typeID int64) ([]int64, error) {
+	var ids []int64
+	return ids, db.GetEngine(ctx).Table("issue").Where("type_id = ?", typeID).Cols("id").Find(&ids)
+}
+
+// GetIssueTypeByID returns an issue type of an owner
+func GetIssueTypeByID(ctx context.Context, ownerID, id int64) (*IssueType, error) {
+	t, has, err := db.Get[IssueType](ctx, builder.Eq{"id": id, "owner_id": ownerID})
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, fmt.Errorf("issue type with id %d: %w", id, util.ErrNotExist)
+	}
+	return t, nil
+}
+
+// GetIssueTypeByName returns an issue type of an owner by its case-insensitive name
+func GetIssueTypeByName(ctx context.Context, ownerID int64, name string) (*IssueType, error) {
+	t, has, err := db.Get[IssueType](ctx, builder.Eq{"owner_id": ownerID, "lower_name": strings.ToLower(strings.TrimSpace(name))})
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, fmt.Errorf("issue type %q: %w", name, util.ErrNotExist)
+	}
+	return t, nil
+}
+
+// GetIssueTypeIDsByNames returns the IDs of the issue types of an owner by their case-insensitive names, unknown names are ignored
+func GetIssueTypeIDsByNames(ctx context.Context, ownerID int64, names []string) ([]int64, error) {
+	lowerNames := make([]string, 0, len(names))
+	for _, name := range names {
+		lowerNames = append(lowerNames, strings.ToLower(strings.TrimSpace(name)))
+	}
+	ids := make([]int64, 0, len(names))
+	return ids, db.GetEngine(ctx).Table("issue_type").
+		Where(builder.Eq{"owner_id": ownerID}.And(builder.In("lower_name", lowerNames))).
+		Cols("id").Find(&ids)
+}
+
+type FindIssueTypesOptions struct {
+	db.ListOptions
+	OwnerID         int64
+	IncludeDisabled bool
+}
+
+func (opts FindIssueTypesOptions) ToConds() builder.Cond {
+	cond := builder.NewCond().And(builder.Eq{"owner_id": opts.OwnerID})
+	if !opts.IncludeDisabled {
+		cond = cond.And(builder.Eq{"is_disabled": false})
+	}
+	return cond
+}
+
+func (opts FindIssueTypesOptions) ToOrders() string {
+	return "lower_name ASC"
+}
+
+// LoadType loads the type of the issue
+func (issue *Issue) LoadType(ctx context.Context) (err error) {
+	if issue.TypeID == 0 || (issue.Type != nil && issue.Type.ID == issue.TypeID) {
+		return nil
+	}
+	issue.Type, _, err = db.GetByID[IssueType](ctx, issue.TypeID)
+	return err
+}
+
+// LoadTypes loads the types of the issues
+func (issues IssueList) LoadTypes(ctx context.Context) error {
+	typeIDs := container.FilterSlice(issues, func(issue *Issue) (int64, bool) {
+		return issue.TypeID, issue.TypeID > 0
+	})
+	if len(typeIDs) == 0 {
+		return nil
+	}
+	types := make(map[int64]*IssueType, len(typeIDs))
+	if err := db.GetEngine(ctx).In("id", typeIDs).Find(&types); err != nil {
+		return err
+	}
+	for _, issue := range issues {
+		issue.Type = types[issue.TypeID]
+	}
+	return nil
+}
+
+// SetIssueType changes the type of an issue, 0 removes its type.
+// The type must be an enabled type of the owner of the repository of the issue.
+func SetIssueType(ctx context.Context, issue *Issue, typeID int64) error {
+	if issue.TypeID == typeID {
+		return nil
+	}
+	if err := issue.LoadRepo(ctx); err != nil {
+		return err
+	}
+	var t *IssueType
+	if typeID > 0 {
+		var err error
+		t, err = GetIssueTypeByID(ctx, issue.Repo.OwnerID, typeID)
+		if err != nil {
+			return err
+		}
+		if t.IsDisabled {
+			return util.NewInvalidArgumentErrorf("issue type %q is disabled", t.Name)
+		}
+	}
+	issue.TypeID, issue.Type = typeID, t
+	return UpdateIssueCols(ctx, &Issue{ID: issue.ID, TypeID: typeID}, "type_id")
+}
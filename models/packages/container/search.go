@@ -27,6 +27,7 @@ type BlobSearchOptions struct {
 	IsManifest bool
 	OnlyLead   bool
 	Repository string
+	Subject    string
 }
 
 func (opts *BlobSearchOptions) toConds() builder.Cond {
@@ -58,6 +59,15 @@ func (opts *BlobSearchOptions) toConds() builder.Cond {
 
 		cond = cond.And(builder.In("package_file.id", builder.Select("package_property.ref_id").Where(propsCond).From("package_property")))
 	}
+	if opts.Subject != "" {
+		var propsCond builder.Cond = builder.Eq{
+			"package_property.ref_type": packages.PropertyTypeVersion,
+			"package_property.name":     container_module.PropertyManifestSubject,
+			"package_property.value":    opts.Subject,
+		}
+
+		cond = cond.And(builder.In("package_version.id", builder.Select("package_property.ref_id").Where(propsCond).From("package_property")))
+	}
 	if opts.Repository != "" {
 		var propsCond builder.Cond = builder.Eq{
 			"package_property.ref_type": packages.PropertyTypePackage,
@@ -248,6 +258,33 @@ func SearchExpiredUploadedBlobs(ctx context.Context, olderThan time.Duration) ([
 		Find(&pfs)
 }
 
+// SearchExpiredReferrers gets all untagged manifests which refer to a subject and are older than specified
+func SearchExpiredReferrers(ctx context.Context, olderThan time.Duration) ([]*packages.PackageVersion, error) {
+	var cond builder.Cond = builder.Eq{
+		"package_version.is_internal": false,
+		"package.type":                packages.TypeContainer,
+	}
+	cond = cond.And(builder.Lt{"package_version.created_unix": time.Now().Add(-olderThan).Unix()})
+
+	subjectCond := builder.Eq{
+		"package_property.ref_type": packages.PropertyTypeVersion,
+		"package_property.name":     container_module.PropertyManifestSubject,
+	}
+	taggedCond := builder.Eq{
+		"package_property.ref_type": packages.PropertyTypeVersion,
+		"package_property.name":     container_module.PropertyManifestTagged,
+	}
+	cond = cond.
+		And(builder.In("package_version.id", builder.Select("package_property.ref_id").Where(subjectCond).From("package_property"))).
+		And(builder.NotIn("package_version.id", builder.Select("package_property.ref_id").Where(taggedCond).From("package_property")))
+
+	var pvs []*packages.PackageVersion
+	return pvs, db.GetEngine(ctx).
+		Join("INNER", "package", "package.id = package_version.package_id").
+		Where(cond).
+		Find(&pvs)
+}
+
 // GetRepositories gets a sorted list of all repositories
 func GetRepositories(ctx context.Context, actor *user_model.User, n int, last string) ([]string, error) {
 	var cond builder.Cond = builder.Eq{
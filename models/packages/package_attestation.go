@@ -0,0 +1,108 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package packages
+
+import (
+	"context"
+
+	"gitea.dev/models/db"
+	"gitea.dev/modules/timeutil"
+	"gitea.dev/modules/util"
+
+	"xorm.io/builder"
+)
+
+var ErrPackageAttestationNotExist = util.NewNotExistErrorf("package attestation does not exist")
+
+func init() {
+	db.RegisterModel(new(PackageAttestation))
+}
+
+// AttestationType defines the kind of supply-chain information attached to a package version
+type AttestationType string
+
+const (
+	AttestationTypeSignature  AttestationType = "signature"
+	AttestationTypeProvenance AttestationType = "provenance"
+	AttestationTypeSBOM       AttestationType = "sbom"
+)
+
+// IsValid checks if the type is a known attestation type
+func (t AttestationType) IsValid() bool {
+	switch t {
+	case AttestationTypeSignature, AttestationTypeProvenance, AttestationTypeSBOM:
+		return true
+	}
+	return false
+}
+
+// PackageAttestation represents a signature, provenance statement or SBOM attached to a package version.
+// The content is stored as package blob. If FileID is set the attestation belongs to the specific package file.
+type PackageAttestation struct {
+	ID          int64              `xorm:"pk autoincr"`
+	VersionID   int64              `xorm:"INDEX NOT NULL"`
+	FileID      int64              `xorm:"INDEX NOT NULL DEFAULT 0"`
+	BlobID      int64              `xorm:"INDEX NOT NULL"`
+	Type        AttestationType    `xorm:"VARCHAR(20) NOT NULL"`
+	Format      string             `xorm:"VARCHAR(20) NOT NULL DEFAULT ''"`
+	Name        string             `xorm:"NOT NULL"`
+	CreatorID   int64              `xorm:"NOT NULL DEFAULT 0"`
+	CreatedUnix timeutil.TimeStamp `xorm:"created INDEX NOT NULL"`
+}
+
+// InsertAttestation inserts an attestation
+func InsertAttestation(ctx context.Context, pa *PackageAttestation) error {
+	return db.Insert(ctx, pa)
+}
+
+// GetAttestationsByVersionID gets all attestations of the package version
+func GetAttestationsByVersionID(ctx context.Context, versionID int64) ([]*PackageAttestation, error) {
+	pas := make([]*PackageAttestation, 0, 5)
+	return pas, db.GetEngine(ctx).Where("version_id = ?", versionID).OrderBy("id").Find(&pas)
+}
+
+// GetAttestationByID gets the attestation of the package version
+func GetAttestationByID(ctx context.Context, versionID, attestationID int64) (*PackageAttestation, error) {
+	pa := &PackageAttestation{}
+	has, err := db.GetEngine(ctx).Where("version_id = ? AND id = ?", versionID, attestationID).Get(pa)
+	if err != nil {
+		return nil, err
+	}
+	if !has {
+		return nil, ErrPackageAttestationNotExist
+	}
+	return pa, nil
+}
+
+// DeleteAttestationByID deletes an attestation
+func DeleteAttestationByID(ctx context.Context, attestationID int64) error {
+	_, err := db.GetEngine(ctx).ID(attestationID).Delete(&PackageAttestation{})
+	return err
+}
+
+// DeleteAttestationsByName deletes the attestations with the name which belong to the file (or version if fileID is 0)
+func DeleteAttestationsByName(ctx context.Context, versionID, fileID int64, name string) error {
+	_, err := db.GetEngine(ctx).Where("version_id = ? AND file_id = ? AND name = ?", versionID, fileID, name).Delete(&PackageAttestation{})
+	return err
+}
+
+// DeleteAttestationsByFileID deletes all attestations of a specific file
+func DeleteAttestationsByFileID(ctx context.Context, fileID int64) error {
+	_, err := db.GetEngine(ctx).Where("file_id = ?", fileID).Delete(&PackageAttestation{})
+	return err
+}
+
+// DeleteAttestationsByVersionID deletes all attestations of a specific version
+func DeleteAttestationsByVersionID(ctx context.Context, versionID int64) error {
+	_, err := db.GetEngine(ctx).Where("version_id = ?", versionID).Delete(&PackageAttestation{})
+	return err
+}
+
+// DeleteAttestationsByPackageID deletes all attestations of a specific package
+// Versions must not be deleted prior to this call
+func DeleteAttestationsByPackageID(ctx context.Context, packageID int64) error {
+	deleteStmt := builder.Delete(builder.In("version_id", builder.Select("package_version.id").From("package_version").Where(builder.Eq{"package_id": packageID}))).From("package_attestation")
+	_, err := db.GetEngine(ctx).Exec(deleteStmt)
+	return err
+}
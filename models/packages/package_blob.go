@@ -90,13 +90,14 @@ func ExistPackageBlobWithSHA(ctx context.Context, blobSha256 string) (bool, erro
 	})
 }
 
-// FindExpiredUnreferencedBlobs gets all blobs without associated files older than the specific duration
+// FindExpiredUnreferencedBlobs gets all blobs without associated files or attestations older than the specific duration
 func FindExpiredUnreferencedBlobs(ctx context.Context, olderThan time.Duration) ([]*PackageBlob, error) {
 	pbs := make([]*PackageBlob, 0, 10)
 	return pbs, db.GetEngine(ctx).
 		Table("package_blob").
 		Join("LEFT", "package_file", "package_file.blob_id = package_blob.id").
-		Where("package_file.id IS NULL AND package_blob.created_unix < ?", time.Now().Add(-olderThan).Unix()).
+		Join("LEFT", "package_attestation", "package_attestation.blob_id = package_blob.id").
+		Where("package_file.id IS NULL AND package_attestation.id IS NULL AND package_blob.created_unix < ?", time.Now().Add(-olderThan).Unix()).
 		Find(&pbs)
 }
 
@@ -117,7 +118,8 @@ func GetTotalUnreferencedBlobSize(ctx context.Context) (int64, error) {
 	return db.GetEngine(ctx).
 		Table("package_blob").
 		Join("LEFT", "package_file", "package_file.blob_id = package_blob.id").
-		Where("package_file.id IS NULL").
+		Join("LEFT", "package_attestation", "package_attestation.blob_id = package_blob.id").
+		Where("package_file.id IS NULL AND package_attestation.id IS NULL").
 		SumInt(&PackageBlob{}, "size")
 }
 
@@ -0,0 +1,157 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package packages
+
+import (
+	"context"
+	"time"
+
+	"gitea.dev/models/db"
+	"gitea.dev/modules/secret"
+	"gitea.dev/modules/setting"
+	"gitea.dev/modules/timeutil"
+	"gitea.dev/modules/util"
+)
+
+var (
+	ErrPackageRemoteNotExist     = util.NewNotExistErrorf("package remote does not exist")
+	ErrDuplicatePackageRemote    = util.NewAlreadyExistErrorf("package remote already exists")
+	ErrPackageRemoteTypeNotValid = util.NewInvalidArgumentErrorf("package type does not support remotes")
+)
+
+func init() {
+	db.RegisterModel(new(PackageRemote))
+}
+
+// RemoteMode defines how a package remote is combined with the locally stored packages
+type RemoteMode string
+
+const (
+	// RemoteModeRemote serves the upstream registry only, local uploads are rejected
+	RemoteModeRemote RemoteMode = "remote"
+	// RemoteModeVirtual merges local packages with the upstream registry, local packages take precedence
+	RemoteModeVirtual RemoteMode = "virtual"
+)
+
+// IsValid checks if the mode is a known remote mode
+func (m RemoteMode) IsValid() bool {
+	return m == RemoteModeRemote || m == RemoteModeVirtual
+}
+
+// DefaultRemoteMetadataTTL is used if no metadata TTL is configured
+const DefaultRemoteMetadataTTL = 30 * time.Minute
+
+// RemoteTypes are the package types which can be backed by an upstream registry
+var RemoteTypes = []Type{
+	TypeContainer,
+	TypeMaven,
+	TypeNpm,
+	TypePyPI,
+}
+
+// IsRemoteType checks if the package type can be backed by an upstream registry
+func IsRemoteType(t Type) bool {
+	for _, rt := range RemoteTypes {
+		if rt == t {
+			return true
+		}
+	}
+	return false
+}
+
+// PackageRemote represents an upstream registry used as a pull-through source for a package type of an owner
+type PackageRemote struct {
+	ID                int64              `xorm:"pk autoincr"`
+	OwnerID           int64              `xorm:"UNIQUE(s) INDEX NOT NULL DEFAULT 0"`
+	Type              Type               `xorm:"UNIQUE(s) INDEX NOT NULL"`
+	Mode              RemoteMode         `xorm:"VARCHAR(20) NOT NULL DEFAULT 'remote'"`
+	URL               string             `xorm:"TEXT NOT NULL"`
+	Username          string             `xorm:"NOT NULL DEFAULT ''"`
+	PasswordEncrypted string             `xorm:"TEXT"`
+	MetadataTTL       int64              `xorm:"NOT NULL DEFAULT 0"`
+	CreatedUnix       timeutil.TimeStamp `xorm:"created NOT NULL DEFAULT 0"`
+	UpdatedUnix       timeutil.TimeStamp `xorm:"updated NOT NULL DEFAULT 0"`
+}
+
+// TTL returns the duration cached upstream metadata is considered fresh
+func (pr *PackageRemote) TTL() time.Duration {
+	if pr.MetadataTTL <= 0 {
+		return DefaultRemoteMetadataTTL
+	}
+	return time.Duration(pr.MetadataTTL) * time.Second
+}
+
+// AllowsUpload checks if packages may be uploaded next to the remote
+func (pr *PackageRemote) AllowsUpload() bool {
+	return pr.Mode == RemoteModeVirtual
+}
+
+// Password returns the decrypted upstream password
+func (pr *PackageRemote) Password() (string, error) {
+	if pr.PasswordEncrypted == "" {
+		return "", nil
+	}
+	return secret.DecryptSecret(setting.SecretKey, pr.PasswordEncrypted)
+}
+
+// SetPassword encrypts and stores the upstream password
+func (pr *PackageRemote) SetPassword(cleartext string) error {
+	if cleartext == "" {
+		pr.PasswordEncrypted = ""
+		return nil
+	}
+	ciphertext, err := secret.EncryptSecret(setting.SecretKey, cleartext)
+	if err != nil {
+		return err
+	}
+	pr.PasswordEncrypted = ciphertext
+	return nil
+}
+
+func InsertRemote(ctx context.Context, pr *PackageRemote) (*PackageRemote, error) {
+	has, err := HasOwnerRemoteForPackageType(ctx, pr.OwnerID, pr.Type)
+	if err != nil {
+		return nil, err
+	}
+	if has {
+		return nil, ErrDuplicatePackageRemote
+	}
+	return pr, db.Insert(ctx, pr)
+}
+
+func GetRemoteByOwnerAndType(ctx context.Context, ownerID int64, packageType Type) (*PackageRemote, error) {
+	pr := &PackageRemote{}
+
+	has, err := db.GetEngine(ctx).
+		Where("owner_id = ? AND type = ?", ownerID, packageType).
+		Get(pr)
+	if err != nil {
+		return nil, err
+	}
+	if !has {
+		return nil, ErrPackageRemoteNotExist
+	}
+	return pr, nil
+}
+
+func UpdateRemote(ctx context.Context, pr *PackageRemote) error {
+	_, err := db.GetEngine(ctx).ID(pr.ID).AllCols().Update(pr)
+	return err
+}
+
+func GetRemotesByOwner(ctx context.Context, ownerID int64) ([]*PackageRemote, error) {
+	prs := make([]*PackageRemote, 0, len(RemoteTypes))
+	return prs, db.GetEngine(ctx).Where("owner_id = ?", ownerID).OrderBy("type").Find(&prs)
+}
+
+func DeleteRemoteByID(ctx context.Context, remoteID int64) error {
+	_, err := db.GetEngine(ctx).ID(remoteID).Delete(&PackageRemote{})
+	return err
+}
+
+func HasOwnerRemoteForPackageType(ctx context.Context, ownerID int64, packageType Type) (bool, error) {
+	return db.GetEngine(ctx).
+		Where("owner_id = ? AND type = ?", ownerID, packageType).
+		Exist(&PackageRemote{})
+}
@@ -0,0 +1,62 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package packages_test
+
+import (
+	"testing"
+
+	packages_model "gitea.dev/models/packages"
+	"gitea.dev/models/unittest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPackageRemote(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	pr, err := packages_model.InsertRemote(t.Context(), &packages_model.PackageRemote{
+		OwnerID: 2,
+		Type:    packages_model.TypeNpm,
+		Mode:    packages_model.RemoteModeRemote,
+		URL:     "https://registry.npmjs.org",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, packages_model.DefaultRemoteMetadataTTL, pr.TTL())
+	assert.False(t, pr.AllowsUpload())
+
+	_, err = packages_model.InsertRemote(t.Context(), &packages_model.PackageRemote{
+		OwnerID: 2,
+		Type:    packages_model.TypeNpm,
+		URL:     "https://registry.example.com",
+	})
+	assert.ErrorIs(t, err, packages_model.ErrDuplicatePackageRemote)
+
+	has, err := packages_model.HasOwnerRemoteForPackageType(t.Context(), 2, packages_model.TypeNpm)
+	assert.NoError(t, err)
+	assert.True(t, has)
+	has, err = packages_model.HasOwnerRemoteForPackageType(t.Context(), 2, packages_model.TypePyPI)
+	assert.NoError(t, err)
+	assert.False(t, has)
+
+	require.NoError(t, pr.SetPassword("secret"))
+	assert.NotEqual(t, "secret", pr.PasswordEncrypted)
+	pr.Mode = packages_model.RemoteModeVirtual
+	require.NoError(t, packages_model.UpdateRemote(t.Context(), pr))
+
+	pr, err = packages_model.GetRemoteByOwnerAndType(t.Context(), 2, packages_model.TypeNpm)
+	require.NoError(t, err)
+	assert.True(t, pr.AllowsUpload())
+	password, err := pr.Password()
+	assert.NoError(t, err)
+	assert.Equal(t, "secret", password)
+
+	remotes, err := packages_model.GetRemotesByOwner(t.Context(), 2)
+	assert.NoError(t, err)
+	assert.Len(t, remotes, 1)
+
+	require.NoError(t, packages_model.DeleteRemoteByID(t.Context(), pr.ID))
+	_, err = packages_model.GetRemoteByOwnerAndType(t.Context(), 2, packages_model.TypeNpm)
+	assert.ErrorIs(t, err, packages_model.ErrPackageRemoteNotExist)
+}
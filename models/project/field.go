@@ -0,0 +1,480 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package project
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"gitea.dev/models/db"
+	"gitea.dev/modules/container"
+	"gitea.dev/modules/timeutil"
+	"gitea.dev/modules/util"
+
+	"xorm.io/builder"
+)
+
+// FieldType is the type of the values of a project field
+type FieldType string
+
+const (
+	FieldTypeText         FieldType = "text"
+	FieldTypeNumber       FieldType = "number"
+	FieldTypeDate         FieldType = "date"
+	FieldTypeSingleSelect FieldType = "single_select"
+	// FieldTypeIteration values are iterations of the field, like the sprints of a team
+	FieldTypeIteration FieldType = "iteration"
+)
+
+// FieldDateLayout is the layout of the date values and of the start dates of the iterations
+const FieldDateLayout = "2006-01-02"
+
+const (
+	fieldMaxNameLength  = 100
+	fieldMaxValueLength = 255
+	// MaxIterationDuration is the maximum duration of an iteration in days
+	MaxIterationDuration = 365
+)
+
+// IsValid returns true if the type is a known project field type
+func (t FieldType) IsValid() bool {
+	switch t {
+	case FieldTypeText, FieldTypeNumber, FieldTypeDate, FieldTypeSingleSelect, FieldTypeIteration:
+		return true
+	}
+	return false
+}
+
+// Field is a custom field of the issues of a project, its values belong to the issues in the project
+// and are removed with them.
+type Field struct {
+	ID        int64     `xorm:"pk autoincr"`
+	ProjectID int64     `xorm:"INDEX NOT NULL"`
+	Name      string    `xorm:"NOT NULL"`
+	Type      FieldType `xorm:"VARCHAR(20) NOT NULL"`
+	// Options are the choices of a single select field, their order is the sort order of the field
+	Options []string `xorm:"JSON TEXT"`
+
+	CreatedUnix timeutil.TimeStamp `xorm:"created"`
+	UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
+}
+
+// TableName sets the table name of the project fields
+func (Field) TableName() string {
+	return "project_field"
+}
+
+// Iteration is a time box of an iteration field, it starts on its start date and lasts Duration days
+type Iteration struct {
+	ID        int64              `xorm:"pk autoincr"`
+	FieldID   int64              `xorm:"INDEX NOT NULL"`
+	Title     string             `xorm:"NOT NULL"`
+	StartDate timeutil.TimeStamp `xorm:"NOT NULL"`
+	Duration  int                `xorm:"NOT NULL"`
+
+	CreatedUnix timeutil.TimeStamp `xorm:"created"`
+	UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
+}
+
+// TableName sets the table name of the project iterations
+func (Iteration) TableName() string {
+	return "project_iteration"
+}
+
+// IssueFieldValue is the value of a field of a project for an issue in the project
+type IssueFieldValue struct {
+	ID        int64  `xorm:"pk autoincr"`
+	ProjectID int64  `xorm:"UNIQUE(s) NOT NULL"`
+	IssueID   int64  `xorm:"UNIQUE(s) INDEX NOT NULL"`
+	FieldID   int64  `xorm:"UNIQUE(s) INDEX NOT NULL"`
+	Value     string `xorm:"VARCHAR(255) NOT NULL"`
+	// Number is the sort key of the value: the number, the unix time of the date or of the start of the iteration,
+	// or the index of the option
+	Number float64 `xorm:"NOT NULL DEFAULT 0"`
+}
+
+// TableName sets the table name of the project field values
+func (IssueFieldValue) TableName() string {
+	return "project_issue_field_value"
+}
+
+func init() {
+	db.RegisterModel(new(Field))
+	db.RegisterModel(new(Iteration))
+	db.RegisterModel(new(IssueFieldValue))
+}
+
+// ParseFieldDate parses a date value or an iteration start date, the dates are stored as their midnight in UTC
+func ParseFieldDate(s string) (timeutil.TimeStamp, error) {
+	d, err := time.Parse(FieldDateLayout, strings.TrimSpace(s))
+	if err != nil {
+		return 0, util.NewInvalidArgumentErrorf("%q is not a date formatted as %s", s, FieldDateLayout)
+	}
+	return timeutil.TimeStamp(d.Unix()), nil
+}
+
+// FormatFieldDate formats a date stored by ParseFieldDate
+func FormatFieldDate(ts timeutil.TimeStamp) string {
+	return ts.AsTimeInLocation(time.UTC).Format(FieldDateLayout)
+}
+
+// EndDate returns the first day after the iteration
+func (it *Iteration) EndDate() timeutil.TimeStamp {
+	return it.StartDate.AddDuration(time.Duration(it.Duration) * 24 * time.Hour)
+}
+
+// IsCurrent returns true if the iteration contains the time
+func (it *Iteration) IsCurrent(now timeutil.TimeStamp) bool {
+	return it.StartDate <= now && now < it.EndDate()
+}
+
+// ValidateField checks the fields of a project field before it is saved
+func ValidateField(f *Field) error {
+	f.Name = strings.TrimSpace(f.Name)
+	if f.Name == "" || utf8.RuneCountInString(f.Name) > fieldMaxNameLength {
+		return util.NewInvalidArgumentErrorf("project field name must be between 1 and %d characters", fieldMaxNameLength)
+	}
+	if !f.Type.IsValid() {
+		return util.NewInvalidArgumentErrorf("invalid project field type %q", f.Type)
+	}
+	if f.Type != FieldTypeSingleSelect {
+		f.Options = nil
+		return nil
+	}
+	if len(f.Options) == 0 {
+		return util.NewInvalidArgumentErrorf("project field %q needs at least one option", f.Name)
+	}
+	seen := make(container.Set[string], len(f.Options))
+	for i, option := range f.Options {
+		option = strings.TrimSpace(option)
+		if option == "" || utf8.RuneCountInString(option) > fieldMaxValueLength {
+			return util.NewInvalidArgumentErrorf("invalid option %q of project field %q", option, f.Name)
+		}
+		if !seen.Add(option) {
+			return util.NewInvalidArgumentErrorf("duplicated option %q of project field %q", option, f.Name)
+		}
+		f.Options[i] = option
+	}
+	return nil
+}
+
+func fieldNameExists(ctx context.Context, f *Field) (bool, error) {
+	return db.GetEngine(ctx).Where(builder.Eq{"project_id": f.ProjectID}.And(builder.Eq{"LOWER(name)": strings.ToLower(f.Name)}).And(builder.Neq{"id": f.ID})).Exist(new(Field))
+}
+
+// CreateField creates a field of a project
+func CreateField(ctx context.Context, f *Field) error {
+	if err := ValidateField(f); err != nil {
+		return err
+	}
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		if exists, err := fieldNameExists(ctx, f); err != nil {
+			return err
+		} else if exists {
+			return util.NewAlreadyExistErrorf("project field %q already exists", f.Name)
+		}
+		return db.Insert(ctx, f)
+	})
+}
+
+// UpdateField updates the name and the options of a project field, the type can't be changed.
+// The values of the removed options are deleted and the sort keys of the others follow the new order.
+func UpdateField(ctx context.Context, f *Field) error {
+	if err := ValidateField(f); err != nil {
+		return err
+	}
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		if exists, err := fieldNameExists(ctx, f); err != nil {
+			return err
+		} else if exists {
+			return util.NewAlreadyExistErrorf("project field %q already exists", f.Name)
+		}
+		if _, err := db.GetEngine(ctx).ID(f.ID).Cols("name", "options").Update(f); err != nil {
+			return err
+		}
+		if f.Type != FieldTypeSingleSelect {
+			return nil
+		}
+		if _, err := db.GetEngine(ctx).Where(builder.Eq{"field_id": f.ID}.And(builder.NotIn("value", f.Options))).
+			Delete(new(IssueFieldValue)); err != nil {
+			return err
+		}
+		for i, option := range f.Options {
+			if _, err := db.GetEngine(ctx).Where(builder.Eq{"field_id": f.ID, "value": option}).
+				Cols("number").Update(&IssueFieldValue{Number: float64(i)}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// DeleteField deletes a project field with its iterations and values, the views grouped or sorted by it fall back to their defaults
+func DeleteField(ctx context.Context, f *Field) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		if _, err := db.GetEngine(ctx).Where("field_id = ?", f.ID).Delete(new(IssueFieldValue)); err != nil {
+			return err
+		}
+		if _, err := db.GetEngine(ctx).Where("field_id = ?", f.ID).Delete(new(Iteration)); err != nil {
+			return err
+		}
+		if err := resetViewsOfField(ctx, f); err != nil {
+			return err
+		}
+		_, err := db.DeleteByID[Field](ctx, f.ID)
+		return err
+	})
+}
+
+// GetFieldByIDAndProjectID returns a field of a project
+func GetFieldByIDAndProjectID(ctx context.Context, id, projectID int64) (*Field, error) {
+	f, has, err := db.Get[Field](ctx, builder.Eq{"id": id, "project_id": projectID})
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, fmt.Errorf("project field with id %d: %w", id, util.ErrNotExist)
+	}
+	return f, nil
+}
+
+type FindFieldsOptions struct {
+	db.ListOptions
+	ProjectID int64
+}
+
+func (opts FindFieldsOptions) ToConds() builder.Cond {
+	return builder.Eq{"project_id": opts.ProjectID}
+}
+
+func (opts FindFieldsOptions) ToOrders() string {
+	return "id ASC"
+}
+
+// GetProjectFields returns all the fields of a project
+func GetProjectFields(ctx context.Context, projectID int64) ([]*Field, error) {
+	return db.Find[Field](ctx, FindFieldsOptions{ListOptions: db.ListOptionsAll, ProjectID: projectID})
+}
+
+// ValidateIteration checks the fields of an iteration before it is saved
+func ValidateIteration(it *Iteration) error {
+	it.Title = strings.TrimSpace(it.Title)
+	if it.Title == "" || utf8.RuneCountInString(it.Title) > fieldMaxNameLength {
+		return util.NewInvalidArgumentErrorf("iteration title must be between 1 and %d characters", fieldMaxNameLength)
+	}
+	if it.StartDate <= 0 {
+		return util.NewInvalidArgumentErrorf("iteration %q needs a start date", it.Title)
+	}
+	if it.Duration < 1 || it.Duration > MaxIterationDuration {
+		return util.NewInvalidArgumentErrorf("iteration duration must be between 1 and %d days", MaxIterationDuration)
+	}
+	return nil
+}
+
+// CreateIteration adds an iteration to an iteration field
+func CreateIteration(ctx context.Context, f *Field, it *Iteration) error {
+	if f.Type != FieldTypeIteration {
+		return util.NewInvalidArgumentErrorf("project field %q is not an iteration field", f.Name)
+	}
+	if err := ValidateIteration(it); err != nil {
+		return err
+	}
+	it.FieldID = f.ID
+	return db.Insert(ctx, it)
+}
+
+// UpdateIteration updates the title and the dates of an iteration, the sort keys of its values follow its start date
+func UpdateIteration(ctx context.Context, it *Iteration) error {
+	if err := ValidateIteration(it); err != nil {
+		return err
+	}
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		if _, err := db.GetEngine(ctx).ID(it.ID).Cols("title", "start_date", "duration").Update(it); err != nil {
+			return err
+		}
+		_, err := db.GetEngine(ctx).Where(builder.Eq{"field_id": it.FieldID, "value": strconv.FormatInt(it.ID, 10)}).
+			Cols("number").Update(&IssueFieldValue{Number: float64(it.StartDate)})
+		return err
+	})
+}
+
+// DeleteIteration deletes an iteration, the issues in it are left without iteration
+func DeleteIteration(ctx context.Context, it *Iteration) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		if _, err := db.GetEngine(ctx).Where(builder.Eq{"field_id": it.FieldID, "value": strconv.FormatInt(it.ID, 10)}).
+			Delete(new(IssueFieldValue)); err != nil {
+			return err
+		}
+		_, err := db.DeleteByID[Iteration](ctx, it.ID)
+		return err
+	})
+}
+
+// GetIterationByIDAndFieldID returns an iteration of an iteration field
+func GetIterationByIDAndFieldID(ctx context.Context, id, fieldID int64) (*Iteration, error) {
+	it, has, err := db.Get[Iteration](ctx, builder.Eq{"id": id, "field_id": fieldID})
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, fmt.Errorf("iteration with id %d: %w", id, util.ErrNotExist)
+	}
+	return it, nil
+}
+
+// GetFieldIterations returns the iterations of a field ordered by start date
+func GetFieldIterations(ctx context.Context, fieldIDs ...int64) ([]*Iteration, error) {
+	iterations := make([]*Iteration, 0, 10)
+	if len(fieldIDs) == 0 {
+		return iterations, nil
+	}
+	return iterations, db.GetEngine(ctx).In("field_id", fieldIDs).OrderBy("start_date ASC, id ASC").Find(&iterations)
+}
+
+// NextIteration returns the iteration starting after an iteration, nil if it is the last one
+func NextIteration(ctx context.Context, it *Iteration) (*Iteration, error) {
+	next := new(Iteration)
+	has, err := db.GetEngine(ctx).
+		Where(builder.Eq{"field_id": it.FieldID}.And(builder.Or(
+			builder.Gt{"start_date": it.StartDate},
+			builder.Eq{"start_date": it.StartDate}.And(builder.Gt{"id": it.ID}),
+		))).
+		OrderBy("start_date ASC, id ASC").Get(next)
+	if err != nil || !has {
+		return nil, err
+	}
+	return next, nil
+}
+
+// NormalizeValue checks a value to be set for the field and returns it in its canonical form,
+// the iterations of an iteration field are given by their IDs. An empty value returns nil.
+func (f *Field) NormalizeValue(ctx context.Context, v string) (*IssueFieldValue, error) {
+	v = strings.TrimSpace(v)
+	if v == "" {
+		return nil, nil
+	}
+	value := &IssueFieldValue{ProjectID: f.ProjectID, FieldID: f.ID}
+	switch f.Type {
+	case FieldTypeText:
+		if utf8.RuneCountInString(v) > fieldMaxValueLength {
+			return nil, util.NewInvalidArgumentErrorf("value of project field %q is longer than %d characters", f.Name, fieldMaxValueLength)
+		}
+		value.Value = v
+	case FieldTypeNumber:
+		n, err := strconv.ParseFloat(v, 64)
+		if err != nil || math.IsInf(n, 0) || math.IsNaN(n) {
+			return nil, util.NewInvalidArgumentErrorf("value %q of project field %q is not a number", v, f.Name)
+		}
+		value.Value, value.Number = strconv.FormatFloat(n, 'f', -1, 64), n
+	case FieldTypeDate:
+		d, err := ParseFieldDate(v)
+		if err != nil {
+			return nil, err
+		}
+		value.Value, value.Number = FormatFieldDate(d), float64(d)
+	case FieldTypeSingleSelect:
+		idx := slices.Index(f.Options, v)
+		if idx < 0 {
+			return nil, util.NewInvalidArgumentErrorf("value %q is not an option of project field %q", v, f.Name)
+		}
+		value.Value, value.Number = v, float64(idx)
+	case FieldTypeIteration:
+		id, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || id <= 0 {
+			return nil, util.NewInvalidArgumentErrorf("value %q of project field %q is not an iteration ID", v, f.Name)
+		}
+		it, err := GetIterationByIDAndFieldID(ctx, id, f.ID)
+		if err != nil {
+			if errors.Is(err, util.ErrNotExist) {
+				return nil, util.NewInvalidArgumentErrorf("%d is not an iteration of project field %q", id, f.Name)
+			}
+			return nil, err
+		}
+		value.Value, value.Number = strconv.FormatInt(it.ID, 10), float64(it.StartDate)
+	default:
+		return nil, util.NewInvalidArgumentErrorf("invalid project field type %q", f.Type)
+	}
+	return value, nil
+}
+
+// SetIssueFieldValue sets the value of a field for an issue in the project of the field, an empty value clears it
+func SetIssueFieldValue(ctx context.Context, f *Field, issueID int64, v string) error {
+	value, err := f.NormalizeValue(ctx, v)
+	if err != nil {
+		return err
+	}
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		inProject, err := db.Exist[ProjectIssue](ctx, builder.Eq{"project_id": f.ProjectID, "issue_id": issueID})
+		if err != nil {
+			return err
+		} else if !inProject {
+			return util.ErrorWrap(util.ErrUnprocessableContent, "issue %d is not in project %d", issueID, f.ProjectID)
+		}
+		if _, err := db.GetEngine(ctx).Where("issue_id = ? AND field_id = ?", issueID, f.ID).Delete(new(IssueFieldValue)); err != nil {
+			return err
+		}
+		if value == nil {
+			return nil
+		}
+		value.IssueID = issueID
+		return db.Insert(ctx, value)
+	})
+}
+
+// GetIssueFieldValues returns the field values of the issues of a project, keyed by issue ID
+func GetIssueFieldValues(ctx context.Context, projectID int64, issueIDs []int64) (map[int64][]*IssueFieldValue, error) {
+	res := make(map[int64][]*IssueFieldValue, len(issueIDs))
+	for ids := range slices.Chunk(issueIDs, db.DefaultMaxInSize) {
+		values := make([]*IssueFieldValue, 0, len(ids))
+		if err := db.GetEngine(ctx).Where("project_id = ?", projectID).In("issue_id", ids).
+			OrderBy("field_id ASC").Find(&values); err != nil {
+			return nil, err
+		}
+		for _, v := range values {
+			res[v.IssueID] = append(res[v.IssueID], v)
+		}
+	}
+	return res, nil
+}
+
+// RolloverIteration moves the open issues of an iteration to another iteration of the same field,
+// the closed issues stay in the iteration they were done in. It returns the number of moved issues.
+func RolloverIteration(ctx context.Context, from, to *Iteration) (int64, error) {
+	if from.FieldID != to.FieldID {
+		return 0, util.NewInvalidArgumentErrorf("iterations have to belong to the same field")
+	}
+	if from.ID == to.ID {
+		return 0, nil
+	}
+	return db.WithTx2(ctx, func(ctx context.Context) (int64, error) {
+		return db.GetEngine(ctx).
+			Where(builder.Eq{"field_id": from.FieldID, "value": strconv.FormatInt(from.ID, 10)}).
+			And(builder.In("issue_id", builder.Select("id").From("issue").Where(builder.Eq{"is_closed": false}))).
+			Cols("value", "number").
+			Update(&IssueFieldValue{Value: strconv.FormatInt(to.ID, 10), Number: float64(to.StartDate)})
+	})
+}
+
+func deleteFieldsByProjectID(ctx context.Context, projectID int64) error {
+	fieldIDs := builder.Select("id").From("project_field").Where(builder.Eq{"project_id": projectID})
+	if _, err := db.GetEngine(ctx).Where(builder.In("field_id", fieldIDs)).Delete(new(Iteration)); err != nil {
+		return err
+	}
+	if _, err := db.GetEngine(ctx).Where("project_id = ?", projectID).Delete(new(IssueFieldValue)); err != nil {
+		return err
+	}
+	_, err := db.GetEngine(ctx).Where("project_id = ?", projectID).Delete(new(Field))
+	return err
+}
+
+// deleteIssueFieldValues removes the field values of issues leaving projects
+func deleteIssueFieldValues(ctx context.Context, issueIDs, projectIDs []int64) error {
+	_, err := db.GetEngine(ctx).In("project_id", projectIDs).In("issue_id", issueIDs).Delete(new(IssueFieldValue))
+	return err
+}
@@ -0,0 +1,109 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package project
+
+import (
+	"testing"
+
+	"gitea.dev/models/unittest"
+	"gitea.dev/modules/util"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestField_NormalizeValue(t *testing.T) {
+	field := &Field{Name: "status", Type: FieldTypeSingleSelect, Options: []string{"todo", "done"}}
+	value, err := field.NormalizeValue(t.Context(), " done ")
+	require.NoError(t, err)
+	assert.Equal(t, "done", value.Value)
+	assert.InDelta(t, 1, value.Number, 0)
+	_, err = field.NormalizeValue(t.Context(), "doing")
+	assert.ErrorIs(t, err, util.ErrInvalidArgument)
+
+	field = &Field{Name: "estimate", Type: FieldTypeNumber}
+	value, err = field.NormalizeValue(t.Context(), "2.50")
+	require.NoError(t, err)
+	assert.Equal(t, "2.5", value.Value)
+	value, err = field.NormalizeValue(t.Context(), "")
+	require.NoError(t, err)
+	assert.Nil(t, value)
+
+	field = &Field{Name: "due", Type: FieldTypeDate}
+	_, err = field.NormalizeValue(t.Context(), "2026-13-01")
+	assert.ErrorIs(t, err, util.ErrInvalidArgument)
+}
+
+func TestIteration_Dates(t *testing.T) {
+	start, err := ParseFieldDate("2026-03-30")
+	require.NoError(t, err)
+	it := &Iteration{StartDate: start, Duration: 14}
+	assert.Equal(t, "2026-04-13", FormatFieldDate(it.EndDate()))
+	assert.True(t, it.IsCurrent(start))
+	assert.False(t, it.IsCurrent(it.EndDate()))
+}
+
+func TestProjectFields(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	field := &Field{ProjectID: 1, Name: "Status", Type: FieldTypeSingleSelect, Options: []string{"todo", "doing", "done"}}
+	require.NoError(t, CreateField(t.Context(), field))
+	err := CreateField(t.Context(), &Field{ProjectID: 1, Name: "status", Type: FieldTypeText})
+	assert.ErrorIs(t, err, util.ErrAlreadyExist)
+
+	require.NoError(t, SetIssueFieldValue(t.Context(), field, 1, "doing"))
+	require.NoError(t, SetIssueFieldValue(t.Context(), field, 2, "done"))
+	// issue 4 is not in the project
+	assert.ErrorIs(t, SetIssueFieldValue(t.Context(), field, 4, "done"), util.ErrUnprocessableContent)
+
+	// the values of the removed options are cleared, the others follow the new order
+	field.Options = []string{"done", "todo"}
+	require.NoError(t, UpdateField(t.Context(), field))
+	values, err := GetIssueFieldValues(t.Context(), 1, []int64{1, 2})
+	require.NoError(t, err)
+	assert.Empty(t, values[1])
+	require.Len(t, values[2], 1)
+	assert.InDelta(t, 0, values[2][0].Number, 0)
+
+	// leaving the project removes the values of the issue
+	require.NoError(t, DeleteAllProjectIssueByIssueIDsAndProjectIDs(t.Context(), []int64{2}, []int64{1}))
+	unittest.AssertNotExistsBean(t, &IssueFieldValue{IssueID: 2, ProjectID: 1})
+
+	require.NoError(t, DeleteField(t.Context(), field))
+	unittest.AssertNotExistsBean(t, &Field{ID: field.ID})
+}
+
+func TestProjectViews(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	field := &Field{ProjectID: 1, Name: "Estimate", Type: FieldTypeNumber}
+	require.NoError(t, CreateField(t.Context(), field))
+
+	board := &View{ProjectID: 1, Name: "Board", Layout: ViewLayoutBoard}
+	require.NoError(t, CreateView(t.Context(), board))
+	assert.Equal(t, ViewGroupByColumn, board.GroupBy)
+
+	// a board can't be grouped by a number field, a table can
+	board.GroupBy = ViewFieldKey(field.ID)
+	assert.ErrorIs(t, UpdateView(t.Context(), board), util.ErrInvalidArgument)
+	table := &View{ProjectID: 1, Name: "Table", Layout: ViewLayoutTable, GroupBy: ViewFieldKey(field.ID), SortBy: ViewFieldKey(field.ID)}
+	require.NoError(t, CreateView(t.Context(), table))
+	assert.Greater(t, table.Sorting, board.Sorting)
+
+	// the fields of other projects can't be used
+	other := &View{ProjectID: 2, Name: "Table", Layout: ViewLayoutTable, SortBy: ViewFieldKey(field.ID)}
+	assert.ErrorIs(t, CreateView(t.Context(), other), util.ErrInvalidArgument)
+	roadmap := &View{ProjectID: 1, Name: "Roadmap", Layout: ViewLayoutRoadmap, DateFieldID: field.ID}
+	assert.ErrorIs(t, CreateView(t.Context(), roadmap), util.ErrInvalidArgument)
+
+	// deleting the field resets the views using it
+	require.NoError(t, DeleteField(t.Context(), field))
+	table, err := GetViewByIDAndProjectID(t.Context(), table.ID, 1)
+	require.NoError(t, err)
+	assert.Equal(t, ViewGroupByNone, table.GroupBy)
+	assert.Equal(t, ViewSortByPosition, table.SortBy)
+
+	require.NoError(t, DeleteProjectByID(t.Context(), 1))
+	unittest.AssertNotExistsBean(t, &View{ProjectID: 1})
+}
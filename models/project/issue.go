@@ -6,6 +6,7 @@ package project
 import (
 	"context"
 	"errors"
+	"slices"
 
 	"gitea.dev/models/db"
 	"gitea.dev/modules/util"
@@ -112,6 +113,24 @@ func moveIssuesToAnotherColumn(ctx context.Context, oldColumn, newColumn *Column
 
 // DeleteAllProjectIssueByIssueIDsAndProjectIDs delete all project's issues by issue's and project's ids
 func DeleteAllProjectIssueByIssueIDsAndProjectIDs(ctx context.Context, issueIDs, projectIDs []int64) error {
+	if err := deleteIssueFieldValues(ctx, issueIDs, projectIDs); err != nil {
+		return err
+	}
 	_, err := db.GetEngine(ctx).In("project_id", projectIDs).In("issue_id", issueIDs).Delete(&ProjectIssue{})
 	return err
 }
+
+// GetProjectIssuesMap returns the placements of issues in a project, keyed by issue ID
+func GetProjectIssuesMap(ctx context.Context, projectID int64, issueIDs []int64) (map[int64]*ProjectIssue, error) {
+	res := make(map[int64]*ProjectIssue, len(issueIDs))
+	for ids := range slices.Chunk(issueIDs, db.DefaultMaxInSize) {
+		projectIssues := make([]*ProjectIssue, 0, len(ids))
+		if err := db.GetEngine(ctx).Where("project_id=?", projectID).In("issue_id", ids).Find(&projectIssues); err != nil {
+			return nil, err
+		}
+		for _, pi := range projectIssues {
+			res[pi.IssueID] = pi
+		}
+	}
+	return res, nil
+}
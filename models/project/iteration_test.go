@@ -0,0 +1,55 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package project_test
+
+import (
+	"strconv"
+	"testing"
+
+	_ "gitea.dev/models/issues" // the rollover leaves the closed issues in their iteration
+	project_model "gitea.dev/models/project"
+	"gitea.dev/models/unittest"
+	"gitea.dev/modules/util"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRolloverIteration(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	field := &project_model.Field{ProjectID: 1, Name: "Sprint", Type: project_model.FieldTypeIteration}
+	require.NoError(t, project_model.CreateField(t.Context(), field))
+	start, err := project_model.ParseFieldDate("2026-01-05")
+	require.NoError(t, err)
+	sprint1 := &project_model.Iteration{Title: "Sprint 1", StartDate: start, Duration: 14}
+	require.NoError(t, project_model.CreateIteration(t.Context(), field, sprint1))
+	sprint2 := &project_model.Iteration{Title: "Sprint 2", StartDate: sprint1.EndDate(), Duration: 14}
+	require.NoError(t, project_model.CreateIteration(t.Context(), field, sprint2))
+	assert.ErrorIs(t, project_model.CreateIteration(t.Context(), field, &project_model.Iteration{Title: "Sprint 3", StartDate: start, Duration: 0}), util.ErrInvalidArgument)
+	assert.ErrorIs(t, project_model.CreateIteration(t.Context(), &project_model.Field{Type: project_model.FieldTypeText}, &project_model.Iteration{Title: "Sprint 3", StartDate: start, Duration: 7}), util.ErrInvalidArgument)
+
+	next, err := project_model.NextIteration(t.Context(), sprint1)
+	require.NoError(t, err)
+	assert.Equal(t, sprint2.ID, next.ID)
+	next, err = project_model.NextIteration(t.Context(), sprint2)
+	require.NoError(t, err)
+	assert.Nil(t, next)
+
+	// issue 5 is closed, it stays in the first sprint
+	for _, issueID := range []int64{1, 3, 5} {
+		require.NoError(t, project_model.SetIssueFieldValue(t.Context(), field, issueID, strconv.FormatInt(sprint1.ID, 10)))
+	}
+	moved, err := project_model.RolloverIteration(t.Context(), sprint1, sprint2)
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, moved)
+	values, err := project_model.GetIssueFieldValues(t.Context(), 1, []int64{1, 3, 5})
+	require.NoError(t, err)
+	assert.Equal(t, strconv.FormatInt(sprint2.ID, 10), values[1][0].Value)
+	assert.InDelta(t, float64(sprint2.StartDate), values[3][0].Number, 0)
+	assert.Equal(t, strconv.FormatInt(sprint1.ID, 10), values[5][0].Value)
+
+	require.NoError(t, project_model.DeleteIteration(t.Context(), sprint2))
+	unittest.AssertNotExistsBean(t, &project_model.IssueFieldValue{FieldID: field.ID, IssueID: 1})
+}
@@ -14,6 +14,7 @@ import (
 func TestMain(m *testing.M) {
 	unittest.MainTest(m, &unittest.TestOptions{
 		FixtureFiles: []string{
+			"issue.yml",
 			"project.yml",
 			"project_board.yml",
 			"project_issue.yml",
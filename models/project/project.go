@@ -435,6 +435,14 @@ func DeleteProjectByID(ctx context.Context, id int64) error {
 			return err
 		}
 
+		if err := deleteViewsByProjectID(ctx, id); err != nil {
+			return err
+		}
+
+		if err := deleteFieldsByProjectID(ctx, id); err != nil {
+			return err
+		}
+
 		if _, err = db.GetEngine(ctx).ID(p.ID).Delete(new(Project)); err != nil {
 			return err
 		}
@@ -444,6 +452,19 @@ func DeleteProjectByID(ctx context.Context, id int64) error {
 }
 
 func DeleteProjectByRepoID(ctx context.Context, repoID int64) error {
+	var projectIDs []int64
+	if err := db.GetEngine(ctx).Table("project").Where("repo_id = ?", repoID).Cols("id").Find(&projectIDs); err != nil {
+		return err
+	}
+	for _, projectID := range projectIDs {
+		if err := deleteViewsByProjectID(ctx, projectID); err != nil {
+			return err
+		}
+		if err := deleteFieldsByProjectID(ctx, projectID); err != nil {
+			return err
+		}
+	}
+
 	switch {
 	case setting.Database.Type.IsSQLite3():
 		if _, err := db.GetEngine(ctx).Exec("DELETE FROM project_issue WHERE project_issue.id IN (SELECT project_issue.id FROM project_issue INNER JOIN project WHERE project.id = project_issue.project_id AND project.repo_id = ?)", repoID); err != nil {
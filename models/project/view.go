@@ -0,0 +1,245 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package project
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"gitea.dev/models/db"
+	"gitea.dev/modules/timeutil"
+	"gitea.dev/modules/util"
+
+	"xorm.io/builder"
+)
+
+// ViewLayout is the way a view of a project shows its issues
+type ViewLayout string
+
+const (
+	ViewLayoutBoard ViewLayout = "board"
+	ViewLayoutTable ViewLayout = "table"
+	// ViewLayoutRoadmap places the issues on a timeline by the dates of its date field, or by their deadlines
+	ViewLayoutRoadmap ViewLayout = "roadmap"
+)
+
+// IsValid returns true if the layout is a known view layout
+func (l ViewLayout) IsValid() bool {
+	return l == ViewLayoutBoard || l == ViewLayoutTable || l == ViewLayoutRoadmap
+}
+
+// The keys a view groups its issues by, besides the fields of the project
+const (
+	ViewGroupByNone       = ""
+	ViewGroupByColumn     = "column"
+	ViewGroupByMilestone  = "milestone"
+	ViewGroupByRepository = "repository"
+)
+
+// The keys a view sorts its issues by, besides the fields of the project
+const (
+	// ViewSortByPosition keeps the order of the issues in their columns
+	ViewSortByPosition = ""
+	ViewSortByCreated  = "created"
+	ViewSortByUpdated  = "updated"
+	ViewSortByTitle    = "title"
+)
+
+// viewFieldKeyPrefix is the prefix of the group and sort keys of the fields, followed by the field ID
+const viewFieldKeyPrefix = "field:"
+
+// ViewFieldKey returns the group or sort key of a field
+func ViewFieldKey(fieldID int64) string {
+	return viewFieldKeyPrefix + strconv.FormatInt(fieldID, 10)
+}
+
+// ParseViewFieldKey returns the ID of the field of a group or sort key
+func ParseViewFieldKey(key string) (int64, bool) {
+	idStr, ok := strings.CutPrefix(key, viewFieldKeyPrefix)
+	if !ok {
+		return 0, false
+	}
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	return id, err == nil && id > 0
+}
+
+// View is a saved way to show the issues of a project
+type View struct {
+	ID        int64      `xorm:"pk autoincr"`
+	ProjectID int64      `xorm:"INDEX NOT NULL"`
+	Name      string     `xorm:"NOT NULL"`
+	Layout    ViewLayout `xorm:"VARCHAR(20) NOT NULL"`
+	GroupBy   string     `xorm:"VARCHAR(50) NOT NULL DEFAULT ''"`
+	SortBy    string     `xorm:"VARCHAR(50) NOT NULL DEFAULT ''"`
+	SortDesc  bool       `xorm:"NOT NULL DEFAULT false"`
+	// DateFieldID is the date or iteration field placing the issues of a roadmap, 0 uses their deadlines
+	DateFieldID int64 `xorm:"NOT NULL DEFAULT 0"`
+	// Sorting is the position of the view in the tabs of the project
+	Sorting int64 `xorm:"NOT NULL DEFAULT 0"`
+
+	CreatedUnix timeutil.TimeStamp `xorm:"created"`
+	UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
+}
+
+// TableName sets the table name of the project views
+func (View) TableName() string {
+	return "project_view"
+}
+
+func init() {
+	db.RegisterModel(new(View))
+}
+
+// ValidateView checks the fields of a view before it is saved, the fields it refers to must belong to its project
+func ValidateView(ctx context.Context, v *View) error {
+	v.Name = strings.TrimSpace(v.Name)
+	if v.Name == "" || utf8.RuneCountInString(v.Name) > fieldMaxNameLength {
+		return util.NewInvalidArgumentErrorf("project view name must be between 1 and %d characters", fieldMaxNameLength)
+	}
+	if !v.Layout.IsValid() {
+		return util.NewInvalidArgumentErrorf("invalid project view layout %q", v.Layout)
+	}
+
+	loadField := func(id int64) (*Field, error) {
+		f, err := GetFieldByIDAndProjectID(ctx, id, v.ProjectID)
+		if errors.Is(err, util.ErrNotExist) {
+			return nil, util.NewInvalidArgumentErrorf("project field %d doesn't exist", id)
+		}
+		return f, err
+	}
+
+	if v.Layout == ViewLayoutBoard && v.GroupBy == ViewGroupByNone {
+		v.GroupBy = ViewGroupByColumn
+	}
+	switch v.GroupBy {
+	case ViewGroupByNone, ViewGroupByMilestone, ViewGroupByRepository:
+		if v.Layout == ViewLayoutBoard {
+			return util.NewInvalidArgumentErrorf("a board can only be grouped by column, single select or iteration field")
+		}
+	case ViewGroupByColumn:
+	default:
+		fieldID, ok := ParseViewFieldKey(v.GroupBy)
+		if !ok {
+			return util.NewInvalidArgumentErrorf("invalid project view grouping %q", v.GroupBy)
+		}
+		f, err := loadField(fieldID)
+		if err != nil {
+			return err
+		}
+		if v.Layout == ViewLayoutBoard && f.Type != FieldTypeSingleSelect && f.Type != FieldTypeIteration {
+			return util.NewInvalidArgumentErrorf("a board can only be grouped by column, single select or iteration field")
+		}
+	}
+
+	switch v.SortBy {
+	case ViewSortByPosition, ViewSortByCreated, ViewSortByUpdated, ViewSortByTitle:
+	default:
+		fieldID, ok := ParseViewFieldKey(v.SortBy)
+		if !ok {
+			return util.NewInvalidArgumentErrorf("invalid project view sorting %q", v.SortBy)
+		}
+		if _, err := loadField(fieldID); err != nil {
+			return err
+		}
+	}
+
+	if v.Layout != ViewLayoutRoadmap {
+		v.DateFieldID = 0
+	} else if v.DateFieldID > 0 {
+		f, err := loadField(v.DateFieldID)
+		if err != nil {
+			return err
+		}
+		if f.Type != FieldTypeDate && f.Type != FieldTypeIteration {
+			return util.NewInvalidArgumentErrorf("a roadmap can only be placed by a date or iteration field")
+		}
+	}
+	return nil
+}
+
+// CreateView creates a view of a project, it is added after the other views
+func CreateView(ctx context.Context, v *View) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		if err := ValidateView(ctx, v); err != nil {
+			return err
+		}
+		var maxSorting int64
+		if _, err := db.GetEngine(ctx).Table("project_view").Where("project_id = ?", v.ProjectID).
+			Select("COALESCE(MAX(sorting), 0)").Get(&maxSorting); err != nil {
+			return err
+		}
+		v.Sorting = maxSorting + 1
+		return db.Insert(ctx, v)
+	})
+}
+
+// UpdateView updates the name, the layout, the grouping and the sorting of a view
+func UpdateView(ctx context.Context, v *View) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		if err := ValidateView(ctx, v); err != nil {
+			return err
+		}
+		_, err := db.GetEngine(ctx).ID(v.ID).Cols("name", "layout", "group_by", "sort_by", "sort_desc", "date_field_id", "sorting").Update(v)
+		return err
+	})
+}
+
+// DeleteView deletes a view of a project
+func DeleteView(ctx context.Context, v *View) error {
+	_, err := db.DeleteByID[View](ctx, v.ID)
+	return err
+}
+
+// GetViewByIDAndProjectID returns a view of a project
+func GetViewByIDAndProjectID(ctx context.Context, id, projectID int64) (*View, error) {
+	v, has, err := db.Get[View](ctx, builder.Eq{"id": id, "project_id": projectID})
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, fmt.Errorf("project view with id %d: %w", id, util.ErrNotExist)
+	}
+	return v, nil
+}
+
+type FindViewsOptions struct {
+	db.ListOptions
+	ProjectID int64
+}
+
+func (opts FindViewsOptions) ToConds() builder.Cond {
+	return builder.Eq{"project_id": opts.ProjectID}
+}
+
+func (opts FindViewsOptions) ToOrders() string {
+	return "sorting ASC, id ASC"
+}
+
+// resetViewsOfField makes the views grouped, sorted or placed by a field fall back to their defaults before it is deleted
+func resetViewsOfField(ctx context.Context, f *Field) error {
+	key := ViewFieldKey(f.ID)
+	if _, err := db.GetEngine(ctx).Where(builder.Eq{"project_id": f.ProjectID, "group_by": key}).
+		Cols("group_by").Update(&View{GroupBy: ViewGroupByNone}); err != nil {
+		return err
+	}
+	if _, err := db.GetEngine(ctx).Where(builder.Eq{"project_id": f.ProjectID, "layout": ViewLayoutBoard, "group_by": ViewGroupByNone}).
+		Cols("group_by").Update(&View{GroupBy: ViewGroupByColumn}); err != nil {
+		return err
+	}
+	if _, err := db.GetEngine(ctx).Where(builder.Eq{"project_id": f.ProjectID, "sort_by": key}).
+		Cols("sort_by", "sort_desc").Update(&View{SortBy: ViewSortByPosition}); err != nil {
+		return err
+	}
+	_, err := db.GetEngine(ctx).Where(builder.Eq{"project_id": f.ProjectID, "date_field_id": f.ID}).
+		Cols("date_field_id").Update(&View{})
+	return err
+}
+
+func deleteViewsByProjectID(ctx context.Context, projectID int64) error {
+	_, err := db.GetEngine(ctx).Where("project_id = ?", projectID).Delete(new(View))
+	return err
+}
@@ -0,0 +1,170 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package pull
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"gitea.dev/models/db"
+	repo_model "gitea.dev/models/repo"
+	user_model "gitea.dev/models/user"
+	"gitea.dev/modules/timeutil"
+	"gitea.dev/modules/util"
+
+	"xorm.io/builder"
+)
+
+// MergeQueueEntryStatus is the state of a pull request in the merge queue of its base branch
+type MergeQueueEntryStatus int
+
+const (
+	MergeQueueEntryStatusWaiting MergeQueueEntryStatus = iota // 0, waiting for its speculative merge to be built
+	MergeQueueEntryStatusTesting                              // 1, the speculative merge is pushed and its checks are running
+	MergeQueueEntryStatusEjected                              // 2, removed from the queue, Reason tells why
+)
+
+var mergeQueueEntryStatusNames = map[MergeQueueEntryStatus]string{
+	MergeQueueEntryStatusWaiting: "waiting",
+	MergeQueueEntryStatusTesting: "testing",
+	MergeQueueEntryStatusEjected: "ejected",
+}
+
+func (s MergeQueueEntryStatus) String() string {
+	return mergeQueueEntryStatusNames[s]
+}
+
+// MergeQueueEntry represents a pull request queued to be merged once its combination with the base branch
+// and all the pull requests queued before it passes the checks
+type MergeQueueEntry struct {
+	ID                     int64                 `xorm:"pk autoincr"`
+	RepoID                 int64                 `xorm:"INDEX(repo_branch) NOT NULL"`
+	BaseBranch             string                `xorm:"INDEX(repo_branch) VARCHAR(255) NOT NULL"`
+	PullID                 int64                 `xorm:"UNIQUE NOT NULL"`
+	DoerID                 int64                 `xorm:"INDEX NOT NULL"`
+	Doer                   *user_model.User      `xorm:"-"`
+	MergeStyle             repo_model.MergeStyle `xorm:"varchar(30)"`
+	Message                string                `xorm:"LONGTEXT"`
+	DeleteBranchAfterMerge bool
+	Status                 MergeQueueEntryStatus `xorm:"NOT NULL DEFAULT 0"`
+	// HeadCommitID is the head of the pull request the speculative merge was built from
+	HeadCommitID string `xorm:"VARCHAR(64)"`
+	// BaseCommitID is the commit the speculative merge was built onto, the base branch or the speculative merge of the previous entry
+	BaseCommitID string `xorm:"VARCHAR(64)"`
+	// MergeCommitID is the speculative merge, the base branch is fast-forwarded to it once its checks pass
+	MergeCommitID string             `xorm:"INDEX VARCHAR(64)"`
+	Reason        string             `xorm:"TEXT"`
+	TestedUnix    timeutil.TimeStamp `xorm:"INDEX"`
+	CreatedUnix   timeutil.TimeStamp `xorm:"created"`
+	UpdatedUnix   timeutil.TimeStamp `xorm:"updated"`
+}
+
+// TableName return database table name for xorm
+func (MergeQueueEntry) TableName() string {
+	return "pull_merge_queue_entry"
+}
+
+func init() {
+	db.RegisterModel(new(MergeQueueEntry))
+}
+
+// LoadDoer loads the user who added the pull request to the queue
+func (e *MergeQueueEntry) LoadDoer(ctx context.Context) (err error) {
+	if e.Doer != nil {
+		return nil
+	}
+	e.DoerID, e.Doer, err = user_model.GetPossibleUserByID(ctx, e.DoerID)
+	return err
+}
+
+// AddToMergeQueue appends a pull request to the merge queue of its base branch, an ejected entry of the pull request is replaced
+func AddToMergeQueue(ctx context.Context, entry *MergeQueueEntry) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		existing, err := GetMergeQueueEntryByPullID(ctx, entry.PullID)
+		if err == nil {
+			if existing.Status != MergeQueueEntryStatusEjected {
+				return util.NewAlreadyExistErrorf("pull request %d is already in the merge queue", entry.PullID)
+			}
+			if _, err := db.DeleteByID[MergeQueueEntry](ctx, existing.ID); err != nil {
+				return err
+			}
+		} else if !errors.Is(err, util.ErrNotExist) {
+			return err
+		}
+		entry.ID = 0
+		entry.Status = MergeQueueEntryStatusWaiting
+		return db.Insert(ctx, entry)
+	})
+}
+
+// GetMergeQueueEntryByPullID returns the merge queue entry of a pull request
+func GetMergeQueueEntryByPullID(ctx context.Context, pullID int64) (*MergeQueueEntry, error) {
+	entry, has, err := db.Get[MergeQueueEntry](ctx, builder.Eq{"pull_id": pullID})
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, fmt.Errorf("merge queue entry of pull request %d: %w", pullID, util.ErrNotExist)
+	}
+	return entry, nil
+}
+
+// UpdateMergeQueueEntry updates the given columns of a merge queue entry
+func UpdateMergeQueueEntry(ctx context.Context, entry *MergeQueueEntry, cols ...string) error {
+	_, err := db.GetEngine(ctx).ID(entry.ID).Cols(cols...).Update(entry)
+	return err
+}
+
+// DeleteMergeQueueEntry deletes a merge queue entry
+func DeleteMergeQueueEntry(ctx context.Context, entry *MergeQueueEntry) error {
+	_, err := db.DeleteByID[MergeQueueEntry](ctx, entry.ID)
+	return err
+}
+
+type FindMergeQueueEntriesOptions struct {
+	db.ListOptions
+	RepoID        int64
+	BaseBranch    string
+	MergeCommitID string
+	// Queued only finds the entries which are still waiting or testing
+	Queued bool
+}
+
+func (opts FindMergeQueueEntriesOptions) ToConds() builder.Cond {
+	cond := builder.NewCond()
+	if opts.RepoID > 0 {
+		cond = cond.And(builder.Eq{"repo_id": opts.RepoID})
+	}
+	if opts.BaseBranch != "" {
+		cond = cond.And(builder.Eq{"base_branch": opts.BaseBranch})
+	}
+	if opts.MergeCommitID != "" {
+		cond = cond.And(builder.Eq{"merge_commit_id": opts.MergeCommitID})
+	}
+	if opts.Queued {
+		cond = cond.And(builder.Neq{"status": MergeQueueEntryStatusEjected})
+	}
+	return cond
+}
+
+// ToOrders keeps the entries in the order they were queued
+func (opts FindMergeQueueEntriesOptions) ToOrders() string {
+	return "id ASC"
+}
+
+// MergeQueue identifies the merge queue of a branch
+type MergeQueue struct {
+	RepoID     int64
+	BaseBranch string
+}
+
+// GetActiveMergeQueues returns the branches which have pull requests waiting or testing in their merge queue
+func GetActiveMergeQueues(ctx context.Context) ([]MergeQueue, error) {
+	queues := make([]MergeQueue, 0, 10)
+	err := db.GetEngine(ctx).Table("pull_merge_queue_entry").
+		Where(builder.Neq{"status": MergeQueueEntryStatusEjected}).
+		Distinct("repo_id", "base_branch").
+		Find(&queues)
+	return queues, err
+}
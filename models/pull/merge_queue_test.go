@@ -0,0 +1,64 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package pull_test
+
+import (
+	"testing"
+
+	"gitea.dev/models/db"
+	pull_model "gitea.dev/models/pull"
+	repo_model "gitea.dev/models/repo"
+	"gitea.dev/models/unittest"
+	"gitea.dev/modules/util"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddToMergeQueue(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+	ctx := t.Context()
+
+	newEntry := func(pullID int64, branch string) *pull_model.MergeQueueEntry {
+		return &pull_model.MergeQueueEntry{RepoID: 1, BaseBranch: branch, PullID: pullID, DoerID: 2, MergeStyle: repo_model.MergeStyleMerge}
+	}
+	first := newEntry(2, "master")
+	require.NoError(t, pull_model.AddToMergeQueue(ctx, first))
+	second := newEntry(3, "master")
+	require.NoError(t, pull_model.AddToMergeQueue(ctx, second))
+	require.NoError(t, pull_model.AddToMergeQueue(ctx, newEntry(5, "develop")))
+	assert.ErrorIs(t, pull_model.AddToMergeQueue(ctx, newEntry(2, "master")), util.ErrAlreadyExist)
+
+	entries, err := db.Find[pull_model.MergeQueueEntry](ctx, pull_model.FindMergeQueueEntriesOptions{RepoID: 1, BaseBranch: "master", Queued: true})
+	require.NoError(t, err)
+	if assert.Len(t, entries, 2) {
+		assert.Equal(t, first.ID, entries[0].ID, "the entries are kept in the order they were queued")
+		assert.Equal(t, second.ID, entries[1].ID)
+	}
+
+	// an ejected pull request leaves the queue and can be queued again, behind the others
+	first.Status = pull_model.MergeQueueEntryStatusEjected
+	first.Reason = "the required checks failed"
+	require.NoError(t, pull_model.UpdateMergeQueueEntry(ctx, first, "status", "reason"))
+	entries, err = db.Find[pull_model.MergeQueueEntry](ctx, pull_model.FindMergeQueueEntriesOptions{RepoID: 1, BaseBranch: "master", Queued: true})
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+
+	requeued := newEntry(2, "master")
+	require.NoError(t, pull_model.AddToMergeQueue(ctx, requeued))
+	entry, err := pull_model.GetMergeQueueEntryByPullID(ctx, 2)
+	require.NoError(t, err)
+	assert.Equal(t, requeued.ID, entry.ID)
+	assert.Greater(t, entry.ID, second.ID)
+	assert.Equal(t, pull_model.MergeQueueEntryStatusWaiting, entry.Status)
+	assert.Empty(t, entry.Reason)
+
+	queues, err := pull_model.GetActiveMergeQueues(ctx)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []pull_model.MergeQueue{{RepoID: 1, BaseBranch: "master"}, {RepoID: 1, BaseBranch: "develop"}}, queues)
+
+	require.NoError(t, pull_model.DeleteMergeQueueEntry(ctx, entry))
+	_, err = pull_model.GetMergeQueueEntryByPullID(ctx, 2)
+	assert.ErrorIs(t, err, util.ErrNotExist)
+}
@@ -0,0 +1,194 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package quota
+
+import (
+	"context"
+
+	"gitea.dev/models/db"
+	"gitea.dev/modules/timeutil"
+	"gitea.dev/modules/util"
+
+	"xorm.io/builder"
+)
+
+// Group is a set of rules applied to all its members
+type Group struct {
+	ID   int64  `xorm:"pk autoincr"`
+	Name string `xorm:"UNIQUE NOT NULL"`
+
+	CreatedUnix timeutil.TimeStamp `xorm:"created"`
+}
+
+// TableName sets the table name of the quota groups
+func (Group) TableName() string {
+	return "quota_group"
+}
+
+// GroupRule attaches a rule to a group
+type GroupRule struct {
+	ID      int64 `xorm:"pk autoincr"`
+	GroupID int64 `xorm:"UNIQUE(s) NOT NULL"`
+	RuleID  int64 `xorm:"UNIQUE(s) INDEX NOT NULL"`
+}
+
+// TableName sets the table name of the rules of the groups
+func (GroupRule) TableName() string {
+	return "quota_group_rule"
+}
+
+// GroupUser is the membership of a user or an organization in a group
+type GroupUser struct {
+	ID      int64 `xorm:"pk autoincr"`
+	GroupID int64 `xorm:"UNIQUE(s) NOT NULL"`
+	UserID  int64 `xorm:"UNIQUE(s) INDEX NOT NULL"`
+}
+
+// TableName sets the table name of the group members
+func (GroupUser) TableName() string {
+	return "quota_group_user"
+}
+
+func init() {
+	db.RegisterModel(new(Group))
+	db.RegisterModel(new(GroupRule))
+	db.RegisterModel(new(GroupUser))
+}
+
+// CreateGroup creates a group
+func CreateGroup(ctx context.Context, g *Group) error {
+	name, err := validateName(g.Name, "group")
+	if err != nil {
+		return err
+	}
+	g.Name = name
+
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		exists, err := db.GetEngine(ctx).Where("name = ?", g.Name).Exist(new(Group))
+		if err != nil {
+			return err
+		} else if exists {
+			return util.NewAlreadyExistErrorf("quota group %q already exists", g.Name)
+		}
+		return db.Insert(ctx, g)
+	})
+}
+
+// GetGroupByName gets a group by its name
+func GetGroupByName(ctx context.Context, name string) (*Group, error) {
+	g := &Group{}
+	has, err := db.GetEngine(ctx).Where("name = ?", name).Get(g)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, util.NewNotExistErrorf("quota group %q does not exist", name)
+	}
+	return g, nil
+}
+
+// GetGroups gets all the groups ordered by name
+func GetGroups(ctx context.Context) ([]*Group, error) {
+	groups := make([]*Group, 0, 10)
+	return groups, db.GetEngine(ctx).OrderBy("name").Find(&groups)
+}
+
+// GetGroupsByNames gets the existing groups with the names
+func GetGroupsByNames(ctx context.Context, names []string) ([]*Group, error) {
+	groups := make([]*Group, 0, len(names))
+	if len(names) == 0 {
+		return groups, nil
+	}
+	return groups, db.GetEngine(ctx).In("name", names).OrderBy("name").Find(&groups)
+}
+
+// GetGroupsByUserID gets the groups a user or an organization is member of
+func GetGroupsByUserID(ctx context.Context, userID int64) ([]*Group, error) {
+	groups := make([]*Group, 0, 5)
+	return groups, db.GetEngine(ctx).
+		Where(builder.In("id", builder.Select("group_id").From("quota_group_user").Where(builder.Eq{"user_id": userID}))).
+		OrderBy("name").
+		Find(&groups)
+}
+
+// DeleteGroup deletes a group with its rule attachments and memberships, the rules are kept
+func DeleteGroup(ctx context.Context, id int64) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		if _, err := db.GetEngine(ctx).Where("group_id = ?", id).Delete(new(GroupRule)); err != nil {
+			return err
+		}
+		if _, err := db.GetEngine(ctx).Where("group_id = ?", id).Delete(new(GroupUser)); err != nil {
+			return err
+		}
+		_, err := db.GetEngine(ctx).ID(id).Delete(new(Group))
+		return err
+	})
+}
+
+// GetRulesByGroupID gets the rules of a group
+func GetRulesByGroupID(ctx context.Context, groupID int64) ([]*Rule, error) {
+	rules := make([]*Rule, 0, 5)
+	return rules, db.GetEngine(ctx).
+		Where(builder.In("id", builder.Select("rule_id").From("quota_group_rule").Where(builder.Eq{"group_id": groupID}))).
+		OrderBy("name").
+		Find(&rules)
+}
+
+// AddRuleToGroup attaches a rule to a group
+func AddRuleToGroup(ctx context.Context, groupID, ruleID int64) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		exists, err := db.GetEngine(ctx).Where("group_id = ? AND rule_id = ?", groupID, ruleID).Exist(new(GroupRule))
+		if err != nil {
+			return err
+		} else if exists {
+			return util.NewAlreadyExistErrorf("quota rule is already in the group")
+		}
+		return db.Insert(ctx, &GroupRule{GroupID: groupID, RuleID: ruleID})
+	})
+}
+
+// RemoveRuleFromGroup detaches a rule from a group
+func RemoveRuleFromGroup(ctx context.Context, groupID, ruleID int64) error {
+	n, err := db.GetEngine(ctx).Where("group_id = ? AND rule_id = ?", groupID, ruleID).Delete(new(GroupRule))
+	if err != nil {
+		return err
+	} else if n == 0 {
+		return util.NewNotExistErrorf("quota rule is not in the group")
+	}
+	return nil
+}
+
+// GetGroupUserIDs gets the IDs of the members of a group
+func GetGroupUserIDs(ctx context.Context, groupID int64, opts db.ListOptions) ([]int64, int64, error) {
+	sess := db.GetEngine(ctx).Table("quota_group_user").Where("group_id = ?", groupID).OrderBy("user_id")
+	if opts.PageSize > 0 {
+		db.SetSessionPagination(sess, &opts)
+	}
+	ids := make([]int64, 0, 10)
+	count, err := sess.Cols("user_id").FindAndCount(&ids)
+	return ids, count, err
+}
+
+// AddUserToGroup adds a user or an organization to a group
+func AddUserToGroup(ctx context.Context, groupID, userID int64) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		exists, err := db.GetEngine(ctx).Where("group_id = ? AND user_id = ?", groupID, userID).Exist(new(GroupUser))
+		if err != nil {
+			return err
+		} else if exists {
+			return util.NewAlreadyExistErrorf("user is already member of the quota group")
+		}
+		return db.Insert(ctx, &GroupUser{GroupID: groupID, UserID: userID})
+	})
+}
+
+// RemoveUserFromGroup removes a user or an organization from a group
+func RemoveUserFromGroup(ctx context.Context, groupID, userID int64) error {
+	n, err := db.GetEngine(ctx).Where("group_id = ? AND user_id = ?", groupID, userID).Delete(new(GroupUser))
+	if err != nil {
+		return err
+	} else if n == 0 {
+		return util.NewNotExistErrorf("user is not member of the quota group")
+	}
+	return nil
+}
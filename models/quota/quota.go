@@ -0,0 +1,135 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package quota
+
+import (
+	"fmt"
+	"slices"
+
+	"gitea.dev/modules/util"
+)
+
+// Category is a kind of storage whose usage is accounted per owner
+type Category string
+
+const (
+	CategoryGit         Category = "git"
+	CategoryLFS         Category = "lfs"
+	CategoryAttachments Category = "attachments"
+	CategoryArtifacts   Category = "artifacts"
+	CategoryPackages    Category = "packages"
+)
+
+// Categories are all the accounted categories
+var Categories = []Category{CategoryGit, CategoryLFS, CategoryAttachments, CategoryArtifacts, CategoryPackages}
+
+// Subject is what a rule limits, either a single category or a group of categories
+type Subject string
+
+const (
+	SubjectSizeAll         Subject = "size:all"
+	SubjectSizeRepos       Subject = "size:repos"
+	SubjectSizeGit         Subject = "size:git"
+	SubjectSizeLFS         Subject = "size:lfs"
+	SubjectSizeAssets      Subject = "size:assets"
+	SubjectSizeAttachments Subject = "size:attachments"
+	SubjectSizeArtifacts   Subject = "size:artifacts"
+	SubjectSizePackages    Subject = "size:packages"
+)
+
+var subjectCategories = map[Subject][]Category{
+	SubjectSizeAll:         Categories,
+	SubjectSizeRepos:       {CategoryGit, CategoryLFS},
+	SubjectSizeGit:         {CategoryGit},
+	SubjectSizeLFS:         {CategoryLFS},
+	SubjectSizeAssets:      {CategoryAttachments, CategoryArtifacts, CategoryPackages},
+	SubjectSizeAttachments: {CategoryAttachments},
+	SubjectSizeArtifacts:   {CategoryArtifacts},
+	SubjectSizePackages:    {CategoryPackages},
+}
+
+// IsValid checks if the subject is known
+func (s Subject) IsValid() bool {
+	_, ok := subjectCategories[s]
+	return ok
+}
+
+// Categories returns the categories covered by the subject
+func (s Subject) Categories() []Category {
+	return subjectCategories[s]
+}
+
+// ValidateSubjects checks the subjects of a rule
+func ValidateSubjects(subjects []Subject) error {
+	if len(subjects) == 0 {
+		return util.NewInvalidArgumentErrorf("a quota rule needs at least one subject")
+	}
+	for _, s := range subjects {
+		if !s.IsValid() {
+			return util.NewInvalidArgumentErrorf("invalid quota subject %q", s)
+		}
+	}
+	return nil
+}
+
+// ErrQuotaExceeded is returned if storing more content would exceed the quota of the owner
+type ErrQuotaExceeded struct {
+	OwnerID  int64
+	Category Category
+}
+
+func (err ErrQuotaExceeded) Error() string {
+	return fmt.Sprintf("quota exceeded [owner_id: %d, category: %s]", err.OwnerID, err.Category)
+}
+
+func (err ErrQuotaExceeded) Unwrap() error {
+	return util.ErrContentTooLarge
+}
+
+// Covers checks if the rule limits the category
+func (r *Rule) Covers(category Category) bool {
+	for _, s := range r.Subjects {
+		if slices.Contains(s.Categories(), category) {
+			return true
+		}
+	}
+	return false
+}
+
+// Used returns the usage of all the categories covered by the rule, each category is counted once
+// even if several subjects of the rule cover it
+func (r *Rule) Used(u *Usage) int64 {
+	var used int64
+	for _, c := range Categories {
+		if r.Covers(c) {
+			used += u.Get(c)
+		}
+	}
+	return used
+}
+
+// Allows checks if the rule allows to add size bytes to the category
+func (r *Rule) Allows(u *Usage, category Category, size int64) bool {
+	if r.Limit < 0 || !r.Covers(category) {
+		return true
+	}
+	return r.Used(u)+size <= r.Limit
+}
+
+// Evaluate checks if adding size bytes to the category is allowed by the rules.
+// Only the rules covering the category are considered and one of them allowing the change is enough,
+// so an owner can be granted more space by adding a larger rule. No covering rule means unlimited.
+func Evaluate(rules []*Rule, u *Usage, category Category, size int64) bool {
+	covered := false
+	for _, r := range rules {
+		if !r.Covers(category) {
+			continue
+		}
+		if r.Allows(u, category, size) {
+			return true
+		}
+		covered = true
+	}
+	return !covered
+}
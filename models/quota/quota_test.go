@@ -0,0 +1,99 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package quota
+
+import (
+	"testing"
+
+	"gitea.dev/models/db"
+	repo_model "gitea.dev/models/repo"
+	"gitea.dev/models/unittest"
+	"gitea.dev/modules/util"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEvaluate(t *testing.T) {
+	u := &Usage{GitSize: 100, LFSSize: 50, PackageSize: 30}
+
+	repos := &Rule{Limit: 200, Subjects: []Subject{SubjectSizeRepos}}
+	packages := &Rule{Limit: 40, Subjects: []Subject{SubjectSizePackages}}
+	all := &Rule{Limit: 1000, Subjects: []Subject{SubjectSizeAll, SubjectSizeGit}}
+	unlimited := &Rule{Limit: -1, Subjects: []Subject{SubjectSizePackages}}
+
+	assert.Equal(t, int64(150), repos.Used(u))
+	assert.Equal(t, int64(180), all.Used(u), "a category covered by several subjects is counted once")
+
+	assert.True(t, Evaluate([]*Rule{repos}, u, CategoryLFS, 50))
+	assert.False(t, Evaluate([]*Rule{repos}, u, CategoryLFS, 51))
+	assert.True(t, Evaluate([]*Rule{repos}, u, CategoryPackages, 1<<30), "no rule covers the category")
+	assert.False(t, Evaluate([]*Rule{repos, packages}, u, CategoryPackages, 20))
+	assert.True(t, Evaluate([]*Rule{repos, packages, all}, u, CategoryPackages, 20), "a larger rule allows the change")
+	assert.True(t, Evaluate([]*Rule{packages, unlimited}, u, CategoryPackages, 1<<30))
+	assert.True(t, Evaluate(nil, u, CategoryGit, 1<<30))
+}
+
+func TestValidateSubjects(t *testing.T) {
+	require.NoError(t, ValidateSubjects([]Subject{SubjectSizeAll, SubjectSizeArtifacts}))
+	assert.ErrorIs(t, ValidateSubjects(nil), util.ErrInvalidArgument)
+	assert.ErrorIs(t, ValidateSubjects([]Subject{"size:unknown"}), util.ErrInvalidArgument)
+}
+
+func TestRulesAndGroups(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	r := &Rule{Name: "small", Limit: 1024, Subjects: []Subject{SubjectSizeAll}}
+	require.NoError(t, CreateRule(t.Context(), r))
+	assert.ErrorIs(t, CreateRule(t.Context(), &Rule{Name: "small", Subjects: []Subject{SubjectSizeAll}}), util.ErrAlreadyExist)
+
+	g := &Group{Name: "default"}
+	require.NoError(t, CreateGroup(t.Context(), g))
+	require.NoError(t, AddRuleToGroup(t.Context(), g.ID, r.ID))
+	assert.ErrorIs(t, AddRuleToGroup(t.Context(), g.ID, r.ID), util.ErrAlreadyExist)
+	require.NoError(t, AddUserToGroup(t.Context(), g.ID, 2))
+
+	groups, err := GetGroupsByUserID(t.Context(), 2)
+	require.NoError(t, err)
+	require.Len(t, groups, 1)
+	assert.Equal(t, "default", groups[0].Name)
+
+	ids, total, err := GetGroupUserIDs(t.Context(), g.ID, db.ListOptions{})
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, total)
+	assert.Equal(t, []int64{2}, ids)
+
+	require.NoError(t, DeleteRule(t.Context(), r.ID))
+	rules, err := GetRulesByGroupID(t.Context(), g.ID)
+	require.NoError(t, err)
+	assert.Empty(t, rules)
+
+	require.NoError(t, DeleteGroup(t.Context(), g.ID))
+	unittest.AssertNotExistsBean(t, &GroupUser{GroupID: g.ID})
+	_, err = GetGroupByName(t.Context(), "default")
+	assert.ErrorIs(t, err, util.ErrNotExist)
+}
+
+func TestUpdateUsage(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	// user2 owns the LFS objects of the fixtures
+	u, err := GetUsage(t.Context(), 2)
+	require.NoError(t, err)
+	assert.Equal(t, int64(107+2048+27+25), u.LFSSize)
+	assert.Zero(t, u.GitSize)
+
+	_, err = db.GetEngine(t.Context()).ID(1).Cols("git_size").Update(&repo_model.Repository{GitSize: 4096})
+	require.NoError(t, err)
+
+	u, err = GetUsage(t.Context(), 2)
+	require.NoError(t, err)
+	assert.Zero(t, u.GitSize, "the usage is only recalculated when it is updated")
+
+	u, err = UpdateUsage(t.Context(), 2, CategoryGit)
+	require.NoError(t, err)
+	assert.Equal(t, int64(4096), u.GitSize)
+	assert.Equal(t, int64(2207), u.LFSSize)
+	assert.Equal(t, int64(4096+2207), u.Total())
+}
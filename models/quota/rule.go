@@ -0,0 +1,161 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package quota
+
+import (
+	"context"
+	"strings"
+	"unicode/utf8"
+
+	"gitea.dev/models/db"
+	"gitea.dev/modules/timeutil"
+	"gitea.dev/modules/util"
+
+	"xorm.io/builder"
+)
+
+const nameMaxLength = 100
+
+// Rule limits the size of the subjects, a negative limit means unlimited
+type Rule struct {
+	ID       int64     `xorm:"pk autoincr"`
+	Name     string    `xorm:"UNIQUE NOT NULL"`
+	Limit    int64     `xorm:"NOT NULL DEFAULT -1"`
+	Subjects []Subject `xorm:"JSON TEXT"`
+
+	CreatedUnix timeutil.TimeStamp `xorm:"created"`
+	UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
+}
+
+// TableName sets the table name of the quota rules
+func (Rule) TableName() string {
+	return "quota_rule"
+}
+
+// UserRule attaches a rule directly to a user or an organization
+type UserRule struct {
+	ID     int64 `xorm:"pk autoincr"`
+	UserID int64 `xorm:"UNIQUE(s) NOT NULL"`
+	RuleID int64 `xorm:"UNIQUE(s) INDEX NOT NULL"`
+}
+
+// TableName sets the table name of the rules attached to users
+func (UserRule) TableName() string {
+	return "quota_user_rule"
+}
+
+func init() {
+	db.RegisterModel(new(Rule))
+	db.RegisterModel(new(UserRule))
+}
+
+func validateName(name, kind string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || utf8.RuneCountInString(name) > nameMaxLength {
+		return "", util.NewInvalidArgumentErrorf("quota %s name must be between 1 and %d characters", kind, nameMaxLength)
+	}
+	return name, nil
+}
+
+// CreateRule creates a rule
+func CreateRule(ctx context.Context, r *Rule) error {
+	name, err := validateName(r.Name, "rule")
+	if err != nil {
+		return err
+	}
+	r.Name = name
+	if err := ValidateSubjects(r.Subjects); err != nil {
+		return err
+	}
+	if r.Limit < 0 {
+		r.Limit = -1
+	}
+
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		exists, err := db.GetEngine(ctx).Where("name = ?", r.Name).Exist(new(Rule))
+		if err != nil {
+			return err
+		} else if exists {
+			return util.NewAlreadyExistErrorf("quota rule %q already exists", r.Name)
+		}
+		return db.Insert(ctx, r)
+	})
+}
+
+// UpdateRule updates the limit and the subjects of a rule
+func UpdateRule(ctx context.Context, r *Rule) error {
+	if err := ValidateSubjects(r.Subjects); err != nil {
+		return err
+	}
+	if r.Limit < 0 {
+		r.Limit = -1
+	}
+	_, err := db.GetEngine(ctx).ID(r.ID).Cols("limit", "subjects").Update(r)
+	return err
+}
+
+// GetRuleByName gets a rule by its name
+func GetRuleByName(ctx context.Context, name string) (*Rule, error) {
+	r := &Rule{}
+	has, err := db.GetEngine(ctx).Where("name = ?", name).Get(r)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, util.NewNotExistErrorf("quota rule %q does not exist", name)
+	}
+	return r, nil
+}
+
+// GetRules gets all the rules ordered by name
+func GetRules(ctx context.Context) ([]*Rule, error) {
+	rules := make([]*Rule, 0, 10)
+	return rules, db.GetEngine(ctx).OrderBy("name").Find(&rules)
+}
+
+// DeleteRule deletes a rule and detaches it from the groups and users
+func DeleteRule(ctx context.Context, id int64) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		if _, err := db.GetEngine(ctx).Where("rule_id = ?", id).Delete(new(GroupRule)); err != nil {
+			return err
+		}
+		if _, err := db.GetEngine(ctx).Where("rule_id = ?", id).Delete(new(UserRule)); err != nil {
+			return err
+		}
+		_, err := db.GetEngine(ctx).ID(id).Delete(new(Rule))
+		return err
+	})
+}
+
+// AddRuleToUser attaches a rule directly to a user or an organization
+func AddRuleToUser(ctx context.Context, userID, ruleID int64) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		exists, err := db.GetEngine(ctx).Where("user_id = ? AND rule_id = ?", userID, ruleID).Exist(new(UserRule))
+		if err != nil {
+			return err
+		} else if exists {
+			return util.NewAlreadyExistErrorf("quota rule is already attached to the user")
+		}
+		return db.Insert(ctx, &UserRule{UserID: userID, RuleID: ruleID})
+	})
+}
+
+// RemoveRuleFromUser detaches a rule from a user or an organization
+func RemoveRuleFromUser(ctx context.Context, userID, ruleID int64) error {
+	n, err := db.GetEngine(ctx).Where("user_id = ? AND rule_id = ?", userID, ruleID).Delete(new(UserRule))
+	if err != nil {
+		return err
+	} else if n == 0 {
+		return util.NewNotExistErrorf("quota rule is not attached to the user")
+	}
+	return nil
+}
+
+// GetRulesByUserID gets the rules attached directly to a user or an organization
+func GetRulesByUserID(ctx context.Context, userID int64) ([]*Rule, error) {
+	rules := make([]*Rule, 0, 5)
+	return rules, db.GetEngine(ctx).
+		Where(builder.In("id", builder.Select("rule_id").From("quota_user_rule").Where(builder.Eq{"user_id": userID}))).
+		OrderBy("name").
+		Find(&rules)
+}
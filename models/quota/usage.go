@@ -0,0 +1,189 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package quota
+
+import (
+	"context"
+	"fmt"
+
+	actions_model "gitea.dev/models/actions"
+	"gitea.dev/models/db"
+	git_model "gitea.dev/models/git"
+	packages_model "gitea.dev/models/packages"
+	repo_model "gitea.dev/models/repo"
+	"gitea.dev/modules/timeutil"
+
+	"xorm.io/builder"
+)
+
+// Usage is the storage used by a user or an organization, it is updated for the affected categories
+// when content is added or removed, so the quota checks don't need to sum up all the content of the owner
+type Usage struct {
+	UserID         int64 `xorm:"pk"`
+	GitSize        int64 `xorm:"NOT NULL DEFAULT 0"`
+	LFSSize        int64 `xorm:"NOT NULL DEFAULT 0"`
+	AttachmentSize int64 `xorm:"NOT NULL DEFAULT 0"`
+	ArtifactSize   int64 `xorm:"NOT NULL DEFAULT 0"`
+	PackageSize    int64 `xorm:"NOT NULL DEFAULT 0"`
+
+	UpdatedUnix timeutil.TimeStamp `xorm:"updated INDEX"`
+}
+
+// TableName sets the table name of the usages
+func (Usage) TableName() string {
+	return "quota_usage"
+}
+
+func init() {
+	db.RegisterModel(new(Usage))
+}
+
+var categoryColumns = map[Category]string{
+	CategoryGit:         "git_size",
+	CategoryLFS:         "lfs_size",
+	CategoryAttachments: "attachment_size",
+	CategoryArtifacts:   "artifact_size",
+	CategoryPackages:    "package_size",
+}
+
+// usageTotalExpr sums up the usage columns, used to sort the owners by their usage
+const usageTotalExpr = "git_size + lfs_size + attachment_size + artifact_size + package_size"
+
+// Get returns the usage of a category
+func (u *Usage) Get(c Category) int64 {
+	switch c {
+	case CategoryGit:
+		return u.GitSize
+	case CategoryLFS:
+		return u.LFSSize
+	case CategoryAttachments:
+		return u.AttachmentSize
+	case CategoryArtifacts:
+		return u.ArtifactSize
+	case CategoryPackages:
+		return u.PackageSize
+	}
+	return 0
+}
+
+func (u *Usage) set(c Category, size int64) {
+	switch c {
+	case CategoryGit:
+		u.GitSize = size
+	case CategoryLFS:
+		u.LFSSize = size
+	case CategoryAttachments:
+		u.AttachmentSize = size
+	case CategoryArtifacts:
+		u.ArtifactSize = size
+	case CategoryPackages:
+		u.PackageSize = size
+	}
+}
+
+// Total returns the usage of all the categories
+func (u *Usage) Total() int64 {
+	return u.GitSize + u.LFSSize + u.AttachmentSize + u.ArtifactSize + u.PackageSize
+}
+
+// calculate sums up the content of a category owned by the user
+func calculate(ctx context.Context, userID int64, c Category) (int64, error) {
+	e := db.GetEngine(ctx)
+	switch c {
+	case CategoryGit:
+		return e.Table("repository").Where("owner_id = ?", userID).SumInt(new(repo_model.Repository), "git_size")
+	case CategoryLFS:
+		return e.Table("lfs_meta_object").
+			Join("INNER", "repository", "repository.id = lfs_meta_object.repository_id").
+			Where("repository.owner_id = ?", userID).
+			SumInt(new(git_model.LFSMetaObject), "lfs_meta_object.size")
+	case CategoryAttachments:
+		return e.Table("attachment").
+			Join("INNER", "repository", "repository.id = attachment.repo_id").
+			Where("repository.owner_id = ?", userID).
+			SumInt(new(repo_model.Attachment), "attachment.size")
+	case CategoryArtifacts:
+		// expired and deleted artifacts don't use storage anymore
+		return e.Table("action_artifact").
+			Where(builder.Eq{"owner_id": userID}.And(builder.In("status",
+				actions_model.ArtifactStatusUploadPending,
+				actions_model.ArtifactStatusUploadConfirmed,
+				actions_model.ArtifactStatusPendingDeletion,
+			))).
+			SumInt(new(actions_model.ActionArtifact), "file_compressed_size")
+	case CategoryPackages:
+		return packages_model.CalculateFileSize(ctx, &packages_model.PackageFileSearchOptions{OwnerID: userID})
+	}
+	return 0, fmt.Errorf("unknown quota category %q", c)
+}
+
+// UpdateUsage recalculates the usage of the categories of a user or an organization.
+// All the categories are calculated if none is given or if the usage has not been recorded yet.
+func UpdateUsage(ctx context.Context, userID int64, categories ...Category) (*Usage, error) {
+	u := &Usage{UserID: userID}
+	return u, db.WithTx(ctx, func(ctx context.Context) error {
+		has, err := db.GetEngine(ctx).Where("user_id = ?", userID).Exist(new(Usage))
+		if err != nil {
+			return err
+		}
+		if !has || len(categories) == 0 {
+			categories = Categories
+		}
+
+		cols := make([]string, 0, len(categories))
+		for _, c := range categories {
+			size, err := calculate(ctx, userID, c)
+			if err != nil {
+				return err
+			}
+			u.set(c, size)
+			cols = append(cols, categoryColumns[c])
+		}
+
+		if !has {
+			return db.Insert(ctx, u)
+		}
+		if _, err := db.GetEngine(ctx).Where("user_id = ?", userID).Cols(cols...).Update(u); err != nil {
+			return err
+		}
+		_, err = db.GetEngine(ctx).Where("user_id = ?", userID).Get(u)
+		return err
+	})
+}
+
+// GetUsage gets the usage of a user or an organization, it is calculated if it has not been recorded yet
+func GetUsage(ctx context.Context, userID int64) (*Usage, error) {
+	u := &Usage{}
+	has, err := db.GetEngine(ctx).Where("user_id = ?", userID).Get(u)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return UpdateUsage(ctx, userID)
+	}
+	return u, nil
+}
+
+// FindUsagesOptions are the options to list the usages
+type FindUsagesOptions struct {
+	db.ListOptions
+}
+
+// FindUsages lists the usages, the largest first
+func FindUsages(ctx context.Context, opts FindUsagesOptions) ([]*Usage, int64, error) {
+	sess := db.GetEngine(ctx).OrderBy(usageTotalExpr + " DESC, user_id")
+	if opts.PageSize > 0 {
+		db.SetSessionPagination(sess, &opts)
+	}
+	usages := make([]*Usage, 0, 10)
+	count, err := sess.FindAndCount(&usages)
+	return usages, count, err
+}
+
+// DeleteOrphanedUsages removes the usages of deleted users and organizations
+func DeleteOrphanedUsages(ctx context.Context) error {
+	_, err := db.GetEngine(ctx).
+		Where(builder.NotIn("user_id", builder.Select("id").From("`user`"))).
+		Delete(new(Usage))
+	return err
+}
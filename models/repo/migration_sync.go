@@ -0,0 +1,172 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repo
+
+import (
+	"context"
+	"time"
+
+	"gitea.dev/models/db"
+	"gitea.dev/modules/json"
+	"gitea.dev/modules/log"
+	"gitea.dev/modules/migration"
+	"gitea.dev/modules/secret"
+	"gitea.dev/modules/setting"
+	"gitea.dev/modules/timeutil"
+	"gitea.dev/modules/util"
+
+	"xorm.io/builder"
+)
+
+// ErrMigrationSyncNotExist migration sync does not exist error
+var ErrMigrationSyncNotExist = util.NewNotExistErrorf("Migration sync does not exist")
+
+// MigrationSync represents the periodic re-sync of the issues, pull requests, comments, reviews and releases
+// of a migrated repository with its source, so the old and the new forge can be used in parallel.
+type MigrationSync struct {
+	ID     int64       `xorm:"pk autoincr"`
+	RepoID int64       `xorm:"UNIQUE NOT NULL"`
+	Repo   *Repository `xorm:"-"`
+	DoerID int64       `xorm:"NOT NULL"`
+
+	// PayloadContent is the JSON of the migration options, the credentials are encrypted
+	PayloadContent string `xorm:"LONGTEXT"`
+
+	// LastSyncUnix is the cursor of the sync, the source items updated after it are synced again
+	LastSyncUnix timeutil.TimeStamp `xorm:"NOT NULL DEFAULT 0"`
+	NextSyncUnix timeutil.TimeStamp `xorm:"INDEX"`
+	LastError    string             `xorm:"TEXT"`
+
+	CreatedUnix timeutil.TimeStamp `xorm:"created"`
+	UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
+}
+
+func init() {
+	db.RegisterModel(new(MigrationSync))
+	db.RegisterModel(new(ForeignReference))
+}
+
+// LoadRepo loads the repository of the migration sync
+func (s *MigrationSync) LoadRepo(ctx context.Context) (err error) {
+	if s.Repo != nil {
+		return nil
+	}
+	s.Repo, err = GetRepositoryByID(ctx, s.RepoID)
+	return err
+}
+
+// ScheduleNextSync sets the next sync time according to the configured interval.
+func (s *MigrationSync) ScheduleNextSync() {
+	s.NextSyncUnix = timeutil.TimeStampNow().AddDuration(setting.Migrations.SyncInterval)
+}
+
+// MigrateConfig returns the migration options with the decrypted credentials
+func (s *MigrationSync) MigrateConfig() (*migration.MigrateOptions, error) {
+	var opts migration.MigrateOptions
+	if err := json.Unmarshal([]byte(s.PayloadContent), &opts); err != nil {
+		return nil, err
+	}
+
+	var err error
+	if opts.CloneAddrEncrypted != "" {
+		if opts.CloneAddr, err = secret.DecryptSecret(setting.SecretKey, opts.CloneAddrEncrypted); err != nil {
+			log.Error("Unable to decrypt CloneAddr, maybe SECRET_KEY is wrong: %v", err)
+		}
+	}
+	if opts.AuthPasswordEncrypted != "" {
+		if opts.AuthPassword, err = secret.DecryptSecret(setting.SecretKey, opts.AuthPasswordEncrypted); err != nil {
+			log.Error("Unable to decrypt AuthPassword, maybe SECRET_KEY is wrong: %v", err)
+		}
+	}
+	if opts.AuthTokenEncrypted != "" {
+		if opts.AuthToken, err = secret.DecryptSecret(setting.SecretKey, opts.AuthTokenEncrypted); err != nil {
+			log.Error("Unable to decrypt AuthToken, maybe SECRET_KEY is wrong: %v", err)
+		}
+	}
+	if opts.AWSSecretAccessKeyEncrypted != "" {
+		if opts.AWSSecretAccessKey, err = secret.DecryptSecret(setting.SecretKey, opts.AWSSecretAccessKeyEncrypted); err != nil {
+			log.Error("Unable to decrypt AWSSecretAccessKey, maybe SECRET_KEY is wrong: %v", err)
+		}
+	}
+	return &opts, nil
+}
+
+// GetMigrationSyncByRepoID returns the migration sync of a repository
+func GetMigrationSyncByRepoID(ctx context.Context, repoID int64) (*MigrationSync, error) {
+	s, has, err := db.Get[MigrationSync](ctx, builder.Eq{"repo_id": repoID})
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, ErrMigrationSyncNotExist
+	}
+	return s, nil
+}
+
+// InsertMigrationSync inserts a migration sync
+func InsertMigrationSync(ctx context.Context, s *MigrationSync) error {
+	return db.Insert(ctx, s)
+}
+
+// UpdateMigrationSyncCols updates the given columns of a migration sync
+func UpdateMigrationSyncCols(ctx context.Context, s *MigrationSync, cols ...string) error {
+	_, err := db.GetEngine(ctx).ID(s.ID).Cols(cols...).Update(s)
+	return err
+}
+
+// DeleteMigrationSyncByRepoID stops the sync of a repository and forgets the references to the source items
+func DeleteMigrationSyncByRepoID(ctx context.Context, repoID int64) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		if _, err := db.GetEngine(ctx).Delete(&MigrationSync{RepoID: repoID}); err != nil {
+			return err
+		}
+		_, err := db.GetEngine(ctx).Delete(&ForeignReference{RepoID: repoID})
+		return err
+	})
+}
+
+// FindDueMigrationSyncs returns the migration syncs which are due, the least recently synced first
+func FindDueMigrationSyncs(ctx context.Context, limit int) ([]*MigrationSync, error) {
+	sess := db.GetEngine(ctx).
+		Where("next_sync_unix<=?", time.Now().Unix()).
+		OrderBy("next_sync_unix ASC")
+	if limit > 0 {
+		sess = sess.Limit(limit)
+	}
+	syncs := make([]*MigrationSync, 0, 10)
+	return syncs, sess.Find(&syncs)
+}
+
+// Foreign reference types
+const (
+	ForeignReferenceTypeComment = "comment"
+	ForeignReferenceTypeReview  = "review"
+)
+
+// ForeignReference maps the ID of an item on the source of a migration to the ID of the local item,
+// for the items which can't be matched by their index or name when the repository is synced again.
+type ForeignReference struct {
+	ID        int64  `xorm:"pk autoincr"`
+	RepoID    int64  `xorm:"UNIQUE(s) NOT NULL"`
+	Type      string `xorm:"VARCHAR(16) UNIQUE(s) NOT NULL"`
+	ForeignID int64  `xorm:"UNIQUE(s) NOT NULL"`
+	LocalID   int64  `xorm:"INDEX NOT NULL"`
+}
+
+// GetForeignReferenceLocalID returns the local ID of a source item, 0 if it is unknown
+func GetForeignReferenceLocalID(ctx context.Context, repoID int64, tp string, foreignID int64) (int64, error) {
+	ref, has, err := db.Get[ForeignReference](ctx, builder.Eq{"repo_id": repoID, "`type`": tp, "foreign_id": foreignID})
+	if err != nil || !has {
+		return 0, err
+	}
+	return ref.LocalID, nil
+}
+
+// InsertForeignReferences inserts the references of newly created local items
+func InsertForeignReferences(ctx context.Context, refs ...*ForeignReference) error {
+	if len(refs) == 0 {
+		return nil
+	}
+	_, err := db.GetEngine(ctx).Insert(refs)
+	return err
+}
@@ -0,0 +1,49 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repo_test
+
+import (
+	"testing"
+
+	repo_model "gitea.dev/models/repo"
+	"gitea.dev/models/unittest"
+	"gitea.dev/modules/timeutil"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigrationSync(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	now := timeutil.TimeStampNow()
+	require.NoError(t, repo_model.InsertMigrationSync(t.Context(), &repo_model.MigrationSync{RepoID: 1, DoerID: 2, PayloadContent: "{}", NextSyncUnix: now - 10}))
+	require.NoError(t, repo_model.InsertMigrationSync(t.Context(), &repo_model.MigrationSync{RepoID: 2, DoerID: 2, PayloadContent: "{}", NextSyncUnix: now + 3600}))
+
+	syncs, err := repo_model.FindDueMigrationSyncs(t.Context(), 10)
+	require.NoError(t, err)
+	if assert.Len(t, syncs, 1) {
+		assert.EqualValues(t, 1, syncs[0].RepoID)
+	}
+
+	require.NoError(t, repo_model.InsertForeignReferences(t.Context(),
+		&repo_model.ForeignReference{RepoID: 1, Type: repo_model.ForeignReferenceTypeComment, ForeignID: 100, LocalID: 1},
+		&repo_model.ForeignReference{RepoID: 1, Type: repo_model.ForeignReferenceTypeReview, ForeignID: 100, LocalID: 2},
+	))
+
+	localID, err := repo_model.GetForeignReferenceLocalID(t.Context(), 1, repo_model.ForeignReferenceTypeReview, 100)
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, localID)
+
+	localID, err = repo_model.GetForeignReferenceLocalID(t.Context(), 1, repo_model.ForeignReferenceTypeComment, 101)
+	require.NoError(t, err)
+	assert.Zero(t, localID)
+
+	require.NoError(t, repo_model.DeleteMigrationSyncByRepoID(t.Context(), 1))
+	_, err = repo_model.GetMigrationSyncByRepoID(t.Context(), 1)
+	assert.ErrorIs(t, err, repo_model.ErrMigrationSyncNotExist)
+	localID, err = repo_model.GetForeignReferenceLocalID(t.Context(), 1, repo_model.ForeignReferenceTypeComment, 100)
+	require.NoError(t, err)
+	assert.Zero(t, localID)
+}
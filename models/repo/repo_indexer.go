@@ -23,12 +23,14 @@ const (
 )
 
 // RepoIndexerStatus status of a repo's entry in the repo indexer
-// For now, implicitly refers to default branch
+// The status of the default branch has an empty RefName, the code indexer
+// keeps one more status for every additional ref it indexes.
 type RepoIndexerStatus struct { //revive:disable-line:exported
 	ID          int64           `xorm:"pk autoincr"`
 	RepoID      int64           `xorm:"INDEX(s)"`
 	CommitSha   string          `xorm:"VARCHAR(64)"`
 	IndexerType RepoIndexerType `xorm:"INDEX(s) NOT NULL DEFAULT 0"`
+	RefName     string          `xorm:"VARCHAR(255) NOT NULL DEFAULT ''"`
 }
 
 func init() {
@@ -75,7 +77,7 @@ func GetIndexerStatus(ctx context.Context, repo *Repository, indexerType RepoInd
 		}
 	}
 	status := &RepoIndexerStatus{RepoID: repo.ID}
-	if has, err := db.GetEngine(ctx).Where("`indexer_type` = ?", indexerType).Get(status); err != nil {
+	if has, err := db.GetEngine(ctx).Where("`indexer_type` = ? AND ref_name = ''", indexerType).Get(status); err != nil {
 		return nil, err
 	} else if !has {
 		status.IndexerType = indexerType
@@ -112,3 +114,27 @@ func UpdateIndexerStatus(ctx context.Context, repo *Repository, indexerType Repo
 	}
 	return nil
 }
+
+// GetIndexerRefStatuses returns the statuses of the additional refs indexed for a repository
+func GetIndexerRefStatuses(ctx context.Context, repoID int64, indexerType RepoIndexerType) ([]*RepoIndexerStatus, error) {
+	statuses := make([]*RepoIndexerStatus, 0, 5)
+	return statuses, db.GetEngine(ctx).
+		Where("repo_id = ? AND `indexer_type` = ? AND ref_name <> ''", repoID, indexerType).
+		OrderBy("ref_name").
+		Find(&statuses)
+}
+
+// UpdateIndexerRefStatus inserts or updates the status of an additional ref
+func UpdateIndexerRefStatus(ctx context.Context, status *RepoIndexerStatus) error {
+	if status.ID == 0 {
+		return db.Insert(ctx, status)
+	}
+	_, err := db.GetEngine(ctx).ID(status.ID).Cols("commit_sha").Update(status)
+	return err
+}
+
+// DeleteIndexerRefStatus deletes the status of an additional ref which is not indexed anymore
+func DeleteIndexerRefStatus(ctx context.Context, status *RepoIndexerStatus) error {
+	_, err := db.GetEngine(ctx).ID(status.ID).Delete(new(RepoIndexerStatus))
+	return err
+}
@@ -0,0 +1,365 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package secretscan
+
+import (
+	"context"
+	"fmt"
+
+	audit_model "gitea.dev/models/audit"
+	"gitea.dev/models/db"
+	user_model "gitea.dev/models/user"
+	"gitea.dev/modules/timeutil"
+	"gitea.dev/modules/util"
+
+	"xorm.io/builder"
+)
+
+// AlertState is the state of a secret scanning alert
+type AlertState string
+
+const (
+	AlertStateOpen     AlertState = "open"
+	AlertStateResolved AlertState = "resolved"
+)
+
+// IsValid returns true if the state is a known alert state
+func (s AlertState) IsValid() bool {
+	return s == AlertStateOpen || s == AlertStateResolved
+}
+
+// AlertResolution tells why an alert was resolved
+type AlertResolution string
+
+const (
+	AlertResolutionNone          AlertResolution = ""
+	AlertResolutionFalsePositive AlertResolution = "false_positive"
+	AlertResolutionRevoked       AlertResolution = "revoked"
+	AlertResolutionUsedInTests   AlertResolution = "used_in_tests"
+	AlertResolutionWontFix       AlertResolution = "wont_fix"
+)
+
+// IsValid returns true if the resolution is a known alert resolution
+func (r AlertResolution) IsValid() bool {
+	switch r {
+	case AlertResolutionFalsePositive, AlertResolutionRevoked, AlertResolutionUsedInTests, AlertResolutionWontFix:
+		return true
+	}
+	return false
+}
+
+// BypassReason is given by the repository administrators pushing secrets despite the push protection
+type BypassReason string
+
+const (
+	BypassReasonFalsePositive BypassReason = "false_positive"
+	BypassReasonUsedInTests   BypassReason = "used_in_tests"
+	BypassReasonWillFixLater  BypassReason = "will_fix_later"
+)
+
+// BypassReasons are the reasons accepted to bypass the push protection
+var BypassReasons = []BypassReason{BypassReasonFalsePositive, BypassReasonUsedInTests, BypassReasonWillFixLater}
+
+// IsValid returns true if the reason is a known bypass reason
+func (r BypassReason) IsValid() bool {
+	return r == BypassReasonFalsePositive || r == BypassReasonUsedInTests || r == BypassReasonWillFixLater
+}
+
+// Resolution returns the resolution of the alerts of the bypassed secrets, the secrets to be fixed later stay open
+func (r BypassReason) Resolution() AlertResolution {
+	switch r {
+	case BypassReasonFalsePositive:
+		return AlertResolutionFalsePositive
+	case BypassReasonUsedInTests:
+		return AlertResolutionUsedInTests
+	}
+	return AlertResolutionNone
+}
+
+// Alert is a secret found in a repository, a secret has one alert per repository wherever it is found
+type Alert struct {
+	ID     int64 `xorm:"pk autoincr"`
+	RepoID int64 `xorm:"UNIQUE(s) NOT NULL"`
+	// SecretHash is the SHA-256 of the secret, the secret itself is never stored
+	SecretHash     string `xorm:"UNIQUE(s) VARCHAR(64) NOT NULL"`
+	PatternID      string `xorm:"INDEX VARCHAR(100) NOT NULL"`
+	PatternName    string `xorm:"NOT NULL"`
+	SecretRedacted string `xorm:"VARCHAR(255) NOT NULL DEFAULT ''"`
+	// CommitID, Path and Line locate the secret in the oldest commit known to add it
+	CommitID string `xorm:"VARCHAR(64) NOT NULL DEFAULT ''"`
+	Path     string `xorm:"TEXT"`
+	Line     int    `xorm:"NOT NULL DEFAULT 0"`
+
+	State             AlertState         `xorm:"VARCHAR(20) INDEX NOT NULL DEFAULT 'open'"`
+	Resolution        AlertResolution    `xorm:"VARCHAR(20) NOT NULL DEFAULT ''"`
+	ResolutionComment string             `xorm:"TEXT"`
+	ResolverID        int64              `xorm:"NOT NULL DEFAULT 0"`
+	Resolver          *user_model.User   `xorm:"-"`
+	ResolvedUnix      timeutil.TimeStamp `xorm:"NOT NULL DEFAULT 0"`
+
+	// PushProtectionBypassed is set when the secret was pushed despite the push protection
+	PushProtectionBypassed bool               `xorm:"NOT NULL DEFAULT false"`
+	BypasserID             int64              `xorm:"NOT NULL DEFAULT 0"`
+	Bypasser               *user_model.User   `xorm:"-"`
+	BypassedUnix           timeutil.TimeStamp `xorm:"NOT NULL DEFAULT 0"`
+
+	CreatedUnix timeutil.TimeStamp `xorm:"created"`
+	UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
+}
+
+// TableName sets the table name of the secret scanning alerts
+func (Alert) TableName() string {
+	return "secret_scanning_alert"
+}
+
+// AlertEventType is the kind of change recorded in the audit trail of an alert
+type AlertEventType string
+
+const (
+	AlertEventCreated  AlertEventType = "created"
+	AlertEventBypassed AlertEventType = "bypassed"
+	AlertEventResolved AlertEventType = "resolved"
+	AlertEventReopened AlertEventType = "reopened"
+)
+
+// AlertEvent is an entry of the audit trail of an alert
+type AlertEvent struct {
+	ID      int64 `xorm:"pk autoincr"`
+	AlertID int64 `xorm:"INDEX NOT NULL"`
+	RepoID  int64 `xorm:"INDEX NOT NULL"`
+	// DoerID is 0 for the alerts created by the scans of the history
+	DoerID int64            `xorm:"NOT NULL DEFAULT 0"`
+	Doer   *user_model.User `xorm:"-"`
+	Type   AlertEventType   `xorm:"VARCHAR(20) NOT NULL"`
+	// Reason is the resolution of a resolved event or the reason of a bypassed event
+	Reason      string             `xorm:"VARCHAR(20) NOT NULL DEFAULT ''"`
+	Comment     string             `xorm:"TEXT"`
+	CreatedUnix timeutil.TimeStamp `xorm:"created"`
+}
+
+// TableName sets the table name of the events of the secret scanning alerts
+func (AlertEvent) TableName() string {
+	return "secret_scanning_alert_event"
+}
+
+func init() {
+	db.RegisterModel(new(Alert))
+	db.RegisterModel(new(AlertEvent))
+}
+
+// LoadAttributes loads the users who resolved and bypassed the alert
+func (a *Alert) LoadAttributes(ctx context.Context) (err error) {
+	if a.ResolverID != 0 && a.Resolver == nil {
+		if a.ResolverID, a.Resolver, err = user_model.GetPossibleUserByID(ctx, a.ResolverID); err != nil {
+			return err
+		}
+	}
+	if a.BypasserID != 0 && a.Bypasser == nil {
+		if a.BypasserID, a.Bypasser, err = user_model.GetPossibleUserByID(ctx, a.BypasserID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func addAlertEvent(ctx context.Context, a *Alert, doerID int64, typ AlertEventType, reason, comment string) error {
+	return db.Insert(ctx, &AlertEvent{AlertID: a.ID, RepoID: a.RepoID, DoerID: doerID, Type: typ, Reason: reason, Comment: comment})
+}
+
+func getAlertBySecretHash(ctx context.Context, repoID int64, secretHash string) (*Alert, bool, error) {
+	return db.Get[Alert](ctx, builder.Eq{"repo_id": repoID, "secret_hash": secretHash})
+}
+
+// CreateAlertIfNotExist creates the alert of a secret found by a scan, nothing is done if the secret already has an alert
+func CreateAlertIfNotExist(ctx context.Context, a *Alert) (bool, error) {
+	created := false
+	err := db.WithTx(ctx, func(ctx context.Context) error {
+		if _, has, err := getAlertBySecretHash(ctx, a.RepoID, a.SecretHash); err != nil || has {
+			return err
+		}
+		a.State, a.Resolution = AlertStateOpen, AlertResolutionNone
+		if err := db.Insert(ctx, a); err != nil {
+			return err
+		}
+		created = true
+		return addAlertEvent(ctx, a, 0, AlertEventCreated, "", "")
+	})
+	return created, err
+}
+
+// BypassPushProtection records that a secret was pushed despite the push protection, its alert is created if it has none
+// and it is resolved unless the secret is to be fixed later
+func BypassPushProtection(ctx context.Context, a *Alert, doerID int64, reason BypassReason) (*Alert, error) {
+	if !reason.IsValid() {
+		return nil, util.NewInvalidArgumentErrorf("invalid bypass reason %q", reason)
+	}
+	err := db.WithTx(ctx, func(ctx context.Context) error {
+		existing, has, err := getAlertBySecretHash(ctx, a.RepoID, a.SecretHash)
+		if err != nil {
+			return err
+		}
+		if has {
+			a = existing
+		} else {
+			a.State, a.Resolution = AlertStateOpen, AlertResolutionNone
+			if err := db.Insert(ctx, a); err != nil {
+				return err
+			}
+			if err := addAlertEvent(ctx, a, doerID, AlertEventCreated, "", ""); err != nil {
+				return err
+			}
+		}
+
+		a.PushProtectionBypassed, a.BypasserID, a.BypassedUnix = true, doerID, timeutil.TimeStampNow()
+		cols := []string{"push_protection_bypassed", "bypasser_id", "bypassed_unix"}
+		if resolution := reason.Resolution(); resolution != AlertResolutionNone && a.State == AlertStateOpen {
+			a.State, a.Resolution, a.ResolverID, a.ResolvedUnix = AlertStateResolved, resolution, doerID, a.BypassedUnix
+			cols = append(cols, "state", "resolution", "resolver_id", "resolved_unix")
+		}
+		if _, err := db.GetEngine(ctx).ID(a.ID).Cols(cols...).Update(a); err != nil {
+			return err
+		}
+		return addAlertEvent(ctx, a, doerID, AlertEventBypassed, string(reason), "")
+	})
+	return a, err
+}
+
+// ResolveAlert closes an alert, an alert already resolved gets the new resolution
+func ResolveAlert(ctx context.Context, a *Alert, doerID int64, resolution AlertResolution, comment string) error {
+	if !resolution.IsValid() {
+		return util.NewInvalidArgumentErrorf("invalid resolution %q", resolution)
+	}
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		a.State, a.Resolution, a.ResolutionComment = AlertStateResolved, resolution, comment
+		a.ResolverID, a.ResolvedUnix, a.Resolver = doerID, timeutil.TimeStampNow(), nil
+		if _, err := db.GetEngine(ctx).ID(a.ID).
+			Cols("state", "resolution", "resolution_comment", "resolver_id", "resolved_unix").Update(a); err != nil {
+			return err
+		}
+		recordAlertAuditEvent(ctx, audit_model.ActionSecretScanningResolve, a, doerID, map[string]string{"resolution": string(resolution)})
+		return addAlertEvent(ctx, a, doerID, AlertEventResolved, string(resolution), comment)
+	})
+}
+
+// ReopenAlert opens a resolved alert again
+func ReopenAlert(ctx context.Context, a *Alert, doerID int64, comment string) error {
+	if a.State == AlertStateOpen {
+		return util.NewInvalidArgumentErrorf("secret scanning alert %d is already open", a.ID)
+	}
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		a.State, a.Resolution, a.ResolutionComment = AlertStateOpen, AlertResolutionNone, ""
+		a.ResolverID, a.ResolvedUnix, a.Resolver = 0, 0, nil
+		if _, err := db.GetEngine(ctx).ID(a.ID).
+			Cols("state", "resolution", "resolution_comment", "resolver_id", "resolved_unix").Update(a); err != nil {
+			return err
+		}
+		recordAlertAuditEvent(ctx, audit_model.ActionSecretScanningReopen, a, doerID, nil)
+		return addAlertEvent(ctx, a, doerID, AlertEventReopened, "", comment)
+	})
+}
+
+// recordAlertAuditEvent records a change of an alert by a user in the audit log
+func recordAlertAuditEvent(ctx context.Context, action audit_model.Action, a *Alert, doerID int64, metadata map[string]string) {
+	audit_model.Record(ctx, &audit_model.Event{
+		Action:     action,
+		DoerID:     doerID,
+		RepoID:     a.RepoID,
+		TargetType: audit_model.TargetSecretScanningAlert,
+		TargetID:   a.ID,
+		TargetName: a.PatternName,
+		Metadata:   metadata,
+	})
+}
+
+// GetAlertByID returns an alert of a repository
+func GetAlertByID(ctx context.Context, repoID, id int64) (*Alert, error) {
+	a, has, err := db.Get[Alert](ctx, builder.Eq{"id": id, "repo_id": repoID})
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, fmt.Errorf("secret scanning alert with id %d: %w", id, util.ErrNotExist)
+	}
+	return a, nil
+}
+
+// GetAlertsBySecretHashes returns the alerts of the secrets of a repository by the hashes of the secrets
+func GetAlertsBySecretHashes(ctx context.Context, repoID int64, secretHashes []string) (map[string]*Alert, error) {
+	alerts := make(map[string]*Alert, len(secretHashes))
+	if len(secretHashes) == 0 {
+		return alerts, nil
+	}
+	var list []*Alert
+	if err := db.GetEngine(ctx).Where("repo_id = ?", repoID).In("secret_hash", secretHashes).Find(&list); err != nil {
+		return nil, err
+	}
+	for _, a := range list {
+		alerts[a.SecretHash] = a
+	}
+	return alerts, nil
+}
+
+type FindAlertsOptions struct {
+	db.ListOptions
+	RepoID    int64
+	State     AlertState
+	PatternID string
+}
+
+func (opts FindAlertsOptions) ToConds() builder.Cond {
+	cond := builder.NewCond().And(builder.Eq{"repo_id": opts.RepoID})
+	if opts.State != "" {
+		cond = cond.And(builder.Eq{"state": opts.State})
+	}
+	if opts.PatternID != "" {
+		cond = cond.And(builder.Eq{"pattern_id": opts.PatternID})
+	}
+	return cond
+}
+
+func (opts FindAlertsOptions) ToOrders() string {
+	return "id DESC"
+}
+
+type FindAlertEventsOptions struct {
+	db.ListOptions
+	AlertID int64
+}
+
+func (opts FindAlertEventsOptions) ToConds() builder.Cond {
+	return builder.Eq{"alert_id": opts.AlertID}
+}
+
+func (opts FindAlertEventsOptions) ToOrders() string {
+	return "id ASC"
+}
+
+// AlertEventList is a list of alert events
+type AlertEventList []*AlertEvent
+
+// LoadDoers loads the users of the events, the events of the scans have none
+func (events AlertEventList) LoadDoers(ctx context.Context) error {
+	ids := make([]int64, 0, len(events))
+	for _, e := range events {
+		if e.DoerID != 0 {
+			ids = append(ids, e.DoerID)
+		}
+	}
+	users, err := user_model.GetPossibleUserByIDs(ctx, ids)
+	if err != nil {
+		return err
+	}
+	byID := make(map[int64]*user_model.User, len(users))
+	for _, u := range users {
+		byID[u.ID] = u
+	}
+	for _, e := range events {
+		if e.DoerID == 0 {
+			continue
+		}
+		if e.Doer = byID[e.DoerID]; e.Doer == nil {
+			e.Doer = user_model.NewGhostUser()
+		}
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package secretscan
+
+import (
+	"testing"
+
+	"gitea.dev/models/db"
+	"gitea.dev/models/unittest"
+	"gitea.dev/modules/util"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateAlertIfNotExist(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	alert := &Alert{RepoID: 1, SecretHash: "hash1", PatternID: "aws_access_key_id", PatternName: "AWS Access Key ID", Path: "a.env", Line: 2}
+	created, err := CreateAlertIfNotExist(t.Context(), alert)
+	require.NoError(t, err)
+	assert.True(t, created)
+	assert.Equal(t, AlertStateOpen, alert.State)
+
+	// the same secret found again, even in another file, doesn't raise another alert
+	created, err = CreateAlertIfNotExist(t.Context(), &Alert{RepoID: 1, SecretHash: "hash1", PatternID: "aws_access_key_id", PatternName: "AWS Access Key ID", Path: "b.env"})
+	require.NoError(t, err)
+	assert.False(t, created)
+	// but it does in another repository
+	created, err = CreateAlertIfNotExist(t.Context(), &Alert{RepoID: 2, SecretHash: "hash1", PatternID: "aws_access_key_id", PatternName: "AWS Access Key ID"})
+	require.NoError(t, err)
+	assert.True(t, created)
+
+	alerts, err := GetAlertsBySecretHashes(t.Context(), 1, []string{"hash1", "hash2"})
+	require.NoError(t, err)
+	require.Len(t, alerts, 1)
+	assert.Equal(t, "a.env", alerts["hash1"].Path)
+}
+
+func TestAlertAuditTrail(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	// a secret pushed as a false positive is resolved at once
+	alert, err := BypassPushProtection(t.Context(), &Alert{RepoID: 1, SecretHash: "hash1", PatternID: "private_key", PatternName: "Private Key"}, 2, BypassReasonFalsePositive)
+	require.NoError(t, err)
+	assert.True(t, alert.PushProtectionBypassed)
+	assert.Equal(t, AlertStateResolved, alert.State)
+	assert.Equal(t, AlertResolutionFalsePositive, alert.Resolution)
+
+	require.NoError(t, ReopenAlert(t.Context(), alert, 1, "it is a real key"))
+	assert.ErrorIs(t, ReopenAlert(t.Context(), alert, 1, ""), util.ErrInvalidArgument)
+	assert.ErrorIs(t, ResolveAlert(t.Context(), alert, 1, "done", ""), util.ErrInvalidArgument)
+	require.NoError(t, ResolveAlert(t.Context(), alert, 1, AlertResolutionRevoked, "rotated"))
+
+	// a secret to be fixed later stays open
+	later, err := BypassPushProtection(t.Context(), &Alert{RepoID: 1, SecretHash: "hash2", PatternID: "private_key", PatternName: "Private Key"}, 2, BypassReasonWillFixLater)
+	require.NoError(t, err)
+	assert.Equal(t, AlertStateOpen, later.State)
+
+	alert, err = GetAlertByID(t.Context(), 1, alert.ID)
+	require.NoError(t, err)
+	assert.Equal(t, AlertResolutionRevoked, alert.Resolution)
+	assert.EqualValues(t, 1, alert.ResolverID)
+	assert.EqualValues(t, 2, alert.BypasserID)
+
+	events, err := db.Find[AlertEvent](t.Context(), FindAlertEventsOptions{AlertID: alert.ID})
+	require.NoError(t, err)
+	types := make([]AlertEventType, 0, len(events))
+	for _, e := range events {
+		types = append(types, e.Type)
+	}
+	assert.Equal(t, []AlertEventType{AlertEventCreated, AlertEventBypassed, AlertEventReopened, AlertEventResolved}, types)
+	assert.Equal(t, string(BypassReasonFalsePositive), events[1].Reason)
+	assert.Equal(t, "rotated", events[3].Comment)
+
+	require.NoError(t, AlertEventList(events).LoadDoers(t.Context()))
+	assert.Equal(t, "user2", events[1].Doer.Name)
+
+	count, err := db.Count[Alert](t.Context(), FindAlertsOptions{RepoID: 1, State: AlertStateOpen})
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, count)
+}
+
+func TestCustomPatterns(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	instance := &CustomPattern{Name: "Internal key", Pattern: `ikey_[a-z0-9]{16}`, PushProtection: true}
+	require.NoError(t, CreateCustomPattern(t.Context(), instance))
+	org := &CustomPattern{OwnerID: 3, Name: "Internal key", Pattern: `okey_([a-z0-9]{16})`}
+	require.NoError(t, CreateCustomPattern(t.Context(), org))
+
+	assert.ErrorIs(t, CreateCustomPattern(t.Context(), &CustomPattern{OwnerID: 3, Name: "internal key ", Pattern: `x+`}), util.ErrAlreadyExist)
+	assert.ErrorIs(t, CreateCustomPattern(t.Context(), &CustomPattern{OwnerID: 3, Name: "Empty", Pattern: `x*`}), util.ErrInvalidArgument)
+
+	org.Pattern = `(unclosed`
+	assert.ErrorIs(t, UpdateCustomPattern(t.Context(), org), util.ErrInvalidArgument)
+
+	patterns, err := db.Find[CustomPattern](t.Context(), FindCustomPatternsOptions{OwnerID: 3, IncludeInstance: true})
+	require.NoError(t, err)
+	require.Len(t, patterns, 2)
+	assert.Equal(t, instance.ID, patterns[0].ID)
+	compiled, err := patterns[1].Compile()
+	require.NoError(t, err)
+	assert.Equal(t, org.PatternID(), compiled.ID)
+	assert.False(t, compiled.PushProtection)
+
+	_, err = GetCustomPatternByID(t.Context(), 0, org.ID)
+	assert.ErrorIs(t, err, util.ErrNotExist)
+	require.NoError(t, DeleteCustomPattern(t.Context(), org))
+	unittest.AssertNotExistsBean(t, &CustomPattern{ID: org.ID})
+}
@@ -0,0 +1,155 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package secretscan
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	audit_model "gitea.dev/models/audit"
+	"gitea.dev/models/db"
+	"gitea.dev/modules/secretscan"
+	"gitea.dev/modules/timeutil"
+	"gitea.dev/modules/util"
+
+	"xorm.io/builder"
+)
+
+const patternMaxNameLength = 100
+
+// customPatternIDPrefix is the prefix of the pattern IDs of the custom patterns in the alerts, followed by their ID
+const customPatternIDPrefix = "custom_"
+
+// CustomPattern is a pattern of secret defined by an administrator, for all the repositories of the instance
+// when OwnerID is 0, otherwise for all the repositories of an organization
+type CustomPattern struct {
+	ID      int64  `xorm:"pk autoincr"`
+	OwnerID int64  `xorm:"INDEX NOT NULL DEFAULT 0"`
+	Name    string `xorm:"NOT NULL"`
+	// Pattern is a regular expression, the secret is its capture group if it has one
+	Pattern string `xorm:"TEXT NOT NULL"`
+	// PushProtection rejects the pushes adding secrets matched by the pattern, otherwise they are only reported as alerts
+	PushProtection bool `xorm:"NOT NULL DEFAULT true"`
+
+	CreatedUnix timeutil.TimeStamp `xorm:"created"`
+	UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
+}
+
+// TableName sets the table name of the custom patterns
+func (CustomPattern) TableName() string {
+	return "secret_scanning_pattern"
+}
+
+func init() {
+	db.RegisterModel(new(CustomPattern))
+}
+
+// PatternID returns the ID of the pattern in the alerts
+func (p *CustomPattern) PatternID() string {
+	return customPatternIDPrefix + strconv.FormatInt(p.ID, 10)
+}
+
+// Compile returns the pattern used to scan the contents
+func (p *CustomPattern) Compile() (*secretscan.Pattern, error) {
+	return secretscan.CompileCustomPattern(p.PatternID(), p.Name, p.Pattern, p.PushProtection)
+}
+
+func validateCustomPattern(ctx context.Context, p *CustomPattern) error {
+	p.Name = strings.TrimSpace(p.Name)
+	if p.Name == "" || utf8.RuneCountInString(p.Name) > patternMaxNameLength {
+		return util.NewInvalidArgumentErrorf("secret scanning pattern name must be between 1 and %d characters", patternMaxNameLength)
+	}
+	if _, err := p.Compile(); err != nil {
+		return err
+	}
+	exists, err := db.GetEngine(ctx).Where(builder.Eq{"owner_id": p.OwnerID}.And(builder.Neq{"id": p.ID})).
+		And("LOWER(name) = ?", strings.ToLower(p.Name)).Exist(new(CustomPattern))
+	if err != nil {
+		return err
+	} else if exists {
+		return util.NewAlreadyExistErrorf("secret scanning pattern %q already exists", p.Name)
+	}
+	return nil
+}
+
+// CreateCustomPattern creates a custom pattern
+func CreateCustomPattern(ctx context.Context, p *CustomPattern) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		if err := validateCustomPattern(ctx, p); err != nil {
+			return err
+		}
+		if err := db.Insert(ctx, p); err != nil {
+			return err
+		}
+		recordPatternAuditEvent(ctx, p, "create")
+		return nil
+	})
+}
+
+// recordPatternAuditEvent records a change of the custom patterns of an owner in the audit log
+func recordPatternAuditEvent(ctx context.Context, p *CustomPattern, operation string) {
+	audit_model.Record(ctx, &audit_model.Event{
+		Action:     audit_model.ActionSecretScanningPatterns,
+		OwnerID:    p.OwnerID,
+		TargetType: audit_model.TargetSecretScanningPattern,
+		TargetID:   p.ID,
+		TargetName: p.Name,
+		Metadata:   map[string]string{"operation": operation, "push_protection": strconv.FormatBool(p.PushProtection)},
+	})
+}
+
+// UpdateCustomPattern updates the name, the regular expression and the push protection of a custom pattern
+func UpdateCustomPattern(ctx context.Context, p *CustomPattern) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		if err := validateCustomPattern(ctx, p); err != nil {
+			return err
+		}
+		if _, err := db.GetEngine(ctx).ID(p.ID).Cols("name", "pattern", "push_protection").Update(p); err != nil {
+			return err
+		}
+		recordPatternAuditEvent(ctx, p, "update")
+		return nil
+	})
+}
+
+// DeleteCustomPattern deletes a custom pattern, the alerts it raised are kept
+func DeleteCustomPattern(ctx context.Context, p *CustomPattern) error {
+	if _, err := db.DeleteByID[CustomPattern](ctx, p.ID); err != nil {
+		return err
+	}
+	recordPatternAuditEvent(ctx, p, "delete")
+	return nil
+}
+
+// GetCustomPatternByID returns a custom pattern of an owner, 0 for the instance
+func GetCustomPatternByID(ctx context.Context, ownerID, id int64) (*CustomPattern, error) {
+	p, has, err := db.Get[CustomPattern](ctx, builder.Eq{"id": id, "owner_id": ownerID})
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, fmt.Errorf("secret scanning pattern with id %d: %w", id, util.ErrNotExist)
+	}
+	return p, nil
+}
+
+type FindCustomPatternsOptions struct {
+	db.ListOptions
+	OwnerID int64
+	// IncludeInstance also returns the patterns of the instance for an owner
+	IncludeInstance bool
+}
+
+func (opts FindCustomPatternsOptions) ToConds() builder.Cond {
+	if opts.IncludeInstance && opts.OwnerID != 0 {
+		return builder.In("owner_id", 0, opts.OwnerID)
+	}
+	return builder.Eq{"owner_id": opts.OwnerID}
+}
+
+func (opts FindCustomPatternsOptions) ToOrders() string {
+	return "owner_id ASC, id ASC"
+}
@@ -20,6 +20,7 @@ import (
 	"time"
 	"unicode"
 
+	audit_model "gitea.dev/models/audit"
 	"gitea.dev/models/auth"
 	"gitea.dev/models/db"
 	"gitea.dev/modules/auth/openid"
@@ -662,7 +663,17 @@ func CreateUser(ctx context.Context, u *User, meta *Meta, overwriteDefault ...*C
 
 // AdminCreateUser is used by admins to manually create users
 func AdminCreateUser(ctx context.Context, u *User, meta *Meta, overwriteDefault ...*CreateUserOverwriteOptions) (err error) {
-	return createUser(ctx, u, meta, true, overwriteDefault...)
+	if err = createUser(ctx, u, meta, true, overwriteDefault...); err != nil {
+		return err
+	}
+	audit_model.Record(ctx, &audit_model.Event{
+		Action:     audit_model.ActionUserCreate,
+		TargetType: audit_model.TargetUser,
+		TargetID:   u.ID,
+		TargetName: u.Name,
+		Metadata:   map[string]string{"admin": strconv.FormatBool(u.IsAdmin), "restricted": strconv.FormatBool(u.IsRestricted)},
+	})
+	return nil
 }
 
 // createUser creates record of a new user.
@@ -1142,6 +1153,15 @@ func GetUsersBySource(ctx context.Context, s *auth.Source) ([]*User, error) {
 	return users, err
 }
 
+// GetUsersBySourceLoginName returns the users of a login source with the login name, compared case-insensitively
+func GetUsersBySourceLoginName(ctx context.Context, s *auth.Source, loginName string) ([]*User, error) {
+	var users []*User
+	err := db.GetEngine(ctx).Where("login_type = ? AND login_source = ?", s.Type, s.ID).
+		And("LOWER(login_name) = ?", strings.ToLower(loginName)).
+		Find(&users)
+	return users, err
+}
+
 func GetUserByGitAuthor(ctx context.Context, c *git.Commit) *User {
 	if c.Author == nil {
 		return nil
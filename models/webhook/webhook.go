@@ -7,8 +7,11 @@ package webhook
 import (
 	"context"
 	"fmt"
+	"net/url"
+	"strconv"
 	"strings"
 
+	"gitea.dev/models/audit"
 	"gitea.dev/models/db"
 	"gitea.dev/modules/json"
 	"gitea.dev/modules/log"
@@ -232,7 +235,32 @@ func (w *Webhook) SetHeaderAuthorization(cleartext string) error {
 // CreateWebhook creates a new web hook.
 func CreateWebhook(ctx context.Context, w *Webhook) error {
 	w.Type = strings.TrimSpace(w.Type)
-	return db.Insert(ctx, w)
+	if err := db.Insert(ctx, w); err != nil {
+		return err
+	}
+	recordWebhookEvent(ctx, audit.ActionWebhookCreate, w)
+	return nil
+}
+
+// recordWebhookEvent records a change of a webhook, only the host of its URL is kept as the URL could hold a secret
+func recordWebhookEvent(ctx context.Context, action audit.Action, w *Webhook) {
+	var host string
+	if u, err := url.Parse(w.URL); err == nil {
+		host = u.Host
+	}
+	audit.Record(ctx, &audit.Event{
+		Action:     action,
+		OwnerID:    w.OwnerID,
+		RepoID:     w.RepoID,
+		TargetType: audit.TargetWebhook,
+		TargetID:   w.ID,
+		TargetName: host,
+		Metadata: map[string]string{
+			"type":   w.Type,
+			"active": strconv.FormatBool(w.IsActive),
+			"system": strconv.FormatBool(w.IsSystemWebhook),
+		},
+	})
 }
 
 // CreateWebhooks creates multiple web hooks
@@ -307,8 +335,11 @@ func (opts ListWebhookOptions) ToConds() builder.Cond {
 
 // UpdateWebhook updates information of webhook.
 func UpdateWebhook(ctx context.Context, w *Webhook) error {
-	_, err := db.GetEngine(ctx).ID(w.ID).AllCols().Update(w)
-	return err
+	if _, err := db.GetEngine(ctx).ID(w.ID).AllCols().Update(w); err != nil {
+		return err
+	}
+	recordWebhookEvent(ctx, audit.ActionWebhookUpdate, w)
+	return nil
 }
 
 // UpdateWebhookLastStatus updates last status of webhook.
@@ -320,7 +351,11 @@ func UpdateWebhookLastStatus(ctx context.Context, w *Webhook) error {
 // DeleteWebhookByID uses argument bean as query condition,
 // ID must be specified and do not assign unnecessary fields.
 func DeleteWebhookByID(ctx context.Context, id int64) (err error) {
-	return db.WithTx(ctx, func(ctx context.Context) error {
+	w, err := GetWebhookByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	err = db.WithTx(ctx, func(ctx context.Context) error {
 		if count, err := db.DeleteByID[Webhook](ctx, id); err != nil {
 			return err
 		} else if count == 0 {
@@ -330,6 +365,11 @@ func DeleteWebhookByID(ctx context.Context, id int64) (err error) {
 		}
 		return nil
 	})
+	if err != nil {
+		return err
+	}
+	recordWebhookEvent(ctx, audit.ActionWebhookDelete, w)
+	return nil
 }
 
 // DeleteWebhookByRepoID deletes webhook of repository by given ID.
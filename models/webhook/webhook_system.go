@@ -7,6 +7,7 @@ import (
 	"context"
 	"fmt"
 
+	"gitea.dev/models/audit"
 	"gitea.dev/models/db"
 	"gitea.dev/modules/optional"
 
@@ -74,7 +75,11 @@ func GetSystemWebhooks(ctx context.Context, isActive optional.Option[bool]) ([]*
 
 // DeleteDefaultSystemWebhook deletes an admin-configured default or system webhook (where Org and Repo ID both 0)
 func DeleteDefaultSystemWebhook(ctx context.Context, id int64) error {
-	return db.WithTx(ctx, func(ctx context.Context) error {
+	w, err := GetWebhookByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	err = db.WithTx(ctx, func(ctx context.Context) error {
 		count, err := db.GetEngine(ctx).
 			Where("repo_id=? AND owner_id=?", 0, 0).
 			Delete(&Webhook{ID: id})
@@ -87,6 +92,11 @@ func DeleteDefaultSystemWebhook(ctx context.Context, id int64) error {
 		_, err = db.DeleteByBean(ctx, &HookTask{HookID: id})
 		return err
 	})
+	if err != nil {
+		return err
+	}
+	recordWebhookEvent(ctx, audit.ActionWebhookDelete, w)
+	return nil
 }
 
 // CopyDefaultWebhooksToRepo creates copies of the default webhooks in a new repo
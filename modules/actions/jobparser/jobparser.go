@@ -288,11 +288,30 @@ func buildMatrixCombos(jobID string, src *Job, matrixes []map[string]any, actJob
 		if err := evaluator.EvaluateYamlNode(&combo.RawContinueOnError); err != nil {
 			return nil, fmt.Errorf("evaluate continue-on-error for job %q: %w", jobID, err)
 		}
+		if err := evaluateEnvironmentName(evaluator, &combo.RawEnvironment); err != nil {
+			return nil, fmt.Errorf("evaluate environment for job %q: %w", jobID, err)
+		}
 		combos = append(combos, combo)
 	}
 	return combos, nil
 }
 
+// evaluateEnvironmentName interpolates the name of a job's environment in place.
+// The url may read step outputs that only exist once the job has run, so it is left as is.
+func evaluateEnvironmentName(evaluator expreval.Evaluator, node *yaml.Node) error {
+	switch node.Kind {
+	case yaml.ScalarNode:
+		return evaluator.EvaluateYamlNode(node)
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if node.Content[i].Value == "name" {
+				return evaluator.EvaluateYamlNode(node.Content[i+1])
+			}
+		}
+	}
+	return nil
+}
+
 func WithGitContext(context *model.GithubContext) ParseOption {
 	return func(c *parseContext) {
 		c.gitContext = context
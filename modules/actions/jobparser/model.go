@@ -120,6 +120,7 @@ type Job struct {
 	RawSecrets         yaml.Node                 `yaml:"secrets,omitempty"`
 	RawConcurrency     *model.RawConcurrency     `yaml:"concurrency,omitempty"`
 	RawPermissions     yaml.Node                 `yaml:"permissions,omitempty"`
+	RawEnvironment     yaml.Node                 `yaml:"environment,omitempty"`
 }
 
 // GetContinueOnError decodes the continue-on-error field to a bool.
@@ -158,9 +159,33 @@ func (j *Job) Clone() *Job {
 		RawSecrets:         j.RawSecrets,
 		RawConcurrency:     j.RawConcurrency,
 		RawPermissions:     j.RawPermissions,
+		RawEnvironment:     j.RawEnvironment,
 	}
 }
 
+// Environment decodes the environment field, which is either a plain name or a mapping with a name and an url.
+// See https://docs.github.com/en/actions/reference/workflows-and-actions/workflow-syntax#jobsjob_idenvironment
+func (j *Job) Environment() (name, url string) {
+	switch j.RawEnvironment.Kind {
+	case yaml.ScalarNode:
+		var v string
+		if err := j.RawEnvironment.Decode(&v); err != nil {
+			return "", ""
+		}
+		return v, ""
+	case yaml.MappingNode:
+		var v struct {
+			Name string `yaml:"name"`
+			URL  string `yaml:"url"`
+		}
+		if err := j.RawEnvironment.Decode(&v); err != nil {
+			return "", ""
+		}
+		return v.Name, v.URL
+	}
+	return "", ""
+}
+
 func (j *Job) Needs() []string {
 	return (&model.Job{RawNeeds: j.RawNeeds}).Needs()
 }
@@ -384,6 +384,61 @@ func TestGetContinueOnError(t *testing.T) {
 	})
 }
 
+func TestJobEnvironment(t *testing.T) {
+	tests := []struct {
+		name     string
+		yaml     string
+		wantName string
+		wantURL  string
+	}{
+		{
+			name: "absent",
+			yaml: "on: push\njobs:\n  job1:\n    runs-on: ubuntu-22.04\n    steps:\n      - run: echo hi\n",
+		},
+		{
+			name:     "plain name",
+			yaml:     "on: push\njobs:\n  job1:\n    runs-on: ubuntu-22.04\n    environment: production\n    steps:\n      - run: echo hi\n",
+			wantName: "production",
+		},
+		{
+			name:     "name and url",
+			yaml:     "on: push\njobs:\n  job1:\n    runs-on: ubuntu-22.04\n    environment:\n      name: staging\n      url: https://staging.example.com\n    steps:\n      - run: echo hi\n",
+			wantName: "staging",
+			wantURL:  "https://staging.example.com",
+		},
+		{
+			name:     "url reading step outputs is kept",
+			yaml:     "on: push\njobs:\n  job1:\n    runs-on: ubuntu-22.04\n    environment:\n      name: review\n      url: ${{ steps.deploy.outputs.url }}\n    steps:\n      - id: deploy\n        run: echo hi\n",
+			wantName: "review",
+			wantURL:  "${{ steps.deploy.outputs.url }}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse([]byte(tt.yaml))
+			require.NoError(t, err)
+			require.Len(t, got, 1)
+			_, job := got[0].Job()
+			name, url := job.Environment()
+			assert.Equal(t, tt.wantName, name)
+			assert.Equal(t, tt.wantURL, url)
+		})
+	}
+
+	t.Run("matrix expression", func(t *testing.T) {
+		content := "on: push\njobs:\n  deploy:\n    runs-on: ubuntu-22.04\n    strategy:\n      matrix:\n        target: [production, staging]\n    environment: ${{ matrix.target }}\n    steps:\n      - run: echo hi\n"
+		got, err := Parse([]byte(content))
+		require.NoError(t, err)
+		require.Len(t, got, 2)
+		_, jobProduction := got[0].Job()
+		_, jobStaging := got[1].Job()
+		name, _ := jobProduction.Environment()
+		assert.Equal(t, "production", name)
+		name, _ = jobStaging.Environment()
+		assert.Equal(t, "staging", name)
+	})
+}
+
 func TestParseMappingNode(t *testing.T) {
 	tests := []struct {
 		input   string
@@ -0,0 +1,103 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+// Package codenav reads the code navigation indexes of a commit: the SCIP and LSIF indexes produced by the language
+// indexers and the definitions found by ctags. An index is flattened to the occurrences of the symbols in the files,
+// the definitions and the references of a symbol are its occurrences in all the files.
+package codenav
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// Format is the format of an index
+type Format string
+
+const (
+	FormatSCIP  Format = "scip"
+	FormatLSIF  Format = "lsif"
+	FormatCtags Format = "ctags"
+)
+
+// IsUploadable reports whether the indexes of the format can be uploaded
+func (f Format) IsUploadable() bool {
+	return f == FormatSCIP || f == FormatLSIF
+}
+
+// FormatFromFilename returns the format of an index file from its extension
+func FormatFromFilename(filename string) (Format, bool) {
+	switch {
+	case strings.HasSuffix(filename, ".scip"):
+		return FormatSCIP, true
+	case strings.HasSuffix(filename, ".lsif"), strings.HasSuffix(filename, ".lsif.json"):
+		return FormatLSIF, true
+	}
+	return "", false
+}
+
+// Range is the position of an occurrence in a file, the lines and the characters start at 0 like in the LSP
+type Range struct {
+	Line      int
+	StartChar int
+	EndLine   int
+	EndChar   int
+}
+
+// Contains reports whether the position is in the range, the end is excluded
+func (r Range) Contains(line, character int) bool {
+	if line < r.Line || line > r.EndLine {
+		return false
+	}
+	if line == r.Line && character < r.StartChar {
+		return false
+	}
+	return line != r.EndLine || character < r.EndChar
+}
+
+// Occurrence is a symbol found in a file
+type Occurrence struct {
+	Path string
+	Range
+	Symbol       string
+	IsDefinition bool
+}
+
+// Index is the flattened index of a commit
+type Index struct {
+	Format      Format
+	Occurrences []*Occurrence
+	// Documentation is the Markdown documentation of the symbols, shown when hovering them
+	Documentation map[string]string
+}
+
+func newIndex(format Format) *Index {
+	return &Index{Format: format, Documentation: make(map[string]string)}
+}
+
+// Parse reads an uploaded index
+func Parse(format Format, r io.Reader) (*Index, error) {
+	switch format {
+	case FormatSCIP:
+		return ParseSCIP(r)
+	case FormatLSIF:
+		return ParseLSIF(r)
+	}
+	return nil, fmt.Errorf("unsupported index format %q", format)
+}
+
+// UTF16Len returns the number of UTF-16 code units of a string, the characters of the positions in the LSP
+func UTF16Len(s string) int {
+	n := 0
+	for _, r := range s {
+		if r == utf8.RuneError {
+			n++
+			continue
+		}
+		n += utf16.RuneLen(r)
+	}
+	return n
+}
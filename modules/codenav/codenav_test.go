@@ -0,0 +1,147 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package codenav
+
+import (
+	"strings"
+	"testing"
+
+	"gitea.dev/modules/ctags"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+func appendBytes(b []byte, num protowire.Number, v []byte) []byte {
+	b = protowire.AppendTag(b, num, protowire.BytesType)
+	return protowire.AppendBytes(b, v)
+}
+
+func scipOccurrence(symbol string, roles uint64, rng ...int) []byte {
+	var packed []byte
+	for _, x := range rng {
+		packed = protowire.AppendVarint(packed, uint64(x))
+	}
+	b := appendBytes(nil, scipOccurrenceRange, packed)
+	b = appendBytes(b, scipOccurrenceSymbol, []byte(symbol))
+	if roles != 0 {
+		b = protowire.AppendTag(b, scipOccurrenceSymbolRoles, protowire.VarintType)
+		b = protowire.AppendVarint(b, roles)
+	}
+	return b
+}
+
+func scipSymbolInformation(symbol, doc string) []byte {
+	b := appendBytes(nil, scipSymbolInformationSymbol, []byte(symbol))
+	return appendBytes(b, scipSymbolInformationDocumentation, []byte(doc))
+}
+
+func TestParseSCIP(t *testing.T) {
+	const foo = "scip-go gomod example v1 `example`/Foo()."
+	main := appendBytes(nil, scipDocumentRelativePath, []byte("main.go"))
+	main = appendBytes(main, scipDocumentOccurrences, scipOccurrence(foo, 0, 4, 1, 4))
+	main = appendBytes(main, scipDocumentOccurrences, scipOccurrence("local 0", scipSymbolRoleDefinition, 5, 1, 6, 2))
+	foobar := appendBytes(nil, scipDocumentRelativePath, []byte("foo.go"))
+	foobar = appendBytes(foobar, scipDocumentOccurrences, scipOccurrence(foo, scipSymbolRoleDefinition, 2, 5, 8))
+	foobar = appendBytes(foobar, scipDocumentSymbols, scipSymbolInformation(foo, "Foo does nothing"))
+	foobar = appendBytes(foobar, scipDocumentOccurrences, scipOccurrence("local 0", scipSymbolRoleDefinition, 3, 1, 2))
+
+	index := appendBytes(nil, scipIndexDocuments, main)
+	index = appendBytes(index, scipIndexDocuments, foobar)
+	index = appendBytes(index, scipIndexExternalSymbols, scipSymbolInformation("scip-go gomod fmt v1 `fmt`/Println().", "Println prints"))
+
+	idx, err := Parse(FormatSCIP, strings.NewReader(string(index)))
+	require.NoError(t, err)
+	assert.Equal(t, []*Occurrence{
+		{Path: "main.go", Range: Range{Line: 4, StartChar: 1, EndLine: 4, EndChar: 4}, Symbol: foo},
+		{Path: "main.go", Range: Range{Line: 5, StartChar: 1, EndLine: 6, EndChar: 2}, Symbol: "local 0 main.go", IsDefinition: true},
+		{Path: "foo.go", Range: Range{Line: 2, StartChar: 5, EndLine: 2, EndChar: 8}, Symbol: foo, IsDefinition: true},
+		{Path: "foo.go", Range: Range{Line: 3, StartChar: 1, EndLine: 3, EndChar: 2}, Symbol: "local 0 foo.go", IsDefinition: true},
+	}, idx.Occurrences)
+	assert.Equal(t, map[string]string{
+		foo:                                     "Foo does nothing",
+		"scip-go gomod fmt v1 `fmt`/Println().": "Println prints",
+	}, idx.Documentation)
+
+	_, err = ParseSCIP(strings.NewReader(string(index[:len(index)-3])))
+	assert.Error(t, err)
+}
+
+const lsifDump = `{"id":1,"type":"vertex","label":"metaData","version":"0.6.0","projectRoot":"file:///src/project"}
+{"id":2,"type":"vertex","label":"document","uri":"file:///src/project/lib/a.ts","languageId":"typescript"}
+{"id":3,"type":"vertex","label":"range","start":{"line":0,"character":16},"end":{"line":0,"character":19}}
+{"id":4,"type":"vertex","label":"range","start":{"line":3,"character":2},"end":{"line":3,"character":5}}
+{"id":5,"type":"vertex","label":"resultSet"}
+{"id":6,"type":"edge","label":"next","outV":3,"inV":5}
+{"id":7,"type":"edge","label":"next","outV":4,"inV":5}
+{"id":8,"type":"vertex","label":"definitionResult"}
+{"id":9,"type":"edge","label":"textDocument/definition","outV":5,"inV":8}
+{"id":10,"type":"edge","label":"item","outV":8,"inVs":[3],"document":2}
+{"id":11,"type":"vertex","label":"hoverResult","result":{"contents":[{"language":"typescript","value":"function foo(): void"},"Does foo"]}}
+{"id":12,"type":"edge","label":"textDocument/hover","outV":5,"inV":11}
+{"id":13,"type":"vertex","label":"range","start":{"line":5,"character":0},"end":{"line":5,"character":3}}
+{"id":14,"type":"edge","label":"contains","outV":2,"inVs":[3,4,13]}
+`
+
+func TestParseLSIF(t *testing.T) {
+	for _, dump := range []string{lsifDump, "[" + strings.ReplaceAll(strings.TrimSpace(lsifDump), "\n", ",") + "]"} {
+		idx, err := Parse(FormatLSIF, strings.NewReader(dump))
+		require.NoError(t, err)
+		assert.Equal(t, []*Occurrence{
+			{Path: "lib/a.ts", Range: Range{Line: 0, StartChar: 16, EndLine: 0, EndChar: 19}, Symbol: "lsif 5", IsDefinition: true},
+			{Path: "lib/a.ts", Range: Range{Line: 3, StartChar: 2, EndLine: 3, EndChar: 5}, Symbol: "lsif 5"},
+			{Path: "lib/a.ts", Range: Range{Line: 5, StartChar: 0, EndLine: 5, EndChar: 3}, Symbol: "lsif 13"},
+		}, idx.Occurrences)
+		assert.Equal(t, map[string]string{"lsif 5": "```typescript\nfunction foo(): void\n```\n\nDoes foo"}, idx.Documentation)
+	}
+
+	_, err := ParseLSIF(strings.NewReader(`{"id":1,`))
+	assert.Error(t, err)
+}
+
+func TestCtags(t *testing.T) {
+	content := []byte("package main\n\n// héllo\nfunc héllo() {}\n\nvar x = héllo\n")
+	idx := NewCtagsIndex()
+	idx.AddCtagsEntries("main.go", content, []*ctags.Entry{
+		{Name: "héllo", Line: 4, Language: "Go", Kind: "func"},
+		{Name: "missing", Line: 4},
+		{Name: "outside", Line: 100},
+	})
+	assert.Equal(t, []*Occurrence{
+		{Path: "main.go", Range: Range{Line: 3, StartChar: 5, EndLine: 3, EndChar: 10}, Symbol: "ctags héllo", IsDefinition: true},
+	}, idx.Occurrences)
+	assert.Equal(t, "```go\nfunc héllo() {}\n```", idx.Documentation["ctags héllo"])
+
+	name, rng, ok := IdentifierAt(content, 5, 10)
+	assert.True(t, ok)
+	assert.Equal(t, "héllo", name)
+	assert.Equal(t, Range{Line: 5, StartChar: 8, EndLine: 5, EndChar: 13}, rng)
+	assert.True(t, rng.Contains(5, 12))
+	assert.False(t, rng.Contains(5, 13))
+
+	_, _, ok = IdentifierAt(content, 5, 6) // "="
+	assert.False(t, ok)
+	_, _, ok = IdentifierAt(content, 50, 0)
+	assert.False(t, ok)
+}
+
+func TestIsWordAt(t *testing.T) {
+	line := "foo(xfoo, foo_, é foo)"
+	assert.True(t, IsWordAt(line, 0, 3))
+	assert.False(t, IsWordAt(line, 5, 3))
+	assert.False(t, IsWordAt(line, 10, 3))
+	assert.True(t, IsWordAt(line, 19, 3))
+}
+
+func TestFormatFromFilename(t *testing.T) {
+	format, ok := FormatFromFilename("out/index.scip")
+	assert.True(t, ok)
+	assert.Equal(t, FormatSCIP, format)
+	format, ok = FormatFromFilename("dump.lsif")
+	assert.True(t, ok)
+	assert.Equal(t, FormatLSIF, format)
+	_, ok = FormatFromFilename("README.md")
+	assert.False(t, ok)
+}
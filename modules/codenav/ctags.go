@@ -0,0 +1,99 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package codenav
+
+import (
+	"bytes"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"gitea.dev/modules/ctags"
+)
+
+// CtagsSymbol returns the symbol of the definitions found by ctags for a name, ctags doesn't know which definition
+// a name refers to so all the definitions of a name are the same symbol
+func CtagsSymbol(name string) string {
+	return "ctags " + name
+}
+
+// NewCtagsIndex returns an empty index to add the definitions found by ctags to
+func NewCtagsIndex() *Index {
+	return newIndex(FormatCtags)
+}
+
+// AddCtagsEntries adds the definitions found by ctags in a file, the documentation of a symbol is the line
+// it is first defined on
+func (idx *Index) AddCtagsEntries(path string, content []byte, entries []*ctags.Entry) {
+	lines := bytes.Split(content, []byte{'\n'})
+	for _, entry := range entries {
+		if entry.Name == "" || entry.Line <= 0 || entry.Line > len(lines) {
+			continue
+		}
+		line := strings.TrimSuffix(string(lines[entry.Line-1]), "\r")
+		i := strings.Index(line, entry.Name)
+		if i < 0 {
+			continue
+		}
+		start := UTF16Len(line[:i])
+		symbol := CtagsSymbol(entry.Name)
+		idx.Occurrences = append(idx.Occurrences, &Occurrence{
+			Path:         path,
+			Range:        Range{Line: entry.Line - 1, StartChar: start, EndLine: entry.Line - 1, EndChar: start + UTF16Len(entry.Name)},
+			Symbol:       symbol,
+			IsDefinition: true,
+		})
+		if _, ok := idx.Documentation[symbol]; !ok {
+			idx.Documentation[symbol] = "```" + strings.ToLower(entry.Language) + "\n" + strings.TrimSpace(line) + "\n```"
+		}
+	}
+}
+
+func isIdentifierRune(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// IsWordAt reports whether the bytes [i, i+size) of a line aren't a part of a longer identifier
+func IsWordAt(line string, i, size int) bool {
+	if before, _ := utf8.DecodeLastRuneInString(line[:i]); i > 0 && isIdentifierRune(before) {
+		return false
+	}
+	after, _ := utf8.DecodeRuneInString(line[i+size:])
+	return i+size == len(line) || !isIdentifierRune(after)
+}
+
+// IdentifierAt returns the identifier at a position of a file, the character is counted in UTF-16 code units
+func IdentifierAt(content []byte, line, character int) (string, Range, bool) {
+	lines := bytes.Split(content, []byte{'\n'})
+	if line < 0 || line >= len(lines) {
+		return "", Range{}, false
+	}
+	runes := []rune(string(lines[line]))
+	// find the rune at the character
+	pos, units := -1, 0
+	for i, r := range runes {
+		n := UTF16Len(string(r))
+		if character < units+n {
+			pos = i
+			break
+		}
+		units += n
+	}
+	if pos < 0 || !isIdentifierRune(runes[pos]) {
+		return "", Range{}, false
+	}
+	start, end := pos, pos+1
+	for start > 0 && isIdentifierRune(runes[start-1]) {
+		start--
+	}
+	for end < len(runes) && isIdentifierRune(runes[end]) {
+		end++
+	}
+	startChar := UTF16Len(string(runes[:start]))
+	name := string(runes[start:end])
+	if unicode.IsDigit(runes[start]) {
+		return "", Range{}, false // a number
+	}
+	return name, Range{Line: line, StartChar: startChar, EndLine: line, EndChar: startChar + UTF16Len(name)}, true
+}
@@ -0,0 +1,263 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package codenav
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"slices"
+	"strings"
+
+	"gitea.dev/modules/json"
+)
+
+// lsifElement is a vertex or an edge of an LSIF graph, see https://microsoft.github.io/language-server-protocol/specifications/lsif/0.6.0/specification/
+type lsifElement struct {
+	ID    json.Value `json:"id"`
+	Type  string     `json:"type"`
+	Label string     `json:"label"`
+
+	// metaData
+	ProjectRoot string `json:"projectRoot"`
+	// document
+	URI string `json:"uri"`
+	// range
+	Start *lsifPosition `json:"start"`
+	End   *lsifPosition `json:"end"`
+	// hoverResult
+	Result *struct {
+		Contents json.Value `json:"contents"`
+	} `json:"result"`
+
+	// edges
+	OutV json.Value   `json:"outV"`
+	InV  json.Value   `json:"inV"`
+	InVs []json.Value `json:"inVs"`
+}
+
+type lsifPosition struct {
+	Line      int `json:"line"`
+	Character int `json:"character"`
+}
+
+// lsifGraph keeps the parts of the graph needed to find the definitions, the references and the hovers of the ranges
+type lsifGraph struct {
+	projectRoot string
+	documents   map[string]string // id -> uri
+	ranges      map[string]Range
+	contains    map[string][]string // document -> ranges
+	next        map[string]string   // range or result set -> result set
+	definition  map[string]string   // range or result set -> definition result
+	hover       map[string]string   // range or result set -> hover result
+	items       map[string][]string // definition result -> ranges
+	hovers      map[string]string   // hover result -> Markdown
+}
+
+// lsifID normalizes an id, it can be a number or a string
+func lsifID(raw json.Value) string {
+	return strings.Trim(string(raw), `"`)
+}
+
+// ParseLSIF reads an LSIF dump, either JSON lines or a JSON array of the elements. Like the LSP, the characters
+// of the positions are UTF-16 code units.
+func ParseLSIF(r io.Reader) (*Index, error) {
+	g := &lsifGraph{
+		documents:  make(map[string]string),
+		ranges:     make(map[string]Range),
+		contains:   make(map[string][]string),
+		next:       make(map[string]string),
+		definition: make(map[string]string),
+		hover:      make(map[string]string),
+		items:      make(map[string][]string),
+		hovers:     make(map[string]string),
+	}
+
+	br := bufio.NewReader(r)
+	first, err := peekNonSpace(br)
+	if err != nil {
+		return nil, err
+	}
+	if first == '[' {
+		var elements []*lsifElement
+		if err := json.NewDecoder(br).Decode(&elements); err != nil {
+			return nil, fmt.Errorf("malformed LSIF dump: %w", err)
+		}
+		for _, element := range elements {
+			g.add(element)
+		}
+		return g.index()
+	}
+	for {
+		line, err := br.ReadBytes('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return nil, err
+		}
+		if line = bytes.TrimSpace(line); len(line) > 0 {
+			var element lsifElement
+			if err := json.Unmarshal(line, &element); err != nil {
+				return nil, fmt.Errorf("malformed LSIF dump: %w", err)
+			}
+			g.add(&element)
+		}
+		if err != nil {
+			break
+		}
+	}
+	return g.index()
+}
+
+func peekNonSpace(br *bufio.Reader) (byte, error) {
+	for {
+		b, err := br.Peek(1)
+		if errors.Is(err, io.EOF) {
+			return 0, nil
+		} else if err != nil {
+			return 0, err
+		}
+		if !bytes.ContainsAny(b, " \t\r\n") {
+			return b[0], nil
+		}
+		_, _ = br.ReadByte()
+	}
+}
+
+func (g *lsifGraph) add(e *lsifElement) {
+	id := lsifID(e.ID)
+	switch e.Label {
+	case "metaData":
+		g.projectRoot = e.ProjectRoot
+	case "document":
+		g.documents[id] = e.URI
+	case "range":
+		if e.Start != nil && e.End != nil {
+			g.ranges[id] = Range{Line: e.Start.Line, StartChar: e.Start.Character, EndLine: e.End.Line, EndChar: e.End.Character}
+		}
+	case "hoverResult":
+		if e.Result != nil {
+			g.hovers[id] = lsifHoverMarkdown(e.Result.Contents)
+		}
+	case "contains":
+		for _, inV := range e.InVs {
+			g.contains[lsifID(e.OutV)] = append(g.contains[lsifID(e.OutV)], lsifID(inV))
+		}
+	case "next":
+		g.next[lsifID(e.OutV)] = lsifID(e.InV)
+	case "textDocument/definition":
+		g.definition[lsifID(e.OutV)] = lsifID(e.InV)
+	case "textDocument/hover":
+		g.hover[lsifID(e.OutV)] = lsifID(e.InV)
+	case "item":
+		for _, inV := range e.InVs {
+			g.items[lsifID(e.OutV)] = append(g.items[lsifID(e.OutV)], lsifID(inV))
+		}
+	}
+}
+
+// chain returns the range and the result sets it is linked to by the next edges
+func (g *lsifGraph) chain(id string) []string {
+	ids := []string{id}
+	for {
+		next, ok := g.next[id]
+		if !ok || slices.Contains(ids, next) {
+			return ids
+		}
+		ids = append(ids, next)
+		id = next
+	}
+}
+
+func (g *lsifGraph) path(uri string) string {
+	if g.projectRoot != "" {
+		if rel, ok := strings.CutPrefix(uri, strings.TrimSuffix(g.projectRoot, "/")+"/"); ok {
+			uri = rel
+		}
+	}
+	if u, err := url.Parse(uri); err == nil && u.Scheme != "" {
+		uri = u.Path
+	} else if unescaped, err := url.PathUnescape(uri); err == nil {
+		uri = unescaped
+	}
+	return strings.TrimPrefix(uri, "/")
+}
+
+func (g *lsifGraph) index() (*Index, error) {
+	idx := newIndex(FormatLSIF)
+	definitions := make(map[string]bool)
+	for _, ranges := range g.items {
+		for _, id := range ranges {
+			definitions[id] = false
+		}
+	}
+	// only the items of the definition results are definitions, the other ones are references
+	for _, result := range g.definition {
+		for _, id := range g.items[result] {
+			definitions[id] = true
+		}
+	}
+
+	for docID, rangeIDs := range g.contains {
+		uri, ok := g.documents[docID]
+		if !ok {
+			continue
+		}
+		path := g.path(uri)
+		for _, id := range rangeIDs {
+			rng, ok := g.ranges[id]
+			if !ok {
+				continue
+			}
+			// the symbol of a range is the last result set of its chain, which is shared by all its occurrences
+			chain := g.chain(id)
+			symbol := "lsif " + chain[len(chain)-1]
+			idx.Occurrences = append(idx.Occurrences, &Occurrence{Path: path, Range: rng, Symbol: symbol, IsDefinition: definitions[id]})
+			if _, ok := idx.Documentation[symbol]; ok {
+				continue
+			}
+			for _, link := range chain {
+				if hover, ok := g.hovers[g.hover[link]]; ok && hover != "" {
+					idx.Documentation[symbol] = hover
+					break
+				}
+			}
+		}
+	}
+	if len(idx.Occurrences) == 0 && len(g.ranges) > 0 {
+		return nil, errors.New("malformed LSIF dump: no range is contained by a document")
+	}
+	return idx, nil
+}
+
+// lsifHoverMarkdown converts the contents of a hover to Markdown, they are a MarkupContent,
+// a MarkedString or a list of MarkedStrings
+func lsifHoverMarkdown(raw json.Value) string {
+	var markup struct {
+		Kind     string `json:"kind"`
+		Language string `json:"language"`
+		Value    string `json:"value"`
+	}
+	var str string
+	var list []json.Value
+	switch {
+	case json.Unmarshal(raw, &str) == nil:
+		return str
+	case json.Unmarshal(raw, &list) == nil:
+		parts := make([]string, 0, len(list))
+		for _, item := range list {
+			if part := lsifHoverMarkdown(item); part != "" {
+				parts = append(parts, part)
+			}
+		}
+		return strings.Join(parts, "\n\n")
+	case json.Unmarshal(raw, &markup) == nil:
+		if markup.Language != "" {
+			return "```" + markup.Language + "\n" + markup.Value + "\n```"
+		}
+		return markup.Value
+	}
+	return ""
+}
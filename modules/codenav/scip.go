@@ -0,0 +1,202 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package codenav
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// The field numbers of the SCIP protobuf messages, see https://github.com/sourcegraph/scip/blob/main/scip.proto
+const (
+	scipIndexDocuments       = 2
+	scipIndexExternalSymbols = 3
+
+	scipDocumentRelativePath = 1
+	scipDocumentOccurrences  = 2
+	scipDocumentSymbols      = 3
+
+	scipOccurrenceRange       = 1
+	scipOccurrenceSymbol      = 2
+	scipOccurrenceSymbolRoles = 3
+
+	scipSymbolInformationSymbol        = 1
+	scipSymbolInformationDocumentation = 3
+
+	scipSymbolRoleDefinition = 0x1
+)
+
+var errSCIPMalformed = errors.New("malformed SCIP index")
+
+// ParseSCIP reads a SCIP index, the positions are kept in the encoding used by the indexer
+func ParseSCIP(r io.Reader) (*Index, error) {
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	idx := newIndex(FormatSCIP)
+	err = scipFields(b, func(num protowire.Number, typ protowire.Type, v []byte, _ uint64) error {
+		if typ != protowire.BytesType {
+			return nil
+		}
+		switch num {
+		case scipIndexDocuments:
+			return idx.addSCIPDocument(v)
+		case scipIndexExternalSymbols:
+			return idx.addSCIPSymbol(v, "")
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return idx, nil
+}
+
+// scipFields calls fn for every field of a message, v is the value of the length-delimited fields
+// and n the value of the varint ones
+func scipFields(b []byte, fn func(num protowire.Number, typ protowire.Type, v []byte, n uint64) error) error {
+	for len(b) > 0 {
+		num, typ, l := protowire.ConsumeTag(b)
+		if l < 0 {
+			return errSCIPMalformed
+		}
+		b = b[l:]
+		var v []byte
+		var n uint64
+		switch typ {
+		case protowire.BytesType:
+			v, l = protowire.ConsumeBytes(b)
+		case protowire.VarintType:
+			n, l = protowire.ConsumeVarint(b)
+		default:
+			l = protowire.ConsumeFieldValue(num, typ, b)
+		}
+		if l < 0 {
+			return errSCIPMalformed
+		}
+		b = b[l:]
+		if err := fn(num, typ, v, n); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// scipSymbol returns the name of a symbol, the local symbols are only unique in their document
+func scipSymbol(symbol, path string) string {
+	if strings.HasPrefix(symbol, "local ") {
+		return symbol + " " + path
+	}
+	return symbol
+}
+
+func (idx *Index) addSCIPDocument(b []byte) error {
+	var path string
+	var occurrences, symbols [][]byte
+	err := scipFields(b, func(num protowire.Number, typ protowire.Type, v []byte, _ uint64) error {
+		if typ != protowire.BytesType {
+			return nil
+		}
+		switch num {
+		case scipDocumentRelativePath:
+			path = string(v)
+		case scipDocumentOccurrences:
+			occurrences = append(occurrences, v)
+		case scipDocumentSymbols:
+			symbols = append(symbols, v)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if path == "" {
+		return fmt.Errorf("%w: a document has no path", errSCIPMalformed)
+	}
+	for _, v := range occurrences {
+		if err := idx.addSCIPOccurrence(v, path); err != nil {
+			return err
+		}
+	}
+	for _, v := range symbols {
+		if err := idx.addSCIPSymbol(v, path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (idx *Index) addSCIPOccurrence(b []byte, path string) error {
+	var rng []int
+	var symbol string
+	var roles uint64
+	err := scipFields(b, func(num protowire.Number, typ protowire.Type, v []byte, n uint64) error {
+		switch {
+		case num == scipOccurrenceRange && typ == protowire.BytesType: // packed
+			for len(v) > 0 {
+				x, l := protowire.ConsumeVarint(v)
+				if l < 0 {
+					return errSCIPMalformed
+				}
+				rng = append(rng, int(int32(x)))
+				v = v[l:]
+			}
+		case num == scipOccurrenceRange && typ == protowire.VarintType:
+			rng = append(rng, int(int32(n)))
+		case num == scipOccurrenceSymbol && typ == protowire.BytesType:
+			symbol = string(v)
+		case num == scipOccurrenceSymbolRoles && typ == protowire.VarintType:
+			roles = n
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if symbol == "" {
+		return nil
+	}
+
+	// the range is [line, start, end] on a single line or [line, start, end line, end]
+	occurrence := &Occurrence{Path: path, Symbol: scipSymbol(symbol, path), IsDefinition: roles&scipSymbolRoleDefinition != 0}
+	switch len(rng) {
+	case 3:
+		occurrence.Range = Range{Line: rng[0], StartChar: rng[1], EndLine: rng[0], EndChar: rng[2]}
+	case 4:
+		occurrence.Range = Range{Line: rng[0], StartChar: rng[1], EndLine: rng[2], EndChar: rng[3]}
+	default:
+		return fmt.Errorf("%w: invalid range in %s", errSCIPMalformed, path)
+	}
+	idx.Occurrences = append(idx.Occurrences, occurrence)
+	return nil
+}
+
+func (idx *Index) addSCIPSymbol(b []byte, path string) error {
+	var symbol string
+	var docs []string
+	err := scipFields(b, func(num protowire.Number, typ protowire.Type, v []byte, _ uint64) error {
+		if typ != protowire.BytesType {
+			return nil
+		}
+		switch num {
+		case scipSymbolInformationSymbol:
+			symbol = string(v)
+		case scipSymbolInformationDocumentation:
+			docs = append(docs, string(v))
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if symbol != "" && len(docs) > 0 {
+		idx.Documentation[scipSymbol(symbol, path)] = strings.Join(docs, "\n\n")
+	}
+	return nil
+}
@@ -0,0 +1,172 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+// Package ctags extracts the symbols of source files with Universal Ctags.
+// The ctags process is started once in its interactive mode and the files are sent to it one by one,
+// so no file has to be written to the disk.
+package ctags
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+	"sync"
+
+	"gitea.dev/modules/json"
+	"gitea.dev/modules/log"
+	"gitea.dev/modules/process"
+)
+
+// maxLineSize is the size of the longest line read from ctags, a tag repeats the source line it is defined on
+const maxLineSize = 1024 * 1024
+
+// Entry is a symbol found by ctags
+type Entry struct {
+	Name      string `json:"name"`
+	Path      string `json:"path"`
+	Language  string `json:"language"`
+	Line      int    `json:"line"`
+	Kind      string `json:"kind"`
+	Scope     string `json:"scope"`
+	ScopeKind string `json:"scopeKind"`
+	Signature string `json:"signature"`
+}
+
+// reply is a line written by ctags, the name of the program is the name of the entry in the first one
+type reply struct {
+	Type    string `json:"_type"`
+	Command string `json:"command"`
+	Message string `json:"message"`
+	Fatal   bool   `json:"fatal"`
+	Entry
+}
+
+type request struct {
+	Command  string `json:"command"`
+	Filename string `json:"filename"`
+	Size     int    `json:"size"`
+}
+
+// Parser sends files to a running ctags process, the files of concurrent calls are sent one after the other
+type Parser struct {
+	mu       sync.Mutex
+	cmd      *exec.Cmd
+	finished process.FinishedFunc
+	in       io.WriteCloser
+	out      *bufio.Scanner
+}
+
+// NewParser starts ctags, binary is the path of a Universal Ctags executable built with the interactive mode
+func NewParser(ctx context.Context, binary string) (*Parser, error) {
+	ctx, _, finished := process.GetManager().AddTypedContext(ctx, "ctags", process.SystemProcessType, false)
+	cmd := exec.CommandContext(ctx, binary, "--_interactive=default", "--fields=*")
+	process.SetSysProcAttribute(cmd)
+	in, err := cmd.StdinPipe()
+	if err != nil {
+		finished()
+		return nil, err
+	}
+	out, err := cmd.StdoutPipe()
+	if err != nil {
+		finished()
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		finished()
+		return nil, fmt.Errorf("unable to start %s: %w", binary, err)
+	}
+
+	p := newParser(in, out)
+	p.cmd = cmd
+	p.finished = finished
+	if err := p.readBanner(); err != nil {
+		p.Close()
+		return nil, fmt.Errorf("%s is not a Universal Ctags with the interactive mode: %w", binary, err)
+	}
+	return p, nil
+}
+
+func newParser(in io.WriteCloser, out io.Reader) *Parser {
+	scanner := bufio.NewScanner(out)
+	scanner.Buffer(make([]byte, 0, 4096), maxLineSize)
+	return &Parser{in: in, out: scanner}
+}
+
+func (p *Parser) readReply() (*reply, error) {
+	if !p.out.Scan() {
+		if err := p.out.Err(); err != nil {
+			return nil, err
+		}
+		return nil, io.ErrUnexpectedEOF
+	}
+	r := &reply{}
+	if err := json.Unmarshal(p.out.Bytes(), r); err != nil {
+		return nil, fmt.Errorf("unexpected ctags output %q: %w", p.out.Text(), err)
+	}
+	return r, nil
+}
+
+func (p *Parser) readBanner() error {
+	r, err := p.readReply()
+	if err != nil {
+		return err
+	}
+	if r.Type != "program" || !strings.Contains(r.Name, "Universal Ctags") {
+		return fmt.Errorf("unexpected program %q", r.Name)
+	}
+	return nil
+}
+
+// Parse returns the symbols defined in a file, the language is detected from the filename
+func (p *Parser) Parse(filename string, content []byte) ([]*Entry, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	req, err := json.Marshal(&request{Command: "generate-tags", Filename: filename, Size: len(content)})
+	if err != nil {
+		return nil, err
+	}
+	if _, err := p.in.Write(append(req, '\n')); err != nil {
+		return nil, err
+	}
+	if _, err := p.in.Write(content); err != nil {
+		return nil, err
+	}
+
+	var entries []*Entry
+	for {
+		r, err := p.readReply()
+		if err != nil {
+			return nil, err
+		}
+		switch r.Type {
+		case "tag":
+			entry := r.Entry
+			entries = append(entries, &entry)
+		case "completed":
+			return entries, nil
+		case "error":
+			if r.Fatal {
+				return nil, errors.New(r.Message)
+			}
+			log.Debug("ctags error for %s: %s", filename, r.Message)
+		}
+	}
+}
+
+// Close stops ctags
+func (p *Parser) Close() {
+	_ = p.in.Close()
+	if p.cmd != nil {
+		if err := p.cmd.Wait(); err != nil {
+			log.Debug("ctags exited: %v", err)
+		}
+	}
+	if p.finished != nil {
+		p.finished()
+	}
+}
@@ -0,0 +1,54 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package ctags
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type nopWriteCloser struct {
+	bytes.Buffer
+}
+
+func (w *nopWriteCloser) Close() error {
+	return nil
+}
+
+func TestParser(t *testing.T) {
+	out := strings.Join([]string{
+		`{"_type": "program", "name": "Universal Ctags", "version": "6.1.0"}`,
+		`{"_type": "tag", "name": "main", "path": "main.go", "language": "Go", "line": 3, "kind": "func", "scope": "main", "scopeKind": "package", "signature": "()"}`,
+		`{"_type": "error", "message": "unknown field"}`,
+		`{"_type": "completed", "command": "generate-tags"}`,
+		`{"_type": "completed", "command": "generate-tags"}`,
+		`{"_type": "error", "message": "broken", "fatal": true}`,
+	}, "\n")
+	in := &nopWriteCloser{}
+	p := newParser(in, strings.NewReader(out))
+	require.NoError(t, p.readBanner())
+
+	entries, err := p.Parse("main.go", []byte("package main\n\nfunc main() {}\n"))
+	require.NoError(t, err)
+	if assert.Len(t, entries, 1) {
+		assert.Equal(t, Entry{Name: "main", Path: "main.go", Language: "Go", Line: 3, Kind: "func", Scope: "main", ScopeKind: "package", Signature: "()"}, *entries[0])
+	}
+	assert.Equal(t, `{"command":"generate-tags","filename":"main.go","size":29}`+"\npackage main\n\nfunc main() {}\n", in.String())
+
+	entries, err = p.Parse("empty.txt", nil)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+
+	_, err = p.Parse("broken.c", []byte("int"))
+	assert.EqualError(t, err, "broken")
+}
+
+func TestParserBanner(t *testing.T) {
+	p := newParser(&nopWriteCloser{}, strings.NewReader(`{"_type": "program", "name": "Exuberant Ctags"}`))
+	assert.Error(t, p.readBanner())
+}
@@ -7,6 +7,7 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -34,13 +35,15 @@ import (
 	"github.com/blevesearch/bleve/v2/analysis/token/unicodenorm"
 	"github.com/blevesearch/bleve/v2/analysis/tokenizer/unicode"
 	"github.com/blevesearch/bleve/v2/mapping"
+	"github.com/blevesearch/bleve/v2/search"
 	"github.com/blevesearch/bleve/v2/search/query"
 	"github.com/go-enry/go-enry/v2"
 )
 
 const (
-	unicodeNormalizeName = "unicodeNormalize"
-	maxBatchSize         = 16
+	unicodeNormalizeName  = "unicodeNormalize"
+	maxBatchSize          = 16
+	maxDocumentsQuerySize = 100
 )
 
 func addUnicodeNormalizeTokenFilter(m *mapping.IndexMappingImpl) error {
@@ -57,6 +60,7 @@ type RepoIndexerData struct {
 	Content   string
 	Filename  string
 	Language  string
+	Refs      []string
 	UpdatedAt time.Time
 }
 
@@ -69,7 +73,7 @@ const (
 	repoIndexerAnalyzer      = "repoIndexerAnalyzer"
 	filenameIndexerAnalyzer  = "filenameIndexerAnalyzer"
 	repoIndexerDocType       = "repoIndexerDocType"
-	repoIndexerLatestVersion = 10
+	repoIndexerLatestVersion = 11
 )
 
 // generateBleveIndexMapping generates a bleve index mapping for the repo indexer
@@ -93,6 +97,7 @@ func generateBleveIndexMapping() (mapping.IndexMapping, error) {
 	termFieldMapping.Analyzer = analyzer_keyword.Name
 	docMapping.AddFieldMappingsAt("Language", termFieldMapping)
 	docMapping.AddFieldMappingsAt("CommitID", termFieldMapping)
+	docMapping.AddFieldMappingsAt("Refs", termFieldMapping)
 
 	timeFieldMapping := bleve.NewDateTimeFieldMapping()
 	timeFieldMapping.IncludeInAll = false
@@ -139,6 +144,10 @@ func (b *Indexer) SupportedSearchModes() []indexer.SearchMode {
 	return indexer.SearchModesExactWords()
 }
 
+func (b *Indexer) SupportsSearchFilters() bool {
+	return false
+}
+
 // NewIndexer creates a new bleve local indexer
 func NewIndexer(indexDir string) *Indexer {
 	inner := inner_bleve.NewIndexer(indexDir, repoIndexerLatestVersion, generateBleveIndexMapping)
@@ -148,7 +157,7 @@ func NewIndexer(indexDir string) *Indexer {
 	}
 }
 
-func (b *Indexer) addUpdate(ctx context.Context, catFileBatch git.CatFileBatch, commitSha string,
+func (b *Indexer) addUpdate(ctx context.Context, catFileBatch git.CatFileBatch, commitSha, ref string,
 	update internal.FileUpdate, repo *repo_model.Repository, batch *inner_bleve.FlushingBatch,
 ) error {
 	// Ignore vendored files in code search
@@ -171,7 +180,7 @@ func (b *Indexer) addUpdate(ctx context.Context, catFileBatch git.CatFileBatch,
 	}
 
 	if size > setting.Indexer.MaxIndexerFileSize {
-		return b.addDelete(update.Filename, repo, batch)
+		return nil
 	}
 
 	info, batchReader, err := catFileBatch.QueryContent(update.BlobSha)
@@ -190,25 +199,103 @@ func (b *Indexer) addUpdate(ctx context.Context, catFileBatch git.CatFileBatch,
 	if _, err = batchReader.Discard(1); err != nil {
 		return err
 	}
-	id := internal.FilenameIndexerID(repo.ID, update.Filename)
+	id := internal.FileIndexerID(repo.ID, update.BlobSha, update.Filename)
 	return batch.Index(id, &RepoIndexerData{
 		RepoID:    repo.ID,
 		CommitID:  commitSha,
 		Filename:  update.Filename,
 		Content:   string(charset.ToUTF8DropErrors(fileContents)),
 		Language:  analyze.GetCodeLanguage(update.Filename, fileContents),
+		Refs:      []string{ref},
 		UpdatedAt: time.Now().UTC(),
 	})
 }
 
-func (b *Indexer) addDelete(filename string, repo *repo_model.Repository, batch *inner_bleve.FlushingBatch) error {
-	id := internal.FilenameIndexerID(repo.ID, filename)
-	return batch.Delete(id)
+// removeRef removes the ref from an indexed file, the file is deleted when it isn't in any other ref
+func removeRef(id string, doc *RepoIndexerData, ref string, batch *inner_bleve.FlushingBatch) error {
+	doc.Refs = slices.DeleteFunc(doc.Refs, func(r string) bool { return r == ref })
+	if len(doc.Refs) == 0 {
+		return batch.Delete(id)
+	}
+	return batch.Index(id, doc)
+}
+
+var documentFields = []string{"RepoID", "CommitID", "Content", "Filename", "Language", "Refs", "UpdatedAt"}
+
+func documentFromHit(hit *search.DocumentMatch) (*RepoIndexerData, error) {
+	doc := &RepoIndexerData{}
+	repoID, okRepoID := hit.Fields["RepoID"].(float64)
+	doc.CommitID, _ = hit.Fields["CommitID"].(string)
+	doc.Content, _ = hit.Fields["Content"].(string)
+	doc.Filename, _ = hit.Fields["Filename"].(string)
+	doc.Language, _ = hit.Fields["Language"].(string)
+	updatedAt, okUpdatedAt := hit.Fields["UpdatedAt"].(string)
+	if !okRepoID || !okUpdatedAt {
+		return nil, fmt.Errorf("unexpected field types in document %q", hit.ID)
+	}
+	doc.RepoID = int64(repoID)
+	doc.UpdatedAt, _ = time.Parse(time.RFC3339, updatedAt)
+
+	// a multi-valued field is returned as a single value when there is only one
+	switch refs := hit.Fields["Refs"].(type) {
+	case string:
+		doc.Refs = []string{refs}
+	case []any:
+		for _, ref := range refs {
+			if s, ok := ref.(string); ok {
+				doc.Refs = append(doc.Refs, s)
+			}
+		}
+	}
+	return doc, nil
+}
+
+// getDocuments returns the indexed files among the given ids
+func (b *Indexer) getDocuments(ctx context.Context, ids []string) (map[string]*RepoIndexerData, error) {
+	docs := make(map[string]*RepoIndexerData, len(ids))
+	if len(ids) == 0 {
+		return docs, nil
+	}
+	searchRequest := bleve.NewSearchRequestOptions(bleve.NewDocIDQuery(ids), len(ids), 0, false)
+	searchRequest.Fields = documentFields
+	result, err := b.inner.Indexer.SearchInContext(ctx, searchRequest)
+	if err != nil {
+		return nil, err
+	}
+	for _, hit := range result.Hits {
+		if docs[hit.ID], err = documentFromHit(hit); err != nil {
+			return nil, err
+		}
+	}
+	return docs, nil
 }
 
 // Index indexes the data
-func (b *Indexer) Index(ctx context.Context, repo *repo_model.Repository, sha string, changes *internal.RepoChanges) error {
+func (b *Indexer) Index(ctx context.Context, repo *repo_model.Repository, ref, sha string, changes *internal.RepoChanges) error {
 	batch := inner_bleve.NewFlushingBatch(b.inner.Indexer, maxBatchSize)
+
+	for removals := range slices.Chunk(changes.RemovedFiles, maxDocumentsQuerySize) {
+		ids := make([]string, 0, len(removals))
+		for _, removal := range removals {
+			ids = append(ids, internal.FileIndexerID(repo.ID, removal.BlobSha, removal.Filename))
+		}
+		docs, err := b.getDocuments(ctx, ids)
+		if err != nil {
+			return err
+		}
+		for _, id := range ids {
+			if doc, ok := docs[id]; ok {
+				if err := removeRef(id, doc, ref, batch); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	// the lookup of the updated files must see the removals
+	if err := batch.Flush(); err != nil {
+		return err
+	}
+
 	if len(changes.Updates) > 0 {
 		catfileBatch, err := git.NewBatch(ctx, repo)
 		if err != nil {
@@ -216,18 +303,65 @@ func (b *Indexer) Index(ctx context.Context, repo *repo_model.Repository, sha st
 		}
 		defer catfileBatch.Close()
 
-		for _, update := range changes.Updates {
-			if err := b.addUpdate(ctx, catfileBatch, sha, update, repo, batch); err != nil {
+		for updates := range slices.Chunk(changes.Updates, maxDocumentsQuerySize) {
+			ids := make([]string, 0, len(updates))
+			for _, update := range updates {
+				ids = append(ids, internal.FileIndexerID(repo.ID, update.BlobSha, update.Filename))
+			}
+			docs, err := b.getDocuments(ctx, ids)
+			if err != nil {
 				return err
 			}
+			for i, update := range updates {
+				// the file is already indexed for another ref, only the ref is added to it
+				if doc, ok := docs[ids[i]]; ok {
+					if !slices.Contains(doc.Refs, ref) {
+						doc.Refs = append(doc.Refs, ref)
+						if err := batch.Index(ids[i], doc); err != nil {
+							return err
+						}
+					}
+					continue
+				}
+				if err := b.addUpdate(ctx, catfileBatch, sha, ref, update, repo, batch); err != nil {
+					return err
+				}
+			}
 		}
 	}
-	for _, filename := range changes.RemovedFilenames {
-		if err := b.addDelete(filename, repo, batch); err != nil {
+	return batch.Flush()
+}
+
+// DeleteRef removes a ref from the indexed files of a repository
+func (b *Indexer) DeleteRef(ctx context.Context, repoID int64, ref string) error {
+	refQuery := bleve.NewTermQuery(ref)
+	refQuery.FieldVal = "Refs"
+	query := bleve.NewConjunctionQuery(inner_bleve.NumericEqualityQuery(repoID, "RepoID"), refQuery)
+	for {
+		// the updated documents don't match the query anymore, so the first page is always requested
+		searchRequest := bleve.NewSearchRequestOptions(query, maxDocumentsQuerySize, 0, false)
+		searchRequest.Fields = documentFields
+		result, err := b.inner.Indexer.SearchInContext(ctx, searchRequest)
+		if err != nil {
+			return err
+		} else if len(result.Hits) == 0 {
+			return nil
+		}
+
+		batch := inner_bleve.NewFlushingBatch(b.inner.Indexer, maxBatchSize)
+		for _, hit := range result.Hits {
+			doc, err := documentFromHit(hit)
+			if err != nil {
+				return err
+			}
+			if err := removeRef(hit.ID, doc, ref, batch); err != nil {
+				return err
+			}
+		}
+		if err := batch.Flush(); err != nil {
 			return err
 		}
 	}
-	return batch.Flush()
 }
 
 // Delete deletes indexes by ids
@@ -296,6 +430,10 @@ func (b *Indexer) Search(ctx context.Context, opts *internal.SearchOptions) (int
 		indexerQuery = keywordQuery
 	}
 
+	refQuery := bleve.NewTermQuery(util.IfZero(opts.Ref, internal.DefaultBranchRef))
+	refQuery.FieldVal = "Refs"
+	indexerQuery = bleve.NewConjunctionQuery(indexerQuery, refQuery)
+
 	// Save for reuse without language filter
 	facetQuery := indexerQuery
 	if len(opts.Language) > 0 {
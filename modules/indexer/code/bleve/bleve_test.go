@@ -24,7 +24,7 @@ func TestBleveIndexerTokenFilter(t *testing.T) {
 	require.NoError(t, err)
 
 	batch := inner_bleve.NewFlushingBatch(indexer.inner.Indexer, maxBatchSize)
-	batch.Index("2", &RepoIndexerData{RepoID: 2, Content: "mDNS.port2=12345", UpdatedAt: time.Now()})
+	batch.Index("2", &RepoIndexerData{RepoID: 2, Content: "mDNS.port2=12345", Refs: []string{internal.DefaultBranchRef}, UpdatedAt: time.Now()})
 	batch.Flush()
 
 	testCases := []struct {
@@ -50,6 +50,49 @@ func TestBleveIndexerTokenFilter(t *testing.T) {
 	}
 }
 
+func TestBleveIndexerSearchRef(t *testing.T) {
+	dir := t.TempDir()
+	indexer := NewIndexer(dir)
+	defer indexer.Close()
+
+	_, err := indexer.Init(t.Context())
+	require.NoError(t, err)
+
+	batch := inner_bleve.NewFlushingBatch(indexer.inner.Indexer, maxBatchSize)
+	batch.Index(internal.FileIndexerID(2, "1111", "main.go"), &RepoIndexerData{RepoID: 2, Filename: "main.go", Content: "shared onlyondefault", Refs: []string{internal.DefaultBranchRef}, UpdatedAt: time.Now()})
+	batch.Index(internal.FileIndexerID(2, "2222", "both.go"), &RepoIndexerData{RepoID: 2, Filename: "both.go", Content: "shared", Refs: []string{internal.DefaultBranchRef, "refs/heads/feature"}, UpdatedAt: time.Now()})
+	batch.Index(internal.FileIndexerID(2, "3333", "feature.go"), &RepoIndexerData{RepoID: 2, Filename: "feature.go", Content: "shared onlyonfeature", Refs: []string{"refs/heads/feature"}, UpdatedAt: time.Now()})
+	batch.Flush()
+
+	testCases := []struct {
+		ref           string
+		keyword       string
+		expectedFiles []string
+	}{
+		{ref: "", keyword: "shared", expectedFiles: []string{"main.go", "both.go"}},
+		{ref: "refs/heads/feature", keyword: "shared", expectedFiles: []string{"both.go", "feature.go"}},
+		{ref: "refs/heads/feature", keyword: "onlyondefault", expectedFiles: []string{}},
+		{ref: "", keyword: "onlyonfeature", expectedFiles: []string{}},
+		{ref: "refs/heads/unknown", keyword: "shared", expectedFiles: []string{}},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.ref+":"+testCase.keyword, func(t *testing.T) {
+			_, results, _, err := indexer.Search(t.Context(), &internal.SearchOptions{
+				Paginator: &db.ListOptions{Page: 1, PageSize: 10},
+				Keyword:   testCase.keyword,
+				Ref:       testCase.ref,
+			})
+			require.NoError(t, err)
+			filenames := make([]string, 0, len(results))
+			for _, result := range results {
+				filenames = append(filenames, result.Filename)
+			}
+			assert.ElementsMatch(t, testCase.expectedFiles, filenames)
+		})
+	}
+}
+
 func searchResultIDs(result []*internal.SearchResult) []int64 {
 	ids := make([]int64, 0, len(result))
 	for _, hit := range result {
@@ -7,6 +7,7 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -28,7 +29,7 @@ import (
 	"github.com/go-enry/go-enry/v2"
 )
 
-const esRepoIndexerLatestVersion = 3
+const esRepoIndexerLatestVersion = 4
 
 var _ internal.Indexer = &Indexer{}
 
@@ -41,6 +42,10 @@ func (b *Indexer) SupportedSearchModes() []indexer.SearchMode {
 	return indexer.SearchModesExactWords()
 }
 
+func (b *Indexer) SupportsSearchFilters() bool {
+	return false
+}
+
 // NewIndexer creates a new elasticsearch indexer
 func NewIndexer(url, indexerName string) *Indexer {
 	return &Indexer{Indexer: es.NewIndexer(url, indexerName, esRepoIndexerLatestVersion, defaultMapping)}
@@ -110,6 +115,10 @@ const (
 					"type": "keyword",
 					"index": true
 				},
+				"refs": {
+					"type": "keyword",
+					"index": true
+				},
 				"language": {
 					"type": "keyword",
 					"index": true
@@ -123,7 +132,14 @@ const (
 	}`
 )
 
-func (b *Indexer) addUpdate(ctx context.Context, catFileBatch git.CatFileBatch, sha string, update internal.FileUpdate, repo *repo_model.Repository) ([]es.BulkOp, error) {
+const (
+	addRefScript    = `if (ctx._source.refs == null) { ctx._source.refs = [params.ref]; } else if (ctx._source.refs.contains(params.ref)) { ctx.op = 'noop'; } else { ctx._source.refs.add(params.ref); }`
+	removeRefScript = `if (ctx._source.refs != null) { ctx._source.refs.removeIf(r -> r == params.ref); } if (ctx._source.refs == null || ctx._source.refs.isEmpty()) { ctx.op = 'delete'; }`
+
+	esBatchSize = 50
+)
+
+func (b *Indexer) addUpdate(ctx context.Context, catFileBatch git.CatFileBatch, sha, ref string, update internal.FileUpdate, repo *repo_model.Repository) ([]es.BulkOp, error) {
 	// Ignore vendored files in code search
 	if setting.Indexer.ExcludeVendored && analyze.IsVendor(update.Filename) {
 		return nil, nil
@@ -142,9 +158,8 @@ func (b *Indexer) addUpdate(ctx context.Context, catFileBatch git.CatFileBatch,
 		}
 	}
 
-	id := internal.FilenameIndexerID(repo.ID, update.Filename)
 	if size > setting.Indexer.MaxIndexerFileSize {
-		return []es.BulkOp{es.DeleteOp(id)}, nil
+		return nil, nil
 	}
 
 	info, batchReader, err := catFileBatch.QueryContent(update.BlobSha)
@@ -164,23 +179,33 @@ func (b *Indexer) addUpdate(ctx context.Context, catFileBatch git.CatFileBatch,
 		return nil, err
 	}
 
-	return []es.BulkOp{es.IndexOp(id, map[string]any{
+	return []es.BulkOp{es.IndexOp(internal.FileIndexerID(repo.ID, update.BlobSha, update.Filename), map[string]any{
 		"repo_id":    repo.ID,
 		"filename":   update.Filename,
 		"content":    string(charset.ToUTF8DropErrors(fileContents)),
 		"commit_id":  sha,
 		"language":   analyze.GetCodeLanguage(update.Filename, fileContents),
+		"refs":       []string{ref},
 		"updated_at": timeutil.TimeStampNow(),
 	})}, nil
 }
 
-func (b *Indexer) addDelete(filename string, repo *repo_model.Repository) es.BulkOp {
-	return es.DeleteOp(internal.FilenameIndexerID(repo.ID, filename))
+func refScript(source, ref string) es.Script {
+	return es.Script{Source: source, Params: map[string]any{"ref": ref}}
 }
 
 // Index will save the index data
-func (b *Indexer) Index(ctx context.Context, repo *repo_model.Repository, sha string, changes *internal.RepoChanges) error {
+func (b *Indexer) Index(ctx context.Context, repo *repo_model.Repository, ref, sha string, changes *internal.RepoChanges) error {
 	ops := make([]es.BulkOp, 0)
+	for _, removal := range changes.RemovedFiles {
+		// the file is deleted by the script when it isn't in any other ref, the missing files are ignored
+		ops = append(ops, es.UpdateOp(internal.FileIndexerID(repo.ID, removal.BlobSha, removal.Filename), refScript(removeRefScript, ref)))
+	}
+	// the lookup of the updated files must see the removals
+	if err := b.bulk(ctx, ops); err != nil {
+		return err
+	}
+
 	if len(changes.Updates) > 0 {
 		batch, err := git.NewBatch(ctx, repo)
 		if err != nil {
@@ -188,33 +213,56 @@ func (b *Indexer) Index(ctx context.Context, repo *repo_model.Repository, sha st
 		}
 		defer batch.Close()
 
-		for _, update := range changes.Updates {
-			updateOps, err := b.addUpdate(ctx, batch, sha, update, repo)
+		ops = ops[:0]
+		for updates := range slices.Chunk(changes.Updates, esBatchSize) {
+			ids := make([]string, 0, len(updates))
+			for _, update := range updates {
+				ids = append(ids, internal.FileIndexerID(repo.ID, update.BlobSha, update.Filename))
+			}
+			existing, err := b.ExistingIDs(ctx, ids)
 			if err != nil {
 				return err
 			}
-			if len(updateOps) > 0 {
+			for i, update := range updates {
+				// the file is already indexed for another ref, only the ref is added to it
+				if existing.Contains(ids[i]) {
+					ops = append(ops, es.UpdateOp(ids[i], refScript(addRefScript, ref)))
+					continue
+				}
+				updateOps, err := b.addUpdate(ctx, batch, sha, ref, update, repo)
+				if err != nil {
+					return err
+				}
 				ops = append(ops, updateOps...)
 			}
+			if err := b.bulk(ctx, ops); err != nil {
+				return err
+			}
+			ops = ops[:0]
 		}
 	}
+	return nil
+}
 
-	for _, filename := range changes.RemovedFilenames {
-		ops = append(ops, b.addDelete(filename, repo))
-	}
-
-	if len(ops) > 0 {
-		esBatchSize := 50
-
-		for i := 0; i < len(ops); i += esBatchSize {
-			if err := b.Bulk(ctx, ops[i:min(i+esBatchSize, len(ops))]); err != nil {
-				return err
-			}
+func (b *Indexer) bulk(ctx context.Context, ops []es.BulkOp) error {
+	for chunk := range slices.Chunk(ops, esBatchSize) {
+		if err := b.Bulk(ctx, chunk); err != nil {
+			return err
 		}
 	}
 	return nil
 }
 
+// DeleteRef removes a ref from the indexed files of a repository
+func (b *Indexer) DeleteRef(ctx context.Context, repoID int64, ref string) error {
+	// the files indexed since the last refresh must be found by the query
+	if err := b.Refresh(ctx); err != nil {
+		return err
+	}
+	query := es.NewBoolQuery().Must(es.TermQuery("repo_id", repoID), es.TermQuery("refs", ref))
+	return b.UpdateByQuery(ctx, query, refScript(removeRefScript, ref))
+}
+
 // Delete entries by repoId
 func (b *Indexer) Delete(ctx context.Context, repoID int64) error {
 	if err := b.doDelete(ctx, repoID); err != nil {
@@ -337,7 +385,7 @@ func (b *Indexer) Search(ctx context.Context, opts *internal.SearchOptions) (int
 		contentQuery,
 		es.NewMultiMatchQuery(opts.Keyword, "filename^10").Type(es.MultiMatchTypePhrasePrefix),
 	)
-	query := es.NewBoolQuery().Must(kwQuery)
+	query := es.NewBoolQuery().Must(kwQuery, es.TermQuery("refs", util.IfZero(opts.Ref, internal.DefaultBranchRef)))
 	if len(opts.RepoIDs) > 0 {
 		query.Must(es.TermsQuery("repo_id", es.ToAnySlice(opts.RepoIDs)...))
 	}
@@ -24,24 +24,106 @@ func getDefaultBranchSha(ctx context.Context, repo *repo_model.Repository) (stri
 	return strings.TrimSpace(stdout), nil
 }
 
-// getRepoChanges returns changes to repo since last indexer update
-func getRepoChanges(ctx context.Context, repo *repo_model.Repository, gitRepo *git.Repository, revision string) (*internal.RepoChanges, error) {
-	status, err := repo_model.GetIndexerStatus(ctx, repo, repo_model.RepoIndexerTypeCode)
+// indexedRef is a ref indexed in addition to the default branch
+type indexedRef struct {
+	Name     string
+	CommitID string
+}
+
+func matchRefGlobs(globs []*setting.GlobMatcher, name string) bool {
+	for _, g := range globs {
+		if g.Match(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsIndexedRef returns whether the code index of the repository has to be updated when the ref changes
+func IsIndexedRef(repo *repo_model.Repository, ref git.RefName) bool {
+	switch {
+	case ref.IsBranch():
+		return ref.BranchName() == repo.DefaultBranch || matchRefGlobs(setting.Indexer.RepoIndexerBranches, ref.BranchName())
+	case ref.IsTag():
+		return matchRefGlobs(setting.Indexer.RepoIndexerTags, ref.TagName())
+	}
+	return false
+}
+
+// getAdditionalRefs returns the branches and the most recent tags matching the indexer settings, except the default branch
+func getAdditionalRefs(ctx context.Context, repo *repo_model.Repository) ([]indexedRef, error) {
+	var refs []indexedRef
+	if len(setting.Indexer.RepoIndexerBranches) > 0 {
+		branches, err := listRefs(ctx, repo, git.BranchPrefix, "refname")
+		if err != nil {
+			return nil, err
+		}
+		for _, ref := range branches {
+			name := git.RefName(ref.Name).BranchName()
+			if name != repo.DefaultBranch && matchRefGlobs(setting.Indexer.RepoIndexerBranches, name) {
+				refs = append(refs, ref)
+			}
+		}
+	}
+
+	if len(setting.Indexer.RepoIndexerTags) > 0 {
+		tags, err := listRefs(ctx, repo, git.TagPrefix, "-creatordate")
+		if err != nil {
+			return nil, err
+		}
+		count := 0
+		for _, ref := range tags {
+			if setting.Indexer.RepoIndexerMaxTags > 0 && count >= setting.Indexer.RepoIndexerMaxTags {
+				break
+			}
+			if matchRefGlobs(setting.Indexer.RepoIndexerTags, git.RefName(ref.Name).TagName()) {
+				refs = append(refs, ref)
+				count++
+			}
+		}
+	}
+	return refs, nil
+}
+
+// listRefs lists the refs with the given prefix, the annotated tags are peeled to their commit
+func listRefs(ctx context.Context, repo *repo_model.Repository, prefix, sort string) ([]indexedRef, error) {
+	stdout, _, err := gitcmd.NewCommand("for-each-ref", "--format=%(refname) %(objectname) %(*objectname)").
+		AddOptionFormat("--sort=%s", sort).AddDynamicArguments(prefix).WithRepo(repo).RunStdString(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	needGenesis := len(status.CommitSha) == 0
+	var refs []indexedRef
+	for line := range strings.SplitSeq(stdout, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+		// the peeled object is only set for the annotated tags
+		refs = append(refs, indexedRef{Name: fields[0], CommitID: fields[len(fields)-1]})
+	}
+	return refs, nil
+}
+
+// getRepoChanges returns changes to a ref since the indexed revision
+func getRepoChanges(ctx context.Context, indexer internal.Indexer, repo *repo_model.Repository, gitRepo *git.Repository, ref, indexedRevision, revision string) (*internal.RepoChanges, error) {
+	needGenesis := len(indexedRevision) == 0
 	if !needGenesis {
-		hasAncestorCmd := gitcmd.NewCommand("merge-base").AddDynamicArguments(status.CommitSha, revision)
+		hasAncestorCmd := gitcmd.NewCommand("merge-base").AddDynamicArguments(indexedRevision, revision)
 		stdout, _, _ := hasAncestorCmd.WithRepo(repo).RunStdString(ctx) // FIXME: error is not handled
 		needGenesis = len(stdout) == 0
 	}
 
 	if needGenesis {
+		if len(indexedRevision) > 0 {
+			// the history has been rewritten, forget the files indexed for the ref
+			if err := indexer.DeleteRef(ctx, repo.ID, ref); err != nil {
+				return nil, err
+			}
+		}
 		return genesisChanges(ctx, repo, gitRepo, revision)
 	}
-	return nonGenesisChanges(ctx, repo, gitRepo, revision)
+	return nonGenesisChanges(ctx, indexer, repo, gitRepo, ref, indexedRevision, revision)
 }
 
 func isIndexable(entry *git.TreeEntry) bool {
@@ -98,14 +180,14 @@ func genesisChanges(ctx context.Context, repo *repo_model.Repository, gitRepo *g
 }
 
 // nonGenesisChanges get changes since the previous indexer update
-func nonGenesisChanges(ctx context.Context, repo *repo_model.Repository, gitRepo *git.Repository, revision string) (*internal.RepoChanges, error) {
-	diffCmd := gitcmd.NewCommand("diff", "--name-status").AddDynamicArguments(repo.CodeIndexerStatus.CommitSha, revision)
+func nonGenesisChanges(ctx context.Context, indexer internal.Indexer, repo *repo_model.Repository, gitRepo *git.Repository, ref, indexedRevision, revision string) (*internal.RepoChanges, error) {
+	diffCmd := gitcmd.NewCommand("diff", "--raw", "--no-abbrev").AddDynamicArguments(indexedRevision, revision)
 	stdout, _, runErr := diffCmd.WithRepo(repo).RunStdString(ctx)
 	if runErr != nil {
 		// previous commit sha may have been removed by a force push, so
 		// try rebuilding from scratch
 		log.Warn("git diff: %v", runErr)
-		if err := (*globalIndexer.Load()).Delete(ctx, repo.ID); err != nil {
+		if err := indexer.DeleteRef(ctx, repo.ID, ref); err != nil {
 			return nil, err
 		}
 		return genesisChanges(ctx, repo, gitRepo, revision)
@@ -136,11 +218,18 @@ func nonGenesisChanges(ctx context.Context, repo *repo_model.Repository, gitRepo
 		if len(line) == 0 {
 			continue
 		}
+		// :<old mode> <new mode> <old sha> <new sha> <status>\t<path>[\t<new path>]
 		fields := strings.Split(line, "\t")
 		if len(fields) < 2 {
-			log.Warn("Unparseable output for diff --name-status: `%s`)", line)
+			log.Warn("Unparseable output for diff --raw: `%s`)", line)
 			continue
 		}
+		meta := strings.Fields(fields[0])
+		if len(meta) != 5 || len(meta[4]) == 0 {
+			log.Warn("Unparseable output for diff --raw: `%s`)", line)
+			continue
+		}
+		oldSha, newSha := meta[2], meta[3]
 		filename := fields[1]
 		if len(filename) == 0 {
 			continue
@@ -151,19 +240,26 @@ func nonGenesisChanges(ctx context.Context, repo *repo_model.Repository, gitRepo
 			}
 		}
 
-		switch status := fields[0][0]; status {
-		case 'M', 'A':
+		switch status := meta[4][0]; status {
+		case 'M', 'T':
+			if oldSha == newSha {
+				// only the mode has changed, the indexed file is the same
+				continue
+			}
+			changes.RemovedFiles = append(changes.RemovedFiles, internal.FileRemoval{Filename: filename, BlobSha: oldSha})
+			updatedFilenames = append(updatedFilenames, filename)
+		case 'A':
 			updatedFilenames = append(updatedFilenames, filename)
 		case 'D':
-			changes.RemovedFilenames = append(changes.RemovedFilenames, filename)
+			changes.RemovedFiles = append(changes.RemovedFiles, internal.FileRemoval{Filename: filename, BlobSha: oldSha})
 		case 'R', 'C':
 			if len(fields) < 3 {
-				log.Warn("Unparseable output for diff --name-status: `%s`)", line)
+				log.Warn("Unparseable output for diff --raw: `%s`)", line)
 				continue
 			}
 			dest := fields[2]
 			if len(dest) == 0 {
-				log.Warn("Unparseable output for diff --name-status: `%s`)", line)
+				log.Warn("Unparseable output for diff --raw: `%s`)", line)
 				continue
 			}
 			if dest[0] == '"' {
@@ -173,7 +269,7 @@ func nonGenesisChanges(ctx context.Context, repo *repo_model.Repository, gitRepo
 				}
 			}
 			if status == 'R' {
-				changes.RemovedFilenames = append(changes.RemovedFilenames, filename)
+				changes.RemovedFiles = append(changes.RemovedFiles, internal.FileRemoval{Filename: filename, BlobSha: oldSha})
 			}
 			updatedFilenames = append(updatedFilenames, dest)
 		default:
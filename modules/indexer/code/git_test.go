@@ -0,0 +1,65 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package code
+
+import (
+	"testing"
+
+	repo_model "gitea.dev/models/repo"
+	"gitea.dev/models/unittest"
+	"gitea.dev/modules/git"
+	"gitea.dev/modules/setting"
+	"gitea.dev/modules/test"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func refGlobs(t *testing.T, patterns ...string) []*setting.GlobMatcher {
+	globs := make([]*setting.GlobMatcher, 0, len(patterns))
+	for _, pattern := range patterns {
+		g, err := setting.GlobMatcherCompile(pattern, '/')
+		require.NoError(t, err)
+		globs = append(globs, g)
+	}
+	return globs
+}
+
+func TestIsIndexedRef(t *testing.T) {
+	defer test.MockVariableValue(&setting.Indexer.RepoIndexerBranches, refGlobs(t, "release/*"))()
+	defer test.MockVariableValue(&setting.Indexer.RepoIndexerTags, refGlobs(t, "v*"))()
+
+	repo := &repo_model.Repository{DefaultBranch: "main"}
+	assert.True(t, IsIndexedRef(repo, git.RefNameFromBranch("main")))
+	assert.True(t, IsIndexedRef(repo, git.RefNameFromBranch("release/1.0")))
+	assert.False(t, IsIndexedRef(repo, git.RefNameFromBranch("release/1.0/fix")))
+	assert.False(t, IsIndexedRef(repo, git.RefNameFromBranch("feature/1")))
+	assert.True(t, IsIndexedRef(repo, git.RefNameFromTag("v1.0")))
+	assert.False(t, IsIndexedRef(repo, git.RefNameFromTag("nightly")))
+	assert.False(t, IsIndexedRef(repo, git.RefName("refs/pull/1/head")))
+}
+
+func TestGetAdditionalRefs(t *testing.T) {
+	unittest.PrepareTestEnv(t)
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+
+	refs, err := getAdditionalRefs(t.Context(), repo)
+	require.NoError(t, err)
+	assert.Empty(t, refs)
+
+	// the default branch is indexed anyway
+	defer test.MockVariableValue(&setting.Indexer.RepoIndexerBranches, refGlobs(t, "feature/*", "master"))()
+	defer test.MockVariableValue(&setting.Indexer.RepoIndexerTags, refGlobs(t, "v*"))()
+	refs, err = getAdditionalRefs(t.Context(), repo)
+	require.NoError(t, err)
+	assert.Equal(t, []indexedRef{
+		{Name: "refs/heads/feature/1", CommitID: "65f1bf27bc3bf70f64657658635e66094edbcb4d"},
+		{Name: "refs/tags/v1.1", CommitID: "65f1bf27bc3bf70f64657658635e66094edbcb4d"},
+	}, refs)
+
+	defer test.MockVariableValue(&setting.Indexer.RepoIndexerMaxTags, 0)()
+	refs, err = getAdditionalRefs(t.Context(), repo)
+	require.NoError(t, err)
+	assert.Len(t, refs, 2)
+}
@@ -13,12 +13,14 @@ import (
 
 	"gitea.dev/models/db"
 	repo_model "gitea.dev/models/repo"
+	"gitea.dev/modules/container"
 	"gitea.dev/modules/git"
 	"gitea.dev/modules/graceful"
 	"gitea.dev/modules/indexer"
 	"gitea.dev/modules/indexer/code/bleve"
 	"gitea.dev/modules/indexer/code/elasticsearch"
 	"gitea.dev/modules/indexer/code/internal"
+	"gitea.dev/modules/indexer/code/trigram"
 	"gitea.dev/modules/log"
 	"gitea.dev/modules/process"
 	"gitea.dev/modules/queue"
@@ -84,18 +86,89 @@ func index(ctx context.Context, indexer internal.Indexer, repoID int64) error {
 	if err != nil {
 		return err
 	}
-	changes, err := getRepoChanges(ctx, repo, gitRepo, sha)
+	status, err := repo_model.GetIndexerStatus(ctx, repo, repo_model.RepoIndexerTypeCode)
+	if err != nil {
+		return err
+	}
+	if err := indexRef(ctx, indexer, repo, gitRepo, internal.DefaultBranchRef, status.CommitSha, sha); err != nil {
+		return err
+	}
+	if err := repo_model.UpdateIndexerStatus(ctx, repo, repo_model.RepoIndexerTypeCode, sha); err != nil {
+		return err
+	}
+
+	return indexAdditionalRefs(ctx, indexer, repo, gitRepo)
+}
+
+func indexRef(ctx context.Context, indexer internal.Indexer, repo *repo_model.Repository, gitRepo *git.Repository, ref, indexedSha, sha string) error {
+	changes, err := getRepoChanges(ctx, indexer, repo, gitRepo, ref, indexedSha, sha)
 	if err != nil {
 		return err
 	} else if changes == nil {
 		return nil
 	}
+	return indexer.Index(ctx, repo, ref, sha, changes)
+}
 
-	if err := indexer.Index(ctx, repo, sha, changes); err != nil {
+// indexAdditionalRefs indexes the branches and tags configured besides the default branch,
+// and removes the refs which are not indexed anymore.
+func indexAdditionalRefs(ctx context.Context, indexer internal.Indexer, repo *repo_model.Repository, gitRepo *git.Repository) error {
+	refs, err := getAdditionalRefs(ctx, repo)
+	if err != nil {
 		return err
 	}
+	statuses, err := repo_model.GetIndexerRefStatuses(ctx, repo.ID, repo_model.RepoIndexerTypeCode)
+	if err != nil {
+		return err
+	}
+
+	refNames := make(container.Set[string], len(refs))
+	for _, ref := range refs {
+		refNames.Add(ref.Name)
+	}
+	indexed := make(map[string]*repo_model.RepoIndexerStatus, len(statuses))
+	for _, status := range statuses {
+		if refNames.Contains(status.RefName) {
+			indexed[status.RefName] = status
+			continue
+		}
+		if err := indexer.DeleteRef(ctx, repo.ID, status.RefName); err != nil {
+			return err
+		}
+		if err := repo_model.DeleteIndexerRefStatus(ctx, status); err != nil {
+			return err
+		}
+	}
 
-	return repo_model.UpdateIndexerStatus(ctx, repo, repo_model.RepoIndexerTypeCode, sha)
+	for _, ref := range refs {
+		status, ok := indexed[ref.Name]
+		if !ok {
+			status = &repo_model.RepoIndexerStatus{RepoID: repo.ID, IndexerType: repo_model.RepoIndexerTypeCode, RefName: ref.Name}
+		} else if status.CommitSha == ref.CommitID {
+			continue
+		}
+		if err := indexRef(ctx, indexer, repo, gitRepo, ref.Name, status.CommitSha, ref.CommitID); err != nil {
+			return err
+		}
+		status.CommitSha = ref.CommitID
+		if err := repo_model.UpdateIndexerRefStatus(ctx, status); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetIndexedRefs returns the full names of the refs indexed for a repository besides its default branch
+func GetIndexedRefs(ctx context.Context, repo *repo_model.Repository) ([]string, error) {
+	statuses, err := repo_model.GetIndexerRefStatuses(ctx, repo.ID, repo_model.RepoIndexerTypeCode)
+	if err != nil {
+		return nil, err
+	}
+	refs := make([]string, 0, len(statuses))
+	for _, status := range statuses {
+		refs = append(refs, status.RefName)
+	}
+	return refs, nil
 }
 
 // Init initialize the repo indexer
@@ -124,7 +197,7 @@ func Init() {
 
 	// Create the Queue
 	switch setting.Indexer.RepoType {
-	case "bleve", "elasticsearch":
+	case "bleve", "elasticsearch", "trigram":
 		handler := func(items ...*internal.IndexerData) (unhandled []*internal.IndexerData) {
 			indexer := *globalIndexer.Load()
 			for _, indexerData := range items {
@@ -191,6 +264,16 @@ func Init() {
 				close(waitChannel)
 				log.Fatal("PID: %d Unable to initialize the elasticsearch Repository Indexer connstr: %s Error: %v", os.Getpid(), util.SanitizeCredentialURLs(setting.Indexer.RepoConnStr), err)
 			}
+		case "trigram":
+			log.Info("PID: %d Initializing Repository Indexer at: %s", os.Getpid(), setting.Indexer.RepoPath)
+			rIndexer = trigram.NewIndexer(setting.Indexer.RepoPath)
+			existed, err = rIndexer.Init(ctx)
+			if err != nil {
+				cancel()
+				(*globalIndexer.Load()).Close()
+				close(waitChannel)
+				log.Fatal("PID: %d Unable to initialize the trigram Repository Indexer at path: %s Error: %v", os.Getpid(), setting.Indexer.RepoPath, err)
+			}
 
 		default:
 			log.Fatal("PID: %d Unknown Indexer type: %s", os.Getpid(), setting.Indexer.RepoType)
@@ -319,3 +402,12 @@ func SupportedSearchModes() []indexer.SearchMode {
 	}
 	return (*gi).SupportedSearchModes()
 }
+
+// SupportsSearchFilters reports whether the indexer honours the case-sensitive and path search options
+func SupportsSearchFilters() bool {
+	gi := globalIndexer.Load()
+	if gi == nil {
+		return false
+	}
+	return (*gi).SupportsSearchFilters()
+}
@@ -11,11 +11,14 @@ import (
 	"time"
 
 	"gitea.dev/models/db"
+	repo_model "gitea.dev/models/repo"
 	"gitea.dev/models/unittest"
+	"gitea.dev/modules/git"
 	indexer_module "gitea.dev/modules/indexer"
 	"gitea.dev/modules/indexer/code/bleve"
 	"gitea.dev/modules/indexer/code/elasticsearch"
 	"gitea.dev/modules/indexer/code/internal"
+	"gitea.dev/modules/indexer/code/trigram"
 	"gitea.dev/modules/setting"
 	"gitea.dev/modules/test"
 	"gitea.dev/modules/util"
@@ -289,10 +292,50 @@ func testIndexer(name string, t *testing.T, indexer internal.Indexer) {
 			})
 		}
 
+		t.Run("refs", func(t *testing.T) {
+			testIndexerRefs(t, indexer)
+		})
+
 		assert.NoError(t, tearDownRepositoryIndexes(t.Context(), indexer))
 	})
 }
 
+func testIndexerRefs(t *testing.T, indexer internal.Indexer) {
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+	gitRepo, closer, err := git.RepositoryFromContextOrOpen(t.Context(), repo)
+	require.NoError(t, err)
+	defer closer.Close()
+
+	searchTotal := func(ref string) int64 {
+		total, _, _, err := indexer.Search(t.Context(), &internal.SearchOptions{
+			RepoIDs:   []int64{repo.ID},
+			Keyword:   "Description",
+			Ref:       ref,
+			Paginator: &db.ListOptions{Page: 1, PageSize: 10},
+		})
+		require.NoError(t, err)
+		return total
+	}
+	const tag = "refs/tags/v1.1"
+
+	// the files of the default branch are shared with the tag
+	sha, err := getDefaultBranchSha(t.Context(), repo)
+	require.NoError(t, err)
+	changes, err := genesisChanges(t.Context(), repo, gitRepo, sha)
+	require.NoError(t, err)
+	require.NoError(t, indexer.Index(t.Context(), repo, tag, sha, changes))
+	require.Eventually(t, func() bool { return searchTotal(tag) == 1 }, 10*time.Second, 100*time.Millisecond)
+	assert.EqualValues(t, 1, searchTotal(""))
+
+	require.NoError(t, indexer.DeleteRef(t.Context(), repo.ID, internal.DefaultBranchRef))
+	require.Eventually(t, func() bool { return searchTotal("") == 0 }, 10*time.Second, 100*time.Millisecond)
+	assert.EqualValues(t, 1, searchTotal(tag))
+
+	// the files are deleted once they are not in any ref
+	require.NoError(t, indexer.DeleteRef(t.Context(), repo.ID, tag))
+	require.Eventually(t, func() bool { return searchTotal(tag) == 0 }, 10*time.Second, 100*time.Millisecond)
+}
+
 func TestBleveIndexAndSearch(t *testing.T) {
 	unittest.PrepareTestEnv(t)
 	defer test.MockVariableValue(&setting.Indexer.TypeBleveMaxFuzzniess, 2)()
@@ -307,6 +350,18 @@ func TestBleveIndexAndSearch(t *testing.T) {
 	testIndexer("bleve", t, idx)
 }
 
+func TestTrigramIndexAndSearch(t *testing.T) {
+	unittest.PrepareTestEnv(t)
+
+	idx := trigram.NewIndexer(t.TempDir())
+	defer idx.Close()
+
+	_, err := idx.Init(t.Context())
+	require.NoError(t, err)
+
+	testIndexer("trigram", t, idx)
+}
+
 func TestESIndexAndSearch(t *testing.T) {
 	unittest.PrepareTestEnv(t)
 
@@ -16,16 +16,27 @@ import (
 // Indexer defines an interface to index and search code contents
 type Indexer interface {
 	internal.Indexer
-	Index(ctx context.Context, repo *repo_model.Repository, sha string, changes *RepoChanges) error
+	// Index adds the changes of a ref at the given commit, a file is indexed once for all the refs it is identical in
+	Index(ctx context.Context, repo *repo_model.Repository, ref, sha string, changes *RepoChanges) error
+	// DeleteRef removes a ref from the index, the files which are not in any other ref are deleted
+	DeleteRef(ctx context.Context, repoID int64, ref string) error
 	Delete(ctx context.Context, repoID int64) error
 	Search(ctx context.Context, opts *SearchOptions) (int64, []*SearchResult, []*SearchResultLanguages, error)
 	SupportedSearchModes() []indexer.SearchMode
+	// SupportsSearchFilters reports whether the CaseSensitive and Path search options are honoured
+	SupportsSearchFilters() bool
 }
 
 type SearchOptions struct {
 	RepoIDs  []int64
 	Keyword  string
 	Language string
+	// Ref is the full name of the indexed ref to search in, the default branch when empty
+	Ref string
+	// CaseSensitive matches the keyword with its case
+	CaseSensitive bool
+	// Path is a regexp the paths of the matched files must match
+	Path string
 
 	SearchMode indexer.SearchModeType
 
@@ -47,7 +58,15 @@ func (d *dummyIndexer) SupportedSearchModes() []indexer.SearchMode {
 	return nil
 }
 
-func (d *dummyIndexer) Index(ctx context.Context, repo *repo_model.Repository, sha string, changes *RepoChanges) error {
+func (d *dummyIndexer) SupportsSearchFilters() bool {
+	return false
+}
+
+func (d *dummyIndexer) Index(ctx context.Context, repo *repo_model.Repository, ref, sha string, changes *RepoChanges) error {
+	return errors.New("indexer is not ready")
+}
+
+func (d *dummyIndexer) DeleteRef(ctx context.Context, repoID int64, ref string) error {
 	return errors.New("indexer is not ready")
 }
 
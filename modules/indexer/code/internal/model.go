@@ -5,6 +5,10 @@ package internal
 
 import "gitea.dev/modules/timeutil"
 
+// DefaultBranchRef is the ref the default branch of a repository is indexed as,
+// so it can be searched without knowing its name and survives the renames.
+const DefaultBranchRef = "HEAD"
+
 type FileUpdate struct {
 	Filename string
 	BlobSha  string
@@ -12,10 +16,16 @@ type FileUpdate struct {
 	Sized    bool
 }
 
+// FileRemoval a file removed from a ref, or whose content has been replaced
+type FileRemoval struct {
+	Filename string
+	BlobSha  string
+}
+
 // RepoChanges changes (file additions/updates/removals) to a repo
 type RepoChanges struct {
-	Updates          []FileUpdate
-	RemovedFilenames []string
+	Updates      []FileUpdate
+	RemovedFiles []FileRemoval
 }
 
 // IndexerData represents data stored in the code indexer
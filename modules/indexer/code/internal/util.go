@@ -12,12 +12,17 @@ import (
 
 const filenameMatchNumberOfLines = 7 // Copied from GitHub search
 
-func FilenameIndexerID(repoID int64, filename string) string {
-	return internal.Base36(repoID) + "_" + filename
+// FileIndexerID returns the ID of a file in the indexer, a file is identified by its blob and its path
+// so it is shared by all the indexed refs of a repository it hasn't changed in.
+func FileIndexerID(repoID int64, blobSha, filename string) string {
+	return internal.Base36(repoID) + "_" + blobSha + "_" + filename
 }
 
 func ParseIndexerID(indexerID string) (int64, string) {
 	before, after, ok := strings.Cut(indexerID, "_")
+	if ok {
+		_, after, ok = strings.Cut(after, "_")
+	}
 	if !ok {
 		log.Error("Unexpected ID in repo indexer: %s", indexerID)
 	}
@@ -26,11 +31,8 @@ func ParseIndexerID(indexerID string) (int64, string) {
 }
 
 func FilenameOfIndexerID(indexerID string) string {
-	_, after, ok := strings.Cut(indexerID, "_")
-	if !ok {
-		log.Error("Unexpected ID in repo indexer: %s", indexerID)
-	}
-	return after
+	_, filename := ParseIndexerID(indexerID)
+	return filename
 }
 
 // FilenameMatchIndexPos returns the boundaries of its first seven lines.
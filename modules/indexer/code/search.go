@@ -28,6 +28,7 @@ type Result struct {
 type ResultLine struct {
 	Num              int
 	FormattedContent template.HTML
+	RawContent       string
 }
 
 type SearchResultLanguages = internal.SearchResultLanguages
@@ -77,12 +78,17 @@ func HighlightSearchResultCode(filename, language string, lineNums []int, code s
 	highlightedLines := highlight.UnsafeSplitHighlightedLines(hl)
 
 	// The lineNums outputted by render might not match the original lineNums, because "highlight" removes the last `\n`
+	rawLines := strings.Split(code, "\n")
+
 	lines := make([]*ResultLine, min(len(highlightedLines), len(lineNums)))
 	for i := range lines {
 		lines[i] = &ResultLine{
 			Num:              lineNums[i],
 			FormattedContent: template.HTML(highlightedLines[i]),
 		}
+		if i < len(rawLines) {
+			lines[i].RawContent = rawLines[i]
+		}
 	}
 	return lines
 }
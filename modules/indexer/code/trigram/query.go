@@ -0,0 +1,211 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package trigram
+
+import (
+	"bytes"
+	"regexp"
+	"regexp/syntax"
+	"slices"
+	"strings"
+
+	"gitea.dev/modules/indexer"
+	"gitea.dev/modules/indexer/code/internal"
+	"gitea.dev/modules/util"
+)
+
+// matcher is a search compiled from the search options
+type matcher struct {
+	mode indexer.SearchModeType
+	// res must all match the content, the filename or the name of a symbol of a file
+	res []*regexp.Regexp
+	// phrase matches the whole keyword in the words mode, the files containing it rank first
+	phrase *regexp.Regexp
+	// trigrams are contained by all the files whose content matches res
+	trigrams []uint32
+	path     *regexp.Regexp
+	keyword  string
+}
+
+func newMatcher(opts *internal.SearchOptions) (*matcher, error) {
+	m := &matcher{
+		mode:    util.IfZero(opts.SearchMode, indexer.SearchModeExact),
+		keyword: opts.Keyword,
+	}
+	flags := "(?i)"
+	if opts.CaseSensitive {
+		flags = ""
+	}
+
+	var exprs []string
+	switch m.mode {
+	case indexer.SearchModeRegexp:
+		exprs = []string{opts.Keyword}
+	case indexer.SearchModeExact, indexer.SearchModeSymbol:
+		exprs = []string{regexp.QuoteMeta(opts.Keyword)}
+	default: // words, the fuzzy searches are run as words searches
+		m.mode = indexer.SearchModeWords
+		for _, word := range strings.Fields(opts.Keyword) {
+			exprs = append(exprs, regexp.QuoteMeta(word))
+		}
+		m.phrase = regexp.MustCompile(flags + regexp.QuoteMeta(opts.Keyword))
+	}
+
+	for _, expr := range exprs {
+		re, err := regexp.Compile(flags + expr)
+		if err != nil {
+			return nil, util.NewInvalidArgumentErrorf("invalid regexp %q: %v", opts.Keyword, err)
+		}
+		m.res = append(m.res, re)
+
+		parsed, err := syntax.Parse(flags+expr, syntax.Perl)
+		if err != nil {
+			return nil, util.NewInvalidArgumentErrorf("invalid regexp %q: %v", opts.Keyword, err)
+		}
+		for _, lit := range requiredLiterals(parsed.Simplify()) {
+			m.trigrams = append(m.trigrams, lit.trigrams()...)
+		}
+	}
+	slices.Sort(m.trigrams)
+	m.trigrams = slices.Compact(m.trigrams)
+
+	if opts.Path != "" {
+		path, err := regexp.Compile(flags + opts.Path)
+		if err != nil {
+			return nil, util.NewInvalidArgumentErrorf("invalid path regexp %q: %v", opts.Path, err)
+		}
+		m.path = path
+	}
+	return m, nil
+}
+
+// literal is a string contained by all the matches of a regexp
+type literal struct {
+	s        string
+	foldCase bool
+}
+
+// trigrams returns the folded trigrams of the literal. When the case is folded, the trigrams with non-ASCII characters
+// are skipped: the index only folds the ASCII letters, so they might be indexed with another case.
+func (l literal) trigrams() []uint32 {
+	b := []byte(l.s)
+	trigrams := make([]uint32, 0, max(len(b)-2, 0))
+	for i := 0; i+3 <= len(b); i++ {
+		if l.foldCase && !isASCIITrigram(b, i) {
+			continue
+		}
+		trigrams = append(trigrams, trigramAt(b, i))
+	}
+	return trigrams
+}
+
+// requiredLiterals returns the literals all the matches of the regexp contain, it is conservative:
+// the alternations and the optional parts don't require anything.
+func requiredLiterals(re *syntax.Regexp) []literal {
+	switch re.Op {
+	case syntax.OpLiteral:
+		return []literal{{s: string(re.Rune), foldCase: re.Flags&syntax.FoldCase != 0}}
+	case syntax.OpCapture, syntax.OpPlus:
+		return requiredLiterals(re.Sub[0])
+	case syntax.OpRepeat:
+		if re.Min > 0 {
+			return requiredLiterals(re.Sub[0])
+		}
+	case syntax.OpConcat:
+		// the consecutive literals are joined, so their trigrams across the boundaries are required too
+		var literals []literal
+		var run literal
+		for _, sub := range re.Sub {
+			if sub.Op == syntax.OpLiteral {
+				run.s += string(sub.Rune)
+				run.foldCase = run.foldCase || sub.Flags&syntax.FoldCase != 0
+				continue
+			}
+			if run.s != "" {
+				literals = append(literals, run)
+				run = literal{}
+			}
+			literals = append(literals, requiredLiterals(sub)...)
+		}
+		if run.s != "" {
+			literals = append(literals, run)
+		}
+		return literals
+	}
+	return nil
+}
+
+func (m *matcher) matchPath(filename string) bool {
+	return m.path == nil || m.path.MatchString(filename)
+}
+
+// matchFilename reports whether all the regexps match the filename
+func (m *matcher) matchFilename(filename string) bool {
+	for _, re := range m.res {
+		if !re.MatchString(filename) {
+			return false
+		}
+	}
+	return len(m.res) > 0
+}
+
+// matchContent returns the boundaries of the first matches of all the regexps in the content
+func (m *matcher) matchContent(content []byte) (start, end int, ok bool) {
+	start, end = -1, -1
+	for _, re := range m.res {
+		loc := re.FindIndex(content)
+		if loc == nil {
+			return 0, 0, false
+		}
+		if start < 0 || loc[0] < start {
+			start = loc[0]
+		}
+		if loc[1] > end {
+			end = loc[1]
+		}
+	}
+	return start, end, len(m.res) > 0
+}
+
+// matchSymbol returns the best symbol of the file matching the keyword and its score,
+// a symbol named like the keyword ranks before the symbols starting with it, and then the other ones
+func (m *matcher) matchSymbol(symbols []*symbol) (*symbol, int) {
+	var best *symbol
+	bestScore := 0
+	for _, sym := range symbols {
+		if !m.res[0].MatchString(sym.Name) {
+			continue
+		}
+		score := 1
+		if strings.EqualFold(sym.Name, m.keyword) {
+			score = 3
+		} else if loc := m.res[0].FindStringIndex(sym.Name); loc[0] == 0 {
+			score = 2
+		}
+		if score > bestScore {
+			best, bestScore = sym, score
+		}
+	}
+	return best, bestScore
+}
+
+// symbolPos returns the boundaries of the name of the symbol in its line, or the whole line if it can't be found
+func symbolPos(content []byte, sym *symbol) (int, int) {
+	start := 0
+	for line := 1; line < sym.Line; line++ {
+		i := bytes.IndexByte(content[start:], '\n')
+		if i < 0 {
+			return 0, 0
+		}
+		start += i + 1
+	}
+	end := len(content)
+	if i := bytes.IndexByte(content[start:], '\n'); i >= 0 {
+		end = start + i
+	}
+	if i := bytes.Index(content[start:end], []byte(sym.Name)); i >= 0 {
+		return start + i, start + i + len(sym.Name)
+	}
+	return start, end
+}
@@ -0,0 +1,389 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package trigram
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"unicode/utf8"
+
+	"gitea.dev/modules/indexer/code/internal"
+	indexer_internal "gitea.dev/modules/indexer/internal"
+	"gitea.dev/modules/json"
+	"gitea.dev/modules/timeutil"
+	"gitea.dev/modules/util"
+)
+
+// A shard holds all the indexed files of a repository, it is never modified: a change of the repository
+// writes a new shard which replaces the previous one. Its layout is:
+//
+//	header:   magic, version
+//	contents: the contents of the files one after the other
+//	postings: for every trigram, the varint-encoded deltas of the sorted ordinals of the files containing it
+//	table:    the sorted trigrams with the offset and the size of their postings
+//	metadata: the JSON encoded list of the files
+//	footer:   the offset and the number of entries of the table, the offset and the size of the metadata, magic
+//
+// Only the table and the metadata are kept in memory, the contents and the postings are read when searching.
+const (
+	shardMagic     = "GTRI"
+	shardExt       = ".shard"
+	headerSize     = 8
+	footerSize     = 36
+	tableEntrySize = 16
+)
+
+var errShardVersion = errors.New("the shard was written by another version of the indexer")
+
+type symbol struct {
+	Name string `json:"name"`
+	Kind string `json:"kind"`
+	Line int    `json:"line"`
+}
+
+// document is an indexed file, like in the other indexers a file is identified by its blob and its path
+// and it is shared by all the refs it is identical in
+type document struct {
+	Filename    string             `json:"filename"`
+	BlobSha     string             `json:"blob_sha"`
+	CommitID    string             `json:"commit_id"`
+	Language    string             `json:"language"`
+	Refs        []string           `json:"refs"`
+	Symbols     []*symbol          `json:"symbols,omitempty"`
+	UpdatedUnix timeutil.TimeStamp `json:"updated_unix"`
+	Offset      int64              `json:"offset"`
+	Size        int64              `json:"size"`
+}
+
+func (d *document) id(repoID int64) string {
+	return internal.FileIndexerID(repoID, d.BlobSha, d.Filename)
+}
+
+type shardMetadata struct {
+	RepoID int64       `json:"repo_id"`
+	Docs   []*document `json:"docs"`
+}
+
+type tableEntry struct {
+	trigram uint32
+	size    uint32
+	offset  uint64
+}
+
+type shard struct {
+	repoID int64
+	file   *os.File
+	docs   []*document
+	table  []tableEntry
+}
+
+func shardPath(dir string, repoID int64) string {
+	return filepath.Join(dir, indexer_internal.Base36(repoID)+shardExt)
+}
+
+func openShard(path string) (*shard, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	s, err := readShard(file)
+	if err != nil {
+		_ = file.Close()
+		return nil, fmt.Errorf("unable to read the shard %s: %w", path, err)
+	}
+	return s, nil
+}
+
+func readShard(file *os.File) (*shard, error) {
+	header := make([]byte, headerSize)
+	if _, err := file.ReadAt(header, 0); err != nil {
+		return nil, err
+	}
+	if string(header[:4]) != shardMagic {
+		return nil, errors.New("not a shard")
+	}
+	if binary.LittleEndian.Uint32(header[4:]) != shardVersion {
+		return nil, errShardVersion
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		return nil, err
+	}
+	footer := make([]byte, footerSize)
+	if _, err := file.ReadAt(footer, info.Size()-footerSize); err != nil {
+		return nil, err
+	}
+	if string(footer[32:]) != shardMagic {
+		return nil, errors.New("truncated shard")
+	}
+	tableOffset := int64(binary.LittleEndian.Uint64(footer))
+	tableCount := int64(binary.LittleEndian.Uint64(footer[8:]))
+	metadataOffset := int64(binary.LittleEndian.Uint64(footer[16:]))
+	metadataSize := int64(binary.LittleEndian.Uint64(footer[24:]))
+
+	rawTable := make([]byte, tableCount*tableEntrySize)
+	if _, err := file.ReadAt(rawTable, tableOffset); err != nil {
+		return nil, err
+	}
+	table := make([]tableEntry, tableCount)
+	for i := range table {
+		entry := rawTable[i*tableEntrySize:]
+		table[i] = tableEntry{
+			trigram: binary.LittleEndian.Uint32(entry),
+			size:    binary.LittleEndian.Uint32(entry[4:]),
+			offset:  binary.LittleEndian.Uint64(entry[8:]),
+		}
+	}
+
+	var metadata shardMetadata
+	if err := json.NewDecoder(io.NewSectionReader(file, metadataOffset, metadataSize)).Decode(&metadata); err != nil {
+		return nil, err
+	}
+	return &shard{
+		repoID: metadata.RepoID,
+		file:   file,
+		docs:   metadata.Docs,
+		table:  table,
+	}, nil
+}
+
+func (s *shard) close() {
+	_ = s.file.Close()
+}
+
+func (s *shard) content(doc *document) ([]byte, error) {
+	content := make([]byte, doc.Size)
+	if _, err := s.file.ReadAt(content, doc.Offset); err != nil {
+		return nil, err
+	}
+	return content, nil
+}
+
+func (s *shard) entry(trigram uint32) (tableEntry, bool) {
+	i, found := slices.BinarySearchFunc(s.table, trigram, func(e tableEntry, t uint32) int {
+		return int(int64(e.trigram) - int64(t))
+	})
+	if !found {
+		return tableEntry{}, false
+	}
+	return s.table[i], true
+}
+
+func (s *shard) postings(entry tableEntry) ([]uint32, error) {
+	raw := make([]byte, entry.size)
+	if _, err := s.file.ReadAt(raw, int64(entry.offset)); err != nil {
+		return nil, err
+	}
+	var ordinals []uint32
+	var last uint64
+	for len(raw) > 0 {
+		delta, n := binary.Uvarint(raw)
+		if n <= 0 {
+			return nil, errors.New("corrupted postings")
+		}
+		last += delta
+		ordinals = append(ordinals, uint32(last))
+		raw = raw[n:]
+	}
+	return ordinals, nil
+}
+
+// candidates returns the sorted ordinals of the files containing all the trigrams,
+// filtered is false when there is no trigram to look for and all the files are candidates
+func (s *shard) candidates(trigrams []uint32) (ordinals []uint32, filtered bool, err error) {
+	if len(trigrams) == 0 {
+		return nil, false, nil
+	}
+	entries := make([]tableEntry, 0, len(trigrams))
+	for _, trigram := range trigrams {
+		entry, ok := s.entry(trigram)
+		if !ok {
+			return nil, true, nil
+		}
+		entries = append(entries, entry)
+	}
+	// the shortest lists are intersected first
+	slices.SortFunc(entries, func(a, b tableEntry) int { return int(int64(a.size) - int64(b.size)) })
+
+	for i, entry := range entries {
+		entryOrdinals, err := s.postings(entry)
+		if err != nil {
+			return nil, false, err
+		}
+		if i == 0 {
+			ordinals = entryOrdinals
+		} else if ordinals = intersect(ordinals, entryOrdinals); len(ordinals) == 0 {
+			break
+		}
+	}
+	return ordinals, true, nil
+}
+
+func intersect(a, b []uint32) []uint32 {
+	result := a[:0]
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		switch {
+		case a[i] < b[j]:
+			i++
+		case a[i] > b[j]:
+			j++
+		default:
+			result = append(result, a[i])
+			i++
+			j++
+		}
+	}
+	return result
+}
+
+// shardWriter writes a shard to a temporary file, which replaces the shard of the repository once it is complete
+type shardWriter struct {
+	file     *os.File
+	w        *bufio.Writer
+	offset   int64
+	docs     []*document
+	postings map[uint32][]uint32
+}
+
+func newShardWriter(dir string) (*shardWriter, error) {
+	file, err := os.CreateTemp(dir, "*.tmp")
+	if err != nil {
+		return nil, err
+	}
+	w := &shardWriter{
+		file:     file,
+		w:        bufio.NewWriter(file),
+		postings: make(map[uint32][]uint32),
+	}
+	header := binary.LittleEndian.AppendUint32([]byte(shardMagic), shardVersion)
+	if err := w.write(header); err != nil {
+		w.abort()
+		return nil, err
+	}
+	return w, nil
+}
+
+func (w *shardWriter) write(b []byte) error {
+	n, err := w.w.Write(b)
+	w.offset += int64(n)
+	return err
+}
+
+func (w *shardWriter) add(doc *document, content []byte) error {
+	ordinal := uint32(len(w.docs))
+	doc.Offset = w.offset
+	doc.Size = int64(len(content))
+	if err := w.write(content); err != nil {
+		return err
+	}
+	w.docs = append(w.docs, doc)
+
+	for i := 0; i+3 <= len(content); i++ {
+		trigram := trigramAt(content, i)
+		// the ordinals of a trigram are appended in order, so a repeated trigram is the last one
+		if ordinals := w.postings[trigram]; len(ordinals) > 0 && ordinals[len(ordinals)-1] == ordinal {
+			continue
+		}
+		w.postings[trigram] = append(w.postings[trigram], ordinal)
+	}
+	return nil
+}
+
+// finish completes the shard and moves it to its path
+func (w *shardWriter) finish(repoID int64, path string) error {
+	trigrams := make([]uint32, 0, len(w.postings))
+	for trigram := range w.postings {
+		trigrams = append(trigrams, trigram)
+	}
+	slices.Sort(trigrams)
+
+	table := make([]byte, 0, len(trigrams)*tableEntrySize)
+	for _, trigram := range trigrams {
+		offset := w.offset
+		var buf []byte
+		var last uint32
+		for _, ordinal := range w.postings[trigram] {
+			buf = binary.AppendUvarint(buf, uint64(ordinal-last))
+			last = ordinal
+		}
+		if err := w.write(buf); err != nil {
+			w.abort()
+			return err
+		}
+		table = binary.LittleEndian.AppendUint32(table, trigram)
+		table = binary.LittleEndian.AppendUint32(table, uint32(len(buf)))
+		table = binary.LittleEndian.AppendUint64(table, uint64(offset))
+	}
+
+	tableOffset := w.offset
+	if err := w.write(table); err != nil {
+		w.abort()
+		return err
+	}
+
+	metadata, err := json.Marshal(&shardMetadata{RepoID: repoID, Docs: w.docs})
+	if err != nil {
+		w.abort()
+		return err
+	}
+	metadataOffset := w.offset
+	if err := w.write(metadata); err != nil {
+		w.abort()
+		return err
+	}
+
+	footer := binary.LittleEndian.AppendUint64(nil, uint64(tableOffset))
+	footer = binary.LittleEndian.AppendUint64(footer, uint64(len(trigrams)))
+	footer = binary.LittleEndian.AppendUint64(footer, uint64(metadataOffset))
+	footer = binary.LittleEndian.AppendUint64(footer, uint64(len(metadata)))
+	footer = append(footer, shardMagic...)
+	if err := w.write(footer); err != nil {
+		w.abort()
+		return err
+	}
+
+	if err := w.w.Flush(); err != nil {
+		w.abort()
+		return err
+	}
+	if err := w.file.Sync(); err != nil {
+		w.abort()
+		return err
+	}
+	if err := w.file.Close(); err != nil {
+		_ = util.RemoveWithRetry(w.file.Name())
+		return err
+	}
+	return util.RenameWithRetry(w.file.Name(), path)
+}
+
+func (w *shardWriter) abort() {
+	_ = w.file.Close()
+	_ = util.RemoveWithRetry(w.file.Name())
+}
+
+func foldASCII(b byte) byte {
+	if 'A' <= b && b <= 'Z' {
+		return b + 'a' - 'A'
+	}
+	return b
+}
+
+// trigramAt returns the trigram starting at i, the ASCII letters are folded to lower case
+// so the index can be used by both the case-sensitive and the case-insensitive searches
+func trigramAt(b []byte, i int) uint32 {
+	return uint32(foldASCII(b[i]))<<16 | uint32(foldASCII(b[i+1]))<<8 | uint32(foldASCII(b[i+2]))
+}
+
+// isASCIITrigram reports whether the trigram starting at i only has ASCII bytes
+func isASCIITrigram(b []byte, i int) bool {
+	return b[i] < utf8.RuneSelf && b[i+1] < utf8.RuneSelf && b[i+2] < utf8.RuneSelf
+}
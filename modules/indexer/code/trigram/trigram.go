@@ -0,0 +1,584 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+// Package trigram implements a code indexer keeping a trigram index of every repository in a shard on the local disk,
+// like Zoekt does. The trigrams narrow the files a search has to look at, which are then matched by a regexp,
+// so it supports the regexp and case-sensitive searches and it can find the definitions of the symbols found by ctags.
+package trigram
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	repo_model "gitea.dev/models/repo"
+	"gitea.dev/modules/analyze"
+	"gitea.dev/modules/charset"
+	"gitea.dev/modules/ctags"
+	"gitea.dev/modules/git"
+	"gitea.dev/modules/git/gitcmd"
+	"gitea.dev/modules/indexer"
+	"gitea.dev/modules/indexer/code/internal"
+	"gitea.dev/modules/log"
+	"gitea.dev/modules/setting"
+	"gitea.dev/modules/timeutil"
+	"gitea.dev/modules/typesniffer"
+	"gitea.dev/modules/util"
+
+	"github.com/go-enry/go-enry/v2"
+)
+
+const (
+	shardVersion = 1
+	// maxFileSymbols limits the symbols indexed for a file, a generated file might define a lot of them
+	maxFileSymbols = 1000
+	// maxLanguages is the number of the languages counted in the search results
+	maxLanguages = 10
+)
+
+var _ internal.Indexer = &Indexer{}
+
+// Indexer represents a trigram indexer implementation
+type Indexer struct {
+	dir string
+
+	// mu guards the shards, a search holds it for reading until it has read the contents it needs
+	mu     sync.RWMutex
+	shards map[int64]*shard
+	// writeMu serializes the changes of the shards
+	writeMu sync.Mutex
+}
+
+// NewIndexer creates a new trigram indexer storing its shards in a directory
+func NewIndexer(indexDir string) *Indexer {
+	return &Indexer{dir: indexDir}
+}
+
+// Init loads the shards, they are all deleted if they have been written by another version of the indexer
+func (b *Indexer) Init(_ context.Context) (bool, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if err := os.MkdirAll(b.dir, os.ModePerm); err != nil {
+		return false, err
+	}
+
+	entries, err := os.ReadDir(b.dir)
+	if err != nil {
+		return false, err
+	}
+	shards := make(map[int64]*shard, len(entries))
+	outdated := false
+	for _, entry := range entries {
+		path := filepath.Join(b.dir, entry.Name())
+		if strings.HasSuffix(entry.Name(), ".tmp") {
+			// a shard whose writing has been interrupted
+			_ = util.RemoveWithRetry(path)
+			continue
+		}
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), shardExt) {
+			continue
+		}
+		s, err := openShard(path)
+		if errors.Is(err, errShardVersion) {
+			outdated = true
+			continue
+		} else if err != nil {
+			for _, s := range shards {
+				s.close()
+			}
+			return false, err
+		}
+		shards[s.repoID] = s
+	}
+
+	if outdated {
+		log.Info("The trigram indexer at %s has been written by another version, it is recreated", b.dir)
+		for _, s := range shards {
+			s.close()
+		}
+		for _, entry := range entries {
+			if strings.HasSuffix(entry.Name(), shardExt) {
+				if err := util.RemoveWithRetry(filepath.Join(b.dir, entry.Name())); err != nil {
+					return false, err
+				}
+			}
+		}
+		shards = map[int64]*shard{}
+	}
+	b.shards = shards
+	// the repositories are indexed again when there is no shard
+	return len(shards) > 0, nil
+}
+
+// Ping checks if the indexer is available
+func (b *Indexer) Ping(_ context.Context) error {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if b.shards == nil {
+		return errors.New("trigram indexer is not initialized")
+	}
+	return nil
+}
+
+// Close closes the shards
+func (b *Indexer) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for _, s := range b.shards {
+		s.close()
+	}
+	b.shards = nil
+}
+
+func (b *Indexer) SupportedSearchModes() []indexer.SearchMode {
+	return indexer.TrigramSupportedSearchModes()
+}
+
+func (b *Indexer) SupportsSearchFilters() bool {
+	return true
+}
+
+func (b *Indexer) getShard(repoID int64) *shard {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.shards[repoID]
+}
+
+// replaceShard makes the searches use the new shard of the repository, nil if it has no file anymore
+func (b *Indexer) replaceShard(repoID int64, s *shard) error {
+	b.mu.Lock()
+	if b.shards == nil {
+		b.mu.Unlock()
+		return errors.New("trigram indexer is closed")
+	}
+	old := b.shards[repoID]
+	if s != nil {
+		b.shards[repoID] = s
+	} else {
+		delete(b.shards, repoID)
+	}
+	b.mu.Unlock()
+
+	// no search can be using the previous shard anymore
+	if old != nil {
+		old.close()
+	}
+	if s == nil {
+		if err := util.RemoveWithRetry(shardPath(b.dir, repoID)); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// rewriteShard writes a new shard of the repository with the kept documents of the current one,
+// addNew adds the new files to it
+func (b *Indexer) rewriteShard(repoID int64, old *shard, kept []*document, addNew func(w *shardWriter) error) error {
+	w, err := newShardWriter(b.dir)
+	if err != nil {
+		return err
+	}
+	for _, doc := range kept {
+		content, err := old.content(doc)
+		if err != nil {
+			w.abort()
+			return err
+		}
+		if err := w.add(doc, content); err != nil {
+			w.abort()
+			return err
+		}
+	}
+	if addNew != nil {
+		if err := addNew(w); err != nil {
+			w.abort()
+			return err
+		}
+	}
+
+	if len(w.docs) == 0 {
+		w.abort()
+		return b.replaceShard(repoID, nil)
+	}
+	path := shardPath(b.dir, repoID)
+	if err := w.finish(repoID, path); err != nil {
+		return err
+	}
+	s, err := openShard(path)
+	if err != nil {
+		return err
+	}
+	return b.replaceShard(repoID, s)
+}
+
+// copyDocuments copies the documents of a shard, so they can be changed without affecting the searches
+func copyDocuments(s *shard) []*document {
+	if s == nil {
+		return nil
+	}
+	docs := make([]*document, 0, len(s.docs))
+	for _, doc := range s.docs {
+		docCopy := *doc
+		docCopy.Refs = slices.Clone(doc.Refs)
+		docs = append(docs, &docCopy)
+	}
+	return docs
+}
+
+// Index indexes the data
+func (b *Indexer) Index(ctx context.Context, repo *repo_model.Repository, ref, sha string, changes *internal.RepoChanges) error {
+	b.writeMu.Lock()
+	defer b.writeMu.Unlock()
+
+	old := b.getShard(repo.ID)
+	docs := copyDocuments(old)
+	byID := make(map[string]*document, len(docs))
+	for _, doc := range docs {
+		byID[doc.id(repo.ID)] = doc
+	}
+
+	changed := false
+	for _, removal := range changes.RemovedFiles {
+		if doc, ok := byID[internal.FileIndexerID(repo.ID, removal.BlobSha, removal.Filename)]; ok {
+			if i := slices.Index(doc.Refs, ref); i >= 0 {
+				doc.Refs = slices.Delete(doc.Refs, i, i+1)
+				changed = true
+			}
+		}
+	}
+	updates := make([]internal.FileUpdate, 0, len(changes.Updates))
+	for _, update := range changes.Updates {
+		id := internal.FileIndexerID(repo.ID, update.BlobSha, update.Filename)
+		if doc, ok := byID[id]; ok {
+			// the file is already indexed for another ref, only the ref is added to it
+			if !slices.Contains(doc.Refs, ref) {
+				doc.Refs = append(doc.Refs, ref)
+				changed = true
+			}
+			continue
+		}
+		byID[id] = &document{} // a file listed twice is only indexed once
+		updates = append(updates, update)
+	}
+	kept := slices.DeleteFunc(docs, func(doc *document) bool { return len(doc.Refs) == 0 })
+
+	if !changed && len(updates) == 0 {
+		return nil
+	}
+	return b.rewriteShard(repo.ID, old, kept, func(w *shardWriter) error {
+		if len(updates) == 0 {
+			return nil
+		}
+		catfileBatch, err := git.NewBatch(ctx, repo)
+		if err != nil {
+			return err
+		}
+		defer catfileBatch.Close()
+
+		var parser *ctags.Parser
+		if setting.Indexer.RepoIndexerCtagsPath != "" {
+			if parser, err = ctags.NewParser(ctx, setting.Indexer.RepoIndexerCtagsPath); err != nil {
+				log.Warn("Unable to start ctags, no symbol is indexed: %v", err)
+			} else {
+				defer func() {
+					if parser != nil {
+						parser.Close()
+					}
+				}()
+			}
+		}
+
+		for _, update := range updates {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			doc, content, err := readFile(ctx, catfileBatch, repo, sha, ref, update)
+			if err != nil {
+				return err
+			} else if doc == nil {
+				continue
+			}
+			if parser != nil && len(content) > 0 {
+				if doc.Symbols, err = parseSymbols(parser, doc.Filename, content); err != nil {
+					log.Warn("Unable to find the symbols of %s in %s with ctags, no symbol is indexed anymore: %v", doc.Filename, repo.FullName(), err)
+					parser.Close()
+					parser = nil
+				}
+			}
+			if err := w.add(doc, content); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// readFile reads an updated file, it returns nil if the file must not be indexed
+func readFile(ctx context.Context, catFileBatch git.CatFileBatch, repo *repo_model.Repository, commitSha, ref string, update internal.FileUpdate) (*document, []byte, error) {
+	// Ignore vendored files in code search
+	if setting.Indexer.ExcludeVendored && analyze.IsVendor(update.Filename) {
+		return nil, nil, nil
+	}
+
+	size := update.Size
+	if !update.Sized {
+		stdout, _, runErr := gitcmd.NewCommand("cat-file", "-s").AddDynamicArguments(update.BlobSha).WithRepo(repo).RunStdString(ctx)
+		if runErr != nil {
+			return nil, nil, runErr
+		}
+		var err error
+		if size, err = strconv.ParseInt(strings.TrimSpace(stdout), 10, 64); err != nil {
+			return nil, nil, fmt.Errorf("misformatted git cat-file output: %w", err)
+		}
+	}
+	if size > setting.Indexer.MaxIndexerFileSize {
+		return nil, nil, nil
+	}
+
+	info, batchReader, err := catFileBatch.QueryContent(update.BlobSha)
+	if err != nil {
+		return nil, nil, err
+	}
+	fileContents, err := io.ReadAll(io.LimitReader(batchReader, info.Size))
+	if err != nil {
+		return nil, nil, err
+	}
+	if _, err = batchReader.Discard(1); err != nil {
+		return nil, nil, err
+	}
+	language := analyze.GetCodeLanguage(update.Filename, fileContents)
+	if !typesniffer.DetectContentType(fileContents).IsText() {
+		// the binary files are only searchable by their names
+		fileContents = nil
+	}
+
+	return &document{
+		Filename:    update.Filename,
+		BlobSha:     update.BlobSha,
+		CommitID:    commitSha,
+		Language:    language,
+		Refs:        []string{ref},
+		UpdatedUnix: timeutil.TimeStampNow(),
+	}, charset.ToUTF8DropErrors(fileContents), nil
+}
+
+func parseSymbols(parser *ctags.Parser, filename string, content []byte) ([]*symbol, error) {
+	entries, err := parser.Parse(filename, content)
+	if err != nil {
+		return nil, err
+	}
+	symbols := make([]*symbol, 0, min(len(entries), maxFileSymbols))
+	for _, entry := range entries {
+		if entry.Line <= 0 || entry.Name == "" {
+			continue
+		}
+		symbols = append(symbols, &symbol{Name: entry.Name, Kind: entry.Kind, Line: entry.Line})
+		if len(symbols) == maxFileSymbols {
+			break
+		}
+	}
+	return symbols, nil
+}
+
+// DeleteRef removes a ref from the indexed files of a repository
+func (b *Indexer) DeleteRef(_ context.Context, repoID int64, ref string) error {
+	b.writeMu.Lock()
+	defer b.writeMu.Unlock()
+
+	old := b.getShard(repoID)
+	if old == nil {
+		return nil
+	}
+	docs := copyDocuments(old)
+	changed := false
+	for _, doc := range docs {
+		if i := slices.Index(doc.Refs, ref); i >= 0 {
+			doc.Refs = slices.Delete(doc.Refs, i, i+1)
+			changed = true
+		}
+	}
+	if !changed {
+		return nil
+	}
+	kept := slices.DeleteFunc(docs, func(doc *document) bool { return len(doc.Refs) == 0 })
+	return b.rewriteShard(repoID, old, kept, nil)
+}
+
+// Delete deletes the shard of a repository
+func (b *Indexer) Delete(_ context.Context, repoID int64) error {
+	b.writeMu.Lock()
+	defer b.writeMu.Unlock()
+	return b.replaceShard(repoID, nil)
+}
+
+// hit is a file matched by a search
+type hit struct {
+	shard      *shard
+	doc        *document
+	startIndex int
+	endIndex   int
+	score      int
+}
+
+func (s *shard) search(ctx context.Context, m *matcher, ref string) ([]*hit, error) {
+	var candidates []uint32
+	filtered := false
+	if m.mode != indexer.SearchModeSymbol {
+		var err error
+		if candidates, filtered, err = s.candidates(m.trigrams); err != nil {
+			return nil, err
+		}
+	}
+
+	var hits []*hit
+	for ordinal, doc := range s.docs {
+		if ordinal%100 == 0 && ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		if !slices.Contains(doc.Refs, ref) || !m.matchPath(doc.Filename) {
+			continue
+		}
+
+		if m.mode == indexer.SearchModeSymbol {
+			if sym, score := m.matchSymbol(doc.Symbols); sym != nil {
+				content, err := s.content(doc)
+				if err != nil {
+					return nil, err
+				}
+				start, end := symbolPos(content, sym)
+				hits = append(hits, &hit{shard: s, doc: doc, startIndex: start, endIndex: end, score: score})
+			}
+			continue
+		}
+
+		h := &hit{shard: s, doc: doc}
+		if m.matchFilename(doc.Filename) {
+			// like the other indexers, the beginning of the file is shown when its name matches
+			h.score = 10
+			h.startIndex, h.endIndex = -1, -1
+		}
+		if _, ok := slices.BinarySearch(candidates, uint32(ordinal)); ok || !filtered {
+			content, err := s.content(doc)
+			if err != nil {
+				return nil, err
+			}
+			if start, end, ok := m.matchContent(content); ok {
+				h.score++
+				if m.phrase != nil && m.phrase.Match(content) {
+					h.score += 5
+				}
+				if h.startIndex >= 0 {
+					h.startIndex, h.endIndex = start, end
+				}
+			}
+		}
+		if h.score > 0 {
+			hits = append(hits, h)
+		}
+	}
+	return hits, nil
+}
+
+// Search searches for files in the specified repo.
+// Returns the matching file-paths
+func (b *Indexer) Search(ctx context.Context, opts *internal.SearchOptions) (int64, []*internal.SearchResult, []*internal.SearchResultLanguages, error) {
+	m, err := newMatcher(opts)
+	if err != nil {
+		return 0, nil, nil, err
+	}
+	ref := util.IfZero(opts.Ref, internal.DefaultBranchRef)
+
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	shards := make([]*shard, 0, len(b.shards))
+	if len(opts.RepoIDs) > 0 {
+		for _, repoID := range opts.RepoIDs {
+			if s, ok := b.shards[repoID]; ok {
+				shards = append(shards, s)
+			}
+		}
+	} else {
+		for _, s := range b.shards {
+			shards = append(shards, s)
+		}
+	}
+
+	var hits []*hit
+	languageCounts := make(map[string]int)
+	for _, s := range shards {
+		shardHits, err := s.search(ctx, m, ref)
+		if err != nil {
+			return 0, nil, nil, err
+		}
+		for _, h := range shardHits {
+			// the languages are counted before the language filter, so the other languages can be selected
+			if h.doc.Language != "" {
+				languageCounts[h.doc.Language]++
+			}
+			if opts.Language == "" || h.doc.Language == opts.Language {
+				hits = append(hits, h)
+			}
+		}
+	}
+
+	// the files closer to the root of the repositories rank first among the files with the same score
+	slices.SortFunc(hits, func(a, b *hit) int {
+		return cmp.Or(
+			cmp.Compare(b.score, a.score),
+			cmp.Compare(len(a.doc.Filename), len(b.doc.Filename)),
+			cmp.Compare(a.shard.repoID, b.shard.repoID),
+			strings.Compare(a.doc.Filename, b.doc.Filename),
+		)
+	})
+
+	skip, take := opts.GetSkipTake()
+	pageHits := hits[min(skip, len(hits)):min(skip+take, len(hits))]
+	searchResults := make([]*internal.SearchResult, 0, len(pageHits))
+	for _, h := range pageHits {
+		content, err := h.shard.content(h.doc)
+		if err != nil {
+			return 0, nil, nil, err
+		}
+		startIndex, endIndex := h.startIndex, h.endIndex
+		if startIndex < 0 {
+			startIndex, endIndex = internal.FilenameMatchIndexPos(string(content))
+		}
+		searchResults = append(searchResults, &internal.SearchResult{
+			RepoID:      h.shard.repoID,
+			StartIndex:  startIndex,
+			EndIndex:    endIndex,
+			Filename:    h.doc.Filename,
+			Content:     string(content),
+			CommitID:    h.doc.CommitID,
+			UpdatedUnix: h.doc.UpdatedUnix,
+			Language:    h.doc.Language,
+			Color:       enry.GetColor(h.doc.Language),
+		})
+	}
+
+	searchResultLanguages := make([]*internal.SearchResultLanguages, 0, len(languageCounts))
+	for language, count := range languageCounts {
+		searchResultLanguages = append(searchResultLanguages, &internal.SearchResultLanguages{
+			Language: language,
+			Color:    enry.GetColor(language),
+			Count:    count,
+		})
+	}
+	slices.SortFunc(searchResultLanguages, func(a, b *internal.SearchResultLanguages) int {
+		return cmp.Or(cmp.Compare(b.Count, a.Count), strings.Compare(a.Language, b.Language))
+	})
+	if len(searchResultLanguages) > maxLanguages {
+		searchResultLanguages = searchResultLanguages[:maxLanguages]
+	}
+	return int64(len(hits)), searchResults, searchResultLanguages, nil
+}
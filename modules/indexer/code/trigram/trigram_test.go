@@ -0,0 +1,228 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package trigram
+
+import (
+	"encoding/binary"
+	"os"
+	"testing"
+
+	"gitea.dev/models/db"
+	"gitea.dev/modules/indexer"
+	"gitea.dev/modules/indexer/code/internal"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func trigramsOf(s ...string) []uint32 {
+	var trigrams []uint32
+	for _, str := range s {
+		trigrams = append(trigrams, literal{s: str}.trigrams()...)
+	}
+	return trigrams
+}
+
+func TestMatcherTrigrams(t *testing.T) {
+	cases := []struct {
+		mode     indexer.SearchModeType
+		keyword  string
+		trigrams []uint32
+	}{
+		{indexer.SearchModeExact, "Hello", trigramsOf("hello")},
+		{indexer.SearchModeWords, "foo bar", trigramsOf("foo", "bar")},
+		{indexer.SearchModeRegexp, `func\s+Foo`, trigramsOf("func", "foo")},
+		{indexer.SearchModeRegexp, `(foo)+bar?`, trigramsOf("foo")},
+		{indexer.SearchModeRegexp, `abc|def`, nil},
+		{indexer.SearchModeRegexp, `x{2,}yza`, trigramsOf("yza")},
+		// the trigrams with non-ASCII characters can't be used when the case is folded
+		{indexer.SearchModeExact, "Ünicode", trigramsOf("nic", "ico", "cod", "ode")},
+	}
+	for _, c := range cases {
+		m, err := newMatcher(&internal.SearchOptions{Keyword: c.keyword, SearchMode: c.mode})
+		require.NoError(t, err, c.keyword)
+		assert.ElementsMatch(t, c.trigrams, m.trigrams, c.keyword)
+	}
+
+	m, err := newMatcher(&internal.SearchOptions{Keyword: "Ünicode", CaseSensitive: true})
+	require.NoError(t, err)
+	assert.Len(t, m.trigrams, 6)
+
+	_, err = newMatcher(&internal.SearchOptions{Keyword: "(", SearchMode: indexer.SearchModeRegexp})
+	assert.Error(t, err)
+	_, err = newMatcher(&internal.SearchOptions{Keyword: "a", Path: "["})
+	assert.Error(t, err)
+}
+
+type testFile struct {
+	filename string
+	content  string
+	language string
+	refs     []string
+	symbols  []*symbol
+}
+
+func addFiles(t *testing.T, b *Indexer, repoID int64, files ...testFile) {
+	old := b.getShard(repoID)
+	require.NoError(t, b.rewriteShard(repoID, old, copyDocuments(old), func(w *shardWriter) error {
+		for _, file := range files {
+			doc := &document{
+				Filename: file.filename,
+				BlobSha:  file.filename + "-blob",
+				CommitID: "commit",
+				Language: file.language,
+				Refs:     file.refs,
+				Symbols:  file.symbols,
+			}
+			if doc.Refs == nil {
+				doc.Refs = []string{internal.DefaultBranchRef}
+			}
+			if err := w.add(doc, []byte(file.content)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}))
+}
+
+func search(t *testing.T, b *Indexer, opts *internal.SearchOptions) ([]string, []*internal.SearchResultLanguages) {
+	opts.Paginator = &db.ListOptions{Page: 1, PageSize: 10}
+	total, results, languages, err := b.Search(t.Context(), opts)
+	require.NoError(t, err)
+	filenames := make([]string, 0, len(results))
+	for _, result := range results {
+		filenames = append(filenames, result.Filename)
+	}
+	assert.EqualValues(t, len(results), total)
+	return filenames, languages
+}
+
+func TestIndexer(t *testing.T) {
+	dir := t.TempDir()
+	b := NewIndexer(dir)
+	existed, err := b.Init(t.Context())
+	require.NoError(t, err)
+	assert.False(t, existed)
+	require.NoError(t, b.Ping(t.Context()))
+
+	addFiles(t, b, 1,
+		testFile{
+			filename: "main.go",
+			content:  "package main\n\nfunc NewFooBar() *FooBar {\n\treturn &FooBar{}\n}\n",
+			language: "Go",
+			symbols:  []*symbol{{Name: "NewFooBar", Kind: "func", Line: 3}},
+		},
+		testFile{
+			filename: "foobar.go",
+			content:  "package main\n\ntype FooBar struct{}\n",
+			language: "Go",
+			symbols:  []*symbol{{Name: "FooBar", Kind: "struct", Line: 3}},
+		},
+		testFile{filename: "docs/README.md", content: "# foobar\n\nSee foobar.go", language: "Markdown"},
+		testFile{filename: "release.txt", content: "foobar 1.0", refs: []string{"refs/tags/v1.0"}},
+	)
+	addFiles(t, b, 2, testFile{filename: "other.go", content: "var fooBar = 1\n", language: "Go"})
+
+	t.Run("Exact", func(t *testing.T) {
+		filenames, languages := search(t, b, &internal.SearchOptions{Keyword: "foobar", RepoIDs: []int64{1}})
+		// the filename matches rank first
+		assert.Equal(t, []string{"foobar.go", "main.go", "docs/README.md"}, filenames)
+		if assert.Len(t, languages, 2) {
+			assert.Equal(t, internal.SearchResultLanguages{Language: "Go", Color: languages[0].Color, Count: 2}, *languages[0])
+			assert.Equal(t, "Markdown", languages[1].Language)
+		}
+
+		filenames, _ = search(t, b, &internal.SearchOptions{Keyword: "foobar"})
+		assert.Len(t, filenames, 4)
+	})
+
+	t.Run("CaseSensitive", func(t *testing.T) {
+		filenames, _ := search(t, b, &internal.SearchOptions{Keyword: "fooBar", CaseSensitive: true})
+		assert.Equal(t, []string{"other.go"}, filenames)
+	})
+
+	t.Run("Regexp", func(t *testing.T) {
+		filenames, _ := search(t, b, &internal.SearchOptions{Keyword: `func\s+New\w+\(`, SearchMode: indexer.SearchModeRegexp})
+		assert.Equal(t, []string{"main.go"}, filenames)
+	})
+
+	t.Run("Filters", func(t *testing.T) {
+		filenames, languages := search(t, b, &internal.SearchOptions{Keyword: "foobar", Language: "Markdown"})
+		assert.Equal(t, []string{"docs/README.md"}, filenames)
+		// the languages are counted without the language filter
+		assert.Len(t, languages, 2)
+
+		filenames, _ = search(t, b, &internal.SearchOptions{Keyword: "foobar", Path: `^docs/`})
+		assert.Equal(t, []string{"docs/README.md"}, filenames)
+
+		filenames, _ = search(t, b, &internal.SearchOptions{Keyword: "foobar", Ref: "refs/tags/v1.0"})
+		assert.Equal(t, []string{"release.txt"}, filenames)
+	})
+
+	t.Run("Symbol", func(t *testing.T) {
+		_, results, _, err := b.Search(t.Context(), &internal.SearchOptions{
+			Keyword:    "foobar",
+			SearchMode: indexer.SearchModeSymbol,
+			Paginator:  &db.ListOptions{Page: 1, PageSize: 10},
+		})
+		require.NoError(t, err)
+		// the symbol named like the keyword ranks first
+		if assert.Len(t, results, 2) {
+			assert.Equal(t, "foobar.go", results[0].Filename)
+			assert.Equal(t, "FooBar", results[0].Content[results[0].StartIndex:results[0].EndIndex])
+			assert.Equal(t, "main.go", results[1].Filename)
+			assert.Equal(t, "NewFooBar", results[1].Content[results[1].StartIndex:results[1].EndIndex])
+		}
+	})
+
+	t.Run("DeleteRef", func(t *testing.T) {
+		require.NoError(t, b.DeleteRef(t.Context(), 1, "refs/tags/v1.0"))
+		filenames, _ := search(t, b, &internal.SearchOptions{Keyword: "foobar", Ref: "refs/tags/v1.0"})
+		assert.Empty(t, filenames)
+		assert.Len(t, b.getShard(1).docs, 3)
+
+		require.NoError(t, b.DeleteRef(t.Context(), 2, internal.DefaultBranchRef))
+		assert.Nil(t, b.getShard(2))
+		assert.NoFileExists(t, shardPath(dir, 2))
+	})
+
+	t.Run("Reopen", func(t *testing.T) {
+		b.Close()
+		b = NewIndexer(dir)
+		existed, err := b.Init(t.Context())
+		require.NoError(t, err)
+		assert.True(t, existed)
+		filenames, _ := search(t, b, &internal.SearchOptions{Keyword: "FooBar", CaseSensitive: true})
+		assert.Equal(t, []string{"main.go", "foobar.go"}, filenames)
+
+		require.NoError(t, b.Delete(t.Context(), 1))
+		filenames, _ = search(t, b, &internal.SearchOptions{Keyword: "foobar"})
+		assert.Empty(t, filenames)
+		b.Close()
+	})
+}
+
+func TestIndexerOutdatedShards(t *testing.T) {
+	dir := t.TempDir()
+	b := NewIndexer(dir)
+	_, err := b.Init(t.Context())
+	require.NoError(t, err)
+	addFiles(t, b, 1, testFile{filename: "a.txt", content: "content"})
+	b.Close()
+
+	// change the version in the header of the shard
+	path := shardPath(dir, 1)
+	file, err := os.OpenFile(path, os.O_WRONLY, 0)
+	require.NoError(t, err)
+	_, err = file.WriteAt(binary.LittleEndian.AppendUint32(nil, shardVersion+1), 4)
+	require.NoError(t, err)
+	require.NoError(t, file.Close())
+
+	b = NewIndexer(dir)
+	existed, err := b.Init(t.Context())
+	require.NoError(t, err)
+	defer b.Close()
+	assert.False(t, existed)
+	assert.NoFileExists(t, path)
+}
@@ -10,6 +10,7 @@ const (
 	SearchModeWords  SearchModeType = "words"
 	SearchModeFuzzy  SearchModeType = "fuzzy"
 	SearchModeRegexp SearchModeType = "regexp"
+	SearchModeSymbol SearchModeType = "symbol"
 )
 
 type SearchMode struct {
@@ -52,3 +53,13 @@ func GitGrepSupportedSearchModes() []SearchMode {
 		},
 	}...)
 }
+
+func TrigramSupportedSearchModes() []SearchMode {
+	return append(GitGrepSupportedSearchModes(), []SearchMode{
+		{
+			ModeValue:    SearchModeSymbol,
+			TooltipTrKey: "search.symbol_tooltip",
+			TitleTrKey:   "search.symbol",
+		},
+	}...)
+}
@@ -16,6 +16,7 @@ import (
 	"strings"
 	"time"
 
+	"gitea.dev/modules/container"
 	"gitea.dev/modules/indexer/internal"
 	"gitea.dev/modules/json"
 )
@@ -131,7 +132,7 @@ func (i *Indexer) Bulk(ctx context.Context, ops []BulkOp) error {
 		if err := writeJSONLine(&buf, meta); err != nil {
 			return err
 		}
-		if op.action == bulkActionIndex {
+		if op.action == bulkActionIndex || op.action == bulkActionUpdate {
 			if err := writeJSONLine(&buf, op.doc); err != nil {
 				return err
 			}
@@ -161,8 +162,8 @@ func (i *Indexer) Bulk(ctx context.Context, ops []BulkOp) error {
 }
 
 // firstBulkError returns the first item-level failure in a bulk response.
-// Each items entry is a single-key map ({"index": {...}} or {"delete": {...}}).
-// Delete-of-missing (404) is idempotent and not reported.
+// Each items entry is a single-key map ({"index": {...}}, {"delete": {...}} or {"update": {...}}).
+// Delete-of-missing and update-of-missing (404) are idempotent and not reported.
 func firstBulkError(items []map[string]struct {
 	Status int        `json:"status"`
 	Error  json.Value `json:"error"`
@@ -170,7 +171,7 @@ func firstBulkError(items []map[string]struct {
 ) error {
 	for _, item := range items {
 		for action, result := range item {
-			if action == bulkActionDelete && result.Status == http.StatusNotFound {
+			if (action == bulkActionDelete || action == bulkActionUpdate) && result.Status == http.StatusNotFound {
 				continue
 			}
 			if result.Status >= 300 {
@@ -209,6 +210,44 @@ func (i *Indexer) DeleteByQuery(ctx context.Context, query Query) error {
 	return i.doJSON(ctx, http.MethodPost, urlPath(i.VersionedIndexName(), "_delete_by_query"), bytes.NewReader(body), nil)
 }
 
+// UpdateByQuery runs the script on every document matching the query.
+// The script may set ctx.op to "delete" or "noop", version conflicts are not reported.
+func (i *Indexer) UpdateByQuery(ctx context.Context, query Query, script Script) error {
+	body, err := json.Marshal(map[string]any{"query": query.querySource(), "script": script.source()})
+	if err != nil {
+		return err
+	}
+	return i.doJSON(ctx, http.MethodPost, urlPath(i.VersionedIndexName(), "_update_by_query")+"?conflicts=proceed", bytes.NewReader(body), nil)
+}
+
+// ExistingIDs returns the ids of the given documents which exist. Unlike a search it sees
+// the documents written since the last refresh.
+func (i *Indexer) ExistingIDs(ctx context.Context, ids []string) (container.Set[string], error) {
+	existing := make(container.Set[string], len(ids))
+	if len(ids) == 0 {
+		return existing, nil
+	}
+	body, err := json.Marshal(map[string]any{"ids": ids})
+	if err != nil {
+		return nil, err
+	}
+	var resp struct {
+		Docs []struct {
+			ID    string `json:"_id"`
+			Found bool   `json:"found"`
+		} `json:"docs"`
+	}
+	if err := i.doJSON(ctx, http.MethodPost, urlPath(i.VersionedIndexName(), "_mget")+"?_source=false", bytes.NewReader(body), &resp); err != nil {
+		return nil, err
+	}
+	for _, doc := range resp.Docs {
+		if doc.Found {
+			existing.Add(doc.ID)
+		}
+	}
+	return existing, nil
+}
+
 // Refresh forces a refresh so recent writes are searchable.
 func (i *Indexer) Refresh(ctx context.Context) error {
 	return i.doJSON(ctx, http.MethodPost, urlPath(i.VersionedIndexName(), "_refresh"), nil, nil)
@@ -37,3 +37,19 @@ func TestBulkAcceptsDelete404(t *testing.T) {
 	ix := newRealIndexer(t)
 	require.NoError(t, ix.Bulk(t.Context(), []BulkOp{DeleteOp("missing-id")}))
 }
+
+func TestBulkAcceptsUpdate404(t *testing.T) {
+	ix := newRealIndexer(t)
+	require.NoError(t, ix.Bulk(t.Context(), []BulkOp{UpdateOp("missing-id", Script{Source: "ctx.op = 'noop'"})}))
+}
+
+func TestExistingIDs(t *testing.T) {
+	ix := newRealIndexer(t)
+	require.NoError(t, ix.Bulk(t.Context(), []BulkOp{IndexOp("existing-id", map[string]any{"x": "a"})}))
+
+	// the documents are found before the index is refreshed
+	existing, err := ix.ExistingIDs(t.Context(), []string{"existing-id", "missing-id"})
+	require.NoError(t, err)
+	require.True(t, existing.Contains("existing-id"))
+	require.False(t, existing.Contains("missing-id"))
+}
@@ -8,9 +8,10 @@ import "gitea.dev/modules/json"
 const (
 	bulkActionIndex  = "index"
 	bulkActionDelete = "delete"
+	bulkActionUpdate = "update"
 )
 
-// BulkOp is a single write inside a Bulk call. Construct with IndexOp or DeleteOp.
+// BulkOp is a single write inside a Bulk call. Construct with IndexOp, DeleteOp or UpdateOp.
 type BulkOp struct {
 	action string
 	id     string
@@ -27,10 +28,31 @@ func DeleteOp(id string) BulkOp {
 	return BulkOp{action: bulkActionDelete, id: id}
 }
 
+// UpdateOp builds a bulk update operation running a painless script on an existing document.
+func UpdateOp(id string, script Script) BulkOp {
+	return BulkOp{action: bulkActionUpdate, id: id, doc: map[string]any{"script": script.source()}}
+}
+
+// Script is a painless script with its parameters, used to update documents in place.
+type Script struct {
+	Source string
+	Params map[string]any
+}
+
+func (s Script) source() map[string]any {
+	script := map[string]any{"source": s.Source, "lang": "painless"}
+	if len(s.Params) > 0 {
+		script["params"] = s.Params
+	}
+	return script
+}
+
 // SortField is one entry of the search sort array.
 type SortField struct {
 	Field string
 	Desc  bool
+	// UnmappedType is the type the field is sorted as when it isn't mapped yet, e.g. a field of a dynamic template
+	UnmappedType string
 }
 
 func (s SortField) source() map[string]any {
@@ -38,7 +60,11 @@ func (s SortField) source() map[string]any {
 	if s.Desc {
 		order = "desc"
 	}
-	return map[string]any{s.Field: map[string]any{"order": order}}
+	field := map[string]any{"order": order}
+	if s.UnmappedType != "" {
+		field["unmapped_type"] = s.UnmappedType
+	}
+	return map[string]any{s.Field: field}
 }
 
 // SearchRequest captures everything Gitea sends to the _search endpoint.
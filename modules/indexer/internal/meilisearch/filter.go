@@ -68,6 +68,23 @@ func NewFilterIn[T int64](field string, values ...T) FilterIn {
 	return FilterIn(fmt.Sprintf("%s IN [%v]", field, strings.Join(vs, ", ")))
 }
 
+// NewFilterInStrings creates a new FilterIn for string values, they are quoted and escaped.
+func NewFilterInStrings(field string, values ...string) FilterIn {
+	if len(values) == 0 {
+		return ""
+	}
+	vs := make([]string, len(values))
+	for i, v := range values {
+		vs[i] = quoteFilterString(v)
+	}
+	return FilterIn(fmt.Sprintf("%s IN [%v]", field, strings.Join(vs, ", ")))
+}
+
+// quoteFilterString quotes a string for a filter expression, only the quotes and the backslashes have to be escaped
+func quoteFilterString(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
+
 func (f FilterIn) Statement() string {
 	return string(f)
 }
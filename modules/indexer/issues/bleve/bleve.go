@@ -25,7 +25,7 @@ import (
 const (
 	issueIndexerAnalyzer      = "issueIndexer"
 	issueIndexerDocType       = "issueIndexerDocType"
-	issueIndexerLatestVersion = 8
+	issueIndexerLatestVersion = 10
 )
 
 const unicodeNormalizeName = "unicodeNormalize"
@@ -81,6 +81,7 @@ func generateIssueIndexMapping() (mapping.IndexMapping, error) {
 	docMapping.AddFieldMappingsAt("label_ids", numberFieldMapping)
 	docMapping.AddFieldMappingsAt("no_label", boolFieldMapping)
 	docMapping.AddFieldMappingsAt("milestone_id", numberFieldMapping)
+	docMapping.AddFieldMappingsAt("type_id", numberFieldMapping)
 	docMapping.AddFieldMappingsAt("project_ids", numberFieldMapping)
 	docMapping.AddFieldMappingsAt("no_project", boolFieldMapping)
 	docMapping.AddFieldMappingsAt("poster_id", numberFieldMapping)
@@ -90,12 +91,22 @@ func generateIssueIndexMapping() (mapping.IndexMapping, error) {
 	docMapping.AddFieldMappingsAt("reviewed_ids", numberFieldMapping)
 	docMapping.AddFieldMappingsAt("review_requested_ids", numberFieldMapping)
 	docMapping.AddFieldMappingsAt("subscriber_ids", numberFieldMapping)
+
+	keywordFieldMapping := bleve.NewKeywordFieldMapping()
+	keywordFieldMapping.Store = false
+	keywordFieldMapping.IncludeInAll = false
+	docMapping.AddFieldMappingsAt("custom_field_values", keywordFieldMapping)
 	docMapping.AddFieldMappingsAt("updated_unix", numberFieldMapping)
 
 	docMapping.AddFieldMappingsAt("created_unix", numberFieldMapping)
 	docMapping.AddFieldMappingsAt("deadline_unix", numberFieldMapping)
 	docMapping.AddFieldMappingsAt("comment_count", numberFieldMapping)
 
+	// the keys of the custom field sort keys are the field IDs, so they are indexed as dynamic numeric fields
+	customFieldSortMapping := bleve.NewDocumentMapping()
+	customFieldSortMapping.Dynamic = true
+	docMapping.AddSubDocumentMapping("custom_field_sort", customFieldSortMapping)
+
 	if err := addUnicodeNormalizeTokenFilter(mapping); err != nil {
 		return nil, err
 	} else if err = mapping.AddCustomAnalyzer(issueIndexerAnalyzer, map[string]any{
@@ -240,6 +251,14 @@ func (b *Indexer) Search(ctx context.Context, options *internal.SearchOptions) (
 		queries = append(queries, bleve.NewDisjunctionQuery(milestoneQueries...))
 	}
 
+	if len(options.TypeIDs) > 0 {
+		var typeQueries []query.Query
+		for _, typeID := range options.TypeIDs {
+			typeQueries = append(typeQueries, inner_bleve.NumericEqualityQuery(typeID, "type_id"))
+		}
+		queries = append(queries, bleve.NewDisjunctionQuery(typeQueries...))
+	}
+
 	if options.NoProjectOnly {
 		queries = append(queries, inner_bleve.BoolFieldQuery(true, "no_project"))
 	} else if len(options.ProjectIDs) > 0 {
@@ -283,6 +302,16 @@ func (b *Indexer) Search(ctx context.Context, options *internal.SearchOptions) (
 		queries = append(queries, inner_bleve.NumericEqualityQuery(options.SubscriberID.Value(), "subscriber_ids"))
 	}
 
+	for _, filter := range options.CustomFields {
+		var valueQueries []query.Query
+		for _, token := range filter.Tokens() {
+			q := bleve.NewTermQuery(token)
+			q.SetField("custom_field_values")
+			valueQueries = append(valueQueries, q)
+		}
+		queries = append(queries, bleve.NewDisjunctionQuery(valueQueries...))
+	}
+
 	if options.UpdatedAfterUnix.Has() || options.UpdatedBeforeUnix.Has() {
 		queries = append(queries, inner_bleve.NumericRangeInclusiveQuery(
 			options.UpdatedAfterUnix,
@@ -38,6 +38,8 @@ func ToDBOptions(ctx context.Context, options *internal.SearchOptions) (*issue_m
 	default:
 		if strings.HasPrefix(string(options.SortBy), issue_model.ScopeSortPrefix) {
 			sortType = string(options.SortBy)
+		} else if fieldID, desc, ok := options.SortBy.CustomField(); ok {
+			sortType = issue_model.CustomFieldSortType(fieldID, desc)
 		} else {
 			sortType = "newest"
 		}
@@ -82,12 +84,22 @@ func ToDBOptions(ctx context.Context, options *internal.SearchOptions) (*issue_m
 		Doer:               nil,
 	}
 
+	for _, filter := range options.CustomFields {
+		opts.CustomFields = append(opts.CustomFields, issue_model.CustomFieldFilter{FieldID: filter.FieldID, Values: filter.Values})
+	}
+
 	if len(options.MilestoneIDs) == 1 && options.MilestoneIDs[0] == 0 {
 		opts.MilestoneIDs = []int64{db.NoConditionID}
 	} else {
 		opts.MilestoneIDs = options.MilestoneIDs
 	}
 
+	if len(options.TypeIDs) == 1 && options.TypeIDs[0] == 0 {
+		opts.TypeIDs = []int64{db.NoConditionID}
+	} else {
+		opts.TypeIDs = options.TypeIDs
+	}
+
 	if options.NoLabelOnly {
 		opts.LabelIDs = []int64{0} // Be careful, it's zero, not db.NoConditionID
 	} else {
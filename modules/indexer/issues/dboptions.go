@@ -46,6 +46,12 @@ func ToSearchOptions(keyword string, opts *issues_model.IssuesOptions) *SearchOp
 		searchOpt.MilestoneIDs = opts.MilestoneIDs
 	}
 
+	if len(opts.TypeIDs) == 1 && opts.TypeIDs[0] == db.NoConditionID {
+		searchOpt.TypeIDs = []int64{0}
+	} else {
+		searchOpt.TypeIDs = opts.TypeIDs
+	}
+
 	if len(opts.ProjectIDs) == 1 && opts.ProjectIDs[0] == db.NoConditionID {
 		searchOpt.NoProjectOnly = true
 	} else {
@@ -71,6 +77,8 @@ func ToSearchOptions(keyword string, opts *issues_model.IssuesOptions) *SearchOp
 	searchOpt.ReviewRequestedID = convertID(opts.ReviewRequestedID)
 	searchOpt.SubscriberID = convertID(opts.SubscriberID)
 
+	searchOpt.CustomFields = ToCustomFieldFilters(opts.CustomFields)
+
 	if opts.UpdatedAfterUnix > 0 {
 		searchOpt.UpdatedAfterUnix = optional.Some(opts.UpdatedAfterUnix)
 	}
@@ -103,6 +111,8 @@ func ToSearchOptions(keyword string, opts *issues_model.IssuesOptions) *SearchOp
 	default:
 		if strings.HasPrefix(opts.SortType, issues_model.ScopeSortPrefix) {
 			searchOpt.SortBy = internal.SortBy(opts.SortType)
+		} else if fieldID, desc, ok := issues_model.ParseCustomFieldSortType(opts.SortType); ok {
+			searchOpt.SortBy = internal.SortByCustomField(fieldID, desc)
 		} else {
 			searchOpt.SortBy = SortByUpdatedDesc
 		}
@@ -110,3 +120,12 @@ func ToSearchOptions(keyword string, opts *issues_model.IssuesOptions) *SearchOp
 
 	return searchOpt
 }
+
+// ToCustomFieldFilters converts the custom field filters of the database options to the ones of the search options
+func ToCustomFieldFilters(filters []issues_model.CustomFieldFilter) []CustomFieldFilter {
+	res := make([]CustomFieldFilter, 0, len(filters))
+	for _, filter := range filters {
+		res = append(res, CustomFieldFilter{FieldID: filter.FieldID, Values: filter.Values})
+	}
+	return res
+}
@@ -16,7 +16,7 @@ import (
 	"gitea.dev/modules/util"
 )
 
-const issueIndexerLatestVersion = 4
+const issueIndexerLatestVersion = 6
 
 var _ internal.Indexer = &Indexer{}
 
@@ -39,6 +39,14 @@ const (
 	defaultMapping = `
 {
 	"mappings": {
+		"dynamic_templates": [
+			{
+				"custom_field_sort": {
+					"path_match": "custom_field_sort.*",
+					"mapping": { "type": "double", "index": true }
+				}
+			}
+		],
 		"properties": {
 			"id": { "type": "integer", "index": true },
 			"repo_id": { "type": "integer", "index": true },
@@ -54,6 +62,7 @@ const (
 			"label_ids": { "type": "integer", "index": true },
 			"no_label": { "type": "boolean", "index": true },
 			"milestone_id": { "type": "integer", "index": true },
+			"type_id": { "type": "integer", "index": true },
 			"project_ids": { "type": "integer", "index": true },
 			"no_project": { "type": "boolean", "index": true },
 			"poster_id": { "type": "integer", "index": true },
@@ -63,6 +72,7 @@ const (
 			"reviewed_ids": { "type": "integer", "index": true },
 			"review_requested_ids": { "type": "integer", "index": true },
 			"subscriber_ids": { "type": "integer", "index": true },
+			"custom_field_values": { "type": "keyword", "index": true },
 			"updated_unix": { "type": "integer", "index": true },
 
 			"created_unix": { "type": "integer", "index": true },
@@ -165,6 +175,10 @@ func (b *Indexer) Search(ctx context.Context, options *internal.SearchOptions) (
 		query.Must(es.TermsQuery("milestone_id", es.ToAnySlice(options.MilestoneIDs)...))
 	}
 
+	if len(options.TypeIDs) > 0 {
+		query.Must(es.TermsQuery("type_id", es.ToAnySlice(options.TypeIDs)...))
+	}
+
 	if options.NoProjectOnly {
 		query.Must(es.TermQuery("no_project", true))
 	} else if len(options.ProjectIDs) > 0 {
@@ -204,6 +218,10 @@ func (b *Indexer) Search(ctx context.Context, options *internal.SearchOptions) (
 		query.Must(es.TermQuery("subscriber_ids", options.SubscriberID.Value()))
 	}
 
+	for _, filter := range options.CustomFields {
+		query.Must(es.TermsQuery("custom_field_values", es.ToAnySlice(filter.Tokens())...))
+	}
+
 	if options.UpdatedAfterUnix.Has() || options.UpdatedBeforeUnix.Has() {
 		q := es.NewRangeQuery("updated_unix")
 		if options.UpdatedAfterUnix.Has() {
@@ -253,5 +271,9 @@ func (b *Indexer) Search(ctx context.Context, options *internal.SearchOptions) (
 
 func parseSortBy(sortBy internal.SortBy) es.SortField {
 	field, desc := strings.CutPrefix(string(sortBy), "-")
+	if _, _, ok := sortBy.CustomField(); ok {
+		// the field is only mapped once an issue has a value for it
+		return es.SortField{Field: field, Desc: desc, UnmappedType: "double"}
+	}
 	return es.SortField{Field: field, Desc: desc}
 }
@@ -270,6 +270,9 @@ func IsAvailable(ctx context.Context) bool {
 // SearchOptions indicates the options for searching issues
 type SearchOptions = internal.SearchOptions
 
+// CustomFieldFilter filters the issues having one of the values for a custom field
+type CustomFieldFilter = internal.CustomFieldFilter
+
 const (
 	SortByCreatedDesc  = internal.SortByCreatedDesc
 	SortByUpdatedDesc  = internal.SortByUpdatedDesc
@@ -281,6 +284,11 @@ const (
 	SortByDeadlineAsc  = internal.SortByDeadlineAsc
 )
 
+// SortByCustomField returns the sort by the values of a sortable custom field, the issues without a value come last
+func SortByCustomField(fieldID int64, desc bool) internal.SortBy {
+	return internal.SortByCustomField(fieldID, desc)
+}
+
 // SearchIssues search issues by options.
 func SearchIssues(ctx context.Context, opts *SearchOptions) ([]int64, int64, error) {
 	ix := *globalIndexer.Load()
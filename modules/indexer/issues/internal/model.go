@@ -5,6 +5,7 @@ package internal
 
 import (
 	"strconv"
+	"strings"
 
 	"gitea.dev/models/db"
 	"gitea.dev/modules/indexer"
@@ -30,6 +31,7 @@ type IndexerData struct {
 	LabelIDs           []int64            `json:"label_ids"`
 	NoLabel            bool               `json:"no_label"` // True if LabelIDs is empty
 	MilestoneID        int64              `json:"milestone_id"`
+	TypeID             int64              `json:"type_id"`
 	ProjectIDs         []int64            `json:"project_ids"`
 	NoProject          bool               `json:"no_project"`                   // True if ProjectIDs is empty
 	ProjectColumnMap   map[int64]int64    `json:"project_column_map,omitempty"` // Maps project ID to column ID for each project the issue is in
@@ -40,6 +42,7 @@ type IndexerData struct {
 	ReviewedIDs        []int64            `json:"reviewed_ids"`
 	ReviewRequestedIDs []int64            `json:"review_requested_ids"`
 	SubscriberIDs      []int64            `json:"subscriber_ids"`
+	CustomFieldValues  []string           `json:"custom_field_values"` // CustomFieldValueToken of each custom field value
 	UpdatedUnix        timeutil.TimeStamp `json:"updated_unix"`
 
 	// Fields used for sorting
@@ -48,6 +51,28 @@ type IndexerData struct {
 	CreatedUnix  timeutil.TimeStamp `json:"created_unix"`
 	DeadlineUnix timeutil.TimeStamp `json:"deadline_unix"`
 	CommentCount int64              `json:"comment_count"`
+	// CustomFieldSort maps the IDs of the sortable custom fields set for the issue to the sort keys of their values
+	CustomFieldSort map[string]float64 `json:"custom_field_sort,omitempty"`
+}
+
+// CustomFieldValueToken returns the token indexed for a value of a custom field, it matches the exact value of the field only
+func CustomFieldValueToken(fieldID int64, value string) string {
+	return strconv.FormatInt(fieldID, 10) + ":" + value
+}
+
+// CustomFieldFilter filters the issues having one of the values for a custom field
+type CustomFieldFilter struct {
+	FieldID int64
+	Values  []string
+}
+
+// Tokens returns the indexed tokens of the values of the filter
+func (f CustomFieldFilter) Tokens() []string {
+	tokens := make([]string, 0, len(f.Values))
+	for _, v := range f.Values {
+		tokens = append(tokens, CustomFieldValueToken(f.FieldID, v))
+	}
+	return tokens
 }
 
 // Match represents on search result
@@ -96,6 +121,8 @@ type SearchOptions struct {
 
 	MilestoneIDs []int64 // milestones the issues have
 
+	TypeIDs []int64 // types the issues have, 0 means no type
+
 	ProjectIDs    []int64 // project the issues belong to. FIXME: ISSUE-MULTIPLE-PROJECTS-FILTER: no multiple project filter support yet. Search logic is wrong.
 	NoProjectOnly bool    // if the issues have no project, if true, ProjectIDs will be ignored
 
@@ -109,6 +136,8 @@ type SearchOptions struct {
 
 	SubscriberID optional.Option[int64] // subscriber of the issues
 
+	CustomFields []CustomFieldFilter // issues have one of the values of each custom field
+
 	UpdatedAfterUnix  optional.Option[int64]
 	UpdatedBeforeUnix optional.Option[int64]
 
@@ -160,3 +189,29 @@ const (
 	//                    but what if the issue belongs to multiple projects?
 	//                    Since it's unsupported to search issues with keyword in project page, we don't need to support it.
 )
+
+// customFieldSortField is the field of IndexerData holding the sort keys of the custom fields
+const customFieldSortField = "custom_field_sort"
+
+// SortByCustomField returns the sort by the values of a sortable custom field, the issues without a value come last
+func SortByCustomField(fieldID int64, desc bool) SortBy {
+	s := customFieldSortField + "." + strconv.FormatInt(fieldID, 10)
+	if desc {
+		s = "-" + s
+	}
+	return SortBy(s)
+}
+
+// CustomField returns the ID of the custom field if the issues are sorted by a custom field
+func (s SortBy) CustomField() (fieldID int64, desc, ok bool) {
+	field, desc := strings.CutPrefix(string(s), "-")
+	idStr, ok := strings.CutPrefix(field, customFieldSortField+".")
+	if !ok {
+		return 0, false, false
+	}
+	fieldID, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		return 0, false, false
+	}
+	return fieldID, desc, true
+}
@@ -310,6 +310,42 @@ var cases = []*testIndexerCase{
 			}), result.Total)
 		},
 	},
+	{
+		Name: "TypeIDs",
+		SearchOptions: &internal.SearchOptions{
+			Paginator: &db.ListOptions{
+				PageSize: 5,
+			},
+			TypeIDs: []int64{1, 2},
+		},
+		Expected: func(t *testing.T, data map[int64]*internal.IndexerData, result *internal.SearchResult) {
+			assert.Len(t, result.Hits, 5)
+			for _, v := range result.Hits {
+				assert.Contains(t, []int64{1, 2}, data[v.ID].TypeID)
+			}
+			assert.Equal(t, countIndexerData(data, func(v *internal.IndexerData) bool {
+				return v.TypeID == 1 || v.TypeID == 2
+			}), result.Total)
+		},
+	},
+	{
+		Name: "no TypeIDs",
+		SearchOptions: &internal.SearchOptions{
+			Paginator: &db.ListOptions{
+				PageSize: 5,
+			},
+			TypeIDs: []int64{0},
+		},
+		Expected: func(t *testing.T, data map[int64]*internal.IndexerData, result *internal.SearchResult) {
+			assert.Len(t, result.Hits, 5)
+			for _, v := range result.Hits {
+				assert.Equal(t, int64(0), data[v.ID].TypeID)
+			}
+			assert.Equal(t, countIndexerData(data, func(v *internal.IndexerData) bool {
+				return v.TypeID == 0
+			}), result.Total)
+		},
+	},
 	{
 		Name: "ProjectIDs",
 		SearchOptions: &internal.SearchOptions{
@@ -622,6 +658,47 @@ var cases = []*testIndexerCase{
 			}
 		},
 	},
+	{
+		Name:      "CustomFields",
+		ExtraData: customFieldIndexerData(),
+		SearchOptions: &internal.SearchOptions{
+			CustomFields: []internal.CustomFieldFilter{{FieldID: 1, Values: []string{"high", "low"}}},
+		},
+		ExpectedIDs:   []int64{1001, 1002},
+		ExpectedTotal: 2,
+	},
+	{
+		Name:      "CustomFields of several fields",
+		ExtraData: customFieldIndexerData(),
+		SearchOptions: &internal.SearchOptions{
+			CustomFields: []internal.CustomFieldFilter{
+				{FieldID: 1, Values: []string{"high", "low"}},
+				{FieldID: 2, Values: []string{`a "b"`}},
+			},
+		},
+		ExpectedIDs:   []int64{1001},
+		ExpectedTotal: 1,
+	},
+	{
+		Name:      "SortByCustomFieldAsc",
+		ExtraData: customFieldIndexerData(),
+		SearchOptions: &internal.SearchOptions{
+			RepoIDs: []int64{1000},
+			SortBy:  internal.SortByCustomField(1, false),
+		},
+		ExpectedIDs:   []int64{1002, 1001, 1003},
+		ExpectedTotal: 3,
+	},
+	{
+		Name:      "SortByCustomFieldDesc",
+		ExtraData: customFieldIndexerData(),
+		SearchOptions: &internal.SearchOptions{
+			RepoIDs: []int64{1000},
+			SortBy:  internal.SortByCustomField(1, true),
+		},
+		ExpectedIDs:   []int64{1001, 1002, 1003},
+		ExpectedTotal: 3,
+	},
 	{
 		Name: "SearchAnyAssignee",
 		SearchOptions: &internal.SearchOptions{
@@ -711,6 +788,7 @@ func generateDefaultIndexerData() []*internal.IndexerData {
 				LabelIDs:           labelIDs,
 				NoLabel:            len(labelIDs) == 0,
 				MilestoneID:        issueIndex % 4,
+				TypeID:             issueIndex % 3,
 				ProjectIDs:         projectIDs,
 				NoProject:          len(projectIDs) == 0,
 				PosterID:           id%10 + 1, // PosterID should not be 0
@@ -731,6 +809,33 @@ func generateDefaultIndexerData() []*internal.IndexerData {
 	return data
 }
 
+// customFieldIndexerData returns issues of a repository without other issues, with the custom field 1 being
+// a sortable select field and the custom field 2 a text field
+func customFieldIndexerData() []*internal.IndexerData {
+	return []*internal.IndexerData{
+		{
+			ID:                1001,
+			RepoID:            1000,
+			CustomFieldValues: []string{internal.CustomFieldValueToken(1, "high"), internal.CustomFieldValueToken(2, `a "b"`)},
+			CustomFieldSort:   map[string]float64{"1": 2},
+			CreatedUnix:       1001,
+		},
+		{
+			ID:                1002,
+			RepoID:            1000,
+			CustomFieldValues: []string{internal.CustomFieldValueToken(1, "low")},
+			CustomFieldSort:   map[string]float64{"1": 0},
+			CreatedUnix:       1002,
+		},
+		{
+			ID:                1003,
+			RepoID:            1000,
+			CustomFieldValues: []string{internal.CustomFieldValueToken(2, `a "b"`)},
+			CreatedUnix:       1003,
+		},
+	}
+}
+
 func countIndexerData(data map[int64]*internal.IndexerData, f func(v *internal.IndexerData) bool) int64 {
 	var count int64
 	for _, v := range data {
@@ -20,7 +20,7 @@ import (
 )
 
 const (
-	issueIndexerLatestVersion = 6
+	issueIndexerLatestVersion = 8
 
 	// TODO: make this configurable if necessary
 	maxTotalHits = 10000
@@ -71,6 +71,7 @@ func NewIndexer(url, apiKey, indexerName string) *Indexer {
 			"label_ids",
 			"no_label",
 			"milestone_id",
+			"type_id",
 			"project_ids",
 			"no_project",
 			"poster_id",
@@ -80,6 +81,7 @@ func NewIndexer(url, apiKey, indexerName string) *Indexer {
 			"reviewed_ids",
 			"review_requested_ids",
 			"subscriber_ids",
+			"custom_field_values",
 			"updated_unix",
 		},
 		SortableAttributes: []string{
@@ -87,6 +89,7 @@ func NewIndexer(url, apiKey, indexerName string) *Indexer {
 			"created_unix",
 			"deadline_unix",
 			"comment_count",
+			"custom_field_sort",
 			"id",
 		},
 		Pagination: &meilisearch.Pagination{
@@ -183,6 +186,10 @@ func (b *Indexer) Search(ctx context.Context, options *internal.SearchOptions) (
 		query.And(inner_meilisearch.NewFilterIn("milestone_id", options.MilestoneIDs...))
 	}
 
+	if len(options.TypeIDs) > 0 {
+		query.And(inner_meilisearch.NewFilterIn("type_id", options.TypeIDs...))
+	}
+
 	if options.NoProjectOnly {
 		query.And(inner_meilisearch.NewFilterEq("no_project", true))
 	} else if len(options.ProjectIDs) > 0 {
@@ -222,6 +229,10 @@ func (b *Indexer) Search(ctx context.Context, options *internal.SearchOptions) (
 		query.And(inner_meilisearch.NewFilterEq("subscriber_ids", options.SubscriberID.Value()))
 	}
 
+	for _, filter := range options.CustomFields {
+		query.And(inner_meilisearch.NewFilterInStrings("custom_field_values", filter.Tokens()...))
+	}
+
 	if options.UpdatedAfterUnix.Has() {
 		query.And(inner_meilisearch.NewFilterGte("updated_unix", options.UpdatedAfterUnix.Value()))
 	}
@@ -7,6 +7,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strconv"
 
 	"gitea.dev/models/db"
 	issue_model "gitea.dev/models/issues"
@@ -101,6 +102,11 @@ func getIssueIndexerData(ctx context.Context, issueID int64) (*internal.IndexerD
 		return nil, false, fmt.Errorf("issue.Repo.LoadOwner: %w", err)
 	}
 
+	customFieldValues, customFieldSort, err := getIssueCustomFieldIndexerData(ctx, issue)
+	if err != nil {
+		return nil, false, err
+	}
+
 	return &internal.IndexerData{
 		ID:                 issue.ID,
 		RepoID:             issue.RepoID,
@@ -114,6 +120,7 @@ func getIssueIndexerData(ctx context.Context, issueID int64) (*internal.IndexerD
 		LabelIDs:           labels,
 		NoLabel:            len(labels) == 0,
 		MilestoneID:        issue.MilestoneID,
+		TypeID:             issue.TypeID,
 		ProjectIDs:         projectIDs,
 		NoProject:          len(projectIDs) == 0,
 		PosterID:           issue.PosterID,
@@ -123,13 +130,46 @@ func getIssueIndexerData(ctx context.Context, issueID int64) (*internal.IndexerD
 		ReviewedIDs:        reviewedIDs,
 		ReviewRequestedIDs: reviewRequestedIDs,
 		SubscriberIDs:      subscriberIDs,
+		CustomFieldValues:  customFieldValues,
 		UpdatedUnix:        issue.UpdatedUnix,
 		CreatedUnix:        issue.CreatedUnix,
 		DeadlineUnix:       issue.DeadlineUnix,
 		CommentCount:       int64(len(issue.Comments)),
+		CustomFieldSort:    customFieldSort,
 	}, true, nil
 }
 
+// getIssueCustomFieldIndexerData returns the tokens of the custom field values of an issue and the sort keys of its sortable fields
+func getIssueCustomFieldIndexerData(ctx context.Context, issue *issue_model.Issue) ([]string, map[string]float64, error) {
+	values, err := issue_model.GetIssueCustomFieldValues(ctx, issue.ID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("issue_model.GetIssueCustomFieldValues: %w", err)
+	}
+	if len(values) == 0 {
+		return nil, nil, nil
+	}
+	fields, err := issue_model.GetCustomFieldsForRepo(ctx, issue.Repo)
+	if err != nil {
+		return nil, nil, fmt.Errorf("issue_model.GetCustomFieldsForRepo: %w", err)
+	}
+	sortable := make(container.Set[int64], len(fields))
+	for _, field := range fields {
+		if field.Type.IsSortable() {
+			sortable.Add(field.ID)
+		}
+	}
+
+	tokens := make([]string, 0, len(values))
+	sortKeys := make(map[string]float64)
+	for _, v := range values {
+		tokens = append(tokens, internal.CustomFieldValueToken(v.FieldID, v.Value))
+		if sortable.Contains(v.FieldID) {
+			sortKeys[strconv.FormatInt(v.FieldID, 10)] = v.Number
+		}
+	}
+	return tokens, sortKeys, nil
+}
+
 func updateRepoIndexer(ctx context.Context, repoID int64) error {
 	ids, err := issue_model.GetIssueIDsByRepoID(ctx, repoID)
 	if err != nil {
@@ -6,6 +6,7 @@ package migration
 
 import (
 	"context"
+	"time"
 
 	"gitea.dev/modules/structs"
 )
@@ -26,6 +27,15 @@ type Downloader interface {
 	FormatCloneURL(opts MigrateOptions, remoteAddr string) (string, error)
 }
 
+// IncrementalDownloader is implemented by the downloaders able to only list the issues and pull requests
+// updated since a given time, it is used when syncing a migrated repository with its source again.
+// The methods return ErrNotSupported when the source can't filter the items, then all of them are listed.
+type IncrementalDownloader interface {
+	GetIssuesSince(ctx context.Context, since time.Time, page, perPage int) ([]*Issue, bool, error)
+	GetPullRequestsSince(ctx context.Context, since time.Time, page, perPage int) ([]*PullRequest, bool, error)
+	GetAllCommentsSince(ctx context.Context, since time.Time, page, perPage int) ([]*Comment, bool, error)
+}
+
 // DownloaderFactory defines an interface to match a downloader implementation and create a downloader
 type DownloaderFactory interface {
 	New(ctx context.Context, opts MigrateOptions) (Downloader, error)
@@ -38,6 +38,7 @@ type MigrateOptions struct {
 	ReleaseAssets   bool
 	MigrateToRepoID int64
 	MirrorInterval  string `json:"mirror_interval"`
+	Sync            bool   `json:"sync"`
 
 	AWSAccessKeyID     string
 	AWSSecretAccessKey string `json:",omitempty"`
@@ -8,7 +8,10 @@ import (
 	"time"
 )
 
-var _ Downloader = &RetryDownloader{}
+var (
+	_ Downloader            = &RetryDownloader{}
+	_ IncrementalDownloader = &RetryDownloader{}
+)
 
 // RetryDownloader retry the downloads
 type RetryDownloader struct {
@@ -183,3 +186,66 @@ func (d *RetryDownloader) GetReviews(ctx context.Context, reviewable Reviewable)
 
 	return reviews, err
 }
+
+// GetIssuesSince returns a repository's issues updated since the given time with retry
+func (d *RetryDownloader) GetIssuesSince(ctx context.Context, since time.Time, page, perPage int) ([]*Issue, bool, error) {
+	incremental, ok := d.Downloader.(IncrementalDownloader)
+	if !ok {
+		return nil, false, ErrNotSupported{Entity: "IssuesSince"}
+	}
+
+	var (
+		issues []*Issue
+		isEnd  bool
+		err    error
+	)
+
+	err = d.retry(ctx, func(ctx context.Context) error {
+		issues, isEnd, err = incremental.GetIssuesSince(ctx, since, page, perPage)
+		return err
+	})
+
+	return issues, isEnd, err
+}
+
+// GetPullRequestsSince returns a repository's pull requests updated since the given time with retry
+func (d *RetryDownloader) GetPullRequestsSince(ctx context.Context, since time.Time, page, perPage int) ([]*PullRequest, bool, error) {
+	incremental, ok := d.Downloader.(IncrementalDownloader)
+	if !ok {
+		return nil, false, ErrNotSupported{Entity: "PullRequestsSince"}
+	}
+
+	var (
+		prs   []*PullRequest
+		isEnd bool
+		err   error
+	)
+
+	err = d.retry(ctx, func(ctx context.Context) error {
+		prs, isEnd, err = incremental.GetPullRequestsSince(ctx, since, page, perPage)
+		return err
+	})
+
+	return prs, isEnd, err
+}
+
+// GetAllCommentsSince returns a repository's comments updated since the given time with retry
+func (d *RetryDownloader) GetAllCommentsSince(ctx context.Context, since time.Time, page, perPage int) ([]*Comment, bool, error) {
+	incremental, ok := d.Downloader.(IncrementalDownloader)
+	if !ok {
+		return nil, false, ErrNotSupported{Entity: "AllCommentsSince"}
+	}
+
+	var (
+		comments []*Comment
+		isEnd    bool
+		err      error
+	)
+
+	err = d.retry(ctx, func(ctx context.Context) error {
+		comments, isEnd, err = incremental.GetAllCommentsSince(ctx, since, page, perPage)
+		return err
+	})
+
+	return comments, isEnd, err
+}
@@ -0,0 +1,250 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package ansible
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"regexp"
+	"strings"
+
+	"gitea.dev/modules/json"
+	"gitea.dev/modules/util"
+	"gitea.dev/modules/validation"
+
+	"github.com/hashicorp/go-version"
+)
+
+var (
+	ErrMissingManifestFile  = util.NewInvalidArgumentErrorf("MANIFEST.json file is missing")
+	ErrManifestFileTooLarge = util.NewInvalidArgumentErrorf("MANIFEST.json file is too large")
+	ErrMissingFilesFile     = util.NewInvalidArgumentErrorf("FILES.json file is missing")
+	ErrFilesFileTooLarge    = util.NewInvalidArgumentErrorf("FILES.json file is too large")
+	ErrFilesChecksum        = util.NewInvalidArgumentErrorf("FILES.json checksum does not match")
+	ErrInvalidNamespace     = util.NewInvalidArgumentErrorf("collection namespace is invalid")
+	ErrInvalidName          = util.NewInvalidArgumentErrorf("collection name is invalid")
+	ErrInvalidVersion       = util.NewInvalidArgumentErrorf("collection version is invalid")
+)
+
+const (
+	manifestFilename    = "MANIFEST.json"
+	filesFilename       = "FILES.json"
+	maxManifestFileSize = 1024 * 1024
+	maxFilesFileSize    = 10 * 1024 * 1024
+	maxReadmeFileSize   = 1024 * 1024
+)
+
+// https://docs.ansible.com/ansible/latest/dev_guide/collections_galaxy_meta.html
+var namePattern = regexp.MustCompile(`\A[a-z][a-z0-9_]*\z`)
+
+// Package represents an Ansible collection
+type Package struct {
+	Namespace string
+	Name      string
+	Version   string
+	Metadata  *Metadata
+}
+
+// FullName returns the fully qualified collection name which is used as package name
+func (p *Package) FullName() string {
+	return p.Namespace + "." + p.Name
+}
+
+// Filename returns the name of the collection artifact
+func (p *Package) Filename() string {
+	return FormatFilename(p.Namespace, p.Name, p.Version)
+}
+
+// FormatFilename returns the name of the collection artifact like "ansible-galaxy collection build" creates it
+func FormatFilename(namespace, name, version string) string {
+	return namespace + "-" + name + "-" + version + ".tar.gz"
+}
+
+// Metadata represents the metadata of an Ansible collection
+type Metadata struct {
+	Namespace        string            `json:"namespace"`
+	Name             string            `json:"name"`
+	Authors          []string          `json:"authors,omitempty"`
+	Description      string            `json:"description,omitempty"`
+	License          []string          `json:"license,omitempty"`
+	LicenseFile      string            `json:"license_file,omitempty"`
+	Tags             []string          `json:"tags,omitempty"`
+	Dependencies     map[string]string `json:"dependencies,omitempty"`
+	RepositoryURL    string            `json:"repository_url,omitempty"`
+	DocumentationURL string            `json:"documentation_url,omitempty"`
+	HomepageURL      string            `json:"homepage_url,omitempty"`
+	IssuesURL        string            `json:"issues_url,omitempty"`
+	Readme           string            `json:"readme,omitempty"`
+	Files            []string          `json:"files,omitempty"`
+}
+
+type collectionManifest struct {
+	CollectionInfo struct {
+		Namespace     string            `json:"namespace"`
+		Name          string            `json:"name"`
+		Version       string            `json:"version"`
+		Authors       []string          `json:"authors"`
+		Readme        string            `json:"readme"`
+		Tags          []string          `json:"tags"`
+		Description   string            `json:"description"`
+		License       []string          `json:"license"`
+		LicenseFile   string            `json:"license_file"`
+		Dependencies  map[string]string `json:"dependencies"`
+		Repository    string            `json:"repository"`
+		Documentation string            `json:"documentation"`
+		Homepage      string            `json:"homepage"`
+		Issues        string            `json:"issues"`
+	} `json:"collection_info"`
+	FileManifestFile struct {
+		Name         string `json:"name"`
+		ChecksumType string `json:"chksum_type"`
+		Checksum     string `json:"chksum_sha256"`
+	} `json:"file_manifest_file"`
+}
+
+type filesManifest struct {
+	Files []struct {
+		Name string `json:"name"`
+		Type string `json:"ftype"`
+	} `json:"files"`
+}
+
+// ParsePackage parses the collection artifact created by "ansible-galaxy collection build"
+func ParsePackage(r io.Reader) (*Package, error) {
+	gzr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, err
+	}
+	defer gzr.Close()
+
+	var manifestData, filesData []byte
+	readmes := make(map[string]string)
+
+	tr := tar.NewReader(gzr)
+	for {
+		hd, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if hd.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		switch name := strings.TrimPrefix(hd.Name, "./"); {
+		case name == manifestFilename:
+			if hd.Size > maxManifestFileSize {
+				return nil, ErrManifestFileTooLarge
+			}
+			if manifestData, err = util.ReadWithLimit(tr, maxManifestFileSize); err != nil {
+				return nil, err
+			}
+		case name == filesFilename:
+			if hd.Size > maxFilesFileSize {
+				return nil, ErrFilesFileTooLarge
+			}
+			if filesData, err = util.ReadWithLimit(tr, maxFilesFileSize); err != nil {
+				return nil, err
+			}
+		case !strings.Contains(name, "/") && strings.HasPrefix(strings.ToLower(name), "readme"):
+			// the readme file is defined in the manifest which may not be read yet
+			data, err := util.ReadWithLimit(tr, maxReadmeFileSize)
+			if err != nil {
+				return nil, err
+			}
+			readmes[name] = string(data)
+		}
+	}
+
+	if manifestData == nil {
+		return nil, ErrMissingManifestFile
+	}
+	if filesData == nil {
+		return nil, ErrMissingFilesFile
+	}
+
+	p, err := parseManifest(manifestData, filesData)
+	if err != nil {
+		return nil, err
+	}
+
+	p.Metadata.Readme = readmes[p.Metadata.Readme]
+
+	return p, nil
+}
+
+func parseManifest(manifestData, filesData []byte) (*Package, error) {
+	var manifest collectionManifest
+	if err := json.Unmarshal(manifestData, &manifest); err != nil {
+		return nil, util.NewInvalidArgumentErrorf("MANIFEST.json is invalid: %v", err)
+	}
+
+	if manifest.FileManifestFile.Checksum != "" {
+		checksum := sha256.Sum256(filesData)
+		if !strings.EqualFold(manifest.FileManifestFile.Checksum, hex.EncodeToString(checksum[:])) {
+			return nil, ErrFilesChecksum
+		}
+	}
+
+	var files filesManifest
+	if err := json.Unmarshal(filesData, &files); err != nil {
+		return nil, util.NewInvalidArgumentErrorf("FILES.json is invalid: %v", err)
+	}
+
+	info := manifest.CollectionInfo
+
+	if !namePattern.MatchString(info.Namespace) {
+		return nil, ErrInvalidNamespace
+	}
+	if !namePattern.MatchString(info.Name) {
+		return nil, ErrInvalidName
+	}
+
+	v, err := version.NewSemver(info.Version)
+	if err != nil {
+		return nil, ErrInvalidVersion
+	}
+
+	m := &Metadata{
+		Namespace:        info.Namespace,
+		Name:             info.Name,
+		Authors:          info.Authors,
+		Description:      info.Description,
+		License:          info.License,
+		LicenseFile:      info.LicenseFile,
+		Tags:             info.Tags,
+		Dependencies:     info.Dependencies,
+		RepositoryURL:    info.Repository,
+		DocumentationURL: info.Documentation,
+		HomepageURL:      info.Homepage,
+		IssuesURL:        info.Issues,
+		// the name of the readme file is replaced by its content later
+		Readme: info.Readme,
+	}
+
+	for _, u := range []*string{&m.RepositoryURL, &m.DocumentationURL, &m.HomepageURL, &m.IssuesURL} {
+		if !validation.IsValidURL(*u) {
+			*u = ""
+		}
+	}
+
+	for _, f := range files.Files {
+		if f.Type == "file" {
+			m.Files = append(m.Files, f.Name)
+		}
+	}
+
+	return &Package{
+		Namespace: info.Namespace,
+		Name:      info.Name,
+		Version:   v.String(),
+		Metadata:  m,
+	}, nil
+}
@@ -0,0 +1,121 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package ansible
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	namespace         = "gitea"
+	collectionName    = "tools"
+	collectionVersion = "1.0.1"
+	description       = "Collection Description"
+	readme            = "# Gitea Tools"
+	projectURL        = "https://gitea.com"
+)
+
+const filesContent = `{"files":[{"name":".","ftype":"dir","chksum_type":null,"chksum_sha256":null,"format":1},{"name":"README.md","ftype":"file","chksum_type":"sha256","chksum_sha256":"00","format":1},{"name":"plugins/modules/test.py","ftype":"file","chksum_type":"sha256","chksum_sha256":"00","format":1}],"format":1}`
+
+func manifestContent(namespace, v, filesChecksum string) string {
+	return `{"collection_info":{"namespace":"` + namespace + `","name":"` + collectionName + `","version":"` + v + `","authors":["KN4CK3R"],"readme":"README.md","tags":["gitea"],"description":"` + description + `","license":["MIT"],"license_file":null,"dependencies":{"community.general":">=1.0.0"},"repository":"` + projectURL + `","documentation":"invalid","homepage":null,"issues":null},"file_manifest_file":{"name":"FILES.json","ftype":"file","chksum_type":"sha256","chksum_sha256":"` + filesChecksum + `","format":1},"format":1}`
+}
+
+func createArchive(files map[string]string) io.Reader {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(zw)
+	for filename, content := range files {
+		tw.WriteHeader(&tar.Header{
+			Name: filename,
+			Mode: 0o600,
+			Size: int64(len(content)),
+		})
+		tw.Write([]byte(content))
+	}
+	tw.Close()
+	zw.Close()
+	return &buf
+}
+
+func TestParsePackage(t *testing.T) {
+	checksum := sha256.Sum256([]byte(filesContent))
+	filesChecksum := hex.EncodeToString(checksum[:])
+
+	t.Run("MissingManifestFile", func(t *testing.T) {
+		p, err := ParsePackage(createArchive(map[string]string{"FILES.json": filesContent}))
+		assert.Nil(t, p)
+		assert.ErrorIs(t, err, ErrMissingManifestFile)
+	})
+
+	t.Run("MissingFilesFile", func(t *testing.T) {
+		p, err := ParsePackage(createArchive(map[string]string{"MANIFEST.json": manifestContent(namespace, collectionVersion, filesChecksum)}))
+		assert.Nil(t, p)
+		assert.ErrorIs(t, err, ErrMissingFilesFile)
+	})
+
+	t.Run("ChecksumMismatch", func(t *testing.T) {
+		p, err := ParsePackage(createArchive(map[string]string{
+			"MANIFEST.json": manifestContent(namespace, collectionVersion, "00"),
+			"FILES.json":    filesContent,
+		}))
+		assert.Nil(t, p)
+		assert.ErrorIs(t, err, ErrFilesChecksum)
+	})
+
+	t.Run("InvalidNamespace", func(t *testing.T) {
+		p, err := ParsePackage(createArchive(map[string]string{
+			"MANIFEST.json": manifestContent("_gitea", collectionVersion, filesChecksum),
+			"FILES.json":    filesContent,
+		}))
+		assert.Nil(t, p)
+		assert.ErrorIs(t, err, ErrInvalidNamespace)
+	})
+
+	t.Run("InvalidVersion", func(t *testing.T) {
+		p, err := ParsePackage(createArchive(map[string]string{
+			"MANIFEST.json": manifestContent(namespace, "1.a", filesChecksum),
+			"FILES.json":    filesContent,
+		}))
+		assert.Nil(t, p)
+		assert.ErrorIs(t, err, ErrInvalidVersion)
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		p, err := ParsePackage(createArchive(map[string]string{
+			"MANIFEST.json":           manifestContent(namespace, collectionVersion, filesChecksum),
+			"FILES.json":              filesContent,
+			"README.md":               readme,
+			"plugins/modules/test.py": "",
+		}))
+		require.NoError(t, err)
+		require.NotNil(t, p)
+
+		assert.Equal(t, namespace, p.Namespace)
+		assert.Equal(t, collectionName, p.Name)
+		assert.Equal(t, collectionVersion, p.Version)
+		assert.Equal(t, "gitea.tools", p.FullName())
+		assert.Equal(t, "gitea-tools-1.0.1.tar.gz", p.Filename())
+
+		m := p.Metadata
+		assert.Equal(t, description, m.Description)
+		assert.Equal(t, readme, m.Readme)
+		assert.Equal(t, []string{"KN4CK3R"}, m.Authors)
+		assert.Equal(t, []string{"MIT"}, m.License)
+		assert.Equal(t, []string{"gitea"}, m.Tags)
+		assert.Equal(t, map[string]string{"community.general": ">=1.0.0"}, m.Dependencies)
+		assert.Equal(t, projectURL, m.RepositoryURL)
+		assert.Empty(t, m.DocumentationURL)
+		assert.Equal(t, []string{"README.md", "plugins/modules/test.py"}, m.Files)
+	})
+}
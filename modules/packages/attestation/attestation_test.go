@@ -0,0 +1,214 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package attestation
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/42wim/sshsig"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+)
+
+func TestSignature(t *testing.T) {
+	_, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	block, err := ssh.MarshalPrivateKey(priv, "")
+	require.NoError(t, err)
+
+	sig, err := sshsig.Sign(pem.EncodeToMemory(block), bytes.NewReader([]byte("content")), "file")
+	require.NoError(t, err)
+
+	format, err := DetectSignatureFormat(sig)
+	require.NoError(t, err)
+	assert.Equal(t, FormatSSH, format)
+
+	pk, namespace, err := ParseSSHSignature(sig)
+	require.NoError(t, err)
+	assert.Equal(t, "file", namespace)
+	signer, err := ssh.NewSignerFromKey(priv)
+	require.NoError(t, err)
+	assert.Equal(t, ssh.FingerprintSHA256(signer.PublicKey()), ssh.FingerprintSHA256(pk))
+
+	format, err = DetectSignatureFormat([]byte("-----BEGIN PGP SIGNATURE-----\n\n-----END PGP SIGNATURE-----\n"))
+	require.NoError(t, err)
+	assert.Equal(t, FormatGPG, format)
+
+	format, err = DetectSignatureFormat([]byte{0x89, 0x01})
+	require.NoError(t, err)
+	assert.Equal(t, FormatGPG, format)
+
+	_, err = DetectSignatureFormat([]byte("invalid"))
+	assert.ErrorIs(t, err, ErrInvalidSignature)
+
+	_, _, err = ParseSSHSignature([]byte("-----BEGIN SSH SIGNATURE-----\naW52YWxpZA==\n-----END SSH SIGNATURE-----\n"))
+	assert.ErrorIs(t, err, ErrInvalidSignature)
+}
+
+func TestParseSBOM(t *testing.T) {
+	t.Run("SPDX", func(t *testing.T) {
+		sbom, err := ParseSBOM([]byte(`{
+  "spdxVersion": "SPDX-2.3",
+  "SPDXID": "SPDXRef-DOCUMENT",
+  "name": "test-package",
+  "documentDescribes": ["SPDXRef-Package-root"],
+  "packages": [
+    {"SPDXID": "SPDXRef-Package-root", "name": "test-package", "versionInfo": "1.0.0"},
+    {
+      "SPDXID": "SPDXRef-Package-dep",
+      "name": "left-pad",
+      "versionInfo": "1.3.0",
+      "licenseConcluded": "NOASSERTION",
+      "licenseDeclared": "WTFPL",
+      "externalRefs": [{"referenceCategory": "PACKAGE-MANAGER", "referenceType": "purl", "referenceLocator": "pkg:npm/left-pad@1.3.0"}]
+    }
+  ]
+}`))
+		require.NoError(t, err)
+		assert.Equal(t, FormatSPDX, sbom.Format)
+		assert.Equal(t, "2.3", sbom.SpecVersion)
+		assert.Equal(t, "test-package", sbom.Name)
+		assert.Equal(t, []Component{{Name: "left-pad", Version: "1.3.0", PURL: "pkg:npm/left-pad@1.3.0", License: "WTFPL"}}, sbom.Components)
+	})
+
+	t.Run("CycloneDXJSON", func(t *testing.T) {
+		sbom, err := ParseSBOM([]byte(`{
+  "bomFormat": "CycloneDX",
+  "specVersion": "1.5",
+  "metadata": {"component": {"type": "library", "group": "org.example", "name": "app"}},
+  "components": [
+    {
+      "type": "library",
+      "group": "com.google.guava",
+      "name": "guava",
+      "version": "32.1.2-jre",
+      "purl": "pkg:maven/com.google.guava/guava@32.1.2-jre",
+      "licenses": [{"license": {"id": "Apache-2.0"}}],
+      "components": [{"type": "library", "name": "failureaccess", "version": "1.0.1"}]
+    }
+  ]
+}`))
+		require.NoError(t, err)
+		assert.Equal(t, FormatCycloneDX, sbom.Format)
+		assert.Equal(t, "1.5", sbom.SpecVersion)
+		assert.Equal(t, "org.example/app", sbom.Name)
+		assert.Equal(t, []Component{
+			{Name: "com.google.guava/guava", Version: "32.1.2-jre", PURL: "pkg:maven/com.google.guava/guava@32.1.2-jre", License: "Apache-2.0"},
+			{Name: "failureaccess", Version: "1.0.1"},
+		}, sbom.Components)
+	})
+
+	t.Run("CycloneDXXML", func(t *testing.T) {
+		sbom, err := ParseSBOM([]byte(`<?xml version="1.0" encoding="UTF-8"?>
+<bom xmlns="http://cyclonedx.org/schema/bom/1.4" version="1">
+  <components>
+    <component type="library">
+      <name>requests</name>
+      <version>2.31.0</version>
+      <purl>pkg:pypi/requests@2.31.0</purl>
+      <licenses><expression>Apache-2.0</expression></licenses>
+    </component>
+  </components>
+</bom>`))
+		require.NoError(t, err)
+		assert.Equal(t, FormatCycloneDX, sbom.Format)
+		assert.Equal(t, "1.4", sbom.SpecVersion)
+		assert.Equal(t, []Component{{Name: "requests", Version: "2.31.0", PURL: "pkg:pypi/requests@2.31.0", License: "Apache-2.0"}}, sbom.Components)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		for _, content := range []string{"", "{}", "invalid", `{"bomFormat":"other"}`, `<bom xmlns="http://example.com"/>`} {
+			_, err := ParseSBOM([]byte(content))
+			assert.ErrorIs(t, err, ErrInvalidSBOM, content)
+		}
+	})
+}
+
+func TestParseProvenance(t *testing.T) {
+	statement := `{"_type":"https://in-toto.io/Statement/v1","subject":[{"name":"test-1.0.0.tgz","digest":{"sha256":"ABCDEF"}}],"predicateType":"https://slsa.dev/provenance/v1","predicate":{"buildDefinition":{"buildType":"https://actions.github.io/buildtypes/workflow/v1","resolvedDependencies":[{"uri":"git+https://example.com/org/repo@refs/heads/main"}]},"runDetails":{"builder":{"id":"https://example.com/runner"}}}}`
+
+	check := func(t *testing.T, p *Provenance) {
+		assert.Equal(t, "https://slsa.dev/provenance/v1", p.PredicateType)
+		assert.Equal(t, "https://actions.github.io/buildtypes/workflow/v1", p.BuildType)
+		assert.Equal(t, "https://example.com/runner", p.BuilderID)
+		assert.Equal(t, "git+https://example.com/org/repo@refs/heads/main", p.SourceURI)
+		require.Len(t, p.Subjects, 1)
+		assert.Equal(t, "test-1.0.0.tgz", p.Subjects[0].Name)
+		assert.True(t, p.MatchesDigest("sha256", "abcdef"))
+		assert.False(t, p.MatchesDigest("sha512", "abcdef"))
+	}
+
+	t.Run("Statement", func(t *testing.T) {
+		p, err := ParseProvenance([]byte(statement))
+		require.NoError(t, err)
+		check(t, p)
+		assert.False(t, p.Enveloped)
+		assert.Nil(t, p.Envelope)
+	})
+
+	envelope := `{"payloadType":"application/vnd.in-toto+json","payload":"` + base64.StdEncoding.EncodeToString([]byte(statement)) + `","signatures":[{"keyid":"","sig":"c2ln"}]}`
+
+	t.Run("Envelope", func(t *testing.T) {
+		p, err := ParseProvenance([]byte(envelope + "\n" + envelope + "\n"))
+		require.NoError(t, err)
+		check(t, p)
+		assert.True(t, p.Enveloped)
+		require.NotNil(t, p.Envelope)
+		assert.Equal(t, [][]byte{[]byte("sig")}, p.Envelope.Signatures)
+		assert.Equal(t, fmt.Sprintf("DSSEv1 28 application/vnd.in-toto+json %d %s", len(statement), statement), string(p.Envelope.PAE()))
+	})
+
+	t.Run("Bundle", func(t *testing.T) {
+		p, err := ParseProvenance([]byte(`{"mediaType":"application/vnd.dev.sigstore.bundle.v0.3+json","dsseEnvelope":` + envelope + `}`))
+		require.NoError(t, err)
+		check(t, p)
+		assert.True(t, p.Enveloped)
+	})
+
+	t.Run("PEP740", func(t *testing.T) {
+		p, err := ParseProvenance([]byte(`{"version":1,"verification_material":{},"envelope":{"statement":"` + base64.StdEncoding.EncodeToString([]byte(statement)) + `","signature":"c2ln"}}`))
+		require.NoError(t, err)
+		check(t, p)
+		assert.True(t, p.Enveloped)
+		require.NotNil(t, p.Envelope)
+		assert.Equal(t, [][]byte{[]byte("sig")}, p.Envelope.Signatures)
+	})
+
+	t.Run("Indented", func(t *testing.T) {
+		p, err := ParseProvenance([]byte(strings.ReplaceAll(statement, ",", ",\n  ")))
+		require.NoError(t, err)
+		check(t, p)
+	})
+
+	t.Run("V02", func(t *testing.T) {
+		p, err := ParseProvenance([]byte(`{"_type":"https://in-toto.io/Statement/v0.1","subject":[{"name":"a","digest":{"sha256":"00"}}],"predicateType":"https://slsa.dev/provenance/v0.2","predicate":{"builder":{"id":"builder"},"buildType":"type","invocation":{"configSource":{"uri":"git+https://example.com/repo"}}}}`))
+		require.NoError(t, err)
+		assert.Equal(t, "builder", p.BuilderID)
+		assert.Equal(t, "type", p.BuildType)
+		assert.Equal(t, "git+https://example.com/repo", p.SourceURI)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		for _, content := range []string{
+			"",
+			"invalid",
+			"{}",
+			`{"_type":"https://example.com","subject":[{"name":"a"}],"predicateType":"a"}`,
+			`{"_type":"https://in-toto.io/Statement/v1","subject":[],"predicateType":"a"}`,
+			`{"payloadType":"text/plain","payload":""}`,
+			`{"payloadType":"application/vnd.in-toto+json","payload":"invalid"}`,
+		} {
+			_, err := ParseProvenance([]byte(content))
+			assert.ErrorIs(t, err, ErrInvalidProvenance, content)
+		}
+	})
+}
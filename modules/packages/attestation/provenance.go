@@ -0,0 +1,200 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package attestation
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"gitea.dev/modules/json"
+	"gitea.dev/modules/util"
+)
+
+var ErrInvalidProvenance = util.NewInvalidArgumentErrorf("provenance is invalid or has an unsupported format")
+
+const (
+	inTotoStatementTypePrefix = "https://in-toto.io/Statement/"
+	inTotoPayloadType         = "application/vnd.in-toto+json"
+)
+
+// Provenance is the normalized representation of an in-toto statement with a SLSA provenance predicate
+type Provenance struct {
+	PredicateType string     `json:"predicate_type"`
+	BuildType     string     `json:"build_type,omitempty"`
+	BuilderID     string     `json:"builder_id,omitempty"`
+	SourceURI     string     `json:"source_uri,omitempty"`
+	Subjects      []*Subject `json:"subjects"`
+	// Enveloped is set if the statement was wrapped in a DSSE envelope
+	Enveloped bool `json:"enveloped"`
+	// Envelope is the DSSE envelope the statement was wrapped in
+	Envelope *Envelope `json:"-"`
+}
+
+// Envelope is a DSSE envelope, its signatures are created for the pre-authentication encoding of the payload
+type Envelope struct {
+	PayloadType string
+	Payload     []byte
+	Signatures  [][]byte
+}
+
+// PAE returns the DSSE pre-authentication encoding of the payload, which is the content the signatures sign
+func (e *Envelope) PAE() []byte {
+	return fmt.Appendf(nil, "DSSEv1 %d %s %d %s", len(e.PayloadType), e.PayloadType, len(e.Payload), e.Payload)
+}
+
+// Subject is an artifact described by the statement
+type Subject struct {
+	Name   string            `json:"name"`
+	Digest map[string]string `json:"digest"`
+}
+
+type inTotoStatement struct {
+	Type          string     `json:"_type"`
+	Subject       []*Subject `json:"subject"`
+	PredicateType string     `json:"predicateType"`
+	Predicate     json.Value `json:"predicate"`
+}
+
+type dsseEnvelope struct {
+	PayloadType string `json:"payloadType"`
+	Payload     string `json:"payload"`
+	Signatures  []struct {
+		KeyID string `json:"keyid"`
+		Sig   string `json:"sig"`
+	} `json:"signatures"`
+}
+
+// slsaPredicate contains the interesting fields of the v0.2 and v1 SLSA provenance predicates
+type slsaPredicate struct {
+	// v0.2
+	Builder struct {
+		ID string `json:"id"`
+	} `json:"builder"`
+	BuildType  string `json:"buildType"`
+	Invocation struct {
+		ConfigSource struct {
+			URI string `json:"uri"`
+		} `json:"configSource"`
+	} `json:"invocation"`
+	// v1
+	BuildDefinition struct {
+		BuildType            string `json:"buildType"`
+		ResolvedDependencies []struct {
+			URI string `json:"uri"`
+		} `json:"resolvedDependencies"`
+	} `json:"buildDefinition"`
+	RunDetails struct {
+		Builder struct {
+			ID string `json:"id"`
+		} `json:"builder"`
+	} `json:"runDetails"`
+}
+
+// ParseProvenance parses an in-toto statement which may be wrapped in a DSSE envelope, a Sigstore bundle or a PEP 740 attestation.
+// Only the first statement of an in-toto JSON lines file is used.
+func ParseProvenance(data []byte) (*Provenance, error) {
+	data = bytes.TrimSpace(data)
+	if !json.Valid(data) {
+		line, _, _ := bytes.Cut(data, []byte("\n"))
+		data = bytes.TrimSpace(line)
+	}
+
+	var probe struct {
+		Type         string        `json:"_type"`
+		PayloadType  string        `json:"payloadType"`
+		DsseEnvelope *dsseEnvelope `json:"dsseEnvelope"`
+		// PEP 740 attestation object
+		Envelope *struct {
+			Statement string `json:"statement"`
+			Signature string `json:"signature"`
+		} `json:"envelope"`
+	}
+	if err := json.Unmarshal(data, &probe); err != nil {
+		return nil, ErrInvalidProvenance
+	}
+
+	var envelope *Envelope
+	if probe.Type == "" {
+		var dsse *dsseEnvelope
+		if probe.DsseEnvelope != nil {
+			dsse = probe.DsseEnvelope
+		} else if probe.Envelope != nil {
+			dsse = &dsseEnvelope{PayloadType: inTotoPayloadType, Payload: probe.Envelope.Statement}
+			dsse.Signatures = append(dsse.Signatures, struct {
+				KeyID string `json:"keyid"`
+				Sig   string `json:"sig"`
+			}{Sig: probe.Envelope.Signature})
+		} else if probe.PayloadType != "" {
+			dsse = &dsseEnvelope{}
+			if err := json.Unmarshal(data, dsse); err != nil {
+				return nil, ErrInvalidProvenance
+			}
+		}
+		if dsse == nil || dsse.PayloadType != inTotoPayloadType {
+			return nil, ErrInvalidProvenance
+		}
+
+		payload, err := base64.StdEncoding.DecodeString(dsse.Payload)
+		if err != nil {
+			return nil, ErrInvalidProvenance
+		}
+		envelope = &Envelope{PayloadType: dsse.PayloadType, Payload: payload}
+		for _, sig := range dsse.Signatures {
+			// the signatures which can't be decoded can't be verified either
+			if decoded, err := base64.StdEncoding.DecodeString(sig.Sig); err == nil && len(decoded) > 0 {
+				envelope.Signatures = append(envelope.Signatures, decoded)
+			}
+		}
+		data = payload
+	}
+
+	var statement inTotoStatement
+	if err := json.Unmarshal(data, &statement); err != nil {
+		return nil, ErrInvalidProvenance
+	}
+	if !strings.HasPrefix(statement.Type, inTotoStatementTypePrefix) || statement.PredicateType == "" || len(statement.Subject) == 0 {
+		return nil, ErrInvalidProvenance
+	}
+
+	p := &Provenance{
+		PredicateType: statement.PredicateType,
+		Subjects:      statement.Subject,
+		Enveloped:     envelope != nil,
+		Envelope:      envelope,
+	}
+
+	if strings.HasPrefix(statement.PredicateType, "https://slsa.dev/provenance/") && len(statement.Predicate) > 0 {
+		var predicate slsaPredicate
+		if err := json.Unmarshal(statement.Predicate, &predicate); err != nil {
+			return nil, ErrInvalidProvenance
+		}
+
+		p.BuilderID = predicate.Builder.ID
+		if p.BuilderID == "" {
+			p.BuilderID = predicate.RunDetails.Builder.ID
+		}
+		p.BuildType = predicate.BuildType
+		if p.BuildType == "" {
+			p.BuildType = predicate.BuildDefinition.BuildType
+		}
+		p.SourceURI = predicate.Invocation.ConfigSource.URI
+		if p.SourceURI == "" && len(predicate.BuildDefinition.ResolvedDependencies) > 0 {
+			p.SourceURI = predicate.BuildDefinition.ResolvedDependencies[0].URI
+		}
+	}
+
+	return p, nil
+}
+
+// MatchesDigest checks if a subject of the statement has the digest
+func (p *Provenance) MatchesDigest(algorithm, digest string) bool {
+	for _, s := range p.Subjects {
+		if d, ok := s.Digest[algorithm]; ok && strings.EqualFold(d, digest) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,282 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package attestation
+
+import (
+	"bytes"
+	"encoding/xml"
+	"strings"
+
+	"gitea.dev/modules/json"
+	"gitea.dev/modules/util"
+)
+
+var ErrInvalidSBOM = util.NewInvalidArgumentErrorf("SBOM is invalid or has an unsupported format")
+
+// SBOM is the normalized representation of a SPDX or CycloneDX document
+type SBOM struct {
+	Format      string      `json:"format"`
+	SpecVersion string      `json:"spec_version"`
+	Name        string      `json:"name,omitempty"`
+	Components  []Component `json:"components"`
+}
+
+// Component is a dependency listed in the SBOM
+type Component struct {
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"`
+	PURL    string `json:"purl,omitempty"`
+	License string `json:"license,omitempty"`
+}
+
+type spdxDocument struct {
+	SpdxVersion       string   `json:"spdxVersion"`
+	Name              string   `json:"name"`
+	DocumentDescribes []string `json:"documentDescribes"`
+	Packages          []struct {
+		SPDXID           string `json:"SPDXID"`
+		Name             string `json:"name"`
+		VersionInfo      string `json:"versionInfo"`
+		LicenseConcluded string `json:"licenseConcluded"`
+		LicenseDeclared  string `json:"licenseDeclared"`
+		ExternalRefs     []struct {
+			ReferenceType    string `json:"referenceType"`
+			ReferenceLocator string `json:"referenceLocator"`
+		} `json:"externalRefs"`
+	} `json:"packages"`
+	Relationships []struct {
+		SpdxElementID      string `json:"spdxElementId"`
+		RelationshipType   string `json:"relationshipType"`
+		RelatedSpdxElement string `json:"relatedSpdxElement"`
+	} `json:"relationships"`
+}
+
+type cycloneDXJSONComponent struct {
+	Group    string `json:"group"`
+	Name     string `json:"name"`
+	Version  string `json:"version"`
+	PURL     string `json:"purl"`
+	Licenses []struct {
+		License struct {
+			ID   string `json:"id"`
+			Name string `json:"name"`
+		} `json:"license"`
+		Expression string `json:"expression"`
+	} `json:"licenses"`
+	Components []*cycloneDXJSONComponent `json:"components"`
+}
+
+type cycloneDXJSONDocument struct {
+	BomFormat   string `json:"bomFormat"`
+	SpecVersion string `json:"specVersion"`
+	Metadata    struct {
+		Component *cycloneDXJSONComponent `json:"component"`
+	} `json:"metadata"`
+	Components []*cycloneDXJSONComponent `json:"components"`
+}
+
+type cycloneDXXMLComponent struct {
+	Group    string `xml:"group"`
+	Name     string `xml:"name"`
+	Version  string `xml:"version"`
+	PURL     string `xml:"purl"`
+	Licenses struct {
+		License []struct {
+			ID   string `xml:"id"`
+			Name string `xml:"name"`
+		} `xml:"license"`
+		Expression string `xml:"expression"`
+	} `xml:"licenses"`
+	Components []*cycloneDXXMLComponent `xml:"components>component"`
+}
+
+type cycloneDXXMLDocument struct {
+	XMLName  xml.Name `xml:"bom"`
+	Metadata struct {
+		Component *cycloneDXXMLComponent `xml:"component"`
+	} `xml:"metadata"`
+	Components []*cycloneDXXMLComponent `xml:"components>component"`
+}
+
+// ParseSBOM parses a SPDX (JSON) or CycloneDX (JSON or XML) document
+func ParseSBOM(data []byte) (*SBOM, error) {
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) == 0 {
+		return nil, ErrInvalidSBOM
+	}
+
+	if trimmed[0] == '<' {
+		return parseCycloneDXXML(trimmed)
+	}
+
+	var probe struct {
+		SpdxVersion string `json:"spdxVersion"`
+		BomFormat   string `json:"bomFormat"`
+	}
+	if err := json.Unmarshal(trimmed, &probe); err != nil {
+		return nil, ErrInvalidSBOM
+	}
+
+	switch {
+	case probe.SpdxVersion != "":
+		return parseSPDX(trimmed)
+	case probe.BomFormat == "CycloneDX":
+		return parseCycloneDXJSON(trimmed)
+	}
+	return nil, ErrInvalidSBOM
+}
+
+func parseSPDX(data []byte) (*SBOM, error) {
+	var doc spdxDocument
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, ErrInvalidSBOM
+	}
+
+	// the described packages are the subject of the document and not one of its dependencies
+	described := make(map[string]bool)
+	for _, id := range doc.DocumentDescribes {
+		described[id] = true
+	}
+	for _, r := range doc.Relationships {
+		if r.SpdxElementID == "SPDXRef-DOCUMENT" && r.RelationshipType == "DESCRIBES" {
+			described[r.RelatedSpdxElement] = true
+		}
+	}
+
+	sbom := &SBOM{
+		Format:      FormatSPDX,
+		SpecVersion: strings.TrimPrefix(doc.SpdxVersion, "SPDX-"),
+		Name:        doc.Name,
+		Components:  make([]Component, 0, len(doc.Packages)),
+	}
+	for _, p := range doc.Packages {
+		if described[p.SPDXID] || p.Name == "" {
+			continue
+		}
+
+		c := Component{
+			Name:    p.Name,
+			Version: p.VersionInfo,
+			License: spdxLicense(p.LicenseConcluded),
+		}
+		if c.License == "" {
+			c.License = spdxLicense(p.LicenseDeclared)
+		}
+		for _, ref := range p.ExternalRefs {
+			if ref.ReferenceType == "purl" {
+				c.PURL = ref.ReferenceLocator
+				break
+			}
+		}
+		sbom.Components = append(sbom.Components, c)
+	}
+	return sbom, nil
+}
+
+func spdxLicense(s string) string {
+	if s == "NOASSERTION" || s == "NONE" {
+		return ""
+	}
+	return s
+}
+
+func parseCycloneDXJSON(data []byte) (*SBOM, error) {
+	var doc cycloneDXJSONDocument
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, ErrInvalidSBOM
+	}
+
+	sbom := &SBOM{
+		Format:      FormatCycloneDX,
+		SpecVersion: doc.SpecVersion,
+		Components:  make([]Component, 0, len(doc.Components)),
+	}
+	if doc.Metadata.Component != nil {
+		sbom.Name = cycloneDXName(doc.Metadata.Component.Group, doc.Metadata.Component.Name)
+	}
+
+	var flatten func([]*cycloneDXJSONComponent)
+	flatten = func(cs []*cycloneDXJSONComponent) {
+		for _, c := range cs {
+			if c.Name != "" {
+				licenses := make([]string, 0, len(c.Licenses))
+				for _, l := range c.Licenses {
+					licenses = appendCycloneDXLicense(licenses, l.License.ID, l.License.Name, l.Expression)
+				}
+				sbom.Components = append(sbom.Components, Component{
+					Name:    cycloneDXName(c.Group, c.Name),
+					Version: c.Version,
+					PURL:    c.PURL,
+					License: strings.Join(licenses, " AND "),
+				})
+			}
+			flatten(c.Components)
+		}
+	}
+	flatten(doc.Components)
+
+	return sbom, nil
+}
+
+func parseCycloneDXXML(data []byte) (*SBOM, error) {
+	var doc cycloneDXXMLDocument
+	if err := xml.Unmarshal(data, &doc); err != nil {
+		return nil, ErrInvalidSBOM
+	}
+	specVersion, ok := strings.CutPrefix(doc.XMLName.Space, "http://cyclonedx.org/schema/bom/")
+	if !ok {
+		return nil, ErrInvalidSBOM
+	}
+
+	sbom := &SBOM{
+		Format:      FormatCycloneDX,
+		SpecVersion: specVersion,
+		Components:  make([]Component, 0, len(doc.Components)),
+	}
+	if doc.Metadata.Component != nil {
+		sbom.Name = cycloneDXName(doc.Metadata.Component.Group, doc.Metadata.Component.Name)
+	}
+
+	var flatten func([]*cycloneDXXMLComponent)
+	flatten = func(cs []*cycloneDXXMLComponent) {
+		for _, c := range cs {
+			if c.Name != "" {
+				licenses := make([]string, 0, len(c.Licenses.License)+1)
+				for _, l := range c.Licenses.License {
+					licenses = appendCycloneDXLicense(licenses, l.ID, l.Name, "")
+				}
+				licenses = appendCycloneDXLicense(licenses, "", "", c.Licenses.Expression)
+				sbom.Components = append(sbom.Components, Component{
+					Name:    cycloneDXName(c.Group, c.Name),
+					Version: c.Version,
+					PURL:    c.PURL,
+					License: strings.Join(licenses, " AND "),
+				})
+			}
+			flatten(c.Components)
+		}
+	}
+	flatten(doc.Components)
+
+	return sbom, nil
+}
+
+func appendCycloneDXLicense(licenses []string, id, name, expression string) []string {
+	switch {
+	case expression != "":
+		return append(licenses, expression)
+	case id != "":
+		return append(licenses, id)
+	case name != "":
+		return append(licenses, name)
+	}
+	return licenses
+}
+
+func cycloneDXName(group, name string) string {
+	if group == "" {
+		return name
+	}
+	return group + "/" + name
+}
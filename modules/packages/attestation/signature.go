@@ -0,0 +1,70 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package attestation
+
+import (
+	"bytes"
+	"encoding/pem"
+
+	"gitea.dev/modules/util"
+
+	"github.com/42wim/sshsig"
+	"golang.org/x/crypto/ssh"
+)
+
+const (
+	FormatGPG       = "gpg"
+	FormatSSH       = "ssh"
+	FormatInToto    = "in-toto"
+	FormatSPDX      = "spdx"
+	FormatCycloneDX = "cyclonedx"
+
+	// SSHNamespace is the namespace used by "ssh-keygen -Y sign" if none is specified
+	SSHNamespace = "file"
+)
+
+var ErrInvalidSignature = util.NewInvalidArgumentErrorf("signature is invalid")
+
+const (
+	pgpArmorHeader = "-----BEGIN PGP SIGNATURE-----"
+	sshArmorHeader = "-----BEGIN SSH SIGNATURE-----"
+	sshPemType     = "SSH SIGNATURE"
+)
+
+// DetectSignatureFormat detects if the data is an (armored) OpenPGP or SSH signature
+func DetectSignatureFormat(data []byte) (string, error) {
+	trimmed := bytes.TrimSpace(data)
+	switch {
+	case bytes.HasPrefix(trimmed, []byte(pgpArmorHeader)):
+		return FormatGPG, nil
+	case bytes.HasPrefix(trimmed, []byte(sshArmorHeader)):
+		return FormatSSH, nil
+	case len(data) > 0 && data[0]&0x80 != 0:
+		// binary OpenPGP packets always have the high bit of the tag byte set
+		return FormatGPG, nil
+	}
+	return "", ErrInvalidSignature
+}
+
+// ParseSSHSignature extracts the public key and the namespace of an armored SSH signature
+func ParseSSHSignature(data []byte) (ssh.PublicKey, string, error) {
+	block, _ := pem.Decode(bytes.TrimSpace(data))
+	if block == nil || block.Type != sshPemType {
+		return nil, "", ErrInvalidSignature
+	}
+
+	var sig sshsig.WrappedSig
+	if err := ssh.Unmarshal(block.Bytes, &sig); err != nil {
+		return nil, "", ErrInvalidSignature
+	}
+	if string(sig.MagicHeader[:]) != "SSHSIG" || sig.Version != 1 {
+		return nil, "", ErrInvalidSignature
+	}
+
+	pk, err := ssh.ParsePublicKey([]byte(sig.PublicKey))
+	if err != nil {
+		return nil, "", ErrInvalidSignature
+	}
+	return pk, sig.Namespace, nil
+}
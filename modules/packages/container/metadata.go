@@ -21,6 +21,7 @@ const (
 	PropertyMediaType         = "container.mediatype"
 	PropertyManifestTagged    = "container.manifest.tagged"
 	PropertyManifestReference = "container.manifest.reference"
+	PropertyManifestSubject   = "container.manifest.subject"
 
 	DefaultPlatform = "linux/amd64"
 
@@ -35,8 +36,9 @@ const (
 type ImageType string
 
 const (
-	TypeOCI  ImageType = "oci"
-	TypeHelm ImageType = "helm"
+	TypeOCI      ImageType = "oci"
+	TypeHelm     ImageType = "helm"
+	TypeArtifact ImageType = "artifact"
 )
 
 // Name gets the name of the image type
@@ -44,6 +46,8 @@ func (it ImageType) Name() string {
 	switch it {
 	case TypeHelm:
 		return "Helm Chart"
+	case TypeArtifact:
+		return "OCI Artifact"
 	default:
 		return "OCI / Docker"
 	}
@@ -63,6 +67,9 @@ type Metadata struct {
 	Labels           map[string]string `json:"labels,omitempty"`
 	ImageLayers      []string          `json:"layer_creation,omitempty"`
 	Manifests        []*Manifest       `json:"manifests,omitempty"`
+	ArtifactType     string            `json:"artifact_type,omitempty"`
+	Subject          string            `json:"subject,omitempty"`
+	Annotations      map[string]string `json:"annotations,omitempty"`
 }
 
 type Manifest struct {
@@ -83,6 +90,23 @@ func IsMediaTypeImageIndex(mt string) bool {
 	return strings.EqualFold(mt, oci.MediaTypeImageIndex) || strings.EqualFold(mt, "application/vnd.docker.distribution.manifest.list.v2+json")
 }
 
+// IsMediaTypeImageConfig checks if the media type is the config of a runnable image
+func IsMediaTypeImageConfig(mt string) bool {
+	return strings.EqualFold(mt, oci.MediaTypeImageConfig) || strings.EqualFold(mt, "application/vnd.docker.container.image.v1+json")
+}
+
+// GetArtifactType returns the artifact type of a manifest or an empty string if the manifest describes an image.
+// https://github.com/opencontainers/image-spec/blob/main/manifest.md#guidelines-for-artifact-usage
+func GetArtifactType(artifactType, configMediaType string) string {
+	if artifactType != "" {
+		return artifactType
+	}
+	if configMediaType == "" || IsMediaTypeImageConfig(configMediaType) || strings.EqualFold(configMediaType, helm.ConfigMediaType) {
+		return ""
+	}
+	return configMediaType
+}
+
 // ParseImageConfig parses the metadata of an image config
 func ParseImageConfig(mediaType string, r io.Reader) (*Metadata, error) {
 	if strings.EqualFold(mediaType, helm.ConfigMediaType) {
@@ -66,3 +66,12 @@ func TestParseImageConfig(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, &Metadata{Platform: "unknown/unknown"}, metadata)
 }
+
+func TestGetArtifactType(t *testing.T) {
+	assert.Empty(t, GetArtifactType("", ""))
+	assert.Empty(t, GetArtifactType("", oci.MediaTypeImageConfig))
+	assert.Empty(t, GetArtifactType("", "application/vnd.docker.container.image.v1+json"))
+	assert.Empty(t, GetArtifactType("", helm.ConfigMediaType))
+	assert.Equal(t, "application/vnd.example.sbom", GetArtifactType("application/vnd.example.sbom", oci.MediaTypeEmptyJSON))
+	assert.Equal(t, "application/vnd.cncf.notary.signature", GetArtifactType("", "application/vnd.cncf.notary.signature"))
+}
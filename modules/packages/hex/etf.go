@@ -0,0 +1,332 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package hex
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"sort"
+
+	"gitea.dev/modules/util"
+)
+
+// ContentTypeErlang is the media type of the Erlang external term format used by the Hex API
+const ContentTypeErlang = "application/vnd.hex+erlang"
+
+// https://www.erlang.org/doc/apps/erts/erl_ext_dist.html
+const (
+	etfVersion        = 131
+	etfNewFloat       = 70
+	etfSmallInteger   = 97
+	etfInteger        = 98
+	etfAtom           = 100
+	etfSmallTuple     = 104
+	etfLargeTuple     = 105
+	etfNil            = 106
+	etfString         = 107
+	etfList           = 108
+	etfBinary         = 109
+	etfSmallBig       = 110
+	etfSmallAtom      = 115
+	etfMap            = 116
+	etfAtomUTF8       = 118
+	etfSmallAtomUTF8  = 119
+	maxETFNestedLevel = 64
+)
+
+// ErrInvalidExternalTerm indicates data which is not in the supported subset of the Erlang external term format
+var ErrInvalidExternalTerm = util.NewInvalidArgumentErrorf("Erlang external term is invalid")
+
+// EncodeExternalTerm encodes the value in the Erlang external term format.
+// Maps get binary keys, strings become binaries and nil, true and false become atoms like Elixir expects them.
+func EncodeExternalTerm(v any) ([]byte, error) {
+	return appendExternalTerm([]byte{etfVersion}, v)
+}
+
+func appendExternalTerm(b []byte, v any) ([]byte, error) {
+	var err error
+	switch t := v.(type) {
+	case nil:
+		b = appendAtom(b, "nil")
+	case bool:
+		b = appendAtom(b, fmt.Sprint(t))
+	case Atom:
+		b = appendAtom(b, string(t))
+	case string:
+		b = append(b, etfBinary)
+		b = binary.BigEndian.AppendUint32(b, uint32(len(t)))
+		b = append(b, t...)
+	case int:
+		b = appendInteger(b, int64(t))
+	case int64:
+		b = appendInteger(b, t)
+	case []string:
+		list := make([]any, 0, len(t))
+		for _, s := range t {
+			list = append(list, s)
+		}
+		return appendExternalTerm(b, list)
+	case []any:
+		if len(t) > 0 {
+			b = append(b, etfList)
+			b = binary.BigEndian.AppendUint32(b, uint32(len(t)))
+			for _, e := range t {
+				if b, err = appendExternalTerm(b, e); err != nil {
+					return nil, err
+				}
+			}
+		}
+		b = append(b, etfNil)
+	case Tuple:
+		if len(t) > math.MaxUint8 {
+			return nil, ErrInvalidExternalTerm
+		}
+		b = append(b, etfSmallTuple, byte(len(t)))
+		for _, e := range t {
+			if b, err = appendExternalTerm(b, e); err != nil {
+				return nil, err
+			}
+		}
+	case map[string]any:
+		keys := make([]string, 0, len(t))
+		for k := range t {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		b = append(b, etfMap)
+		b = binary.BigEndian.AppendUint32(b, uint32(len(t)))
+		for _, k := range keys {
+			if b, err = appendExternalTerm(b, k); err != nil {
+				return nil, err
+			}
+			if b, err = appendExternalTerm(b, t[k]); err != nil {
+				return nil, err
+			}
+		}
+	default:
+		return nil, fmt.Errorf("unsupported type %T: %w", v, ErrInvalidExternalTerm)
+	}
+	return b, nil
+}
+
+func appendAtom(b []byte, name string) []byte {
+	b = append(b, etfSmallAtomUTF8, byte(len(name)))
+	return append(b, name...)
+}
+
+func appendInteger(b []byte, i int64) []byte {
+	if i >= 0 && i <= math.MaxUint8 {
+		return append(b, etfSmallInteger, byte(i))
+	}
+	if i >= math.MinInt32 && i <= math.MaxInt32 {
+		b = append(b, etfInteger)
+		return binary.BigEndian.AppendUint32(b, uint32(int32(i)))
+	}
+
+	sign := byte(0)
+	u := uint64(i)
+	if i < 0 {
+		sign = 1
+		u = uint64(-i)
+	}
+	digits := make([]byte, 0, 8)
+	for u > 0 {
+		digits = append(digits, byte(u))
+		u >>= 8
+	}
+	b = append(b, etfSmallBig, byte(len(digits)), sign)
+	return append(b, digits...)
+}
+
+// DecodeExternalTerm decodes data in the Erlang external term format.
+// Binaries are returned as string, atoms as Atom, lists as []any, tuples as Tuple and maps as map[string]any.
+func DecodeExternalTerm(data []byte) (any, error) {
+	if len(data) == 0 || data[0] != etfVersion {
+		return nil, ErrInvalidExternalTerm
+	}
+	d := &etfDecoder{data: data[1:]}
+	v, err := d.decode(0)
+	if err != nil {
+		return nil, err
+	}
+	if len(d.data) != 0 {
+		return nil, ErrInvalidExternalTerm
+	}
+	return v, nil
+}
+
+type etfDecoder struct {
+	data []byte
+}
+
+func (d *etfDecoder) read(n int) ([]byte, error) {
+	if n < 0 || len(d.data) < n {
+		return nil, ErrInvalidExternalTerm
+	}
+	b := d.data[:n]
+	d.data = d.data[n:]
+	return b, nil
+}
+
+func (d *etfDecoder) readUint(n int) (uint64, error) {
+	b, err := d.read(n)
+	if err != nil {
+		return 0, err
+	}
+	var u uint64
+	for _, c := range b {
+		u = u<<8 | uint64(c)
+	}
+	return u, nil
+}
+
+func (d *etfDecoder) decode(level int) (any, error) {
+	if level > maxETFNestedLevel {
+		return nil, ErrInvalidExternalTerm
+	}
+
+	tag, err := d.readUint(1)
+	if err != nil {
+		return nil, err
+	}
+
+	switch tag {
+	case etfSmallInteger:
+		i, err := d.readUint(1)
+		return int64(i), err
+	case etfInteger:
+		i, err := d.readUint(4)
+		return int64(int32(uint32(i))), err
+	case etfSmallBig:
+		n, err := d.readUint(1)
+		if err != nil {
+			return nil, err
+		}
+		sign, err := d.readUint(1)
+		if err != nil {
+			return nil, err
+		}
+		digits, err := d.read(int(n))
+		if err != nil || n > 8 {
+			return nil, ErrInvalidExternalTerm
+		}
+		var u uint64
+		for i := len(digits) - 1; i >= 0; i-- {
+			u = u<<8 | uint64(digits[i])
+		}
+		if u > math.MaxInt64 {
+			return nil, ErrInvalidExternalTerm
+		}
+		if sign != 0 {
+			return -int64(u), nil
+		}
+		return int64(u), nil
+	case etfNewFloat:
+		u, err := d.readUint(8)
+		return math.Float64frombits(u), err
+	case etfAtom, etfAtomUTF8:
+		n, err := d.readUint(2)
+		if err != nil {
+			return nil, err
+		}
+		b, err := d.read(int(n))
+		return Atom(b), err
+	case etfSmallAtom, etfSmallAtomUTF8:
+		n, err := d.readUint(1)
+		if err != nil {
+			return nil, err
+		}
+		b, err := d.read(int(n))
+		return Atom(b), err
+	case etfBinary:
+		n, err := d.readUint(4)
+		if err != nil {
+			return nil, err
+		}
+		b, err := d.read(int(n))
+		return string(b), err
+	case etfString:
+		n, err := d.readUint(2)
+		if err != nil {
+			return nil, err
+		}
+		b, err := d.read(int(n))
+		return string(b), err
+	case etfNil:
+		return []any{}, nil
+	case etfList:
+		n, err := d.readUint(4)
+		if err != nil {
+			return nil, err
+		}
+		if n > uint64(len(d.data)) {
+			return nil, ErrInvalidExternalTerm
+		}
+		list := make([]any, 0, n)
+		for range n {
+			e, err := d.decode(level + 1)
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, e)
+		}
+		// only proper lists are supported
+		if tail, err := d.readUint(1); err != nil || tail != etfNil {
+			return nil, ErrInvalidExternalTerm
+		}
+		return list, nil
+	case etfSmallTuple, etfLargeTuple:
+		size := 1
+		if tag == etfLargeTuple {
+			size = 4
+		}
+		n, err := d.readUint(size)
+		if err != nil {
+			return nil, err
+		}
+		if n > uint64(len(d.data)) {
+			return nil, ErrInvalidExternalTerm
+		}
+		tuple := make(Tuple, 0, n)
+		for range n {
+			e, err := d.decode(level + 1)
+			if err != nil {
+				return nil, err
+			}
+			tuple = append(tuple, e)
+		}
+		return tuple, nil
+	case etfMap:
+		n, err := d.readUint(4)
+		if err != nil {
+			return nil, err
+		}
+		if n > uint64(len(d.data)) {
+			return nil, ErrInvalidExternalTerm
+		}
+		m := make(map[string]any, n)
+		for range n {
+			k, err := d.decode(level + 1)
+			if err != nil {
+				return nil, err
+			}
+			v, err := d.decode(level + 1)
+			if err != nil {
+				return nil, err
+			}
+			switch key := k.(type) {
+			case string:
+				m[key] = v
+			case Atom:
+				m[string(key)] = v
+			default:
+				return nil, ErrInvalidExternalTerm
+			}
+		}
+		return m, nil
+	}
+	return nil, ErrInvalidExternalTerm
+}
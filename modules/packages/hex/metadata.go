@@ -0,0 +1,297 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package hex
+
+import (
+	"archive/tar"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gitea.dev/modules/util"
+	"gitea.dev/modules/validation"
+
+	"github.com/hashicorp/go-version"
+)
+
+var (
+	ErrInvalidTarball       = util.NewInvalidArgumentErrorf("package tarball is invalid")
+	ErrUnsupportedVersion   = util.NewInvalidArgumentErrorf("package tarball version is not supported")
+	ErrChecksumMismatch     = util.NewInvalidArgumentErrorf("package tarball checksum does not match")
+	ErrMissingMetadataFile  = util.NewInvalidArgumentErrorf("metadata.config file is missing")
+	ErrMetadataFileTooLarge = util.NewInvalidArgumentErrorf("metadata.config file is too large")
+	ErrInvalidName          = util.NewInvalidArgumentErrorf("package name is invalid")
+	ErrInvalidVersion       = util.NewInvalidArgumentErrorf("package version is invalid")
+)
+
+const (
+	PropertyRetirement = "hex.retirement"
+
+	SettingKeyPrivate = "hex.key.private"
+	SettingKeyPublic  = "hex.key.public"
+)
+
+// https://github.com/hexpm/specifications/blob/main/package_tarball.md
+const (
+	tarballVersion      = "3"
+	fileVersion         = "VERSION"
+	fileChecksum        = "CHECKSUM"
+	fileMetadata        = "metadata.config"
+	fileContents        = "contents.tar.gz"
+	maxMetadataFileSize = 128 * 1024
+)
+
+var namePattern = regexp.MustCompile(`\A[a-z][a-z0-9_]*\z`)
+
+// Package represents a Hex package
+type Package struct {
+	Name     string
+	Version  string
+	Metadata *Metadata
+}
+
+// Metadata represents the metadata of a Hex package
+type Metadata struct {
+	App           string            `json:"app,omitempty"`
+	Description   string            `json:"description,omitempty"`
+	Licenses      []string          `json:"licenses,omitempty"`
+	Links         map[string]string `json:"links,omitempty"`
+	BuildTools    []string          `json:"build_tools,omitempty"`
+	Elixir        string            `json:"elixir,omitempty"`
+	Requirements  []*Requirement    `json:"requirements,omitempty"`
+	InnerChecksum string            `json:"inner_checksum"`
+}
+
+// Requirement represents a dependency of a Hex package
+type Requirement struct {
+	Name        string `json:"name"`
+	App         string `json:"app,omitempty"`
+	Requirement string `json:"requirement"`
+	Optional    bool   `json:"optional,omitempty"`
+	Repository  string `json:"repository,omitempty"`
+}
+
+// ParsePackage parses the Hex package tarball.
+// The inner checksum covers the VERSION, metadata.config and contents.tar.gz files in this order.
+func ParsePackage(r io.Reader) (*Package, error) {
+	var versionData, metadataData []byte
+	var checksum string
+	var innerChecksum []byte
+
+	tr := tar.NewReader(r)
+	for {
+		hd, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, ErrInvalidTarball
+		}
+
+		if hd.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		switch hd.Name {
+		case fileVersion:
+			versionData, err = util.ReadWithLimit(tr, 16)
+			if err != nil {
+				return nil, err
+			}
+		case fileChecksum:
+			data, err := util.ReadWithLimit(tr, 128)
+			if err != nil {
+				return nil, err
+			}
+			checksum = strings.TrimSpace(string(data))
+		case fileMetadata:
+			if hd.Size > maxMetadataFileSize {
+				return nil, ErrMetadataFileTooLarge
+			}
+			metadataData, err = util.ReadWithLimit(tr, maxMetadataFileSize)
+			if err != nil {
+				return nil, err
+			}
+		case fileContents:
+			// contents.tar.gz is the last file, the previous files are needed to calculate the checksum
+			if versionData == nil || metadataData == nil {
+				return nil, ErrInvalidTarball
+			}
+			h := sha256.New()
+			h.Write(versionData)
+			h.Write(metadataData)
+			if _, err := io.Copy(h, tr); err != nil {
+				return nil, err
+			}
+			innerChecksum = h.Sum(nil)
+		}
+	}
+
+	if versionData == nil {
+		return nil, ErrInvalidTarball
+	}
+	if string(versionData) != tarballVersion {
+		return nil, ErrUnsupportedVersion
+	}
+	if metadataData == nil {
+		return nil, ErrMissingMetadataFile
+	}
+	if innerChecksum == nil {
+		return nil, ErrInvalidTarball
+	}
+	if checksum != "" && !strings.EqualFold(checksum, hex.EncodeToString(innerChecksum)) {
+		return nil, ErrChecksumMismatch
+	}
+
+	p, err := ParseMetadataConfig(string(metadataData))
+	if err != nil {
+		return nil, err
+	}
+	p.Metadata.InnerChecksum = hex.EncodeToString(innerChecksum)
+	return p, nil
+}
+
+// ParseMetadataConfig parses the metadata.config file of a Hex package
+// https://github.com/hexpm/specifications/blob/main/package_metadata.md
+func ParseMetadataConfig(data string) (*Package, error) {
+	terms, err := ParseTerms(data)
+	if err != nil {
+		return nil, err
+	}
+
+	config := make(map[string]any, len(terms))
+	for _, term := range terms {
+		t, ok := term.(Tuple)
+		if !ok || len(t) != 2 {
+			return nil, ErrInvalidTerm
+		}
+		key, ok := t[0].(string)
+		if !ok {
+			return nil, ErrInvalidTerm
+		}
+		config[key] = t[1]
+	}
+
+	name, _ := config["name"].(string)
+	if !namePattern.MatchString(name) {
+		return nil, ErrInvalidName
+	}
+
+	versionString, _ := config["version"].(string)
+	v, err := version.NewSemver(versionString)
+	if err != nil {
+		return nil, ErrInvalidVersion
+	}
+
+	requirements, err := parseRequirements(config["requirements"])
+	if err != nil {
+		return nil, err
+	}
+
+	links := make(map[string]string)
+	for _, link := range toList(config["links"]) {
+		if t, ok := link.(Tuple); ok && len(t) == 2 {
+			linkName, _ := t[0].(string)
+			linkURL, _ := t[1].(string)
+			if linkName != "" && validation.IsValidURL(linkURL) {
+				links[linkName] = linkURL
+			}
+		}
+	}
+
+	app, _ := config["app"].(string)
+	description, _ := config["description"].(string)
+	elixir, _ := config["elixir"].(string)
+
+	return &Package{
+		Name:    name,
+		Version: v.String(),
+		Metadata: &Metadata{
+			App:          app,
+			Description:  description,
+			Licenses:     toStrings(config["licenses"]),
+			Links:        links,
+			BuildTools:   toStrings(config["build_tools"]),
+			Elixir:       elixir,
+			Requirements: requirements,
+		},
+	}, nil
+}
+
+// parseRequirements parses the requirements which are either a list of property lists (current format)
+// or a list of {Name, PropertyList} tuples (legacy format)
+func parseRequirements(v any) ([]*Requirement, error) {
+	entries := toList(v)
+
+	requirements := make([]*Requirement, 0, len(entries))
+	for _, entry := range entries {
+		var name string
+		var props []any
+		switch e := entry.(type) {
+		case []any:
+			props = e
+		case Tuple:
+			if len(e) != 2 {
+				return nil, ErrInvalidTerm
+			}
+			name, _ = e[0].(string)
+			props = toList(e[1])
+		default:
+			return nil, ErrInvalidTerm
+		}
+
+		req := &Requirement{Name: name}
+		for _, prop := range props {
+			t, ok := prop.(Tuple)
+			if !ok || len(t) != 2 {
+				return nil, ErrInvalidTerm
+			}
+			key, _ := t[0].(string)
+			switch key {
+			case "name":
+				req.Name, _ = t[1].(string)
+			case "app":
+				req.App, _ = t[1].(string)
+			case "requirement":
+				req.Requirement, _ = t[1].(string)
+			case "optional":
+				req.Optional = t[1] == Atom("true")
+			case "repository":
+				req.Repository, _ = t[1].(string)
+			}
+		}
+		if !namePattern.MatchString(req.Name) {
+			return nil, ErrInvalidName
+		}
+		requirements = append(requirements, req)
+	}
+
+	sort.Slice(requirements, func(i, j int) bool {
+		return requirements[i].Name < requirements[j].Name
+	})
+
+	return requirements, nil
+}
+
+func toList(v any) []any {
+	l, _ := v.([]any)
+	return l
+}
+
+func toStrings(v any) []string {
+	list := toList(v)
+	if len(list) == 0 {
+		return nil
+	}
+	values := make([]string, 0, len(list))
+	for _, e := range list {
+		if s, ok := e.(string); ok {
+			values = append(values, s)
+		}
+	}
+	return values
+}
@@ -0,0 +1,183 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package hex
+
+import (
+	"archive/tar"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	packageName    = "gitea"
+	packageVersion = "1.0.1"
+	description    = "Package Description"
+	projectURL     = "https://gitea.com"
+)
+
+const metadataContent = `{<<"app">>,<<"gitea">>}.
+{<<"build_tools">>,[<<"mix">>]}.
+{<<"description">>,<<"` + description + `">>}.
+{<<"elixir">>,<<"~> 1.15">>}.
+{<<"licenses">>,[<<"MIT">>]}.
+{<<"links">>,[{<<"GitHub">>,<<"` + projectURL + `">>},{<<"Invalid">>,<<"no url">>}]}.
+{<<"name">>,<<"` + packageName + `">>}.
+{<<"requirements">>,
+ [[{<<"name">>,<<"plug">>},
+   {<<"app">>,<<"plug">>},
+   {<<"optional">>,true},
+   {<<"requirement">>,<<"~> 1.0">>},
+   {<<"repository">>,<<"hexpm">>}],
+  [{<<"name">>,<<"jason">>},
+   {<<"app">>,<<"jason">>},
+   {<<"optional">>,false},
+   {<<"requirement">>,<<">= 1.2.0">>},
+   {<<"repository">>,<<"hexpm">>}]]}.
+{<<"version">>,<<"` + packageVersion + `">>}.
+`
+
+func createArchive(files []string, contents map[string][]byte) io.Reader {
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	for _, filename := range files {
+		content := contents[filename]
+		tw.WriteHeader(&tar.Header{
+			Name: filename,
+			Mode: 0o600,
+			Size: int64(len(content)),
+		})
+		tw.Write(content)
+	}
+	tw.Close()
+	return &buf
+}
+
+func TestParsePackage(t *testing.T) {
+	contents := []byte("dummy")
+
+	h := sha256.New()
+	h.Write([]byte("3"))
+	h.Write([]byte(metadataContent))
+	h.Write(contents)
+	checksum := hex.EncodeToString(h.Sum(nil))
+
+	files := []string{fileVersion, fileChecksum, fileMetadata, fileContents}
+
+	t.Run("InvalidTarball", func(t *testing.T) {
+		p, err := ParsePackage(strings.NewReader("invalid"))
+		assert.Nil(t, p)
+		assert.ErrorIs(t, err, ErrInvalidTarball)
+	})
+
+	t.Run("UnsupportedVersion", func(t *testing.T) {
+		p, err := ParsePackage(createArchive(files, map[string][]byte{
+			fileVersion:  []byte("2"),
+			fileMetadata: []byte(metadataContent),
+			fileContents: contents,
+		}))
+		assert.Nil(t, p)
+		assert.ErrorIs(t, err, ErrUnsupportedVersion)
+	})
+
+	t.Run("MissingMetadataFile", func(t *testing.T) {
+		p, err := ParsePackage(createArchive([]string{fileVersion, fileContents}, map[string][]byte{
+			fileVersion:  []byte("3"),
+			fileContents: contents,
+		}))
+		assert.Nil(t, p)
+		assert.ErrorIs(t, err, ErrInvalidTarball)
+	})
+
+	t.Run("ChecksumMismatch", func(t *testing.T) {
+		p, err := ParsePackage(createArchive(files, map[string][]byte{
+			fileVersion:  []byte("3"),
+			fileChecksum: []byte(strings.Repeat("0", 64)),
+			fileMetadata: []byte(metadataContent),
+			fileContents: contents,
+		}))
+		assert.Nil(t, p)
+		assert.ErrorIs(t, err, ErrChecksumMismatch)
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		p, err := ParsePackage(createArchive(files, map[string][]byte{
+			fileVersion:  []byte("3"),
+			fileChecksum: []byte(strings.ToUpper(checksum)),
+			fileMetadata: []byte(metadataContent),
+			fileContents: contents,
+		}))
+		require.NoError(t, err)
+		require.NotNil(t, p)
+
+		assert.Equal(t, packageName, p.Name)
+		assert.Equal(t, packageVersion, p.Version)
+		assert.Equal(t, checksum, p.Metadata.InnerChecksum)
+	})
+}
+
+func TestParseMetadataConfig(t *testing.T) {
+	t.Run("InvalidName", func(t *testing.T) {
+		for _, name := range []string{"", "Gitea", "1gitea", "gi-tea"} {
+			p, err := ParseMetadataConfig(`{<<"name">>,<<"` + name + `">>}. {<<"version">>,<<"1.0.0">>}.`)
+			assert.Nil(t, p)
+			assert.ErrorIs(t, err, ErrInvalidName)
+		}
+	})
+
+	t.Run("InvalidVersion", func(t *testing.T) {
+		p, err := ParseMetadataConfig(`{<<"name">>,<<"gitea">>}. {<<"version">>,<<"1.a">>}.`)
+		assert.Nil(t, p)
+		assert.ErrorIs(t, err, ErrInvalidVersion)
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		p, err := ParseMetadataConfig(metadataContent)
+		require.NoError(t, err)
+		require.NotNil(t, p)
+
+		assert.Equal(t, packageName, p.Name)
+		assert.Equal(t, packageVersion, p.Version)
+		assert.Equal(t, "gitea", p.Metadata.App)
+		assert.Equal(t, description, p.Metadata.Description)
+		assert.Equal(t, "~> 1.15", p.Metadata.Elixir)
+		assert.Equal(t, []string{"MIT"}, p.Metadata.Licenses)
+		assert.Equal(t, []string{"mix"}, p.Metadata.BuildTools)
+		assert.Equal(t, map[string]string{"GitHub": projectURL}, p.Metadata.Links)
+		assert.Equal(t, []*Requirement{
+			{Name: "jason", App: "jason", Requirement: ">= 1.2.0", Repository: "hexpm"},
+			{Name: "plug", App: "plug", Requirement: "~> 1.0", Optional: true, Repository: "hexpm"},
+		}, p.Metadata.Requirements)
+	})
+
+	t.Run("LegacyRequirements", func(t *testing.T) {
+		p, err := ParseMetadataConfig(`{<<"name">>,<<"gitea">>}.
+{<<"version">>,<<"1.0.0">>}.
+{<<"requirements">>,[{<<"plug">>,[{<<"app">>,<<"plug">>},{<<"optional">>,false},{<<"requirement">>,<<"~> 1.0">>}]}]}.`)
+		require.NoError(t, err)
+		assert.Equal(t, []*Requirement{
+			{Name: "plug", App: "plug", Requirement: "~> 1.0"},
+		}, p.Metadata.Requirements)
+	})
+}
+
+func TestParseTerms(t *testing.T) {
+	terms, err := ParseTerms(`% comment
+{<<"a\"b"/utf8>>, 'quoted atom', atom, [1, -2, 3.5], "str\n", <<104,105>>, {}}.`)
+	require.NoError(t, err)
+	assert.Equal(t, []any{
+		Tuple{"a\"b", Atom("quoted atom"), Atom("atom"), []any{int64(1), int64(-2), 3.5}, "str\n", "hi", Tuple{}},
+	}, terms)
+
+	for _, invalid := range []string{`{<<"a">>}`, `{<<"a">>,}.`, `[1 2].`, `"unterminated.`} {
+		_, err := ParseTerms(invalid)
+		assert.ErrorIs(t, err, ErrInvalidTerm, invalid)
+	}
+}
@@ -0,0 +1,205 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package hex
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha512"
+	"encoding/hex"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// The registry resources are protobuf messages which are signed by the repository and gzipped
+// https://github.com/hexpm/specifications/blob/main/registry-v2.md
+
+// RetirementReason is the reason why a release was retired
+type RetirementReason int
+
+// The values match the RetirementReason enum of the registry specification
+const (
+	RetirementReasonOther RetirementReason = iota
+	RetirementReasonInvalid
+	RetirementReasonSecurity
+	RetirementReasonDeprecated
+	RetirementReasonRenamed
+)
+
+var retirementReasons = []string{"other", "invalid", "security", "deprecated", "renamed"}
+
+// ParseRetirementReason converts the reason used by the Hex API to a RetirementReason
+func ParseRetirementReason(s string) (RetirementReason, bool) {
+	for i, r := range retirementReasons {
+		if r == s {
+			return RetirementReason(i), true
+		}
+	}
+	return RetirementReasonOther, false
+}
+
+func (r RetirementReason) String() string {
+	if r < 0 || int(r) >= len(retirementReasons) {
+		return retirementReasons[RetirementReasonOther]
+	}
+	return retirementReasons[r]
+}
+
+// NameEntry is a package of the names resource
+type NameEntry struct {
+	Name    string
+	Updated time.Time
+}
+
+// VersionsEntry is a package of the versions resource
+type VersionsEntry struct {
+	Name     string
+	Versions []string
+	// Retired contains the indexes of the retired versions
+	Retired []int
+}
+
+// Retirement describes why a release was retired
+type Retirement struct {
+	Reason  RetirementReason `json:"reason"`
+	Message string           `json:"message,omitempty"`
+}
+
+// Release is a release of the package resource
+type Release struct {
+	Version       string
+	InnerChecksum string
+	OuterChecksum string
+	Dependencies  []*Requirement
+	Retired       *Retirement
+}
+
+// EncodeNames encodes the names resource
+func EncodeNames(repository string, entries []*NameEntry) []byte {
+	var b []byte
+	for _, e := range entries {
+		var timestamp []byte
+		timestamp = protowire.AppendTag(timestamp, 1, protowire.VarintType)
+		timestamp = protowire.AppendVarint(timestamp, uint64(e.Updated.Unix()))
+		if nanos := e.Updated.Nanosecond(); nanos != 0 {
+			timestamp = protowire.AppendTag(timestamp, 2, protowire.VarintType)
+			timestamp = protowire.AppendVarint(timestamp, uint64(nanos))
+		}
+
+		var pkg []byte
+		pkg = appendString(pkg, 1, e.Name)
+		pkg = appendBytes(pkg, 2, timestamp)
+
+		b = appendBytes(b, 1, pkg)
+	}
+	return appendString(b, 2, repository)
+}
+
+// EncodeVersions encodes the versions resource
+func EncodeVersions(repository string, entries []*VersionsEntry) []byte {
+	var b []byte
+	for _, e := range entries {
+		var pkg []byte
+		pkg = appendString(pkg, 1, e.Name)
+		for _, v := range e.Versions {
+			pkg = appendString(pkg, 2, v)
+		}
+		if len(e.Retired) > 0 {
+			var packed []byte
+			for _, i := range e.Retired {
+				packed = protowire.AppendVarint(packed, uint64(i))
+			}
+			pkg = appendBytes(pkg, 3, packed)
+		}
+
+		b = appendBytes(b, 1, pkg)
+	}
+	return appendString(b, 2, repository)
+}
+
+// EncodePackage encodes the package resource
+func EncodePackage(repository, name string, releases []*Release) ([]byte, error) {
+	var b []byte
+	for _, r := range releases {
+		innerChecksum, err := hex.DecodeString(r.InnerChecksum)
+		if err != nil {
+			return nil, err
+		}
+		outerChecksum, err := hex.DecodeString(r.OuterChecksum)
+		if err != nil {
+			return nil, err
+		}
+
+		var release []byte
+		release = appendString(release, 1, r.Version)
+		release = appendBytes(release, 2, innerChecksum)
+		for _, dep := range r.Dependencies {
+			var dependency []byte
+			dependency = appendString(dependency, 1, dep.Name)
+			dependency = appendString(dependency, 2, dep.Requirement)
+			if dep.Optional {
+				dependency = protowire.AppendTag(dependency, 3, protowire.VarintType)
+				dependency = protowire.AppendVarint(dependency, 1)
+			}
+			if dep.App != "" && dep.App != dep.Name {
+				dependency = appendString(dependency, 4, dep.App)
+			}
+			if dep.Repository != "" && dep.Repository != repository {
+				dependency = appendString(dependency, 5, dep.Repository)
+			}
+			release = appendBytes(release, 3, dependency)
+		}
+		if r.Retired != nil {
+			var retired []byte
+			retired = protowire.AppendTag(retired, 1, protowire.VarintType)
+			retired = protowire.AppendVarint(retired, uint64(r.Retired.Reason))
+			if r.Retired.Message != "" {
+				retired = appendString(retired, 2, r.Retired.Message)
+			}
+			release = appendBytes(release, 4, retired)
+		}
+		release = appendBytes(release, 5, outerChecksum)
+
+		b = appendBytes(b, 1, release)
+	}
+	b = appendString(b, 2, name)
+	return appendString(b, 3, repository), nil
+}
+
+// SignAndCompress wraps the payload in a Signed message and gzips the result
+func SignAndCompress(payload []byte, privateKey *rsa.PrivateKey) ([]byte, error) {
+	hash := sha512.Sum512(payload)
+	signature, err := rsa.SignPKCS1v15(rand.Reader, privateKey, crypto.SHA512, hash[:])
+	if err != nil {
+		return nil, err
+	}
+
+	var signed []byte
+	signed = appendBytes(signed, 1, payload)
+	signed = appendBytes(signed, 2, signature)
+
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(signed); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func appendString(b []byte, num protowire.Number, s string) []byte {
+	b = protowire.AppendTag(b, num, protowire.BytesType)
+	return protowire.AppendString(b, s)
+}
+
+func appendBytes(b []byte, num protowire.Number, v []byte) []byte {
+	b = protowire.AppendTag(b, num, protowire.BytesType)
+	return protowire.AppendBytes(b, v)
+}
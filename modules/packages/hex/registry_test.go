@@ -0,0 +1,176 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package hex
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha512"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+func TestExternalTerm(t *testing.T) {
+	value := map[string]any{
+		"message": "text",
+		"list":    []any{int64(1), int64(300), int64(-5), int64(1) << 40},
+		"tuple":   Tuple{Atom("ok"), "value"},
+		"empty":   []any{},
+		"strings": []string{"a", "b"},
+		"flag":    true,
+	}
+
+	data, err := EncodeExternalTerm(value)
+	require.NoError(t, err)
+
+	decoded, err := DecodeExternalTerm(data)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"message": "text",
+		"list":    []any{int64(1), int64(300), int64(-5), int64(1) << 40},
+		"tuple":   Tuple{Atom("ok"), "value"},
+		"empty":   []any{},
+		"strings": []any{"a", "b"},
+		"flag":    Atom("true"),
+	}, decoded)
+
+	for _, invalid := range [][]byte{nil, {1}, {etfVersion}, {etfVersion, etfBinary, 0, 0, 0, 5, 'a'}, {etfVersion, etfList, 0xff, 0xff, 0xff, 0xff}} {
+		_, err := DecodeExternalTerm(invalid)
+		assert.ErrorIs(t, err, ErrInvalidExternalTerm)
+	}
+}
+
+func TestRetirementReason(t *testing.T) {
+	r, ok := ParseRetirementReason("security")
+	assert.True(t, ok)
+	assert.Equal(t, RetirementReasonSecurity, r)
+	assert.Equal(t, "security", r.String())
+
+	r, ok = ParseRetirementReason("unknown")
+	assert.False(t, ok)
+	assert.Equal(t, RetirementReasonOther, r)
+}
+
+func TestRegistryResources(t *testing.T) {
+	t.Run("Names", func(t *testing.T) {
+		data := EncodeNames("owner", []*NameEntry{{Name: "gitea", Updated: time.Unix(1700000000, 0)}})
+
+		fields := consumeFields(t, data)
+		assert.Equal(t, "owner", string(fields[2][0]))
+		require.Len(t, fields[1], 1)
+		pkg := consumeFields(t, fields[1][0])
+		assert.Equal(t, "gitea", string(pkg[1][0]))
+		timestamp := consumeFields(t, pkg[2][0])
+		seconds, _ := protowire.ConsumeVarint(timestamp[1][0])
+		assert.EqualValues(t, 1700000000, seconds)
+	})
+
+	t.Run("Versions", func(t *testing.T) {
+		data := EncodeVersions("owner", []*VersionsEntry{{Name: "gitea", Versions: []string{"1.0.0", "1.1.0"}, Retired: []int{1}}})
+
+		fields := consumeFields(t, data)
+		pkg := consumeFields(t, fields[1][0])
+		assert.Equal(t, "gitea", string(pkg[1][0]))
+		require.Len(t, pkg[2], 2)
+		assert.Equal(t, "1.1.0", string(pkg[2][1]))
+		assert.Equal(t, []byte{1}, pkg[3][0])
+	})
+
+	t.Run("Package", func(t *testing.T) {
+		checksum := "6ed6a55d86bc4fcb2bf3c53bfe8a4d8bf54a3a15a8ac6a7ba5c0f3f7d6b3d2b1"
+
+		_, err := EncodePackage("owner", "gitea", []*Release{{Version: "1.0.0", InnerChecksum: "xyz", OuterChecksum: checksum}})
+		assert.Error(t, err)
+
+		data, err := EncodePackage("owner", "gitea", []*Release{
+			{
+				Version:       "1.0.0",
+				InnerChecksum: checksum,
+				OuterChecksum: checksum,
+				Dependencies: []*Requirement{
+					{Name: "plug", App: "plug", Requirement: "~> 1.0", Optional: true, Repository: "hexpm"},
+					{Name: "local", Requirement: "~> 2.0", Repository: "owner"},
+				},
+				Retired: &Retirement{Reason: RetirementReasonDeprecated, Message: "use 2.0"},
+			},
+		})
+		require.NoError(t, err)
+
+		fields := consumeFields(t, data)
+		assert.Equal(t, "gitea", string(fields[2][0]))
+		assert.Equal(t, "owner", string(fields[3][0]))
+
+		release := consumeFields(t, fields[1][0])
+		assert.Equal(t, "1.0.0", string(release[1][0]))
+		assert.Len(t, release[2][0], 32)
+		assert.Len(t, release[5][0], 32)
+		require.Len(t, release[3], 2)
+
+		dependency := consumeFields(t, release[3][0])
+		assert.Equal(t, "plug", string(dependency[1][0]))
+		assert.Equal(t, "hexpm", string(dependency[5][0]))
+		assert.NotContains(t, dependency, protowire.Number(4))
+
+		dependency = consumeFields(t, release[3][1])
+		assert.NotContains(t, dependency, protowire.Number(5))
+
+		retired := consumeFields(t, release[4][0])
+		assert.Equal(t, "use 2.0", string(retired[2][0]))
+	})
+
+	t.Run("Signed", func(t *testing.T) {
+		key, err := rsa.GenerateKey(rand.Reader, 2048)
+		require.NoError(t, err)
+
+		payload := EncodeNames("owner", nil)
+
+		data, err := SignAndCompress(payload, key)
+		require.NoError(t, err)
+
+		zr, err := gzip.NewReader(bytes.NewReader(data))
+		require.NoError(t, err)
+		signed, err := io.ReadAll(zr)
+		require.NoError(t, err)
+
+		fields := consumeFields(t, signed)
+		assert.Equal(t, payload, fields[1][0])
+
+		hash := sha512.Sum512(payload)
+		assert.NoError(t, rsa.VerifyPKCS1v15(&key.PublicKey, crypto.SHA512, hash[:], fields[2][0]))
+	})
+}
+
+// consumeFields returns the raw values of all fields grouped by field number
+func consumeFields(t *testing.T, b []byte) map[protowire.Number][][]byte {
+	fields := make(map[protowire.Number][][]byte)
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		require.GreaterOrEqual(t, n, 0)
+		b = b[n:]
+
+		var value []byte
+		switch typ {
+		case protowire.BytesType:
+			value, n = protowire.ConsumeBytes(b)
+		case protowire.VarintType:
+			_, n = protowire.ConsumeVarint(b)
+			value = b[:n]
+		default:
+			t.Fatalf("unexpected wire type %d", typ)
+		}
+		require.GreaterOrEqual(t, n, 0)
+		b = b[n:]
+
+		fields[num] = append(fields[num], value)
+	}
+	return fields
+}
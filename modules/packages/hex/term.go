@@ -0,0 +1,301 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package hex
+
+import (
+	"strconv"
+	"strings"
+
+	"gitea.dev/modules/util"
+)
+
+// ErrInvalidTerm indicates a malformed Erlang term
+var ErrInvalidTerm = util.NewInvalidArgumentErrorf("Erlang term is invalid")
+
+// Tuple represents an Erlang tuple
+type Tuple []any
+
+// Atom represents an Erlang atom
+type Atom string
+
+// ParseTerms parses the Erlang terms of a file in the format read by file:consult/1.
+// Binaries and strings are returned as string, lists as []any, integers as int64 and floats as float64.
+func ParseTerms(data string) ([]any, error) {
+	p := &termParser{data: data}
+
+	terms := make([]any, 0, 10)
+	for {
+		p.skipWhitespace()
+		if p.eof() {
+			return terms, nil
+		}
+
+		term, err := p.parseTerm()
+		if err != nil {
+			return nil, err
+		}
+		terms = append(terms, term)
+
+		p.skipWhitespace()
+		if !p.consume(".") {
+			return nil, ErrInvalidTerm
+		}
+	}
+}
+
+type termParser struct {
+	data string
+	pos  int
+}
+
+func (p *termParser) eof() bool {
+	return p.pos >= len(p.data)
+}
+
+func (p *termParser) peek() byte {
+	if p.eof() {
+		return 0
+	}
+	return p.data[p.pos]
+}
+
+func (p *termParser) consume(s string) bool {
+	if strings.HasPrefix(p.data[p.pos:], s) {
+		p.pos += len(s)
+		return true
+	}
+	return false
+}
+
+func (p *termParser) skipWhitespace() {
+	for !p.eof() {
+		switch c := p.peek(); {
+		case c == ' ' || c == '\t' || c == '\r' || c == '\n':
+			p.pos++
+		case c == '%':
+			// comments last until the end of the line
+			for !p.eof() && p.peek() != '\n' {
+				p.pos++
+			}
+		default:
+			return
+		}
+	}
+}
+
+func (p *termParser) parseTerm() (any, error) {
+	p.skipWhitespace()
+
+	switch c := p.peek(); {
+	case c == '{':
+		p.pos++
+		elements, err := p.parseSequence('}')
+		if err != nil {
+			return nil, err
+		}
+		return Tuple(elements), nil
+	case c == '[':
+		p.pos++
+		return p.parseSequence(']')
+	case c == '<':
+		return p.parseBinary()
+	case c == '"':
+		return p.parseString('"')
+	case c == '\'':
+		s, err := p.parseString('\'')
+		if err != nil {
+			return nil, err
+		}
+		return Atom(s), nil
+	case c == '-' || c >= '0' && c <= '9':
+		return p.parseNumber()
+	case c >= 'a' && c <= 'z':
+		start := p.pos
+		for !p.eof() {
+			c := p.peek()
+			if c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '_' || c == '@' {
+				p.pos++
+				continue
+			}
+			break
+		}
+		return Atom(p.data[start:p.pos]), nil
+	}
+	return nil, ErrInvalidTerm
+}
+
+func (p *termParser) parseSequence(end byte) ([]any, error) {
+	elements := make([]any, 0, 5)
+
+	p.skipWhitespace()
+	if p.peek() == end {
+		p.pos++
+		return elements, nil
+	}
+
+	for {
+		term, err := p.parseTerm()
+		if err != nil {
+			return nil, err
+		}
+		elements = append(elements, term)
+
+		p.skipWhitespace()
+		switch p.peek() {
+		case ',':
+			p.pos++
+		case end:
+			p.pos++
+			return elements, nil
+		default:
+			return nil, ErrInvalidTerm
+		}
+	}
+}
+
+// parseBinary parses binaries like <<"text">>, <<"text"/utf8>> and <<1,2,3>>
+func (p *termParser) parseBinary() (string, error) {
+	if !p.consume("<<") {
+		return "", ErrInvalidTerm
+	}
+	p.skipWhitespace()
+
+	var sb strings.Builder
+	for !p.consume(">>") {
+		if p.peek() == '"' {
+			s, err := p.parseString('"')
+			if err != nil {
+				return "", err
+			}
+			p.skipWhitespace()
+			p.consume("/utf8")
+			sb.WriteString(s)
+		} else {
+			n, err := p.parseNumber()
+			if err != nil {
+				return "", err
+			}
+			i, ok := n.(int64)
+			if !ok || i < 0 || i > 255 {
+				return "", ErrInvalidTerm
+			}
+			sb.WriteByte(byte(i))
+		}
+
+		p.skipWhitespace()
+		if p.consume(",") {
+			p.skipWhitespace()
+		} else if !strings.HasPrefix(p.data[p.pos:], ">>") {
+			return "", ErrInvalidTerm
+		}
+	}
+	return sb.String(), nil
+}
+
+func (p *termParser) parseString(quote byte) (string, error) {
+	p.pos++ // opening quote
+
+	var sb strings.Builder
+	for {
+		if p.eof() {
+			return "", ErrInvalidTerm
+		}
+		c := p.data[p.pos]
+		p.pos++
+		if c == quote {
+			return sb.String(), nil
+		}
+		if c != '\\' {
+			sb.WriteByte(c)
+			continue
+		}
+
+		if p.eof() {
+			return "", ErrInvalidTerm
+		}
+		c = p.data[p.pos]
+		p.pos++
+		switch c {
+		case 'n':
+			sb.WriteByte('\n')
+		case 't':
+			sb.WriteByte('\t')
+		case 'r':
+			sb.WriteByte('\r')
+		case 's':
+			sb.WriteByte(' ')
+		case 'b':
+			sb.WriteByte('\b')
+		case 'f':
+			sb.WriteByte('\f')
+		case 'v':
+			sb.WriteByte('\v')
+		case 'e':
+			sb.WriteByte(0x1b)
+		case 'd':
+			sb.WriteByte(0x7f)
+		case 'x':
+			var digits string
+			if p.consume("{") {
+				end := strings.IndexByte(p.data[p.pos:], '}')
+				if end == -1 {
+					return "", ErrInvalidTerm
+				}
+				digits = p.data[p.pos : p.pos+end]
+				p.pos += end + 1
+			} else if p.pos+2 <= len(p.data) {
+				digits = p.data[p.pos : p.pos+2]
+				p.pos += 2
+			}
+			r, err := strconv.ParseUint(digits, 16, 32)
+			if err != nil {
+				return "", ErrInvalidTerm
+			}
+			sb.WriteRune(rune(r))
+		case '0', '1', '2', '3', '4', '5', '6', '7':
+			start := p.pos - 1
+			for p.pos < len(p.data) && p.pos-start < 3 && p.data[p.pos] >= '0' && p.data[p.pos] <= '7' {
+				p.pos++
+			}
+			r, _ := strconv.ParseUint(p.data[start:p.pos], 8, 16)
+			sb.WriteByte(byte(r))
+		default:
+			sb.WriteByte(c)
+		}
+	}
+}
+
+func (p *termParser) parseNumber() (any, error) {
+	start := p.pos
+	if p.peek() == '-' {
+		p.pos++
+	}
+	isFloat := false
+	for !p.eof() {
+		c := p.peek()
+		if c >= '0' && c <= '9' {
+			p.pos++
+		} else if c == '.' && !isFloat && p.pos+1 < len(p.data) && p.data[p.pos+1] >= '0' && p.data[p.pos+1] <= '9' {
+			// a dot followed by a digit is a decimal point, otherwise it ends the term
+			isFloat = true
+			p.pos++
+		} else {
+			break
+		}
+	}
+
+	s := p.data[start:p.pos]
+	if isFloat {
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return nil, ErrInvalidTerm
+		}
+		return f, nil
+	}
+	i, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return nil, ErrInvalidTerm
+	}
+	return i, nil
+}
@@ -0,0 +1,69 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package nix
+
+import (
+	"errors"
+	"strings"
+)
+
+// base32Alphabet is the alphabet used by Nix which omits e, o, u and t
+const base32Alphabet = "0123456789abcdfghijklmnpqrsvwxyz"
+
+var ErrInvalidBase32 = errors.New("invalid Nix base32 string")
+
+// EncodedBase32Len returns the length of the Nix base32 representation of n bytes
+func EncodedBase32Len(n int) int {
+	if n == 0 {
+		return 0
+	}
+	return (n*8-1)/5 + 1
+}
+
+// EncodeBase32 encodes the data with the Nix specific base32 encoding.
+// In contrast to RFC 4648 the bytes are processed in reverse order.
+func EncodeBase32(data []byte) string {
+	l := EncodedBase32Len(len(data))
+
+	var sb strings.Builder
+	sb.Grow(l)
+	for n := l - 1; n >= 0; n-- {
+		b := n * 5
+		i := b / 8
+		j := b % 8
+		c := data[i] >> j
+		if i+1 < len(data) {
+			c |= data[i+1] << (8 - j)
+		}
+		sb.WriteByte(base32Alphabet[c&0x1f])
+	}
+	return sb.String()
+}
+
+// DecodeBase32 decodes a string encoded with the Nix specific base32 encoding
+func DecodeBase32(s string) ([]byte, error) {
+	data := make([]byte, len(s)*5/8)
+	for n := range len(s) {
+		digit := strings.IndexByte(base32Alphabet, s[len(s)-n-1])
+		if digit < 0 {
+			return nil, ErrInvalidBase32
+		}
+		b := n * 5
+		i := b / 8
+		j := b % 8
+		if i >= len(data) {
+			if digit != 0 {
+				return nil, ErrInvalidBase32
+			}
+			continue
+		}
+		data[i] |= byte(digit << j)
+		if carry := byte(digit >> (8 - j)); i+1 < len(data) {
+			data[i+1] |= carry
+		} else if carry != 0 {
+			return nil, ErrInvalidBase32
+		}
+	}
+	return data, nil
+}
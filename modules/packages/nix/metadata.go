@@ -0,0 +1,276 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package nix
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"io"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"gitea.dev/modules/util"
+)
+
+var (
+	ErrInvalidNarInfo   = util.NewInvalidArgumentErrorf("narinfo is invalid")
+	ErrInvalidStorePath = util.NewInvalidArgumentErrorf("store path is invalid")
+	ErrInvalidHash      = util.NewInvalidArgumentErrorf("hash is invalid")
+)
+
+const (
+	// StoreDir is the only supported store directory
+	StoreDir = "/nix/store"
+
+	// StoreHashLength is the length of the hash part of a store path
+	StoreHashLength = 32
+
+	// ContentTypeNarInfo is the content type of narinfo files
+	ContentTypeNarInfo = "text/x-nix-narinfo"
+
+	SettingKeyPrivate = "nix.key.private"
+	SettingKeyPublic  = "nix.key.public"
+
+	// UploadPackage and UploadVersion hold the nar files until the narinfo file is uploaded
+	UploadPackage = "_nix"
+	UploadVersion = "_upload"
+)
+
+var (
+	// https://github.com/NixOS/nix/blob/master/src/libstore/path.cc
+	storePathNamePattern = regexp.MustCompile(`\A[a-zA-Z0-9+\-_?=][a-zA-Z0-9+\-._?=]*\z`)
+	storePathHashPattern = regexp.MustCompile(`\A[` + base32Alphabet + `]{32}\z`)
+	narFilenamePattern   = regexp.MustCompile(`\A[a-z0-9]+\.nar(?:\.[a-z0-9]+)?\z`)
+)
+
+// Package represents a store path described by a narinfo file
+type Package struct {
+	Hash        string
+	Name        string
+	NarFilename string
+	Metadata    *Metadata
+}
+
+// Metadata represents the content of a narinfo file
+type Metadata struct {
+	StorePath   string   `json:"store_path"`
+	Compression string   `json:"compression,omitempty"`
+	FileHash    string   `json:"file_hash,omitempty"`
+	FileSize    int64    `json:"file_size,omitempty"`
+	NarHash     string   `json:"nar_hash"`
+	NarSize     int64    `json:"nar_size"`
+	References  []string `json:"references,omitempty"`
+	Deriver     string   `json:"deriver,omitempty"`
+	System      string   `json:"system,omitempty"`
+	CA          string   `json:"ca,omitempty"`
+	Signatures  []string `json:"signatures,omitempty"`
+}
+
+// IsValidStoreHash tests if the hash part of a store path is valid
+func IsValidStoreHash(hash string) bool {
+	return storePathHashPattern.MatchString(hash)
+}
+
+// IsValidNarFilename tests if the filename is a valid name for a (compressed) nar file
+func IsValidNarFilename(filename string) bool {
+	return narFilenamePattern.MatchString(filename)
+}
+
+// SplitStorePathBase splits the base name of a store path into hash and name
+func SplitStorePathBase(base string) (string, string, error) {
+	hash, name, ok := strings.Cut(base, "-")
+	if !ok || !IsValidStoreHash(hash) || len(name) > 211 || !storePathNamePattern.MatchString(name) {
+		return "", "", ErrInvalidStorePath
+	}
+	return hash, name, nil
+}
+
+// ParseStorePath splits a full store path into hash and name
+func ParseStorePath(storePath string) (string, string, error) {
+	base, ok := strings.CutPrefix(storePath, StoreDir+"/")
+	if !ok || strings.Contains(base, "/") {
+		return "", "", ErrInvalidStorePath
+	}
+	return SplitStorePathBase(base)
+}
+
+// ParseHash parses a SHA256 hash in one of the formats accepted by Nix and returns the digest
+func ParseHash(s string) ([]byte, error) {
+	var data []byte
+	var err error
+	if rest, ok := strings.CutPrefix(s, "sha256-"); ok {
+		data, err = base64.StdEncoding.DecodeString(rest)
+	} else if rest, ok := strings.CutPrefix(s, "sha256:"); ok {
+		switch len(rest) {
+		case EncodedBase32Len(sha256.Size):
+			data, err = DecodeBase32(rest)
+		case hex.EncodedLen(sha256.Size):
+			data, err = hex.DecodeString(rest)
+		default:
+			data, err = base64.StdEncoding.DecodeString(rest)
+		}
+	} else {
+		return nil, ErrInvalidHash
+	}
+	if err != nil || len(data) != sha256.Size {
+		return nil, ErrInvalidHash
+	}
+	return data, nil
+}
+
+// FormatHash formats a SHA256 digest like Nix does in narinfo files
+func FormatHash(digest []byte) string {
+	return "sha256:" + EncodeBase32(digest)
+}
+
+func normalizeHash(s string) (string, error) {
+	digest, err := ParseHash(s)
+	if err != nil {
+		return "", err
+	}
+	return FormatHash(digest), nil
+}
+
+// ParseNarInfo parses a narinfo file
+// https://github.com/NixOS/nix/blob/master/src/libstore/nar-info.cc
+func ParseNarInfo(r io.Reader) (*Package, error) {
+	m := &Metadata{}
+	var url string
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, ": ")
+		if !ok {
+			return nil, ErrInvalidNarInfo
+		}
+
+		var err error
+		switch key {
+		case "StorePath":
+			m.StorePath = value
+		case "URL":
+			url = value
+		case "Compression":
+			m.Compression = value
+		case "FileHash":
+			m.FileHash, err = normalizeHash(value)
+		case "FileSize":
+			m.FileSize, err = strconv.ParseInt(value, 10, 64)
+		case "NarHash":
+			m.NarHash, err = normalizeHash(value)
+		case "NarSize":
+			m.NarSize, err = strconv.ParseInt(value, 10, 64)
+		case "References":
+			m.References = strings.Fields(value)
+		case "Deriver":
+			if value != "unknown-deriver" {
+				m.Deriver = value
+			}
+		case "System":
+			m.System = value
+		case "Sig":
+			m.Signatures = append(m.Signatures, value)
+		case "CA":
+			m.CA = value
+		}
+		if err != nil {
+			return nil, util.NewInvalidArgumentErrorf("narinfo field %s is invalid", key)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if m.StorePath == "" || url == "" || m.NarHash == "" || m.NarSize <= 0 {
+		return nil, ErrInvalidNarInfo
+	}
+
+	hash, name, err := ParseStorePath(m.StorePath)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, ref := range m.References {
+		if _, _, err := SplitStorePathBase(ref); err != nil {
+			return nil, err
+		}
+	}
+	if m.Deriver != "" {
+		if _, _, err := SplitStorePathBase(m.Deriver); err != nil {
+			return nil, err
+		}
+	}
+
+	narFilename := path.Base(url)
+	if !IsValidNarFilename(narFilename) {
+		return nil, util.NewInvalidArgumentErrorf("narinfo URL is invalid")
+	}
+
+	if m.Compression == "" {
+		m.Compression = "bzip2"
+	}
+
+	return &Package{
+		Hash:        hash,
+		Name:        name,
+		NarFilename: narFilename,
+		Metadata:    m,
+	}, nil
+}
+
+// Fingerprint returns the data which is signed to prove the authenticity of a store path
+// https://github.com/NixOS/nix/blob/master/src/libstore/path-info.cc
+func (m *Metadata) Fingerprint() string {
+	refs := make([]string, 0, len(m.References))
+	for _, ref := range m.References {
+		refs = append(refs, StoreDir+"/"+ref)
+	}
+	return "1;" + m.StorePath + ";" + m.NarHash + ";" + strconv.FormatInt(m.NarSize, 10) + ";" + strings.Join(refs, ",")
+}
+
+// FormatNarInfo creates the narinfo file for the store path
+func (m *Metadata) FormatNarInfo(url string, signatures []string) string {
+	var sb strings.Builder
+	writeField := func(key, value string) {
+		sb.WriteString(key)
+		sb.WriteString(": ")
+		sb.WriteString(value)
+		sb.WriteByte('\n')
+	}
+
+	writeField("StorePath", m.StorePath)
+	writeField("URL", url)
+	writeField("Compression", m.Compression)
+	if m.FileHash != "" {
+		writeField("FileHash", m.FileHash)
+	}
+	if m.FileSize != 0 {
+		writeField("FileSize", strconv.FormatInt(m.FileSize, 10))
+	}
+	writeField("NarHash", m.NarHash)
+	writeField("NarSize", strconv.FormatInt(m.NarSize, 10))
+	writeField("References", strings.Join(m.References, " "))
+	if m.Deriver != "" {
+		writeField("Deriver", m.Deriver)
+	}
+	if m.System != "" {
+		writeField("System", m.System)
+	}
+	for _, sig := range signatures {
+		writeField("Sig", sig)
+	}
+	if m.CA != "" {
+		writeField("CA", m.CA)
+	}
+	return sb.String()
+}
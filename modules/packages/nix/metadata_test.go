@@ -0,0 +1,162 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package nix
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	storeHash = "7gx4kiv5m0i7d7qkixq2cwzbr10lvxwc"
+	storeName = "hello-2.12.1"
+	narHash   = "sha256:1b8m03r63zqhnjf7l5wnldhh7c134ap5vpj0850ymkq1iyzicy5s"
+	narInfo   = `StorePath: /nix/store/` + storeHash + `-` + storeName + `
+URL: nar/0ib2v9jqqy6xbh3cf9v3bnb1dgi81wc6wbby6ww2l7mdjrsgfzhb.nar.xz
+Compression: xz
+FileHash: sha256:0ib2v9jqqy6xbh3cf9v3bnb1dgi81wc6wbby6ww2l7mdjrsgfzhb
+FileSize: 50088
+NarHash: ` + narHash + `
+NarSize: 226488
+References: 3n58xw4373jp0ljirf06d8077j15pc4j-glibc-2.37-8 7gx4kiv5m0i7d7qkixq2cwzbr10lvxwc-hello-2.12.1
+Deriver: ynqz9m4f1j8p3w4fz7sxg1n4p3cl9h3a-hello-2.12.1.drv
+Sig: cache.nixos.org-1:8ijECciSFzWHwwGVOIVYdp2fOIOJAfmzGHPQVwpktfTQJF6kMPPDre7UtFw3o+VqenC5P8RikKOAAfN7CvPEAg==
+`
+)
+
+func TestBase32(t *testing.T) {
+	digest := sha256.Sum256([]byte("abc"))
+
+	encoded := EncodeBase32(digest[:])
+	assert.Equal(t, "1b8m03r63zqhnjf7l5wnldhh7c134ap5vpj0850ymkq1iyzicy5s", encoded)
+
+	decoded, err := DecodeBase32(encoded)
+	require.NoError(t, err)
+	assert.Equal(t, digest[:], decoded)
+
+	_, err = DecodeBase32("1b8m03r63zqhnjf7l5wnldhh7c134ap5vpj0850ymkq1iyzicy5e")
+	assert.ErrorIs(t, err, ErrInvalidBase32)
+}
+
+func TestParseHash(t *testing.T) {
+	digest := sha256.Sum256([]byte("abc"))
+
+	for _, s := range []string{
+		narHash,
+		"sha256:" + hex.EncodeToString(digest[:]),
+		"sha256-ungWv48Bz+pBQUDeXa4iI7ADYaOWF3qctBD/YfIAFa0=",
+	} {
+		d, err := ParseHash(s)
+		require.NoError(t, err, s)
+		assert.Equal(t, digest[:], d)
+	}
+
+	for _, s := range []string{"", "md5:abc", "sha256:invalid", "sha256:00"} {
+		_, err := ParseHash(s)
+		assert.ErrorIs(t, err, ErrInvalidHash, s)
+	}
+
+	assert.Equal(t, narHash, FormatHash(digest[:]))
+}
+
+func TestParseStorePath(t *testing.T) {
+	hash, name, err := ParseStorePath("/nix/store/" + storeHash + "-" + storeName)
+	require.NoError(t, err)
+	assert.Equal(t, storeHash, hash)
+	assert.Equal(t, storeName, name)
+
+	for _, p := range []string{
+		storeHash + "-" + storeName,
+		"/nix/store/" + storeHash,
+		"/nix/store/" + storeHash + "-",
+		"/nix/store/" + storeHash + "-.hidden",
+		"/nix/store/" + storeHash + "-" + storeName + "/bin",
+		"/nix/store/" + strings.ToUpper(storeHash) + "-" + storeName,
+		"/gnu/store/" + storeHash + "-" + storeName,
+	} {
+		_, _, err := ParseStorePath(p)
+		assert.ErrorIs(t, err, ErrInvalidStorePath, p)
+	}
+}
+
+func TestParseNarInfo(t *testing.T) {
+	t.Run("Valid", func(t *testing.T) {
+		p, err := ParseNarInfo(strings.NewReader(narInfo))
+		require.NoError(t, err)
+		require.NotNil(t, p)
+
+		assert.Equal(t, storeHash, p.Hash)
+		assert.Equal(t, storeName, p.Name)
+		assert.Equal(t, "0ib2v9jqqy6xbh3cf9v3bnb1dgi81wc6wbby6ww2l7mdjrsgfzhb.nar.xz", p.NarFilename)
+
+		m := p.Metadata
+		assert.Equal(t, "/nix/store/"+storeHash+"-"+storeName, m.StorePath)
+		assert.Equal(t, "xz", m.Compression)
+		assert.EqualValues(t, 50088, m.FileSize)
+		assert.Equal(t, narHash, m.NarHash)
+		assert.EqualValues(t, 226488, m.NarSize)
+		assert.Len(t, m.References, 2)
+		assert.Equal(t, "ynqz9m4f1j8p3w4fz7sxg1n4p3cl9h3a-hello-2.12.1.drv", m.Deriver)
+		assert.Len(t, m.Signatures, 1)
+
+		assert.Equal(t, "1;/nix/store/"+storeHash+"-"+storeName+";"+narHash+";226488;/nix/store/3n58xw4373jp0ljirf06d8077j15pc4j-glibc-2.37-8,/nix/store/"+storeHash+"-"+storeName, m.Fingerprint())
+		assert.Equal(t, narInfo, m.FormatNarInfo("nar/"+p.NarFilename, m.Signatures))
+	})
+
+	t.Run("HexHash", func(t *testing.T) {
+		digest := sha256.Sum256([]byte("abc"))
+
+		p, err := ParseNarInfo(strings.NewReader(strings.Replace(narInfo, narHash, "sha256:"+hex.EncodeToString(digest[:]), 1)))
+		require.NoError(t, err)
+		assert.Equal(t, narHash, p.Metadata.NarHash)
+	})
+
+	t.Run("DefaultCompression", func(t *testing.T) {
+		p, err := ParseNarInfo(strings.NewReader(strings.Replace(narInfo, "Compression: xz\n", "", 1)))
+		require.NoError(t, err)
+		assert.Equal(t, "bzip2", p.Metadata.Compression)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		for _, content := range []string{
+			"",
+			"invalid",
+			strings.Replace(narInfo, "StorePath: /nix/store/", "StorePath: /", 1),
+			strings.Replace(narInfo, "NarSize: 226488", "NarSize: a", 1),
+			strings.Replace(narInfo, narHash, "sha256:00", 1),
+			strings.Replace(narInfo, ".nar.xz\n", ".zip\n", 1),
+			strings.Replace(narInfo, "References: 3n58", "References: x", 1),
+		} {
+			p, err := ParseNarInfo(strings.NewReader(content))
+			assert.Nil(t, p)
+			assert.Error(t, err)
+		}
+	})
+}
+
+func TestSign(t *testing.T) {
+	priv, pub, err := GenerateKeyPair("gitea-1")
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(priv, "gitea-1:"))
+	assert.True(t, strings.HasPrefix(pub, "gitea-1:"))
+
+	sig, err := Sign(priv, "fingerprint")
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(sig, "gitea-1:"))
+
+	assert.True(t, Verify(pub, "fingerprint", sig))
+	assert.False(t, Verify(pub, "other", sig))
+
+	_, pub2, err := GenerateKeyPair("gitea-2")
+	require.NoError(t, err)
+	assert.False(t, Verify(pub2, "fingerprint", sig))
+
+	_, err = Sign(pub, "fingerprint")
+	assert.ErrorIs(t, err, ErrInvalidKey)
+}
@@ -0,0 +1,63 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package nix
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"strings"
+)
+
+var ErrInvalidKey = errors.New("invalid Nix signing key")
+
+// GenerateKeyPair creates an Ed25519 key pair in the format used by "nix key generate-secret"
+func GenerateKeyPair(name string) (string, string, error) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return "", "", err
+	}
+
+	return formatKey(name, priv), formatKey(name, pub), nil
+}
+
+func formatKey(name string, key []byte) string {
+	return name + ":" + base64.StdEncoding.EncodeToString(key)
+}
+
+func parseKey(s string, size int) (string, []byte, error) {
+	name, encoded, ok := strings.Cut(s, ":")
+	if !ok || name == "" {
+		return "", nil, ErrInvalidKey
+	}
+	key, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil || len(key) != size {
+		return "", nil, ErrInvalidKey
+	}
+	return name, key, nil
+}
+
+// Sign signs the fingerprint with the secret key and returns the signature in the "name:signature" format
+func Sign(secretKey, fingerprint string) (string, error) {
+	name, key, err := parseKey(secretKey, ed25519.PrivateKeySize)
+	if err != nil {
+		return "", err
+	}
+
+	return formatKey(name, ed25519.Sign(ed25519.PrivateKey(key), []byte(fingerprint))), nil
+}
+
+// Verify tests if the signature of the fingerprint was created with the secret key of the public key
+func Verify(publicKey, fingerprint, signature string) bool {
+	name, key, err := parseKey(publicKey, ed25519.PublicKeySize)
+	if err != nil {
+		return false
+	}
+	sigName, sig, err := parseKey(signature, ed25519.SignatureSize)
+	if err != nil || sigName != name {
+		return false
+	}
+	return ed25519.Verify(ed25519.PublicKey(key), []byte(fingerprint), sig)
+}
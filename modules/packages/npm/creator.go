@@ -10,6 +10,7 @@ import (
 	"crypto/sha1"
 	"crypto/sha512"
 	"encoding/base64"
+	"encoding/hex"
 	"fmt"
 	"io"
 	"regexp"
@@ -36,6 +37,9 @@ var (
 	ErrInvalidIntegrity = util.NewInvalidArgumentErrorf("failed to validate integrity")
 )
 
+// provenanceAttachmentSuffix is the suffix of the sigstore bundle attachment name
+const provenanceAttachmentSuffix = ".sigstore"
+
 var nameMatch = regexp.MustCompile(`^(@[a-z0-9-][a-z0-9-._]*/)?[a-z0-9-][a-z0-9-._]*$`)
 
 // Package represents a npm package
@@ -46,6 +50,8 @@ type Package struct {
 	Metadata Metadata
 	Filename string
 	Data     []byte
+	// Provenance is the sigstore bundle attached by "npm publish --provenance"
+	Provenance []byte
 }
 
 // PackageMetadata https://github.com/npm/registry/blob/master/docs/REGISTRY-API.md#package
@@ -287,75 +293,24 @@ func ParsePackage(r io.Reader) (*Package, error) {
 // parseUploadPackage builds a Package from a decoded publish body.
 func parseUploadPackage(upload *packageUpload) (*Package, error) {
 	for _, meta := range upload.Versions {
-		if !validateName(meta.Name) {
-			return nil, ErrInvalidPackageName
-		}
-
-		v, err := version.NewSemver(meta.Version)
+		p, err := newPackageFromVersion(meta)
 		if err != nil {
-			return nil, ErrInvalidPackageVersion
-		}
-
-		scope := ""
-		name := meta.Name
-		nameParts := strings.SplitN(meta.Name, "/", 2)
-		if len(nameParts) == 2 {
-			scope = nameParts[0]
-			name = nameParts[1]
-		}
-
-		if !validation.IsValidURL(meta.Homepage) {
-			meta.Homepage = ""
-		}
-
-		// A string "bin" means a single executable named after the package.
-		if cmd, ok := meta.Bin[""]; ok && len(meta.Bin) == 1 {
-			meta.Bin = Bin{name: cmd}
-		}
-
-		p := &Package{
-			Name:     meta.Name,
-			Version:  v.String(),
-			DistTags: make([]string, 0, 1),
-			Metadata: Metadata{
-				Scope:                   scope,
-				Name:                    name,
-				Description:             meta.Description,
-				Author:                  meta.Author.Name,
-				License:                 meta.License,
-				ProjectURL:              meta.Homepage,
-				Keywords:                meta.Keywords,
-				Dependencies:            meta.Dependencies,
-				BundleDependencies:      meta.BundleDependencies,
-				DevelopmentDependencies: meta.DevDependencies,
-				PeerDependencies:        meta.PeerDependencies,
-				PeerDependenciesMeta:    meta.PeerDependenciesMeta,
-				OptionalDependencies:    meta.OptionalDependencies,
-				Bin:                     meta.Bin,
-				Readme:                  meta.Readme,
-				Repository:              meta.Repository,
-				Engines:                 meta.Engines,
-				CPU:                     meta.CPU,
-				OS:                      meta.OS,
-				Directories:             meta.Directories,
-				Funding:                 meta.Funding,
-				AcceptDependencies:      meta.AcceptDependencies,
-				Deprecated:              meta.Deprecated,
-			},
+			return nil, err
 		}
 
 		for tag := range upload.DistTags {
 			p.DistTags = append(p.DistTags, tag)
 		}
 
-		p.Filename = strings.ToLower(fmt.Sprintf("%s-%s.tgz", name, p.Version))
-
-		attachment := func() *PackageAttachment {
-			for _, a := range upload.Attachments {
-				return a
+		// "npm publish --provenance" adds the sigstore bundle as second attachment
+		var attachment, provenance *PackageAttachment
+		for name, a := range upload.Attachments {
+			if strings.HasSuffix(name, provenanceAttachmentSuffix) {
+				provenance = a
+			} else {
+				attachment = a
 			}
-			return nil
-		}()
+		}
 		if attachment == nil || len(attachment.Data) == 0 {
 			return nil, ErrInvalidAttachment
 		}
@@ -364,37 +319,144 @@ func parseUploadPackage(upload *packageUpload) (*Package, error) {
 		if err != nil {
 			return nil, ErrInvalidAttachment
 		}
-		p.Data = data
 
-		integrity := strings.SplitN(meta.Dist.Integrity, "-", 2)
-		if len(integrity) != 2 {
-			return nil, ErrInvalidIntegrity
-		}
-		integrityHash, err := base64.StdEncoding.DecodeString(integrity[1])
-		if err != nil {
-			return nil, ErrInvalidIntegrity
+		if err := p.setData(data, meta.Dist.Integrity); err != nil {
+			return nil, err
 		}
-		var hash []byte
-		switch integrity[0] {
-		case "sha1":
-			tmp := sha1.Sum(data)
-			hash = tmp[:]
-		case "sha512":
-			tmp := sha512.Sum512(data)
-			hash = tmp[:]
+
+		if provenance != nil {
+			if p.Provenance, err = base64.StdEncoding.DecodeString(provenance.Data); err != nil {
+				return nil, ErrInvalidAttachment
+			}
 		}
-		if !bytes.Equal(integrityHash, hash) {
+		return p, nil
+	}
+
+	return nil, ErrInvalidPackage
+}
+
+// ParseRemotePackage builds a Package from the version metadata of an upstream registry and the tarball
+// referenced by it. Upstream versions without integrity are verified with the legacy sha1 shasum.
+func ParseRemotePackage(meta *PackageMetadataVersion, data []byte) (*Package, error) {
+	p, err := newPackageFromVersion(meta)
+	if err != nil {
+		return nil, err
+	}
+
+	integrity := meta.Dist.Integrity
+	if integrity == "" && meta.Dist.Shasum != "" {
+		shasum, err := hex.DecodeString(meta.Dist.Shasum)
+		if err != nil {
 			return nil, ErrInvalidIntegrity
 		}
+		integrity = "sha1-" + base64.StdEncoding.EncodeToString(shasum)
+	}
 
-		// Derive _hasShrinkwrap and hasInstallScript from the tarball; the
-		// packument can lie about either.
-		p.Metadata.HasShrinkwrap, p.Metadata.HasInstallScript = inspectTarball(data)
+	if err := p.setData(data, integrity); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
 
-		return p, nil
+// TarballFilename returns the name a tarball of the package version is stored with
+func TarballFilename(packageName, packageVersion string) string {
+	name := packageName
+	if parts := strings.SplitN(packageName, "/", 2); len(parts) == 2 {
+		name = parts[1]
 	}
+	return strings.ToLower(fmt.Sprintf("%s-%s.tgz", name, packageVersion))
+}
 
-	return nil, ErrInvalidPackage
+func newPackageFromVersion(meta *PackageMetadataVersion) (*Package, error) {
+	if !validateName(meta.Name) {
+		return nil, ErrInvalidPackageName
+	}
+
+	v, err := version.NewSemver(meta.Version)
+	if err != nil {
+		return nil, ErrInvalidPackageVersion
+	}
+
+	scope := ""
+	name := meta.Name
+	nameParts := strings.SplitN(meta.Name, "/", 2)
+	if len(nameParts) == 2 {
+		scope = nameParts[0]
+		name = nameParts[1]
+	}
+
+	if !validation.IsValidURL(meta.Homepage) {
+		meta.Homepage = ""
+	}
+
+	// A string "bin" means a single executable named after the package.
+	if cmd, ok := meta.Bin[""]; ok && len(meta.Bin) == 1 {
+		meta.Bin = Bin{name: cmd}
+	}
+
+	return &Package{
+		Name:     meta.Name,
+		Version:  v.String(),
+		DistTags: make([]string, 0, 1),
+		Metadata: Metadata{
+			Scope:                   scope,
+			Name:                    name,
+			Description:             meta.Description,
+			Author:                  meta.Author.Name,
+			License:                 meta.License,
+			ProjectURL:              meta.Homepage,
+			Keywords:                meta.Keywords,
+			Dependencies:            meta.Dependencies,
+			BundleDependencies:      meta.BundleDependencies,
+			DevelopmentDependencies: meta.DevDependencies,
+			PeerDependencies:        meta.PeerDependencies,
+			PeerDependenciesMeta:    meta.PeerDependenciesMeta,
+			OptionalDependencies:    meta.OptionalDependencies,
+			Bin:                     meta.Bin,
+			Readme:                  meta.Readme,
+			Repository:              meta.Repository,
+			Engines:                 meta.Engines,
+			CPU:                     meta.CPU,
+			OS:                      meta.OS,
+			Directories:             meta.Directories,
+			Funding:                 meta.Funding,
+			AcceptDependencies:      meta.AcceptDependencies,
+			Deprecated:              meta.Deprecated,
+		},
+		Filename: TarballFilename(meta.Name, v.String()),
+	}, nil
+}
+
+// setData verifies the tarball against the integrity string and attaches it to the package
+func (p *Package) setData(data []byte, integrityValue string) error {
+	integrity := strings.SplitN(integrityValue, "-", 2)
+	if len(integrity) != 2 {
+		return ErrInvalidIntegrity
+	}
+	integrityHash, err := base64.StdEncoding.DecodeString(integrity[1])
+	if err != nil {
+		return ErrInvalidIntegrity
+	}
+	var hash []byte
+	switch integrity[0] {
+	case "sha1":
+		tmp := sha1.Sum(data)
+		hash = tmp[:]
+	case "sha512":
+		tmp := sha512.Sum512(data)
+		hash = tmp[:]
+	}
+	if !bytes.Equal(integrityHash, hash) {
+		return ErrInvalidIntegrity
+	}
+
+	p.Data = data
+
+	// Derive _hasShrinkwrap and hasInstallScript from the tarball; the
+	// packument can lie about either.
+	p.Metadata.HasShrinkwrap, p.Metadata.HasInstallScript = inspectTarball(data)
+
+	return nil
 }
 
 // maxNpmTarballScanBytes caps the decompressed tarball bytes inspectTarball
@@ -0,0 +1,64 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package pypi
+
+import (
+	"strings"
+
+	"gitea.dev/modules/util"
+)
+
+// SimpleIndexContentType is the media type of the JSON Simple Repository API
+// https://peps.python.org/pep-0691/
+const SimpleIndexContentType = "application/vnd.pypi.simple.v1+json"
+
+// ErrInvalidFilename indicates a distribution filename which does not belong to the package
+var ErrInvalidFilename = util.NewInvalidArgumentErrorf("distribution filename is invalid")
+
+// SimpleIndex is the JSON project page of the Simple Repository API
+type SimpleIndex struct {
+	Name  string             `json:"name"`
+	Files []*SimpleIndexFile `json:"files"`
+}
+
+// SimpleIndexFile is a distribution file listed on a project page
+type SimpleIndexFile struct {
+	Filename       string            `json:"filename"`
+	URL            string            `json:"url"`
+	Hashes         map[string]string `json:"hashes"`
+	RequiresPython string            `json:"requires-python,omitempty"`
+}
+
+var distributionExtensions = []string{".whl", ".tar.gz", ".tar.bz2", ".tar.xz", ".tgz", ".zip", ".egg"}
+
+// ParseVersionFromFilename extracts the version from the filename of a wheel or source distribution
+// https://packaging.python.org/en/latest/specifications/binary-distribution-format/#file-name-convention
+// https://packaging.python.org/en/latest/specifications/source-distribution-format/#source-distribution-file-name
+func ParseVersionFromFilename(normalizedName, filename string) (string, error) {
+	base := ""
+	for _, ext := range distributionExtensions {
+		if strings.HasSuffix(strings.ToLower(filename), ext) {
+			base = filename[:len(filename)-len(ext)]
+			break
+		}
+	}
+	if base == "" || len(base) <= len(normalizedName)+1 {
+		return "", ErrInvalidFilename
+	}
+
+	normalize := strings.NewReplacer(".", "-", "_", "-")
+	if normalize.Replace(strings.ToLower(base[:len(normalizedName)])) != strings.ToLower(normalizedName) || base[len(normalizedName)] != '-' && base[len(normalizedName)] != '_' {
+		return "", ErrInvalidFilename
+	}
+
+	version := base[len(normalizedName)+1:]
+	if strings.HasSuffix(strings.ToLower(filename), ".whl") || strings.HasSuffix(strings.ToLower(filename), ".egg") {
+		// wheels and eggs append tags after the version
+		version, _, _ = strings.Cut(version, "-")
+	}
+	if version == "" {
+		return "", ErrInvalidFilename
+	}
+	return version, nil
+}
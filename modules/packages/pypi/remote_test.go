@@ -0,0 +1,34 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package pypi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseVersionFromFilename(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Filename string
+		Version  string
+	}{
+		{"requests", "requests-2.31.0-py3-none-any.whl", "2.31.0"},
+		{"requests", "requests-2.31.0.tar.gz", "2.31.0"},
+		{"zope-interface", "zope.interface-6.1-cp312-cp312-win_amd64.whl", "6.1"},
+		{"zope-interface", "zope_interface-6.1.tar.gz", "6.1"},
+		{"test-package", "Test_Package-1.0.0rc1.zip", "1.0.0rc1"},
+	}
+	for _, c := range cases {
+		v, err := ParseVersionFromFilename(c.Name, c.Filename)
+		assert.NoError(t, err, c.Filename)
+		assert.Equal(t, c.Version, v, c.Filename)
+	}
+
+	for _, filename := range []string{"requests.tar.gz", "requests-2.31.0.exe", "other-1.0.tar.gz", "requests-.tar.gz"} {
+		_, err := ParseVersionFromFilename("requests", filename)
+		assert.ErrorIs(t, err, ErrInvalidFilename, filename)
+	}
+}
@@ -18,6 +18,9 @@ const (
 	GitPushOptionRepoPrivate  = "repo.private"
 	GitPushOptionRepoTemplate = "repo.template"
 	GitPushOptionForcePush    = "force-push"
+
+	// GitPushOptionSecretScanningBypass pushes the secrets blocked by the push protection, its value is the reason
+	GitPushOptionSecretScanningBypass = "secret_scanning.bypass"
 )
 
 // Bool checks for a key in the map and parses as a boolean
@@ -8,8 +8,11 @@ import (
 	"fmt"
 
 	git_model "gitea.dev/models/git"
+	quota_model "gitea.dev/models/quota"
 	repo_model "gitea.dev/models/repo"
 	"gitea.dev/modules/git"
+	"gitea.dev/modules/log"
+	"gitea.dev/modules/setting"
 )
 
 // UpdateRepoSize updates the repository size, calculating it using getDirectorySize
@@ -24,5 +27,14 @@ func UpdateRepoSize(ctx context.Context, repo *repo_model.Repository) error {
 		return fmt.Errorf("updateSize: GetLFSMetaObjects: %w", err)
 	}
 
-	return repo_model.UpdateRepoSize(ctx, repo.ID, size, lfsSize)
+	if err := repo_model.UpdateRepoSize(ctx, repo.ID, size, lfsSize); err != nil {
+		return err
+	}
+
+	if setting.Quota.Enabled {
+		if _, err := quota_model.UpdateUsage(ctx, repo.OwnerID, quota_model.CategoryGit, quota_model.CategoryLFS); err != nil {
+			log.Error("Unable to update the quota usage of %d: %v", repo.OwnerID, err)
+		}
+	}
+	return nil
 }
@@ -0,0 +1,122 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package saml
+
+import (
+	"bytes"
+	"compress/flate"
+	"crypto"
+	"crypto/x509"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+)
+
+// maxMessageSize limits the size of the inflated messages of the redirect binding
+const maxMessageSize = 512 * 1024
+
+// RedirectBindingURL encodes the message in the query of the destination as the HTTP-Redirect binding
+// specifies, param being "SAMLRequest" or "SAMLResponse". The query is signed when the key is set.
+func RedirectBindingURL(destination, param string, message []byte, relayState string, key crypto.Signer) (string, error) {
+	var deflated bytes.Buffer
+	writer, err := flate.NewWriter(&deflated, flate.BestCompression)
+	if err != nil {
+		return "", err
+	}
+	if _, err := writer.Write(message); err != nil {
+		return "", err
+	}
+	if err := writer.Close(); err != nil {
+		return "", err
+	}
+
+	// the signed string is the query in this exact order, with the values url-encoded
+	query := param + "=" + url.QueryEscape(base64.StdEncoding.EncodeToString(deflated.Bytes()))
+	if relayState != "" {
+		query += "&RelayState=" + url.QueryEscape(relayState)
+	}
+	if key != nil {
+		algorithm, err := signatureAlgorithm(key)
+		if err != nil {
+			return "", err
+		}
+		query += "&SigAlg=" + url.QueryEscape(algorithm)
+		hashed := crypto.SHA256.New()
+		hashed.Write([]byte(query))
+		sig, err := signHashed(key, hashed.Sum(nil))
+		if err != nil {
+			return "", err
+		}
+		query += "&Signature=" + url.QueryEscape(base64.StdEncoding.EncodeToString(sig))
+	}
+
+	if strings.Contains(destination, "?") {
+		return destination + "&" + query, nil
+	}
+	return destination + "?" + query, nil
+}
+
+// DecodeRedirectBinding decodes a message of the HTTP-Redirect binding, the value of its SAMLRequest or
+// SAMLResponse parameter
+func DecodeRedirectBinding(value string) ([]byte, error) {
+	deflated, err := decodeBase64(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid base64 encoding: %w", err)
+	}
+	message, err := io.ReadAll(io.LimitReader(flate.NewReader(bytes.NewReader(deflated)), maxMessageSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("invalid deflate encoding: %w", err)
+	}
+	if len(message) > maxMessageSize {
+		return nil, errors.New("the message is too large")
+	}
+	return message, nil
+}
+
+// verifyRedirectBindingSignature checks the signature of a query of the HTTP-Redirect binding. The signed
+// string is rebuilt from the raw query, the url-encoding of the values may differ between implementations.
+func verifyRedirectBindingSignature(rawQuery, param string, certificates []*x509.Certificate) error {
+	raw := map[string]string{}
+	for part := range strings.SplitSeq(rawQuery, "&") {
+		name, value, _ := strings.Cut(part, "=")
+		if name, err := url.QueryUnescape(name); err == nil {
+			if _, ok := raw[name]; ok {
+				return fmt.Errorf("duplicate %s parameter", name)
+			}
+			raw[name] = value
+		}
+	}
+	if raw["Signature"] == "" || raw["SigAlg"] == "" {
+		return errNotSigned
+	}
+
+	algorithm, err := url.QueryUnescape(raw["SigAlg"])
+	if err != nil {
+		return fmt.Errorf("invalid SigAlg: %w", err)
+	}
+	hash, ok := signatureAlgorithms[algorithm]
+	if !ok {
+		return fmt.Errorf("unsupported signature method %q, SHA-1 is not accepted", algorithm)
+	}
+	signature, err := url.QueryUnescape(raw["Signature"])
+	if err != nil {
+		return fmt.Errorf("invalid Signature: %w", err)
+	}
+	sig, err := decodeBase64(signature)
+	if err != nil {
+		return fmt.Errorf("invalid Signature: %w", err)
+	}
+
+	signed := param + "=" + raw[param]
+	if relayState, ok := raw["RelayState"]; ok {
+		signed += "&RelayState=" + relayState
+	}
+	signed += "&SigAlg=" + raw["SigAlg"]
+	hashed := hash.New()
+	hashed.Write([]byte(signed))
+	return verifyWithCertificates(certificates, hash, hashed.Sum(nil), sig)
+}
@@ -0,0 +1,233 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package saml
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/beevik/etree"
+	dsig "github.com/russellhaering/goxmldsig"
+	"github.com/russellhaering/goxmldsig/etreeutils"
+)
+
+const (
+	nsDSig = "http://www.w3.org/2000/09/xmldsig#"
+
+	algDigestSHA256         = "http://www.w3.org/2001/04/xmlenc#sha256"
+	algDigestSHA512         = "http://www.w3.org/2001/04/xmlenc#sha512"
+	algSignatureRSASHA256   = "http://www.w3.org/2001/04/xmldsig-more#rsa-sha256"
+	algSignatureRSASHA512   = "http://www.w3.org/2001/04/xmldsig-more#rsa-sha512"
+	algSignatureECDSASHA256 = "http://www.w3.org/2001/04/xmldsig-more#ecdsa-sha256"
+	algSignatureECDSASHA512 = "http://www.w3.org/2001/04/xmldsig-more#ecdsa-sha512"
+)
+
+// errNotSigned is returned when the element has no signature at all, which is allowed for an assertion
+// contained in a signed response.
+var errNotSigned = errors.New("element is not signed")
+
+var digestAlgorithms = map[string]crypto.Hash{
+	algDigestSHA256: crypto.SHA256,
+	algDigestSHA512: crypto.SHA512,
+}
+
+var signatureAlgorithms = map[string]crypto.Hash{
+	algSignatureRSASHA256:   crypto.SHA256,
+	algSignatureRSASHA512:   crypto.SHA512,
+	algSignatureECDSASHA256: crypto.SHA256,
+	algSignatureECDSASHA512: crypto.SHA512,
+}
+
+// verifySignature checks the enveloped signature of the element against the trusted certificates with
+// goxmldsig, and returns the element as it was signed. Only the returned element may be read afterwards: the
+// signature doesn't cover anything else in the document, which could have been added by anyone. The signatures
+// using SHA-1 are rejected.
+func verifySignature(el *etree.Element, certificates []*x509.Certificate) (*etree.Element, error) {
+	signatures := 0
+	for _, child := range el.ChildElements() {
+		if child.Tag == "Signature" && child.NamespaceURI() == nsDSig {
+			signatures++
+		}
+	}
+	if signatures == 0 {
+		return nil, errNotSigned
+	} else if signatures > 1 {
+		return nil, errors.New("more than one signature")
+	}
+	if len(certificates) == 0 {
+		return nil, errors.New("no certificate to verify the signature")
+	}
+	if err := checkSignatureAlgorithms(el); err != nil {
+		return nil, err
+	}
+
+	// the namespaces declared by the ancestors are part of the signed content
+	detached, err := detach(el)
+	if err != nil {
+		return nil, err
+	}
+	ctx := dsig.NewDefaultValidationContext(&dsig.MemoryX509CertificateStore{Roots: certificates})
+	ctx.IdAttribute = "ID"
+	signed, err := ctx.Validate(detached)
+	if err != nil {
+		return nil, fmt.Errorf("signature verification failed: %w", err)
+	}
+	return signed, nil
+}
+
+// checkSignatureAlgorithms rejects the element when any of the signatures it contains uses an algorithm
+// other than the ones of signatureAlgorithms and digestAlgorithms
+func checkSignatureAlgorithms(el *etree.Element) error {
+	return etreeutils.NSFindIterate(el, nsDSig, "Signature", func(ctx etreeutils.NSContext, signature *etree.Element) error {
+		if err := etreeutils.NSFindIterateCtx(ctx, signature, nsDSig, "SignatureMethod", func(_ etreeutils.NSContext, method *etree.Element) error {
+			if _, ok := signatureAlgorithms[method.SelectAttrValue("Algorithm", "")]; !ok {
+				return fmt.Errorf("unsupported signature method %q, SHA-1 is not accepted", method.SelectAttrValue("Algorithm", ""))
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+		return etreeutils.NSFindIterateCtx(ctx, signature, nsDSig, "DigestMethod", func(_ etreeutils.NSContext, method *etree.Element) error {
+			if _, ok := digestAlgorithms[method.SelectAttrValue("Algorithm", "")]; !ok {
+				return fmt.Errorf("unsupported digest method %q, SHA-1 is not accepted", method.SelectAttrValue("Algorithm", ""))
+			}
+			return nil
+		})
+	})
+}
+
+// detach returns a copy of the element declaring the namespaces its ancestors declare
+func detach(el *etree.Element) (*etree.Element, error) {
+	ctx, err := etreeutils.NSBuildParentContext(el)
+	if err != nil {
+		return nil, err
+	}
+	return etreeutils.NSDetatch(ctx, el)
+}
+
+// verifyWithCertificates checks the signature of the hashed content with the public key of any of the
+// certificates. XML signatures encode the ECDSA signatures as r and s concatenated, the ASN.1 encoding some
+// implementations use in the redirect binding is accepted too.
+func verifyWithCertificates(certificates []*x509.Certificate, hash crypto.Hash, hashed, sig []byte) error {
+	if len(certificates) == 0 {
+		return errors.New("no certificate to verify the signature")
+	}
+	for _, cert := range certificates {
+		switch key := cert.PublicKey.(type) {
+		case *rsa.PublicKey:
+			if rsa.VerifyPKCS1v15(key, hash, hashed, sig) == nil {
+				return nil
+			}
+		case *ecdsa.PublicKey:
+			if len(sig)%2 == 0 {
+				r, s := new(big.Int).SetBytes(sig[:len(sig)/2]), new(big.Int).SetBytes(sig[len(sig)/2:])
+				if ecdsa.Verify(key, hashed, r, s) {
+					return nil
+				}
+			}
+			if ecdsa.VerifyASN1(key, hashed, sig) {
+				return nil
+			}
+		}
+	}
+	return errors.New("signature verification failed")
+}
+
+// signatureAlgorithm returns the XML signature algorithm matching the key
+func signatureAlgorithm(key crypto.Signer) (string, error) {
+	switch key.Public().(type) {
+	case *rsa.PublicKey:
+		return algSignatureRSASHA256, nil
+	case *ecdsa.PublicKey:
+		return algSignatureECDSASHA256, nil
+	}
+	return "", fmt.Errorf("unsupported key type %T", key.Public())
+}
+
+// signHashed signs a SHA-256 hash with the key, ECDSA signatures are converted to the r and s concatenation
+// of the XML signatures.
+func signHashed(key crypto.Signer, hashed []byte) ([]byte, error) {
+	sig, err := key.Sign(rand.Reader, hashed, crypto.SHA256)
+	if err != nil {
+		return nil, err
+	}
+	ecKey, ok := key.Public().(*ecdsa.PublicKey)
+	if !ok {
+		return sig, nil
+	}
+	var parsed struct{ R, S *big.Int }
+	if _, err := asn1.Unmarshal(sig, &parsed); err != nil {
+		return nil, err
+	}
+	size := (ecKey.Curve.Params().BitSize + 7) / 8
+	raw := make([]byte, 2*size)
+	parsed.R.FillBytes(raw[:size])
+	parsed.S.FillBytes(raw[size:])
+	return raw, nil
+}
+
+// Sign adds an enveloped signature to the element of the document with the ID, after its Issuer as the SAML
+// schema requires, and returns the signed document. The assertions must be signed before the response
+// containing them.
+func Sign(document []byte, id string, key crypto.Signer, cert *x509.Certificate) ([]byte, error) {
+	doc, err := parseEtree(document)
+	if err != nil {
+		return nil, err
+	}
+	el := findByID(doc.Root(), id)
+	if el == nil {
+		return nil, fmt.Errorf("no element with the ID %q", id)
+	}
+
+	var certs [][]byte
+	if cert != nil {
+		certs = append(certs, cert.Raw)
+	}
+	ctx, err := dsig.NewSigningContext(key, certs)
+	if err != nil {
+		return nil, err
+	}
+	ctx.IdAttribute = "ID"
+	ctx.Canonicalizer = dsig.MakeC14N10ExclusiveCanonicalizerWithPrefixList("")
+
+	// the element is signed out of the document, with the namespaces its ancestors declare, the exclusive
+	// canonicalization makes the signature valid once the element is back in the document
+	signed, err := detach(el)
+	if err != nil {
+		return nil, err
+	}
+	signature, err := ctx.ConstructSignature(signed, true)
+	if err != nil {
+		return nil, err
+	}
+	index := 0
+	for _, child := range signed.ChildElements() {
+		if child.Tag == "Issuer" && child.NamespaceURI() == nsSAML {
+			index = child.Index() + 1
+			break
+		}
+	}
+	signed.InsertChildAt(index, signature)
+
+	if parent := el.Parent(); parent != nil {
+		parent.InsertChildAt(el.Index(), signed)
+		parent.RemoveChild(el)
+	} else {
+		doc.SetRoot(signed)
+	}
+	return doc.WriteToBytes()
+}
+
+func decodeBase64(s string) ([]byte, error) {
+	return base64.StdEncoding.DecodeString(strings.Join(strings.Fields(s), ""))
+}
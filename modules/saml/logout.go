@@ -0,0 +1,118 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package saml
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// LogoutRequest is a request of the identity provider to end the session of a user
+type LogoutRequest struct {
+	ID             string
+	NameID         string
+	SessionIndexes []string
+	RelayState     string
+}
+
+// ParseLogoutRequest validates a logout request the identity provider sent to the single logout service,
+// with the HTTP-Redirect or the HTTP-POST binding. The requests must be signed.
+func (sp *ServiceProvider) ParseLogoutRequest(r *http.Request) (*LogoutRequest, error) {
+	message, err := sp.readMessage(r, "SAMLRequest")
+	if err != nil {
+		return nil, err
+	}
+	if !message.is(nsSAMLP, "LogoutRequest") {
+		return nil, errors.New("the message is not a logout request")
+	}
+	if message.child(nsSAML, "Issuer") == nil {
+		return nil, errors.New("the logout request has no issuer")
+	}
+	notOnOrAfter, err := parseTime(message.attr("NotOnOrAfter"))
+	if err != nil {
+		return nil, err
+	} else if !notOnOrAfter.IsZero() && !timeNow().Add(-MaxClockSkew).Before(notOnOrAfter) {
+		return nil, errors.New("the logout request has expired")
+	}
+
+	request := &LogoutRequest{ID: message.attr("ID"), RelayState: r.FormValue("RelayState")}
+	if request.ID == "" {
+		return nil, errors.New("the logout request has no ID")
+	}
+	nameID := message.child(nsSAML, "NameID")
+	if nameID == nil || nameID.text() == "" {
+		return nil, errors.New("the logout request has no NameID")
+	}
+	request.NameID = nameID.text()
+	for _, sessionIndex := range message.childrenNamed(nsSAMLP, "SessionIndex") {
+		request.SessionIndexes = append(request.SessionIndexes, sessionIndex.text())
+	}
+	return request, nil
+}
+
+// ParseLogoutResponse validates the response of the identity provider to the logout request with the ID, and
+// returns its relay state. The responses must be signed.
+func (sp *ServiceProvider) ParseLogoutResponse(r *http.Request, requestID string) (string, error) {
+	message, err := sp.readMessage(r, "SAMLResponse")
+	if err != nil {
+		return "", err
+	}
+	if !message.is(nsSAMLP, "LogoutResponse") {
+		return "", errors.New("the message is not a logout response")
+	}
+	if requestID == "" || message.attr("InResponseTo") != requestID {
+		return "", errors.New("the logout response doesn't answer the logout request")
+	}
+	if err := checkStatus(message); err != nil {
+		return "", err
+	}
+	return r.FormValue("RelayState"), nil
+}
+
+// readMessage reads and verifies a message sent to the single logout service, the redirect binding signs the
+// query and the POST binding the message itself
+func (sp *ServiceProvider) readMessage(r *http.Request, param string) (*element, error) {
+	var message *element
+	if r.Method == http.MethodPost {
+		encoded := r.PostFormValue(param)
+		if encoded == "" {
+			return nil, fmt.Errorf("no %s parameter", param)
+		}
+		data, err := decodeBase64(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("invalid base64 encoding: %w", err)
+		}
+		doc, err := parseEtree(data)
+		if err != nil {
+			return nil, err
+		}
+		signed, err := verifySignature(doc.Root(), sp.IdentityProvider.Certificates)
+		if err != nil {
+			return nil, fmt.Errorf("invalid signature: %w", err)
+		}
+		if message, err = fromEtree(signed); err != nil {
+			return nil, err
+		}
+	} else {
+		encoded := r.URL.Query().Get(param)
+		if encoded == "" {
+			return nil, fmt.Errorf("no %s parameter", param)
+		}
+		if err := verifyRedirectBindingSignature(r.URL.RawQuery, param, sp.IdentityProvider.Certificates); err != nil {
+			return nil, fmt.Errorf("invalid signature: %w", err)
+		}
+		data, err := DecodeRedirectBinding(encoded)
+		if err != nil {
+			return nil, err
+		}
+		if message, err = parseXML(data); err != nil {
+			return nil, err
+		}
+	}
+	if err := sp.checkMessage(message, sp.SingleLogoutServiceURL); err != nil {
+		return nil, err
+	}
+	return message, nil
+}
@@ -0,0 +1,206 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package saml
+
+import (
+	"crypto"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"time"
+)
+
+const (
+	nsMetadata = "urn:oasis:names:tc:SAML:2.0:metadata"
+	nsSAML     = "urn:oasis:names:tc:SAML:2.0:assertion"
+	nsSAMLP    = "urn:oasis:names:tc:SAML:2.0:protocol"
+
+	BindingHTTPRedirect = "urn:oasis:names:tc:SAML:2.0:bindings:HTTP-Redirect"
+	BindingHTTPPost     = "urn:oasis:names:tc:SAML:2.0:bindings:HTTP-POST"
+
+	NameIDFormatUnspecified  = "urn:oasis:names:tc:SAML:1.1:nameid-format:unspecified"
+	NameIDFormatEmailAddress = "urn:oasis:names:tc:SAML:1.1:nameid-format:emailAddress"
+	NameIDFormatPersistent   = "urn:oasis:names:tc:SAML:2.0:nameid-format:persistent"
+	NameIDFormatTransient    = "urn:oasis:names:tc:SAML:2.0:nameid-format:transient"
+
+	StatusSuccess = "urn:oasis:names:tc:SAML:2.0:status:Success"
+)
+
+// IdentityProvider is what the service provider needs to know about the identity provider, taken from its metadata
+type IdentityProvider struct {
+	EntityID string
+	// SSOURL is the endpoint of the single sign-on service with the HTTP-Redirect binding
+	SSOURL string
+	// SLOURL is the endpoint of the single logout service with the HTTP-Redirect binding, empty when the
+	// identity provider doesn't support the single logout
+	SLOURL string
+	// Certificates are the certificates the identity provider signs with, several ones during a key rollover
+	Certificates []*x509.Certificate
+}
+
+// ParseIdentityProviderMetadata reads the metadata of an identity provider, an EntityDescriptor or the first
+// identity provider of an EntitiesDescriptor.
+func ParseIdentityProviderMetadata(data []byte) (*IdentityProvider, error) {
+	root, err := parseXML(data)
+	if err != nil {
+		return nil, err
+	}
+	descriptor := findIdentityProviderDescriptor(root)
+	if descriptor == nil {
+		return nil, errors.New("the metadata doesn't describe an identity provider")
+	}
+	entity := descriptor.parent
+
+	idp := &IdentityProvider{EntityID: entity.attr("entityID")}
+	if idp.EntityID == "" {
+		return nil, errors.New("the metadata has no entityID")
+	}
+	for _, sso := range descriptor.childrenNamed(nsMetadata, "SingleSignOnService") {
+		if sso.attr("Binding") == BindingHTTPRedirect {
+			idp.SSOURL = sso.attr("Location")
+			break
+		}
+	}
+	if idp.SSOURL == "" {
+		return nil, errors.New("the identity provider has no single sign-on service with the HTTP-Redirect binding")
+	}
+	for _, slo := range descriptor.childrenNamed(nsMetadata, "SingleLogoutService") {
+		if slo.attr("Binding") == BindingHTTPRedirect {
+			idp.SLOURL = slo.attr("Location")
+			break
+		}
+	}
+	for _, keyDescriptor := range descriptor.childrenNamed(nsMetadata, "KeyDescriptor") {
+		if use := keyDescriptor.attr("use"); use != "" && use != "signing" {
+			continue
+		}
+		keyInfo := keyDescriptor.child(nsDSig, "KeyInfo")
+		if keyInfo == nil {
+			continue
+		}
+		for _, x509Data := range keyInfo.childrenNamed(nsDSig, "X509Data") {
+			for _, encoded := range x509Data.childrenNamed(nsDSig, "X509Certificate") {
+				der, err := decodeBase64(encoded.text())
+				if err != nil {
+					return nil, fmt.Errorf("invalid certificate: %w", err)
+				}
+				cert, err := x509.ParseCertificate(der)
+				if err != nil {
+					return nil, fmt.Errorf("invalid certificate: %w", err)
+				}
+				idp.Certificates = append(idp.Certificates, cert)
+			}
+		}
+	}
+	if len(idp.Certificates) == 0 {
+		return nil, errors.New("the identity provider has no signing certificate")
+	}
+	return idp, nil
+}
+
+func findIdentityProviderDescriptor(el *element) *element {
+	switch {
+	case el.is(nsMetadata, "EntityDescriptor"):
+		return el.child(nsMetadata, "IDPSSODescriptor")
+	case el.is(nsMetadata, "EntitiesDescriptor"):
+		for _, c := range el.children {
+			if child, ok := c.(*element); ok {
+				if descriptor := findIdentityProviderDescriptor(child); descriptor != nil {
+					return descriptor
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// ServiceProvider validates the messages of an identity provider and creates the ones sent to it
+type ServiceProvider struct {
+	EntityID                    string
+	AssertionConsumerServiceURL string
+	SingleLogoutServiceURL      string
+	// NameIDFormat is requested in the authentication requests, any format is accepted when it is empty
+	NameIDFormat string
+	// Key signs the requests sent to the identity provider when it is set, and Certificate is then published
+	// in the metadata for the identity provider to verify them
+	Key         crypto.Signer
+	Certificate *x509.Certificate
+	// AllowIdPInitiated accepts the responses the identity provider sends without a request of the service provider
+	AllowIdPInitiated bool
+
+	IdentityProvider *IdentityProvider
+}
+
+// MaxClockSkew is the difference tolerated between the clocks of the service and identity providers
+const MaxClockSkew = 3 * time.Minute
+
+var timeNow = time.Now
+
+type metadataKeyInfo struct {
+	XMLName     xml.Name `xml:"http://www.w3.org/2000/09/xmldsig# KeyInfo"`
+	Certificate string   `xml:"X509Data>X509Certificate"`
+}
+
+type metadataKeyDescriptor struct {
+	Use     string          `xml:"use,attr"`
+	KeyInfo metadataKeyInfo `xml:"KeyInfo"`
+}
+
+type metadataEndpoint struct {
+	Binding  string `xml:"Binding,attr"`
+	Location string `xml:"Location,attr"`
+	Index    *int   `xml:"index,attr,omitempty"`
+}
+
+type metadataSPSSODescriptor struct {
+	AuthnRequestsSigned        bool                    `xml:"AuthnRequestsSigned,attr"`
+	WantAssertionsSigned       bool                    `xml:"WantAssertionsSigned,attr"`
+	ProtocolSupportEnumeration string                  `xml:"protocolSupportEnumeration,attr"`
+	KeyDescriptors             []metadataKeyDescriptor `xml:"KeyDescriptor"`
+	SingleLogoutServices       []metadataEndpoint      `xml:"SingleLogoutService"`
+	NameIDFormats              []string                `xml:"NameIDFormat"`
+	AssertionConsumerServices  []metadataEndpoint      `xml:"AssertionConsumerService"`
+}
+
+type metadataEntityDescriptor struct {
+	XMLName         xml.Name                `xml:"urn:oasis:names:tc:SAML:2.0:metadata EntityDescriptor"`
+	EntityID        string                  `xml:"entityID,attr"`
+	SPSSODescriptor metadataSPSSODescriptor `xml:"SPSSODescriptor"`
+}
+
+// Metadata returns the metadata of the service provider, to be imported by the identity provider
+func (sp *ServiceProvider) Metadata() ([]byte, error) {
+	index := 0
+	descriptor := metadataSPSSODescriptor{
+		AuthnRequestsSigned:        sp.Key != nil,
+		WantAssertionsSigned:       true,
+		ProtocolSupportEnumeration: nsSAMLP,
+		AssertionConsumerServices: []metadataEndpoint{
+			{Binding: BindingHTTPPost, Location: sp.AssertionConsumerServiceURL, Index: &index},
+		},
+	}
+	if sp.Key != nil && sp.Certificate != nil {
+		descriptor.KeyDescriptors = append(descriptor.KeyDescriptors, metadataKeyDescriptor{
+			Use:     "signing",
+			KeyInfo: metadataKeyInfo{Certificate: base64.StdEncoding.EncodeToString(sp.Certificate.Raw)},
+		})
+	}
+	if sp.SingleLogoutServiceURL != "" {
+		descriptor.SingleLogoutServices = []metadataEndpoint{
+			{Binding: BindingHTTPRedirect, Location: sp.SingleLogoutServiceURL},
+			{Binding: BindingHTTPPost, Location: sp.SingleLogoutServiceURL},
+		}
+	}
+	if sp.NameIDFormat != "" {
+		descriptor.NameIDFormats = []string{sp.NameIDFormat}
+	}
+
+	data, err := xml.MarshalIndent(metadataEntityDescriptor{EntityID: sp.EntityID, SPSSODescriptor: descriptor}, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), data...), nil
+}
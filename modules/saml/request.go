@@ -0,0 +1,146 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package saml
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"time"
+)
+
+// NewID returns a random identifier for a message, SAML identifiers must not start with a digit
+func NewID() string {
+	b := make([]byte, 20)
+	_, _ = rand.Read(b)
+	return "_" + hex.EncodeToString(b)
+}
+
+// FormatTime formats a time as the xs:dateTime values of the messages
+func FormatTime(t time.Time) string {
+	return t.UTC().Format("2006-01-02T15:04:05Z")
+}
+
+type messageIssuer struct {
+	XMLName xml.Name `xml:"urn:oasis:names:tc:SAML:2.0:assertion Issuer"`
+	Value   string   `xml:",chardata"`
+}
+
+type messageNameID struct {
+	XMLName xml.Name `xml:"urn:oasis:names:tc:SAML:2.0:assertion NameID"`
+	Format  string   `xml:"Format,attr,omitempty"`
+	Value   string   `xml:",chardata"`
+}
+
+type messageNameIDPolicy struct {
+	Format      string `xml:"Format,attr,omitempty"`
+	AllowCreate bool   `xml:"AllowCreate,attr"`
+}
+
+type messageStatusCode struct {
+	Value string `xml:"Value,attr"`
+}
+
+type authnRequest struct {
+	XMLName                     xml.Name             `xml:"urn:oasis:names:tc:SAML:2.0:protocol AuthnRequest"`
+	ID                          string               `xml:"ID,attr"`
+	Version                     string               `xml:"Version,attr"`
+	IssueInstant                string               `xml:"IssueInstant,attr"`
+	Destination                 string               `xml:"Destination,attr"`
+	AssertionConsumerServiceURL string               `xml:"AssertionConsumerServiceURL,attr"`
+	ProtocolBinding             string               `xml:"ProtocolBinding,attr"`
+	Issuer                      messageIssuer        `xml:"Issuer"`
+	NameIDPolicy                *messageNameIDPolicy `xml:"NameIDPolicy,omitempty"`
+}
+
+type logoutRequest struct {
+	XMLName      xml.Name      `xml:"urn:oasis:names:tc:SAML:2.0:protocol LogoutRequest"`
+	ID           string        `xml:"ID,attr"`
+	Version      string        `xml:"Version,attr"`
+	IssueInstant string        `xml:"IssueInstant,attr"`
+	Destination  string        `xml:"Destination,attr"`
+	Issuer       messageIssuer `xml:"Issuer"`
+	NameID       messageNameID `xml:"NameID"`
+	SessionIndex string        `xml:"SessionIndex,omitempty"`
+}
+
+type logoutResponse struct {
+	XMLName      xml.Name          `xml:"urn:oasis:names:tc:SAML:2.0:protocol LogoutResponse"`
+	ID           string            `xml:"ID,attr"`
+	Version      string            `xml:"Version,attr"`
+	IssueInstant string            `xml:"IssueInstant,attr"`
+	Destination  string            `xml:"Destination,attr"`
+	InResponseTo string            `xml:"InResponseTo,attr"`
+	Issuer       messageIssuer     `xml:"Issuer"`
+	StatusCode   messageStatusCode `xml:"Status>StatusCode"`
+}
+
+// AuthnRequestURL returns the URL sending an authentication request to the identity provider with the
+// HTTP-Redirect binding, and the ID of the request the response must be in response to
+func (sp *ServiceProvider) AuthnRequestURL(relayState string) (redirectURL, requestID string, err error) {
+	request := authnRequest{
+		ID:                          NewID(),
+		Version:                     "2.0",
+		IssueInstant:                FormatTime(timeNow()),
+		Destination:                 sp.IdentityProvider.SSOURL,
+		AssertionConsumerServiceURL: sp.AssertionConsumerServiceURL,
+		ProtocolBinding:             BindingHTTPPost,
+		Issuer:                      messageIssuer{Value: sp.EntityID},
+	}
+	if sp.NameIDFormat != "" {
+		request.NameIDPolicy = &messageNameIDPolicy{Format: sp.NameIDFormat, AllowCreate: true}
+	}
+	message, err := xml.Marshal(request)
+	if err != nil {
+		return "", "", err
+	}
+	redirectURL, err = RedirectBindingURL(sp.IdentityProvider.SSOURL, "SAMLRequest", message, relayState, sp.Key)
+	return redirectURL, request.ID, err
+}
+
+// LogoutRequestURL returns the URL sending a logout request for the session to the identity provider with
+// the HTTP-Redirect binding, and the ID of the request
+func (sp *ServiceProvider) LogoutRequestURL(nameID, nameIDFormat, sessionIndex, relayState string) (redirectURL, requestID string, err error) {
+	if sp.IdentityProvider.SLOURL == "" {
+		return "", "", errors.New("the identity provider doesn't support the single logout")
+	}
+	request := logoutRequest{
+		ID:           NewID(),
+		Version:      "2.0",
+		IssueInstant: FormatTime(timeNow()),
+		Destination:  sp.IdentityProvider.SLOURL,
+		Issuer:       messageIssuer{Value: sp.EntityID},
+		NameID:       messageNameID{Format: nameIDFormat, Value: nameID},
+		SessionIndex: sessionIndex,
+	}
+	message, err := xml.Marshal(request)
+	if err != nil {
+		return "", "", err
+	}
+	redirectURL, err = RedirectBindingURL(sp.IdentityProvider.SLOURL, "SAMLRequest", message, relayState, sp.Key)
+	return redirectURL, request.ID, err
+}
+
+// LogoutResponseURL returns the URL answering a logout request of the identity provider with the
+// HTTP-Redirect binding
+func (sp *ServiceProvider) LogoutResponseURL(inResponseTo, relayState string) (string, error) {
+	if sp.IdentityProvider.SLOURL == "" {
+		return "", errors.New("the identity provider doesn't support the single logout")
+	}
+	response := logoutResponse{
+		ID:           NewID(),
+		Version:      "2.0",
+		IssueInstant: FormatTime(timeNow()),
+		Destination:  sp.IdentityProvider.SLOURL,
+		InResponseTo: inResponseTo,
+		Issuer:       messageIssuer{Value: sp.EntityID},
+		StatusCode:   messageStatusCode{Value: StatusSuccess},
+	}
+	message, err := xml.Marshal(response)
+	if err != nil {
+		return "", err
+	}
+	return RedirectBindingURL(sp.IdentityProvider.SLOURL, "SAMLResponse", message, relayState, sp.Key)
+}
@@ -0,0 +1,264 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package saml
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+const confirmationMethodBearer = "urn:oasis:names:tc:SAML:2.0:cm:bearer"
+
+// Assertion is the authenticated identity a response of the identity provider carries
+type Assertion struct {
+	ID           string
+	NameID       string
+	NameIDFormat string
+	// SessionIndex identifies the session at the identity provider, for the single logout
+	SessionIndex string
+	// Attributes are indexed by their Name, and by their FriendlyName too when they have one
+	Attributes map[string][]string
+	// ExpiresAt is the time after which the assertion must not be accepted anymore, to remember the assertions
+	// already seen until then
+	ExpiresAt time.Time
+}
+
+// Attribute returns the first value of the attribute, or an empty string
+func (a *Assertion) Attribute(name string) string {
+	if values := a.Attributes[name]; len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// ParseResponse validates the SAMLResponse form value the identity provider posted to the assertion consumer
+// service and returns its assertion. The request ID is the one of the authentication request the response
+// must answer, empty for a response the identity provider sent on its own.
+func (sp *ServiceProvider) ParseResponse(samlResponse, requestID string) (*Assertion, error) {
+	data, err := decodeBase64(samlResponse)
+	if err != nil {
+		return nil, fmt.Errorf("invalid base64 encoding: %w", err)
+	}
+	doc, err := parseEtree(data)
+	if err != nil {
+		return nil, err
+	}
+
+	// the response and the assertion are read from what the signatures cover only
+	root := doc.Root()
+	responseSigned := false
+	if signed, err := verifySignature(root, sp.IdentityProvider.Certificates); err == nil {
+		root, responseSigned = signed, true
+	} else if !errors.Is(err, errNotSigned) {
+		return nil, fmt.Errorf("invalid response signature: %w", err)
+	}
+	response, err := fromEtree(root)
+	if err != nil {
+		return nil, err
+	}
+	if !response.is(nsSAMLP, "Response") {
+		return nil, errors.New("the message is not a response")
+	}
+	if err := sp.checkMessage(response, sp.AssertionConsumerServiceURL); err != nil {
+		return nil, err
+	}
+
+	inResponseTo := response.attr("InResponseTo")
+	if inResponseTo == "" && !sp.AllowIdPInitiated {
+		return nil, errors.New("the responses initiated by the identity provider are not allowed")
+	} else if inResponseTo != "" && inResponseTo != requestID {
+		return nil, errors.New("the response doesn't answer the authentication request")
+	}
+	if err := checkStatus(response); err != nil {
+		return nil, err
+	}
+
+	if response.child(nsSAML, "EncryptedAssertion") != nil {
+		return nil, errors.New("encrypted assertions are not supported")
+	}
+	assertions := childElements(root, nsSAML, "Assertion")
+	if len(assertions) != 1 {
+		return nil, errors.New("the response must contain exactly one assertion")
+	}
+	signedAssertion, err := verifySignature(assertions[0], sp.IdentityProvider.Certificates)
+	if errors.Is(err, errNotSigned) {
+		if !responseSigned {
+			return nil, errors.New("neither the response nor the assertion is signed")
+		}
+		signedAssertion = assertions[0]
+	} else if err != nil {
+		return nil, fmt.Errorf("invalid assertion signature: %w", err)
+	}
+	assertion, err := fromEtree(signedAssertion)
+	if err != nil {
+		return nil, err
+	}
+
+	return sp.parseAssertion(assertion, inResponseTo)
+}
+
+// checkMessage checks the issuer and the destination of a message of the identity provider
+func (sp *ServiceProvider) checkMessage(message *element, destination string) error {
+	if issuer := message.child(nsSAML, "Issuer"); issuer != nil && issuer.text() != sp.IdentityProvider.EntityID {
+		return fmt.Errorf("unexpected issuer %q", issuer.text())
+	}
+	if dest := message.attr("Destination"); dest != "" && dest != destination {
+		return fmt.Errorf("unexpected destination %q", dest)
+	}
+	if version := message.attr("Version"); version != "2.0" {
+		return fmt.Errorf("unsupported SAML version %q", version)
+	}
+	return nil
+}
+
+func checkStatus(message *element) error {
+	status := message.child(nsSAMLP, "Status")
+	if status == nil {
+		return errors.New("the message has no status")
+	}
+	code := status.child(nsSAMLP, "StatusCode")
+	if code == nil {
+		return errors.New("the message has no status code")
+	}
+	if code.attr("Value") != StatusSuccess {
+		if message := status.child(nsSAMLP, "StatusMessage"); message != nil {
+			return fmt.Errorf("the identity provider returned the status %q: %s", code.attr("Value"), message.text())
+		}
+		return fmt.Errorf("the identity provider returned the status %q", code.attr("Value"))
+	}
+	return nil
+}
+
+func (sp *ServiceProvider) parseAssertion(assertion *element, inResponseTo string) (*Assertion, error) {
+	now := timeNow()
+	result := &Assertion{ID: assertion.attr("ID"), Attributes: map[string][]string{}}
+	if result.ID == "" {
+		return nil, errors.New("the assertion has no ID")
+	}
+	issuer := assertion.child(nsSAML, "Issuer")
+	if issuer == nil || issuer.text() != sp.IdentityProvider.EntityID {
+		return nil, errors.New("the assertion is not issued by the identity provider")
+	}
+
+	conditions := assertion.child(nsSAML, "Conditions")
+	if conditions == nil {
+		return nil, errors.New("the assertion has no conditions")
+	}
+	notBefore, notOnOrAfter, err := validityPeriod(conditions)
+	if err != nil {
+		return nil, err
+	}
+	if !notBefore.IsZero() && now.Add(MaxClockSkew).Before(notBefore) {
+		return nil, errors.New("the assertion is not valid yet")
+	}
+	if !notOnOrAfter.IsZero() {
+		if !now.Add(-MaxClockSkew).Before(notOnOrAfter) {
+			return nil, errors.New("the assertion has expired")
+		}
+		result.ExpiresAt = notOnOrAfter
+	}
+	// an assertion without audience restriction could be replayed to any service provider of the identity
+	// provider, each of its audience restrictions must include this one
+	restrictions := conditions.childrenNamed(nsSAML, "AudienceRestriction")
+	if len(restrictions) == 0 {
+		return nil, errors.New("the assertion has no audience restriction")
+	}
+	for _, restriction := range restrictions {
+		found := false
+		for _, audience := range restriction.childrenNamed(nsSAML, "Audience") {
+			found = found || audience.text() == sp.EntityID
+		}
+		if !found {
+			return nil, errors.New("the service provider is not an audience of the assertion")
+		}
+	}
+
+	subject := assertion.child(nsSAML, "Subject")
+	if subject == nil {
+		return nil, errors.New("the assertion has no subject")
+	}
+	nameID := subject.child(nsSAML, "NameID")
+	if nameID == nil || nameID.text() == "" {
+		return nil, errors.New("the assertion has no NameID")
+	}
+	result.NameID, result.NameIDFormat = nameID.text(), nameID.attr("Format")
+
+	confirmed := false
+	for _, confirmation := range subject.childrenNamed(nsSAML, "SubjectConfirmation") {
+		if confirmation.attr("Method") != confirmationMethodBearer {
+			continue
+		}
+		data := confirmation.child(nsSAML, "SubjectConfirmationData")
+		if data == nil {
+			continue
+		}
+		if recipient := data.attr("Recipient"); recipient != sp.AssertionConsumerServiceURL {
+			continue
+		}
+		if data.attr("InResponseTo") != inResponseTo {
+			continue
+		}
+		notOnOrAfter, err := parseTime(data.attr("NotOnOrAfter"))
+		if err != nil || notOnOrAfter.IsZero() || !now.Add(-MaxClockSkew).Before(notOnOrAfter) {
+			continue
+		}
+		if result.ExpiresAt.IsZero() || notOnOrAfter.Before(result.ExpiresAt) {
+			result.ExpiresAt = notOnOrAfter
+		}
+		confirmed = true
+		break
+	}
+	if !confirmed {
+		return nil, errors.New("the assertion has no valid bearer subject confirmation")
+	}
+
+	authnStatement := assertion.child(nsSAML, "AuthnStatement")
+	if authnStatement == nil {
+		return nil, errors.New("the assertion has no authentication statement")
+	}
+	result.SessionIndex = authnStatement.attr("SessionIndex")
+	if sessionNotOnOrAfter, err := parseTime(authnStatement.attr("SessionNotOnOrAfter")); err != nil {
+		return nil, err
+	} else if !sessionNotOnOrAfter.IsZero() && !now.Add(-MaxClockSkew).Before(sessionNotOnOrAfter) {
+		return nil, errors.New("the session at the identity provider has expired")
+	}
+
+	for _, statement := range assertion.childrenNamed(nsSAML, "AttributeStatement") {
+		for _, attribute := range statement.childrenNamed(nsSAML, "Attribute") {
+			var values []string
+			for _, value := range attribute.childrenNamed(nsSAML, "AttributeValue") {
+				values = append(values, value.text())
+			}
+			name, friendlyName := attribute.attr("Name"), attribute.attr("FriendlyName")
+			if name != "" {
+				result.Attributes[name] = append(result.Attributes[name], values...)
+			}
+			if friendlyName != "" && friendlyName != name {
+				result.Attributes[friendlyName] = append(result.Attributes[friendlyName], values...)
+			}
+		}
+	}
+	return result, nil
+}
+
+func validityPeriod(el *element) (notBefore, notOnOrAfter time.Time, err error) {
+	if notBefore, err = parseTime(el.attr("NotBefore")); err != nil {
+		return notBefore, notOnOrAfter, err
+	}
+	notOnOrAfter, err = parseTime(el.attr("NotOnOrAfter"))
+	return notBefore, notOnOrAfter, err
+}
+
+// parseTime parses a xs:dateTime value, an empty value is the zero time
+func parseTime(s string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	t, err := time.Parse(time.RFC3339Nano, s)
+	if err != nil {
+		return t, fmt.Errorf("invalid time %q: %w", s, err)
+	}
+	return t, nil
+}
@@ -0,0 +1,312 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package saml_test
+
+import (
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"gitea.dev/modules/saml"
+	"gitea.dev/modules/saml/samltest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const spEntityID = "https://gitea.example.com/user/saml/corp/metadata"
+
+func newTestProviders(t *testing.T) (*samltest.IdentityProvider, *saml.ServiceProvider) {
+	idp, err := samltest.NewIdentityProvider("https://idp.example.com")
+	require.NoError(t, err)
+	metadata, err := saml.ParseIdentityProviderMetadata(idp.Metadata())
+	require.NoError(t, err)
+	return idp, &saml.ServiceProvider{
+		EntityID:                    spEntityID,
+		AssertionConsumerServiceURL: "https://gitea.example.com/user/saml/corp/acs",
+		SingleLogoutServiceURL:      "https://gitea.example.com/user/saml/corp/slo",
+		NameIDFormat:                saml.NameIDFormatPersistent,
+		IdentityProvider:            metadata,
+	}
+}
+
+func TestParseIdentityProviderMetadata(t *testing.T) {
+	idp, sp := newTestProviders(t)
+	assert.Equal(t, idp.EntityID, sp.IdentityProvider.EntityID)
+	assert.Equal(t, idp.SSOURL(), sp.IdentityProvider.SSOURL)
+	assert.Equal(t, idp.SLOURL(), sp.IdentityProvider.SLOURL)
+	require.Len(t, sp.IdentityProvider.Certificates, 1)
+	assert.True(t, idp.Certificate.Equal(sp.IdentityProvider.Certificates[0]))
+
+	wrapped := `<EntitiesDescriptor xmlns="urn:oasis:names:tc:SAML:2.0:metadata">` +
+		strings.TrimPrefix(string(idp.Metadata()), `<?xml version="1.0" encoding="UTF-8"?>`) + `</EntitiesDescriptor>`
+	metadata, err := saml.ParseIdentityProviderMetadata([]byte(wrapped))
+	require.NoError(t, err)
+	assert.Equal(t, idp.EntityID, metadata.EntityID)
+
+	_, err = saml.ParseIdentityProviderMetadata([]byte(`<EntityDescriptor xmlns="urn:oasis:names:tc:SAML:2.0:metadata" entityID="x"/>`))
+	assert.Error(t, err)
+}
+
+func TestServiceProviderMetadata(t *testing.T) {
+	_, sp := newTestProviders(t)
+	metadata, err := sp.Metadata()
+	require.NoError(t, err)
+	assert.Contains(t, string(metadata), `entityID="`+spEntityID+`"`)
+	assert.Contains(t, string(metadata), `Location="https://gitea.example.com/user/saml/corp/acs"`)
+	assert.Contains(t, string(metadata), `AuthnRequestsSigned="false"`)
+}
+
+func TestLogin(t *testing.T) {
+	idp, sp := newTestProviders(t)
+
+	redirectURL, requestID, err := sp.AuthnRequestURL("/explore")
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(redirectURL, idp.SSOURL()+"?SAMLRequest="))
+	request, err := idp.ParseAuthnRequest(redirectURL)
+	require.NoError(t, err)
+	assert.Equal(t, requestID, request.ID)
+	assert.Equal(t, spEntityID, request.Issuer)
+	assert.Equal(t, sp.AssertionConsumerServiceURL, request.AssertionConsumerServiceURL)
+	assert.Equal(t, saml.NameIDFormatPersistent, request.NameIDPolicy.Format)
+	assert.Equal(t, "/explore", request.RelayState)
+	assert.False(t, request.Signed)
+
+	options := samltest.ResponseOptions{
+		InResponseTo: requestID,
+		Destination:  sp.AssertionConsumerServiceURL,
+		Audience:     spEntityID,
+		NameID:       "u-1234",
+		NameIDFormat: saml.NameIDFormatPersistent,
+		SessionIndex: "session-1",
+		Attributes: map[string][]string{
+			"uid":    {"alice"},
+			"mail":   {"alice@example.com"},
+			"groups": {"developers", "admins"},
+		},
+		SignAssertion: true,
+	}
+
+	t.Run("SignedAssertion", func(t *testing.T) {
+		response, err := idp.Response(options)
+		require.NoError(t, err)
+		assertion, err := sp.ParseResponse(response, requestID)
+		require.NoError(t, err)
+		assert.Equal(t, "u-1234", assertion.NameID)
+		assert.Equal(t, saml.NameIDFormatPersistent, assertion.NameIDFormat)
+		assert.Equal(t, "session-1", assertion.SessionIndex)
+		assert.Equal(t, "alice", assertion.Attribute("uid"))
+		assert.Equal(t, "alice@example.com", assertion.Attribute("mail"))
+		assert.Equal(t, []string{"developers", "admins"}, assertion.Attributes["groups"])
+		assert.Empty(t, assertion.Attribute("missing"))
+		assert.True(t, assertion.ExpiresAt.After(time.Now()))
+	})
+
+	t.Run("SignedResponse", func(t *testing.T) {
+		opts := options
+		opts.SignAssertion, opts.SignResponse = false, true
+		response, err := idp.Response(opts)
+		require.NoError(t, err)
+		_, err = sp.ParseResponse(response, requestID)
+		require.NoError(t, err)
+
+		opts.SignAssertion = true
+		response, err = idp.Response(opts)
+		require.NoError(t, err)
+		_, err = sp.ParseResponse(response, requestID)
+		require.NoError(t, err)
+	})
+
+	t.Run("Unsigned", func(t *testing.T) {
+		opts := options
+		opts.SignAssertion = false
+		response, err := idp.Response(opts)
+		require.NoError(t, err)
+		_, err = sp.ParseResponse(response, requestID)
+		assert.ErrorContains(t, err, "neither the response nor the assertion is signed")
+	})
+
+	t.Run("Tampered", func(t *testing.T) {
+		response, err := idp.Response(options)
+		require.NoError(t, err)
+		data, err := base64.StdEncoding.DecodeString(response)
+		require.NoError(t, err)
+		tampered := strings.Replace(string(data), ">u-1234<", ">admin<", 1)
+		require.NotEqual(t, string(data), tampered)
+		_, err = sp.ParseResponse(base64.StdEncoding.EncodeToString([]byte(tampered)), requestID)
+		assert.ErrorContains(t, err, "invalid assertion signature")
+	})
+
+	t.Run("SignedByAnotherKey", func(t *testing.T) {
+		other, err := samltest.NewIdentityProvider(idp.EntityID)
+		require.NoError(t, err)
+		response, err := other.Response(options)
+		require.NoError(t, err)
+		_, err = sp.ParseResponse(response, requestID)
+		assert.ErrorContains(t, err, "signature verification failed")
+	})
+
+	t.Run("SHA1", func(t *testing.T) {
+		response, err := idp.Response(options)
+		require.NoError(t, err)
+		data, err := base64.StdEncoding.DecodeString(response)
+		require.NoError(t, err)
+		sha1 := strings.Replace(string(data), "http://www.w3.org/2001/04/xmldsig-more#rsa-sha256", "http://www.w3.org/2000/09/xmldsig#rsa-sha1", 1)
+		require.NotEqual(t, string(data), sha1)
+		_, err = sp.ParseResponse(base64.StdEncoding.EncodeToString([]byte(sha1)), requestID)
+		assert.ErrorContains(t, err, "SHA-1 is not accepted")
+	})
+
+	t.Run("Audience", func(t *testing.T) {
+		opts := options
+		opts.Audience = ""
+		response, err := idp.Response(opts)
+		require.NoError(t, err)
+		_, err = sp.ParseResponse(response, requestID)
+		assert.ErrorContains(t, err, "the assertion has no audience restriction")
+
+		opts = options
+		opts.OmitConditions = true
+		response, err = idp.Response(opts)
+		require.NoError(t, err)
+		_, err = sp.ParseResponse(response, requestID)
+		assert.ErrorContains(t, err, "the assertion has no conditions")
+	})
+
+	t.Run("InvalidConditions", func(t *testing.T) {
+		for name, modify := range map[string]func(*samltest.ResponseOptions){
+			"other request":  func(o *samltest.ResponseOptions) { o.InResponseTo = "_other" },
+			"other audience": func(o *samltest.ResponseOptions) { o.Audience = "https://other.example.com" },
+			"no audience":    func(o *samltest.ResponseOptions) { o.Audience = "" },
+			"no conditions":  func(o *samltest.ResponseOptions) { o.OmitConditions = true },
+			"other issuer":   func(o *samltest.ResponseOptions) { o.Issuer = "https://evil.example.com" },
+			"other destination": func(o *samltest.ResponseOptions) {
+				o.Destination = "https://other.example.com/acs"
+			},
+			"expired":     func(o *samltest.ResponseOptions) { o.IssueInstant = time.Now().Add(-time.Hour) },
+			"not yet":     func(o *samltest.ResponseOptions) { o.IssueInstant = time.Now().Add(time.Hour) },
+			"no name id":  func(o *samltest.ResponseOptions) { o.NameID = "" },
+			"idp started": func(o *samltest.ResponseOptions) { o.InResponseTo = "" },
+		} {
+			opts := options
+			modify(&opts)
+			response, err := idp.Response(opts)
+			require.NoError(t, err)
+			_, err = sp.ParseResponse(response, requestID)
+			assert.Error(t, err, name)
+		}
+	})
+}
+
+func TestIdPInitiatedLogin(t *testing.T) {
+	idp, sp := newTestProviders(t)
+	response, err := idp.Response(samltest.ResponseOptions{
+		Destination:   sp.AssertionConsumerServiceURL,
+		Audience:      spEntityID,
+		NameID:        "u-1234",
+		SignAssertion: true,
+	})
+	require.NoError(t, err)
+
+	_, err = sp.ParseResponse(response, "")
+	assert.ErrorContains(t, err, "not allowed")
+
+	sp.AllowIdPInitiated = true
+	assertion, err := sp.ParseResponse(response, "")
+	require.NoError(t, err)
+	assert.Equal(t, "u-1234", assertion.NameID)
+
+	// a response to a request can't be replayed as an IdP-initiated one
+	response, err = idp.Response(samltest.ResponseOptions{
+		InResponseTo:  "_request",
+		Destination:   sp.AssertionConsumerServiceURL,
+		Audience:      spEntityID,
+		NameID:        "u-1234",
+		SignAssertion: true,
+	})
+	require.NoError(t, err)
+	_, err = sp.ParseResponse(response, "")
+	assert.Error(t, err)
+}
+
+func TestSignedAuthnRequest(t *testing.T) {
+	_, sp := newTestProviders(t)
+	certPEM, keyPEM, err := samltest.NewServiceProviderKeyPair()
+	require.NoError(t, err)
+	block, _ := pem.Decode([]byte(keyPEM))
+	key, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+	require.NoError(t, err)
+	block, _ = pem.Decode([]byte(certPEM))
+	cert, err := x509.ParseCertificate(block.Bytes)
+	require.NoError(t, err)
+	sp.Key, sp.Certificate = key, cert
+
+	redirectURL, _, err := sp.AuthnRequestURL("state")
+	require.NoError(t, err)
+	u, err := url.Parse(redirectURL)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"SAMLRequest", "RelayState", "SigAlg", "Signature"}, queryKeys(u.RawQuery))
+
+	metadata, err := sp.Metadata()
+	require.NoError(t, err)
+	assert.Contains(t, string(metadata), `AuthnRequestsSigned="true"`)
+	assert.Contains(t, string(metadata), base64.StdEncoding.EncodeToString(cert.Raw))
+}
+
+func queryKeys(rawQuery string) []string {
+	var keys []string
+	for part := range strings.SplitSeq(rawQuery, "&") {
+		key, _, _ := strings.Cut(part, "=")
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+func TestLogout(t *testing.T) {
+	idp, sp := newTestProviders(t)
+
+	t.Run("IdPInitiated", func(t *testing.T) {
+		redirectURL, requestID, err := idp.LogoutRequestURL(sp.SingleLogoutServiceURL, "u-1234", "session-1", "state")
+		require.NoError(t, err)
+		request, err := sp.ParseLogoutRequest(httptest.NewRequest(http.MethodGet, redirectURL, nil))
+		require.NoError(t, err)
+		assert.Equal(t, requestID, request.ID)
+		assert.Equal(t, "u-1234", request.NameID)
+		assert.Equal(t, []string{"session-1"}, request.SessionIndexes)
+		assert.Equal(t, "state", request.RelayState)
+
+		responseURL, err := sp.LogoutResponseURL(request.ID, request.RelayState)
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(responseURL, idp.SLOURL()+"?SAMLResponse="))
+
+		tampered := strings.Replace(redirectURL, "RelayState=state", "RelayState=other", 1)
+		_, err = sp.ParseLogoutRequest(httptest.NewRequest(http.MethodGet, tampered, nil))
+		assert.ErrorContains(t, err, "invalid signature")
+
+		unsigned := redirectURL[:strings.Index(redirectURL, "&SigAlg=")]
+		_, err = sp.ParseLogoutRequest(httptest.NewRequest(http.MethodGet, unsigned, nil))
+		assert.ErrorContains(t, err, "invalid signature")
+	})
+
+	t.Run("SPInitiated", func(t *testing.T) {
+		redirectURL, requestID, err := sp.LogoutRequestURL("u-1234", saml.NameIDFormatPersistent, "session-1", "")
+		require.NoError(t, err)
+		receivedID, err := idp.ParseLogoutRequest(redirectURL)
+		require.NoError(t, err)
+		assert.Equal(t, requestID, receivedID)
+
+		responseURL, err := idp.LogoutResponseURL(sp.SingleLogoutServiceURL, requestID, "")
+		require.NoError(t, err)
+		_, err = sp.ParseLogoutResponse(httptest.NewRequest(http.MethodGet, responseURL, nil), requestID)
+		require.NoError(t, err)
+		_, err = sp.ParseLogoutResponse(httptest.NewRequest(http.MethodGet, responseURL, nil), "_other")
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,393 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+// Package samltest provides a SAML identity provider stub to test the service providers.
+package samltest
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/url"
+	"strings"
+	"time"
+
+	"gitea.dev/modules/saml"
+)
+
+// IdentityProvider issues signed responses and logout messages like a real identity provider would, with a
+// key generated for it. Its endpoints are not served, the messages are exchanged by the tests themselves.
+type IdentityProvider struct {
+	EntityID    string
+	Key         *rsa.PrivateKey
+	Certificate *x509.Certificate
+}
+
+// NewIdentityProvider creates an identity provider, its single sign-on and single logout services are
+// EntityID+"/sso" and EntityID+"/slo"
+func NewIdentityProvider(entityID string) (*IdentityProvider, error) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return nil, err
+	}
+	cert, err := newCertificate(key, "samltest identity provider")
+	if err != nil {
+		return nil, err
+	}
+	return &IdentityProvider{EntityID: entityID, Key: key, Certificate: cert}, nil
+}
+
+func newCertificate(key *rsa.PrivateKey, commonName string) (*x509.Certificate, error) {
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(24 * time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return nil, err
+	}
+	return x509.ParseCertificate(der)
+}
+
+// NewServiceProviderKeyPair returns a PEM encoded certificate and private key a service provider can sign its
+// requests with
+func NewServiceProviderKeyPair() (certPEM, keyPEM string, err error) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return "", "", err
+	}
+	cert, err := newCertificate(key, "samltest service provider")
+	if err != nil {
+		return "", "", err
+	}
+	certPEM = string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw}))
+	keyPEM = string(pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}))
+	return certPEM, keyPEM, nil
+}
+
+func (idp *IdentityProvider) SSOURL() string {
+	return idp.EntityID + "/sso"
+}
+
+func (idp *IdentityProvider) SLOURL() string {
+	return idp.EntityID + "/slo"
+}
+
+// Metadata returns the metadata of the identity provider
+func (idp *IdentityProvider) Metadata() []byte {
+	var buf []byte
+	buf = fmt.Appendf(buf, `<?xml version="1.0" encoding="UTF-8"?>
+<md:EntityDescriptor xmlns:md="urn:oasis:names:tc:SAML:2.0:metadata" xmlns:ds="http://www.w3.org/2000/09/xmldsig#" entityID="%s">
+  <md:IDPSSODescriptor WantAuthnRequestsSigned="false" protocolSupportEnumeration="urn:oasis:names:tc:SAML:2.0:protocol">
+    <md:KeyDescriptor use="signing">
+      <ds:KeyInfo><ds:X509Data><ds:X509Certificate>%s</ds:X509Certificate></ds:X509Data></ds:KeyInfo>
+    </md:KeyDescriptor>
+    <md:SingleLogoutService Binding="%s" Location="%s"/>
+    <md:NameIDFormat>%s</md:NameIDFormat>
+    <md:SingleSignOnService Binding="%s" Location="%s"/>
+  </md:IDPSSODescriptor>
+</md:EntityDescriptor>
+`, escape(idp.EntityID), base64.StdEncoding.EncodeToString(idp.Certificate.Raw),
+		saml.BindingHTTPRedirect, escape(idp.SLOURL()), saml.NameIDFormatPersistent,
+		saml.BindingHTTPRedirect, escape(idp.SSOURL()))
+	return buf
+}
+
+func escape(s string) string {
+	var sb strings.Builder
+	_ = xml.EscapeText(&sb, []byte(s))
+	return sb.String()
+}
+
+// AuthnRequest is an authentication request a service provider sent
+type AuthnRequest struct {
+	ID                          string `xml:"ID,attr"`
+	Destination                 string `xml:"Destination,attr"`
+	AssertionConsumerServiceURL string `xml:"AssertionConsumerServiceURL,attr"`
+	Issuer                      string `xml:"urn:oasis:names:tc:SAML:2.0:assertion Issuer"`
+	NameIDPolicy                struct {
+		Format string `xml:"Format,attr"`
+	} `xml:"NameIDPolicy"`
+	RelayState string `xml:"-"`
+	Signed     bool   `xml:"-"`
+}
+
+// ParseAuthnRequest reads the authentication request of the URL a service provider redirects to
+func (idp *IdentityProvider) ParseAuthnRequest(redirectURL string) (*AuthnRequest, error) {
+	u, err := url.Parse(redirectURL)
+	if err != nil {
+		return nil, err
+	}
+	query := u.Query()
+	message, err := saml.DecodeRedirectBinding(query.Get("SAMLRequest"))
+	if err != nil {
+		return nil, err
+	}
+	request := &AuthnRequest{}
+	if err := xml.Unmarshal(message, request); err != nil {
+		return nil, err
+	}
+	if request.ID == "" {
+		return nil, errors.New("the authentication request has no ID")
+	}
+	request.RelayState = query.Get("RelayState")
+	request.Signed = query.Get("Signature") != ""
+	return request, nil
+}
+
+// ResponseOptions describe the response the identity provider issues
+type ResponseOptions struct {
+	// InResponseTo is the ID of the authentication request, empty for an IdP-initiated login
+	InResponseTo string
+	// Destination is the assertion consumer service of the service provider
+	Destination string
+	// Audience is the entity ID of the service provider, the assertion has no audience restriction when it is
+	// empty
+	Audience     string
+	NameID       string
+	NameIDFormat string
+	SessionIndex string
+	Attributes   map[string][]string
+
+	// SignResponse signs the response and SignAssertion the assertion, a valid response needs either one
+	SignResponse  bool
+	SignAssertion bool
+	// Issuer overrides the issuer of the assertion
+	Issuer string
+	// IssueInstant is the time of the response, now when it is zero
+	IssueInstant time.Time
+	// Validity is how long the assertion is valid, five minutes when it is zero
+	Validity time.Duration
+	// OmitConditions leaves the Conditions out of the assertion
+	OmitConditions bool
+}
+
+type responseStatus struct {
+	StatusCode struct {
+		Value string `xml:"Value,attr"`
+	} `xml:"StatusCode"`
+}
+
+type assertionAttribute struct {
+	Name   string   `xml:"Name,attr"`
+	Values []string `xml:"AttributeValue"`
+}
+
+type assertionNameID struct {
+	Format string `xml:"Format,attr,omitempty"`
+	Value  string `xml:",chardata"`
+}
+
+type subjectConfirmationData struct {
+	InResponseTo string `xml:"InResponseTo,attr,omitempty"`
+	NotOnOrAfter string `xml:"NotOnOrAfter,attr"`
+	Recipient    string `xml:"Recipient,attr"`
+}
+
+type audienceRestriction struct {
+	Audience string `xml:"Audience"`
+}
+
+type assertionConditions struct {
+	NotBefore           string               `xml:"NotBefore,attr"`
+	NotOnOrAfter        string               `xml:"NotOnOrAfter,attr"`
+	AudienceRestriction *audienceRestriction `xml:"AudienceRestriction"`
+}
+
+type assertion struct {
+	XMLName      xml.Name        `xml:"urn:oasis:names:tc:SAML:2.0:assertion Assertion"`
+	ID           string          `xml:"ID,attr"`
+	Version      string          `xml:"Version,attr"`
+	IssueInstant string          `xml:"IssueInstant,attr"`
+	Issuer       string          `xml:"Issuer"`
+	NameID       assertionNameID `xml:"Subject>NameID"`
+	Confirmation struct {
+		Method string                  `xml:"Method,attr"`
+		Data   subjectConfirmationData `xml:"SubjectConfirmationData"`
+	} `xml:"Subject>SubjectConfirmation"`
+	Conditions     *assertionConditions `xml:"Conditions"`
+	AuthnStatement struct {
+		AuthnInstant string `xml:"AuthnInstant,attr"`
+		SessionIndex string `xml:"SessionIndex,attr,omitempty"`
+		ClassRef     string `xml:"AuthnContext>AuthnContextClassRef"`
+	} `xml:"AuthnStatement"`
+	Attributes []assertionAttribute `xml:"AttributeStatement>Attribute,omitempty"`
+}
+
+type response struct {
+	XMLName      xml.Name       `xml:"urn:oasis:names:tc:SAML:2.0:protocol Response"`
+	ID           string         `xml:"ID,attr"`
+	Version      string         `xml:"Version,attr"`
+	IssueInstant string         `xml:"IssueInstant,attr"`
+	Destination  string         `xml:"Destination,attr"`
+	InResponseTo string         `xml:"InResponseTo,attr,omitempty"`
+	Issuer       messageIssuer  `xml:"Issuer"`
+	Status       responseStatus `xml:"Status"`
+	Assertion    assertion
+}
+
+type messageIssuer struct {
+	XMLName xml.Name `xml:"urn:oasis:names:tc:SAML:2.0:assertion Issuer"`
+	Value   string   `xml:",chardata"`
+}
+
+// Response returns the SAMLResponse form value of a successful authentication
+func (idp *IdentityProvider) Response(opts ResponseOptions) (string, error) {
+	now := opts.IssueInstant
+	if now.IsZero() {
+		now = time.Now()
+	}
+	validity := opts.Validity
+	if validity == 0 {
+		validity = 5 * time.Minute
+	}
+	issuer := opts.Issuer
+	if issuer == "" {
+		issuer = idp.EntityID
+	}
+
+	resp := response{
+		ID:           saml.NewID(),
+		Version:      "2.0",
+		IssueInstant: saml.FormatTime(now),
+		Destination:  opts.Destination,
+		InResponseTo: opts.InResponseTo,
+		Issuer:       messageIssuer{Value: idp.EntityID},
+	}
+	resp.Status.StatusCode.Value = saml.StatusSuccess
+
+	a := &resp.Assertion
+	a.ID = saml.NewID()
+	a.Version = "2.0"
+	a.IssueInstant = saml.FormatTime(now)
+	a.Issuer = issuer
+	a.NameID = assertionNameID{Format: opts.NameIDFormat, Value: opts.NameID}
+	a.Confirmation.Method = "urn:oasis:names:tc:SAML:2.0:cm:bearer"
+	a.Confirmation.Data = subjectConfirmationData{
+		InResponseTo: opts.InResponseTo,
+		NotOnOrAfter: saml.FormatTime(now.Add(validity)),
+		Recipient:    opts.Destination,
+	}
+	if !opts.OmitConditions {
+		a.Conditions = &assertionConditions{
+			NotBefore:    saml.FormatTime(now.Add(-time.Minute)),
+			NotOnOrAfter: saml.FormatTime(now.Add(validity)),
+		}
+		if opts.Audience != "" {
+			a.Conditions.AudienceRestriction = &audienceRestriction{Audience: opts.Audience}
+		}
+	}
+	a.AuthnStatement.AuthnInstant = saml.FormatTime(now)
+	a.AuthnStatement.SessionIndex = opts.SessionIndex
+	a.AuthnStatement.ClassRef = "urn:oasis:names:tc:SAML:2.0:ac:classes:PasswordProtectedTransport"
+	for name, values := range opts.Attributes {
+		a.Attributes = append(a.Attributes, assertionAttribute{Name: name, Values: values})
+	}
+
+	document, err := xml.Marshal(resp)
+	if err != nil {
+		return "", err
+	}
+	if opts.SignAssertion {
+		if document, err = saml.Sign(document, a.ID, idp.Key, idp.Certificate); err != nil {
+			return "", err
+		}
+	}
+	if opts.SignResponse {
+		if document, err = saml.Sign(document, resp.ID, idp.Key, idp.Certificate); err != nil {
+			return "", err
+		}
+	}
+	return base64.StdEncoding.EncodeToString(document), nil
+}
+
+type logoutRequest struct {
+	XMLName      xml.Name      `xml:"urn:oasis:names:tc:SAML:2.0:protocol LogoutRequest"`
+	ID           string        `xml:"ID,attr"`
+	Version      string        `xml:"Version,attr"`
+	IssueInstant string        `xml:"IssueInstant,attr"`
+	Destination  string        `xml:"Destination,attr"`
+	Issuer       messageIssuer `xml:"Issuer"`
+	NameID       struct {
+		XMLName xml.Name `xml:"urn:oasis:names:tc:SAML:2.0:assertion NameID"`
+		Value   string   `xml:",chardata"`
+	}
+	SessionIndex string `xml:"SessionIndex,omitempty"`
+}
+
+type logoutResponse struct {
+	XMLName      xml.Name       `xml:"urn:oasis:names:tc:SAML:2.0:protocol LogoutResponse"`
+	ID           string         `xml:"ID,attr"`
+	Version      string         `xml:"Version,attr"`
+	IssueInstant string         `xml:"IssueInstant,attr"`
+	Destination  string         `xml:"Destination,attr"`
+	InResponseTo string         `xml:"InResponseTo,attr"`
+	Issuer       messageIssuer  `xml:"Issuer"`
+	Status       responseStatus `xml:"Status"`
+}
+
+// LogoutRequestURL returns the URL sending a signed logout request to the single logout service of a service
+// provider with the HTTP-Redirect binding, and the ID of the request
+func (idp *IdentityProvider) LogoutRequestURL(sloURL, nameID, sessionIndex, relayState string) (redirectURL, requestID string, err error) {
+	request := logoutRequest{
+		ID:           saml.NewID(),
+		Version:      "2.0",
+		IssueInstant: saml.FormatTime(time.Now()),
+		Destination:  sloURL,
+		Issuer:       messageIssuer{Value: idp.EntityID},
+		SessionIndex: sessionIndex,
+	}
+	request.NameID.Value = nameID
+	message, err := xml.Marshal(request)
+	if err != nil {
+		return "", "", err
+	}
+	redirectURL, err = saml.RedirectBindingURL(sloURL, "SAMLRequest", message, relayState, idp.Key)
+	return redirectURL, request.ID, err
+}
+
+// LogoutResponseURL returns the URL sending a signed response to a logout request of a service provider with
+// the HTTP-Redirect binding
+func (idp *IdentityProvider) LogoutResponseURL(sloURL, inResponseTo, relayState string) (string, error) {
+	response := logoutResponse{
+		ID:           saml.NewID(),
+		Version:      "2.0",
+		IssueInstant: saml.FormatTime(time.Now()),
+		Destination:  sloURL,
+		InResponseTo: inResponseTo,
+		Issuer:       messageIssuer{Value: idp.EntityID},
+	}
+	response.Status.StatusCode.Value = saml.StatusSuccess
+	message, err := xml.Marshal(response)
+	if err != nil {
+		return "", err
+	}
+	return saml.RedirectBindingURL(sloURL, "SAMLResponse", message, relayState, idp.Key)
+}
+
+// ParseLogoutRequest reads the logout request of the URL a service provider redirects to, and returns its ID
+func (idp *IdentityProvider) ParseLogoutRequest(redirectURL string) (string, error) {
+	u, err := url.Parse(redirectURL)
+	if err != nil {
+		return "", err
+	}
+	message, err := saml.DecodeRedirectBinding(u.Query().Get("SAMLRequest"))
+	if err != nil {
+		return "", err
+	}
+	var request logoutRequest
+	if err := xml.Unmarshal(message, &request); err != nil {
+		return "", err
+	}
+	return request.ID, nil
+}
@@ -0,0 +1,207 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package saml
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/beevik/etree"
+)
+
+const nsXML = "http://www.w3.org/XML/1998/namespace"
+
+// element is a node of a parsed XML document. Unlike encoding/xml, it keeps the namespace prefixes and
+// declarations as they were written.
+type element struct {
+	prefix   string
+	local    string
+	attrs    []xml.Attr // Name.Space is the prefix of the attribute, namespace declarations included
+	children []any      // *element, xml.CharData or xml.ProcInst
+	parent   *element
+}
+
+// parseXML parses a document into a tree of elements. Documents with a DTD are rejected, SAML never needs
+// one and they only open the door to entity expansion attacks.
+func parseXML(data []byte) (*element, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	var root, current *element
+	for {
+		token, err := decoder.RawToken()
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			el := &element{prefix: t.Name.Space, local: t.Name.Local, attrs: t.Attr, parent: current}
+			if current != nil {
+				current.children = append(current.children, el)
+			} else if root != nil {
+				return nil, errors.New("xml: more than one root element")
+			} else {
+				root = el
+			}
+			current = el
+			if _, ok := el.lookupNamespace(el.prefix); !ok {
+				return nil, fmt.Errorf("xml: undeclared namespace prefix %q", el.prefix)
+			}
+			for _, attr := range el.attrs {
+				if attr.Name.Space == "" || attr.Name.Space == "xmlns" {
+					continue
+				}
+				if _, ok := el.lookupNamespace(attr.Name.Space); !ok {
+					return nil, fmt.Errorf("xml: undeclared namespace prefix %q", attr.Name.Space)
+				}
+			}
+		case xml.EndElement:
+			if current == nil || current.prefix != t.Name.Space || current.local != t.Name.Local {
+				return nil, fmt.Errorf("xml: unexpected end element </%s>", t.Name.Local)
+			}
+			current = current.parent
+		case xml.CharData:
+			if current != nil {
+				current.children = append(current.children, t.Copy())
+			} else if len(bytes.TrimSpace(t)) > 0 {
+				return nil, errors.New("xml: text outside of the root element")
+			}
+		case xml.ProcInst:
+			if current != nil {
+				current.children = append(current.children, t.Copy())
+			}
+		case xml.Directive:
+			return nil, errors.New("xml: directives are not allowed")
+		}
+	}
+	if root == nil || current != nil {
+		return nil, errors.New("xml: incomplete document")
+	}
+	return root, nil
+}
+
+// lookupNamespace returns the namespace URI the prefix is bound to in the scope of the element
+func (e *element) lookupNamespace(prefix string) (string, bool) {
+	if prefix == "xml" {
+		return nsXML, true
+	}
+	for el := e; el != nil; el = el.parent {
+		for _, attr := range el.attrs {
+			if (prefix == "" && attr.Name.Space == "" && attr.Name.Local == "xmlns") ||
+				(prefix != "" && attr.Name.Space == "xmlns" && attr.Name.Local == prefix) {
+				return attr.Value, true
+			}
+		}
+	}
+	// the default namespace is empty when it is not declared
+	return "", prefix == ""
+}
+
+func (e *element) namespace() string {
+	ns, _ := e.lookupNamespace(e.prefix)
+	return ns
+}
+
+func (e *element) is(ns, local string) bool {
+	return e.local == local && e.namespace() == ns
+}
+
+// child returns the first child element with the name
+func (e *element) child(ns, local string) *element {
+	for _, c := range e.children {
+		if el, ok := c.(*element); ok && el.is(ns, local) {
+			return el
+		}
+	}
+	return nil
+}
+
+func (e *element) childrenNamed(ns, local string) []*element {
+	var elements []*element
+	for _, c := range e.children {
+		if el, ok := c.(*element); ok && el.is(ns, local) {
+			elements = append(elements, el)
+		}
+	}
+	return elements
+}
+
+// attr returns the value of an attribute without namespace
+func (e *element) attr(local string) string {
+	for _, attr := range e.attrs {
+		if attr.Name.Space == "" && attr.Name.Local == local {
+			return attr.Value
+		}
+	}
+	return ""
+}
+
+// text returns the text content of the element, trimmed of the surrounding spaces
+func (e *element) text() string {
+	var sb strings.Builder
+	for _, c := range e.children {
+		if data, ok := c.(xml.CharData); ok {
+			sb.Write(data)
+		}
+	}
+	return strings.TrimSpace(sb.String())
+}
+
+// parseEtree parses a document into the tree goxmldsig works with, after checking it with parseXML, which
+// rejects the DTDs
+func parseEtree(data []byte) (*etree.Document, error) {
+	if _, err := parseXML(data); err != nil {
+		return nil, err
+	}
+	doc := etree.NewDocument()
+	if err := doc.ReadFromBytes(data); err != nil {
+		return nil, err
+	}
+	if doc.Root() == nil {
+		return nil, errors.New("xml: incomplete document")
+	}
+	return doc, nil
+}
+
+// fromEtree converts an element of the tree goxmldsig works with into an element
+func fromEtree(el *etree.Element) (*element, error) {
+	detached, err := detach(el)
+	if err != nil {
+		return nil, err
+	}
+	data, err := etree.NewDocumentWithRoot(detached).WriteToBytes()
+	if err != nil {
+		return nil, err
+	}
+	return parseXML(data)
+}
+
+// findByID returns the element of the tree with the ID attribute, SAML elements use "ID" for their identifiers
+func findByID(el *etree.Element, id string) *etree.Element {
+	if el.SelectAttrValue("ID", "") == id {
+		return el
+	}
+	for _, child := range el.ChildElements() {
+		if found := findByID(child, id); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+// childElements returns the child elements of el with the name
+func childElements(el *etree.Element, ns, local string) []*etree.Element {
+	var elements []*etree.Element
+	for _, child := range el.ChildElements() {
+		if child.Tag == local && child.NamespaceURI() == ns {
+			elements = append(elements, child)
+		}
+	}
+	return elements
+}
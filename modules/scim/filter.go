@@ -0,0 +1,540 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package scim
+
+import (
+	"strconv"
+	"strings"
+
+	"gitea.dev/modules/json"
+)
+
+// Filter selects resources, RFC 7644 section 3.4.2.2
+type Filter interface {
+	// Match reports whether the resource, in its generic form, is selected
+	Match(resource map[string]any) bool
+}
+
+type tokenKind int
+
+const (
+	tokenWord tokenKind = iota
+	tokenString
+	tokenLeftParen
+	tokenRightParen
+	tokenLeftBracket
+	tokenRightBracket
+)
+
+type token struct {
+	kind  tokenKind
+	value string
+}
+
+func tokenize(s string) ([]token, error) {
+	var tokens []token
+	for i := 0; i < len(s); {
+		switch c := s[i]; c {
+		case ' ', '\t', '\r', '\n':
+			i++
+		case '(':
+			tokens = append(tokens, token{kind: tokenLeftParen, value: "("})
+			i++
+		case ')':
+			tokens = append(tokens, token{kind: tokenRightParen, value: ")"})
+			i++
+		case '[':
+			tokens = append(tokens, token{kind: tokenLeftBracket, value: "["})
+			i++
+		case ']':
+			tokens = append(tokens, token{kind: tokenRightBracket, value: "]"})
+			i++
+		case '"':
+			end := i + 1
+			for ; end < len(s) && s[end] != '"'; end++ {
+				if s[end] == '\\' {
+					end++
+				}
+			}
+			if end >= len(s) {
+				return nil, invalidFilter("unterminated string at position %d", i)
+			}
+			var value string
+			if err := json.Unmarshal([]byte(s[i:end+1]), &value); err != nil {
+				return nil, invalidFilter("invalid string %s", s[i:end+1])
+			}
+			tokens = append(tokens, token{kind: tokenString, value: value})
+			i = end + 1
+		default:
+			end := i
+			for ; end < len(s) && !strings.ContainsRune(" \t\r\n()[]\"", rune(s[end])); end++ {
+			}
+			tokens = append(tokens, token{kind: tokenWord, value: s[i:end]})
+			i = end
+		}
+	}
+	return tokens, nil
+}
+
+// attrPath is an attribute and its sub-attribute, the attributes of the extension schemas are
+// the sub-attributes of the schema URN
+type attrPath struct {
+	name string
+	sub  string
+}
+
+func parseAttrPath(s string) (attrPath, error) {
+	if len(s) > 4 && strings.EqualFold(s[:4], "urn:") {
+		idx := strings.LastIndexByte(s, ':')
+		urn, rest := s[:idx], s[idx+1:]
+		if !strings.EqualFold(urn, SchemaUser) && !strings.EqualFold(urn, SchemaGroup) {
+			if rest == "" {
+				return attrPath{}, invalidPath("invalid attribute path %q", s)
+			}
+			return attrPath{name: urn, sub: rest}, nil
+		}
+		s = rest
+	}
+	name, sub, _ := strings.Cut(s, ".")
+	if !isAttrName(name) || (sub != "" && !isAttrName(sub)) {
+		return attrPath{}, invalidPath("invalid attribute path %q", s)
+	}
+	return attrPath{name: name, sub: sub}, nil
+}
+
+func isAttrName(s string) bool {
+	if s == "$ref" {
+		return true
+	}
+	for i, c := range s {
+		isAlpha := (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+		if !isAlpha && (i == 0 || (c != '-' && c != '_' && (c < '0' || c > '9'))) {
+			return false
+		}
+	}
+	return s != ""
+}
+
+// isCaseExact reports whether the values of the attribute are compared case-sensitively
+func (p attrPath) isCaseExact() bool {
+	return p.sub == "" && (strings.EqualFold(p.name, "id") || strings.EqualFold(p.name, "externalId"))
+}
+
+// values returns the values of the attribute, the values of a multi-valued complex attribute
+// are their "value" sub-attributes unless another sub-attribute is given
+func (p attrPath) values(resource map[string]any) []any {
+	_, v, ok := lookup(resource, p.name)
+	if !ok || v == nil {
+		return nil
+	}
+	sub := p.sub
+	if elems, ok := v.([]any); ok {
+		if sub == "" {
+			sub = "value"
+		}
+		values := make([]any, 0, len(elems))
+		for _, elem := range elems {
+			if m, ok := elem.(map[string]any); ok {
+				if _, value, ok := lookup(m, sub); ok {
+					values = append(values, value)
+				}
+			} else if p.sub == "" {
+				values = append(values, elem)
+			}
+		}
+		return values
+	}
+	if sub == "" {
+		return []any{v}
+	}
+	if m, ok := v.(map[string]any); ok {
+		if _, value, ok := lookup(m, sub); ok {
+			return []any{value}
+		}
+	}
+	return nil
+}
+
+// lookup returns an attribute of the resource, the attribute names are case-insensitive
+func lookup(resource map[string]any, name string) (string, any, bool) {
+	if v, ok := resource[name]; ok {
+		return name, v, true
+	}
+	for key, v := range resource {
+		if strings.EqualFold(key, name) {
+			return key, v, true
+		}
+	}
+	return "", nil, false
+}
+
+type andFilter struct{ left, right Filter }
+
+func (f *andFilter) Match(resource map[string]any) bool {
+	return f.left.Match(resource) && f.right.Match(resource)
+}
+
+type orFilter struct{ left, right Filter }
+
+func (f *orFilter) Match(resource map[string]any) bool {
+	return f.left.Match(resource) || f.right.Match(resource)
+}
+
+type notFilter struct{ filter Filter }
+
+func (f *notFilter) Match(resource map[string]any) bool {
+	return !f.filter.Match(resource)
+}
+
+// valuePathFilter selects the resources with an element of a multi-valued attribute matching the filter
+type valuePathFilter struct {
+	path   attrPath
+	filter Filter
+}
+
+func (f *valuePathFilter) Match(resource map[string]any) bool {
+	return len(f.elements(resource)) > 0
+}
+
+// elements returns the indexes of the elements matching the filter
+func (f *valuePathFilter) elements(resource map[string]any) []int {
+	_, v, _ := lookup(resource, f.path.name)
+	var matched []int
+	switch v := v.(type) {
+	case []any:
+		for i, elem := range v {
+			if m, ok := elem.(map[string]any); ok && f.filter.Match(m) {
+				matched = append(matched, i)
+			}
+		}
+	case map[string]any:
+		if f.filter.Match(v) {
+			matched = append(matched, 0)
+		}
+	}
+	return matched
+}
+
+type presentFilter struct{ path attrPath }
+
+func (f *presentFilter) Match(resource map[string]any) bool {
+	for _, v := range f.path.values(resource) {
+		switch v := v.(type) {
+		case nil:
+		case string:
+			if v != "" {
+				return true
+			}
+		case []any:
+			if len(v) > 0 {
+				return true
+			}
+		case map[string]any:
+			if len(v) > 0 {
+				return true
+			}
+		default:
+			return true
+		}
+	}
+	return false
+}
+
+type compareFilter struct {
+	path  attrPath
+	op    string
+	value any
+}
+
+func (f *compareFilter) Match(resource map[string]any) bool {
+	values := f.path.values(resource)
+	if f.value == nil {
+		present := (&presentFilter{path: f.path}).Match(resource)
+		return present == (f.op == "ne")
+	}
+	if f.op == "ne" {
+		for _, v := range values {
+			if compareValues("eq", v, f.value, f.path.isCaseExact()) {
+				return false
+			}
+		}
+		return true
+	}
+	for _, v := range values {
+		if compareValues(f.op, v, f.value, f.path.isCaseExact()) {
+			return true
+		}
+	}
+	return false
+}
+
+func compareValues(op string, actual, expected any, caseExact bool) bool {
+	switch expected := expected.(type) {
+	case string:
+		actual, ok := actual.(string)
+		if !ok {
+			return false
+		}
+		if !caseExact {
+			actual, expected = strings.ToLower(actual), strings.ToLower(expected)
+		}
+		switch op {
+		case "eq":
+			return actual == expected
+		case "co":
+			return strings.Contains(actual, expected)
+		case "sw":
+			return strings.HasPrefix(actual, expected)
+		case "ew":
+			return strings.HasSuffix(actual, expected)
+		case "gt":
+			return actual > expected
+		case "ge":
+			return actual >= expected
+		case "lt":
+			return actual < expected
+		case "le":
+			return actual <= expected
+		}
+	case bool:
+		actual, ok := actual.(bool)
+		return ok && op == "eq" && actual == expected
+	case float64:
+		var number float64
+		switch actual := actual.(type) {
+		case float64:
+			number = actual
+		case string:
+			var err error
+			if number, err = strconv.ParseFloat(actual, 64); err != nil {
+				return false
+			}
+		default:
+			return false
+		}
+		switch op {
+		case "eq":
+			return number == expected
+		case "gt":
+			return number > expected
+		case "ge":
+			return number >= expected
+		case "lt":
+			return number < expected
+		case "le":
+			return number <= expected
+		}
+	}
+	return false
+}
+
+var compareOperators = map[string]bool{"eq": true, "ne": true, "co": true, "sw": true, "ew": true, "gt": true, "ge": true, "lt": true, "le": true}
+
+type filterParser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *filterParser) peek() *token {
+	if p.pos >= len(p.tokens) {
+		return nil
+	}
+	return &p.tokens[p.pos]
+}
+
+func (p *filterParser) next() *token {
+	t := p.peek()
+	if t != nil {
+		p.pos++
+	}
+	return t
+}
+
+func (p *filterParser) isKeyword(keyword string) bool {
+	t := p.peek()
+	return t != nil && t.kind == tokenWord && strings.EqualFold(t.value, keyword)
+}
+
+func (p *filterParser) expect(kind tokenKind, value string) error {
+	if t := p.next(); t == nil || t.kind != kind {
+		return invalidFilter("expected %q", value)
+	}
+	return nil
+}
+
+func (p *filterParser) parseOr() (Filter, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.isKeyword("or") {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &orFilter{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *filterParser) parseAnd() (Filter, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for p.isKeyword("and") {
+		p.next()
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		left = &andFilter{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *filterParser) parseNot() (Filter, error) {
+	if p.isKeyword("not") {
+		p.next()
+		if err := p.expect(tokenLeftParen, "("); err != nil {
+			return nil, err
+		}
+		filter, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		return &notFilter{filter: filter}, p.expect(tokenRightParen, ")")
+	}
+	if t := p.peek(); t != nil && t.kind == tokenLeftParen {
+		p.next()
+		filter, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		return filter, p.expect(tokenRightParen, ")")
+	}
+	return p.parseAttrExp()
+}
+
+func (p *filterParser) parseAttrExp() (Filter, error) {
+	attrToken := p.next()
+	if attrToken == nil || attrToken.kind != tokenWord {
+		return nil, invalidFilter("expected an attribute path")
+	}
+	path, err := parseAttrPath(attrToken.value)
+	if err != nil {
+		return nil, invalidFilter("%s", err.(*Error).Detail)
+	}
+
+	if t := p.peek(); t != nil && t.kind == tokenLeftBracket {
+		if path.sub != "" {
+			return nil, invalidFilter("invalid value path %q", attrToken.value)
+		}
+		p.next()
+		filter, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		return &valuePathFilter{path: path, filter: filter}, p.expect(tokenRightBracket, "]")
+	}
+
+	opToken := p.next()
+	if opToken == nil || opToken.kind != tokenWord {
+		return nil, invalidFilter("expected an operator after %q", attrToken.value)
+	}
+	op := strings.ToLower(opToken.value)
+	if op == "pr" {
+		return &presentFilter{path: path}, nil
+	}
+	if !compareOperators[op] {
+		return nil, invalidFilter("unknown operator %q", opToken.value)
+	}
+
+	valueToken := p.next()
+	if valueToken == nil {
+		return nil, invalidFilter("expected a value after %q", opToken.value)
+	}
+	var value any
+	switch {
+	case valueToken.kind == tokenString:
+		value = valueToken.value
+	case valueToken.kind != tokenWord:
+		return nil, invalidFilter("expected a value after %q", opToken.value)
+	case valueToken.value == "true" || valueToken.value == "false":
+		value = valueToken.value == "true"
+		if op != "eq" && op != "ne" {
+			return nil, invalidFilter("operator %q can't compare booleans", opToken.value)
+		}
+	case valueToken.value == "null":
+		if op != "eq" && op != "ne" {
+			return nil, invalidFilter("operator %q can't compare null", opToken.value)
+		}
+	default:
+		number, err := strconv.ParseFloat(valueToken.value, 64)
+		if err != nil {
+			return nil, invalidFilter("invalid value %q", valueToken.value)
+		}
+		value = number
+	}
+	return &compareFilter{path: path, op: op, value: value}, nil
+}
+
+// ParseFilter parses the "filter" query parameter
+func ParseFilter(s string) (Filter, error) {
+	tokens, err := tokenize(s)
+	if err != nil {
+		return nil, err
+	}
+	p := &filterParser{tokens: tokens}
+	filter, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t != nil {
+		return nil, invalidFilter("unexpected %q", t.value)
+	}
+	return filter, nil
+}
+
+// EqualityValue returns the value a filter requires the attribute to be equal to, if it does.
+// It lets the callers narrow the resources down before matching the filter.
+func EqualityValue(filter Filter, attribute string) (string, bool) {
+	switch f := filter.(type) {
+	case *compareFilter:
+		value, ok := f.value.(string)
+		return value, ok && f.op == "eq" && f.path.sub == "" && strings.EqualFold(f.path.name, attribute)
+	case *andFilter:
+		if value, ok := EqualityValue(f.left, attribute); ok {
+			return value, true
+		}
+		return EqualityValue(f.right, attribute)
+	}
+	return "", false
+}
+
+// equalities returns the attributes an element must have to match the filter, to create it
+func equalities(filter Filter) (map[string]any, bool) {
+	switch f := filter.(type) {
+	case *compareFilter:
+		if f.op != "eq" || f.path.sub != "" || f.value == nil {
+			return nil, false
+		}
+		return map[string]any{f.path.name: f.value}, true
+	case *andFilter:
+		left, ok := equalities(f.left)
+		if !ok {
+			return nil, false
+		}
+		right, ok := equalities(f.right)
+		if !ok {
+			return nil, false
+		}
+		for k, v := range right {
+			left[k] = v
+		}
+		return left, true
+	}
+	return nil, false
+}
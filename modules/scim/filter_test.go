@@ -0,0 +1,107 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package scim
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFilter(t *testing.T) {
+	active := Bool(true)
+	resource, err := ToResource(&User{
+		Schemas:    []string{SchemaUser},
+		ID:         "42",
+		ExternalID: "Ext-42",
+		UserName:   "Alice",
+		Name:       &Name{GivenName: "Alice", FamilyName: "Liddell"},
+		Active:     &active,
+		Emails: []MultiValue{
+			{Value: "alice@example.com", Type: "work", Primary: true},
+			{Value: "alice@home.example.org", Type: "home"},
+		},
+		Meta: &Meta{ResourceType: "User", LastModified: "2026-05-01T10:00:00Z"},
+	})
+	require.NoError(t, err)
+
+	cases := []struct {
+		filter  string
+		matches bool
+	}{
+		{`userName eq "alice"`, true},
+		{`USERNAME Eq "ALICE"`, true},
+		{`urn:ietf:params:scim:schemas:core:2.0:User:userName eq "alice"`, true},
+		{`userName ne "alice"`, false},
+		{`userName sw "al"`, true},
+		{`userName ew "ce"`, true},
+		{`userName co "lic"`, true},
+		{`userName gt "aaa"`, true},
+		{`userName lt "aaa"`, false},
+		{`externalId eq "ext-42"`, false},
+		{`externalId eq "Ext-42"`, true},
+		{`id eq "42"`, true},
+		{`name.familyName eq "liddell"`, true},
+		{`name.middleName pr`, false},
+		{`title pr`, false},
+		{`title eq null`, true},
+		{`active eq true`, true},
+		{`active eq false`, false},
+		{`emails eq "alice@home.example.org"`, true},
+		{`emails.value ew "@example.com"`, true},
+		{`emails[type eq "work" and value co "@example.com"]`, true},
+		{`emails[type eq "home" and value co "@example.com"]`, false},
+		{`meta.lastModified gt "2026-01-01T00:00:00Z"`, true},
+		{`userName eq "bob" or name.givenName eq "alice"`, true},
+		{`userName eq "bob" or userName eq "carol" and id eq "42"`, false},
+		{`(userName eq "bob" or userName eq "alice") and id eq "42"`, true},
+		{`not (userName eq "alice")`, false},
+		{`not(userName eq "bob") and active eq true`, true},
+		{`userName eq "a \"quoted\" name"`, false},
+	}
+	for _, c := range cases {
+		filter, err := ParseFilter(c.filter)
+		require.NoError(t, err, c.filter)
+		assert.Equal(t, c.matches, filter.Match(resource), c.filter)
+	}
+
+	for _, invalid := range []string{
+		``,
+		`userName`,
+		`userName eq`,
+		`userName xx "alice"`,
+		`userName eq "alice`,
+		`userName eq alice`,
+		`active gt true`,
+		`(userName eq "alice"`,
+		`userName eq "alice")`,
+		`emails[type eq "work"`,
+		`not userName eq "alice"`,
+		`userName eq "alice" and`,
+		`1userName eq "alice"`,
+		`emails[primary eq true].value eq "x"`,
+	} {
+		_, err := ParseFilter(invalid)
+		var scimErr *Error
+		if assert.ErrorAs(t, err, &scimErr, invalid) {
+			assert.Equal(t, ErrorInvalidFilter, scimErr.Type, invalid)
+		}
+	}
+}
+
+func TestEqualityValue(t *testing.T) {
+	filter, err := ParseFilter(`userName eq "alice" and active eq true`)
+	require.NoError(t, err)
+	value, ok := EqualityValue(filter, "username")
+	assert.True(t, ok)
+	assert.Equal(t, "alice", value)
+	_, ok = EqualityValue(filter, "externalId")
+	assert.False(t, ok)
+
+	filter, err = ParseFilter(`userName eq "alice" or userName eq "bob"`)
+	require.NoError(t, err)
+	_, ok = EqualityValue(filter, "userName")
+	assert.False(t, ok)
+}
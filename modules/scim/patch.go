@@ -0,0 +1,330 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package scim
+
+import (
+	"net/http"
+	"reflect"
+	"strings"
+)
+
+// Path is the target of a PATCH operation, RFC 7644 section 3.5.2
+type Path struct {
+	attr   attrPath
+	filter Filter
+	// sub is the sub-attribute of the elements selected by the filter
+	sub string
+}
+
+// ParsePath parses the "path" of a PATCH operation
+func ParsePath(s string) (*Path, error) {
+	tokens, err := tokenize(s)
+	if err != nil {
+		return nil, invalidPath("%s", err.(*Error).Detail)
+	}
+	if len(tokens) == 0 || tokens[0].kind != tokenWord {
+		return nil, invalidPath("invalid path %q", s)
+	}
+	attr, err := parseAttrPath(tokens[0].value)
+	if err != nil {
+		return nil, err
+	}
+	path := &Path{attr: attr}
+	if len(tokens) == 1 {
+		return path, nil
+	}
+
+	if tokens[1].kind != tokenLeftBracket || attr.sub != "" {
+		return nil, invalidPath("invalid path %q", s)
+	}
+	end := len(tokens) - 1
+	if tokens[end].kind == tokenWord {
+		if !strings.HasPrefix(tokens[end].value, ".") || !isAttrName(tokens[end].value[1:]) {
+			return nil, invalidPath("invalid path %q", s)
+		}
+		path.sub = tokens[end].value[1:]
+		end--
+	}
+	if tokens[end].kind != tokenRightBracket {
+		return nil, invalidPath("invalid path %q", s)
+	}
+	p := &filterParser{tokens: tokens[2:end]}
+	if path.filter, err = p.parseOr(); err != nil {
+		return nil, invalidPath("invalid path %q: %s", s, err.(*Error).Detail)
+	}
+	if t := p.peek(); t != nil {
+		return nil, invalidPath("invalid path %q: unexpected %q", s, t.value)
+	}
+	return path, nil
+}
+
+// ApplyPatch applies the PATCH operations to the resource in its generic form
+func ApplyPatch(resource map[string]any, operations []PatchOperation) error {
+	for _, op := range operations {
+		var path *Path
+		if op.Path != "" {
+			var err error
+			if path, err = ParsePath(op.Path); err != nil {
+				return err
+			}
+		}
+
+		var err error
+		switch strings.ToLower(op.Op) {
+		case "add":
+			err = patchSet(resource, path, op.Value, false)
+		case "replace":
+			err = patchSet(resource, path, op.Value, true)
+		case "remove":
+			err = patchRemove(resource, path, op.Value)
+		default:
+			err = NewError(http.StatusBadRequest, ErrorInvalidSyntax, "unknown operation %q", op.Op)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// patchSet adds or replaces the values of the target, without a path the value holds the attributes to set
+func patchSet(resource map[string]any, path *Path, value any, replace bool) error {
+	if path == nil {
+		attributes, ok := value.(map[string]any)
+		if !ok {
+			return NewError(http.StatusBadRequest, ErrorInvalidValue, "the value of an operation without a path must be an object")
+		}
+		for name, value := range attributes {
+			path, err := ParsePath(name)
+			if err != nil {
+				return err
+			}
+			if err := patchSet(resource, path, value, replace); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if path.filter != nil {
+		return patchSetFiltered(resource, path, value, replace)
+	}
+
+	key, existing, _ := lookup(resource, path.attr.name)
+	key = firstNonEmpty(key, path.attr.name)
+	if path.attr.sub != "" {
+		switch existing := existing.(type) {
+		case []any:
+			for _, elem := range existing {
+				if m, ok := elem.(map[string]any); ok {
+					setAttribute(m, path.attr.sub, value, replace)
+				}
+			}
+		case map[string]any:
+			setAttribute(existing, path.attr.sub, value, replace)
+		default:
+			resource[key] = map[string]any{path.attr.sub: value}
+		}
+		return nil
+	}
+	setAttribute(resource, key, value, replace)
+	return nil
+}
+
+// setAttribute sets an attribute: the values are appended to the multi-valued attributes unless they are
+// replaced, and the sub-attributes of the complex attributes are merged
+func setAttribute(m map[string]any, name string, value any, replace bool) {
+	key, existing, ok := lookup(m, name)
+	if !ok {
+		m[name] = value
+		return
+	}
+	switch existing := existing.(type) {
+	case []any:
+		values, ok := value.([]any)
+		if !ok {
+			values = []any{value}
+		}
+		if replace {
+			m[key] = values
+			return
+		}
+		for _, v := range values {
+			if !containsValue(existing, v) {
+				existing = append(existing, v)
+			}
+		}
+		m[key] = existing
+	case map[string]any:
+		if values, ok := value.(map[string]any); ok {
+			for k, v := range values {
+				setAttribute(existing, k, v, replace)
+			}
+			return
+		}
+		m[key] = value
+	default:
+		m[key] = value
+	}
+}
+
+func patchSetFiltered(resource map[string]any, path *Path, value any, replace bool) error {
+	key, existing, _ := lookup(resource, path.attr.name)
+	key = firstNonEmpty(key, path.attr.name)
+	elems, ok := existing.([]any)
+	if !ok && existing != nil {
+		return invalidPath("%q is not a multi-valued attribute", path.attr.name)
+	}
+	values, isObject := value.(map[string]any)
+	if path.sub == "" && !isObject {
+		return NewError(http.StatusBadRequest, ErrorInvalidValue, "the value of %q must be an object", path.attr.name)
+	}
+
+	matched := (&valuePathFilter{path: path.attr, filter: path.filter}).elements(resource)
+	if len(matched) == 0 {
+		// some identity providers expect the element to be created, if the filter tells what it is
+		seed, ok := equalities(path.filter)
+		if !ok {
+			return NewError(http.StatusBadRequest, ErrorNoTarget, "no value matches the path %q", path.attr.name)
+		}
+		if path.sub != "" {
+			seed[path.sub] = value
+		}
+		for k, v := range values {
+			seed[k] = v
+		}
+		resource[key] = append(elems, seed)
+		return nil
+	}
+
+	for _, i := range matched {
+		elem, _ := elems[i].(map[string]any)
+		switch {
+		case path.sub != "":
+			setAttribute(elem, path.sub, value, true)
+		case replace:
+			elems[i] = values
+		default:
+			for k, v := range values {
+				setAttribute(elem, k, v, false)
+			}
+		}
+	}
+	return nil
+}
+
+// patchRemove removes the values of the target, or the given values of a multi-valued attribute
+func patchRemove(resource map[string]any, path *Path, value any) error {
+	if path == nil {
+		return NewError(http.StatusBadRequest, ErrorNoTarget, "the remove operation requires a path")
+	}
+	key, existing, ok := lookup(resource, path.attr.name)
+	if !ok {
+		return nil
+	}
+
+	if path.filter != nil {
+		elems, ok := existing.([]any)
+		if !ok {
+			return invalidPath("%q is not a multi-valued attribute", path.attr.name)
+		}
+		matched := (&valuePathFilter{path: path.attr, filter: path.filter}).elements(resource)
+		if path.sub != "" {
+			for _, i := range matched {
+				if elem, ok := elems[i].(map[string]any); ok {
+					removeAttribute(elem, path.sub)
+				}
+			}
+			return nil
+		}
+		kept := make([]any, 0, len(elems))
+		for i, elem := range elems {
+			if !containsIndex(matched, i) {
+				kept = append(kept, elem)
+			}
+		}
+		resource[key] = kept
+		return nil
+	}
+
+	if path.attr.sub != "" {
+		switch existing := existing.(type) {
+		case []any:
+			for _, elem := range existing {
+				if m, ok := elem.(map[string]any); ok {
+					removeAttribute(m, path.attr.sub)
+				}
+			}
+		case map[string]any:
+			removeAttribute(existing, path.attr.sub)
+		}
+		return nil
+	}
+
+	// some identity providers remove the members of a group by giving them as the value
+	if elems, ok := existing.([]any); ok && value != nil {
+		values, ok := value.([]any)
+		if !ok {
+			values = []any{value}
+		}
+		kept := make([]any, 0, len(elems))
+		for _, elem := range elems {
+			if !containsValue(values, elem) {
+				kept = append(kept, elem)
+			}
+		}
+		resource[key] = kept
+		return nil
+	}
+	delete(resource, key)
+	return nil
+}
+
+func removeAttribute(m map[string]any, name string) {
+	if key, _, ok := lookup(m, name); ok {
+		delete(m, key)
+	}
+}
+
+// containsValue reports whether the values contain the value, the elements of the multi-valued
+// complex attributes are identified by their "value" sub-attribute
+func containsValue(values []any, value any) bool {
+	for _, v := range values {
+		if sameValue(v, value) {
+			return true
+		}
+	}
+	return false
+}
+
+func sameValue(a, b any) bool {
+	ma, okA := a.(map[string]any)
+	mb, okB := b.(map[string]any)
+	if okA && okB {
+		_, va, hasA := lookup(ma, "value")
+		_, vb, hasB := lookup(mb, "value")
+		if hasA && hasB {
+			return reflect.DeepEqual(va, vb)
+		}
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+func containsIndex(indexes []int, i int) bool {
+	for _, idx := range indexes {
+		if idx == i {
+			return true
+		}
+	}
+	return false
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
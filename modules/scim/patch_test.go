@@ -0,0 +1,112 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package scim
+
+import (
+	"testing"
+
+	"gitea.dev/modules/json"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func applyPatchJSON[T any](t *testing.T, v *T, operations string) error {
+	var ops []PatchOperation
+	require.NoError(t, json.Unmarshal([]byte(operations), &ops))
+	resource, err := ToResource(v)
+	require.NoError(t, err)
+	if err := ApplyPatch(resource, ops); err != nil {
+		return err
+	}
+	*v = *new(T)
+	return FromResource(resource, v)
+}
+
+func TestApplyPatchUser(t *testing.T) {
+	user := &User{
+		Schemas:  []string{SchemaUser},
+		UserName: "alice",
+		Name:     &Name{GivenName: "Alice", FamilyName: "Liddell"},
+		Emails:   []MultiValue{{Value: "alice@example.com", Type: "work", Primary: true}},
+	}
+
+	require.NoError(t, applyPatchJSON(t, user, `[
+		{"op": "Replace", "path": "active", "value": "False"},
+		{"op": "replace", "value": {"displayName": "Alice L.", "name": {"givenName": "Alicia"}}},
+		{"op": "add", "path": "name.middleName", "value": "Pleasance"}
+	]`))
+	assert.False(t, user.IsActive())
+	assert.Equal(t, "Alice L.", user.DisplayName)
+	assert.Equal(t, &Name{GivenName: "Alicia", MiddleName: "Pleasance", FamilyName: "Liddell"}, user.Name)
+	assert.Equal(t, "Alicia Pleasance Liddell", user.FullName())
+
+	require.NoError(t, applyPatchJSON(t, user, `[
+		{"op": "replace", "path": "emails[type eq \"work\"].value", "value": "alice@corp.example.com"},
+		{"op": "add", "path": "emails[type eq \"home\"].value", "value": "alice@home.example.org"}
+	]`))
+	assert.Equal(t, []MultiValue{
+		{Value: "alice@corp.example.com", Type: "work", Primary: true},
+		{Value: "alice@home.example.org", Type: "home"},
+	}, user.Emails)
+	assert.Equal(t, "alice@corp.example.com", user.PrimaryEmail())
+
+	require.NoError(t, applyPatchJSON(t, user, `[
+		{"op": "remove", "path": "emails[type eq \"work\"]"},
+		{"op": "remove", "path": "name.middleName"},
+		{"op": "remove", "path": "displayName"}
+	]`))
+	assert.Equal(t, []MultiValue{{Value: "alice@home.example.org", Type: "home"}}, user.Emails)
+	assert.Equal(t, "alice@home.example.org", user.PrimaryEmail())
+	assert.Empty(t, user.Name.MiddleName)
+	assert.Empty(t, user.DisplayName)
+
+	require.NoError(t, applyPatchJSON(t, user, `[{"op": "replace", "path": "emails", "value": [{"value": "alice@example.net"}]}]`))
+	assert.Equal(t, []MultiValue{{Value: "alice@example.net"}}, user.Emails)
+}
+
+func TestApplyPatchGroup(t *testing.T) {
+	group := &Group{
+		Schemas:     []string{SchemaGroup},
+		DisplayName: "developers",
+		Members:     []MultiValue{{Value: "1"}, {Value: "2"}},
+	}
+
+	require.NoError(t, applyPatchJSON(t, group, `[
+		{"op": "add", "path": "members", "value": [{"value": "2"}, {"value": "3"}]},
+		{"op": "remove", "path": "members[value eq \"1\"]"}
+	]`))
+	assert.Equal(t, []MultiValue{{Value: "2"}, {Value: "3"}}, group.Members)
+
+	require.NoError(t, applyPatchJSON(t, group, `[
+		{"op": "Remove", "path": "members", "value": [{"value": "3"}]},
+		{"op": "Replace", "path": "displayName", "value": "engineers"}
+	]`))
+	assert.Equal(t, []MultiValue{{Value: "2"}}, group.Members)
+	assert.Equal(t, "engineers", group.DisplayName)
+
+	require.NoError(t, applyPatchJSON(t, group, `[{"op": "remove", "path": "members"}]`))
+	assert.Empty(t, group.Members)
+}
+
+func TestApplyPatchErrors(t *testing.T) {
+	cases := map[string]ErrorType{
+		`[{"op": "move", "path": "displayName"}]`:                                      ErrorInvalidSyntax,
+		`[{"op": "remove"}]`:                                                           ErrorNoTarget,
+		`[{"op": "add", "value": "x"}]`:                                                ErrorInvalidValue,
+		`[{"op": "add", "path": "members[", "value": "x"}]`:                            ErrorInvalidPath,
+		`[{"op": "add", "path": "name.x.y", "value": "x"}]`:                            ErrorInvalidPath,
+		`[{"op": "replace", "path": "members[value co \"1\"].display", "value": "x"}]`: ErrorNoTarget,
+		`[{"op": "replace", "path": "displayName[value eq \"1\"]", "value": {}}]`:      ErrorInvalidPath,
+		`[{"op": "replace", "path": "displayName", "value": 42}]`:                      ErrorInvalidValue,
+	}
+	for operations, errorType := range cases {
+		group := &Group{Schemas: []string{SchemaGroup}, DisplayName: "developers"}
+		err := applyPatchJSON(t, group, operations)
+		var scimErr *Error
+		if assert.ErrorAs(t, err, &scimErr, operations) {
+			assert.Equal(t, errorType, scimErr.Type, operations)
+		}
+	}
+}
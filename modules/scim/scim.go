@@ -0,0 +1,256 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+// Package scim implements the protocol parts of SCIM 2.0 (RFC 7643 and RFC 7644):
+// the resources, the filters and the PATCH operations.
+package scim
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"gitea.dev/modules/json"
+)
+
+// ContentType is the media type of the SCIM requests and responses
+const ContentType = "application/scim+json"
+
+const (
+	SchemaUser                  = "urn:ietf:params:scim:schemas:core:2.0:User"
+	SchemaGroup                 = "urn:ietf:params:scim:schemas:core:2.0:Group"
+	SchemaEnterpriseUser        = "urn:ietf:params:scim:schemas:extension:enterprise:2.0:User"
+	SchemaServiceProviderConfig = "urn:ietf:params:scim:schemas:core:2.0:ServiceProviderConfig"
+	SchemaResourceType          = "urn:ietf:params:scim:schemas:core:2.0:ResourceType"
+	SchemaSchema                = "urn:ietf:params:scim:schemas:core:2.0:Schema"
+	SchemaListResponse          = "urn:ietf:params:scim:api:messages:2.0:ListResponse"
+	SchemaPatchOp               = "urn:ietf:params:scim:api:messages:2.0:PatchOp"
+	SchemaError                 = "urn:ietf:params:scim:api:messages:2.0:Error"
+)
+
+// ErrorType is the "scimType" of an error response, RFC 7644 section 3.12
+type ErrorType string
+
+const (
+	ErrorInvalidFilter ErrorType = "invalidFilter"
+	ErrorTooMany       ErrorType = "tooMany"
+	ErrorUniqueness    ErrorType = "uniqueness"
+	ErrorMutability    ErrorType = "mutability"
+	ErrorInvalidSyntax ErrorType = "invalidSyntax"
+	ErrorInvalidPath   ErrorType = "invalidPath"
+	ErrorNoTarget      ErrorType = "noTarget"
+	ErrorInvalidValue  ErrorType = "invalidValue"
+)
+
+// Error is an error which is reported to the client as a SCIM error response
+type Error struct {
+	Status int
+	Type   ErrorType
+	Detail string
+}
+
+// NewError creates an error with the status and the type of the SCIM error response
+func NewError(status int, typ ErrorType, format string, args ...any) *Error {
+	return &Error{Status: status, Type: typ, Detail: fmt.Sprintf(format, args...)}
+}
+
+func (e *Error) Error() string {
+	if e.Type == "" {
+		return e.Detail
+	}
+	return fmt.Sprintf("%s: %s", e.Type, e.Detail)
+}
+
+// ErrorResponse is the body of an error response
+type ErrorResponse struct {
+	Schemas  []string  `json:"schemas"`
+	Status   string    `json:"status"`
+	ScimType ErrorType `json:"scimType,omitempty"`
+	Detail   string    `json:"detail,omitempty"`
+}
+
+// Response returns the body of the error response
+func (e *Error) Response() *ErrorResponse {
+	return &ErrorResponse{
+		Schemas:  []string{SchemaError},
+		Status:   strconv.Itoa(e.Status),
+		ScimType: e.Type,
+		Detail:   e.Detail,
+	}
+}
+
+func invalidFilter(format string, args ...any) *Error {
+	return NewError(http.StatusBadRequest, ErrorInvalidFilter, format, args...)
+}
+
+func invalidPath(format string, args ...any) *Error {
+	return NewError(http.StatusBadRequest, ErrorInvalidPath, format, args...)
+}
+
+// Bool is a boolean which also accepts the "True" and "False" strings some identity providers send
+type Bool bool
+
+func (b *Bool) UnmarshalJSON(data []byte) error {
+	var v any
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	switch v := v.(type) {
+	case bool:
+		*b = Bool(v)
+	case string:
+		parsed, err := strconv.ParseBool(strings.ToLower(v))
+		if err != nil {
+			return fmt.Errorf("invalid boolean %q", v)
+		}
+		*b = Bool(parsed)
+	case nil:
+		*b = false
+	default:
+		return fmt.Errorf("invalid boolean %s", data)
+	}
+	return nil
+}
+
+// Meta is the metadata of a resource
+type Meta struct {
+	ResourceType string `json:"resourceType"`
+	Created      string `json:"created,omitempty"`
+	LastModified string `json:"lastModified,omitempty"`
+	Location     string `json:"location,omitempty"`
+	Version      string `json:"version,omitempty"`
+}
+
+// Name is the name of a user
+type Name struct {
+	Formatted  string `json:"formatted,omitempty"`
+	FamilyName string `json:"familyName,omitempty"`
+	GivenName  string `json:"givenName,omitempty"`
+	MiddleName string `json:"middleName,omitempty"`
+}
+
+// MultiValue is an element of a multi-valued attribute, like the emails of a user or the members of a group
+type MultiValue struct {
+	Value   string `json:"value"`
+	Display string `json:"display,omitempty"`
+	Type    string `json:"type,omitempty"`
+	Primary Bool   `json:"primary,omitempty"`
+	Ref     string `json:"$ref,omitempty"`
+}
+
+// User is the User resource
+type User struct {
+	Schemas     []string     `json:"schemas"`
+	ID          string       `json:"id,omitempty"`
+	ExternalID  string       `json:"externalId,omitempty"`
+	UserName    string       `json:"userName"`
+	Name        *Name        `json:"name,omitempty"`
+	DisplayName string       `json:"displayName,omitempty"`
+	Active      *Bool        `json:"active,omitempty"`
+	Emails      []MultiValue `json:"emails,omitempty"`
+	Groups      []MultiValue `json:"groups,omitempty"`
+	Meta        *Meta        `json:"meta,omitempty"`
+}
+
+// PrimaryEmail returns the primary email of the user, or the first one if none is marked as primary
+func (u *User) PrimaryEmail() string {
+	for _, email := range u.Emails {
+		if email.Primary {
+			return email.Value
+		}
+	}
+	if len(u.Emails) > 0 {
+		return u.Emails[0].Value
+	}
+	return ""
+}
+
+// FullName returns the name of the user to display, the name components take precedence over
+// the formatted name as the identity providers may patch them alone
+func (u *User) FullName() string {
+	if u.Name != nil {
+		if fullName := strings.Join(strings.Fields(u.Name.GivenName+" "+u.Name.MiddleName+" "+u.Name.FamilyName), " "); fullName != "" {
+			return fullName
+		}
+		if u.Name.Formatted != "" {
+			return u.Name.Formatted
+		}
+	}
+	return u.DisplayName
+}
+
+// IsActive returns whether the user is active, the users are active unless stated otherwise
+func (u *User) IsActive() bool {
+	return u.Active == nil || bool(*u.Active)
+}
+
+// Group is the Group resource
+type Group struct {
+	Schemas     []string     `json:"schemas"`
+	ID          string       `json:"id,omitempty"`
+	ExternalID  string       `json:"externalId,omitempty"`
+	DisplayName string       `json:"displayName"`
+	Members     []MultiValue `json:"members,omitempty"`
+	Meta        *Meta        `json:"meta,omitempty"`
+}
+
+// ListResponse is the response of a query
+type ListResponse struct {
+	Schemas      []string `json:"schemas"`
+	TotalResults int      `json:"totalResults"`
+	StartIndex   int      `json:"startIndex"`
+	ItemsPerPage int      `json:"itemsPerPage"`
+	Resources    []any    `json:"Resources"`
+}
+
+// NewListResponse returns the page of the resources starting at the 1-based startIndex
+func NewListResponse[T any](resources []T, startIndex, count int) *ListResponse {
+	resp := &ListResponse{
+		Schemas:      []string{SchemaListResponse},
+		TotalResults: len(resources),
+		StartIndex:   startIndex,
+		Resources:    []any{},
+	}
+	for i := startIndex - 1; i < len(resources) && len(resp.Resources) < count; i++ {
+		resp.Resources = append(resp.Resources, resources[i])
+	}
+	resp.ItemsPerPage = len(resp.Resources)
+	return resp
+}
+
+// PatchRequest is the body of a PATCH request
+type PatchRequest struct {
+	Schemas    []string         `json:"schemas"`
+	Operations []PatchOperation `json:"Operations"`
+}
+
+// PatchOperation is an operation of a PATCH request
+type PatchOperation struct {
+	Op    string `json:"op"`
+	Path  string `json:"path,omitempty"`
+	Value any    `json:"value,omitempty"`
+}
+
+// ToResource converts a resource to the generic form the filters and the PATCH operations work on
+func ToResource(v any) (map[string]any, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var resource map[string]any
+	return resource, json.Unmarshal(data, &resource)
+}
+
+// FromResource converts the generic form of a resource back into v, which must be a zero value,
+// the values of the wrong type are invalid
+func FromResource(resource map[string]any, v any) error {
+	data, err := json.Marshal(resource)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return NewError(http.StatusBadRequest, ErrorInvalidValue, "invalid resource: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,159 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package secretscan
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"sync"
+
+	"gitea.dev/modules/util"
+)
+
+// VerifyFunc confirms that a match of a pattern is a real secret, a pattern matching too broadly can be narrowed by it
+type VerifyFunc func(ctx context.Context, secret string) (bool, error)
+
+// Pattern describes a kind of secret
+type Pattern struct {
+	// ID identifies the pattern in the alerts, the built-in patterns use a name and the custom patterns "custom_<id>"
+	ID   string
+	Name string
+	// Regexp matches the secret, the secret is its first submatch if it has one, otherwise the whole match
+	Regexp *regexp.Regexp
+	// Keywords skip the content which contains none of them before the regexp is run, they are lowercase
+	Keywords []string
+	// PushProtection rejects the pushes adding the secret
+	PushProtection bool
+	// Verify is only set for the patterns whose matches must be confirmed
+	Verify VerifyFunc
+	// NeedsVerify drops all the matches of the pattern as long as no Verify is registered
+	NeedsVerify bool
+}
+
+// GiteaAccessTokenPatternID is the built-in pattern of the access tokens of this instance. The tokens have no prefix,
+// the 40 hex characters found near a keyword are only reported when they are the value of an existing token.
+const GiteaAccessTokenPatternID = "gitea_access_token"
+
+var builtinPatterns = []*Pattern{
+	{
+		ID:       "aws_access_key_id",
+		Name:     "AWS Access Key ID",
+		Regexp:   regexp.MustCompile(`\b((?:AKIA|ASIA)[0-9A-Z]{16})\b`),
+		Keywords: []string{"akia", "asia"},
+	},
+	{
+		ID:       "aws_secret_access_key",
+		Name:     "AWS Secret Access Key",
+		Regexp:   regexp.MustCompile(`(?i)aws.{0,20}?(?:secret|key).{0,20}?['"=:\s]+([A-Za-z0-9/+]{40})\b`),
+		Keywords: []string{"aws"},
+	},
+	{
+		ID:       "google_api_key",
+		Name:     "Google API Key",
+		Regexp:   regexp.MustCompile(`\b(AIza[0-9A-Za-z_\-]{35})`),
+		Keywords: []string{"aiza"},
+	},
+	{
+		ID:       "azure_storage_account_key",
+		Name:     "Azure Storage Account Key",
+		Regexp:   regexp.MustCompile(`AccountKey=([A-Za-z0-9+/]{86}==)`),
+		Keywords: []string{"accountkey="},
+	},
+	{
+		ID:       "github_token",
+		Name:     "GitHub Token",
+		Regexp:   regexp.MustCompile(`\b(gh[pousr]_[A-Za-z0-9]{36,255}|github_pat_[A-Za-z0-9_]{82})\b`),
+		Keywords: []string{"ghp_", "gho_", "ghu_", "ghs_", "ghr_", "github_pat_"},
+	},
+	{
+		ID:       "gitlab_token",
+		Name:     "GitLab Personal Access Token",
+		Regexp:   regexp.MustCompile(`\b(glpat-[A-Za-z0-9_\-]{20,})`),
+		Keywords: []string{"glpat-"},
+	},
+	{
+		ID:       "slack_token",
+		Name:     "Slack Token",
+		Regexp:   regexp.MustCompile(`\b(xox[abeoprs]-[A-Za-z0-9-]{10,})`),
+		Keywords: []string{"xoxa-", "xoxb-", "xoxe-", "xoxo-", "xoxp-", "xoxr-", "xoxs-"},
+	},
+	{
+		ID:       "slack_webhook_url",
+		Name:     "Slack Incoming Webhook URL",
+		Regexp:   regexp.MustCompile(`https://hooks\.slack\.com/services/T[A-Za-z0-9_]+/B[A-Za-z0-9_]+/[A-Za-z0-9_]{20,}`),
+		Keywords: []string{"hooks.slack.com"},
+	},
+	{
+		ID:       "stripe_secret_key",
+		Name:     "Stripe Secret Key",
+		Regexp:   regexp.MustCompile(`\b((?:sk|rk)_live_[A-Za-z0-9]{24,})`),
+		Keywords: []string{"sk_live_", "rk_live_"},
+	},
+	{
+		ID:       "private_key",
+		Name:     "Private Key",
+		Regexp:   regexp.MustCompile(`-----BEGIN (?:RSA |DSA |EC |OPENSSH |PGP |ENCRYPTED )?PRIVATE KEY(?: BLOCK)?-----[\s\S]{32,}?-----END (?:RSA |DSA |EC |OPENSSH |PGP |ENCRYPTED )?PRIVATE KEY(?: BLOCK)?-----`),
+		Keywords: []string{"private key"},
+	},
+	{
+		ID:          GiteaAccessTokenPatternID,
+		Name:        "Gitea Access Token",
+		Regexp:      regexp.MustCompile(`(?i)(?:gitea|token|authorization|bearer|password|secret)[^\n]{0,32}?\b([0-9a-f]{40})\b`),
+		Keywords:    []string{"gitea", "token", "authorization", "bearer", "password", "secret"},
+		NeedsVerify: true,
+	},
+}
+
+func init() {
+	for _, p := range builtinPatterns {
+		p.PushProtection = true
+	}
+}
+
+var verifiersMu sync.RWMutex
+
+// RegisterVerifier sets the function confirming the matches of a built-in pattern
+func RegisterVerifier(patternID string, verify VerifyFunc) {
+	verifiersMu.Lock()
+	defer verifiersMu.Unlock()
+	for _, p := range builtinPatterns {
+		if p.ID == patternID {
+			p.Verify = verify
+		}
+	}
+}
+
+// BuiltinPatterns returns the catalogue of the built-in patterns
+func BuiltinPatterns() []*Pattern {
+	verifiersMu.RLock()
+	defer verifiersMu.RUnlock()
+	res := make([]*Pattern, 0, len(builtinPatterns))
+	for _, p := range builtinPatterns {
+		clone := *p
+		res = append(res, &clone)
+	}
+	return res
+}
+
+// MaxCustomPatternLength is the maximum length of the regular expression of a custom pattern
+const MaxCustomPatternLength = 1000
+
+// CompileCustomPattern compiles a regular expression defined by an administrator into a pattern
+func CompileCustomPattern(id, name, expr string, pushProtection bool) (*Pattern, error) {
+	if expr == "" || len(expr) > MaxCustomPatternLength {
+		return nil, util.NewInvalidArgumentErrorf("the pattern must be between 1 and %d characters", MaxCustomPatternLength)
+	}
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return nil, util.NewInvalidArgumentErrorf("invalid regular expression: %v", err)
+	}
+	if re.MatchString("") {
+		return nil, util.NewInvalidArgumentErrorf("the pattern must not match an empty string")
+	}
+	if re.NumSubexp() > 1 {
+		return nil, util.NewInvalidArgumentErrorf("the pattern must have at most one capture group")
+	}
+	return &Pattern{ID: id, Name: strings.TrimSpace(name), Regexp: re, PushProtection: pushProtection}, nil
+}
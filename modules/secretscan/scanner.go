@@ -0,0 +1,129 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package secretscan
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+// maxFindingsPerContent limits the findings of a single content, a generated file can match a pattern many times
+const maxFindingsPerContent = 100
+
+// Finding is a secret found in a content
+type Finding struct {
+	Pattern *Pattern
+	Secret  string
+	// Line is the 1-based line where the secret starts
+	Line int
+}
+
+// SecretHash identifies the secret without keeping it
+func (f *Finding) SecretHash() string {
+	return HashSecret(f.Secret)
+}
+
+// HashSecret returns the hex encoded SHA-256 of a secret
+func HashSecret(secret string) string {
+	h := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(h[:])
+}
+
+// Redact hides a secret so it can be shown: only the first line of a multi-line secret like a private key,
+// and only the first and last 4 characters of a long secret are kept
+func Redact(secret string) string {
+	if first, _, ok := strings.Cut(secret, "\n"); ok {
+		return strings.TrimSpace(first) + "…"
+	}
+	n := utf8.RuneCountInString(secret)
+	if n < 16 {
+		return strings.Repeat("*", n)
+	}
+	runes := []rune(secret)
+	return string(runes[:4]) + strings.Repeat("*", n-8) + string(runes[n-4:])
+}
+
+// Scanner finds the secrets matching a set of patterns
+type Scanner struct {
+	patterns []*Pattern
+}
+
+// NewScanner returns a scanner for the patterns
+func NewScanner(patterns []*Pattern) *Scanner {
+	return &Scanner{patterns: patterns}
+}
+
+// Patterns returns the patterns of the scanner
+func (s *Scanner) Patterns() []*Pattern {
+	return s.patterns
+}
+
+// isBinary uses the same heuristic as git: a content with a NUL byte in its first 8000 bytes is binary
+func isBinary(content []byte) bool {
+	return bytes.IndexByte(content[:min(len(content), 8000)], 0) >= 0
+}
+
+// Scan returns the secrets of a content ordered by line, a secret matched several times is only reported once per pattern.
+// Binary contents are skipped.
+func (s *Scanner) Scan(ctx context.Context, content []byte) ([]*Finding, error) {
+	if len(content) == 0 || isBinary(content) {
+		return nil, nil
+	}
+
+	var lower []byte
+	var findings []*Finding
+	seen := make(map[string]bool)
+	for _, p := range s.patterns {
+		if p.NeedsVerify && p.Verify == nil {
+			continue
+		}
+		if len(p.Keywords) > 0 {
+			if lower == nil {
+				lower = bytes.ToLower(content)
+			}
+			if !slices.ContainsFunc(p.Keywords, func(k string) bool { return bytes.Contains(lower, []byte(k)) }) {
+				continue
+			}
+		}
+		for _, m := range p.Regexp.FindAllSubmatchIndex(content, maxFindingsPerContent) {
+			start, end := m[0], m[1]
+			if len(m) >= 4 && m[2] >= 0 {
+				start, end = m[2], m[3]
+			}
+			secret := string(content[start:end])
+			key := p.ID + "\x00" + secret
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			if p.Verify != nil {
+				ok, err := p.Verify(ctx, secret)
+				if err != nil {
+					return nil, err
+				} else if !ok {
+					continue
+				}
+			}
+			findings = append(findings, &Finding{
+				Pattern: p,
+				Secret:  secret,
+				Line:    1 + bytes.Count(content[:start], []byte{'\n'}),
+			})
+			if len(findings) >= maxFindingsPerContent {
+				break
+			}
+		}
+		if len(findings) >= maxFindingsPerContent {
+			break
+		}
+	}
+	slices.SortStableFunc(findings, func(a, b *Finding) int { return cmp.Compare(a.Line, b.Line) })
+	return findings, nil
+}
@@ -15,4 +15,12 @@ const (
 	KeySignInMethod = "signInMethod"
 
 	SignInMethodOAuth2 = "oauth2"
+	SignInMethodSAML   = "saml"
+
+	// KeySAMLSourceID and the other KeySAML keys identify the session at the SAML identity
+	// provider, for the single logout.
+	KeySAMLSourceID     = "samlSourceID"
+	KeySAMLNameID       = "samlNameID"
+	KeySAMLNameIDFormat = "samlNameIDFormat"
+	KeySAMLSessionIndex = "samlSessionIndex"
 )
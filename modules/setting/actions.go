@@ -19,6 +19,8 @@ const (
 	defaultArtifactRetentionDays  = 90
 	defaultLogRetentionDays       = 365
 	defaultRunRetentionDays       = 400
+	defaultCacheRetentionDays     = 7
+	defaultCacheMaxRepoSize       = "10GiB"
 )
 
 // Actions settings
@@ -31,6 +33,10 @@ var (
 		ArtifactStorage       *Storage          // how the created artifacts should be stored
 		ArtifactRetentionDays int64             `ini:"ARTIFACT_RETENTION_DAYS"`
 		RunRetentionDays      int64             `ini:"RUN_RETENTION_DAYS"`
+		CacheEnabled          bool              `ini:"CACHE_ENABLED"`
+		CacheStorage          *Storage          // how the caches of actions/cache should be stored
+		CacheRetentionDays    int64             `ini:"CACHE_RETENTION_DAYS"`
+		CacheMaxRepoSize      int64             `ini:"-"`
 		DefaultActionsURL     defaultActionsURL `ini:"DEFAULT_ACTIONS_URL"`
 		ZombieTaskTimeout     time.Duration     `ini:"ZOMBIE_TASK_TIMEOUT"`
 		EndlessTaskTimeout    time.Duration     `ini:"ENDLESS_TASK_TIMEOUT"`
@@ -54,6 +60,8 @@ var (
 		LogRetentionDays:       defaultLogRetentionDays,
 		ArtifactRetentionDays:  defaultArtifactRetentionDays,
 		RunRetentionDays:       defaultRunRetentionDays,
+		CacheEnabled:           true,
+		CacheRetentionDays:     defaultCacheRetentionDays,
 	}
 )
 
@@ -127,6 +135,17 @@ func loadActionsFrom(rootCfg ConfigProvider) error {
 		return err
 	}
 
+	cacheSec, _ := rootCfg.GetSection("actions.caches")
+
+	Actions.CacheStorage, err = getStorage(rootCfg, "actions_caches", "", cacheSec)
+	if err != nil {
+		return err
+	}
+
+	// a repository over this size has its least recently used caches evicted, -1 means no limit
+	sec.Key("CACHE_MAX_REPO_SIZE").MustString(defaultCacheMaxRepoSize)
+	Actions.CacheMaxRepoSize = mustBytes(sec, "CACHE_MAX_REPO_SIZE")
+
 	Actions.ZombieTaskTimeout = sec.Key("ZOMBIE_TASK_TIMEOUT").MustDuration(10 * time.Minute)
 	Actions.EndlessTaskTimeout = sec.Key("ENDLESS_TASK_TIMEOUT").MustDuration(3 * time.Hour)
 	Actions.AbandonedJobTimeout = sec.Key("ABANDONED_JOB_TIMEOUT").MustDuration(24 * time.Hour)
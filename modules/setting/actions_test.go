@@ -100,6 +100,34 @@ STORAGE_TYPE = minio
 	assert.Equal(t, "actions_artifacts", filepath.Base(Actions.ArtifactStorage.Path))
 }
 
+func Test_loadActionsCacheFrom(t *testing.T) {
+	cfg, err := NewConfigProviderFromData(`
+[storage]
+STORAGE_TYPE = minio
+`)
+	require.NoError(t, err)
+	require.NoError(t, loadActionsFrom(cfg))
+	assert.True(t, Actions.CacheEnabled)
+	assert.EqualValues(t, 7, Actions.CacheRetentionDays)
+	assert.EqualValues(t, 10<<30, Actions.CacheMaxRepoSize)
+	assert.EqualValues(t, "minio", Actions.CacheStorage.Type)
+	assert.Equal(t, "actions_caches/", Actions.CacheStorage.MinioConfig.BasePath)
+
+	cfg, err = NewConfigProviderFromData(`
+[actions]
+CACHE_ENABLED = false
+CACHE_RETENTION_DAYS = 0
+CACHE_MAX_REPO_SIZE = -1
+`)
+	require.NoError(t, err)
+	require.NoError(t, loadActionsFrom(cfg))
+	assert.False(t, Actions.CacheEnabled)
+	assert.Zero(t, Actions.CacheRetentionDays)
+	assert.EqualValues(t, -1, Actions.CacheMaxRepoSize)
+	assert.EqualValues(t, "local", Actions.CacheStorage.Type)
+	assert.Equal(t, "actions_caches", filepath.Base(Actions.CacheStorage.Path))
+}
+
 func Test_WorkflowDirs(t *testing.T) {
 	oldActions := Actions
 	defer func() {
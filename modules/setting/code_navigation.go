@@ -0,0 +1,25 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package setting
+
+// CodeNavigation settings
+var CodeNavigation = struct {
+	Enabled      bool
+	ArtifactName string
+	MaxIndexSize int64
+	CtagsPath    string
+	MaxFileSize  int64
+	MaxResults   int
+}{
+	Enabled:      false,
+	ArtifactName: "code-navigation",
+	MaxIndexSize: 256 << 20,
+	CtagsPath:    "",
+	MaxFileSize:  1 << 20,
+	MaxResults:   100,
+}
+
+func loadCodeNavigationFrom(rootCfg ConfigProvider) {
+	mustMapSetting(rootCfg, "code_navigation", &CodeNavigation)
+}
@@ -31,6 +31,12 @@ var Indexer = struct {
 	IncludePatterns      []*GlobMatcher
 	ExcludePatterns      []*GlobMatcher
 	ExcludeVendored      bool
+	// the default branch is always indexed, these patterns select the additional refs to index
+	RepoIndexerBranches []*GlobMatcher
+	RepoIndexerTags     []*GlobMatcher
+	RepoIndexerMaxTags  int
+	// the path of the Universal Ctags executable the trigram indexer extracts the symbols with, no symbols are indexed when empty
+	RepoIndexerCtagsPath string
 
 	TypeBleveMaxFuzzniess int
 }{
@@ -48,6 +54,7 @@ var Indexer = struct {
 	RepoIndexerName:      "gitea_codes",
 	MaxIndexerFileSize:   1024 * 1024,
 	ExcludeVendored:      true,
+	RepoIndexerMaxTags:   3,
 }
 
 func loadIndexerFrom(rootCfg ConfigProvider) {
@@ -78,7 +85,11 @@ func loadIndexerFrom(rootCfg ConfigProvider) {
 	Indexer.RepoIndexerEnabled = sec.Key("REPO_INDEXER_ENABLED").MustBool(false)
 	Indexer.RepoIndexerRepoTypes = strings.Split(sec.Key("REPO_INDEXER_REPO_TYPES").MustString("sources,forks,mirrors,templates"), ",")
 	Indexer.RepoType = sec.Key("REPO_INDEXER_TYPE").MustString("bleve")
-	Indexer.RepoPath = filepath.ToSlash(sec.Key("REPO_INDEXER_PATH").MustString(filepath.ToSlash(filepath.Join(AppDataPath, "indexers/repos.bleve"))))
+	defaultRepoPath := "indexers/repos.bleve"
+	if Indexer.RepoType == "trigram" {
+		defaultRepoPath = "indexers/repos.trigram"
+	}
+	Indexer.RepoPath = filepath.ToSlash(sec.Key("REPO_INDEXER_PATH").MustString(filepath.ToSlash(filepath.Join(AppDataPath, defaultRepoPath))))
 	if !filepath.IsAbs(Indexer.RepoPath) {
 		Indexer.RepoPath = filepath.ToSlash(filepath.Join(AppWorkPath, Indexer.RepoPath))
 	}
@@ -88,6 +99,10 @@ func loadIndexerFrom(rootCfg ConfigProvider) {
 	Indexer.IncludePatterns = IndexerGlobFromString(sec.Key("REPO_INDEXER_INCLUDE").MustString(""))
 	Indexer.ExcludePatterns = IndexerGlobFromString(sec.Key("REPO_INDEXER_EXCLUDE").MustString(""))
 	Indexer.ExcludeVendored = sec.Key("REPO_INDEXER_EXCLUDE_VENDORED").MustBool(true)
+	Indexer.RepoIndexerBranches = refGlobsFromString(sec.Key("REPO_INDEXER_BRANCHES").MustString(""))
+	Indexer.RepoIndexerTags = refGlobsFromString(sec.Key("REPO_INDEXER_TAGS").MustString(""))
+	Indexer.RepoIndexerMaxTags = sec.Key("REPO_INDEXER_MAX_TAGS").MustInt(3)
+	Indexer.RepoIndexerCtagsPath = sec.Key("REPO_INDEXER_CTAGS_PATH").MustString("")
 	Indexer.MaxIndexerFileSize = sec.Key("MAX_FILE_SIZE").MustInt64(1024 * 1024)
 	Indexer.StartupTimeout = sec.Key("STARTUP_TIMEOUT").MustDuration(30 * time.Second)
 	Indexer.TypeBleveMaxFuzzniess = sec.Key("TYPE_BLEVE_MAX_FUZZINESS").MustInt(0)
@@ -108,3 +123,19 @@ func IndexerGlobFromString(globstr string) []*GlobMatcher {
 	}
 	return extarr
 }
+
+// refGlobsFromString parses a comma separated list of branch or tag name patterns, unlike the file patterns they are case-sensitive
+func refGlobsFromString(globstr string) []*GlobMatcher {
+	var globs []*GlobMatcher
+	for expr := range strings.SplitSeq(globstr, ",") {
+		expr = strings.TrimSpace(expr)
+		if expr != "" {
+			if g, err := GlobMatcherCompile(expr, '/'); err != nil {
+				log.Warn("Invalid glob expression '%s' (skipped): %v", expr, err)
+			} else {
+				globs = append(globs, g)
+			}
+		}
+	}
+	return globs
+}
@@ -69,3 +69,14 @@ func checkGlobMatch(t *testing.T, globstr string, list []indexerMatchList) {
 		}
 	}
 }
+
+func TestRefGlobsFromString(t *testing.T) {
+	globs := refGlobsFromString("release/*, Stable, ")
+	if assert.Len(t, globs, 2) {
+		assert.True(t, globs[0].Match("release/1.0"))
+		assert.False(t, globs[0].Match("release/1.0/fix"))
+		// unlike the file patterns, the ref patterns are case-sensitive
+		assert.True(t, globs[1].Match("Stable"))
+		assert.False(t, globs[1].Match("stable"))
+	}
+}
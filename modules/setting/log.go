@@ -120,12 +120,12 @@ func loadLogModeByName(rootCfg ConfigProvider, loggerName, modeName string) (wri
 	writerName = modeName
 	defaultFlags := "stdflags"
 	defaultFilaName := "gitea.log"
-	if loggerName == "access" {
-		// "access" logger is special, by default it doesn't have output flags, so it also needs a new writer name to avoid conflicting with other writers.
+	if loggerName == "access" || loggerName == "audit" {
+		// "access" and "audit" loggers are special, by default they don't have output flags, so they also need a new writer name to avoid conflicting with other writers.
 		// so "access" logger's writer name is usually "file.access" or "console.access"
-		writerName += ".access"
+		writerName += "." + loggerName
 		defaultFlags = "none"
-		defaultFilaName = "access.log"
+		defaultFilaName = loggerName + ".log"
 	}
 
 	writerMode.Level = log.LevelFromString(ConfigInheritedKeyString(sec, "LEVEL", Log.Level.String()))
@@ -208,6 +208,7 @@ func initManagedLoggers(manager *log.LoggerManager, cfg ConfigProvider) {
 
 	initLoggerByName(manager, cfg, log.DEFAULT) // default
 	initLoggerByName(manager, cfg, "access")
+	initLoggerByName(manager, cfg, "audit")
 	initLoggerByName(manager, cfg, "router")
 	initLoggerByName(manager, cfg, "xorm")
 }
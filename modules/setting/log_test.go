@@ -219,6 +219,48 @@ ACCESS = file
 	require.JSONEq(t, strings.ReplaceAll(writerDump, "$FILENAME", tempPath("gitea.log")), toJSON(dump))
 }
 
+func TestLogConfigAuditLogger(t *testing.T) {
+	tempDir := t.TempDir()
+
+	manager, managerClose := initLoggersByConfig(t, `
+[log]
+ROOT_PATH = `+tempDir+`
+logger.audit.MODE = file
+`)
+	defer managerClose()
+
+	writerDumpAudit := `
+{
+	"file.audit": {
+		"BufferLen": 10000,
+		"Colorize": false,
+		"Expression": "",
+		"Flags": "none",
+		"Level": "info",
+		"Prefix": "",
+		"StacktraceLevel": "none",
+		"WriterOption": {
+			"Compress": true,
+			"CompressionLevel": -1,
+			"DailyRotate": true,
+			"FileName": "$FILENAME",
+			"LogRotate": true,
+			"MaxDays": 7,
+			"MaxSize": 268435456
+		},
+		"WriterType": "file"
+	}
+}
+`
+	dump := manager.GetLogger("audit").DumpWriters()
+	require.JSONEq(t, strings.ReplaceAll(writerDumpAudit, "$FILENAME", filepath.Join(tempDir, "audit.log")), toJSON(dump))
+
+	// the audit logger is disabled by default
+	manager, managerClose = initLoggersByConfig(t, ``)
+	defer managerClose()
+	require.JSONEq(t, "{}", toJSON(manager.GetLogger("audit").DumpWriters()))
+}
+
 func TestLogConfigLegacyModeDisable(t *testing.T) {
 	manager, managerClose := initLoggersByConfig(t, `
 [log]
@@ -19,6 +19,7 @@ var (
 		LimitTotalOwnerCount    int64
 		LimitTotalOwnerSize     int64
 		LimitSizeAlpine         int64
+		LimitSizeAnsible        int64
 		LimitSizeArch           int64
 		LimitSizeCargo          int64
 		LimitSizeChef           int64
@@ -31,7 +32,9 @@ var (
 		LimitSizeGeneric        int64
 		LimitSizeGo             int64
 		LimitSizeHelm           int64
+		LimitSizeHex            int64
 		LimitSizeMaven          int64
+		LimitSizeNix            int64
 		LimitSizeNpm            int64
 		LimitSizeNuGet          int64
 		LimitSizePub            int64
@@ -43,6 +46,8 @@ var (
 		LimitSizeVagrant        int64
 
 		DefaultRPMSignEnabled bool
+
+		RemoteAllowedHostList string
 	}{
 		Enabled:              true,
 		LimitTotalOwnerCount: -1,
@@ -52,6 +57,7 @@ var (
 func loadPackagesFrom(rootCfg ConfigProvider) (err error) {
 	sec, _ := rootCfg.GetSection("packages")
 	if sec == nil {
+		Packages.RemoteAllowedHostList = Security.AllowedHostList
 		Packages.Storage, err = getStorage(rootCfg, "packages", "", nil)
 		return err
 	}
@@ -67,6 +73,7 @@ func loadPackagesFrom(rootCfg ConfigProvider) (err error) {
 
 	Packages.LimitTotalOwnerSize = mustBytes(sec, "LIMIT_TOTAL_OWNER_SIZE")
 	Packages.LimitSizeAlpine = mustBytes(sec, "LIMIT_SIZE_ALPINE")
+	Packages.LimitSizeAnsible = mustBytes(sec, "LIMIT_SIZE_ANSIBLE")
 	Packages.LimitSizeArch = mustBytes(sec, "LIMIT_SIZE_ARCH")
 	Packages.LimitSizeCargo = mustBytes(sec, "LIMIT_SIZE_CARGO")
 	Packages.LimitSizeChef = mustBytes(sec, "LIMIT_SIZE_CHEF")
@@ -79,7 +86,9 @@ func loadPackagesFrom(rootCfg ConfigProvider) (err error) {
 	Packages.LimitSizeGeneric = mustBytes(sec, "LIMIT_SIZE_GENERIC")
 	Packages.LimitSizeGo = mustBytes(sec, "LIMIT_SIZE_GO")
 	Packages.LimitSizeHelm = mustBytes(sec, "LIMIT_SIZE_HELM")
+	Packages.LimitSizeHex = mustBytes(sec, "LIMIT_SIZE_HEX")
 	Packages.LimitSizeMaven = mustBytes(sec, "LIMIT_SIZE_MAVEN")
+	Packages.LimitSizeNix = mustBytes(sec, "LIMIT_SIZE_NIX")
 	Packages.LimitSizeNpm = mustBytes(sec, "LIMIT_SIZE_NPM")
 	Packages.LimitSizeNuGet = mustBytes(sec, "LIMIT_SIZE_NUGET")
 	Packages.LimitSizePub = mustBytes(sec, "LIMIT_SIZE_PUB")
@@ -90,6 +99,7 @@ func loadPackagesFrom(rootCfg ConfigProvider) (err error) {
 	Packages.LimitSizeTerraformState = mustBytes(sec, "LIMIT_SIZE_TERRAFORM_STATE")
 	Packages.LimitSizeVagrant = mustBytes(sec, "LIMIT_SIZE_VAGRANT")
 	Packages.DefaultRPMSignEnabled = sec.Key("DEFAULT_RPM_SIGN_ENABLED").MustBool(false)
+	Packages.RemoteAllowedHostList = sec.Key("REMOTE_ALLOWED_HOST_LIST").MustString(Security.AllowedHostList)
 	return nil
 }
 
@@ -6,6 +6,8 @@ package setting
 import (
 	"testing"
 
+	"gitea.dev/modules/test"
+
 	"github.com/stretchr/testify/assert"
 )
 
@@ -196,3 +198,23 @@ MINIO_SECRET_ACCESS_KEY = correct_key
 	assert.Equal(t, "my_packages/", storage.MinioConfig.BasePath)
 	assert.True(t, storage.MinioConfig.ServeDirect)
 }
+
+func TestPackagesRemoteAllowedHostList(t *testing.T) {
+	defer test.MockVariableValue(&Security.AllowedHostList, "external")()
+
+	cfg, err := NewConfigProviderFromData(`
+[packages]
+ENABLED = true
+`)
+	assert.NoError(t, err)
+	assert.NoError(t, loadPackagesFrom(cfg))
+	assert.Equal(t, "external", Packages.RemoteAllowedHostList)
+
+	cfg, err = NewConfigProviderFromData(`
+[packages]
+REMOTE_ALLOWED_HOST_LIST = registry.npmjs.org,*.pypi.org
+`)
+	assert.NoError(t, err)
+	assert.NoError(t, loadPackagesFrom(cfg))
+	assert.Equal(t, "registry.npmjs.org,*.pypi.org", Packages.RemoteAllowedHostList)
+}
@@ -7,6 +7,7 @@ import (
 	"os/exec"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"gitea.dev/modules/log"
 )
@@ -100,6 +101,8 @@ var (
 			DelayCheckForInactiveDays                int
 			DefaultDeleteBranchAfterMerge            bool
 			DefaultTitleSource                       string
+			MergeQueueMaxBatchSize                   int
+			MergeQueueCheckTimeout                   time.Duration
 		} `ini:"repository.pull-request"`
 
 		// Issue Setting
@@ -228,6 +231,8 @@ var (
 			DelayCheckForInactiveDays                int
 			DefaultDeleteBranchAfterMerge            bool
 			DefaultTitleSource                       string
+			MergeQueueMaxBatchSize                   int
+			MergeQueueCheckTimeout                   time.Duration
 		}{
 			WorkInProgressPrefixes: []string{"WIP:", "[WIP]"},
 			// Same as GitHub. See
@@ -245,6 +250,8 @@ var (
 			RetargetChildrenOnMerge:                  true,
 			DelayCheckForInactiveDays:                7,
 			DefaultTitleSource:                       RepoPRTitleSourceAuto,
+			MergeQueueMaxBatchSize:                   5,
+			MergeQueueCheckTimeout:                   time.Hour,
 		},
 
 		// Issue settings
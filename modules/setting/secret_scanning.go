@@ -0,0 +1,19 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package setting
+
+// SecretScanning settings
+var SecretScanning = struct {
+	Enabled        bool
+	PushProtection bool
+	MaxFileSize    int64
+}{
+	Enabled:        false,
+	PushProtection: true,
+	MaxFileSize:    1 << 20,
+}
+
+func loadSecretScanningFrom(rootCfg ConfigProvider) {
+	mustMapSetting(rootCfg, "secret_scanning", &SecretScanning)
+}
@@ -155,6 +155,10 @@ func loadCommonSettingsFrom(cfg ConfigProvider) error {
 	loadI18nFrom(cfg)
 	loadGitFrom(cfg)
 	loadMirrorFrom(cfg)
+	loadSecretScanningFrom(cfg)
+	loadCodeNavigationFrom(cfg)
+	loadAuditFrom(cfg)
+	loadQuotaFrom(cfg)
 	loadMarkupFrom(cfg)
 	loadRedisFrom(cfg)
 	loadGlobalLockFrom(cfg)
@@ -180,6 +180,8 @@ var (
 	Actions ObjectStorage = uninitializedStorage
 	// ActionsArtifacts Artifacts represents actions artifacts storage
 	ActionsArtifacts ObjectStorage = uninitializedStorage
+	// ActionsCaches represents the storage of the caches of actions/cache
+	ActionsCaches ObjectStorage = uninitializedStorage
 )
 
 // Init init the storage
@@ -265,6 +267,7 @@ func initActions() (err error) {
 	if !setting.Actions.Enabled {
 		Actions = discardStorage("Actions isn't enabled")
 		ActionsArtifacts = discardStorage("ActionsArtifacts isn't enabled")
+		ActionsCaches = discardStorage("ActionsCaches isn't enabled")
 		return nil
 	}
 	log.Info("Initialising Actions storage with type: %s", setting.Actions.LogStorage.Type)
@@ -272,6 +275,14 @@ func initActions() (err error) {
 		return err
 	}
 	log.Info("Initialising ActionsArtifacts storage with type: %s", setting.Actions.ArtifactStorage.Type)
-	ActionsArtifacts, err = NewStorage(setting.Actions.ArtifactStorage.Type, setting.Actions.ArtifactStorage)
+	if ActionsArtifacts, err = NewStorage(setting.Actions.ArtifactStorage.Type, setting.Actions.ArtifactStorage); err != nil {
+		return err
+	}
+	if !setting.Actions.CacheEnabled {
+		ActionsCaches = discardStorage("ActionsCaches isn't enabled")
+		return nil
+	}
+	log.Info("Initialising ActionsCaches storage with type: %s", setting.Actions.CacheStorage.Type)
+	ActionsCaches, err = NewStorage(setting.Actions.CacheStorage.Type, setting.Actions.CacheStorage)
 	return err
 }
@@ -0,0 +1,41 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package structs
+
+import "time"
+
+// AuditEvent represents an administrative or security-relevant change recorded in the audit log
+type AuditEvent struct {
+	ID int64 `json:"id"`
+	// The kind of the change, e.g. "access_token.create" or "repo.visibility.change"
+	Action string `json:"action"`
+	// The user who made the change, 0 for the changes made by Gitea itself
+	ActorID   int64  `json:"actor_id"`
+	ActorName string `json:"actor_name"`
+	// The address of the client of the actor, only shown to the site administrators
+	IPAddress string `json:"ip_address,omitempty"`
+	// The user or the organization owning the target of the change
+	OwnerID int64 `json:"owner_id"`
+	// The repository of the target of the change, 0 if it has none
+	RepoID int64 `json:"repo_id"`
+	// The kind of the object changed, e.g. "webhook" or "team"
+	TargetType string            `json:"target_type"`
+	TargetID   int64             `json:"target_id"`
+	TargetName string            `json:"target_name"`
+	Metadata   map[string]string `json:"metadata"`
+	// swagger:strfmt date-time
+	Created time.Time `json:"created_at"`
+}
+
+// AuditLogVerification represents the result of the verification of the hash chain of the audit log
+type AuditLogVerification struct {
+	// Whether no event of the chain was altered or deleted, except the oldest ones deleted by the retention
+	Valid bool `json:"valid"`
+	// The number of events verified before the first invalid one
+	Verified int64 `json:"verified"`
+	// The first event which was altered or which follows deleted events, if any
+	FirstInvalid *AuditEvent `json:"first_invalid,omitempty"`
+	// The number of the recent events which aren't chained yet
+	Unsealed int64 `json:"unsealed"`
+}
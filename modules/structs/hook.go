@@ -53,7 +53,7 @@ type CreateHookOptionConfig map[string]string
 // CreateHookOption options when create a hook
 type CreateHookOption struct {
 	// required: true
-	// enum: ["dingtalk","discord","gitea","gogs","msteams","slack","telegram","feishu","wechatwork","packagist"]
+	// enum: ["dingtalk","discord","gitea","gogs","msteams","slack","telegram","feishu","wechatwork","packagist","template"]
 	// The type of the webhook to create
 	Type string `json:"type" binding:"Required"`
 	// required: true
@@ -88,6 +88,25 @@ type EditHookOption struct {
 	Name *string `json:"name,omitzero" binding:"MaxSize(255)"`
 }
 
+// PreviewHookOption options when previewing the request of a hook
+type PreviewHookOption struct {
+	// Configuration settings overriding the stored ones of a template webhook,
+	// e.g. "body_template", "headers_template" and "body_content_type"
+	Config map[string]string `json:"config"`
+}
+
+// HookPreview represents the request a hook would deliver for a push event
+type HookPreview struct {
+	// The HTTP method of the request
+	Method string `json:"method"`
+	// The URL the request would be sent to
+	URL string `json:"url"`
+	// The headers of the request, without the Authorization header
+	Headers map[string]string `json:"headers"`
+	// The body of the request
+	Body string `json:"body"`
+}
+
 // Payloader payload is some part of one hook
 type Payloader interface {
 	JSONPayload() ([]byte, error)
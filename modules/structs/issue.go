@@ -61,6 +61,9 @@ type Issue struct {
 	Labels           []*Label      `json:"labels"`
 	Milestone        *Milestone    `json:"milestone"`
 	Projects         []*Project    `json:"projects"`
+	Type             *IssueType    `json:"type"`
+	// The progress of the sub-issues, omitted for the issues without sub-issues
+	SubIssuesSummary *SubIssuesSummary `json:"sub_issues_summary,omitempty"`
 	// deprecated
 	Assignee  *User     `json:"assignee"`
 	Assignees []*User   `json:"assignees"`
@@ -103,7 +106,9 @@ type CreateIssueOption struct {
 	Labels []int64 `json:"labels"`
 	// list of project ids
 	Projects []int64 `json:"projects"`
-	Closed   bool    `json:"closed"`
+	// name of an issue type of the owner of the repository
+	Type   string `json:"type"`
+	Closed bool   `json:"closed"`
 }
 
 // EditIssueOption options for editing an issue
@@ -117,7 +122,9 @@ type EditIssueOption struct {
 	Milestone *int64   `json:"milestone"`
 	// list of project ids to set (replaces existing projects)
 	Projects *[]int64 `json:"projects"`
-	State    *string  `json:"state"`
+	// name of an issue type of the owner of the repository, empty removes the type
+	Type  *string `json:"type"`
+	State *string `json:"state"`
 	// swagger:strfmt date-time
 	Deadline       *time.Time `json:"due_date"`
 	RemoveDeadline *bool      `json:"unset_due_date"`
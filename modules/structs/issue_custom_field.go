@@ -0,0 +1,66 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package structs
+
+import "time"
+
+// CustomField represents a custom field of the issues and pull requests of a repository or of all the repositories of an organization
+type CustomField struct {
+	// The unique identifier of the custom field
+	ID int64 `json:"id"`
+	// The name of the custom field
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	// The type of the values of the custom field
+	// enum: text,number,date,single_select,multi_select,user
+	Type string `json:"type"`
+	// The choices of the select fields, in their sort order
+	Options []string `json:"options"`
+	// Whether the custom field belongs to the organization owning the repository
+	OrgLevel bool `json:"org_level"`
+	// Whether the issues can be sorted by the custom field
+	Sortable bool `json:"sortable"`
+	// swagger:strfmt date-time
+	Created time.Time `json:"created_at"`
+	// swagger:strfmt date-time
+	Updated time.Time `json:"updated_at"`
+}
+
+// CreateCustomFieldOption options for creating a custom field
+type CreateCustomFieldOption struct {
+	// required: true
+	Name        string `json:"name" binding:"Required;MaxSize(255)"`
+	Description string `json:"description"`
+	// required: true
+	// enum: text,number,date,single_select,multi_select,user
+	Type string `json:"type" binding:"Required"`
+	// The choices of the select fields, in their sort order
+	Options []string `json:"options"`
+}
+
+// EditCustomFieldOption options for editing a custom field, only the fields that are set are changed.
+// The type of a custom field can't be changed, the values of the removed options are cleared from the issues.
+type EditCustomFieldOption struct {
+	Name        *string  `json:"name" binding:"MaxSize(255)"`
+	Description *string  `json:"description"`
+	Options     []string `json:"options"`
+}
+
+// IssueCustomFieldValue represents the values of a custom field of an issue or pull request
+type IssueCustomFieldValue struct {
+	FieldID int64  `json:"field_id"`
+	Name    string `json:"name"`
+	// enum: text,number,date,single_select,multi_select,user
+	Type string `json:"type"`
+	// The values of the field: a number, a date formatted as YYYY-MM-DD, options of the select fields or user IDs.
+	// Only the multi select fields can have several values.
+	Values []string `json:"values"`
+}
+
+// SetIssueCustomFieldOption options for setting the values of a custom field of an issue or pull request
+type SetIssueCustomFieldOption struct {
+	// The values of the field: a number, a date formatted as YYYY-MM-DD, options of the select fields or user IDs.
+	// No values clears the field.
+	Values []string `json:"values"`
+}
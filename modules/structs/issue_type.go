@@ -0,0 +1,54 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package structs
+
+import "time"
+
+// IssueType represents a type of the issues of the repositories of an organization
+type IssueType struct {
+	ID          int64  `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	// example: 00aabb
+	Color string `json:"color"`
+	// Whether the type can't be set on issues anymore
+	IsDisabled bool `json:"is_disabled"`
+	// swagger:strfmt date-time
+	Created time.Time `json:"created_at"`
+	// swagger:strfmt date-time
+	Updated time.Time `json:"updated_at"`
+}
+
+// CreateIssueTypeOption options for creating an issue type
+type CreateIssueTypeOption struct {
+	// required: true
+	Name        string `json:"name" binding:"Required;MaxSize(50)"`
+	Description string `json:"description"`
+	// example: #00aabb
+	Color string `json:"color"`
+}
+
+// EditIssueTypeOption options for editing an issue type, only the fields that are set are changed
+type EditIssueTypeOption struct {
+	Name        *string `json:"name" binding:"MaxSize(50)"`
+	Description *string `json:"description"`
+	// example: #00aabb
+	Color      *string `json:"color"`
+	IsDisabled *bool   `json:"is_disabled"`
+}
+
+// SubIssuesSummary represents the progress of the sub-issues of an issue
+type SubIssuesSummary struct {
+	Total  int64 `json:"total"`
+	Closed int64 `json:"closed"`
+	// Percentage of the closed sub-issues
+	PercentCompleted int `json:"percent_completed"`
+}
+
+// ReorderSubIssuesOption options for changing the order of the sub-issues of an issue
+type ReorderSubIssuesOption struct {
+	// All the sub-issues of the issue in their new order
+	// required: true
+	SubIssues []IssueMeta `json:"sub_issues" binding:"Required"`
+}
@@ -39,3 +39,17 @@ type PushMirror struct {
 	// Whether to sync on every commit
 	SyncOnCommit bool `json:"sync_on_commit"`
 }
+
+// MigrationSync represents the periodic sync of a migrated repository with its source
+// swagger:model
+type MigrationSync struct {
+	// The source the issues, pull requests, comments, reviews and releases are synced from
+	OriginalURL string `json:"original_url"`
+	// The items updated on the source since this time are synced by the next sync
+	// swagger:strfmt date-time
+	LastSync time.Time `json:"last_sync"`
+	// swagger:strfmt date-time
+	NextSync time.Time `json:"next_sync"`
+	// The error of the last sync, empty if it succeeded
+	LastError string `json:"last_error"`
+}
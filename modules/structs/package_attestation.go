@@ -0,0 +1,58 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package structs
+
+import (
+	"time"
+)
+
+// PackageAttestation represents a signature, provenance statement or SBOM attached to a package version
+type PackageAttestation struct {
+	// The unique identifier of the attestation
+	ID int64 `json:"id"`
+	// The kind of the attestation
+	// enum: signature,provenance,sbom
+	Type string `json:"type"`
+	// The format of the content (gpg, ssh, in-toto, spdx, cyclonedx)
+	Format string `json:"format"`
+	// The name of the attestation
+	Name string `json:"name"`
+	// The name of the package file the attestation belongs to, empty if it applies to the whole version
+	FileName string `json:"file_name"`
+	// The size of the content in bytes
+	Size int64 `json:"size"`
+	// The SHA256 hash of the content
+	HashSHA256 string `json:"sha256"`
+	// The result of the verification against the package files
+	Verification *PackageAttestationVerification `json:"verification"`
+	// swagger:strfmt date-time
+	Created time.Time `json:"created_at"`
+}
+
+// PackageAttestationVerification represents the verification status of an attestation
+type PackageAttestationVerification struct {
+	Verified bool   `json:"verified"`
+	Reason   string `json:"reason"`
+	// The user who owns the key which created the signature
+	Signer *User `json:"signer"`
+	// The GPG key id or SSH key fingerprint of the signature
+	KeyID string `json:"key_id"`
+	// The name of the package file which matched the attestation
+	Subject string `json:"subject"`
+}
+
+// CreatePackageAttestationOption options for attaching an attestation to a package version
+type CreatePackageAttestationOption struct {
+	// required: true
+	// enum: signature,provenance,sbom
+	Type string `json:"type" binding:"Required;In(signature,provenance,sbom)"`
+	// The format is detected from the content if empty
+	Format string `json:"format"`
+	Name   string `json:"name" binding:"MaxSize(255)"`
+	// The name of the package file the attestation belongs to. If empty the attestation applies to the whole version.
+	FileName string `json:"file_name"`
+	// Base64 encoded content of the attestation
+	// required: true
+	Content string `json:"content" binding:"Required"`
+}
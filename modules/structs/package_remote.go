@@ -0,0 +1,56 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package structs
+
+import (
+	"time"
+)
+
+// PackageRemote represents an upstream registry used as pull-through source for a package type
+type PackageRemote struct {
+	// The unique identifier of the remote
+	ID int64 `json:"id"`
+	// The package type served by the remote
+	Type string `json:"type"`
+	// The mode of the remote, "remote" serves only upstream packages while "virtual" also accepts uploads
+	Mode string `json:"mode"`
+	// The URL of the upstream registry
+	URL string `json:"url"`
+	// The username used to authenticate at the upstream registry
+	Username string `json:"username"`
+	// Whether a password is configured for the upstream registry
+	HasPassword bool `json:"has_password"`
+	// The number of seconds cached upstream metadata is considered fresh
+	MetadataTTL int64 `json:"metadata_ttl"`
+	// swagger:strfmt date-time
+	Created time.Time `json:"created_at"`
+	// swagger:strfmt date-time
+	Updated time.Time `json:"updated_at"`
+}
+
+// CreatePackageRemoteOption options for creating a package remote
+type CreatePackageRemoteOption struct {
+	// required: true
+	// enum: container,maven,npm,pypi
+	Type string `json:"type" binding:"Required"`
+	// enum: remote,virtual
+	Mode string `json:"mode"`
+	// required: true
+	URL      string `json:"url" binding:"Required;ValidUrl"`
+	Username string `json:"username"`
+	Password string `json:"password"`
+	// The number of seconds cached upstream metadata is considered fresh, 0 uses the default
+	MetadataTTL int64 `json:"metadata_ttl"`
+}
+
+// EditPackageRemoteOption options for editing a package remote
+type EditPackageRemoteOption struct {
+	// enum: remote,virtual
+	Mode     *string `json:"mode"`
+	URL      *string `json:"url" binding:"OmitEmpty;ValidUrl"`
+	Username *string `json:"username"`
+	// An empty password removes the configured password
+	Password    *string `json:"password"`
+	MetadataTTL *int64  `json:"metadata_ttl"`
+}
@@ -119,3 +119,184 @@ type MoveProjectIssueOption struct {
 	// the rest, equal values are ordered newest first.
 	Sorting *int64 `json:"sorting,omitempty"`
 }
+
+// ProjectView represents a saved view of a project's issues
+// swagger:model
+type ProjectView struct {
+	ID        int64  `json:"id"`
+	ProjectID int64  `json:"project_id"`
+	Name      string `json:"name"`
+	// Layout of the view: "board", "table" or "roadmap"
+	Layout string `json:"layout"`
+	// Grouping of the issues: "column", "milestone", "repository" or "field:{field_id}", empty when not grouped
+	GroupBy string `json:"group_by"`
+	// Sorting of the issues within their group: "created", "updated", "title" or "field:{field_id}",
+	// empty to keep their order in the columns
+	SortBy   string `json:"sort_by"`
+	SortDesc bool   `json:"sort_desc"`
+	// Date or iteration field placing the issues on a roadmap, 0 uses their due dates
+	DateFieldID int64 `json:"date_field_id"`
+	// Position of the view within the project's views
+	Sorting int64 `json:"sorting"`
+	// swagger:strfmt date-time
+	CreatedAt time.Time `json:"created_at"`
+	// swagger:strfmt date-time
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+}
+
+// CreateProjectViewOption represents options for creating a project view
+// swagger:model
+type CreateProjectViewOption struct {
+	// required: true
+	Name string `json:"name" binding:"Required"`
+	// Layout of the view: "board", "table" or "roadmap"
+	// required: true
+	Layout string `json:"layout" binding:"Required"`
+	// Grouping of the issues, a board is grouped by column when omitted
+	GroupBy  string `json:"group_by"`
+	SortBy   string `json:"sort_by"`
+	SortDesc bool   `json:"sort_desc"`
+	// Date or iteration field placing the issues of a roadmap
+	DateFieldID int64 `json:"date_field_id"`
+}
+
+// EditProjectViewOption represents options for editing a project view
+// swagger:model
+type EditProjectViewOption struct {
+	Name        *string `json:"name,omitempty" binding:"TrimSpace;Required"`
+	Layout      *string `json:"layout,omitempty"`
+	GroupBy     *string `json:"group_by,omitempty"`
+	SortBy      *string `json:"sort_by,omitempty"`
+	SortDesc    *bool   `json:"sort_desc,omitempty"`
+	DateFieldID *int64  `json:"date_field_id,omitempty"`
+	Sorting     *int64  `json:"sorting,omitempty"`
+}
+
+// ProjectViewGroup represents the group of an issue in a project view
+// swagger:model
+type ProjectViewGroup struct {
+	// Key of the group: a column, milestone, repository or iteration ID, or a field value. Empty for the issues
+	// without value, which are listed last.
+	Key   string `json:"key"`
+	Title string `json:"title"`
+}
+
+// ProjectViewItem represents an issue of a project as shown by a view
+// swagger:model
+type ProjectViewItem struct {
+	Issue       *Issue               `json:"issue"`
+	ColumnID    int64                `json:"column_id"`
+	FieldValues []*ProjectFieldValue `json:"field_values"`
+	Group       *ProjectViewGroup    `json:"group,omitempty"`
+	// Start of the issue on a roadmap
+	// swagger:strfmt date-time
+	StartDate *time.Time `json:"start_date,omitempty"`
+	// End of the issue on a roadmap
+	// swagger:strfmt date-time
+	EndDate *time.Time `json:"end_date,omitempty"`
+}
+
+// ProjectField represents a custom field of a project's issues
+// swagger:model
+type ProjectField struct {
+	ID        int64  `json:"id"`
+	ProjectID int64  `json:"project_id"`
+	Name      string `json:"name"`
+	// Type of the field: "text", "number", "date", "single_select" or "iteration"
+	Type string `json:"type"`
+	// Choices of a single select field
+	Options []string `json:"options,omitempty"`
+	// Iterations of an iteration field, by start date
+	Iterations []*ProjectIteration `json:"iterations,omitempty"`
+	// swagger:strfmt date-time
+	CreatedAt time.Time `json:"created_at"`
+	// swagger:strfmt date-time
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+}
+
+// CreateProjectFieldOption represents options for creating a project field
+// swagger:model
+type CreateProjectFieldOption struct {
+	// required: true
+	Name string `json:"name" binding:"Required"`
+	// Type of the field: "text", "number", "date", "single_select" or "iteration"
+	// required: true
+	Type string `json:"type" binding:"Required"`
+	// Choices of a single select field
+	Options []string `json:"options"`
+}
+
+// EditProjectFieldOption represents options for editing a project field, its type can't be changed
+// swagger:model
+type EditProjectFieldOption struct {
+	Name *string `json:"name,omitempty" binding:"TrimSpace;Required"`
+	// Choices of a single select field, the values of the removed choices are cleared
+	Options []string `json:"options,omitempty"`
+}
+
+// ProjectIteration represents an iteration of an iteration field, like a sprint
+// swagger:model
+type ProjectIteration struct {
+	ID      int64  `json:"id"`
+	FieldID int64  `json:"field_id"`
+	Title   string `json:"title"`
+	// First day of the iteration, formatted as YYYY-MM-DD
+	StartDate string `json:"start_date"`
+	// Duration of the iteration in days
+	Duration int `json:"duration"`
+	// First day after the iteration, formatted as YYYY-MM-DD
+	EndDate string `json:"end_date"`
+}
+
+// CreateProjectIterationOption represents options for creating an iteration
+// swagger:model
+type CreateProjectIterationOption struct {
+	// required: true
+	Title string `json:"title" binding:"Required"`
+	// First day of the iteration, formatted as YYYY-MM-DD
+	// required: true
+	StartDate string `json:"start_date" binding:"Required"`
+	// Duration of the iteration in days
+	// required: true
+	Duration int `json:"duration" binding:"Required"`
+}
+
+// EditProjectIterationOption represents options for editing an iteration
+// swagger:model
+type EditProjectIterationOption struct {
+	Title     *string `json:"title,omitempty" binding:"TrimSpace;Required"`
+	StartDate *string `json:"start_date,omitempty"`
+	Duration  *int    `json:"duration,omitempty"`
+}
+
+// RolloverProjectIterationOption represents options for moving the open issues of an iteration to another one
+// swagger:model
+type RolloverProjectIterationOption struct {
+	// Iteration receiving the open issues, the iteration following the rolled over one when omitted
+	ToIterationID int64 `json:"to_iteration_id"`
+}
+
+// ProjectIterationRollover represents the result of an iteration rollover
+// swagger:model
+type ProjectIterationRollover struct {
+	From *ProjectIteration `json:"from"`
+	To   *ProjectIteration `json:"to"`
+	// Number of open issues moved to the target iteration
+	MovedIssues int64 `json:"moved_issues"`
+}
+
+// ProjectFieldValue represents the value of a project field for an issue
+// swagger:model
+type ProjectFieldValue struct {
+	FieldID int64 `json:"field_id"`
+	// Value of the field: a number, a date formatted as YYYY-MM-DD, an option, or the ID of an iteration
+	Value string `json:"value"`
+}
+
+// SetProjectFieldValueOption represents options for setting the value of a project field for an issue
+// swagger:model
+type SetProjectFieldValueOption struct {
+	// Value of the field: a number, a date formatted as YYYY-MM-DD, an option, or the ID of an iteration
+	// required: true
+	Value string `json:"value" binding:"Required"`
+}
@@ -0,0 +1,34 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package structs
+
+import "time"
+
+// MergeQueueEntry represents a pull request in the merge queue of its base branch
+type MergeQueueEntry struct {
+	// The index of the queued pull request
+	PullRequestIndex int64 `json:"pull_request_index"`
+	// The branch the pull request is merged into
+	BaseBranch string `json:"base_branch"`
+	// "waiting" for its speculative merge to be built, "testing" its speculative merge, or "ejected" from the queue
+	// enum: waiting,testing,ejected
+	Status string `json:"status"`
+	// The merge style used to build the speculative merge
+	MergeStyle string `json:"merge_style"`
+	// The head of the pull request the speculative merge was built from
+	HeadCommitID string `json:"head_commit_id"`
+	// The base branch or the speculative merge of the previous entry the speculative merge was built onto
+	BaseCommitID string `json:"base_commit_id"`
+	// The speculative merge whose checks must pass, it is pushed to the gitea-merge-queue/{base_branch}/pr-{index} branch
+	MergeCommitID string `json:"merge_commit_id"`
+	// Why the pull request was ejected from the queue
+	Reason   string `json:"reason"`
+	QueuedBy *User  `json:"queued_by"`
+	// swagger:strfmt date-time
+	Tested *time.Time `json:"tested_at"`
+	// swagger:strfmt date-time
+	Created time.Time `json:"created_at"`
+	// swagger:strfmt date-time
+	Updated time.Time `json:"updated_at"`
+}
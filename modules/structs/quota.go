@@ -0,0 +1,76 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package structs
+
+import (
+	"time"
+)
+
+// QuotaInfo represents the quota of a user or an organization
+type QuotaInfo struct {
+	Used QuotaUsed `json:"used"`
+	// The groups of the owner, or the default groups if it has neither groups nor rules of its own
+	Groups []*QuotaGroup `json:"groups"`
+	// The rules attached directly to the owner
+	Rules []*QuotaRule `json:"rules"`
+}
+
+// QuotaUsed represents the storage used by a user or an organization in bytes
+type QuotaUsed struct {
+	Git         int64 `json:"git"`
+	LFS         int64 `json:"lfs"`
+	Attachments int64 `json:"attachments"`
+	Artifacts   int64 `json:"artifacts"`
+	Packages    int64 `json:"packages"`
+	Total       int64 `json:"total"`
+	// swagger:strfmt date-time
+	Updated time.Time `json:"updated_at"`
+}
+
+// QuotaUsage represents the storage used by a user or an organization
+type QuotaUsage struct {
+	Owner *User     `json:"owner"`
+	Used  QuotaUsed `json:"used"`
+}
+
+// QuotaRule represents a quota rule
+type QuotaRule struct {
+	Name string `json:"name"`
+	// The limit in bytes, -1 means unlimited
+	Limit int64 `json:"limit"`
+	// The subjects limited by the rule
+	Subjects []string `json:"subjects"`
+}
+
+// QuotaGroup represents a quota group
+type QuotaGroup struct {
+	Name  string       `json:"name"`
+	Rules []*QuotaRule `json:"rules"`
+}
+
+// CreateQuotaRuleOption options for creating a quota rule
+type CreateQuotaRuleOption struct {
+	// required: true
+	Name string `json:"name" binding:"Required;MaxSize(100)"`
+	// The limit in bytes, -1 means unlimited
+	// required: true
+	Limit int64 `json:"limit"`
+	// The subjects limited by the rule: size:all, size:repos, size:git, size:lfs, size:assets, size:attachments, size:artifacts, size:packages
+	// required: true
+	Subjects []string `json:"subjects" binding:"Required"`
+}
+
+// EditQuotaRuleOption options for editing a quota rule
+type EditQuotaRuleOption struct {
+	// The limit in bytes, -1 means unlimited
+	Limit *int64 `json:"limit"`
+	// The subjects limited by the rule
+	Subjects *[]string `json:"subjects"`
+}
+
+// CreateQuotaGroupOption options for creating a quota group
+type CreateQuotaGroupOption struct {
+	// required: true
+	Name string `json:"name" binding:"Required;MaxSize(100)"`
+}
@@ -344,22 +344,24 @@ type GitServiceType int
 
 // enumerate all GitServiceType
 const (
-	NotMigrated       GitServiceType = iota // 0 not migrated from external sites
-	PlainGitService                         // 1 plain git service
-	GithubService                           // 2 github.com
-	GiteaService                            // 3 gitea service
-	GitlabService                           // 4 gitlab service
-	GogsService                             // 5 gogs service
-	OneDevService                           // 6 onedev service
-	GitBucketService                        // 7 gitbucket service
-	CodebaseService                         // 8 codebase service
-	CodeCommitService                       // 9 codecommit service
+	NotMigrated        GitServiceType = iota // 0 not migrated from external sites
+	PlainGitService                          // 1 plain git service
+	GithubService                            // 2 github.com
+	GiteaService                             // 3 gitea service
+	GitlabService                            // 4 gitlab service
+	GogsService                              // 5 gogs service
+	OneDevService                            // 6 onedev service
+	GitBucketService                         // 7 gitbucket service
+	CodebaseService                          // 8 codebase service
+	CodeCommitService                        // 9 codecommit service
+	BitbucketService                         // 10 bitbucket cloud or server service
+	AzureDevOpsService                       // 11 azure devops services or server
 )
 
 // Name represents the service type's name
 // WARNING: the name has to be equal to that on goth's library
 func (gt GitServiceType) Name() string {
-	return strings.ToLower(gt.Title())
+	return strings.ToLower(strings.ReplaceAll(gt.Title(), " ", ""))
 }
 
 // Title represents the service type's proper title
@@ -381,6 +383,10 @@ func (gt GitServiceType) Title() string {
 		return "Codebase"
 	case CodeCommitService:
 		return "CodeCommit"
+	case BitbucketService:
+		return "Bitbucket"
+	case AzureDevOpsService:
+		return "Azure DevOps"
 	case PlainGitService:
 		return "Git"
 	}
@@ -399,7 +405,7 @@ type MigrateRepoOptions struct {
 	// required: true
 	RepoName string `json:"repo_name" binding:"Required;AlphaDashDot;MaxSize(100)"`
 
-	// enum: ["git","github","gitea","gitlab","gogs","onedev","gitbucket","codebase","codecommit"]
+	// enum: ["git","github","gitea","gitlab","gogs","onedev","gitbucket","codebase","codecommit","bitbucket","azuredevops"]
 	Service      string `json:"service"`
 	AuthUsername string `json:"auth_username"`
 	AuthPassword string `json:"auth_password"`
@@ -417,6 +423,9 @@ type MigrateRepoOptions struct {
 	PullRequests   bool   `json:"pull_requests"`
 	Releases       bool   `json:"releases"`
 	MirrorInterval string `json:"mirror_interval"`
+	// periodically sync the issues, pull requests, comments, reviews and releases updated on the source
+	// after the migration, not supported for mirrors and plain git repositories
+	Sync bool `json:"sync"`
 
 	AWSAccessKeyID     string `json:"aws_access_key_id"`
 	AWSSecretAccessKey string `json:"aws_secret_access_key"`
@@ -425,7 +434,7 @@ type MigrateRepoOptions struct {
 // TokenAuth represents whether a service type supports token-based auth
 func (gt GitServiceType) TokenAuth() bool {
 	switch gt {
-	case GithubService, GiteaService, GitlabService:
+	case GithubService, GiteaService, GitlabService, BitbucketService, AzureDevOpsService:
 		return true
 	}
 	return false
@@ -442,6 +451,8 @@ var SupportedFullGitService = []GitServiceType{
 	GitBucketService,
 	CodebaseService,
 	CodeCommitService,
+	BitbucketService,
+	AzureDevOpsService,
 }
 
 // RepoTransfer represents a pending repo transfer
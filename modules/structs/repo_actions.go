@@ -186,6 +186,26 @@ type ActionArtifactsResponse struct {
 	TotalCount int64             `json:"total_count"`
 }
 
+// ActionCache represents an entry of the cache used by actions/cache
+type ActionCache struct {
+	ID           int64  `json:"id"`
+	RepositoryID int64  `json:"repository_id"`
+	Ref          string `json:"ref"`
+	Key          string `json:"key"`
+	Version      string `json:"version"`
+	SizeInBytes  int64  `json:"size_in_bytes"`
+	// swagger:strfmt date-time
+	LastAccessedAt time.Time `json:"last_accessed_at"`
+	// swagger:strfmt date-time
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// ActionCacheList returns a list of actions caches
+type ActionCacheList struct {
+	Entries    []*ActionCache `json:"actions_caches"`
+	TotalCount int64          `json:"total_count"`
+}
+
 // ActionWorkflowStep represents a step of a WorkflowJob
 type ActionWorkflowStep struct {
 	Name       string `json:"name"`
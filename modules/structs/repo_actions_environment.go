@@ -0,0 +1,94 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package structs
+
+import "time"
+
+// ActionEnvironment represents a deployment environment of a repository
+// swagger:model
+type ActionEnvironment struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+	// the url shown for deployments to the environment
+	ExternalURL string `json:"external_url"`
+	// minutes a job waits after its deployment has been approved
+	WaitTimer int64 `json:"wait_timer"`
+	// users who may approve deployments
+	Reviewers []*User `json:"reviewers"`
+	// teams whose members may approve deployments
+	ReviewerTeams []*Team `json:"reviewer_teams"`
+	// whether the user who triggered a run may approve its deployments
+	PreventSelfReview bool `json:"prevent_self_review"`
+	// which refs may deploy to the environment
+	// enum: all,protected,custom
+	DeploymentBranchPolicy string `json:"deployment_branch_policy"`
+	// glob patterns of the branches and tags allowed by the "custom" policy
+	BranchPatterns []string `json:"branch_patterns"`
+	// swagger:strfmt date-time
+	Created time.Time `json:"created_at"`
+	// swagger:strfmt date-time
+	Updated time.Time `json:"updated_at"`
+}
+
+// CreateOrUpdateActionEnvironmentOption options when creating or updating a deployment environment
+// swagger:model
+type CreateOrUpdateActionEnvironmentOption struct {
+	ExternalURL string `json:"external_url" binding:"OmitEmpty;ValidUrl"`
+	// minutes a job waits after its deployment has been approved, at most 43200 (30 days)
+	WaitTimer int64 `json:"wait_timer"`
+	// usernames of the users who may approve deployments
+	Reviewers []string `json:"reviewers"`
+	// names of the teams whose members may approve deployments, only for repositories owned by an organization
+	ReviewerTeams     []string `json:"reviewer_teams"`
+	PreventSelfReview bool     `json:"prevent_self_review"`
+	// enum: all,protected,custom
+	DeploymentBranchPolicy string `json:"deployment_branch_policy"`
+	// glob patterns of the branches and tags allowed by the "custom" policy
+	BranchPatterns []string `json:"branch_patterns"`
+}
+
+// ActionDeployment represents a job deploying to an environment
+// swagger:model
+type ActionDeployment struct {
+	ID          int64  `json:"id"`
+	Environment string `json:"environment"`
+	RunID       int64  `json:"run_id"`
+	JobID       int64  `json:"job_id"`
+	JobName     string `json:"job_name"`
+	Ref         string `json:"ref"`
+	CommitSHA   string `json:"sha"`
+	URL         string `json:"url"`
+	// enum: waiting,approved,rejected
+	Status string `json:"status"`
+	// the status of the deploying job
+	JobStatus string `json:"job_status"`
+	Creator   *User  `json:"creator"`
+	// swagger:strfmt date-time
+	Created time.Time `json:"created_at"`
+	// swagger:strfmt date-time
+	Updated time.Time `json:"updated_at"`
+}
+
+// PendingDeployment represents a deployment of a run waiting for a reviewer
+// swagger:model
+type PendingDeployment struct {
+	Deployment *ActionDeployment `json:"deployment"`
+	// minutes the job waits once the deployment has been approved
+	WaitTimer int64 `json:"wait_timer"`
+	// whether the authenticated user may approve or reject the deployment
+	CurrentUserCanApprove bool    `json:"current_user_can_approve"`
+	Reviewers             []*User `json:"reviewers"`
+	ReviewerTeams         []*Team `json:"reviewer_teams"`
+}
+
+// ReviewPendingDeploymentsOption options when approving or rejecting the pending deployments of a run
+// swagger:model
+type ReviewPendingDeploymentsOption struct {
+	// names of the environments to review, all the pending deployments the user can review if empty
+	Environments []string `json:"environments"`
+	// required: true
+	// enum: approved,rejected
+	State   string `json:"state" binding:"Required;In(approved,rejected)"`
+	Comment string `json:"comment"`
+}
@@ -0,0 +1,54 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package structs
+
+import "time"
+
+// CodeNavPosition represents a position in a file, like in the LSP the line and the character start at 0
+type CodeNavPosition struct {
+	Line      int `json:"line"`
+	Character int `json:"character"`
+}
+
+// CodeNavRange represents the range of an occurrence of a symbol, the end is excluded
+type CodeNavRange struct {
+	Start CodeNavPosition `json:"start"`
+	End   CodeNavPosition `json:"end"`
+}
+
+// CodeNavLocation represents an occurrence of a symbol in a file of the commit
+type CodeNavLocation struct {
+	// Path is the path of the file in the repository
+	Path  string       `json:"path"`
+	Range CodeNavRange `json:"range"`
+	// HTMLURL is the web URL of the line of the occurrence
+	HTMLURL string `json:"html_url"`
+}
+
+// CodeNavSymbol represents the symbol at a position of a file with its definitions and references
+type CodeNavSymbol struct {
+	// Symbol identifies the symbol in the index
+	Symbol string `json:"symbol"`
+	// Range is the occurrence of the symbol at the requested position
+	Range CodeNavRange `json:"range"`
+	// Documentation is the Markdown documentation of the symbol
+	Documentation string `json:"documentation"`
+	// Definitions are the occurrences defining the symbol
+	Definitions []*CodeNavLocation `json:"definitions"`
+	// References are all the occurrences of the symbol, including the definitions
+	References []*CodeNavLocation `json:"references"`
+}
+
+// CodeNavIndex represents the code navigation index of a commit
+type CodeNavIndex struct {
+	CommitSHA string `json:"commit_sha"`
+	// Format is the format of the index: scip, lsif or ctags for the indexes generated by Gitea
+	Format string `json:"format"`
+	// Occurrences is the number of the occurrences of the symbols in the index
+	Occurrences int64 `json:"occurrences"`
+	// ArtifactID is the Actions artifact the index was imported from, 0 for the uploaded and generated indexes
+	ArtifactID int64 `json:"artifact_id"`
+	// swagger:strfmt date-time
+	Created time.Time `json:"created_at"`
+}
@@ -0,0 +1,44 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package structs
+
+// CodeSearchResults represents the results of a code search in a repository
+type CodeSearchResults struct {
+	// TotalCount is the number of files matching the search
+	TotalCount int64 `json:"total_count"`
+	// Ref is the full name of the branch or tag searched in
+	Ref string `json:"ref"`
+	// Items are the matching files of the requested page
+	Items []*CodeSearchResult `json:"items"`
+	// Languages are the languages of the matching files with their count, they are only provided by the code indexer
+	Languages []*CodeSearchLanguage `json:"languages"`
+}
+
+// CodeSearchResult represents a file matching a code search
+type CodeSearchResult struct {
+	// Filename is the path of the file
+	Filename string `json:"filename"`
+	// CommitID is the commit the file has been indexed at
+	CommitID string `json:"commit_id"`
+	// Language is the detected language of the file
+	Language string `json:"language"`
+	// HTMLURL is the web URL of the file
+	HTMLURL string `json:"html_url"`
+	// Lines are the matching lines with their context
+	Lines []*CodeSearchResultLine `json:"lines"`
+}
+
+// CodeSearchResultLine represents a line of a file matching a code search
+type CodeSearchResultLine struct {
+	// Number is the line number in the file
+	Number int `json:"number"`
+	// Content is the content of the line
+	Content string `json:"content"`
+}
+
+// CodeSearchLanguage represents the number of files of a language matching a code search
+type CodeSearchLanguage struct {
+	Language string `json:"language"`
+	Count    int    `json:"count"`
+}
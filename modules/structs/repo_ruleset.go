@@ -0,0 +1,77 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package structs
+
+import "time"
+
+// RulesetRules are the requirements of a ruleset
+type RulesetRules struct {
+	// Prevent deleting the matched refs
+	Deletion bool `json:"deletion"`
+	// Prevent force pushes to the matched refs
+	NonFastForward bool `json:"non_fast_forward"`
+	// Prevent merge commits from being pushed
+	RequiredLinearHistory bool `json:"required_linear_history"`
+	// Glob patterns of the commit status contexts which must succeed
+	RequiredStatusChecks []string `json:"required_status_checks"`
+	// Require the pushed commits to be signed with a verified key
+	RequiredSignatures bool `json:"required_signatures"`
+	// Glob patterns of the files the pushed commits must not change
+	BlockedFilePaths []string `json:"blocked_file_paths"`
+	// Regular expression the messages of the pushed commits must match
+	CommitMessagePattern string `json:"commit_message_pattern"`
+	// Largest file in bytes the pushed commits may add, 0 means no limit
+	MaxFileSize int64 `json:"max_file_size"`
+}
+
+// Ruleset represents a set of rules applied to the branches or tags of a repository or of all the repositories of an organization
+type Ruleset struct {
+	// The unique identifier of the ruleset
+	ID int64 `json:"id"`
+	// The name of the ruleset
+	Name string `json:"name"`
+	// The kind of refs the ruleset applies to
+	// enum: branch,tag
+	Target string `json:"target"`
+	// How the violations are handled, "evaluate" only logs them
+	// enum: disabled,active,evaluate
+	Enforcement string `json:"enforcement"`
+	// Whether the ruleset belongs to the organization owning the repository
+	OrgLevel bool `json:"org_level"`
+	// Glob patterns of the branch or tag names the ruleset applies to, "~DEFAULT_BRANCH" and "~ALL" are supported
+	IncludeRefs []string `json:"include_refs"`
+	// Glob patterns of the branch or tag names excluded from the ruleset
+	ExcludeRefs []string      `json:"exclude_refs"`
+	Rules       *RulesetRules `json:"rules"`
+	// swagger:strfmt date-time
+	Created time.Time `json:"created_at"`
+	// swagger:strfmt date-time
+	Updated time.Time `json:"updated_at"`
+}
+
+// CreateRulesetOption options for creating a ruleset
+type CreateRulesetOption struct {
+	// required: true
+	Name string `json:"name" binding:"Required;MaxSize(255)"`
+	// enum: branch,tag
+	Target string `json:"target" binding:"Required"`
+	// enum: disabled,active,evaluate
+	Enforcement string `json:"enforcement" binding:"Required"`
+	// required: true
+	IncludeRefs []string      `json:"include_refs" binding:"Required"`
+	ExcludeRefs []string      `json:"exclude_refs"`
+	Rules       *RulesetRules `json:"rules"`
+}
+
+// EditRulesetOption options for editing a ruleset, only the fields that are set are changed
+type EditRulesetOption struct {
+	Name *string `json:"name" binding:"MaxSize(255)"`
+	// enum: branch,tag
+	Target *string `json:"target"`
+	// enum: disabled,active,evaluate
+	Enforcement *string       `json:"enforcement"`
+	IncludeRefs []string      `json:"include_refs"`
+	ExcludeRefs []string      `json:"exclude_refs"`
+	Rules       *RulesetRules `json:"rules"`
+}
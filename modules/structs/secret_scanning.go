@@ -0,0 +1,94 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package structs
+
+import "time"
+
+// SecretScanningAlert represents a secret found in the history of a repository
+type SecretScanningAlert struct {
+	// The unique identifier of the alert
+	ID int64 `json:"id"`
+	// The identifier of the pattern which matched the secret, "custom_<id>" for the custom patterns
+	PatternID   string `json:"pattern_id"`
+	PatternName string `json:"pattern_name"`
+	// The secret with most of its characters hidden
+	Secret string `json:"secret"`
+	// The oldest commit known to add the secret
+	CommitID string `json:"commit_id"`
+	Path     string `json:"path"`
+	Line     int    `json:"line"`
+	// enum: open,resolved
+	State string `json:"state"`
+	// enum: false_positive,revoked,used_in_tests,wont_fix
+	Resolution        string `json:"resolution"`
+	ResolutionComment string `json:"resolution_comment"`
+	ResolvedBy        *User  `json:"resolved_by"`
+	// swagger:strfmt date-time
+	Resolved *time.Time `json:"resolved_at"`
+	// Whether the secret was pushed despite the push protection
+	PushProtectionBypassed   bool  `json:"push_protection_bypassed"`
+	PushProtectionBypassedBy *User `json:"push_protection_bypassed_by"`
+	// swagger:strfmt date-time
+	PushProtectionBypassedAt *time.Time `json:"push_protection_bypassed_at"`
+	// swagger:strfmt date-time
+	Created time.Time `json:"created_at"`
+	// swagger:strfmt date-time
+	Updated time.Time `json:"updated_at"`
+}
+
+// SecretScanningAlertEvent represents a change of a secret scanning alert
+type SecretScanningAlertEvent struct {
+	ID int64 `json:"id"`
+	// enum: created,bypassed,resolved,reopened
+	Type string `json:"type"`
+	// The resolution of a resolved event or the reason of a bypassed event
+	Reason  string `json:"reason"`
+	Comment string `json:"comment"`
+	// The user who made the change, null for the alerts created by a scan of the history
+	Doer *User `json:"doer"`
+	// swagger:strfmt date-time
+	Created time.Time `json:"created_at"`
+}
+
+// EditSecretScanningAlertOption options for resolving or reopening a secret scanning alert
+type EditSecretScanningAlertOption struct {
+	// required: true
+	// enum: open,resolved
+	State string `json:"state" binding:"Required"`
+	// Required when the alert is resolved
+	// enum: false_positive,revoked,used_in_tests,wont_fix
+	Resolution        string `json:"resolution"`
+	ResolutionComment string `json:"resolution_comment"`
+}
+
+// SecretScanningPattern represents a custom pattern of secret of the instance or of an organization
+type SecretScanningPattern struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+	// A regular expression, the secret is its capture group if it has one
+	Pattern string `json:"pattern"`
+	// Whether the pushes adding secrets matched by the pattern are rejected, otherwise they are only reported as alerts
+	PushProtection bool `json:"push_protection"`
+	// swagger:strfmt date-time
+	Created time.Time `json:"created_at"`
+	// swagger:strfmt date-time
+	Updated time.Time `json:"updated_at"`
+}
+
+// CreateSecretScanningPatternOption options for creating a custom pattern of secret
+type CreateSecretScanningPatternOption struct {
+	// required: true
+	Name string `json:"name" binding:"Required;MaxSize(100)"`
+	// required: true
+	Pattern string `json:"pattern" binding:"Required"`
+	// Defaults to true
+	PushProtection *bool `json:"push_protection"`
+}
+
+// EditSecretScanningPatternOption options for editing a custom pattern of secret, only the fields that are set are changed
+type EditSecretScanningPatternOption struct {
+	Name           *string `json:"name" binding:"MaxSize(100)"`
+	Pattern        *string `json:"pattern"`
+	PushProtection *bool   `json:"push_protection"`
+}
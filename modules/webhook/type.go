@@ -128,6 +128,7 @@ const (
 	MATRIX     HookType = "matrix"
 	WECHATWORK HookType = "wechatwork"
 	PACKAGIST  HookType = "packagist"
+	TEMPLATE   HookType = "template"
 )
 
 // HookStatus is the status of a web hook
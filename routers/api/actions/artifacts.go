@@ -72,6 +72,7 @@ import (
 
 	"gitea.dev/models/actions"
 	"gitea.dev/models/db"
+	quota_model "gitea.dev/models/quota"
 	"gitea.dev/modules/httplib"
 	"gitea.dev/modules/json"
 	"gitea.dev/modules/log"
@@ -85,6 +86,7 @@ import (
 	web_types "gitea.dev/modules/web/types"
 	actions_service "gitea.dev/services/actions"
 	"gitea.dev/services/context"
+	quota_service "gitea.dev/services/quota"
 )
 
 const artifactRouteBase = "/_apis/pipelines/workflows/{run_id}/artifacts"
@@ -246,6 +248,9 @@ func (ar artifactRoutes) uploadArtifact(ctx *ArtifactContext) {
 	if !ok {
 		return
 	}
+	if !checkArtifactQuota(ctx, task.OwnerID, ctx.Req.ContentLength) {
+		return
+	}
 
 	fileRealTotalSize := getUploadFileSize(ctx)
 	var expiry optional.Option[timeutil.TimeStamp]
@@ -291,6 +296,21 @@ func (ar artifactRoutes) uploadArtifact(ctx *ArtifactContext) {
 	})
 }
 
+// checkArtifactQuota checks if the owner may store size more bytes of artifacts, the error response is written otherwise
+func checkArtifactQuota(ctx *ArtifactContext, ownerID, size int64) bool {
+	err := quota_service.CheckQuota(ctx, ownerID, quota_model.CategoryArtifacts, max(size, 0))
+	if err == nil {
+		return true
+	}
+	if errors.Is(err, util.ErrContentTooLarge) {
+		ctx.HTTPError(http.StatusRequestEntityTooLarge, "Quota exceeded")
+	} else {
+		log.Error("Error check quota: %v", err)
+		ctx.HTTPError(http.StatusInternalServerError, "Error check quota")
+	}
+	return false
+}
+
 // confirmUploadArtifact confirm upload artifact.
 // if all chunks are uploaded, merge them to one file.
 func (ar artifactRoutes) confirmUploadArtifact(ctx *ArtifactContext) {
@@ -309,6 +329,7 @@ func (ar artifactRoutes) confirmUploadArtifact(ctx *ArtifactContext) {
 		ctx.HTTPError(http.StatusInternalServerError, "Error merge chunks")
 		return
 	}
+	quota_service.UpdateUsage(ctx, ctx.ActionTask.OwnerID, quota_model.CategoryArtifacts)
 	ctx.JSON(http.StatusOK, map[string]string{
 		"message": "success",
 	})
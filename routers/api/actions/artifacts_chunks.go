@@ -22,6 +22,7 @@ import (
 	"gitea.dev/modules/log"
 	"gitea.dev/modules/setting"
 	"gitea.dev/modules/storage"
+	codenav_service "gitea.dev/services/codenav"
 )
 
 type saveUploadChunkOptions struct {
@@ -291,6 +292,7 @@ func mergeChunksForRun(ctx *ArtifactContext, st storage.ObjectStorage, runID, ru
 		if err := actions.UpdateArtifact(ctx, art, "storage_path", "status"); err != nil {
 			return fmt.Errorf("update artifact error: %v", err)
 		}
+		codenav_service.AddArtifactToQueue(art)
 	}
 	return nil
 }
@@ -104,6 +104,7 @@ import (
 
 	actions_model "gitea.dev/models/actions"
 	"gitea.dev/models/db"
+	quota_model "gitea.dev/models/quota"
 	actions_module "gitea.dev/modules/actions"
 	"gitea.dev/modules/httplib"
 	"gitea.dev/modules/log"
@@ -114,7 +115,9 @@ import (
 	"gitea.dev/modules/util"
 	"gitea.dev/modules/web"
 	"gitea.dev/services/actions"
+	codenav_service "gitea.dev/services/codenav"
 	"gitea.dev/services/context"
+	quota_service "gitea.dev/services/quota"
 
 	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/reflect/protoreflect"
@@ -327,10 +330,14 @@ func (r *artifactV4Routes) createArtifact(ctx *ArtifactContext) {
 	if ok := r.parseProtobufBody(ctx, &req); !ok {
 		return
 	}
-	_, _, ok := validateRunIDV4(ctx, req.WorkflowRunBackendId)
+	task, _, ok := validateRunIDV4(ctx, req.WorkflowRunBackendId)
 	if !ok {
 		return
 	}
+	// the size is not known yet, only reject the artifact if the quota is already exceeded
+	if !checkArtifactQuota(ctx, task.OwnerID, 0) {
+		return
+	}
 
 	artifactName := req.Name
 
@@ -404,6 +411,9 @@ func (r *artifactV4Routes) uploadArtifact(ctx *ArtifactContext) {
 	comp := ctx.Req.URL.Query().Get("comp")
 	switch comp {
 	case "block", "appendBlock":
+		if !checkArtifactQuota(ctx, task.OwnerID, ctx.Req.ContentLength) {
+			return
+		}
 		// get artifact by name
 		artifact, err := r.getOwnAttemptArtifactByName(ctx, task.Job.RunID, task.Job.RunAttemptID, artifactName)
 		if err != nil {
@@ -505,6 +515,7 @@ func (r *artifactV4Routes) finalizeArtifact(ctx *ArtifactContext) {
 	if ctx.Written() {
 		return
 	}
+	quota_service.UpdateUsage(ctx, ctx.ActionTask.OwnerID, quota_model.CategoryArtifacts)
 
 	respData := FinalizeArtifactResponse{
 		Ok:         true,
@@ -548,6 +559,7 @@ func (r *artifactV4Routes) finalizeDefaultArtifact(ctx *ArtifactContext, req *Fi
 		ctx.HTTPError(http.StatusInternalServerError, "Error UpdateArtifact")
 		return
 	}
+	codenav_service.AddArtifactToQueue(artifact)
 }
 
 func (r *artifactV4Routes) finalizeAzureServeDirect(ctx *ArtifactContext, req *FinalizeArtifactRequest, artifact *actions_model.ActionArtifact) {
@@ -600,6 +612,7 @@ func (r *artifactV4Routes) finalizeAzureServeDirect(ctx *ArtifactContext, req *F
 		ctx.HTTPError(http.StatusInternalServerError, "Error UpdateArtifactByID")
 		return
 	}
+	codenav_service.AddArtifactToQueue(artifact)
 }
 
 func (r *artifactV4Routes) listArtifacts(ctx *ArtifactContext) {
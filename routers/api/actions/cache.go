@@ -0,0 +1,339 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+// GitHub Actions Cache V2 API Simple Description
+//
+// The runner has to set ACTIONS_CACHE_SERVICE_V2=true and ACTIONS_RESULTS_URL to the root url of Gitea,
+// the cache is scoped to the ref of the run which saved it.
+//
+// 1. Save cache
+// 1.1. CreateCacheEntry
+// Post: /twirp/github.actions.results.api.v1.CacheService/CreateCacheEntry
+// Request:
+// {
+//     "key": "npm-linux-0f3e...",
+//     "version": "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+// }
+// Response:
+// {
+//     "ok": true,
+//     "signed_upload_url": "http://localhost:3000/twirp/github.actions.results.api.v1.CacheService/UploadCache?sig=...&expires=...&taskID=75&cacheID=3"
+// }
+// 1.2. Upload the archive to Blobstorage (unauthenticated request), either at once or as blocks followed by the block list
+// PUT: http://localhost:3000/twirp/github.actions.results.api.v1.CacheService/UploadCache?sig=...&expires=...&taskID=75&cacheID=3
+// PUT: http://localhost:3000/twirp/github.actions.results.api.v1.CacheService/UploadCache?sig=...&expires=...&taskID=75&cacheID=3&comp=block&blockid=...
+// PUT: http://localhost:3000/twirp/github.actions.results.api.v1.CacheService/UploadCache?sig=...&expires=...&taskID=75&cacheID=3&comp=blocklist
+// 1.3. FinalizeCacheEntryUpload
+// Post: /twirp/github.actions.results.api.v1.CacheService/FinalizeCacheEntryUpload
+// Request:
+// {
+//     "key": "npm-linux-0f3e...",
+//     "size_bytes": "2097",
+//     "version": "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+// }
+// Response:
+// {
+//     "ok": true,
+//     "entry_id": "3"
+// }
+// 2. Restore cache
+// 2.1. GetCacheEntryDownloadURL, the caches of the run ref, then of the pull request base branch, then of the default branch are looked up
+// Post: /twirp/github.actions.results.api.v1.CacheService/GetCacheEntryDownloadURL
+// Request:
+// {
+//     "key": "npm-linux-0f3e...",
+//     "restore_keys": ["npm-linux-", "npm-"],
+//     "version": "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+// }
+// Response:
+// {
+//     "ok": true,
+//     "signed_download_url": "http://localhost:3000/twirp/github.actions.results.api.v1.CacheService/DownloadCache?sig=...&expires=...&taskID=76&cacheID=3",
+//     "matched_key": "npm-linux-0f3e..."
+// }
+// 2.2. Download the archive from Blobstorage (unauthenticated request), range requests are supported
+// GET: http://localhost:3000/twirp/github.actions.results.api.v1.CacheService/DownloadCache?sig=...&expires=...&taskID=76&cacheID=3
+
+import (
+	"crypto/hmac"
+	"encoding/base64"
+	"encoding/xml"
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	actions_model "gitea.dev/models/actions"
+	actions_module "gitea.dev/modules/actions"
+	"gitea.dev/modules/httplib"
+	"gitea.dev/modules/json"
+	"gitea.dev/modules/log"
+	"gitea.dev/modules/setting"
+	"gitea.dev/modules/storage"
+	"gitea.dev/modules/util"
+	"gitea.dev/modules/web"
+	actions_service "gitea.dev/services/actions"
+)
+
+const CacheV2RouteBase = "/twirp/github.actions.results.api.v1.CacheService"
+
+type CreateCacheEntryRequest struct {
+	Key     string `json:"key"`
+	Version string `json:"version"`
+}
+
+type CreateCacheEntryResponse struct {
+	Ok              bool   `json:"ok"`
+	SignedUploadURL string `json:"signed_upload_url"`
+}
+
+type FinalizeCacheEntryUploadRequest struct {
+	Key       string `json:"key"`
+	SizeBytes int64  `json:"size_bytes,string"`
+	Version   string `json:"version"`
+}
+
+type FinalizeCacheEntryUploadResponse struct {
+	Ok      bool  `json:"ok"`
+	EntryID int64 `json:"entry_id,string"`
+}
+
+type GetCacheEntryDownloadURLRequest struct {
+	Key         string   `json:"key"`
+	RestoreKeys []string `json:"restore_keys"`
+	Version     string   `json:"version"`
+}
+
+type GetCacheEntryDownloadURLResponse struct {
+	Ok                bool   `json:"ok"`
+	SignedDownloadURL string `json:"signed_download_url"`
+	MatchedKey        string `json:"matched_key"`
+}
+
+type cacheRoutes struct {
+	prefix string
+}
+
+func CacheRoutes(prefix string) *web.Router {
+	m := web.NewRouter()
+
+	r := cacheRoutes{prefix: prefix}
+
+	m.Group("", func() {
+		m.Post("CreateCacheEntry", r.createCacheEntry)
+		m.Post("FinalizeCacheEntryUpload", r.finalizeCacheEntryUpload)
+		m.Post("GetCacheEntryDownloadURL", r.getCacheEntryDownloadURL)
+	}, ArtifactContexter())
+	m.Group("", func() {
+		m.Put("UploadCache", r.uploadCache)
+		m.Get("DownloadCache", r.downloadCache)
+	}, ArtifactV4Contexter())
+
+	return m
+}
+
+func (r *cacheRoutes) buildSignature(endpoint, expires string, taskID, cacheID int64) []byte {
+	return actions_module.BuildSignature("cache", endpoint, expires, strconv.FormatInt(taskID, 10), strconv.FormatInt(cacheID, 10))
+}
+
+func (r *cacheRoutes) buildCacheURL(ctx *ArtifactContext, endpoint string, taskID, cacheID int64) string {
+	expires := time.Now().Add(60 * time.Minute).Format("2006-01-02 15:04:05.999999999 -0700 MST")
+	return strings.TrimSuffix(httplib.GuessCurrentAppURL(ctx), "/") + strings.TrimSuffix(r.prefix, "/") +
+		"/" + endpoint +
+		"?sig=" + base64.RawURLEncoding.EncodeToString(r.buildSignature(endpoint, expires, taskID, cacheID)) +
+		"&expires=" + url.QueryEscape(expires) +
+		"&taskID=" + strconv.FormatInt(taskID, 10) +
+		"&cacheID=" + strconv.FormatInt(cacheID, 10)
+}
+
+// verifySignature checks the signed url of an upload or download and returns the cache it was issued for
+func (r *cacheRoutes) verifySignature(ctx *ArtifactContext, endpoint string, status actions_model.CacheStatus) (*actions_model.ActionCache, bool) {
+	query := ctx.Req.URL.Query()
+	expires := query.Get("expires")
+	dsig, errSig := base64.RawURLEncoding.DecodeString(query.Get("sig"))
+	taskID, errTask := strconv.ParseInt(query.Get("taskID"), 10, 64)
+	cacheID, errCache := strconv.ParseInt(query.Get("cacheID"), 10, 64)
+	if err := errors.Join(errSig, errTask, errCache); err != nil {
+		log.Error("Error decoding signature values: %v", err)
+		ctx.HTTPError(http.StatusBadRequest, "Error decoding signature values")
+		return nil, false
+	}
+	if !hmac.Equal(dsig, r.buildSignature(endpoint, expires, taskID, cacheID)) {
+		ctx.HTTPError(http.StatusUnauthorized, "Error unauthorized")
+		return nil, false
+	}
+	t, err := time.Parse("2006-01-02 15:04:05.999999999 -0700 MST", expires)
+	if err != nil || t.Before(time.Now()) {
+		ctx.HTTPError(http.StatusUnauthorized, "Error link expired")
+		return nil, false
+	}
+	task, err := actions_model.GetTaskByID(ctx, taskID)
+	if err != nil {
+		log.Error("Error runner api getting task by ID: %v", err)
+		ctx.HTTPError(http.StatusInternalServerError, "Error runner api getting task by ID")
+		return nil, false
+	}
+	if task.Status != actions_model.StatusRunning {
+		ctx.HTTPError(http.StatusUnauthorized, "Error runner api getting task: task is not running")
+		return nil, false
+	}
+	cache, err := actions_model.GetCacheByRepoAndID(ctx, task.RepoID, cacheID)
+	if err != nil || cache.Status != status {
+		ctx.HTTPError(http.StatusNotFound, "Error cache not found")
+		return nil, false
+	}
+	return cache, true
+}
+
+func (r *cacheRoutes) parseJSONBody(ctx *ArtifactContext, req any) bool {
+	if err := json.NewDecoder(ctx.Req.Body).Decode(req); err != nil {
+		log.Error("Error decode request body: %v", err)
+		ctx.HTTPError(http.StatusBadRequest, "Error decode request body")
+		return false
+	}
+	return true
+}
+
+func (r *cacheRoutes) sendJSONBody(ctx *ArtifactContext, resp any) {
+	ctx.JSON(http.StatusOK, resp)
+}
+
+func (r *cacheRoutes) createCacheEntry(ctx *ArtifactContext) {
+	var req CreateCacheEntryRequest
+	if !r.parseJSONBody(ctx, &req) {
+		return
+	}
+
+	cache, err := actions_service.ReserveCache(ctx, ctx.ActionTask, req.Key, req.Version)
+	if err != nil {
+		if errors.Is(err, util.ErrAlreadyExist) || errors.Is(err, util.ErrInvalidArgument) {
+			// another job is saving the same cache or it has already been saved, actions/cache skips saving it
+			log.Debug("Skip creating cache %q: %v", req.Key, err)
+			r.sendJSONBody(ctx, &CreateCacheEntryResponse{Ok: false})
+			return
+		}
+		log.Error("Error reserving cache: %v", err)
+		ctx.HTTPError(http.StatusInternalServerError, "Error reserving cache")
+		return
+	}
+
+	r.sendJSONBody(ctx, &CreateCacheEntryResponse{
+		Ok:              true,
+		SignedUploadURL: r.buildCacheURL(ctx, "UploadCache", ctx.ActionTask.ID, cache.ID),
+	})
+}
+
+func (r *cacheRoutes) uploadCache(ctx *ArtifactContext) {
+	cache, ok := r.verifySignature(ctx, "UploadCache", actions_model.CacheStatusReserved)
+	if !ok {
+		return
+	}
+
+	var err error
+	switch ctx.Req.URL.Query().Get("comp") {
+	case "":
+		err = actions_service.UploadCacheContent(cache, ctx.Req.Body, ctx.Req.ContentLength)
+	case "block":
+		err = actions_service.UploadCacheBlock(ctx, cache, ctx.Req.URL.Query().Get("blockid"), ctx.Req.Body, ctx.Req.ContentLength)
+	case "blocklist":
+		var blockList BlockList
+		if err = xml.NewDecoder(ctx.Req.Body).Decode(&blockList); err == nil {
+			err = actions_service.CommitCacheBlocks(cache, blockList.Latest)
+		}
+	default:
+		ctx.HTTPError(http.StatusBadRequest, "Error unknown upload operation")
+		return
+	}
+	if err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			ctx.HTTPError(http.StatusBadRequest, err.Error())
+			return
+		}
+		if errors.Is(err, util.ErrContentTooLarge) {
+			ctx.HTTPError(http.StatusRequestEntityTooLarge, err.Error())
+			return
+		}
+		log.Error("Error uploading cache %d: %v", cache.ID, err)
+		ctx.HTTPError(http.StatusInternalServerError, "Error uploading cache")
+		return
+	}
+	ctx.Status(http.StatusCreated)
+}
+
+func (r *cacheRoutes) finalizeCacheEntryUpload(ctx *ArtifactContext) {
+	var req FinalizeCacheEntryUploadRequest
+	if !r.parseJSONBody(ctx, &req) {
+		return
+	}
+
+	cache, err := actions_service.FinalizeCache(ctx, ctx.ActionTask, req.Key, req.Version, req.SizeBytes)
+	if err != nil {
+		if errors.Is(err, util.ErrNotExist) || errors.Is(err, util.ErrInvalidArgument) {
+			log.Debug("Cannot finalize cache %q: %v", req.Key, err)
+			r.sendJSONBody(ctx, &FinalizeCacheEntryUploadResponse{Ok: false})
+			return
+		}
+		log.Error("Error finalizing cache: %v", err)
+		ctx.HTTPError(http.StatusInternalServerError, "Error finalizing cache")
+		return
+	}
+
+	r.sendJSONBody(ctx, &FinalizeCacheEntryUploadResponse{Ok: true, EntryID: cache.ID})
+}
+
+func (r *cacheRoutes) getCacheEntryDownloadURL(ctx *ArtifactContext) {
+	var req GetCacheEntryDownloadURLRequest
+	if !r.parseJSONBody(ctx, &req) {
+		return
+	}
+
+	cache, err := actions_service.RestoreCache(ctx, ctx.ActionTask, req.Key, req.RestoreKeys, req.Version)
+	if err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			r.sendJSONBody(ctx, &GetCacheEntryDownloadURLResponse{Ok: false})
+			return
+		}
+		log.Error("Error finding cache: %v", err)
+		ctx.HTTPError(http.StatusInternalServerError, "Error finding cache")
+		return
+	}
+
+	downloadURL := r.buildCacheURL(ctx, "DownloadCache", ctx.ActionTask.ID, cache.ID)
+	if setting.Actions.CacheStorage.ServeDirect() {
+		u, err := storage.ActionsCaches.ServeDirectURL(cache.StoragePath, "cache.tzst", http.MethodGet, nil)
+		if err != nil {
+			log.Warn("Error getting serve direct url of cache %d: %v", cache.ID, err)
+		} else {
+			downloadURL = u.String()
+		}
+	}
+
+	r.sendJSONBody(ctx, &GetCacheEntryDownloadURLResponse{
+		Ok:                true,
+		SignedDownloadURL: downloadURL,
+		MatchedKey:        cache.Key,
+	})
+}
+
+func (r *cacheRoutes) downloadCache(ctx *ArtifactContext) {
+	cache, ok := r.verifySignature(ctx, "DownloadCache", actions_model.CacheStatusComplete)
+	if !ok {
+		return
+	}
+
+	f, err := storage.ActionsCaches.Open(cache.StoragePath)
+	if err != nil {
+		log.Error("Error opening cache %d: %v", cache.ID, err)
+		ctx.HTTPError(http.StatusInternalServerError, "Error opening cache")
+		return
+	}
+	defer f.Close()
+	httplib.ServeUserContentByFile(ctx.Req, ctx.Resp, f, httplib.ServeHeaderOptions{
+		Filename:    "cache.tzst",
+		ContentType: "application/octet-stream",
+	})
+}
@@ -0,0 +1,549 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package ansible
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gitea.dev/models/db"
+	packages_model "gitea.dev/models/packages"
+	"gitea.dev/modules/json"
+	"gitea.dev/modules/optional"
+	packages_module "gitea.dev/modules/packages"
+	ansible_module "gitea.dev/modules/packages/ansible"
+	"gitea.dev/modules/setting"
+	"gitea.dev/modules/util"
+	"gitea.dev/routers/api/packages/helper"
+	"gitea.dev/services/context"
+	packages_service "gitea.dev/services/packages"
+)
+
+const (
+	defaultPageSize = 100
+	maxPageSize     = 1000
+)
+
+func jsonResponse(ctx *context.Context, status int, obj any) {
+	resp := ctx.Resp
+	resp.Header().Set("Content-Type", "application/json")
+	resp.WriteHeader(status)
+	_ = json.NewEncoder(resp).Encode(obj)
+}
+
+// https://github.com/ansible/ansible/blob/devel/lib/ansible/galaxy/api.py GalaxyError
+func apiError(ctx *context.Context, status int, obj any) {
+	type Error struct {
+		Status string `json:"status"`
+		Code   string `json:"code"`
+		Title  string `json:"title"`
+		Detail string `json:"detail"`
+	}
+	type ErrorWrapper struct {
+		Errors []Error `json:"errors"`
+	}
+
+	message := helper.ProcessErrorForUser(ctx, status, obj)
+	jsonResponse(ctx, status, ErrorWrapper{
+		Errors: []Error{
+			{
+				Status: strconv.Itoa(status),
+				Code:   strings.ToLower(strings.ReplaceAll(http.StatusText(status), " ", "_")),
+				Title:  http.StatusText(status),
+				Detail: message,
+			},
+		},
+	})
+}
+
+func baseURL(ctx *context.Context) string {
+	return setting.AppURL + "api/packages/" + url.PathEscape(ctx.Package.Owner.Name) + "/ansible"
+}
+
+// basePath is used for the pagination links which must be relative to the server
+func basePath(ctx *context.Context) string {
+	return setting.AppSubURL + "/api/packages/" + url.PathEscape(ctx.Package.Owner.Name) + "/ansible"
+}
+
+func collectionURL(ctx *context.Context, namespace, name string) string {
+	return fmt.Sprintf("%s/v3/collections/%s/%s/", baseURL(ctx), url.PathEscape(namespace), url.PathEscape(name))
+}
+
+func formatTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339Nano)
+}
+
+type links struct {
+	First    *string `json:"first"`
+	Previous *string `json:"previous"`
+	Next     *string `json:"next"`
+	Last     *string `json:"last"`
+}
+
+type paginatedResponse struct {
+	Meta struct {
+		Count int64 `json:"count"`
+	} `json:"meta"`
+	Links links `json:"links"`
+	Data  any   `json:"data"`
+}
+
+// getPagination reads the limit and offset parameters used by the Galaxy v3 API
+func getPagination(ctx *context.Context) (limit, offset int) {
+	limit = ctx.FormInt("limit")
+	if limit <= 0 {
+		limit = defaultPageSize
+	}
+	limit = min(limit, maxPageSize)
+	offset = max(ctx.FormInt("offset"), 0)
+	return limit, offset
+}
+
+func newPaginatedResponse(path string, limit, offset int, total int64, data any) *paginatedResponse {
+	link := func(offset int) *string {
+		s := fmt.Sprintf("%s?limit=%d&offset=%d", path, limit, offset)
+		return &s
+	}
+
+	resp := &paginatedResponse{Data: data}
+	resp.Meta.Count = total
+	resp.Links.First = link(0)
+	resp.Links.Last = link(max(int(total)-1, 0) / limit * limit)
+	if offset > 0 {
+		resp.Links.Previous = link(max(offset-limit, 0))
+	}
+	if int64(offset+limit) < total {
+		resp.Links.Next = link(offset + limit)
+	}
+	return resp
+}
+
+func splitName(pd *packages_model.PackageDescriptor) (string, string) {
+	metadata := packages_model.DescriptorMetadata[*ansible_module.Metadata](pd)
+	return metadata.Namespace, metadata.Name
+}
+
+// ServiceIndex lists the available API versions
+// https://github.com/ansible/ansible/blob/devel/lib/ansible/galaxy/api.py available_api_versions
+func ServiceIndex(ctx *context.Context) {
+	jsonResponse(ctx, http.StatusOK, map[string]any{
+		"description": "Gitea Galaxy API",
+		"available_versions": map[string]string{
+			"v3": "v3/",
+		},
+	})
+}
+
+type collectionVersionSummary struct {
+	HREF      string `json:"href"`
+	Version   string `json:"version"`
+	CreatedAt string `json:"created_at,omitempty"`
+}
+
+type collectionResponse struct {
+	HREF           string                    `json:"href"`
+	Namespace      string                    `json:"namespace"`
+	Name           string                    `json:"name"`
+	Deprecated     bool                      `json:"deprecated"`
+	VersionsURL    string                    `json:"versions_url"`
+	HighestVersion *collectionVersionSummary `json:"highest_version"`
+	CreatedAt      string                    `json:"created_at"`
+	UpdatedAt      string                    `json:"updated_at"`
+	DownloadCount  int64                     `json:"download_count"`
+}
+
+// ListCollections lists the latest version of all collections
+func ListCollections(ctx *context.Context) {
+	limit, offset := getPagination(ctx)
+
+	pvs, total, err := packages_model.SearchLatestVersions(ctx, &packages_model.PackageSearchOptions{
+		OwnerID:    ctx.Package.Owner.ID,
+		Type:       packages_model.TypeAnsible,
+		IsInternal: optional.Some(false),
+		Paginator:  db.NewAbsoluteListOptions(offset, limit),
+	})
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	pds, err := packages_model.GetPackageDescriptors(ctx, pvs)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	collections := make([]*collectionResponse, 0, len(pds))
+	for _, pd := range pds {
+		namespace, name := splitName(pd)
+		u := collectionURL(ctx, namespace, name)
+		collections = append(collections, &collectionResponse{
+			HREF:        u,
+			Namespace:   namespace,
+			Name:        name,
+			VersionsURL: u + "versions/",
+			HighestVersion: &collectionVersionSummary{
+				HREF:    fmt.Sprintf("%sversions/%s/", u, url.PathEscape(pd.Version.Version)),
+				Version: pd.Version.Version,
+			},
+			CreatedAt: formatTime(pd.Version.CreatedUnix.AsTime()),
+			UpdatedAt: formatTime(pd.Version.CreatedUnix.AsTime()),
+		})
+	}
+
+	jsonResponse(ctx, http.StatusOK, newPaginatedResponse(basePath(ctx)+"/v3/collections/", limit, offset, total, collections))
+}
+
+// getCollectionDescriptors returns the descriptors of all versions of the collection sorted descending
+func getCollectionDescriptors(ctx *context.Context) []*packages_model.PackageDescriptor {
+	packageName := ctx.PathParam("namespace") + "." + ctx.PathParam("name")
+
+	pvs, err := packages_model.GetVersionsByPackageName(ctx, ctx.Package.Owner.ID, packages_model.TypeAnsible, packageName)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return nil
+	}
+	if len(pvs) == 0 {
+		apiError(ctx, http.StatusNotFound, packages_model.ErrPackageNotExist)
+		return nil
+	}
+
+	pds, err := packages_model.GetPackageDescriptors(ctx, pvs)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return nil
+	}
+
+	sort.Slice(pds, func(i, j int) bool {
+		return pds[i].SemVer.GreaterThan(pds[j].SemVer)
+	})
+
+	return pds
+}
+
+// CollectionMetadata returns the information about a collection
+func CollectionMetadata(ctx *context.Context) {
+	pds := getCollectionDescriptors(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	namespace, name := splitName(pds[0])
+	u := collectionURL(ctx, namespace, name)
+
+	created, updated := pds[0].Version.CreatedUnix, pds[0].Version.CreatedUnix
+	var downloads int64
+	for _, pd := range pds {
+		created = min(created, pd.Version.CreatedUnix)
+		updated = max(updated, pd.Version.CreatedUnix)
+		downloads += pd.Version.DownloadCount
+	}
+
+	jsonResponse(ctx, http.StatusOK, &collectionResponse{
+		HREF:        u,
+		Namespace:   namespace,
+		Name:        name,
+		VersionsURL: u + "versions/",
+		HighestVersion: &collectionVersionSummary{
+			HREF:    fmt.Sprintf("%sversions/%s/", u, url.PathEscape(pds[0].Version.Version)),
+			Version: pds[0].Version.Version,
+		},
+		CreatedAt:     formatTime(created.AsTime()),
+		UpdatedAt:     formatTime(updated.AsTime()),
+		DownloadCount: downloads,
+	})
+}
+
+// ListCollectionVersions lists the versions of a collection
+func ListCollectionVersions(ctx *context.Context) {
+	pds := getCollectionDescriptors(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	limit, offset := getPagination(ctx)
+
+	namespace, name := splitName(pds[0])
+	u := collectionURL(ctx, namespace, name)
+
+	versions := make([]*collectionVersionSummary, 0, limit)
+	for _, pd := range pds[min(offset, len(pds)):min(offset+limit, len(pds))] {
+		versions = append(versions, &collectionVersionSummary{
+			HREF:      fmt.Sprintf("%sversions/%s/", u, url.PathEscape(pd.Version.Version)),
+			Version:   pd.Version.Version,
+			CreatedAt: formatTime(pd.Version.CreatedUnix.AsTime()),
+		})
+	}
+
+	path := fmt.Sprintf("%s/v3/collections/%s/%s/versions/", basePath(ctx), url.PathEscape(namespace), url.PathEscape(name))
+	jsonResponse(ctx, http.StatusOK, newPaginatedResponse(path, limit, offset, int64(len(pds)), versions))
+}
+
+// CollectionVersionMetadata returns the information needed to install a collection version
+func CollectionVersionMetadata(ctx *context.Context) {
+	packageName := ctx.PathParam("namespace") + "." + ctx.PathParam("name")
+
+	pv, err := packages_model.GetVersionByNameAndVersion(ctx, ctx.Package.Owner.ID, packages_model.TypeAnsible, packageName, ctx.PathParam("version"))
+	if err != nil {
+		if errors.Is(err, packages_model.ErrPackageNotExist) {
+			apiError(ctx, http.StatusNotFound, err)
+			return
+		}
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	pd, err := packages_model.GetPackageDescriptor(ctx, pv)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	metadata := packages_model.DescriptorMetadata[*ansible_module.Metadata](pd)
+	pfd := pd.Files[0]
+	u := collectionURL(ctx, metadata.Namespace, metadata.Name)
+
+	dependencies := metadata.Dependencies
+	if dependencies == nil {
+		dependencies = map[string]string{}
+	}
+
+	type namedObject struct {
+		Name string `json:"name"`
+	}
+
+	jsonResponse(ctx, http.StatusOK, map[string]any{
+		"href":         fmt.Sprintf("%sversions/%s/", u, url.PathEscape(pd.Version.Version)),
+		"namespace":    namedObject{metadata.Namespace},
+		"collection":   namedObject{metadata.Name},
+		"name":         metadata.Name,
+		"version":      pd.Version.Version,
+		"download_url": fmt.Sprintf("%s/download/%s", baseURL(ctx), url.PathEscape(pfd.File.Name)),
+		"artifact": map[string]any{
+			"filename": pfd.File.Name,
+			"sha256":   pfd.Blob.HashSHA256,
+			"size":     pfd.Blob.Size,
+		},
+		"metadata": map[string]any{
+			"authors":       metadata.Authors,
+			"description":   metadata.Description,
+			"license":       metadata.License,
+			"tags":          metadata.Tags,
+			"dependencies":  dependencies,
+			"repository":    metadata.RepositoryURL,
+			"documentation": metadata.DocumentationURL,
+			"homepage":      metadata.HomepageURL,
+			"issues":        metadata.IssuesURL,
+		},
+		"created_at": formatTime(pd.Version.CreatedUnix.AsTime()),
+		"updated_at": formatTime(pd.Version.CreatedUnix.AsTime()),
+		"signatures": []any{},
+	})
+}
+
+// DownloadPackageFile serves the collection artifact
+func DownloadPackageFile(ctx *context.Context) {
+	filename := ctx.PathParam("filename")
+
+	// namespace and name can't contain "-" but the version can
+	parts := strings.SplitN(strings.TrimSuffix(filename, ".tar.gz"), "-", 3)
+	if len(parts) != 3 || !strings.HasSuffix(filename, ".tar.gz") {
+		apiError(ctx, http.StatusNotFound, nil)
+		return
+	}
+
+	s, u, pf, err := packages_service.OpenFileForDownloadByPackageNameAndVersion(
+		ctx,
+		&packages_service.PackageInfo{
+			Owner:       ctx.Package.Owner,
+			PackageType: packages_model.TypeAnsible,
+			Name:        parts[0] + "." + parts[1],
+			Version:     parts[2],
+		},
+		&packages_service.PackageFileInfo{
+			Filename: filename,
+		},
+		ctx.Req.Method,
+	)
+	if err != nil {
+		if errors.Is(err, packages_model.ErrPackageNotExist) || errors.Is(err, packages_model.ErrPackageFileNotExist) {
+			apiError(ctx, http.StatusNotFound, err)
+			return
+		}
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	helper.ServePackageFile(ctx, s, u, pf)
+}
+
+// readUploadedFile reads the multipart body sent by "ansible-galaxy collection publish".
+// The client uses "Content-Disposition: file" for the artifact which is not supported by http.Request.FormFile.
+func readUploadedFile(ctx *context.Context) (*packages_module.HashedBuffer, string, error) {
+	mr, err := ctx.Req.MultipartReader()
+	if err != nil {
+		return nil, "", util.NewInvalidArgumentErrorf("invalid multipart body: %v", err)
+	}
+
+	var buf *packages_module.HashedBuffer
+	var checksum string
+	for {
+		part, err := mr.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			if buf != nil {
+				buf.Close()
+			}
+			return nil, "", util.NewInvalidArgumentErrorf("invalid multipart body: %v", err)
+		}
+
+		_, params, _ := mime.ParseMediaType(part.Header.Get("Content-Disposition"))
+		switch params["name"] {
+		case "file":
+			if buf != nil {
+				buf.Close()
+			}
+			if buf, err = packages_module.CreateHashedBufferFromReader(part); err != nil {
+				return nil, "", err
+			}
+		case "sha256":
+			data, err := util.ReadWithLimit(part, 128)
+			if err != nil {
+				if buf != nil {
+					buf.Close()
+				}
+				return nil, "", err
+			}
+			checksum = strings.TrimSpace(string(data))
+		}
+	}
+
+	if buf == nil {
+		return nil, "", util.NewInvalidArgumentErrorf("file is missing")
+	}
+	return buf, checksum, nil
+}
+
+// UploadPackage publishes a collection artifact.
+// The import is done synchronously, the returned task is always completed.
+func UploadPackage(ctx *context.Context) {
+	buf, checksum, err := readUploadedFile(ctx)
+	if err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			apiError(ctx, http.StatusBadRequest, err)
+		} else {
+			apiError(ctx, http.StatusInternalServerError, err)
+		}
+		return
+	}
+	defer buf.Close()
+
+	if checksum != "" {
+		_, _, hashSHA256, _ := buf.Sums()
+		if !strings.EqualFold(checksum, hex.EncodeToString(hashSHA256)) {
+			apiError(ctx, http.StatusBadRequest, "the sha256 checksum of the file does not match")
+			return
+		}
+	}
+
+	pck, err := ansible_module.ParsePackage(buf)
+	if err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			apiError(ctx, http.StatusBadRequest, err)
+		} else {
+			apiError(ctx, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	if _, err := buf.Seek(0, io.SeekStart); err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	pv, _, err := packages_service.CreatePackageAndAddFile(
+		ctx,
+		&packages_service.PackageCreationInfo{
+			PackageInfo: packages_service.PackageInfo{
+				Owner:       ctx.Package.Owner,
+				PackageType: packages_model.TypeAnsible,
+				Name:        pck.FullName(),
+				Version:     pck.Version,
+			},
+			SemverCompatible: true,
+			Creator:          ctx.Doer,
+			Metadata:         pck.Metadata,
+		},
+		&packages_service.PackageFileCreationInfo{
+			PackageFileInfo: packages_service.PackageFileInfo{
+				Filename: pck.Filename(),
+			},
+			Creator: ctx.Doer,
+			Data:    buf,
+			IsLead:  true,
+		},
+	)
+	if err != nil {
+		switch err {
+		case packages_model.ErrDuplicatePackageVersion:
+			apiError(ctx, http.StatusConflict, err)
+		case packages_service.ErrQuotaTotalCount, packages_service.ErrQuotaTypeSize, packages_service.ErrQuotaTotalSize:
+			apiError(ctx, http.StatusForbidden, err)
+		default:
+			apiError(ctx, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	jsonResponse(ctx, http.StatusAccepted, map[string]string{
+		"task": fmt.Sprintf("%s/v3/imports/collections/%d/", baseURL(ctx), pv.ID),
+	})
+}
+
+// ImportTask returns the state of an import task
+// The task id is the id of the created package version.
+func ImportTask(ctx *context.Context) {
+	pv, err := packages_model.GetVersionByID(ctx, ctx.PathParamInt64("id"))
+	if err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			apiError(ctx, http.StatusNotFound, err)
+			return
+		}
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	p, err := packages_model.GetPackageByID(ctx, pv.PackageID)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+	if p.OwnerID != ctx.Package.Owner.ID || p.Type != packages_model.TypeAnsible {
+		apiError(ctx, http.StatusNotFound, nil)
+		return
+	}
+
+	created := formatTime(pv.CreatedUnix.AsTime())
+
+	jsonResponse(ctx, http.StatusOK, map[string]any{
+		"id":          strconv.FormatInt(pv.ID, 10),
+		"state":       "completed",
+		"created_at":  created,
+		"started_at":  created,
+		"finished_at": created,
+		"error":       nil,
+		"messages":    []any{},
+	})
+}
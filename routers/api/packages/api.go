@@ -4,14 +4,17 @@
 package packages
 
 import (
+	"errors"
 	"net/http"
 
 	auth_model "gitea.dev/models/auth"
+	packages_model "gitea.dev/models/packages"
 	"gitea.dev/models/perm"
 	"gitea.dev/modules/log"
 	"gitea.dev/modules/setting"
 	"gitea.dev/modules/web"
 	"gitea.dev/routers/api/packages/alpine"
+	"gitea.dev/routers/api/packages/ansible"
 	"gitea.dev/routers/api/packages/arch"
 	"gitea.dev/routers/api/packages/cargo"
 	"gitea.dev/routers/api/packages/chef"
@@ -24,7 +27,9 @@ import (
 	"gitea.dev/routers/api/packages/generic"
 	"gitea.dev/routers/api/packages/goproxy"
 	"gitea.dev/routers/api/packages/helm"
+	"gitea.dev/routers/api/packages/hex"
 	"gitea.dev/routers/api/packages/maven"
+	"gitea.dev/routers/api/packages/nix"
 	"gitea.dev/routers/api/packages/npm"
 	"gitea.dev/routers/api/packages/nuget"
 	"gitea.dev/routers/api/packages/pub"
@@ -91,6 +96,31 @@ func reqPackageAccess(accessMode perm.AccessMode) func(ctx *context.Context) {
 	}
 }
 
+// packageRemoteAssignment loads the remote configured for the package type of the owner
+func packageRemoteAssignment(packageType packages_model.Type) func(ctx *context.Context) {
+	return func(ctx *context.Context) {
+		pr, err := packages_model.GetRemoteByOwnerAndType(ctx, ctx.Package.Owner.ID, packageType)
+		if err != nil {
+			if errors.Is(err, packages_model.ErrPackageRemoteNotExist) {
+				return
+			}
+			ctx.HTTPError(http.StatusInternalServerError, "GetRemoteByOwnerAndType", err.Error())
+			return
+		}
+		ctx.Package.Remote = pr
+	}
+}
+
+// reqPackageUploadAllowed rejects uploads to package types which only mirror an upstream registry
+func reqPackageUploadAllowed() func(ctx *context.Context) {
+	return func(ctx *context.Context) {
+		if ctx.Package.Remote != nil && !ctx.Package.Remote.AllowsUpload() {
+			ctx.HTTPError(http.StatusMethodNotAllowed, "reqPackageUploadAllowed", "packages of this type are mirrored from an upstream registry")
+			return
+		}
+	}
+}
+
 type verifyAuthOptions struct {
 	afterAuthCallback func(ctx *context.Context, err error)
 }
@@ -125,6 +155,7 @@ func CommonRoutes() *web.Router {
 		&auth.OAuth2{},
 		&auth.Basic{},
 		&nuget.Auth{},
+		&hex.Auth{},
 		&Auth{},
 		&chef.Auth{},
 	}, verifyAuthOptions{})
@@ -143,6 +174,22 @@ func CommonRoutes() *web.Router {
 				})
 			})
 		}, reqPackageAccess(perm.AccessModeRead))
+		r.Group("/ansible", func() {
+			r.Get("", ansible.ServiceIndex)
+			r.Get("/download/{filename}", ansible.DownloadPackageFile)
+			r.Group("/v3", func() {
+				r.Post("/artifacts/collections", reqPackageAccess(perm.AccessModeWrite), ansible.UploadPackage)
+				r.Get("/imports/collections/{id}", ansible.ImportTask)
+				r.Group("/collections", func() {
+					r.Get("", ansible.ListCollections)
+					r.Group("/{namespace}/{name}", func() {
+						r.Get("", ansible.CollectionMetadata)
+						r.Get("/versions", ansible.ListCollectionVersions)
+						r.Get("/versions/{version}", ansible.CollectionVersionMetadata)
+					})
+				})
+			})
+		}, reqPackageAccess(perm.AccessModeRead))
 		r.Group("/arch", func() {
 			r.Methods("HEAD,GET", "/repository.key", arch.GetRepositoryKey)
 			r.Methods("PUT", "" /* no repository */, reqPackageAccess(perm.AccessModeWrite), arch.UploadPackageFile)
@@ -361,11 +408,26 @@ func CommonRoutes() *web.Router {
 			r.Post("/api/charts", reqPackageAccess(perm.AccessModeWrite), helm.UploadPackage)
 			r.Post("/api/prov", reqPackageAccess(perm.AccessModeWrite), helm.UploadProvenanceFile)
 		}, reqPackageAccess(perm.AccessModeRead))
+		r.Group("/hex", func() {
+			r.Get("/names", hex.EnumeratePackageNames)
+			r.Get("/versions", hex.EnumeratePackageVersions)
+			r.Get("/packages/{name}", hex.PackageReleases)
+			r.Get("/tarballs/{filename}", hex.DownloadPackageFile)
+			r.Get("/public_key", hex.GetPublicKey)
+			r.Group("/api", func() {
+				r.Post("/publish", hex.UploadPackage)
+				r.Group("/packages/{name}/releases/{version}", func() {
+					r.Delete("", hex.RevertRelease)
+					r.Post("/retire", hex.RetireRelease)
+					r.Delete("/retire", hex.UnretireRelease)
+				})
+			}, reqPackageAccess(perm.AccessModeWrite))
+		}, reqPackageAccess(perm.AccessModeRead))
 		r.Group("/maven", func() {
-			r.Put("/*", reqPackageAccess(perm.AccessModeWrite), maven.UploadPackageFile)
+			r.Put("/*", reqPackageAccess(perm.AccessModeWrite), reqPackageUploadAllowed(), maven.UploadPackageFile)
 			r.Get("/*", maven.DownloadPackageFile)
 			r.Head("/*", maven.ProvidePackageFileHeader)
-		}, reqPackageAccess(perm.AccessModeRead))
+		}, reqPackageAccess(perm.AccessModeRead), packageRemoteAssignment(packages_model.TypeMaven))
 		r.Group("/nuget", func() {
 			r.Group("", func() { // Needs to be unauthenticated for the NuGet client.
 				r.Get("/", nuget.ServiceIndexV2)
@@ -403,10 +465,22 @@ func CommonRoutes() *web.Router {
 				})
 			}, reqPackageAccess(perm.AccessModeRead))
 		})
+		r.Group("/nix", func() {
+			r.Get("/nix-cache-info", nix.CacheInfo)
+			r.Get("/key", nix.GetPublicKey)
+			r.Group("/nar/{filename}", func() {
+				r.Methods("HEAD,GET", "", nix.DownloadNarFile)
+				r.Put("", reqPackageAccess(perm.AccessModeWrite), nix.UploadNarFile)
+			})
+			r.Group("/{filename}", func() {
+				r.Methods("HEAD,GET", "", nix.GetNarInfo)
+				r.Put("", reqPackageAccess(perm.AccessModeWrite), nix.UploadNarInfo)
+			})
+		}, reqPackageAccess(perm.AccessModeRead))
 		r.Group("/npm", func() {
 			r.Group("/@{scope}/{id}", func() {
 				r.Get("", npm.PackageMetadata)
-				r.Put("", reqPackageAccess(perm.AccessModeWrite), npm.UploadPackage)
+				r.Put("", reqPackageAccess(perm.AccessModeWrite), reqPackageUploadAllowed(), npm.UploadPackage)
 				r.Group("/-/{version}/{filename}", func() {
 					r.Get("", npm.DownloadPackageFile)
 					r.Delete("/-rev/{revision}", reqPackageAccess(perm.AccessModeWrite), npm.DeletePackageVersion)
@@ -419,7 +493,7 @@ func CommonRoutes() *web.Router {
 			})
 			r.Group("/{id}", func() {
 				r.Get("", npm.PackageMetadata)
-				r.Put("", reqPackageAccess(perm.AccessModeWrite), npm.UploadPackage)
+				r.Put("", reqPackageAccess(perm.AccessModeWrite), reqPackageUploadAllowed(), npm.UploadPackage)
 				r.Group("/-/{version}/{filename}", func() {
 					r.Get("", npm.DownloadPackageFile)
 					r.Delete("/-rev/{revision}", reqPackageAccess(perm.AccessModeWrite), npm.DeletePackageVersion)
@@ -447,7 +521,7 @@ func CommonRoutes() *web.Router {
 			r.Group("/-/v1/search", func() {
 				r.Get("", npm.PackageSearch)
 			})
-		}, reqPackageAccess(perm.AccessModeRead))
+		}, reqPackageAccess(perm.AccessModeRead), packageRemoteAssignment(packages_model.TypeNpm))
 		r.Group("/pub", func() {
 			r.Group("/api/packages", func() {
 				r.Group("/versions/new", func() {
@@ -464,10 +538,10 @@ func CommonRoutes() *web.Router {
 		}, reqPackageAccess(perm.AccessModeRead))
 
 		r.Group("/pypi", func() {
-			r.Post("/", reqPackageAccess(perm.AccessModeWrite), pypi.UploadPackageFile)
+			r.Post("/", reqPackageAccess(perm.AccessModeWrite), reqPackageUploadAllowed(), pypi.UploadPackageFile)
 			r.Get("/files/{id}/{version}/{filename}", pypi.DownloadPackageFile)
 			r.Get("/simple/{id}", pypi.PackageMetadata)
-		}, reqPackageAccess(perm.AccessModeRead))
+		}, reqPackageAccess(perm.AccessModeRead), packageRemoteAssignment(packages_model.TypePyPI))
 
 		r.Methods("HEAD,GET", "/rpm.repo", reqPackageAccess(perm.AccessModeRead), rpm.GetRepositoryConfig)
 		r.PathGroup("/rpm/*", func(g *web.RouterPathGroup) {
@@ -573,8 +647,6 @@ func ContainerRoutes() *web.Router {
 		},
 	})
 
-	// TODO: Content Discovery / References (not implemented yet)
-
 	r.Get("", container.ReqContainerAccess, container.DetermineSupport)
 	r.Group("/token", func() {
 		r.Get("", container.Authenticate)
@@ -583,8 +655,9 @@ func ContainerRoutes() *web.Router {
 	r.Get("/_catalog", container.ReqContainerAccess, container.GetRepositoryList)
 	r.Group("/{username}", func() {
 		r.PathGroup("/*", func(g *web.RouterPathGroup) {
-			g.MatchPath("POST", "/<image:*>/blobs/uploads", reqPackageAccess(perm.AccessModeWrite), container.VerifyImageName, container.PostBlobsUploads)
+			g.MatchPath("POST", "/<image:*>/blobs/uploads", reqPackageAccess(perm.AccessModeWrite), reqPackageUploadAllowed(), container.VerifyImageName, container.PostBlobsUploads)
 			g.MatchPath("GET", "/<image:*>/tags/list", container.VerifyImageName, container.GetTagsList)
+			g.MatchPath("GET", "/<image:*>/referrers/<digest>", container.VerifyImageName, container.GetReferrers)
 
 			patternBlobsUploadsUUID := g.PatternRegexp(`/<image:*>/blobs/uploads/<uuid:[-.=\w]+>`, reqPackageAccess(perm.AccessModeWrite), container.VerifyImageName)
 			g.MatchPattern("GET", patternBlobsUploadsUUID, container.GetBlobsUpload)
@@ -598,10 +671,10 @@ func ContainerRoutes() *web.Router {
 
 			g.MatchPath("HEAD", `/<image:*>/manifests/<reference>`, container.VerifyImageName, container.HeadManifest)
 			g.MatchPath("GET", `/<image:*>/manifests/<reference>`, container.VerifyImageName, container.GetManifest)
-			g.MatchPath("PUT", `/<image:*>/manifests/<reference>`, container.VerifyImageName, reqPackageAccess(perm.AccessModeWrite), container.PutManifest)
+			g.MatchPath("PUT", `/<image:*>/manifests/<reference>`, container.VerifyImageName, reqPackageAccess(perm.AccessModeWrite), reqPackageUploadAllowed(), container.PutManifest)
 			g.MatchPath("DELETE", `/<image:*>/manifests/<reference>`, container.VerifyImageName, reqPackageAccess(perm.AccessModeWrite), container.DeleteManifest)
 		})
-	}, container.ReqContainerAccess, context.UserAssignmentWeb(), context.PackageAssignment(), reqPackageAccess(perm.AccessModeRead))
+	}, container.ReqContainerAccess, context.UserAssignmentWeb(), context.PackageAssignment(), reqPackageAccess(perm.AccessModeRead), packageRemoteAssignment(packages_model.TypeContainer))
 
 	return r
 }
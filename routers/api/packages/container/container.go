@@ -11,6 +11,7 @@ import (
 	"net/url"
 	"os"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 	"sync"
@@ -36,6 +37,8 @@ import (
 	container_service "gitea.dev/services/packages/container"
 
 	"github.com/opencontainers/go-digest"
+	specs "github.com/opencontainers/image-spec/specs-go"
+	oci "github.com/opencontainers/image-spec/specs-go/v1"
 )
 
 // maximum size of a container manifest
@@ -52,13 +55,15 @@ var globalVars = sync.OnceValue(func() (ret struct {
 })
 
 type containerHeaders struct {
-	Status        int
-	ContentDigest string
-	UploadUUID    string
-	Range         string
-	Location      string
-	ContentType   string
-	ContentLength optional.Option[int64]
+	Status         int
+	ContentDigest  string
+	UploadUUID     string
+	Range          string
+	Location       string
+	ContentType    string
+	ContentLength  optional.Option[int64]
+	Subject        string
+	FiltersApplied string
 }
 
 // https://github.com/opencontainers/distribution-spec/blob/main/spec.md#legacy-docker-support-http-headers
@@ -82,6 +87,12 @@ func setResponseHeaders(resp http.ResponseWriter, h *containerHeaders) {
 		resp.Header().Set("Docker-Content-Digest", h.ContentDigest)
 		resp.Header().Set("ETag", fmt.Sprintf(`"%s"`, h.ContentDigest))
 	}
+	if h.Subject != "" {
+		resp.Header().Set("OCI-Subject", h.Subject)
+	}
+	if h.FiltersApplied != "" {
+		resp.Header().Set("OCI-Filters-Applied", h.FiltersApplied)
+	}
 	resp.Header().Set("Docker-Distribution-Api-Version", "registry/2.0")
 	resp.WriteHeader(h.Status)
 }
@@ -516,6 +527,12 @@ func getBlobFromContext(ctx *context.Context) (*packages_model.PackageFileDescri
 // https://github.com/opencontainers/distribution-spec/blob/main/spec.md#checking-if-content-exists-in-the-registry
 func HeadBlob(ctx *context.Context) {
 	blob, err := getBlobFromContext(ctx)
+	if err != nil && ctx.Package.Remote != nil && errors.Is(err, container_model.ErrContainerBlobNotExist) {
+		if blob, err = pullRemoteBlob(ctx); err != nil {
+			handleRemoteError(ctx, err, errBlobUnknown)
+			return
+		}
+	}
 	if err != nil {
 		if errors.Is(err, container_model.ErrContainerBlobNotExist) {
 			apiErrorDefined(ctx, errBlobUnknown)
@@ -535,6 +552,12 @@ func HeadBlob(ctx *context.Context) {
 // https://github.com/opencontainers/distribution-spec/blob/main/spec.md#pulling-blobs
 func GetBlob(ctx *context.Context) {
 	blob, err := getBlobFromContext(ctx)
+	if err != nil && ctx.Package.Remote != nil && errors.Is(err, container_model.ErrContainerBlobNotExist) {
+		if blob, err = pullRemoteBlob(ctx); err != nil {
+			handleRemoteError(ctx, err, errBlobUnknown)
+			return
+		}
+	}
 	if err != nil {
 		if errors.Is(err, container_model.ErrContainerBlobNotExist) {
 			apiErrorDefined(ctx, errBlobUnknown)
@@ -596,7 +619,7 @@ func PutManifest(ctx *context.Context) {
 		return
 	}
 
-	digest, err := processManifest(ctx, mci, buf)
+	digest, subject, err := processManifest(ctx, mci, buf)
 	if err != nil {
 		var namedError *namedError
 		if errors.As(err, &namedError) {
@@ -614,6 +637,7 @@ func PutManifest(ctx *context.Context) {
 	setResponseHeaders(ctx.Resp, &containerHeaders{
 		Location:      fmt.Sprintf("/v2/%s/%s/manifests/%s", ctx.Package.Owner.LowerName, mci.Image, reference),
 		ContentDigest: digest,
+		Subject:       subject,
 		Status:        http.StatusCreated,
 	})
 }
@@ -650,6 +674,10 @@ func getManifestFromContext(ctx *context.Context) (*packages_model.PackageFileDe
 // https://github.com/opencontainers/distribution-spec/blob/main/spec.md#checking-if-content-exists-in-the-registry
 func HeadManifest(ctx *context.Context) {
 	manifest, err := getManifestFromContext(ctx)
+	if err != nil && ctx.Package.Remote != nil && errors.Is(err, container_model.ErrContainerBlobNotExist) {
+		serveRemoteManifest(ctx, false)
+		return
+	}
 	if err != nil {
 		if errors.Is(err, container_model.ErrContainerBlobNotExist) {
 			apiErrorDefined(ctx, errManifestUnknown)
@@ -670,6 +698,10 @@ func HeadManifest(ctx *context.Context) {
 // https://github.com/opencontainers/distribution-spec/blob/main/spec.md#pulling-manifests
 func GetManifest(ctx *context.Context) {
 	manifest, err := getManifestFromContext(ctx)
+	if err != nil && ctx.Package.Remote != nil && errors.Is(err, container_model.ErrContainerBlobNotExist) {
+		serveRemoteManifest(ctx, true)
+		return
+	}
 	if err != nil {
 		if errors.Is(err, container_model.ErrContainerBlobNotExist) {
 			apiErrorDefined(ctx, errManifestUnknown)
@@ -790,6 +822,79 @@ func GetTagsList(ctx *context.Context) {
 	})
 }
 
+// https://github.com/opencontainers/distribution-spec/blob/main/spec.md#listing-referrers
+func GetReferrers(ctx *context.Context) {
+	d := digest.Digest(ctx.PathParam("digest"))
+	if d.Validate() != nil {
+		apiErrorDefined(ctx, errDigestInvalid)
+		return
+	}
+
+	artifactType := ctx.FormTrim("artifactType")
+
+	pfds, err := container_model.GetContainerBlobs(ctx, &container_model.BlobSearchOptions{
+		OwnerID:    ctx.Package.Owner.ID,
+		Image:      ctx.PathParam("image"),
+		IsManifest: true,
+		OnlyLead:   true,
+		Subject:    string(d),
+	})
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	manifests := make([]oci.Descriptor, 0, len(pfds))
+	for _, pfd := range pfds {
+		pv, err := packages_model.GetVersionByID(ctx, pfd.File.VersionID)
+		if err != nil {
+			apiError(ctx, http.StatusInternalServerError, err)
+			return
+		}
+		metadata := &container_module.Metadata{}
+		if err := json.Unmarshal([]byte(pv.MetadataJSON), metadata); err != nil {
+			apiError(ctx, http.StatusInternalServerError, err)
+			return
+		}
+
+		manifests = append(manifests, oci.Descriptor{
+			MediaType:    pfd.Properties.GetByName(container_module.PropertyMediaType),
+			Digest:       digest.Digest(pfd.Properties.GetByName(container_module.PropertyDigest)),
+			Size:         pfd.Blob.Size,
+			ArtifactType: metadata.ArtifactType,
+			Annotations:  metadata.Annotations,
+		})
+	}
+
+	if ctx.Package.Remote != nil {
+		remoteManifests, err := fetchRemoteReferrers(ctx, ctx.PathParam("image"), d)
+		if err != nil {
+			log.Warn("Failed to fetch referrers of %s from remote: %v", d, err)
+		}
+		for _, m := range remoteManifests {
+			if !slices.ContainsFunc(manifests, func(local oci.Descriptor) bool { return local.Digest == m.Digest }) {
+				manifests = append(manifests, m)
+			}
+		}
+	}
+
+	headers := &containerHeaders{
+		ContentType: oci.MediaTypeImageIndex,
+		Status:      http.StatusOK,
+	}
+	if artifactType != "" {
+		manifests = slices.DeleteFunc(manifests, func(m oci.Descriptor) bool { return m.ArtifactType != artifactType })
+		headers.FiltersApplied = "artifactType"
+	}
+
+	setResponseHeaders(ctx.Resp, headers)
+	_ = json.NewEncoder(ctx.Resp).Encode(oci.Index{
+		Versioned: specs.Versioned{SchemaVersion: 2},
+		MediaType: oci.MediaTypeImageIndex,
+		Manifests: manifests,
+	})
+}
+
 // FIXME: Workaround to be removed in v1.20.
 // Update maybe we should never really remote it, as long as there is legacy data?
 // https://github.com/go-gitea/gitea/issues/19586
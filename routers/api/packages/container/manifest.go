@@ -40,38 +40,51 @@ type manifestCreationInfo struct {
 	Properties map[string]string
 }
 
-func processManifest(ctx context.Context, mci *manifestCreationInfo, buf *packages_module.HashedBuffer) (string, error) {
+// processManifest stores the manifest and returns its digest and the digest of its subject if it refers to another manifest
+func processManifest(ctx context.Context, mci *manifestCreationInfo, buf *packages_module.HashedBuffer) (manifestDigest, subjectDigest string, _ error) {
 	var index oci.Index
 	if err := json.NewDecoder(buf).Decode(&index); err != nil {
-		return "", err
+		return "", "", err
 	}
 	if index.SchemaVersion != 2 {
-		return "", errUnsupported.WithMessage("Schema version is not supported")
+		return "", "", errUnsupported.WithMessage("Schema version is not supported")
 	}
 	if _, err := buf.Seek(0, io.SeekStart); err != nil {
-		return "", err
+		return "", "", err
 	}
 
 	if !container_module.IsMediaTypeValid(mci.MediaType) {
 		mci.MediaType = index.MediaType
 		if !container_module.IsMediaTypeValid(mci.MediaType) {
-			return "", errManifestInvalid.WithMessage("MediaType not recognized")
+			return "", "", errManifestInvalid.WithMessage("MediaType not recognized")
+		}
+	}
+
+	if index.Subject != nil {
+		if index.Subject.Digest.Validate() != nil {
+			return "", "", errManifestInvalid.WithMessage("Subject digest is invalid")
 		}
+		subjectDigest = index.Subject.Digest.String()
 	}
 
 	// .../container/manifest.go:453:createManifestBlob() [E] Error inserting package blob: Error 1062 (23000): Duplicate entry '..........' for key 'package_blob.UQE_package_blob_md5'
 	releaser, err := globallock.Lock(ctx, containerGlobalLockKey(mci.Owner.ID, mci.Image, "manifest"))
 	if err != nil {
-		return "", err
+		return "", "", err
 	}
 	defer releaser()
 
 	if container_module.IsMediaTypeImageManifest(mci.MediaType) {
-		return processOciImageManifest(ctx, mci, buf)
+		manifestDigest, err = processOciImageManifest(ctx, mci, buf)
 	} else if container_module.IsMediaTypeImageIndex(mci.MediaType) {
-		return processOciImageIndex(ctx, mci, buf)
+		manifestDigest, err = processOciImageIndex(ctx, mci, buf)
+	} else {
+		err = errManifestInvalid
 	}
-	return "", errManifestInvalid
+	if err != nil {
+		return "", "", err
+	}
+	return manifestDigest, subjectDigest, nil
 }
 
 type processManifestTxRet struct {
@@ -173,8 +186,16 @@ func processOciImageIndex(ctx context.Context, mci *manifestCreationInfo, buf *p
 	var txRet processManifestTxRet
 	err := db.WithTx(ctx, func(ctx context.Context) (err error) {
 		metadata := &container_module.Metadata{
-			Type:      container_module.TypeOCI,
-			Manifests: make([]*container_module.Manifest, 0, len(index.Manifests)),
+			Type:         container_module.TypeOCI,
+			Manifests:    make([]*container_module.Manifest, 0, len(index.Manifests)),
+			ArtifactType: index.ArtifactType,
+			Annotations:  index.Annotations,
+		}
+		if index.ArtifactType != "" {
+			metadata.Type = container_module.TypeArtifact
+		}
+		if index.Subject != nil {
+			metadata.Subject = index.Subject.Digest.String()
 		}
 
 		for _, manifest := range index.Manifests {
@@ -318,6 +339,15 @@ func createPackageAndVersion(ctx context.Context, mci *manifestCreationInfo, met
 		}
 	}
 
+	if err = packages_model.DeletePropertiesByName(ctx, packages_model.PropertyTypeVersion, pv.ID, container_module.PropertyManifestSubject); err != nil {
+		return nil, fmt.Errorf("DeletePropertiesByName(ManifestSubject): %w", err)
+	}
+	if metadata.Subject != "" {
+		if _, err = packages_model.InsertProperty(ctx, packages_model.PropertyTypeVersion, pv.ID, container_module.PropertyManifestSubject, metadata.Subject); err != nil {
+			return nil, fmt.Errorf("InsertProperty(ManifestSubject): %w", err)
+		}
+	}
+
 	return pv, nil
 }
 
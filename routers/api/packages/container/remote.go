@@ -0,0 +1,194 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package container
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	packages_model "gitea.dev/models/packages"
+	container_model "gitea.dev/models/packages/container"
+	"gitea.dev/modules/json"
+	"gitea.dev/modules/optional"
+	container_module "gitea.dev/modules/packages/container"
+	"gitea.dev/routers/api/packages/helper"
+	"gitea.dev/services/context"
+	packages_service "gitea.dev/services/packages"
+	remote_service "gitea.dev/services/packages/remote"
+
+	"github.com/opencontainers/go-digest"
+	oci "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+// remoteManifestAccept lists the manifest media types requested from the upstream registry
+var remoteManifestAccept = strings.Join([]string{
+	oci.MediaTypeImageIndex,
+	oci.MediaTypeImageManifest,
+	"application/vnd.docker.distribution.manifest.list.v2+json",
+	"application/vnd.docker.distribution.manifest.v2+json",
+}, ", ")
+
+var errRemoteDigestMismatch = errors.New("upstream content does not match the digest")
+
+// remoteImageName maps the image name to the name in the upstream registry.
+// Docker Hub keeps official images in the implicit "library" namespace.
+func remoteImageName(remoteURL, image string) string {
+	u, err := url.Parse(remoteURL)
+	if err == nil && !strings.Contains(image, "/") && (u.Host == "registry-1.docker.io" || u.Host == "docker.io" || u.Host == "index.docker.io") {
+		return "library/" + image
+	}
+	return image
+}
+
+type remoteManifest struct {
+	MediaType string
+	Digest    string
+	Data      []byte
+}
+
+// fetchRemoteManifest fetches the manifest from the upstream registry.
+// Manifests are cached as remote metadata so tags are refreshed after the remote TTL.
+func fetchRemoteManifest(ctx *context.Context, image, reference string) (*remoteManifest, error) {
+	r, err := remote_service.New(ctx.Package.Owner, ctx.Package.Remote)
+	if err != nil {
+		return nil, err
+	}
+
+	p := fmt.Sprintf("v2/%s/manifests/%s", remoteImageName(r.Config.URL, image), reference)
+	data, err := r.FetchMetadata(ctx, p, http.Header{"Accept": []string{remoteManifestAccept}})
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > maxManifestSize {
+		return nil, errManifestInvalid.WithMessage("Manifest exceeds maximum size")
+	}
+
+	d := digest.FromBytes(data)
+	if expected := digest.Digest(reference); expected.Validate() == nil && expected != d {
+		return nil, errRemoteDigestMismatch
+	}
+
+	var index oci.Index
+	if err := json.Unmarshal(data, &index); err != nil {
+		return nil, err
+	}
+	mediaType := index.MediaType
+	if !container_module.IsMediaTypeValid(mediaType) {
+		if len(index.Manifests) > 0 {
+			mediaType = oci.MediaTypeImageIndex
+		} else {
+			mediaType = oci.MediaTypeImageManifest
+		}
+	}
+
+	return &remoteManifest{
+		MediaType: mediaType,
+		Digest:    string(d),
+		Data:      data,
+	}, nil
+}
+
+// serveRemoteManifest serves the manifest of the upstream registry
+func serveRemoteManifest(ctx *context.Context, withContent bool) {
+	m, err := fetchRemoteManifest(ctx, ctx.PathParam("image"), ctx.PathParam("reference"))
+	if err != nil {
+		handleRemoteError(ctx, err, errManifestUnknown)
+		return
+	}
+
+	setResponseHeaders(ctx.Resp, &containerHeaders{
+		ContentDigest: m.Digest,
+		ContentType:   m.MediaType,
+		ContentLength: optional.Some(int64(len(m.Data))),
+		Status:        http.StatusOK,
+	})
+	if withContent {
+		_, _ = ctx.Resp.Write(m.Data)
+	}
+}
+
+// pullRemoteBlob fetches the blob from the upstream registry and stores it for the image.
+// Pulled blobs are kept like uploaded blobs and expire with them if no manifest references them.
+func pullRemoteBlob(ctx *context.Context) (*packages_model.PackageFileDescriptor, error) {
+	image := ctx.PathParam("image")
+	d := digest.Digest(ctx.PathParam("digest"))
+	if d.Validate() != nil || d.Algorithm() != digest.SHA256 {
+		return nil, container_model.ErrContainerBlobNotExist
+	}
+
+	r, err := remote_service.New(ctx.Package.Owner, ctx.Package.Remote)
+	if err != nil {
+		return nil, err
+	}
+
+	buf, source, err := r.FetchFile(ctx, fmt.Sprintf("v2/%s/blobs/%s", remoteImageName(r.Config.URL, image), d), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer buf.Close()
+
+	if digestFromHashSummer(buf) != string(d) {
+		return nil, errRemoteDigestMismatch
+	}
+
+	if _, err := saveAsPackageBlob(ctx, buf, &packages_service.PackageCreationInfo{
+		PackageInfo: packages_service.PackageInfo{
+			Owner: ctx.Package.Owner,
+			Name:  image,
+		},
+		Creator: ctx.Package.Owner,
+	}); err != nil {
+		return nil, err
+	}
+
+	pfd, err := getBlobFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := remote_service.RecordFileProvenance(ctx, pfd.File, source); err != nil {
+		return nil, err
+	}
+	return pfd, nil
+}
+
+// handleRemoteError writes the response for a failed pull-through request
+func handleRemoteError(ctx *context.Context, err error, errUnknown *namedError) {
+	var namedError *namedError
+	if errors.As(err, &namedError) {
+		apiErrorDefined(ctx, namedError)
+		return
+	}
+	status, err := helper.RemoteError(err)
+	if status == http.StatusNotFound {
+		apiErrorDefined(ctx, errUnknown)
+		return
+	}
+	apiError(ctx, status, err)
+}
+
+// fetchRemoteReferrers fetches the referrers of the manifest from the upstream registry.
+// Registries without support for the referrers API do not know any referrers.
+func fetchRemoteReferrers(ctx *context.Context, image string, d digest.Digest) ([]oci.Descriptor, error) {
+	r, err := remote_service.New(ctx.Package.Owner, ctx.Package.Remote)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := r.FetchMetadata(ctx, fmt.Sprintf("v2/%s/referrers/%s", remoteImageName(r.Config.URL, image), d), http.Header{"Accept": []string{oci.MediaTypeImageIndex}})
+	if err != nil {
+		if errors.Is(err, remote_service.ErrUpstreamNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var index oci.Index
+	if err := json.Unmarshal(data, &index); err != nil {
+		return nil, err
+	}
+	return index.Manifests, nil
+}
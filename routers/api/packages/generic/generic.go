@@ -5,6 +5,7 @@ package generic
 
 import (
 	"errors"
+	"io"
 	"net/http"
 	"regexp"
 	"strings"
@@ -15,6 +16,7 @@ import (
 	"gitea.dev/routers/api/packages/helper"
 	"gitea.dev/services/context"
 	packages_service "gitea.dev/services/packages"
+	attestation_service "gitea.dev/services/packages/attestation"
 )
 
 var (
@@ -105,7 +107,7 @@ func UploadPackage(ctx *context.Context) {
 	}
 	defer buf.Close()
 
-	_, _, err = packages_service.CreatePackageOrAddFileToExisting(
+	pv, _, err := packages_service.CreatePackageOrAddFileToExisting(
 		ctx,
 		&packages_service.PackageCreationInfo{
 			PackageInfo: packages_service.PackageInfo{
@@ -137,6 +139,16 @@ func UploadPackage(ctx *context.Context) {
 		return
 	}
 
+	// signatures, provenance statements and SBOMs uploaded next to the files are recorded as attestations
+	if _, err := buf.Seek(0, io.SeekStart); err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+	if err := attestation_service.CreateFromSidecar(ctx, ctx.Doer, pv, filename, buf); err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
 	ctx.Status(http.StatusCreated)
 }
 
@@ -4,6 +4,7 @@
 package helper
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -12,9 +13,13 @@ import (
 	packages_model "gitea.dev/models/packages"
 	"gitea.dev/modules/log"
 	"gitea.dev/modules/setting"
+	"gitea.dev/modules/util"
 	"gitea.dev/services/context"
+	packages_service "gitea.dev/services/packages"
 )
 
+var errUpstreamFailed = errors.New("failed to fetch from upstream registry")
+
 // ProcessErrorForUser logs the error and returns a user-error message for the end user.
 // If the status is http.StatusInternalServerError, the message is stripped for non-admin users in production.
 func ProcessErrorForUser(ctx *context.Context, status int, errObj any) string {
@@ -37,6 +42,19 @@ func ProcessErrorForUser(ctx *context.Context, status int, errObj any) string {
 	return message
 }
 
+// RemoteError returns the status and the error to report for a failed pull-through request.
+// Upstream failures are logged and replaced by a generic error as they may expose internal details.
+func RemoteError(err error) (int, error) {
+	switch {
+	case errors.Is(err, util.ErrNotExist):
+		return http.StatusNotFound, err
+	case errors.Is(err, packages_service.ErrQuotaTotalCount), errors.Is(err, packages_service.ErrQuotaTypeSize), errors.Is(err, packages_service.ErrQuotaTotalSize):
+		return http.StatusForbidden, err
+	}
+	log.Warn("Package remote request failed: %v", err)
+	return http.StatusBadGateway, errUpstreamFailed
+}
+
 // ServePackageFile the content of the package file
 // If the url is set it will redirect the request, otherwise the content is copied to the response.
 func ServePackageFile(ctx *context.Context, s io.ReadSeekCloser, u *url.URL, pf *packages_model.PackageFile, forceOpts ...context.ServeHeaderOptions) {
@@ -0,0 +1,316 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package hex
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	packages_model "gitea.dev/models/packages"
+	"gitea.dev/modules/json"
+	packages_module "gitea.dev/modules/packages"
+	hex_module "gitea.dev/modules/packages/hex"
+	"gitea.dev/modules/setting"
+	"gitea.dev/modules/util"
+	"gitea.dev/routers/api/packages/helper"
+	"gitea.dev/services/context"
+	packages_service "gitea.dev/services/packages"
+	hex_service "gitea.dev/services/packages/hex"
+)
+
+const maxRetirementBodySize = 64 * 1024
+
+// termResponse writes the object in the Erlang external term format if the client accepts it, otherwise as JSON
+func termResponse(ctx *context.Context, status int, obj map[string]any) {
+	if strings.Contains(ctx.Req.Header.Get("Accept"), hex_module.ContentTypeErlang) {
+		data, err := hex_module.EncodeExternalTerm(obj)
+		if err == nil {
+			ctx.Resp.Header().Set("Content-Type", hex_module.ContentTypeErlang)
+			ctx.Resp.WriteHeader(status)
+			_, _ = ctx.Resp.Write(data)
+			return
+		}
+	}
+
+	ctx.Resp.Header().Set("Content-Type", "application/json")
+	ctx.Resp.WriteHeader(status)
+	_ = json.NewEncoder(ctx.Resp).Encode(obj)
+}
+
+func apiError(ctx *context.Context, status int, obj any) {
+	message := helper.ProcessErrorForUser(ctx, status, obj)
+	termResponse(ctx, status, map[string]any{
+		"status":  status,
+		"message": message,
+	})
+}
+
+func serveResource(ctx *context.Context, data []byte, err error) {
+	if err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			apiError(ctx, http.StatusNotFound, err)
+		} else {
+			apiError(ctx, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	ctx.Resp.Header().Set("Content-Type", "application/octet-stream")
+	ctx.Resp.WriteHeader(http.StatusOK)
+	_, _ = ctx.Resp.Write(data)
+}
+
+// https://github.com/hexpm/specifications/blob/main/endpoints.md#repository
+func EnumeratePackageNames(ctx *context.Context) {
+	data, err := hex_service.BuildNamesResource(ctx, ctx.Package.Owner)
+	serveResource(ctx, data, err)
+}
+
+// https://github.com/hexpm/specifications/blob/main/endpoints.md#repository
+func EnumeratePackageVersions(ctx *context.Context) {
+	data, err := hex_service.BuildVersionsResource(ctx, ctx.Package.Owner)
+	serveResource(ctx, data, err)
+}
+
+// https://github.com/hexpm/specifications/blob/main/endpoints.md#repository
+func PackageReleases(ctx *context.Context) {
+	data, err := hex_service.BuildPackageResource(ctx, ctx.Package.Owner, ctx.PathParam("name"))
+	serveResource(ctx, data, err)
+}
+
+// GetPublicKey returns the public key used to verify the signed registry resources
+func GetPublicKey(ctx *context.Context) {
+	_, pub, err := hex_service.GetOrCreateKeyPair(ctx, ctx.Package.Owner.ID)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.ServeContent(strings.NewReader(pub), context.ServeHeaderOptions{
+		ContentType: "application/x-pem-file",
+		Filename:    ctx.Package.Owner.LowerName + ".pem",
+	})
+}
+
+// https://github.com/hexpm/specifications/blob/main/endpoints.md#repository
+func DownloadPackageFile(ctx *context.Context) {
+	filename := ctx.PathParam("filename")
+
+	packageName, packageVersion, ok := strings.Cut(strings.TrimSuffix(filename, ".tar"), "-")
+	if !ok || !strings.HasSuffix(filename, ".tar") {
+		apiError(ctx, http.StatusNotFound, nil)
+		return
+	}
+
+	s, u, pf, err := packages_service.OpenFileForDownloadByPackageNameAndVersion(
+		ctx,
+		&packages_service.PackageInfo{
+			Owner:       ctx.Package.Owner,
+			PackageType: packages_model.TypeHex,
+			Name:        packageName,
+			Version:     packageVersion,
+		},
+		&packages_service.PackageFileInfo{
+			Filename: filename,
+		},
+		ctx.Req.Method,
+	)
+	if err != nil {
+		if errors.Is(err, packages_model.ErrPackageNotExist) || errors.Is(err, packages_model.ErrPackageFileNotExist) {
+			apiError(ctx, http.StatusNotFound, err)
+			return
+		}
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	helper.ServePackageFile(ctx, s, u, pf)
+}
+
+// UploadPackage publishes a package tarball like "mix hex.publish" does
+// https://github.com/hexpm/specifications/blob/main/apiary.apib
+func UploadPackage(ctx *context.Context) {
+	upload, needToClose, err := ctx.UploadStream()
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+	if needToClose {
+		defer upload.Close()
+	}
+
+	buf, err := packages_module.CreateHashedBufferFromReader(upload)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+	defer buf.Close()
+
+	pck, err := hex_module.ParsePackage(buf)
+	if err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			apiError(ctx, http.StatusUnprocessableEntity, err)
+		} else {
+			apiError(ctx, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	if _, err := buf.Seek(0, io.SeekStart); err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	if ctx.FormBool("replace") {
+		pv, err := packages_model.GetVersionByNameAndVersion(ctx, ctx.Package.Owner.ID, packages_model.TypeHex, pck.Name, pck.Version)
+		if err != nil && !errors.Is(err, packages_model.ErrPackageNotExist) {
+			apiError(ctx, http.StatusInternalServerError, err)
+			return
+		}
+		if pv != nil {
+			if err := packages_service.RemovePackageVersion(ctx, ctx.Doer, pv); err != nil {
+				apiError(ctx, http.StatusInternalServerError, err)
+				return
+			}
+		}
+	}
+
+	pv, _, err := packages_service.CreatePackageAndAddFile(
+		ctx,
+		&packages_service.PackageCreationInfo{
+			PackageInfo: packages_service.PackageInfo{
+				Owner:       ctx.Package.Owner,
+				PackageType: packages_model.TypeHex,
+				Name:        pck.Name,
+				Version:     pck.Version,
+			},
+			SemverCompatible: true,
+			Creator:          ctx.Doer,
+			Metadata:         pck.Metadata,
+		},
+		&packages_service.PackageFileCreationInfo{
+			PackageFileInfo: packages_service.PackageFileInfo{
+				Filename: pck.Name + "-" + pck.Version + ".tar",
+			},
+			Creator: ctx.Doer,
+			Data:    buf,
+			IsLead:  true,
+		},
+	)
+	if err != nil {
+		switch err {
+		case packages_model.ErrDuplicatePackageVersion:
+			apiError(ctx, http.StatusConflict, err)
+		case packages_service.ErrQuotaTotalCount, packages_service.ErrQuotaTypeSize, packages_service.ErrQuotaTotalSize:
+			apiError(ctx, http.StatusForbidden, err)
+		default:
+			apiError(ctx, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	pd, err := packages_model.GetPackageDescriptor(ctx, pv)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	termResponse(ctx, http.StatusCreated, map[string]any{
+		"name":     pd.Package.Name,
+		"version":  pd.Version.Version,
+		"checksum": pck.Metadata.InnerChecksum,
+		"url":      fmt.Sprintf("%sapi/packages/%s/hex/packages/%s", setting.AppURL, url.PathEscape(ctx.Package.Owner.Name), url.PathEscape(pd.Package.Name)),
+		"html_url": pd.VersionHTMLURL(ctx),
+	})
+}
+
+func getPackageVersion(ctx *context.Context) *packages_model.PackageVersion {
+	pv, err := packages_model.GetVersionByNameAndVersion(ctx, ctx.Package.Owner.ID, packages_model.TypeHex, ctx.PathParam("name"), ctx.PathParam("version"))
+	if err != nil {
+		if errors.Is(err, packages_model.ErrPackageNotExist) {
+			apiError(ctx, http.StatusNotFound, err)
+		} else {
+			apiError(ctx, http.StatusInternalServerError, err)
+		}
+		return nil
+	}
+	return pv
+}
+
+// RetireRelease marks a release as retired, the client warns about retired releases
+func RetireRelease(ctx *context.Context) {
+	pv := getPackageVersion(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	data, err := util.ReadWithLimit(ctx.Req.Body, maxRetirementBodySize)
+	if err != nil {
+		apiError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	var body map[string]any
+	if strings.Contains(ctx.Req.Header.Get("Content-Type"), hex_module.ContentTypeErlang) {
+		term, err := hex_module.DecodeExternalTerm(data)
+		if err != nil {
+			apiError(ctx, http.StatusBadRequest, err)
+			return
+		}
+		body, _ = term.(map[string]any)
+	} else if err := json.NewDecoder(bytes.NewReader(data)).Decode(&body); err != nil {
+		apiError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	reasonName, _ := body["reason"].(string)
+	reason, ok := hex_module.ParseRetirementReason(reasonName)
+	if !ok {
+		apiError(ctx, http.StatusUnprocessableEntity, "invalid retirement reason")
+		return
+	}
+	message, _ := body["message"].(string)
+
+	if err := hex_service.SetRetirement(ctx, pv, &hex_module.Retirement{Reason: reason, Message: message}); err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// UnretireRelease removes the retirement of a release
+func UnretireRelease(ctx *context.Context) {
+	pv := getPackageVersion(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	if err := hex_service.SetRetirement(ctx, pv, nil); err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// RevertRelease deletes a release like "mix hex.publish --revert" does
+func RevertRelease(ctx *context.Context) {
+	pv := getPackageVersion(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	if err := packages_service.RemovePackageVersion(ctx, ctx.Doer, pv); err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
@@ -22,12 +22,14 @@ import (
 	packages_model "gitea.dev/models/packages"
 	"gitea.dev/modules/globallock"
 	"gitea.dev/modules/json"
+	"gitea.dev/modules/log"
 	packages_module "gitea.dev/modules/packages"
 	maven_module "gitea.dev/modules/packages/maven"
 	"gitea.dev/modules/util"
 	"gitea.dev/routers/api/packages/helper"
 	"gitea.dev/services/context"
 	packages_service "gitea.dev/services/packages"
+	attestation_service "gitea.dev/services/packages/attestation"
 )
 
 const maxChecksumSize = sha512.Size*2 + 1
@@ -94,34 +96,56 @@ func serveMavenMetadata(ctx *context.Context, params parameters) {
 	}
 	pvs = append(pvsLegacy, pvs...)
 
-	if len(pvs) == 0 {
-		apiError(ctx, http.StatusNotFound, packages_model.ErrPackageNotExist)
-		return
+	var metadata *MetadataResponse
+	if len(pvs) > 0 {
+		pds, err := packages_model.GetPackageDescriptors(ctx, pvs)
+		if err != nil {
+			apiError(ctx, http.StatusInternalServerError, err)
+			return
+		}
+
+		sort.Slice(pds, func(i, j int) bool {
+			// Maven and Gradle order packages by their creation timestamp and not by their version string
+			return pds[i].Version.CreatedUnix < pds[j].Version.CreatedUnix
+		})
+
+		metadata = createMetadataResponse(pds, params.GroupID, params.ArtifactID)
+
+		latest := pds[len(pds)-1]
+		// http.TimeFormat required a UTC time, refer to https://pkg.go.dev/net/http#TimeFormat
+		lastModified := latest.Version.CreatedUnix.AsTime().UTC().Format(http.TimeFormat)
+		ctx.Resp.Header().Set("Last-Modified", lastModified)
 	}
 
-	pds, err := packages_model.GetPackageDescriptors(ctx, pvs)
-	if err != nil {
-		apiError(ctx, http.StatusInternalServerError, err)
-		return
+	if ctx.Package.Remote != nil {
+		upstream, err := fetchRemoteMetadata(ctx, params)
+		if err == nil {
+			metadata = mergeMetadataResponse(upstream, metadata)
+		} else if metadata == nil {
+			status, err := helper.RemoteError(err)
+			apiError(ctx, status, err)
+			return
+		} else {
+			log.Warn("Serving local metadata of Maven package %s: %v", params.toInternalPackageName(), err)
+		}
 	}
 
-	sort.Slice(pds, func(i, j int) bool {
-		// Maven and Gradle order packages by their creation timestamp and not by their version string
-		return pds[i].Version.CreatedUnix < pds[j].Version.CreatedUnix
-	})
+	if metadata == nil {
+		apiError(ctx, http.StatusNotFound, packages_model.ErrPackageNotExist)
+		return
+	}
 
-	xmlMetadata, err := xml.Marshal(createMetadataResponse(pds, params.GroupID, params.ArtifactID))
+	xmlMetadata, err := xml.Marshal(metadata)
 	if err != nil {
 		apiError(ctx, http.StatusInternalServerError, err)
 		return
 	}
-	xmlMetadataWithHeader := append([]byte(xml.Header), xmlMetadata...)
 
-	latest := pds[len(pds)-1]
-	// http.TimeFormat required a UTC time, refer to https://pkg.go.dev/net/http#TimeFormat
-	lastModified := latest.Version.CreatedUnix.AsTime().UTC().Format(http.TimeFormat)
-	ctx.Resp.Header().Set("Last-Modified", lastModified)
+	writeMetadata(ctx, params, append([]byte(xml.Header), xmlMetadata...))
+}
 
+// writeMetadata writes the metadata document or its checksum if requested
+func writeMetadata(ctx *context.Context, params parameters, xmlMetadataWithHeader []byte) {
 	ext := strings.ToLower(path.Ext(params.Filename))
 	if isChecksumExtension(ext) {
 		var hash []byte
@@ -150,19 +174,6 @@ func serveMavenMetadata(ctx *context.Context, params parameters) {
 }
 
 func servePackageFile(ctx *context.Context, params parameters, serveContent bool) {
-	pv, err := packages_model.GetVersionByNameAndVersion(ctx, ctx.Package.Owner.ID, packages_model.TypeMaven, params.toInternalPackageName(), params.Version)
-	if errors.Is(err, util.ErrNotExist) {
-		pv, err = packages_model.GetVersionByNameAndVersion(ctx, ctx.Package.Owner.ID, packages_model.TypeMaven, params.toInternalPackageNameLegacy(), params.Version)
-	}
-	if err != nil {
-		if errors.Is(err, packages_model.ErrPackageNotExist) {
-			apiError(ctx, http.StatusNotFound, err)
-		} else {
-			apiError(ctx, http.StatusInternalServerError, err)
-		}
-		return
-	}
-
 	filename := params.Filename
 
 	ext := strings.ToLower(path.Ext(filename))
@@ -170,9 +181,22 @@ func servePackageFile(ctx *context.Context, params parameters, serveContent bool
 		filename = filename[:len(filename)-len(ext)]
 	}
 
-	pf, err := packages_model.GetFileForVersionByName(ctx, pv.ID, filename, packages_model.EmptyFileKey)
+	pf, err := getPackageFile(ctx, params, filename)
+	if err != nil && ctx.Package.Remote != nil && errors.Is(err, util.ErrNotExist) {
+		if params.IsMeta {
+			// snapshot metadata changes with every deployment and is not stored
+			serveRemoteMetadata(ctx, params)
+			return
+		}
+		if err = pullRemotePackageFile(ctx, params, filename); err != nil {
+			status, err := helper.RemoteError(err)
+			apiError(ctx, status, err)
+			return
+		}
+		pf, err = getPackageFile(ctx, params, filename)
+	}
 	if err != nil {
-		if errors.Is(err, packages_model.ErrPackageFileNotExist) {
+		if errors.Is(err, util.ErrNotExist) {
 			apiError(ctx, http.StatusNotFound, err)
 		} else {
 			apiError(ctx, http.StatusInternalServerError, err)
@@ -230,6 +254,17 @@ func servePackageFile(ctx *context.Context, params parameters, serveContent bool
 	helper.ServePackageFile(ctx, s, u, pf, opts)
 }
 
+func getPackageFile(ctx *context.Context, params parameters, filename string) (*packages_model.PackageFile, error) {
+	pv, err := packages_model.GetVersionByNameAndVersion(ctx, ctx.Package.Owner.ID, packages_model.TypeMaven, params.toInternalPackageName(), params.Version)
+	if errors.Is(err, util.ErrNotExist) {
+		pv, err = packages_model.GetVersionByNameAndVersion(ctx, ctx.Package.Owner.ID, packages_model.TypeMaven, params.toInternalPackageNameLegacy(), params.Version)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return packages_model.GetFileForVersionByName(ctx, pv.ID, filename, packages_model.EmptyFileKey)
+}
+
 func mavenPkgNameKey(packageName string) string {
 	return "pkg_maven_" + packageName
 }
@@ -393,7 +428,7 @@ func UploadPackageFile(ctx *context.Context) {
 		}
 	}
 
-	_, _, err = packages_service.CreatePackageOrAddFileToExisting(
+	pv, _, err := packages_service.CreatePackageOrAddFileToExisting(
 		ctx,
 		pvci,
 		pfci,
@@ -410,6 +445,16 @@ func UploadPackageFile(ctx *context.Context) {
 		return
 	}
 
+	// "gpg:sign-and-deploy-file" uploads a ".asc" file for every artifact
+	if _, err := buf.Seek(0, io.SeekStart); err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+	if err := attestation_service.CreateFromSidecar(ctx, ctx.Doer, pv, params.Filename, buf); err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
 	ctx.Status(http.StatusCreated)
 }
 
@@ -0,0 +1,188 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package maven
+
+import (
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"io"
+	"path"
+	"slices"
+	"strings"
+
+	packages_model "gitea.dev/models/packages"
+	"gitea.dev/modules/globallock"
+	"gitea.dev/modules/json"
+	"gitea.dev/modules/log"
+	maven_module "gitea.dev/modules/packages/maven"
+	"gitea.dev/modules/util"
+	"gitea.dev/routers/api/packages/helper"
+	"gitea.dev/services/context"
+	packages_service "gitea.dev/services/packages"
+	remote_service "gitea.dev/services/packages/remote"
+)
+
+var errChecksumMismatch = util.NewInvalidArgumentErrorf("checksum mismatch")
+
+// remotePath returns the requested path without a checksum extension
+func remotePath(ctx *context.Context) string {
+	p := ctx.PathParam("*")
+	if ext := strings.ToLower(path.Ext(p)); isChecksumExtension(ext) {
+		p = p[:len(p)-len(ext)]
+	}
+	return p
+}
+
+func fetchRemoteMetadataBytes(ctx *context.Context) ([]byte, error) {
+	r, err := remote_service.New(ctx.Package.Owner, ctx.Package.Remote)
+	if err != nil {
+		return nil, err
+	}
+	return r.FetchMetadata(ctx, remotePath(ctx), nil)
+}
+
+// fetchRemoteMetadata fetches the artifact metadata from the upstream repository
+func fetchRemoteMetadata(ctx *context.Context, params parameters) (*MetadataResponse, error) {
+	data, err := fetchRemoteMetadataBytes(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	metadata := &MetadataResponse{}
+	if err := xml.Unmarshal(data, metadata); err != nil {
+		return nil, err
+	}
+	metadata.GroupID = params.GroupID
+	metadata.ArtifactID = params.ArtifactID
+	return metadata, nil
+}
+
+// mergeMetadataResponse combines the upstream metadata with the local one.
+// Versions which only exist locally are newer uploads and take precedence for latest and release.
+func mergeMetadataResponse(upstream, local *MetadataResponse) *MetadataResponse {
+	if local == nil {
+		return upstream
+	}
+
+	addedLocal := false
+	for _, v := range local.Version {
+		if !slices.Contains(upstream.Version, v) {
+			upstream.Version = append(upstream.Version, v)
+			addedLocal = true
+		}
+	}
+	if addedLocal {
+		upstream.Latest = local.Latest
+		if local.Release != "" && !slices.Contains(upstream.Version[:len(upstream.Version)-1], local.Release) {
+			upstream.Release = local.Release
+		}
+	}
+	if upstream.Latest == "" && len(upstream.Version) > 0 {
+		upstream.Latest = upstream.Version[len(upstream.Version)-1]
+	}
+	return upstream
+}
+
+// serveRemoteMetadata serves the snapshot metadata of the upstream repository
+func serveRemoteMetadata(ctx *context.Context, params parameters) {
+	data, err := fetchRemoteMetadataBytes(ctx)
+	if err != nil {
+		status, err := helper.RemoteError(err)
+		apiError(ctx, status, err)
+		return
+	}
+	writeMetadata(ctx, params, data)
+}
+
+// pullRemotePackageFile fetches the file from the upstream repository and stores it.
+// The file is verified with the SHA-1 checksum published next to it if the upstream provides one.
+func pullRemotePackageFile(ctx *context.Context, params parameters, filename string) error {
+	r, err := remote_service.New(ctx.Package.Owner, ctx.Package.Remote)
+	if err != nil {
+		return err
+	}
+
+	packageName := params.toInternalPackageName()
+
+	releaser, err := globallock.Lock(ctx, mavenPkgNameKey(packageName))
+	if err != nil {
+		return err
+	}
+	defer releaser()
+
+	p := remotePath(ctx)
+
+	buf, source, err := r.FetchFile(ctx, p, nil)
+	if err != nil {
+		return err
+	}
+	defer buf.Close()
+
+	checksum, _, err := r.FetchFile(ctx, p+extensionSHA1, nil)
+	if err == nil {
+		defer checksum.Close()
+
+		expected, err := io.ReadAll(io.LimitReader(checksum, maxChecksumSize))
+		if err != nil {
+			return err
+		}
+		// some repositories append the filename to the checksum
+		expectedHash, _, _ := strings.Cut(strings.TrimSpace(string(expected)), " ")
+		_, hashSHA1, _, _ := buf.Sums()
+		if !strings.EqualFold(expectedHash, hex.EncodeToString(hashSHA1)) {
+			return errChecksumMismatch
+		}
+	} else if !errors.Is(err, remote_service.ErrUpstreamNotFound) {
+		return err
+	} else {
+		log.Debug("No checksum published for %s", source)
+	}
+
+	pvci := &packages_service.PackageCreationInfo{
+		PackageInfo: packages_service.PackageInfo{
+			Name:    packageName,
+			Version: params.Version,
+		},
+		SemverCompatible: false,
+	}
+	pfci := &packages_service.PackageFileCreationInfo{
+		PackageFileInfo: packages_service.PackageFileInfo{
+			Filename: filename,
+		},
+		Data: buf,
+	}
+
+	if strings.ToLower(path.Ext(filename)) == extensionPom {
+		pfci.IsLead = true
+
+		pvci.Metadata, err = maven_module.ParsePackageMetaData(buf)
+		if err != nil {
+			return err
+		}
+
+		// the version may have been created by pulling another file of it before
+		pv, err := packages_model.GetVersionByNameAndVersion(ctx, ctx.Package.Owner.ID, packages_model.TypeMaven, packageName, params.Version)
+		if err != nil && !errors.Is(err, packages_model.ErrPackageNotExist) {
+			return err
+		}
+		if pv != nil && pvci.Metadata != nil {
+			raw, err := json.Marshal(pvci.Metadata)
+			if err != nil {
+				return err
+			}
+			pv.MetadataJSON = string(raw)
+			if err := packages_model.UpdateVersion(ctx, pv); err != nil {
+				return err
+			}
+		}
+
+		if _, err := buf.Seek(0, io.SeekStart); err != nil {
+			return err
+		}
+	}
+
+	_, _, err = r.StoreFile(ctx, pvci, pfci, source)
+	return err
+}
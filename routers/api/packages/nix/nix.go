@@ -0,0 +1,216 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package nix
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"strings"
+
+	packages_model "gitea.dev/models/packages"
+	packages_module "gitea.dev/modules/packages"
+	nix_module "gitea.dev/modules/packages/nix"
+	"gitea.dev/modules/util"
+	"gitea.dev/routers/api/packages/helper"
+	"gitea.dev/services/context"
+	packages_service "gitea.dev/services/packages"
+	nix_service "gitea.dev/services/packages/nix"
+)
+
+const maxNarInfoSize = 1024 * 1024
+
+func apiError(ctx *context.Context, status int, obj any) {
+	message := helper.ProcessErrorForUser(ctx, status, obj)
+	ctx.PlainText(status, message)
+}
+
+// CacheInfo describes the binary cache
+// https://nix.dev/manual/nix/latest/package-management/binary-cache-substituter
+func CacheInfo(ctx *context.Context) {
+	ctx.Resp.Header().Set("Content-Type", "text/x-nix-cache-info")
+	ctx.PlainText(http.StatusOK, "StoreDir: "+nix_module.StoreDir+"\nWantMassQuery: 1\nPriority: 50\n")
+}
+
+// GetPublicKey returns the public key which must be added to "trusted-public-keys"
+func GetPublicKey(ctx *context.Context) {
+	_, pub, err := nix_service.GetOrCreateKeyPair(ctx, ctx.Package.Owner)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.PlainText(http.StatusOK, pub)
+}
+
+func parseNarInfoFilename(ctx *context.Context) (string, bool) {
+	hash, ok := strings.CutSuffix(ctx.PathParam("filename"), ".narinfo")
+	if !ok || !nix_module.IsValidStoreHash(hash) {
+		apiError(ctx, http.StatusNotFound, nil)
+		return "", false
+	}
+	return hash, true
+}
+
+// GetNarInfo serves the signed narinfo file of a store path
+func GetNarInfo(ctx *context.Context) {
+	hash, ok := parseNarInfoFilename(ctx)
+	if !ok {
+		return
+	}
+
+	pv, err := nix_service.GetStorePathVersion(ctx, ctx.Package.Owner.ID, hash)
+	if err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			apiError(ctx, http.StatusNotFound, err)
+		} else {
+			apiError(ctx, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	pd, err := packages_model.GetPackageDescriptor(ctx, pv)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	narInfo, err := nix_service.BuildNarInfo(ctx, pd)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.ServeContent(strings.NewReader(narInfo), context.ServeHeaderOptions{
+		ContentType:  nix_module.ContentTypeNarInfo,
+		LastModified: pv.CreatedUnix.AsLocalTime(),
+	})
+}
+
+// UploadNarInfo creates a store path from the narinfo file like "nix copy --to" uploads it
+func UploadNarInfo(ctx *context.Context) {
+	hash, ok := parseNarInfoFilename(ctx)
+	if !ok {
+		return
+	}
+
+	data, err := util.ReadWithLimit(ctx.Req.Body, maxNarInfoSize)
+	if err != nil {
+		apiError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	pck, err := nix_module.ParseNarInfo(bytes.NewReader(data))
+	if err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			apiError(ctx, http.StatusBadRequest, err)
+		} else {
+			apiError(ctx, http.StatusInternalServerError, err)
+		}
+		return
+	}
+	if pck.Hash != hash {
+		apiError(ctx, http.StatusBadRequest, "the store path does not match the narinfo filename")
+		return
+	}
+
+	_, err = nix_service.CreateStorePath(ctx, ctx.Doer, ctx.Package.Owner, pck)
+	if err != nil {
+		switch {
+		case errors.Is(err, packages_model.ErrDuplicatePackageVersion):
+			apiError(ctx, http.StatusConflict, err)
+		case errors.Is(err, util.ErrInvalidArgument):
+			apiError(ctx, http.StatusBadRequest, err)
+		case errors.Is(err, packages_service.ErrQuotaTotalCount), errors.Is(err, packages_service.ErrQuotaTypeSize), errors.Is(err, packages_service.ErrQuotaTotalSize):
+			apiError(ctx, http.StatusForbidden, err)
+		default:
+			apiError(ctx, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	ctx.Status(http.StatusCreated)
+}
+
+// DownloadNarFile serves the (compressed) nar file of a store path
+func DownloadNarFile(ctx *context.Context) {
+	filename := ctx.PathParam("filename")
+	if !nix_module.IsValidNarFilename(filename) {
+		apiError(ctx, http.StatusNotFound, nil)
+		return
+	}
+
+	pf, err := nix_service.GetNarFile(ctx, ctx.Package.Owner.ID, filename)
+	if err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			apiError(ctx, http.StatusNotFound, err)
+		} else {
+			apiError(ctx, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	s, u, pf, err := packages_service.OpenFileForDownload(ctx, pf, ctx.Req.Method)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	helper.ServePackageFile(ctx, s, u, pf)
+}
+
+// UploadNarFile stores the nar file until the narinfo file which references it is uploaded
+func UploadNarFile(ctx *context.Context) {
+	filename := ctx.PathParam("filename")
+	if !nix_module.IsValidNarFilename(filename) {
+		apiError(ctx, http.StatusBadRequest, "invalid nar filename")
+		return
+	}
+
+	upload, needToClose, err := ctx.UploadStream()
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+	if needToClose {
+		defer upload.Close()
+	}
+
+	buf, err := packages_module.CreateHashedBufferFromReader(upload)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+	defer buf.Close()
+
+	pv, err := nix_service.GetOrCreateUploadVersion(ctx, ctx.Package.Owner.ID)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	_, err = packages_service.AddFileToPackageVersionInternal(
+		ctx,
+		pv,
+		&packages_service.PackageFileCreationInfo{
+			PackageFileInfo: packages_service.PackageFileInfo{
+				Filename: filename,
+			},
+			Creator:           ctx.Doer,
+			Data:              buf,
+			OverwriteExisting: true,
+		},
+	)
+	if err != nil {
+		switch err {
+		case packages_service.ErrQuotaTotalCount, packages_service.ErrQuotaTypeSize, packages_service.ErrQuotaTotalSize:
+			apiError(ctx, http.StatusForbidden, err)
+		default:
+			apiError(ctx, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	ctx.Status(http.StatusCreated)
+}
@@ -18,6 +18,7 @@ import (
 	repo_model "gitea.dev/models/repo"
 	"gitea.dev/models/unit"
 	"gitea.dev/modules/json"
+	"gitea.dev/modules/log"
 	"gitea.dev/modules/optional"
 	packages_module "gitea.dev/modules/packages"
 	npm_module "gitea.dev/modules/packages/npm"
@@ -26,6 +27,8 @@ import (
 	"gitea.dev/routers/api/packages/helper"
 	"gitea.dev/services/context"
 	packages_service "gitea.dev/services/packages"
+	attestation_service "gitea.dev/services/packages/attestation"
+	remote_service "gitea.dev/services/packages/remote"
 
 	"github.com/hashicorp/go-version"
 )
@@ -54,29 +57,50 @@ func packageNameFromParams(ctx *context.Context) string {
 // PackageMetadata returns the metadata for a single package
 func PackageMetadata(ctx *context.Context) {
 	packageName := packageNameFromParams(ctx)
+	registryURL := setting.AppURL + "api/packages/" + ctx.Package.Owner.Name + "/npm"
 
 	pvs, err := packages_model.GetVersionsByPackageName(ctx, ctx.Package.Owner.ID, packages_model.TypeNpm, packageName)
 	if err != nil {
 		apiError(ctx, http.StatusInternalServerError, err)
 		return
 	}
-	if len(pvs) == 0 {
-		apiError(ctx, http.StatusNotFound, err)
-		return
+
+	var local *npm_module.PackageMetadata
+	if len(pvs) > 0 {
+		pds, err := packages_model.GetPackageDescriptors(ctx, pvs)
+		if err != nil {
+			apiError(ctx, http.StatusInternalServerError, err)
+			return
+		}
+		local = createPackageMetadataResponse(registryURL, pds)
 	}
 
-	pds, err := packages_model.GetPackageDescriptors(ctx, pvs)
-	if err != nil {
-		apiError(ctx, http.StatusInternalServerError, err)
-		return
+	if ctx.Package.Remote != nil {
+		upstream, err := func() (*npm_module.PackageMetadata, error) {
+			r, err := remote_service.New(ctx.Package.Owner, ctx.Package.Remote)
+			if err != nil {
+				return nil, err
+			}
+			return fetchRemotePackageMetadata(ctx, r, packageName)
+		}()
+		if err == nil {
+			ctx.JSON(http.StatusOK, mergePackageMetadata(registryURL, upstream, local))
+			return
+		}
+		if local == nil {
+			status, err := helper.RemoteError(err)
+			apiError(ctx, status, err)
+			return
+		}
+		log.Warn("Serving local metadata of npm package %s: %v", packageName, err)
 	}
 
-	resp := createPackageMetadataResponse(
-		setting.AppURL+"api/packages/"+ctx.Package.Owner.Name+"/npm",
-		pds,
-	)
+	if local == nil {
+		apiError(ctx, http.StatusNotFound, nil)
+		return
+	}
 
-	ctx.JSON(http.StatusOK, resp)
+	ctx.JSON(http.StatusOK, local)
 }
 
 // DownloadPackageFile serves the content of a package
@@ -85,19 +109,25 @@ func DownloadPackageFile(ctx *context.Context) {
 	packageVersion := ctx.PathParam("version")
 	filename := ctx.PathParam("filename")
 
-	s, u, pf, err := packages_service.OpenFileForDownloadByPackageNameAndVersion(
-		ctx,
-		&packages_service.PackageInfo{
-			Owner:       ctx.Package.Owner,
-			PackageType: packages_model.TypeNpm,
-			Name:        packageName,
-			Version:     packageVersion,
-		},
-		&packages_service.PackageFileInfo{
-			Filename: filename,
-		},
-		ctx.Req.Method,
-	)
+	pvi := &packages_service.PackageInfo{
+		Owner:       ctx.Package.Owner,
+		PackageType: packages_model.TypeNpm,
+		Name:        packageName,
+		Version:     packageVersion,
+	}
+	pfi := &packages_service.PackageFileInfo{
+		Filename: filename,
+	}
+
+	s, u, pf, err := packages_service.OpenFileForDownloadByPackageNameAndVersion(ctx, pvi, pfi, ctx.Req.Method)
+	if err != nil && ctx.Package.Remote != nil && (errors.Is(err, packages_model.ErrPackageNotExist) || errors.Is(err, packages_model.ErrPackageFileNotExist)) {
+		if _, err = pullRemotePackageVersion(ctx, packageName, packageVersion, filename); err != nil {
+			status, err := helper.RemoteError(err)
+			apiError(ctx, status, err)
+			return
+		}
+		s, u, pf, err = packages_service.OpenFileForDownloadByPackageNameAndVersion(ctx, pvi, pfi, ctx.Req.Method)
+	}
 	if err != nil {
 		if errors.Is(err, packages_model.ErrPackageNotExist) || errors.Is(err, packages_model.ErrPackageFileNotExist) {
 			apiError(ctx, http.StatusNotFound, err)
@@ -198,7 +228,14 @@ func UploadPackage(ctx *context.Context) {
 	}
 	defer buf.Close()
 
-	pv, _, err := packages_service.CreatePackageAndAddFile(
+	if npmPackage.Provenance != nil {
+		if _, err := attestation_service.DetectFormat(packages_model.AttestationTypeProvenance, npmPackage.Provenance); err != nil {
+			apiError(ctx, http.StatusBadRequest, err)
+			return
+		}
+	}
+
+	pv, pf, err := packages_service.CreatePackageAndAddFile(
 		ctx,
 		&packages_service.PackageCreationInfo{
 			PackageInfo: packages_service.PackageInfo{
@@ -232,6 +269,13 @@ func UploadPackage(ctx *context.Context) {
 		return
 	}
 
+	if npmPackage.Provenance != nil {
+		if err := attestation_service.CreateForFile(ctx, ctx.Doer, pf, packages_model.AttestationTypeProvenance, pf.Name+".sigstore", npmPackage.Provenance); err != nil {
+			apiError(ctx, http.StatusInternalServerError, err)
+			return
+		}
+	}
+
 	for _, tag := range npmPackage.DistTags {
 		if err := setPackageTag(ctx, tag, pv, false); err != nil {
 			if err == errInvalidTagName {
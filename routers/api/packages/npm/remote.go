@@ -0,0 +1,130 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package npm
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	packages_model "gitea.dev/models/packages"
+	"gitea.dev/modules/json"
+	npm_module "gitea.dev/modules/packages/npm"
+	"gitea.dev/services/context"
+	packages_service "gitea.dev/services/packages"
+	remote_service "gitea.dev/services/packages/remote"
+)
+
+// fetchRemotePackageMetadata fetches the packument of the package from the upstream registry
+func fetchRemotePackageMetadata(ctx *context.Context, r *remote_service.Remote, packageName string) (*npm_module.PackageMetadata, error) {
+	data, err := r.FetchMetadata(ctx, packageName, http.Header{"Accept": []string{"application/json"}})
+	if err != nil {
+		return nil, err
+	}
+
+	metadata := &npm_module.PackageMetadata{}
+	if err := json.Unmarshal(data, metadata); err != nil {
+		return nil, err
+	}
+	return metadata, nil
+}
+
+// mergePackageMetadata combines the upstream packument with the local one.
+// Tarball URLs of upstream versions are rewritten to point to this registry, local versions and tags take precedence.
+func mergePackageMetadata(registryURL string, upstream, local *npm_module.PackageMetadata) *npm_module.PackageMetadata {
+	for v, pmv := range upstream.Versions {
+		if pmv == nil {
+			delete(upstream.Versions, v)
+			continue
+		}
+		pmv.Dist.Tarball = fmt.Sprintf("%s/%s/-/%s/%s", registryURL, url.QueryEscape(upstream.Name), url.PathEscape(v), url.PathEscape(npm_module.TarballFilename(upstream.Name, v)))
+	}
+
+	if local == nil {
+		return upstream
+	}
+
+	if upstream.Versions == nil {
+		upstream.Versions = make(map[string]*npm_module.PackageMetadataVersion, len(local.Versions))
+	}
+	for v, pmv := range local.Versions {
+		upstream.Versions[v] = pmv
+	}
+	if upstream.DistTags == nil {
+		upstream.DistTags = make(map[string]string, len(local.DistTags))
+	}
+	for tag, v := range local.DistTags {
+		upstream.DistTags[tag] = v
+	}
+	if upstream.Time == nil {
+		upstream.Time = make(map[string]time.Time, len(local.Time))
+	}
+	for v, t := range local.Time {
+		if _, has := upstream.Time[v]; has && (v == "created" || v == "modified") {
+			continue
+		}
+		upstream.Time[v] = t
+	}
+	return upstream
+}
+
+// pullRemotePackageVersion fetches the tarball of the package version from the upstream registry and stores it
+func pullRemotePackageVersion(ctx *context.Context, packageName, packageVersion, filename string) (*packages_model.PackageFile, error) {
+	r, err := remote_service.New(ctx.Package.Owner, ctx.Package.Remote)
+	if err != nil {
+		return nil, err
+	}
+
+	metadata, err := fetchRemotePackageMetadata(ctx, r, packageName)
+	if err != nil {
+		return nil, err
+	}
+
+	pmv, has := metadata.Versions[packageVersion]
+	if !has || pmv == nil || pmv.Dist.Tarball == "" || filename != npm_module.TarballFilename(packageName, packageVersion) {
+		return nil, packages_model.ErrPackageFileNotExist
+	}
+
+	buf, source, err := r.FetchFile(ctx, pmv.Dist.Tarball, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer buf.Close()
+
+	data, err := io.ReadAll(buf)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := buf.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+
+	npmPackage, err := npm_module.ParseRemotePackage(pmv, data)
+	if err != nil {
+		return nil, err
+	}
+
+	_, pf, err := r.StoreFile(
+		ctx,
+		&packages_service.PackageCreationInfo{
+			PackageInfo: packages_service.PackageInfo{
+				Name:    npmPackage.Name,
+				Version: npmPackage.Version,
+			},
+			SemverCompatible: true,
+			Metadata:         npmPackage.Metadata,
+		},
+		&packages_service.PackageFileCreationInfo{
+			PackageFileInfo: packages_service.PackageFileInfo{
+				Filename: npmPackage.Filename,
+			},
+			Data:   buf,
+			IsLead: true,
+		},
+		source,
+	)
+	return pf, err
+}
@@ -6,6 +6,7 @@ package pypi
 import (
 	"encoding/hex"
 	"errors"
+	"fmt"
 	"io"
 	"net/http"
 	"regexp"
@@ -14,13 +15,18 @@ import (
 	"unicode"
 
 	packages_model "gitea.dev/models/packages"
+	"gitea.dev/modules/json"
+	"gitea.dev/modules/log"
 	packages_module "gitea.dev/modules/packages"
 	pypi_module "gitea.dev/modules/packages/pypi"
 	"gitea.dev/modules/setting"
+	"gitea.dev/modules/util"
 	"gitea.dev/modules/validation"
 	"gitea.dev/routers/api/packages/helper"
 	"gitea.dev/services/context"
 	packages_service "gitea.dev/services/packages"
+	attestation_service "gitea.dev/services/packages/attestation"
+	remote_service "gitea.dev/services/packages/remote"
 )
 
 // https://peps.python.org/pep-0426/#name
@@ -53,10 +59,6 @@ func PackageMetadata(ctx *context.Context) {
 		apiError(ctx, http.StatusInternalServerError, err)
 		return
 	}
-	if len(pvs) == 0 {
-		apiError(ctx, http.StatusNotFound, err)
-		return
-	}
 
 	pds, err := packages_model.GetPackageDescriptors(ctx, pvs)
 	if err != nil {
@@ -69,9 +71,55 @@ func PackageMetadata(ctx *context.Context) {
 		return strings.Compare(pds[i].Version.Version, pds[j].Version.Version) < 0
 	})
 
+	links := make([]*simpleIndexLink, 0, len(pds))
+	for _, pd := range pds {
+		metadata := packages_model.DescriptorMetadata[*pypi_module.Metadata](pd)
+		for _, pfd := range pd.Files {
+			links = append(links, &simpleIndexLink{
+				PackageName:    pd.Package.LowerName,
+				Version:        pd.Version.Version,
+				Filename:       pfd.File.Name,
+				SHA256:         pfd.Blob.HashSHA256,
+				RequiresPython: metadata.RequiresPython,
+			})
+		}
+	}
+	displayName := packageName
+	if len(pds) > 0 {
+		displayName = pds[0].Package.Name
+	}
+
+	if ctx.Package.Remote != nil {
+		index, err := func() (*pypi_module.SimpleIndex, error) {
+			r, err := remote_service.New(ctx.Package.Owner, ctx.Package.Remote)
+			if err != nil {
+				return nil, err
+			}
+			index, _, err := fetchRemoteSimpleIndex(ctx, r, packageName)
+			return index, err
+		}()
+		if err == nil {
+			links = mergeSimpleIndexLinks(remoteSimpleIndexLinks(packageName, index), links)
+			if len(pds) == 0 && index.Name != "" {
+				displayName = index.Name
+			}
+		} else if len(links) == 0 {
+			status, err := helper.RemoteError(err)
+			apiError(ctx, status, err)
+			return
+		} else {
+			log.Warn("Serving local index of PyPI package %s: %v", packageName, err)
+		}
+	}
+
+	if len(links) == 0 {
+		apiError(ctx, http.StatusNotFound, nil)
+		return
+	}
+
 	ctx.Data["RegistryURL"] = setting.AppURL + "api/packages/" + ctx.Package.Owner.Name + "/pypi"
-	ctx.Data["PackageDescriptor"] = pds[0]
-	ctx.Data["PackageDescriptors"] = pds
+	ctx.Data["PackageName"] = displayName
+	ctx.Data["Links"] = links
 	ctx.HTML(http.StatusOK, "api/packages/pypi/simple")
 }
 
@@ -81,19 +129,25 @@ func DownloadPackageFile(ctx *context.Context) {
 	packageVersion := ctx.PathParam("version")
 	filename := ctx.PathParam("filename")
 
-	s, u, pf, err := packages_service.OpenFileForDownloadByPackageNameAndVersion(
-		ctx,
-		&packages_service.PackageInfo{
-			Owner:       ctx.Package.Owner,
-			PackageType: packages_model.TypePyPI,
-			Name:        packageName,
-			Version:     packageVersion,
-		},
-		&packages_service.PackageFileInfo{
-			Filename: filename,
-		},
-		ctx.Req.Method,
-	)
+	pvi := &packages_service.PackageInfo{
+		Owner:       ctx.Package.Owner,
+		PackageType: packages_model.TypePyPI,
+		Name:        packageName,
+		Version:     packageVersion,
+	}
+	pfi := &packages_service.PackageFileInfo{
+		Filename: filename,
+	}
+
+	s, u, pf, err := packages_service.OpenFileForDownloadByPackageNameAndVersion(ctx, pvi, pfi, ctx.Req.Method)
+	if err != nil && ctx.Package.Remote != nil && (errors.Is(err, packages_model.ErrPackageNotExist) || errors.Is(err, packages_model.ErrPackageFileNotExist)) {
+		if _, err = pullRemotePackageFile(ctx, packageName, packageVersion, filename); err != nil {
+			status, err := helper.RemoteError(err)
+			apiError(ctx, status, err)
+			return
+		}
+		s, u, pf, err = packages_service.OpenFileForDownloadByPackageNameAndVersion(ctx, pvi, pfi, ctx.Req.Method)
+	}
 	if err != nil {
 		if errors.Is(err, packages_model.ErrPackageNotExist) || errors.Is(err, packages_model.ErrPackageFileNotExist) {
 			apiError(ctx, http.StatusNotFound, err)
@@ -167,7 +221,17 @@ func UploadPackageFile(ctx *context.Context) {
 		homepageURL = ""
 	}
 
-	_, _, err = packages_service.CreatePackageOrAddFileToExisting(
+	signature, attestations, err := readAttestations(ctx)
+	if err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			apiError(ctx, http.StatusBadRequest, err)
+		} else {
+			apiError(ctx, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	_, pf, err := packages_service.CreatePackageOrAddFileToExisting(
 		ctx,
 		&packages_service.PackageCreationInfo{
 			PackageInfo: packages_service.PackageInfo{
@@ -209,9 +273,55 @@ func UploadPackageFile(ctx *context.Context) {
 		return
 	}
 
+	if signature != nil {
+		if err := attestation_service.CreateForFile(ctx, ctx.Doer, pf, packages_model.AttestationTypeSignature, pf.Name+".asc", signature); err != nil {
+			apiError(ctx, http.StatusInternalServerError, err)
+			return
+		}
+	}
+	for i, attestation := range attestations {
+		if err := attestation_service.CreateForFile(ctx, ctx.Doer, pf, packages_model.AttestationTypeProvenance, fmt.Sprintf("%s.attestation-%d.json", pf.Name, i), attestation); err != nil {
+			apiError(ctx, http.StatusInternalServerError, err)
+			return
+		}
+	}
+
 	ctx.Status(http.StatusCreated)
 }
 
+// readAttestations reads and validates the "gpg_signature" file and the PEP 740 "attestations" of the upload request
+func readAttestations(ctx *context.Context) ([]byte, [][]byte, error) {
+	var signature []byte
+	if file, _, err := ctx.Req.FormFile("gpg_signature"); err == nil {
+		defer file.Close()
+
+		if signature, err = util.ReadWithLimit(file, attestation_service.MaxSize); err != nil {
+			return nil, nil, err
+		}
+		if _, err := attestation_service.DetectFormat(packages_model.AttestationTypeSignature, signature); err != nil {
+			return nil, nil, err
+		}
+	} else if !errors.Is(err, http.ErrMissingFile) {
+		return nil, nil, err
+	}
+
+	var attestations [][]byte
+	if value := ctx.Req.FormValue("attestations"); value != "" {
+		var raw []json.Value
+		if err := json.Unmarshal([]byte(value), &raw); err != nil {
+			return nil, nil, util.NewInvalidArgumentErrorf("attestations are invalid")
+		}
+		for _, a := range raw {
+			if _, err := attestation_service.DetectFormat(packages_model.AttestationTypeProvenance, a); err != nil {
+				return nil, nil, err
+			}
+			attestations = append(attestations, a)
+		}
+	}
+
+	return signature, attestations, nil
+}
+
 // Normalizes a Project-URL label.
 // See https://packaging.python.org/en/latest/specifications/well-known-project-urls/#label-normalization.
 func normalizeLabel(label string) string {
@@ -0,0 +1,170 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package pypi
+
+import (
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	packages_model "gitea.dev/models/packages"
+	"gitea.dev/modules/json"
+	pypi_module "gitea.dev/modules/packages/pypi"
+	"gitea.dev/modules/util"
+	"gitea.dev/services/context"
+	packages_service "gitea.dev/services/packages"
+	remote_service "gitea.dev/services/packages/remote"
+)
+
+var errHashMismatch = util.NewInvalidArgumentErrorf("hash mismatch")
+
+// simpleIndexLink is a file entry rendered on the project page
+type simpleIndexLink struct {
+	PackageName    string
+	Version        string
+	Filename       string
+	SHA256         string
+	RequiresPython string
+}
+
+// fetchRemoteSimpleIndex fetches the project page of the package from the upstream index.
+// The remote URL is the root of the upstream simple index, for example https://pypi.org/simple
+func fetchRemoteSimpleIndex(ctx *context.Context, r *remote_service.Remote, packageName string) (*pypi_module.SimpleIndex, string, error) {
+	indexURL := r.Client.ResolveURL(url.PathEscape(packageName) + "/")
+
+	data, err := r.FetchMetadata(ctx, indexURL, http.Header{"Accept": []string{pypi_module.SimpleIndexContentType}})
+	if err != nil {
+		return nil, "", err
+	}
+
+	index := &pypi_module.SimpleIndex{}
+	if err := json.Unmarshal(data, index); err != nil {
+		return nil, "", err
+	}
+	return index, indexURL, nil
+}
+
+// remoteSimpleIndexLinks converts the upstream files to links served by this registry.
+// Files without a SHA256 hash or with an unparsable version are skipped as they could not be verified or addressed.
+func remoteSimpleIndexLinks(packageName string, index *pypi_module.SimpleIndex) []*simpleIndexLink {
+	links := make([]*simpleIndexLink, 0, len(index.Files))
+	for _, f := range index.Files {
+		if f == nil || f.Hashes["sha256"] == "" {
+			continue
+		}
+		version, err := pypi_module.ParseVersionFromFilename(packageName, f.Filename)
+		if err != nil {
+			continue
+		}
+		links = append(links, &simpleIndexLink{
+			PackageName:    packageName,
+			Version:        version,
+			Filename:       f.Filename,
+			SHA256:         strings.ToLower(f.Hashes["sha256"]),
+			RequiresPython: f.RequiresPython,
+		})
+	}
+	return links
+}
+
+// mergeSimpleIndexLinks combines upstream and local links, local files take precedence
+func mergeSimpleIndexLinks(upstream, local []*simpleIndexLink) []*simpleIndexLink {
+	localByName := make(map[string]*simpleIndexLink, len(local))
+	for _, l := range local {
+		localByName[strings.ToLower(l.Filename)] = l
+	}
+
+	merged := make([]*simpleIndexLink, 0, len(upstream)+len(local))
+	for _, l := range upstream {
+		if ll, has := localByName[strings.ToLower(l.Filename)]; has {
+			merged = append(merged, ll)
+			delete(localByName, strings.ToLower(l.Filename))
+			continue
+		}
+		merged = append(merged, l)
+	}
+	for _, l := range local {
+		if _, has := localByName[strings.ToLower(l.Filename)]; has {
+			merged = append(merged, l)
+		}
+	}
+	return merged
+}
+
+// pullRemotePackageFile fetches the distribution file from the upstream index and stores it
+func pullRemotePackageFile(ctx *context.Context, packageName, packageVersion, filename string) (*packages_model.PackageFile, error) {
+	r, err := remote_service.New(ctx.Package.Owner, ctx.Package.Remote)
+	if err != nil {
+		return nil, err
+	}
+
+	index, indexURL, err := fetchRemoteSimpleIndex(ctx, r, packageName)
+	if err != nil {
+		return nil, err
+	}
+
+	var file *pypi_module.SimpleIndexFile
+	for _, f := range index.Files {
+		if f != nil && strings.EqualFold(f.Filename, filename) {
+			file = f
+			break
+		}
+	}
+	if file == nil || file.Hashes["sha256"] == "" {
+		return nil, packages_model.ErrPackageFileNotExist
+	}
+	if version, err := pypi_module.ParseVersionFromFilename(packageName, file.Filename); err != nil || version != packageVersion {
+		return nil, packages_model.ErrPackageFileNotExist
+	}
+
+	base, err := url.Parse(indexURL)
+	if err != nil {
+		return nil, err
+	}
+	ref, err := url.Parse(file.URL)
+	if err != nil {
+		return nil, err
+	}
+	// the hash fragment is not part of the request
+	ref.Fragment = ""
+
+	buf, source, err := r.FetchFile(ctx, base.ResolveReference(ref).String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer buf.Close()
+
+	_, _, hashSHA256, _ := buf.Sums()
+	if !strings.EqualFold(file.Hashes["sha256"], hex.EncodeToString(hashSHA256)) {
+		return nil, errHashMismatch
+	}
+	if _, err := buf.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+
+	_, pf, err := r.StoreFile(
+		ctx,
+		&packages_service.PackageCreationInfo{
+			PackageInfo: packages_service.PackageInfo{
+				Name:    packageName,
+				Version: packageVersion,
+			},
+			SemverCompatible: false,
+			Metadata: &pypi_module.Metadata{
+				RequiresPython: file.RequiresPython,
+			},
+		},
+		&packages_service.PackageFileCreationInfo{
+			PackageFileInfo: packages_service.PackageFileInfo{
+				Filename: file.Filename,
+			},
+			Data:   buf,
+			IsLead: true,
+		},
+		source,
+	)
+	return pf, err
+}
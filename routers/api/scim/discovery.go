@@ -0,0 +1,135 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package scim
+
+import (
+	"net/http"
+
+	scim_module "gitea.dev/modules/scim"
+	scim_service "gitea.dev/services/scim"
+)
+
+type supported struct {
+	Supported bool `json:"supported"`
+}
+
+// ServiceProviderConfig returns the features of the service provider, RFC 7643 section 5
+func ServiceProviderConfig(ctx *SCIMContext) {
+	ctx.JSON(http.StatusOK, map[string]any{
+		"schemas":        []string{scim_module.SchemaServiceProviderConfig},
+		"patch":          supported{true},
+		"bulk":           map[string]any{"supported": false, "maxOperations": 0, "maxPayloadSize": 0},
+		"filter":         map[string]any{"supported": true, "maxResults": maxCount},
+		"changePassword": supported{false},
+		"sort":           supported{false},
+		"etag":           supported{false},
+		"authenticationSchemes": []map[string]any{{
+			"type":        "oauthbearertoken",
+			"name":        "OAuth Bearer Token",
+			"description": "Authentication with the SCIM token of the authentication source",
+			"primary":     true,
+		}},
+		"meta": scim_module.Meta{
+			ResourceType: "ServiceProviderConfig",
+			Location:     scim_service.EndpointURL("ServiceProviderConfig"),
+		},
+	})
+}
+
+func resourceTypes() []map[string]any {
+	return []map[string]any{
+		{
+			"schemas":          []string{scim_module.SchemaResourceType},
+			"id":               "User",
+			"name":             "User",
+			"endpoint":         "/Users",
+			"schema":           scim_module.SchemaUser,
+			"schemaExtensions": []any{},
+			"meta":             scim_module.Meta{ResourceType: "ResourceType", Location: scim_service.EndpointURL("ResourceTypes/User")},
+		},
+		{
+			"schemas":          []string{scim_module.SchemaResourceType},
+			"id":               "Group",
+			"name":             "Group",
+			"endpoint":         "/Groups",
+			"schema":           scim_module.SchemaGroup,
+			"schemaExtensions": []any{},
+			"meta":             scim_module.Meta{ResourceType: "ResourceType", Location: scim_service.EndpointURL("ResourceTypes/Group")},
+		},
+	}
+}
+
+// ResourceTypes returns the resource types of the service provider
+func ResourceTypes(ctx *SCIMContext) {
+	types := resourceTypes()
+	ctx.JSON(http.StatusOK, scim_module.NewListResponse(types, 1, len(types)))
+}
+
+func attribute(name, typ string, multiValued, required bool, mutability, uniqueness string, subAttributes ...map[string]any) map[string]any {
+	attr := map[string]any{
+		"name":        name,
+		"type":        typ,
+		"multiValued": multiValued,
+		"required":    required,
+		"caseExact":   false,
+		"mutability":  mutability,
+		"returned":    "default",
+		"uniqueness":  uniqueness,
+	}
+	if len(subAttributes) > 0 {
+		attr["subAttributes"] = subAttributes
+	}
+	return attr
+}
+
+func schemas() []map[string]any {
+	multiValue := func(name, mutability string) map[string]any {
+		return attribute(name, "complex", true, false, mutability, "none",
+			attribute("value", "string", false, false, mutability, "none"),
+			attribute("display", "string", false, false, "readOnly", "none"),
+			attribute("type", "string", false, false, mutability, "none"),
+			attribute("primary", "boolean", false, false, mutability, "none"),
+			attribute("$ref", "reference", false, false, "readOnly", "none"),
+		)
+	}
+	return []map[string]any{
+		{
+			"schemas":     []string{scim_module.SchemaSchema},
+			"id":          scim_module.SchemaUser,
+			"name":        "User",
+			"description": "User Account",
+			"attributes": []map[string]any{
+				attribute("userName", "string", false, true, "readWrite", "server"),
+				attribute("name", "complex", false, false, "readWrite", "none",
+					attribute("formatted", "string", false, false, "readWrite", "none"),
+					attribute("familyName", "string", false, false, "readWrite", "none"),
+					attribute("givenName", "string", false, false, "readWrite", "none"),
+					attribute("middleName", "string", false, false, "readWrite", "none"),
+				),
+				attribute("displayName", "string", false, false, "readWrite", "none"),
+				attribute("active", "boolean", false, false, "readWrite", "none"),
+				multiValue("emails", "readWrite"),
+				multiValue("groups", "readOnly"),
+			},
+			"meta": scim_module.Meta{ResourceType: "Schema", Location: scim_service.EndpointURL("Schemas/" + scim_module.SchemaUser)},
+		},
+		{
+			"schemas":     []string{scim_module.SchemaSchema},
+			"id":          scim_module.SchemaGroup,
+			"name":        "Group",
+			"description": "Group",
+			"attributes": []map[string]any{
+				attribute("displayName", "string", false, true, "readWrite", "server"),
+				multiValue("members", "readWrite"),
+			},
+			"meta": scim_module.Meta{ResourceType: "Schema", Location: scim_service.EndpointURL("Schemas/" + scim_module.SchemaGroup)},
+		},
+	}
+}
+
+// Schemas returns the schemas of the resources of the service provider
+func Schemas(ctx *SCIMContext) {
+	list := schemas()
+	ctx.JSON(http.StatusOK, scim_module.NewListResponse(list, 1, len(list)))
+}
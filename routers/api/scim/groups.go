@@ -0,0 +1,88 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package scim
+
+import (
+	"net/http"
+
+	scim_module "gitea.dev/modules/scim"
+	scim_service "gitea.dev/services/scim"
+)
+
+// ListGroups lists the groups of the source matching the filter
+func ListGroups(ctx *SCIMContext) {
+	filter, ok := ctx.parseFilter()
+	if !ok {
+		return
+	}
+	groups, err := scim_service.ListGroups(ctx, ctx.Source, filter, !ctx.isExcluded("members"))
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	startIndex, count := ctx.page()
+	ctx.JSON(http.StatusOK, scim_module.NewListResponse(groups, startIndex, count))
+}
+
+// GetGroup returns a group of the source
+func GetGroup(ctx *SCIMContext) {
+	group, err := scim_service.GetGroup(ctx, ctx.Source, ctx.PathParam("id"), !ctx.isExcluded("members"))
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, group)
+}
+
+// CreateGroup creates a group of the source
+func CreateGroup(ctx *SCIMContext) {
+	in := new(scim_module.Group)
+	if !ctx.decodeBody(in) {
+		return
+	}
+	group, err := scim_service.CreateGroup(ctx, ctx.Source, in)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	ctx.RespHeader().Set("Location", group.Meta.Location)
+	ctx.JSON(http.StatusCreated, group)
+}
+
+// ReplaceGroup replaces a group of the source
+func ReplaceGroup(ctx *SCIMContext) {
+	in := new(scim_module.Group)
+	if !ctx.decodeBody(in) {
+		return
+	}
+	group, err := scim_service.ReplaceGroup(ctx, ctx.Source, ctx.PathParam("id"), in)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, group)
+}
+
+// PatchGroup applies PATCH operations to a group of the source
+func PatchGroup(ctx *SCIMContext) {
+	req := new(scim_module.PatchRequest)
+	if !ctx.decodeBody(req) {
+		return
+	}
+	group, err := scim_service.PatchGroup(ctx, ctx.Source, ctx.PathParam("id"), req.Operations)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, group)
+}
+
+// DeleteGroup deletes a group of the source
+func DeleteGroup(ctx *SCIMContext) {
+	if err := scim_service.DeleteGroup(ctx, ctx.Source, ctx.PathParam("id")); err != nil {
+		ctx.Error(err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
@@ -0,0 +1,193 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+// Package scim serves the SCIM 2.0 service provider an identity provider provisions
+// the users and the groups of an authentication source with.
+package scim
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	auth_model "gitea.dev/models/auth"
+	"gitea.dev/modules/json"
+	"gitea.dev/modules/log"
+	"gitea.dev/modules/reqctx"
+	scim_module "gitea.dev/modules/scim"
+	"gitea.dev/modules/util"
+	"gitea.dev/modules/web"
+	web_types "gitea.dev/modules/web/types"
+	"gitea.dev/services/context"
+)
+
+const (
+	defaultCount = 100
+	maxCount     = 1000
+)
+
+type scimContextKeyType struct{}
+
+var scimContextKey = scimContextKeyType{}
+
+// SCIMContext is the context of a SCIM request, authenticated by the SCIM token of its source
+type SCIMContext struct {
+	*context.Base
+
+	Source *auth_model.Source
+}
+
+func init() {
+	web.RegisterResponseStatusProvider[*SCIMContext](func(req *http.Request) web_types.ResponseStatusProvider {
+		return reqctx.MustContextValue[*SCIMContext](req.Context(), scimContextKey)
+	})
+}
+
+// Routes returns the SCIM 2.0 routes
+func Routes() *web.Router {
+	m := web.NewRouter()
+	m.AfterRouting(SCIMContexter())
+
+	m.Get("/ServiceProviderConfig", ServiceProviderConfig)
+	m.Get("/ResourceTypes", ResourceTypes)
+	m.Get("/Schemas", Schemas)
+
+	m.Group("/Users", func() {
+		m.Combo("").Get(ListUsers).Post(CreateUser)
+		m.Combo("/{id}").Get(GetUser).Put(ReplaceUser).Patch(PatchUser).Delete(DeleteUser)
+	})
+	m.Group("/Groups", func() {
+		m.Combo("").Get(ListGroups).Post(CreateGroup)
+		m.Combo("/{id}").Get(GetGroup).Put(ReplaceGroup).Patch(PatchGroup).Delete(DeleteGroup)
+	})
+
+	m.NotFound(func(resp http.ResponseWriter, req *http.Request) {
+		writeError(resp, scim_module.NewError(http.StatusNotFound, "", "endpoint not found"))
+	})
+	return m
+}
+
+// SCIMContexter authenticates the requests with the SCIM token of a source
+func SCIMContexter() func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+			base := context.NewBaseContext(resp, req)
+
+			ctx := &SCIMContext{Base: base}
+			ctx.SetContextValue(scimContextKey, ctx)
+
+			authHeader := req.Header.Get("Authorization")
+			token, ok := strings.CutPrefix(authHeader, "Bearer ")
+			if !ok || token == "" {
+				ctx.Error(scim_module.NewError(http.StatusUnauthorized, "", "bad authorization header"))
+				return
+			}
+			scimToken, err := auth_model.GetSCIMTokenBySHA(req.Context(), token)
+			if err != nil {
+				if errors.Is(err, util.ErrNotExist) {
+					ctx.Error(scim_module.NewError(http.StatusUnauthorized, "", "invalid token"))
+				} else {
+					ctx.Error(err)
+				}
+				return
+			}
+			source, err := auth_model.GetSourceByID(req.Context(), scimToken.SourceID)
+			if err != nil {
+				ctx.Error(err)
+				return
+			}
+			if !source.IsActive {
+				ctx.Error(scim_module.NewError(http.StatusUnauthorized, "", "the authentication source is not active"))
+				return
+			}
+
+			ctx.Source = source
+			next.ServeHTTP(ctx.Resp, ctx.Req)
+		})
+	}
+}
+
+func writeJSON(resp http.ResponseWriter, status int, content any) {
+	resp.Header().Set("Content-Type", scim_module.ContentType+";charset=utf-8")
+	resp.WriteHeader(status)
+	if err := json.MarshalWrite(resp, content); err != nil {
+		log.Error("Render SCIM response failed: %v", err)
+	}
+}
+
+func writeError(resp http.ResponseWriter, err *scim_module.Error) {
+	writeJSON(resp, err.Status, err.Response())
+}
+
+// JSON renders the content as a SCIM response
+func (ctx *SCIMContext) JSON(status int, content any) {
+	writeJSON(ctx.Resp, status, content)
+}
+
+// Error renders the error as a SCIM error response
+func (ctx *SCIMContext) Error(err error) {
+	var scimErr *scim_module.Error
+	switch {
+	case errors.As(err, &scimErr):
+	case errors.Is(err, util.ErrNotExist):
+		scimErr = scim_module.NewError(http.StatusNotFound, "", "resource not found")
+	case errors.Is(err, util.ErrAlreadyExist):
+		scimErr = scim_module.NewError(http.StatusConflict, scim_module.ErrorUniqueness, "%v", err)
+	case errors.Is(err, util.ErrInvalidArgument):
+		scimErr = scim_module.NewError(http.StatusBadRequest, scim_module.ErrorInvalidValue, "%v", err)
+	default:
+		log.Error("SCIM[%s]: %s %s: %v", ctx.sourceName(), ctx.Req.Method, ctx.Req.URL.Path, err)
+		scimErr = scim_module.NewError(http.StatusInternalServerError, "", "internal server error")
+	}
+	writeError(ctx.Resp, scimErr)
+}
+
+func (ctx *SCIMContext) sourceName() string {
+	if ctx.Source == nil {
+		return ""
+	}
+	return ctx.Source.Name
+}
+
+// decodeBody decodes the body of the request into v
+func (ctx *SCIMContext) decodeBody(v any) bool {
+	if err := json.NewDecoder(ctx.Req.Body).Decode(v); err != nil {
+		ctx.Error(scim_module.NewError(http.StatusBadRequest, scim_module.ErrorInvalidSyntax, "invalid request body: %v", err))
+		return false
+	}
+	return true
+}
+
+// parseFilter parses the filter of a query, a missing filter is nil
+func (ctx *SCIMContext) parseFilter() (scim_module.Filter, bool) {
+	filter := ctx.FormString("filter")
+	if filter == "" {
+		return nil, true
+	}
+	f, err := scim_module.ParseFilter(filter)
+	if err != nil {
+		ctx.Error(err)
+		return nil, false
+	}
+	return f, true
+}
+
+// page returns the 1-based start index and the count of a query
+func (ctx *SCIMContext) page() (startIndex, count int) {
+	startIndex = max(ctx.FormInt("startIndex"), 1)
+	count = defaultCount
+	if ctx.FormString("count") != "" {
+		count = min(max(ctx.FormInt("count"), 0), maxCount)
+	}
+	return startIndex, count
+}
+
+// isExcluded returns whether the attribute is listed in the excludedAttributes of the request
+func (ctx *SCIMContext) isExcluded(attr string) bool {
+	for excluded := range strings.SplitSeq(ctx.FormString("excludedAttributes"), ",") {
+		if strings.EqualFold(strings.TrimSpace(excluded), attr) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,88 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package scim
+
+import (
+	"net/http"
+
+	scim_module "gitea.dev/modules/scim"
+	scim_service "gitea.dev/services/scim"
+)
+
+// ListUsers lists the users of the source matching the filter
+func ListUsers(ctx *SCIMContext) {
+	filter, ok := ctx.parseFilter()
+	if !ok {
+		return
+	}
+	users, err := scim_service.ListUsers(ctx, ctx.Source, filter)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	startIndex, count := ctx.page()
+	ctx.JSON(http.StatusOK, scim_module.NewListResponse(users, startIndex, count))
+}
+
+// GetUser returns a user of the source
+func GetUser(ctx *SCIMContext) {
+	user, err := scim_service.GetUser(ctx, ctx.Source, ctx.PathParam("id"))
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, user)
+}
+
+// CreateUser creates a user of the source
+func CreateUser(ctx *SCIMContext) {
+	in := new(scim_module.User)
+	if !ctx.decodeBody(in) {
+		return
+	}
+	user, err := scim_service.CreateUser(ctx, ctx.Source, in)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	ctx.RespHeader().Set("Location", user.Meta.Location)
+	ctx.JSON(http.StatusCreated, user)
+}
+
+// ReplaceUser replaces a user of the source
+func ReplaceUser(ctx *SCIMContext) {
+	in := new(scim_module.User)
+	if !ctx.decodeBody(in) {
+		return
+	}
+	user, err := scim_service.ReplaceUser(ctx, ctx.Source, ctx.PathParam("id"), in)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, user)
+}
+
+// PatchUser applies PATCH operations to a user of the source
+func PatchUser(ctx *SCIMContext) {
+	req := new(scim_module.PatchRequest)
+	if !ctx.decodeBody(req) {
+		return
+	}
+	user, err := scim_service.PatchUser(ctx, ctx.Source, ctx.PathParam("id"), req.Operations)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, user)
+}
+
+// DeleteUser deletes a user of the source
+func DeleteUser(ctx *SCIMContext) {
+	if err := scim_service.DeleteUser(ctx, ctx.Source, ctx.PathParam("id")); err != nil {
+		ctx.Error(err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
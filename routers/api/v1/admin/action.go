@@ -101,3 +101,57 @@ func ListWorkflowRuns(ctx *context.APIContext) {
 
 	shared.ListRuns(ctx, 0, 0, "")
 }
+
+// ListActionCaches lists the actions caches of all repositories
+func ListActionCaches(ctx *context.APIContext) {
+	// swagger:operation GET /admin/actions/caches admin listAdminActionCaches
+	// ---
+	// summary: Lists the actions caches of all repositories
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: key
+	//   in: query
+	//   description: key of the caches
+	//   type: string
+	// - name: ref
+	//   in: query
+	//   description: full git reference the caches are scoped to, e.g. refs/heads/main
+	//   type: string
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ActionCacheList"
+
+	shared.ListCaches(ctx, 0)
+}
+
+// DeleteActionCache deletes an actions cache of any repository
+func DeleteActionCache(ctx *context.APIContext) {
+	// swagger:operation DELETE /admin/actions/caches/{cache_id} admin deleteAdminActionCache
+	// ---
+	// summary: Deletes an actions cache
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: cache_id
+	//   in: path
+	//   description: id of the cache
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "204":
+	//     description: "No Content"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	shared.DeleteCache(ctx, 0, ctx.PathParamInt64("cache_id"))
+}
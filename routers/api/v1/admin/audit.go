@@ -0,0 +1,85 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package admin
+
+import (
+	"net/http"
+
+	audit_model "gitea.dev/models/audit"
+	"gitea.dev/routers/api/v1/shared"
+	"gitea.dev/services/context"
+	"gitea.dev/services/convert"
+)
+
+// ListAuditEvents lists the events of the audit log of the instance
+func ListAuditEvents(ctx *context.APIContext) {
+	// swagger:operation GET /admin/audit-log admin adminListAuditEvents
+	// ---
+	// summary: List the events of the audit log of the instance, from the most recent
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: action
+	//   in: query
+	//   description: only the events of this action, or of the actions starting with it followed by a dot, e.g. "team" or "access_token.create"
+	//   type: string
+	// - name: actor
+	//   in: query
+	//   description: only the events of the changes made by this user
+	//   type: string
+	// - name: since
+	//   in: query
+	//   description: only the events recorded since the specified time
+	//   type: string
+	//   format: date-time
+	// - name: before
+	//   in: query
+	//   description: only the events recorded before the specified time
+	//   type: string
+	//   format: date-time
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/AuditEventList"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	shared.ListAuditEvents(ctx, 0, 0)
+}
+
+// VerifyAuditLog verifies the hash chain of the audit log of the instance
+func VerifyAuditLog(ctx *context.APIContext) {
+	// swagger:operation GET /admin/audit-log/verify admin adminVerifyAuditLog
+	// ---
+	// summary: Verify that no event of the audit log was altered or deleted, except the oldest ones deleted by the retention
+	// produces:
+	// - application/json
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/AuditLogVerification"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+
+	if err := audit_model.SealEvents(ctx); err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	v, err := audit_model.VerifyEvents(ctx)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, convert.ToAuditLogVerification(v))
+}
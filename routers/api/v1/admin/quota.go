@@ -0,0 +1,716 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package admin
+
+import (
+	"net/http"
+
+	quota_model "gitea.dev/models/quota"
+	user_model "gitea.dev/models/user"
+	api "gitea.dev/modules/structs"
+	"gitea.dev/modules/web"
+	"gitea.dev/routers/api/v1/shared"
+	"gitea.dev/routers/api/v1/utils"
+	"gitea.dev/services/context"
+	"gitea.dev/services/convert"
+	quota_service "gitea.dev/services/quota"
+)
+
+func toQuotaSubjects(subjects []string) []quota_model.Subject {
+	result := make([]quota_model.Subject, 0, len(subjects))
+	for _, s := range subjects {
+		result = append(result, quota_model.Subject(s))
+	}
+	return result
+}
+
+func getQuotaRuleByParams(ctx *context.APIContext) *quota_model.Rule {
+	r, err := quota_model.GetRuleByName(ctx, ctx.PathParam("quotarule"))
+	if err != nil {
+		ctx.APIErrorAuto(err)
+		return nil
+	}
+	return r
+}
+
+func getQuotaGroupByParams(ctx *context.APIContext) *quota_model.Group {
+	g, err := quota_model.GetGroupByName(ctx, ctx.PathParam("quotagroup"))
+	if err != nil {
+		ctx.APIErrorAuto(err)
+		return nil
+	}
+	return g
+}
+
+// ListQuotaRules lists the quota rules
+func ListQuotaRules(ctx *context.APIContext) {
+	// swagger:operation GET /admin/quota/rules admin adminListQuotaRules
+	// ---
+	// summary: List the quota rules
+	// produces:
+	// - application/json
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/QuotaRuleList"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+
+	rules, err := quota_model.GetRules(ctx)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, convert.ToQuotaRules(rules))
+}
+
+// CreateQuotaRule creates a quota rule
+func CreateQuotaRule(ctx *context.APIContext) {
+	// swagger:operation POST /admin/quota/rules admin adminCreateQuotaRule
+	// ---
+	// summary: Create a quota rule
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/CreateQuotaRuleOption"
+	// responses:
+	//   "201":
+	//     "$ref": "#/responses/QuotaRule"
+	//   "400":
+	//     "$ref": "#/responses/error"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "409":
+	//     "$ref": "#/responses/conflict"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	form := web.GetForm[*api.CreateQuotaRuleOption](ctx)
+
+	r := &quota_model.Rule{
+		Name:     form.Name,
+		Limit:    form.Limit,
+		Subjects: toQuotaSubjects(form.Subjects),
+	}
+	if err := quota_model.CreateRule(ctx, r); err != nil {
+		ctx.APIErrorAuto(err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, convert.ToQuotaRule(r))
+}
+
+// GetQuotaRule gets a quota rule
+func GetQuotaRule(ctx *context.APIContext) {
+	// swagger:operation GET /admin/quota/rules/{quotarule} admin adminGetQuotaRule
+	// ---
+	// summary: Get a quota rule
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: quotarule
+	//   in: path
+	//   description: name of the rule
+	//   type: string
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/QuotaRule"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	r := getQuotaRuleByParams(ctx)
+	if ctx.Written() {
+		return
+	}
+	ctx.JSON(http.StatusOK, convert.ToQuotaRule(r))
+}
+
+// EditQuotaRule edits a quota rule
+func EditQuotaRule(ctx *context.APIContext) {
+	// swagger:operation PATCH /admin/quota/rules/{quotarule} admin adminEditQuotaRule
+	// ---
+	// summary: Edit a quota rule. Only fields that are set will be changed
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: quotarule
+	//   in: path
+	//   description: name of the rule
+	//   type: string
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/EditQuotaRuleOption"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/QuotaRule"
+	//   "400":
+	//     "$ref": "#/responses/error"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	form := web.GetForm[*api.EditQuotaRuleOption](ctx)
+
+	r := getQuotaRuleByParams(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	if form.Limit != nil {
+		r.Limit = *form.Limit
+	}
+	if form.Subjects != nil {
+		r.Subjects = toQuotaSubjects(*form.Subjects)
+	}
+	if err := quota_model.UpdateRule(ctx, r); err != nil {
+		ctx.APIErrorAuto(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, convert.ToQuotaRule(r))
+}
+
+// DeleteQuotaRule deletes a quota rule
+func DeleteQuotaRule(ctx *context.APIContext) {
+	// swagger:operation DELETE /admin/quota/rules/{quotarule} admin adminDeleteQuotaRule
+	// ---
+	// summary: Delete a quota rule, it is removed from all groups, users and organizations
+	// parameters:
+	// - name: quotarule
+	//   in: path
+	//   description: name of the rule
+	//   type: string
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	r := getQuotaRuleByParams(ctx)
+	if ctx.Written() {
+		return
+	}
+	if err := quota_model.DeleteRule(ctx, r.ID); err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+// ListQuotaGroups lists the quota groups
+func ListQuotaGroups(ctx *context.APIContext) {
+	// swagger:operation GET /admin/quota/groups admin adminListQuotaGroups
+	// ---
+	// summary: List the quota groups with their rules
+	// produces:
+	// - application/json
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/QuotaGroupList"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+
+	groups, err := quota_model.GetGroups(ctx)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	res := make([]*api.QuotaGroup, 0, len(groups))
+	for _, g := range groups {
+		gr, err := quota_service.GetGroupRules(ctx, g)
+		if err != nil {
+			ctx.APIErrorInternal(err)
+			return
+		}
+		res = append(res, convert.ToQuotaGroup(gr))
+	}
+	ctx.JSON(http.StatusOK, res)
+}
+
+// CreateQuotaGroup creates a quota group
+func CreateQuotaGroup(ctx *context.APIContext) {
+	// swagger:operation POST /admin/quota/groups admin adminCreateQuotaGroup
+	// ---
+	// summary: Create a quota group
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/CreateQuotaGroupOption"
+	// responses:
+	//   "201":
+	//     "$ref": "#/responses/QuotaGroup"
+	//   "400":
+	//     "$ref": "#/responses/error"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "409":
+	//     "$ref": "#/responses/conflict"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	form := web.GetForm[*api.CreateQuotaGroupOption](ctx)
+
+	g := &quota_model.Group{Name: form.Name}
+	if err := quota_model.CreateGroup(ctx, g); err != nil {
+		ctx.APIErrorAuto(err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, convert.ToQuotaGroup(&quota_service.GroupRules{Group: g}))
+}
+
+// GetQuotaGroup gets a quota group
+func GetQuotaGroup(ctx *context.APIContext) {
+	// swagger:operation GET /admin/quota/groups/{quotagroup} admin adminGetQuotaGroup
+	// ---
+	// summary: Get a quota group with its rules
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: quotagroup
+	//   in: path
+	//   description: name of the group
+	//   type: string
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/QuotaGroup"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	g := getQuotaGroupByParams(ctx)
+	if ctx.Written() {
+		return
+	}
+	gr, err := quota_service.GetGroupRules(ctx, g)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, convert.ToQuotaGroup(gr))
+}
+
+// DeleteQuotaGroup deletes a quota group
+func DeleteQuotaGroup(ctx *context.APIContext) {
+	// swagger:operation DELETE /admin/quota/groups/{quotagroup} admin adminDeleteQuotaGroup
+	// ---
+	// summary: Delete a quota group, its rules are kept
+	// parameters:
+	// - name: quotagroup
+	//   in: path
+	//   description: name of the group
+	//   type: string
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	g := getQuotaGroupByParams(ctx)
+	if ctx.Written() {
+		return
+	}
+	if err := quota_model.DeleteGroup(ctx, g.ID); err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+// AddRuleToQuotaGroup adds a rule to a quota group
+func AddRuleToQuotaGroup(ctx *context.APIContext) {
+	// swagger:operation PUT /admin/quota/groups/{quotagroup}/rules/{quotarule} admin adminAddRuleToQuotaGroup
+	// ---
+	// summary: Add a rule to a quota group
+	// parameters:
+	// - name: quotagroup
+	//   in: path
+	//   description: name of the group
+	//   type: string
+	//   required: true
+	// - name: quotarule
+	//   in: path
+	//   description: name of the rule
+	//   type: string
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "409":
+	//     "$ref": "#/responses/conflict"
+
+	g := getQuotaGroupByParams(ctx)
+	if ctx.Written() {
+		return
+	}
+	r := getQuotaRuleByParams(ctx)
+	if ctx.Written() {
+		return
+	}
+	if err := quota_model.AddRuleToGroup(ctx, g.ID, r.ID); err != nil {
+		ctx.APIErrorAuto(err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+// RemoveRuleFromQuotaGroup removes a rule from a quota group
+func RemoveRuleFromQuotaGroup(ctx *context.APIContext) {
+	// swagger:operation DELETE /admin/quota/groups/{quotagroup}/rules/{quotarule} admin adminRemoveRuleFromQuotaGroup
+	// ---
+	// summary: Remove a rule from a quota group
+	// parameters:
+	// - name: quotagroup
+	//   in: path
+	//   description: name of the group
+	//   type: string
+	//   required: true
+	// - name: quotarule
+	//   in: path
+	//   description: name of the rule
+	//   type: string
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	g := getQuotaGroupByParams(ctx)
+	if ctx.Written() {
+		return
+	}
+	r := getQuotaRuleByParams(ctx)
+	if ctx.Written() {
+		return
+	}
+	if err := quota_model.RemoveRuleFromGroup(ctx, g.ID, r.ID); err != nil {
+		ctx.APIErrorAuto(err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+// ListQuotaGroupUsers lists the users and organizations of a quota group
+func ListQuotaGroupUsers(ctx *context.APIContext) {
+	// swagger:operation GET /admin/quota/groups/{quotagroup}/users admin adminListQuotaGroupUsers
+	// ---
+	// summary: List the users and organizations of a quota group
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: quotagroup
+	//   in: path
+	//   description: name of the group
+	//   type: string
+	//   required: true
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/UserList"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	g := getQuotaGroupByParams(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	listOptions := utils.GetListOptions(ctx)
+	ids, total, err := quota_model.GetGroupUserIDs(ctx, g.ID, listOptions)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	users, err := user_model.GetUsersByIDs(ctx, ids)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	res := make([]*api.User, 0, len(users))
+	for _, u := range users {
+		res = append(res, convert.ToUser(ctx, u, ctx.Doer))
+	}
+	ctx.SetLinkHeader(total, listOptions.PageSize)
+	ctx.SetTotalCountHeader(total)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func getQuotaGroupAndUser(ctx *context.APIContext) (*quota_model.Group, *user_model.User) {
+	g := getQuotaGroupByParams(ctx)
+	if ctx.Written() {
+		return nil, nil
+	}
+	u, err := user_model.GetUserByName(ctx, ctx.PathParam("username"))
+	if err != nil {
+		ctx.APIErrorAuto(err)
+		return nil, nil
+	}
+	return g, u
+}
+
+// AddUserToQuotaGroup adds a user or an organization to a quota group
+func AddUserToQuotaGroup(ctx *context.APIContext) {
+	// swagger:operation PUT /admin/quota/groups/{quotagroup}/users/{username} admin adminAddUserToQuotaGroup
+	// ---
+	// summary: Add a user or an organization to a quota group
+	// parameters:
+	// - name: quotagroup
+	//   in: path
+	//   description: name of the group
+	//   type: string
+	//   required: true
+	// - name: username
+	//   in: path
+	//   description: name of the user or organization
+	//   type: string
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "409":
+	//     "$ref": "#/responses/conflict"
+
+	g, u := getQuotaGroupAndUser(ctx)
+	if ctx.Written() {
+		return
+	}
+	if err := quota_model.AddUserToGroup(ctx, g.ID, u.ID); err != nil {
+		ctx.APIErrorAuto(err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+// RemoveUserFromQuotaGroup removes a user or an organization from a quota group
+func RemoveUserFromQuotaGroup(ctx *context.APIContext) {
+	// swagger:operation DELETE /admin/quota/groups/{quotagroup}/users/{username} admin adminRemoveUserFromQuotaGroup
+	// ---
+	// summary: Remove a user or an organization from a quota group
+	// parameters:
+	// - name: quotagroup
+	//   in: path
+	//   description: name of the group
+	//   type: string
+	//   required: true
+	// - name: username
+	//   in: path
+	//   description: name of the user or organization
+	//   type: string
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	g, u := getQuotaGroupAndUser(ctx)
+	if ctx.Written() {
+		return
+	}
+	if err := quota_model.RemoveUserFromGroup(ctx, g.ID, u.ID); err != nil {
+		ctx.APIErrorAuto(err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+// ListQuotaUsages lists the storage usage of the users and organizations
+func ListQuotaUsages(ctx *context.APIContext) {
+	// swagger:operation GET /admin/quota/usage admin adminListQuotaUsages
+	// ---
+	// summary: List the storage usage of the users and organizations, the largest first
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/QuotaUsageList"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+
+	opts := quota_model.FindUsagesOptions{ListOptions: utils.GetListOptions(ctx)}
+	usages, total, err := quota_model.FindUsages(ctx, opts)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	userIDs := make([]int64, 0, len(usages))
+	for _, u := range usages {
+		userIDs = append(userIDs, u.UserID)
+	}
+	users, err := user_model.GetUsersMapByIDs(ctx, userIDs)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	res := make([]*api.QuotaUsage, 0, len(usages))
+	for _, u := range usages {
+		owner, ok := users[u.UserID]
+		if !ok {
+			// the owner has been deleted, the usage is removed by the next recalculation
+			continue
+		}
+		res = append(res, &api.QuotaUsage{
+			Owner: convert.ToUser(ctx, owner, ctx.Doer),
+			Used:  convert.ToQuotaUsed(u),
+		})
+	}
+	ctx.SetLinkHeader(total, opts.PageSize)
+	ctx.SetTotalCountHeader(total)
+	ctx.JSON(http.StatusOK, res)
+}
+
+// GetUserQuota gets the quota of a user or an organization
+func GetUserQuota(ctx *context.APIContext) {
+	// swagger:operation GET /admin/users/{username}/quota admin adminGetUserQuota
+	// ---
+	// summary: Get the storage usage, the groups and the rules of a user or an organization
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: username
+	//   in: path
+	//   description: name of the user or organization
+	//   type: string
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/QuotaInfo"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	shared.GetQuota(ctx, ctx.ContextUser.ID)
+}
+
+// AddRuleToUserQuota attaches a rule directly to a user or an organization
+func AddRuleToUserQuota(ctx *context.APIContext) {
+	// swagger:operation PUT /admin/users/{username}/quota/rules/{quotarule} admin adminAddRuleToUserQuota
+	// ---
+	// summary: Attach a quota rule directly to a user or an organization
+	// parameters:
+	// - name: username
+	//   in: path
+	//   description: name of the user or organization
+	//   type: string
+	//   required: true
+	// - name: quotarule
+	//   in: path
+	//   description: name of the rule
+	//   type: string
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "409":
+	//     "$ref": "#/responses/conflict"
+
+	r := getQuotaRuleByParams(ctx)
+	if ctx.Written() {
+		return
+	}
+	if err := quota_model.AddRuleToUser(ctx, ctx.ContextUser.ID, r.ID); err != nil {
+		ctx.APIErrorAuto(err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+// RemoveRuleFromUserQuota detaches a rule from a user or an organization
+func RemoveRuleFromUserQuota(ctx *context.APIContext) {
+	// swagger:operation DELETE /admin/users/{username}/quota/rules/{quotarule} admin adminRemoveRuleFromUserQuota
+	// ---
+	// summary: Detach a quota rule from a user or an organization
+	// parameters:
+	// - name: username
+	//   in: path
+	//   description: name of the user or organization
+	//   type: string
+	//   required: true
+	// - name: quotarule
+	//   in: path
+	//   description: name of the rule
+	//   type: string
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	r := getQuotaRuleByParams(ctx)
+	if ctx.Written() {
+		return
+	}
+	if err := quota_model.RemoveRuleFromUser(ctx, ctx.ContextUser.ID, r.ID); err != nil {
+		ctx.APIErrorAuto(err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
@@ -0,0 +1,146 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package admin
+
+import (
+	"gitea.dev/routers/api/v1/shared"
+	"gitea.dev/services/context"
+)
+
+// ListSecretScanningPatterns lists the custom secret scanning patterns of the instance
+func ListSecretScanningPatterns(ctx *context.APIContext) {
+	// swagger:operation GET /admin/secret-scanning/patterns admin adminListSecretScanningPatterns
+	// ---
+	// summary: List the custom secret scanning patterns of the instance, they apply to all the repositories
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/SecretScanningPatternList"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+
+	shared.ListSecretScanningPatterns(ctx, 0)
+}
+
+// GetSecretScanningPattern gets a custom secret scanning pattern of the instance
+func GetSecretScanningPattern(ctx *context.APIContext) {
+	// swagger:operation GET /admin/secret-scanning/patterns/{id} admin adminGetSecretScanningPattern
+	// ---
+	// summary: Get a custom secret scanning pattern of the instance
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: id
+	//   in: path
+	//   description: id of the pattern
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/SecretScanningPattern"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	shared.GetSecretScanningPattern(ctx, 0)
+}
+
+// CreateSecretScanningPattern creates a custom secret scanning pattern for the instance
+func CreateSecretScanningPattern(ctx *context.APIContext) {
+	// swagger:operation POST /admin/secret-scanning/patterns admin adminCreateSecretScanningPattern
+	// ---
+	// summary: Create a custom secret scanning pattern for the instance
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/CreateSecretScanningPatternOption"
+	// responses:
+	//   "201":
+	//     "$ref": "#/responses/SecretScanningPattern"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "409":
+	//     "$ref": "#/responses/conflict"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	shared.CreateSecretScanningPattern(ctx, 0)
+}
+
+// EditSecretScanningPattern edits a custom secret scanning pattern of the instance
+func EditSecretScanningPattern(ctx *context.APIContext) {
+	// swagger:operation PATCH /admin/secret-scanning/patterns/{id} admin adminEditSecretScanningPattern
+	// ---
+	// summary: Edit a custom secret scanning pattern of the instance. Only fields that are set will be changed
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: id
+	//   in: path
+	//   description: id of the pattern
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/EditSecretScanningPatternOption"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/SecretScanningPattern"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "409":
+	//     "$ref": "#/responses/conflict"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	shared.EditSecretScanningPattern(ctx, 0)
+}
+
+// DeleteSecretScanningPattern deletes a custom secret scanning pattern of the instance
+func DeleteSecretScanningPattern(ctx *context.APIContext) {
+	// swagger:operation DELETE /admin/secret-scanning/patterns/{id} admin adminDeleteSecretScanningPattern
+	// ---
+	// summary: Delete a custom secret scanning pattern of the instance
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: id
+	//   in: path
+	//   description: id of the pattern
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	shared.DeleteSecretScanningPattern(ctx, 0)
+}
@@ -833,6 +833,13 @@ func addProjectRoutes(m *web.Router, writeChecks ...any) {
 			m.Get("", shared.GetProjectColumn)
 			m.Get("/issues", shared.ListProjectColumnIssues)
 		})
+		m.Get("/views", shared.ListProjectViews)
+		m.Group("/views/{view_id}", func() {
+			m.Get("", shared.GetProjectView)
+			m.Get("/items", shared.ListProjectViewItems)
+		})
+		m.Get("/fields", shared.ListProjectFields)
+		m.Get("/fields/{field_id}", shared.GetProjectField)
 	})
 	m.Group("", func() {
 		m.Post("", bind(api.CreateProjectOption{}), shared.CreateProject)
@@ -849,6 +856,25 @@ func addProjectRoutes(m *web.Router, writeChecks ...any) {
 				m.Delete("/issues/{issue_id}", shared.RemoveIssueFromProjectColumn)
 			})
 			m.Post("/issues/{issue_id}/move", bind(api.MoveProjectIssueOption{}), shared.MoveProjectIssue)
+			m.Combo("/issues/{issue_id}/fields/{field_id}").
+				Put(bind(api.SetProjectFieldValueOption{}), shared.SetProjectIssueFieldValue).
+				Delete(shared.DeleteProjectIssueFieldValue)
+			m.Post("/views", bind(api.CreateProjectViewOption{}), shared.CreateProjectView)
+			m.Group("/views/{view_id}", func() {
+				m.Patch("", bind(api.EditProjectViewOption{}), shared.EditProjectView)
+				m.Delete("", shared.DeleteProjectView)
+			})
+			m.Post("/fields", bind(api.CreateProjectFieldOption{}), shared.CreateProjectField)
+			m.Group("/fields/{field_id}", func() {
+				m.Patch("", bind(api.EditProjectFieldOption{}), shared.EditProjectField)
+				m.Delete("", shared.DeleteProjectField)
+				m.Post("/iterations", bind(api.CreateProjectIterationOption{}), shared.CreateProjectIteration)
+				m.Group("/iterations/{iteration_id}", func() {
+					m.Patch("", bind(api.EditProjectIterationOption{}), shared.EditProjectIteration)
+					m.Delete("", shared.DeleteProjectIteration)
+					m.Post("/rollover", bind(api.RolloverProjectIterationOption{}), shared.RolloverProjectIteration)
+				})
+			})
 		})
 	}, writeChecks...)
 }
@@ -1161,6 +1187,7 @@ func Routes() *web.Router {
 		// Users (requires user scope)
 		m.Group("/user", func() {
 			m.Get("", user.GetAuthenticatedUser)
+			m.Get("/quota", user.GetQuota)
 			m.Group("/settings", func() {
 				m.Get("", user.GetUserSettings)
 				m.Patch("", bind(api.UserSettingsOptions{}), user.UpdateUserSettings)
@@ -1347,6 +1374,7 @@ func Routes() *web.Router {
 							Patch(bind(api.EditHookOption{}), repo.EditHook).
 							Delete(repo.DeleteHook)
 						m.Post("/tests", context.ReferencesGitRepo(), context.RepoRefForAPI, repo.TestHook)
+						m.Post("/preview", bind(api.PreviewHookOption{}), context.ReferencesGitRepo(), context.RepoRefForAPI, repo.PreviewHook)
 					})
 				}, reqToken(), reqAdmin(), reqWebhooksEnabled())
 				m.Group("/collaborators", func() {
@@ -1406,6 +1434,23 @@ func Routes() *web.Router {
 							Delete(repo.DeleteTagProtection)
 					})
 				}, reqToken(), reqAdmin())
+				m.Group("/rulesets", func() {
+					m.Combo("").Get(repo.ListRulesets).
+						Post(bind(api.CreateRulesetOption{}), mustNotBeArchived, repo.CreateRuleset)
+					m.Combo("/{id}").Get(repo.GetRuleset).
+						Patch(bind(api.EditRulesetOption{}), mustNotBeArchived, repo.EditRuleset).
+						Delete(repo.DeleteRuleset)
+				}, reqToken(), reqAdmin())
+				m.Group("/secret-scanning", func() {
+					m.Get("/alerts", repo.ListSecretScanningAlerts)
+					m.Group("/alerts/{id}", func() {
+						m.Combo("").Get(repo.GetSecretScanningAlert).
+							Patch(bind(api.EditSecretScanningAlertOption{}), repo.EditSecretScanningAlert)
+						m.Get("/events", repo.ListSecretScanningAlertEvents)
+					})
+					m.Post("/scan", mustNotBeArchived, repo.ScanRepositorySecrets)
+				}, reqToken(), reqAdmin())
+				m.Get("/audit-log", reqToken(), reqAdmin(), repo.ListAuditEvents)
 				m.Group("/actions", func() {
 					m.Get("/tasks", repo.ListActionTasks)
 					m.Group("/runs", func() {
@@ -1427,6 +1472,8 @@ func Routes() *web.Router {
 							})
 							m.Get("/logs", reqToken(), repo.GetWorkflowRunLogs)
 							m.Get("/artifacts", repo.GetArtifactsOfRun)
+							m.Combo("/pending_deployments").Get(repo.GetPendingDeployments).
+								Post(reqToken(), bind(api.ReviewPendingDeploymentsOption{}), repo.ReviewPendingDeployments)
 						})
 					})
 					m.Get("/artifacts", repo.GetArtifacts)
@@ -1435,7 +1482,33 @@ func Routes() *web.Router {
 						m.Delete("", reqRepoWriter(unit.TypeActions), repo.DeleteArtifact)
 					})
 					m.Get("/artifacts/{artifact_id}/zip", repo.DownloadArtifact)
+					m.Combo("/caches").Get(repo.ListActionCaches).
+						Delete(reqToken(), reqRepoWriter(unit.TypeActions), repo.DeleteActionCachesByKey)
+					m.Delete("/caches/{cache_id}", reqToken(), reqRepoWriter(unit.TypeActions), repo.DeleteActionCache)
 				}, reqRepoReader(unit.TypeActions))
+				m.Group("/environments", func() {
+					m.Get("", repo.ListActionEnvironments)
+					m.Group("/{environment_name}", func() {
+						m.Combo("").Get(repo.GetActionEnvironment).
+							Put(reqOwner(), bind(api.CreateOrUpdateActionEnvironmentOption{}), repo.CreateOrUpdateActionEnvironment).
+							Delete(reqOwner(), repo.DeleteActionEnvironment)
+						m.Group("/secrets", func() {
+							m.Get("", repo.ListActionEnvironmentSecrets)
+							m.Combo("/{secretname}").
+								Put(bind(api.CreateOrUpdateSecretOption{}), repo.CreateOrUpdateActionEnvironmentSecret).
+								Delete(repo.DeleteActionEnvironmentSecret)
+						}, reqOwner())
+						m.Group("/variables", func() {
+							m.Get("", repo.ListActionEnvironmentVariables)
+							m.Combo("/{variablename}").
+								Get(repo.GetActionEnvironmentVariable).
+								Delete(repo.DeleteActionEnvironmentVariable).
+								Post(bind(api.CreateVariableOption{}), repo.CreateActionEnvironmentVariable).
+								Put(bind(api.UpdateVariableOption{}), repo.UpdateActionEnvironmentVariable)
+						}, reqOwner())
+					})
+				}, reqToken(), reqRepoReader(unit.TypeActions))
+				m.Get("/deployments", reqRepoReader(unit.TypeActions), repo.ListActionDeployments)
 				m.Group("/keys", func() {
 					m.Combo("").Get(repo.ListDeployKeys).
 						Post(bind(api.CreateKeyOption{}), repo.CreateDeployKey)
@@ -1488,6 +1561,9 @@ func Routes() *web.Router {
 					})
 				}, reqRepoReader(unit.TypeReleases))
 				m.Post("/mirror-sync", reqToken(), reqRepoWriter(unit.TypeCode), mustNotBeArchived, repo.MirrorSync)
+				m.Combo("/migration-sync", reqToken(), reqAdmin()).
+					Get(repo.GetMigrationSync).
+					Delete(repo.StopMigrationSync)
 				m.Post("/push_mirrors-sync", reqAdmin(), reqToken(), mustNotBeArchived, repo.PushMirrorSync)
 				m.Group("/push_mirrors", func() {
 					m.Combo("").Get(repo.ListPushMirrors).
@@ -1514,6 +1590,9 @@ func Routes() *web.Router {
 						m.Combo("/merge").Get(repo.IsPullRequestMerged).
 							Post(reqToken(), mustNotBeArchived, bind(forms.MergePullRequestForm{}), repo.MergePullRequest).
 							Delete(reqToken(), mustNotBeArchived, repo.CancelScheduledAutoMerge)
+						m.Combo("/merge_queue").Get(repo.GetPullRequestMergeQueueEntry).
+							Post(reqToken(), mustNotBeArchived, bind(forms.MergePullRequestForm{}), repo.AddPullRequestToMergeQueue).
+							Delete(reqToken(), mustNotBeArchived, repo.RemovePullRequestFromMergeQueue)
 						m.Group("/reviews", func() {
 							m.Combo("").
 								Get(repo.ListPullReviews).
@@ -1536,6 +1615,7 @@ func Routes() *web.Router {
 					})
 					m.Get("/{base}/*", repo.GetPullRequestByBaseHead)
 				}, mustAllowPulls, reqRepoReader(unit.TypeCode), context.ReferencesGitRepo())
+				m.Get("/merge_queue", mustAllowPulls, reqRepoReader(unit.TypeCode), repo.ListMergeQueue)
 				m.Group("/statuses", func() { // "/statuses/{sha}" only accepts commit ID
 					m.Combo("/{sha}").Get(repo.GetCommitStatuses).
 						Post(reqToken(), reqRepoWriter(unit.TypeCode), bind(api.CreateStatusOption{}), repo.NewCommitStatus)
@@ -1601,6 +1681,13 @@ func Routes() *web.Router {
 				m.Get("/issue_config", reqRepoReader(unit.TypeCode), context.ReferencesGitRepo(), repo.GetIssueConfig)
 				m.Get("/issue_config/validate", reqRepoReader(unit.TypeCode), context.ReferencesGitRepo(), repo.ValidateIssueConfig)
 				m.Get("/languages", reqRepoReader(unit.TypeCode), repo.GetLanguages)
+				m.Get("/code/search", reqRepoReader(unit.TypeCode), context.ReferencesGitRepo(), repo.SearchCode)
+				m.Group("/code-nav/{sha}", func() {
+					m.Get("", repo.GetCodeNavSymbol)
+					m.Combo("/index").Get(repo.GetCodeNavIndex).
+						Put(reqToken(), reqRepoWriter(unit.TypeCode), mustNotBeArchived, repo.UploadCodeNavIndex).
+						Delete(reqToken(), reqRepoWriter(unit.TypeCode), mustNotBeArchived, repo.DeleteCodeNavIndex)
+				}, reqRepoReader(unit.TypeCode), context.ReferencesGitRepo())
 				m.Get("/licenses", reqRepoReader(unit.TypeCode), repo.GetLicenses)
 				m.Get("/activities/feeds", repo.ListRepoActivityFeeds)
 				m.Get("/new_pin_allowed", repo.AreNewIssuePinsAllowed)
@@ -1679,6 +1766,12 @@ func Routes() *web.Router {
 								Delete(reqToken(), repo.ClearIssueLabels)
 							m.Delete("/{id}", reqToken(), repo.DeleteIssueLabel)
 						})
+						m.Group("/custom_fields", func() {
+							m.Get("", repo.ListIssueCustomFields)
+							m.Combo("/{id}", reqToken(), mustNotBeArchived).
+								Put(bind(api.SetIssueCustomFieldOption{}), repo.SetIssueCustomField).
+								Delete(repo.DeleteIssueCustomField)
+						})
 						m.Group("/times", func() {
 							m.Combo("").
 								Get(repo.ListTrackedTimes).
@@ -1715,6 +1808,12 @@ func Routes() *web.Router {
 							Get(repo.GetIssueDependencies).
 							Post(reqToken(), mustNotBeArchived, bind(api.IssueMeta{}), repo.CreateIssueDependency).
 							Delete(reqToken(), mustNotBeArchived, bind(api.IssueMeta{}), repo.RemoveIssueDependency)
+						m.Combo("/sub_issues").
+							Get(repo.ListSubIssues).
+							Post(reqToken(), mustNotBeArchived, bind(api.IssueMeta{}), repo.AddSubIssue).
+							Delete(reqToken(), mustNotBeArchived, bind(api.IssueMeta{}), repo.RemoveSubIssue).
+							Patch(reqToken(), mustNotBeArchived, bind(api.ReorderSubIssuesOption{}), repo.ReorderSubIssues)
+						m.Get("/parent", repo.GetParentIssue)
 						m.Combo("/blocks").
 							Get(repo.GetIssueBlocks).
 							Post(reqToken(), bind(api.IssueMeta{}), repo.CreateIssueBlocking).
@@ -1739,6 +1838,14 @@ func Routes() *web.Router {
 						Patch(reqToken(), reqRepoWriter(unit.TypeIssues, unit.TypePullRequests), bind(api.EditLabelOption{}), repo.EditLabel).
 						Delete(reqToken(), reqRepoWriter(unit.TypeIssues, unit.TypePullRequests), repo.DeleteLabel)
 				})
+				m.Get("/issue_types", repo.ListIssueTypes)
+				m.Group("/custom_fields", func() {
+					m.Combo("").Get(repo.ListCustomFields).
+						Post(reqToken(), reqRepoWriter(unit.TypeIssues, unit.TypePullRequests), bind(api.CreateCustomFieldOption{}), repo.CreateCustomField)
+					m.Combo("/{id}").Get(repo.GetCustomField).
+						Patch(reqToken(), reqRepoWriter(unit.TypeIssues, unit.TypePullRequests), bind(api.EditCustomFieldOption{}), repo.EditCustomField).
+						Delete(reqToken(), reqRepoWriter(unit.TypeIssues, unit.TypePullRequests), repo.DeleteCustomField)
+				})
 				m.Group("/milestones", func() {
 					m.Combo("").Get(repo.ListMilestones).
 						Post(reqToken(), reqRepoWriter(unit.TypeIssues, unit.TypePullRequests), bind(api.CreateMilestoneOption{}), repo.CreateMilestone)
@@ -1754,6 +1861,14 @@ func Routes() *web.Router {
 
 		// NOTE: these are Gitea package management API - see packages.CommonRoutes and packages.DockerContainerRoutes for endpoints that implement package manager APIs
 		m.Group("/packages/{username}", func() {
+			m.Group("/-/remotes", func() {
+				m.Combo("").Get(packages.ListPackageRemotes).
+					Post(bind(api.CreatePackageRemoteOption{}), packages.CreatePackageRemote)
+				m.Combo("/{type}").Get(packages.GetPackageRemote).
+					Patch(bind(api.EditPackageRemoteOption{}), packages.EditPackageRemote).
+					Delete(packages.DeletePackageRemote)
+			}, reqPackageAccess(perm.AccessModeAdmin))
+
 			m.Group("/{type}/{name}", func() {
 				m.Get("/", packages.ListPackageVersions)
 				m.Delete("", reqPackageAccess(perm.AccessModeWrite), packages.DeletePackage)
@@ -1762,6 +1877,13 @@ func Routes() *web.Router {
 					m.Get("", packages.GetPackage)
 					m.Delete("", reqPackageAccess(perm.AccessModeWrite), packages.DeletePackageVersion)
 					m.Get("/files", packages.ListPackageFiles)
+					m.Group("/attestations", func() {
+						m.Combo("").Get(packages.ListPackageAttestations).
+							Post(reqPackageAccess(perm.AccessModeWrite), bind(api.CreatePackageAttestationOption{}), packages.CreatePackageAttestation)
+						m.Combo("/{id}").Get(packages.GetPackageAttestation).
+							Delete(reqPackageAccess(perm.AccessModeWrite), packages.DeletePackageAttestation)
+						m.Get("/{id}/content", packages.GetPackageAttestationContent)
+					})
 				})
 
 				m.Group("/-", func() {
@@ -1822,6 +1944,20 @@ func Routes() *web.Router {
 					Patch(reqToken(), reqOrgOwnership(), bind(api.EditLabelOption{}), org.EditLabel).
 					Delete(reqToken(), reqOrgOwnership(), org.DeleteLabel)
 			}, reqOrgVisible())
+			m.Group("/custom_fields", func() {
+				m.Get("", org.ListCustomFields)
+				m.Post("", reqToken(), reqOrgOwnership(), bind(api.CreateCustomFieldOption{}), org.CreateCustomField)
+				m.Combo("/{id}").Get(reqToken(), org.GetCustomField).
+					Patch(reqToken(), reqOrgOwnership(), bind(api.EditCustomFieldOption{}), org.EditCustomField).
+					Delete(reqToken(), reqOrgOwnership(), org.DeleteCustomField)
+			}, reqOrgVisible())
+			m.Group("/issue_types", func() {
+				m.Get("", org.ListIssueTypes)
+				m.Post("", reqToken(), reqOrgOwnership(), bind(api.CreateIssueTypeOption{}), org.CreateIssueType)
+				m.Combo("/{id}").Get(org.GetIssueType).
+					Patch(reqToken(), reqOrgOwnership(), bind(api.EditIssueTypeOption{}), org.EditIssueType).
+					Delete(reqToken(), reqOrgOwnership(), org.DeleteIssueType)
+			}, reqOrgVisible())
 			m.Group("/hooks", func() {
 				m.Combo("").Get(org.ListHooks).
 					Post(bind(api.CreateHookOption{}), org.CreateHook)
@@ -1829,6 +1965,22 @@ func Routes() *web.Router {
 					Patch(bind(api.EditHookOption{}), org.EditHook).
 					Delete(org.DeleteHook)
 			}, reqToken(), reqOrgOwnership(), reqWebhooksEnabled())
+			m.Group("/rulesets", func() {
+				m.Combo("").Get(org.ListRulesets).
+					Post(bind(api.CreateRulesetOption{}), org.CreateRuleset)
+				m.Combo("/{id}").Get(org.GetRuleset).
+					Patch(bind(api.EditRulesetOption{}), org.EditRuleset).
+					Delete(org.DeleteRuleset)
+			}, reqToken(), reqOrgOwnership())
+			m.Group("/secret-scanning/patterns", func() {
+				m.Combo("").Get(org.ListSecretScanningPatterns).
+					Post(bind(api.CreateSecretScanningPatternOption{}), org.CreateSecretScanningPattern)
+				m.Combo("/{id}").Get(org.GetSecretScanningPattern).
+					Patch(bind(api.EditSecretScanningPatternOption{}), org.EditSecretScanningPattern).
+					Delete(org.DeleteSecretScanningPattern)
+			}, reqToken(), reqOrgOwnership())
+			m.Get("/audit-log", reqToken(), reqOrgOwnership(), org.ListAuditEvents)
+			m.Get("/quota", reqToken(), reqOrgMembership(), org.GetQuota)
 			m.Group("/avatar", func() {
 				m.Post("", bind(api.UpdateUserAvatarOption{}), org.UpdateAvatar)
 				m.Delete("", org.DeleteAvatar)
@@ -1895,6 +2047,10 @@ func Routes() *web.Router {
 					m.Get("/badges", admin.ListUserBadges)
 					m.Post("/badges", bind(api.UserBadgeOption{}), admin.AddUserBadges)
 					m.Delete("/badges", bind(api.UserBadgeOption{}), admin.DeleteUserBadges)
+					m.Get("/quota", admin.GetUserQuota)
+					m.Combo("/quota/rules/{quotarule}").
+						Put(admin.AddRuleToUserQuota).
+						Delete(admin.RemoveRuleFromUserQuota)
 				}, context.UserAssignmentAPI())
 			})
 			m.Group("/emails", func() {
@@ -1913,6 +2069,36 @@ func Routes() *web.Router {
 					Patch(bind(api.EditHookOption{}), admin.EditHook).
 					Delete(admin.DeleteHook)
 			})
+			m.Group("/secret-scanning/patterns", func() {
+				m.Combo("").Get(admin.ListSecretScanningPatterns).
+					Post(bind(api.CreateSecretScanningPatternOption{}), admin.CreateSecretScanningPattern)
+				m.Combo("/{id}").Get(admin.GetSecretScanningPattern).
+					Patch(bind(api.EditSecretScanningPatternOption{}), admin.EditSecretScanningPattern).
+					Delete(admin.DeleteSecretScanningPattern)
+			})
+			m.Group("/quota", func() {
+				m.Combo("/rules").Get(admin.ListQuotaRules).
+					Post(bind(api.CreateQuotaRuleOption{}), admin.CreateQuotaRule)
+				m.Combo("/rules/{quotarule}").Get(admin.GetQuotaRule).
+					Patch(bind(api.EditQuotaRuleOption{}), admin.EditQuotaRule).
+					Delete(admin.DeleteQuotaRule)
+				m.Combo("/groups").Get(admin.ListQuotaGroups).
+					Post(bind(api.CreateQuotaGroupOption{}), admin.CreateQuotaGroup)
+				m.Group("/groups/{quotagroup}", func() {
+					m.Combo("").Get(admin.GetQuotaGroup).
+						Delete(admin.DeleteQuotaGroup)
+					m.Combo("/rules/{quotarule}").Put(admin.AddRuleToQuotaGroup).
+						Delete(admin.RemoveRuleFromQuotaGroup)
+					m.Get("/users", admin.ListQuotaGroupUsers)
+					m.Combo("/users/{username}").Put(admin.AddUserToQuotaGroup).
+						Delete(admin.RemoveUserFromQuotaGroup)
+				})
+				m.Get("/usage", admin.ListQuotaUsages)
+			})
+			m.Group("/audit-log", func() {
+				m.Get("", admin.ListAuditEvents)
+				m.Get("/verify", admin.VerifyAuditLog)
+			})
 			m.Group("/actions", func() {
 				m.Group("/runners", func() {
 					m.Get("", admin.ListRunners)
@@ -1923,6 +2109,8 @@ func Routes() *web.Router {
 				})
 				m.Get("/runs", admin.ListWorkflowRuns)
 				m.Get("/jobs", admin.ListWorkflowJobs)
+				m.Get("/caches", admin.ListActionCaches)
+				m.Delete("/caches/{cache_id}", admin.DeleteActionCache)
 			})
 		}, tokenRequiresScopes(auth_model.AccessTokenScopeCategoryAdmin), reqToken(), reqSiteAdmin())
 
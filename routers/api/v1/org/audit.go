@@ -0,0 +1,61 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package org
+
+import (
+	"gitea.dev/routers/api/v1/shared"
+	"gitea.dev/services/context"
+)
+
+// ListAuditEvents lists the events of the audit log of an organization
+func ListAuditEvents(ctx *context.APIContext) {
+	// swagger:operation GET /orgs/{org}/audit-log organization orgListAuditEvents
+	// ---
+	// summary: List the events of the audit log of an organization and of its repositories, from the most recent
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: action
+	//   in: query
+	//   description: only the events of this action, or of the actions starting with it followed by a dot, e.g. "team" or "access_token.create"
+	//   type: string
+	// - name: actor
+	//   in: query
+	//   description: only the events of the changes made by this user
+	//   type: string
+	// - name: since
+	//   in: query
+	//   description: only the events recorded since the specified time
+	//   type: string
+	//   format: date-time
+	// - name: before
+	//   in: query
+	//   description: only the events recorded before the specified time
+	//   type: string
+	//   format: date-time
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/AuditEventList"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	shared.ListAuditEvents(ctx, ctx.Org.Organization.ID, 0)
+}
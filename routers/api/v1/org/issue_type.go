@@ -0,0 +1,245 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package org
+
+import (
+	"errors"
+	"net/http"
+
+	issues_model "gitea.dev/models/issues"
+	api "gitea.dev/modules/structs"
+	"gitea.dev/modules/util"
+	"gitea.dev/modules/web"
+	"gitea.dev/routers/api/v1/shared"
+	"gitea.dev/services/context"
+	"gitea.dev/services/convert"
+	issue_service "gitea.dev/services/issue"
+)
+
+// ListIssueTypes lists the issue types of an organization
+func ListIssueTypes(ctx *context.APIContext) {
+	// swagger:operation GET /orgs/{org}/issue_types organization orgListIssueTypes
+	// ---
+	// summary: List the issue types of an organization, they apply to the issues of all its repositories
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: disabled
+	//   in: query
+	//   description: include the disabled issue types
+	//   type: boolean
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/IssueTypeList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	shared.ListIssueTypes(ctx, ctx.Org.Organization.ID)
+}
+
+func getIssueType(ctx *context.APIContext) *issues_model.IssueType {
+	t, err := issues_model.GetIssueTypeByID(ctx, ctx.Org.Organization.ID, ctx.PathParamInt64("id"))
+	if err != nil {
+		ctx.APIErrorAuto(err)
+		return nil
+	}
+	return t
+}
+
+func handleIssueTypeSaveError(ctx *context.APIContext, err error) {
+	if errors.Is(err, util.ErrInvalidArgument) {
+		ctx.APIError(http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+	ctx.APIErrorAuto(err)
+}
+
+// GetIssueType gets an issue type of an organization
+func GetIssueType(ctx *context.APIContext) {
+	// swagger:operation GET /orgs/{org}/issue_types/{id} organization orgGetIssueType
+	// ---
+	// summary: Get an issue type of an organization
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the issue type
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/IssueType"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	t := getIssueType(ctx)
+	if ctx.Written() {
+		return
+	}
+	ctx.JSON(http.StatusOK, convert.ToIssueType(t))
+}
+
+// CreateIssueType creates an issue type for an organization
+func CreateIssueType(ctx *context.APIContext) {
+	// swagger:operation POST /orgs/{org}/issue_types organization orgCreateIssueType
+	// ---
+	// summary: Create an issue type for an organization
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/CreateIssueTypeOption"
+	// responses:
+	//   "201":
+	//     "$ref": "#/responses/IssueType"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "409":
+	//     "$ref": "#/responses/conflict"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	form := web.GetForm[*api.CreateIssueTypeOption](ctx)
+	t := &issues_model.IssueType{
+		OwnerID:     ctx.Org.Organization.ID,
+		Name:        form.Name,
+		Description: form.Description,
+		Color:       form.Color,
+	}
+	if err := issues_model.CreateIssueType(ctx, t); err != nil {
+		handleIssueTypeSaveError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, convert.ToIssueType(t))
+}
+
+// EditIssueType edits an issue type of an organization
+func EditIssueType(ctx *context.APIContext) {
+	// swagger:operation PATCH /orgs/{org}/issue_types/{id} organization orgEditIssueType
+	// ---
+	// summary: Edit an issue type of an organization. Only fields that are set will be changed
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the issue type
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/EditIssueTypeOption"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/IssueType"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "409":
+	//     "$ref": "#/responses/conflict"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	form := web.GetForm[*api.EditIssueTypeOption](ctx)
+	t := getIssueType(ctx)
+	if ctx.Written() {
+		return
+	}
+	if form.Name != nil {
+		t.Name = *form.Name
+	}
+	if form.Description != nil {
+		t.Description = *form.Description
+	}
+	if form.Color != nil {
+		t.Color = *form.Color
+	}
+	if form.IsDisabled != nil {
+		t.IsDisabled = *form.IsDisabled
+	}
+	if err := issues_model.UpdateIssueType(ctx, t); err != nil {
+		handleIssueTypeSaveError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, convert.ToIssueType(t))
+}
+
+// DeleteIssueType deletes an issue type of an organization
+func DeleteIssueType(ctx *context.APIContext) {
+	// swagger:operation DELETE /orgs/{org}/issue_types/{id} organization orgDeleteIssueType
+	// ---
+	// summary: Delete an issue type of an organization, the issues having it are left without type
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the issue type
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	t := getIssueType(ctx)
+	if ctx.Written() {
+		return
+	}
+	if err := issue_service.DeleteIssueType(ctx, t); err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
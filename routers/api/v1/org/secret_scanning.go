@@ -0,0 +1,167 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package org
+
+import (
+	"gitea.dev/routers/api/v1/shared"
+	"gitea.dev/services/context"
+)
+
+// ListSecretScanningPatterns lists the custom secret scanning patterns of an organization
+func ListSecretScanningPatterns(ctx *context.APIContext) {
+	// swagger:operation GET /orgs/{org}/secret-scanning/patterns organization orgListSecretScanningPatterns
+	// ---
+	// summary: List the custom secret scanning patterns of an organization, they apply to all its repositories
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/SecretScanningPatternList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	shared.ListSecretScanningPatterns(ctx, ctx.Org.Organization.ID)
+}
+
+// GetSecretScanningPattern gets a custom secret scanning pattern of an organization
+func GetSecretScanningPattern(ctx *context.APIContext) {
+	// swagger:operation GET /orgs/{org}/secret-scanning/patterns/{id} organization orgGetSecretScanningPattern
+	// ---
+	// summary: Get a custom secret scanning pattern of an organization
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the pattern
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/SecretScanningPattern"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	shared.GetSecretScanningPattern(ctx, ctx.Org.Organization.ID)
+}
+
+// CreateSecretScanningPattern creates a custom secret scanning pattern for an organization
+func CreateSecretScanningPattern(ctx *context.APIContext) {
+	// swagger:operation POST /orgs/{org}/secret-scanning/patterns organization orgCreateSecretScanningPattern
+	// ---
+	// summary: Create a custom secret scanning pattern for an organization
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/CreateSecretScanningPatternOption"
+	// responses:
+	//   "201":
+	//     "$ref": "#/responses/SecretScanningPattern"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "409":
+	//     "$ref": "#/responses/conflict"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	shared.CreateSecretScanningPattern(ctx, ctx.Org.Organization.ID)
+}
+
+// EditSecretScanningPattern edits a custom secret scanning pattern of an organization
+func EditSecretScanningPattern(ctx *context.APIContext) {
+	// swagger:operation PATCH /orgs/{org}/secret-scanning/patterns/{id} organization orgEditSecretScanningPattern
+	// ---
+	// summary: Edit a custom secret scanning pattern of an organization. Only fields that are set will be changed
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the pattern
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/EditSecretScanningPatternOption"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/SecretScanningPattern"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "409":
+	//     "$ref": "#/responses/conflict"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	shared.EditSecretScanningPattern(ctx, ctx.Org.Organization.ID)
+}
+
+// DeleteSecretScanningPattern deletes a custom secret scanning pattern of an organization
+func DeleteSecretScanningPattern(ctx *context.APIContext) {
+	// swagger:operation DELETE /orgs/{org}/secret-scanning/patterns/{id} organization orgDeleteSecretScanningPattern
+	// ---
+	// summary: Delete a custom secret scanning pattern of an organization
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the pattern
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	shared.DeleteSecretScanningPattern(ctx, ctx.Org.Organization.ID)
+}
@@ -0,0 +1,340 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package packages
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"net/http"
+
+	packages_model "gitea.dev/models/packages"
+	api "gitea.dev/modules/structs"
+	"gitea.dev/modules/util"
+	"gitea.dev/modules/web"
+	"gitea.dev/services/context"
+	"gitea.dev/services/convert"
+	packages_service "gitea.dev/services/packages"
+	attestation_service "gitea.dev/services/packages/attestation"
+)
+
+// ListPackageAttestations gets all attestations of a package version
+func ListPackageAttestations(ctx *context.APIContext) {
+	// swagger:operation GET /packages/{owner}/{type}/{name}/{version}/attestations package listPackageAttestations
+	// ---
+	// summary: Gets all signatures, provenance statements and SBOMs of a package version
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the package
+	//   type: string
+	//   required: true
+	// - name: type
+	//   in: path
+	//   description: type of the package
+	//   type: string
+	//   required: true
+	// - name: name
+	//   in: path
+	//   description: name of the package
+	//   type: string
+	//   required: true
+	// - name: version
+	//   in: path
+	//   description: version of the package
+	//   type: string
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/PackageAttestationList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	views, err := attestation_service.LoadViews(ctx, ctx.Package.Descriptor)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	apiAttestations := make([]*api.PackageAttestation, 0, len(views))
+	for _, v := range views {
+		apiAttestations = append(apiAttestations, convert.ToPackageAttestation(ctx, v, ctx.Doer))
+	}
+
+	ctx.JSON(http.StatusOK, apiAttestations)
+}
+
+// CreatePackageAttestation attaches an attestation to a package version
+func CreatePackageAttestation(ctx *context.APIContext) {
+	// swagger:operation POST /packages/{owner}/{type}/{name}/{version}/attestations package createPackageAttestation
+	// ---
+	// summary: Attach a signature, provenance statement or SBOM to a package version
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the package
+	//   type: string
+	//   required: true
+	// - name: type
+	//   in: path
+	//   description: type of the package
+	//   type: string
+	//   required: true
+	// - name: name
+	//   in: path
+	//   description: name of the package
+	//   type: string
+	//   required: true
+	// - name: version
+	//   in: path
+	//   description: version of the package
+	//   type: string
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/CreatePackageAttestationOption"
+	// responses:
+	//   "201":
+	//     "$ref": "#/responses/PackageAttestation"
+	//   "400":
+	//     "$ref": "#/responses/error"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "413":
+	//     "$ref": "#/responses/error"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	form := web.GetForm[*api.CreatePackageAttestationOption](ctx)
+
+	data, err := base64.StdEncoding.DecodeString(form.Content)
+	if err != nil {
+		ctx.APIError(http.StatusBadRequest, "content is not valid base64")
+		return
+	}
+	if len(data) > attestation_service.MaxSize {
+		ctx.APIErrorAuto(util.ErrContentTooLarge)
+		return
+	}
+
+	pd := ctx.Package.Descriptor
+
+	opts := &attestation_service.CreateOptions{
+		Type:   packages_model.AttestationType(form.Type),
+		Format: form.Format,
+		Name:   form.Name,
+		Data:   data,
+	}
+	if form.FileName != "" {
+		for _, pfd := range pd.Files {
+			if pfd.File.Name == form.FileName {
+				opts.File = pfd.File
+				break
+			}
+		}
+		if opts.File == nil {
+			ctx.APIErrorNotFound("package file not found")
+			return
+		}
+	}
+
+	pa, err := attestation_service.Create(ctx, ctx.Doer, pd, opts)
+	if err != nil {
+		switch {
+		case errors.Is(err, packages_service.ErrQuotaTotalCount), errors.Is(err, packages_service.ErrQuotaTypeSize), errors.Is(err, packages_service.ErrQuotaTotalSize):
+			ctx.APIError(http.StatusForbidden, err.Error())
+		default:
+			ctx.APIErrorAuto(err)
+		}
+		return
+	}
+
+	v, err := attestation_service.LoadView(ctx, pd, pa)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, convert.ToPackageAttestation(ctx, v, ctx.Doer))
+}
+
+func getPackageAttestationByParams(ctx *context.APIContext) *packages_model.PackageAttestation {
+	pa, err := packages_model.GetAttestationByID(ctx, ctx.Package.Descriptor.Version.ID, ctx.PathParamInt64("id"))
+	if err != nil {
+		ctx.APIErrorAuto(err)
+		return nil
+	}
+	return pa
+}
+
+// GetPackageAttestation gets an attestation of a package version
+func GetPackageAttestation(ctx *context.APIContext) {
+	// swagger:operation GET /packages/{owner}/{type}/{name}/{version}/attestations/{id} package getPackageAttestation
+	// ---
+	// summary: Gets an attestation of a package version with its verification status
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the package
+	//   type: string
+	//   required: true
+	// - name: type
+	//   in: path
+	//   description: type of the package
+	//   type: string
+	//   required: true
+	// - name: name
+	//   in: path
+	//   description: name of the package
+	//   type: string
+	//   required: true
+	// - name: version
+	//   in: path
+	//   description: version of the package
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the attestation
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/PackageAttestation"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	pa := getPackageAttestationByParams(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	v, err := attestation_service.LoadView(ctx, ctx.Package.Descriptor, pa)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, convert.ToPackageAttestation(ctx, v, ctx.Doer))
+}
+
+// GetPackageAttestationContent serves the raw content of an attestation
+func GetPackageAttestationContent(ctx *context.APIContext) {
+	// swagger:operation GET /packages/{owner}/{type}/{name}/{version}/attestations/{id}/content package getPackageAttestationContent
+	// ---
+	// summary: Gets the raw content of an attestation
+	// produces:
+	// - application/octet-stream
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the package
+	//   type: string
+	//   required: true
+	// - name: type
+	//   in: path
+	//   description: type of the package
+	//   type: string
+	//   required: true
+	// - name: name
+	//   in: path
+	//   description: name of the package
+	//   type: string
+	//   required: true
+	// - name: version
+	//   in: path
+	//   description: version of the package
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the attestation
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "200":
+	//     description: success
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	pa := getPackageAttestationByParams(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	data, _, err := attestation_service.GetContent(ctx, pa)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	ctx.ServeContent(bytes.NewReader(data), context.ServeHeaderOptions{
+		Filename:     pa.Name,
+		LastModified: pa.CreatedUnix.AsLocalTime(),
+	})
+}
+
+// DeletePackageAttestation removes an attestation from a package version
+func DeletePackageAttestation(ctx *context.APIContext) {
+	// swagger:operation DELETE /packages/{owner}/{type}/{name}/{version}/attestations/{id} package deletePackageAttestation
+	// ---
+	// summary: Remove an attestation from a package version
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the package
+	//   type: string
+	//   required: true
+	// - name: type
+	//   in: path
+	//   description: type of the package
+	//   type: string
+	//   required: true
+	// - name: name
+	//   in: path
+	//   description: name of the package
+	//   type: string
+	//   required: true
+	// - name: version
+	//   in: path
+	//   description: version of the package
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the attestation
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	pa := getPackageAttestationByParams(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	if err := packages_model.DeleteAttestationByID(ctx, pa.ID); err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
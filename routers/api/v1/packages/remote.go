@@ -0,0 +1,295 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package packages
+
+import (
+	"net/http"
+
+	packages_model "gitea.dev/models/packages"
+	api "gitea.dev/modules/structs"
+	"gitea.dev/modules/util"
+	"gitea.dev/modules/web"
+	"gitea.dev/services/context"
+	"gitea.dev/services/convert"
+	remote_service "gitea.dev/services/packages/remote"
+)
+
+// ListPackageRemotes lists the package remotes of an owner
+func ListPackageRemotes(ctx *context.APIContext) {
+	// swagger:operation GET /packages/{owner}/-/remotes package listPackageRemotes
+	// ---
+	// summary: Gets all package remotes of an owner
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the package remotes
+	//   type: string
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/PackageRemoteList"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	remotes, err := packages_model.GetRemotesByOwner(ctx, ctx.Package.Owner.ID)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	apiRemotes := make([]*api.PackageRemote, 0, len(remotes))
+	for _, pr := range remotes {
+		apiRemotes = append(apiRemotes, convert.ToPackageRemote(pr))
+	}
+
+	ctx.SetTotalCountHeader(int64(len(apiRemotes)))
+	ctx.JSON(http.StatusOK, apiRemotes)
+}
+
+// GetPackageRemote gets the package remote of a package type
+func GetPackageRemote(ctx *context.APIContext) {
+	// swagger:operation GET /packages/{owner}/-/remotes/{type} package getPackageRemote
+	// ---
+	// summary: Gets the package remote of a package type
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the package remote
+	//   type: string
+	//   required: true
+	// - name: type
+	//   in: path
+	//   description: package type of the remote
+	//   type: string
+	//   enum: [container, maven, npm, pypi]
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/PackageRemote"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	pr := getPackageRemoteByParams(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, convert.ToPackageRemote(pr))
+}
+
+// CreatePackageRemote creates a package remote for a package type
+func CreatePackageRemote(ctx *context.APIContext) {
+	// swagger:operation POST /packages/{owner}/-/remotes package createPackageRemote
+	// ---
+	// summary: Create a package remote for a package type
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the package remote
+	//   type: string
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/CreatePackageRemoteOption"
+	// responses:
+	//   "201":
+	//     "$ref": "#/responses/PackageRemote"
+	//   "400":
+	//     "$ref": "#/responses/error"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "409":
+	//     "$ref": "#/responses/error"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	form := web.GetForm[*api.CreatePackageRemoteOption](ctx)
+
+	packageType := packages_model.Type(form.Type)
+	if !packages_model.IsRemoteType(packageType) {
+		ctx.APIErrorAuto(packages_model.ErrPackageRemoteTypeNotValid)
+		return
+	}
+
+	mode := packages_model.RemoteMode(form.Mode)
+	if mode == "" {
+		mode = packages_model.RemoteModeRemote
+	}
+	if !mode.IsValid() {
+		ctx.APIErrorAuto(util.NewInvalidArgumentErrorf("invalid remote mode %q", form.Mode))
+		return
+	}
+
+	if form.MetadataTTL < 0 {
+		ctx.APIErrorAuto(util.NewInvalidArgumentErrorf("metadata ttl must not be negative"))
+		return
+	}
+
+	pr := &packages_model.PackageRemote{
+		OwnerID:     ctx.Package.Owner.ID,
+		Type:        packageType,
+		Mode:        mode,
+		URL:         form.URL,
+		Username:    form.Username,
+		MetadataTTL: form.MetadataTTL,
+	}
+	if err := pr.SetPassword(form.Password); err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	pr, err := packages_model.InsertRemote(ctx, pr)
+	if err != nil {
+		ctx.APIErrorAuto(err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, convert.ToPackageRemote(pr))
+}
+
+// EditPackageRemote edits the package remote of a package type
+func EditPackageRemote(ctx *context.APIContext) {
+	// swagger:operation PATCH /packages/{owner}/-/remotes/{type} package editPackageRemote
+	// ---
+	// summary: Edit the package remote of a package type
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the package remote
+	//   type: string
+	//   required: true
+	// - name: type
+	//   in: path
+	//   description: package type of the remote
+	//   type: string
+	//   enum: [container, maven, npm, pypi]
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/EditPackageRemoteOption"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/PackageRemote"
+	//   "400":
+	//     "$ref": "#/responses/error"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	pr := getPackageRemoteByParams(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	form := web.GetForm[*api.EditPackageRemoteOption](ctx)
+
+	upstreamChanged := false
+	if form.Mode != nil {
+		mode := packages_model.RemoteMode(*form.Mode)
+		if !mode.IsValid() {
+			ctx.APIErrorAuto(util.NewInvalidArgumentErrorf("invalid remote mode %q", *form.Mode))
+			return
+		}
+		pr.Mode = mode
+	}
+	if form.URL != nil && *form.URL != "" && *form.URL != pr.URL {
+		pr.URL = *form.URL
+		upstreamChanged = true
+	}
+	if form.Username != nil && *form.Username != pr.Username {
+		pr.Username = *form.Username
+		upstreamChanged = true
+	}
+	if form.Password != nil {
+		if err := pr.SetPassword(*form.Password); err != nil {
+			ctx.APIErrorInternal(err)
+			return
+		}
+		upstreamChanged = true
+	}
+	if form.MetadataTTL != nil {
+		if *form.MetadataTTL < 0 {
+			ctx.APIErrorAuto(util.NewInvalidArgumentErrorf("metadata ttl must not be negative"))
+			return
+		}
+		pr.MetadataTTL = *form.MetadataTTL
+	}
+
+	if err := remote_service.UpdateRemote(ctx, pr, upstreamChanged); err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, convert.ToPackageRemote(pr))
+}
+
+// DeletePackageRemote deletes the package remote of a package type
+func DeletePackageRemote(ctx *context.APIContext) {
+	// swagger:operation DELETE /packages/{owner}/-/remotes/{type} package deletePackageRemote
+	// ---
+	// summary: Delete the package remote of a package type
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the package remote
+	//   type: string
+	//   required: true
+	// - name: type
+	//   in: path
+	//   description: package type of the remote
+	//   type: string
+	//   enum: [container, maven, npm, pypi]
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	pr := getPackageRemoteByParams(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	if err := remote_service.DeleteRemote(ctx, pr); err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func getPackageRemoteByParams(ctx *context.APIContext) *packages_model.PackageRemote {
+	pr, err := packages_model.GetRemoteByOwnerAndType(ctx, ctx.Package.Owner.ID, packages_model.Type(ctx.PathParam("type")))
+	if err != nil {
+		ctx.APIErrorAuto(err)
+		return nil
+	}
+	return pr
+}
@@ -0,0 +1,155 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repo
+
+import (
+	"net/http"
+
+	actions_model "gitea.dev/models/actions"
+	api "gitea.dev/modules/structs"
+	"gitea.dev/routers/api/v1/shared"
+	actions_service "gitea.dev/services/actions"
+	"gitea.dev/services/context"
+	"gitea.dev/services/convert"
+)
+
+// ListActionCaches lists the actions caches of a repository
+func ListActionCaches(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/actions/caches repository listActionCaches
+	// ---
+	// summary: List the actions caches of a repository
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repository
+	//   type: string
+	//   required: true
+	// - name: key
+	//   in: query
+	//   description: key of the caches
+	//   type: string
+	// - name: ref
+	//   in: query
+	//   description: full git reference the caches are scoped to, e.g. refs/heads/main
+	//   type: string
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ActionCacheList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	shared.ListCaches(ctx, ctx.Repo.Repository.ID)
+}
+
+// DeleteActionCachesByKey deletes the actions caches of a repository with the given key
+func DeleteActionCachesByKey(ctx *context.APIContext) {
+	// swagger:operation DELETE /repos/{owner}/{repo}/actions/caches repository deleteActionCachesByKey
+	// ---
+	// summary: Delete the actions caches of a repository with the given key
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repository
+	//   type: string
+	//   required: true
+	// - name: key
+	//   in: query
+	//   description: key of the caches
+	//   type: string
+	//   required: true
+	// - name: ref
+	//   in: query
+	//   description: full git reference the caches are scoped to, e.g. refs/heads/main
+	//   type: string
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ActionCacheList"
+	//   "400":
+	//     "$ref": "#/responses/error"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	key := ctx.FormString("key")
+	if key == "" {
+		ctx.APIError(http.StatusBadRequest, "key is required")
+		return
+	}
+	caches, err := actions_service.DeleteCaches(ctx, actions_model.FindCachesOptions{
+		RepoID: ctx.Repo.Repository.ID,
+		Ref:    ctx.FormString("ref"),
+		Key:    key,
+	})
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	if len(caches) == 0 {
+		ctx.APIErrorNotFound()
+		return
+	}
+
+	res := &api.ActionCacheList{
+		Entries:    make([]*api.ActionCache, 0, len(caches)),
+		TotalCount: int64(len(caches)),
+	}
+	for _, cache := range caches {
+		res.Entries = append(res.Entries, convert.ToActionCache(cache))
+	}
+	ctx.JSON(http.StatusOK, res)
+}
+
+// DeleteActionCache deletes an actions cache of a repository
+func DeleteActionCache(ctx *context.APIContext) {
+	// swagger:operation DELETE /repos/{owner}/{repo}/actions/caches/{cache_id} repository deleteActionCache
+	// ---
+	// summary: Delete an actions cache of a repository
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repository
+	//   type: string
+	//   required: true
+	// - name: cache_id
+	//   in: path
+	//   description: id of the cache
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "204":
+	//     description: "No Content"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	shared.DeleteCache(ctx, ctx.Repo.Repository.ID, ctx.PathParamInt64("cache_id"))
+}
@@ -0,0 +1,1054 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repo
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	actions_model "gitea.dev/models/actions"
+	"gitea.dev/models/db"
+	"gitea.dev/models/organization"
+	secret_model "gitea.dev/models/secret"
+	user_model "gitea.dev/models/user"
+	api "gitea.dev/modules/structs"
+	"gitea.dev/modules/util"
+	"gitea.dev/modules/web"
+	"gitea.dev/routers/api/v1/utils"
+	actions_service "gitea.dev/services/actions"
+	"gitea.dev/services/context"
+	"gitea.dev/services/convert"
+	secret_service "gitea.dev/services/secrets"
+)
+
+func getRepoActionEnvironment(ctx *context.APIContext) *actions_model.ActionEnvironment {
+	env, err := actions_model.GetEnvironmentByRepoAndName(ctx, ctx.Repo.Repository.ID, ctx.PathParam("environment_name"))
+	if err != nil {
+		ctx.APIErrorAuto(err)
+		return nil
+	}
+	return env
+}
+
+// ListActionEnvironments list the deployment environments of a repository
+func ListActionEnvironments(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/environments repository repoListActionEnvironments
+	// ---
+	// summary: List a repository's deployment environments
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repository
+	//   type: string
+	//   required: true
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ActionEnvironmentList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	listOptions := utils.GetListOptions(ctx)
+	envs, count, err := db.FindAndCount[actions_model.ActionEnvironment](ctx, actions_model.FindEnvironmentsOptions{
+		ListOptions: listOptions,
+		RepoID:      ctx.Repo.Repository.ID,
+	})
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	apiEnvs := make([]*api.ActionEnvironment, 0, len(envs))
+	for _, env := range envs {
+		apiEnv, err := convert.ToActionEnvironment(ctx, env, ctx.Doer)
+		if err != nil {
+			ctx.APIErrorInternal(err)
+			return
+		}
+		apiEnvs = append(apiEnvs, apiEnv)
+	}
+	ctx.SetLinkHeader(count, listOptions.PageSize)
+	ctx.SetTotalCountHeader(count)
+	ctx.JSON(http.StatusOK, apiEnvs)
+}
+
+// GetActionEnvironment get a deployment environment of a repository
+func GetActionEnvironment(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/environments/{environment_name} repository repoGetActionEnvironment
+	// ---
+	// summary: Get a deployment environment
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repository
+	//   type: string
+	//   required: true
+	// - name: environment_name
+	//   in: path
+	//   description: name of the environment
+	//   type: string
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ActionEnvironment"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	env := getRepoActionEnvironment(ctx)
+	if ctx.Written() {
+		return
+	}
+	apiEnv, err := convert.ToActionEnvironment(ctx, env, ctx.Doer)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, apiEnv)
+}
+
+// CreateOrUpdateActionEnvironment create or update a deployment environment of a repository
+func CreateOrUpdateActionEnvironment(ctx *context.APIContext) {
+	// swagger:operation PUT /repos/{owner}/{repo}/environments/{environment_name} repository repoCreateOrUpdateActionEnvironment
+	// ---
+	// summary: Create or update a deployment environment and its protection rules
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repository
+	//   type: string
+	//   required: true
+	// - name: environment_name
+	//   in: path
+	//   description: name of the environment
+	//   type: string
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/CreateOrUpdateActionEnvironmentOption"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ActionEnvironment"
+	//   "201":
+	//     "$ref": "#/responses/ActionEnvironment"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	opt := web.GetForm[*api.CreateOrUpdateActionEnvironmentOption](ctx)
+	repo := ctx.Repo.Repository
+
+	env, err := actions_model.GetEnvironmentByRepoAndName(ctx, repo.ID, ctx.PathParam("environment_name"))
+	isNew := errors.Is(err, util.ErrNotExist)
+	if err != nil && !isNew {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	if isNew {
+		env = &actions_model.ActionEnvironment{RepoID: repo.ID, Name: ctx.PathParam("environment_name")}
+	}
+
+	policy, ok := actions_model.EnvironmentBranchPolicyFromString(util.IfZero(opt.DeploymentBranchPolicy, "all"))
+	if !ok {
+		ctx.APIError(http.StatusUnprocessableEntity, fmt.Sprintf("unknown deployment branch policy %q", opt.DeploymentBranchPolicy))
+		return
+	}
+	reviewerIDs, err := getEnvironmentReviewerIDs(ctx, opt.Reviewers)
+	if err != nil {
+		ctx.APIErrorAuto(err)
+		return
+	}
+	reviewerTeamIDs, err := getEnvironmentReviewerTeamIDs(ctx, opt.ReviewerTeams)
+	if err != nil {
+		ctx.APIErrorAuto(err)
+		return
+	}
+
+	env.ExternalURL = opt.ExternalURL
+	env.WaitTimer = opt.WaitTimer
+	env.ReviewerIDs = reviewerIDs
+	env.ReviewerTeamIDs = reviewerTeamIDs
+	env.PreventSelfReview = opt.PreventSelfReview
+	env.BranchPolicy = policy
+	env.BranchPatterns = util.Iif(policy == actions_model.EnvironmentBranchPolicyCustom, opt.BranchPatterns, nil)
+
+	if isNew {
+		err = actions_model.CreateEnvironment(ctx, env)
+	} else {
+		err = actions_model.UpdateEnvironment(ctx, env, "external_url", "wait_timer", "reviewer_ids", "reviewer_team_ids", "prevent_self_review", "branch_policy", "branch_patterns")
+	}
+	if err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) || errors.Is(err, util.ErrAlreadyExist) {
+			ctx.APIError(http.StatusUnprocessableEntity, err.Error())
+		} else {
+			ctx.APIErrorInternal(err)
+		}
+		return
+	}
+
+	apiEnv, err := convert.ToActionEnvironment(ctx, env, ctx.Doer)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	ctx.JSON(util.Iif(isNew, http.StatusCreated, http.StatusOK), apiEnv)
+}
+
+func getEnvironmentReviewerIDs(ctx *context.APIContext, names []string) ([]int64, error) {
+	ids := make([]int64, 0, len(names))
+	for _, name := range names {
+		u, err := user_model.GetUserByName(ctx, name)
+		if err != nil {
+			if user_model.IsErrUserNotExist(err) {
+				return nil, util.NewInvalidArgumentErrorf("reviewer %q does not exist", name)
+			}
+			return nil, err
+		}
+		ids = append(ids, u.ID)
+	}
+	return ids, nil
+}
+
+func getEnvironmentReviewerTeamIDs(ctx *context.APIContext, names []string) ([]int64, error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+	if !ctx.Repo.Owner.IsOrganization() {
+		return nil, util.NewInvalidArgumentErrorf("reviewer teams are only supported for repositories owned by an organization")
+	}
+	ids := make([]int64, 0, len(names))
+	for _, name := range names {
+		team, err := organization.GetTeam(ctx, ctx.Repo.Owner.ID, name)
+		if err != nil {
+			if organization.IsErrTeamNotExist(err) {
+				return nil, util.NewInvalidArgumentErrorf("reviewer team %q does not exist", name)
+			}
+			return nil, err
+		}
+		ids = append(ids, team.ID)
+	}
+	return ids, nil
+}
+
+// DeleteActionEnvironment delete a deployment environment of a repository
+func DeleteActionEnvironment(ctx *context.APIContext) {
+	// swagger:operation DELETE /repos/{owner}/{repo}/environments/{environment_name} repository repoDeleteActionEnvironment
+	// ---
+	// summary: Delete a deployment environment with its secrets, variables and deployment history
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repository
+	//   type: string
+	//   required: true
+	// - name: environment_name
+	//   in: path
+	//   description: name of the environment
+	//   type: string
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	env := getRepoActionEnvironment(ctx)
+	if ctx.Written() {
+		return
+	}
+	if err := actions_service.DeleteEnvironment(ctx, env); err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+// ListActionEnvironmentSecrets list the secrets of a deployment environment
+func ListActionEnvironmentSecrets(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/environments/{environment_name}/secrets repository repoListActionEnvironmentSecrets
+	// ---
+	// summary: List the secrets of a deployment environment
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repository
+	//   type: string
+	//   required: true
+	// - name: environment_name
+	//   in: path
+	//   description: name of the environment
+	//   type: string
+	//   required: true
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/SecretList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	env := getRepoActionEnvironment(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	listOptions := utils.GetListOptions(ctx)
+	secrets, count, err := db.FindAndCount[secret_model.Secret](ctx, secret_model.FindSecretsOptions{
+		ListOptions:   listOptions,
+		RepoID:        env.RepoID,
+		EnvironmentID: env.ID,
+	})
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	apiSecrets := make([]*api.Secret, len(secrets))
+	for k, v := range secrets {
+		apiSecrets[k] = &api.Secret{
+			Name:        v.Name,
+			Description: v.Description,
+			Created:     v.CreatedUnix.AsTime(),
+		}
+	}
+	ctx.SetLinkHeader(count, listOptions.PageSize)
+	ctx.SetTotalCountHeader(count)
+	ctx.JSON(http.StatusOK, apiSecrets)
+}
+
+// CreateOrUpdateActionEnvironmentSecret create or update a secret of a deployment environment
+func CreateOrUpdateActionEnvironmentSecret(ctx *context.APIContext) {
+	// swagger:operation PUT /repos/{owner}/{repo}/environments/{environment_name}/secrets/{secretname} repository updateRepoEnvironmentSecret
+	// ---
+	// summary: Create or Update a secret value in a deployment environment
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repository
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repository
+	//   type: string
+	//   required: true
+	// - name: environment_name
+	//   in: path
+	//   description: name of the environment
+	//   type: string
+	//   required: true
+	// - name: secretname
+	//   in: path
+	//   description: name of the secret
+	//   type: string
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/CreateOrUpdateSecretOption"
+	// responses:
+	//   "201":
+	//     description: response when creating a secret
+	//   "204":
+	//     description: response when updating a secret
+	//   "400":
+	//     "$ref": "#/responses/error"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	env := getRepoActionEnvironment(ctx)
+	if ctx.Written() {
+		return
+	}
+	opt := web.GetForm[*api.CreateOrUpdateSecretOption](ctx)
+
+	_, created, err := secret_service.CreateOrUpdateEnvironmentSecret(ctx, env.RepoID, env.ID, ctx.PathParam("secretname"), opt.Data, opt.Description)
+	if err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			ctx.APIError(http.StatusBadRequest, err.Error())
+		} else {
+			ctx.APIErrorInternal(err)
+		}
+		return
+	}
+
+	if created {
+		ctx.Status(http.StatusCreated)
+	} else {
+		ctx.Status(http.StatusNoContent)
+	}
+}
+
+// DeleteActionEnvironmentSecret delete a secret of a deployment environment
+func DeleteActionEnvironmentSecret(ctx *context.APIContext) {
+	// swagger:operation DELETE /repos/{owner}/{repo}/environments/{environment_name}/secrets/{secretname} repository deleteRepoEnvironmentSecret
+	// ---
+	// summary: Delete a secret in a deployment environment
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repository
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repository
+	//   type: string
+	//   required: true
+	// - name: environment_name
+	//   in: path
+	//   description: name of the environment
+	//   type: string
+	//   required: true
+	// - name: secretname
+	//   in: path
+	//   description: name of the secret
+	//   type: string
+	//   required: true
+	// responses:
+	//   "204":
+	//     description: delete one secret of the environment
+	//   "400":
+	//     "$ref": "#/responses/error"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	env := getRepoActionEnvironment(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	if err := secret_service.DeleteEnvironmentSecretByName(ctx, env.RepoID, env.ID, ctx.PathParam("secretname")); err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			ctx.APIError(http.StatusNotFound, err.Error())
+		} else {
+			ctx.APIErrorInternal(err)
+		}
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func toAPIActionVariable(v *actions_model.ActionVariable) *api.ActionVariable {
+	return &api.ActionVariable{
+		OwnerID:     v.OwnerID,
+		RepoID:      v.RepoID,
+		Name:        v.Name,
+		Data:        v.Data,
+		Description: v.Description,
+	}
+}
+
+// ListActionEnvironmentVariables list the variables of a deployment environment
+func ListActionEnvironmentVariables(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/environments/{environment_name}/variables repository getRepoEnvironmentVariablesList
+	// ---
+	// summary: Get the variables of a deployment environment
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repository
+	//   type: string
+	//   required: true
+	// - name: environment_name
+	//   in: path
+	//   description: name of the environment
+	//   type: string
+	//   required: true
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/VariableList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	env := getRepoActionEnvironment(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	listOptions := utils.GetListOptions(ctx)
+	vars, count, err := db.FindAndCount[actions_model.ActionVariable](ctx, &actions_model.FindVariablesOpts{
+		ListOptions:   listOptions,
+		RepoID:        env.RepoID,
+		EnvironmentID: env.ID,
+	})
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	variables := make([]*api.ActionVariable, len(vars))
+	for i, v := range vars {
+		variables[i] = toAPIActionVariable(v)
+	}
+	ctx.SetLinkHeader(count, listOptions.PageSize)
+	ctx.SetTotalCountHeader(count)
+	ctx.JSON(http.StatusOK, variables)
+}
+
+func getActionEnvironmentVariable(ctx *context.APIContext, env *actions_model.ActionEnvironment) *actions_model.ActionVariable {
+	v, err := actions_service.GetVariable(ctx, actions_model.FindVariablesOpts{
+		RepoID:        env.RepoID,
+		EnvironmentID: env.ID,
+		Name:          ctx.PathParam("variablename"),
+	})
+	if err != nil {
+		ctx.APIErrorAuto(err)
+		return nil
+	}
+	return v
+}
+
+// GetActionEnvironmentVariable get a variable of a deployment environment
+func GetActionEnvironmentVariable(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/environments/{environment_name}/variables/{variablename} repository getRepoEnvironmentVariable
+	// ---
+	// summary: Get a variable of a deployment environment
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repository
+	//   type: string
+	//   required: true
+	// - name: environment_name
+	//   in: path
+	//   description: name of the environment
+	//   type: string
+	//   required: true
+	// - name: variablename
+	//   in: path
+	//   description: name of the variable
+	//   type: string
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ActionVariable"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	env := getRepoActionEnvironment(ctx)
+	if ctx.Written() {
+		return
+	}
+	v := getActionEnvironmentVariable(ctx, env)
+	if ctx.Written() {
+		return
+	}
+	ctx.JSON(http.StatusOK, toAPIActionVariable(v))
+}
+
+// CreateActionEnvironmentVariable create a variable of a deployment environment
+func CreateActionEnvironmentVariable(ctx *context.APIContext) {
+	// swagger:operation POST /repos/{owner}/{repo}/environments/{environment_name}/variables/{variablename} repository createRepoEnvironmentVariable
+	// ---
+	// summary: Create a variable in a deployment environment
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repository
+	//   type: string
+	//   required: true
+	// - name: environment_name
+	//   in: path
+	//   description: name of the environment
+	//   type: string
+	//   required: true
+	// - name: variablename
+	//   in: path
+	//   description: name of the variable
+	//   type: string
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/CreateVariableOption"
+	// responses:
+	//   "201":
+	//     description: response when creating an environment variable
+	//   "400":
+	//     "$ref": "#/responses/error"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "409":
+	//     description: variable name already exists.
+
+	env := getRepoActionEnvironment(ctx)
+	if ctx.Written() {
+		return
+	}
+	opt := web.GetForm[*api.CreateVariableOption](ctx)
+	variableName := ctx.PathParam("variablename")
+
+	v, err := actions_service.GetVariable(ctx, actions_model.FindVariablesOpts{
+		RepoID:        env.RepoID,
+		EnvironmentID: env.ID,
+		Name:          variableName,
+	})
+	if err != nil && !errors.Is(err, util.ErrNotExist) {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	if v != nil && v.ID > 0 {
+		ctx.APIError(http.StatusConflict, "variable name already exists")
+		return
+	}
+
+	if _, err := actions_service.CreateEnvironmentVariable(ctx, env.RepoID, env.ID, variableName, opt.Value, opt.Description); err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			ctx.APIError(http.StatusBadRequest, err.Error())
+		} else {
+			ctx.APIErrorInternal(err)
+		}
+		return
+	}
+
+	ctx.Status(http.StatusCreated)
+}
+
+// UpdateActionEnvironmentVariable update a variable of a deployment environment
+func UpdateActionEnvironmentVariable(ctx *context.APIContext) {
+	// swagger:operation PUT /repos/{owner}/{repo}/environments/{environment_name}/variables/{variablename} repository updateRepoEnvironmentVariable
+	// ---
+	// summary: Update a variable in a deployment environment
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repository
+	//   type: string
+	//   required: true
+	// - name: environment_name
+	//   in: path
+	//   description: name of the environment
+	//   type: string
+	//   required: true
+	// - name: variablename
+	//   in: path
+	//   description: name of the variable
+	//   type: string
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/UpdateVariableOption"
+	// responses:
+	//   "204":
+	//     description: response when updating an environment variable
+	//   "400":
+	//     "$ref": "#/responses/error"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	env := getRepoActionEnvironment(ctx)
+	if ctx.Written() {
+		return
+	}
+	v := getActionEnvironmentVariable(ctx, env)
+	if ctx.Written() {
+		return
+	}
+	opt := web.GetForm[*api.UpdateVariableOption](ctx)
+
+	v.Name = util.IfZero(opt.Name, ctx.PathParam("variablename"))
+	v.Data = opt.Value
+	v.Description = opt.Description
+
+	if _, err := actions_service.UpdateVariableNameData(ctx, v); err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			ctx.APIError(http.StatusBadRequest, err.Error())
+		} else {
+			ctx.APIErrorInternal(err)
+		}
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// DeleteActionEnvironmentVariable delete a variable of a deployment environment
+func DeleteActionEnvironmentVariable(ctx *context.APIContext) {
+	// swagger:operation DELETE /repos/{owner}/{repo}/environments/{environment_name}/variables/{variablename} repository deleteRepoEnvironmentVariable
+	// ---
+	// summary: Delete a variable in a deployment environment
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repository
+	//   type: string
+	//   required: true
+	// - name: environment_name
+	//   in: path
+	//   description: name of the environment
+	//   type: string
+	//   required: true
+	// - name: variablename
+	//   in: path
+	//   description: name of the variable
+	//   type: string
+	//   required: true
+	// responses:
+	//   "204":
+	//     description: response when deleting an environment variable
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	env := getRepoActionEnvironment(ctx)
+	if ctx.Written() {
+		return
+	}
+	v := getActionEnvironmentVariable(ctx, env)
+	if ctx.Written() {
+		return
+	}
+	if err := actions_service.DeleteVariableByID(ctx, v.ID); err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// ListActionDeployments list the deployments of a repository
+func ListActionDeployments(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/deployments repository repoListActionDeployments
+	// ---
+	// summary: List the deployments of a repository, the most recent first
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repository
+	//   type: string
+	//   required: true
+	// - name: environment
+	//   in: query
+	//   description: only list the deployments to this environment
+	//   type: string
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ActionDeploymentList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	listOptions := utils.GetListOptions(ctx)
+	opts := actions_model.FindDeploymentsOptions{
+		ListOptions: listOptions,
+		RepoID:      ctx.Repo.Repository.ID,
+	}
+	if name := ctx.FormTrim("environment"); name != "" {
+		env, err := actions_model.GetEnvironmentByRepoAndName(ctx, ctx.Repo.Repository.ID, name)
+		if err != nil {
+			ctx.APIErrorAuto(err)
+			return
+		}
+		opts.EnvironmentID = env.ID
+	}
+
+	deployments, count, err := db.FindAndCount[actions_model.ActionDeployment](ctx, opts)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	if err := actions_model.DeploymentList(deployments).LoadAttributes(ctx); err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	apiDeployments := make([]*api.ActionDeployment, len(deployments))
+	for i, d := range deployments {
+		apiDeployments[i] = convert.ToActionDeployment(ctx, d, ctx.Doer)
+	}
+	ctx.SetLinkHeader(count, listOptions.PageSize)
+	ctx.SetTotalCountHeader(count)
+	ctx.JSON(http.StatusOK, apiDeployments)
+}
+
+func getWaitingDeploymentsOfRun(ctx *context.APIContext, run *actions_model.ActionRun) []*actions_model.ActionDeployment {
+	deployments, err := db.Find[actions_model.ActionDeployment](ctx, actions_model.FindDeploymentsOptions{
+		RepoID:   run.RepoID,
+		RunID:    run.ID,
+		Statuses: []actions_model.DeploymentStatus{actions_model.DeploymentStatusWaiting},
+	})
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return nil
+	}
+	return deployments
+}
+
+// GetPendingDeployments list the deployments of a run waiting for a reviewer
+func GetPendingDeployments(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/actions/runs/{run}/pending_deployments repository getWorkflowRunPendingDeployments
+	// ---
+	// summary: Get the deployments of a workflow run waiting for a reviewer
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repository
+	//   type: string
+	//   required: true
+	// - name: run
+	//   in: path
+	//   description: id of the run
+	//   type: integer
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/PendingDeploymentList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	run := getCurrentRepoActionRunByID(ctx)
+	if ctx.Written() {
+		return
+	}
+	deployments := getWaitingDeploymentsOfRun(ctx, run)
+	if ctx.Written() {
+		return
+	}
+
+	pending := make([]*api.PendingDeployment, 0, len(deployments))
+	for _, d := range deployments {
+		if d.ApprovedUnix != 0 {
+			continue // only the wait timer holds it back
+		}
+		if err := d.LoadJob(ctx); err != nil {
+			ctx.APIErrorInternal(err)
+			return
+		}
+		if d.Job.Status != actions_model.StatusBlocked {
+			continue // the job has been cancelled while it was waiting
+		}
+		canApprove, err := actions_service.CanReviewDeployment(ctx, ctx.Doer, d)
+		if err != nil {
+			ctx.APIErrorInternal(err)
+			return
+		}
+		p, err := convert.ToPendingDeployment(ctx, d, ctx.Doer, canApprove)
+		if err != nil {
+			ctx.APIErrorInternal(err)
+			return
+		}
+		pending = append(pending, p)
+	}
+	ctx.JSON(http.StatusOK, pending)
+}
+
+// ReviewPendingDeployments approve or reject the pending deployments of a run
+func ReviewPendingDeployments(ctx *context.APIContext) {
+	// swagger:operation POST /repos/{owner}/{repo}/actions/runs/{run}/pending_deployments repository reviewWorkflowRunPendingDeployments
+	// ---
+	// summary: Approve or reject the deployments of a workflow run waiting for a reviewer
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repository
+	//   type: string
+	//   required: true
+	// - name: run
+	//   in: path
+	//   description: id of the run
+	//   type: integer
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/ReviewPendingDeploymentsOption"
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	run := getCurrentRepoActionRunByID(ctx)
+	if ctx.Written() {
+		return
+	}
+	opt := web.GetForm[*api.ReviewPendingDeploymentsOption](ctx)
+	deployments := getWaitingDeploymentsOfRun(ctx, run)
+	if ctx.Written() {
+		return
+	}
+
+	toReview := make([]*actions_model.ActionDeployment, 0, len(deployments))
+	for _, d := range deployments {
+		if err := d.LoadEnvironment(ctx); err != nil {
+			ctx.APIErrorInternal(err)
+			return
+		}
+		if len(opt.Environments) > 0 && !containsEnvironmentName(opt.Environments, d.Environment.Name) {
+			continue
+		}
+		if len(opt.Environments) == 0 {
+			// without an explicit list, only review what the user is allowed to
+			canReview, err := actions_service.CanReviewDeployment(ctx, ctx.Doer, d)
+			if err != nil {
+				ctx.APIErrorInternal(err)
+				return
+			} else if !canReview {
+				continue
+			}
+		}
+		toReview = append(toReview, d)
+	}
+	if len(toReview) == 0 {
+		ctx.APIError(http.StatusUnprocessableEntity, "no pending deployment to review")
+		return
+	}
+
+	if err := actions_service.ReviewDeployments(ctx, ctx.Doer, run, toReview, opt.State == "approved", opt.Comment); err != nil {
+		ctx.APIErrorAuto(err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+func containsEnvironmentName(names []string, name string) bool {
+	for _, n := range names {
+		if strings.EqualFold(n, name) {
+			return true
+		}
+	}
+	return false
+}
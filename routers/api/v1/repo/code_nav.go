@@ -0,0 +1,257 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repo
+
+import (
+	"errors"
+	"net/http"
+
+	codenav_model "gitea.dev/models/codenav"
+	"gitea.dev/modules/codenav"
+	"gitea.dev/modules/setting"
+	"gitea.dev/modules/util"
+	codenav_service "gitea.dev/services/codenav"
+	"gitea.dev/services/context"
+	"gitea.dev/services/convert"
+)
+
+// codeNavCommitID resolves the commit of the path, it writes the error response if it can't be found
+func codeNavCommitID(ctx *context.APIContext) (string, bool) {
+	if !setting.CodeNavigation.Enabled {
+		ctx.APIErrorNotFound("code navigation is disabled")
+		return "", false
+	}
+	commit, err := ctx.Repo.GitRepo.GetCommit(ctx, ctx.PathParam("sha"))
+	if errors.Is(err, util.ErrNotExist) {
+		ctx.APIErrorNotFound(err.Error())
+		return "", false
+	} else if err != nil {
+		ctx.APIErrorInternal(err)
+		return "", false
+	}
+	return commit.ID.String(), true
+}
+
+// GetCodeNavSymbol returns the symbol at a position of a file
+func GetCodeNavSymbol(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/code-nav/{sha} repository repoGetCodeNavSymbol
+	// ---
+	// summary: Get the documentation, the definitions and the references of the symbol at a position of a file
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: sha
+	//   in: path
+	//   description: SHA of the commit
+	//   type: string
+	//   required: true
+	// - name: path
+	//   in: query
+	//   description: path of the file in the repository
+	//   type: string
+	//   required: true
+	// - name: line
+	//   in: query
+	//   description: line of the position, starting at 0
+	//   type: integer
+	//   required: true
+	// - name: character
+	//   in: query
+	//   description: character of the position in the line, starting at 0
+	//   type: integer
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/CodeNavSymbol"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	commitID, ok := codeNavCommitID(ctx)
+	if !ok {
+		return
+	}
+	nav, err := codenav_service.Navigate(ctx, ctx.Repo.Repository, ctx.Repo.GitRepo, commitID, ctx.FormString("path"), ctx.FormInt("line"), ctx.FormInt("character"))
+	if errors.Is(err, util.ErrNotExist) {
+		ctx.APIErrorNotFound(err.Error())
+		return
+	} else if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, convert.ToCodeNavSymbol(ctx, ctx.Repo.Repository, commitID, nav))
+}
+
+// GetCodeNavIndex returns the code navigation index of a commit
+func GetCodeNavIndex(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/code-nav/{sha}/index repository repoGetCodeNavIndex
+	// ---
+	// summary: Get the code navigation index of a commit
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: sha
+	//   in: path
+	//   description: SHA of the commit
+	//   type: string
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/CodeNavIndex"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	commitID, ok := codeNavCommitID(ctx)
+	if !ok {
+		return
+	}
+	index, err := codenav_model.GetIndex(ctx, ctx.Repo.Repository.ID, commitID)
+	if errors.Is(err, util.ErrNotExist) {
+		ctx.APIErrorNotFound(err.Error())
+		return
+	} else if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, convert.ToCodeNavIndex(index))
+}
+
+// UploadCodeNavIndex uploads the SCIP or LSIF index of a commit
+func UploadCodeNavIndex(ctx *context.APIContext) {
+	// swagger:operation PUT /repos/{owner}/{repo}/code-nav/{sha}/index repository repoUploadCodeNavIndex
+	// ---
+	// summary: Upload the SCIP or LSIF index of a commit, it replaces the previous index of the commit
+	// consumes:
+	// - application/octet-stream
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: sha
+	//   in: path
+	//   description: SHA of the commit
+	//   type: string
+	//   required: true
+	// - name: format
+	//   in: query
+	//   description: format of the index
+	//   type: string
+	//   enum: [scip, lsif]
+	//   required: true
+	// - name: body
+	//   in: body
+	//   description: the index, a SCIP protobuf message or an LSIF dump
+	//   schema:
+	//     type: string
+	//     format: binary
+	// responses:
+	//   "201":
+	//     "$ref": "#/responses/CodeNavIndex"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "413":
+	//     "$ref": "#/responses/error"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	commitID, ok := codeNavCommitID(ctx)
+	if !ok {
+		return
+	}
+	if ctx.Req.ContentLength > setting.CodeNavigation.MaxIndexSize {
+		ctx.APIError(http.StatusRequestEntityTooLarge, "the index is too large")
+		return
+	}
+	format := codenav.Format(ctx.FormString("format"))
+	if err := codenav_service.ImportIndex(ctx, ctx.Repo.Repository, commitID, format, ctx.Req.Body); err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			ctx.APIError(http.StatusUnprocessableEntity, err.Error())
+		} else {
+			ctx.APIErrorInternal(err)
+		}
+		return
+	}
+	index, err := codenav_model.GetIndex(ctx, ctx.Repo.Repository.ID, commitID)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, convert.ToCodeNavIndex(index))
+}
+
+// DeleteCodeNavIndex deletes the code navigation index of a commit
+func DeleteCodeNavIndex(ctx *context.APIContext) {
+	// swagger:operation DELETE /repos/{owner}/{repo}/code-nav/{sha}/index repository repoDeleteCodeNavIndex
+	// ---
+	// summary: Delete the code navigation index of a commit
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: sha
+	//   in: path
+	//   description: SHA of the commit
+	//   type: string
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	commitID, ok := codeNavCommitID(ctx)
+	if !ok {
+		return
+	}
+	index, err := codenav_model.GetIndex(ctx, ctx.Repo.Repository.ID, commitID)
+	if errors.Is(err, util.ErrNotExist) {
+		ctx.APIErrorNotFound(err.Error())
+		return
+	} else if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	if err := codenav_model.DeleteIndex(ctx, index); err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
@@ -0,0 +1,161 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repo
+
+import (
+	"errors"
+	"net/http"
+
+	"gitea.dev/modules/git"
+	"gitea.dev/modules/indexer"
+	code_indexer "gitea.dev/modules/indexer/code"
+	"gitea.dev/modules/indexer/code/gitgrep"
+	"gitea.dev/modules/setting"
+	api "gitea.dev/modules/structs"
+	"gitea.dev/modules/util"
+	"gitea.dev/routers/api/v1/utils"
+	"gitea.dev/services/context"
+)
+
+// SearchCode searches the code of a repository
+func SearchCode(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/code/search repository repoSearchCode
+	// ---
+	// summary: Search the code of a repository
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: q
+	//   in: query
+	//   description: keyword to search for
+	//   type: string
+	//   required: true
+	// - name: ref
+	//   in: query
+	//   description: full name of the branch or tag to search in, the default branch if empty. With the code indexer, only the indexed refs can be searched
+	//   type: string
+	// - name: language
+	//   in: query
+	//   description: only search the files of this language, only supported by the code indexer
+	//   type: string
+	// - name: mode
+	//   in: query
+	//   description: search mode, symbol is only supported by the trigram code indexer
+	//   type: string
+	//   enum: [exact, words, fuzzy, regexp, symbol]
+	// - name: path
+	//   in: query
+	//   description: regexp the paths of the files must match, only supported by the trigram code indexer
+	//   type: string
+	// - name: case_sensitive
+	//   in: query
+	//   description: match the case of the keyword, only supported by the trigram code indexer
+	//   type: boolean
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results, ignored when the code indexer is disabled
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/CodeSearchResults"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	keyword := ctx.FormTrim("q")
+	if keyword == "" {
+		ctx.APIError(http.StatusUnprocessableEntity, "q is required")
+		return
+	}
+	searchMode := indexer.SearchModeType(ctx.FormTrim("mode"))
+
+	defaultRef := git.RefNameFromBranch(ctx.Repo.Repository.DefaultBranch)
+	ref := defaultRef
+	if formRef := ctx.FormTrim("ref"); formRef != "" {
+		ref = git.RefName(formRef)
+		if !ref.IsBranch() && !ref.IsTag() {
+			ctx.APIError(http.StatusUnprocessableEntity, "ref must be the full name of a branch or a tag")
+			return
+		}
+	}
+
+	var (
+		total     int64
+		results   []*code_indexer.Result
+		languages []*code_indexer.SearchResultLanguages
+	)
+	if setting.Indexer.RepoIndexerEnabled {
+		listOptions := utils.GetListOptions(ctx)
+		var err error
+		total, results, languages, err = code_indexer.PerformSearch(ctx, &code_indexer.SearchOptions{
+			RepoIDs:       []int64{ctx.Repo.Repository.ID},
+			Keyword:       keyword,
+			Language:      ctx.FormTrim("language"),
+			Ref:           util.Iif(ref == defaultRef, "", ref.String()),
+			SearchMode:    searchMode,
+			Path:          ctx.FormTrim("path"),
+			CaseSensitive: ctx.FormBool("case_sensitive"),
+			Paginator:     &listOptions,
+		})
+		if errors.Is(err, util.ErrInvalidArgument) {
+			ctx.APIError(http.StatusUnprocessableEntity, err.Error())
+			return
+		} else if err != nil {
+			ctx.APIErrorInternal(err)
+			return
+		}
+	} else {
+		if !ctx.Repo.GitRepo.IsReferenceExist(ctx, ref.String()) {
+			ctx.APIErrorNotFound("ref not found")
+			return
+		}
+		var err error
+		results, total, err = gitgrep.PerformSearch(ctx, max(ctx.FormInt("page"), 1), ctx.Repo.Repository.ID, ctx.Repo.GitRepo, ref, keyword, searchMode)
+		if err != nil {
+			ctx.APIErrorInternal(err)
+			return
+		}
+	}
+
+	apiResults := &api.CodeSearchResults{
+		TotalCount: total,
+		Ref:        ref.String(),
+		Items:      make([]*api.CodeSearchResult, 0, len(results)),
+		Languages:  make([]*api.CodeSearchLanguage, 0, len(languages)),
+	}
+	for _, result := range results {
+		item := &api.CodeSearchResult{
+			Filename: result.Filename,
+			CommitID: result.CommitID,
+			Language: result.Language,
+			HTMLURL:  ctx.Repo.Repository.HTMLURL(ctx) + "/src/commit/" + util.PathEscapeSegments(result.CommitID) + "/" + util.PathEscapeSegments(result.Filename),
+			Lines:    make([]*api.CodeSearchResultLine, 0, len(result.Lines)),
+		}
+		for _, line := range result.Lines {
+			item.Lines = append(item.Lines, &api.CodeSearchResultLine{Number: line.Num, Content: line.RawContent})
+		}
+		apiResults.Items = append(apiResults.Items, item)
+	}
+	for _, language := range languages {
+		apiResults.Languages = append(apiResults.Languages, &api.CodeSearchLanguage{Language: language.Language, Count: language.Count})
+	}
+
+	ctx.SetTotalCountHeader(total)
+	ctx.JSON(http.StatusOK, apiResults)
+}
@@ -174,10 +174,20 @@ func TestHook(ctx *context.APIContext) {
 		return
 	}
 
+	if err := webhook_service.PrepareTestWebhook(ctx, hook, webhook_module.HookEventPush, testPushPayload(ctx, ref)); err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// testPushPayload returns the payload of a push of the loaded commit to ref
+func testPushPayload(ctx *context.APIContext, ref string) *api.PushPayload {
 	commit := convert.ToPayloadCommit(ctx, ctx.Repo.Repository, ctx.Repo.Commit)
 
 	commitID := ctx.Repo.Commit.ID.String()
-	if err := webhook_service.PrepareTestWebhook(ctx, hook, webhook_module.HookEventPush, &api.PushPayload{
+	return &api.PushPayload{
 		Ref:          ref,
 		Before:       commitID,
 		After:        commitID,
@@ -188,12 +198,65 @@ func TestHook(ctx *context.APIContext) {
 		Repo:         convert.ToRepo(ctx, ctx.Repo.Repository, access_model.Permission{AccessMode: perm.AccessModeNone}),
 		Pusher:       convert.ToUserWithAccessMode(ctx, ctx.Doer, perm.AccessModeNone),
 		Sender:       convert.ToUserWithAccessMode(ctx, ctx.Doer, perm.AccessModeNone),
-	}); err != nil {
-		ctx.APIErrorInternal(err)
+	}
+}
+
+// PreviewHook previews the request of a hook for a push event
+func PreviewHook(ctx *context.APIContext) {
+	// swagger:operation POST /repos/{owner}/{repo}/hooks/{id}/preview repository repoPreviewHook
+	// ---
+	// summary: Preview the request a webhook would deliver for a push event, without sending it
+	// description: The config of the body lets template webhooks be previewed with unsaved templates.
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the hook to preview
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: ref
+	//   in: query
+	//   description: "The name of the commit/branch/tag, indicates which commit will be loaded to the webhook payload."
+	//   type: string
+	//   required: false
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/PreviewHookOption"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/HookPreview"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	if ctx.Repo.Commit == nil {
+		ctx.APIError(http.StatusUnprocessableEntity, "repository has no commit to preview a push of")
 		return
 	}
 
-	ctx.Status(http.StatusNoContent)
+	ref := git.BranchPrefix + ctx.Repo.Repository.DefaultBranch
+	if r := ctx.FormTrim("ref"); r != "" {
+		ref = r
+	}
+
+	form := web.GetForm[*api.PreviewHookOption](ctx)
+	utils.PreviewRepoHook(ctx, form, ctx.PathParamInt64("id"), testPushPayload(ctx, ref))
 }
 
 // CreateHook create a hook for a repository
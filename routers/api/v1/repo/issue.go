@@ -290,6 +290,10 @@ func ListIssues(ctx *context.APIContext) {
 	//   in: query
 	//   description: comma separated list of milestone names or ids. It uses names and fall back to ids. Fetch only issues that have any of this milestones. Non existent milestones are discarded
 	//   type: string
+	// - name: issue_types
+	//   in: query
+	//   description: comma separated list of the names of issue types of the repository owner. Fetch only issues that have any of these types. Non existent types are discarded
+	//   type: string
 	// - name: since
 	//   in: query
 	//   description: Only show items updated after the given time. This is a timestamp in RFC 3339 format
@@ -314,6 +318,22 @@ func ListIssues(ctx *context.APIContext) {
 	//   in: query
 	//   description: Only show items in which the given user was mentioned
 	//   type: string
+	// - name: custom_field
+	//   in: query
+	//   description: Only show items having one of the given values for a custom field, formatted as "{field id}:{value}". The values of a field are combined with OR, the different fields with AND
+	//   type: array
+	//   collectionFormat: multi
+	//   items:
+	//     type: string
+	// - name: custom_field_sort
+	//   in: query
+	//   description: Sort the items by the values of a sortable custom field, the items without a value come last
+	//   type: integer
+	//   format: int64
+	// - name: custom_field_sort_desc
+	//   in: query
+	//   description: Sort the items by descending values of the custom_field_sort field
+	//   type: boolean
 	// - name: page
 	//   in: query
 	//   description: page number of results to return (1-based)
@@ -375,6 +395,15 @@ func ListIssues(ctx *context.APIContext) {
 		}
 	}
 
+	var typeIDs []int64
+	if typeNames := ctx.FormTrim("issue_types"); typeNames != "" {
+		typeIDs, err = issues_model.GetIssueTypeIDsByNames(ctx, ctx.Repo.Repository.OwnerID, strings.Split(typeNames, ","))
+		if err != nil {
+			ctx.APIErrorInternal(err)
+			return
+		}
+	}
+
 	listOptions := utils.GetListOptions(ctx)
 
 	isPull := common.ParseIssueFilterTypeIsPull(ctx.FormString("type"))
@@ -411,6 +440,12 @@ func ListIssues(ctx *context.APIContext) {
 		return
 	}
 
+	customFields, err := common.ParseIssueFilterCustomFields(ctx, ctx.Repo.Repository, ctx.FormStrings("custom_field"))
+	if err != nil {
+		ctx.APIErrorAuto(err)
+		return
+	}
+
 	searchOpt := &issue_indexer.SearchOptions{
 		Paginator: &listOptions,
 		Keyword:   keyword,
@@ -442,6 +477,7 @@ func ListIssues(ctx *context.APIContext) {
 	} else {
 		searchOpt.MilestoneIDs = mileIDs
 	}
+	searchOpt.TypeIDs = typeIDs
 
 	if createdByID > 0 {
 		searchOpt.PosterID = strconv.FormatInt(createdByID, 10)
@@ -452,6 +488,19 @@ func ListIssues(ctx *context.APIContext) {
 	if mentionedByID > 0 {
 		searchOpt.MentionID = optional.Some(mentionedByID)
 	}
+	searchOpt.CustomFields = issue_indexer.ToCustomFieldFilters(customFields)
+	if fieldID := ctx.FormInt64("custom_field_sort"); fieldID > 0 {
+		desc := ctx.FormBool("custom_field_sort_desc")
+		sortType, err := common.ParseIssueFilterCustomFieldSort(ctx, ctx.Repo.Repository, issues_model.CustomFieldSortType(fieldID, desc))
+		if err != nil {
+			ctx.APIErrorInternal(err)
+			return
+		} else if sortType == "" {
+			ctx.APIError(http.StatusUnprocessableEntity, "the items can't be sorted by this custom field")
+			return
+		}
+		searchOpt.SortBy = issue_indexer.SortByCustomField(fieldID, desc)
+	}
 
 	ids, total, err := issue_indexer.SearchIssues(ctx, searchOpt)
 	if err != nil {
@@ -588,6 +637,12 @@ func CreateIssue(ctx *context.APIContext) {
 	var err error
 	if ctx.Repo.Permission.CanWrite(unit.TypeIssues) {
 		issue.MilestoneID = form.Milestone
+		if form.Type != "" {
+			issue.TypeID = getIssueTypeIDByName(ctx, form.Type)
+			if ctx.Written() {
+				return
+			}
+		}
 		assigneeIDs, err = issues_model.MakeIDsFromAPIAssigneesToAdd(ctx, form.Assignee, form.Assignees)
 		if err != nil {
 			if user_model.IsErrUserNotExist(err) {
@@ -694,6 +749,8 @@ func EditIssue(ctx *context.APIContext) {
 	//     "$ref": "#/responses/notFound"
 	//   "412":
 	//     "$ref": "#/responses/error"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
 
 	form := web.GetForm[*api.EditIssueOption](ctx)
 	issue, err := issues_model.GetIssueByIndex(ctx, ctx.Repo.Repository.ID, ctx.PathParamInt64("index"))
@@ -825,6 +882,19 @@ func EditIssue(ctx *context.APIContext) {
 			return
 		}
 	}
+	if canWrite && form.Type != nil {
+		var typeID int64
+		if *form.Type != "" {
+			typeID = getIssueTypeIDByName(ctx, *form.Type)
+			if ctx.Written() {
+				return
+			}
+		}
+		if err = issue_service.ChangeIssueType(ctx, ctx.Doer, issue, typeID); err != nil {
+			ctx.APIErrorInternal(err)
+			return
+		}
+	}
 	if form.State != nil {
 		if issue.IsPull {
 			if err := issue.LoadPullRequest(ctx); err != nil {
@@ -336,7 +336,7 @@ func DeleteIssueCommentAttachment(ctx *context.APIContext) {
 		return
 	}
 
-	if err := repo_model.DeleteAttachment(ctx, attach, true); err != nil {
+	if err := attachment_service.DeleteAttachment(ctx, attach); err != nil {
 		ctx.APIErrorInternal(err)
 		return
 	}
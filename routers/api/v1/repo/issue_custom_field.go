@@ -0,0 +1,210 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repo
+
+import (
+	"errors"
+	"net/http"
+
+	issues_model "gitea.dev/models/issues"
+	api "gitea.dev/modules/structs"
+	"gitea.dev/modules/util"
+	"gitea.dev/modules/web"
+	"gitea.dev/services/context"
+	"gitea.dev/services/convert"
+	issue_service "gitea.dev/services/issue"
+)
+
+// ListIssueCustomFields lists the custom fields of an issue with their values
+func ListIssueCustomFields(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/issues/{index}/custom_fields issue issueListCustomFields
+	// ---
+	// summary: List the custom fields applying to an issue with their values
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: index
+	//   in: path
+	//   description: index of the issue
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/IssueCustomFieldValueList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	issue, err := issues_model.GetIssueByIndex(ctx, ctx.Repo.Repository.ID, ctx.PathParamInt64("index"))
+	if err != nil {
+		ctx.APIErrorAuto(err)
+		return
+	}
+
+	fields, err := issues_model.GetCustomFieldsForRepo(ctx, ctx.Repo.Repository)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	values, err := issues_model.GetIssueCustomFieldValues(ctx, issue.ID)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, convert.ToIssueCustomFieldValues(fields, values))
+}
+
+// prepareIssueCustomField returns the issue and the custom field of the request if the doer can change the field of the issue
+func prepareIssueCustomField(ctx *context.APIContext) (*issues_model.Issue, *issues_model.CustomField) {
+	issue, err := issues_model.GetIssueByIndex(ctx, ctx.Repo.Repository.ID, ctx.PathParamInt64("index"))
+	if err != nil {
+		ctx.APIErrorAuto(err)
+		return nil, nil
+	}
+
+	if !ctx.Repo.Permission.CanWriteIssuesOrPulls(issue.IsPull) {
+		ctx.APIError(http.StatusForbidden, "write permission is required")
+		return nil, nil
+	}
+
+	field, err := issues_model.GetCustomFieldByIDForRepo(ctx, ctx.Repo.Repository, ctx.PathParamInt64("id"))
+	if err != nil {
+		ctx.APIErrorAuto(err)
+		return nil, nil
+	}
+	return issue, field
+}
+
+func setIssueCustomFieldValues(ctx *context.APIContext, issue *issues_model.Issue, field *issues_model.CustomField, values []string) bool {
+	if err := issue_service.SetCustomFieldValues(ctx, ctx.Doer, issue, field, values); err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			ctx.APIError(http.StatusUnprocessableEntity, err.Error())
+		} else {
+			ctx.APIErrorInternal(err)
+		}
+		return false
+	}
+	return true
+}
+
+// SetIssueCustomField sets the values of a custom field of an issue
+func SetIssueCustomField(ctx *context.APIContext) {
+	// swagger:operation PUT /repos/{owner}/{repo}/issues/{index}/custom_fields/{id} issue issueSetCustomField
+	// ---
+	// summary: Set the values of a custom field of an issue, they replace the current values
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: index
+	//   in: path
+	//   description: index of the issue
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the custom field
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/SetIssueCustomFieldOption"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/IssueCustomFieldValue"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	form := web.GetForm[*api.SetIssueCustomFieldOption](ctx)
+	issue, field := prepareIssueCustomField(ctx)
+	if ctx.Written() {
+		return
+	}
+	if !setIssueCustomFieldValues(ctx, issue, field, form.Values) {
+		return
+	}
+
+	values, err := issues_model.GetIssueCustomFieldValues(ctx, issue.ID)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, convert.ToIssueCustomFieldValues([]*issues_model.CustomField{field}, values)[0])
+}
+
+// DeleteIssueCustomField clears the values of a custom field of an issue
+func DeleteIssueCustomField(ctx *context.APIContext) {
+	// swagger:operation DELETE /repos/{owner}/{repo}/issues/{index}/custom_fields/{id} issue issueDeleteCustomField
+	// ---
+	// summary: Clear the values of a custom field of an issue
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: index
+	//   in: path
+	//   description: index of the issue
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the custom field
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	issue, field := prepareIssueCustomField(ctx)
+	if ctx.Written() {
+		return
+	}
+	if !setIssueCustomFieldValues(ctx, issue, field, nil) {
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
@@ -0,0 +1,378 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repo
+
+import (
+	"errors"
+	"net/http"
+
+	issues_model "gitea.dev/models/issues"
+	access_model "gitea.dev/models/perm/access"
+	repo_model "gitea.dev/models/repo"
+	api "gitea.dev/modules/structs"
+	"gitea.dev/modules/util"
+	"gitea.dev/modules/web"
+	"gitea.dev/services/context"
+	"gitea.dev/services/convert"
+	issue_service "gitea.dev/services/issue"
+)
+
+// ListSubIssues lists the sub-issues of an issue
+func ListSubIssues(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/issues/{index}/sub_issues issue issueListSubIssues
+	// ---
+	// summary: List the sub-issues of an issue in their order, the sub-issues the user can't read are omitted
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: index
+	//   in: path
+	//   description: index of the issue
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/IssueList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	parent := getParamsIssue(ctx)
+	if ctx.Written() {
+		return
+	}
+	if !ctx.Repo.Permission.CanReadIssuesOrPulls(parent.IsPull) {
+		ctx.APIErrorNotFound()
+		return
+	}
+
+	subIssues, err := issues_model.GetSubIssues(ctx, parent.ID)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	if _, err := subIssues.LoadRepositories(ctx); err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	repoPerms := map[int64]*access_model.Permission{ctx.Repo.Repository.ID: &ctx.Repo.Permission}
+	readable := make(issues_model.IssueList, 0, len(subIssues))
+	for _, subIssue := range subIssues {
+		perm, ok := repoPerms[subIssue.RepoID]
+		if !ok {
+			perm = getPermissionForRepo(ctx, subIssue.Repo)
+			if ctx.Written() {
+				return
+			}
+			repoPerms[subIssue.RepoID] = perm
+		}
+		if perm.CanReadIssuesOrPulls(subIssue.IsPull) {
+			readable = append(readable, subIssue)
+		}
+	}
+	ctx.JSON(http.StatusOK, convert.ToAPIIssueList(ctx, ctx.Doer, readable))
+}
+
+// AddSubIssue adds a sub-issue to an issue
+func AddSubIssue(ctx *context.APIContext) {
+	// swagger:operation POST /repos/{owner}/{repo}/issues/{index}/sub_issues issue issueAddSubIssue
+	// ---
+	// summary: Make the issue in the form a sub-issue of the issue in the url, both must belong to repositories of the same owner
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: index
+	//   in: path
+	//   description: index of the issue
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/IssueMeta"
+	// responses:
+	//   "201":
+	//     "$ref": "#/responses/Issue"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "409":
+	//     "$ref": "#/responses/conflict"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+	//   "423":
+	//     "$ref": "#/responses/repoArchivedError"
+
+	parent, subIssue := getSubIssueParams(ctx, web.GetForm[*api.IssueMeta](ctx))
+	if ctx.Written() {
+		return
+	}
+
+	if err := issue_service.AddSubIssue(ctx, ctx.Doer, parent, subIssue); err != nil {
+		handleSubIssueError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, convert.ToAPIIssue(ctx, ctx.Doer, subIssue))
+}
+
+// RemoveSubIssue removes a sub-issue from an issue
+func RemoveSubIssue(ctx *context.APIContext) {
+	// swagger:operation DELETE /repos/{owner}/{repo}/issues/{index}/sub_issues issue issueRemoveSubIssue
+	// ---
+	// summary: Remove the issue in the form from the sub-issues of the issue in the url
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: index
+	//   in: path
+	//   description: index of the issue
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/IssueMeta"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/Issue"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "423":
+	//     "$ref": "#/responses/repoArchivedError"
+
+	parent, subIssue := getSubIssueParams(ctx, web.GetForm[*api.IssueMeta](ctx))
+	if ctx.Written() {
+		return
+	}
+
+	if err := issue_service.RemoveSubIssue(ctx, ctx.Doer, parent, subIssue); err != nil {
+		handleSubIssueError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, convert.ToAPIIssue(ctx, ctx.Doer, subIssue))
+}
+
+// ReorderSubIssues changes the order of the sub-issues of an issue
+func ReorderSubIssues(ctx *context.APIContext) {
+	// swagger:operation PATCH /repos/{owner}/{repo}/issues/{index}/sub_issues issue issueReorderSubIssues
+	// ---
+	// summary: Change the order of the sub-issues of an issue, all its sub-issues must be listed
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: index
+	//   in: path
+	//   description: index of the issue
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/ReorderSubIssuesOption"
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+	//   "423":
+	//     "$ref": "#/responses/repoArchivedError"
+
+	form := web.GetForm[*api.ReorderSubIssuesOption](ctx)
+	parent := getParamsIssue(ctx)
+	if ctx.Written() {
+		return
+	}
+	if !ctx.Repo.Permission.CanWriteIssuesOrPulls(parent.IsPull) {
+		ctx.APIErrorNotFound()
+		return
+	}
+
+	subIssueIDs := make([]int64, 0, len(form.SubIssues))
+	for i := range form.SubIssues {
+		subIssue := getSameOwnerFormIssue(ctx, &form.SubIssues[i])
+		if ctx.Written() {
+			return
+		}
+		subIssueIDs = append(subIssueIDs, subIssue.ID)
+	}
+
+	if err := issues_model.ReorderSubIssues(ctx, parent, subIssueIDs); err != nil {
+		handleSubIssueError(ctx, err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+// GetParentIssue gets the parent of an issue
+func GetParentIssue(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/issues/{index}/parent issue issueGetParentIssue
+	// ---
+	// summary: Get the parent of an issue
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: index
+	//   in: path
+	//   description: index of the issue
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/Issue"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	issue := getParamsIssue(ctx)
+	if ctx.Written() {
+		return
+	}
+	if !ctx.Repo.Permission.CanReadIssuesOrPulls(issue.IsPull) {
+		ctx.APIErrorNotFound()
+		return
+	}
+
+	parent, err := issues_model.GetParentIssue(ctx, issue.ID)
+	if err != nil {
+		ctx.APIErrorAuto(err)
+		return
+	}
+	if err := parent.LoadRepo(ctx); err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	perm := getPermissionForRepo(ctx, parent.Repo)
+	if ctx.Written() {
+		return
+	}
+	if !perm.CanReadIssuesOrPulls(parent.IsPull) {
+		ctx.APIErrorNotFound()
+		return
+	}
+	ctx.JSON(http.StatusOK, convert.ToAPIIssue(ctx, ctx.Doer, parent))
+}
+
+// getSameOwnerFormIssue returns the issue of the form, it must belong to a repository of the owner of the current repository
+func getSameOwnerFormIssue(ctx *context.APIContext, form *api.IssueMeta) *issues_model.Issue {
+	repo := ctx.Repo.Repository
+	if form.Owner != repo.OwnerName || form.Name != repo.Name {
+		var err error
+		repo, err = repo_model.GetRepositoryByOwnerAndName(ctx, form.Owner, form.Name)
+		if err != nil {
+			ctx.APIErrorAuto(err)
+			return nil
+		}
+		if repo.OwnerID != ctx.Repo.Repository.OwnerID {
+			ctx.APIError(http.StatusUnprocessableEntity, "sub-issues must belong to repositories of the same owner")
+			return nil
+		}
+	}
+
+	issue, err := issues_model.GetIssueByIndex(ctx, repo.ID, form.Index)
+	if err != nil {
+		ctx.APIErrorAuto(err)
+		return nil
+	}
+	issue.Repo = repo
+	return issue
+}
+
+// getSubIssueParams returns the parent issue of the url and the sub-issue of the form, the doer must be able to change both
+func getSubIssueParams(ctx *context.APIContext, form *api.IssueMeta) (parent, subIssue *issues_model.Issue) {
+	parent = getParamsIssue(ctx)
+	if ctx.Written() {
+		return nil, nil
+	}
+	if !ctx.Repo.Permission.CanWriteIssuesOrPulls(parent.IsPull) {
+		ctx.APIErrorNotFound()
+		return nil, nil
+	}
+
+	subIssue = getSameOwnerFormIssue(ctx, form)
+	if ctx.Written() {
+		return nil, nil
+	}
+	if subIssue.Repo.IsArchived {
+		ctx.APIError(http.StatusLocked, "the repository of the sub-issue is archived")
+		return nil, nil
+	}
+	perm := getPermissionForRepo(ctx, subIssue.Repo)
+	if ctx.Written() {
+		return nil, nil
+	}
+	if !perm.CanWriteIssuesOrPulls(subIssue.IsPull) {
+		ctx.APIErrorNotFound()
+		return nil, nil
+	}
+	return parent, subIssue
+}
+
+func handleSubIssueError(ctx *context.APIContext, err error) {
+	if errors.Is(err, util.ErrInvalidArgument) {
+		ctx.APIError(http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+	ctx.APIErrorAuto(err)
+}
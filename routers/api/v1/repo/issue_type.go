@@ -0,0 +1,72 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repo
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	issues_model "gitea.dev/models/issues"
+	"gitea.dev/modules/util"
+	"gitea.dev/routers/api/v1/shared"
+	"gitea.dev/services/context"
+)
+
+// ListIssueTypes lists the issue types that can be set on the issues of a repository
+func ListIssueTypes(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/issue_types issue issueListIssueTypes
+	// ---
+	// summary: List the issue types of the owner of a repository, they can be set on its issues
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: disabled
+	//   in: query
+	//   description: include the disabled issue types
+	//   type: boolean
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/IssueTypeList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	shared.ListIssueTypes(ctx, ctx.Repo.Repository.OwnerID)
+}
+
+// getIssueTypeIDByName returns the ID of an enabled issue type of the repository owner
+func getIssueTypeIDByName(ctx *context.APIContext, name string) int64 {
+	t, err := issues_model.GetIssueTypeByName(ctx, ctx.Repo.Repository.OwnerID, name)
+	if err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			ctx.APIError(http.StatusUnprocessableEntity, fmt.Sprintf("issue type %q does not exist", name))
+		} else {
+			ctx.APIErrorInternal(err)
+		}
+		return 0
+	}
+	if t.IsDisabled {
+		ctx.APIError(http.StatusUnprocessableEntity, fmt.Sprintf("issue type %q is disabled", t.Name))
+		return 0
+	}
+	return t.ID
+}
@@ -119,6 +119,11 @@ func Migrate(ctx *context.APIContext) {
 		return
 	}
 
+	if form.Sync && (form.Mirror || gitServiceType == api.PlainGitService) {
+		ctx.APIError(http.StatusUnprocessableEntity, "sync is only supported for the migrations of the issues and pull requests of a git service")
+		return
+	}
+
 	form.LFS = form.LFS && setting.LFS.StartServer
 
 	if form.LFS && len(form.LFSEndpoint) > 0 {
@@ -155,6 +160,7 @@ func Migrate(ctx *context.APIContext) {
 		Releases:       form.Releases,
 		GitServiceType: gitServiceType,
 		MirrorInterval: form.MirrorInterval,
+		Sync:           form.Sync,
 	}
 	if opts.Mirror {
 		opts.Issues = false
@@ -417,3 +417,86 @@ func HandleRemoteAddressError(ctx *context.APIContext, err error) {
 		return
 	}
 }
+
+// GetMigrationSync gets the sync of a migrated repository with its source
+func GetMigrationSync(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/migration-sync repository repoGetMigrationSync
+	// ---
+	// summary: Get the sync of a migrated repository with its source
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/MigrationSync"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	s, err := repo_model.GetMigrationSyncByRepoID(ctx, ctx.Repo.Repository.ID)
+	if err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			ctx.APIErrorNotFound("the repository is not synced with its source")
+			return
+		}
+		ctx.APIErrorInternal(err)
+		return
+	}
+	s.Repo = ctx.Repo.Repository
+
+	ctx.JSON(http.StatusOK, convert.ToMigrationSync(s))
+}
+
+// StopMigrationSync stops the sync of a migrated repository with its source
+func StopMigrationSync(ctx *context.APIContext) {
+	// swagger:operation DELETE /repos/{owner}/{repo}/migration-sync repository repoStopMigrationSync
+	// ---
+	// summary: Stop the sync of a migrated repository with its source, its credentials are deleted
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	if _, err := repo_model.GetMigrationSyncByRepoID(ctx, ctx.Repo.Repository.ID); err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			ctx.APIErrorNotFound("the repository is not synced with its source")
+			return
+		}
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	if err := migrations.StopMigrationSync(ctx, ctx.Repo.Repository); err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
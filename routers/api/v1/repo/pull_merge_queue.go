@@ -0,0 +1,329 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repo
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"gitea.dev/models/db"
+	issues_model "gitea.dev/models/issues"
+	pull_model "gitea.dev/models/pull"
+	repo_model "gitea.dev/models/repo"
+	api "gitea.dev/modules/structs"
+	"gitea.dev/modules/util"
+	"gitea.dev/modules/web"
+	"gitea.dev/routers/api/v1/utils"
+	asymkey_service "gitea.dev/services/asymkey"
+	"gitea.dev/services/context"
+	"gitea.dev/services/convert"
+	"gitea.dev/services/forms"
+	"gitea.dev/services/mergequeue"
+	pull_service "gitea.dev/services/pull"
+)
+
+// ListMergeQueue lists the pull requests in the merge queues of a repository
+func ListMergeQueue(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/merge_queue repository repoListMergeQueue
+	// ---
+	// summary: List the pull requests in the merge queues of a repository, in the order they are merged
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: branch
+	//   in: query
+	//   description: only list the merge queue of this base branch
+	//   type: string
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/MergeQueueEntryList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	opts := pull_model.FindMergeQueueEntriesOptions{
+		ListOptions: utils.GetListOptions(ctx),
+		RepoID:      ctx.Repo.Repository.ID,
+		BaseBranch:  ctx.FormTrim("branch"),
+	}
+	entries, total, err := db.FindAndCount[pull_model.MergeQueueEntry](ctx, opts)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	res := make([]*api.MergeQueueEntry, 0, len(entries))
+	for _, entry := range entries {
+		pr, err := issues_model.GetPullRequestByID(ctx, entry.PullID)
+		if err != nil {
+			ctx.APIErrorInternal(err)
+			return
+		}
+		if err := entry.LoadDoer(ctx); err != nil {
+			ctx.APIErrorInternal(err)
+			return
+		}
+		res = append(res, convert.ToMergeQueueEntry(ctx, entry, pr, ctx.Doer))
+	}
+	ctx.SetLinkHeader(total, opts.PageSize)
+	ctx.SetTotalCountHeader(total)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func getPullRequestForMergeQueue(ctx *context.APIContext) *issues_model.PullRequest {
+	pr, err := issues_model.GetPullRequestByIndex(ctx, ctx.Repo.Repository.ID, ctx.PathParamInt64("index"))
+	if err != nil {
+		if issues_model.IsErrPullRequestNotExist(err) {
+			ctx.APIErrorNotFound()
+		} else {
+			ctx.APIErrorInternal(err)
+		}
+		return nil
+	}
+	return pr
+}
+
+// GetPullRequestMergeQueueEntry gets the merge queue entry of a pull request
+func GetPullRequestMergeQueueEntry(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/pulls/{index}/merge_queue repository repoGetPullRequestMergeQueueEntry
+	// ---
+	// summary: Get the merge queue entry of a pull request
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: index
+	//   in: path
+	//   description: index of the pull request
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/MergeQueueEntry"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	pr := getPullRequestForMergeQueue(ctx)
+	if ctx.Written() {
+		return
+	}
+	entry, err := pull_model.GetMergeQueueEntryByPullID(ctx, pr.ID)
+	if err != nil {
+		ctx.APIErrorAuto(err)
+		return
+	}
+	if err := entry.LoadDoer(ctx); err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, convert.ToMergeQueueEntry(ctx, entry, pr, ctx.Doer))
+}
+
+// AddPullRequestToMergeQueue adds a pull request to the merge queue of its base branch
+func AddPullRequestToMergeQueue(ctx *context.APIContext) {
+	// swagger:operation POST /repos/{owner}/{repo}/pulls/{index}/merge_queue repository repoAddPullRequestToMergeQueue
+	// ---
+	// summary: Add a pull request to the merge queue of its base branch
+	// description: The pull request is merged once the status checks of its combination with the base branch
+	//   and the pull requests queued before it pass. Only the merge style, the messages and the branch deletion
+	//   of the merge options are used.
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: index
+	//   in: path
+	//   description: index of the pull request to queue
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     $ref: "#/definitions/MergePullRequestOption"
+	// responses:
+	//   "201":
+	//     "$ref": "#/responses/MergeQueueEntry"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "405":
+	//     "$ref": "#/responses/error"
+	//   "409":
+	//     "$ref": "#/responses/error"
+	//   "423":
+	//     "$ref": "#/responses/repoArchivedError"
+
+	form := web.GetForm[*forms.MergePullRequestForm](ctx)
+	pr := getPullRequestForMergeQueue(ctx)
+	if ctx.Written() {
+		return
+	}
+	if err := pr.LoadIssue(ctx); err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	pr.Issue.Repo = ctx.Repo.Repository
+
+	if len(form.Do) == 0 {
+		form.Do = string(repo_model.MergeStyleMerge)
+	}
+	style := repo_model.MergeStyle(form.Do)
+
+	message := strings.TrimSpace(form.MergeTitleField)
+	if len(message) == 0 {
+		var err error
+		message, _, err = pull_service.GetDefaultMergeMessage(ctx, ctx.Repo.GitRepo, pr, style)
+		if err != nil {
+			ctx.APIErrorInternal(err)
+			return
+		}
+	}
+	form.MergeMessageField = strings.TrimSpace(form.MergeMessageField)
+	if len(form.MergeMessageField) > 0 {
+		message += "\n\n" + form.MergeMessageField
+	}
+
+	deleteBranchAfterMerge, err := pull_service.ShouldDeleteBranchAfterMerge(ctx, form.DeleteBranchAfterMerge, ctx.Repo.Repository, pr)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	if err := mergequeue.Enqueue(ctx, ctx.Doer, pr, style, message, deleteBranchAfterMerge); err != nil {
+		switch {
+		case errors.Is(err, pull_service.ErrIsClosed):
+			ctx.APIErrorNotFound()
+		case errors.Is(err, pull_service.ErrNoPermissionToMerge):
+			ctx.APIError(http.StatusMethodNotAllowed, "User not allowed to merge PR")
+		case errors.Is(err, pull_service.ErrHasMerged):
+			ctx.APIError(http.StatusMethodNotAllowed, "The PR is already merged")
+		case errors.Is(err, pull_service.ErrIsWorkInProgress):
+			ctx.APIError(http.StatusMethodNotAllowed, "Work in progress PRs cannot be merged")
+		case errors.Is(err, pull_service.ErrNotMergeableState), errors.Is(err, pull_service.ErrIsChecking):
+			ctx.APIError(http.StatusMethodNotAllowed, "Please try again later")
+		case errors.Is(err, pull_service.ErrNotReadyToMerge),
+			errors.Is(err, pull_service.ErrHeadCommitsNotAllVerified),
+			errors.Is(err, pull_service.ErrRulesetViolated),
+			errors.Is(err, pull_service.ErrDependenciesLeft),
+			asymkey_service.IsErrWontSign(err):
+			ctx.APIError(http.StatusMethodNotAllowed, err.Error())
+		case pull_service.IsErrInvalidMergeStyle(err):
+			ctx.APIError(http.StatusMethodNotAllowed, fmt.Sprintf("%s is not allowed an allowed merge style for this repository", style))
+		case errors.Is(err, util.ErrAlreadyExist):
+			ctx.APIError(http.StatusConflict, err.Error())
+		default:
+			ctx.APIErrorInternal(err)
+		}
+		return
+	}
+
+	entry, err := pull_model.GetMergeQueueEntryByPullID(ctx, pr.ID)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	entry.Doer = ctx.Doer
+	ctx.JSON(http.StatusCreated, convert.ToMergeQueueEntry(ctx, entry, pr, ctx.Doer))
+}
+
+// RemovePullRequestFromMergeQueue removes a pull request from the merge queue of its base branch
+func RemovePullRequestFromMergeQueue(ctx *context.APIContext) {
+	// swagger:operation DELETE /repos/{owner}/{repo}/pulls/{index}/merge_queue repository repoRemovePullRequestFromMergeQueue
+	// ---
+	// summary: Remove a pull request from the merge queue of its base branch
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: index
+	//   in: path
+	//   description: index of the pull request to remove
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "423":
+	//     "$ref": "#/responses/repoArchivedError"
+
+	pr := getPullRequestForMergeQueue(ctx)
+	if ctx.Written() {
+		return
+	}
+	entry, err := pull_model.GetMergeQueueEntryByPullID(ctx, pr.ID)
+	if err != nil {
+		ctx.APIErrorAuto(err)
+		return
+	}
+
+	if ctx.Doer.ID != entry.DoerID {
+		allowed, err := pull_service.IsUserAllowedToMerge(ctx, pr, ctx.Repo.Permission, ctx.Doer)
+		if err != nil {
+			ctx.APIErrorInternal(err)
+			return
+		}
+		if !allowed {
+			ctx.APIError(http.StatusForbidden, "user has no permission to remove the pull request from the merge queue")
+			return
+		}
+	}
+
+	if err := mergequeue.Dequeue(ctx, ctx.Doer, pr); err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
@@ -0,0 +1,196 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repo
+
+import (
+	"gitea.dev/routers/api/v1/shared"
+	"gitea.dev/services/context"
+)
+
+// ListRulesets lists the rulesets of a repository
+func ListRulesets(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/rulesets repository repoListRulesets
+	// ---
+	// summary: List the rulesets of a repository
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: includes_parents
+	//   in: query
+	//   description: include the rulesets of the organization owning the repository
+	//   type: boolean
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/RulesetList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	shared.ListRulesets(ctx, ctx.Repo.Repository.OwnerID, ctx.Repo.Repository.ID, ctx.FormBool("includes_parents"))
+}
+
+// GetRuleset gets a ruleset of a repository
+func GetRuleset(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/rulesets/{id} repository repoGetRuleset
+	// ---
+	// summary: Get a ruleset of a repository
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the ruleset
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/Ruleset"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	shared.GetRuleset(ctx, 0, ctx.Repo.Repository.ID)
+}
+
+// CreateRuleset creates a ruleset for a repository
+func CreateRuleset(ctx *context.APIContext) {
+	// swagger:operation POST /repos/{owner}/{repo}/rulesets repository repoCreateRuleset
+	// ---
+	// summary: Create a ruleset for a repository
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/CreateRulesetOption"
+	// responses:
+	//   "201":
+	//     "$ref": "#/responses/Ruleset"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+	//   "423":
+	//     "$ref": "#/responses/repoArchivedError"
+
+	shared.CreateRuleset(ctx, 0, ctx.Repo.Repository.ID)
+}
+
+// EditRuleset edits a ruleset of a repository
+func EditRuleset(ctx *context.APIContext) {
+	// swagger:operation PATCH /repos/{owner}/{repo}/rulesets/{id} repository repoEditRuleset
+	// ---
+	// summary: Edit a ruleset of a repository. Only fields that are set will be changed
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the ruleset
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/EditRulesetOption"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/Ruleset"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+	//   "423":
+	//     "$ref": "#/responses/repoArchivedError"
+
+	shared.EditRuleset(ctx, 0, ctx.Repo.Repository.ID)
+}
+
+// DeleteRuleset deletes a ruleset of a repository
+func DeleteRuleset(ctx *context.APIContext) {
+	// swagger:operation DELETE /repos/{owner}/{repo}/rulesets/{id} repository repoDeleteRuleset
+	// ---
+	// summary: Delete a ruleset of a repository
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the ruleset
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	shared.DeleteRuleset(ctx, 0, ctx.Repo.Repository.ID)
+}
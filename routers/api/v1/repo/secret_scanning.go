@@ -0,0 +1,313 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repo
+
+import (
+	"errors"
+	"net/http"
+
+	"gitea.dev/models/db"
+	secretscan_model "gitea.dev/models/secretscan"
+	api "gitea.dev/modules/structs"
+	"gitea.dev/modules/util"
+	"gitea.dev/modules/web"
+	"gitea.dev/routers/api/v1/utils"
+	"gitea.dev/services/context"
+	"gitea.dev/services/convert"
+	secretscan_service "gitea.dev/services/secretscan"
+)
+
+// ListSecretScanningAlerts lists the secret scanning alerts of a repository
+func ListSecretScanningAlerts(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/secret-scanning/alerts repository repoListSecretScanningAlerts
+	// ---
+	// summary: List the secret scanning alerts of a repository
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: state
+	//   in: query
+	//   description: only the alerts in this state
+	//   type: string
+	//   enum: [open, resolved]
+	// - name: pattern_id
+	//   in: query
+	//   description: only the alerts raised by this pattern
+	//   type: string
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/SecretScanningAlertList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	opts := secretscan_model.FindAlertsOptions{
+		ListOptions: utils.GetListOptions(ctx),
+		RepoID:      ctx.Repo.Repository.ID,
+		State:       secretscan_model.AlertState(ctx.FormString("state")),
+		PatternID:   ctx.FormString("pattern_id"),
+	}
+	if opts.State != "" && !opts.State.IsValid() {
+		ctx.APIError(http.StatusUnprocessableEntity, "invalid state")
+		return
+	}
+
+	alerts, total, err := db.FindAndCount[secretscan_model.Alert](ctx, opts)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	res := make([]*api.SecretScanningAlert, 0, len(alerts))
+	for _, a := range alerts {
+		if err := a.LoadAttributes(ctx); err != nil {
+			ctx.APIErrorInternal(err)
+			return
+		}
+		res = append(res, convert.ToSecretScanningAlert(ctx, a, ctx.Doer))
+	}
+	ctx.SetLinkHeader(total, opts.PageSize)
+	ctx.SetTotalCountHeader(total)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func getSecretScanningAlert(ctx *context.APIContext) *secretscan_model.Alert {
+	a, err := secretscan_model.GetAlertByID(ctx, ctx.Repo.Repository.ID, ctx.PathParamInt64("id"))
+	if err != nil {
+		ctx.APIErrorAuto(err)
+		return nil
+	}
+	if err := a.LoadAttributes(ctx); err != nil {
+		ctx.APIErrorInternal(err)
+		return nil
+	}
+	return a
+}
+
+// GetSecretScanningAlert gets a secret scanning alert of a repository
+func GetSecretScanningAlert(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/secret-scanning/alerts/{id} repository repoGetSecretScanningAlert
+	// ---
+	// summary: Get a secret scanning alert of a repository
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the alert
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/SecretScanningAlert"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	a := getSecretScanningAlert(ctx)
+	if ctx.Written() {
+		return
+	}
+	ctx.JSON(http.StatusOK, convert.ToSecretScanningAlert(ctx, a, ctx.Doer))
+}
+
+// EditSecretScanningAlert resolves or reopens a secret scanning alert of a repository
+func EditSecretScanningAlert(ctx *context.APIContext) {
+	// swagger:operation PATCH /repos/{owner}/{repo}/secret-scanning/alerts/{id} repository repoEditSecretScanningAlert
+	// ---
+	// summary: Resolve or reopen a secret scanning alert of a repository
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the alert
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/EditSecretScanningAlertOption"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/SecretScanningAlert"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	form := web.GetForm[*api.EditSecretScanningAlertOption](ctx)
+	a := getSecretScanningAlert(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	var err error
+	switch secretscan_model.AlertState(form.State) {
+	case secretscan_model.AlertStateResolved:
+		err = secretscan_model.ResolveAlert(ctx, a, ctx.Doer.ID, secretscan_model.AlertResolution(form.Resolution), form.ResolutionComment)
+	case secretscan_model.AlertStateOpen:
+		err = secretscan_model.ReopenAlert(ctx, a, ctx.Doer.ID, form.ResolutionComment)
+	default:
+		err = util.NewInvalidArgumentErrorf("invalid state %q", form.State)
+	}
+	if errors.Is(err, util.ErrInvalidArgument) {
+		ctx.APIError(http.StatusUnprocessableEntity, err.Error())
+		return
+	} else if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	if err := a.LoadAttributes(ctx); err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, convert.ToSecretScanningAlert(ctx, a, ctx.Doer))
+}
+
+// ListSecretScanningAlertEvents lists the audit trail of a secret scanning alert of a repository
+func ListSecretScanningAlertEvents(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/secret-scanning/alerts/{id}/events repository repoListSecretScanningAlertEvents
+	// ---
+	// summary: List the changes of a secret scanning alert of a repository, from the oldest
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the alert
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/SecretScanningAlertEventList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	a, err := secretscan_model.GetAlertByID(ctx, ctx.Repo.Repository.ID, ctx.PathParamInt64("id"))
+	if err != nil {
+		ctx.APIErrorAuto(err)
+		return
+	}
+
+	opts := secretscan_model.FindAlertEventsOptions{
+		ListOptions: utils.GetListOptions(ctx),
+		AlertID:     a.ID,
+	}
+	events, total, err := db.FindAndCount[secretscan_model.AlertEvent](ctx, opts)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	if err := secretscan_model.AlertEventList(events).LoadDoers(ctx); err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	res := make([]*api.SecretScanningAlertEvent, 0, len(events))
+	for _, e := range events {
+		res = append(res, convert.ToSecretScanningAlertEvent(ctx, e, ctx.Doer))
+	}
+	ctx.SetLinkHeader(total, opts.PageSize)
+	ctx.SetTotalCountHeader(total)
+	ctx.JSON(http.StatusOK, res)
+}
+
+// ScanRepositorySecrets schedules a secret scanning of the whole history of a repository
+func ScanRepositorySecrets(ctx *context.APIContext) {
+	// swagger:operation POST /repos/{owner}/{repo}/secret-scanning/scan repository repoScanSecrets
+	// ---
+	// summary: Scan the whole history of a repository for secrets, the secrets found are reported as alerts
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// responses:
+	//   "202":
+	//     "$ref": "#/responses/empty"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	if err := secretscan_service.AddRepoToQueue(ctx.Repo.Repository); errors.Is(err, util.ErrInvalidArgument) {
+		ctx.APIError(http.StatusUnprocessableEntity, err.Error())
+		return
+	} else if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	ctx.Status(http.StatusAccepted)
+}
@@ -19,6 +19,7 @@ import (
 	"gitea.dev/modules/util"
 	"gitea.dev/modules/webhook"
 	"gitea.dev/routers/api/v1/utils"
+	actions_service "gitea.dev/services/actions"
 	"gitea.dev/services/context"
 	"gitea.dev/services/convert"
 
@@ -245,3 +246,53 @@ func ListRuns(ctx *context.APIContext, ownerID, repoID int64, workflowID string)
 	ctx.SetTotalCountHeader(total)
 	ctx.JSON(http.StatusOK, &res)
 }
+
+// ListCaches lists the actions caches for api route validated repoID
+// repoID == 0 means all caches
+// Access rights are checked at the API route level
+func ListCaches(ctx *context.APIContext, repoID int64) {
+	listOptions := utils.GetListOptions(ctx)
+	opts := actions_model.FindCachesOptions{
+		ListOptions: listOptions,
+		RepoID:      repoID,
+		Ref:         ctx.FormString("ref"),
+		Key:         ctx.FormString("key"),
+		Status:      actions_model.CacheStatusComplete,
+	}
+
+	caches, total, err := db.FindAndCount[actions_model.ActionCache](ctx, opts)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	res := &api.ActionCacheList{
+		Entries:    make([]*api.ActionCache, 0, len(caches)),
+		TotalCount: total,
+	}
+	for _, cache := range caches {
+		res.Entries = append(res.Entries, convert.ToActionCache(cache))
+	}
+	ctx.SetLinkHeader(total, listOptions.PageSize)
+	ctx.SetTotalCountHeader(total)
+	ctx.JSON(http.StatusOK, res)
+}
+
+// DeleteCache deletes an actions cache for api route validated repoID
+// repoID == 0 means the cache may belong to any repository
+// Access rights are checked at the API route level
+func DeleteCache(ctx *context.APIContext, repoID, cacheID int64) {
+	caches, err := db.Find[actions_model.ActionCache](ctx, actions_model.FindCachesOptions{ID: cacheID, RepoID: repoID})
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	} else if len(caches) == 0 {
+		ctx.APIErrorNotFound()
+		return
+	}
+	if err := actions_service.DeleteCache(ctx, caches[0]); err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
@@ -0,0 +1,57 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package shared
+
+import (
+	"net/http"
+
+	audit_model "gitea.dev/models/audit"
+	"gitea.dev/models/db"
+	user_model "gitea.dev/models/user"
+	api "gitea.dev/modules/structs"
+	"gitea.dev/routers/api/v1/utils"
+	"gitea.dev/services/context"
+	"gitea.dev/services/convert"
+)
+
+// ListAuditEvents lists the events of the audit log of an owner or a repository, of the instance when both are 0.
+// The addresses of the actors are only shown to the site administrators.
+func ListAuditEvents(ctx *context.APIContext, ownerID, repoID int64) {
+	before, since, err := context.GetQueryBeforeSince(ctx.Base)
+	if err != nil {
+		ctx.APIError(http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+
+	opts := audit_model.FindEventsOptions{
+		ListOptions: utils.GetListOptions(ctx),
+		OwnerID:     ownerID,
+		RepoID:      repoID,
+		Action:      ctx.FormTrim("action"),
+		Since:       since,
+		Before:      before,
+	}
+	if actor := ctx.FormTrim("actor"); actor != "" {
+		user, err := user_model.GetUserByName(ctx, actor)
+		if err != nil {
+			ctx.APIErrorAuto(err)
+			return
+		}
+		opts.DoerID = user.ID
+	}
+
+	events, total, err := db.FindAndCount[audit_model.Event](ctx, opts)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	res := make([]*api.AuditEvent, 0, len(events))
+	for _, e := range events {
+		res = append(res, convert.ToAuditEvent(e, ctx.Doer.IsAdmin))
+	}
+	ctx.SetLinkHeader(total, opts.PageSize)
+	ctx.SetTotalCountHeader(total)
+	ctx.JSON(http.StatusOK, res)
+}
@@ -0,0 +1,131 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package shared
+
+import (
+	"errors"
+	"net/http"
+
+	"gitea.dev/models/db"
+	issues_model "gitea.dev/models/issues"
+	api "gitea.dev/modules/structs"
+	"gitea.dev/modules/util"
+	"gitea.dev/modules/web"
+	"gitea.dev/routers/api/v1/utils"
+	"gitea.dev/services/context"
+	"gitea.dev/services/convert"
+	issue_service "gitea.dev/services/issue"
+)
+
+// ListCustomFields lists the custom fields of a repository, or the organization custom fields of an owner when repoID is 0.
+// includeOwner also lists the organization custom fields applying to the repository.
+func ListCustomFields(ctx *context.APIContext, ownerID, repoID int64, includeOwner bool) {
+	opts := issues_model.FindCustomFieldsOptions{
+		ListOptions: utils.GetListOptions(ctx),
+		RepoID:      repoID,
+	}
+	if repoID == 0 || includeOwner {
+		opts.OwnerID = ownerID
+	}
+
+	fields, total, err := db.FindAndCount[issues_model.CustomField](ctx, opts)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	res := make([]*api.CustomField, 0, len(fields))
+	for _, f := range fields {
+		res = append(res, convert.ToCustomField(f))
+	}
+	ctx.SetLinkHeader(total, opts.PageSize)
+	ctx.SetTotalCountHeader(total)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func getCustomField(ctx *context.APIContext, ownerID, repoID int64) *issues_model.CustomField {
+	f, err := issues_model.GetCustomFieldByID(ctx, ownerID, repoID, ctx.PathParamInt64("id"))
+	if err != nil {
+		ctx.APIErrorAuto(err)
+		return nil
+	}
+	return f
+}
+
+// GetCustomField gets a custom field of a repository, or an organization custom field when repoID is 0
+func GetCustomField(ctx *context.APIContext, ownerID, repoID int64) {
+	f := getCustomField(ctx, ownerID, repoID)
+	if ctx.Written() {
+		return
+	}
+	ctx.JSON(http.StatusOK, convert.ToCustomField(f))
+}
+
+func handleCustomFieldSaveError(ctx *context.APIContext, err error) {
+	if errors.Is(err, util.ErrInvalidArgument) {
+		ctx.APIError(http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+	ctx.APIErrorAuto(err)
+}
+
+// CreateCustomField creates a custom field for a repository, or an organization custom field when repoID is 0
+func CreateCustomField(ctx *context.APIContext, ownerID, repoID int64) {
+	form := web.GetForm[*api.CreateCustomFieldOption](ctx)
+
+	f := &issues_model.CustomField{
+		RepoID:      repoID,
+		Name:        form.Name,
+		Description: form.Description,
+		Type:        issues_model.CustomFieldType(form.Type),
+		Options:     form.Options,
+	}
+	if repoID == 0 {
+		f.OwnerID = ownerID
+	}
+
+	if err := issues_model.CreateCustomField(ctx, f); err != nil {
+		handleCustomFieldSaveError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, convert.ToCustomField(f))
+}
+
+// EditCustomField edits a custom field of a repository, or an organization custom field when repoID is 0
+func EditCustomField(ctx *context.APIContext, ownerID, repoID int64) {
+	form := web.GetForm[*api.EditCustomFieldOption](ctx)
+	f := getCustomField(ctx, ownerID, repoID)
+	if ctx.Written() {
+		return
+	}
+
+	if form.Name != nil {
+		f.Name = *form.Name
+	}
+	if form.Description != nil {
+		f.Description = *form.Description
+	}
+	if form.Options != nil {
+		f.Options = form.Options
+	}
+
+	if err := issue_service.UpdateCustomField(ctx, f); err != nil {
+		handleCustomFieldSaveError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, convert.ToCustomField(f))
+}
+
+// DeleteCustomField deletes a custom field of a repository, or an organization custom field when repoID is 0
+func DeleteCustomField(ctx *context.APIContext, ownerID, repoID int64) {
+	f := getCustomField(ctx, ownerID, repoID)
+	if ctx.Written() {
+		return
+	}
+	if err := issue_service.DeleteCustomField(ctx, f); err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
@@ -0,0 +1,38 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package shared
+
+import (
+	"net/http"
+
+	"gitea.dev/models/db"
+	issues_model "gitea.dev/models/issues"
+	api "gitea.dev/modules/structs"
+	"gitea.dev/routers/api/v1/utils"
+	"gitea.dev/services/context"
+	"gitea.dev/services/convert"
+)
+
+// ListIssueTypes lists the issue types of an owner, the disabled types are only listed when the "disabled" query parameter is true
+func ListIssueTypes(ctx *context.APIContext, ownerID int64) {
+	opts := issues_model.FindIssueTypesOptions{
+		ListOptions:     utils.GetListOptions(ctx),
+		OwnerID:         ownerID,
+		IncludeDisabled: ctx.FormBool("disabled"),
+	}
+
+	types, total, err := db.FindAndCount[issues_model.IssueType](ctx, opts)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	res := make([]*api.IssueType, 0, len(types))
+	for _, t := range types {
+		res = append(res, convert.ToIssueType(t))
+	}
+	ctx.SetLinkHeader(total, opts.PageSize)
+	ctx.SetTotalCountHeader(total)
+	ctx.JSON(http.StatusOK, res)
+}
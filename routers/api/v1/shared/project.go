@@ -4,6 +4,7 @@
 package shared
 
 import (
+	"errors"
 	"math"
 	"net/http"
 
@@ -148,6 +149,27 @@ func (s projectScope) findIssue(ctx *context.APIContext) *issues_model.Issue {
 	return issue
 }
 
+// issuesOptions searches the issues of a project visible to the doer
+func (s projectScope) issuesOptions(ctx *context.APIContext, project *project_model.Project) *issues_model.IssuesOptions {
+	issuesOpts := &issues_model.IssuesOptions{
+		ProjectIDs: []int64{project.ID}, // joins project_issue so the column sorting applies
+	}
+	if s.Repo != nil {
+		// the route already established repo read access, and every issue here is that repo's
+		issuesOpts.RepoIDs = []int64{s.Repo.ID}
+	} else {
+		// an owner-level board spans repositories, so filter to what this doer may see
+		issuesOpts.Owner = s.Owner
+		issuesOpts.Doer = ctx.Doer
+		issuesOpts.AllPublic = ctx.Doer == nil
+		if ctx.PublicOnly {
+			issuesOpts.AllPublic = true
+			issuesOpts.Doer = nil // a public-only token must not reach the doer's private repos
+		}
+	}
+	return issuesOpts
+}
+
 // findOpenProject is findProject for mutating endpoints: a closed project is read-only.
 func (s projectScope) findOpenProject(ctx *context.APIContext) *project_model.Project {
 	project := s.findProject(ctx)
@@ -170,6 +192,45 @@ func (s projectScope) findOpenColumn(ctx *context.APIContext) (*project_model.Pr
 	return project, columnIn(ctx, project)
 }
 
+// viewIn resolves the "view_id" path param inside an already-resolved project.
+func viewIn(ctx *context.APIContext, project *project_model.Project) *project_model.View {
+	view, err := project_model.GetViewByIDAndProjectID(ctx, ctx.PathParamInt64("view_id"), project.ID)
+	if err != nil {
+		ctx.APIErrorAuto(err)
+		return nil
+	}
+	return view
+}
+
+// fieldIn resolves the "field_id" path param inside an already-resolved project.
+func fieldIn(ctx *context.APIContext, project *project_model.Project) *project_model.Field {
+	field, err := project_model.GetFieldByIDAndProjectID(ctx, ctx.PathParamInt64("field_id"), project.ID)
+	if err != nil {
+		ctx.APIErrorAuto(err)
+		return nil
+	}
+	return field
+}
+
+// iterationIn resolves the "iteration_id" path param inside an already-resolved field.
+func iterationIn(ctx *context.APIContext, field *project_model.Field) *project_model.Iteration {
+	iteration, err := project_model.GetIterationByIDAndFieldID(ctx, ctx.PathParamInt64("iteration_id"), field.ID)
+	if err != nil {
+		ctx.APIErrorAuto(err)
+		return nil
+	}
+	return iteration
+}
+
+// handleProjectSaveError reports the invalid views, fields, iterations and values as validation errors
+func handleProjectSaveError(ctx *context.APIContext, err error) {
+	if errors.Is(err, util.ErrInvalidArgument) {
+		ctx.APIError(http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+	ctx.APIErrorAuto(err)
+}
+
 func ListProjects(ctx *context.APIContext) {
 	// swagger:operation GET /repos/{owner}/{repo}/projects repository repoListProjects
 	// ---
@@ -1674,25 +1735,10 @@ func ListProjectColumnIssues(ctx *context.APIContext) {
 	}
 
 	listOptions := utils.GetListOptions(ctx)
-	issuesOpts := &issues_model.IssuesOptions{
-		Paginator:  &listOptions,
-		IssueIDs:   issueIDs,
-		ProjectIDs: []int64{project.ID}, // joins project_issue so the column sorting applies
-		SortType:   "project-column-sorting",
-	}
-	if scope.Repo != nil {
-		// the route already established repo read access, and every issue here is that repo's
-		issuesOpts.RepoIDs = []int64{scope.Repo.ID}
-	} else {
-		// an owner-level board spans repositories, so filter to what this doer may see
-		issuesOpts.Owner = scope.Owner
-		issuesOpts.Doer = ctx.Doer
-		issuesOpts.AllPublic = ctx.Doer == nil
-		if ctx.PublicOnly {
-			issuesOpts.AllPublic = true
-			issuesOpts.Doer = nil // a public-only token must not reach the doer's private repos
-		}
-	}
+	issuesOpts := scope.issuesOptions(ctx, project)
+	issuesOpts.Paginator = &listOptions
+	issuesOpts.IssueIDs = issueIDs
+	issuesOpts.SortType = "project-column-sorting"
 
 	count, err := issues_model.CountIssues(ctx, issuesOpts)
 	if err != nil {
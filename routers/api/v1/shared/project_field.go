@@ -0,0 +1,1584 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package shared
+
+import (
+	"errors"
+	"net/http"
+
+	"gitea.dev/models/db"
+	project_model "gitea.dev/models/project"
+	api "gitea.dev/modules/structs"
+	"gitea.dev/modules/util"
+	"gitea.dev/modules/web"
+	"gitea.dev/routers/api/v1/utils"
+	"gitea.dev/services/context"
+	"gitea.dev/services/convert"
+)
+
+// toProjectField converts a field with its iterations
+func toProjectField(ctx *context.APIContext, field *project_model.Field) *api.ProjectField {
+	var iterations []*project_model.Iteration
+	if field.Type == project_model.FieldTypeIteration {
+		var err error
+		if iterations, err = project_model.GetFieldIterations(ctx, field.ID); err != nil {
+			ctx.APIErrorInternal(err)
+			return nil
+		}
+	}
+	return convert.ToProjectField(field, iterations)
+}
+
+func ListProjectFields(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/projects/{id}/fields repository repoListProjectFields
+	// ---
+	// summary: List a project's fields
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the project
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ProjectFieldList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	// swagger:operation GET /orgs/{org}/projects/{id}/fields organization orgListProjectFields
+	// ---
+	// summary: List a project's fields
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the project
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ProjectFieldList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	// swagger:operation GET /user/projects/{id}/fields user userCurrentListProjectFields
+	// ---
+	// summary: List a project's fields
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: id
+	//   in: path
+	//   description: id of the project
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ProjectFieldList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	project := projectScopeFromContext(ctx).findProject(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	listOptions := utils.GetListOptions(ctx)
+	fields, total, err := db.FindAndCount[project_model.Field](ctx, project_model.FindFieldsOptions{
+		ListOptions: listOptions,
+		ProjectID:   project.ID,
+	})
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	fieldIDs := make([]int64, 0, len(fields))
+	for _, field := range fields {
+		if field.Type == project_model.FieldTypeIteration {
+			fieldIDs = append(fieldIDs, field.ID)
+		}
+	}
+	iterations, err := project_model.GetFieldIterations(ctx, fieldIDs...)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	apiFields := make([]*api.ProjectField, len(fields))
+	for i, field := range fields {
+		apiFields[i] = convert.ToProjectField(field, iterations)
+	}
+	ctx.SetLinkHeader(total, listOptions.PageSize)
+	ctx.SetTotalCountHeader(total)
+	ctx.JSON(http.StatusOK, apiFields)
+}
+
+func CreateProjectField(ctx *context.APIContext) {
+	// swagger:operation POST /repos/{owner}/{repo}/projects/{id}/fields repository repoCreateProjectField
+	// ---
+	// summary: Create a field in a project
+	// description: The values of the fields belong to the issues in the project, they are removed when an issue leaves the project.
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the project
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/CreateProjectFieldOption"
+	// responses:
+	//   "201":
+	//     "$ref": "#/responses/ProjectField"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "423":
+	//     "$ref": "#/responses/repoArchivedError"
+
+	// swagger:operation POST /orgs/{org}/projects/{id}/fields organization orgCreateProjectField
+	// ---
+	// summary: Create a field in a project
+	// description: The values of the fields belong to the issues in the project, they are removed when an issue leaves the project.
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the project
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/CreateProjectFieldOption"
+	// responses:
+	//   "201":
+	//     "$ref": "#/responses/ProjectField"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	// swagger:operation POST /user/projects/{id}/fields user userCurrentCreateProjectField
+	// ---
+	// summary: Create a field in a project
+	// description: The values of the fields belong to the issues in the project, they are removed when an issue leaves the project.
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: id
+	//   in: path
+	//   description: id of the project
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/CreateProjectFieldOption"
+	// responses:
+	//   "201":
+	//     "$ref": "#/responses/ProjectField"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	project := projectScopeFromContext(ctx).findOpenProject(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	form := web.GetForm[*api.CreateProjectFieldOption](ctx)
+	field := &project_model.Field{
+		ProjectID: project.ID,
+		Name:      form.Name,
+		Type:      project_model.FieldType(form.Type),
+		Options:   form.Options,
+	}
+	if err := project_model.CreateField(ctx, field); err != nil {
+		handleProjectSaveError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, convert.ToProjectField(field, nil))
+}
+
+func GetProjectField(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/projects/{id}/fields/{field_id} repository repoGetProjectField
+	// ---
+	// summary: Get a project field
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the project
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: field_id
+	//   in: path
+	//   description: id of the field
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ProjectField"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	// swagger:operation GET /orgs/{org}/projects/{id}/fields/{field_id} organization orgGetProjectField
+	// ---
+	// summary: Get a project field
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the project
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: field_id
+	//   in: path
+	//   description: id of the field
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ProjectField"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	// swagger:operation GET /user/projects/{id}/fields/{field_id} user userCurrentGetProjectField
+	// ---
+	// summary: Get a project field
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: id
+	//   in: path
+	//   description: id of the project
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: field_id
+	//   in: path
+	//   description: id of the field
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ProjectField"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	project := projectScopeFromContext(ctx).findProject(ctx)
+	if ctx.Written() {
+		return
+	}
+	field := fieldIn(ctx, project)
+	if ctx.Written() {
+		return
+	}
+	apiField := toProjectField(ctx, field)
+	if ctx.Written() {
+		return
+	}
+	ctx.JSON(http.StatusOK, apiField)
+}
+
+func EditProjectField(ctx *context.APIContext) {
+	// swagger:operation PATCH /repos/{owner}/{repo}/projects/{id}/fields/{field_id} repository repoEditProjectField
+	// ---
+	// summary: Edit a project field
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the project
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: field_id
+	//   in: path
+	//   description: id of the field
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/EditProjectFieldOption"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ProjectField"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "423":
+	//     "$ref": "#/responses/repoArchivedError"
+
+	// swagger:operation PATCH /orgs/{org}/projects/{id}/fields/{field_id} organization orgEditProjectField
+	// ---
+	// summary: Edit a project field
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the project
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: field_id
+	//   in: path
+	//   description: id of the field
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/EditProjectFieldOption"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ProjectField"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	// swagger:operation PATCH /user/projects/{id}/fields/{field_id} user userCurrentEditProjectField
+	// ---
+	// summary: Edit a project field
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: id
+	//   in: path
+	//   description: id of the project
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: field_id
+	//   in: path
+	//   description: id of the field
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/EditProjectFieldOption"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ProjectField"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	project := projectScopeFromContext(ctx).findOpenProject(ctx)
+	if ctx.Written() {
+		return
+	}
+	field := fieldIn(ctx, project)
+	if ctx.Written() {
+		return
+	}
+
+	form := web.GetForm[*api.EditProjectFieldOption](ctx)
+	if form.Name != nil {
+		field.Name = *form.Name
+	}
+	if form.Options != nil {
+		field.Options = form.Options
+	}
+	if err := project_model.UpdateField(ctx, field); err != nil {
+		handleProjectSaveError(ctx, err)
+		return
+	}
+
+	apiField := toProjectField(ctx, field)
+	if ctx.Written() {
+		return
+	}
+	ctx.JSON(http.StatusOK, apiField)
+}
+
+func DeleteProjectField(ctx *context.APIContext) {
+	// swagger:operation DELETE /repos/{owner}/{repo}/projects/{id}/fields/{field_id} repository repoDeleteProjectField
+	// ---
+	// summary: Delete a project field
+	// description: The values of the field are deleted with it, the views grouped or sorted by it fall back to their defaults.
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the project
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: field_id
+	//   in: path
+	//   description: id of the field
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "423":
+	//     "$ref": "#/responses/repoArchivedError"
+
+	// swagger:operation DELETE /orgs/{org}/projects/{id}/fields/{field_id} organization orgDeleteProjectField
+	// ---
+	// summary: Delete a project field
+	// description: The values of the field are deleted with it, the views grouped or sorted by it fall back to their defaults.
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the project
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: field_id
+	//   in: path
+	//   description: id of the field
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	// swagger:operation DELETE /user/projects/{id}/fields/{field_id} user userCurrentDeleteProjectField
+	// ---
+	// summary: Delete a project field
+	// description: The values of the field are deleted with it, the views grouped or sorted by it fall back to their defaults.
+	// parameters:
+	// - name: id
+	//   in: path
+	//   description: id of the project
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: field_id
+	//   in: path
+	//   description: id of the field
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	project := projectScopeFromContext(ctx).findOpenProject(ctx)
+	if ctx.Written() {
+		return
+	}
+	field := fieldIn(ctx, project)
+	if ctx.Written() {
+		return
+	}
+	if err := project_model.DeleteField(ctx, field); err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+func CreateProjectIteration(ctx *context.APIContext) {
+	// swagger:operation POST /repos/{owner}/{repo}/projects/{id}/fields/{field_id}/iterations repository repoCreateProjectIteration
+	// ---
+	// summary: Add an iteration to an iteration field
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the project
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: field_id
+	//   in: path
+	//   description: id of the field
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/CreateProjectIterationOption"
+	// responses:
+	//   "201":
+	//     "$ref": "#/responses/ProjectIteration"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "423":
+	//     "$ref": "#/responses/repoArchivedError"
+
+	// swagger:operation POST /orgs/{org}/projects/{id}/fields/{field_id}/iterations organization orgCreateProjectIteration
+	// ---
+	// summary: Add an iteration to an iteration field
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the project
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: field_id
+	//   in: path
+	//   description: id of the field
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/CreateProjectIterationOption"
+	// responses:
+	//   "201":
+	//     "$ref": "#/responses/ProjectIteration"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	// swagger:operation POST /user/projects/{id}/fields/{field_id}/iterations user userCurrentCreateProjectIteration
+	// ---
+	// summary: Add an iteration to an iteration field
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: id
+	//   in: path
+	//   description: id of the project
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: field_id
+	//   in: path
+	//   description: id of the field
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/CreateProjectIterationOption"
+	// responses:
+	//   "201":
+	//     "$ref": "#/responses/ProjectIteration"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	project := projectScopeFromContext(ctx).findOpenProject(ctx)
+	if ctx.Written() {
+		return
+	}
+	field := fieldIn(ctx, project)
+	if ctx.Written() {
+		return
+	}
+
+	form := web.GetForm[*api.CreateProjectIterationOption](ctx)
+	startDate, err := project_model.ParseFieldDate(form.StartDate)
+	if err != nil {
+		handleProjectSaveError(ctx, err)
+		return
+	}
+	iteration := &project_model.Iteration{
+		Title:     form.Title,
+		StartDate: startDate,
+		Duration:  form.Duration,
+	}
+	if err := project_model.CreateIteration(ctx, field, iteration); err != nil {
+		handleProjectSaveError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, convert.ToProjectIteration(iteration))
+}
+
+func EditProjectIteration(ctx *context.APIContext) {
+	// swagger:operation PATCH /repos/{owner}/{repo}/projects/{id}/fields/{field_id}/iterations/{iteration_id} repository repoEditProjectIteration
+	// ---
+	// summary: Edit an iteration
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the project
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: field_id
+	//   in: path
+	//   description: id of the field
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: iteration_id
+	//   in: path
+	//   description: id of the iteration
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/EditProjectIterationOption"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ProjectIteration"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "423":
+	//     "$ref": "#/responses/repoArchivedError"
+
+	// swagger:operation PATCH /orgs/{org}/projects/{id}/fields/{field_id}/iterations/{iteration_id} organization orgEditProjectIteration
+	// ---
+	// summary: Edit an iteration
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the project
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: field_id
+	//   in: path
+	//   description: id of the field
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: iteration_id
+	//   in: path
+	//   description: id of the iteration
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/EditProjectIterationOption"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ProjectIteration"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	// swagger:operation PATCH /user/projects/{id}/fields/{field_id}/iterations/{iteration_id} user userCurrentEditProjectIteration
+	// ---
+	// summary: Edit an iteration
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: id
+	//   in: path
+	//   description: id of the project
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: field_id
+	//   in: path
+	//   description: id of the field
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: iteration_id
+	//   in: path
+	//   description: id of the iteration
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/EditProjectIterationOption"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ProjectIteration"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	project := projectScopeFromContext(ctx).findOpenProject(ctx)
+	if ctx.Written() {
+		return
+	}
+	field := fieldIn(ctx, project)
+	if ctx.Written() {
+		return
+	}
+	iteration := iterationIn(ctx, field)
+	if ctx.Written() {
+		return
+	}
+
+	form := web.GetForm[*api.EditProjectIterationOption](ctx)
+	if form.Title != nil {
+		iteration.Title = *form.Title
+	}
+	if form.StartDate != nil {
+		startDate, err := project_model.ParseFieldDate(*form.StartDate)
+		if err != nil {
+			handleProjectSaveError(ctx, err)
+			return
+		}
+		iteration.StartDate = startDate
+	}
+	if form.Duration != nil {
+		iteration.Duration = *form.Duration
+	}
+	if err := project_model.UpdateIteration(ctx, iteration); err != nil {
+		handleProjectSaveError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, convert.ToProjectIteration(iteration))
+}
+
+func DeleteProjectIteration(ctx *context.APIContext) {
+	// swagger:operation DELETE /repos/{owner}/{repo}/projects/{id}/fields/{field_id}/iterations/{iteration_id} repository repoDeleteProjectIteration
+	// ---
+	// summary: Delete an iteration
+	// description: The issues in the iteration are left without iteration.
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the project
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: field_id
+	//   in: path
+	//   description: id of the field
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: iteration_id
+	//   in: path
+	//   description: id of the iteration
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "423":
+	//     "$ref": "#/responses/repoArchivedError"
+
+	// swagger:operation DELETE /orgs/{org}/projects/{id}/fields/{field_id}/iterations/{iteration_id} organization orgDeleteProjectIteration
+	// ---
+	// summary: Delete an iteration
+	// description: The issues in the iteration are left without iteration.
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the project
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: field_id
+	//   in: path
+	//   description: id of the field
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: iteration_id
+	//   in: path
+	//   description: id of the iteration
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	// swagger:operation DELETE /user/projects/{id}/fields/{field_id}/iterations/{iteration_id} user userCurrentDeleteProjectIteration
+	// ---
+	// summary: Delete an iteration
+	// description: The issues in the iteration are left without iteration.
+	// parameters:
+	// - name: id
+	//   in: path
+	//   description: id of the project
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: field_id
+	//   in: path
+	//   description: id of the field
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: iteration_id
+	//   in: path
+	//   description: id of the iteration
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	project := projectScopeFromContext(ctx).findOpenProject(ctx)
+	if ctx.Written() {
+		return
+	}
+	field := fieldIn(ctx, project)
+	if ctx.Written() {
+		return
+	}
+	iteration := iterationIn(ctx, field)
+	if ctx.Written() {
+		return
+	}
+	if err := project_model.DeleteIteration(ctx, iteration); err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+func RolloverProjectIteration(ctx *context.APIContext) {
+	// swagger:operation POST /repos/{owner}/{repo}/projects/{id}/fields/{field_id}/iterations/{iteration_id}/rollover repository repoRolloverProjectIteration
+	// ---
+	// summary: Move the open issues of an iteration to another iteration
+	// description: The closed issues stay in the iteration they were done in. The open issues move to the given iteration, or to the iteration starting next.
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the project
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: field_id
+	//   in: path
+	//   description: id of the field
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: iteration_id
+	//   in: path
+	//   description: id of the iteration
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/RolloverProjectIterationOption"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ProjectIterationRollover"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "423":
+	//     "$ref": "#/responses/repoArchivedError"
+
+	// swagger:operation POST /orgs/{org}/projects/{id}/fields/{field_id}/iterations/{iteration_id}/rollover organization orgRolloverProjectIteration
+	// ---
+	// summary: Move the open issues of an iteration to another iteration
+	// description: The closed issues stay in the iteration they were done in. The open issues move to the given iteration, or to the iteration starting next.
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the project
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: field_id
+	//   in: path
+	//   description: id of the field
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: iteration_id
+	//   in: path
+	//   description: id of the iteration
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/RolloverProjectIterationOption"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ProjectIterationRollover"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	// swagger:operation POST /user/projects/{id}/fields/{field_id}/iterations/{iteration_id}/rollover user userCurrentRolloverProjectIteration
+	// ---
+	// summary: Move the open issues of an iteration to another iteration
+	// description: The closed issues stay in the iteration they were done in. The open issues move to the given iteration, or to the iteration starting next.
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: id
+	//   in: path
+	//   description: id of the project
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: field_id
+	//   in: path
+	//   description: id of the field
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: iteration_id
+	//   in: path
+	//   description: id of the iteration
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/RolloverProjectIterationOption"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ProjectIterationRollover"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	project := projectScopeFromContext(ctx).findOpenProject(ctx)
+	if ctx.Written() {
+		return
+	}
+	field := fieldIn(ctx, project)
+	if ctx.Written() {
+		return
+	}
+	from := iterationIn(ctx, field)
+	if ctx.Written() {
+		return
+	}
+
+	form := web.GetForm[*api.RolloverProjectIterationOption](ctx)
+	var to *project_model.Iteration
+	var err error
+	if form.ToIterationID > 0 {
+		to, err = project_model.GetIterationByIDAndFieldID(ctx, form.ToIterationID, field.ID)
+		if errors.Is(err, util.ErrNotExist) {
+			ctx.APIError(http.StatusUnprocessableEntity, "target iteration does not belong to this field")
+			return
+		}
+	} else {
+		to, err = project_model.NextIteration(ctx, from)
+		if err == nil && to == nil {
+			ctx.APIError(http.StatusUnprocessableEntity, "no iteration starts after this iteration")
+			return
+		}
+	}
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	moved, err := project_model.RolloverIteration(ctx, from, to)
+	if err != nil {
+		handleProjectSaveError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, &api.ProjectIterationRollover{
+		From:        convert.ToProjectIteration(from),
+		To:          convert.ToProjectIteration(to),
+		MovedIssues: moved,
+	})
+}
+
+func SetProjectIssueFieldValue(ctx *context.APIContext) {
+	// swagger:operation PUT /repos/{owner}/{repo}/projects/{id}/issues/{issue_id}/fields/{field_id} repository repoSetProjectIssueFieldValue
+	// ---
+	// summary: Set the value of a project field for an issue
+	// description: The issue must be in the project. Iterations are given by their ID, dates are formatted as YYYY-MM-DD.
+	// consumes:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the project
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: issue_id
+	//   in: path
+	//   description: global id of the issue, not the repository-local index
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: field_id
+	//   in: path
+	//   description: id of the field
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/SetProjectFieldValueOption"
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "423":
+	//     "$ref": "#/responses/repoArchivedError"
+
+	// swagger:operation PUT /orgs/{org}/projects/{id}/issues/{issue_id}/fields/{field_id} organization orgSetProjectIssueFieldValue
+	// ---
+	// summary: Set the value of a project field for an issue
+	// description: The issue must be in the project. Iterations are given by their ID, dates are formatted as YYYY-MM-DD.
+	// consumes:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the project
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: issue_id
+	//   in: path
+	//   description: global id of the issue, not the repository-local index
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: field_id
+	//   in: path
+	//   description: id of the field
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/SetProjectFieldValueOption"
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	// swagger:operation PUT /user/projects/{id}/issues/{issue_id}/fields/{field_id} user userCurrentSetProjectIssueFieldValue
+	// ---
+	// summary: Set the value of a project field for an issue
+	// description: The issue must be in the project. Iterations are given by their ID, dates are formatted as YYYY-MM-DD.
+	// consumes:
+	// - application/json
+	// parameters:
+	// - name: id
+	//   in: path
+	//   description: id of the project
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: issue_id
+	//   in: path
+	//   description: global id of the issue, not the repository-local index
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: field_id
+	//   in: path
+	//   description: id of the field
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/SetProjectFieldValueOption"
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	scope := projectScopeFromContext(ctx)
+	project := scope.findOpenProject(ctx)
+	if ctx.Written() {
+		return
+	}
+	field := fieldIn(ctx, project)
+	if ctx.Written() {
+		return
+	}
+	issue := scope.findIssue(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	form := web.GetForm[*api.SetProjectFieldValueOption](ctx)
+	if err := project_model.SetIssueFieldValue(ctx, field, issue.ID, form.Value); err != nil {
+		handleProjectSaveError(ctx, err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+func DeleteProjectIssueFieldValue(ctx *context.APIContext) {
+	// swagger:operation DELETE /repos/{owner}/{repo}/projects/{id}/issues/{issue_id}/fields/{field_id} repository repoDeleteProjectIssueFieldValue
+	// ---
+	// summary: Clear the value of a project field for an issue
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the project
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: issue_id
+	//   in: path
+	//   description: global id of the issue, not the repository-local index
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: field_id
+	//   in: path
+	//   description: id of the field
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "423":
+	//     "$ref": "#/responses/repoArchivedError"
+
+	// swagger:operation DELETE /orgs/{org}/projects/{id}/issues/{issue_id}/fields/{field_id} organization orgDeleteProjectIssueFieldValue
+	// ---
+	// summary: Clear the value of a project field for an issue
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the project
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: issue_id
+	//   in: path
+	//   description: global id of the issue, not the repository-local index
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: field_id
+	//   in: path
+	//   description: id of the field
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	// swagger:operation DELETE /user/projects/{id}/issues/{issue_id}/fields/{field_id} user userCurrentDeleteProjectIssueFieldValue
+	// ---
+	// summary: Clear the value of a project field for an issue
+	// parameters:
+	// - name: id
+	//   in: path
+	//   description: id of the project
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: issue_id
+	//   in: path
+	//   description: global id of the issue, not the repository-local index
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: field_id
+	//   in: path
+	//   description: id of the field
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	scope := projectScopeFromContext(ctx)
+	project := scope.findOpenProject(ctx)
+	if ctx.Written() {
+		return
+	}
+	field := fieldIn(ctx, project)
+	if ctx.Written() {
+		return
+	}
+	issue := scope.findIssue(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	if err := project_model.SetIssueFieldValue(ctx, field, issue.ID, ""); err != nil {
+		handleProjectSaveError(ctx, err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
@@ -0,0 +1,775 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package shared
+
+import (
+	"net/http"
+
+	"gitea.dev/models/db"
+	issues_model "gitea.dev/models/issues"
+	project_model "gitea.dev/models/project"
+	api "gitea.dev/modules/structs"
+	"gitea.dev/modules/web"
+	"gitea.dev/routers/api/v1/utils"
+	"gitea.dev/services/context"
+	"gitea.dev/services/convert"
+	project_service "gitea.dev/services/projects"
+)
+
+func ListProjectViews(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/projects/{id}/views repository repoListProjectViews
+	// ---
+	// summary: List a project's views
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the project
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ProjectViewList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	// swagger:operation GET /orgs/{org}/projects/{id}/views organization orgListProjectViews
+	// ---
+	// summary: List a project's views
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the project
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ProjectViewList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	// swagger:operation GET /user/projects/{id}/views user userCurrentListProjectViews
+	// ---
+	// summary: List a project's views
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: id
+	//   in: path
+	//   description: id of the project
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ProjectViewList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	project := projectScopeFromContext(ctx).findProject(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	listOptions := utils.GetListOptions(ctx)
+	views, total, err := db.FindAndCount[project_model.View](ctx, project_model.FindViewsOptions{
+		ListOptions: listOptions,
+		ProjectID:   project.ID,
+	})
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	ctx.SetLinkHeader(total, listOptions.PageSize)
+	ctx.SetTotalCountHeader(total)
+	ctx.JSON(http.StatusOK, convert.ToProjectViewList(views))
+}
+
+func CreateProjectView(ctx *context.APIContext) {
+	// swagger:operation POST /repos/{owner}/{repo}/projects/{id}/views repository repoCreateProjectView
+	// ---
+	// summary: Create a view in a project
+	// description: A view shows the project's issues as a board, a table or a roadmap, grouped and sorted by its columns, their milestones, repositories or the project's fields.
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the project
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/CreateProjectViewOption"
+	// responses:
+	//   "201":
+	//     "$ref": "#/responses/ProjectView"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "423":
+	//     "$ref": "#/responses/repoArchivedError"
+
+	// swagger:operation POST /orgs/{org}/projects/{id}/views organization orgCreateProjectView
+	// ---
+	// summary: Create a view in a project
+	// description: A view shows the project's issues as a board, a table or a roadmap, grouped and sorted by its columns, their milestones, repositories or the project's fields.
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the project
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/CreateProjectViewOption"
+	// responses:
+	//   "201":
+	//     "$ref": "#/responses/ProjectView"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	// swagger:operation POST /user/projects/{id}/views user userCurrentCreateProjectView
+	// ---
+	// summary: Create a view in a project
+	// description: A view shows the project's issues as a board, a table or a roadmap, grouped and sorted by its columns, their milestones, repositories or the project's fields.
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: id
+	//   in: path
+	//   description: id of the project
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/CreateProjectViewOption"
+	// responses:
+	//   "201":
+	//     "$ref": "#/responses/ProjectView"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	project := projectScopeFromContext(ctx).findOpenProject(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	form := web.GetForm[*api.CreateProjectViewOption](ctx)
+	view := &project_model.View{
+		ProjectID:   project.ID,
+		Name:        form.Name,
+		Layout:      project_model.ViewLayout(form.Layout),
+		GroupBy:     form.GroupBy,
+		SortBy:      form.SortBy,
+		SortDesc:    form.SortDesc,
+		DateFieldID: form.DateFieldID,
+	}
+	if err := project_model.CreateView(ctx, view); err != nil {
+		handleProjectSaveError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, convert.ToProjectView(view))
+}
+
+func GetProjectView(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/projects/{id}/views/{view_id} repository repoGetProjectView
+	// ---
+	// summary: Get a project view
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the project
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: view_id
+	//   in: path
+	//   description: id of the view
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ProjectView"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	// swagger:operation GET /orgs/{org}/projects/{id}/views/{view_id} organization orgGetProjectView
+	// ---
+	// summary: Get a project view
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the project
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: view_id
+	//   in: path
+	//   description: id of the view
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ProjectView"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	// swagger:operation GET /user/projects/{id}/views/{view_id} user userCurrentGetProjectView
+	// ---
+	// summary: Get a project view
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: id
+	//   in: path
+	//   description: id of the project
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: view_id
+	//   in: path
+	//   description: id of the view
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ProjectView"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	project := projectScopeFromContext(ctx).findProject(ctx)
+	if ctx.Written() {
+		return
+	}
+	view := viewIn(ctx, project)
+	if ctx.Written() {
+		return
+	}
+	ctx.JSON(http.StatusOK, convert.ToProjectView(view))
+}
+
+func EditProjectView(ctx *context.APIContext) {
+	// swagger:operation PATCH /repos/{owner}/{repo}/projects/{id}/views/{view_id} repository repoEditProjectView
+	// ---
+	// summary: Edit a project view
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the project
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: view_id
+	//   in: path
+	//   description: id of the view
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/EditProjectViewOption"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ProjectView"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "423":
+	//     "$ref": "#/responses/repoArchivedError"
+
+	// swagger:operation PATCH /orgs/{org}/projects/{id}/views/{view_id} organization orgEditProjectView
+	// ---
+	// summary: Edit a project view
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the project
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: view_id
+	//   in: path
+	//   description: id of the view
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/EditProjectViewOption"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ProjectView"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	// swagger:operation PATCH /user/projects/{id}/views/{view_id} user userCurrentEditProjectView
+	// ---
+	// summary: Edit a project view
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: id
+	//   in: path
+	//   description: id of the project
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: view_id
+	//   in: path
+	//   description: id of the view
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/EditProjectViewOption"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ProjectView"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	project := projectScopeFromContext(ctx).findOpenProject(ctx)
+	if ctx.Written() {
+		return
+	}
+	view := viewIn(ctx, project)
+	if ctx.Written() {
+		return
+	}
+
+	form := web.GetForm[*api.EditProjectViewOption](ctx)
+	if form.Name != nil {
+		view.Name = *form.Name
+	}
+	if form.Layout != nil {
+		view.Layout = project_model.ViewLayout(*form.Layout)
+	}
+	if form.GroupBy != nil {
+		view.GroupBy = *form.GroupBy
+	}
+	if form.SortBy != nil {
+		view.SortBy = *form.SortBy
+	}
+	if form.SortDesc != nil {
+		view.SortDesc = *form.SortDesc
+	}
+	if form.DateFieldID != nil {
+		view.DateFieldID = *form.DateFieldID
+	}
+	if form.Sorting != nil {
+		view.Sorting = *form.Sorting
+	}
+
+	if err := project_model.UpdateView(ctx, view); err != nil {
+		handleProjectSaveError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, convert.ToProjectView(view))
+}
+
+func DeleteProjectView(ctx *context.APIContext) {
+	// swagger:operation DELETE /repos/{owner}/{repo}/projects/{id}/views/{view_id} repository repoDeleteProjectView
+	// ---
+	// summary: Delete a project view
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the project
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: view_id
+	//   in: path
+	//   description: id of the view
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "423":
+	//     "$ref": "#/responses/repoArchivedError"
+
+	// swagger:operation DELETE /orgs/{org}/projects/{id}/views/{view_id} organization orgDeleteProjectView
+	// ---
+	// summary: Delete a project view
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the project
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: view_id
+	//   in: path
+	//   description: id of the view
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	// swagger:operation DELETE /user/projects/{id}/views/{view_id} user userCurrentDeleteProjectView
+	// ---
+	// summary: Delete a project view
+	// parameters:
+	// - name: id
+	//   in: path
+	//   description: id of the project
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: view_id
+	//   in: path
+	//   description: id of the view
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	project := projectScopeFromContext(ctx).findOpenProject(ctx)
+	if ctx.Written() {
+		return
+	}
+	view := viewIn(ctx, project)
+	if ctx.Written() {
+		return
+	}
+	if err := project_model.DeleteView(ctx, view); err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+func ListProjectViewItems(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/projects/{id}/views/{view_id}/items repository repoListProjectViewItems
+	// ---
+	// summary: List the issues of a project as a view shows them
+	// description: The issues are ordered by group, then sorted within their group. The issues without value for the grouping or sorting field come last.
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the project
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: view_id
+	//   in: path
+	//   description: id of the view
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ProjectViewItemList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	// swagger:operation GET /orgs/{org}/projects/{id}/views/{view_id}/items organization orgListProjectViewItems
+	// ---
+	// summary: List the issues of a project as a view shows them
+	// description: The issues are ordered by group, then sorted within their group. The issues without value for the grouping or sorting field come last.
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the project
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: view_id
+	//   in: path
+	//   description: id of the view
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ProjectViewItemList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	// swagger:operation GET /user/projects/{id}/views/{view_id}/items user userCurrentListProjectViewItems
+	// ---
+	// summary: List the issues of a project as a view shows them
+	// description: The issues are ordered by group, then sorted within their group. The issues without value for the grouping or sorting field come last.
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: id
+	//   in: path
+	//   description: id of the project
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: view_id
+	//   in: path
+	//   description: id of the view
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ProjectViewItemList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	scope := projectScopeFromContext(ctx)
+	project := scope.findProject(ctx)
+	if ctx.Written() {
+		return
+	}
+	view := viewIn(ctx, project)
+	if ctx.Written() {
+		return
+	}
+
+	// any field can group or sort the view, so the whole project is arranged before the page is cut
+	issues, err := issues_model.Issues(ctx, scope.issuesOptions(ctx, project))
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	items, err := project_service.ArrangeViewItems(ctx, project, view, issues)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	listOptions := utils.GetListOptions(ctx)
+	skip, take := listOptions.GetSkipTake()
+	total := len(items)
+	items = items[min(skip, total):min(skip+take, total)]
+
+	ctx.SetLinkHeader(int64(total), listOptions.PageSize)
+	ctx.SetTotalCountHeader(int64(total))
+	ctx.JSON(http.StatusOK, convert.ToProjectViewItems(ctx, ctx.Doer, items))
+}
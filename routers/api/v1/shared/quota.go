@@ -0,0 +1,22 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package shared
+
+import (
+	"net/http"
+
+	"gitea.dev/services/context"
+	"gitea.dev/services/convert"
+	quota_service "gitea.dev/services/quota"
+)
+
+// GetQuota responds with the storage usage, the groups and the rules of a user or an organization
+func GetQuota(ctx *context.APIContext, userID int64) {
+	info, err := quota_service.GetInfo(ctx, userID)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, convert.ToQuotaInfo(info))
+}
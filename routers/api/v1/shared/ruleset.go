@@ -0,0 +1,185 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package shared
+
+import (
+	"errors"
+	"net/http"
+
+	"gitea.dev/models/db"
+	git_model "gitea.dev/models/git"
+	api "gitea.dev/modules/structs"
+	"gitea.dev/modules/util"
+	"gitea.dev/modules/web"
+	"gitea.dev/routers/api/v1/utils"
+	"gitea.dev/services/context"
+	"gitea.dev/services/convert"
+)
+
+// ListRulesets lists the rulesets of a repository, or the organization rulesets of an owner when repoID is 0.
+// includeOwner also lists the organization rulesets applying to the repository.
+func ListRulesets(ctx *context.APIContext, ownerID, repoID int64, includeOwner bool) {
+	opts := git_model.FindRulesetsOptions{
+		ListOptions: utils.GetListOptions(ctx),
+		RepoID:      repoID,
+	}
+	if repoID == 0 || includeOwner {
+		opts.OwnerID = ownerID
+	}
+
+	rulesets, total, err := db.FindAndCount[git_model.Ruleset](ctx, opts)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	res := make([]*api.Ruleset, 0, len(rulesets))
+	for _, rs := range rulesets {
+		res = append(res, convert.ToRuleset(rs))
+	}
+	ctx.SetLinkHeader(total, opts.PageSize)
+	ctx.SetTotalCountHeader(total)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func getRuleset(ctx *context.APIContext, ownerID, repoID int64) *git_model.Ruleset {
+	rs, err := git_model.GetRulesetByID(ctx, ownerID, repoID, ctx.PathParamInt64("id"))
+	if err != nil {
+		ctx.APIErrorAuto(err)
+		return nil
+	}
+	return rs
+}
+
+// GetRuleset gets a ruleset of a repository, or an organization ruleset when repoID is 0
+func GetRuleset(ctx *context.APIContext, ownerID, repoID int64) {
+	rs := getRuleset(ctx, ownerID, repoID)
+	if ctx.Written() {
+		return
+	}
+	ctx.JSON(http.StatusOK, convert.ToRuleset(rs))
+}
+
+func applyRulesetTarget(rs *git_model.Ruleset, target string) error {
+	t, ok := git_model.RulesetTargetFromString(target)
+	if !ok {
+		return util.NewInvalidArgumentErrorf("invalid target %q", target)
+	}
+	rs.Target = t
+	return nil
+}
+
+func applyRulesetEnforcement(rs *git_model.Ruleset, enforcement string) error {
+	e, ok := git_model.RulesetEnforcementFromString(enforcement)
+	if !ok {
+		return util.NewInvalidArgumentErrorf("invalid enforcement %q", enforcement)
+	}
+	rs.Enforcement = e
+	return nil
+}
+
+func applyRulesetRules(rs *git_model.Ruleset, rules *api.RulesetRules) {
+	if rules == nil {
+		return
+	}
+	rs.Rules = git_model.RulesetRules{
+		Deletion:              rules.Deletion,
+		NonFastForward:        rules.NonFastForward,
+		RequiredLinearHistory: rules.RequiredLinearHistory,
+		RequiredStatusChecks:  rules.RequiredStatusChecks,
+		RequiredSignatures:    rules.RequiredSignatures,
+		BlockedFilePaths:      rules.BlockedFilePaths,
+		CommitMessagePattern:  rules.CommitMessagePattern,
+		MaxFileSize:           rules.MaxFileSize,
+	}
+}
+
+func handleRulesetSaveError(ctx *context.APIContext, err error) {
+	if errors.Is(err, util.ErrInvalidArgument) {
+		ctx.APIError(http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+	ctx.APIErrorInternal(err)
+}
+
+// CreateRuleset creates a ruleset for a repository, or an organization ruleset when repoID is 0
+func CreateRuleset(ctx *context.APIContext, ownerID, repoID int64) {
+	form := web.GetForm[*api.CreateRulesetOption](ctx)
+
+	rs := &git_model.Ruleset{
+		RepoID:      repoID,
+		Name:        form.Name,
+		IncludeRefs: form.IncludeRefs,
+		ExcludeRefs: form.ExcludeRefs,
+	}
+	if repoID == 0 {
+		rs.OwnerID = ownerID
+	}
+	if err := applyRulesetTarget(rs, form.Target); err != nil {
+		handleRulesetSaveError(ctx, err)
+		return
+	}
+	if err := applyRulesetEnforcement(rs, form.Enforcement); err != nil {
+		handleRulesetSaveError(ctx, err)
+		return
+	}
+	applyRulesetRules(rs, form.Rules)
+
+	if err := git_model.CreateRuleset(ctx, rs); err != nil {
+		handleRulesetSaveError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, convert.ToRuleset(rs))
+}
+
+// EditRuleset edits a ruleset of a repository, or an organization ruleset when repoID is 0
+func EditRuleset(ctx *context.APIContext, ownerID, repoID int64) {
+	form := web.GetForm[*api.EditRulesetOption](ctx)
+	rs := getRuleset(ctx, ownerID, repoID)
+	if ctx.Written() {
+		return
+	}
+
+	if form.Name != nil {
+		rs.Name = *form.Name
+	}
+	if form.Target != nil {
+		if err := applyRulesetTarget(rs, *form.Target); err != nil {
+			handleRulesetSaveError(ctx, err)
+			return
+		}
+	}
+	if form.Enforcement != nil {
+		if err := applyRulesetEnforcement(rs, *form.Enforcement); err != nil {
+			handleRulesetSaveError(ctx, err)
+			return
+		}
+	}
+	if form.IncludeRefs != nil {
+		rs.IncludeRefs = form.IncludeRefs
+	}
+	if form.ExcludeRefs != nil {
+		rs.ExcludeRefs = form.ExcludeRefs
+	}
+	applyRulesetRules(rs, form.Rules)
+
+	if err := git_model.UpdateRuleset(ctx, rs); err != nil {
+		handleRulesetSaveError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, convert.ToRuleset(rs))
+}
+
+// DeleteRuleset deletes a ruleset of a repository, or an organization ruleset when repoID is 0
+func DeleteRuleset(ctx *context.APIContext, ownerID, repoID int64) {
+	rs := getRuleset(ctx, ownerID, repoID)
+	if ctx.Written() {
+		return
+	}
+	if err := git_model.DeleteRuleset(ctx, rs); err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
@@ -0,0 +1,125 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package shared
+
+import (
+	"errors"
+	"net/http"
+
+	"gitea.dev/models/db"
+	secretscan_model "gitea.dev/models/secretscan"
+	api "gitea.dev/modules/structs"
+	"gitea.dev/modules/util"
+	"gitea.dev/modules/web"
+	"gitea.dev/routers/api/v1/utils"
+	"gitea.dev/services/context"
+	"gitea.dev/services/convert"
+)
+
+// ListSecretScanningPatterns lists the custom secret scanning patterns of an owner, of the instance when ownerID is 0
+func ListSecretScanningPatterns(ctx *context.APIContext, ownerID int64) {
+	opts := secretscan_model.FindCustomPatternsOptions{
+		ListOptions: utils.GetListOptions(ctx),
+		OwnerID:     ownerID,
+	}
+	patterns, total, err := db.FindAndCount[secretscan_model.CustomPattern](ctx, opts)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	res := make([]*api.SecretScanningPattern, 0, len(patterns))
+	for _, p := range patterns {
+		res = append(res, convert.ToSecretScanningPattern(p))
+	}
+	ctx.SetLinkHeader(total, opts.PageSize)
+	ctx.SetTotalCountHeader(total)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func getSecretScanningPattern(ctx *context.APIContext, ownerID int64) *secretscan_model.CustomPattern {
+	p, err := secretscan_model.GetCustomPatternByID(ctx, ownerID, ctx.PathParamInt64("id"))
+	if err != nil {
+		ctx.APIErrorAuto(err)
+		return nil
+	}
+	return p
+}
+
+// GetSecretScanningPattern gets a custom secret scanning pattern of an owner, of the instance when ownerID is 0
+func GetSecretScanningPattern(ctx *context.APIContext, ownerID int64) {
+	p := getSecretScanningPattern(ctx, ownerID)
+	if ctx.Written() {
+		return
+	}
+	ctx.JSON(http.StatusOK, convert.ToSecretScanningPattern(p))
+}
+
+func handleSecretScanningPatternSaveError(ctx *context.APIContext, err error) {
+	switch {
+	case errors.Is(err, util.ErrInvalidArgument):
+		ctx.APIError(http.StatusUnprocessableEntity, err.Error())
+	case errors.Is(err, util.ErrAlreadyExist):
+		ctx.APIError(http.StatusConflict, err.Error())
+	default:
+		ctx.APIErrorInternal(err)
+	}
+}
+
+// CreateSecretScanningPattern creates a custom secret scanning pattern for an owner, for the instance when ownerID is 0
+func CreateSecretScanningPattern(ctx *context.APIContext, ownerID int64) {
+	form := web.GetForm[*api.CreateSecretScanningPatternOption](ctx)
+
+	p := &secretscan_model.CustomPattern{
+		OwnerID:        ownerID,
+		Name:           form.Name,
+		Pattern:        form.Pattern,
+		PushProtection: true,
+	}
+	if form.PushProtection != nil {
+		p.PushProtection = *form.PushProtection
+	}
+	if err := secretscan_model.CreateCustomPattern(ctx, p); err != nil {
+		handleSecretScanningPatternSaveError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, convert.ToSecretScanningPattern(p))
+}
+
+// EditSecretScanningPattern edits a custom secret scanning pattern of an owner, of the instance when ownerID is 0
+func EditSecretScanningPattern(ctx *context.APIContext, ownerID int64) {
+	form := web.GetForm[*api.EditSecretScanningPatternOption](ctx)
+	p := getSecretScanningPattern(ctx, ownerID)
+	if ctx.Written() {
+		return
+	}
+
+	if form.Name != nil {
+		p.Name = *form.Name
+	}
+	if form.Pattern != nil {
+		p.Pattern = *form.Pattern
+	}
+	if form.PushProtection != nil {
+		p.PushProtection = *form.PushProtection
+	}
+	if err := secretscan_model.UpdateCustomPattern(ctx, p); err != nil {
+		handleSecretScanningPatternSaveError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, convert.ToSecretScanningPattern(p))
+}
+
+// DeleteSecretScanningPattern deletes a custom secret scanning pattern of an owner, of the instance when ownerID is 0
+func DeleteSecretScanningPattern(ctx *context.APIContext, ownerID int64) {
+	p := getSecretScanningPattern(ctx, ownerID)
+	if ctx.Written() {
+		return
+	}
+	if err := secretscan_model.DeleteCustomPattern(ctx, p); err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
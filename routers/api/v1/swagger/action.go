@@ -46,3 +46,38 @@ type swaggerResponseRunDetails struct {
 	// in:body
 	Body api.RunDetails `json:"body"`
 }
+
+// ActionEnvironment
+// swagger:response ActionEnvironment
+type swaggerResponseActionEnvironment struct {
+	// in:body
+	Body api.ActionEnvironment `json:"body"`
+}
+
+// ActionEnvironmentList
+// swagger:response ActionEnvironmentList
+type swaggerResponseActionEnvironmentList struct {
+	// in:body
+	Body []api.ActionEnvironment `json:"body"`
+}
+
+// ActionDeploymentList
+// swagger:response ActionDeploymentList
+type swaggerResponseActionDeploymentList struct {
+	// in:body
+	Body []api.ActionDeployment `json:"body"`
+}
+
+// PendingDeploymentList
+// swagger:response PendingDeploymentList
+type swaggerResponsePendingDeploymentList struct {
+	// in:body
+	Body []api.PendingDeployment `json:"body"`
+}
+
+// ActionCacheList
+// swagger:response ActionCacheList
+type swaggerResponseActionCacheList struct {
+	// in:body
+	Body api.ActionCacheList `json:"body"`
+}
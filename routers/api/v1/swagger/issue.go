@@ -56,6 +56,48 @@ type swaggerResponseLabelList struct {
 	Body []api.Label `json:"body"`
 }
 
+// CustomField
+// swagger:response CustomField
+type swaggerResponseCustomField struct {
+	// in:body
+	Body api.CustomField `json:"body"`
+}
+
+// CustomFieldList
+// swagger:response CustomFieldList
+type swaggerResponseCustomFieldList struct {
+	// in:body
+	Body []api.CustomField `json:"body"`
+}
+
+// IssueCustomFieldValue
+// swagger:response IssueCustomFieldValue
+type swaggerResponseIssueCustomFieldValue struct {
+	// in:body
+	Body api.IssueCustomFieldValue `json:"body"`
+}
+
+// IssueCustomFieldValueList
+// swagger:response IssueCustomFieldValueList
+type swaggerResponseIssueCustomFieldValueList struct {
+	// in:body
+	Body []api.IssueCustomFieldValue `json:"body"`
+}
+
+// IssueType
+// swagger:response IssueType
+type swaggerResponseIssueType struct {
+	// in:body
+	Body api.IssueType `json:"body"`
+}
+
+// IssueTypeList
+// swagger:response IssueTypeList
+type swaggerResponseIssueTypeList struct {
+	// in:body
+	Body []api.IssueType `json:"body"`
+}
+
 // Milestone
 // swagger:response Milestone
 type swaggerResponseMilestone struct {
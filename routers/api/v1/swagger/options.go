@@ -27,6 +27,8 @@ type swaggerParameterBodies struct {
 	CreateHookOption api.CreateHookOption
 	// in:body
 	EditHookOption api.EditHookOption
+	// in:body
+	PreviewHookOption api.PreviewHookOption
 
 	// in:body
 	EditGitHookOption api.EditGitHookOption
@@ -195,6 +197,48 @@ type swaggerParameterBodies struct {
 	// in:body
 	EditTagProtectionOption api.EditTagProtectionOption
 
+	// in:body
+	CreateRulesetOption api.CreateRulesetOption
+
+	// in:body
+	EditRulesetOption api.EditRulesetOption
+
+	// in:body
+	EditSecretScanningAlertOption api.EditSecretScanningAlertOption
+
+	// in:body
+	CreateSecretScanningPatternOption api.CreateSecretScanningPatternOption
+
+	// in:body
+	EditSecretScanningPatternOption api.EditSecretScanningPatternOption
+
+	// in:body
+	CreateQuotaRuleOption api.CreateQuotaRuleOption
+
+	// in:body
+	EditQuotaRuleOption api.EditQuotaRuleOption
+
+	// in:body
+	CreateQuotaGroupOption api.CreateQuotaGroupOption
+
+	// in:body
+	CreateCustomFieldOption api.CreateCustomFieldOption
+
+	// in:body
+	EditCustomFieldOption api.EditCustomFieldOption
+
+	// in:body
+	SetIssueCustomFieldOption api.SetIssueCustomFieldOption
+
+	// in:body
+	CreateIssueTypeOption api.CreateIssueTypeOption
+
+	// in:body
+	EditIssueTypeOption api.EditIssueTypeOption
+
+	// in:body
+	ReorderSubIssuesOption api.ReorderSubIssuesOption
+
 	// in:body
 	CreateAccessTokenOption api.CreateAccessTokenOption
 
@@ -234,6 +278,12 @@ type swaggerParameterBodies struct {
 	// in:body
 	EditActionRunnerOption api.EditActionRunnerOption
 
+	// in:body
+	CreateOrUpdateActionEnvironmentOption api.CreateOrUpdateActionEnvironmentOption
+
+	// in:body
+	ReviewPendingDeploymentsOption api.ReviewPendingDeploymentsOption
+
 	// in:body
 	LockIssueOption api.LockIssueOption
 
@@ -253,6 +303,34 @@ type swaggerParameterBodies struct {
 	// in:body
 	MoveProjectIssueOption api.MoveProjectIssueOption
 
+	// in:body
+	CreateProjectViewOption api.CreateProjectViewOption
+	// in:body
+	EditProjectViewOption api.EditProjectViewOption
+
+	// in:body
+	CreateProjectFieldOption api.CreateProjectFieldOption
+	// in:body
+	EditProjectFieldOption api.EditProjectFieldOption
+
+	// in:body
+	CreateProjectIterationOption api.CreateProjectIterationOption
+	// in:body
+	EditProjectIterationOption api.EditProjectIterationOption
+	// in:body
+	RolloverProjectIterationOption api.RolloverProjectIterationOption
+
+	// in:body
+	SetProjectFieldValueOption api.SetProjectFieldValueOption
+
 	// in:body
 	MergeUpstreamRequest api.MergeUpstreamRequest
+
+	// in:body
+	CreatePackageRemoteOption api.CreatePackageRemoteOption
+	// in:body
+	EditPackageRemoteOption api.EditPackageRemoteOption
+
+	// in:body
+	CreatePackageAttestationOption api.CreatePackageAttestationOption
 }
@@ -27,3 +27,31 @@ type swaggerResponsePackageFileList struct {
 	// in:body
 	Body []api.PackageFile `json:"body"`
 }
+
+// PackageRemote
+// swagger:response PackageRemote
+type swaggerResponsePackageRemote struct {
+	// in:body
+	Body api.PackageRemote `json:"body"`
+}
+
+// PackageRemoteList
+// swagger:response PackageRemoteList
+type swaggerResponsePackageRemoteList struct {
+	// in:body
+	Body []api.PackageRemote `json:"body"`
+}
+
+// PackageAttestation
+// swagger:response PackageAttestation
+type swaggerResponsePackageAttestation struct {
+	// in:body
+	Body api.PackageAttestation `json:"body"`
+}
+
+// PackageAttestationList
+// swagger:response PackageAttestationList
+type swaggerResponsePackageAttestationList struct {
+	// in:body
+	Body []api.PackageAttestation `json:"body"`
+}
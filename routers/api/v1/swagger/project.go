@@ -34,3 +34,52 @@ type swaggerResponseProjectColumnList struct {
 	// in:body
 	Body []api.ProjectColumn `json:"body"`
 }
+
+// ProjectView
+// swagger:response ProjectView
+type swaggerResponseProjectView struct {
+	// in:body
+	Body api.ProjectView `json:"body"`
+}
+
+// ProjectViewList
+// swagger:response ProjectViewList
+type swaggerResponseProjectViewList struct {
+	// in:body
+	Body []api.ProjectView `json:"body"`
+}
+
+// ProjectViewItemList
+// swagger:response ProjectViewItemList
+type swaggerResponseProjectViewItemList struct {
+	// in:body
+	Body []api.ProjectViewItem `json:"body"`
+}
+
+// ProjectField
+// swagger:response ProjectField
+type swaggerResponseProjectField struct {
+	// in:body
+	Body api.ProjectField `json:"body"`
+}
+
+// ProjectFieldList
+// swagger:response ProjectFieldList
+type swaggerResponseProjectFieldList struct {
+	// in:body
+	Body []api.ProjectField `json:"body"`
+}
+
+// ProjectIteration
+// swagger:response ProjectIteration
+type swaggerResponseProjectIteration struct {
+	// in:body
+	Body api.ProjectIteration `json:"body"`
+}
+
+// ProjectIterationRollover
+// swagger:response ProjectIterationRollover
+type swaggerResponseProjectIterationRollover struct {
+	// in:body
+	Body api.ProjectIterationRollover `json:"body"`
+}
@@ -84,6 +84,83 @@ type swaggerResponseTagProtection struct {
 	Body api.TagProtection `json:"body"`
 }
 
+// RulesetList
+// swagger:response RulesetList
+type swaggerResponseRulesetList struct {
+	// in:body
+	Body []api.Ruleset `json:"body"`
+}
+
+// Ruleset
+// swagger:response Ruleset
+type swaggerResponseRuleset struct {
+	// in:body
+	Body api.Ruleset `json:"body"`
+}
+
+// SecretScanningAlertList
+// swagger:response SecretScanningAlertList
+type swaggerResponseSecretScanningAlertList struct {
+	// in:body
+	Body []api.SecretScanningAlert `json:"body"`
+}
+
+// SecretScanningAlert
+// swagger:response SecretScanningAlert
+type swaggerResponseSecretScanningAlert struct {
+	// in:body
+	Body api.SecretScanningAlert `json:"body"`
+}
+
+// SecretScanningAlertEventList
+// swagger:response SecretScanningAlertEventList
+type swaggerResponseSecretScanningAlertEventList struct {
+	// in:body
+	Body []api.SecretScanningAlertEvent `json:"body"`
+}
+
+// SecretScanningPatternList
+// swagger:response SecretScanningPatternList
+type swaggerResponseSecretScanningPatternList struct {
+	// in:body
+	Body []api.SecretScanningPattern `json:"body"`
+}
+
+// SecretScanningPattern
+// swagger:response SecretScanningPattern
+type swaggerResponseSecretScanningPattern struct {
+	// in:body
+	Body api.SecretScanningPattern `json:"body"`
+}
+
+// AuditEventList
+// swagger:response AuditEventList
+type swaggerResponseAuditEventList struct {
+	// in:body
+	Body []api.AuditEvent `json:"body"`
+}
+
+// AuditLogVerification
+// swagger:response AuditLogVerification
+type swaggerResponseAuditLogVerification struct {
+	// in:body
+	Body api.AuditLogVerification `json:"body"`
+}
+
+// MergeQueueEntryList
+// swagger:response MergeQueueEntryList
+type swaggerResponseMergeQueueEntryList struct {
+	// in:body
+	Body []api.MergeQueueEntry `json:"body"`
+}
+
+// MergeQueueEntry
+// swagger:response MergeQueueEntry
+type swaggerResponseMergeQueueEntry struct {
+	// in:body
+	Body api.MergeQueueEntry `json:"body"`
+}
+
 // ReferenceList
 // swagger:response ReferenceList
 type swaggerResponseReferenceList struct {
@@ -105,6 +182,13 @@ type swaggerResponseHookList struct {
 	Body []api.Hook `json:"body"`
 }
 
+// HookPreview
+// swagger:response HookPreview
+type swaggerResponseHookPreview struct {
+	// in:body
+	Body api.HookPreview `json:"body"`
+}
+
 // GitHook
 // swagger:response GitHook
 type swaggerResponseGitHook struct {
@@ -224,6 +308,27 @@ type swaggerGitTreeResponse struct {
 	Body api.GitTreeResponse `json:"body"`
 }
 
+// CodeSearchResults
+// swagger:response CodeSearchResults
+type swaggerCodeSearchResults struct {
+	// in: body
+	Body api.CodeSearchResults `json:"body"`
+}
+
+// CodeNavSymbol
+// swagger:response CodeNavSymbol
+type swaggerCodeNavSymbol struct {
+	// in: body
+	Body api.CodeNavSymbol `json:"body"`
+}
+
+// CodeNavIndex
+// swagger:response CodeNavIndex
+type swaggerCodeNavIndex struct {
+	// in: body
+	Body api.CodeNavIndex `json:"body"`
+}
+
 // GitBlobResponse
 // swagger:response GitBlobResponse
 type swaggerGitBlobResponse struct {
@@ -400,6 +505,13 @@ type swaggerPushMirror struct {
 	Body api.PushMirror `json:"body"`
 }
 
+// MigrationSync
+// swagger:response MigrationSync
+type swaggerMigrationSync struct {
+	// in:body
+	Body api.MigrationSync `json:"body"`
+}
+
 // PushMirrorList
 // swagger:response PushMirrorList
 type swaggerPushMirrorList struct {
@@ -55,3 +55,45 @@ type swaggerResponseBadgeList struct {
 	// in:body
 	Body []api.Badge `json:"body"`
 }
+
+// QuotaInfo
+// swagger:response QuotaInfo
+type swaggerResponseQuotaInfo struct {
+	// in:body
+	Body api.QuotaInfo `json:"body"`
+}
+
+// QuotaUsageList
+// swagger:response QuotaUsageList
+type swaggerResponseQuotaUsageList struct {
+	// in:body
+	Body []api.QuotaUsage `json:"body"`
+}
+
+// QuotaRule
+// swagger:response QuotaRule
+type swaggerResponseQuotaRule struct {
+	// in:body
+	Body api.QuotaRule `json:"body"`
+}
+
+// QuotaRuleList
+// swagger:response QuotaRuleList
+type swaggerResponseQuotaRuleList struct {
+	// in:body
+	Body []api.QuotaRule `json:"body"`
+}
+
+// QuotaGroup
+// swagger:response QuotaGroup
+type swaggerResponseQuotaGroup struct {
+	// in:body
+	Body api.QuotaGroup `json:"body"`
+}
+
+// QuotaGroupList
+// swagger:response QuotaGroupList
+type swaggerResponseQuotaGroupList struct {
+	// in:body
+	Body []api.QuotaGroup `json:"body"`
+}
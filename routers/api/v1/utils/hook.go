@@ -259,6 +259,11 @@ func addHook(ctx *context.APIContext, form *api.CreateHookOption, ownerID, repoI
 		}
 		w.Meta = string(meta)
 	}
+	if w.Type == webhook_module.TEMPLATE {
+		if !applyTemplateHookConfig(ctx, form.Config, w, &webhook_service.TemplateMeta{ContentType: "application/json"}) {
+			return nil, false
+		}
+	}
 
 	if err := w.UpdateEvent(); err != nil {
 		ctx.APIErrorInternal(err)
@@ -270,6 +275,69 @@ func addHook(ctx *context.APIContext, form *api.CreateHookOption, ownerID, repoI
 	return w, true
 }
 
+// PreviewRepoHook writes to `ctx` the request the webhook of the repository would deliver for the push payload.
+// The template options of the form override the stored ones of a template webhook.
+func PreviewRepoHook(ctx *context.APIContext, form *api.PreviewHookOption, hookID int64, p *api.PushPayload) {
+	w, err := GetRepoHook(ctx, ctx.Repo.Repository.ID, hookID)
+	if err != nil {
+		return
+	}
+	if w.Type == webhook_module.TEMPLATE && form.Config != nil {
+		if !applyTemplateHookConfig(ctx, form.Config, w, webhook_service.GetTemplateHook(w)) {
+			return
+		}
+	}
+
+	req, body, err := webhook_service.PreviewWebhook(ctx, w, webhook_module.HookEventPush, p)
+	if err != nil {
+		ctx.APIError(http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+	headers := make(map[string]string, len(req.Header))
+	for k, vals := range req.Header {
+		headers[k] = strings.Join(vals, ",")
+	}
+	ctx.JSON(http.StatusOK, &api.HookPreview{
+		Method:  req.Method,
+		URL:     req.URL.String(),
+		Headers: headers,
+		Body:    string(body),
+	})
+}
+
+// applyTemplateHookConfig applies the template options of the config to the templated webhook `w`, whose
+// current metadata is `meta`. If they are invalid, write to `ctx` accordingly. Return whether successful
+func applyTemplateHookConfig(ctx *context.APIContext, config map[string]string, w *webhook.Webhook, meta *webhook_service.TemplateMeta) bool {
+	if method, ok := config["http_method"]; ok {
+		method = strings.ToUpper(strings.TrimSpace(method))
+		if method != http.MethodPost && method != http.MethodPut && method != http.MethodPatch {
+			ctx.APIError(http.StatusUnprocessableEntity, "Invalid http_method")
+			return false
+		}
+		w.HTTPMethod = method
+	}
+	if contentType, ok := config["body_content_type"]; ok {
+		meta.ContentType = strings.TrimSpace(contentType)
+	}
+	if body, ok := config["body_template"]; ok {
+		meta.BodyTemplate = body
+	}
+	if headers, ok := config["headers_template"]; ok {
+		meta.HeadersTemplate = headers
+	}
+	if err := webhook_service.ValidateTemplateMeta(meta); err != nil {
+		ctx.APIError(http.StatusUnprocessableEntity, "Invalid template: "+err.Error())
+		return false
+	}
+	b, err := json.Marshal(meta)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return false
+	}
+	w.Meta = string(b)
+	return true
+}
+
 // EditSystemHook edit system webhook `w` according to `form`. Writes to `ctx` accordingly
 func EditSystemHook(ctx *context.APIContext, form *api.EditHookOption, hookID int64) {
 	hook, err := webhook.GetSystemOrDefaultWebhook(ctx, hookID)
@@ -369,6 +437,11 @@ func editHook(ctx *context.APIContext, form *api.EditHookOption, w *webhook.Webh
 				w.Meta = string(meta)
 			}
 		}
+		if w.Type == webhook_module.TEMPLATE {
+			if !applyTemplateHookConfig(ctx, form.Config, w, webhook_service.GetTemplateHook(w)) {
+				return false
+			}
+		}
 	}
 
 	// Update events
@@ -4,6 +4,7 @@
 package common
 
 import (
+	audit_model "gitea.dev/models/audit"
 	user_model "gitea.dev/models/user"
 	"gitea.dev/modules/web/middleware"
 	auth_service "gitea.dev/services/auth"
@@ -30,6 +31,7 @@ func AuthShared(ctx *context.Base, sessionStore auth_service.SessionStore, authM
 		ctx.Data[middleware.ContextDataKeySignedUser] = ar.Doer
 		ctx.Data["SignedUserID"] = ar.Doer.ID
 		ctx.Data["IsAdmin"] = ar.Doer.IsAdmin
+		ctx.SetContextValue(audit_model.ActorContextKey, &audit_model.Actor{ID: ar.Doer.ID, Name: ar.Doer.Name})
 	} else {
 		ctx.Data["SignedUserID"] = int64(0)
 	}
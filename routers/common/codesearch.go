@@ -11,14 +11,23 @@ import (
 )
 
 func PrepareCodeSearch(ctx *context.Context) (ret struct {
-	Keyword    string
-	Language   string
-	SearchMode indexer.SearchModeType
+	Keyword       string
+	Language      string
+	SearchMode    indexer.SearchModeType
+	Path          string
+	CaseSensitive bool
 },
 ) {
 	ret.Language = ctx.FormTrim("l")
 	ret.Keyword = ctx.FormTrim("q")
 	ret.SearchMode = indexer.SearchModeType(ctx.FormTrim("search_mode"))
+	if setting.Indexer.RepoIndexerEnabled && code_indexer.SupportsSearchFilters() {
+		ret.Path = ctx.FormTrim("path")
+		ret.CaseSensitive = ctx.FormBool("case_sensitive")
+		ctx.Data["SupportsCodeSearchFilters"] = true
+		ctx.Data["CodeSearchPath"] = ret.Path
+		ctx.Data["CodeSearchCaseSensitive"] = ret.CaseSensitive
+	}
 
 	ctx.Data["Keyword"] = ret.Keyword
 	ctx.Data["Language"] = ret.Language
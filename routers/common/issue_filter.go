@@ -5,7 +5,9 @@ package common
 
 import (
 	"context"
+	"errors"
 
+	issues_model "gitea.dev/models/issues"
 	"gitea.dev/models/organization"
 	repo_model "gitea.dev/models/repo"
 	user_model "gitea.dev/models/user"
@@ -85,3 +87,36 @@ func SearchIssuesRepoIDs(ctx context.Context, opts SearchIssuesRepoIDsOptions) (
 
 	return repoIDs, allPublic, nil
 }
+
+// ParseIssueFilterCustomFields parses the "<field id>:<value>" custom field filters of an issue list of a repository,
+// the values of the fields applying to the repository are normalized to match the stored ones
+func ParseIssueFilterCustomFields(ctx context.Context, repo *repo_model.Repository, params []string) ([]issues_model.CustomFieldFilter, error) {
+	filters, err := issues_model.ParseCustomFieldFilters(params)
+	if err != nil || len(filters) == 0 {
+		return nil, err
+	}
+	fields, err := issues_model.GetCustomFieldsForRepo(ctx, repo)
+	if err != nil {
+		return nil, err
+	}
+	return issues_model.NormalizeCustomFieldFilters(fields, filters), nil
+}
+
+// ParseIssueFilterCustomFieldSort returns the sort type ordering the issues of a repository by a custom field,
+// or an empty string if the field doesn't apply to the repository or the issues can't be sorted by it
+func ParseIssueFilterCustomFieldSort(ctx context.Context, repo *repo_model.Repository, sortType string) (string, error) {
+	fieldID, _, ok := issues_model.ParseCustomFieldSortType(sortType)
+	if !ok {
+		return "", nil
+	}
+	field, err := issues_model.GetCustomFieldByIDForRepo(ctx, repo, fieldID)
+	if errors.Is(err, util.ErrNotExist) {
+		return "", nil
+	} else if err != nil {
+		return "", err
+	}
+	if !field.Type.IsSortable() {
+		return "", nil
+	}
+	return sortType, nil
+}
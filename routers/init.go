@@ -28,6 +28,7 @@ import (
 	"gitea.dev/modules/web/routing"
 	actions_router "gitea.dev/routers/api/actions"
 	packages_router "gitea.dev/routers/api/packages"
+	scim_router "gitea.dev/routers/api/scim"
 	apiv1 "gitea.dev/routers/api/v1"
 	"gitea.dev/routers/common"
 	"gitea.dev/routers/private"
@@ -37,12 +38,14 @@ import (
 	"gitea.dev/services/auth"
 	"gitea.dev/services/auth/source/oauth2"
 	"gitea.dev/services/automerge"
+	codenav_service "gitea.dev/services/codenav"
 	"gitea.dev/services/cron"
 	feed_service "gitea.dev/services/feed"
 	indexer_service "gitea.dev/services/indexer"
 	"gitea.dev/services/mailer"
 	mailer_incoming "gitea.dev/services/mailer/incoming"
 	markup_service "gitea.dev/services/markup"
+	"gitea.dev/services/mergequeue"
 	repo_migrations "gitea.dev/services/migrations"
 	mirror_service "gitea.dev/services/mirror"
 	"gitea.dev/services/oauth2_provider"
@@ -51,6 +54,7 @@ import (
 	release_service "gitea.dev/services/release"
 	repo_service "gitea.dev/services/repository"
 	"gitea.dev/services/repository/archiver"
+	secretscan_service "gitea.dev/services/secretscan"
 	"gitea.dev/services/task"
 	"gitea.dev/services/uinotification"
 	"gitea.dev/services/webhook"
@@ -153,6 +157,9 @@ func InitWebInstalled(ctx context.Context) {
 	mustInit(webhook.Init)
 	mustInit(pull_service.Init)
 	mustInit(automerge.Init)
+	mustInit(mergequeue.Init)
+	mustInit(secretscan_service.Init)
+	mustInit(codenav_service.Init)
 	mustInit(task.Init)
 	mustInit(repo_migrations.Init)
 	mustInit(websocket_service.Init)
@@ -183,6 +190,7 @@ func NormalRoutes() *web.Router {
 	r.Mount("/", web_routers.Routes())
 	r.Mount("/api/v1", apiv1.Routes())
 	r.Mount("/api/internal", private.Routes())
+	r.Mount("/api/scim/v2", scim_router.Routes())
 
 	r.Post("/-/fetch-redirect", common.FetchRedirectDelegate)
 
@@ -205,6 +213,10 @@ func NormalRoutes() *web.Router {
 		r.Mount(prefix, actions_router.ArtifactsRoutes(prefix))
 		prefix = actions_router.ArtifactV4RouteBase
 		r.Mount(prefix, actions_router.ArtifactsV4Routes(prefix))
+		if setting.Actions.CacheEnabled {
+			prefix = actions_router.CacheV2RouteBase
+			r.Mount(prefix, actions_router.CacheRoutes(prefix))
+		}
 	}
 
 	r.NotFound(func(w http.ResponseWriter, req *http.Request) {
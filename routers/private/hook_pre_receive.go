@@ -7,22 +7,26 @@ import (
 	"errors"
 	"net/http"
 	"os"
+	"strings"
 
 	asymkey_model "gitea.dev/models/asymkey"
 	git_model "gitea.dev/models/git"
 	issues_model "gitea.dev/models/issues"
 	perm_model "gitea.dev/models/perm"
 	access_model "gitea.dev/models/perm/access"
+	secretscan_model "gitea.dev/models/secretscan"
 	"gitea.dev/models/unit"
 	user_model "gitea.dev/models/user"
 	"gitea.dev/modules/git"
 	"gitea.dev/modules/git/gitcmd"
 	"gitea.dev/modules/private"
+	repo_module "gitea.dev/modules/repository"
 	"gitea.dev/modules/util"
 	"gitea.dev/modules/web"
 	"gitea.dev/services/agit"
 	gitea_context "gitea.dev/services/context"
 	pull_service "gitea.dev/services/pull"
+	secretscan_service "gitea.dev/services/secretscan"
 )
 
 type preReceiveContext struct {
@@ -123,7 +127,7 @@ func HookPreReceive(ctx *gitea_context.PrivateContext) {
 		case refFullName.IsBranch():
 			preReceiveBranch(ourCtx, oldCommitID, newCommitID, refFullName)
 		case refFullName.IsTag():
-			preReceiveTag(ourCtx, refFullName)
+			preReceiveTag(ourCtx, oldCommitID, newCommitID, refFullName)
 		case git.DefaultFeatures().SupportProcReceive && refFullName.IsFor():
 			preReceiveFor(ourCtx, refFullName)
 		default:
@@ -144,6 +148,14 @@ func preReceiveBranch(ctx *preReceiveContext, oldCommitID, newCommitID string, r
 		return
 	}
 
+	// The speculative merges of the merge queues are only pushed by the merge queue itself
+	if !ctx.opts.IsWiki && pull_service.IsMergeQueueBranch(branchName) {
+		if ctx.opts.PushTrigger != repo_module.PushTriggerMergeQueue {
+			ctx.PrivateUserErrorf(http.StatusForbidden, "Branch %s is reserved for the merge queue", branchName)
+		}
+		return
+	}
+
 	repo := ctx.Repo.Repository
 	gitRepo := ctx.Repo.GitRepo
 	objectFormat := ctx.Repo.GetObjectFormat()
@@ -157,6 +169,14 @@ func preReceiveBranch(ctx *preReceiveContext, oldCommitID, newCommitID string, r
 		return
 	}
 
+	if !preReceiveRulesets(ctx, oldCommitID, newCommitID, refFullName) {
+		return
+	}
+
+	if !preReceiveSecretScanning(ctx, newCommitID, refFullName) {
+		return
+	}
+
 	protectBranch, err := git_model.GetFirstMatchProtectedBranchRule(ctx, repo.ID, branchName)
 	if err != nil {
 		ctx.PrivateInternalErrorf("Unable to get protected branch: %v", err)
@@ -253,6 +273,20 @@ func preReceiveBranch(ctx *preReceiveContext, oldCommitID, newCommitID string, r
 
 	// 6. If we're not allowed to push directly
 	if !canPush {
+		// Is this the merge queue fast-forwarding the branch to a tested merge?
+		// The status checks have been verified by the queue on the merged result, so only the merge permission matters.
+		if ctx.opts.PushTrigger == repo_module.PushTriggerMergeQueue && !isForcePush && !changedProtectedfiles {
+			allowedMerge, err := pull_service.IsUserAllowedToMergeInBranch(ctx, repo.ID, branchName, ctx.userPerm, ctx.user)
+			if err != nil {
+				ctx.PrivateInternalErrorf("Error calculating if allowed to merge: %v", err)
+				return
+			}
+			if !allowedMerge {
+				ctx.PrivateUserErrorf(http.StatusForbidden, "Not allowed to push to protected branch %s", branchName)
+			}
+			return
+		}
+
 		// Is this is a merge from the UI/API?
 		if ctx.opts.PullRequestID == 0 {
 			// 6a. If we're not merging from the UI/API then there are two ways we got here:
@@ -330,11 +364,19 @@ func preReceiveBranch(ctx *preReceiveContext, oldCommitID, newCommitID string, r
 	}
 }
 
-func preReceiveTag(ctx *preReceiveContext, refFullName git.RefName) {
+func preReceiveTag(ctx *preReceiveContext, oldCommitID, newCommitID string, refFullName git.RefName) {
 	if !ctx.assertCanWriteRef(refFullName) {
 		return
 	}
 
+	if !preReceiveRulesets(ctx, oldCommitID, newCommitID, refFullName) {
+		return
+	}
+
+	if !preReceiveSecretScanning(ctx, newCommitID, refFullName) {
+		return
+	}
+
 	tagName := refFullName.TagName()
 
 	if !ctx.gotProtectedTags {
@@ -358,6 +400,101 @@ func preReceiveTag(ctx *preReceiveContext, refFullName git.RefName) {
 	}
 }
 
+// preReceiveRulesets returns true if the ref update satisfies the active rulesets of the ref,
+// otherwise it responds with 403 Forbidden and returns false
+func preReceiveRulesets(ctx *preReceiveContext, oldCommitID, newCommitID string, refFullName git.RefName) bool {
+	if ctx.opts.IsWiki {
+		return true
+	}
+
+	opts := &pull_service.RulesetPushOptions{
+		Repo:        ctx.Repo.Repository,
+		GitRepo:     ctx.Repo.GitRepo,
+		RefName:     refFullName,
+		OldCommitID: oldCommitID,
+		NewCommitID: newCommitID,
+		Env:         ctx.env,
+	}
+	// the status checks of a merged pull request are the ones of its head
+	if ctx.opts.PullRequestID != 0 {
+		pr, err := issues_model.GetPullRequestByID(ctx, ctx.opts.PullRequestID)
+		if err != nil {
+			ctx.PrivateInternalErrorf("Unable to get PullRequest %d Error: %v", ctx.opts.PullRequestID, err)
+			return false
+		}
+		opts.StatusCommitID, err = ctx.Repo.GitRepo.GetRefCommitID(ctx, pr.GetGitHeadRefName())
+		if err != nil {
+			ctx.PrivateInternalErrorf("Unable to get the head commit of PullRequest %d: %v", ctx.opts.PullRequestID, err)
+			return false
+		}
+	}
+
+	violations, err := pull_service.EvaluateRulesetsForPush(ctx, opts)
+	if err != nil {
+		ctx.PrivateInternalErrorf("Unable to check the rulesets of %s: %v", refFullName, err)
+		return false
+	}
+	if enforced := violations.Enforced(ctx.Repo.Repository, refFullName); len(enforced) > 0 {
+		ctx.PrivateUserErrorf(http.StatusForbidden, "Ref %s is protected by a ruleset: %s", refFullName.ShortName(), enforced.String())
+		return false
+	}
+	return true
+}
+
+// preReceiveSecretScanning returns true if the ref update adds no secret blocked by the push protection, or if a repository
+// administrator bypasses the push protection, otherwise it responds with 403 Forbidden and returns false
+func preReceiveSecretScanning(ctx *preReceiveContext, newCommitID string, refFullName git.RefName) bool {
+	if ctx.opts.IsWiki {
+		return true
+	}
+
+	detections, err := secretscan_service.FindBlockedSecrets(ctx, &secretscan_service.PushOptions{
+		Repo:        ctx.Repo.Repository,
+		GitRepo:     ctx.Repo.GitRepo,
+		RefName:     refFullName,
+		NewCommitID: newCommitID,
+		Env:         ctx.env,
+	})
+	if err != nil {
+		ctx.PrivateInternalErrorf("Unable to scan the commits pushed to %s for secrets: %v", refFullName, err)
+		return false
+	}
+	if len(detections) == 0 {
+		return true
+	}
+
+	if value, ok := ctx.opts.GitPushOptions[private.GitPushOptionSecretScanningBypass]; ok {
+		reason := secretscan_model.BypassReason(value)
+		switch {
+		case !reason.IsValid():
+			ctx.PrivateUserErrorf(http.StatusForbidden, "Invalid secret scanning bypass reason %q, it must be one of: %s", value, strings.Join(bypassReasonNames(), ", "))
+		case ctx.opts.DeployKeyID != 0 || !ctx.userPerm.IsAdmin():
+			ctx.PrivateUserErrorf(http.StatusForbidden, "Only repository administrators can bypass the secret scanning push protection")
+		default:
+			if err := secretscan_service.BypassPushProtection(ctx, ctx.Repo.Repository, ctx.user, detections, reason); err != nil {
+				ctx.PrivateInternalErrorf("Unable to record the bypass of the secret scanning push protection: %v", err)
+				return false
+			}
+			return true
+		}
+		return false
+	}
+
+	ctx.PrivateUserErrorf(http.StatusForbidden, "Push to %s rejected, %d secret(s) found in the pushed commits:\n%s\n"+
+		"Remove the secrets from the commits and push again, or revoke them. Repository administrators can push them anyway with:\n"+
+		"  git push -o %s=<%s>",
+		refFullName.ShortName(), len(detections), detections.String(), private.GitPushOptionSecretScanningBypass, strings.Join(bypassReasonNames(), "|"))
+	return false
+}
+
+func bypassReasonNames() []string {
+	names := make([]string, 0, len(secretscan_model.BypassReasons))
+	for _, r := range secretscan_model.BypassReasons {
+		names = append(names, string(r))
+	}
+	return names
+}
+
 func preReceiveFor(ctx *preReceiveContext, refFullName git.RefName) {
 	if !ctx.AssertCreatePullRequest() {
 		return
@@ -23,10 +23,12 @@ import (
 	"gitea.dev/services/auth/source/ldap"
 	"gitea.dev/services/auth/source/oauth2"
 	pam_service "gitea.dev/services/auth/source/pam"
+	saml_service "gitea.dev/services/auth/source/saml"
 	"gitea.dev/services/auth/source/smtp"
 	"gitea.dev/services/auth/source/sspi"
 	"gitea.dev/services/context"
 	"gitea.dev/services/forms"
+	scim_service "gitea.dev/services/scim"
 )
 
 const (
@@ -68,6 +70,7 @@ var (
 			{auth.SMTP.String(), auth.SMTP},
 			{auth.OAuth2.String(), auth.OAuth2},
 			{auth.SSPI.String(), auth.SSPI},
+			{auth.SAML.String(), auth.SAML},
 		}
 		if pam.Supported {
 			items = append(items, dropdownItem{auth.Names[auth.PAM], auth.PAM})
@@ -232,6 +235,44 @@ func parseSSPIConfig(ctx *context.Context, form forms.AuthenticationForm) (*sspi
 	}, nil
 }
 
+// parseSAMLConfig builds the SAML config, the identity provider metadata is downloaded again when its URL is
+// set and the private key kept when it is left blank
+func parseSAMLConfig(ctx *context.Context, form forms.AuthenticationForm, existing *saml_service.Source) (*saml_service.Source, error) {
+	config := &saml_service.Source{
+		IdentityProviderMetadata:    form.SAMLIdentityProviderMetadata,
+		IdentityProviderMetadataURL: form.SAMLIdentityProviderMetadataURL,
+		ServiceProviderCertificate:  form.SAMLServiceProviderCertificate,
+		ServiceProviderPrivateKey:   form.SAMLServiceProviderPrivateKey,
+		NameIDFormat:                form.SAMLNameIDFormat,
+		AllowIdPInitiated:           form.SAMLAllowIDPInitiated,
+		AutoRegister:                form.SAMLAutoRegister,
+		IconURL:                     form.SAMLIconURL,
+		AttributeUsername:           form.SAMLAttributeUsername,
+		AttributeEmail:              form.SAMLAttributeEmail,
+		AttributeFullName:           form.SAMLAttributeFullName,
+		AttributeGroups:             form.SAMLAttributeGroups,
+		AdminGroup:                  form.SAMLAdminGroup,
+		RestrictedGroup:             form.SAMLRestrictedGroup,
+		GroupTeamMap:                form.SAMLGroupTeamMap,
+		GroupTeamMapRemoval:         form.SAMLGroupTeamMapRemoval,
+	}
+	if config.ServiceProviderPrivateKey == "" && existing != nil && config.ServiceProviderCertificate != "" {
+		config.ServiceProviderPrivateKey = existing.ServiceProviderPrivateKey
+	}
+	if config.IdentityProviderMetadataURL != "" {
+		metadata, err := saml_service.FetchIdentityProviderMetadata(ctx, config.IdentityProviderMetadataURL)
+		if err != nil {
+			ctx.Data["Err_SAMLIdentityProviderMetadataURL"] = true
+			return nil, errors.New(ctx.Locale.TrString("admin.auths.saml_invalid_metadata", err.Error()))
+		}
+		config.IdentityProviderMetadata = metadata
+	}
+	if err := config.Validate(); err != nil {
+		return nil, errors.New(ctx.Locale.TrString("admin.auths.saml_invalid_config", err.Error()))
+	}
+	return config, nil
+}
+
 // NewAuthSourcePost response for adding an auth source
 func NewAuthSourcePost(ctx *context.Context) {
 	form := *web.GetForm[*forms.AuthenticationForm](ctx)
@@ -290,6 +331,13 @@ func NewAuthSourcePost(ctx *context.Context) {
 			ctx.RenderWithErrDeprecated(ctx.Tr("admin.auths.login_source_of_type_exist"), tplAuthNew, form)
 			return
 		}
+	case auth.SAML:
+		var err error
+		config, err = parseSAMLConfig(ctx, form, nil)
+		if err != nil {
+			ctx.RenderWithErrDeprecated(err.Error(), tplAuthNew, form)
+			return
+		}
 	default:
 		ctx.HTTPError(http.StatusBadRequest)
 		return
@@ -354,6 +402,19 @@ func EditAuthSource(ctx *context.Context) {
 			}
 		}
 	}
+	if source.IsSAML() {
+		ctx.Data["SAMLMetadataURL"] = saml_service.EndpointURL(source.Name, "metadata")
+		ctx.Data["SAMLAssertionConsumerServiceURL"] = saml_service.EndpointURL(source.Name, "acs")
+		ctx.Data["SAMLSingleLogoutServiceURL"] = saml_service.EndpointURL(source.Name, "slo")
+	}
+
+	scimToken, _, err := auth.GetSCIMTokenBySourceID(ctx, source.ID)
+	if err != nil {
+		ctx.ServerError("auth.GetSCIMTokenBySourceID", err)
+		return
+	}
+	ctx.Data["SCIMEndpointURL"] = scim_service.EndpointURL("")
+	ctx.Data["SCIMToken"] = scimToken
 
 	ctx.HTML(http.StatusOK, tplAuthEdit)
 }
@@ -409,6 +470,13 @@ func EditAuthSourcePost(ctx *context.Context) {
 			ctx.RenderWithErrDeprecated(err.Error(), tplAuthEdit, form)
 			return
 		}
+	case auth.SAML:
+		existing, _ := source.Cfg.(*saml_service.Source)
+		config, err = parseSAMLConfig(ctx, form, existing)
+		if err != nil {
+			ctx.RenderWithErrDeprecated(err.Error(), tplAuthEdit, form)
+			return
+		}
 	default:
 		ctx.HTTPError(http.StatusBadRequest)
 		return
@@ -460,3 +528,41 @@ func DeleteAuthSource(ctx *context.Context) {
 	ctx.Flash.Success(ctx.Tr("admin.auths.deletion_success"))
 	ctx.JSONRedirect(setting.AppSubURL + "/-/admin/auths")
 }
+
+// GenerateSCIMToken generates the SCIM token of an auth source, the token is only shown once
+func GenerateSCIMToken(ctx *context.Context) {
+	source, err := auth.GetSourceByID(ctx, ctx.PathParamInt64("authid"))
+	if err != nil {
+		ctx.ServerError("auth.GetSourceByID", err)
+		return
+	}
+
+	t, err := scim_service.GenerateToken(ctx, source)
+	if err != nil {
+		ctx.ServerError("scim_service.GenerateToken", err)
+		return
+	}
+	log.Trace("SCIM token of authentication %d generated by admin(%s)", source.ID, ctx.Doer.Name)
+
+	ctx.Flash.Success(ctx.Tr("admin.auths.scim_token_generate_success"))
+	ctx.Flash.Info(t.Token)
+	ctx.JSONRedirect(setting.AppSubURL + "/-/admin/auths/" + strconv.FormatInt(source.ID, 10))
+}
+
+// RevokeSCIMToken revokes the SCIM token of an auth source
+func RevokeSCIMToken(ctx *context.Context) {
+	source, err := auth.GetSourceByID(ctx, ctx.PathParamInt64("authid"))
+	if err != nil {
+		ctx.ServerError("auth.GetSourceByID", err)
+		return
+	}
+
+	if err := scim_service.RevokeToken(ctx, source); err != nil {
+		ctx.ServerError("scim_service.RevokeToken", err)
+		return
+	}
+	log.Trace("SCIM token of authentication %d revoked by admin(%s)", source.ID, ctx.Doer.Name)
+
+	ctx.Flash.Success(ctx.Tr("admin.auths.scim_token_revoke_success"))
+	ctx.JSONRedirect(setting.AppSubURL + "/-/admin/auths/" + strconv.FormatInt(source.ID, 10))
+}
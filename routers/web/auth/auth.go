@@ -29,6 +29,7 @@ import (
 	"gitea.dev/modules/web/middleware"
 	auth_service "gitea.dev/services/auth"
 	"gitea.dev/services/auth/source/oauth2"
+	"gitea.dev/services/auth/source/saml"
 	"gitea.dev/services/context"
 	"gitea.dev/services/externalaccount"
 	"gitea.dev/services/forms"
@@ -224,7 +225,8 @@ func performAutoLoginOAuth2(ctx *context.Context, data *preparedSignInData) bool
 		!setting.Service.EnablePasswordSignInForm &&
 		!setting.Service.EnableOpenIDSignIn &&
 		!setting.Service.EnablePasskeyAuth &&
-		!data.enableSSPI
+		!data.enableSSPI &&
+		len(data.samlProviders) == 0
 
 	if !onlySingleOAuth2 {
 		return false
@@ -240,6 +242,7 @@ func performAutoLoginOAuth2(ctx *context.Context, data *preparedSignInData) bool
 
 type preparedSignInData struct {
 	oauth2Providers []oauth2.Provider
+	samlProviders   []*saml.Provider
 	enableSSPI      bool
 }
 
@@ -250,8 +253,13 @@ func prepareSignInPageData(ctx *context.Context) (ret preparedSignInData) {
 	if err != nil {
 		log.Error("Failed to get OAuth2 providers: %v", err)
 	}
+	ret.samlProviders, err = saml.GetActiveSAMLProviders(ctx)
+	if err != nil {
+		log.Error("Failed to get SAML providers: %v", err)
+	}
 	ctx.Data["Title"] = ctx.Tr("sign_in")
 	ctx.Data["OAuth2Providers"] = ret.oauth2Providers
+	ctx.Data["SAMLProviders"] = ret.samlProviders
 	ctx.Data["Title"] = ctx.Tr("sign_in")
 	ctx.Data["SignInLink"] = setting.AppSubURL + "/user/login"
 	ctx.Data["PageIsSignIn"] = true
@@ -475,6 +483,11 @@ func buildSignOutRedirectURL(ctx *context.Context) string {
 			return s
 		}
 	}
+	if ctx.Doer != nil && ctx.Session.Get(session.KeySignInMethod) == session.SignInMethodSAML {
+		if s := buildSAMLLogoutURL(ctx); s != "" {
+			return s
+		}
+	}
 
 	// The assumption is: if reverse proxy auth is enabled, then the users should only sign-in via reverse proxy auth.
 	if setting.Service.EnableReverseProxyAuth && setting.ReverseProxyLogoutRedirect != "" {
@@ -510,6 +523,13 @@ func prepareSignUpPageData(ctx *context.Context) bool {
 	}
 	ctx.Data["OAuth2Providers"] = oauth2Providers
 
+	samlProviders, err := saml.GetActiveSAMLProviders(ctx)
+	if err != nil {
+		ctx.ServerError("GetActiveSAMLProviders", err)
+		return false
+	}
+	ctx.Data["SAMLProviders"] = samlProviders
+
 	prepareCommonAuthPageData(ctx, CommonAuthOptions{
 		EnableCaptcha: setting.Service.EnableCaptcha,
 	})
@@ -21,6 +21,8 @@ import (
 	"gitea.dev/services/context"
 	"gitea.dev/services/externalaccount"
 	"gitea.dev/services/forms"
+
+	"github.com/markbates/goth"
 )
 
 var tplLinkAccount templates.TplName = "user/auth/link_account"
@@ -63,7 +65,7 @@ func LinkAccount(ctx *context.Context) {
 		ctx.Data["AutoRegistrationFailedPrompt"] = ctx.Tr("auth.oauth_callback_unable_auto_reg", linkAccountData.GothUser.Provider, strings.Join(missingFields, ","))
 	}
 
-	uname, err := extractUserNameFromOAuth2(&linkAccountData.GothUser)
+	uname, err := extractUserNameFromLinkAccountData(ctx, linkAccountData)
 	if err != nil {
 		ctx.ServerError("UserSignIn", err)
 		return
@@ -95,6 +97,18 @@ func LinkAccount(ctx *context.Context) {
 	ctx.HTML(http.StatusOK, tplLinkAccount)
 }
 
+// extractUserNameFromLinkAccountData returns the normalized username the external account suggests
+func extractUserNameFromLinkAccountData(ctx *context.Context, linkAccountData *LinkAccountData) (string, error) {
+	authSource, err := auth.GetSourceByID(ctx, linkAccountData.AuthSourceID)
+	if err != nil {
+		return "", err
+	}
+	if authSource.IsSAML() {
+		return user_model.NormalizeUserName(linkAccountData.GothUser.NickName)
+	}
+	return extractUserNameFromOAuth2(&linkAccountData.GothUser)
+}
+
 func handleSignInError(ctx *context.Context, userName string, ptrForm any, tmpl templates.TplName, invoker string, err error) {
 	if errors.Is(err, util.ErrNotExist) {
 		ctx.RenderWithErrDeprecated(ctx.Tr("form.username_password_incorrect"), tmpl, ptrForm)
@@ -139,8 +153,30 @@ func LinkAccountPostSignIn(ctx *context.Context) {
 	oauth2LinkAccount(ctx, u, linkAccountData, signInForm.Remember)
 }
 
+// externalSignInSync updates the user with the external account of the OAuth2 or SAML source it signs in with
+func externalSignInSync(ctx *context.Context, authSourceID int64, u *user_model.User, gothUser goth.User) {
+	authSource, err := auth.GetSourceByID(ctx, authSourceID)
+	if err != nil {
+		ctx.ServerError("GetSourceByID", err)
+		return
+	}
+	if authSource.IsSAML() {
+		samlSignInSync(ctx, authSource, u, &gothUser)
+		return
+	}
+	oauth2SignInSync(ctx, authSourceID, u, gothUser)
+}
+
+// externalSignInMethod returns the session sign-in method of the OAuth2 or SAML source
+func externalSignInMethod(ctx *context.Context, authSourceID int64) string {
+	if authSource, err := auth.GetSourceByID(ctx, authSourceID); err == nil && authSource.IsSAML() {
+		return session.SignInMethodSAML
+	}
+	return session.SignInMethodOAuth2
+}
+
 func oauth2LinkAccount(ctx *context.Context, u *user_model.User, linkAccountData *LinkAccountData, remember bool) {
-	oauth2SignInSync(ctx, linkAccountData.AuthSourceID, u, linkAccountData.GothUser)
+	externalSignInSync(ctx, linkAccountData.AuthSourceID, u, linkAccountData.GothUser)
 	if ctx.Written() {
 		return
 	}
@@ -170,7 +206,7 @@ func oauth2LinkAccount(ctx *context.Context, u *user_model.User, linkAccountData
 
 	handleTwoFactorRequired(ctx, u, remember, map[string]any{
 		"linkAccount":           true,
-		session.KeySignInMethod: session.SignInMethodOAuth2,
+		session.KeySignInMethod: externalSignInMethod(ctx, linkAccountData.AuthSourceID),
 	})
 }
 
@@ -229,11 +265,17 @@ func LinkAccountPostRegister(ctx *context.Context) {
 		}
 	}
 
+	authSource, err := auth.GetSourceByID(ctx, linkAccountData.AuthSourceID)
+	if err != nil {
+		ctx.ServerError("GetSourceByID", err)
+		return
+	}
+
 	u := &user_model.User{
 		Name:        form.UserName,
 		Email:       form.Email,
 		Passwd:      form.Password,
-		LoginType:   auth.OAuth2,
+		LoginType:   authSource.Type,
 		LoginSource: linkAccountData.AuthSourceID,
 		LoginName:   linkAccountData.GothUser.UserID,
 	}
@@ -243,20 +285,17 @@ func LinkAccountPostRegister(ctx *context.Context) {
 		return
 	}
 
-	oauth2SignInSync(ctx, linkAccountData.AuthSourceID, u, linkAccountData.GothUser)
+	externalSignInSync(ctx, linkAccountData.AuthSourceID, u, linkAccountData.GothUser)
 	if ctx.Written() {
 		return
 	}
 
-	authSource, err := auth.GetSourceByID(ctx, linkAccountData.AuthSourceID)
-	if err != nil {
-		ctx.ServerError("GetSourceByID", err)
-		return
-	}
-	source := auth.MustSourceCfg[*oauth2.Source](authSource)
-	if err := syncGroupsToTeams(ctx, source, &linkAccountData.GothUser, u); err != nil {
-		ctx.ServerError("SyncGroupsToTeams", err)
-		return
+	// the SAML sign-in sync already maps the groups to the teams
+	if source, ok := authSource.Cfg.(*oauth2.Source); ok {
+		if err := syncGroupsToTeams(ctx, source, &linkAccountData.GothUser, u); err != nil {
+			ctx.ServerError("SyncGroupsToTeams", err)
+			return
+		}
 	}
 
 	handleSignIn(ctx, u, false)
@@ -0,0 +1,464 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"gitea.dev/models/auth"
+	user_model "gitea.dev/models/user"
+	auth_module "gitea.dev/modules/auth"
+	"gitea.dev/modules/cache"
+	"gitea.dev/modules/log"
+	"gitea.dev/modules/optional"
+	"gitea.dev/modules/saml"
+	"gitea.dev/modules/session"
+	"gitea.dev/modules/setting"
+	"gitea.dev/modules/util"
+	"gitea.dev/modules/web/middleware"
+	source_service "gitea.dev/services/auth/source"
+	saml_source "gitea.dev/services/auth/source/saml"
+	"gitea.dev/services/context"
+	"gitea.dev/services/externalaccount"
+	user_service "gitea.dev/services/user"
+	websocket_service "gitea.dev/services/websocket"
+
+	"github.com/markbates/goth"
+)
+
+const (
+	// samlRequestCookieName keeps the ID of the pending authentication request and where to redirect after it,
+	// to bind the response of the identity provider to the browser which started the sign-in
+	samlRequestCookieName = "gitea_saml_request"
+	// samlLogoutCookieName keeps the ID of the pending logout request
+	samlLogoutCookieName = "gitea_saml_logout"
+	samlCookieMaxAge     = 10 * 60
+)
+
+// setSAMLCookie sets a cookie the browser must send back with the cross-site POST of the identity provider,
+// which the default SameSite=Lax cookies are not. Browsers only accept SameSite=None on secure cookies, on
+// plain HTTP the browser default is used.
+func setSAMLCookie(resp http.ResponseWriter, name, value string, maxAge int) {
+	cookie := &http.Cookie{
+		Name:     name,
+		Value:    url.QueryEscape(value),
+		MaxAge:   maxAge,
+		Path:     util.IfZero(setting.SessionConfig.CookiePath, "/"),
+		Domain:   setting.SessionConfig.Domain,
+		Secure:   setting.SessionConfig.Secure,
+		HttpOnly: true,
+		SameSite: http.SameSiteDefaultMode,
+	}
+	if setting.SessionConfig.Secure {
+		cookie.SameSite = http.SameSiteNoneMode
+	}
+	resp.Header().Add("Set-Cookie", cookie.String())
+}
+
+// readSAMLCookie returns the values stored in the cookie and deletes it, the requests can be answered only once
+func readSAMLCookie(ctx *context.Context, name string) url.Values {
+	values, _ := url.ParseQuery(middleware.GetSiteCookie(ctx.Req, name))
+	setSAMLCookie(ctx.Resp, name, "", -1)
+	return values
+}
+
+// samlServiceProvider returns the active SAML source of the request and its service provider
+func samlServiceProvider(ctx *context.Context) (*auth.Source, *saml.ServiceProvider) {
+	authSource, err := auth.GetActiveSAMLSourceByName(ctx, ctx.PathParam("provider"))
+	if err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			ctx.NotFound(err)
+		} else {
+			ctx.ServerError("GetActiveSAMLSourceByName", err)
+		}
+		return nil, nil
+	}
+	sp, err := auth.MustSourceCfg[*saml_source.Source](authSource).ServiceProvider()
+	if err != nil {
+		ctx.ServerError("ServiceProvider", err)
+		return nil, nil
+	}
+	return authSource, sp
+}
+
+// SAMLMetadata serves the metadata of the service provider of a SAML source, to register it at the identity provider
+func SAMLMetadata(ctx *context.Context) {
+	_, sp := samlServiceProvider(ctx)
+	if ctx.Written() {
+		return
+	}
+	metadata, err := sp.Metadata()
+	if err != nil {
+		ctx.ServerError("Metadata", err)
+		return
+	}
+	ctx.Resp.Header().Set("Content-Type", "application/samlmetadata+xml")
+	ctx.Resp.WriteHeader(http.StatusOK)
+	_, _ = ctx.Resp.Write(metadata)
+}
+
+// SignInSAML sends the user to the identity provider of a SAML source to sign in
+func SignInSAML(ctx *context.Context) {
+	_, sp := samlServiceProvider(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	redirectURL, requestID, err := sp.AuthnRequestURL("")
+	if err != nil {
+		ctx.ServerError("AuthnRequestURL", err)
+		return
+	}
+	// the sign-in page remembers the redirect link in a cookie the cross-site POST of the response won't send
+	redirectTo := util.IfZero(ctx.FormString("redirect_to"), middleware.GetRedirectToCookie(ctx.Req))
+	setSAMLCookie(ctx.Resp, samlRequestCookieName, url.Values{
+		"id":          {requestID},
+		"redirect_to": {redirectTo},
+	}.Encode(), samlCookieMaxAge)
+	ctx.Redirect(redirectURL)
+}
+
+// SAMLAssertionConsumer handles the responses the identity provider posts after the user signed in, to answer
+// the authentication requests of SignInSAML or on its own when the source allows it
+func SAMLAssertionConsumer(ctx *context.Context) {
+	authSource, sp := samlServiceProvider(ctx)
+	if ctx.Written() {
+		return
+	}
+	samlSource := auth.MustSourceCfg[*saml_source.Source](authSource)
+
+	pending := readSAMLCookie(ctx, samlRequestCookieName)
+	assertion, err := sp.ParseResponse(ctx.Req.PostFormValue("SAMLResponse"), pending.Get("id"))
+	if err != nil {
+		log.Warn("Invalid SAML response for source %q from %s: %v", authSource.Name, ctx.RemoteAddr(), err)
+		ctx.Flash.Error(ctx.Tr("auth.saml.signin.error", authSource.Name))
+		ctx.Redirect(setting.AppSubURL + "/user/login")
+		return
+	}
+	redirectTo := pending.Get("redirect_to")
+	if pending.Get("id") == "" {
+		// the identity provider may pass the page to land on as the relay state of the responses it sends on its own
+		redirectTo = ctx.Req.PostFormValue("RelayState")
+	}
+
+	// the assertions are bearer tokens, each one must be used only once
+	replayKey := cache.SafeCacheKey("saml_assertion", fmt.Sprintf("%d:%s", authSource.ID, assertion.ID))
+	if ctx.Cache.IsExist(replayKey) {
+		log.Warn("Replayed SAML assertion %q for source %q from %s", assertion.ID, authSource.Name, ctx.RemoteAddr())
+		ctx.Flash.Error(ctx.Tr("auth.saml.signin.error", authSource.Name))
+		ctx.Redirect(setting.AppSubURL + "/user/login")
+		return
+	}
+	ttl := int64(time.Until(assertion.ExpiresAt.Add(saml.MaxClockSkew)).Seconds()) + 1
+	if err := ctx.Cache.Put(replayKey, "1", ttl); err != nil {
+		ctx.ServerError("CachePut", err)
+		return
+	}
+
+	gothUser := samlSource.ToGothUser(assertion)
+	u, err := samlUserByNameID(ctx, authSource, assertion.NameID)
+	if err != nil {
+		ctx.ServerError("UserSignIn", err)
+		return
+	}
+
+	if u == nil {
+		if ctx.Doer != nil {
+			// attach user to the current signed-in user
+			if err := externalaccount.LinkAccountToUser(ctx, authSource.ID, ctx.Doer, gothUser); err != nil {
+				ctx.ServerError("UserLinkAccount", err)
+				return
+			}
+			ctx.Redirect(setting.AppSubURL + "/user/settings/security")
+			return
+		}
+
+		if redirectTo != "" {
+			middleware.SetRedirectToCookie(ctx.Resp, redirectTo)
+		}
+		if setting.Service.AllowOnlyInternalRegistration || !samlSource.AutoRegister {
+			// no existing user is found, request attach or new account
+			showLinkingLogin(ctx, authSource.ID, gothUser)
+			return
+		}
+
+		uname, err := user_model.NormalizeUserName(gothUser.NickName)
+		if err != nil {
+			ctx.ServerError("NormalizeUserName", err)
+			return
+		}
+		var missingFields []string
+		if gothUser.Email == "" {
+			missingFields = append(missingFields, util.IfZero(samlSource.AttributeEmail, "email"))
+		}
+		if uname == "" {
+			missingFields = append(missingFields, util.IfZero(samlSource.AttributeUsername, "NameID"))
+		}
+		if len(missingFields) > 0 {
+			log.Error("SAML auto registration is enabled but the identity provider of %q doesn't return the attributes: %s", authSource.Name, strings.Join(missingFields, ","))
+			gothUser.RawData["__giteaAutoRegMissingFields"] = missingFields
+			showLinkingLogin(ctx, authSource.ID, gothUser)
+			return
+		}
+
+		u = &user_model.User{
+			Name:        uname,
+			FullName:    gothUser.Name,
+			Email:       gothUser.Email,
+			LoginType:   auth.SAML,
+			LoginSource: authSource.ID,
+			LoginName:   gothUser.UserID,
+		}
+		overwriteDefault := &user_model.CreateUserOverwriteOptions{
+			IsActive: optional.Some(!setting.Service.RegisterManualConfirm),
+		}
+		linkAccountData := &LinkAccountData{authSource.ID, gothUser}
+		if setting.OAuth2Client.AccountLinking == setting.OAuth2AccountLinkingDisabled {
+			linkAccountData = nil
+		}
+		if !createAndHandleCreatedUser(ctx, "", nil, u, overwriteDefault, linkAccountData) {
+			// error already handled
+			return
+		}
+	}
+
+	handleSAMLSignIn(ctx, authSource, u, gothUser, assertion, redirectTo)
+}
+
+// samlUserByNameID returns the user the NameID of a SAML source identifies, or nil when there is none
+func samlUserByNameID(ctx *context.Context, authSource *auth.Source, nameID string) (*user_model.User, error) {
+	u, has, err := user_model.GetIndividualUserByLoginSource(ctx, auth.SAML, authSource.ID, nameID)
+	if err != nil || has {
+		return u, err
+	}
+
+	externalLoginUser, has, err := user_model.GetExternalLogin(ctx, authSource.ID, nameID)
+	if err != nil || !has {
+		return nil, err
+	}
+	u, err = user_model.GetUserByID(ctx, externalLoginUser.UserID)
+	if err != nil && !user_model.IsErrUserNotExist(err) {
+		return nil, err
+	}
+	if err == nil && u.IsIndividual() {
+		return u, nil
+	}
+
+	// the link is stale, remove it so the external account can be linked again
+	log.Warn("Ignoring stale external login link [external-id=%s login-source-id=%d user-id=%d]", externalLoginUser.ExternalID, externalLoginUser.LoginSourceID, externalLoginUser.UserID)
+	return nil, user_model.RemoveExternalLoginByExternalID(ctx, externalLoginUser.LoginSourceID, externalLoginUser.ExternalID)
+}
+
+func handleSAMLSignIn(ctx *context.Context, authSource *auth.Source, u *user_model.User, gothUser goth.User, assertion *saml.Assertion, redirectTo string) {
+	samlSignInSync(ctx, authSource, u, &gothUser)
+	if ctx.Written() {
+		return
+	}
+
+	needs2FA := false
+	if !authSource.TwoFactorShouldSkip() {
+		var err error
+		if needs2FA, err = auth.HasTwoFactorOrWebAuthn(ctx, u.ID); err != nil {
+			ctx.ServerError("UserSignIn", err)
+			return
+		}
+	}
+
+	if err := externalaccount.EnsureLinkExternalToUser(ctx, authSource.ID, u, gothUser); err != nil {
+		ctx.ServerError("EnsureLinkExternalToUser", err)
+		return
+	}
+
+	sessionData := map[string]any{
+		session.KeySignInMethod:     session.SignInMethodSAML,
+		session.KeySAMLSourceID:     authSource.ID,
+		session.KeySAMLNameID:       assertion.NameID,
+		session.KeySAMLNameIDFormat: assertion.NameIDFormat,
+		session.KeySAMLSessionIndex: assertion.SessionIndex,
+	}
+
+	// If this user is enrolled in 2FA and this source doesn't override it,
+	// we can't sign the user in just yet. Instead, redirect them to the 2FA authentication page.
+	if needs2FA {
+		if redirectTo != "" {
+			middleware.SetRedirectToCookie(ctx.Resp, redirectTo)
+		}
+		handleTwoFactorRequired(ctx, u, false, sessionData)
+		return
+	}
+
+	if err := user_service.UpdateUser(ctx, u, &user_service.UpdateOptions{SetLastLogin: true}); err != nil {
+		ctx.ServerError("UpdateUser", err)
+		return
+	}
+	userHasTwoFactorAuth, err := auth.HasTwoFactorOrWebAuthn(ctx, u.ID)
+	if err != nil {
+		ctx.ServerError("HasTwoFactorOrWebAuthn", err)
+		return
+	}
+	sessionData[session.KeyUID] = u.ID
+	sessionData[session.KeyUserHasTwoFactorAuth] = userHasTwoFactorAuth
+	if err := regenerateSession(ctx, sessionData); err != nil {
+		ctx.ServerError("updateSession", err)
+		return
+	}
+
+	if err := resetLocale(ctx, u); err != nil {
+		ctx.ServerError("resetLocale", err)
+		return
+	}
+
+	if setting.Config().Instance.MaintenanceMode.Value(ctx).IsActive() {
+		redirectAfterAuth(ctx)
+		return
+	}
+	middleware.DeleteRedirectToCookie(ctx.Resp)
+	ctx.RedirectToCurrentSite(redirectTo)
+}
+
+// samlSignInSync updates the user with the attributes of the assertion: the full name, the admin and restricted
+// flags and the team memberships mapped from the groups
+func samlSignInSync(ctx *context.Context, authSource *auth.Source, u *user_model.User, gothUser *goth.User) {
+	samlSource := auth.MustSourceCfg[*saml_source.Source](authSource)
+
+	if gothUser.Name != "" && u.FullName != gothUser.Name {
+		u.FullName = gothUser.Name
+		if err := user_model.UpdateUserCols(ctx, u, "full_name"); err != nil {
+			log.Error("Unable to sync SAML user full name %s: %v", gothUser.Provider, err)
+		}
+	}
+
+	groups := samlSource.Groups(gothUser)
+	opts := &user_service.UpdateOptions{}
+	if samlSource.AdminGroup != "" {
+		opts.IsAdmin = user_service.UpdateOptionFieldFromSync(groups.Contains(samlSource.AdminGroup))
+	}
+	if samlSource.RestrictedGroup != "" {
+		opts.IsRestricted = optional.Some(groups.Contains(samlSource.RestrictedGroup))
+	}
+	if opts.IsAdmin.Has() || opts.IsRestricted.Has() {
+		if err := user_service.UpdateUser(ctx, u, opts); err != nil {
+			log.Error("Unable to sync SAML user admin or restricted status %s: %v", gothUser.Provider, err)
+		}
+	}
+
+	if samlSource.GroupTeamMap != "" || samlSource.GroupTeamMapRemoval {
+		groupTeamMapping, err := auth_module.UnmarshalGroupTeamMapping(samlSource.GroupTeamMap)
+		if err != nil {
+			ctx.ServerError("UnmarshalGroupTeamMapping", err)
+			return
+		}
+		if err := source_service.SyncGroupsToTeams(ctx, u, groups, groupTeamMapping, samlSource.GroupTeamMapRemoval); err != nil {
+			ctx.ServerError("SyncGroupsToTeams", err)
+			return
+		}
+	}
+}
+
+// SAMLSingleLogout handles the logout requests the identity provider sends when the user signed out of another
+// service provider, and its responses to the logout requests of buildSAMLLogoutURL
+func SAMLSingleLogout(ctx *context.Context) {
+	authSource, sp := samlServiceProvider(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	if ctx.Req.FormValue("SAMLRequest") == "" {
+		pending := readSAMLCookie(ctx, samlLogoutCookieName)
+		if _, err := sp.ParseLogoutResponse(ctx.Req, pending.Get("id")); err != nil {
+			// the local session has already ended, only the session at the identity provider may remain
+			log.Warn("Invalid SAML logout response for source %q from %s: %v", authSource.Name, ctx.RemoteAddr(), err)
+		}
+		ctx.Redirect(setting.AppSubURL + "/")
+		return
+	}
+
+	request, err := sp.ParseLogoutRequest(ctx.Req)
+	if err != nil {
+		log.Warn("Invalid SAML logout request for source %q from %s: %v", authSource.Name, ctx.RemoteAddr(), err)
+		ctx.HTTPError(http.StatusBadRequest)
+		return
+	}
+
+	if samlSessionMatches(ctx, authSource, request) {
+		if ctx.Doer != nil {
+			websocket_service.PublishLogout(ctx.Doer.ID, ctx.Session.ID())
+		}
+		HandleSignOut(ctx)
+	}
+
+	// the identity provider is told the logout succeeded when the session is not the one of the request too,
+	// it has already ended or belongs to another user
+	redirectURL, err := sp.LogoutResponseURL(request.ID, request.RelayState)
+	if err != nil {
+		ctx.ServerError("LogoutResponseURL", err)
+		return
+	}
+	ctx.Redirect(redirectURL)
+}
+
+// samlSessionMatches reports whether the logout request of the identity provider is for the current session
+func samlSessionMatches(ctx *context.Context, authSource *auth.Source, request *saml.LogoutRequest) bool {
+	if ctx.Session.Get(session.KeySignInMethod) != session.SignInMethodSAML {
+		return false
+	}
+	if sourceID, _ := ctx.Session.Get(session.KeySAMLSourceID).(int64); sourceID != authSource.ID {
+		return false
+	}
+	if nameID, _ := ctx.Session.Get(session.KeySAMLNameID).(string); nameID != request.NameID {
+		return false
+	}
+	sessionIndex, _ := ctx.Session.Get(session.KeySAMLSessionIndex).(string)
+	if len(request.SessionIndexes) == 0 || sessionIndex == "" {
+		return true
+	}
+	for _, index := range request.SessionIndexes {
+		if index == sessionIndex {
+			return true
+		}
+	}
+	return false
+}
+
+// buildSAMLLogoutURL returns the URL sending a logout request for the session to the identity provider, or an
+// empty string when the session didn't start at a SAML identity provider supporting the single logout
+func buildSAMLLogoutURL(ctx *context.Context) string {
+	sourceID, _ := ctx.Session.Get(session.KeySAMLSourceID).(int64)
+	nameID, _ := ctx.Session.Get(session.KeySAMLNameID).(string)
+	if sourceID == 0 || nameID == "" {
+		return ""
+	}
+	authSource, err := auth.GetSourceByID(ctx, sourceID)
+	if err != nil {
+		log.Error("Failed to get auth source for SAML logout (source=%d): %v", sourceID, err)
+		return ""
+	}
+	samlSource, ok := authSource.Cfg.(*saml_source.Source)
+	if !ok || !authSource.IsActive {
+		return ""
+	}
+	sp, err := samlSource.ServiceProvider()
+	if err != nil {
+		log.Error("Invalid SAML source %q: %v", authSource.Name, err)
+		return ""
+	}
+	if sp.IdentityProvider.SLOURL == "" {
+		return ""
+	}
+
+	nameIDFormat, _ := ctx.Session.Get(session.KeySAMLNameIDFormat).(string)
+	sessionIndex, _ := ctx.Session.Get(session.KeySAMLSessionIndex).(string)
+	redirectURL, requestID, err := sp.LogoutRequestURL(nameID, nameIDFormat, sessionIndex, "")
+	if err != nil {
+		log.Error("Failed to build the SAML logout request for source %q: %v", authSource.Name, err)
+		return ""
+	}
+	setSAMLCookie(ctx.Resp, samlLogoutCookieName, url.Values{"id": {requestID}}.Encode(), samlCookieMaxAge)
+	return redirectURL
+}
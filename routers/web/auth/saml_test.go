@@ -0,0 +1,180 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package auth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	auth_model "gitea.dev/models/auth"
+	"gitea.dev/models/unittest"
+	user_model "gitea.dev/models/user"
+	"gitea.dev/modules/saml"
+	"gitea.dev/modules/saml/samltest"
+	"gitea.dev/modules/session"
+	"gitea.dev/modules/test"
+	saml_source "gitea.dev/services/auth/source/saml"
+	"gitea.dev/services/contexttest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func responseCookie(t *testing.T, resp *httptest.ResponseRecorder, name string) *http.Cookie {
+	for _, cookie := range resp.Result().Cookies() {
+		if cookie.Name == name {
+			return &http.Cookie{Name: cookie.Name, Value: cookie.Value}
+		}
+	}
+	require.Failf(t, "cookie not found", "no %q cookie in the response", name)
+	return nil
+}
+
+func TestWebAuthSAML(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	idp, err := samltest.NewIdentityProvider("https://idp.example.com")
+	require.NoError(t, err)
+	require.NoError(t, auth_model.CreateSource(t.Context(), &auth_model.Source{
+		Type:     auth_model.SAML,
+		Name:     "corp saml",
+		IsActive: true,
+		Cfg: &saml_source.Source{
+			IdentityProviderMetadata: string(idp.Metadata()),
+			AutoRegister:             true,
+			AttributeUsername:        "uid",
+			AttributeEmail:           "mail",
+			AttributeFullName:        "displayName",
+		},
+	}))
+	authSource, err := auth_model.GetActiveSAMLSourceByName(t.Context(), "corp saml")
+	require.NoError(t, err)
+	acsURL := saml_source.EndpointURL("corp saml", "acs")
+	sloURL := saml_source.EndpointURL("corp saml", "slo")
+
+	newResponse := func(t *testing.T, inResponseTo string) string {
+		samlResponse, err := idp.Response(samltest.ResponseOptions{
+			InResponseTo: inResponseTo,
+			Destination:  acsURL,
+			Audience:     saml_source.EndpointURL("corp saml", "metadata"),
+			NameID:       "saml-user-1",
+			NameIDFormat: saml.NameIDFormatPersistent,
+			SessionIndex: "idp-session-1",
+			Attributes: map[string][]string{
+				"uid":         {"saml-user"},
+				"mail":        {"saml-user@example.com"},
+				"displayName": {"SAML User"},
+			},
+			SignAssertion: true,
+		})
+		require.NoError(t, err)
+		return samlResponse
+	}
+
+	postResponse := func(t *testing.T, samlResponse string, cookie *http.Cookie, mockOpt contexttest.MockContextOption) *httptest.ResponseRecorder {
+		ctx, resp := contexttest.MockContext(t, "POST /user/saml/corp%20saml/acs", mockOpt)
+		ctx.SetPathParamRaw("provider", "corp%20saml")
+		if cookie != nil {
+			ctx.Req.AddCookie(cookie)
+		}
+		contexttest.MockRequestPostForm(ctx.Req, url.Values{"SAMLResponse": {samlResponse}})
+		SAMLAssertionConsumer(ctx)
+		return resp
+	}
+
+	t.Run("SPInitiated", func(t *testing.T) {
+		ctx, resp := contexttest.MockContext(t, "/user/saml/corp%20saml?redirect_to=/explore")
+		ctx.SetPathParamRaw("provider", "corp%20saml")
+		SignInSAML(ctx)
+		require.Equal(t, http.StatusSeeOther, resp.Code)
+		redirectURL := test.RedirectURL(resp)
+		assert.True(t, strings.HasPrefix(redirectURL, idp.SSOURL()+"?"), redirectURL)
+		request, err := idp.ParseAuthnRequest(redirectURL)
+		require.NoError(t, err)
+		assert.Equal(t, acsURL, request.AssertionConsumerServiceURL)
+		cookie := responseCookie(t, resp, samlRequestCookieName)
+
+		mockOpt := contexttest.MockContextOption{SessionStore: session.NewMockMemStore("dummy-sid-saml")}
+		samlResponse := newResponse(t, request.ID)
+		resp = postResponse(t, samlResponse, cookie, mockOpt)
+		assert.Equal(t, http.StatusSeeOther, resp.Code)
+		assert.Equal(t, "/explore", test.RedirectURL(resp))
+
+		u := unittest.AssertExistsAndLoadBean(t, &user_model.User{Name: "saml-user"})
+		assert.Equal(t, auth_model.SAML, u.LoginType)
+		assert.Equal(t, authSource.ID, u.LoginSource)
+		assert.Equal(t, "saml-user-1", u.LoginName)
+		assert.Equal(t, "saml-user@example.com", u.Email)
+		assert.Equal(t, "SAML User", u.FullName)
+		assert.Equal(t, u.ID, mockOpt.SessionStore.Get(session.KeyUID))
+		assert.Equal(t, session.SignInMethodSAML, mockOpt.SessionStore.Get(session.KeySignInMethod))
+		assert.Equal(t, "idp-session-1", mockOpt.SessionStore.Get(session.KeySAMLSessionIndex))
+
+		// the assertions can't be replayed
+		resp = postResponse(t, samlResponse, cookie, contexttest.MockContextOption{SessionStore: session.NewMockMemStore("dummy-sid-saml-replay")})
+		assert.Equal(t, "/user/login", test.RedirectURL(resp))
+	})
+
+	t.Run("IdPInitiatedNotAllowed", func(t *testing.T) {
+		resp := postResponse(t, newResponse(t, ""), nil, contexttest.MockContextOption{SessionStore: session.NewMockMemStore("dummy-sid-saml-idp")})
+		assert.Equal(t, http.StatusSeeOther, resp.Code)
+		assert.Equal(t, "/user/login", test.RedirectURL(resp))
+	})
+
+	t.Run("WrongRequest", func(t *testing.T) {
+		cookie := &http.Cookie{Name: samlRequestCookieName, Value: url.QueryEscape("id=_other")}
+		resp := postResponse(t, newResponse(t, "_unknown"), cookie, contexttest.MockContextOption{SessionStore: session.NewMockMemStore("dummy-sid-saml-wrong")})
+		assert.Equal(t, "/user/login", test.RedirectURL(resp))
+	})
+
+	u := unittest.AssertExistsAndLoadBean(t, &user_model.User{Name: "saml-user"})
+	signedIn := func(t *testing.T, sid string) contexttest.MockContextOption {
+		mockOpt := contexttest.MockContextOption{SessionStore: session.NewMockMemStore(sid)}
+		require.NoError(t, mockOpt.SessionStore.Set(session.KeyUID, u.ID))
+		require.NoError(t, mockOpt.SessionStore.Set(session.KeySignInMethod, session.SignInMethodSAML))
+		require.NoError(t, mockOpt.SessionStore.Set(session.KeySAMLSourceID, authSource.ID))
+		require.NoError(t, mockOpt.SessionStore.Set(session.KeySAMLNameID, "saml-user-1"))
+		require.NoError(t, mockOpt.SessionStore.Set(session.KeySAMLSessionIndex, "idp-session-1"))
+		return mockOpt
+	}
+
+	t.Run("SPInitiatedLogout", func(t *testing.T) {
+		ctx, resp := contexttest.MockContext(t, "/user/logout", signedIn(t, "dummy-sid-saml-logout"))
+		ctx.Doer = u
+		SignOut(ctx)
+		assert.Equal(t, http.StatusSeeOther, resp.Code)
+		redirectURL := test.RedirectURL(resp)
+		assert.True(t, strings.HasPrefix(redirectURL, idp.SLOURL()+"?"), redirectURL)
+		requestID, err := idp.ParseLogoutRequest(redirectURL)
+		require.NoError(t, err)
+		cookie := responseCookie(t, resp, samlLogoutCookieName)
+
+		responseURL, err := idp.LogoutResponseURL(sloURL, requestID, "")
+		require.NoError(t, err)
+		ctx, resp = contexttest.MockContext(t, responseURL)
+		ctx.SetPathParamRaw("provider", "corp%20saml")
+		ctx.Req.AddCookie(cookie)
+		SAMLSingleLogout(ctx)
+		assert.Equal(t, "/", test.RedirectURL(resp))
+	})
+
+	t.Run("IdPInitiatedLogout", func(t *testing.T) {
+		requestURL, requestID, err := idp.LogoutRequestURL(sloURL, "saml-user-1", "idp-session-1", "relay")
+		require.NoError(t, err)
+		mockOpt := signedIn(t, "dummy-sid-saml-slo")
+		ctx, resp := contexttest.MockContext(t, requestURL, mockOpt)
+		ctx.SetPathParamRaw("provider", "corp%20saml")
+		ctx.Doer = u
+		SAMLSingleLogout(ctx)
+		assert.Equal(t, http.StatusSeeOther, resp.Code)
+		redirectURL := test.RedirectURL(resp)
+		assert.True(t, strings.HasPrefix(redirectURL, idp.SLOURL()+"?"), redirectURL)
+		assert.Contains(t, redirectURL, "RelayState=relay")
+		assert.Nil(t, mockOpt.SessionStore.Get(session.KeyUID))
+		assert.NotEmpty(t, requestID)
+	})
+}
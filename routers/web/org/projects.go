@@ -317,6 +317,11 @@ func ViewProject(ctx *context.Context) {
 	if ctx.Written() {
 		return
 	}
+	preparedCustomFieldFilter := issue.PrepareFilterIssueCustomFields(ctx, project.RepoID, project.Owner)
+	if ctx.Written() {
+		return
+	}
+
 	assigneeID := ctx.FormString("assignee")
 	milestoneID := ctx.FormInt64("milestone")
 
@@ -333,6 +338,8 @@ func ViewProject(ctx *context.Context) {
 		AssigneeID:   assigneeID,
 		MilestoneIDs: milestoneIDs,
 		Owner:        project.Owner,
+		CustomFields: preparedCustomFieldFilter.Filters,
+		SortType:     preparedCustomFieldFilter.SortType,
 	}
 	if ctx.Doer != nil {
 		opts.Doer = ctx.Doer
@@ -446,6 +453,7 @@ func ViewProject(ctx *context.Context) {
 	ctx.Data["OpenMilestones"] = openMilestones
 	ctx.Data["ClosedMilestones"] = closedMilestones
 	ctx.Data["MilestoneID"] = milestoneID
+	ctx.Data["SortType"] = preparedCustomFieldFilter.SortType
 
 	// Get assignees.
 	assigneeUsers, err := project_service.LoadIssuesAssigneesForProject(ctx, project.ID)
@@ -0,0 +1,101 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"errors"
+	"net/http"
+
+	actions_model "gitea.dev/models/actions"
+	"gitea.dev/models/db"
+	"gitea.dev/modules/templates"
+	"gitea.dev/modules/util"
+	actions_service "gitea.dev/services/actions"
+	"gitea.dev/services/context"
+	"gitea.dev/services/convert"
+)
+
+const tplDeploymentsActions templates.TplName = "repo/actions/deployments"
+
+// Deployments shows the deployment history of a repository
+func Deployments(ctx *context.Context) {
+	ctx.Data["Title"] = ctx.Tr("actions.deployments")
+	ctx.Data["PageIsActions"] = true
+	ctx.Data["PageIsActionsDeployments"] = true
+
+	envs, err := db.Find[actions_model.ActionEnvironment](ctx, actions_model.FindEnvironmentsOptions{RepoID: ctx.Repo.Repository.ID})
+	if err != nil {
+		ctx.ServerError("FindEnvironments", err)
+		return
+	}
+	ctx.Data["Environments"] = envs
+
+	opts := actions_model.FindDeploymentsOptions{
+		ListOptions: db.ListOptions{
+			Page:     max(ctx.FormInt("page"), 1),
+			PageSize: convert.ToCorrectPageSize(ctx.FormInt("limit")),
+		},
+		RepoID:        ctx.Repo.Repository.ID,
+		EnvironmentID: ctx.FormInt64("environment"),
+	}
+	ctx.Data["CurEnvironment"] = opts.EnvironmentID
+
+	deployments, total, err := db.FindAndCount[actions_model.ActionDeployment](ctx, opts)
+	if err != nil {
+		ctx.ServerError("FindDeployments", err)
+		return
+	}
+	if err := actions_model.DeploymentList(deployments).LoadAttributes(ctx); err != nil {
+		ctx.ServerError("LoadAttributes", err)
+		return
+	}
+	ctx.Data["Deployments"] = deployments
+
+	canReview := make(map[int64]bool, len(deployments))
+	for _, d := range deployments {
+		if canReview[d.ID], err = actions_service.CanReviewDeployment(ctx, ctx.Doer, d); err != nil {
+			ctx.ServerError("CanReviewDeployment", err)
+			return
+		}
+	}
+	ctx.Data["CanReviewDeployment"] = canReview
+
+	pager := context.NewPagination(total, opts.PageSize, opts.Page, 5)
+	pager.AddParamFromRequest(ctx.Req)
+	ctx.Data["Page"] = pager
+
+	ctx.HTML(http.StatusOK, tplDeploymentsActions)
+}
+
+// ReviewDeploymentPost approves or rejects a deployment waiting for a reviewer
+func ReviewDeploymentPost(ctx *context.Context) {
+	deployment, err := actions_model.GetDeploymentByRepoAndID(ctx, ctx.Repo.Repository.ID, ctx.PathParamInt64("deployment_id"))
+	if err != nil {
+		ctx.NotFoundOrServerError("GetDeploymentByRepoAndID", func(err error) bool {
+			return errors.Is(err, util.ErrNotExist)
+		}, err)
+		return
+	}
+	if err := deployment.LoadRun(ctx); err != nil {
+		ctx.ServerError("LoadRun", err)
+		return
+	}
+
+	approve := ctx.FormString("action") == "approve"
+	if err := actions_service.ReviewDeployments(ctx, ctx.Doer, deployment.Run, []*actions_model.ActionDeployment{deployment}, approve, ctx.FormString("comment")); err != nil {
+		if errors.Is(err, util.ErrPermissionDenied) || errors.Is(err, util.ErrInvalidArgument) {
+			ctx.JSONError(ctx.Tr("actions.deployments.review_not_allowed"))
+			return
+		}
+		ctx.ServerError("ReviewDeployments", err)
+		return
+	}
+
+	if approve {
+		ctx.Flash.Success(ctx.Tr("actions.deployments.approve_success"))
+	} else {
+		ctx.Flash.Success(ctx.Tr("actions.deployments.reject_success"))
+	}
+	ctx.JSONRedirect(ctx.Repo.RepoLink + "/actions/deployments")
+}
@@ -747,6 +747,9 @@ func fillViewRunResponseCurrentJob(ctx *context_module.Context, resp *ViewRespon
 	resp.State.CurrentJob.Detail = current.Status.LocaleString(ctx.Locale)
 	if run.NeedApproval {
 		resp.State.CurrentJob.Detail = ctx.Locale.TrString("actions.need_approval_desc")
+	} else if current.Status == actions_model.StatusFailure && current.Environment != "" && actions_model.ValidateEnvironmentName(current.Environment) != nil {
+		// the job failed before it started as its environment can't exist, it has no logs to explain it
+		resp.State.CurrentJob.Detail = ctx.Locale.TrString("actions.runs.invalid_environment_name", current.Environment)
 	} else if detail := describePendingJobDetail(ctx, current, jobs); detail != "" {
 		resp.State.CurrentJob.Detail = detail
 	}
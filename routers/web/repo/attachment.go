@@ -4,6 +4,7 @@
 package repo
 
 import (
+	"errors"
 	"net/http"
 
 	auth_model "gitea.dev/models/auth"
@@ -16,6 +17,7 @@ import (
 	"gitea.dev/modules/log"
 	"gitea.dev/modules/setting"
 	"gitea.dev/modules/storage"
+	"gitea.dev/modules/util"
 	"gitea.dev/services/attachment"
 	"gitea.dev/services/context"
 	"gitea.dev/services/context/upload"
@@ -68,6 +70,10 @@ func uploadAttachment(ctx *context.Context, repoID int64, uploadFunc attachment.
 			ctx.HTTPError(http.StatusBadRequest, err.Error())
 			return
 		}
+		if errors.Is(err, util.ErrContentTooLarge) {
+			ctx.HTTPError(http.StatusRequestEntityTooLarge, err.Error())
+			return
+		}
 		ctx.ServerError("uploadAttachment(uploadFunc)", err)
 		return
 	}
@@ -121,7 +127,7 @@ func DeleteAttachment(ctx *context.Context) {
 		}
 	}
 
-	err = repo_model.DeleteAttachment(ctx, attach, true)
+	err = attachment.DeleteAttachment(ctx, attach)
 	if err != nil {
 		ctx.ServerError("DeleteAttachment", err)
 		return
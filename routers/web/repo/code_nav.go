@@ -0,0 +1,75 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repo
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"gitea.dev/models/renderhelper"
+	"gitea.dev/modules/codenav"
+	"gitea.dev/modules/markup/markdown"
+	"gitea.dev/modules/setting"
+	"gitea.dev/modules/templates"
+	"gitea.dev/modules/util"
+	codenav_service "gitea.dev/services/codenav"
+	"gitea.dev/services/context"
+)
+
+const tplCodeNav templates.TplName = "repo/code_nav"
+
+type codeNavLocation struct {
+	Path string
+	// Line starts at 1 like the line numbers of the file view
+	Line int
+	Link string
+}
+
+// CodeNavigation renders the documentation, the definitions and the references of the symbol at a position of a file,
+// the line and the character of the position start at 0
+func CodeNavigation(ctx *context.Context) {
+	if !setting.CodeNavigation.Enabled {
+		ctx.NotFound(nil)
+		return
+	}
+	commit, err := ctx.Repo.GitRepo.GetCommit(ctx, ctx.PathParam("sha"))
+	if err != nil {
+		ctx.NotFoundOrServerError("GetCommit", func(err error) bool { return errors.Is(err, util.ErrNotExist) }, err)
+		return
+	}
+	commitID := commit.ID.String()
+
+	nav, err := codenav_service.Navigate(ctx, ctx.Repo.Repository, ctx.Repo.GitRepo, commitID, ctx.FormString("path"), ctx.FormInt("line"), ctx.FormInt("character"))
+	if errors.Is(err, util.ErrNotExist) {
+		ctx.JSONErrorNotFound()
+		return
+	} else if err != nil {
+		ctx.ServerError("Navigate", err)
+		return
+	}
+
+	if nav.Documentation != "" {
+		rctx := renderhelper.NewRenderContextRepoFile(ctx, ctx.Repo.Repository, renderhelper.RepoFileOptions{CurrentRefSubURL: "commit/" + commitID})
+		if ctx.Data["Documentation"], err = markdown.RenderString(rctx, nav.Documentation); err != nil {
+			ctx.ServerError("RenderString", err)
+			return
+		}
+	}
+	toLocations := func(locations []*codenav_service.Location) []*codeNavLocation {
+		result := make([]*codeNavLocation, 0, len(locations))
+		for _, location := range locations {
+			result = append(result, &codeNavLocation{
+				Path: location.Path,
+				Line: location.Line + 1,
+				Link: fmt.Sprintf("%s/src/commit/%s/%s#L%d", ctx.Repo.RepoLink, commitID, util.PathEscapeSegments(location.Path), location.Line+1),
+			})
+		}
+		return result
+	}
+	ctx.Data["Definitions"] = toLocations(nav.Definitions)
+	ctx.Data["References"] = toLocations(nav.References)
+	ctx.Data["IsHeuristic"] = nav.Format == codenav.FormatCtags
+	ctx.HTML(http.StatusOK, tplCodeNav)
+}
@@ -0,0 +1,150 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repo
+
+import (
+	"errors"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	issues_model "gitea.dev/models/issues"
+	user_model "gitea.dev/models/user"
+	"gitea.dev/modules/util"
+	"gitea.dev/services/context"
+	issue_service "gitea.dev/services/issue"
+)
+
+// IssueCustomFieldSidebarItem is a custom field and its values shown in the issue sidebar
+type IssueCustomFieldSidebarItem struct {
+	Field  *issues_model.CustomField
+	Values []string
+	// Users are the users of a user field
+	Users []*user_model.User
+}
+
+// HasValue returns true if the field has a value for the issue
+func (item *IssueCustomFieldSidebarItem) HasValue(v string) bool {
+	return slices.Contains(item.Values, v)
+}
+
+func prepareIssueViewSidebarCustomFields(ctx *context.Context, issue *issues_model.Issue) {
+	fields, err := issues_model.GetCustomFieldsForRepo(ctx, ctx.Repo.Repository)
+	if err != nil {
+		ctx.ServerError("GetCustomFieldsForRepo", err)
+		return
+	}
+	if len(fields) == 0 {
+		return
+	}
+	values, err := issues_model.GetIssueCustomFieldValues(ctx, issue.ID)
+	if err != nil {
+		ctx.ServerError("GetIssueCustomFieldValues", err)
+		return
+	}
+
+	var userIDs []int64
+	for _, v := range values {
+		if f := fieldByID(fields, v.FieldID); f != nil && f.Type == issues_model.CustomFieldTypeUser {
+			userID, _ := strconv.ParseInt(v.Value, 10, 64)
+			userIDs = append(userIDs, userID)
+		}
+	}
+	users, err := user_model.GetPossibleUserByIDs(ctx, userIDs)
+	if err != nil {
+		ctx.ServerError("GetPossibleUserByIDs", err)
+		return
+	}
+
+	items := make([]*IssueCustomFieldSidebarItem, 0, len(fields))
+	for _, field := range fields {
+		item := &IssueCustomFieldSidebarItem{Field: field}
+		for _, v := range values {
+			if v.FieldID != field.ID {
+				continue
+			}
+			item.Values = append(item.Values, v.Value)
+			if field.Type == issues_model.CustomFieldTypeUser {
+				for _, u := range users {
+					if strconv.FormatInt(u.ID, 10) == v.Value {
+						item.Users = append(item.Users, u)
+					}
+				}
+			}
+		}
+		items = append(items, item)
+	}
+	ctx.Data["IssueCustomFields"] = items
+}
+
+func fieldByID(fields []*issues_model.CustomField, id int64) *issues_model.CustomField {
+	for _, f := range fields {
+		if f.ID == id {
+			return f
+		}
+	}
+	return nil
+}
+
+// UpdateIssueCustomField sets the values of a custom field of an issue, no values clears the field
+func UpdateIssueCustomField(ctx *context.Context) {
+	issue, err := issues_model.GetIssueByIndex(ctx, ctx.Repo.Repository.ID, ctx.PathParamInt64("index"))
+	if err != nil {
+		if issues_model.IsErrIssueNotExist(err) {
+			ctx.NotFound(err)
+		} else {
+			ctx.ServerError("GetIssueByIndex", err)
+		}
+		return
+	}
+
+	if !ctx.Repo.Permission.CanWriteIssuesOrPulls(issue.IsPull) {
+		ctx.HTTPError(http.StatusForbidden, "", "Not repo writer")
+		return
+	}
+
+	field, err := issues_model.GetCustomFieldByIDForRepo(ctx, ctx.Repo.Repository, ctx.PathParamInt64("id"))
+	if err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			ctx.NotFound(err)
+		} else {
+			ctx.ServerError("GetCustomFieldByIDForRepo", err)
+		}
+		return
+	}
+
+	var values []string
+	for _, v := range ctx.FormStrings("values") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+	if field.Type == issues_model.CustomFieldTypeUser {
+		// the sidebar submits user names, the values are stored as user ids
+		for i, name := range values {
+			u, err := user_model.GetUserByName(ctx, name)
+			if err != nil {
+				if user_model.IsErrUserNotExist(err) {
+					ctx.JSONError(ctx.Tr("form.user_not_exist"))
+				} else {
+					ctx.ServerError("GetUserByName", err)
+				}
+				return
+			}
+			values[i] = strconv.FormatInt(u.ID, 10)
+		}
+	}
+
+	if err := issue_service.SetCustomFieldValues(ctx, ctx.Doer, issue, field, values); err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			ctx.JSONError(err.Error())
+		} else {
+			ctx.ServerError("SetCustomFieldValues", err)
+		}
+		return
+	}
+
+	ctx.JSONRedirect("")
+}
@@ -435,6 +435,20 @@ func prepareIssueFilterAndList(ctx *context.Context, milestoneID int64, projectI
 		mileIDs = []int64{milestoneID}
 	}
 
+	var issueTypeIDs []int64
+	issueTypeID := ctx.FormInt64("issue_type")
+	if !isPullOption.ValueOrDefault(false) {
+		issueTypes, err := db.Find[issues_model.IssueType](ctx, issues_model.FindIssueTypesOptions{OwnerID: repo.OwnerID, IncludeDisabled: true})
+		if err != nil {
+			ctx.ServerError("FindIssueTypes", err)
+			return
+		}
+		ctx.Data["IssueTypes"] = issueTypes
+		if issueTypeID > 0 || issueTypeID == db.NoConditionID { // -1 to get those issues which have no type
+			issueTypeIDs = []int64{issueTypeID}
+		}
+	}
+
 	preparedLabelFilter := issue.PrepareFilterIssueLabels(ctx, repo.ID, ctx.Repo.Owner)
 	if ctx.Written() {
 		return
@@ -442,6 +456,15 @@ func prepareIssueFilterAndList(ctx *context.Context, milestoneID int64, projectI
 
 	prepareIssueFilterExclusiveOrderScopes(ctx, preparedLabelFilter.AllLabels)
 
+	preparedCustomFieldFilter := issue.PrepareFilterIssueCustomFields(ctx, repo.ID, ctx.Repo.Owner)
+	if ctx.Written() {
+		return
+	}
+	customFieldFilters := preparedCustomFieldFilter.Filters
+	if _, _, ok := issues_model.ParseCustomFieldSortType(sortType); ok {
+		sortType = preparedCustomFieldFilter.SortType
+	}
+
 	var keywordMatchedIssueIDs []int64
 	var issueStats *issues_model.IssueStats
 	statsOpts := &issues_model.IssuesOptions{
@@ -456,6 +479,8 @@ func prepareIssueFilterAndList(ctx *context.Context, milestoneID int64, projectI
 		ReviewedID:        reviewedID,
 		IsPull:            isPullOption,
 		IssueIDs:          nil,
+		CustomFields:      customFieldFilters,
+		TypeIDs:           issueTypeIDs,
 	}
 
 	if keyword != "" {
@@ -535,6 +560,8 @@ func prepareIssueFilterAndList(ctx *context.Context, milestoneID int64, projectI
 			LabelIDs:          preparedLabelFilter.SelectedLabelIDs,
 			SortType:          sortType,
 			IssueIDs:          keywordMatchedIssueIDs,
+			CustomFields:      customFieldFilters,
+			TypeIDs:           issueTypeIDs,
 		})
 		if err != nil {
 			ctx.ServerError("DBIndexer.Search", err)
@@ -632,6 +659,7 @@ func prepareIssueFilterAndList(ctx *context.Context, milestoneID int64, projectI
 	ctx.Data["ViewType"] = viewType
 	ctx.Data["SortType"] = sortType
 	ctx.Data["MilestoneID"] = milestoneID
+	ctx.Data["IssueTypeID"] = issueTypeID
 	ctx.Data["ProjectIDs"] = projectIDs
 	ctx.Data["AssigneeID"] = assigneeID
 	ctx.Data["PosterUsername"] = posterUsername
@@ -0,0 +1,230 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repo
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"gitea.dev/models/db"
+	issues_model "gitea.dev/models/issues"
+	access_model "gitea.dev/models/perm/access"
+	repo_model "gitea.dev/models/repo"
+	"gitea.dev/modules/util"
+	"gitea.dev/services/context"
+	issue_service "gitea.dev/services/issue"
+)
+
+func prepareIssueViewSidebarTypeAndSubIssues(ctx *context.Context, issue *issues_model.Issue) {
+	if issue.IsPull {
+		return
+	}
+
+	types, err := db.Find[issues_model.IssueType](ctx, issues_model.FindIssueTypesOptions{OwnerID: ctx.Repo.Repository.OwnerID})
+	if err != nil {
+		ctx.ServerError("FindIssueTypes", err)
+		return
+	}
+	if err := issue.LoadType(ctx); err != nil {
+		ctx.ServerError("LoadType", err)
+		return
+	}
+	ctx.Data["IssueTypes"] = types
+
+	canRead := func(issue *issues_model.Issue) (bool, error) {
+		if issue.RepoID == ctx.Repo.Repository.ID {
+			return ctx.Repo.Permission.CanReadIssuesOrPulls(issue.IsPull), nil
+		}
+		if err := issue.LoadRepo(ctx); err != nil {
+			return false, err
+		}
+		perm, err := access_model.GetDoerRepoPermission(ctx, issue.Repo, ctx.Doer)
+		if err != nil {
+			return false, err
+		}
+		return perm.CanReadIssuesOrPulls(issue.IsPull), nil
+	}
+
+	parent, err := issues_model.GetParentIssue(ctx, issue.ID)
+	if err != nil && !errors.Is(err, util.ErrNotExist) {
+		ctx.ServerError("GetParentIssue", err)
+		return
+	}
+	if parent != nil {
+		if ok, err := canRead(parent); err != nil {
+			ctx.ServerError("canRead", err)
+			return
+		} else if ok {
+			if err := parent.LoadRepo(ctx); err != nil {
+				ctx.ServerError("LoadRepo", err)
+				return
+			}
+			ctx.Data["ParentIssue"] = parent
+		}
+	}
+
+	subIssues, err := issues_model.GetSubIssues(ctx, issue.ID)
+	if err != nil {
+		ctx.ServerError("GetSubIssues", err)
+		return
+	}
+	if _, err := subIssues.LoadRepositories(ctx); err != nil {
+		ctx.ServerError("LoadRepositories", err)
+		return
+	}
+	readable := make(issues_model.IssueList, 0, len(subIssues))
+	for _, subIssue := range subIssues {
+		if ok, err := canRead(subIssue); err != nil {
+			ctx.ServerError("canRead", err)
+			return
+		} else if ok {
+			readable = append(readable, subIssue)
+		}
+	}
+	ctx.Data["SubIssues"] = readable
+
+	progress, err := issues_model.GetSubIssueProgress(ctx, issue.ID)
+	if err != nil {
+		ctx.ServerError("GetSubIssueProgress", err)
+		return
+	}
+	ctx.Data["SubIssueProgress"] = progress[issue.ID]
+}
+
+// UpdateIssueType changes the type of an issue
+func UpdateIssueType(ctx *context.Context) {
+	issue := getSubIssueActionIssue(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	if err := issue_service.ChangeIssueType(ctx, ctx.Doer, issue, ctx.FormInt64("type_id")); err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) || errors.Is(err, util.ErrNotExist) {
+			ctx.JSONError(err.Error())
+		} else {
+			ctx.ServerError("ChangeIssueType", err)
+		}
+		return
+	}
+	ctx.JSONRedirect("")
+}
+
+// AddSubIssue adds a sub-issue to an issue, the sub-issue is given as "#index" or "owner/repo#index"
+func AddSubIssue(ctx *context.Context) {
+	parent := getSubIssueActionIssue(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	repo := ctx.Repo.Repository
+	ref := strings.TrimSpace(ctx.FormString("sub_issue"))
+	repoRef, indexRef, hasRepo := strings.Cut(ref, "#")
+	if !hasRepo {
+		indexRef = repoRef
+	} else if repoRef != "" {
+		ownerName, repoName, ok := strings.Cut(repoRef, "/")
+		if !ok {
+			ctx.JSONError(ctx.Tr("repo.issues.sub_issue.add_error_not_exist"))
+			return
+		}
+		var err error
+		repo, err = repo_model.GetRepositoryByOwnerAndName(ctx, ownerName, repoName)
+		if err != nil {
+			if repo_model.IsErrRepoNotExist(err) {
+				ctx.JSONError(ctx.Tr("repo.issues.sub_issue.add_error_not_exist"))
+			} else {
+				ctx.ServerError("GetRepositoryByOwnerAndName", err)
+			}
+			return
+		}
+	}
+	index, err := strconv.ParseInt(indexRef, 10, 64)
+	if err != nil {
+		ctx.JSONError(ctx.Tr("repo.issues.sub_issue.add_error_not_exist"))
+		return
+	}
+	if repo.OwnerID != ctx.Repo.Repository.OwnerID {
+		ctx.JSONError(ctx.Tr("repo.issues.sub_issue.add_error_other_owner"))
+		return
+	}
+
+	perm := ctx.Repo.Permission
+	if repo.ID != ctx.Repo.Repository.ID {
+		if perm, err = access_model.GetDoerRepoPermission(ctx, repo, ctx.Doer); err != nil {
+			ctx.ServerError("GetDoerRepoPermission", err)
+			return
+		}
+	}
+	subIssue, err := issues_model.GetIssueByIndex(ctx, repo.ID, index)
+	if err != nil || !perm.CanWriteIssuesOrPulls(subIssue.IsPull) {
+		if err != nil && !issues_model.IsErrIssueNotExist(err) {
+			ctx.ServerError("GetIssueByIndex", err)
+			return
+		}
+		ctx.JSONError(ctx.Tr("repo.issues.sub_issue.add_error_not_exist"))
+		return
+	}
+	subIssue.Repo = repo
+
+	if err := issue_service.AddSubIssue(ctx, ctx.Doer, parent, subIssue); err != nil {
+		if errors.Is(err, util.ErrAlreadyExist) {
+			ctx.JSONError(ctx.Tr("repo.issues.sub_issue.add_error_has_parent"))
+		} else if errors.Is(err, util.ErrInvalidArgument) {
+			ctx.JSONError(err.Error())
+		} else {
+			ctx.ServerError("AddSubIssue", err)
+		}
+		return
+	}
+	ctx.JSONRedirect("")
+}
+
+// RemoveSubIssue removes a sub-issue from an issue
+func RemoveSubIssue(ctx *context.Context) {
+	parent := getSubIssueActionIssue(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	subIssue, err := issues_model.GetIssueByID(ctx, ctx.FormInt64("sub_issue_id"))
+	if err != nil {
+		if issues_model.IsErrIssueNotExist(err) {
+			ctx.NotFound(err)
+		} else {
+			ctx.ServerError("GetIssueByID", err)
+		}
+		return
+	}
+
+	if err := issue_service.RemoveSubIssue(ctx, ctx.Doer, parent, subIssue); err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			ctx.NotFound(err)
+		} else {
+			ctx.ServerError("RemoveSubIssue", err)
+		}
+		return
+	}
+	ctx.JSONRedirect("")
+}
+
+// getSubIssueActionIssue returns the issue of the url, the doer must be able to change it
+func getSubIssueActionIssue(ctx *context.Context) *issues_model.Issue {
+	issue, err := issues_model.GetIssueByIndex(ctx, ctx.Repo.Repository.ID, ctx.PathParamInt64("index"))
+	if err != nil {
+		if issues_model.IsErrIssueNotExist(err) {
+			ctx.NotFound(err)
+		} else {
+			ctx.ServerError("GetIssueByIndex", err)
+		}
+		return nil
+	}
+	if !ctx.Repo.Permission.CanWriteIssuesOrPulls(issue.IsPull) {
+		ctx.HTTPError(http.StatusForbidden, "", "Not repo writer")
+		return nil
+	}
+	issue.Repo = ctx.Repo.Repository
+	return issue
+}
@@ -375,6 +375,8 @@ func ViewIssue(ctx *context.Context) {
 		prepareIssueViewSidebarTimeTracker,
 		prepareIssueViewSidebarDependency,
 		prepareIssueViewSidebarPin,
+		prepareIssueViewSidebarCustomFields,
+		prepareIssueViewSidebarTypeAndSubIssues,
 	}
 	if issue.IsPull {
 		prepareFuncs = append(prepareFuncs,
@@ -729,7 +731,9 @@ func prepareIssueViewCommentsAndSidebarParticipants(ctx *context.Context, issue
 				ctx.ServerError("LoadAssigneeUserAndTeam", err)
 				return
 			}
-		} else if comment.Type == issues_model.CommentTypeRemoveDependency || comment.Type == issues_model.CommentTypeAddDependency {
+		} else if comment.Type == issues_model.CommentTypeRemoveDependency || comment.Type == issues_model.CommentTypeAddDependency ||
+			comment.Type == issues_model.CommentTypeAddSubIssue || comment.Type == issues_model.CommentTypeRemoveSubIssue ||
+			comment.Type == issues_model.CommentTypeAddParentIssue || comment.Type == issues_model.CommentTypeRemoveParentIssue {
 			if err = comment.LoadDepIssueDetails(ctx); err != nil {
 				if !issues_model.IsErrIssueNotExist(err) {
 					ctx.ServerError("LoadDepIssueDetails", err)
@@ -62,6 +62,7 @@ func Migrate(ctx *context.Context) {
 	ctx.Data["issues"] = ctx.FormString("issues") == "1"
 	ctx.Data["pull_requests"] = ctx.FormString("pull_requests") == "1"
 	ctx.Data["releases"] = ctx.FormString("releases") == "1"
+	ctx.Data["sync"] = ctx.FormString("sync") == "1"
 
 	ctxUser := checkContextUser(ctx, ctx.FormInt64("org"))
 	if ctx.Written() {
@@ -226,6 +227,7 @@ func MigratePost(ctx *context.Context) {
 		Comments:       form.Issues || form.PullRequests,
 		PullRequests:   form.PullRequests,
 		Releases:       form.Releases,
+		Sync:           form.Sync && !form.Mirror && form.Service != structs.PlainGitService,
 	}
 	if opts.Mirror {
 		opts.Issues = false
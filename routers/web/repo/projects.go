@@ -302,6 +302,11 @@ func ViewProject(ctx *context.Context) {
 		return
 	}
 
+	preparedCustomFieldFilter := issue.PrepareFilterIssueCustomFields(ctx, ctx.Repo.Repository.ID, ctx.Repo.Owner)
+	if ctx.Written() {
+		return
+	}
+
 	assigneeID := ctx.FormString("assignee")
 	milestoneID := ctx.FormInt64("milestone")
 
@@ -317,6 +322,8 @@ func ViewProject(ctx *context.Context) {
 		LabelIDs:     preparedLabelFilter.SelectedLabelIDs,
 		AssigneeID:   assigneeID,
 		MilestoneIDs: milestoneIDs,
+		CustomFields: preparedCustomFieldFilter.Filters,
+		SortType:     preparedCustomFieldFilter.SortType,
 	})
 	if err != nil {
 		ctx.ServerError("LoadIssuesOfColumns", err)
@@ -412,6 +419,7 @@ func ViewProject(ctx *context.Context) {
 		return
 	}
 	ctx.Data["MilestoneID"] = milestoneID
+	ctx.Data["SortType"] = preparedCustomFieldFilter.SortType
 
 	rctx := renderhelper.NewRenderContextRepoComment(ctx, ctx.Repo.Repository)
 	project.RenderedContent, err = markdown.RenderString(rctx, project.Description)
@@ -1086,6 +1086,8 @@ func MergePullRequest(ctx *context.Context) {
 			ctx.JSONError(err.Error()) // has no translation ...
 		case errors.Is(err, pull_service.ErrHeadCommitsNotAllVerified):
 			ctx.JSONError(ctx.Tr("repo.pulls.require_signed_head_commits_unverified"))
+		case errors.Is(err, pull_service.ErrRulesetViolated):
+			ctx.JSONError(ctx.Tr("repo.pulls.no_merge_ruleset", err.Error()))
 		case errors.Is(err, pull_service.ErrDependenciesLeft):
 			ctx.JSONError(ctx.Tr("repo.issues.dependency.pr_close_blocked"))
 		default:
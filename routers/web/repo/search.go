@@ -4,6 +4,7 @@
 package repo
 
 import (
+	"errors"
 	"net/http"
 
 	"gitea.dev/models/db"
@@ -12,6 +13,7 @@ import (
 	"gitea.dev/modules/indexer/code/gitgrep"
 	"gitea.dev/modules/setting"
 	"gitea.dev/modules/templates"
+	"gitea.dev/modules/util"
 	"gitea.dev/routers/common"
 	"gitea.dev/services/context"
 )
@@ -23,6 +25,11 @@ func Search(ctx *context.Context) {
 	ctx.Data["PageIsViewCode"] = true
 	prepareSearch := common.PrepareCodeSearch(ctx)
 	if prepareSearch.Keyword == "" {
+		if setting.Indexer.RepoIndexerEnabled {
+			if _, ok := prepareRefSearch(ctx); !ok {
+				return
+			}
+		}
 		ctx.HTML(http.StatusOK, tplSearch)
 		return
 	}
@@ -36,30 +43,45 @@ func Search(ctx *context.Context) {
 	var searchResults []*code_indexer.Result
 	var searchResultLanguages []*code_indexer.SearchResultLanguages
 	if setting.Indexer.RepoIndexerEnabled {
+		searchRef, ok := prepareRefSearch(ctx)
+		if !ok {
+			return
+		}
 		var err error
 		total, searchResults, searchResultLanguages, err = code_indexer.PerformSearch(ctx, &code_indexer.SearchOptions{
-			RepoIDs:    []int64{ctx.Repo.Repository.ID},
-			Keyword:    prepareSearch.Keyword,
-			SearchMode: prepareSearch.SearchMode,
-			Language:   prepareSearch.Language,
+			RepoIDs:       []int64{ctx.Repo.Repository.ID},
+			Keyword:       prepareSearch.Keyword,
+			SearchMode:    prepareSearch.SearchMode,
+			Language:      prepareSearch.Language,
+			Path:          prepareSearch.Path,
+			CaseSensitive: prepareSearch.CaseSensitive,
+			Ref:           searchRef,
 			Paginator: &db.ListOptions{
 				Page:     page,
 				PageSize: setting.UI.RepoSearchPagingNum,
 			},
 		})
 		if err != nil {
-			if code_indexer.IsAvailable(ctx) {
+			if errors.Is(err, util.ErrInvalidArgument) {
+				ctx.Flash.Error(err.Error(), true)
+			} else if code_indexer.IsAvailable(ctx) {
 				ctx.ServerError("SearchResults", err)
 				return
+			} else {
+				ctx.Data["CodeIndexerUnavailable"] = true
 			}
-			ctx.Data["CodeIndexerUnavailable"] = true
 		} else {
 			ctx.Data["CodeIndexerUnavailable"] = !code_indexer.IsAvailable(ctx)
 		}
 	} else {
 		var err error
-		// ref should be default branch or the first existing branch
+		// ref should be default branch or the first existing branch, unless another branch or tag is requested
 		searchRef := git.RefNameFromBranch(ctx.Repo.Repository.DefaultBranch)
+		if ref := git.RefName(ctx.FormTrim("ref")); (ref.IsBranch() || ref.IsTag()) && ctx.Repo.GitRepo.IsReferenceExist(ctx, ref.String()) {
+			searchRef = ref
+			ctx.Data["CodeSearchRefs"] = []git.RefName{git.RefNameFromBranch(ctx.Repo.Repository.DefaultBranch), ref}
+			ctx.Data["CodeSearchRef"] = ref
+		}
 		searchResults, total, err = gitgrep.PerformSearch(ctx, page, ctx.Repo.Repository.ID, ctx.Repo.GitRepo, searchRef, prepareSearch.Keyword, prepareSearch.SearchMode)
 		if err != nil {
 			ctx.ServerError("gitgrep.PerformSearch", err)
@@ -77,3 +99,33 @@ func Search(ctx *context.Context) {
 
 	ctx.HTML(http.StatusOK, tplSearch)
 }
+
+// prepareRefSearch lists the refs indexed for the repository and returns the requested one,
+// the default branch is searched when the ref is empty
+func prepareRefSearch(ctx *context.Context) (string, bool) {
+	indexedRefs, err := code_indexer.GetIndexedRefs(ctx, ctx.Repo.Repository)
+	if err != nil {
+		ctx.ServerError("GetIndexedRefs", err)
+		return "", false
+	}
+
+	defaultRef := git.RefNameFromBranch(ctx.Repo.Repository.DefaultBranch)
+	selectedRef := defaultRef
+	if ref := ctx.FormTrim("ref"); ref != "" && ref != defaultRef.String() {
+		selectedRef = git.RefName(ref)
+	}
+	if len(indexedRefs) > 0 {
+		refs := make([]git.RefName, 0, len(indexedRefs)+1)
+		refs = append(refs, defaultRef)
+		for _, ref := range indexedRefs {
+			refs = append(refs, git.RefName(ref))
+		}
+		ctx.Data["CodeSearchRefs"] = refs
+		ctx.Data["CodeSearchRef"] = selectedRef
+	}
+
+	if selectedRef == defaultRef {
+		return "", true
+	}
+	return selectedRef.String(), true
+}
@@ -150,6 +150,9 @@ func WebhooksNew(ctx *context.Context) {
 			"Username": "Gitea",
 		}
 	}
+	if hookType == webhook_module.TEMPLATE {
+		ctx.Data["TemplateHook"] = &webhook_service.TemplateMeta{ContentType: "application/json"}
+	}
 	ctx.Data["BaseLink"] = orCtx.LinkNew
 	ctx.Data["BaseLinkNew"] = orCtx.LinkNew
 
@@ -592,6 +595,43 @@ func packagistHookParams(ctx *context.Context) webhookParams {
 	}
 }
 
+// TemplateHooksNewPost response for creating templated webhook
+func TemplateHooksNewPost(ctx *context.Context) {
+	createWebhook(ctx, templateHookParams(ctx))
+}
+
+// TemplateHooksEditPost response for editing templated webhook
+func TemplateHooksEditPost(ctx *context.Context) {
+	editWebhook(ctx, templateHookParams(ctx))
+}
+
+func templateHookParams(ctx *context.Context) webhookParams {
+	form := web.GetForm[*forms.NewTemplateHookForm](ctx)
+
+	meta := &webhook_service.TemplateMeta{
+		ContentType:     strings.TrimSpace(form.BodyContentType),
+		BodyTemplate:    form.BodyTemplate,
+		HeadersTemplate: form.HeadersTemplate,
+	}
+	ctx.Data["TemplateHook"] = meta
+	if !ctx.HasError() {
+		if err := webhook_service.ValidateTemplateMeta(meta); err != nil {
+			ctx.Data["Err_BodyTemplate"] = true
+			ctx.Data["HasError"] = true
+			ctx.Data["ErrorMsg"] = ctx.Tr("repo.settings.template_invalid", err.Error())
+		}
+	}
+
+	return webhookParams{
+		Type:        webhook_module.TEMPLATE,
+		URL:         form.PayloadURL,
+		ContentType: webhook.ContentTypeJSON,
+		HTTPMethod:  form.HTTPMethod,
+		WebhookForm: form.WebhookForm,
+		Meta:        meta,
+	}
+}
+
 func checkWebhook(ctx *context.Context) (*ownerRepoCtx, *webhook.Webhook) {
 	orCtx, err := getOwnerRepoCtx(ctx)
 	if err != nil {
@@ -630,6 +670,8 @@ func checkWebhook(ctx *context.Context) (*ownerRepoCtx, *webhook.Webhook) {
 		ctx.Data["MatrixHook"] = webhook_service.GetMatrixHook(w)
 	case webhook_module.PACKAGIST:
 		ctx.Data["PackagistHook"] = webhook_service.GetPackagistHook(w)
+	case webhook_module.TEMPLATE:
+		ctx.Data["TemplateHook"] = webhook_service.GetTemplateHook(w)
 	}
 
 	ctx.Data["History"], err = w.History(ctx, 1)
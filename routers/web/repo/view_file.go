@@ -24,6 +24,7 @@ import (
 	"gitea.dev/modules/markup"
 	"gitea.dev/modules/setting"
 	"gitea.dev/modules/util"
+	codenav_service "gitea.dev/services/codenav"
 	"gitea.dev/services/context"
 	issue_service "gitea.dev/services/issue"
 )
@@ -132,6 +133,7 @@ func handleFileViewRenderSource(ctx *context.Context, attrs *attribute.Attribute
 	ctx.Data["EscapeStatus"] = status
 	ctx.Data["FileContent"] = fileContent
 	ctx.Data["LineEscapeStatus"] = statuses
+	codenav_service.AddCommitToQueue(ctx, ctx.Repo.Repository, ctx.Repo.CommitID)
 	return true
 }
 
@@ -0,0 +1,50 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package issue
+
+import (
+	"slices"
+	"strconv"
+
+	"gitea.dev/models/db"
+	issues_model "gitea.dev/models/issues"
+	user_model "gitea.dev/models/user"
+	"gitea.dev/services/context"
+)
+
+// PrepareFilterIssueCustomFields reads the "custom_field" and "sort" query parameters,
+// sets `ctx.Data["CustomFields"]` and `ctx.Data["CustomFieldFilter"]`.
+// Invalid filters are ignored, SortType is only set if the issues are sorted by a sortable custom field.
+func PrepareFilterIssueCustomFields(ctx *context.Context, repoID int64, owner *user_model.User) (ret struct {
+	Filters  []issues_model.CustomFieldFilter
+	SortType string
+},
+) {
+	opts := issues_model.FindCustomFieldsOptions{ListOptions: db.ListOptionsAll, RepoID: repoID}
+	if owner != nil && owner.IsOrganization() {
+		opts.OwnerID = owner.ID
+	}
+	fields, err := db.Find[issues_model.CustomField](ctx, opts)
+	if err != nil {
+		ctx.ServerError("FindCustomFields", err)
+		return ret
+	}
+	ctx.Data["CustomFields"] = fields
+
+	if filters, err := issues_model.ParseCustomFieldFilters(ctx.FormStrings("custom_field")); err == nil {
+		ret.Filters = issues_model.NormalizeCustomFieldFilters(fields, filters)
+	}
+	// the filter menu only selects one value
+	if len(ret.Filters) > 0 && len(ret.Filters[0].Values) > 0 {
+		ctx.Data["CustomFieldFilter"] = strconv.FormatInt(ret.Filters[0].FieldID, 10) + ":" + ret.Filters[0].Values[0]
+	}
+
+	sortType := ctx.FormString("sort")
+	if fieldID, _, ok := issues_model.ParseCustomFieldSortType(sortType); ok {
+		if slices.ContainsFunc(fields, func(f *issues_model.CustomField) bool { return f.ID == fieldID && f.Type.IsSortable() }) {
+			ret.SortType = sortType
+		}
+	}
+	return ret
+}
@@ -4,6 +4,7 @@
 package user
 
 import (
+	"errors"
 	"net/http"
 	"slices"
 
@@ -12,6 +13,7 @@ import (
 	code_indexer "gitea.dev/modules/indexer/code"
 	"gitea.dev/modules/setting"
 	"gitea.dev/modules/templates"
+	"gitea.dev/modules/util"
 	"gitea.dev/routers/common"
 	shared_user "gitea.dev/routers/web/shared/user"
 	"gitea.dev/services/context"
@@ -66,21 +68,26 @@ func CodeSearch(ctx *context.Context) {
 
 	if len(repoIDs) > 0 {
 		total, searchResults, searchResultLanguages, err = code_indexer.PerformSearch(ctx, &code_indexer.SearchOptions{
-			RepoIDs:    repoIDs,
-			Keyword:    prepareSearch.Keyword,
-			SearchMode: prepareSearch.SearchMode,
-			Language:   prepareSearch.Language,
+			RepoIDs:       repoIDs,
+			Keyword:       prepareSearch.Keyword,
+			SearchMode:    prepareSearch.SearchMode,
+			Language:      prepareSearch.Language,
+			Path:          prepareSearch.Path,
+			CaseSensitive: prepareSearch.CaseSensitive,
 			Paginator: &db.ListOptions{
 				Page:     page,
 				PageSize: setting.UI.RepoSearchPagingNum,
 			},
 		})
 		if err != nil {
-			if code_indexer.IsAvailable(ctx) {
+			if errors.Is(err, util.ErrInvalidArgument) {
+				ctx.Flash.Error(err.Error(), true)
+			} else if code_indexer.IsAvailable(ctx) {
 				ctx.ServerError("SearchResults", err)
 				return
+			} else {
+				ctx.Data["CodeIndexerUnavailable"] = true
 			}
-			ctx.Data["CodeIndexerUnavailable"] = true
 		} else {
 			ctx.Data["CodeIndexerUnavailable"] = !code_indexer.IsAvailable(ctx)
 		}
@@ -19,6 +19,7 @@ import (
 	repo_model "gitea.dev/models/repo"
 	"gitea.dev/modules/container"
 	"gitea.dev/modules/httplib"
+	"gitea.dev/modules/json"
 	"gitea.dev/modules/optional"
 	alpine_module "gitea.dev/modules/packages/alpine"
 	arch_module "gitea.dev/modules/packages/arch"
@@ -34,7 +35,10 @@ import (
 	"gitea.dev/services/context"
 	"gitea.dev/services/forms"
 	packages_service "gitea.dev/services/packages"
+	attestation_service "gitea.dev/services/packages/attestation"
 	container_service "gitea.dev/services/packages/container"
+	hex_service "gitea.dev/services/packages/hex"
+	nix_service "gitea.dev/services/packages/nix"
 
 	"github.com/google/uuid"
 )
@@ -186,6 +190,55 @@ func viewPackageContainerImage(ctx gocontext.Context, pd *packages_model.Package
 	return metadata, err
 }
 
+type containerReferrer struct {
+	Digest       string
+	ArtifactType string
+	Size         int64
+}
+
+// viewPackageContainerReferrers gets the manifests like signatures and attestations which refer to the manifest of the version
+func viewPackageContainerReferrers(ctx gocontext.Context, pd *packages_model.PackageDescriptor) ([]*containerReferrer, error) {
+	var subject string
+	for _, pfd := range pd.Files {
+		if pfd.File.IsLead && pfd.File.LowerName == container_module.ManifestFilename {
+			subject = pfd.Properties.GetByName(container_module.PropertyDigest)
+			break
+		}
+	}
+	if subject == "" {
+		return nil, nil
+	}
+
+	pfds, err := container_model.GetContainerBlobs(ctx, &container_model.BlobSearchOptions{
+		OwnerID:    pd.Owner.ID,
+		Image:      pd.Package.LowerName,
+		IsManifest: true,
+		OnlyLead:   true,
+		Subject:    subject,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	referrers := make([]*containerReferrer, 0, len(pfds))
+	for _, pfd := range pfds {
+		pv, err := packages_model.GetVersionByID(ctx, pfd.File.VersionID)
+		if err != nil {
+			return nil, err
+		}
+		metadata := &container_module.Metadata{}
+		if err := json.Unmarshal([]byte(pv.MetadataJSON), metadata); err != nil {
+			return nil, err
+		}
+		referrers = append(referrers, &containerReferrer{
+			Digest:       pfd.Properties.GetByName(container_module.PropertyDigest),
+			ArtifactType: metadata.ArtifactType,
+			Size:         pfd.Blob.Size,
+		})
+	}
+	return referrers, nil
+}
+
 // ViewPackageVersion displays a single package version
 func ViewPackageVersion(ctx *context.Context) {
 	if _, err := shared_user.RenderUserOrgHeader(ctx); err != nil {
@@ -274,7 +327,37 @@ func ViewPackageVersion(ctx *context.Context) {
 			}
 		}
 		ctx.Data["ContainerImageMetadata"] = imageMetadata
+
+		referrers, err := viewPackageContainerReferrers(ctx, pd)
+		if err != nil {
+			ctx.ServerError("viewPackageContainerReferrers", err)
+			return
+		}
+		ctx.Data["ContainerReferrers"] = referrers
+	case packages_model.TypeHex:
+		ctx.Data["HexRetirement"] = hex_service.GetRetirement(pd)
+	case packages_model.TypeNix:
+		narInfo, err := nix_service.BuildNarInfo(ctx, pd)
+		if err != nil {
+			ctx.ServerError("BuildNarInfo", err)
+			return
+		}
+		_, publicKey, err := nix_service.GetOrCreateKeyPair(ctx, pd.Owner)
+		if err != nil {
+			ctx.ServerError("GetOrCreateKeyPair", err)
+			return
+		}
+		ctx.Data["NixNarInfo"] = narInfo
+		ctx.Data["NixPublicKey"] = publicKey
+	}
+
+	attestations, err := attestation_service.LoadViews(ctx, pd)
+	if err != nil {
+		ctx.ServerError("LoadViews", err)
+		return
 	}
+	ctx.Data["PackageAttestations"] = attestations
+
 	var pvs []*packages_model.PackageVersion
 	var pvsTotal int64
 	if pd.Package.Type == packages_model.TypeContainer {
@@ -467,6 +467,7 @@ func registerWebRoutes(m *web.Router, webAuth *AuthMiddleware) {
 		m.Post("/feishu/new", web.Bind[*forms.NewFeishuHookForm](), repo_setting.FeishuHooksNewPost)
 		m.Post("/wechatwork/new", web.Bind[*forms.NewWechatWorkHookForm](), repo_setting.WechatworkHooksNewPost)
 		m.Post("/packagist/new", web.Bind[*forms.NewPackagistHookForm](), repo_setting.PackagistHooksNewPost)
+		m.Post("/template/new", web.Bind[*forms.NewTemplateHookForm](), repo_setting.TemplateHooksNewPost)
 	}
 
 	addWebhookEditRoutes := func() {
@@ -481,6 +482,7 @@ func registerWebRoutes(m *web.Router, webAuth *AuthMiddleware) {
 		m.Post("/feishu/{id}", web.Bind[*forms.NewFeishuHookForm](), repo_setting.FeishuHooksEditPost)
 		m.Post("/wechatwork/{id}", web.Bind[*forms.NewWechatWorkHookForm](), repo_setting.WechatworkHooksEditPost)
 		m.Post("/packagist/{id}", web.Bind[*forms.NewPackagistHookForm](), repo_setting.PackagistHooksEditPost)
+		m.Post("/template/{id}", web.Bind[*forms.NewTemplateHookForm](), repo_setting.TemplateHooksEditPost)
 	}
 
 	addSettingsVariablesRoutes := func() {
@@ -763,6 +765,12 @@ func registerWebRoutes(m *web.Router, webAuth *AuthMiddleware) {
 			m.Get("/{provider}", auth.SignInOAuth)
 			m.Get("/{provider}/callback", auth.SignInOAuthCallback)
 		})
+		m.Group("/saml/{provider}", func() {
+			m.Get("", auth.SignInSAML)
+			m.Get("/metadata", auth.SAMLMetadata)
+			m.Post("/acs", auth.SAMLAssertionConsumer)
+			m.Methods("GET, POST", "/slo", auth.SAMLSingleLogout)
+		})
 	})
 	// ***** END: User *****
 
@@ -865,6 +873,8 @@ func registerWebRoutes(m *web.Router, webAuth *AuthMiddleware) {
 			m.Combo("/{authid}").Get(admin.EditAuthSource).
 				Post(web.Bind[*forms.AuthenticationForm](), admin.EditAuthSourcePost)
 			m.Post("/{authid}/delete", admin.DeleteAuthSource)
+			m.Post("/{authid}/scim/generate", admin.GenerateSCIMToken)
+			m.Post("/{authid}/scim/revoke", admin.RevokeSCIMToken)
 		})
 
 		m.Group("/notices", func() {
@@ -1346,6 +1356,10 @@ func registerWebRoutes(m *web.Router, webAuth *AuthMiddleware) {
 				m.Post("/title", repo.UpdateIssueTitle)
 				m.Post("/content", repo.UpdateIssueContent)
 				m.Post("/deadline", repo.UpdateIssueDeadline)
+				m.Post("/custom_fields/{id}", repo.UpdateIssueCustomField)
+				m.Post("/type", repo.UpdateIssueType)
+				m.Post("/sub_issues/add", repo.AddSubIssue)
+				m.Post("/sub_issues/remove", repo.RemoveSubIssue)
 				m.Post("/watch", repo.IssueWatch)
 				m.Post("/ref", repo.UpdateIssueRef)
 				m.Post("/pin", reqRepoAdmin, repo.IssuePinOrUnpin)
@@ -1547,6 +1561,8 @@ func registerWebRoutes(m *web.Router, webAuth *AuthMiddleware) {
 		m.Post("/run", reqRepoActionsWriter, actions.Run)
 		m.Get("/workflow-dispatch-inputs", reqRepoActionsWriter, actions.WorkflowDispatchInputs)
 		m.Post("/approve-all-checks", reqRepoActionsWriter, actions.ApproveAllChecks)
+		m.Get("/deployments", actions.Deployments)
+		m.Post("/deployments/{deployment_id}/review", reqSignIn, actions.ReviewDeploymentPost)
 
 		m.Group("/runs/{run}", func() {
 			m.Combo("").
@@ -1709,6 +1725,7 @@ func registerWebRoutes(m *web.Router, webAuth *AuthMiddleware) {
 			m.Get("/graph", repo.Graph)
 			m.Get("/commit/{sha:([a-f0-9]{7,64})$}", repo.SetEditorconfigIfExists, repo.SetDiffViewStyle, repo.SetWhitespaceBehavior, repo.Diff)
 			m.Get("/commit/{sha:([a-f0-9]{7,64})$}/load-branches-and-tags", repo.LoadBranchesAndTags)
+			m.Get("/code-nav/{sha:([a-f0-9]{7,64})$}", repo.CodeNavigation)
 
 			// FIXME: this route `/cherry-pick/{sha}` doesn't seem useful or right, the new code always uses `/_cherrypick/` which could handle branch name correctly
 			m.Get("/cherry-pick/{sha:([a-f0-9]{7,64})$}", repo.SetEditorconfigIfExists, context.RepoRefByDefaultBranch(), repo.CherryPick)
@@ -23,6 +23,8 @@ func ApproveRuns(ctx context.Context, repo *repo_model.Repository, doer *user_mo
 	cancelledConcurrencyJobs := make([]*actions_model.ActionRunJob, 0)
 	// Track runs whose reusable callers were just expanded so we can re-emit after the tx commits.
 	expandedCallerRunIDs := make(container.Set[int64])
+	// Track runs with jobs waiting for an environment, they are emitted after the tx commits too.
+	environmentRunIDs := make(container.Set[int64])
 
 	err := db.WithTx(ctx, func(ctx context.Context) (err error) {
 		for _, runID := range runIDs {
@@ -55,6 +57,11 @@ func ApproveRuns(ctx context.Context, repo *repo_model.Repository, doer *user_mo
 				if len(job.Needs) > 0 {
 					continue
 				}
+				// Jobs targeting an environment are left to job_emitter too, it applies the environment's protection rules.
+				if job.Environment != "" && job.Status == actions_model.StatusBlocked {
+					environmentRunIDs.Add(run.ID)
+					continue
+				}
 				// Only a job this approval unblocks competes for a slot, one that is already
 				// active was counted by the seeding loop above and must not take a second.
 				isUnblocking := job.Status == actions_model.StatusBlocked
@@ -118,6 +125,14 @@ func ApproveRuns(ctx context.Context, repo *repo_model.Repository, doer *user_mo
 			log.Error("emit run %d after approval-time caller expansion: %v", runID, err)
 		}
 	}
+	for runID := range environmentRunIDs {
+		if expandedCallerRunIDs.Contains(runID) {
+			continue
+		}
+		if err := EmitJobsIfReadyByRun(runID); err != nil {
+			log.Error("emit run %d after approval for its environments: %v", runID, err)
+		}
+	}
 
 	NotifyWorkflowJobsAndRunsStatusUpdate(ctx, updatedJobs)
 	NotifyWorkflowJobsAndRunsStatusUpdate(ctx, cancelledConcurrencyJobs)
@@ -0,0 +1,377 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	actions_model "gitea.dev/models/actions"
+	"gitea.dev/models/db"
+	"gitea.dev/modules/container"
+	"gitea.dev/modules/git"
+	"gitea.dev/modules/log"
+	"gitea.dev/modules/setting"
+	"gitea.dev/modules/storage"
+	"gitea.dev/modules/timeutil"
+	"gitea.dev/modules/util"
+)
+
+// cacheReservationTimeout is how long a job may take to upload a cache it reserved
+const cacheReservationTimeout = 24 * time.Hour
+
+func cacheStoragePath(cache *actions_model.ActionCache) string {
+	return fmt.Sprintf("%d/%d.cache", cache.RepoID, cache.ID)
+}
+
+func cacheBlockPath(cache *actions_model.ActionCache, blockID string) string {
+	return fmt.Sprintf("tmp/%d/%s", cache.ID, base64.RawURLEncoding.EncodeToString([]byte(blockID)))
+}
+
+// CacheRefsToRestore returns the refs whose caches a job of the run may restore, in order of preference:
+// the ref of the run, the base branch of a pull request and the default branch of the repository.
+func CacheRefsToRestore(ctx context.Context, run *actions_model.ActionRun) ([]string, error) {
+	if err := run.LoadRepo(ctx); err != nil {
+		return nil, err
+	}
+	refs := make([]string, 0, 3)
+	seen := make(container.Set[string], 3)
+	add := func(ref string) {
+		if ref != "" && seen.Add(ref) {
+			refs = append(refs, ref)
+		}
+	}
+	add(run.Ref)
+	if payload, err := run.GetPullRequestEventPayload(); err == nil && payload.PullRequest != nil && payload.PullRequest.Base != nil {
+		add(git.RefNameFromBranch(payload.PullRequest.Base.Ref).String())
+	}
+	add(git.RefNameFromBranch(run.Repo.DefaultBranch).String())
+	return refs, nil
+}
+
+// ReserveCache creates a cache entry the job of the task will upload, scoped to the ref of its run
+func ReserveCache(ctx context.Context, task *actions_model.ActionTask, key, version string) (*actions_model.ActionCache, error) {
+	if key == "" || len(key) > actions_model.MaxCacheKeyLength {
+		return nil, util.NewInvalidArgumentErrorf("invalid cache key %q", key)
+	}
+	if version == "" {
+		return nil, util.NewInvalidArgumentErrorf("cache version is required")
+	}
+	if err := task.LoadJob(ctx); err != nil {
+		return nil, err
+	}
+	if err := task.Job.LoadRun(ctx); err != nil {
+		return nil, err
+	}
+	cache := &actions_model.ActionCache{
+		RepoID:  task.RepoID,
+		Ref:     task.Job.Run.Ref,
+		Key:     key,
+		Version: version,
+		RunID:   task.Job.RunID,
+	}
+	if err := actions_model.ReserveCache(ctx, cache); err != nil {
+		return nil, err
+	}
+	cache.StoragePath = cacheStoragePath(cache)
+	if err := actions_model.UpdateCache(ctx, cache, "storage_path"); err != nil {
+		return nil, err
+	}
+	return cache, nil
+}
+
+// cacheSizeLimitReader fails the upload once more than the size limit of the caches of a repository is read
+type cacheSizeLimitReader struct {
+	r         io.Reader
+	remaining int64
+}
+
+func (r *cacheSizeLimitReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	r.remaining -= int64(n)
+	if r.remaining < 0 {
+		return n, errCacheTooLarge(-1)
+	}
+	return n, err
+}
+
+func errCacheTooLarge(size int64) error {
+	if size < 0 {
+		return util.ErrorWrap(util.ErrContentTooLarge, "cache size exceeds the limit %d", setting.Actions.CacheMaxRepoSize)
+	}
+	return util.ErrorWrap(util.ErrContentTooLarge, "cache size %d exceeds the limit %d", size, setting.Actions.CacheMaxRepoSize)
+}
+
+// saveCacheObject stores an uploaded object, the uploads larger than the size limit of the caches of a repository are rejected
+func saveCacheObject(path string, r io.Reader, size int64) error {
+	if setting.Actions.CacheMaxRepoSize < 0 {
+		_, err := storage.ActionsCaches.Save(path, r, size)
+		return err
+	}
+	if size > setting.Actions.CacheMaxRepoSize {
+		return errCacheTooLarge(size)
+	}
+	limited := &cacheSizeLimitReader{r: r, remaining: setting.Actions.CacheMaxRepoSize}
+	_, err := storage.ActionsCaches.Save(path, limited, size)
+	if limited.remaining < 0 {
+		// the storage may keep what has been written before the upload was aborted
+		if err := storage.ActionsCaches.Delete(path); err != nil {
+			log.Warn("Failed to delete the oversized cache object %s: %v", path, err)
+		}
+		return errCacheTooLarge(-1)
+	}
+	return err
+}
+
+// UploadCacheContent stores the whole content of a reserved cache
+func UploadCacheContent(cache *actions_model.ActionCache, r io.Reader, size int64) error {
+	return saveCacheObject(cache.StoragePath, r, size)
+}
+
+// UploadCacheBlock stores a block of a reserved cache, the blocks are merged by CommitCacheBlocks.
+// The size of the uploaded blocks is summed up in the reservation, so the blocks together can't go over the size limit.
+func UploadCacheBlock(ctx context.Context, cache *actions_model.ActionCache, blockID string, r io.Reader, size int64) error {
+	if blockID == "" {
+		return util.NewInvalidArgumentErrorf("block id is required")
+	}
+	path := cacheBlockPath(cache, blockID)
+	var previousSize int64
+	if fi, err := storage.ActionsCaches.Stat(path); err == nil {
+		previousSize = fi.Size() // the block is uploaded again and replaces the previous one
+	}
+	if err := saveCacheObject(path, r, size); err != nil {
+		return err
+	}
+	fi, err := storage.ActionsCaches.Stat(path)
+	if err != nil {
+		return err
+	}
+	if ok, err := actions_model.AddReservedCacheSize(ctx, cache.ID, fi.Size()-previousSize, setting.Actions.CacheMaxRepoSize); err != nil {
+		return err
+	} else if !ok {
+		if err := storage.ActionsCaches.Delete(path); err != nil {
+			log.Warn("Failed to delete the oversized cache block %s: %v", path, err)
+		}
+		if previousSize > 0 {
+			// the previous block has been replaced, it doesn't count anymore
+			if _, err := actions_model.AddReservedCacheSize(ctx, cache.ID, -previousSize, -1); err != nil {
+				return err
+			}
+		}
+		return errCacheTooLarge(-1)
+	}
+	return nil
+}
+
+// CommitCacheBlocks merges the uploaded blocks of a reserved cache in the given order
+func CommitCacheBlocks(cache *actions_model.ActionCache, blockIDs []string) error {
+	if len(blockIDs) == 0 {
+		return util.NewInvalidArgumentErrorf("empty block list")
+	}
+	readers := make([]io.Reader, 0, len(blockIDs))
+	var size int64
+	for _, blockID := range blockIDs {
+		fi, err := storage.ActionsCaches.Stat(cacheBlockPath(cache, blockID))
+		if err != nil {
+			return fmt.Errorf("block %q: %w", blockID, err)
+		}
+		size += fi.Size()
+	}
+	if setting.Actions.CacheMaxRepoSize >= 0 && size > setting.Actions.CacheMaxRepoSize {
+		deleteCacheBlocks(cache)
+		return errCacheTooLarge(size)
+	}
+	closeBlocks := func() {
+		for _, r := range readers {
+			_ = r.(io.Closer).Close()
+		}
+	}
+	for _, blockID := range blockIDs {
+		f, err := storage.ActionsCaches.Open(cacheBlockPath(cache, blockID))
+		if err != nil {
+			closeBlocks()
+			return fmt.Errorf("block %q: %w", blockID, err)
+		}
+		readers = append(readers, f)
+	}
+	_, err := storage.ActionsCaches.Save(cache.StoragePath, io.MultiReader(readers...), size)
+	closeBlocks()
+	if err != nil {
+		return err
+	}
+	deleteCacheBlocks(cache)
+	return nil
+}
+
+func deleteCacheBlocks(cache *actions_model.ActionCache) {
+	_ = storage.ActionsCaches.IterateObjects(fmt.Sprintf("tmp/%d", cache.ID), func(path string, _ storage.Object) error {
+		if err := storage.ActionsCaches.Delete(path); err != nil {
+			log.Warn("Failed to delete cache block %s: %v", path, err)
+		}
+		return nil
+	})
+}
+
+// FinalizeCache marks the cache reserved by the job of the task as restorable once its content is uploaded.
+// The least recently used caches of the repository are evicted if it goes over the size limit.
+func FinalizeCache(ctx context.Context, task *actions_model.ActionTask, key, version string, size int64) (*actions_model.ActionCache, error) {
+	if err := task.LoadJob(ctx); err != nil {
+		return nil, err
+	}
+	if err := task.Job.LoadRun(ctx); err != nil {
+		return nil, err
+	}
+	cache, err := actions_model.GetReservedCache(ctx, task.RepoID, task.Job.Run.Ref, key, version)
+	if err != nil {
+		return nil, err
+	}
+	fi, err := storage.ActionsCaches.Stat(cache.StoragePath)
+	if err != nil {
+		return nil, fmt.Errorf("cache %q has not been uploaded: %w", key, err)
+	}
+	if size >= 0 && fi.Size() != size {
+		return nil, util.NewInvalidArgumentErrorf("cache %q has %d bytes, expected %d", key, fi.Size(), size)
+	}
+
+	cache.Size = fi.Size()
+	cache.Status = actions_model.CacheStatusComplete
+	cache.LastAccessUnix = timeutil.TimeStampNow()
+	if err := actions_model.UpdateCache(ctx, cache, "size", "status", "last_access_unix"); err != nil {
+		return nil, err
+	}
+
+	if err := EvictCaches(ctx, cache.RepoID); err != nil {
+		log.Error("EvictCaches of repo %d: %v", cache.RepoID, err)
+	}
+	return cache, nil
+}
+
+// RestoreCache finds the cache the job of the task should restore and records the access for the eviction
+func RestoreCache(ctx context.Context, task *actions_model.ActionTask, key string, restoreKeys []string, version string) (*actions_model.ActionCache, error) {
+	if err := task.LoadJob(ctx); err != nil {
+		return nil, err
+	}
+	if err := task.Job.LoadRun(ctx); err != nil {
+		return nil, err
+	}
+	refs, err := CacheRefsToRestore(ctx, task.Job.Run)
+	if err != nil {
+		return nil, err
+	}
+	cache, err := actions_model.FindCacheToRestore(ctx, task.RepoID, refs, version, key, restoreKeys)
+	if err != nil {
+		return nil, err
+	}
+	cache.LastAccessUnix = timeutil.TimeStampNow()
+	if err := actions_model.UpdateCache(ctx, cache, "last_access_unix"); err != nil {
+		return nil, err
+	}
+	return cache, nil
+}
+
+// DeleteCache removes a cache entry and its content
+func DeleteCache(ctx context.Context, cache *actions_model.ActionCache) error {
+	if err := actions_model.DeleteCacheByID(ctx, cache.ID); err != nil {
+		return err
+	}
+	if cache.Status == actions_model.CacheStatusReserved {
+		deleteCacheBlocks(cache)
+	}
+	if err := storage.ActionsCaches.Delete(cache.StoragePath); err != nil && !errors.Is(err, util.ErrNotExist) {
+		log.Error("Failed to delete cache file %s: %v", cache.StoragePath, err)
+	}
+	return nil
+}
+
+// DeleteCaches removes all the caches matching the options and returns them
+func DeleteCaches(ctx context.Context, opts actions_model.FindCachesOptions) ([]*actions_model.ActionCache, error) {
+	caches, err := db.Find[actions_model.ActionCache](ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	for _, cache := range caches {
+		if err := DeleteCache(ctx, cache); err != nil {
+			return nil, err
+		}
+	}
+	return caches, nil
+}
+
+// EvictCaches removes the least recently used caches of a repository until it fits in the size limit
+func EvictCaches(ctx context.Context, repoID int64) error {
+	maxSize := setting.Actions.CacheMaxRepoSize
+	if maxSize < 0 {
+		return nil
+	}
+	size, err := actions_model.GetCacheSizeOfRepo(ctx, repoID)
+	if err != nil {
+		return err
+	}
+	if size <= maxSize {
+		return nil
+	}
+	caches, err := db.Find[actions_model.ActionCache](ctx, actions_model.FindCachesOptions{
+		RepoID:            repoID,
+		Status:            actions_model.CacheStatusComplete,
+		OrderByLastAccess: true,
+	})
+	if err != nil {
+		return err
+	}
+	for _, cache := range caches {
+		if size <= maxSize {
+			break
+		}
+		if err := DeleteCache(ctx, cache); err != nil {
+			return err
+		}
+		size -= cache.Size
+		log.Trace("Actions cache %d of repo %d is evicted", cache.ID, repoID)
+	}
+	return nil
+}
+
+// CleanupCaches removes the expired caches, the abandoned reservations and evicts caches of the repositories over the size limit
+func CleanupCaches(ctx context.Context) error {
+	if !setting.Actions.CacheEnabled {
+		return nil
+	}
+	if setting.Actions.CacheRetentionDays > 0 {
+		expired, err := DeleteCaches(ctx, actions_model.FindCachesOptions{
+			Status:           actions_model.CacheStatusComplete,
+			LastAccessBefore: timeutil.TimeStamp(time.Now().AddDate(0, 0, -int(setting.Actions.CacheRetentionDays)).Unix()),
+		})
+		if err != nil {
+			return fmt.Errorf("delete expired caches: %w", err)
+		}
+		log.Info("Removed %d expired Actions caches", len(expired))
+	}
+
+	abandoned, err := DeleteCaches(ctx, actions_model.FindCachesOptions{
+		Status:           actions_model.CacheStatusReserved,
+		LastAccessBefore: timeutil.TimeStamp(time.Now().Add(-cacheReservationTimeout).Unix()),
+	})
+	if err != nil {
+		return fmt.Errorf("delete abandoned cache reservations: %w", err)
+	}
+	log.Info("Removed %d abandoned Actions cache reservations", len(abandoned))
+
+	if setting.Actions.CacheMaxRepoSize < 0 {
+		return nil
+	}
+	repoIDs, err := actions_model.GetRepoIDsOverCacheSize(ctx, setting.Actions.CacheMaxRepoSize)
+	if err != nil {
+		return err
+	}
+	for _, repoID := range repoIDs {
+		if err := EvictCaches(ctx, repoID); err != nil {
+			return fmt.Errorf("evict caches of repo %d: %w", repoID, err)
+		}
+	}
+	return nil
+}
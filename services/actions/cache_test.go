@@ -0,0 +1,95 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	actions_model "gitea.dev/models/actions"
+	"gitea.dev/models/unittest"
+	"gitea.dev/modules/setting"
+	"gitea.dev/modules/storage"
+	"gitea.dev/modules/test"
+	"gitea.dev/modules/util"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUploadCacheSizeLimit(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+	defer test.MockVariableValue(&setting.Actions.CacheMaxRepoSize, 10)()
+
+	cache := &actions_model.ActionCache{RepoID: 1, Ref: "refs/heads/main", Key: "size-limit", Version: "v1"}
+	require.NoError(t, actions_model.ReserveCache(t.Context(), cache))
+	cache.StoragePath = cacheStoragePath(cache)
+	require.NoError(t, actions_model.UpdateCache(t.Context(), cache, "storage_path"))
+	defer func() {
+		_ = storage.ActionsCaches.Delete(cache.StoragePath)
+		deleteCacheBlocks(cache)
+	}()
+
+	assertNotStored := func(t *testing.T, path string) {
+		_, err := storage.ActionsCaches.Stat(path)
+		assert.Error(t, err)
+	}
+
+	t.Run("Content", func(t *testing.T) {
+		// the declared size is over the limit
+		err := UploadCacheContent(cache, strings.NewReader("01234567890"), 11)
+		assert.ErrorIs(t, err, util.ErrContentTooLarge)
+		assertNotStored(t, cache.StoragePath)
+
+		// the size is unknown or wrong, the upload fails once the limit is read
+		err = UploadCacheContent(cache, strings.NewReader("01234567890"), -1)
+		assert.ErrorIs(t, err, util.ErrContentTooLarge)
+		assertNotStored(t, cache.StoragePath)
+		err = UploadCacheContent(cache, strings.NewReader("01234567890"), 5)
+		assert.ErrorIs(t, err, util.ErrContentTooLarge)
+		assertNotStored(t, cache.StoragePath)
+
+		require.NoError(t, UploadCacheContent(cache, strings.NewReader("0123456789"), -1))
+		f, err := storage.ActionsCaches.Open(cache.StoragePath)
+		require.NoError(t, err)
+		defer f.Close()
+		content, err := io.ReadAll(f)
+		require.NoError(t, err)
+		assert.Equal(t, "0123456789", string(content))
+	})
+
+	t.Run("Blocks", func(t *testing.T) {
+		ctx := t.Context()
+		err := UploadCacheBlock(ctx, cache, "large", strings.NewReader("01234567890"), -1)
+		assert.ErrorIs(t, err, util.ErrContentTooLarge)
+		assertNotStored(t, cacheBlockPath(cache, "large"))
+
+		// every block fits in the limit but not all of them together
+		require.NoError(t, UploadCacheBlock(ctx, cache, "a", strings.NewReader("012345"), -1))
+		err = UploadCacheBlock(ctx, cache, "b", strings.NewReader("012345"), -1)
+		assert.ErrorIs(t, err, util.ErrContentTooLarge)
+		assertNotStored(t, cacheBlockPath(cache, "b"))
+		cache = unittest.AssertExistsAndLoadBean(t, &actions_model.ActionCache{ID: cache.ID})
+		assert.EqualValues(t, 6, cache.Size)
+
+		// a block uploaded again replaces the previous one
+		require.NoError(t, UploadCacheBlock(ctx, cache, "a", strings.NewReader("0123"), -1))
+		require.NoError(t, UploadCacheBlock(ctx, cache, "b", strings.NewReader("012345"), -1))
+		cache = unittest.AssertExistsAndLoadBean(t, &actions_model.ActionCache{ID: cache.ID})
+		assert.EqualValues(t, 10, cache.Size)
+		require.NoError(t, CommitCacheBlocks(cache, []string{"a", "b"}))
+		assertNotStored(t, cacheBlockPath(cache, "a"))
+		assertNotStored(t, cacheBlockPath(cache, "b"))
+	})
+
+	t.Run("NoLimit", func(t *testing.T) {
+		defer test.MockVariableValue(&setting.Actions.CacheMaxRepoSize, -1)()
+
+		require.NoError(t, UploadCacheContent(cache, strings.NewReader("01234567890"), -1))
+		fi, err := storage.ActionsCaches.Stat(cache.StoragePath)
+		require.NoError(t, err)
+		assert.EqualValues(t, 11, fi.Size())
+	})
+}
@@ -10,12 +10,14 @@ import (
 
 	actions_model "gitea.dev/models/actions"
 	"gitea.dev/models/db"
+	quota_model "gitea.dev/models/quota"
 	actions_module "gitea.dev/modules/actions"
 	"gitea.dev/modules/container"
 	"gitea.dev/modules/log"
 	"gitea.dev/modules/setting"
 	"gitea.dev/modules/storage"
 	"gitea.dev/modules/timeutil"
+	quota_service "gitea.dev/services/quota"
 
 	"xorm.io/builder"
 )
@@ -54,11 +56,14 @@ func cleanExpiredArtifacts(taskCtx context.Context) error {
 		return err
 	}
 	log.Info("Found %d expired Actions artifacts", len(artifacts))
+	ownerIDs := make(container.Set[int64])
+	defer updateArtifactQuotaUsage(taskCtx, ownerIDs)
 	for _, artifact := range artifacts {
 		if err := actions_model.SetArtifactExpired(taskCtx, artifact.ID); err != nil {
 			log.Error("Cannot set artifact %d expired: %v", artifact.ID, err)
 			continue
 		}
+		ownerIDs.Add(artifact.OwnerID)
 		if err := storage.ActionsArtifacts.Delete(artifact.StoragePath); err != nil {
 			log.Error("Cannot delete artifact %d: %v", artifact.ID, err)
 			// go on
@@ -72,6 +77,8 @@ func cleanExpiredArtifacts(taskCtx context.Context) error {
 const deleteArtifactBatchSize = 100
 
 func cleanNeedDeleteArtifacts(taskCtx context.Context) error {
+	ownerIDs := make(container.Set[int64])
+	defer updateArtifactQuotaUsage(taskCtx, ownerIDs)
 	for {
 		artifacts, err := actions_model.ListPendingDeleteArtifacts(taskCtx, deleteArtifactBatchSize)
 		if err != nil {
@@ -83,6 +90,7 @@ func cleanNeedDeleteArtifacts(taskCtx context.Context) error {
 				log.Error("Cannot set artifact %d deleted: %v", artifact.ID, err)
 				continue
 			}
+			ownerIDs.Add(artifact.OwnerID)
 			if err := storage.ActionsArtifacts.Delete(artifact.StoragePath); err != nil {
 				log.Error("Cannot delete artifact %d: %v", artifact.ID, err)
 				// go on
@@ -97,6 +105,12 @@ func cleanNeedDeleteArtifacts(taskCtx context.Context) error {
 	return nil
 }
 
+func updateArtifactQuotaUsage(ctx context.Context, ownerIDs container.Set[int64]) {
+	for ownerID := range ownerIDs {
+		quota_service.UpdateUsage(ctx, ownerID, quota_model.CategoryArtifacts)
+	}
+}
+
 const deleteLogBatchSize = 100
 
 func removeTaskLog(ctx context.Context, task *actions_model.ActionTask) {
@@ -0,0 +1,329 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	actions_model "gitea.dev/models/actions"
+	"gitea.dev/models/db"
+	git_model "gitea.dev/models/git"
+	"gitea.dev/models/organization"
+	secret_model "gitea.dev/models/secret"
+	user_model "gitea.dev/models/user"
+	"gitea.dev/modules/actions/jobparser"
+	"gitea.dev/modules/container"
+	"gitea.dev/modules/git"
+	"gitea.dev/modules/log"
+	"gitea.dev/modules/timeutil"
+	"gitea.dev/modules/util"
+
+	"xorm.io/builder"
+)
+
+// ErrDeploymentReviewNotAllowed is returned when a user is not a reviewer of the environment a deployment targets
+var ErrDeploymentReviewNotAllowed = util.NewPermissionDeniedErrorf("user is not allowed to review the deployment")
+
+// DeleteEnvironment removes an environment of a repository with everything scoped to it.
+// The jobs still waiting for a deployment to the environment are failed, they must not start without its protection rules.
+func DeleteEnvironment(ctx context.Context, env *actions_model.ActionEnvironment) error {
+	var failedJobs []*actions_model.ActionRunJob
+
+	if err := db.WithTx(ctx, func(ctx context.Context) error {
+		deployments, err := db.Find[actions_model.ActionDeployment](ctx, actions_model.FindDeploymentsOptions{
+			RepoID:        env.RepoID,
+			EnvironmentID: env.ID,
+			Statuses:      []actions_model.DeploymentStatus{actions_model.DeploymentStatusWaiting},
+		})
+		if err != nil {
+			return err
+		}
+		for _, deployment := range deployments {
+			if err := deployment.LoadJob(ctx); errors.Is(err, util.ErrNotExist) {
+				continue // the run has been deleted
+			} else if err != nil {
+				return err
+			}
+			job := deployment.Job
+			job.Status = actions_model.StatusFailure
+			job.Stopped = timeutil.TimeStampNow()
+			if n, err := actions_model.UpdateRunJob(ctx, job, builder.Eq{"status": actions_model.StatusBlocked}, "status", "stopped"); err != nil {
+				return err
+			} else if n == 1 {
+				failedJobs = append(failedJobs, job)
+			}
+		}
+
+		if err := secret_model.DeleteSecretsOfEnvironment(ctx, env.ID); err != nil {
+			return err
+		}
+		return actions_model.DeleteEnvironment(ctx, env)
+	}); err != nil {
+		return err
+	}
+
+	if len(failedJobs) > 0 {
+		NotifyWorkflowJobsAndRunsStatusUpdate(ctx, failedJobs)
+		EmitJobsIfReadyByJobs(failedJobs)
+	}
+	return nil
+}
+
+// checkJobEnvironment applies the protection rules of the environment a blocked job targets.
+// It returns StatusWaiting when the job may start, StatusBlocked while it waits for a reviewer or the wait timer,
+// and StatusFailure when the deployment has been rejected or the environment name is invalid.
+func checkJobEnvironment(ctx context.Context, job *actions_model.ActionRunJob) (actions_model.Status, error) {
+	if job.Environment == "" {
+		return actions_model.StatusWaiting, nil
+	}
+	if err := actions_model.ValidateEnvironmentName(job.Environment); err != nil {
+		// the environment can never be created, so the job could never start
+		log.Debug("job %d targets an invalid environment: %v", job.ID, err)
+		return actions_model.StatusFailure, nil
+	}
+	if err := job.LoadRun(ctx); err != nil {
+		return actions_model.StatusBlocked, err
+	}
+
+	env, err := actions_model.GetOrCreateEnvironment(ctx, job.RepoID, job.Environment)
+	if err != nil {
+		return actions_model.StatusBlocked, fmt.Errorf("get environment %q: %w", job.Environment, err)
+	}
+	deployment, err := getOrCreateDeployment(ctx, job, env)
+	if err != nil {
+		return actions_model.StatusBlocked, err
+	}
+	switch deployment.Status {
+	case actions_model.DeploymentStatusApproved:
+		return actions_model.StatusWaiting, nil
+	case actions_model.DeploymentStatusRejected:
+		return actions_model.StatusFailure, nil
+	}
+
+	allowed, err := isRefAllowedToDeploy(ctx, env, job.Run.Ref)
+	if err != nil {
+		return actions_model.StatusBlocked, err
+	}
+	if !allowed {
+		log.Debug("ref %q of run %d is not allowed to deploy to environment %d", job.Run.Ref, job.RunID, env.ID)
+		deployment.Status = actions_model.DeploymentStatusRejected
+		if _, err := actions_model.UpdateDeploymentStatus(ctx, deployment, actions_model.DeploymentStatusWaiting); err != nil {
+			return actions_model.StatusBlocked, err
+		}
+		return actions_model.StatusFailure, nil
+	}
+
+	if deployment.ApprovedUnix == 0 {
+		if env.RequiresReview() {
+			return actions_model.StatusBlocked, nil // ReviewDeployments emits the run again once a reviewer decides
+		}
+		deployment.ApprovedUnix = timeutil.TimeStampNow()
+		if err := actions_model.UpdateDeployment(ctx, deployment, "approved_unix"); err != nil {
+			return actions_model.StatusBlocked, err
+		}
+	}
+
+	if env.WaitTimer > 0 {
+		if deployment.StartableUnix == 0 {
+			deployment.StartableUnix = deployment.ApprovedUnix.Add(env.WaitTimer * 60)
+			if err := actions_model.UpdateDeployment(ctx, deployment, "startable_unix"); err != nil {
+				return actions_model.StatusBlocked, err
+			}
+		}
+		if timeutil.TimeStampNow() < deployment.StartableUnix {
+			return actions_model.StatusBlocked, nil // ReleaseEnvironmentWaitTimers emits the run again once the timer elapses
+		}
+	}
+
+	deployment.Status = actions_model.DeploymentStatusApproved
+	if ok, err := actions_model.UpdateDeploymentStatus(ctx, deployment, actions_model.DeploymentStatusWaiting); err != nil {
+		return actions_model.StatusBlocked, err
+	} else if !ok {
+		// a reviewer rejected the deployment in the meantime
+		return actions_model.StatusBlocked, nil
+	}
+	return actions_model.StatusWaiting, nil
+}
+
+func getOrCreateDeployment(ctx context.Context, job *actions_model.ActionRunJob, env *actions_model.ActionEnvironment) (*actions_model.ActionDeployment, error) {
+	deployment, err := actions_model.GetDeploymentByJobID(ctx, job.ID)
+	if err == nil || !errors.Is(err, util.ErrNotExist) {
+		return deployment, err
+	}
+
+	deployment = &actions_model.ActionDeployment{
+		RepoID:        job.RepoID,
+		EnvironmentID: env.ID,
+		RunID:         job.RunID,
+		JobID:         job.ID,
+		Ref:           job.Run.Ref,
+		CommitSHA:     job.CommitSHA,
+		TriggerUserID: job.Run.TriggerUserID,
+		URL:           deploymentURL(job, env),
+		Status:        actions_model.DeploymentStatusWaiting,
+	}
+	if err := db.Insert(ctx, deployment); err != nil {
+		return nil, fmt.Errorf("insert deployment of job %d: %w", job.ID, err)
+	}
+	return deployment, nil
+}
+
+// deploymentURL returns the url the job declares for its environment, or the environment's own url.
+// An url built from step outputs is only known once the job has run, so it is not recorded.
+func deploymentURL(job *actions_model.ActionRunJob, env *actions_model.ActionEnvironment) string {
+	workflows, err := jobparser.Parse(job.WorkflowPayload)
+	if err == nil && len(workflows) == 1 {
+		if _, workflowJob := workflows[0].Job(); workflowJob != nil {
+			if _, url := workflowJob.Environment(); url != "" && !strings.Contains(url, "${{") {
+				return url
+			}
+		}
+	}
+	return env.ExternalURL
+}
+
+// isRefAllowedToDeploy checks a ref against the deployment branch policy of an environment
+func isRefAllowedToDeploy(ctx context.Context, env *actions_model.ActionEnvironment, ref string) (bool, error) {
+	refName := git.RefName(ref)
+	switch env.BranchPolicy {
+	case actions_model.EnvironmentBranchPolicyProtected:
+		if !refName.IsBranch() {
+			return false, nil
+		}
+		return git_model.IsBranchProtected(ctx, env.RepoID, refName.BranchName())
+	case actions_model.EnvironmentBranchPolicyCustom:
+		if !refName.IsBranch() && !refName.IsTag() {
+			return false, nil
+		}
+		return env.MatchBranchPatterns(refName.ShortName()), nil
+	}
+	return true, nil
+}
+
+// CanReviewDeployment returns whether a user is one of the reviewers of the environment a deployment targets
+func CanReviewDeployment(ctx context.Context, doer *user_model.User, deployment *actions_model.ActionDeployment) (bool, error) {
+	if doer == nil || !deployment.Status.IsWaiting() || deployment.ApprovedUnix != 0 {
+		return false, nil
+	}
+	if err := deployment.LoadJob(ctx); err != nil {
+		return false, err
+	}
+	if deployment.Job.Status != actions_model.StatusBlocked {
+		return false, nil // the job has been cancelled while it was waiting
+	}
+	if err := deployment.LoadEnvironment(ctx); err != nil {
+		return false, err
+	}
+	env := deployment.Environment
+	if !env.RequiresReview() {
+		return false, nil
+	}
+	if env.PreventSelfReview && doer.ID == deployment.TriggerUserID {
+		return false, nil
+	}
+	if slices.Contains(env.ReviewerIDs, doer.ID) {
+		return true, nil
+	}
+	if len(env.ReviewerTeamIDs) == 0 {
+		return false, nil
+	}
+	return organization.IsUserInTeams(ctx, doer.ID, env.ReviewerTeamIDs)
+}
+
+// ReviewDeployments approves or rejects the waiting deployments of a run.
+// A single approval lets the job start once the wait timer of its environment elapses, a rejection fails the job.
+func ReviewDeployments(ctx context.Context, doer *user_model.User, run *actions_model.ActionRun, deployments []*actions_model.ActionDeployment, approve bool, comment string) error {
+	state := util.Iif(approve, actions_model.ReviewStateApproved, actions_model.ReviewStateRejected)
+	var rejectedJobs []*actions_model.ActionRunJob
+
+	if err := db.WithTx(ctx, func(ctx context.Context) error {
+		for _, deployment := range deployments {
+			if deployment.RunID != run.ID {
+				return util.NewInvalidArgumentErrorf("deployment %d does not belong to run %d", deployment.ID, run.ID)
+			}
+			canReview, err := CanReviewDeployment(ctx, doer, deployment)
+			if err != nil {
+				return err
+			} else if !canReview {
+				return ErrDeploymentReviewNotAllowed
+			}
+
+			if err := actions_model.InsertDeploymentReview(ctx, &actions_model.ActionDeploymentReview{
+				RepoID:        deployment.RepoID,
+				DeploymentID:  deployment.ID,
+				EnvironmentID: deployment.EnvironmentID,
+				ReviewerID:    doer.ID,
+				State:         state,
+				Comment:       comment,
+			}); err != nil {
+				return err
+			}
+
+			if approve {
+				deployment.ApprovedUnix = timeutil.TimeStampNow()
+				if err := actions_model.UpdateDeployment(ctx, deployment, "approved_unix"); err != nil {
+					return err
+				}
+				continue
+			}
+
+			deployment.Status = actions_model.DeploymentStatusRejected
+			if ok, err := actions_model.UpdateDeploymentStatus(ctx, deployment, actions_model.DeploymentStatusWaiting); err != nil {
+				return err
+			} else if !ok {
+				return util.NewInvalidArgumentErrorf("deployment %d is no longer waiting", deployment.ID)
+			}
+			if err := deployment.LoadJob(ctx); err != nil {
+				return err
+			}
+			job := deployment.Job
+			job.Status = actions_model.StatusFailure
+			job.Stopped = timeutil.TimeStampNow()
+			if n, err := actions_model.UpdateRunJob(ctx, job, builder.Eq{"status": actions_model.StatusBlocked}, "status", "stopped"); err != nil {
+				return err
+			} else if n == 1 {
+				rejectedJobs = append(rejectedJobs, job)
+			}
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	if len(rejectedJobs) > 0 {
+		CreateCommitStatusForRunJobs(ctx, run, rejectedJobs...)
+		NotifyWorkflowJobsAndRunsStatusUpdate(ctx, rejectedJobs)
+		EmitJobsIfReadyByJobs(rejectedJobs)
+	}
+	if approve {
+		return EmitJobsIfReadyByRun(run.ID)
+	}
+	return nil
+}
+
+// ReleaseEnvironmentWaitTimers emits the runs whose deployments have waited long enough to start
+func ReleaseEnvironmentWaitTimers(ctx context.Context) error {
+	deployments, err := db.Find[actions_model.ActionDeployment](ctx, actions_model.FindDeploymentsOptions{
+		Statuses:        []actions_model.DeploymentStatus{actions_model.DeploymentStatusWaiting},
+		StartableBefore: timeutil.TimeStampNow(),
+	})
+	if err != nil {
+		return fmt.Errorf("find deployments: %w", err)
+	}
+
+	emitted := make(container.Set[int64])
+	for _, deployment := range deployments {
+		if !emitted.Add(deployment.RunID) {
+			continue
+		}
+		if err := EmitJobsIfReadyByRun(deployment.RunID); err != nil {
+			log.Error("emit run %d after its wait timer elapsed: %v", deployment.RunID, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,165 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"testing"
+
+	actions_model "gitea.dev/models/actions"
+	"gitea.dev/models/db"
+	"gitea.dev/models/unittest"
+	user_model "gitea.dev/models/user"
+	"gitea.dev/modules/test"
+	"gitea.dev/modules/timeutil"
+	"gitea.dev/modules/util"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckJobEnvironment(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+	defer test.MockVariableValue(&EmitJobsIfReadyByRun, func(runID int64) error { return nil })()
+	ctx := t.Context()
+
+	reviewer := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	other := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 4})
+
+	insertJob := func(index int64, ref, environment string) *actions_model.ActionRunJob {
+		run := &actions_model.ActionRun{
+			Title: "deploy", RepoID: 4, OwnerID: 1, WorkflowID: "deploy.yml", Index: index,
+			TriggerUserID: other.ID, Ref: ref, CommitSHA: "c2d72f548424103f01ee1dc02889c1e2bff816b0",
+			Event: "push", TriggerEvent: "push", Status: actions_model.StatusBlocked,
+		}
+		require.NoError(t, db.Insert(ctx, run))
+		job := &actions_model.ActionRunJob{
+			RunID: run.ID, RepoID: run.RepoID, OwnerID: run.OwnerID, CommitSHA: run.CommitSHA,
+			Name: "deploy", Attempt: 1, JobID: "deploy", Status: actions_model.StatusBlocked,
+			RunsOn: []string{"ubuntu-latest"}, Environment: environment,
+		}
+		require.NoError(t, db.Insert(ctx, job))
+		return job
+	}
+
+	t.Run("a job without environment is not held", func(t *testing.T) {
+		status, err := checkJobEnvironment(ctx, insertJob(9801, "refs/heads/main", ""))
+		require.NoError(t, err)
+		assert.Equal(t, actions_model.StatusWaiting, status)
+	})
+
+	t.Run("an unknown environment is created without protection rules", func(t *testing.T) {
+		job := insertJob(9802, "refs/heads/main", "preview")
+		status, err := checkJobEnvironment(ctx, job)
+		require.NoError(t, err)
+		assert.Equal(t, actions_model.StatusWaiting, status)
+
+		env, err := actions_model.GetEnvironmentByRepoAndName(ctx, 4, "preview")
+		require.NoError(t, err)
+		assert.False(t, env.IsProtected())
+		deployment, err := actions_model.GetDeploymentByJobID(ctx, job.ID)
+		require.NoError(t, err)
+		assert.Equal(t, actions_model.DeploymentStatusApproved, deployment.Status)
+		assert.Equal(t, env.ID, deployment.EnvironmentID)
+	})
+
+	t.Run("a ref outside of the branch policy is rejected", func(t *testing.T) {
+		require.NoError(t, actions_model.CreateEnvironment(ctx, &actions_model.ActionEnvironment{
+			RepoID: 4, Name: "staging", BranchPolicy: actions_model.EnvironmentBranchPolicyCustom, BranchPatterns: []string{"release/*"},
+		}))
+
+		status, err := checkJobEnvironment(ctx, insertJob(9803, "refs/heads/feature", "staging"))
+		require.NoError(t, err)
+		assert.Equal(t, actions_model.StatusFailure, status)
+
+		status, err = checkJobEnvironment(ctx, insertJob(9804, "refs/heads/release/1.0", "staging"))
+		require.NoError(t, err)
+		assert.Equal(t, actions_model.StatusWaiting, status)
+	})
+
+	require.NoError(t, actions_model.CreateEnvironment(ctx, &actions_model.ActionEnvironment{
+		RepoID: 4, Name: "production", ReviewerIDs: []int64{reviewer.ID}, WaitTimer: 10,
+	}))
+
+	t.Run("a reviewer approves, then the wait timer holds the job", func(t *testing.T) {
+		job := insertJob(9805, "refs/heads/main", "production")
+		status, err := checkJobEnvironment(ctx, job)
+		require.NoError(t, err)
+		assert.Equal(t, actions_model.StatusBlocked, status)
+
+		deployment, err := actions_model.GetDeploymentByJobID(ctx, job.ID)
+		require.NoError(t, err)
+		canReview, err := CanReviewDeployment(ctx, other, deployment)
+		require.NoError(t, err)
+		assert.False(t, canReview)
+		canReview, err = CanReviewDeployment(ctx, reviewer, deployment)
+		require.NoError(t, err)
+		assert.True(t, canReview)
+
+		require.NoError(t, job.LoadRun(ctx))
+		err = ReviewDeployments(ctx, other, job.Run, []*actions_model.ActionDeployment{deployment}, true, "")
+		assert.ErrorIs(t, err, util.ErrPermissionDenied)
+		require.NoError(t, ReviewDeployments(ctx, reviewer, job.Run, []*actions_model.ActionDeployment{deployment}, true, "lgtm"))
+
+		status, err = checkJobEnvironment(ctx, job)
+		require.NoError(t, err)
+		assert.Equal(t, actions_model.StatusBlocked, status, "the wait timer has not elapsed yet")
+
+		deployment, err = actions_model.GetDeploymentByJobID(ctx, job.ID)
+		require.NoError(t, err)
+		assert.Equal(t, deployment.ApprovedUnix.Add(600), deployment.StartableUnix)
+
+		deployment.StartableUnix = timeutil.TimeStampNow() - 1
+		require.NoError(t, actions_model.UpdateDeployment(ctx, deployment, "startable_unix"))
+		status, err = checkJobEnvironment(ctx, job)
+		require.NoError(t, err)
+		assert.Equal(t, actions_model.StatusWaiting, status)
+
+		reviews, err := actions_model.GetDeploymentReviews(ctx, deployment.ID)
+		require.NoError(t, err)
+		require.Len(t, reviews, 1)
+		assert.Equal(t, "lgtm", reviews[0].Comment)
+	})
+
+	t.Run("a rejected deployment fails its job", func(t *testing.T) {
+		job := insertJob(9806, "refs/heads/main", "production")
+		status, err := checkJobEnvironment(ctx, job)
+		require.NoError(t, err)
+		assert.Equal(t, actions_model.StatusBlocked, status)
+
+		deployment, err := actions_model.GetDeploymentByJobID(ctx, job.ID)
+		require.NoError(t, err)
+		require.NoError(t, job.LoadRun(ctx))
+		require.NoError(t, ReviewDeployments(ctx, reviewer, job.Run, []*actions_model.ActionDeployment{deployment}, false, "not today"))
+
+		job = unittest.AssertExistsAndLoadBean(t, &actions_model.ActionRunJob{ID: job.ID})
+		assert.Equal(t, actions_model.StatusFailure, job.Status)
+		assert.NotZero(t, job.Stopped)
+		deployment = unittest.AssertExistsAndLoadBean(t, &actions_model.ActionDeployment{ID: deployment.ID})
+		assert.Equal(t, actions_model.DeploymentStatusRejected, deployment.Status)
+	})
+
+	t.Run("an invalid environment name fails the job", func(t *testing.T) {
+		status, err := checkJobEnvironment(ctx, insertJob(9807, "refs/heads/main", "prod/eu"))
+		require.NoError(t, err)
+		assert.Equal(t, actions_model.StatusFailure, status)
+		unittest.AssertNotExistsBean(t, &actions_model.ActionEnvironment{RepoID: 4, LowerName: "prod/eu"})
+	})
+
+	t.Run("deleting an environment fails the jobs waiting for it", func(t *testing.T) {
+		job := insertJob(9808, "refs/heads/main", "production")
+		status, err := checkJobEnvironment(ctx, job)
+		require.NoError(t, err)
+		assert.Equal(t, actions_model.StatusBlocked, status)
+
+		env, err := actions_model.GetEnvironmentByRepoAndName(ctx, 4, "production")
+		require.NoError(t, err)
+		require.NoError(t, DeleteEnvironment(ctx, env))
+
+		job = unittest.AssertExistsAndLoadBean(t, &actions_model.ActionRunJob{ID: job.ID})
+		assert.Equal(t, actions_model.StatusFailure, job.Status)
+		assert.NotZero(t, job.Stopped)
+		unittest.AssertNotExistsBean(t, &actions_model.ActionDeployment{JobID: job.ID})
+		unittest.AssertNotExistsBean(t, &actions_model.ActionEnvironment{ID: env.ID})
+	})
+}
@@ -334,7 +334,13 @@ func checkJobsOfCurrentRunAttempt(ctx context.Context, run *actions_model.Action
 
 			// Non-caller: standard status update.
 			job.Status = status
-			if n, err := actions_model.UpdateRunJob(ctx, job, builder.Eq{"status": actions_model.StatusBlocked}, "status"); err != nil {
+			cols := []string{"status"}
+			if status == actions_model.StatusFailure {
+				// a job refused by its environment never reaches a runner, so it is stopped here
+				job.Stopped = timeutil.TimeStampNow()
+				cols = append(cols, "stopped")
+			}
+			if n, err := actions_model.UpdateRunJob(ctx, job, builder.Eq{"status": actions_model.StatusBlocked}, cols...); err != nil {
 				return err
 			} else if n != 1 {
 				return fmt.Errorf("no affected for updating blocked job %v", job.ID)
@@ -539,6 +545,19 @@ func (r *jobStatusResolver) resolve(ctx context.Context) (map[int64]actions_mode
 			}
 		}
 
+		// A job targeting an environment cannot start before the environment's protection rules allow it.
+		envStatus, err := checkJobEnvironment(ctx, actionRunJob)
+		if err != nil {
+			log.Error("checkJobEnvironment failed, this job will stay blocked: job: %d, err: %v", id, err)
+			continue
+		}
+		if envStatus == actions_model.StatusFailure {
+			ret[id] = envStatus
+			continue
+		} else if envStatus != actions_model.StatusWaiting {
+			continue
+		}
+
 		// A slot-starved job cannot start, skip the following checks.
 		if !slots.available(actionRunJob) {
 			continue
@@ -109,7 +109,7 @@ func expandDeferredMatrix(ctx context.Context, job *actions_model.ActionRunJob,
 		return nil, failTerminal(fmt.Errorf("expand matrix: %w", err))
 	}
 	// Combinations differ only in what the matrix feeds: the name, the payload, and a
-	// runs-on/continue-on-error that may interpolate matrix.*.
+	// runs-on/continue-on-error/environment that may interpolate matrix.*.
 	applyCombo := func(dst *actions_model.ActionRunJob, combo *jobparser.Job) error {
 		swf := baseSWF.CloneHeader()
 		if err := swf.SetJob(job.JobID, combo.EraseNeeds()); err != nil {
@@ -122,6 +122,8 @@ func expandDeferredMatrix(ctx context.Context, job *actions_model.ActionRunJob,
 		dst.Name = util.EllipsisDisplayString(combo.Name, 255)
 		dst.WorkflowPayload, dst.RunsOn = payload, combo.RunsOn()
 		dst.ContinueOnError = combo.GetContinueOnError()
+		environment, _ := combo.Environment()
+		dst.Environment = util.EllipsisDisplayString(environment, 255)
 		return nil
 	}
 
@@ -174,7 +176,7 @@ func expandDeferredMatrix(ctx context.Context, job *actions_model.ActionRunJob,
 	job.IsMatrixDeferred = false
 	affected, err := actions_model.UpdateRunJob(ctx, job,
 		builder.Eq{"is_matrix_deferred": true, "status": actions_model.StatusBlocked},
-		"name", "workflow_payload", "runs_on", "continue_on_error", "is_matrix_deferred")
+		"name", "workflow_payload", "runs_on", "continue_on_error", "environment", "is_matrix_deferred")
 	if err != nil {
 		return nil, fmt.Errorf("claim placeholder of job %d: %w", job.ID, err)
 	}
@@ -212,6 +214,8 @@ func restoreDeferredMatrixPlaceholder(clone *actions_model.ActionRunJob) error {
 	clone.WorkflowPayload = slices.Clone(clone.DeferredMatrixPayload)
 	clone.RunsOn = parsed.RunsOn()
 	clone.ContinueOnError = parsed.GetContinueOnError()
+	environment, _ := parsed.Environment()
+	clone.Environment = util.EllipsisDisplayString(environment, 255)
 	clone.IsMatrixDeferred = true
 	return nil
 }
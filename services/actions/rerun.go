@@ -223,6 +223,7 @@ func execRerunPlan(ctx context.Context, plan *rerunPlan) (*actions_model.ActionR
 	var newJobs, newJobsToRerun actions_model.ActionJobList
 	var cancelledConcurrencyJobs []*actions_model.ActionRunJob
 	var hasWaitingCallerJobs bool
+	var hasEnvironmentJobs bool
 
 	err = db.WithTx(ctx, func(ctx context.Context) error {
 		newAttemptStatus, jobsToCancel, err := PrepareToStartRunWithConcurrency(ctx, newAttempt)
@@ -284,7 +285,9 @@ func execRerunPlan(ctx context.Context, plan *rerunPlan) (*actions_model.ActionR
 			if plan.rerunAttemptJobIDs.Contains(templateJob.AttemptJobID) {
 				// A deferred-matrix placeholder must go through the emitter, which is the only place
 				// that expands it: dispatching it directly would hand the runner the raw payload.
-				shouldBlockJob := shouldBlock || plan.hasRerunDependency(templateJob) || newJob.IsMatrixDeferred
+				// A job targeting an environment has to pass the environment's protection rules again.
+				shouldBlockJob := shouldBlock || plan.hasRerunDependency(templateJob) || newJob.IsMatrixDeferred || newJob.Environment != ""
+				hasEnvironmentJobs = hasEnvironmentJobs || newJob.Environment != ""
 
 				newJob.Status = util.Iif(shouldBlockJob, actions_model.StatusBlocked, actions_model.StatusWaiting)
 				newJob.TaskID = 0
@@ -384,8 +387,9 @@ func execRerunPlan(ctx context.Context, plan *rerunPlan) (*actions_model.ActionR
 	NotifyWorkflowJobsAndRunsStatusUpdate(ctx, newJobsToRerun)
 
 	// Post-commit kick for expanded callers and restored matrix placeholders: let job_emitter
-	// resolve child jobs, and re-expand a placeholder whose needs may all be pass-through and done.
-	if hasWaitingCallerJobs || len(plan.matrixPlaceholderTemplateIDs) > 0 {
+	// resolve child jobs, re-expand a placeholder whose needs may all be pass-through and done,
+	// and apply the protection rules of the environments targeted by rerun jobs.
+	if hasWaitingCallerJobs || hasEnvironmentJobs || len(plan.matrixPlaceholderTemplateIDs) > 0 {
 		if err := EmitJobsIfReadyByRun(plan.run.ID); err != nil {
 			log.Error("emit run %d after rerun: %v", plan.run.ID, err)
 		}
@@ -543,6 +547,7 @@ func cloneRunJobForAttempt(templateJob *actions_model.ActionRunJob, attempt *act
 		Needs:                  slices.Clone(templateJob.Needs),
 		RunsOn:                 slices.Clone(templateJob.RunsOn),
 		ContinueOnError:        templateJob.ContinueOnError,
+		Environment:            templateJob.Environment,
 		IsMatrixDeferred:       templateJob.IsMatrixDeferred,
 		DeferredMatrixPayload:  slices.Clone(templateJob.DeferredMatrixPayload),
 		Status:                 templateJob.Status,
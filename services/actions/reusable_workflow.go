@@ -350,6 +350,7 @@ func insertCallerChildren(ctx context.Context, run *actions_model.ActionRun, att
 		}
 
 		parsedChild.Name = util.EllipsisDisplayString(parsedChild.Name, 255)
+		environment, _ := parsedChild.Environment()
 
 		// AttemptJobID: prefer a prior-attempt match and fall back to a fresh allocator value for newly-appearing logical jobs.
 		var attemptJobID int64
@@ -376,6 +377,7 @@ func insertCallerChildren(ctx context.Context, run *actions_model.ActionRun, att
 			Needs:                   needs,
 			RunsOn:                  parsedChild.RunsOn(),
 			ContinueOnError:         parsedChild.GetContinueOnError(),
+			Environment:             util.EllipsisDisplayString(environment, 255),
 			MaxParallel:             parseMaxParallel(jobID, parsedChild.Strategy.MaxParallelString),
 			Status:                  actions_model.StatusBlocked,
 			ParentJobID:             caller.ID,
@@ -193,7 +193,9 @@ func insertRunJob(ctx context.Context, run *actions_model.ActionRun, runAttempt
 	payload, _ := workflowJob.Marshal()
 
 	isReusableWorkflowCaller := job.Uses != ""
-	shouldBlockJob := runAttempt.Status == actions_model.StatusBlocked || len(needs) > 0 || run.NeedApproval
+	environment, _ := job.Environment()
+	// A job targeting an environment is left to the job emitter, which applies the environment's protection rules.
+	shouldBlockJob := runAttempt.Status == actions_model.StatusBlocked || len(needs) > 0 || run.NeedApproval || environment != ""
 
 	attemptJobID, err := actions_model.GetNextAttemptJobID(ctx, run.ID)
 	if err != nil {
@@ -219,6 +221,7 @@ func insertRunJob(ctx context.Context, run *actions_model.ActionRun, runAttempt
 		WorkflowSourceRepoID:    run.WorkflowRepoID,
 		WorkflowSourceCommitSHA: run.WorkflowCommitSHA,
 		ContinueOnError:         job.GetContinueOnError(),
+		Environment:             util.EllipsisDisplayString(environment, 255),
 		IsMatrixDeferred:        isMatrixDeferred,
 		MaxParallel:             parseMaxParallel(id, job.Strategy.MaxParallelString),
 	}
@@ -282,6 +285,10 @@ func insertRunJob(ctx context.Context, run *actions_model.ActionRun, runAttempt
 		//   - if the caller is skipped, propagate its state to its dependents
 		needPostCommitEmit = true
 	}
+	// A job blocked only by its environment needs a resolver pass to check the protection rules.
+	if runJob.Environment != "" && len(needs) == 0 {
+		needPostCommitEmit = true
+	}
 
 	return runJob, cancelledConcurrencyJobs, needPostCommitEmit, nil
 }
@@ -8,6 +8,7 @@ import (
 	"fmt"
 
 	asymkey_model "gitea.dev/models/asymkey"
+	audit_model "gitea.dev/models/audit"
 	"gitea.dev/models/db"
 	repo_model "gitea.dev/models/repo"
 )
@@ -49,6 +50,7 @@ func deleteDeployKeyFromDB(ctx context.Context, key *asymkey_model.DeployKey) er
 // DeleteDeployKey deletes deploy key from its repository authorized_keys file if needed.
 // Permissions check should be done outside.
 func DeleteDeployKey(ctx context.Context, repo *repo_model.Repository, id int64) error {
+	var deleted *asymkey_model.DeployKey
 	if err := db.WithTx(ctx, func(ctx context.Context) error {
 		key, err := asymkey_model.GetDeployKeyByID(ctx, repo.ID, id)
 		if err != nil {
@@ -57,10 +59,26 @@ func DeleteDeployKey(ctx context.Context, repo *repo_model.Repository, id int64)
 			}
 			return fmt.Errorf("GetDeployKeyByID: %w", err)
 		}
-		return deleteDeployKeyFromDB(ctx, key)
+		if err := deleteDeployKeyFromDB(ctx, key); err != nil {
+			return err
+		}
+		deleted = key
+		return nil
 	}); err != nil {
 		return err
 	}
 
+	if deleted != nil {
+		audit_model.Record(ctx, &audit_model.Event{
+			Action:     audit_model.ActionDeployKeyRemove,
+			OwnerID:    repo.OwnerID,
+			RepoID:     repo.ID,
+			TargetType: audit_model.TargetDeployKey,
+			TargetID:   deleted.ID,
+			TargetName: deleted.Name,
+			Metadata:   map[string]string{"fingerprint": deleted.Fingerprint},
+		})
+	}
+
 	return RewriteAllPublicKeys(ctx)
 }
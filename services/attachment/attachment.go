@@ -12,22 +12,33 @@ import (
 	"net/http"
 
 	"gitea.dev/models/db"
+	quota_model "gitea.dev/models/quota"
 	repo_model "gitea.dev/models/repo"
 	"gitea.dev/modules/setting"
 	"gitea.dev/modules/storage"
 	"gitea.dev/modules/util"
 	"gitea.dev/services/context/upload"
+	quota_service "gitea.dev/services/quota"
 
 	"github.com/google/uuid"
 )
 
 // NewAttachment creates a new attachment object, but do not verify.
+// The size is checked against the quota of the repository owner, a negative size means it is unknown.
 func NewAttachment(ctx context.Context, attach *repo_model.Attachment, file io.Reader, size int64) (*repo_model.Attachment, error) {
 	if attach.RepoID == 0 {
 		return nil, fmt.Errorf("attachment %s should belong to a repository", attach.Name)
 	}
 
-	err := db.WithTx(ctx, func(ctx context.Context) error {
+	repo, err := repo_model.GetRepositoryByID(ctx, attach.RepoID)
+	if err != nil {
+		return nil, err
+	}
+	if err := quota_service.CheckQuota(ctx, repo.OwnerID, quota_model.CategoryAttachments, max(size, 0)); err != nil {
+		return nil, err
+	}
+
+	err = db.WithTx(ctx, func(ctx context.Context) error {
 		attach.UUID = uuid.New().String()
 		size, err := storage.Attachments.Save(attach.RelativePath(), file, size)
 		if err != nil {
@@ -36,6 +47,9 @@ func NewAttachment(ctx context.Context, attach *repo_model.Attachment, file io.R
 		attach.Size = size
 		return db.Insert(ctx, attach)
 	})
+	if err == nil {
+		quota_service.UpdateUsage(ctx, repo.OwnerID, quota_model.CategoryAttachments)
+	}
 
 	return attach, err
 }
@@ -92,6 +106,22 @@ func uploadAttachment(ctx context.Context, file *UploaderFile, allowedTypes stri
 	return attach, err
 }
 
+// DeleteAttachment deletes an attachment with its file
+func DeleteAttachment(ctx context.Context, attach *repo_model.Attachment) error {
+	if err := repo_model.DeleteAttachment(ctx, attach, true); err != nil {
+		return err
+	}
+
+	if setting.Quota.Enabled {
+		repo, err := repo_model.GetRepositoryByID(ctx, attach.RepoID)
+		if err != nil {
+			return err
+		}
+		quota_service.UpdateUsage(ctx, repo.OwnerID, quota_model.CategoryAttachments)
+	}
+	return nil
+}
+
 // UpdateAttachment updates an attachment, verifying that its name is among the allowed types.
 func UpdateAttachment(ctx context.Context, allowedTypes string, attach *repo_model.Attachment) error {
 	if err := upload.Verify(nil, attach.Name, allowedTypes); err != nil {
@@ -18,6 +18,7 @@ import (
 	_ "gitea.dev/services/auth/source/db"   // register the sources (and below)
 	_ "gitea.dev/services/auth/source/ldap" // register the ldap source
 	_ "gitea.dev/services/auth/source/pam"  // register the pam source
+	_ "gitea.dev/services/auth/source/saml" // register the saml source
 	_ "gitea.dev/services/auth/source/sspi" // register the sspi source
 
 	"xorm.io/builder"
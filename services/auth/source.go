@@ -6,6 +6,7 @@ package auth
 import (
 	"context"
 
+	audit_model "gitea.dev/models/audit"
 	"gitea.dev/models/auth"
 	"gitea.dev/models/db"
 	user_model "gitea.dev/models/user"
@@ -37,6 +38,19 @@ func DeleteSource(ctx context.Context, source *auth.Source) error {
 		}
 	}
 
-	_, err = db.GetEngine(ctx).ID(source.ID).Delete(new(auth.Source))
-	return err
+	if err := auth.DeleteSCIMData(ctx, source.ID); err != nil {
+		return err
+	}
+
+	if _, err = db.GetEngine(ctx).ID(source.ID).Delete(new(auth.Source)); err != nil {
+		return err
+	}
+	audit_model.Record(ctx, &audit_model.Event{
+		Action:     audit_model.ActionAuthSourceDelete,
+		TargetType: audit_model.TargetAuthSource,
+		TargetID:   source.ID,
+		TargetName: source.Name,
+		Metadata:   map[string]string{"type": source.TypeName()},
+	})
+	return nil
 }
@@ -20,6 +20,7 @@ type sourceInterface interface {
 	auth_model.SkipVerifiable
 	auth_model.HasTLSer
 	auth_model.UseTLSer
+	auth_model.GroupTeamMapper
 }
 
 var _ (sourceInterface) = &ldap.Source{}
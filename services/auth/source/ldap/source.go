@@ -113,6 +113,11 @@ func (source *Source) ProvidesSSHKeys() bool {
 	return strings.TrimSpace(source.AttributeSSHPublicKey) != ""
 }
 
+// GetGroupTeamMap returns the mapping between the groups and the teams, and whether the memberships are removed
+func (source *Source) GetGroupTeamMap() (string, bool) {
+	return source.GroupTeamMap, source.GroupTeamMapRemoval
+}
+
 func init() {
 	auth.RegisterTypeConfig(auth.LDAP, &Source{})
 	auth.RegisterTypeConfig(auth.DLDAP, &Source{})
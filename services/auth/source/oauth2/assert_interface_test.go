@@ -15,6 +15,7 @@ import (
 type sourceInterface interface {
 	auth_model.Config
 	auth_model.RegisterableSource
+	auth_model.GroupTeamMapper
 	auth.PasswordAuthenticator
 }
 
@@ -43,6 +43,11 @@ func (source *Source) ToDB() ([]byte, error) {
 	return json.Marshal(source)
 }
 
+// GetGroupTeamMap returns the mapping between the groups and the teams, and whether the memberships are removed
+func (source *Source) GetGroupTeamMap() (string, bool) {
+	return source.GroupTeamMap, source.GroupTeamMapRemoval
+}
+
 func init() {
 	auth.RegisterTypeConfig(auth.OAuth2, &Source{})
 }
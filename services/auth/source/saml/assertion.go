@@ -0,0 +1,59 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package saml
+
+import (
+	"strings"
+
+	"gitea.dev/modules/container"
+	"gitea.dev/modules/saml"
+
+	"github.com/markbates/goth"
+)
+
+// ToGothUser converts the identity an assertion carries to the user the external account linking works with.
+// The NameID identifies the external account, the attributes are kept in the raw data for the group mapping.
+func (source *Source) ToGothUser(assertion *saml.Assertion) goth.User {
+	rawData := make(map[string]any, len(assertion.Attributes))
+	for name, values := range assertion.Attributes {
+		rawData[name] = values
+	}
+
+	email := assertion.Attribute(source.AttributeEmail)
+	if email == "" && assertion.NameIDFormat == saml.NameIDFormatEmailAddress {
+		email = assertion.NameID
+	}
+	userName := assertion.NameID
+	if source.AttributeUsername != "" {
+		userName = assertion.Attribute(source.AttributeUsername)
+	}
+
+	return goth.User{
+		Provider:  source.AuthSource.Name,
+		UserID:    assertion.NameID,
+		Email:     email,
+		NickName:  userName,
+		Name:      assertion.Attribute(source.AttributeFullName),
+		RawData:   rawData,
+		ExpiresAt: assertion.ExpiresAt,
+	}
+}
+
+// Groups returns the groups of the user, the values of the groups attribute
+func (source *Source) Groups(gothUser *goth.User) container.Set[string] {
+	if source.AttributeGroups == "" {
+		return nil
+	}
+	values, _ := gothUser.RawData[source.AttributeGroups].([]string)
+	groups := make(container.Set[string], len(values))
+	for _, value := range values {
+		// some identity providers send all the groups in a single comma separated value
+		for group := range strings.SplitSeq(value, ",") {
+			if group = strings.TrimSpace(group); group != "" {
+				groups.Add(group)
+			}
+		}
+	}
+	return groups
+}
@@ -0,0 +1,52 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package saml
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"html/template"
+	"sort"
+
+	"gitea.dev/models/auth"
+	"gitea.dev/models/db"
+	"gitea.dev/modules/optional"
+	"gitea.dev/modules/svg"
+)
+
+// Provider is a SAML source as the sign-in page shows it
+type Provider struct {
+	Name    string
+	iconURL string
+}
+
+// IconHTML returns icon HTML for this provider
+func (p *Provider) IconHTML(size int) template.HTML {
+	if p.iconURL != "" {
+		return template.HTML(fmt.Sprintf(`<img class="tw-object-contain" width="%d" height="%d" src="%s" alt="%s">`,
+			size, size, html.EscapeString(p.iconURL), html.EscapeString(p.Name)))
+	}
+	return svg.RenderHTML("gitea-lock", size)
+}
+
+// GetActiveSAMLProviders returns the active SAML sources the users can sign in with
+func GetActiveSAMLProviders(ctx context.Context) ([]*Provider, error) {
+	authSources, err := db.Find[auth.Source](ctx, auth.FindSourcesOptions{
+		IsActive:  optional.Some(true),
+		LoginType: auth.SAML,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	providers := make([]*Provider, 0, len(authSources))
+	for _, source := range authSources {
+		providers = append(providers, &Provider{Name: source.Name, iconURL: auth.MustSourceCfg[*Source](source).IconURL})
+	}
+	sort.Slice(providers, func(i, j int) bool {
+		return providers[i].Name < providers[j].Name
+	})
+	return providers, nil
+}
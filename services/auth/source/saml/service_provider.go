@@ -0,0 +1,136 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package saml
+
+import (
+	"context"
+	"crypto"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	auth_module "gitea.dev/modules/auth"
+	"gitea.dev/modules/proxy"
+	"gitea.dev/modules/saml"
+	"gitea.dev/modules/setting"
+)
+
+// EndpointURL returns the URL of an endpoint of the service provider of the source: "metadata", "acs" or "slo"
+func EndpointURL(authName, endpoint string) string {
+	return setting.AppURL + "user/saml/" + url.PathEscape(authName) + "/" + endpoint
+}
+
+// ServiceProvider returns the SAML service provider of the source, its entity ID is the URL of its metadata
+func (source *Source) ServiceProvider() (*saml.ServiceProvider, error) {
+	return source.serviceProvider(source.AuthSource.Name)
+}
+
+func (source *Source) serviceProvider(name string) (*saml.ServiceProvider, error) {
+	idp, err := saml.ParseIdentityProviderMetadata([]byte(source.IdentityProviderMetadata))
+	if err != nil {
+		return nil, fmt.Errorf("invalid identity provider metadata: %w", err)
+	}
+	sp := &saml.ServiceProvider{
+		EntityID:                    EndpointURL(name, "metadata"),
+		AssertionConsumerServiceURL: EndpointURL(name, "acs"),
+		SingleLogoutServiceURL:      EndpointURL(name, "slo"),
+		NameIDFormat:                source.NameIDFormat,
+		AllowIdPInitiated:           source.AllowIdPInitiated,
+		IdentityProvider:            idp,
+	}
+	if source.ServiceProviderPrivateKey != "" {
+		if sp.Key, err = parsePrivateKey(source.ServiceProviderPrivateKey); err != nil {
+			return nil, fmt.Errorf("invalid service provider private key: %w", err)
+		}
+		if sp.Certificate, err = parseCertificate(source.ServiceProviderCertificate); err != nil {
+			return nil, fmt.Errorf("invalid service provider certificate: %w", err)
+		}
+	}
+	return sp, nil
+}
+
+// Validate checks the configuration can be used
+func (source *Source) Validate() error {
+	if source.IdentityProviderMetadata == "" {
+		return errors.New("the identity provider metadata is required")
+	}
+	if (source.ServiceProviderPrivateKey == "") != (source.ServiceProviderCertificate == "") {
+		return errors.New("the service provider certificate and private key must be set together")
+	}
+	if _, err := source.serviceProvider(""); err != nil {
+		return err
+	}
+	if _, err := auth_module.UnmarshalGroupTeamMapping(source.GroupTeamMap); err != nil {
+		return fmt.Errorf("invalid group team map: %w", err)
+	}
+	return nil
+}
+
+func parsePrivateKey(data string) (crypto.Signer, error) {
+	block, _ := pem.Decode([]byte(data))
+	if block == nil {
+		return nil, errors.New("no PEM block found")
+	}
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		return x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		return x509.ParseECPrivateKey(block.Bytes)
+	case "PRIVATE KEY":
+		key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		if signer, ok := key.(crypto.Signer); ok {
+			return signer, nil
+		}
+		return nil, fmt.Errorf("unsupported key type %T", key)
+	}
+	return nil, fmt.Errorf("unsupported PEM block %q", block.Type)
+}
+
+func parseCertificate(data string) (*x509.Certificate, error) {
+	block, _ := pem.Decode([]byte(data))
+	if block == nil || block.Type != "CERTIFICATE" {
+		return nil, errors.New("no certificate PEM block found")
+	}
+	return x509.ParseCertificate(block.Bytes)
+}
+
+// FetchIdentityProviderMetadata downloads the metadata the identity provider publishes
+func FetchIdentityProviderMetadata(ctx context.Context, metadataURL string) (string, error) {
+	u, err := url.Parse(metadataURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return "", fmt.Errorf("invalid metadata URL %q", metadataURL)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, metadataURL, nil)
+	if err != nil {
+		return "", err
+	}
+	client := &http.Client{
+		Timeout:   30 * time.Second,
+		Transport: &http.Transport{Proxy: proxy.Proxy()},
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("unable to fetch the metadata: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("unable to fetch the metadata: %s", resp.Status)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 10*1024*1024))
+	if err != nil {
+		return "", fmt.Errorf("unable to fetch the metadata: %w", err)
+	}
+	if _, err := saml.ParseIdentityProviderMetadata(data); err != nil {
+		return "", fmt.Errorf("invalid identity provider metadata: %w", err)
+	}
+	return string(data), nil
+}
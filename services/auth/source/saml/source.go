@@ -0,0 +1,57 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package saml
+
+import (
+	"gitea.dev/models/auth"
+	"gitea.dev/modules/json"
+)
+
+// Source holds configuration for the SAML login source, Gitea being the service provider.
+type Source struct {
+	auth.ConfigBase `json:"-"`
+
+	// IdentityProviderMetadata is the XML metadata of the identity provider, fetched from
+	// IdentityProviderMetadataURL when the source is saved if the URL is set
+	IdentityProviderMetadata    string
+	IdentityProviderMetadataURL string
+
+	// ServiceProviderCertificate and ServiceProviderPrivateKey are PEM encoded, the authentication and
+	// logout requests are signed when they are set
+	ServiceProviderCertificate string
+	ServiceProviderPrivateKey  string
+
+	NameIDFormat      string
+	AllowIdPInitiated bool
+	AutoRegister      bool
+	IconURL           string
+
+	AttributeUsername   string
+	AttributeEmail      string
+	AttributeFullName   string
+	AttributeGroups     string
+	AdminGroup          string
+	RestrictedGroup     string
+	GroupTeamMap        string
+	GroupTeamMapRemoval bool
+}
+
+// FromDB fills up a SAML Source from serialized format.
+func (source *Source) FromDB(bs []byte) error {
+	return json.UnmarshalHandleDoubleEncode(bs, &source)
+}
+
+// ToDB exports a SAML Source to a serialized format.
+func (source *Source) ToDB() ([]byte, error) {
+	return json.Marshal(source)
+}
+
+// GetGroupTeamMap returns the mapping between the groups and the teams, and whether the memberships are removed
+func (source *Source) GetGroupTeamMap() (string, bool) {
+	return source.GroupTeamMap, source.GroupTeamMapRemoval
+}
+
+func init() {
+	auth.RegisterTypeConfig(auth.SAML, &Source{})
+}
@@ -0,0 +1,16 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package saml
+
+import (
+	"context"
+
+	user_model "gitea.dev/models/user"
+	"gitea.dev/services/auth/source/db"
+)
+
+// Authenticate falls back to the db authenticator, the users of a SAML source may have set a local password
+func (source *Source) Authenticate(ctx context.Context, user *user_model.User, login, password string) (*user_model.User, error) {
+	return db.Authenticate(ctx, user, login, password)
+}
@@ -0,0 +1,92 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package saml
+
+import (
+	"testing"
+
+	"gitea.dev/models/auth"
+	"gitea.dev/modules/container"
+	"gitea.dev/modules/saml"
+	"gitea.dev/modules/saml/samltest"
+	"gitea.dev/modules/setting"
+	"gitea.dev/modules/test"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServiceProvider(t *testing.T) {
+	defer test.MockVariableValue(&setting.AppURL, "https://gitea.example.com/")()
+
+	idp, err := samltest.NewIdentityProvider("https://idp.example.com")
+	require.NoError(t, err)
+	source := &Source{
+		ConfigBase:               auth.ConfigBase{AuthSource: &auth.Source{Type: auth.SAML, Name: "corp idp"}},
+		IdentityProviderMetadata: string(idp.Metadata()),
+		AllowIdPInitiated:        true,
+	}
+	require.NoError(t, source.Validate())
+
+	sp, err := source.ServiceProvider()
+	require.NoError(t, err)
+	assert.Equal(t, "https://gitea.example.com/user/saml/corp%20idp/metadata", sp.EntityID)
+	assert.Equal(t, "https://gitea.example.com/user/saml/corp%20idp/acs", sp.AssertionConsumerServiceURL)
+	assert.Equal(t, "https://gitea.example.com/user/saml/corp%20idp/slo", sp.SingleLogoutServiceURL)
+	assert.True(t, sp.AllowIdPInitiated)
+	assert.Nil(t, sp.Key)
+	assert.Equal(t, idp.SSOURL(), sp.IdentityProvider.SSOURL)
+
+	certPEM, keyPEM, err := samltest.NewServiceProviderKeyPair()
+	require.NoError(t, err)
+	source.ServiceProviderPrivateKey = keyPEM
+	assert.Error(t, source.Validate())
+	source.ServiceProviderCertificate = certPEM
+	require.NoError(t, source.Validate())
+	sp, err = source.ServiceProvider()
+	require.NoError(t, err)
+	assert.NotNil(t, sp.Key)
+	assert.NotNil(t, sp.Certificate)
+
+	source.GroupTeamMap = "{invalid"
+	assert.Error(t, source.Validate())
+
+	source.IdentityProviderMetadata = "<invalid/>"
+	assert.Error(t, source.Validate())
+}
+
+func TestToGothUser(t *testing.T) {
+	source := &Source{
+		ConfigBase:        auth.ConfigBase{AuthSource: &auth.Source{Type: auth.SAML, Name: "corp"}},
+		AttributeUsername: "uid",
+		AttributeEmail:    "mail",
+		AttributeFullName: "displayName",
+		AttributeGroups:   "memberOf",
+	}
+	assertion := &saml.Assertion{
+		NameID:       "u-1234",
+		NameIDFormat: saml.NameIDFormatPersistent,
+		Attributes: map[string][]string{
+			"uid":         {"alice"},
+			"mail":        {"alice@example.com"},
+			"displayName": {"Alice Liddell"},
+			"memberOf":    {"developers", "admins, ops"},
+		},
+	}
+
+	gothUser := source.ToGothUser(assertion)
+	assert.Equal(t, "corp", gothUser.Provider)
+	assert.Equal(t, "u-1234", gothUser.UserID)
+	assert.Equal(t, "alice", gothUser.NickName)
+	assert.Equal(t, "alice@example.com", gothUser.Email)
+	assert.Equal(t, "Alice Liddell", gothUser.Name)
+	assert.Equal(t, container.SetOf("developers", "admins", "ops"), source.Groups(&gothUser))
+
+	source.AttributeUsername, source.AttributeEmail, source.AttributeGroups = "", "", ""
+	assertion.NameID, assertion.NameIDFormat = "bob@example.com", saml.NameIDFormatEmailAddress
+	gothUser = source.ToGothUser(assertion)
+	assert.Equal(t, "bob@example.com", gothUser.NickName)
+	assert.Equal(t, "bob@example.com", gothUser.Email)
+	assert.Nil(t, source.Groups(&gothUser))
+}
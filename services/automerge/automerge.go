@@ -255,6 +255,10 @@ func handlePullRequestAutoMerge(pullID int64, sha string) {
 			log.Info("%-v was scheduled to automerge by an unauthorized user", pr)
 			return
 		}
+		if errors.Is(err, pull_service.ErrRulesetViolated) {
+			log.Info("%-v was scheduled to automerge but does not satisfy the rulesets of the base branch: %v", pr, err)
+			return
+		}
 		log.Error("%-v CheckPullMergeable: %v", pr, err)
 		return
 	}
@@ -0,0 +1,271 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package codenav
+
+import (
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	actions_model "gitea.dev/models/actions"
+	codenav_model "gitea.dev/models/codenav"
+	"gitea.dev/models/db"
+	repo_model "gitea.dev/models/repo"
+	"gitea.dev/modules/analyze"
+	"gitea.dev/modules/charset"
+	"gitea.dev/modules/codenav"
+	"gitea.dev/modules/ctags"
+	"gitea.dev/modules/git"
+	"gitea.dev/modules/graceful"
+	"gitea.dev/modules/log"
+	"gitea.dev/modules/process"
+	"gitea.dev/modules/queue"
+	"gitea.dev/modules/setting"
+	"gitea.dev/modules/storage"
+	"gitea.dev/modules/typesniffer"
+	"gitea.dev/modules/util"
+)
+
+// maxKeptIndexes is the number of indexes kept per repository, the indexes of the older commits are deleted
+const maxKeptIndexes = 50
+
+// indexRequest asks for the artifact to be imported, or for the commit to be indexed with ctags when there is no artifact
+type indexRequest struct {
+	RepoID     int64
+	CommitSHA  string
+	ArtifactID int64
+}
+
+var indexQueue *queue.WorkerPoolQueue[*indexRequest]
+
+// Init runs the queue importing the artifacts and indexing the commits with ctags
+func Init() error {
+	if !setting.CodeNavigation.Enabled {
+		return nil
+	}
+	indexQueue = queue.CreateUniqueQueue(graceful.GetManager().ShutdownContext(), "code_navigation", handler)
+	if indexQueue == nil {
+		return errors.New("unable to create code_navigation queue")
+	}
+	go graceful.GetManager().RunWithCancel(indexQueue)
+	return nil
+}
+
+func handler(items ...*indexRequest) []*indexRequest {
+	ctx := graceful.GetManager().ShutdownContext()
+	for _, req := range items {
+		var err error
+		if req.ArtifactID != 0 {
+			err = importArtifact(ctx, req.ArtifactID)
+		} else {
+			err = indexWithCtags(ctx, req.RepoID, req.CommitSHA)
+		}
+		if err != nil {
+			log.Error("Code navigation indexing of commit %s of repository %d failed: %v", req.CommitSHA, req.RepoID, err)
+		}
+	}
+	return nil
+}
+
+func push(req *indexRequest) {
+	if err := indexQueue.Push(req); err != nil && !errors.Is(err, queue.ErrAlreadyInQueue) {
+		log.Error("Unable to add commit %s of repository %d to the code navigation queue: %v", req.CommitSHA, req.RepoID, err)
+	}
+}
+
+// AddArtifactToQueue schedules the import of an uploaded Actions artifact, only the artifacts named like the code navigation
+// artifacts are imported
+func AddArtifactToQueue(artifact *actions_model.ActionArtifact) {
+	if indexQueue == nil || artifact.ArtifactName != setting.CodeNavigation.ArtifactName || artifact.CommitSHA == "" {
+		return
+	}
+	push(&indexRequest{RepoID: artifact.RepoID, CommitSHA: artifact.CommitSHA, ArtifactID: artifact.ID})
+}
+
+// AddCommitToQueue schedules the indexing of a commit with ctags if it has no index yet
+func AddCommitToQueue(ctx context.Context, repo *repo_model.Repository, commitSHA string) {
+	if indexQueue == nil || setting.CodeNavigation.CtagsPath == "" {
+		return
+	}
+	if has, err := codenav_model.ExistIndex(ctx, repo.ID, commitSHA); err != nil {
+		log.Error("ExistIndex: %v", err)
+		return
+	} else if has {
+		return
+	}
+	push(&indexRequest{RepoID: repo.ID, CommitSHA: commitSHA})
+}
+
+// ImportIndex stores the SCIP or LSIF index of a commit, it replaces the previous index of the commit
+func ImportIndex(ctx context.Context, repo *repo_model.Repository, commitSHA string, format codenav.Format, r io.Reader) error {
+	return importIndex(ctx, repo, commitSHA, format, r, 0)
+}
+
+func importIndex(ctx context.Context, repo *repo_model.Repository, commitSHA string, format codenav.Format, r io.Reader, artifactID int64) error {
+	if !format.IsUploadable() {
+		return util.NewInvalidArgumentErrorf("unsupported index format %q", format)
+	}
+	gitRepo, err := git.OpenRepository(ctx, repo)
+	if err != nil {
+		return err
+	}
+	defer gitRepo.Close()
+	commit, err := gitRepo.GetCommit(ctx, commitSHA)
+	if err != nil {
+		if git.IsErrNotExist(err) {
+			return util.NewInvalidArgumentErrorf("commit %s doesn't exist", commitSHA)
+		}
+		return err
+	}
+
+	// the index is read at once, the size is limited because it is decoded in memory
+	content, err := io.ReadAll(io.LimitReader(r, setting.CodeNavigation.MaxIndexSize+1))
+	if err != nil {
+		return err
+	}
+	if int64(len(content)) > setting.CodeNavigation.MaxIndexSize {
+		return util.NewInvalidArgumentErrorf("the index is larger than %d bytes", setting.CodeNavigation.MaxIndexSize)
+	}
+	parsed, err := codenav.Parse(format, bytes.NewReader(content))
+	if err != nil {
+		return util.NewInvalidArgumentErrorf("invalid %s index: %v", format, err)
+	}
+
+	index := &codenav_model.Index{RepoID: repo.ID, CommitSHA: commit.ID.String(), ArtifactID: artifactID}
+	if err := codenav_model.SaveIndex(ctx, index, parsed, maxKeptIndexes); err != nil {
+		return err
+	}
+	log.Debug("Imported the %s index of commit %s of %s with %d occurrences", format, index.CommitSHA, repo.FullName(), index.Occurrences)
+	return nil
+}
+
+func importArtifact(ctx context.Context, artifactID int64) error {
+	artifact, has, err := db.GetByID[actions_model.ActionArtifact](ctx, artifactID)
+	if err != nil || !has || artifact.Status != actions_model.ArtifactStatusUploadConfirmed {
+		return err
+	}
+	repo, err := repo_model.GetRepositoryByID(ctx, artifact.RepoID)
+	if errors.Is(err, util.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	obj, err := storage.ActionsArtifacts.Open(artifact.StoragePath)
+	if err != nil {
+		return err
+	}
+	defer obj.Close()
+
+	// a v4 artifact of several files is a zip archive, the other artifacts are single files which the v3 backend can gzip
+	var r io.Reader = obj
+	filename := artifact.ArtifactPath
+	switch {
+	case artifact.ContentEncodingOrType == actions_model.ContentTypeZip:
+		content, err := io.ReadAll(io.LimitReader(obj, setting.CodeNavigation.MaxIndexSize+1))
+		if err != nil {
+			return err
+		}
+		archive, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+		if err != nil {
+			return fmt.Errorf("unable to read the artifact %d: %w", artifact.ID, err)
+		}
+		var file *zip.File
+		for _, f := range archive.File {
+			if _, ok := codenav.FormatFromFilename(f.Name); ok {
+				file = f
+				break
+			}
+		}
+		if file == nil {
+			log.Warn("The code navigation artifact %d of %s has no .scip or .lsif file", artifact.ID, repo.FullName())
+			return nil
+		}
+		rc, err := file.Open()
+		if err != nil {
+			return err
+		}
+		defer rc.Close()
+		r, filename = rc, file.Name
+	case artifact.ContentEncodingOrType == actions_model.ContentEncodingV3Gzip:
+		gzr, err := gzip.NewReader(obj)
+		if err != nil {
+			return err
+		}
+		defer gzr.Close()
+		r = gzr
+	}
+
+	format, ok := codenav.FormatFromFilename(filename)
+	if !ok {
+		return nil // another file of a v3 artifact
+	}
+	return importIndex(ctx, repo, artifact.CommitSHA, format, r, artifact.ID)
+}
+
+// indexWithCtags indexes the definitions of a commit found by ctags, an uploaded index is never replaced
+func indexWithCtags(ctx context.Context, repoID int64, commitSHA string) error {
+	if has, err := codenav_model.ExistIndex(ctx, repoID, commitSHA); err != nil || has {
+		return err
+	}
+	repo, err := repo_model.GetRepositoryByID(ctx, repoID)
+	if errors.Is(err, util.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	ctx, _, finished := process.GetManager().AddContext(ctx, fmt.Sprintf("Code navigation indexing of %s at %s", repo.FullName(), commitSHA))
+	defer finished()
+
+	gitRepo, err := git.OpenRepository(ctx, repo)
+	if err != nil {
+		return err
+	}
+	defer gitRepo.Close()
+	commit, err := gitRepo.GetCommit(ctx, commitSHA)
+	if err != nil {
+		return err
+	}
+	entries, err := commit.Tree().ListEntriesRecursiveWithSize(ctx, gitRepo)
+	if err != nil {
+		return err
+	}
+
+	parser, err := ctags.NewParser(ctx, setting.CodeNavigation.CtagsPath)
+	if err != nil {
+		return err
+	}
+	defer parser.Close()
+
+	parsed := codenav.NewCtagsIndex()
+	for _, entry := range entries {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		filename := entry.Name()
+		if !entry.IsRegular() && !entry.IsExecutable() || analyze.IsVendor(filename) ||
+			entry.GetSize(ctx, gitRepo) > setting.CodeNavigation.MaxFileSize {
+			continue
+		}
+		content, err := entry.Blob(gitRepo).GetBlobBytes(ctx, setting.CodeNavigation.MaxFileSize)
+		if err != nil {
+			return err
+		}
+		if !typesniffer.DetectContentType(content).IsText() {
+			continue
+		}
+		content = charset.ToUTF8DropErrors(content)
+		tags, err := parser.Parse(filename, content)
+		if err != nil {
+			return err
+		}
+		parsed.AddCtagsEntries(filename, content, tags)
+	}
+	return codenav_model.SaveIndex(ctx, &codenav_model.Index{RepoID: repo.ID, CommitSHA: commit.ID.String()}, parsed, maxKeptIndexes)
+}
@@ -0,0 +1,158 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package codenav
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	codenav_model "gitea.dev/models/codenav"
+	"gitea.dev/models/db"
+	repo_model "gitea.dev/models/repo"
+	"gitea.dev/modules/codenav"
+	"gitea.dev/modules/git"
+	"gitea.dev/modules/optional"
+	"gitea.dev/modules/setting"
+	"gitea.dev/modules/util"
+)
+
+// Location is an occurrence of a symbol in a file of the commit
+type Location struct {
+	Path string
+	codenav.Range
+}
+
+// Navigation describes the symbol at a position of a file
+type Navigation struct {
+	Format codenav.Format
+	Symbol string
+	// Range is the occurrence of the symbol at the position
+	Range         codenav.Range
+	Documentation string
+	Definitions   []*Location
+	References    []*Location
+}
+
+// Navigate returns the documentation, the definitions and the references of the symbol at a position of a file, the
+// position starts at 0 like in the LSP. The ctags indexes only know the definitions, the symbol at the position is the
+// identifier there and its references are the occurrences of its name.
+func Navigate(ctx context.Context, repo *repo_model.Repository, gitRepo *git.Repository, commitSHA, path string, line, character int) (*Navigation, error) {
+	index, err := codenav_model.GetIndex(ctx, repo.ID, commitSHA)
+	if err != nil {
+		return nil, err
+	}
+	if index.Format == codenav.FormatCtags {
+		return navigateCtags(ctx, index, gitRepo, path, line, character)
+	}
+
+	occurrence, has, err := codenav_model.GetOccurrenceAt(ctx, index, path, line, character)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, fmt.Errorf("no symbol at %s:%d:%d: %w", path, line, character, util.ErrNotExist)
+	}
+	symbol, err := codenav_model.GetSymbol(ctx, index, occurrence.SymbolKey)
+	if err != nil {
+		return nil, err
+	}
+	nav := &Navigation{Format: index.Format, Symbol: symbol.Symbol, Range: occurrence.Range(), Documentation: symbol.Documentation}
+	if nav.Definitions, err = findLocations(ctx, index, occurrence.SymbolKey, optional.Some(true)); err != nil {
+		return nil, err
+	}
+	if nav.References, err = findLocations(ctx, index, occurrence.SymbolKey, optional.None[bool]()); err != nil {
+		return nil, err
+	}
+	return nav, nil
+}
+
+func findLocations(ctx context.Context, index *codenav_model.Index, symbolKey string, isDefinition optional.Option[bool]) ([]*Location, error) {
+	occurrences, err := db.Find[codenav_model.Occurrence](ctx, codenav_model.FindOccurrencesOptions{
+		ListOptions:  db.ListOptions{Page: 1, PageSize: setting.CodeNavigation.MaxResults},
+		IndexID:      index.ID,
+		SymbolKey:    symbolKey,
+		IsDefinition: isDefinition,
+	})
+	if err != nil {
+		return nil, err
+	}
+	locations := make([]*Location, 0, len(occurrences))
+	for _, occurrence := range occurrences {
+		locations = append(locations, &Location{Path: occurrence.Path, Range: occurrence.Range()})
+	}
+	return locations, nil
+}
+
+func navigateCtags(ctx context.Context, index *codenav_model.Index, gitRepo *git.Repository, path string, line, character int) (*Navigation, error) {
+	commit, err := gitRepo.GetCommit(ctx, index.CommitSHA)
+	if err != nil {
+		return nil, err
+	}
+	blob, err := commit.GetBlobByPath(ctx, gitRepo, path)
+	if err != nil {
+		return nil, err
+	}
+	content, err := blob.GetBlobBytes(ctx, setting.CodeNavigation.MaxFileSize)
+	if err != nil {
+		return nil, err
+	}
+	name, rng, ok := codenav.IdentifierAt(content, line, character)
+	if !ok {
+		return nil, fmt.Errorf("no symbol at %s:%d:%d: %w", path, line, character, util.ErrNotExist)
+	}
+
+	symbolKey := codenav_model.SymbolKey(codenav.CtagsSymbol(name))
+	nav := &Navigation{Format: index.Format, Symbol: name, Range: rng}
+	if nav.Definitions, err = findLocations(ctx, index, symbolKey, optional.Some(true)); err != nil {
+		return nil, err
+	}
+	if len(nav.Definitions) == 0 {
+		// only the symbols defined in the repository can be navigated
+		return nil, fmt.Errorf("no definition of %s: %w", name, util.ErrNotExist)
+	}
+	if symbol, err := codenav_model.GetSymbol(ctx, index, symbolKey); err == nil {
+		nav.Documentation = symbol.Documentation
+	}
+	if nav.References, err = grepReferences(ctx, gitRepo, index.CommitSHA, name); err != nil {
+		return nil, err
+	}
+	return nav, nil
+}
+
+// grepReferences finds the occurrences of a name as a whole word in the files of a commit
+func grepReferences(ctx context.Context, gitRepo *git.Repository, commitSHA, name string) ([]*Location, error) {
+	results, err := git.GrepSearch(ctx, gitRepo, name, git.GrepOptions{
+		RefName:        commitSHA,
+		MaxResultLimit: setting.CodeNavigation.MaxResults,
+		GrepMode:       git.GrepModeExact,
+	})
+	if err != nil {
+		return nil, err
+	}
+	var locations []*Location
+	for _, result := range results {
+		for i, code := range result.LineCodes {
+			for start := 0; ; {
+				j := strings.Index(code[start:], name)
+				if j < 0 {
+					break
+				}
+				j += start
+				start = j + len(name)
+				if codenav.IsWordAt(code, j, len(name)) {
+					char := codenav.UTF16Len(code[:j])
+					line := result.LineNumbers[i] - 1
+					locations = append(locations, &Location{
+						Path:  result.Filename,
+						Range: codenav.Range{Line: line, StartChar: char, EndLine: line, EndChar: char + codenav.UTF16Len(name)},
+					})
+					if len(locations) == setting.CodeNavigation.MaxResults {
+						return locations, nil
+					}
+				}
+			}
+		}
+	}
+	return locations, nil
+}
@@ -18,11 +18,12 @@ import (
 	"gitea.dev/modules/templates"
 )
 
-// Package contains owner, access mode and optional the package descriptor
+// Package contains owner, access mode, optional the package descriptor and the remote of the package type
 type Package struct {
 	Owner      *user_model.User
 	AccessMode perm.AccessMode
 	Descriptor *packages_model.PackageDescriptor
+	Remote     *packages_model.PackageRemote
 }
 
 type packageAssignmentCtx struct {
@@ -0,0 +1,107 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package convert
+
+import (
+	"context"
+
+	actions_model "gitea.dev/models/actions"
+	"gitea.dev/models/organization"
+	user_model "gitea.dev/models/user"
+	api "gitea.dev/modules/structs"
+	"gitea.dev/modules/util"
+)
+
+// ToActionEnvironment converts an actions_model.ActionEnvironment to an api.ActionEnvironment
+func ToActionEnvironment(ctx context.Context, env *actions_model.ActionEnvironment, doer *user_model.User) (*api.ActionEnvironment, error) {
+	reviewers, reviewerTeams, err := toEnvironmentReviewers(ctx, env, doer)
+	if err != nil {
+		return nil, err
+	}
+	return &api.ActionEnvironment{
+		ID:                     env.ID,
+		Name:                   env.Name,
+		ExternalURL:            env.ExternalURL,
+		WaitTimer:              env.WaitTimer,
+		Reviewers:              reviewers,
+		ReviewerTeams:          reviewerTeams,
+		PreventSelfReview:      env.PreventSelfReview,
+		DeploymentBranchPolicy: env.BranchPolicy.String(),
+		BranchPatterns:         env.BranchPatterns,
+		Created:                env.CreatedUnix.AsTime(),
+		Updated:                env.UpdatedUnix.AsTime(),
+	}, nil
+}
+
+func toEnvironmentReviewers(ctx context.Context, env *actions_model.ActionEnvironment, doer *user_model.User) ([]*api.User, []*api.Team, error) {
+	users, err := user_model.GetUsersByIDs(ctx, env.ReviewerIDs)
+	if err != nil {
+		return nil, nil, err
+	}
+	apiUsers := make([]*api.User, 0, len(users))
+	for _, u := range users {
+		apiUsers = append(apiUsers, ToUser(ctx, u, doer))
+	}
+
+	teamMap, err := organization.GetTeamsByIDs(ctx, env.ReviewerTeamIDs)
+	if err != nil {
+		return nil, nil, err
+	}
+	teams := make([]*organization.Team, 0, len(teamMap))
+	for _, id := range env.ReviewerTeamIDs {
+		if team, ok := teamMap[id]; ok {
+			teams = append(teams, team)
+		}
+	}
+	apiTeams, err := ToTeams(ctx, teams, false)
+	if err != nil {
+		return nil, nil, err
+	}
+	return apiUsers, apiTeams, nil
+}
+
+// ToActionDeployment converts an actions_model.ActionDeployment with its loaded attributes to an api.ActionDeployment
+func ToActionDeployment(ctx context.Context, d *actions_model.ActionDeployment, doer *user_model.User) *api.ActionDeployment {
+	deployment := &api.ActionDeployment{
+		ID:        d.ID,
+		RunID:     d.RunID,
+		JobID:     d.JobID,
+		Ref:       d.Ref,
+		CommitSHA: d.CommitSHA,
+		URL:       d.URL,
+		Status:    d.Status.String(),
+		Created:   d.CreatedUnix.AsTime(),
+		Updated:   d.UpdatedUnix.AsTime(),
+	}
+	if d.Environment != nil {
+		deployment.Environment = d.Environment.Name
+	}
+	if d.Job != nil {
+		deployment.JobName = d.Job.Name
+		action, conclusion := ToActionsStatus(d.Job.Status)
+		deployment.JobStatus = util.Iif(conclusion != "", conclusion, action)
+	}
+	if d.TriggerUser != nil {
+		deployment.Creator = ToUser(ctx, d.TriggerUser, doer)
+	}
+	return deployment
+}
+
+// ToPendingDeployment converts a waiting actions_model.ActionDeployment to an api.PendingDeployment
+func ToPendingDeployment(ctx context.Context, d *actions_model.ActionDeployment, doer *user_model.User, canApprove bool) (*api.PendingDeployment, error) {
+	if err := d.LoadAttributes(ctx); err != nil {
+		return nil, err
+	}
+	reviewers, reviewerTeams, err := toEnvironmentReviewers(ctx, d.Environment, doer)
+	if err != nil {
+		return nil, err
+	}
+	return &api.PendingDeployment{
+		Deployment:            ToActionDeployment(ctx, d, doer),
+		WaitTimer:             d.Environment.WaitTimer,
+		CurrentUserCanApprove: canApprove,
+		Reviewers:             reviewers,
+		ReviewerTeams:         reviewerTeams,
+	}, nil
+}
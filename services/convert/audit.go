@@ -0,0 +1,43 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package convert
+
+import (
+	audit_model "gitea.dev/models/audit"
+	api "gitea.dev/modules/structs"
+)
+
+// ToAuditEvent converts an audit_model.Event to an api.AuditEvent, the address of the actor is only kept when showIP is set
+func ToAuditEvent(e *audit_model.Event, showIP bool) *api.AuditEvent {
+	res := &api.AuditEvent{
+		ID:         e.ID,
+		Action:     string(e.Action),
+		ActorID:    e.DoerID,
+		ActorName:  e.DoerName,
+		OwnerID:    e.OwnerID,
+		RepoID:     e.RepoID,
+		TargetType: e.TargetType,
+		TargetID:   e.TargetID,
+		TargetName: e.TargetName,
+		Metadata:   e.Metadata,
+		Created:    e.CreatedUnix.AsTime(),
+	}
+	if showIP {
+		res.IPAddress = e.IPAddress
+	}
+	return res
+}
+
+// ToAuditLogVerification converts an audit_model.Verification to an api.AuditLogVerification
+func ToAuditLogVerification(v *audit_model.Verification) *api.AuditLogVerification {
+	res := &api.AuditLogVerification{
+		Valid:    v.FirstInvalid == nil,
+		Verified: v.Verified,
+		Unsealed: v.Unsealed,
+	}
+	if v.FirstInvalid != nil {
+		res.FirstInvalid = ToAuditEvent(v.FirstInvalid, true)
+	}
+	return res
+}
@@ -0,0 +1,56 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package convert
+
+import (
+	"context"
+	"fmt"
+
+	codenav_model "gitea.dev/models/codenav"
+	repo_model "gitea.dev/models/repo"
+	"gitea.dev/modules/codenav"
+	api "gitea.dev/modules/structs"
+	"gitea.dev/modules/util"
+	codenav_service "gitea.dev/services/codenav"
+)
+
+// ToCodeNavIndex converts a code navigation index to its API format
+func ToCodeNavIndex(index *codenav_model.Index) *api.CodeNavIndex {
+	return &api.CodeNavIndex{
+		CommitSHA:   index.CommitSHA,
+		Format:      string(index.Format),
+		Occurrences: index.Occurrences,
+		ArtifactID:  index.ArtifactID,
+		Created:     index.CreatedUnix.AsTime(),
+	}
+}
+
+func toCodeNavRange(rng codenav.Range) api.CodeNavRange {
+	return api.CodeNavRange{
+		Start: api.CodeNavPosition{Line: rng.Line, Character: rng.StartChar},
+		End:   api.CodeNavPosition{Line: rng.EndLine, Character: rng.EndChar},
+	}
+}
+
+// ToCodeNavSymbol converts the navigation of a symbol in a commit to its API format
+func ToCodeNavSymbol(ctx context.Context, repo *repo_model.Repository, commitSHA string, nav *codenav_service.Navigation) *api.CodeNavSymbol {
+	toLocations := func(locations []*codenav_service.Location) []*api.CodeNavLocation {
+		result := make([]*api.CodeNavLocation, 0, len(locations))
+		for _, location := range locations {
+			result = append(result, &api.CodeNavLocation{
+				Path:    location.Path,
+				Range:   toCodeNavRange(location.Range),
+				HTMLURL: fmt.Sprintf("%s/src/commit/%s/%s#L%d", repo.HTMLURL(ctx), commitSHA, util.PathEscapeSegments(location.Path), location.Line+1),
+			})
+		}
+		return result
+	}
+	return &api.CodeNavSymbol{
+		Symbol:        nav.Symbol,
+		Range:         toCodeNavRange(nav.Range),
+		Documentation: nav.Documentation,
+		Definitions:   toLocations(nav.Definitions),
+		References:    toLocations(nav.References),
+	}
+}
@@ -731,6 +731,20 @@ func ToActionArtifact(repo *repo_model.Repository, art *actions_model.ActionArti
 	}, nil
 }
 
+// ToActionCache converts an actions cache to API format
+func ToActionCache(cache *actions_model.ActionCache) *api.ActionCache {
+	return &api.ActionCache{
+		ID:             cache.ID,
+		RepositoryID:   cache.RepoID,
+		Ref:            cache.Ref,
+		Key:            cache.Key,
+		Version:        cache.Version,
+		SizeInBytes:    cache.Size,
+		LastAccessedAt: cache.LastAccessUnix.AsLocalTime(),
+		CreatedAt:      cache.CreatedUnix.AsLocalTime(),
+	}
+}
+
 func ToActionRunner(ctx context.Context, runner *actions_model.ActionRunner) *api.ActionRunner {
 	status := runner.Status()
 	apiStatus := "offline"
@@ -951,6 +965,66 @@ func ToAnnotatedTagObject(repo *repo_model.Repository, commit *git.Commit) *api.
 	}
 }
 
+// ToRuleset converts a git.Ruleset to an api.Ruleset
+func ToRuleset(rs *git_model.Ruleset) *api.Ruleset {
+	return &api.Ruleset{
+		ID:          rs.ID,
+		Name:        rs.Name,
+		Target:      rs.Target.String(),
+		Enforcement: rs.Enforcement.String(),
+		OrgLevel:    rs.IsOrgLevel(),
+		IncludeRefs: util.SliceNilAsEmpty(rs.IncludeRefs),
+		ExcludeRefs: util.SliceNilAsEmpty(rs.ExcludeRefs),
+		Rules: &api.RulesetRules{
+			Deletion:              rs.Rules.Deletion,
+			NonFastForward:        rs.Rules.NonFastForward,
+			RequiredLinearHistory: rs.Rules.RequiredLinearHistory,
+			RequiredStatusChecks:  util.SliceNilAsEmpty(rs.Rules.RequiredStatusChecks),
+			RequiredSignatures:    rs.Rules.RequiredSignatures,
+			BlockedFilePaths:      util.SliceNilAsEmpty(rs.Rules.BlockedFilePaths),
+			CommitMessagePattern:  rs.Rules.CommitMessagePattern,
+			MaxFileSize:           rs.Rules.MaxFileSize,
+		},
+		Created: rs.CreatedUnix.AsTime(),
+		Updated: rs.UpdatedUnix.AsTime(),
+	}
+}
+
+// ToCustomField converts an issues_model.CustomField to an api.CustomField
+func ToCustomField(f *issues_model.CustomField) *api.CustomField {
+	return &api.CustomField{
+		ID:          f.ID,
+		Name:        f.Name,
+		Description: f.Description,
+		Type:        string(f.Type),
+		Options:     util.SliceNilAsEmpty(f.Options),
+		OrgLevel:    f.IsOrgLevel(),
+		Sortable:    f.Type.IsSortable(),
+		Created:     f.CreatedUnix.AsTime(),
+		Updated:     f.UpdatedUnix.AsTime(),
+	}
+}
+
+// ToIssueCustomFieldValues converts the custom field values of an issue to api.IssueCustomFieldValue, the fields without a value are listed too
+func ToIssueCustomFieldValues(fields []*issues_model.CustomField, values []*issues_model.IssueCustomFieldValue) []*api.IssueCustomFieldValue {
+	res := make([]*api.IssueCustomFieldValue, 0, len(fields))
+	for _, f := range fields {
+		v := &api.IssueCustomFieldValue{
+			FieldID: f.ID,
+			Name:    f.Name,
+			Type:    string(f.Type),
+			Values:  []string{},
+		}
+		for _, value := range values {
+			if value.FieldID == f.ID {
+				v.Values = append(v.Values, value.Value)
+			}
+		}
+		res = append(res, v)
+	}
+	return res
+}
+
 // ToTagProtection convert a git.ProtectedTag to an api.TagProtection
 func ToTagProtection(ctx context.Context, pt *git_model.ProtectedTag, repo *repo_model.Repository) *api.TagProtection {
 	readers, err := access_model.GetUsersWithAnyUnitAccess(ctx, repo, perm.AccessModeRead, unit.TypeCode, unit.TypePullRequests)
@@ -102,6 +102,25 @@ func toIssue(ctx context.Context, doer *user_model.User, issue *issues_model.Iss
 		apiIssue.Projects = ToProjectList(ctx, issue.Projects, doer)
 	}
 
+	if err := issue.LoadType(ctx); err != nil {
+		return &api.Issue{}
+	}
+	if issue.Type != nil {
+		apiIssue.Type = ToIssueType(issue.Type)
+	}
+
+	progress, err := issues_model.GetSubIssueProgress(ctx, issue.ID)
+	if err != nil {
+		return &api.Issue{}
+	}
+	if p := progress[issue.ID]; p != nil {
+		apiIssue.SubIssuesSummary = &api.SubIssuesSummary{
+			Total:            p.Total,
+			Closed:           p.Closed,
+			PercentCompleted: p.Percent(),
+		}
+	}
+
 	if err := issue.LoadAssignees(ctx); err != nil {
 		return &api.Issue{}
 	}
@@ -134,6 +153,19 @@ func toIssue(ctx context.Context, doer *user_model.User, issue *issues_model.Iss
 	return apiIssue
 }
 
+// ToIssueType converts issues_model.IssueType to api.IssueType
+func ToIssueType(t *issues_model.IssueType) *api.IssueType {
+	return &api.IssueType{
+		ID:          t.ID,
+		Name:        t.Name,
+		Description: t.Description,
+		Color:       strings.TrimLeft(t.Color, "#"),
+		IsDisabled:  t.IsDisabled,
+		Created:     t.CreatedUnix.AsTime(),
+		Updated:     t.UpdatedUnix.AsTime(),
+	}
+}
+
 // ToIssueList converts an IssueList to API format
 func ToIssueList(ctx context.Context, doer *user_model.User, il issues_model.IssueList) []*api.Issue {
 	result := make([]*api.Issue, len(il))
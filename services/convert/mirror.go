@@ -24,3 +24,13 @@ func ToPushMirror(ctx context.Context, pm *repo_model.PushMirror) (*api.PushMirr
 		SyncOnCommit:   pm.SyncOnCommit,
 	}, nil
 }
+
+// ToMigrationSync convert from repo_model.MigrationSync to api.MigrationSync
+func ToMigrationSync(s *repo_model.MigrationSync) *api.MigrationSync {
+	return &api.MigrationSync{
+		OriginalURL: s.Repo.OriginalURL,
+		LastSync:    s.LastSyncUnix.AsTime(),
+		NextSync:    s.NextSyncUnix.AsTime(),
+		LastError:   s.LastError,
+	}
+}
@@ -10,6 +10,7 @@ import (
 	access_model "gitea.dev/models/perm/access"
 	user_model "gitea.dev/models/user"
 	api "gitea.dev/modules/structs"
+	attestation_service "gitea.dev/services/packages/attestation"
 )
 
 // ToPackage convert a packages.PackageDescriptor to api.Package
@@ -51,3 +52,44 @@ func ToPackageFile(pfd *packages.PackageFileDescriptor) *api.PackageFile {
 		HashSHA512: pfd.Blob.HashSHA512,
 	}
 }
+
+// ToPackageRemote convert a packages.PackageRemote to api.PackageRemote
+func ToPackageRemote(pr *packages.PackageRemote) *api.PackageRemote {
+	return &api.PackageRemote{
+		ID:          pr.ID,
+		Type:        string(pr.Type),
+		Mode:        string(pr.Mode),
+		URL:         pr.URL,
+		Username:    pr.Username,
+		HasPassword: pr.PasswordEncrypted != "",
+		MetadataTTL: int64(pr.TTL().Seconds()),
+		Created:     pr.CreatedUnix.AsTime(),
+		Updated:     pr.UpdatedUnix.AsTime(),
+	}
+}
+
+// ToPackageAttestation converts attestation_service.View to api.PackageAttestation
+func ToPackageAttestation(ctx context.Context, v *attestation_service.View, doer *user_model.User) *api.PackageAttestation {
+	apiAttestation := &api.PackageAttestation{
+		ID:         v.Attestation.ID,
+		Type:       string(v.Attestation.Type),
+		Format:     v.Attestation.Format,
+		Name:       v.Attestation.Name,
+		FileName:   v.FileName(),
+		Size:       v.Blob.Size,
+		HashSHA256: v.Blob.HashSHA256,
+		Created:    v.Attestation.CreatedUnix.AsTime(),
+	}
+	if v.Verification != nil {
+		apiAttestation.Verification = &api.PackageAttestationVerification{
+			Verified: v.Verification.Verified,
+			Reason:   v.Verification.Reason,
+			KeyID:    v.Verification.KeyID,
+			Subject:  v.Verification.Subject,
+		}
+		if v.Verification.Signer != nil {
+			apiAttestation.Verification.Signer = ToUser(ctx, v.Verification.Signer, doer)
+		}
+	}
+	return apiAttestation
+}
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"time"
 
+	issues_model "gitea.dev/models/issues"
 	project_model "gitea.dev/models/project"
 	user_model "gitea.dev/models/user"
 	"gitea.dev/modules/container"
@@ -15,6 +16,7 @@ import (
 	"gitea.dev/modules/log"
 	api "gitea.dev/modules/structs"
 	"gitea.dev/modules/timeutil"
+	project_service "gitea.dev/services/projects"
 )
 
 func projectTemplateTypeToString(t project_model.TemplateType) string {
@@ -195,3 +197,89 @@ func ToProjectColumnList(ctx context.Context, columns []*project_model.Column, d
 	}
 	return result
 }
+
+func ToProjectView(v *project_model.View) *api.ProjectView {
+	return &api.ProjectView{
+		ID:          v.ID,
+		ProjectID:   v.ProjectID,
+		Name:        v.Name,
+		Layout:      string(v.Layout),
+		GroupBy:     v.GroupBy,
+		SortBy:      v.SortBy,
+		SortDesc:    v.SortDesc,
+		DateFieldID: v.DateFieldID,
+		Sorting:     v.Sorting,
+		CreatedAt:   v.CreatedUnix.AsTime(),
+		UpdatedAt:   timeStampPtr(v.UpdatedUnix),
+	}
+}
+
+func ToProjectViewList(views []*project_model.View) []*api.ProjectView {
+	result := make([]*api.ProjectView, len(views))
+	for i, v := range views {
+		result[i] = ToProjectView(v)
+	}
+	return result
+}
+
+func ToProjectIteration(it *project_model.Iteration) *api.ProjectIteration {
+	return &api.ProjectIteration{
+		ID:        it.ID,
+		FieldID:   it.FieldID,
+		Title:     it.Title,
+		StartDate: project_model.FormatFieldDate(it.StartDate),
+		Duration:  it.Duration,
+		EndDate:   project_model.FormatFieldDate(it.EndDate()),
+	}
+}
+
+// ToProjectField converts a project field, the iterations of an iteration field are picked from the given ones
+func ToProjectField(f *project_model.Field, iterations []*project_model.Iteration) *api.ProjectField {
+	apiField := &api.ProjectField{
+		ID:        f.ID,
+		ProjectID: f.ProjectID,
+		Name:      f.Name,
+		Type:      string(f.Type),
+		Options:   f.Options,
+		CreatedAt: f.CreatedUnix.AsTime(),
+		UpdatedAt: timeStampPtr(f.UpdatedUnix),
+	}
+	for _, it := range iterations {
+		if it.FieldID == f.ID {
+			apiField.Iterations = append(apiField.Iterations, ToProjectIteration(it))
+		}
+	}
+	return apiField
+}
+
+func ToProjectFieldValue(v *project_model.IssueFieldValue) *api.ProjectFieldValue {
+	return &api.ProjectFieldValue{FieldID: v.FieldID, Value: v.Value}
+}
+
+// ToProjectViewItems converts the items of a project view, their issues are converted together
+func ToProjectViewItems(ctx context.Context, doer *user_model.User, items []*project_service.ViewItem) []*api.ProjectViewItem {
+	issues := make(issues_model.IssueList, len(items))
+	for i, item := range items {
+		issues[i] = item.Issue
+	}
+	apiIssues := ToAPIIssueList(ctx, doer, issues)
+
+	result := make([]*api.ProjectViewItem, len(items))
+	for i, item := range items {
+		apiItem := &api.ProjectViewItem{
+			Issue:       apiIssues[i],
+			ColumnID:    item.ColumnID,
+			FieldValues: make([]*api.ProjectFieldValue, 0, len(item.Values)),
+			StartDate:   timeStampPtr(item.StartDate),
+			EndDate:     timeStampPtr(item.EndDate),
+		}
+		for _, v := range item.Values {
+			apiItem.FieldValues = append(apiItem.FieldValues, ToProjectFieldValue(v))
+		}
+		if item.Group != nil {
+			apiItem.Group = &api.ProjectViewGroup{Key: item.Group.Key, Title: item.Group.Title}
+		}
+		result[i] = apiItem
+	}
+	return result
+}
@@ -10,6 +10,7 @@ import (
 	issues_model "gitea.dev/models/issues"
 	"gitea.dev/models/perm"
 	access_model "gitea.dev/models/perm/access"
+	pull_model "gitea.dev/models/pull"
 	repo_model "gitea.dev/models/repo"
 	user_model "gitea.dev/models/user"
 	"gitea.dev/modules/cache"
@@ -479,3 +480,24 @@ func ToAPIPullRequests(ctx context.Context, baseRepo *repo_model.Repository, prs
 
 	return apiPullRequests, nil
 }
+
+// ToMergeQueueEntry convert a pull_model.MergeQueueEntry to an api.MergeQueueEntry
+func ToMergeQueueEntry(ctx context.Context, entry *pull_model.MergeQueueEntry, pr *issues_model.PullRequest, doer *user_model.User) *api.MergeQueueEntry {
+	res := &api.MergeQueueEntry{
+		PullRequestIndex: pr.Index,
+		BaseBranch:       entry.BaseBranch,
+		Status:           entry.Status.String(),
+		MergeStyle:       string(entry.MergeStyle),
+		HeadCommitID:     entry.HeadCommitID,
+		BaseCommitID:     entry.BaseCommitID,
+		MergeCommitID:    entry.MergeCommitID,
+		Reason:           entry.Reason,
+		QueuedBy:         ToUser(ctx, entry.Doer, doer),
+		Created:          entry.CreatedUnix.AsTime(),
+		Updated:          entry.UpdatedUnix.AsTime(),
+	}
+	if entry.TestedUnix != 0 {
+		res.Tested = entry.TestedUnix.AsTimePtr()
+	}
+	return res
+}
@@ -0,0 +1,66 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package convert
+
+import (
+	quota_model "gitea.dev/models/quota"
+	api "gitea.dev/modules/structs"
+	quota_service "gitea.dev/services/quota"
+)
+
+// ToQuotaRule converts a quota_model.Rule to an api.QuotaRule
+func ToQuotaRule(r *quota_model.Rule) *api.QuotaRule {
+	subjects := make([]string, 0, len(r.Subjects))
+	for _, s := range r.Subjects {
+		subjects = append(subjects, string(s))
+	}
+	return &api.QuotaRule{
+		Name:     r.Name,
+		Limit:    r.Limit,
+		Subjects: subjects,
+	}
+}
+
+// ToQuotaRules converts a list of quota_model.Rule to a list of api.QuotaRule
+func ToQuotaRules(rules []*quota_model.Rule) []*api.QuotaRule {
+	result := make([]*api.QuotaRule, 0, len(rules))
+	for _, r := range rules {
+		result = append(result, ToQuotaRule(r))
+	}
+	return result
+}
+
+// ToQuotaGroup converts a group with its rules to an api.QuotaGroup
+func ToQuotaGroup(g *quota_service.GroupRules) *api.QuotaGroup {
+	return &api.QuotaGroup{
+		Name:  g.Group.Name,
+		Rules: ToQuotaRules(g.Rules),
+	}
+}
+
+// ToQuotaUsed converts a quota_model.Usage to an api.QuotaUsed
+func ToQuotaUsed(u *quota_model.Usage) api.QuotaUsed {
+	return api.QuotaUsed{
+		Git:         u.GitSize,
+		LFS:         u.LFSSize,
+		Attachments: u.AttachmentSize,
+		Artifacts:   u.ArtifactSize,
+		Packages:    u.PackageSize,
+		Total:       u.Total(),
+		Updated:     u.UpdatedUnix.AsTime(),
+	}
+}
+
+// ToQuotaInfo converts a quota_service.Info to an api.QuotaInfo
+func ToQuotaInfo(info *quota_service.Info) *api.QuotaInfo {
+	groups := make([]*api.QuotaGroup, 0, len(info.Groups))
+	for _, g := range info.Groups {
+		groups = append(groups, ToQuotaGroup(g))
+	}
+	return &api.QuotaInfo{
+		Used:   ToQuotaUsed(info.Usage),
+		Groups: groups,
+		Rules:  ToQuotaRules(info.Rules),
+	}
+}
@@ -0,0 +1,64 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package convert
+
+import (
+	"context"
+
+	secretscan_model "gitea.dev/models/secretscan"
+	user_model "gitea.dev/models/user"
+	api "gitea.dev/modules/structs"
+)
+
+// ToSecretScanningAlert converts a secretscan_model.Alert to an api.SecretScanningAlert, its users must be loaded
+func ToSecretScanningAlert(ctx context.Context, a *secretscan_model.Alert, doer *user_model.User) *api.SecretScanningAlert {
+	res := &api.SecretScanningAlert{
+		ID:                       a.ID,
+		PatternID:                a.PatternID,
+		PatternName:              a.PatternName,
+		Secret:                   a.SecretRedacted,
+		CommitID:                 a.CommitID,
+		Path:                     a.Path,
+		Line:                     a.Line,
+		State:                    string(a.State),
+		Resolution:               string(a.Resolution),
+		ResolutionComment:        a.ResolutionComment,
+		ResolvedBy:               ToUser(ctx, a.Resolver, doer),
+		PushProtectionBypassed:   a.PushProtectionBypassed,
+		PushProtectionBypassedBy: ToUser(ctx, a.Bypasser, doer),
+		Created:                  a.CreatedUnix.AsTime(),
+		Updated:                  a.UpdatedUnix.AsTime(),
+	}
+	if a.ResolvedUnix != 0 {
+		res.Resolved = a.ResolvedUnix.AsTimePtr()
+	}
+	if a.BypassedUnix != 0 {
+		res.PushProtectionBypassedAt = a.BypassedUnix.AsTimePtr()
+	}
+	return res
+}
+
+// ToSecretScanningAlertEvent converts a secretscan_model.AlertEvent to an api.SecretScanningAlertEvent, its doer must be loaded
+func ToSecretScanningAlertEvent(ctx context.Context, e *secretscan_model.AlertEvent, doer *user_model.User) *api.SecretScanningAlertEvent {
+	return &api.SecretScanningAlertEvent{
+		ID:      e.ID,
+		Type:    string(e.Type),
+		Reason:  e.Reason,
+		Comment: e.Comment,
+		Doer:    ToUser(ctx, e.Doer, doer),
+		Created: e.CreatedUnix.AsTime(),
+	}
+}
+
+// ToSecretScanningPattern converts a secretscan_model.CustomPattern to an api.SecretScanningPattern
+func ToSecretScanningPattern(p *secretscan_model.CustomPattern) *api.SecretScanningPattern {
+	return &api.SecretScanningPattern{
+		ID:             p.ID,
+		Name:           p.Name,
+		Pattern:        p.Pattern,
+		PushProtection: p.PushProtection,
+		Created:        p.CreatedUnix.AsTime(),
+		Updated:        p.UpdatedUnix.AsTime(),
+	}
+}
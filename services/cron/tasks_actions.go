@@ -21,6 +21,8 @@ func initActionsTasks() {
 	registerScheduleTasks()
 	registerActionsCleanup()
 	registerCleanupActionRuns()
+	registerReleaseEnvironmentWaitTimers()
+	registerCleanupActionsCaches()
 }
 
 func registerStopZombieTasks() {
@@ -85,3 +87,23 @@ func registerCleanupActionRuns() {
 		return actions_service.CleanupOldRuns(ctx)
 	})
 }
+
+func registerReleaseEnvironmentWaitTimers() {
+	RegisterTaskFatal("release_environment_wait_timers", &BaseConfig{
+		Enabled:    true,
+		RunAtStart: true,
+		Schedule:   "@every 1m",
+	}, func(ctx context.Context, _ *user_model.User, _ *BaseConfig) error {
+		return actions_service.ReleaseEnvironmentWaitTimers(ctx)
+	})
+}
+
+func registerCleanupActionsCaches() {
+	RegisterTaskFatal("cleanup_actions_caches", &BaseConfig{
+		Enabled:    true,
+		RunAtStart: false,
+		Schedule:   "@every 1h",
+	}, func(ctx context.Context, _ *user_model.User, _ *BaseConfig) error {
+		return actions_service.CleanupCaches(ctx)
+	})
+}
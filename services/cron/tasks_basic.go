@@ -7,6 +7,7 @@ import (
 	"context"
 	"time"
 
+	audit_model "gitea.dev/models/audit"
 	git_model "gitea.dev/models/git"
 	"gitea.dev/models/repostats"
 	user_model "gitea.dev/models/user"
@@ -14,9 +15,11 @@ import (
 	"gitea.dev/modules/git/gitcmd"
 	"gitea.dev/modules/setting"
 	"gitea.dev/services/auth"
+	"gitea.dev/services/mergequeue"
 	"gitea.dev/services/migrations"
 	mirror_service "gitea.dev/services/mirror"
 	packages_cleanup_service "gitea.dev/services/packages/cleanup"
+	quota_service "gitea.dev/services/quota"
 	repo_service "gitea.dev/services/repository"
 	archiver_service "gitea.dev/services/repository/archiver"
 )
@@ -41,6 +44,24 @@ func registerUpdateMirrorTask() {
 	})
 }
 
+func registerSyncMigrations() {
+	type SyncMigrationsTaskConfig struct {
+		BaseConfig
+		Limit int
+	}
+
+	RegisterTaskFatal("sync_migrations", &SyncMigrationsTaskConfig{
+		BaseConfig: BaseConfig{
+			Enabled:    true,
+			RunAtStart: false,
+			Schedule:   "@every 10m",
+		},
+		Limit: 20,
+	}, func(ctx context.Context, _ *user_model.User, cfg *SyncMigrationsTaskConfig) error {
+		return migrations.SyncMigratedRepositories(ctx, cfg.Limit)
+	})
+}
+
 func registerRepoHealthCheck() {
 	type RepoHealthCheckConfig struct {
 		BaseConfig
@@ -149,6 +170,29 @@ func registerCleanupPackages() {
 	})
 }
 
+func registerCleanupAuditLog() {
+	RegisterTaskFatal("cleanup_audit_log", &OlderThanConfig{
+		BaseConfig: BaseConfig{
+			Enabled:    true,
+			RunAtStart: false,
+			Schedule:   "@midnight",
+		},
+		OlderThan: 365 * 24 * time.Hour,
+	}, func(ctx context.Context, _ *user_model.User, config *OlderThanConfig) error {
+		return audit_model.DeleteEventsOlderThan(ctx, config.OlderThan)
+	})
+}
+
+func registerUpdateQuotaUsage() {
+	RegisterTaskFatal("update_quota_usage", &BaseConfig{
+		Enabled:    true,
+		RunAtStart: true,
+		Schedule:   "@midnight",
+	}, func(ctx context.Context, _ *user_model.User, _ *BaseConfig) error {
+		return quota_service.UpdateAllUsages(ctx)
+	})
+}
+
 func registerSyncRepoLicenses() {
 	RegisterTaskFatal("sync_repo_licenses", &BaseConfig{
 		Enabled:    false,
@@ -159,10 +203,23 @@ func registerSyncRepoLicenses() {
 	})
 }
 
+func registerProcessMergeQueues() {
+	RegisterTaskFatal("process_merge_queues", &BaseConfig{
+		Enabled:    true,
+		RunAtStart: true,
+		Schedule:   "@every 5m",
+	}, func(ctx context.Context, _ *user_model.User, _ *BaseConfig) error {
+		return mergequeue.ProcessAllMergeQueues(ctx)
+	})
+}
+
 func initBasicTasks() {
 	if setting.Mirror.Enabled {
 		registerUpdateMirrorTask()
 	}
+	if !setting.Repository.DisableMigrations {
+		registerSyncMigrations()
+	}
 	registerRepoHealthCheck()
 	registerCheckRepoStats()
 	registerArchiveCleanup()
@@ -175,5 +232,12 @@ func initBasicTasks() {
 	if setting.Packages.Enabled {
 		registerCleanupPackages()
 	}
+	if setting.Audit.Enabled {
+		registerCleanupAuditLog()
+	}
+	if setting.Quota.Enabled {
+		registerUpdateQuotaUsage()
+	}
 	registerSyncRepoLicenses()
+	registerProcessMergeQueues()
 }
@@ -8,7 +8,7 @@ import "gitea.dev/modules/web/middleware"
 // AuthenticationForm form for authentication
 type AuthenticationForm struct {
 	middleware.FormDefaultValidator
-	Type            int    `binding:"Range(2,7)"`
+	Type            int    `binding:"Range(2,8)"`
 	Name            string `binding:"Required;MaxSize(30)"`
 	TwoFactorPolicy string
 	IsActive        bool
@@ -89,4 +89,22 @@ type AuthenticationForm struct {
 	SSPIStripDomainNames     bool
 	SSPISeparatorReplacement string `binding:"TrimSpace;AlphaDashDot;MaxSize(5)"`
 	SSPIDefaultLanguage      string
+
+	// SAML
+	SAMLIdentityProviderMetadata    string
+	SAMLIdentityProviderMetadataURL string
+	SAMLServiceProviderCertificate  string
+	SAMLServiceProviderPrivateKey   string
+	SAMLNameIDFormat                string
+	SAMLAllowIDPInitiated           bool
+	SAMLAutoRegister                bool
+	SAMLIconURL                     string
+	SAMLAttributeUsername           string
+	SAMLAttributeEmail              string
+	SAMLAttributeFullName           string
+	SAMLAttributeGroups             string
+	SAMLAdminGroup                  string
+	SAMLRestrictedGroup             string
+	SAMLGroupTeamMap                string `binding:"ValidGroupTeamMap"`
+	SAMLGroupTeamMapRemoval         bool
 }
@@ -71,6 +71,7 @@ type MigrateRepoForm struct {
 	PullRequests   bool   `json:"pull_requests"`
 	Releases       bool   `json:"releases"`
 	MirrorInterval string `json:"mirror_interval"`
+	Sync           bool   `json:"sync"`
 
 	AWSAccessKeyID     string `json:"aws_access_key_id"`
 	AWSSecretAccessKey string `json:"aws_secret_access_key"`
@@ -333,6 +334,17 @@ type NewPackagistHookForm struct {
 	WebhookForm
 }
 
+// NewTemplateHookForm form for creating templated hook
+type NewTemplateHookForm struct {
+	middleware.FormDefaultValidator
+	PayloadURL      string `binding:"Required;ValidUrl"`
+	HTTPMethod      string `binding:"Required;In(POST,PUT,PATCH)"`
+	BodyContentType string `binding:"Required"`
+	BodyTemplate    string
+	HeadersTemplate string
+	WebhookForm
+}
+
 // CreateIssueForm form for creating issue
 type CreateIssueForm struct {
 	middleware.FormDefaultValidator
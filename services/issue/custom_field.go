@@ -0,0 +1,76 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package issue
+
+import (
+	"context"
+	"strconv"
+
+	issues_model "gitea.dev/models/issues"
+	user_model "gitea.dev/models/user"
+	issue_indexer "gitea.dev/modules/indexer/issues"
+	"gitea.dev/modules/util"
+	notify_service "gitea.dev/services/notify"
+)
+
+// SetCustomFieldValues replaces the values of a custom field of an issue, no values clears the field
+func SetCustomFieldValues(ctx context.Context, doer *user_model.User, issue *issues_model.Issue, field *issues_model.CustomField, values []string) error {
+	if field.Type == issues_model.CustomFieldTypeUser {
+		normalized, err := field.NormalizeValues(values)
+		if err != nil {
+			return err
+		}
+		for _, v := range normalized {
+			userID, _ := strconv.ParseInt(v.Value, 10, 64)
+			u, err := user_model.GetUserByID(ctx, userID)
+			if err != nil {
+				if user_model.IsErrUserNotExist(err) {
+					return util.NewInvalidArgumentErrorf("user %d of custom field %q does not exist", userID, field.Name)
+				}
+				return err
+			}
+			if !u.IsIndividual() {
+				return util.NewInvalidArgumentErrorf("%s is not a user", u.Name)
+			}
+		}
+	}
+
+	if err := issues_model.SetIssueCustomFieldValues(ctx, issue, field, values); err != nil {
+		return err
+	}
+
+	notify_service.IssueChangeCustomField(ctx, doer, issue, field)
+	return nil
+}
+
+// UpdateCustomField updates a custom field, the issues having a value for it are indexed again
+// as the values of the removed options are cleared and the sort keys of the others may change.
+func UpdateCustomField(ctx context.Context, field *issues_model.CustomField) error {
+	issueIDs, err := issues_model.GetIssueIDsByCustomField(ctx, field.ID)
+	if err != nil {
+		return err
+	}
+	if err := issues_model.UpdateCustomField(ctx, field); err != nil {
+		return err
+	}
+	for _, issueID := range issueIDs {
+		issue_indexer.UpdateIssueIndexer(ctx, issueID)
+	}
+	return nil
+}
+
+// DeleteCustomField deletes a custom field and its values, the issues having a value for it are indexed again
+func DeleteCustomField(ctx context.Context, field *issues_model.CustomField) error {
+	issueIDs, err := issues_model.GetIssueIDsByCustomField(ctx, field.ID)
+	if err != nil {
+		return err
+	}
+	if err := issues_model.DeleteCustomField(ctx, field); err != nil {
+		return err
+	}
+	for _, issueID := range issueIDs {
+		issue_indexer.UpdateIssueIndexer(ctx, issueID)
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package issue
+
+import (
+	"context"
+
+	issues_model "gitea.dev/models/issues"
+	user_model "gitea.dev/models/user"
+	issue_indexer "gitea.dev/modules/indexer/issues"
+	notify_service "gitea.dev/services/notify"
+)
+
+// ChangeIssueType changes the type of an issue, 0 removes its type
+func ChangeIssueType(ctx context.Context, doer *user_model.User, issue *issues_model.Issue, typeID int64) error {
+	oldTypeID := issue.TypeID
+	if oldTypeID == typeID {
+		return nil
+	}
+	if err := issues_model.SetIssueType(ctx, issue, typeID); err != nil {
+		return err
+	}
+
+	notify_service.IssueChangeType(ctx, doer, issue, oldTypeID)
+	return nil
+}
+
+// DeleteIssueType deletes an issue type, the issues having it are indexed again without type
+func DeleteIssueType(ctx context.Context, t *issues_model.IssueType) error {
+	issueIDs, err := issues_model.GetIssueIDsByType(ctx, t.ID)
+	if err != nil {
+		return err
+	}
+	if err := issues_model.DeleteIssueType(ctx, t); err != nil {
+		return err
+	}
+	for _, issueID := range issueIDs {
+		issue_indexer.UpdateIssueIndexer(ctx, issueID)
+	}
+	return nil
+}
@@ -0,0 +1,32 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package issue
+
+import (
+	"context"
+
+	issues_model "gitea.dev/models/issues"
+	user_model "gitea.dev/models/user"
+	notify_service "gitea.dev/services/notify"
+)
+
+// AddSubIssue makes an issue a sub-issue of another issue
+func AddSubIssue(ctx context.Context, doer *user_model.User, parent, subIssue *issues_model.Issue) error {
+	if err := issues_model.AddSubIssue(ctx, doer, parent, subIssue); err != nil {
+		return err
+	}
+
+	notify_service.IssueChangeSubIssue(ctx, doer, parent, subIssue, false)
+	return nil
+}
+
+// RemoveSubIssue removes a sub-issue from its parent
+func RemoveSubIssue(ctx context.Context, doer *user_model.User, parent, subIssue *issues_model.Issue) error {
+	if err := issues_model.RemoveSubIssue(ctx, doer, parent, subIssue); err != nil {
+		return err
+	}
+
+	notify_service.IssueChangeSubIssue(ctx, doer, parent, subIssue, true)
+	return nil
+}
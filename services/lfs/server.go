@@ -23,6 +23,7 @@ import (
 	git_model "gitea.dev/models/git"
 	perm_model "gitea.dev/models/perm"
 	access_model "gitea.dev/models/perm/access"
+	quota_model "gitea.dev/models/quota"
 	repo_model "gitea.dev/models/repo"
 	"gitea.dev/models/unit"
 	user_model "gitea.dev/models/user"
@@ -35,6 +36,7 @@ import (
 	"gitea.dev/modules/storage"
 	"gitea.dev/modules/util"
 	"gitea.dev/services/context"
+	quota_service "gitea.dev/services/quota"
 
 	"github.com/golang-jwt/jwt/v5"
 )
@@ -221,6 +223,8 @@ func BatchHandler(ctx *context.Context) {
 	contentStore := lfs_module.NewContentStore()
 
 	var responseObjects []*lfs_module.ObjectResponse
+	// the size of the new objects accepted for upload in this batch, they all count for the quota
+	var uploadSize int64
 
 	for _, p := range br.Objects {
 		if !p.IsValid() {
@@ -273,6 +277,21 @@ func BatchHandler(ctx *context.Context) {
 					Message: fmt.Sprintf("Size must be less than or equal to %d", setting.LFS.MaxFileSize),
 				}
 			}
+			if !exists && err == nil {
+				if qerr := quota_service.CheckQuota(ctx, repository.OwnerID, quota_model.CategoryLFS, uploadSize+p.Size); qerr != nil {
+					if !errors.Is(qerr, util.ErrContentTooLarge) {
+						log.Error("Unable to check the quota of %s/%s. Error: %v", rc.User, rc.Repo, qerr)
+						writeStatus(ctx, http.StatusInternalServerError)
+						return
+					}
+					err = &lfs_module.ObjectError{
+						Code:    http.StatusRequestEntityTooLarge,
+						Message: "Quota exceeded",
+					}
+				} else {
+					uploadSize += p.Size
+				}
+			}
 
 			responseObject = buildObjectResponse(rc, p, false, !exists, err)
 		} else {
@@ -354,6 +373,9 @@ func UploadHandler(ctx *context.Context) {
 				}
 			}
 		} else if errors.Is(err, fs.ErrNotExist) {
+			if err := quota_service.CheckQuota(ctx, repository.OwnerID, quota_model.CategoryLFS, p.Size); err != nil {
+				return err
+			}
 			// not exist, store it into the store
 			if err := contentStore.Put(p, ctx.Req.Body); err != nil {
 				log.Error("Error putting LFS MetaObject [%s] into content store. Error: %v", p.Oid, err)
@@ -363,8 +385,11 @@ func UploadHandler(ctx *context.Context) {
 			log.Error("Unable to check LFS OID[%s] stat. Error: %v", p.Oid, err)
 			return err
 		}
-		_, err = git_model.NewLFSMetaObject(ctx, repository.ID, p)
-		return err
+		if _, err = git_model.NewLFSMetaObject(ctx, repository.ID, p); err != nil {
+			return err
+		}
+		quota_service.UpdateUsage(ctx, repository.OwnerID, quota_model.CategoryLFS)
+		return nil
 	}
 
 	defer ctx.Req.Body.Close()
@@ -372,6 +397,8 @@ func UploadHandler(ctx *context.Context) {
 		if errors.Is(err, lfs_module.ErrSizeMismatch) || errors.Is(err, lfs_module.ErrHashMismatch) {
 			log.Error("Upload does not match LFS MetaObject [%s]. Error: %v", p.Oid, err)
 			writeStatusMessage(ctx, http.StatusUnprocessableEntity, err.Error())
+		} else if errors.Is(err, util.ErrContentTooLarge) {
+			writeStatusMessage(ctx, http.StatusRequestEntityTooLarge, "Quota exceeded")
 		} else {
 			log.Error("Error whilst uploadOrVerify LFS OID[%s]: %v", p.Oid, err)
 			writeStatus(ctx, http.StatusInternalServerError)
@@ -0,0 +1,149 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package mergequeue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	issues_model "gitea.dev/models/issues"
+	access_model "gitea.dev/models/perm/access"
+	pull_model "gitea.dev/models/pull"
+	repo_model "gitea.dev/models/repo"
+	"gitea.dev/models/unit"
+	user_model "gitea.dev/models/user"
+	"gitea.dev/modules/globallock"
+	"gitea.dev/modules/graceful"
+	"gitea.dev/modules/log"
+	"gitea.dev/modules/queue"
+	notify_service "gitea.dev/services/notify"
+	pull_service "gitea.dev/services/pull"
+)
+
+var mergeQueue *queue.WorkerPoolQueue[string]
+
+// Init runs the task queue that processes the merge queues of the branches
+func Init() error {
+	notify_service.RegisterNotifier(NewNotifier())
+
+	mergeQueue = queue.CreateUniqueQueue(graceful.GetManager().ShutdownContext(), "pr_merge_queue", handler)
+	if mergeQueue == nil {
+		return errors.New("unable to create pr_merge_queue queue")
+	}
+	go graceful.GetManager().RunWithCancel(mergeQueue)
+	return nil
+}
+
+// handle passed "repoID_branch" items and process the merge queues of the branches
+func handler(items ...string) []string {
+	for _, s := range items {
+		idStr, branch, ok := strings.Cut(s, "_")
+		repoID, err := strconv.ParseInt(idStr, 10, 64)
+		if !ok || err != nil || branch == "" {
+			log.Error("could not parse data from pr_merge_queue queue (%v)", s)
+			continue
+		}
+		processMergeQueue(repoID, branch)
+	}
+	return nil
+}
+
+// AddToQueue schedules the processing of the merge queue of a branch
+func AddToQueue(repoID int64, baseBranch string) {
+	log.Trace("Adding the merge queue of branch %s of repository %d to the processing queue", baseBranch, repoID)
+	if err := mergeQueue.Push(fmt.Sprintf("%d_%s", repoID, baseBranch)); err != nil && !errors.Is(err, queue.ErrAlreadyInQueue) {
+		log.Error("Error adding the merge queue of branch %s of repository %d to the processing queue: %v", baseBranch, repoID, err)
+	}
+}
+
+// ProcessAllMergeQueues schedules the processing of every branch which has pull requests in its merge queue,
+// the checks of the speculative merges may have timed out without any event
+func ProcessAllMergeQueues(ctx context.Context) error {
+	queues, err := pull_model.GetActiveMergeQueues(ctx)
+	if err != nil {
+		return err
+	}
+	for _, q := range queues {
+		AddToQueue(q.RepoID, q.BaseBranch)
+	}
+	return nil
+}
+
+func getMergeQueueLockKey(repoID int64, baseBranch string) string {
+	return fmt.Sprintf("merge_queue_%d_%s", repoID, baseBranch)
+}
+
+// Enqueue adds the pull request to the merge queue of its base branch. The pull request must be mergeable except for
+// its status checks, which are run on the speculative merge of the base branch with the pull requests queued before it.
+func Enqueue(ctx context.Context, doer *user_model.User, pr *issues_model.PullRequest, style repo_model.MergeStyle, message string, deleteBranchAfterMerge bool) error {
+	if err := pr.LoadBaseRepo(ctx); err != nil {
+		return err
+	}
+
+	prUnit, err := pr.BaseRepo.GetUnit(ctx, unit.TypePullRequests)
+	if err != nil {
+		return err
+	}
+	if style == repo_model.MergeStyleManuallyMerged || !prUnit.PullRequestsConfig().IsMergeStyleAllowed(style) {
+		return pull_service.ErrInvalidMergeStyle{ID: pr.BaseRepo.ID, Style: style}
+	}
+
+	perm, err := access_model.GetDoerRepoPermission(ctx, pr.BaseRepo, doer)
+	if err != nil {
+		return err
+	}
+	if err := pull_service.CheckPullMergeable(ctx, doer, &perm, pr, pull_service.MergeCheckTypeQueue, style, false); err != nil {
+		return err
+	}
+
+	if err := pull_model.AddToMergeQueue(ctx, &pull_model.MergeQueueEntry{
+		RepoID:                 pr.BaseRepoID,
+		BaseBranch:             pr.BaseBranch,
+		PullID:                 pr.ID,
+		DoerID:                 doer.ID,
+		MergeStyle:             style,
+		Message:                message,
+		DeleteBranchAfterMerge: deleteBranchAfterMerge,
+	}); err != nil {
+		return err
+	}
+	log.Trace("Pull request [%d] added to the merge queue of branch %s with style [%s]", pr.ID, pr.BaseBranch, style)
+	AddToQueue(pr.BaseRepoID, pr.BaseBranch)
+	return nil
+}
+
+// Dequeue removes the pull request from the merge queue of its base branch,
+// the entries queued after it are rebuilt without it
+func Dequeue(ctx context.Context, doer *user_model.User, pr *issues_model.PullRequest) error {
+	entry, err := pull_model.GetMergeQueueEntryByPullID(ctx, pr.ID)
+	if err != nil {
+		return err
+	}
+	return removeEntry(ctx, doer, pr, entry)
+}
+
+func removeEntry(ctx context.Context, doer *user_model.User, pr *issues_model.PullRequest, entry *pull_model.MergeQueueEntry) error {
+	releaser, err := globallock.Lock(ctx, getMergeQueueLockKey(entry.RepoID, entry.BaseBranch))
+	if err != nil {
+		return fmt.Errorf("lock.Lock: %w", err)
+	}
+	defer releaser()
+
+	if err := pull_model.DeleteMergeQueueEntry(ctx, entry); err != nil {
+		return err
+	}
+	if err := pr.LoadBaseRepo(ctx); err != nil {
+		return err
+	}
+	if err := pull_service.DeleteMergeQueueBranches(ctx, pr.BaseRepo, doer, []*issues_model.PullRequest{pr}); err != nil {
+		log.Error("DeleteMergeQueueBranches %-v: %v", pr, err)
+	}
+	if entry.Status != pull_model.MergeQueueEntryStatusEjected {
+		AddToQueue(entry.RepoID, entry.BaseBranch)
+	}
+	return nil
+}
@@ -0,0 +1,118 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package mergequeue
+
+import (
+	"context"
+	"errors"
+
+	"gitea.dev/models/db"
+	git_model "gitea.dev/models/git"
+	issues_model "gitea.dev/models/issues"
+	pull_model "gitea.dev/models/pull"
+	repo_model "gitea.dev/models/repo"
+	user_model "gitea.dev/models/user"
+	"gitea.dev/modules/log"
+	"gitea.dev/modules/repository"
+	"gitea.dev/modules/util"
+	notify_service "gitea.dev/services/notify"
+	pull_service "gitea.dev/services/pull"
+)
+
+type mergeQueueNotifier struct {
+	notify_service.NullNotifier
+}
+
+var _ notify_service.Notifier = &mergeQueueNotifier{}
+
+// NewNotifier create a new mergeQueueNotifier notifier
+func NewNotifier() notify_service.Notifier {
+	return &mergeQueueNotifier{}
+}
+
+// processPullRequestQueue schedules the merge queue of a queued pull request, which checks whether it is still mergeable
+func processPullRequestQueue(ctx context.Context, pr *issues_model.PullRequest) {
+	entry, err := pull_model.GetMergeQueueEntryByPullID(ctx, pr.ID)
+	if err != nil {
+		if !errors.Is(err, util.ErrNotExist) {
+			log.Error("GetMergeQueueEntryByPullID: %v", err)
+		}
+		return
+	}
+	if entry.Status == pull_model.MergeQueueEntryStatusEjected {
+		// the reason of the ejection is only kept while the pull request can be queued again
+		if pr.HasMerged || (pr.Issue != nil && pr.Issue.IsClosed) {
+			if err := pull_model.DeleteMergeQueueEntry(ctx, entry); err != nil {
+				log.Error("DeleteMergeQueueEntry: %v", err)
+			}
+		}
+		return
+	}
+	AddToQueue(entry.RepoID, entry.BaseBranch)
+}
+
+func (n *mergeQueueNotifier) CreateCommitStatus(ctx context.Context, repo *repo_model.Repository, commit *repository.PushCommit, sender *user_model.User, status *git_model.CommitStatus) {
+	if status.State.IsPending() {
+		return
+	}
+	entries, err := db.Find[pull_model.MergeQueueEntry](ctx, pull_model.FindMergeQueueEntriesOptions{
+		RepoID:        repo.ID,
+		MergeCommitID: commit.Sha1,
+		Queued:        true,
+	})
+	if err != nil {
+		log.Error("Find merge queue entries of commit %s: %v", commit.Sha1, err)
+		return
+	}
+	for _, entry := range entries {
+		AddToQueue(entry.RepoID, entry.BaseBranch)
+	}
+}
+
+func (n *mergeQueueNotifier) PullRequestSynchronized(ctx context.Context, doer *user_model.User, pr *issues_model.PullRequest, before, after string) {
+	processPullRequestQueue(ctx, pr)
+}
+
+func (n *mergeQueueNotifier) PullRequestChangeTargetBranch(ctx context.Context, doer *user_model.User, pr *issues_model.PullRequest, oldBranch string) {
+	processPullRequestQueue(ctx, pr)
+}
+
+func (n *mergeQueueNotifier) IssueChangeStatus(ctx context.Context, doer *user_model.User, commitID string, issue *issues_model.Issue, actionComment *issues_model.Comment, closeOrReopen bool) {
+	if !closeOrReopen || !issue.IsPull {
+		return
+	}
+	if err := issue.LoadPullRequest(ctx); err != nil {
+		log.Error("LoadPullRequest: %v", err)
+		return
+	}
+	issue.PullRequest.Issue = issue
+	processPullRequestQueue(ctx, issue.PullRequest)
+}
+
+func (n *mergeQueueNotifier) MergePullRequest(ctx context.Context, doer *user_model.User, pr *issues_model.PullRequest) {
+	processPullRequestQueue(ctx, pr)
+}
+
+func (n *mergeQueueNotifier) AutoMergePullRequest(ctx context.Context, doer *user_model.User, pr *issues_model.PullRequest) {
+	processPullRequestQueue(ctx, pr)
+}
+
+func (n *mergeQueueNotifier) PushCommits(ctx context.Context, pusher *user_model.User, repo *repo_model.Repository, opts *repository.PushUpdateOptions, commits *repository.PushCommits) {
+	if !opts.RefFullName.IsBranch() || pull_service.IsMergeQueueBranch(opts.RefFullName.BranchName()) {
+		return
+	}
+	// the speculative merges are rebuilt onto the new head of the base branch
+	exist, err := db.Exist[pull_model.MergeQueueEntry](ctx, pull_model.FindMergeQueueEntriesOptions{
+		RepoID:     repo.ID,
+		BaseBranch: opts.RefFullName.BranchName(),
+		Queued:     true,
+	}.ToConds())
+	if err != nil {
+		log.Error("Find merge queue entries of branch %s: %v", opts.RefFullName.BranchName(), err)
+		return
+	}
+	if exist {
+		AddToQueue(repo.ID, opts.RefFullName.BranchName())
+	}
+}
@@ -0,0 +1,372 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package mergequeue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"gitea.dev/models/db"
+	git_model "gitea.dev/models/git"
+	issues_model "gitea.dev/models/issues"
+	access_model "gitea.dev/models/perm/access"
+	pull_model "gitea.dev/models/pull"
+	repo_model "gitea.dev/models/repo"
+	"gitea.dev/modules/commitstatus"
+	"gitea.dev/modules/git"
+	"gitea.dev/modules/globallock"
+	"gitea.dev/modules/graceful"
+	"gitea.dev/modules/log"
+	"gitea.dev/modules/process"
+	"gitea.dev/modules/setting"
+	"gitea.dev/modules/timeutil"
+	asymkey_service "gitea.dev/services/asymkey"
+	pull_service "gitea.dev/services/pull"
+	repo_service "gitea.dev/services/repository"
+)
+
+type queuedPull struct {
+	entry *pull_model.MergeQueueEntry
+	pr    *issues_model.PullRequest
+}
+
+func pullRequestsOf(pulls []*queuedPull) []*issues_model.PullRequest {
+	prs := make([]*issues_model.PullRequest, 0, len(pulls))
+	for _, p := range pulls {
+		prs = append(prs, p.pr)
+	}
+	return prs
+}
+
+// processMergeQueue merges the tested entries of the merge queue of a branch, ejects the failing ones
+// and builds the speculative merges of the waiting ones
+func processMergeQueue(repoID int64, baseBranch string) {
+	ctx, _, finished := process.GetManager().AddContext(graceful.GetManager().HammerContext(),
+		fmt.Sprintf("Process the merge queue of branch %s of repository %d", baseBranch, repoID))
+	defer finished()
+
+	releaser, err := globallock.Lock(ctx, getMergeQueueLockKey(repoID, baseBranch))
+	if err != nil {
+		log.Error("lock.Lock(): %v", err)
+		return
+	}
+	defer releaser()
+
+	if err := processMergeQueueLocked(ctx, repoID, baseBranch); err != nil {
+		log.Error("Unable to process the merge queue of branch %s of repository %d: %v", baseBranch, repoID, err)
+	}
+}
+
+func processMergeQueueLocked(ctx context.Context, repoID int64, baseBranch string) error {
+	repo, err := repo_model.GetRepositoryByID(ctx, repoID)
+	if err != nil {
+		if repo_model.IsErrRepoNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	entries, err := db.Find[pull_model.MergeQueueEntry](ctx, pull_model.FindMergeQueueEntriesOptions{
+		RepoID:     repoID,
+		BaseBranch: baseBranch,
+		Queued:     true,
+	})
+	if err != nil || len(entries) == 0 {
+		return err
+	}
+
+	gitRepo, closer, err := git.RepositoryFromContextOrOpen(ctx, repo)
+	if err != nil {
+		return err
+	}
+	defer closer.Close()
+	baseCommitID, err := gitRepo.GetBranchCommitID(ctx, baseBranch)
+	if err != nil {
+		return err
+	}
+
+	// 1. Drop the pull requests which have been merged or closed meanwhile and eject the ones updated while being tested
+	pulls := make([]*queuedPull, 0, len(entries))
+	var removed []*queuedPull
+	for _, entry := range entries {
+		pr, err := issues_model.GetPullRequestByID(ctx, entry.PullID)
+		if err != nil {
+			if issues_model.IsErrPullRequestNotExist(err) {
+				if err := pull_model.DeleteMergeQueueEntry(ctx, entry); err != nil {
+					return err
+				}
+				continue
+			}
+			return err
+		}
+		if err := pr.LoadIssue(ctx); err != nil {
+			return err
+		}
+		if err := entry.LoadDoer(ctx); err != nil {
+			return err
+		}
+		pr.BaseRepo = repo
+		p := &queuedPull{entry: entry, pr: pr}
+
+		if pr.HasMerged || pr.Issue.IsClosed || pr.BaseBranch != baseBranch {
+			if err := pull_model.DeleteMergeQueueEntry(ctx, entry); err != nil {
+				return err
+			}
+			removed = append(removed, p)
+			continue
+		}
+		if entry.Status == pull_model.MergeQueueEntryStatusTesting {
+			headCommitID, err := gitRepo.GetRefCommitID(ctx, pr.GetGitHeadRefName())
+			if err != nil {
+				return err
+			}
+			if headCommitID != entry.HeadCommitID {
+				if err := ejectEntry(ctx, repo, p, "the head branch of the pull request was updated"); err != nil {
+					return err
+				}
+				continue
+			}
+		}
+		pulls = append(pulls, p)
+	}
+	if len(removed) > 0 {
+		if err := pull_service.DeleteMergeQueueBranches(ctx, repo, removed[0].entry.Doer, pullRequestsOf(removed)); err != nil {
+			log.Error("DeleteMergeQueueBranches: %v", err)
+		}
+	}
+
+	// 2. Each speculative merge must still be built onto the base branch or the speculative merge of the entry before it,
+	// the entries are rebuilt from the first one which is not
+	testing := 0
+	expectedBaseCommitID := baseCommitID
+	for i, p := range pulls {
+		if p.entry.Status != pull_model.MergeQueueEntryStatusTesting || p.entry.BaseCommitID != expectedBaseCommitID {
+			if err := resetEntries(ctx, pulls[i:]); err != nil {
+				return err
+			}
+			break
+		}
+		expectedBaseCommitID = p.entry.MergeCommitID
+		testing++
+	}
+
+	// 3. Fast-forward the base branch to the last speculative merge which passed its checks and eject the first failing one,
+	// the next run checks the remaining entries again
+	if testing > 0 {
+		ev, err := evaluateEntries(ctx, repo, baseBranch, pulls[:testing])
+		if err != nil {
+			return err
+		}
+		if ev.MergeUpTo >= 0 {
+			if err := mergeEntries(ctx, repo, baseBranch, pulls[:ev.MergeUpTo+1]); err != nil {
+				return err
+			}
+		}
+		if ev.Eject >= 0 {
+			if err := ejectEntry(ctx, repo, pulls[ev.Eject], ev.Reason); err != nil {
+				return err
+			}
+		}
+		if ev.MergeUpTo >= 0 || ev.Eject >= 0 {
+			AddToQueue(repoID, baseBranch)
+			return nil
+		}
+	}
+
+	// 4. Build the speculative merges of the waiting entries until the batch is full
+	if testing > 0 {
+		expectedBaseCommitID = pulls[testing-1].entry.MergeCommitID
+	}
+	for _, p := range pulls[testing:] {
+		if testing >= setting.Repository.PullRequest.MergeQueueMaxBatchSize {
+			break
+		}
+		built, err := buildEntry(ctx, repo, p, expectedBaseCommitID)
+		if err != nil {
+			return err
+		}
+		if built {
+			expectedBaseCommitID = p.entry.MergeCommitID
+			testing++
+		}
+	}
+	return nil
+}
+
+// evaluation is the outcome of the status checks of the speculative merges being tested
+type evaluation struct {
+	// MergeUpTo is the index of the last entry whose speculative merge passed its checks, -1 if none. A speculative merge
+	// contains the entries queued before it, so they are merged with it even if their own checks are still pending.
+	MergeUpTo int
+	// Eject is the index of the first entry whose checks failed or timed out, -1 if none
+	Eject  int
+	Reason string
+}
+
+// evaluateStates returns which entries can be merged and which one must be ejected given the state of the required
+// checks of their speculative merges
+func evaluateStates(entries []*pull_model.MergeQueueEntry, states []commitstatus.CommitStatusState, now timeutil.TimeStamp, timeout time.Duration) evaluation {
+	ev := evaluation{MergeUpTo: -1, Eject: -1}
+	for i, entry := range entries {
+		switch {
+		case states[i].IsSuccess():
+			ev.MergeUpTo = i
+		case states[i].IsFailure():
+			ev.Eject, ev.Reason = i, fmt.Sprintf("the required checks of the speculative merge %s failed", entry.MergeCommitID)
+			return ev
+		case timeout > 0 && now >= entry.TestedUnix.AddDuration(timeout):
+			ev.Eject, ev.Reason = i, fmt.Sprintf("the required checks of the speculative merge %s did not complete within %s", entry.MergeCommitID, timeout)
+			return ev
+		}
+	}
+	return ev
+}
+
+func evaluateEntries(ctx context.Context, repo *repo_model.Repository, baseBranch string, pulls []*queuedPull) (evaluation, error) {
+	required, err := pull_service.MergeQueueRequiredContexts(ctx, repo, baseBranch)
+	if err != nil {
+		return evaluation{}, err
+	}
+	entries := make([]*pull_model.MergeQueueEntry, 0, len(pulls))
+	states := make([]commitstatus.CommitStatusState, 0, len(pulls))
+	for _, p := range pulls {
+		statuses, err := git_model.GetLatestCommitStatus(ctx, repo.ID, p.entry.MergeCommitID, db.ListOptionsAll)
+		if err != nil {
+			return evaluation{}, err
+		}
+		entries = append(entries, p.entry)
+		states = append(states, pull_service.MergeRequiredContextsCommitStatus(statuses, required))
+	}
+	return evaluateStates(entries, states, timeutil.TimeStampNow(), setting.Repository.PullRequest.MergeQueueCheckTimeout), nil
+}
+
+// mergeEntries fast-forwards the base branch to the speculative merge of the last entry and marks all the pull requests as merged
+func mergeEntries(ctx context.Context, repo *repo_model.Repository, baseBranch string, pulls []*queuedPull) error {
+	last := pulls[len(pulls)-1]
+	if err := pull_service.FastForwardMergeQueue(ctx, repo, last.entry.Doer, baseBranch, last.entry.MergeCommitID); err != nil {
+		if git.IsErrPushOutOfDate(err) {
+			// the base branch has been updated meanwhile, the next run rebuilds the speculative merges onto it
+			return nil
+		}
+		if pushErr, ok := errors.AsType[*git.ErrPushRejected](err); ok {
+			return ejectEntry(ctx, repo, last, "the speculative merge was rejected by the base branch: "+pushErr.Message)
+		}
+		return err
+	}
+	log.Trace("Merge queue of branch %s of %-v fast-forwarded to %s", baseBranch, repo, last.entry.MergeCommitID)
+
+	for _, p := range pulls {
+		if err := pull_model.DeleteMergeQueueEntry(ctx, p.entry); err != nil {
+			return err
+		}
+		if err := pull_service.SetMergeQueueMerged(ctx, p.pr, p.entry.Doer, p.entry.MergeCommitID); err != nil {
+			log.Error("SetMergeQueueMerged %-v: %v", p.pr, err)
+			continue
+		}
+
+		deleteBranchAfterMerge, err := pull_service.ShouldDeleteBranchAfterMerge(ctx, &p.entry.DeleteBranchAfterMerge, repo, p.pr)
+		if err != nil {
+			log.Error("ShouldDeleteBranchAfterMerge: %v", err)
+		} else if deleteBranchAfterMerge {
+			if err := repo_service.DeleteBranchAfterMerge(ctx, p.entry.Doer, p.pr.ID, nil); err != nil {
+				log.Error("DeleteBranchAfterMerge: %v", err)
+			}
+		}
+	}
+
+	if err := pull_service.DeleteMergeQueueBranches(ctx, repo, last.entry.Doer, pullRequestsOf(pulls)); err != nil {
+		log.Error("DeleteMergeQueueBranches: %v", err)
+	}
+	return nil
+}
+
+// ejectEntry removes a pull request from the queue, the entry is kept with the reason until the pull request is queued again
+func ejectEntry(ctx context.Context, repo *repo_model.Repository, p *queuedPull, reason string) error {
+	log.Info("%-v ejected from the merge queue of branch %s: %s", p.pr, p.entry.BaseBranch, reason)
+	p.entry.Status = pull_model.MergeQueueEntryStatusEjected
+	p.entry.Reason = reason
+	if err := pull_model.UpdateMergeQueueEntry(ctx, p.entry, "status", "reason"); err != nil {
+		return err
+	}
+	if err := pull_service.DeleteMergeQueueBranches(ctx, repo, p.entry.Doer, []*issues_model.PullRequest{p.pr}); err != nil {
+		log.Error("DeleteMergeQueueBranches %-v: %v", p.pr, err)
+	}
+	return nil
+}
+
+// resetEntries marks the entries as waiting so their speculative merges are rebuilt
+func resetEntries(ctx context.Context, pulls []*queuedPull) error {
+	for _, p := range pulls {
+		if p.entry.Status == pull_model.MergeQueueEntryStatusWaiting {
+			continue
+		}
+		p.entry.Status = pull_model.MergeQueueEntryStatusWaiting
+		p.entry.HeadCommitID, p.entry.BaseCommitID, p.entry.MergeCommitID = "", "", ""
+		p.entry.TestedUnix = 0
+		if err := pull_model.UpdateMergeQueueEntry(ctx, p.entry, "status", "head_commit_id", "base_commit_id", "merge_commit_id", "tested_unix"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// isEjectingCheckError returns true if the pull request cannot be merged anymore and must leave the queue
+func isEjectingCheckError(err error) bool {
+	for _, target := range []error{
+		pull_service.ErrIsClosed,
+		pull_service.ErrHasMerged,
+		pull_service.ErrNoPermissionToMerge,
+		pull_service.ErrNotReadyToMerge,
+		pull_service.ErrIsWorkInProgress,
+		pull_service.ErrDependenciesLeft,
+		pull_service.ErrHeadCommitsNotAllVerified,
+		pull_service.ErrRulesetViolated,
+	} {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return asymkey_service.IsErrWontSign(err)
+}
+
+// buildEntry merges a waiting entry onto baseCommitID and pushes the result to its merge queue branch,
+// which triggers its checks. It returns false if the entry has been ejected instead.
+func buildEntry(ctx context.Context, repo *repo_model.Repository, p *queuedPull, baseCommitID string) (bool, error) {
+	perm, err := access_model.GetDoerRepoPermission(ctx, repo, p.entry.Doer)
+	if err != nil {
+		return false, err
+	}
+	// the conflicts are detected by the speculative merge itself, the pull request may only conflict with the base branch
+	if err := pull_service.CheckPullMergeable(ctx, p.entry.Doer, &perm, p.pr, pull_service.MergeCheckTypeQueue, p.entry.MergeStyle, false); err != nil &&
+		!errors.Is(err, pull_service.ErrIsChecking) && !errors.Is(err, pull_service.ErrNotMergeableState) {
+		if !isEjectingCheckError(err) {
+			return false, err
+		}
+		return false, ejectEntry(ctx, repo, p, err.Error())
+	}
+
+	headCommitID, mergeCommitID, err := pull_service.CreateMergeQueueCommit(ctx, p.pr, p.entry.Doer, p.entry.MergeStyle, p.entry.Message, baseCommitID)
+	if err != nil {
+		if pull_service.IsErrMergeQueueEjecting(err) {
+			return false, ejectEntry(ctx, repo, p, fmt.Sprintf("the pull request cannot be merged with style %s onto the base branch and the pull requests queued before it", p.entry.MergeStyle))
+		}
+		if pushErr, ok := errors.AsType[*git.ErrPushRejected](err); ok {
+			return false, ejectEntry(ctx, repo, p, "the speculative merge was rejected: "+pushErr.Message)
+		}
+		return false, err
+	}
+
+	p.entry.Status = pull_model.MergeQueueEntryStatusTesting
+	p.entry.HeadCommitID = headCommitID
+	p.entry.BaseCommitID = baseCommitID
+	p.entry.MergeCommitID = mergeCommitID
+	p.entry.TestedUnix = timeutil.TimeStampNow()
+	p.entry.Reason = ""
+	if err := pull_model.UpdateMergeQueueEntry(ctx, p.entry, "status", "head_commit_id", "base_commit_id", "merge_commit_id", "tested_unix", "reason"); err != nil {
+		return false, err
+	}
+	log.Trace("Speculative merge %s of %-v pushed to %s", mergeCommitID, p.pr, pull_service.MergeQueueBranchName(p.pr))
+	return true, nil
+}
@@ -0,0 +1,45 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package mergequeue
+
+import (
+	"testing"
+	"time"
+
+	pull_model "gitea.dev/models/pull"
+	"gitea.dev/modules/commitstatus"
+	"gitea.dev/modules/timeutil"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvaluateStates(t *testing.T) {
+	const now = timeutil.TimeStamp(10000)
+	entries := []*pull_model.MergeQueueEntry{
+		{MergeCommitID: "m1", TestedUnix: now - 60},
+		{MergeCommitID: "m2", TestedUnix: now - 60},
+		{MergeCommitID: "m3", TestedUnix: now - 7200},
+	}
+	pending, success, failure := commitstatus.CommitStatusPending, commitstatus.CommitStatusSuccess, commitstatus.CommitStatusFailure
+
+	ev := evaluateStates(entries[:2], []commitstatus.CommitStatusState{pending, pending}, now, time.Hour)
+	assert.Equal(t, evaluation{MergeUpTo: -1, Eject: -1}, ev)
+
+	ev = evaluateStates(entries[:2], []commitstatus.CommitStatusState{pending, success}, now, time.Hour)
+	assert.Equal(t, 1, ev.MergeUpTo, "the speculative merge of an entry contains the entries before it")
+	assert.Equal(t, -1, ev.Eject)
+
+	ev = evaluateStates(entries, []commitstatus.CommitStatusState{success, failure, success}, now, time.Hour)
+	assert.Equal(t, 0, ev.MergeUpTo)
+	assert.Equal(t, 1, ev.Eject, "the entries after a failing one are not merged")
+	assert.Contains(t, ev.Reason, "m2 failed")
+
+	ev = evaluateStates(entries, []commitstatus.CommitStatusState{success, pending, pending}, now, time.Hour)
+	assert.Equal(t, 0, ev.MergeUpTo)
+	assert.Equal(t, 2, ev.Eject)
+	assert.Contains(t, ev.Reason, "did not complete within 1h0m0s")
+
+	ev = evaluateStates(entries, []commitstatus.CommitStatusState{success, pending, pending}, now, 0)
+	assert.Equal(t, -1, ev.Eject, "the checks never time out without a timeout")
+}
@@ -0,0 +1,928 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package migrations
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"gitea.dev/modules/json"
+	"gitea.dev/modules/log"
+	base "gitea.dev/modules/migration"
+	"gitea.dev/modules/structs"
+	"gitea.dev/modules/util"
+)
+
+var (
+	_ base.Downloader        = &AzureDevOpsDownloader{}
+	_ base.DownloaderFactory = &AzureDevOpsDownloaderFactory{}
+)
+
+func init() {
+	RegisterDownloaderFactory(&AzureDevOpsDownloaderFactory{})
+}
+
+const (
+	// azureDevOpsAPIVersion is supported by Azure DevOps Services and Azure DevOps Server 2022
+	azureDevOpsAPIVersion = "7.0"
+	// azureDevOpsCommentsAPIVersion is the version of the work item comments API, which is still a preview
+	azureDevOpsCommentsAPIVersion = "7.0-preview.3"
+	// azureDevOpsMaxPerPage is the largest number of work items which can be requested at once
+	azureDevOpsMaxPerPage = 200
+	// azureDevOpsMaxQueryResults is the largest number of results of a work item query
+	azureDevOpsMaxQueryResults = 20000
+	// azureDevOpsHiddenCategory contains the work item types which aren't shown in the backlogs, like test cases
+	azureDevOpsHiddenCategory = "Microsoft.HiddenCategory"
+)
+
+// AzureDevOpsDownloaderFactory defines a downloader factory for Azure DevOps Services and Azure DevOps Server
+type AzureDevOpsDownloaderFactory struct{}
+
+// New returns a downloader related to this factory according MigrateOptions
+func (f *AzureDevOpsDownloaderFactory) New(ctx context.Context, opts base.MigrateOptions) (base.Downloader, error) {
+	u, err := url.Parse(opts.CloneAddr)
+	if err != nil {
+		return nil, err
+	}
+	u.User = nil
+
+	baseURL, project, repoName, err := parseAzureDevOpsURL(u)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Trace("Create Azure DevOps downloader. BaseURL: %s Project: %s RepoName: %s", baseURL, project, repoName)
+	return NewAzureDevOpsDownloader(ctx, baseURL, project, repoName, opts.AuthUsername, opts.AuthPassword, opts.AuthToken), nil
+}
+
+// GitServiceType returns the type of git service
+func (f *AzureDevOpsDownloaderFactory) GitServiceType() structs.GitServiceType {
+	return structs.AzureDevOpsService
+}
+
+// parseAzureDevOpsURL parses the URL of the organization or the collection, the project and the name of
+// a repository from its URL, like https://dev.azure.com/{org}/{project}/_git/{repo},
+// https://{org}.visualstudio.com/{project}/_git/{repo} or https://{server}/{collection}/{project}/_git/{repo}.
+// The project can be omitted from the URL if the repository has the name of the project.
+func parseAzureDevOpsURL(u *url.URL) (baseURL *url.URL, project, repoName string, err error) {
+	fields := strings.Split(strings.Trim(u.Path, "/"), "/")
+	i := slices.Index(fields, "_git")
+	if i < 0 || i+1 >= len(fields) || fields[i+1] == "" {
+		return nil, "", "", fmt.Errorf("invalid Azure DevOps repository path: %s", u.Path)
+	}
+	repoName = strings.TrimSuffix(fields[i+1], ".git")
+
+	// the organization is the host of the legacy URLs, else it's the first part of the path
+	collectionFields := 1
+	if strings.HasSuffix(strings.ToLower(u.Hostname()), ".visualstudio.com") {
+		collectionFields = 0
+	}
+	if i < collectionFields {
+		return nil, "", "", fmt.Errorf("invalid Azure DevOps repository path: %s", u.Path)
+	}
+
+	project = repoName
+	collection := fields[:i]
+	if i > collectionFields {
+		project = fields[i-1]
+		collection = fields[:i-1]
+	}
+
+	baseURL = &url.URL{Scheme: u.Scheme, Host: u.Host}
+	if len(collection) > 0 {
+		baseURL.Path = "/" + strings.Join(collection, "/")
+	}
+	return baseURL, project, repoName, nil
+}
+
+type azureDevOpsIdentity struct {
+	ID          string `json:"id"`
+	DisplayName string `json:"displayName"`
+	UniqueName  string `json:"uniqueName"`
+}
+
+// Name returns the display name of the identity
+func (i *azureDevOpsIdentity) Name() string {
+	if i == nil {
+		return ""
+	}
+	return i.DisplayName
+}
+
+// Email returns the unique name of the identity if it's an email address, local accounts use DOMAIN\user
+func (i *azureDevOpsIdentity) Email() string {
+	if i == nil || !strings.Contains(i.UniqueName, "@") {
+		return ""
+	}
+	return i.UniqueName
+}
+
+type azureDevOpsIssueContext struct {
+	IsPullRequest bool
+}
+
+type azureDevOpsWorkItemType struct {
+	Name       string `json:"name"`
+	Color      string `json:"color"`
+	IsDisabled bool   `json:"isDisabled"`
+	States     []struct {
+		Name     string `json:"name"`
+		Color    string `json:"color"`
+		Category string `json:"category"` // Proposed, InProgress, Resolved, Completed or Removed
+	} `json:"states"`
+}
+
+type azureDevOpsClassificationNode struct {
+	Name       string `json:"name"`
+	Attributes *struct {
+		StartDate  *time.Time `json:"startDate"`
+		FinishDate *time.Time `json:"finishDate"`
+	} `json:"attributes"`
+	Children []*azureDevOpsClassificationNode `json:"children"`
+}
+
+// walkAzureDevOpsClassificationNodes calls fn with the path of each node below the root, the path is
+// relative to the project like the area and the iteration paths of the work items without the project
+func walkAzureDevOpsClassificationNodes(root *azureDevOpsClassificationNode, fn func(path string, node *azureDevOpsClassificationNode)) {
+	var walk func(prefix string, nodes []*azureDevOpsClassificationNode)
+	walk = func(prefix string, nodes []*azureDevOpsClassificationNode) {
+		for _, node := range nodes {
+			path := prefix + node.Name
+			fn(path, node)
+			walk(path+`\`, node.Children)
+		}
+	}
+	walk("", root.Children)
+}
+
+type azureDevOpsPullRequest struct {
+	PullRequestID int64                `json:"pullRequestId"`
+	Status        string               `json:"status"` // active, abandoned or completed
+	CreatedBy     *azureDevOpsIdentity `json:"createdBy"`
+	CreationDate  time.Time            `json:"creationDate"`
+	ClosedDate    *time.Time           `json:"closedDate"`
+	Title         string               `json:"title"`
+	Description   string               `json:"description"`
+	SourceRefName string               `json:"sourceRefName"`
+	TargetRefName string               `json:"targetRefName"`
+	IsDraft       bool                 `json:"isDraft"`
+	ForkSource    *struct {
+		Repository struct {
+			Name      string `json:"name"`
+			RemoteURL string `json:"remoteUrl"`
+			Project   struct {
+				Name string `json:"name"`
+			} `json:"project"`
+		} `json:"repository"`
+	} `json:"forkSource"`
+	LastMergeSourceCommit *azureDevOpsCommit `json:"lastMergeSourceCommit"`
+	LastMergeTargetCommit *azureDevOpsCommit `json:"lastMergeTargetCommit"`
+	LastMergeCommit       *azureDevOpsCommit `json:"lastMergeCommit"`
+	Reviewers             []struct {
+		azureDevOpsIdentity
+		Vote int `json:"vote"` // 10 approved, 5 approved with suggestions, -5 waiting for author, -10 rejected
+	} `json:"reviewers"`
+}
+
+type azureDevOpsCommit struct {
+	CommitID string `json:"commitId"`
+}
+
+func (c *azureDevOpsCommit) SHA() string {
+	if c == nil {
+		return ""
+	}
+	return c.CommitID
+}
+
+type azureDevOpsThread struct {
+	ID            int64 `json:"id"`
+	IsDeleted     bool  `json:"isDeleted"`
+	ThreadContext *struct {
+		FilePath       string                      `json:"filePath"`
+		LeftFileStart  *azureDevOpsCommentPosition `json:"leftFileStart"`
+		RightFileStart *azureDevOpsCommentPosition `json:"rightFileStart"`
+	} `json:"threadContext"`
+	Comments []struct {
+		ID              int64                `json:"id"`
+		ParentCommentID int64                `json:"parentCommentId"`
+		Author          *azureDevOpsIdentity `json:"author"`
+		Content         string               `json:"content"`
+		PublishedDate   time.Time            `json:"publishedDate"`
+		LastUpdatedDate time.Time            `json:"lastUpdatedDate"`
+		CommentType     string               `json:"commentType"` // text, codeChange or system
+		IsDeleted       bool                 `json:"isDeleted"`
+	} `json:"comments"`
+	Properties map[string]struct {
+		Value any `json:"$value"`
+	} `json:"properties"`
+}
+
+type azureDevOpsCommentPosition struct {
+	Line int `json:"line"`
+}
+
+// AzureDevOpsDownloader implements a Downloader interface to get repository information from
+// Azure DevOps Services or Azure DevOps Server. The work items of the project are migrated as issues.
+type AzureDevOpsDownloader struct {
+	base.NullDownloader
+	client        *http.Client
+	baseURL       *url.URL
+	project       string
+	repoName      string
+	workItemIDs   []int64
+	maxIssueIndex int64
+	workItemTypes []*azureDevOpsWorkItemType
+}
+
+// NewAzureDevOpsDownloader creates a new downloader, baseURL is the URL of the organization or the collection
+func NewAzureDevOpsDownloader(ctx context.Context, baseURL *url.URL, project, repoName, username, password, token string) *AzureDevOpsDownloader {
+	httpTransport := NewMigrationHTTPTransport()
+	return &AzureDevOpsDownloader{
+		client: &http.Client{
+			Transport: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				// personal access tokens are sent as password with any user name
+				if token != "" {
+					req.SetBasicAuth(username, token)
+				} else if username != "" && password != "" {
+					req.SetBasicAuth(username, password)
+				}
+				return httpTransport.RoundTrip(req.WithContext(ctx))
+			}),
+		},
+		baseURL:  baseURL,
+		project:  project,
+		repoName: repoName,
+	}
+}
+
+// String implements Stringer
+func (d *AzureDevOpsDownloader) String() string {
+	return fmt.Sprintf("migration from Azure DevOps %s %s/%s", d.baseURL, d.project, d.repoName)
+}
+
+func (d *AzureDevOpsDownloader) LogString() string {
+	if d == nil {
+		return "<AzureDevOpsDownloader nil>"
+	}
+	return fmt.Sprintf("<AzureDevOpsDownloader %s %s/%s>", d.baseURL, d.project, d.repoName)
+}
+
+// FormatCloneURL add authentication into remote URLs
+func (d *AzureDevOpsDownloader) FormatCloneURL(opts base.MigrateOptions, remoteAddr string) (string, error) {
+	if opts.AuthToken == "" && opts.AuthUsername == "" {
+		return remoteAddr, nil
+	}
+	u, err := url.Parse(remoteAddr)
+	if err != nil {
+		return "", err
+	}
+	if opts.AuthToken != "" {
+		// Azure Repos accepts any user name with a personal access token
+		username := opts.AuthUsername
+		if username == "" {
+			username = "pat"
+		}
+		u.User = url.UserPassword(username, opts.AuthToken)
+	} else {
+		u.User = url.UserPassword(opts.AuthUsername, opts.AuthPassword)
+	}
+	return u.String(), nil
+}
+
+func (d *AzureDevOpsDownloader) projectEndpoint(format string, args ...any) string {
+	return "/" + url.PathEscape(d.project) + "/_apis" + fmt.Sprintf(format, args...)
+}
+
+func (d *AzureDevOpsDownloader) repoEndpoint(format string, args ...any) string {
+	return d.projectEndpoint("/git/repositories/%s", url.PathEscape(d.repoName)) + fmt.Sprintf(format, args...)
+}
+
+// callAPI requests the endpoint below the URL of the organization and decodes the JSON response into result,
+// the request body is sent as JSON if it's not nil
+func (d *AzureDevOpsDownloader) callAPI(ctx context.Context, method, endpoint string, query url.Values, body, result any) error {
+	u, err := url.Parse(strings.TrimSuffix(d.baseURL.String(), "/") + endpoint)
+	if err != nil {
+		return err
+	}
+	if query == nil {
+		query = url.Values{}
+	}
+	if !query.Has("api-version") {
+		query.Set("api-version", azureDevOpsAPIVersion)
+	}
+	u.RawQuery = query.Encode()
+
+	var reqBody io.Reader
+	if body != nil {
+		bs, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reqBody = bytes.NewReader(bs)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), reqBody)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return util.NewNotExistErrorf("Azure DevOps API %s: not found", u.Path)
+	}
+	// unauthenticated requests are answered with 203 and the sign in page
+	if resp.StatusCode != http.StatusOK {
+		bs, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("Azure DevOps API %s returned status %d: %s", u.Path, resp.StatusCode, bs)
+	}
+	return json.NewDecoder(resp.Body).Decode(result)
+}
+
+// GetRepoInfo returns repository information
+func (d *AzureDevOpsDownloader) GetRepoInfo(ctx context.Context) (*base.Repository, error) {
+	var repo struct {
+		Name          string `json:"name"`
+		DefaultBranch string `json:"defaultBranch"`
+		RemoteURL     string `json:"remoteUrl"`
+		WebURL        string `json:"webUrl"`
+		Project       struct {
+			Name        string `json:"name"`
+			Description string `json:"description"`
+			Visibility  string `json:"visibility"` // private or public
+		} `json:"project"`
+	}
+	if err := d.callAPI(ctx, http.MethodGet, d.repoEndpoint(""), nil, nil, &repo); err != nil {
+		return nil, err
+	}
+
+	// the remote URL contains the user name of the authenticated user
+	cloneURL, err := url.Parse(repo.RemoteURL)
+	if err != nil {
+		return nil, err
+	}
+	cloneURL.User = nil
+
+	return &base.Repository{
+		Name:          repo.Name,
+		Owner:         repo.Project.Name,
+		IsPrivate:     repo.Project.Visibility != "public",
+		Description:   repo.Project.Description,
+		CloneURL:      cloneURL.String(),
+		OriginalURL:   repo.WebURL,
+		DefaultBranch: strings.TrimPrefix(repo.DefaultBranch, "refs/heads/"),
+	}, nil
+}
+
+// GetTopics return repository topics
+func (d *AzureDevOpsDownloader) GetTopics(_ context.Context) ([]string, error) {
+	return []string{}, nil
+}
+
+func (d *AzureDevOpsDownloader) getClassificationNodes(ctx context.Context, structureGroup string) (*azureDevOpsClassificationNode, error) {
+	var root azureDevOpsClassificationNode
+	err := d.callAPI(ctx, http.MethodGet, d.projectEndpoint("/wit/classificationnodes/%s", structureGroup), url.Values{
+		"$depth": {"100"},
+	}, nil, &root)
+	return &root, err
+}
+
+// GetMilestones returns the iterations as milestones
+func (d *AzureDevOpsDownloader) GetMilestones(ctx context.Context) ([]*base.Milestone, error) {
+	root, err := d.getClassificationNodes(ctx, "Iterations")
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	milestones := make([]*base.Milestone, 0, 10)
+	walkAzureDevOpsClassificationNodes(root, func(path string, node *azureDevOpsClassificationNode) {
+		milestone := &base.Milestone{
+			Title: path,
+			State: "open",
+		}
+		if node.Attributes != nil {
+			if node.Attributes.StartDate != nil {
+				milestone.Created = *node.Attributes.StartDate
+			}
+			milestone.Deadline = node.Attributes.FinishDate
+			// the iterations which are over are closed
+			if milestone.Deadline != nil && milestone.Deadline.Before(now) {
+				milestone.State = "closed"
+				milestone.Closed = milestone.Deadline
+			}
+		}
+		milestones = append(milestones, milestone)
+	})
+	return milestones, nil
+}
+
+// getWorkItemTypes returns the enabled work item types which are shown in the backlogs
+func (d *AzureDevOpsDownloader) getWorkItemTypes(ctx context.Context) ([]*azureDevOpsWorkItemType, error) {
+	if d.workItemTypes != nil {
+		return d.workItemTypes, nil
+	}
+
+	var categories struct {
+		Value []struct {
+			ReferenceName string `json:"referenceName"`
+			WorkItemTypes []struct {
+				Name string `json:"name"`
+			} `json:"workItemTypes"`
+		} `json:"value"`
+	}
+	if err := d.callAPI(ctx, http.MethodGet, d.projectEndpoint("/wit/workitemtypecategories"), nil, nil, &categories); err != nil {
+		return nil, err
+	}
+	var hiddenTypes []string
+	for _, category := range categories.Value {
+		if category.ReferenceName == azureDevOpsHiddenCategory {
+			for _, t := range category.WorkItemTypes {
+				hiddenTypes = append(hiddenTypes, t.Name)
+			}
+		}
+	}
+
+	var types struct {
+		Value []*azureDevOpsWorkItemType `json:"value"`
+	}
+	if err := d.callAPI(ctx, http.MethodGet, d.projectEndpoint("/wit/workitemtypes"), nil, nil, &types); err != nil {
+		return nil, err
+	}
+	d.workItemTypes = make([]*azureDevOpsWorkItemType, 0, len(types.Value))
+	for _, t := range types.Value {
+		if !t.IsDisabled && !slices.Contains(hiddenTypes, t.Name) {
+			d.workItemTypes = append(d.workItemTypes, t)
+		}
+	}
+	return d.workItemTypes, nil
+}
+
+// GetLabels returns the work item types, their states and the areas as labels
+func (d *AzureDevOpsDownloader) GetLabels(ctx context.Context) ([]*base.Label, error) {
+	types, err := d.getWorkItemTypes(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	labels := make([]*base.Label, 0, 20)
+	for _, t := range types {
+		labels = append(labels, &base.Label{Name: "type/" + t.Name, Color: strings.ToLower(t.Color), Exclusive: true})
+	}
+	// the work item types share most of their states
+	states := make(map[string]bool)
+	for _, t := range types {
+		for _, state := range t.States {
+			if states[state.Name] {
+				continue
+			}
+			states[state.Name] = true
+			labels = append(labels, &base.Label{Name: "state/" + state.Name, Color: strings.ToLower(state.Color), Exclusive: true})
+		}
+	}
+
+	root, err := d.getClassificationNodes(ctx, "Areas")
+	if err != nil {
+		return nil, err
+	}
+	walkAzureDevOpsClassificationNodes(root, func(path string, _ *azureDevOpsClassificationNode) {
+		labels = append(labels, &base.Label{Name: "area/" + path, Color: "c5def5", Exclusive: true})
+	})
+	return labels, nil
+}
+
+// queryWorkItemIDs returns the IDs of all the work items of the project shown in the backlogs
+func (d *AzureDevOpsDownloader) queryWorkItemIDs(ctx context.Context) ([]int64, error) {
+	ids := make([]int64, 0, 100)
+	var lastID int64
+	for {
+		var result struct {
+			WorkItems []struct {
+				ID int64 `json:"id"`
+			} `json:"workItems"`
+		}
+		// the number of the results of a query is limited, so the work items are queried in batches
+		query := fmt.Sprintf("SELECT [System.Id] FROM WorkItems WHERE [System.TeamProject] = @project AND [System.Id] > %d "+
+			"AND [System.WorkItemType] NOT IN GROUP '%s' ORDER BY [System.Id]", lastID, azureDevOpsHiddenCategory)
+		err := d.callAPI(ctx, http.MethodPost, d.projectEndpoint("/wit/wiql"), url.Values{
+			"$top": {strconv.Itoa(azureDevOpsMaxQueryResults)},
+		}, map[string]string{"query": query}, &result)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range result.WorkItems {
+			ids = append(ids, item.ID)
+			lastID = item.ID
+		}
+		if len(result.WorkItems) < azureDevOpsMaxQueryResults {
+			return ids, nil
+		}
+	}
+}
+
+// relativeAzureDevOpsPath returns the area or iteration path without the project, the root has an empty path
+func relativeAzureDevOpsPath(path string) string {
+	_, rel, _ := strings.Cut(path, `\`)
+	return rel
+}
+
+// GetIssues returns the work items as issues
+func (d *AzureDevOpsDownloader) GetIssues(ctx context.Context, page, perPage int) ([]*base.Issue, bool, error) {
+	if d.workItemIDs == nil {
+		ids, err := d.queryWorkItemIDs(ctx)
+		if err != nil {
+			return nil, false, err
+		}
+		d.workItemIDs = ids
+		if len(ids) > 0 {
+			d.maxIssueIndex = ids[len(ids)-1]
+		}
+	}
+	types, err := d.getWorkItemTypes(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+
+	perPage = min(perPage, azureDevOpsMaxPerPage)
+	start := (page - 1) * perPage
+	if start >= len(d.workItemIDs) {
+		return []*base.Issue{}, true, nil
+	}
+	end := min(start+perPage, len(d.workItemIDs))
+
+	ids := make([]string, 0, end-start)
+	for _, id := range d.workItemIDs[start:end] {
+		ids = append(ids, strconv.FormatInt(id, 10))
+	}
+	var result struct {
+		Value []*struct {
+			ID     int64 `json:"id"`
+			Fields struct {
+				Title         string               `json:"System.Title"`
+				Description   string               `json:"System.Description"`
+				ReproSteps    string               `json:"Microsoft.VSTS.TCM.ReproSteps"`
+				WorkItemType  string               `json:"System.WorkItemType"`
+				State         string               `json:"System.State"`
+				AreaPath      string               `json:"System.AreaPath"`
+				IterationPath string               `json:"System.IterationPath"`
+				CreatedBy     *azureDevOpsIdentity `json:"System.CreatedBy"`
+				CreatedDate   time.Time            `json:"System.CreatedDate"`
+				ChangedDate   time.Time            `json:"System.ChangedDate"`
+				ClosedDate    *time.Time           `json:"Microsoft.VSTS.Common.ClosedDate"`
+			} `json:"fields"`
+		} `json:"value"`
+	}
+	// the work items deleted since the query are omitted
+	err = d.callAPI(ctx, http.MethodGet, d.projectEndpoint("/wit/workitems"), url.Values{
+		"ids":         {strings.Join(ids, ",")},
+		"errorPolicy": {"omit"},
+	}, nil, &result)
+	if err != nil {
+		return nil, false, err
+	}
+
+	issues := make([]*base.Issue, 0, len(result.Value))
+	for _, item := range result.Value {
+		if item == nil {
+			continue
+		}
+		fields := item.Fields
+
+		state := "open"
+		var closed *time.Time
+		if isAzureDevOpsStateClosed(types, fields.WorkItemType, fields.State) {
+			state = "closed"
+			closed = fields.ClosedDate
+			if closed == nil {
+				closed = &fields.ChangedDate
+			}
+		}
+
+		labels := []*base.Label{
+			{Name: "type/" + fields.WorkItemType},
+			{Name: "state/" + fields.State},
+		}
+		if area := relativeAzureDevOpsPath(fields.AreaPath); area != "" {
+			labels = append(labels, &base.Label{Name: "area/" + area})
+		}
+
+		// bugs are described by their repro steps
+		content := fields.Description
+		if content == "" {
+			content = fields.ReproSteps
+		}
+
+		issues = append(issues, &base.Issue{
+			Number:       item.ID,
+			Title:        fields.Title,
+			PosterName:   fields.CreatedBy.Name(),
+			PosterEmail:  fields.CreatedBy.Email(),
+			Content:      content,
+			Milestone:    relativeAzureDevOpsPath(fields.IterationPath),
+			State:        state,
+			Created:      fields.CreatedDate,
+			Updated:      fields.ChangedDate,
+			Closed:       closed,
+			Labels:       labels,
+			ForeignIndex: item.ID,
+			Context:      azureDevOpsIssueContext{IsPullRequest: false},
+		})
+	}
+	return issues, end == len(d.workItemIDs), nil
+}
+
+// isAzureDevOpsStateClosed returns whether the state of the work item type means that the work item is done or removed
+func isAzureDevOpsStateClosed(types []*azureDevOpsWorkItemType, workItemType, state string) bool {
+	for _, t := range types {
+		if t.Name != workItemType {
+			continue
+		}
+		for _, s := range t.States {
+			if s.Name == state {
+				return s.Category == "Completed" || s.Category == "Removed"
+			}
+		}
+	}
+	return state == "Closed" || state == "Done" || state == "Removed"
+}
+
+// GetComments returns the comments of a work item or the general comments of a pull request,
+// the comments on the files of the pull requests are returned as reviews
+func (d *AzureDevOpsDownloader) GetComments(ctx context.Context, commentable base.Commentable) ([]*base.Comment, bool, error) {
+	context, ok := commentable.GetContext().(azureDevOpsIssueContext)
+	if !ok {
+		return nil, false, fmt.Errorf("unexpected context: %+v", commentable.GetContext())
+	}
+	if context.IsPullRequest {
+		return d.getPullRequestComments(ctx, commentable)
+	}
+
+	comments := make([]*base.Comment, 0, 10)
+	continuationToken := ""
+	for {
+		var result struct {
+			Comments []struct {
+				ID           int64                `json:"id"`
+				Text         string               `json:"text"`
+				CreatedBy    *azureDevOpsIdentity `json:"createdBy"`
+				CreatedDate  time.Time            `json:"createdDate"`
+				ModifiedDate time.Time            `json:"modifiedDate"`
+				IsDeleted    bool                 `json:"isDeleted"`
+			} `json:"comments"`
+			ContinuationToken string `json:"continuationToken"`
+		}
+		query := url.Values{
+			"api-version": {azureDevOpsCommentsAPIVersion},
+			"$top":        {strconv.Itoa(azureDevOpsMaxPerPage)},
+			"order":       {"asc"},
+		}
+		if continuationToken != "" {
+			query.Set("continuationToken", continuationToken)
+		}
+		err := d.callAPI(ctx, http.MethodGet, d.projectEndpoint("/wit/workItems/%d/comments", commentable.GetForeignIndex()), query, nil, &result)
+		if err != nil {
+			return nil, false, err
+		}
+
+		for _, comment := range result.Comments {
+			if comment.IsDeleted {
+				continue
+			}
+			comments = append(comments, &base.Comment{
+				IssueIndex:  commentable.GetLocalIndex(),
+				Index:       comment.ID,
+				PosterName:  comment.CreatedBy.Name(),
+				PosterEmail: comment.CreatedBy.Email(),
+				Content:     comment.Text,
+				Created:     comment.CreatedDate,
+				Updated:     comment.ModifiedDate,
+			})
+		}
+
+		if result.ContinuationToken == "" || len(result.Comments) == 0 {
+			return comments, true, nil
+		}
+		continuationToken = result.ContinuationToken
+	}
+}
+
+func (d *AzureDevOpsDownloader) getThreads(ctx context.Context, prID int64) ([]*azureDevOpsThread, error) {
+	var result struct {
+		Value []*azureDevOpsThread `json:"value"`
+	}
+	if err := d.callAPI(ctx, http.MethodGet, d.repoEndpoint("/pullRequests/%d/threads", prID), nil, nil, &result); err != nil {
+		return nil, err
+	}
+	return result.Value, nil
+}
+
+// getPullRequestComments returns the comments of the threads which aren't on a file
+func (d *AzureDevOpsDownloader) getPullRequestComments(ctx context.Context, commentable base.Commentable) ([]*base.Comment, bool, error) {
+	threads, err := d.getThreads(ctx, commentable.GetForeignIndex())
+	if err != nil {
+		return nil, false, err
+	}
+
+	comments := make([]*base.Comment, 0, len(threads))
+	for _, thread := range threads {
+		if thread.IsDeleted || thread.ThreadContext != nil {
+			continue
+		}
+		for _, comment := range thread.Comments {
+			// the system comments record the updates of the pull request, like the votes
+			if comment.IsDeleted || comment.CommentType != "text" {
+				continue
+			}
+			comments = append(comments, &base.Comment{
+				IssueIndex:  commentable.GetLocalIndex(),
+				Index:       comment.ID,
+				PosterName:  comment.Author.Name(),
+				PosterEmail: comment.Author.Email(),
+				Content:     comment.Content,
+				Created:     comment.PublishedDate,
+				Updated:     comment.LastUpdatedDate,
+			})
+		}
+	}
+	return comments, true, nil
+}
+
+// GetPullRequests returns pull requests, they are numbered after the work items because Azure DevOps numbers them separately
+func (d *AzureDevOpsDownloader) GetPullRequests(ctx context.Context, page, perPage int) ([]*base.PullRequest, bool, error) {
+	var result struct {
+		Value []*azureDevOpsPullRequest `json:"value"`
+	}
+	err := d.callAPI(ctx, http.MethodGet, d.repoEndpoint("/pullrequests"), url.Values{
+		"searchCriteria.status": {"all"},
+		"$skip":                 {strconv.Itoa((page - 1) * perPage)},
+		"$top":                  {strconv.Itoa(perPage)},
+	}, nil, &result)
+	if err != nil {
+		return nil, false, err
+	}
+
+	pullRequests := make([]*base.PullRequest, 0, len(result.Value))
+	for _, pr := range result.Value {
+		state := "open"
+		merged := false
+		var closed, mergedTime *time.Time
+		var mergeCommitSHA string
+		if pr.Status != "active" {
+			state = "closed"
+			closed = pr.ClosedDate
+			if pr.Status == "completed" {
+				merged = true
+				mergedTime = pr.ClosedDate
+				mergeCommitSHA = pr.LastMergeCommit.SHA()
+			}
+		}
+
+		updated := pr.CreationDate
+		if pr.ClosedDate != nil {
+			updated = *pr.ClosedDate
+		}
+
+		head := base.PullRequestBranch{
+			Ref:       strings.TrimPrefix(pr.SourceRefName, "refs/heads/"),
+			SHA:       pr.LastMergeSourceCommit.SHA(),
+			OwnerName: d.project,
+			RepoName:  d.repoName,
+		}
+		if pr.ForkSource != nil {
+			if u, err := url.Parse(pr.ForkSource.Repository.RemoteURL); err == nil {
+				u.User = nil
+				head.CloneURL = u.String()
+			}
+			head.OwnerName = pr.ForkSource.Repository.Project.Name
+			head.RepoName = pr.ForkSource.Repository.Name
+		}
+
+		pullRequests = append(pullRequests, &base.PullRequest{
+			Number:         pr.PullRequestID + d.maxIssueIndex,
+			Title:          pr.Title,
+			PosterName:     pr.CreatedBy.Name(),
+			PosterEmail:    pr.CreatedBy.Email(),
+			Content:        pr.Description,
+			State:          state,
+			Created:        pr.CreationDate,
+			Updated:        updated,
+			Closed:         closed,
+			Merged:         merged,
+			MergedTime:     mergedTime,
+			MergeCommitSHA: mergeCommitSHA,
+			IsDraft:        pr.IsDraft,
+			Head:           head,
+			Base: base.PullRequestBranch{
+				Ref:       strings.TrimPrefix(pr.TargetRefName, "refs/heads/"),
+				SHA:       pr.LastMergeTargetCommit.SHA(),
+				OwnerName: d.project,
+				RepoName:  d.repoName,
+			},
+			ForeignIndex: pr.PullRequestID,
+			Context:      azureDevOpsIssueContext{IsPullRequest: true},
+		})
+
+		// SECURITY: Ensure that the PR is safe
+		_ = CheckAndEnsureSafePR(pullRequests[len(pullRequests)-1], d.baseURL.String(), d)
+	}
+
+	return pullRequests, len(result.Value) < perPage, nil
+}
+
+// GetReviews returns the votes of the reviewers and the comments on the files of a pull request
+func (d *AzureDevOpsDownloader) GetReviews(ctx context.Context, reviewable base.Reviewable) ([]*base.Review, error) {
+	var pr azureDevOpsPullRequest
+	if err := d.callAPI(ctx, http.MethodGet, d.repoEndpoint("/pullrequests/%d", reviewable.GetForeignIndex()), nil, nil, &pr); err != nil {
+		return nil, err
+	}
+	threads, err := d.getThreads(ctx, reviewable.GetForeignIndex())
+	if err != nil {
+		return nil, err
+	}
+
+	// the votes have no date, but each vote adds a system thread
+	voteDates := make(map[string]time.Time)
+	for _, thread := range threads {
+		if p, ok := thread.Properties["CodeReviewThreadType"]; !ok || p.Value != "VoteUpdate" || len(thread.Comments) == 0 {
+			continue
+		}
+		comment := thread.Comments[0]
+		if comment.Author != nil && comment.PublishedDate.After(voteDates[comment.Author.ID]) {
+			voteDates[comment.Author.ID] = comment.PublishedDate
+		}
+	}
+
+	reviews := make([]*base.Review, 0, len(pr.Reviewers)+len(threads))
+	for _, reviewer := range pr.Reviewers {
+		var state string
+		switch {
+		case reviewer.Vote > 0:
+			state = base.ReviewStateApproved
+		case reviewer.Vote < 0:
+			state = base.ReviewStateChangesRequested
+		default:
+			continue
+		}
+		createdAt, ok := voteDates[reviewer.ID]
+		if !ok {
+			createdAt = pr.CreationDate
+		}
+		reviews = append(reviews, &base.Review{
+			IssueIndex:   reviewable.GetLocalIndex(),
+			ReviewerName: reviewer.Name(),
+			CreatedAt:    createdAt,
+			State:        state,
+		})
+	}
+
+	for _, thread := range threads {
+		if thread.IsDeleted || thread.ThreadContext == nil || thread.ThreadContext.FilePath == "" {
+			continue
+		}
+		// the line in the new file, or the negated line in the old file for removed lines
+		var line int
+		if thread.ThreadContext.RightFileStart != nil {
+			line = thread.ThreadContext.RightFileStart.Line
+		} else if thread.ThreadContext.LeftFileStart != nil {
+			line = -thread.ThreadContext.LeftFileStart.Line
+		}
+		for _, comment := range thread.Comments {
+			if comment.IsDeleted || comment.CommentType != "text" {
+				continue
+			}
+			reviews = append(reviews, &base.Review{
+				IssueIndex:   reviewable.GetLocalIndex(),
+				ReviewerName: comment.Author.Name(),
+				CreatedAt:    comment.PublishedDate,
+				State:        base.ReviewStateCommented,
+				Comments: []*base.ReviewComment{{
+					ID:        comment.ID,
+					InReplyTo: comment.ParentCommentID,
+					Content:   comment.Content,
+					TreePath:  strings.TrimPrefix(thread.ThreadContext.FilePath, "/"),
+					Line:      line,
+					CreatedAt: comment.PublishedDate,
+					UpdatedAt: comment.LastUpdatedDate,
+				}},
+			})
+		}
+	}
+	return reviews, nil
+}
@@ -0,0 +1,384 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package migrations
+
+import (
+	"net/url"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"gitea.dev/models/unittest"
+	base "gitea.dev/modules/migration"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseAzureDevOpsURL(t *testing.T) {
+	cases := []struct {
+		url      string
+		baseURL  string
+		project  string
+		repoName string
+	}{
+		{"https://dev.azure.com/gitea-test/test_project/_git/test_repo", "https://dev.azure.com/gitea-test", "test_project", "test_repo"},
+		{"https://dev.azure.com/gitea-test/test%20project/_git/test_repo/", "https://dev.azure.com/gitea-test", "test project", "test_repo"},
+		{"https://dev.azure.com/gitea-test/_git/test_project", "https://dev.azure.com/gitea-test", "test_project", "test_project"},
+		{"https://gitea-test.visualstudio.com/test_project/_git/test_repo", "https://gitea-test.visualstudio.com", "test_project", "test_repo"},
+		{"https://gitea-test.visualstudio.com/DefaultCollection/test_project/_git/test_repo", "https://gitea-test.visualstudio.com/DefaultCollection", "test_project", "test_repo"},
+		{"https://gitea-test.visualstudio.com/_git/test_repo", "https://gitea-test.visualstudio.com", "test_repo", "test_repo"},
+		{"https://azure.example.com/tfs/DefaultCollection/test_project/_git/test_repo", "https://azure.example.com/tfs/DefaultCollection", "test_project", "test_repo"},
+	}
+	for _, c := range cases {
+		u, _ := url.Parse(c.url)
+		baseURL, project, repoName, err := parseAzureDevOpsURL(u)
+		if assert.NoError(t, err, c.url) {
+			assert.Equal(t, c.baseURL, baseURL.String(), c.url)
+			assert.Equal(t, c.project, project, c.url)
+			assert.Equal(t, c.repoName, repoName, c.url)
+		}
+	}
+
+	for _, s := range []string{"https://dev.azure.com/gitea-test/test_project", "https://dev.azure.com/_git/test_repo", "https://dev.azure.com/gitea-test/test_project/_git/"} {
+		u, _ := url.Parse(s)
+		_, _, _, err := parseAzureDevOpsURL(u)
+		assert.Error(t, err, s)
+	}
+}
+
+func TestAzureDevOpsDownloadRepo(t *testing.T) {
+	token := os.Getenv("AZURE_DEVOPS_TOKEN")
+	liveMode := token != ""
+
+	_, callerFile, _, _ := runtime.Caller(0)
+	fixtureDir := filepath.Join(filepath.Dir(callerFile), "_mock_data/TestAzureDevOpsDownloadRepo")
+	mockServer := unittest.NewMockWebServer(t, "https://dev.azure.com", fixtureDir, liveMode)
+
+	baseURL, _ := url.Parse(mockServer.URL + "/gitea-test")
+	ctx := t.Context()
+	downloader := NewAzureDevOpsDownloader(ctx, baseURL, "test_project", "test_repo", "", "", token)
+
+	repo, err := downloader.GetRepoInfo(ctx)
+	assert.NoError(t, err)
+	assertRepositoryEqual(t, &base.Repository{
+		Name:          "test_repo",
+		Owner:         "test_project",
+		IsPrivate:     true,
+		Description:   "Test project for testing migration from Azure DevOps to Gitea",
+		CloneURL:      "https://dev.azure.com/gitea-test/test_project/_git/test_repo",
+		OriginalURL:   mockServer.URL + "/gitea-test/test_project/_git/test_repo",
+		DefaultBranch: "main",
+	}, repo)
+
+	// the iterations which are over are closed
+	milestones, err := downloader.GetMilestones(ctx)
+	assert.NoError(t, err)
+	assertMilestonesEqual(t, []*base.Milestone{
+		{
+			Title:    "Sprint 1",
+			Created:  time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC),
+			Deadline: new(time.Date(2026, 1, 16, 0, 0, 0, 0, time.UTC)),
+			Closed:   new(time.Date(2026, 1, 16, 0, 0, 0, 0, time.UTC)),
+			State:    "closed",
+		},
+		{
+			Title:    "Sprint 2",
+			Created:  time.Date(2036, 1, 19, 0, 0, 0, 0, time.UTC),
+			Deadline: new(time.Date(2036, 1, 30, 0, 0, 0, 0, time.UTC)),
+			State:    "open",
+		},
+		{
+			Title: "Release 1",
+			State: "open",
+		},
+		{
+			Title: `Release 1\Sprint 3`,
+			State: "open",
+		},
+	}, milestones)
+
+	// the disabled and the hidden work item types are skipped
+	labels, err := downloader.GetLabels(ctx)
+	assert.NoError(t, err)
+	assertLabelsEqual(t, []*base.Label{
+		{Name: "type/Bug", Color: "cc293d", Exclusive: true},
+		{Name: "type/Task", Color: "f2cb1d", Exclusive: true},
+		{Name: "type/User Story", Color: "009ccc", Exclusive: true},
+		{Name: "state/New", Color: "b2b2b2", Exclusive: true},
+		{Name: "state/Active", Color: "007acc", Exclusive: true},
+		{Name: "state/Resolved", Color: "ff9d00", Exclusive: true},
+		{Name: "state/Closed", Color: "339933", Exclusive: true},
+		{Name: "state/Removed", Color: "ffffff", Exclusive: true},
+		{Name: "area/Backend", Color: "c5def5", Exclusive: true},
+		{Name: `area/Backend\API`, Color: "c5def5", Exclusive: true},
+		{Name: "area/Frontend", Color: "c5def5", Exclusive: true},
+	}, labels)
+
+	issues, isEnd, err := downloader.GetIssues(ctx, 1, 2)
+	assert.NoError(t, err)
+	assert.False(t, isEnd)
+	assertIssuesEqual(t, []*base.Issue{
+		{
+			Number:      1,
+			Title:       "Migrate the repositories",
+			Content:     "<div>As an administrator I want to migrate the repositories to Gitea.</div>",
+			PosterName:  "Contributor",
+			PosterEmail: "contributor@example.com",
+			Milestone:   "Sprint 1",
+			State:       "open",
+			Created:     time.Date(2026, 1, 6, 9, 15, 0, 0, time.UTC),
+			Updated:     time.Date(2026, 1, 8, 14, 20, 30, 457000000, time.UTC),
+			Labels: []*base.Label{
+				{Name: "type/User Story"},
+				{Name: "state/Active"},
+				{Name: "area/Backend"},
+			},
+			ForeignIndex: 1,
+			Context:      azureDevOpsIssueContext{IsPullRequest: false},
+		},
+		{
+			Number:      2,
+			Title:       "Crash when the repository is empty",
+			Content:     "<div>Migrate an empty repository.</div>",
+			PosterName:  "Reviewer",
+			PosterEmail: "reviewer@example.com",
+			Milestone:   "Sprint 1",
+			State:       "closed",
+			Created:     time.Date(2026, 1, 7, 10, 0, 0, 0, time.UTC),
+			Updated:     time.Date(2026, 1, 15, 16, 45, 0, 0, time.UTC),
+			Closed:      new(time.Date(2026, 1, 15, 16, 44, 59, 120000000, time.UTC)),
+			Labels: []*base.Label{
+				{Name: "type/Bug"},
+				{Name: "state/Closed"},
+			},
+			ForeignIndex: 2,
+			Context:      azureDevOpsIssueContext{IsPullRequest: false},
+		},
+	}, issues)
+
+	issues, isEnd, err = downloader.GetIssues(ctx, 2, 2)
+	assert.NoError(t, err)
+	assert.True(t, isEnd)
+	assertIssuesEqual(t, []*base.Issue{
+		{
+			Number:      3,
+			Title:       "Write the API documentation",
+			PosterName:  "Gitea Test",
+			PosterEmail: "gitea-test@example.com",
+			Milestone:   `Release 1\Sprint 3`,
+			State:       "closed",
+			Created:     time.Date(2026, 1, 9, 8, 0, 0, 0, time.UTC),
+			Updated:     time.Date(2026, 1, 12, 11, 30, 0, 0, time.UTC),
+			Closed:      new(time.Date(2026, 1, 12, 11, 30, 0, 0, time.UTC)),
+			Labels: []*base.Label{
+				{Name: "type/Task"},
+				{Name: "state/Removed"},
+				{Name: `area/Backend\API`},
+			},
+			ForeignIndex: 3,
+			Context:      azureDevOpsIssueContext{IsPullRequest: false},
+		},
+	}, issues)
+
+	comments, _, err := downloader.GetComments(ctx, &base.Issue{
+		Number:       1,
+		ForeignIndex: 1,
+		Context:      azureDevOpsIssueContext{IsPullRequest: false},
+	})
+	assert.NoError(t, err)
+	assertCommentsEqual(t, []*base.Comment{
+		{
+			IssueIndex:  1,
+			PosterName:  "Gitea Test",
+			PosterEmail: "gitea-test@example.com",
+			Created:     time.Date(2026, 1, 6, 10, 0, 0, 0, time.UTC),
+			Updated:     time.Date(2026, 1, 6, 10, 0, 0, 0, time.UTC),
+			Content:     "<div>Which repositories come first?</div>",
+		},
+		{
+			IssueIndex:  1,
+			PosterName:  "Contributor",
+			PosterEmail: "contributor@example.com",
+			Created:     time.Date(2026, 1, 6, 12, 0, 0, 0, time.UTC),
+			Updated:     time.Date(2026, 1, 7, 8, 0, 0, 0, time.UTC),
+			Content:     "<div>The ones without pipelines.</div>",
+		},
+	}, comments)
+
+	// the pull requests are numbered after the work items
+	prs, isEnd, err := downloader.GetPullRequests(ctx, 1, 50)
+	assert.NoError(t, err)
+	assert.True(t, isEnd)
+	assertPullRequestsEqual(t, []*base.PullRequest{
+		{
+			Number:      6,
+			Title:       "Try another approach",
+			PosterName:  "Gitea Test",
+			PosterEmail: "gitea-test@example.com",
+			State:       "closed",
+			Created:     time.Date(2026, 2, 5, 8, 0, 0, 0, time.UTC),
+			Updated:     time.Date(2026, 2, 6, 9, 0, 0, 0, time.UTC),
+			Closed:      new(time.Date(2026, 2, 6, 9, 0, 0, 0, time.UTC)),
+			Head: base.PullRequestBranch{
+				Ref:       "experiment",
+				SHA:       "0123456789abcdef0123456789abcdef01234567",
+				OwnerName: "test_project",
+				RepoName:  "test_repo",
+			},
+			Base: base.PullRequestBranch{
+				Ref:       "main",
+				SHA:       "4a5b6c7d8e9f0a1b2c3d4e5f6a7b8c9d0e1f2a3b",
+				OwnerName: "test_project",
+				RepoName:  "test_repo",
+			},
+			ForeignIndex: 3,
+			Context:      azureDevOpsIssueContext{IsPullRequest: true},
+		},
+		{
+			Number:      5,
+			Title:       "Add the wiki migration",
+			Content:     "Work in progress",
+			PosterName:  "Contributor",
+			PosterEmail: "contributor@example.com",
+			State:       "open",
+			Created:     time.Date(2026, 2, 3, 10, 0, 0, 0, time.UTC),
+			Updated:     time.Date(2026, 2, 3, 10, 0, 0, 0, time.UTC),
+			IsDraft:     true,
+			Head: base.PullRequestBranch{
+				CloneURL:  mockServer.URL + "/gitea-test/contributor_project/_git/test_repo_fork",
+				Ref:       "wiki",
+				SHA:       "abcdef0123456789abcdef0123456789abcdef01",
+				OwnerName: "contributor_project",
+				RepoName:  "test_repo_fork",
+			},
+			Base: base.PullRequestBranch{
+				Ref:       "main",
+				SHA:       "4a5b6c7d8e9f0a1b2c3d4e5f6a7b8c9d0e1f2a3b",
+				OwnerName: "test_project",
+				RepoName:  "test_repo",
+			},
+			ForeignIndex: 2,
+			Context:      azureDevOpsIssueContext{IsPullRequest: true},
+		},
+		{
+			Number:         4,
+			Title:          "Fix the crash for empty repositories",
+			Content:        "Fixes AB#2",
+			PosterName:     "Contributor",
+			PosterEmail:    "contributor@example.com",
+			State:          "closed",
+			Created:        time.Date(2026, 1, 13, 9, 0, 0, 0, time.UTC),
+			Updated:        time.Date(2026, 1, 14, 15, 30, 0, 0, time.UTC),
+			Closed:         new(time.Date(2026, 1, 14, 15, 30, 0, 0, time.UTC)),
+			Merged:         true,
+			MergedTime:     new(time.Date(2026, 1, 14, 15, 30, 0, 0, time.UTC)),
+			MergeCommitSHA: "c0ffee1234567890abcdef1234567890abcdef12",
+			Head: base.PullRequestBranch{
+				Ref:       "fix-empty",
+				SHA:       "1a2b3c4d5e6f7a8b9c0d1e2f3a4b5c6d7e8f9a0b",
+				OwnerName: "test_project",
+				RepoName:  "test_repo",
+			},
+			Base: base.PullRequestBranch{
+				Ref:       "main",
+				SHA:       "4a5b6c7d8e9f0a1b2c3d4e5f6a7b8c9d0e1f2a3b",
+				OwnerName: "test_project",
+				RepoName:  "test_repo",
+			},
+			ForeignIndex: 1,
+			Context:      azureDevOpsIssueContext{IsPullRequest: true},
+		},
+	}, prs)
+
+	// the system comments and the deleted threads are skipped
+	comments, _, err = downloader.GetComments(ctx, prs[2])
+	assert.NoError(t, err)
+	assertCommentsEqual(t, []*base.Comment{
+		{
+			IssueIndex:  4,
+			PosterName:  "Gitea Test",
+			PosterEmail: "gitea-test@example.com",
+			Created:     time.Date(2026, 1, 13, 10, 0, 0, 0, time.UTC),
+			Updated:     time.Date(2026, 1, 13, 10, 0, 0, 0, time.UTC),
+			Content:     "Looks good overall.",
+		},
+		{
+			IssueIndex:  4,
+			PosterName:  "Contributor",
+			PosterEmail: "contributor@example.com",
+			Created:     time.Date(2026, 1, 13, 11, 0, 0, 0, time.UTC),
+			Updated:     time.Date(2026, 1, 13, 11, 5, 0, 0, time.UTC),
+			Content:     "Thanks!",
+		},
+	}, comments)
+
+	// the votes are dated by their last update
+	reviews, err := downloader.GetReviews(ctx, prs[2])
+	assert.NoError(t, err)
+	assertReviewsEqual(t, []*base.Review{
+		{
+			IssueIndex:   4,
+			ReviewerName: "Gitea Test",
+			CreatedAt:    time.Date(2026, 1, 14, 15, 10, 0, 0, time.UTC),
+			State:        base.ReviewStateApproved,
+		},
+		{
+			IssueIndex:   4,
+			ReviewerName: "Reviewer",
+			CreatedAt:    time.Date(2026, 1, 13, 10, 40, 0, 0, time.UTC),
+			State:        base.ReviewStateChangesRequested,
+		},
+		{
+			IssueIndex:   4,
+			ReviewerName: "Reviewer",
+			CreatedAt:    time.Date(2026, 1, 13, 10, 30, 0, 0, time.UTC),
+			State:        base.ReviewStateCommented,
+			Comments: []*base.ReviewComment{
+				{
+					ID:        1,
+					Content:   "Please check the error here.",
+					TreePath:  "services/migrate.go",
+					Line:      12,
+					CreatedAt: time.Date(2026, 1, 13, 10, 30, 0, 0, time.UTC),
+					UpdatedAt: time.Date(2026, 1, 13, 10, 31, 0, 0, time.UTC),
+				},
+			},
+		},
+		{
+			IssueIndex:   4,
+			ReviewerName: "Contributor",
+			CreatedAt:    time.Date(2026, 1, 13, 12, 0, 0, 0, time.UTC),
+			State:        base.ReviewStateCommented,
+			Comments: []*base.ReviewComment{
+				{
+					ID:        2,
+					InReplyTo: 1,
+					Content:   "Done.",
+					TreePath:  "services/migrate.go",
+					Line:      12,
+					CreatedAt: time.Date(2026, 1, 13, 12, 0, 0, 0, time.UTC),
+					UpdatedAt: time.Date(2026, 1, 13, 12, 0, 0, 0, time.UTC),
+				},
+			},
+		},
+		{
+			IssueIndex:   4,
+			ReviewerName: "Reviewer",
+			CreatedAt:    time.Date(2026, 1, 13, 10, 35, 0, 0, time.UTC),
+			State:        base.ReviewStateCommented,
+			Comments: []*base.ReviewComment{
+				{
+					ID:        1,
+					Content:   "Why was this removed?",
+					TreePath:  "README.md",
+					Line:      -7,
+					CreatedAt: time.Date(2026, 1, 13, 10, 35, 0, 0, time.UTC),
+					UpdatedAt: time.Date(2026, 1, 13, 10, 35, 0, 0, time.UTC),
+				},
+			},
+		},
+	}, reviews)
+}
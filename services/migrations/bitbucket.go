@@ -0,0 +1,206 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package migrations
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"gitea.dev/modules/json"
+	"gitea.dev/modules/log"
+	base "gitea.dev/modules/migration"
+	"gitea.dev/modules/structs"
+	"gitea.dev/modules/util"
+)
+
+var _ base.DownloaderFactory = &BitbucketDownloaderFactory{}
+
+func init() {
+	RegisterDownloaderFactory(&BitbucketDownloaderFactory{})
+}
+
+// bitbucketCloudHost is the host of Bitbucket Cloud, every other host is treated as Bitbucket Server / Data Center
+const bitbucketCloudHost = "bitbucket.org"
+
+// BitbucketDownloaderFactory defines a downloader factory for Bitbucket Cloud and Bitbucket Server / Data Center
+type BitbucketDownloaderFactory struct{}
+
+// New returns a downloader related to this factory according MigrateOptions
+func (f *BitbucketDownloaderFactory) New(ctx context.Context, opts base.MigrateOptions) (base.Downloader, error) {
+	u, err := url.Parse(opts.CloneAddr)
+	if err != nil {
+		return nil, err
+	}
+	u.User = nil
+
+	if host := strings.ToLower(u.Hostname()); host == bitbucketCloudHost || host == "www."+bitbucketCloudHost {
+		workspace, repoSlug, err := parseBitbucketCloudPath(u.Path)
+		if err != nil {
+			return nil, err
+		}
+		apiURL, _ := url.Parse("https://api." + bitbucketCloudHost + "/2.0")
+
+		log.Trace("Create Bitbucket Cloud downloader. Workspace: %s RepoSlug: %s", workspace, repoSlug)
+		return NewBitbucketCloudDownloader(ctx, apiURL, workspace, repoSlug, opts.AuthUsername, opts.AuthPassword, opts.AuthToken), nil
+	}
+
+	baseURL, projectKey, repoSlug, err := parseBitbucketServerURL(u)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Trace("Create Bitbucket Server downloader. BaseURL: %s ProjectKey: %s RepoSlug: %s", baseURL, projectKey, repoSlug)
+	return NewBitbucketServerDownloader(ctx, baseURL, projectKey, repoSlug, opts.AuthUsername, opts.AuthPassword, opts.AuthToken), nil
+}
+
+// GitServiceType returns the type of git service
+func (f *BitbucketDownloaderFactory) GitServiceType() structs.GitServiceType {
+	return structs.BitbucketService
+}
+
+// parseBitbucketCloudPath parses the workspace and the repository slug of a Bitbucket Cloud repository URL
+// like /{workspace}/{repo_slug}, /{workspace}/{repo_slug}.git or /{workspace}/{repo_slug}/src/main
+func parseBitbucketCloudPath(p string) (workspace, repoSlug string, err error) {
+	fields := strings.Split(strings.Trim(p, "/"), "/")
+	if len(fields) < 2 || fields[0] == "" || fields[1] == "" {
+		return "", "", fmt.Errorf("invalid Bitbucket repository path: %s", p)
+	}
+	return fields[0], strings.TrimSuffix(fields[1], ".git"), nil
+}
+
+// parseBitbucketServerURL parses the base URL of the instance, the project key and the repository slug of a
+// Bitbucket Server repository URL. The base URL keeps the context path the instance may be served under.
+// Supported are the browse URLs /projects/{key}/repos/{slug} and /users/{user}/repos/{slug}
+// and the clone URLs /scm/{key}/{slug}.git and /scm/~{user}/{slug}.git
+func parseBitbucketServerURL(u *url.URL) (baseURL *url.URL, projectKey, repoSlug string, err error) {
+	fields := strings.Split(strings.Trim(u.Path, "/"), "/")
+	for i := 0; i < len(fields); i++ {
+		switch fields[i] {
+		case "projects", "users":
+			if i+3 >= len(fields) || fields[i+2] != "repos" {
+				continue
+			}
+			projectKey = fields[i+1]
+			if fields[i] == "users" {
+				projectKey = "~" + projectKey
+			}
+			repoSlug = fields[i+3]
+		case "scm":
+			if i+2 >= len(fields) {
+				continue
+			}
+			projectKey = fields[i+1]
+			repoSlug = strings.TrimSuffix(fields[i+2], ".git")
+		default:
+			continue
+		}
+
+		baseURL = &url.URL{Scheme: u.Scheme, Host: u.Host}
+		if i > 0 {
+			baseURL.Path = "/" + strings.Join(fields[:i], "/")
+		}
+		return baseURL, projectKey, repoSlug, nil
+	}
+	return nil, "", "", fmt.Errorf("invalid Bitbucket Server repository path: %s", u.Path)
+}
+
+// newBitbucketHTTPClient returns a HTTP client which authenticates with the access token as bearer token,
+// or with the username and the (app) password
+func newBitbucketHTTPClient(ctx context.Context, username, password, token string) *http.Client {
+	httpTransport := NewMigrationHTTPTransport()
+	return &http.Client{
+		Transport: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			if token != "" {
+				req.Header.Set("Authorization", "Bearer "+token)
+			} else if username != "" && password != "" {
+				req.SetBasicAuth(username, password)
+			}
+			return httpTransport.RoundTrip(req.WithContext(ctx))
+		}),
+	}
+}
+
+// callBitbucketAPI requests the endpoint below the API base URL and decodes the JSON response into result.
+// A util.ErrNotExist is returned if the resource doesn't exist or the feature is disabled for the repository.
+func callBitbucketAPI(ctx context.Context, client *http.Client, apiURL *url.URL, endpoint string, query url.Values, result any) error {
+	u, err := url.Parse(strings.TrimSuffix(apiURL.String(), "/") + endpoint)
+	if err != nil {
+		return err
+	}
+	if len(query) > 0 {
+		u.RawQuery = query.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return util.NewNotExistErrorf("Bitbucket API %s: not found", u.Path)
+	}
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("Bitbucket API %s returned status %d: %s", u.Path, resp.StatusCode, body)
+	}
+	return json.NewDecoder(resp.Body).Decode(result)
+}
+
+// formatBitbucketCloneURL adds the credentials to the clone URL, the access token is sent as password of
+// the user or of the "x-token-auth" user Bitbucket accepts for access tokens
+func formatBitbucketCloneURL(opts base.MigrateOptions, remoteAddr string) (string, error) {
+	if opts.AuthToken == "" && opts.AuthUsername == "" {
+		return remoteAddr, nil
+	}
+	u, err := url.Parse(remoteAddr)
+	if err != nil {
+		return "", err
+	}
+	switch {
+	case opts.AuthToken != "" && opts.AuthUsername != "":
+		u.User = url.UserPassword(opts.AuthUsername, opts.AuthToken)
+	case opts.AuthToken != "":
+		u.User = url.UserPassword("x-token-auth", opts.AuthToken)
+	default:
+		u.User = url.UserPassword(opts.AuthUsername, opts.AuthPassword)
+	}
+	return u.String(), nil
+}
+
+type bitbucketIssueContext struct {
+	IsPullRequest bool
+}
+
+// bitbucketCloneLink is a clone link of a repository, Cloud names the HTTPS link "https" and Server "http"
+type bitbucketCloneLink struct {
+	Href string `json:"href"`
+	Name string `json:"name"`
+}
+
+// findBitbucketHTTPCloneURL returns the HTTP(S) clone URL without the user Bitbucket adds for the authenticated user
+func findBitbucketHTTPCloneURL(links []bitbucketCloneLink) string {
+	for _, link := range links {
+		if link.Name != "https" && link.Name != "http" {
+			continue
+		}
+		u, err := url.Parse(link.Href)
+		if err != nil {
+			continue
+		}
+		u.User = nil
+		return u.String()
+	}
+	return ""
+}
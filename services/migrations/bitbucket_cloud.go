@@ -0,0 +1,686 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package migrations
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"gitea.dev/modules/log"
+	base "gitea.dev/modules/migration"
+)
+
+var _ base.Downloader = &BitbucketCloudDownloader{}
+
+// bitbucketCloudMaxPerPage is the largest page size the Bitbucket Cloud API accepts for all the listed resources
+const bitbucketCloudMaxPerPage = 50
+
+var (
+	// bitbucketCloudIssueKinds are the kinds of the issues, migrated as exclusive "kind/..." labels
+	bitbucketCloudIssueKinds = []string{"bug", "enhancement", "proposal", "task"}
+	// bitbucketCloudIssuePriorities are the priorities of the issues, migrated as exclusive "priority/..." labels
+	bitbucketCloudIssuePriorities = []string{"trivial", "minor", "major", "critical", "blocker"}
+)
+
+type bitbucketCloudUser struct {
+	DisplayName string `json:"display_name"`
+	Nickname    string `json:"nickname"`
+	UUID        string `json:"uuid"`
+}
+
+// Name returns the name of the user, the nickname is what Bitbucket shows in the URLs of the user
+func (u *bitbucketCloudUser) Name() string {
+	if u == nil {
+		return ""
+	}
+	if u.Nickname != "" {
+		return u.Nickname
+	}
+	return u.DisplayName
+}
+
+type bitbucketCloudContent struct {
+	Raw string `json:"raw"`
+}
+
+type bitbucketCloudNamed struct {
+	Name string `json:"name"`
+}
+
+type bitbucketCloudCommit struct {
+	Hash string `json:"hash"`
+}
+
+type bitbucketCloudPage[T any] struct {
+	Values []T    `json:"values"`
+	Next   string `json:"next"`
+}
+
+// BitbucketCloudDownloader implements a Downloader interface to get repository information from Bitbucket Cloud
+type BitbucketCloudDownloader struct {
+	base.NullDownloader
+	client        *http.Client
+	baseURL       *url.URL
+	webURL        string
+	workspace     string
+	repoSlug      string
+	hasIssues     bool
+	maxIssueIndex int64
+	commitMap     map[string]string
+}
+
+// NewBitbucketCloudDownloader creates a new downloader, baseURL is the URL of the API version 2.0
+func NewBitbucketCloudDownloader(ctx context.Context, baseURL *url.URL, workspace, repoSlug, username, password, token string) *BitbucketCloudDownloader {
+	return &BitbucketCloudDownloader{
+		client:    newBitbucketHTTPClient(ctx, username, password, token),
+		baseURL:   baseURL,
+		webURL:    "https://" + bitbucketCloudHost,
+		workspace: workspace,
+		repoSlug:  repoSlug,
+		commitMap: make(map[string]string),
+	}
+}
+
+// String implements Stringer
+func (d *BitbucketCloudDownloader) String() string {
+	return fmt.Sprintf("migration from Bitbucket Cloud %s/%s", d.workspace, d.repoSlug)
+}
+
+func (d *BitbucketCloudDownloader) LogString() string {
+	if d == nil {
+		return "<BitbucketCloudDownloader nil>"
+	}
+	return fmt.Sprintf("<BitbucketCloudDownloader %s %s/%s>", d.baseURL, d.workspace, d.repoSlug)
+}
+
+// FormatCloneURL add authentication into remote URLs
+func (d *BitbucketCloudDownloader) FormatCloneURL(opts base.MigrateOptions, remoteAddr string) (string, error) {
+	return formatBitbucketCloneURL(opts, remoteAddr)
+}
+
+func (d *BitbucketCloudDownloader) repoEndpoint(format string, args ...any) string {
+	return fmt.Sprintf("/repositories/%s/%s", url.PathEscape(d.workspace), url.PathEscape(d.repoSlug)) + fmt.Sprintf(format, args...)
+}
+
+func (d *BitbucketCloudDownloader) callAPI(ctx context.Context, endpoint string, query url.Values, result any) error {
+	return callBitbucketAPI(ctx, d.client, d.baseURL, endpoint, query, result)
+}
+
+// getAllBitbucketCloudPages requests all the pages of a list
+func getAllBitbucketCloudPages[T any](ctx context.Context, d *BitbucketCloudDownloader, endpoint string, query url.Values) ([]T, error) {
+	if query == nil {
+		query = url.Values{}
+	}
+	var result []T
+	for page := 1; ; page++ {
+		query.Set("page", strconv.Itoa(page))
+		query.Set("pagelen", strconv.Itoa(bitbucketCloudMaxPerPage))
+
+		var resp bitbucketCloudPage[T]
+		if err := d.callAPI(ctx, endpoint, query, &resp); err != nil {
+			return nil, err
+		}
+		result = append(result, resp.Values...)
+		if resp.Next == "" || len(resp.Values) == 0 {
+			return result, nil
+		}
+	}
+}
+
+// GetRepoInfo returns repository information
+func (d *BitbucketCloudDownloader) GetRepoInfo(ctx context.Context) (*base.Repository, error) {
+	var repo struct {
+		Name        string               `json:"name"`
+		Description string               `json:"description"`
+		IsPrivate   bool                 `json:"is_private"`
+		Website     string               `json:"website"`
+		HasIssues   bool                 `json:"has_issues"`
+		MainBranch  *bitbucketCloudNamed `json:"mainbranch"`
+		Links       struct {
+			Clone []bitbucketCloneLink `json:"clone"`
+			HTML  struct {
+				Href string `json:"href"`
+			} `json:"html"`
+		} `json:"links"`
+	}
+	if err := d.callAPI(ctx, d.repoEndpoint(""), nil, &repo); err != nil {
+		return nil, err
+	}
+
+	d.hasIssues = repo.HasIssues
+	if u, err := url.Parse(repo.Links.HTML.Href); err == nil && u.Host != "" {
+		d.webURL = u.Scheme + "://" + u.Host
+	}
+
+	cloneURL := findBitbucketHTTPCloneURL(repo.Links.Clone)
+	if cloneURL == "" {
+		cloneURL = fmt.Sprintf("%s/%s/%s.git", d.webURL, d.workspace, d.repoSlug)
+	}
+
+	r := &base.Repository{
+		Name:        repo.Name,
+		Owner:       d.workspace,
+		IsPrivate:   repo.IsPrivate,
+		Description: repo.Description,
+		Website:     repo.Website,
+		CloneURL:    cloneURL,
+		OriginalURL: repo.Links.HTML.Href,
+	}
+	if repo.MainBranch != nil {
+		r.DefaultBranch = repo.MainBranch.Name
+	}
+	return r, nil
+}
+
+// GetTopics return repository topics
+func (d *BitbucketCloudDownloader) GetTopics(_ context.Context) ([]string, error) {
+	return []string{}, nil
+}
+
+// GetMilestones returns milestones of the issue tracker
+func (d *BitbucketCloudDownloader) GetMilestones(ctx context.Context) ([]*base.Milestone, error) {
+	if !d.hasIssues {
+		return []*base.Milestone{}, nil
+	}
+
+	rawMilestones, err := getAllBitbucketCloudPages[bitbucketCloudNamed](ctx, d, d.repoEndpoint("/milestones"), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	milestones := make([]*base.Milestone, 0, len(rawMilestones))
+	for _, m := range rawMilestones {
+		milestones = append(milestones, &base.Milestone{
+			Title: m.Name,
+			State: "open",
+		})
+	}
+	return milestones, nil
+}
+
+// GetLabels returns the kinds, the priorities and the components of the issue tracker as labels
+func (d *BitbucketCloudDownloader) GetLabels(ctx context.Context) ([]*base.Label, error) {
+	if !d.hasIssues {
+		return []*base.Label{}, nil
+	}
+
+	labels := make([]*base.Label, 0, 10)
+	for _, kind := range bitbucketCloudIssueKinds {
+		labels = append(labels, &base.Label{Name: "kind/" + kind, Color: "1d76db", Exclusive: true})
+	}
+	for _, priority := range bitbucketCloudIssuePriorities {
+		labels = append(labels, &base.Label{Name: "priority/" + priority, Color: "e99695", Exclusive: true})
+	}
+
+	components, err := getAllBitbucketCloudPages[bitbucketCloudNamed](ctx, d, d.repoEndpoint("/components"), nil)
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range components {
+		labels = append(labels, &base.Label{Name: "component/" + c.Name, Color: "c5def5", Exclusive: true})
+	}
+	return labels, nil
+}
+
+// GetReleases returns the annotated tags as releases, and the downloads of the repository as the assets
+// of a draft release since they don't belong to a tag
+func (d *BitbucketCloudDownloader) GetReleases(ctx context.Context) ([]*base.Release, error) {
+	type rawTag struct {
+		Name    string    `json:"name"`
+		Message string    `json:"message"`
+		Date    time.Time `json:"date"`
+		Tagger  *struct {
+			Raw  string              `json:"raw"`
+			User *bitbucketCloudUser `json:"user"`
+		} `json:"tagger"`
+		Target struct {
+			Hash string    `json:"hash"`
+			Date time.Time `json:"date"`
+		} `json:"target"`
+	}
+	tags, err := getAllBitbucketCloudPages[rawTag](ctx, d, d.repoEndpoint("/refs/tags"), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	releases := make([]*base.Release, 0, len(tags)+1)
+	for _, tag := range tags {
+		// lightweight tags have neither a message nor a tagger, they are migrated with the git data
+		if tag.Tagger == nil || strings.TrimSpace(tag.Message) == "" {
+			continue
+		}
+		created := tag.Date
+		if created.IsZero() {
+			created = tag.Target.Date
+		}
+		r := &base.Release{
+			TagName:         tag.Name,
+			TargetCommitish: tag.Target.Hash,
+			Name:            tag.Name,
+			Body:            strings.TrimSpace(tag.Message),
+			Created:         created,
+			Published:       created,
+		}
+		if tag.Tagger.User != nil {
+			r.PublisherName = tag.Tagger.User.Name()
+		} else {
+			r.PublisherName, r.PublisherEmail = parseBitbucketRawAuthor(tag.Tagger.Raw)
+		}
+		releases = append(releases, r)
+	}
+
+	downloads, err := d.getDownloads(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(downloads) > 0 {
+		r := &base.Release{
+			Name:   "Downloads",
+			Body:   "The files of the Downloads page of the Bitbucket repository.",
+			Draft:  true,
+			Assets: downloads,
+		}
+		for _, asset := range downloads {
+			if r.Created.IsZero() || asset.Created.Before(r.Created) {
+				r.Created = asset.Created
+			}
+		}
+		releases = append(releases, r)
+	}
+	return releases, nil
+}
+
+func (d *BitbucketCloudDownloader) getDownloads(ctx context.Context) ([]*base.ReleaseAsset, error) {
+	type rawDownload struct {
+		Name      string    `json:"name"`
+		Size      int       `json:"size"`
+		Downloads int       `json:"downloads"`
+		CreatedOn time.Time `json:"created_on"`
+		Links     struct {
+			Self struct {
+				Href string `json:"href"`
+			} `json:"self"`
+		} `json:"links"`
+	}
+	rawDownloads, err := getAllBitbucketCloudPages[rawDownload](ctx, d, d.repoEndpoint("/downloads"), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	assets := make([]*base.ReleaseAsset, 0, len(rawDownloads))
+	for _, download := range rawDownloads {
+		href := download.Links.Self.Href
+		assets = append(assets, &base.ReleaseAsset{
+			Name:          download.Name,
+			Size:          &download.Size,
+			DownloadCount: &download.Downloads,
+			Created:       download.CreatedOn,
+			Updated:       download.CreatedOn,
+			DownloadFunc: func() (io.ReadCloser, error) {
+				// SECURITY: only download from the API, it redirects to the storage of the files
+				if !hasBaseURL(href, d.baseURL.String()) {
+					WarnAndNotice("Unexpected download URL of %s in %s: %s", download.Name, d, href)
+					return io.NopCloser(strings.NewReader(href)), nil
+				}
+				req, err := http.NewRequestWithContext(ctx, http.MethodGet, href, nil)
+				if err != nil {
+					return nil, err
+				}
+				resp, err := d.client.Do(req)
+				if err != nil {
+					return nil, err
+				}
+				if resp.StatusCode != http.StatusOK {
+					resp.Body.Close()
+					return nil, fmt.Errorf("unable to download %s: status %d", download.Name, resp.StatusCode)
+				}
+				// resp.Body is closed by the uploader
+				return resp.Body, nil
+			},
+		})
+	}
+	return assets, nil
+}
+
+// GetIssues returns issues of the issue tracker
+func (d *BitbucketCloudDownloader) GetIssues(ctx context.Context, page, perPage int) ([]*base.Issue, bool, error) {
+	if !d.hasIssues {
+		return []*base.Issue{}, true, nil
+	}
+	perPage = min(perPage, bitbucketCloudMaxPerPage)
+
+	var resp bitbucketCloudPage[struct {
+		ID        int64                 `json:"id"`
+		Title     string                `json:"title"`
+		Content   bitbucketCloudContent `json:"content"`
+		Reporter  *bitbucketCloudUser   `json:"reporter"`
+		Assignee  *bitbucketCloudUser   `json:"assignee"`
+		State     string                `json:"state"`
+		Kind      string                `json:"kind"`
+		Priority  string                `json:"priority"`
+		Milestone *bitbucketCloudNamed  `json:"milestone"`
+		Component *bitbucketCloudNamed  `json:"component"`
+		CreatedOn time.Time             `json:"created_on"`
+		UpdatedOn time.Time             `json:"updated_on"`
+	}]
+	err := d.callAPI(ctx, d.repoEndpoint("/issues"), url.Values{
+		"page":    {strconv.Itoa(page)},
+		"pagelen": {strconv.Itoa(perPage)},
+		"sort":    {"id"},
+	}, &resp)
+	if err != nil {
+		return nil, false, err
+	}
+
+	issues := make([]*base.Issue, 0, len(resp.Values))
+	for _, issue := range resp.Values {
+		labels := make([]*base.Label, 0, 3)
+		if issue.Kind != "" {
+			labels = append(labels, &base.Label{Name: "kind/" + issue.Kind})
+		}
+		if issue.Priority != "" {
+			labels = append(labels, &base.Label{Name: "priority/" + issue.Priority})
+		}
+		if issue.Component != nil {
+			labels = append(labels, &base.Label{Name: "component/" + issue.Component.Name})
+		}
+
+		state := "open"
+		var closed *time.Time
+		switch issue.State {
+		case "resolved", "invalid", "duplicate", "wontfix", "closed":
+			state = "closed"
+			closed = &issue.UpdatedOn
+		}
+
+		var milestone string
+		if issue.Milestone != nil {
+			milestone = issue.Milestone.Name
+		}
+		var assignees []string
+		if issue.Assignee != nil {
+			assignees = []string{issue.Assignee.Name()}
+		}
+
+		issues = append(issues, &base.Issue{
+			Number:       issue.ID,
+			Title:        issue.Title,
+			PosterName:   issue.Reporter.Name(),
+			Content:      issue.Content.Raw,
+			Milestone:    milestone,
+			State:        state,
+			Created:      issue.CreatedOn,
+			Updated:      issue.UpdatedOn,
+			Closed:       closed,
+			Labels:       labels,
+			Assignees:    assignees,
+			ForeignIndex: issue.ID,
+			Context:      bitbucketIssueContext{IsPullRequest: false},
+		})
+
+		d.maxIssueIndex = max(d.maxIssueIndex, issue.ID)
+	}
+
+	return issues, resp.Next == "", nil
+}
+
+type bitbucketCloudComment struct {
+	ID        int64                 `json:"id"`
+	Content   bitbucketCloudContent `json:"content"`
+	User      *bitbucketCloudUser   `json:"user"`
+	CreatedOn time.Time             `json:"created_on"`
+	UpdatedOn time.Time             `json:"updated_on"`
+	Deleted   bool                  `json:"deleted"`
+	Pending   bool                  `json:"pending"`
+	Inline    *struct {
+		Path string `json:"path"`
+		From *int   `json:"from"`
+		To   *int   `json:"to"`
+	} `json:"inline"`
+	Parent *struct {
+		ID int64 `json:"id"`
+	} `json:"parent"`
+}
+
+// GetComments returns the comments of an issue or the general comments of a pull request,
+// the inline comments of the pull requests are returned as reviews
+func (d *BitbucketCloudDownloader) GetComments(ctx context.Context, commentable base.Commentable) ([]*base.Comment, bool, error) {
+	context, ok := commentable.GetContext().(bitbucketIssueContext)
+	if !ok {
+		return nil, false, fmt.Errorf("unexpected context: %+v", commentable.GetContext())
+	}
+
+	endpoint := d.repoEndpoint("/issues/%d/comments", commentable.GetForeignIndex())
+	if context.IsPullRequest {
+		endpoint = d.repoEndpoint("/pullrequests/%d/comments", commentable.GetForeignIndex())
+	}
+	rawComments, err := getAllBitbucketCloudPages[bitbucketCloudComment](ctx, d, endpoint, nil)
+	if err != nil {
+		return nil, false, err
+	}
+
+	comments := make([]*base.Comment, 0, len(rawComments))
+	for _, comment := range rawComments {
+		// issue comments without content record changes of the state or of the fields of the issue
+		if comment.Deleted || comment.Pending || comment.Inline != nil || comment.Content.Raw == "" {
+			continue
+		}
+		comments = append(comments, &base.Comment{
+			IssueIndex: commentable.GetLocalIndex(),
+			Index:      comment.ID,
+			PosterName: comment.User.Name(),
+			Content:    comment.Content.Raw,
+			Created:    comment.CreatedOn,
+			Updated:    comment.UpdatedOn,
+		})
+	}
+	return comments, true, nil
+}
+
+type bitbucketCloudBranch struct {
+	Branch     bitbucketCloudNamed  `json:"branch"`
+	Commit     bitbucketCloudCommit `json:"commit"`
+	Repository *struct {
+		FullName string `json:"full_name"`
+	} `json:"repository"`
+}
+
+// GetPullRequests returns pull requests, they are numbered after the issues because Bitbucket numbers them separately
+func (d *BitbucketCloudDownloader) GetPullRequests(ctx context.Context, page, perPage int) ([]*base.PullRequest, bool, error) {
+	perPage = min(perPage, bitbucketCloudMaxPerPage)
+
+	var resp bitbucketCloudPage[struct {
+		ID          int64                 `json:"id"`
+		Title       string                `json:"title"`
+		Summary     bitbucketCloudContent `json:"summary"`
+		State       string                `json:"state"` // OPEN, MERGED, DECLINED or SUPERSEDED
+		Draft       bool                  `json:"draft"`
+		Author      *bitbucketCloudUser   `json:"author"`
+		Source      bitbucketCloudBranch  `json:"source"`
+		Destination bitbucketCloudBranch  `json:"destination"`
+		MergeCommit *bitbucketCloudCommit `json:"merge_commit"`
+		CreatedOn   time.Time             `json:"created_on"`
+		UpdatedOn   time.Time             `json:"updated_on"`
+	}]
+	err := d.callAPI(ctx, d.repoEndpoint("/pullrequests"), url.Values{
+		"page":    {strconv.Itoa(page)},
+		"pagelen": {strconv.Itoa(perPage)},
+		"state":   {"OPEN", "MERGED", "DECLINED", "SUPERSEDED"},
+	}, &resp)
+	if err != nil {
+		return nil, false, err
+	}
+
+	repoFullName := d.workspace + "/" + d.repoSlug
+	pullRequests := make([]*base.PullRequest, 0, len(resp.Values))
+	for _, pr := range resp.Values {
+		state := "open"
+		merged := false
+		var closed, mergedTime *time.Time
+		var mergeCommitSHA string
+		if pr.State != "OPEN" {
+			state = "closed"
+			closed = &pr.UpdatedOn
+			if pr.State == "MERGED" {
+				merged = true
+				mergedTime = &pr.UpdatedOn
+				if pr.MergeCommit != nil {
+					mergeCommitSHA = d.resolveCommit(ctx, repoFullName, pr.MergeCommit.Hash)
+				}
+			}
+		}
+
+		headFullName := repoFullName
+		if pr.Source.Repository != nil {
+			headFullName = pr.Source.Repository.FullName
+		}
+		headOwner, headRepo, _ := strings.Cut(headFullName, "/")
+
+		pullRequests = append(pullRequests, &base.PullRequest{
+			Number:         pr.ID + d.maxIssueIndex,
+			Title:          pr.Title,
+			PosterName:     pr.Author.Name(),
+			Content:        pr.Summary.Raw,
+			State:          state,
+			Created:        pr.CreatedOn,
+			Updated:        pr.UpdatedOn,
+			Closed:         closed,
+			Merged:         merged,
+			MergedTime:     mergedTime,
+			MergeCommitSHA: mergeCommitSHA,
+			IsDraft:        pr.Draft,
+			Head: base.PullRequestBranch{
+				CloneURL:  fmt.Sprintf("%s/%s.git", d.webURL, headFullName),
+				Ref:       pr.Source.Branch.Name,
+				SHA:       d.resolveCommit(ctx, headFullName, pr.Source.Commit.Hash),
+				OwnerName: headOwner,
+				RepoName:  headRepo,
+			},
+			Base: base.PullRequestBranch{
+				Ref:       pr.Destination.Branch.Name,
+				SHA:       d.resolveCommit(ctx, repoFullName, pr.Destination.Commit.Hash),
+				OwnerName: d.workspace,
+				RepoName:  d.repoSlug,
+			},
+			ForeignIndex: pr.ID,
+			Context:      bitbucketIssueContext{IsPullRequest: true},
+		})
+
+		// SECURITY: Ensure that the PR is safe
+		_ = CheckAndEnsureSafePR(pullRequests[len(pullRequests)-1], d.webURL, d)
+	}
+
+	return pullRequests, resp.Next == "", nil
+}
+
+// resolveCommit returns the full hash of the abbreviated commit hash the pull requests refer to
+func (d *BitbucketCloudDownloader) resolveCommit(ctx context.Context, repoFullName, hash string) string {
+	if hash == "" || len(hash) == 40 {
+		return hash
+	}
+	key := repoFullName + ":" + hash
+	if full, ok := d.commitMap[key]; ok {
+		return full
+	}
+
+	owner, repo, _ := strings.Cut(repoFullName, "/")
+	var commit bitbucketCloudCommit
+	err := d.callAPI(ctx, fmt.Sprintf("/repositories/%s/%s/commit/%s", url.PathEscape(owner), url.PathEscape(repo), url.PathEscape(hash)), url.Values{"fields": {"hash"}}, &commit)
+	if err != nil {
+		// the commit of a deleted fork can't be resolved anymore
+		log.Warn("Unable to resolve the commit %s of %s in %s: %v", hash, repoFullName, d, err)
+	}
+	d.commitMap[key] = commit.Hash
+	return commit.Hash
+}
+
+// GetReviews returns the approvals and the change requests of the participants, and the inline comments
+func (d *BitbucketCloudDownloader) GetReviews(ctx context.Context, reviewable base.Reviewable) ([]*base.Review, error) {
+	var pr struct {
+		Participants []struct {
+			User           *bitbucketCloudUser `json:"user"`
+			Approved       bool                `json:"approved"`
+			State          string              `json:"state"` // approved, changes_requested or null
+			ParticipatedOn *time.Time          `json:"participated_on"`
+		} `json:"participants"`
+	}
+	if err := d.callAPI(ctx, d.repoEndpoint("/pullrequests/%d", reviewable.GetForeignIndex()), nil, &pr); err != nil {
+		return nil, err
+	}
+
+	reviews := make([]*base.Review, 0, len(pr.Participants))
+	for _, p := range pr.Participants {
+		var state string
+		switch {
+		case p.Approved || p.State == "approved":
+			state = base.ReviewStateApproved
+		case p.State == "changes_requested":
+			state = base.ReviewStateChangesRequested
+		default:
+			continue
+		}
+		review := &base.Review{
+			IssueIndex:   reviewable.GetLocalIndex(),
+			ReviewerName: p.User.Name(),
+			State:        state,
+		}
+		if p.ParticipatedOn != nil {
+			review.CreatedAt = *p.ParticipatedOn
+		}
+		reviews = append(reviews, review)
+	}
+
+	comments, err := getAllBitbucketCloudPages[bitbucketCloudComment](ctx, d, d.repoEndpoint("/pullrequests/%d/comments", reviewable.GetForeignIndex()), nil)
+	if err != nil {
+		return nil, err
+	}
+	for _, comment := range comments {
+		if comment.Deleted || comment.Pending || comment.Inline == nil {
+			continue
+		}
+		// the line in the new file, or the negated line in the old file for removed lines
+		var line int
+		if comment.Inline.To != nil {
+			line = *comment.Inline.To
+		} else if comment.Inline.From != nil {
+			line = -*comment.Inline.From
+		}
+		var inReplyTo int64
+		if comment.Parent != nil {
+			inReplyTo = comment.Parent.ID
+		}
+		reviews = append(reviews, &base.Review{
+			IssueIndex:   reviewable.GetLocalIndex(),
+			ReviewerName: comment.User.Name(),
+			CreatedAt:    comment.CreatedOn,
+			State:        base.ReviewStateCommented,
+			Comments: []*base.ReviewComment{{
+				ID:        comment.ID,
+				InReplyTo: inReplyTo,
+				Content:   comment.Content.Raw,
+				TreePath:  comment.Inline.Path,
+				Line:      line,
+				CreatedAt: comment.CreatedOn,
+				UpdatedAt: comment.UpdatedOn,
+			}},
+		})
+	}
+	return reviews, nil
+}
+
+// parseBitbucketRawAuthor splits an author like "Name <email>" into the name and the email
+func parseBitbucketRawAuthor(raw string) (name, email string) {
+	name, rest, ok := strings.Cut(raw, "<")
+	if !ok {
+		return strings.TrimSpace(raw), ""
+	}
+	email, _, _ = strings.Cut(rest, ">")
+	return strings.TrimSpace(name), strings.TrimSpace(email)
+}
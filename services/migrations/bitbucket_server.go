@@ -0,0 +1,404 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package migrations
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"time"
+
+	"gitea.dev/modules/log"
+	base "gitea.dev/modules/migration"
+)
+
+var _ base.Downloader = &BitbucketServerDownloader{}
+
+// bitbucketServerMaxPerPage is the page size used to request all the activities of a pull request
+const bitbucketServerMaxPerPage = 100
+
+type bitbucketServerUser struct {
+	ID           int64  `json:"id"`
+	Name         string `json:"name"`
+	EmailAddress string `json:"emailAddress"`
+	DisplayName  string `json:"displayName"`
+}
+
+type bitbucketServerRepository struct {
+	Slug        string `json:"slug"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Public      bool   `json:"public"`
+	Project     struct {
+		Key string `json:"key"`
+	} `json:"project"`
+	Links struct {
+		Clone []bitbucketCloneLink `json:"clone"`
+		Self  []struct {
+			Href string `json:"href"`
+		} `json:"self"`
+	} `json:"links"`
+}
+
+type bitbucketServerRef struct {
+	ID           string                    `json:"id"`
+	DisplayID    string                    `json:"displayId"`
+	LatestCommit string                    `json:"latestCommit"`
+	Repository   bitbucketServerRepository `json:"repository"`
+}
+
+type bitbucketServerPage[T any] struct {
+	Values        []T  `json:"values"`
+	IsLastPage    bool `json:"isLastPage"`
+	NextPageStart int  `json:"nextPageStart"`
+}
+
+type bitbucketServerComment struct {
+	ID          int64                     `json:"id"`
+	Text        string                    `json:"text"`
+	Author      bitbucketServerUser       `json:"author"`
+	CreatedDate int64                     `json:"createdDate"`
+	UpdatedDate int64                     `json:"updatedDate"`
+	Comments    []*bitbucketServerComment `json:"comments"`
+}
+
+type bitbucketServerActivity struct {
+	ID            int64                   `json:"id"`
+	CreatedDate   int64                   `json:"createdDate"`
+	User          bitbucketServerUser     `json:"user"`
+	Action        string                  `json:"action"`        // OPENED, COMMENTED, APPROVED, UNAPPROVED, REVIEWED, MERGED, DECLINED, ...
+	CommentAction string                  `json:"commentAction"` // ADDED, EDITED, REPLIED or DELETED
+	Comment       *bitbucketServerComment `json:"comment"`
+	CommentAnchor *struct {
+		Path     string `json:"path"`
+		Line     int    `json:"line"`
+		FileType string `json:"fileType"` // FROM or TO
+		ToHash   string `json:"toHash"`
+	} `json:"commentAnchor"`
+	Commit *struct {
+		ID string `json:"id"`
+	} `json:"commit"`
+}
+
+// BitbucketServerDownloader implements a Downloader interface to get repository information from
+// Bitbucket Server / Data Center, which has neither issues nor releases
+type BitbucketServerDownloader struct {
+	base.NullDownloader
+	client     *http.Client
+	baseURL    *url.URL
+	apiURL     *url.URL
+	projectKey string
+	repoSlug   string
+}
+
+// NewBitbucketServerDownloader creates a new downloader, baseURL is the URL of the instance including its context path
+func NewBitbucketServerDownloader(ctx context.Context, baseURL *url.URL, projectKey, repoSlug, username, password, token string) *BitbucketServerDownloader {
+	return &BitbucketServerDownloader{
+		client:     newBitbucketHTTPClient(ctx, username, password, token),
+		baseURL:    baseURL,
+		apiURL:     baseURL.JoinPath("rest", "api", "1.0"),
+		projectKey: projectKey,
+		repoSlug:   repoSlug,
+	}
+}
+
+// String implements Stringer
+func (d *BitbucketServerDownloader) String() string {
+	return fmt.Sprintf("migration from Bitbucket Server %s %s/%s", d.baseURL, d.projectKey, d.repoSlug)
+}
+
+func (d *BitbucketServerDownloader) LogString() string {
+	if d == nil {
+		return "<BitbucketServerDownloader nil>"
+	}
+	return fmt.Sprintf("<BitbucketServerDownloader %s %s/%s>", d.baseURL, d.projectKey, d.repoSlug)
+}
+
+// FormatCloneURL add authentication into remote URLs
+func (d *BitbucketServerDownloader) FormatCloneURL(opts base.MigrateOptions, remoteAddr string) (string, error) {
+	return formatBitbucketCloneURL(opts, remoteAddr)
+}
+
+func (d *BitbucketServerDownloader) repoEndpoint(format string, args ...any) string {
+	return fmt.Sprintf("/projects/%s/repos/%s", url.PathEscape(d.projectKey), url.PathEscape(d.repoSlug)) + fmt.Sprintf(format, args...)
+}
+
+func (d *BitbucketServerDownloader) callAPI(ctx context.Context, endpoint string, query url.Values, result any) error {
+	return callBitbucketAPI(ctx, d.client, d.apiURL, endpoint, query, result)
+}
+
+func bitbucketServerTime(ms int64) time.Time {
+	return time.UnixMilli(ms)
+}
+
+// GetRepoInfo returns repository information
+func (d *BitbucketServerDownloader) GetRepoInfo(ctx context.Context) (*base.Repository, error) {
+	var repo bitbucketServerRepository
+	if err := d.callAPI(ctx, d.repoEndpoint(""), nil, &repo); err != nil {
+		return nil, err
+	}
+
+	cloneURL := findBitbucketHTTPCloneURL(repo.Links.Clone)
+	if cloneURL == "" {
+		return nil, fmt.Errorf("repository %s/%s has no HTTP clone URL", d.projectKey, d.repoSlug)
+	}
+
+	r := &base.Repository{
+		Name:        repo.Name,
+		Owner:       repo.Project.Key,
+		IsPrivate:   !repo.Public,
+		Description: repo.Description,
+		CloneURL:    cloneURL,
+	}
+	if len(repo.Links.Self) > 0 {
+		r.OriginalURL = repo.Links.Self[0].Href
+	}
+
+	var defaultBranch struct {
+		DisplayID string `json:"displayId"`
+	}
+	if err := d.callAPI(ctx, d.repoEndpoint("/default-branch"), nil, &defaultBranch); err != nil {
+		// an empty repository has no default branch
+		log.Warn("Unable to get the default branch of %s: %v", d, err)
+	}
+	r.DefaultBranch = defaultBranch.DisplayID
+	return r, nil
+}
+
+// GetTopics return repository topics
+func (d *BitbucketServerDownloader) GetTopics(_ context.Context) ([]string, error) {
+	return []string{}, nil
+}
+
+// GetPullRequests returns pull requests
+func (d *BitbucketServerDownloader) GetPullRequests(ctx context.Context, page, perPage int) ([]*base.PullRequest, bool, error) {
+	var resp bitbucketServerPage[struct {
+		ID          int64              `json:"id"`
+		Title       string             `json:"title"`
+		Description string             `json:"description"`
+		State       string             `json:"state"` // OPEN, MERGED or DECLINED
+		Draft       bool               `json:"draft"`
+		Locked      bool               `json:"locked"`
+		CreatedDate int64              `json:"createdDate"`
+		UpdatedDate int64              `json:"updatedDate"`
+		ClosedDate  int64              `json:"closedDate"`
+		FromRef     bitbucketServerRef `json:"fromRef"`
+		ToRef       bitbucketServerRef `json:"toRef"`
+		Author      struct {
+			User bitbucketServerUser `json:"user"`
+		} `json:"author"`
+	}]
+	err := d.callAPI(ctx, d.repoEndpoint("/pull-requests"), url.Values{
+		"state": {"ALL"},
+		"order": {"OLDEST"},
+		"start": {strconv.Itoa((page - 1) * perPage)},
+		"limit": {strconv.Itoa(perPage)},
+	}, &resp)
+	if err != nil {
+		return nil, false, err
+	}
+
+	pullRequests := make([]*base.PullRequest, 0, len(resp.Values))
+	for _, pr := range resp.Values {
+		state := "open"
+		merged := false
+		var closed, mergedTime *time.Time
+		var mergeCommitSHA string
+		if pr.State != "OPEN" {
+			state = "closed"
+			// older versions don't return the closed date
+			closedDate := bitbucketServerTime(pr.UpdatedDate)
+			if pr.ClosedDate != 0 {
+				closedDate = bitbucketServerTime(pr.ClosedDate)
+			}
+			closed = &closedDate
+			if pr.State == "MERGED" {
+				merged = true
+				mergedTime = &closedDate
+				mergeCommitSHA = d.getMergeCommit(ctx, pr.ID)
+			}
+		}
+
+		pullRequests = append(pullRequests, &base.PullRequest{
+			Number:         pr.ID,
+			Title:          pr.Title,
+			PosterID:       pr.Author.User.ID,
+			PosterName:     pr.Author.User.Name,
+			PosterEmail:    pr.Author.User.EmailAddress,
+			Content:        pr.Description,
+			State:          state,
+			Created:        bitbucketServerTime(pr.CreatedDate),
+			Updated:        bitbucketServerTime(pr.UpdatedDate),
+			Closed:         closed,
+			Merged:         merged,
+			MergedTime:     mergedTime,
+			MergeCommitSHA: mergeCommitSHA,
+			IsLocked:       pr.Locked,
+			IsDraft:        pr.Draft,
+			Head: base.PullRequestBranch{
+				CloneURL:  findBitbucketHTTPCloneURL(pr.FromRef.Repository.Links.Clone),
+				Ref:       pr.FromRef.DisplayID,
+				SHA:       pr.FromRef.LatestCommit,
+				OwnerName: pr.FromRef.Repository.Project.Key,
+				RepoName:  pr.FromRef.Repository.Slug,
+			},
+			Base: base.PullRequestBranch{
+				Ref:       pr.ToRef.DisplayID,
+				SHA:       pr.ToRef.LatestCommit,
+				OwnerName: pr.ToRef.Repository.Project.Key,
+				RepoName:  pr.ToRef.Repository.Slug,
+			},
+			ForeignIndex: pr.ID,
+			Context:      bitbucketIssueContext{IsPullRequest: true},
+		})
+
+		// SECURITY: Ensure that the PR is safe
+		_ = CheckAndEnsureSafePR(pullRequests[len(pullRequests)-1], d.baseURL.String(), d)
+	}
+
+	return pullRequests, resp.IsLastPage, nil
+}
+
+// getActivities returns the activities of a pull request from the oldest
+func (d *BitbucketServerDownloader) getActivities(ctx context.Context, prID int64) ([]*bitbucketServerActivity, error) {
+	var activities []*bitbucketServerActivity
+	start := 0
+	for {
+		var resp bitbucketServerPage[*bitbucketServerActivity]
+		err := d.callAPI(ctx, d.repoEndpoint("/pull-requests/%d/activities", prID), url.Values{
+			"start": {strconv.Itoa(start)},
+			"limit": {strconv.Itoa(bitbucketServerMaxPerPage)},
+		}, &resp)
+		if err != nil {
+			return nil, err
+		}
+		activities = append(activities, resp.Values...)
+		if resp.IsLastPage || len(resp.Values) == 0 {
+			break
+		}
+		start = resp.NextPageStart
+	}
+	// the activities are listed from the most recent
+	slices.Reverse(activities)
+	return activities, nil
+}
+
+// getMergeCommit returns the merge commit of a merged pull request, the listed pull requests don't include it
+func (d *BitbucketServerDownloader) getMergeCommit(ctx context.Context, prID int64) string {
+	activities, err := d.getActivities(ctx, prID)
+	if err != nil {
+		log.Warn("Unable to get the activities of the pull request %d in %s: %v", prID, d, err)
+		return ""
+	}
+	for _, activity := range activities {
+		if activity.Action == "MERGED" && activity.Commit != nil {
+			return activity.Commit.ID
+		}
+	}
+	return ""
+}
+
+// flattenBitbucketServerComments returns the comment followed by all its replies
+func flattenBitbucketServerComments(comment *bitbucketServerComment) []*bitbucketServerComment {
+	comments := []*bitbucketServerComment{comment}
+	for _, reply := range comment.Comments {
+		comments = append(comments, flattenBitbucketServerComments(reply)...)
+	}
+	return comments
+}
+
+// GetComments returns the general comments of a pull request with their replies,
+// the comments on the diff are returned as reviews
+func (d *BitbucketServerDownloader) GetComments(ctx context.Context, commentable base.Commentable) ([]*base.Comment, bool, error) {
+	activities, err := d.getActivities(ctx, commentable.GetForeignIndex())
+	if err != nil {
+		return nil, false, err
+	}
+
+	comments := make([]*base.Comment, 0, len(activities))
+	for _, activity := range activities {
+		// the replies are nested in the comment they reply to
+		if activity.Action != "COMMENTED" || activity.CommentAction != "ADDED" || activity.Comment == nil || activity.CommentAnchor != nil {
+			continue
+		}
+		for _, comment := range flattenBitbucketServerComments(activity.Comment) {
+			comments = append(comments, &base.Comment{
+				IssueIndex:  commentable.GetLocalIndex(),
+				Index:       comment.ID,
+				PosterID:    comment.Author.ID,
+				PosterName:  comment.Author.Name,
+				PosterEmail: comment.Author.EmailAddress,
+				Content:     comment.Text,
+				Created:     bitbucketServerTime(comment.CreatedDate),
+				Updated:     bitbucketServerTime(comment.UpdatedDate),
+			})
+		}
+	}
+	return comments, true, nil
+}
+
+// GetReviews returns the approvals, the "needs work" reviews and the comments on the diff of a pull request
+func (d *BitbucketServerDownloader) GetReviews(ctx context.Context, reviewable base.Reviewable) ([]*base.Review, error) {
+	activities, err := d.getActivities(ctx, reviewable.GetForeignIndex())
+	if err != nil {
+		return nil, err
+	}
+
+	reviews := make([]*base.Review, 0, len(activities))
+	for _, activity := range activities {
+		switch activity.Action {
+		case "APPROVED", "REVIEWED":
+			state := base.ReviewStateApproved
+			if activity.Action == "REVIEWED" {
+				state = base.ReviewStateChangesRequested
+			}
+			reviews = append(reviews, &base.Review{
+				ID:           activity.ID,
+				IssueIndex:   reviewable.GetLocalIndex(),
+				ReviewerID:   activity.User.ID,
+				ReviewerName: activity.User.Name,
+				CreatedAt:    bitbucketServerTime(activity.CreatedDate),
+				State:        state,
+			})
+		case "COMMENTED":
+			if activity.CommentAction != "ADDED" || activity.Comment == nil || activity.CommentAnchor == nil {
+				continue
+			}
+			anchor := activity.CommentAnchor
+			// the line in the new file, or the negated line in the old file for removed lines
+			line := anchor.Line
+			if anchor.FileType == "FROM" {
+				line = -line
+			}
+			var inReplyTo int64
+			for _, comment := range flattenBitbucketServerComments(activity.Comment) {
+				reviews = append(reviews, &base.Review{
+					IssueIndex:   reviewable.GetLocalIndex(),
+					ReviewerID:   comment.Author.ID,
+					ReviewerName: comment.Author.Name,
+					CreatedAt:    bitbucketServerTime(comment.CreatedDate),
+					State:        base.ReviewStateCommented,
+					Comments: []*base.ReviewComment{{
+						ID:        comment.ID,
+						InReplyTo: inReplyTo,
+						Content:   comment.Text,
+						TreePath:  anchor.Path,
+						Line:      line,
+						CommitID:  anchor.ToHash,
+						PosterID:  comment.Author.ID,
+						CreatedAt: bitbucketServerTime(comment.CreatedDate),
+						UpdatedAt: bitbucketServerTime(comment.UpdatedDate),
+					}},
+				})
+				if inReplyTo == 0 {
+					inReplyTo = comment.ID
+				}
+			}
+		}
+	}
+	return reviews, nil
+}
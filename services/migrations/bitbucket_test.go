@@ -0,0 +1,559 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package migrations
+
+import (
+	"net/url"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"gitea.dev/models/unittest"
+	base "gitea.dev/modules/migration"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseBitbucketCloudPath(t *testing.T) {
+	for _, p := range []string{"/gitea-test/test_repo", "/gitea-test/test_repo.git", "/gitea-test/test_repo/src/main/"} {
+		workspace, repoSlug, err := parseBitbucketCloudPath(p)
+		assert.NoError(t, err, p)
+		assert.Equal(t, "gitea-test", workspace, p)
+		assert.Equal(t, "test_repo", repoSlug, p)
+	}
+
+	_, _, err := parseBitbucketCloudPath("/gitea-test")
+	assert.Error(t, err)
+}
+
+func TestParseBitbucketServerURL(t *testing.T) {
+	cases := []struct {
+		url        string
+		baseURL    string
+		projectKey string
+		repoSlug   string
+	}{
+		{"https://bitbucket.example.com/projects/TEST/repos/test_repo", "https://bitbucket.example.com", "TEST", "test_repo"},
+		{"https://bitbucket.example.com/projects/TEST/repos/test_repo/browse", "https://bitbucket.example.com", "TEST", "test_repo"},
+		{"https://bitbucket.example.com/users/contributor/repos/test_repo/browse", "https://bitbucket.example.com", "~contributor", "test_repo"},
+		{"https://bitbucket.example.com/scm/test/test_repo.git", "https://bitbucket.example.com", "test", "test_repo"},
+		{"https://bitbucket.example.com/scm/~contributor/test_repo.git", "https://bitbucket.example.com", "~contributor", "test_repo"},
+		{"https://example.com/bitbucket/projects/TEST/repos/test_repo", "https://example.com/bitbucket", "TEST", "test_repo"},
+		{"http://example.com:7990/bitbucket/scm/test/test_repo.git", "http://example.com:7990/bitbucket", "test", "test_repo"},
+	}
+	for _, c := range cases {
+		u, _ := url.Parse(c.url)
+		baseURL, projectKey, repoSlug, err := parseBitbucketServerURL(u)
+		if assert.NoError(t, err, c.url) {
+			assert.Equal(t, c.baseURL, baseURL.String(), c.url)
+			assert.Equal(t, c.projectKey, projectKey, c.url)
+			assert.Equal(t, c.repoSlug, repoSlug, c.url)
+		}
+	}
+
+	for _, s := range []string{"https://bitbucket.example.com/", "https://bitbucket.example.com/projects/TEST", "https://bitbucket.example.com/projects/TEST/repos"} {
+		u, _ := url.Parse(s)
+		_, _, _, err := parseBitbucketServerURL(u)
+		assert.Error(t, err, s)
+	}
+}
+
+func TestBitbucketCloudDownloadRepo(t *testing.T) {
+	// Bitbucket Cloud requires an app password or an access token for the API
+	username := os.Getenv("BITBUCKET_USERNAME")
+	password := os.Getenv("BITBUCKET_APP_PASSWORD")
+	liveMode := password != ""
+
+	_, callerFile, _, _ := runtime.Caller(0)
+	fixtureDir := filepath.Join(filepath.Dir(callerFile), "_mock_data/TestBitbucketCloudDownloadRepo")
+	mockServer := unittest.NewMockWebServer(t, "https://api.bitbucket.org", fixtureDir, liveMode)
+
+	apiURL, _ := url.Parse(mockServer.URL + "/2.0")
+	ctx := t.Context()
+	downloader := NewBitbucketCloudDownloader(ctx, apiURL, "gitea-test", "test_repo", username, password, "")
+
+	repo, err := downloader.GetRepoInfo(ctx)
+	assert.NoError(t, err)
+	assertRepositoryEqual(t, &base.Repository{
+		Name:          "test_repo",
+		Owner:         "gitea-test",
+		Description:   "Test repository for testing migration from Bitbucket to Gitea",
+		Website:       "https://gitea.com",
+		CloneURL:      "https://bitbucket.org/gitea-test/test_repo.git",
+		OriginalURL:   "https://bitbucket.org/gitea-test/test_repo",
+		DefaultBranch: "main",
+	}, repo)
+
+	milestones, err := downloader.GetMilestones(ctx)
+	assert.NoError(t, err)
+	assertMilestonesEqual(t, []*base.Milestone{
+		{
+			Title: "1.0.0",
+			State: "open",
+		},
+		{
+			Title: "1.1.0",
+			State: "open",
+		},
+	}, milestones)
+
+	labels, err := downloader.GetLabels(ctx)
+	assert.NoError(t, err)
+	if assert.Len(t, labels, 10) {
+		assertLabelEqual(t, &base.Label{Name: "kind/bug", Color: "1d76db", Exclusive: true}, labels[0])
+		assertLabelEqual(t, &base.Label{Name: "priority/trivial", Color: "e99695", Exclusive: true}, labels[4])
+		assertLabelEqual(t, &base.Label{Name: "component/backend", Color: "c5def5", Exclusive: true}, labels[9])
+	}
+
+	releases, err := downloader.GetReleases(ctx)
+	assert.NoError(t, err)
+	assertReleasesEqual(t, []*base.Release{
+		{
+			TagName:         "v1.0.0",
+			TargetCommitish: "9f8e7d6c5b4a39281706f5e4d3c2b1a09f8e7d6c",
+			Name:            "v1.0.0",
+			Body:            "First release\n\n* Bitbucket migration test",
+			PublisherName:   "gitea-test",
+			Created:         time.Date(2026, 3, 5, 14, 30, 0, 0, time.UTC),
+			Published:       time.Date(2026, 3, 5, 14, 30, 0, 0, time.UTC),
+		},
+		{
+			Name:  "Downloads",
+			Body:  "The files of the Downloads page of the Bitbucket repository.",
+			Draft: true,
+			Assets: []*base.ReleaseAsset{
+				{
+					Name:          "test_repo-1.0.0.tar.gz",
+					Size:          new(2048),
+					DownloadCount: new(7),
+					Created:       time.Date(2026, 3, 5, 15, 1, 12, 532947000, time.UTC),
+					Updated:       time.Date(2026, 3, 5, 15, 1, 12, 532947000, time.UTC),
+				},
+			},
+			Created: time.Date(2026, 3, 5, 15, 1, 12, 532947000, time.UTC),
+		},
+	}, releases)
+
+	issues, isEnd, err := downloader.GetIssues(ctx, 1, 2)
+	assert.NoError(t, err)
+	assert.False(t, isEnd)
+	assertIssuesEqual(t, []*base.Issue{
+		{
+			Number:     1,
+			Title:      "Crash when the repository is empty",
+			Content:    "The migration crashes for empty repositories.",
+			PosterName: "contributor",
+			Milestone:  "1.0.0",
+			State:      "closed",
+			Created:    time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC),
+			Updated:    time.Date(2026, 3, 4, 12, 0, 0, 0, time.UTC),
+			Closed:     new(time.Date(2026, 3, 4, 12, 0, 0, 0, time.UTC)),
+			Labels: []*base.Label{
+				{Name: "kind/bug"},
+				{Name: "priority/critical"},
+				{Name: "component/backend"},
+			},
+			Assignees:    []string{"gitea-test"},
+			ForeignIndex: 1,
+			Context:      bitbucketIssueContext{IsPullRequest: false},
+		},
+		{
+			Number:     2,
+			Title:      "Migrate the wiki",
+			Content:    "It would be nice to migrate the wiki too.",
+			PosterName: "gitea-test",
+			Milestone:  "1.1.0",
+			State:      "open",
+			Created:    time.Date(2026, 3, 3, 8, 30, 0, 0, time.UTC),
+			Updated:    time.Date(2026, 3, 3, 8, 30, 0, 0, time.UTC),
+			Labels: []*base.Label{
+				{Name: "kind/enhancement"},
+				{Name: "priority/minor"},
+			},
+			ForeignIndex: 2,
+			Context:      bitbucketIssueContext{IsPullRequest: false},
+		},
+	}, issues)
+
+	issues, isEnd, err = downloader.GetIssues(ctx, 2, 2)
+	assert.NoError(t, err)
+	assert.True(t, isEnd)
+	assertIssuesEqual(t, []*base.Issue{
+		{
+			Number:     3,
+			Title:      "Duplicate of the crash",
+			Content:    "Same as #1",
+			PosterName: "reviewer",
+			State:      "closed",
+			Created:    time.Date(2026, 3, 3, 9, 0, 0, 0, time.UTC),
+			Updated:    time.Date(2026, 3, 3, 11, 15, 0, 0, time.UTC),
+			Closed:     new(time.Date(2026, 3, 3, 11, 15, 0, 0, time.UTC)),
+			Labels: []*base.Label{
+				{Name: "kind/bug"},
+				{Name: "priority/major"},
+			},
+			ForeignIndex: 3,
+			Context:      bitbucketIssueContext{IsPullRequest: false},
+		},
+	}, issues)
+
+	// the comment without content records a change of the state of the issue
+	comments, _, err := downloader.GetComments(ctx, &base.Issue{
+		Number:       1,
+		ForeignIndex: 1,
+		Context:      bitbucketIssueContext{IsPullRequest: false},
+	})
+	assert.NoError(t, err)
+	assertCommentsEqual(t, []*base.Comment{
+		{
+			IssueIndex: 1,
+			PosterName: "gitea-test",
+			Created:    time.Date(2026, 3, 2, 11, 0, 0, 0, time.UTC),
+			Updated:    time.Date(2026, 3, 2, 11, 0, 0, 0, time.UTC),
+			Content:    "I can reproduce it with an empty repository.",
+		},
+		{
+			IssueIndex: 1,
+			PosterName: "gitea-test",
+			Created:    time.Date(2026, 3, 4, 12, 0, 5, 0, time.UTC),
+			Updated:    time.Date(2026, 3, 4, 12, 10, 0, 0, time.UTC),
+			Content:    "Fixed in v1.0.0",
+		},
+	}, comments)
+
+	// the pull requests are numbered after the issues
+	prs, isEnd, err := downloader.GetPullRequests(ctx, 1, 50)
+	assert.NoError(t, err)
+	assert.True(t, isEnd)
+	assertPullRequestsEqual(t, []*base.PullRequest{
+		{
+			Number:         4,
+			Title:          "Fix the crash for empty repositories",
+			Content:        "Fixes #1",
+			PosterName:     "contributor",
+			State:          "closed",
+			Created:        time.Date(2026, 3, 4, 9, 0, 0, 0, time.UTC),
+			Updated:        time.Date(2026, 3, 4, 11, 45, 0, 0, time.UTC),
+			Closed:         new(time.Date(2026, 3, 4, 11, 45, 0, 0, time.UTC)),
+			Merged:         true,
+			MergedTime:     new(time.Date(2026, 3, 4, 11, 45, 0, 0, time.UTC)),
+			MergeCommitSHA: "c0ffee1234567890abcdef1234567890abcdef12",
+			Head: base.PullRequestBranch{
+				CloneURL:  "https://bitbucket.org/gitea-test/test_repo.git",
+				Ref:       "fix-empty",
+				SHA:       "1a2b3c4d5e6f7a8b9c0d1e2f3a4b5c6d7e8f9a0b",
+				OwnerName: "gitea-test",
+				RepoName:  "test_repo",
+			},
+			Base: base.PullRequestBranch{
+				Ref:       "main",
+				SHA:       "4a5b6c7d8e9f0a1b2c3d4e5f6a7b8c9d0e1f2a3b",
+				OwnerName: "gitea-test",
+				RepoName:  "test_repo",
+			},
+			ForeignIndex: 1,
+			Context:      bitbucketIssueContext{IsPullRequest: true},
+		},
+		{
+			Number:     5,
+			Title:      "Add the wiki migration",
+			Content:    "Work in progress",
+			PosterName: "contributor",
+			State:      "open",
+			Created:    time.Date(2026, 3, 6, 16, 0, 0, 0, time.UTC),
+			Updated:    time.Date(2026, 3, 7, 10, 20, 0, 0, time.UTC),
+			IsDraft:    true,
+			Head: base.PullRequestBranch{
+				CloneURL:  "https://bitbucket.org/contributor/test_repo.git",
+				Ref:       "wiki",
+				SHA:       "abcdef0123456789abcdef0123456789abcdef01",
+				OwnerName: "contributor",
+				RepoName:  "test_repo",
+			},
+			Base: base.PullRequestBranch{
+				Ref:       "main",
+				SHA:       "4a5b6c7d8e9f0a1b2c3d4e5f6a7b8c9d0e1f2a3b",
+				OwnerName: "gitea-test",
+				RepoName:  "test_repo",
+			},
+			ForeignIndex: 2,
+			Context:      bitbucketIssueContext{IsPullRequest: true},
+		},
+	}, prs)
+
+	comments, _, err = downloader.GetComments(ctx, prs[0])
+	assert.NoError(t, err)
+	assertCommentsEqual(t, []*base.Comment{
+		{
+			IssueIndex: 4,
+			PosterName: "gitea-test",
+			Created:    time.Date(2026, 3, 4, 9, 30, 0, 0, time.UTC),
+			Updated:    time.Date(2026, 3, 4, 9, 30, 0, 0, time.UTC),
+			Content:    "Thanks, looks good overall.",
+		},
+	}, comments)
+
+	reviews, err := downloader.GetReviews(ctx, prs[0])
+	assert.NoError(t, err)
+	assertReviewsEqual(t, []*base.Review{
+		{
+			IssueIndex:   4,
+			ReviewerName: "reviewer",
+			CreatedAt:    time.Date(2026, 3, 4, 9, 40, 0, 0, time.UTC),
+			State:        base.ReviewStateChangesRequested,
+		},
+		{
+			IssueIndex:   4,
+			ReviewerName: "gitea-test",
+			CreatedAt:    time.Date(2026, 3, 4, 11, 30, 0, 0, time.UTC),
+			State:        base.ReviewStateApproved,
+		},
+		{
+			IssueIndex:   4,
+			ReviewerName: "reviewer",
+			CreatedAt:    time.Date(2026, 3, 4, 9, 35, 0, 0, time.UTC),
+			State:        base.ReviewStateCommented,
+			Comments: []*base.ReviewComment{
+				{
+					ID:        80002,
+					Content:   "Please check the error here.",
+					TreePath:  "services/migrate.go",
+					Line:      12,
+					CreatedAt: time.Date(2026, 3, 4, 9, 35, 0, 0, time.UTC),
+					UpdatedAt: time.Date(2026, 3, 4, 9, 36, 0, 0, time.UTC),
+				},
+			},
+		},
+		{
+			IssueIndex:   4,
+			ReviewerName: "contributor",
+			CreatedAt:    time.Date(2026, 3, 4, 10, 5, 0, 0, time.UTC),
+			State:        base.ReviewStateCommented,
+			Comments: []*base.ReviewComment{
+				{
+					ID:        80003,
+					InReplyTo: 80002,
+					Content:   "Done.",
+					TreePath:  "services/migrate.go",
+					Line:      12,
+					CreatedAt: time.Date(2026, 3, 4, 10, 5, 0, 0, time.UTC),
+					UpdatedAt: time.Date(2026, 3, 4, 10, 5, 0, 0, time.UTC),
+				},
+			},
+		},
+		{
+			IssueIndex:   4,
+			ReviewerName: "reviewer",
+			CreatedAt:    time.Date(2026, 3, 4, 9, 38, 0, 0, time.UTC),
+			State:        base.ReviewStateCommented,
+			Comments: []*base.ReviewComment{
+				{
+					ID:        80004,
+					Content:   "Why was this removed?",
+					TreePath:  "README.md",
+					Line:      -7,
+					CreatedAt: time.Date(2026, 3, 4, 9, 38, 0, 0, time.UTC),
+					UpdatedAt: time.Date(2026, 3, 4, 9, 38, 0, 0, time.UTC),
+				},
+			},
+		},
+	}, reviews)
+}
+
+func TestBitbucketServerDownloadRepo(t *testing.T) {
+	token := os.Getenv("BITBUCKET_SERVER_TOKEN")
+	liveMode := token != ""
+
+	_, callerFile, _, _ := runtime.Caller(0)
+	fixtureDir := filepath.Join(filepath.Dir(callerFile), "_mock_data/TestBitbucketServerDownloadRepo")
+	mockServer := unittest.NewMockWebServer(t, "https://bitbucket.example.com", fixtureDir, liveMode)
+
+	baseURL, _ := url.Parse(mockServer.URL)
+	ctx := t.Context()
+	downloader := NewBitbucketServerDownloader(ctx, baseURL, "TEST", "test_repo", "", "", token)
+
+	repo, err := downloader.GetRepoInfo(ctx)
+	assert.NoError(t, err)
+	assertRepositoryEqual(t, &base.Repository{
+		Name:          "test_repo",
+		Owner:         "TEST",
+		IsPrivate:     true,
+		Description:   "Test repository for testing migration from Bitbucket Server to Gitea",
+		CloneURL:      mockServer.URL + "/scm/test/test_repo.git",
+		OriginalURL:   mockServer.URL + "/projects/TEST/repos/test_repo/browse",
+		DefaultBranch: "main",
+	}, repo)
+
+	// Bitbucket Server has no issue tracker
+	_, _, err = downloader.GetIssues(ctx, 1, 2)
+	assert.True(t, base.IsErrNotSupported(err))
+
+	prs, isEnd, err := downloader.GetPullRequests(ctx, 1, 2)
+	assert.NoError(t, err)
+	assert.True(t, isEnd)
+	assertPullRequestsEqual(t, []*base.PullRequest{
+		{
+			Number:         1,
+			Title:          "Fix the crash for empty repositories",
+			Content:        "Fixes the crash.",
+			PosterID:       102,
+			PosterName:     "contributor",
+			PosterEmail:    "contributor@example.com",
+			State:          "closed",
+			Created:        time.UnixMilli(1772614800000),
+			Updated:        time.UnixMilli(1772624700000),
+			Closed:         new(time.UnixMilli(1772624700000)),
+			Merged:         true,
+			MergedTime:     new(time.UnixMilli(1772624700000)),
+			MergeCommitSHA: "c0ffee1234567890abcdef1234567890abcdef12",
+			Head: base.PullRequestBranch{
+				CloneURL:  mockServer.URL + "/scm/test/test_repo.git",
+				Ref:       "fix-empty",
+				SHA:       "1a2b3c4d5e6f7a8b9c0d1e2f3a4b5c6d7e8f9a0b",
+				OwnerName: "TEST",
+				RepoName:  "test_repo",
+			},
+			Base: base.PullRequestBranch{
+				Ref:       "main",
+				SHA:       "4a5b6c7d8e9f0a1b2c3d4e5f6a7b8c9d0e1f2a3b",
+				OwnerName: "TEST",
+				RepoName:  "test_repo",
+			},
+			ForeignIndex: 1,
+			Context:      bitbucketIssueContext{IsPullRequest: true},
+		},
+		{
+			Number:      2,
+			Title:       "Add the wiki migration",
+			Content:     "Work in progress",
+			PosterID:    102,
+			PosterName:  "contributor",
+			PosterEmail: "contributor@example.com",
+			State:       "open",
+			Created:     time.UnixMilli(1772812800000),
+			Updated:     time.UnixMilli(1772878800000),
+			IsDraft:     true,
+			Head: base.PullRequestBranch{
+				CloneURL:  mockServer.URL + "/scm/~contributor/test_repo.git",
+				Ref:       "wiki",
+				SHA:       "abcdef0123456789abcdef0123456789abcdef01",
+				OwnerName: "~CONTRIBUTOR",
+				RepoName:  "test_repo",
+			},
+			Base: base.PullRequestBranch{
+				Ref:       "main",
+				SHA:       "4a5b6c7d8e9f0a1b2c3d4e5f6a7b8c9d0e1f2a3b",
+				OwnerName: "TEST",
+				RepoName:  "test_repo",
+			},
+			ForeignIndex: 2,
+			Context:      bitbucketIssueContext{IsPullRequest: true},
+		},
+	}, prs)
+
+	// the replies are nested in the comments, the comments on the diff are reviews
+	comments, _, err := downloader.GetComments(ctx, prs[0])
+	assert.NoError(t, err)
+	assertCommentsEqual(t, []*base.Comment{
+		{
+			IssueIndex:  1,
+			PosterID:    101,
+			PosterName:  "gitea-test",
+			PosterEmail: "gitea-test@example.com",
+			Created:     time.UnixMilli(1772616600000),
+			Updated:     time.UnixMilli(1772616600000),
+			Content:     "Thanks, looks good overall.",
+		},
+		{
+			IssueIndex:  1,
+			PosterID:    102,
+			PosterName:  "contributor",
+			PosterEmail: "contributor@example.com",
+			Created:     time.UnixMilli(1772617200000),
+			Updated:     time.UnixMilli(1772617500000),
+			Content:     "Thank you!",
+		},
+	}, comments)
+
+	reviews, err := downloader.GetReviews(ctx, prs[0])
+	assert.NoError(t, err)
+	assertReviewsEqual(t, []*base.Review{
+		{
+			IssueIndex:   1,
+			ReviewerID:   103,
+			ReviewerName: "reviewer",
+			CreatedAt:    time.UnixMilli(1772616900000),
+			State:        base.ReviewStateCommented,
+			Comments: []*base.ReviewComment{
+				{
+					ID:        303,
+					Content:   "Please check the error here.",
+					TreePath:  "services/migrate.go",
+					Line:      12,
+					CommitID:  "1a2b3c4d5e6f7a8b9c0d1e2f3a4b5c6d7e8f9a0b",
+					PosterID:  103,
+					CreatedAt: time.UnixMilli(1772616900000),
+					UpdatedAt: time.UnixMilli(1772616900000),
+				},
+			},
+		},
+		{
+			IssueIndex:   1,
+			ReviewerID:   102,
+			ReviewerName: "contributor",
+			CreatedAt:    time.UnixMilli(1772618700000),
+			State:        base.ReviewStateCommented,
+			Comments: []*base.ReviewComment{
+				{
+					ID:        304,
+					InReplyTo: 303,
+					Content:   "Done.",
+					TreePath:  "services/migrate.go",
+					Line:      12,
+					CommitID:  "1a2b3c4d5e6f7a8b9c0d1e2f3a4b5c6d7e8f9a0b",
+					PosterID:  102,
+					CreatedAt: time.UnixMilli(1772618700000),
+					UpdatedAt: time.UnixMilli(1772618700000),
+				},
+			},
+		},
+		{
+			IssueIndex:   1,
+			ReviewerID:   103,
+			ReviewerName: "reviewer",
+			CreatedAt:    time.UnixMilli(1772617080000),
+			State:        base.ReviewStateCommented,
+			Comments: []*base.ReviewComment{
+				{
+					ID:        305,
+					Content:   "Why was this removed?",
+					TreePath:  "README.md",
+					Line:      -7,
+					CommitID:  "1a2b3c4d5e6f7a8b9c0d1e2f3a4b5c6d7e8f9a0b",
+					PosterID:  103,
+					CreatedAt: time.UnixMilli(1772617080000),
+					UpdatedAt: time.UnixMilli(1772617080000),
+				},
+			},
+		},
+		{
+			ID:           2005,
+			IssueIndex:   1,
+			ReviewerID:   103,
+			ReviewerName: "reviewer",
+			CreatedAt:    time.UnixMilli(1772617200000),
+			State:        base.ReviewStateChangesRequested,
+		},
+		{
+			ID:           2007,
+			IssueIndex:   1,
+			ReviewerID:   101,
+			ReviewerName: "gitea-test",
+			CreatedAt:    time.UnixMilli(1772623800000),
+			State:        base.ReviewStateApproved,
+		},
+	}, reviews)
+}
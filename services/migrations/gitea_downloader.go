@@ -20,8 +20,9 @@ import (
 )
 
 var (
-	_ base.Downloader        = &GiteaDownloader{}
-	_ base.DownloaderFactory = &GiteaDownloaderFactory{}
+	_ base.Downloader            = &GiteaDownloader{}
+	_ base.IncrementalDownloader = &GiteaDownloader{}
+	_ base.DownloaderFactory     = &GiteaDownloaderFactory{}
 )
 
 func init() {
@@ -411,13 +412,31 @@ func (g *GiteaDownloader) GetIssues(ctx context.Context, page, perPage int) ([]*
 	if perPage > g.maxPerPage {
 		perPage = g.maxPerPage
 	}
-	allIssues := make([]*base.Issue, 0, perPage)
+	return g.getIssues(ctx, gitea_sdk.ListIssueOption{
+		ListOptions: gitea_sdk.ListOptions{Page: page, PageSize: perPage},
+		State:       gitea_sdk.StateAll,
+		Type:        gitea_sdk.IssueTypeIssue,
+	})
+}
 
-	issues, _, err := g.client.Issues.ListRepoIssues(g.ctx, g.repoOwner, g.repoName, gitea_sdk.ListIssueOption{
+// GetIssuesSince returns the issues updated since the given time according page and perPage
+func (g *GiteaDownloader) GetIssuesSince(ctx context.Context, since time.Time, page, perPage int) ([]*base.Issue, bool, error) {
+	if perPage > g.maxPerPage {
+		perPage = g.maxPerPage
+	}
+	return g.getIssues(ctx, gitea_sdk.ListIssueOption{
 		ListOptions: gitea_sdk.ListOptions{Page: page, PageSize: perPage},
 		State:       gitea_sdk.StateAll,
 		Type:        gitea_sdk.IssueTypeIssue,
+		Since:       since,
 	})
+}
+
+func (g *GiteaDownloader) getIssues(ctx context.Context, opt gitea_sdk.ListIssueOption) ([]*base.Issue, bool, error) {
+	perPage := opt.PageSize
+	allIssues := make([]*base.Issue, 0, perPage)
+
+	issues, _, err := g.client.Issues.ListRepoIssues(g.ctx, g.repoOwner, g.repoName, opt)
 	if err != nil {
 		return nil, false, fmt.Errorf("error while listing issues: %w", err)
 	}
@@ -520,19 +539,52 @@ func (g *GiteaDownloader) GetPullRequests(ctx context.Context, page, perPage int
 	if perPage > g.maxPerPage {
 		perPage = g.maxPerPage
 	}
-	allPRs := make([]*base.PullRequest, 0, perPage)
+	return g.getPullRequests(ctx, gitea_sdk.ListPullRequestsOptions{
+		ListOptions: gitea_sdk.ListOptions{
+			Page:     page,
+			PageSize: perPage,
+		},
+		State: gitea_sdk.StateAll,
+	}, time.Time{})
+}
 
-	prs, _, err := g.client.PullRequests.ListRepoPullRequests(g.ctx, g.repoOwner, g.repoName, gitea_sdk.ListPullRequestsOptions{
+// GetPullRequestsSince returns the pull requests updated since the given time according page and perPage,
+// they can't be filtered so they are listed from the most recently updated until an older one is found
+func (g *GiteaDownloader) GetPullRequestsSince(ctx context.Context, since time.Time, page, perPage int) ([]*base.PullRequest, bool, error) {
+	if perPage > g.maxPerPage {
+		perPage = g.maxPerPage
+	}
+	return g.getPullRequests(ctx, gitea_sdk.ListPullRequestsOptions{
 		ListOptions: gitea_sdk.ListOptions{
 			Page:     page,
 			PageSize: perPage,
 		},
 		State: gitea_sdk.StateAll,
-	})
+		Sort:  "recentupdate",
+	}, since)
+}
+
+// GetAllCommentsSince is not supported, the comments are listed per issue
+func (g *GiteaDownloader) GetAllCommentsSince(_ context.Context, _ time.Time, _, _ int) ([]*base.Comment, bool, error) {
+	return nil, false, base.ErrNotSupported{Entity: "AllCommentsSince"}
+}
+
+func (g *GiteaDownloader) getPullRequests(ctx context.Context, opt gitea_sdk.ListPullRequestsOptions, since time.Time) ([]*base.PullRequest, bool, error) {
+	page, perPage := opt.Page, opt.PageSize
+	allPRs := make([]*base.PullRequest, 0, perPage)
+
+	prs, _, err := g.client.PullRequests.ListRepoPullRequests(g.ctx, g.repoOwner, g.repoName, opt)
 	if err != nil {
 		return nil, false, fmt.Errorf("error while listing pull requests (page: %d, pagesize: %d). Error: %w", page, perPage, err)
 	}
+	reachedSince := false
 	for _, pr := range prs {
+		if !since.IsZero() && pr.Updated != nil && pr.Updated.Before(since) {
+			// the pull requests are sorted by their update time, the next ones are older too
+			reachedSince = true
+			break
+		}
+
 		var milestone string
 		if pr.Milestone != nil {
 			milestone = pr.Milestone.Title
@@ -628,9 +680,9 @@ func (g *GiteaDownloader) GetPullRequests(ctx context.Context, page, perPage int
 		_ = CheckAndEnsureSafePR(allPRs[len(allPRs)-1], g.baseURL, g)
 	}
 
-	isEnd := len(prs) < perPage
+	isEnd := len(prs) < perPage || reachedSince
 	if !g.pagination {
-		isEnd = len(prs) == 0
+		isEnd = len(prs) == 0 || reachedSince
 	}
 	return allPRs, isEnd, nil
 }
@@ -18,6 +18,7 @@ import (
 	repo_model "gitea.dev/models/repo"
 	"gitea.dev/models/repostats"
 	user_model "gitea.dev/models/user"
+	"gitea.dev/modules/container"
 	"gitea.dev/modules/git"
 	"gitea.dev/modules/git/gitcmd"
 	"gitea.dev/modules/label"
@@ -53,6 +54,11 @@ type GiteaLocalUploader struct {
 	userMap        map[int64]int64 // external user id mapping to user id
 	prCache        map[int64]*issues_model.PullRequest
 	gitServiceType structs.GitServiceType
+
+	// recordForeignReferences keeps the source IDs of the comments and reviews, so they can be matched
+	// when the repository is synced again
+	recordForeignReferences bool
+	conflictingIssues       container.Set[int64] // indexes of local issues which aren't the source issues, skipped when syncing
 }
 
 // NewGiteaLocalUploader creates a gitea Uploader via gitea API v1
@@ -67,6 +73,8 @@ func NewGiteaLocalUploader(_ context.Context, doer *user_model.User, repoOwner,
 		prHeadCache: make(map[string]string),
 		userMap:     make(map[int64]int64),
 		prCache:     make(map[int64]*issues_model.PullRequest),
+
+		conflictingIssues: make(container.Set[int64]),
 	}
 }
 
@@ -467,6 +475,7 @@ func (g *GiteaLocalUploader) CreateIssues(ctx context.Context, issues ...*base.I
 // CreateComments creates comments of issues
 func (g *GiteaLocalUploader) CreateComments(ctx context.Context, comments ...*base.Comment) error {
 	cms := make([]*issues_model.Comment, 0, len(comments))
+	foreignIDs := make([]int64, 0, len(comments))
 	for _, comment := range comments {
 		var issue *issues_model.Issue
 		issue, ok := g.issues[comment.IssueIndex]
@@ -541,12 +550,25 @@ func (g *GiteaLocalUploader) CreateComments(ctx context.Context, comments ...*ba
 		}
 
 		cms = append(cms, &cm)
+		foreignIDs = append(foreignIDs, comment.Index)
 	}
 
 	if len(cms) == 0 {
 		return nil
 	}
-	return issues_model.InsertIssueComments(ctx, cms)
+	if err := issues_model.InsertIssueComments(ctx, cms); err != nil {
+		return err
+	}
+	if !g.recordForeignReferences {
+		return nil
+	}
+	refs := make([]*repo_model.ForeignReference, 0, len(cms))
+	for i, cm := range cms {
+		if foreignIDs[i] > 0 {
+			refs = append(refs, &repo_model.ForeignReference{RepoID: g.repo.ID, Type: repo_model.ForeignReferenceTypeComment, ForeignID: foreignIDs[i], LocalID: cm.ID})
+		}
+	}
+	return repo_model.InsertForeignReferences(ctx, refs...)
 }
 
 // CreatePullRequests creates pull requests
@@ -848,6 +870,7 @@ func convertReviewState(state string) issues_model.ReviewType {
 // CreateReviews create pull request reviews of currently migrated issues
 func (g *GiteaLocalUploader) CreateReviews(ctx context.Context, reviews ...*base.Review) error {
 	cms := make([]*issues_model.Review, 0, len(reviews))
+	foreignIDs := make([]int64, 0, len(reviews))
 	for _, review := range reviews {
 		var issue *issues_model.Issue
 		issue, ok := g.issues[review.IssueIndex]
@@ -872,6 +895,7 @@ func (g *GiteaLocalUploader) CreateReviews(ctx context.Context, reviews ...*base
 		}
 
 		cms = append(cms, &cm)
+		foreignIDs = append(foreignIDs, review.ID)
 
 		// get pr
 		pr, ok := g.prCache[issue.ID]
@@ -944,7 +968,19 @@ func (g *GiteaLocalUploader) CreateReviews(ctx context.Context, reviews ...*base
 		}
 	}
 
-	return issues_model.InsertReviews(ctx, cms)
+	if err := issues_model.InsertReviews(ctx, cms); err != nil {
+		return err
+	}
+	if !g.recordForeignReferences {
+		return nil
+	}
+	refs := make([]*repo_model.ForeignReference, 0, len(cms))
+	for i, cm := range cms {
+		if foreignIDs[i] > 0 {
+			refs = append(refs, &repo_model.ForeignReference{RepoID: g.repo.ID, Type: repo_model.ForeignReferenceTypeReview, ForeignID: foreignIDs[i], LocalID: cm.ID})
+		}
+	}
+	return repo_model.InsertForeignReferences(ctx, refs...)
 }
 
 // Rollback when migrating failed, this will rollback all the changes.
@@ -0,0 +1,354 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package migrations
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"gitea.dev/models/db"
+	issues_model "gitea.dev/models/issues"
+	repo_model "gitea.dev/models/repo"
+	"gitea.dev/models/repostats"
+	"gitea.dev/modules/git"
+	"gitea.dev/modules/log"
+	base "gitea.dev/modules/migration"
+	"gitea.dev/modules/setting"
+	"gitea.dev/modules/timeutil"
+	"gitea.dev/modules/util"
+	"gitea.dev/services/pull"
+)
+
+// OpenForSync binds the uploader to an already migrated repository, the items of the source
+// are then upserted by the Sync* methods instead of being created.
+func (g *GiteaLocalUploader) OpenForSync(ctx context.Context, repo *repo_model.Repository) error {
+	g.repo = repo
+	g.sameApp = strings.HasPrefix(repo.OriginalURL, setting.AppURL)
+	g.recordForeignReferences = true
+
+	var err error
+	g.gitRepo, err = git.OpenRepository(ctx, repo)
+	if err != nil {
+		return err
+	}
+
+	labels, err := issues_model.GetLabelsByRepoID(ctx, repo.ID, "", db.ListOptions{})
+	if err != nil {
+		return err
+	}
+	for _, lb := range labels {
+		g.labels[lb.Name] = lb
+	}
+
+	milestones, err := db.Find[issues_model.Milestone](ctx, issues_model.FindMilestoneOptions{RepoID: repo.ID})
+	if err != nil {
+		return err
+	}
+	for _, ms := range milestones {
+		g.milestones[ms.Name] = ms.ID
+	}
+	return nil
+}
+
+// SyncMilestones creates the milestones which don't exist yet
+func (g *GiteaLocalUploader) SyncMilestones(ctx context.Context, milestones ...*base.Milestone) error {
+	missing := make([]*base.Milestone, 0, len(milestones))
+	for _, ms := range milestones {
+		if _, ok := g.milestones[ms.Title]; !ok {
+			missing = append(missing, ms)
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+	return g.CreateMilestones(ctx, missing...)
+}
+
+// SyncLabels creates the labels which don't exist yet
+func (g *GiteaLocalUploader) SyncLabels(ctx context.Context, labels ...*base.Label) error {
+	missing := make([]*base.Label, 0, len(labels))
+	for _, l := range labels {
+		if _, ok := g.labels[l.Name]; !ok {
+			missing = append(missing, l)
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+	return g.CreateLabels(ctx, missing...)
+}
+
+// SyncReleases updates the releases matched by their tag name and creates the other ones,
+// the releases without a tag can't be matched so only the ones created since the last sync are created
+func (g *GiteaLocalUploader) SyncReleases(ctx context.Context, since time.Time, releases ...*base.Release) error {
+	missing := make([]*base.Release, 0, len(releases))
+	for _, release := range releases {
+		if release.TagName == "" {
+			if release.Created.After(since) {
+				missing = append(missing, release)
+			}
+			continue
+		}
+
+		rel, err := repo_model.GetRelease(ctx, g.repo.ID, release.TagName)
+		if repo_model.IsErrReleaseNotExist(err) {
+			missing = append(missing, release)
+			continue
+		} else if err != nil {
+			return err
+		}
+
+		// the tag may have been synced from git before the release was created on the source
+		if rel.IsTag || rel.Title != release.Name || rel.Note != release.Body || rel.IsDraft != release.Draft || rel.IsPrerelease != release.Prerelease {
+			rel.IsTag = false
+			rel.Title = release.Name
+			rel.Note = release.Body
+			rel.IsDraft = release.Draft
+			rel.IsPrerelease = release.Prerelease
+			if _, err := db.GetEngine(ctx).ID(rel.ID).Cols("is_tag", "title", "note", "is_draft", "is_prerelease").NoAutoTime().Update(rel); err != nil {
+				return err
+			}
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+	return g.CreateReleases(ctx, missing...)
+}
+
+// getIssue returns the local issue with the given index, nil if there is none or if it conflicts with the source
+func (g *GiteaLocalUploader) getIssue(ctx context.Context, index int64) (*issues_model.Issue, error) {
+	if g.conflictingIssues.Contains(index) {
+		return nil, nil
+	}
+	if issue, ok := g.issues[index]; ok {
+		return issue, nil
+	}
+	issue, err := issues_model.GetIssueByIndex(ctx, g.repo.ID, index)
+	if issues_model.IsErrIssueNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	issue.Repo = g.repo
+	g.issues[index] = issue
+	return issue, nil
+}
+
+// matchIssue returns the local issue of a source issue, nil if it has to be created.
+// An issue created locally with the same index is a conflict, it is skipped together with its comments.
+func (g *GiteaLocalUploader) matchIssue(ctx context.Context, index int64, isPull bool, created time.Time) (*issues_model.Issue, bool, error) {
+	issue, err := g.getIssue(ctx, index)
+	if err != nil || issue == nil {
+		return nil, g.conflictingIssues.Contains(index), err
+	}
+	if issue.IsPull != isPull || (!created.IsZero() && issue.CreatedUnix != timeutil.TimeStamp(created.Unix())) {
+		log.Warn("Issue #%d of %s/%s was not created by the migration, it is not synced with the source", index, g.repoOwner, g.repoName)
+		g.conflictingIssues.Add(index)
+		delete(g.issues, index)
+		return nil, true, nil
+	}
+	return issue, false, nil
+}
+
+// updateIssue updates a local issue with the content of its source
+func (g *GiteaLocalUploader) updateIssue(ctx context.Context, issue *issues_model.Issue, title, content, milestone string, isClosed, isLocked bool, closed *time.Time, updated time.Time, labels []*base.Label) error {
+	issue.Title = util.TruncateRunes(title, 255)
+	issue.Content = content
+	issue.MilestoneID = g.milestones[milestone]
+	issue.IsClosed = isClosed
+	issue.IsLocked = isLocked
+	issue.ClosedUnix = 0
+	if isClosed && closed != nil {
+		issue.ClosedUnix = timeutil.TimeStamp(closed.Unix())
+	}
+	if !updated.IsZero() {
+		issue.UpdatedUnix = timeutil.TimeStamp(updated.Unix())
+	}
+
+	issueLabels := make([]*issues_model.IssueLabel, 0, len(labels))
+	for _, label := range labels {
+		if lb, ok := g.labels[label.Name]; ok {
+			issueLabels = append(issueLabels, &issues_model.IssueLabel{IssueID: issue.ID, LabelID: lb.ID})
+		}
+	}
+
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		if _, err := db.GetEngine(ctx).ID(issue.ID).
+			Cols("name", "content", "milestone_id", "is_closed", "is_locked", "closed_unix", "updated_unix").
+			NoAutoTime().Update(issue); err != nil {
+			return err
+		}
+		if _, err := db.DeleteByBean(ctx, &issues_model.IssueLabel{IssueID: issue.ID}); err != nil {
+			return err
+		}
+		if len(issueLabels) == 0 {
+			return nil
+		}
+		return db.Insert(ctx, issueLabels)
+	})
+}
+
+// SyncIssues updates the issues which already exist and creates the other ones
+func (g *GiteaLocalUploader) SyncIssues(ctx context.Context, issues ...*base.Issue) error {
+	missing := make([]*base.Issue, 0, len(issues))
+	for _, issue := range issues {
+		local, conflicting, err := g.matchIssue(ctx, issue.Number, false, issue.Created)
+		if err != nil {
+			return err
+		} else if conflicting {
+			continue
+		} else if local == nil {
+			missing = append(missing, issue)
+			continue
+		}
+
+		if err := g.updateIssue(ctx, local, issue.Title, issue.Content, issue.Milestone, issue.State == "closed", issue.IsLocked, issue.Closed, issue.Updated, issue.Labels); err != nil {
+			return fmt.Errorf("update issue #%d: %w", issue.Number, err)
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+	return g.CreateIssues(ctx, missing...)
+}
+
+// SyncPullRequests updates the pull requests which already exist and creates the other ones
+func (g *GiteaLocalUploader) SyncPullRequests(ctx context.Context, prs ...*base.PullRequest) error {
+	missing := make([]*base.PullRequest, 0, len(prs))
+	for _, pr := range prs {
+		local, conflicting, err := g.matchIssue(ctx, pr.Number, true, pr.Created)
+		if err != nil {
+			return err
+		} else if conflicting {
+			continue
+		} else if local == nil {
+			missing = append(missing, pr)
+			continue
+		}
+
+		title := pr.Title
+		if pr.IsDraft && !issues_model.HasWorkInProgressPrefix(pr.Title) {
+			title = fmt.Sprintf("%s %s", setting.Repository.PullRequest.WorkInProgressPrefixes[0], pr.Title)
+		}
+		if err := g.updateIssue(ctx, local, title, pr.Content, pr.Milestone, pr.State == "closed", pr.IsLocked, pr.Closed, pr.Updated, pr.Labels); err != nil {
+			return fmt.Errorf("update pull request #%d: %w", pr.Number, err)
+		}
+
+		gpr, err := issues_model.GetPullRequestByIssueIDWithNoAttributes(ctx, local.ID)
+		if err != nil {
+			return err
+		}
+		g.prCache[local.ID] = gpr
+
+		// fetch the new commits of the head
+		if _, err := g.updateGitForPullRequest(ctx, pr); err != nil {
+			return fmt.Errorf("updateGitForPullRequest: %w", err)
+		}
+
+		if pr.Merged && !gpr.HasMerged {
+			gpr.HasMerged = true
+			gpr.MergedCommitID = pr.MergeCommitSHA
+			gpr.MergerID = g.doer.ID
+			if pr.MergedTime != nil {
+				gpr.MergedUnix = timeutil.TimeStamp(pr.MergedTime.Unix())
+			}
+			if _, err := db.GetEngine(ctx).ID(gpr.ID).Cols("has_merged", "merged_commit_id", "merger_id", "merged_unix").NoAutoTime().Update(gpr); err != nil {
+				return err
+			}
+		} else if !local.IsClosed {
+			gpr.Issue = local
+			pull.StartPullRequestCheckImmediately(ctx, gpr)
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+	return g.CreatePullRequests(ctx, missing...)
+}
+
+// SyncComments updates the comments matched by their source ID and creates the other ones,
+// the comments without a source ID can't be matched so only the ones created since the last sync are created
+func (g *GiteaLocalUploader) SyncComments(ctx context.Context, since time.Time, comments ...*base.Comment) error {
+	missing := make([]*base.Comment, 0, len(comments))
+	for _, comment := range comments {
+		issue, err := g.getIssue(ctx, comment.IssueIndex)
+		if err != nil {
+			return err
+		} else if issue == nil {
+			// the issue is either conflicting or has been filtered out by the since cursor
+			continue
+		}
+
+		if comment.Index <= 0 {
+			if comment.Created.After(since) {
+				missing = append(missing, comment)
+			}
+			continue
+		}
+
+		localID, err := repo_model.GetForeignReferenceLocalID(ctx, g.repo.ID, repo_model.ForeignReferenceTypeComment, comment.Index)
+		if err != nil {
+			return err
+		} else if localID == 0 {
+			missing = append(missing, comment)
+			continue
+		}
+
+		if comment.Updated.After(since) {
+			cm := &issues_model.Comment{
+				Content:     comment.Content,
+				UpdatedUnix: timeutil.TimeStamp(comment.Updated.Unix()),
+			}
+			if _, err := db.GetEngine(ctx).ID(localID).Cols("content", "updated_unix").NoAutoTime().Update(cm); err != nil {
+				return err
+			}
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+	return g.CreateComments(ctx, missing...)
+}
+
+// SyncReviews creates the reviews which haven't been migrated yet, the reviews are not updated
+func (g *GiteaLocalUploader) SyncReviews(ctx context.Context, since time.Time, reviews ...*base.Review) error {
+	missing := make([]*base.Review, 0, len(reviews))
+	for _, review := range reviews {
+		issue, err := g.getIssue(ctx, review.IssueIndex)
+		if err != nil {
+			return err
+		} else if issue == nil {
+			continue
+		}
+
+		if review.ID <= 0 {
+			if review.CreatedAt.After(since) {
+				missing = append(missing, review)
+			}
+			continue
+		}
+
+		localID, err := repo_model.GetForeignReferenceLocalID(ctx, g.repo.ID, repo_model.ForeignReferenceTypeReview, review.ID)
+		if err != nil {
+			return err
+		} else if localID == 0 {
+			missing = append(missing, review)
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+	return g.CreateReviews(ctx, missing...)
+}
+
+// FinishSync updates the counters of the synced repository
+func (g *GiteaLocalUploader) FinishSync(ctx context.Context) error {
+	if err := issues_model.RecalculateIssueIndexForRepo(ctx, g.repo.ID); err != nil {
+		return err
+	}
+	return repostats.UpdateRepoStats(ctx, g.repo.ID)
+}
@@ -25,8 +25,9 @@ import (
 )
 
 var (
-	_ base.Downloader        = &GithubDownloaderV3{}
-	_ base.DownloaderFactory = &GithubDownloaderV3Factory{}
+	_ base.Downloader            = &GithubDownloaderV3{}
+	_ base.IncrementalDownloader = &GithubDownloaderV3{}
+	_ base.DownloaderFactory     = &GithubDownloaderV3Factory{}
 	// GithubLimitRateRemaining limit to wait for new rate to apply
 	GithubLimitRateRemaining = 0
 )
@@ -425,7 +426,7 @@ func (g *GithubDownloaderV3) GetIssues(ctx context.Context, page, perPage int) (
 	if perPage > g.maxPerPage {
 		perPage = g.maxPerPage
 	}
-	opt := &github.IssueListByRepoOptions{
+	return g.getIssues(ctx, &github.IssueListByRepoOptions{
 		Sort:      "created",
 		Direction: "asc",
 		State:     "all",
@@ -433,8 +434,28 @@ func (g *GithubDownloaderV3) GetIssues(ctx context.Context, page, perPage int) (
 			PerPage: perPage,
 			Page:    page,
 		},
+	})
+}
+
+// GetIssuesSince returns the issues updated since the given time according page and perPage
+func (g *GithubDownloaderV3) GetIssuesSince(ctx context.Context, since time.Time, page, perPage int) ([]*base.Issue, bool, error) {
+	if perPage > g.maxPerPage {
+		perPage = g.maxPerPage
 	}
+	return g.getIssues(ctx, &github.IssueListByRepoOptions{
+		Sort:      "updated",
+		Direction: "asc",
+		State:     "all",
+		Since:     since,
+		ListOptions: github.ListOptions{
+			PerPage: perPage,
+			Page:    page,
+		},
+	})
+}
 
+func (g *GithubDownloaderV3) getIssues(ctx context.Context, opt *github.IssueListByRepoOptions) ([]*base.Issue, bool, error) {
+	perPage, page := opt.ListOptions.PerPage, opt.ListOptions.Page
 	allIssues := make([]*base.Issue, 0, perPage)
 	g.waitAndPickClient(ctx)
 	issues, resp, err := g.getClient().Issues.ListByRepo(ctx, g.repoOwner, g.repoName, opt)
@@ -592,21 +613,45 @@ func (g *GithubDownloaderV3) getComments(ctx context.Context, commentable base.C
 // GetAllComments returns repository comments according page and perPageSize
 func (g *GithubDownloaderV3) GetAllComments(ctx context.Context, page, perPage int) ([]*base.Comment, bool, error) {
 	var (
-		allComments = make([]*base.Comment, 0, perPage)
-		created     = "created"
-		asc         = "asc"
+		created = "created"
+		asc     = "asc"
 	)
 	if perPage > g.maxPerPage {
 		perPage = g.maxPerPage
 	}
-	opt := &github.IssueListCommentsOptions{
+	return g.getAllComments(ctx, &github.IssueListCommentsOptions{
 		Sort:      &created,
 		Direction: &asc,
 		ListOptions: github.ListOptions{
 			Page:    page,
 			PerPage: perPage,
 		},
+	})
+}
+
+// GetAllCommentsSince returns the repository comments updated since the given time according page and perPage
+func (g *GithubDownloaderV3) GetAllCommentsSince(ctx context.Context, since time.Time, page, perPage int) ([]*base.Comment, bool, error) {
+	var (
+		updated = "updated"
+		asc     = "asc"
+	)
+	if perPage > g.maxPerPage {
+		perPage = g.maxPerPage
 	}
+	return g.getAllComments(ctx, &github.IssueListCommentsOptions{
+		Sort:      &updated,
+		Direction: &asc,
+		Since:     &since,
+		ListOptions: github.ListOptions{
+			Page:    page,
+			PerPage: perPage,
+		},
+	})
+}
+
+func (g *GithubDownloaderV3) getAllComments(ctx context.Context, opt *github.IssueListCommentsOptions) ([]*base.Comment, bool, error) {
+	perPage, page := opt.ListOptions.PerPage, opt.ListOptions.Page
+	allComments := make([]*base.Comment, 0, perPage)
 
 	g.waitAndPickClient(ctx)
 	comments, resp, err := g.getClient().Issues.ListComments(ctx, g.repoOwner, g.repoName, 0, opt)
@@ -668,7 +713,7 @@ func (g *GithubDownloaderV3) GetPullRequests(ctx context.Context, page, perPage
 	if perPage > g.maxPerPage {
 		perPage = g.maxPerPage
 	}
-	opt := &github.PullRequestListOptions{
+	return g.getPullRequests(ctx, &github.PullRequestListOptions{
 		Sort:      "created",
 		Direction: "asc",
 		State:     "all",
@@ -676,7 +721,28 @@ func (g *GithubDownloaderV3) GetPullRequests(ctx context.Context, page, perPage
 			PerPage: perPage,
 			Page:    page,
 		},
+	}, time.Time{})
+}
+
+// GetPullRequestsSince returns the pull requests updated since the given time according page and perPage,
+// GitHub can't filter them so they are listed from the most recently updated until an older one is found
+func (g *GithubDownloaderV3) GetPullRequestsSince(ctx context.Context, since time.Time, page, perPage int) ([]*base.PullRequest, bool, error) {
+	if perPage > g.maxPerPage {
+		perPage = g.maxPerPage
 	}
+	return g.getPullRequests(ctx, &github.PullRequestListOptions{
+		Sort:      "updated",
+		Direction: "desc",
+		State:     "all",
+		ListOptions: github.ListOptions{
+			PerPage: perPage,
+			Page:    page,
+		},
+	}, since)
+}
+
+func (g *GithubDownloaderV3) getPullRequests(ctx context.Context, opt *github.PullRequestListOptions, since time.Time) ([]*base.PullRequest, bool, error) {
+	perPage, page := opt.ListOptions.PerPage, opt.ListOptions.Page
 	allPRs := make([]*base.PullRequest, 0, perPage)
 	g.waitAndPickClient(ctx)
 	prs, resp, err := g.getClient().PullRequests.List(ctx, g.repoOwner, g.repoName, opt)
@@ -685,7 +751,14 @@ func (g *GithubDownloaderV3) GetPullRequests(ctx context.Context, page, perPage
 	}
 	log.Trace("Request get pull requests %d/%d, but in fact get %d", perPage, page, len(prs))
 	g.setRate(&resp.Rate)
+	isEnd := len(prs) < perPage
 	for _, pr := range prs {
+		if !since.IsZero() && pr.GetUpdatedAt().Before(since) {
+			// the pull requests are sorted by their update time, the next ones are older too
+			isEnd = true
+			break
+		}
+
 		labels := make([]*base.Label, 0, len(pr.Labels))
 		for _, l := range pr.Labels {
 			labels = append(labels, convertGithubLabel(l))
@@ -762,7 +835,7 @@ func (g *GithubDownloaderV3) GetPullRequests(ctx context.Context, page, perPage
 		_ = CheckAndEnsureSafePR(allPRs[len(allPRs)-1], g.baseURL, g)
 	}
 
-	return allPRs, len(prs) < perPage, nil
+	return allPRs, isEnd, nil
 }
 
 func convertGithubReview(r *github.PullRequestReview) *base.Review {
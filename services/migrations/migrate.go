@@ -12,6 +12,7 @@ import (
 	"net/url"
 	"path/filepath"
 	"strings"
+	"time"
 
 	repo_model "gitea.dev/models/repo"
 	system_model "gitea.dev/models/system"
@@ -125,7 +126,9 @@ func MigrateRepository(ctx context.Context, doer *user_model.User, ownerName str
 
 	uploader := NewGiteaLocalUploader(ctx, doer, ownerName, opts.RepoName)
 	uploader.gitServiceType = opts.GitServiceType
+	uploader.recordForeignReferences = opts.Sync
 
+	startTime := time.Now()
 	if err := migrateRepository(ctx, doer, downloader, uploader, opts, messenger); err != nil {
 		if err1 := uploader.Rollback(); err1 != nil {
 			log.Error("rollback failed: %v", err1)
@@ -135,6 +138,11 @@ func MigrateRepository(ctx context.Context, doer *user_model.User, ownerName str
 		}
 		return nil, err
 	}
+	if opts.Sync {
+		if err := createMigrationSync(ctx, doer, uploader.repo, opts, startTime); err != nil {
+			return nil, err
+		}
+	}
 	return uploader.repo, nil
 }
 
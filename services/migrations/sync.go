@@ -0,0 +1,369 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package migrations
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"gitea.dev/models/db"
+	repo_model "gitea.dev/models/repo"
+	system_model "gitea.dev/models/system"
+	user_model "gitea.dev/models/user"
+	"gitea.dev/modules/container"
+	"gitea.dev/modules/json"
+	"gitea.dev/modules/log"
+	base "gitea.dev/modules/migration"
+	"gitea.dev/modules/secret"
+	"gitea.dev/modules/setting"
+	"gitea.dev/modules/timeutil"
+	"gitea.dev/modules/util"
+)
+
+// createMigrationSync records the sync of a migrated repository, the cursor is the start of the migration
+// so the items updated on the source while it was running are synced again
+func createMigrationSync(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, opts base.MigrateOptions, startTime time.Time) error {
+	// encrypt credentials for persistence
+	var err error
+	opts.CloneAddrEncrypted, err = secret.EncryptSecret(setting.SecretKey, opts.CloneAddr)
+	if err != nil {
+		return err
+	}
+	opts.CloneAddr = util.SanitizeCredentialURLs(opts.CloneAddr)
+	opts.AuthPasswordEncrypted, err = secret.EncryptSecret(setting.SecretKey, opts.AuthPassword)
+	if err != nil {
+		return err
+	}
+	opts.AuthPassword = ""
+	opts.AuthTokenEncrypted, err = secret.EncryptSecret(setting.SecretKey, opts.AuthToken)
+	if err != nil {
+		return err
+	}
+	opts.AuthToken = ""
+	opts.AWSSecretAccessKeyEncrypted, err = secret.EncryptSecret(setting.SecretKey, opts.AWSSecretAccessKey)
+	if err != nil {
+		return err
+	}
+	opts.AWSSecretAccessKey = ""
+	bs, err := json.Marshal(&opts)
+	if err != nil {
+		return err
+	}
+
+	s := &repo_model.MigrationSync{
+		RepoID:         repo.ID,
+		DoerID:         doer.ID,
+		PayloadContent: string(bs),
+		LastSyncUnix:   timeutil.TimeStamp(startTime.Unix()),
+	}
+	s.ScheduleNextSync()
+	return repo_model.InsertMigrationSync(ctx, s)
+}
+
+// SyncMigratedRepositories syncs the migrated repositories which are due
+func SyncMigratedRepositories(ctx context.Context, limit int) error {
+	syncs, err := repo_model.FindDueMigrationSyncs(ctx, limit)
+	if err != nil {
+		return err
+	}
+	for _, s := range syncs {
+		select {
+		case <-ctx.Done():
+			return db.ErrCancelledf("during SyncMigratedRepositories before repository %d", s.RepoID)
+		default:
+		}
+		if err := SyncMigratedRepository(ctx, s); err != nil {
+			log.Error("SyncMigratedRepository[%d]: %v", s.RepoID, err)
+		}
+	}
+	return nil
+}
+
+// SyncMigratedRepository syncs the issues, pull requests, comments, reviews and releases of a migrated repository
+// updated on the source since its last sync, the cursor only moves forward when the sync succeeds
+func SyncMigratedRepository(ctx context.Context, s *repo_model.MigrationSync) error {
+	startTime := time.Now()
+	err := syncMigratedRepository(ctx, s)
+
+	s.ScheduleNextSync()
+	cols := []string{"next_sync_unix", "last_error"}
+	if err != nil {
+		err = util.SanitizeErrorCredentialURLs(err)
+		s.LastError = err.Error()
+		if err2 := system_model.CreateRepositoryNotice(fmt.Sprintf("Sync migrated repository %d failed: %v", s.RepoID, err)); err2 != nil {
+			log.Error("create repository notice failed: %v", err2)
+		}
+	} else {
+		s.LastError = ""
+		s.LastSyncUnix = timeutil.TimeStamp(startTime.Unix())
+		cols = append(cols, "last_sync_unix")
+	}
+	if err2 := repo_model.UpdateMigrationSyncCols(ctx, s, cols...); err2 != nil {
+		return err2
+	}
+	return err
+}
+
+func syncMigratedRepository(ctx context.Context, s *repo_model.MigrationSync) error {
+	if err := s.LoadRepo(ctx); err != nil {
+		return err
+	}
+	if s.Repo.Status != repo_model.RepositoryReady {
+		return errors.New("the repository is not ready")
+	}
+	if err := s.Repo.LoadOwner(ctx); err != nil {
+		return err
+	}
+
+	doer, err := user_model.GetUserByID(ctx, s.DoerID)
+	if err != nil {
+		return err
+	}
+
+	opts, err := s.MigrateConfig()
+	if err != nil {
+		return err
+	}
+	if err := IsMigrateURLAllowed(opts.CloneAddr, doer); err != nil {
+		return err
+	}
+
+	downloader, err := newDownloader(ctx, s.Repo.OwnerName, *opts)
+	if err != nil {
+		return err
+	}
+
+	uploader := NewGiteaLocalUploader(ctx, doer, s.Repo.OwnerName, s.Repo.Name)
+	uploader.gitServiceType = opts.GitServiceType
+	defer uploader.Close()
+	if err := uploader.OpenForSync(ctx, s.Repo); err != nil {
+		return err
+	}
+
+	return syncRepository(ctx, downloader, uploader, *opts, s.LastSyncUnix.AsTime())
+}
+
+// updatedSince keeps the items updated since the given time, the ones without an update time are kept too
+func updatedSince[T any](items []T, since time.Time, updated func(T) time.Time) []T {
+	kept := items[:0]
+	for _, item := range items {
+		if t := updated(item); t.IsZero() || !t.Before(since) {
+			kept = append(kept, item)
+		}
+	}
+	return kept
+}
+
+func getIssuesSince(ctx context.Context, downloader base.Downloader, since time.Time, page, perPage int) ([]*base.Issue, bool, error) {
+	updated := func(issue *base.Issue) time.Time { return issue.Updated }
+	if incremental, ok := downloader.(base.IncrementalDownloader); ok {
+		issues, isEnd, err := incremental.GetIssuesSince(ctx, since, page, perPage)
+		if !base.IsErrNotSupported(err) {
+			return updatedSince(issues, since, updated), isEnd, err
+		}
+	}
+	issues, isEnd, err := downloader.GetIssues(ctx, page, perPage)
+	return updatedSince(issues, since, updated), isEnd, err
+}
+
+func getPullRequestsSince(ctx context.Context, downloader base.Downloader, since time.Time, page, perPage int) ([]*base.PullRequest, bool, error) {
+	updated := func(pr *base.PullRequest) time.Time { return pr.Updated }
+	if incremental, ok := downloader.(base.IncrementalDownloader); ok {
+		prs, isEnd, err := incremental.GetPullRequestsSince(ctx, since, page, perPage)
+		if !base.IsErrNotSupported(err) {
+			return updatedSince(prs, since, updated), isEnd, err
+		}
+	}
+	prs, isEnd, err := downloader.GetPullRequests(ctx, page, perPage)
+	return updatedSince(prs, since, updated), isEnd, err
+}
+
+func getAllCommentsSince(ctx context.Context, downloader base.Downloader, since time.Time, page, perPage int) ([]*base.Comment, bool, error) {
+	updated := func(comment *base.Comment) time.Time { return comment.Updated }
+	if incremental, ok := downloader.(base.IncrementalDownloader); ok {
+		comments, isEnd, err := incremental.GetAllCommentsSince(ctx, since, page, perPage)
+		if !base.IsErrNotSupported(err) {
+			return updatedSince(comments, since, updated), isEnd, err
+		}
+	}
+	comments, isEnd, err := downloader.GetAllComments(ctx, page, perPage)
+	return updatedSince(comments, since, updated), isEnd, err
+}
+
+// syncRepository downloads the items updated on the source since the given time and upserts them,
+// it follows the steps of migrateRepository without the git data which is left to the mirrors
+func syncRepository(ctx context.Context, downloader base.Downloader, uploader *GiteaLocalUploader, opts base.MigrateOptions, since time.Time) error {
+	if opts.Milestones {
+		log.Trace("syncing milestones")
+		milestones, err := downloader.GetMilestones(ctx)
+		if err != nil && !base.IsErrNotSupported(err) {
+			return err
+		}
+		if err := uploader.SyncMilestones(ctx, milestones...); err != nil {
+			return err
+		}
+	}
+
+	if opts.Labels {
+		log.Trace("syncing labels")
+		labels, err := downloader.GetLabels(ctx)
+		if err != nil && !base.IsErrNotSupported(err) {
+			return err
+		}
+		if err := uploader.SyncLabels(ctx, labels...); err != nil {
+			return err
+		}
+	}
+
+	if opts.Releases {
+		log.Trace("syncing releases")
+		releases, err := downloader.GetReleases(ctx)
+		if err != nil && !base.IsErrNotSupported(err) {
+			return err
+		}
+		if err := uploader.SyncReleases(ctx, since, releases...); err != nil {
+			return err
+		}
+	}
+
+	var (
+		commentBatchSize   = uploader.MaxBatchInsertSize("comment")
+		supportAllComments = downloader.SupportGetRepoComments()
+	)
+
+	if opts.Issues {
+		log.Trace("syncing issues and comments")
+		issueBatchSize := uploader.MaxBatchInsertSize("issue")
+		syncedIssueIndexes := container.Set[int64]{}
+		for i := 1; ; i++ {
+			issues, isEnd, err := getIssuesSince(ctx, downloader, since, i, issueBatchSize)
+			if err != nil {
+				if !base.IsErrNotSupported(err) {
+					return err
+				}
+				log.Warn("syncing issues is not supported, ignored")
+				break
+			}
+			for i := 0; i < len(issues); i++ {
+				if syncedIssueIndexes.Contains(issues[i].Number) {
+					issues = append(issues[:i], issues[i+1:]...)
+					i--
+					continue
+				}
+				syncedIssueIndexes.Add(issues[i].Number)
+			}
+
+			if err := uploader.SyncIssues(ctx, issues...); err != nil {
+				return err
+			}
+
+			if opts.Comments && !supportAllComments {
+				for _, issue := range issues {
+					comments, _, err := downloader.GetComments(ctx, issue)
+					if err != nil {
+						if !base.IsErrNotSupported(err) {
+							return err
+						}
+						log.Warn("syncing comments is not supported, ignored")
+						break
+					}
+					if err := uploader.SyncComments(ctx, since, comments...); err != nil {
+						return err
+					}
+				}
+			}
+
+			if isEnd {
+				break
+			}
+		}
+	}
+
+	if opts.PullRequests {
+		log.Trace("syncing pull requests, comments and reviews")
+		prBatchSize := uploader.MaxBatchInsertSize("pullrequest")
+		syncedPRIndexes := container.Set[int64]{}
+		for i := 1; ; i++ {
+			prs, isEnd, err := getPullRequestsSince(ctx, downloader, since, i, prBatchSize)
+			if err != nil {
+				if !base.IsErrNotSupported(err) {
+					return err
+				}
+				log.Warn("syncing pull requests is not supported, ignored")
+				break
+			}
+			for i := 0; i < len(prs); i++ {
+				if syncedPRIndexes.Contains(prs[i].Number) {
+					prs = append(prs[:i], prs[i+1:]...)
+					i--
+					continue
+				}
+				syncedPRIndexes.Add(prs[i].Number)
+			}
+
+			if err := uploader.SyncPullRequests(ctx, prs...); err != nil {
+				return err
+			}
+
+			if opts.Comments {
+				for _, pr := range prs {
+					if !supportAllComments {
+						comments, _, err := downloader.GetComments(ctx, pr)
+						if err != nil && !base.IsErrNotSupported(err) {
+							return err
+						}
+						if err := uploader.SyncComments(ctx, since, comments...); err != nil {
+							return err
+						}
+					}
+
+					reviews, err := downloader.GetReviews(ctx, pr)
+					if err != nil && !base.IsErrNotSupported(err) {
+						return err
+					}
+					if err := uploader.SyncReviews(ctx, since, reviews...); err != nil {
+						return err
+					}
+				}
+			}
+
+			if isEnd {
+				break
+			}
+		}
+		if len(syncedPRIndexes) > 0 {
+			// the head branches of the new pull requests may have been created in the repository
+			if err := uploader.SyncBranches(ctx); err != nil {
+				return err
+			}
+		}
+	}
+
+	if opts.Comments && supportAllComments {
+		log.Trace("syncing comments")
+		for i := 1; ; i++ {
+			comments, isEnd, err := getAllCommentsSince(ctx, downloader, since, i, commentBatchSize)
+			if err != nil {
+				return err
+			}
+
+			if err := uploader.SyncComments(ctx, since, comments...); err != nil {
+				return err
+			}
+
+			if isEnd {
+				break
+			}
+		}
+	}
+
+	return uploader.FinishSync(ctx)
+}
+
+// StopMigrationSync stops the sync of a migrated repository with its source and forgets its credentials
+func StopMigrationSync(ctx context.Context, repo *repo_model.Repository) error {
+	return repo_model.DeleteMigrationSyncByRepoID(ctx, repo.ID)
+}
@@ -0,0 +1,124 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package migrations
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	issues_model "gitea.dev/models/issues"
+	repo_model "gitea.dev/models/repo"
+	"gitea.dev/models/unittest"
+	user_model "gitea.dev/models/user"
+	base "gitea.dev/modules/migration"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type syncTestDownloader struct {
+	base.NullDownloader
+	issues []*base.Issue
+}
+
+func (d *syncTestDownloader) GetIssues(_ context.Context, _, _ int) ([]*base.Issue, bool, error) {
+	return append([]*base.Issue(nil), d.issues...), true, nil
+}
+
+type syncTestIncrementalDownloader struct {
+	syncTestDownloader
+	since time.Time
+}
+
+func (d *syncTestIncrementalDownloader) GetIssuesSince(_ context.Context, since time.Time, _, _ int) ([]*base.Issue, bool, error) {
+	d.since = since
+	return append([]*base.Issue(nil), d.issues[1:]...), true, nil
+}
+
+func (d *syncTestIncrementalDownloader) GetPullRequestsSince(_ context.Context, _ time.Time, _, _ int) ([]*base.PullRequest, bool, error) {
+	return nil, false, base.ErrNotSupported{Entity: "PullRequestsSince"}
+}
+
+func (d *syncTestIncrementalDownloader) GetAllCommentsSince(_ context.Context, _ time.Time, _, _ int) ([]*base.Comment, bool, error) {
+	return nil, false, base.ErrNotSupported{Entity: "AllCommentsSince"}
+}
+
+func TestGetIssuesSince(t *testing.T) {
+	since := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	issues := []*base.Issue{
+		{Number: 1, Updated: since.Add(-time.Hour)},
+		{Number: 2, Updated: since.Add(time.Hour)},
+		{Number: 3},
+	}
+
+	// the downloader can't filter the issues, they are all listed and filtered by their update time
+	got, isEnd, err := getIssuesSince(t.Context(), &syncTestDownloader{issues: issues}, since, 1, 10)
+	require.NoError(t, err)
+	assert.True(t, isEnd)
+	if assert.Len(t, got, 2) {
+		assert.EqualValues(t, 2, got[0].Number)
+		assert.EqualValues(t, 3, got[1].Number)
+	}
+
+	downloader := &syncTestIncrementalDownloader{syncTestDownloader: syncTestDownloader{issues: issues}}
+	got, _, err = getIssuesSince(t.Context(), downloader, since, 1, 10)
+	require.NoError(t, err)
+	assert.Equal(t, since, downloader.since)
+	assert.Len(t, got, 2)
+
+	// the retry downloader forwards the since cursor to the wrapped downloader
+	downloader.since = time.Time{}
+	got, _, err = getIssuesSince(t.Context(), base.NewRetryDownloader(downloader, 1, 0), since, 1, 10)
+	require.NoError(t, err)
+	assert.Equal(t, since, downloader.since)
+	assert.Len(t, got, 2)
+}
+
+func TestGiteaUploadSync(t *testing.T) {
+	unittest.PrepareTestEnv(t)
+
+	doer := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 1})
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+	require.NoError(t, repo.LoadOwner(t.Context()))
+	issue1 := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{RepoID: repo.ID, Index: 1})
+
+	uploader := NewGiteaLocalUploader(t.Context(), doer, repo.OwnerName, repo.Name)
+	require.NoError(t, uploader.OpenForSync(t.Context(), repo))
+	defer uploader.Close()
+
+	since := time.Unix(int64(issue1.UpdatedUnix), 0)
+	updated := since.Add(time.Hour)
+
+	require.NoError(t, uploader.SyncIssues(t.Context(),
+		&base.Issue{Number: 1, Title: "issue1 updated on the source", State: "closed", Created: issue1.CreatedUnix.AsTime(), Updated: updated, Closed: &updated},
+		// the issue #2 has been created locally, it can't be synced
+		&base.Issue{Number: 2, Title: "conflicting issue", State: "open", Created: updated, Updated: updated},
+		&base.Issue{Number: 1000, Title: "new issue", State: "open", Created: updated, Updated: updated},
+	))
+
+	issue1 = unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: issue1.ID})
+	assert.Equal(t, "issue1 updated on the source", issue1.Title)
+	assert.True(t, issue1.IsClosed)
+	assert.NotEqual(t, "conflicting issue", unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{RepoID: repo.ID, Index: 2}).Title)
+	unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{RepoID: repo.ID, Index: 1000, Title: "new issue"})
+
+	comment := &base.Comment{IssueIndex: 1, Index: 4242, Content: "first", Created: updated, Updated: updated}
+	require.NoError(t, uploader.SyncComments(t.Context(), since, comment,
+		&base.Comment{IssueIndex: 2, Index: 4243, Content: "on the conflicting issue", Created: updated, Updated: updated},
+	))
+	localID, err := repo_model.GetForeignReferenceLocalID(t.Context(), repo.ID, repo_model.ForeignReferenceTypeComment, 4242)
+	require.NoError(t, err)
+	assert.Equal(t, "first", unittest.AssertExistsAndLoadBean(t, &issues_model.Comment{ID: localID}).Content)
+	unittest.AssertNotExistsBean(t, &issues_model.Comment{Content: "on the conflicting issue"})
+
+	// the comment is matched by its source ID when it is edited
+	comment.Content = "edited"
+	comment.Updated = updated.Add(time.Hour)
+	require.NoError(t, uploader.SyncComments(t.Context(), since, comment))
+	assert.Equal(t, "edited", unittest.AssertExistsAndLoadBean(t, &issues_model.Comment{ID: localID}).Content)
+	unittest.AssertCount(t, &issues_model.Comment{IssueID: issue1.ID, Content: "edited"}, 1)
+
+	require.NoError(t, uploader.FinishSync(t.Context()))
+}
@@ -274,6 +274,27 @@ func IssueChangeLabels(ctx context.Context, doer *user_model.User, issue *issues
 	}
 }
 
+// IssueChangeCustomField notifies change of the values of a custom field to notifiers
+func IssueChangeCustomField(ctx context.Context, doer *user_model.User, issue *issues_model.Issue, field *issues_model.CustomField) {
+	for _, notifier := range notifiers {
+		notifier.IssueChangeCustomField(ctx, doer, issue, field)
+	}
+}
+
+// IssueChangeType notifies change of the type of an issue to notifiers
+func IssueChangeType(ctx context.Context, doer *user_model.User, issue *issues_model.Issue, oldTypeID int64) {
+	for _, notifier := range notifiers {
+		notifier.IssueChangeType(ctx, doer, issue, oldTypeID)
+	}
+}
+
+// IssueChangeSubIssue notifies addition or removal of a sub-issue to notifiers
+func IssueChangeSubIssue(ctx context.Context, doer *user_model.User, parent, subIssue *issues_model.Issue, removed bool) {
+	for _, notifier := range notifiers {
+		notifier.IssueChangeSubIssue(ctx, doer, parent, subIssue, removed)
+	}
+}
+
 // CreateRepository notifies create repository to notifiers
 func CreateRepository(ctx context.Context, doer, u *user_model.User, repo *repo_model.Repository) {
 	for _, notifier := range notifiers {
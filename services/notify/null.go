@@ -143,6 +143,18 @@ func (*NullNotifier) IssueChangeLabels(ctx context.Context, doer *user_model.Use
 	addedLabels, removedLabels []*issues_model.Label) {
 }
 
+// IssueChangeCustomField places a place holder function
+func (*NullNotifier) IssueChangeCustomField(ctx context.Context, doer *user_model.User, issue *issues_model.Issue, field *issues_model.CustomField) {
+}
+
+// IssueChangeType places a place holder function
+func (*NullNotifier) IssueChangeType(ctx context.Context, doer *user_model.User, issue *issues_model.Issue, oldTypeID int64) {
+}
+
+// IssueChangeSubIssue places a place holder function
+func (*NullNotifier) IssueChangeSubIssue(ctx context.Context, doer *user_model.User, parent, subIssue *issues_model.Issue, removed bool) {
+}
+
 // CreateRepository places a place holder function
 func (*NullNotifier) CreateRepository(ctx context.Context, doer, u *user_model.User, repo *repo_model.Repository) {
 }
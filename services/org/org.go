@@ -9,12 +9,17 @@ import (
 
 	actions_model "gitea.dev/models/actions"
 	activities_model "gitea.dev/models/activities"
+	audit_model "gitea.dev/models/audit"
 	"gitea.dev/models/db"
+	git_model "gitea.dev/models/git"
+	issues_model "gitea.dev/models/issues"
 	org_model "gitea.dev/models/organization"
 	packages_model "gitea.dev/models/packages"
 	access_model "gitea.dev/models/perm/access"
+	quota_model "gitea.dev/models/quota"
 	repo_model "gitea.dev/models/repo"
 	secret_model "gitea.dev/models/secret"
+	secretscan_model "gitea.dev/models/secretscan"
 	user_model "gitea.dev/models/user"
 	"gitea.dev/modules/git/gitrepo"
 	issue_indexer "gitea.dev/modules/indexer/issues"
@@ -41,6 +46,13 @@ func deleteOrganization(ctx context.Context, org *org_model.Organization) error
 		&actions_model.ActionRunner{OwnerID: org.ID},
 		&actions_model.ActionRunnerToken{OwnerID: org.ID},
 		&actions_model.ActionScopedWorkflowSource{OwnerID: org.ID},
+		&git_model.Ruleset{OwnerID: org.ID},
+		&secretscan_model.CustomPattern{OwnerID: org.ID},
+		&issues_model.CustomField{OwnerID: org.ID},
+		&issues_model.IssueType{OwnerID: org.ID},
+		&quota_model.GroupUser{UserID: org.ID},
+		&quota_model.UserRule{UserID: org.ID},
+		&quota_model.Usage{UserID: org.ID},
 	); err != nil {
 		return fmt.Errorf("DeleteBeans: %w", err)
 	}
@@ -85,6 +97,14 @@ func DeleteOrganization(ctx context.Context, org *org_model.Organization, purge
 		return err
 	}
 
+	audit_model.Record(ctx, &audit_model.Event{
+		Action:     audit_model.ActionOrgDelete,
+		OwnerID:    org.ID,
+		TargetType: audit_model.TargetOrg,
+		TargetID:   org.ID,
+		TargetName: org.Name,
+	})
+
 	// FIXME: system notice
 	// Note: There are something just cannot be roll back,
 	//	so just keep error logs of those operations.
@@ -160,7 +180,7 @@ func ChangeOrganizationVisibility(ctx context.Context, org *org_model.Organizati
 
 	org.Visibility = visibility
 	// FIXME: If it's a big forks network(forks and sub forks), the database transaction will be too long to fail.
-	return db.WithTx(ctx, func(ctx context.Context) error {
+	if err := db.WithTx(ctx, func(ctx context.Context) error {
 		if err := user_model.UpdateUserColsNoAutoTime(ctx, org.AsUser(), "visibility"); err != nil {
 			return err
 		}
@@ -177,7 +197,19 @@ func ChangeOrganizationVisibility(ctx context.Context, org *org_model.Organizati
 			}
 		}
 		return nil
+	}); err != nil {
+		return err
+	}
+
+	audit_model.Record(ctx, &audit_model.Event{
+		Action:     audit_model.ActionOrgVisibilityChange,
+		OwnerID:    org.ID,
+		TargetType: audit_model.TargetOrg,
+		TargetID:   org.ID,
+		TargetName: org.Name,
+		Metadata:   map[string]string{"visibility": visibility.String()},
 	})
+	return nil
 }
 
 // UpdateOrgEmailAddress validates and updates the organization's contact email.
@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"strings"
 
+	audit_model "gitea.dev/models/audit"
 	"gitea.dev/models/db"
 	git_model "gitea.dev/models/git"
 	issues_model "gitea.dev/models/issues"
@@ -56,7 +57,7 @@ func NewTeam(ctx context.Context, t *organization.Team) (err error) {
 		return organization.ErrTeamAlreadyExist{OrgID: t.OrgID, Name: t.LowerName}
 	}
 
-	return db.WithTx(ctx, func(ctx context.Context) error {
+	if err := db.WithTx(ctx, func(ctx context.Context) error {
 		if err = db.Insert(ctx, t); err != nil {
 			return err
 		}
@@ -82,6 +83,22 @@ func NewTeam(ctx context.Context, t *organization.Team) (err error) {
 		// Update organization number of teams.
 		_, err = db.Exec(ctx, "UPDATE `user` SET num_teams=num_teams+1 WHERE id = ?", t.OrgID)
 		return err
+	}); err != nil {
+		return err
+	}
+
+	recordTeamEvent(ctx, audit_model.ActionTeamCreate, t, map[string]string{"access_mode": t.AccessMode.ToString()})
+	return nil
+}
+
+func recordTeamEvent(ctx context.Context, action audit_model.Action, t *organization.Team, metadata map[string]string) {
+	audit_model.Record(ctx, &audit_model.Event{
+		Action:     action,
+		OwnerID:    t.OrgID,
+		TargetType: audit_model.TargetTeam,
+		TargetID:   t.ID,
+		TargetName: t.Name,
+		Metadata:   metadata,
 	})
 }
 
@@ -95,7 +112,7 @@ func UpdateTeam(ctx context.Context, t *organization.Team, authChanged, includeA
 		t.Description = t.Description[:255]
 	}
 
-	return db.WithTx(ctx, func(ctx context.Context) error {
+	if err := db.WithTx(ctx, func(ctx context.Context) error {
 		t.LowerName = strings.ToLower(t.Name)
 		has, err := db.Exist[organization.Team](ctx, builder.Eq{
 			"org_id":     t.OrgID,
@@ -155,13 +172,22 @@ func UpdateTeam(ctx context.Context, t *organization.Team, authChanged, includeA
 		}
 
 		return nil
-	})
+	}); err != nil {
+		return err
+	}
+
+	metadata := map[string]string{"access_mode": t.AccessMode.ToString()}
+	if authChanged {
+		metadata["units_changed"] = "true"
+	}
+	recordTeamEvent(ctx, audit_model.ActionTeamUpdate, t, metadata)
+	return nil
 }
 
 // DeleteTeam deletes given team.
 // It's caller's responsibility to assign organization ID.
 func DeleteTeam(ctx context.Context, t *organization.Team) error {
-	return db.WithTx(ctx, func(ctx context.Context) error {
+	if err := db.WithTx(ctx, func(ctx context.Context) error {
 		if err := t.LoadMembers(ctx); err != nil {
 			return err
 		}
@@ -205,7 +231,12 @@ func DeleteTeam(ctx context.Context, t *organization.Team) error {
 		// Update organization number of teams.
 		_, err := db.Exec(ctx, "UPDATE `user` SET num_teams=num_teams-1 WHERE id=?", t.OrgID)
 		return err
-	})
+	}); err != nil {
+		return err
+	}
+
+	recordTeamEvent(ctx, audit_model.ActionTeamDelete, t, nil)
+	return nil
 }
 
 // AddTeamMember adds new membership of given team to given organization,
@@ -246,10 +277,12 @@ func AddTeamMember(ctx context.Context, team *organization.Team, user *user_mode
 		team.NumMembers++
 		return nil
 	})
-	if err != nil {
+	if err != nil || isAlreadyMember {
 		return err
 	}
 
+	recordTeamEvent(ctx, audit_model.ActionTeamMemberAdd, team, map[string]string{"member": user.Name})
+
 	// this behaviour may spend much time so run it in a goroutine
 	// FIXME: Update watch repos batchly
 	if setting.Service.AutoWatchNewRepos {
@@ -306,6 +339,7 @@ func removeTeamMember(ctx context.Context, team *organization.Team, user *user_m
 		Update(team); err != nil {
 		return err
 	}
+	recordTeamEvent(ctx, audit_model.ActionTeamMemberRemove, team, map[string]string{"member": user.Name})
 
 	// Delete access to team repositories. If any user or repo is missing, we can continue.
 	for _, repo := range repos {
@@ -8,6 +8,7 @@ import (
 	"context"
 	"fmt"
 
+	audit_model "gitea.dev/models/audit"
 	"gitea.dev/models/db"
 	"gitea.dev/models/organization"
 	access_model "gitea.dev/models/perm/access"
@@ -53,6 +54,13 @@ func RemoveOrgUser(ctx context.Context, org *organization.Organization, user *us
 		} else if _, err = db.Exec(ctx, "UPDATE `user` SET num_members=num_members-1 WHERE id=?", org.ID); err != nil {
 			return err
 		}
+		audit_model.Record(ctx, &audit_model.Event{
+			Action:     audit_model.ActionOrgMemberRemove,
+			OwnerID:    org.ID,
+			TargetType: audit_model.TargetUser,
+			TargetID:   user.ID,
+			TargetName: user.Name,
+		})
 
 		// Delete all repository accesses and unwatch them.
 		env, err := repo_model.AccessibleReposEnv(ctx, org, user.ID)
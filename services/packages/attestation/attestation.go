@@ -0,0 +1,174 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package attestation
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+
+	"gitea.dev/models/db"
+	packages_model "gitea.dev/models/packages"
+	user_model "gitea.dev/models/user"
+	"gitea.dev/modules/log"
+	packages_module "gitea.dev/modules/packages"
+	attestation_module "gitea.dev/modules/packages/attestation"
+	"gitea.dev/modules/util"
+	packages_service "gitea.dev/services/packages"
+)
+
+// MaxSize is the maximum size of a single attestation
+const MaxSize = 10 * 1024 * 1024
+
+var (
+	ErrInvalidType      = util.NewInvalidArgumentErrorf("attestation type is invalid")
+	ErrFormatMismatch   = util.NewInvalidArgumentErrorf("attestation format does not match the content")
+	ErrFileNotInVersion = util.NewInvalidArgumentErrorf("file does not belong to the package version")
+)
+
+// CreateOptions defines the attestation to attach to a package version
+type CreateOptions struct {
+	Type packages_model.AttestationType
+	// Format is detected from the content if empty
+	Format string
+	Name   string
+	// File is the subject of the attestation. If nil the attestation applies to the whole version.
+	File *packages_model.PackageFile
+	Data []byte
+}
+
+// DetectFormat validates the content and returns its format
+func DetectFormat(t packages_model.AttestationType, data []byte) (string, error) {
+	switch t {
+	case packages_model.AttestationTypeSignature:
+		format, err := attestation_module.DetectSignatureFormat(data)
+		if err != nil {
+			return "", err
+		}
+		if format == attestation_module.FormatSSH {
+			if _, _, err := attestation_module.ParseSSHSignature(data); err != nil {
+				return "", err
+			}
+		} else if _, err := extractGPGSignature(data); err != nil {
+			return "", attestation_module.ErrInvalidSignature
+		}
+		return format, nil
+	case packages_model.AttestationTypeProvenance:
+		if _, err := attestation_module.ParseProvenance(data); err != nil {
+			return "", err
+		}
+		return attestation_module.FormatInToto, nil
+	case packages_model.AttestationTypeSBOM:
+		sbom, err := attestation_module.ParseSBOM(data)
+		if err != nil {
+			return "", err
+		}
+		return sbom.Format, nil
+	}
+	return "", ErrInvalidType
+}
+
+// Create validates and stores the attestation.
+// An existing attestation with the same name and subject is replaced.
+func Create(ctx context.Context, doer *user_model.User, pd *packages_model.PackageDescriptor, opts *CreateOptions) (*packages_model.PackageAttestation, error) {
+	if !opts.Type.IsValid() {
+		return nil, ErrInvalidType
+	}
+
+	format, err := DetectFormat(opts.Type, opts.Data)
+	if err != nil {
+		return nil, err
+	}
+	if opts.Format != "" && opts.Format != format {
+		return nil, ErrFormatMismatch
+	}
+
+	var fileID int64
+	if opts.File != nil {
+		if opts.File.VersionID != pd.Version.ID {
+			return nil, ErrFileNotInVersion
+		}
+		fileID = opts.File.ID
+	}
+
+	name := opts.Name
+	if name == "" {
+		name = fmt.Sprintf("%s.%s", opts.Type, format)
+	}
+
+	if err := packages_service.CheckSizeQuotaExceeded(ctx, doer, pd.Owner, pd.Package.Type, int64(len(opts.Data))); err != nil {
+		return nil, err
+	}
+
+	buf, err := packages_module.CreateHashedBufferFromReader(bytes.NewReader(opts.Data))
+	if err != nil {
+		return nil, err
+	}
+	defer buf.Close()
+
+	var pa *packages_model.PackageAttestation
+	var pb *packages_model.PackageBlob
+	blobCreated := false
+	err = db.WithTx(ctx, func(ctx context.Context) error {
+		var exists bool
+		pb, exists, err = packages_model.GetOrInsertBlob(ctx, packages_service.NewPackageBlob(buf))
+		if err != nil {
+			return err
+		}
+		if !exists {
+			blobCreated = true
+			if err := packages_module.NewContentStore().Save(packages_module.BlobHash256Key(pb.HashSHA256), buf, buf.Size()); err != nil {
+				return err
+			}
+		}
+
+		if err := packages_model.DeleteAttestationsByName(ctx, pd.Version.ID, fileID, name); err != nil {
+			return err
+		}
+
+		pa = &packages_model.PackageAttestation{
+			VersionID: pd.Version.ID,
+			FileID:    fileID,
+			BlobID:    pb.ID,
+			Type:      opts.Type,
+			Format:    format,
+			Name:      name,
+		}
+		if doer != nil {
+			pa.CreatorID = doer.ID
+		}
+		return packages_model.InsertAttestation(ctx, pa)
+	})
+	if err != nil {
+		if blobCreated {
+			if err := packages_module.NewContentStore().Delete(packages_module.BlobHash256Key(pb.HashSHA256)); err != nil {
+				log.Error("Error deleting package blob from content store: %v", err)
+			}
+		}
+		return nil, err
+	}
+
+	return pa, nil
+}
+
+// GetContent reads the content of the attestation
+func GetContent(ctx context.Context, pa *packages_model.PackageAttestation) ([]byte, *packages_model.PackageBlob, error) {
+	pb, err := packages_model.GetBlobByID(ctx, pa.BlobID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	s, err := packages_service.OpenBlobStream(pb)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer s.Close()
+
+	data, err := io.ReadAll(io.LimitReader(s, MaxSize))
+	if err != nil {
+		return nil, nil, err
+	}
+	return data, pb, nil
+}
@@ -0,0 +1,110 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package attestation
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+
+	packages_model "gitea.dev/models/packages"
+	user_model "gitea.dev/models/user"
+	"gitea.dev/modules/log"
+	"gitea.dev/modules/util"
+)
+
+// sidecarSuffixes maps the file suffixes used by the package managers to the attestation type.
+// The subject file name is the sidecar file name without the suffix.
+var sidecarSuffixes = []struct {
+	Suffix string
+	Type   packages_model.AttestationType
+}{
+	{".asc", packages_model.AttestationTypeSignature},
+	{".sig", packages_model.AttestationTypeSignature},
+	{".intoto.jsonl", packages_model.AttestationTypeProvenance},
+	{".intoto.json", packages_model.AttestationTypeProvenance},
+	{".sigstore.json", packages_model.AttestationTypeProvenance},
+	{".sigstore", packages_model.AttestationTypeProvenance},
+	{".spdx.json", packages_model.AttestationTypeSBOM},
+	{".cdx.json", packages_model.AttestationTypeSBOM},
+	{".cdx.xml", packages_model.AttestationTypeSBOM},
+	// Maven classifiers of the cyclonedx-maven-plugin and spdx-maven-plugin
+	{"-cyclonedx.json", packages_model.AttestationTypeSBOM},
+	{"-cyclonedx.xml", packages_model.AttestationTypeSBOM},
+	{"-spdx.json", packages_model.AttestationTypeSBOM},
+}
+
+// ParseSidecarFilename checks if the file is a sidecar file and returns the attestation type and the name of the subject file
+func ParseSidecarFilename(filename string) (packages_model.AttestationType, string, bool) {
+	lower := strings.ToLower(filename)
+	for _, s := range sidecarSuffixes {
+		if strings.HasSuffix(lower, s.Suffix) && len(filename) > len(s.Suffix) {
+			return s.Type, filename[:len(filename)-len(s.Suffix)], true
+		}
+	}
+	return "", "", false
+}
+
+// CreateFromSidecar records an uploaded sidecar file (like "example.jar.asc") as attestation of its subject file.
+// If the subject file does not exist the attestation applies to the whole version.
+// Files with invalid content are kept as normal package files and are not recorded.
+func CreateFromSidecar(ctx context.Context, doer *user_model.User, pv *packages_model.PackageVersion, filename string, r io.Reader) error {
+	t, subject, ok := ParseSidecarFilename(filename)
+	if !ok {
+		return nil
+	}
+
+	data, err := util.ReadWithLimit(r, MaxSize)
+	if err != nil {
+		return err
+	}
+
+	pd, err := packages_model.GetPackageDescriptor(ctx, pv)
+	if err != nil {
+		return err
+	}
+
+	opts := &CreateOptions{
+		Type: t,
+		Name: filename,
+		Data: data,
+	}
+	for _, pfd := range pd.Files {
+		if pfd.File.Name == subject {
+			opts.File = pfd.File
+			break
+		}
+	}
+
+	if _, err := Create(ctx, doer, pd, opts); err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			log.Debug("Ignoring invalid sidecar file %s of package version %d: %v", filename, pv.ID, err)
+			return nil
+		}
+		return err
+	}
+	return nil
+}
+
+// CreateForFile attaches an attestation which was uploaded together with a package file
+func CreateForFile(ctx context.Context, doer *user_model.User, pf *packages_model.PackageFile, t packages_model.AttestationType, name string, data []byte) error {
+	pv, err := packages_model.GetVersionByID(ctx, pf.VersionID)
+	if err != nil {
+		return err
+	}
+
+	pd, err := packages_model.GetPackageDescriptor(ctx, pv)
+	if err != nil {
+		return err
+	}
+
+	_, err = Create(ctx, doer, pd, &CreateOptions{
+		Type: t,
+		Name: name,
+		File: pf,
+		Data: data,
+	})
+	return err
+}
@@ -0,0 +1,370 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package attestation
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"slices"
+
+	asymkey_model "gitea.dev/models/asymkey"
+	"gitea.dev/models/db"
+	packages_model "gitea.dev/models/packages"
+	user_model "gitea.dev/models/user"
+	"gitea.dev/modules/log"
+	attestation_module "gitea.dev/modules/packages/attestation"
+	packages_service "gitea.dev/services/packages"
+
+	"github.com/42wim/sshsig"
+	"github.com/ProtonMail/go-crypto/openpgp/packet"
+	"golang.org/x/crypto/ssh"
+)
+
+// Reasons why an attestation could not be verified. The values are locale keys.
+const (
+	ReasonNoKey            = "packages.attestation.reason.no_key"
+	ReasonInvalidSignature = "packages.attestation.reason.invalid_signature"
+	ReasonNoSubject        = "packages.attestation.reason.no_subject"
+	ReasonUnsupported      = "packages.attestation.reason.unsupported"
+	ReasonUnsigned         = "packages.attestation.reason.unsigned"
+)
+
+// Verification is the result of the attestation verification
+type Verification struct {
+	Verified bool
+	Reason   string
+	// Signer is the user who owns the key which created the signature
+	Signer *user_model.User
+	// KeyID is the GPG key id or the SSH key fingerprint
+	KeyID string
+	// Subject is the name of the verified file
+	Subject string
+}
+
+// IsUnsigned returns whether the attestation could be parsed but carries no signature which could be verified
+func (v *Verification) IsUnsigned() bool {
+	return v.Reason == ReasonUnsigned
+}
+
+// signedContent is the content a signature may have been created for
+type signedContent struct {
+	// subject is the name of the file the content belongs to
+	subject string
+	open    func() (io.ReadCloser, error)
+}
+
+func fileContent(pfd *packages_model.PackageFileDescriptor) signedContent {
+	return signedContent{
+		subject: pfd.File.Name,
+		open: func() (io.ReadCloser, error) {
+			s, err := packages_service.OpenBlobStream(pfd.Blob)
+			if err != nil {
+				log.Error("Error opening package blob %d: %v", pfd.Blob.ID, err)
+			}
+			return s, err
+		},
+	}
+}
+
+func bytesContent(subject string, data []byte) signedContent {
+	return signedContent{
+		subject: subject,
+		open: func() (io.ReadCloser, error) {
+			return io.NopCloser(bytes.NewReader(data)), nil
+		},
+	}
+}
+
+func extractGPGSignature(data []byte) (*packet.Signature, error) {
+	if bytes.HasPrefix(bytes.TrimSpace(data), []byte("-----BEGIN")) {
+		return asymkey_model.ExtractSignature(string(data))
+	}
+	return asymkey_model.ExtractBinarySignature(data)
+}
+
+// subjectFiles returns the files the attestation could apply to
+func subjectFiles(pd *packages_model.PackageDescriptor, pa *packages_model.PackageAttestation) []*packages_model.PackageFileDescriptor {
+	if pa.FileID == 0 {
+		return pd.Files
+	}
+	for _, pfd := range pd.Files {
+		if pfd.File.ID == pa.FileID {
+			return []*packages_model.PackageFileDescriptor{pfd}
+		}
+	}
+	return nil
+}
+
+// detachedSignatureNames returns the names a detached signature of the attestation is uploaded with
+func detachedSignatureNames(pa *packages_model.PackageAttestation) []string {
+	return []string{pa.Name + ".sig", pa.Name + ".asc"}
+}
+
+// findSignedAttestation returns the provenance attestation the signature attestation has been created for, if any
+func findSignedAttestation(ctx context.Context, pa *packages_model.PackageAttestation) (*packages_model.PackageAttestation, error) {
+	pas, err := packages_model.GetAttestationsByVersionID(ctx, pa.VersionID)
+	if err != nil {
+		return nil, err
+	}
+	for _, other := range pas {
+		if other.Type != packages_model.AttestationTypeProvenance || other.FileID != pa.FileID {
+			continue
+		}
+		if slices.Contains(detachedSignatureNames(other), pa.Name) {
+			return other, nil
+		}
+	}
+	return nil, nil
+}
+
+// Verify checks the attestation against the package files.
+// Signatures must be created by a verified GPG or SSH key of a user. Provenance statements must reference
+// the file digest and must be signed, either in their DSSE envelope or by a detached signature.
+// SBOMs are not verified.
+func Verify(ctx context.Context, pd *packages_model.PackageDescriptor, pa *packages_model.PackageAttestation) (*Verification, error) {
+	data, _, err := GetContent(ctx, pa)
+	if err != nil {
+		return nil, err
+	}
+	return verifyContent(ctx, pd, pa, data)
+}
+
+func verifyContent(ctx context.Context, pd *packages_model.PackageDescriptor, pa *packages_model.PackageAttestation, data []byte) (*Verification, error) {
+	files := subjectFiles(pd, pa)
+
+	switch pa.Type {
+	case packages_model.AttestationTypeSignature:
+		contents := make([]signedContent, 0, len(files))
+		for _, pfd := range files {
+			contents = append(contents, fileContent(pfd))
+		}
+		// a detached signature of a provenance statement signs the statement instead of the file
+		signed, err := findSignedAttestation(ctx, pa)
+		if err != nil {
+			return nil, err
+		}
+		if signed != nil {
+			statement, _, err := GetContent(ctx, signed)
+			if err != nil {
+				return nil, err
+			}
+			contents = []signedContent{bytesContent(signed.Name, statement)}
+		}
+		return verifySignature(ctx, pa.Format, data, contents)
+	case packages_model.AttestationTypeProvenance:
+		return verifyProvenance(ctx, pa, data, files)
+	}
+	return &Verification{Reason: ReasonUnsupported}, nil
+}
+
+func verifySignature(ctx context.Context, format string, data []byte, contents []signedContent) (*Verification, error) {
+	if format == attestation_module.FormatSSH {
+		return verifySSHSignature(ctx, data, contents)
+	}
+	return verifyGPGSignature(ctx, data, contents)
+}
+
+// isTrustedGPGKey returns whether the key has been verified by its owner, subkeys are trusted if their primary key is
+func isTrustedGPGKey(ctx context.Context, key *asymkey_model.GPGKey) (bool, error) {
+	if key.Verified || key.PrimaryKeyID == "" {
+		return key.Verified, nil
+	}
+	primaryKeys, err := db.Find[asymkey_model.GPGKey](ctx, asymkey_model.FindGPGKeyOptions{
+		OwnerID: key.OwnerID,
+		KeyID:   key.PrimaryKeyID,
+	})
+	if err != nil {
+		return false, err
+	}
+	return len(primaryKeys) > 0 && primaryKeys[0].Verified, nil
+}
+
+func verifyGPGSignature(ctx context.Context, data []byte, contents []signedContent) (*Verification, error) {
+	sig, err := extractGPGSignature(data)
+	if err != nil {
+		return &Verification{Reason: ReasonInvalidSignature}, nil
+	}
+
+	keyID := asymkey_model.TryGetKeyIDFromSignature(sig)
+	if keyID == "" {
+		return &Verification{Reason: ReasonNoKey}, nil
+	}
+
+	keys, err := asymkey_model.FindGPGKeyWithSubKeys(ctx, keyID)
+	if err != nil {
+		return nil, err
+	}
+	if len(keys) == 0 {
+		return &Verification{Reason: ReasonNoKey, KeyID: keyID}, nil
+	}
+
+	for _, content := range contents {
+		for _, key := range keys {
+			// like commit signatures only verified keys are trusted
+			trusted, err := isTrustedGPGKey(ctx, key)
+			if err != nil {
+				return nil, err
+			}
+			if !trusted {
+				continue
+			}
+
+			if err := verifyContentWith(content, func(r io.Reader) error {
+				return asymkey_model.VerifyDetachedSignature(sig, r, key)
+			}); err != nil {
+				continue
+			}
+
+			signer, err := user_model.GetUserByID(ctx, key.OwnerID)
+			if err != nil {
+				return nil, err
+			}
+			return &Verification{
+				Verified: true,
+				Signer:   signer,
+				KeyID:    keyID,
+				Subject:  content.subject,
+			}, nil
+		}
+	}
+
+	return &Verification{Reason: ReasonInvalidSignature, KeyID: keyID}, nil
+}
+
+func verifySSHSignature(ctx context.Context, data []byte, contents []signedContent) (*Verification, error) {
+	pk, namespace, err := attestation_module.ParseSSHSignature(data)
+	if err != nil {
+		return &Verification{Reason: ReasonInvalidSignature}, nil
+	}
+	// signatures created for other purposes (like commits) must not be accepted
+	if namespace != attestation_module.SSHNamespace {
+		return &Verification{Reason: ReasonInvalidSignature}, nil
+	}
+
+	fingerprint := ssh.FingerprintSHA256(pk)
+
+	keys, err := db.Find[asymkey_model.PublicKey](ctx, asymkey_model.FindPublicKeyOptions{
+		Fingerprint: fingerprint,
+		NotKeytype:  asymkey_model.KeyTypePrincipal,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, content := range contents {
+		for _, key := range keys {
+			// like commit signatures only verified keys are trusted
+			if !key.Verified {
+				continue
+			}
+
+			if err := verifyContentWith(content, func(r io.Reader) error {
+				return sshsig.Verify(r, data, []byte(key.Content), attestation_module.SSHNamespace)
+			}); err != nil {
+				continue
+			}
+
+			signer, err := user_model.GetUserByID(ctx, key.OwnerID)
+			if err != nil {
+				return nil, err
+			}
+			return &Verification{
+				Verified: true,
+				Signer:   signer,
+				KeyID:    fingerprint,
+				Subject:  content.subject,
+			}, nil
+		}
+	}
+
+	if len(keys) == 0 {
+		return &Verification{Reason: ReasonNoKey, KeyID: fingerprint}, nil
+	}
+	return &Verification{Reason: ReasonInvalidSignature, KeyID: fingerprint}, nil
+}
+
+// verifyContentWith calls the verification function with the content
+func verifyContentWith(content signedContent, verify func(r io.Reader) error) error {
+	r, err := content.open()
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	return verify(r)
+}
+
+// verifyProvenance checks that the statement references a file and that it is signed by a verified key.
+// The signatures of the DSSE envelope sign its pre-authentication encoding, detached signatures sign the uploaded statement.
+func verifyProvenance(ctx context.Context, pa *packages_model.PackageAttestation, data []byte, files []*packages_model.PackageFileDescriptor) (*Verification, error) {
+	p, err := attestation_module.ParseProvenance(data)
+	if err != nil {
+		return &Verification{Reason: ReasonUnsupported}, nil
+	}
+
+	var subject *packages_model.PackageFileDescriptor
+	for _, pfd := range files {
+		if p.MatchesDigest("sha256", pfd.Blob.HashSHA256) || p.MatchesDigest("sha512", pfd.Blob.HashSHA512) {
+			subject = pfd
+			break
+		}
+	}
+	if subject == nil {
+		return &Verification{Reason: ReasonNoSubject}, nil
+	}
+
+	// the result of the last signature which could not be verified is reported
+	var failed *Verification
+	check := func(sig []byte, content signedContent) (*Verification, error) {
+		format, err := attestation_module.DetectSignatureFormat(sig)
+		if err != nil {
+			failed = &Verification{Reason: ReasonInvalidSignature}
+			return nil, nil
+		}
+		v, err := verifySignature(ctx, format, sig, []signedContent{content})
+		if err != nil {
+			return nil, err
+		}
+		if !v.Verified {
+			failed = v
+			return nil, nil
+		}
+		v.Subject = subject.File.Name
+		return v, nil
+	}
+
+	if p.Envelope != nil {
+		pae := bytesContent(subject.File.Name, p.Envelope.PAE())
+		for _, sig := range p.Envelope.Signatures {
+			if v, err := check(sig, pae); v != nil || err != nil {
+				return v, err
+			}
+		}
+	}
+
+	pas, err := packages_model.GetAttestationsByVersionID(ctx, pa.VersionID)
+	if err != nil {
+		return nil, err
+	}
+	names := detachedSignatureNames(pa)
+	for _, other := range pas {
+		if other.Type != packages_model.AttestationTypeSignature || other.FileID != pa.FileID || !slices.Contains(names, other.Name) {
+			continue
+		}
+		sig, _, err := GetContent(ctx, other)
+		if err != nil {
+			return nil, err
+		}
+		if v, err := check(sig, bytesContent(subject.File.Name, data)); v != nil || err != nil {
+			return v, err
+		}
+	}
+
+	if failed != nil {
+		failed.Subject = subject.File.Name
+		return failed, nil
+	}
+	return &Verification{Reason: ReasonUnsigned, Subject: subject.File.Name}, nil
+}
@@ -0,0 +1,85 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package attestation
+
+import (
+	"context"
+
+	packages_model "gitea.dev/models/packages"
+	"gitea.dev/modules/log"
+	attestation_module "gitea.dev/modules/packages/attestation"
+)
+
+// View contains an attestation with its parsed content and verification result
+type View struct {
+	Attestation  *packages_model.PackageAttestation
+	Blob         *packages_model.PackageBlob
+	File         *packages_model.PackageFile
+	Verification *Verification
+	SBOM         *attestation_module.SBOM
+	Provenance   *attestation_module.Provenance
+}
+
+// FileName returns the name of the package file the attestation belongs to
+func (v *View) FileName() string {
+	if v.File == nil {
+		return ""
+	}
+	return v.File.Name
+}
+
+// LoadView reads, parses and verifies the attestation
+func LoadView(ctx context.Context, pd *packages_model.PackageDescriptor, pa *packages_model.PackageAttestation) (*View, error) {
+	data, pb, err := GetContent(ctx, pa)
+	if err != nil {
+		return nil, err
+	}
+
+	v := &View{
+		Attestation: pa,
+		Blob:        pb,
+	}
+	for _, pfd := range pd.Files {
+		if pfd.File.ID == pa.FileID {
+			v.File = pfd.File
+			break
+		}
+	}
+
+	switch pa.Type {
+	case packages_model.AttestationTypeSBOM:
+		// SBOMs were validated on upload, a parse error here is not fatal for the page
+		if v.SBOM, err = attestation_module.ParseSBOM(data); err != nil {
+			log.Warn("Error parsing SBOM of package attestation %d: %v", pa.ID, err)
+		}
+		return v, nil
+	case packages_model.AttestationTypeProvenance:
+		if v.Provenance, err = attestation_module.ParseProvenance(data); err != nil {
+			log.Warn("Error parsing provenance of package attestation %d: %v", pa.ID, err)
+		}
+	}
+
+	if v.Verification, err = verifyContent(ctx, pd, pa, data); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// LoadViews loads the views of all attestations of the package version
+func LoadViews(ctx context.Context, pd *packages_model.PackageDescriptor) ([]*View, error) {
+	pas, err := packages_model.GetAttestationsByVersionID(ctx, pd.Version.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	views := make([]*View, 0, len(pas))
+	for _, pa := range pas {
+		v, err := LoadView(ctx, pd, pa)
+		if err != nil {
+			return nil, err
+		}
+		views = append(views, v)
+	}
+	return views, nil
+}
@@ -10,6 +10,7 @@ import (
 
 	"gitea.dev/models/db"
 	packages_model "gitea.dev/models/packages"
+	quota_model "gitea.dev/models/quota"
 	user_model "gitea.dev/models/user"
 	"gitea.dev/modules/log"
 	"gitea.dev/modules/optional"
@@ -20,7 +21,9 @@ import (
 	cargo_service "gitea.dev/services/packages/cargo"
 	container_service "gitea.dev/services/packages/container"
 	debian_service "gitea.dev/services/packages/debian"
+	nix_service "gitea.dev/services/packages/nix"
 	rpm_service "gitea.dev/services/packages/rpm"
+	quota_service "gitea.dev/services/quota"
 )
 
 // CleanupTask executes cleanup rules and cleanup expired package data
@@ -120,6 +123,8 @@ func executeCleanupOneRule(ctx context.Context, pcr *packages_model.PackageClean
 	}
 
 	if anyVersionDeleted {
+		quota_service.UpdateUsage(ctx, pcr.OwnerID, quota_model.CategoryPackages)
+
 		switch pcr.Type {
 		case packages_model.TypeDebian:
 			if err := debian_service.BuildAllRepositoryFiles(ctx, pcr.OwnerID); err != nil {
@@ -171,6 +176,10 @@ func CleanupExpiredData(ctx context.Context, olderThan time.Duration) error {
 			return err
 		}
 
+		if err := nix_service.Cleanup(ctx, olderThan); err != nil {
+			return err
+		}
+
 		ps, err := packages_model.FindUnreferencedPackages(ctx)
 		if err != nil {
 			return err
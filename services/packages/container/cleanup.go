@@ -5,6 +5,7 @@ package container
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	packages_model "gitea.dev/models/packages"
@@ -21,7 +22,10 @@ func Cleanup(ctx context.Context, olderThan time.Duration) error {
 	if err := cleanupExpiredBlobUploads(ctx, olderThan); err != nil {
 		return err
 	}
-	return cleanupExpiredUploadedBlobs(ctx, olderThan)
+	if err := cleanupExpiredUploadedBlobs(ctx, olderThan); err != nil {
+		return err
+	}
+	return cleanupOrphanedReferrers(ctx, olderThan)
 }
 
 // cleanupExpiredBlobUploads removes expired blob uploads
@@ -79,12 +83,74 @@ func cleanupExpiredUploadedBlobs(ctx context.Context, olderThan time.Duration) e
 	return nil
 }
 
+// cleanupOrphanedReferrers removes untagged manifests like signatures and attestations whose subject does not exist anymore.
+// The subject may be pushed after its referrers, so only referrers older than specified are removed.
+func cleanupOrphanedReferrers(ctx context.Context, olderThan time.Duration) error {
+	pvs, err := container_model.SearchExpiredReferrers(ctx, olderThan)
+	if err != nil {
+		return err
+	}
+
+	for _, pv := range pvs {
+		p, err := packages_model.GetPackageByID(ctx, pv.PackageID)
+		if err != nil {
+			return err
+		}
+
+		has, exists, err := subjectExists(ctx, p, pv)
+		if err != nil {
+			return err
+		}
+		if !has || exists {
+			continue
+		}
+
+		if err := packages_service.DeletePackageVersionAndReferences(ctx, pv); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// subjectExists checks if the manifest refers to a subject and if the subject manifest exists
+func subjectExists(ctx context.Context, p *packages_model.Package, pv *packages_model.PackageVersion) (hasSubject, exists bool, _ error) {
+	pps, err := packages_model.GetPropertiesByName(ctx, packages_model.PropertyTypeVersion, pv.ID, container_module.PropertyManifestSubject)
+	if err != nil {
+		return false, false, err
+	}
+	if len(pps) == 0 {
+		return false, false, nil
+	}
+
+	_, err = container_model.GetContainerBlob(ctx, &container_model.BlobSearchOptions{
+		OwnerID:    p.OwnerID,
+		Image:      p.LowerName,
+		Digest:     pps[0].Value,
+		IsManifest: true,
+	})
+	if err != nil {
+		if errors.Is(err, container_model.ErrContainerBlobNotExist) {
+			return true, false, nil
+		}
+		return true, false, err
+	}
+	return true, true, nil
+}
+
 func ShouldBeSkipped(ctx context.Context, pcr *packages_model.PackageCleanupRule, p *packages_model.Package, pv *packages_model.PackageVersion) (bool, error) {
 	// Always skip the "latest" tag
 	if pv.LowerVersion == "latest" {
 		return true, nil
 	}
 
+	// Keep referrers like signatures and attestations as long as their subject exists
+	if _, exists, err := subjectExists(ctx, p, pv); err != nil {
+		return false, err
+	} else if exists {
+		return true, nil
+	}
+
 	// Check if the version is a digest (or untagged)
 	if digest.Digest(pv.LowerVersion).Validate() == nil {
 		// Check if there is another manifest referencing this version
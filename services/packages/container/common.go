@@ -60,5 +60,19 @@ func ParseManifestMetadata(ctx context.Context, rd io.Reader, ownerID int64, ima
 	}
 	defer configReader.Close()
 	metadata, err := container_module.ParseImageConfig(manifest.Config.MediaType, configReader)
-	return &manifest, configDescriptor, metadata, err
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	if artifactType := container_module.GetArtifactType(manifest.ArtifactType, manifest.Config.MediaType); artifactType != "" {
+		metadata.Type = container_module.TypeArtifact
+		metadata.ArtifactType = artifactType
+		// the config of an artifact does not describe a platform
+		metadata.Platform = ""
+	}
+	if manifest.Subject != nil {
+		metadata.Subject = manifest.Subject.Digest.String()
+	}
+	metadata.Annotations = manifest.Annotations
+	return &manifest, configDescriptor, metadata, nil
 }
@@ -0,0 +1,234 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package hex
+
+import (
+	"context"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"sort"
+
+	packages_model "gitea.dev/models/packages"
+	user_model "gitea.dev/models/user"
+	"gitea.dev/modules/json"
+	hex_module "gitea.dev/modules/packages/hex"
+	"gitea.dev/modules/timeutil"
+	"gitea.dev/modules/util"
+)
+
+// RepositoryName returns the name of the Hex repository of the owner.
+// The name is part of every signed resource and must match the name configured in the client.
+func RepositoryName(owner *user_model.User) string {
+	return owner.LowerName
+}
+
+// GetOrCreateKeyPair gets or creates the RSA keys used to sign the registry resources
+func GetOrCreateKeyPair(ctx context.Context, ownerID int64) (string, string, error) {
+	priv, err := user_model.GetSetting(ctx, ownerID, hex_module.SettingKeyPrivate)
+	if err != nil && !errors.Is(err, util.ErrNotExist) {
+		return "", "", err
+	}
+
+	pub, err := user_model.GetSetting(ctx, ownerID, hex_module.SettingKeyPublic)
+	if err != nil && !errors.Is(err, util.ErrNotExist) {
+		return "", "", err
+	}
+
+	if priv == "" || pub == "" {
+		priv, pub, err = util.GenerateKeyPair(4096)
+		if err != nil {
+			return "", "", err
+		}
+
+		if err := user_model.SetUserSetting(ctx, ownerID, hex_module.SettingKeyPrivate, priv); err != nil {
+			return "", "", err
+		}
+
+		if err := user_model.SetUserSetting(ctx, ownerID, hex_module.SettingKeyPublic, pub); err != nil {
+			return "", "", err
+		}
+	}
+
+	return priv, pub, nil
+}
+
+func getPrivateKey(ctx context.Context, ownerID int64) (*rsa.PrivateKey, error) {
+	priv, _, err := GetOrCreateKeyPair(ctx, ownerID)
+	if err != nil {
+		return nil, err
+	}
+
+	block, _ := pem.Decode([]byte(priv))
+	if block == nil {
+		return nil, errors.New("failed to decode private key pem")
+	}
+
+	return x509.ParsePKCS1PrivateKey(block.Bytes)
+}
+
+// GetRetirement returns the retirement of the package version or nil if the version is not retired
+func GetRetirement(pd *packages_model.PackageDescriptor) *hex_module.Retirement {
+	for _, pvp := range pd.VersionProperties {
+		if pvp.Name == hex_module.PropertyRetirement {
+			r := &hex_module.Retirement{}
+			if err := json.Unmarshal([]byte(pvp.Value), r); err != nil {
+				return nil
+			}
+			return r
+		}
+	}
+	return nil
+}
+
+// SetRetirement retires the package version or removes the retirement if r is nil
+func SetRetirement(ctx context.Context, pv *packages_model.PackageVersion, r *hex_module.Retirement) error {
+	if r == nil {
+		return packages_model.DeletePropertiesByName(ctx, packages_model.PropertyTypeVersion, pv.ID, hex_module.PropertyRetirement)
+	}
+
+	value, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+	return packages_model.InsertOrUpdateProperty(ctx, packages_model.PropertyTypeVersion, pv.ID, hex_module.PropertyRetirement, string(value))
+}
+
+// groupByPackage groups the package descriptors by package and sorts the versions ascending
+func groupByPackage(pds []*packages_model.PackageDescriptor) [][]*packages_model.PackageDescriptor {
+	m := make(map[int64][]*packages_model.PackageDescriptor)
+	for _, pd := range pds {
+		m[pd.Package.ID] = append(m[pd.Package.ID], pd)
+	}
+
+	groups := make([][]*packages_model.PackageDescriptor, 0, len(m))
+	for _, group := range m {
+		sort.Slice(group, func(i, j int) bool {
+			return group[i].SemVer.LessThan(group[j].SemVer)
+		})
+		groups = append(groups, group)
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		return groups[i][0].Package.LowerName < groups[j][0].Package.LowerName
+	})
+	return groups
+}
+
+func getAllPackageDescriptors(ctx context.Context, ownerID int64) ([]*packages_model.PackageDescriptor, error) {
+	pvs, err := packages_model.GetVersionsByPackageType(ctx, ownerID, packages_model.TypeHex)
+	if err != nil {
+		return nil, err
+	}
+	return packages_model.GetPackageDescriptors(ctx, pvs)
+}
+
+// BuildNamesResource creates the signed names resource listing all packages of the owner
+func BuildNamesResource(ctx context.Context, owner *user_model.User) ([]byte, error) {
+	ps, err := packages_model.GetPackagesByType(ctx, owner.ID, packages_model.TypeHex)
+	if err != nil {
+		return nil, err
+	}
+	pvs, err := packages_model.GetVersionsByPackageType(ctx, owner.ID, packages_model.TypeHex)
+	if err != nil {
+		return nil, err
+	}
+
+	updated := make(map[int64]timeutil.TimeStamp, len(ps))
+	for _, pv := range pvs {
+		updated[pv.PackageID] = max(updated[pv.PackageID], pv.CreatedUnix)
+	}
+
+	sort.Slice(ps, func(i, j int) bool {
+		return ps[i].LowerName < ps[j].LowerName
+	})
+
+	entries := make([]*hex_module.NameEntry, 0, len(ps))
+	for _, p := range ps {
+		// packages without versions are removed by the cleanup task
+		if _, has := updated[p.ID]; !has {
+			continue
+		}
+		entries = append(entries, &hex_module.NameEntry{
+			Name:    p.Name,
+			Updated: updated[p.ID].AsTime(),
+		})
+	}
+
+	return sign(ctx, owner.ID, hex_module.EncodeNames(RepositoryName(owner), entries))
+}
+
+// BuildVersionsResource creates the signed versions resource listing the versions of all packages of the owner
+func BuildVersionsResource(ctx context.Context, owner *user_model.User) ([]byte, error) {
+	pds, err := getAllPackageDescriptors(ctx, owner.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	groups := groupByPackage(pds)
+
+	entries := make([]*hex_module.VersionsEntry, 0, len(groups))
+	for _, group := range groups {
+		entry := &hex_module.VersionsEntry{
+			Name:     group[0].Package.Name,
+			Versions: make([]string, 0, len(group)),
+		}
+		for i, pd := range group {
+			entry.Versions = append(entry.Versions, pd.Version.Version)
+			if GetRetirement(pd) != nil {
+				entry.Retired = append(entry.Retired, i)
+			}
+		}
+		entries = append(entries, entry)
+	}
+
+	return sign(ctx, owner.ID, hex_module.EncodeVersions(RepositoryName(owner), entries))
+}
+
+// BuildPackageResource creates the signed package resource containing all releases of the package
+func BuildPackageResource(ctx context.Context, owner *user_model.User, name string) ([]byte, error) {
+	pvs, err := packages_model.GetVersionsByPackageName(ctx, owner.ID, packages_model.TypeHex, name)
+	if err != nil {
+		return nil, err
+	}
+	if len(pvs) == 0 {
+		return nil, packages_model.ErrPackageNotExist
+	}
+
+	pds, err := packages_model.GetPackageDescriptors(ctx, pvs)
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(pds, func(i, j int) bool {
+		return pds[i].SemVer.LessThan(pds[j].SemVer)
+	})
+
+	releases := make([]*hex_module.Release, 0, len(pds))
+	for _, pd := range pds {
+		metadata := packages_model.DescriptorMetadata[*hex_module.Metadata](pd)
+
+		releases = append(releases, &hex_module.Release{
+			Version:       pd.Version.Version,
+			InnerChecksum: metadata.InnerChecksum,
+			OuterChecksum: pd.Files[0].Blob.HashSHA256,
+			Dependencies:  metadata.Requirements,
+			Retired:       GetRetirement(pd),
+		})
+	}
+
+	payload, err := hex_module.EncodePackage(RepositoryName(owner), pds[0].Package.Name, releases)
+	if err != nil {
+		return nil, err
+	}
+	return sign(ctx, owner.ID, payload)
+}
+
+func sign(ctx context.Context, ownerID int64, payload []byte) ([]byte, error) {
+	privateKey, err := getPrivateKey(ctx, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	return hex_module.SignAndCompress(payload, privateKey)
+}
@@ -0,0 +1,243 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package nix
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	packages_model "gitea.dev/models/packages"
+	user_model "gitea.dev/models/user"
+	"gitea.dev/modules/optional"
+	packages_module "gitea.dev/modules/packages"
+	nix_module "gitea.dev/modules/packages/nix"
+	"gitea.dev/modules/setting"
+	"gitea.dev/modules/util"
+	packages_service "gitea.dev/services/packages"
+)
+
+var (
+	ErrNarFileNotUploaded = util.NewInvalidArgumentErrorf("the nar file referenced by the narinfo has not been uploaded")
+	ErrNarFileMismatch    = util.NewInvalidArgumentErrorf("the nar file does not match the FileHash or FileSize of the narinfo")
+)
+
+// GetOrCreateUploadVersion gets or creates the internal package version which holds the uploaded nar files.
+// Nix uploads the nar file before the narinfo file which describes the store path.
+func GetOrCreateUploadVersion(ctx context.Context, ownerID int64) (*packages_model.PackageVersion, error) {
+	return packages_service.GetOrCreateInternalPackageVersion(ctx, ownerID, packages_model.TypeNix, nix_module.UploadPackage, nix_module.UploadVersion)
+}
+
+// GetOrCreateKeyPair gets or creates the Ed25519 keys used to sign the narinfo files
+func GetOrCreateKeyPair(ctx context.Context, owner *user_model.User) (string, string, error) {
+	priv, err := user_model.GetSetting(ctx, owner.ID, nix_module.SettingKeyPrivate)
+	if err != nil && !errors.Is(err, util.ErrNotExist) {
+		return "", "", err
+	}
+
+	pub, err := user_model.GetSetting(ctx, owner.ID, nix_module.SettingKeyPublic)
+	if err != nil && !errors.Is(err, util.ErrNotExist) {
+		return "", "", err
+	}
+
+	if priv == "" || pub == "" {
+		priv, pub, err = nix_module.GenerateKeyPair(setting.Domain + "-" + owner.LowerName + "-1")
+		if err != nil {
+			return "", "", err
+		}
+
+		if err := user_model.SetUserSetting(ctx, owner.ID, nix_module.SettingKeyPrivate, priv); err != nil {
+			return "", "", err
+		}
+
+		if err := user_model.SetUserSetting(ctx, owner.ID, nix_module.SettingKeyPublic, pub); err != nil {
+			return "", "", err
+		}
+	}
+
+	return priv, pub, nil
+}
+
+// GetStorePathVersion gets the package version of the store path with the specific hash
+func GetStorePathVersion(ctx context.Context, ownerID int64, hash string) (*packages_model.PackageVersion, error) {
+	pvs, _, err := packages_model.SearchVersions(ctx, &packages_model.PackageSearchOptions{
+		OwnerID: ownerID,
+		Type:    packages_model.TypeNix,
+		Version: packages_model.SearchValue{
+			ExactMatch: true,
+			Value:      hash,
+		},
+		IsInternal: optional.Some(false),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(pvs) == 0 {
+		return nil, packages_model.ErrPackageNotExist
+	}
+	return pvs[0], nil
+}
+
+// BuildNarInfo creates the narinfo file of the store path signed with the key of the owner
+func BuildNarInfo(ctx context.Context, pd *packages_model.PackageDescriptor) (string, error) {
+	priv, _, err := GetOrCreateKeyPair(ctx, pd.Owner)
+	if err != nil {
+		return "", err
+	}
+
+	metadata := packages_model.DescriptorMetadata[*nix_module.Metadata](pd)
+
+	signature, err := nix_module.Sign(priv, metadata.Fingerprint())
+	if err != nil {
+		return "", err
+	}
+
+	var filename string
+	if len(pd.Files) > 0 {
+		filename = pd.Files[0].File.Name
+	}
+
+	return metadata.FormatNarInfo("nar/"+filename, append([]string{signature}, metadata.Signatures...)), nil
+}
+
+// GetNarFile gets the nar file with the specific name which belongs to a store path
+func GetNarFile(ctx context.Context, ownerID int64, filename string) (*packages_model.PackageFile, error) {
+	pfs, _, err := packages_model.SearchFiles(ctx, &packages_model.PackageFileSearchOptions{
+		OwnerID:     ownerID,
+		PackageType: packages_model.TypeNix,
+		Query:       filename,
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, pf := range pfs {
+		if pf.Name == filename {
+			return pf, nil
+		}
+	}
+	return nil, packages_model.ErrPackageFileNotExist
+}
+
+// CreateStorePath creates the package version of the store path described by the uploaded narinfo file.
+// The referenced nar file must have been uploaded before and is moved from the upload version to the store path.
+func CreateStorePath(ctx context.Context, doer, owner *user_model.User, pck *nix_module.Package) (*packages_model.PackageVersion, error) {
+	uploadVersion, err := GetOrCreateUploadVersion(ctx, owner.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	// a nar file may be shared by multiple store paths with the same content
+	uploadFile, err := packages_model.GetFileForVersionByName(ctx, uploadVersion.ID, pck.NarFilename, packages_model.EmptyFileKey)
+	if err != nil && !errors.Is(err, util.ErrNotExist) {
+		return nil, err
+	}
+	narFile := uploadFile
+	if narFile == nil {
+		if narFile, err = GetNarFile(ctx, owner.ID, pck.NarFilename); err != nil {
+			if errors.Is(err, util.ErrNotExist) {
+				return nil, ErrNarFileNotUploaded
+			}
+			return nil, err
+		}
+	}
+
+	pb, err := packages_model.GetBlobByID(ctx, narFile.BlobID)
+	if err != nil {
+		return nil, err
+	}
+
+	metadata := pck.Metadata
+	if metadata.FileHash != "" {
+		digest, err := nix_module.ParseHash(metadata.FileHash)
+		if err != nil {
+			return nil, err
+		}
+		if hex.EncodeToString(digest) != pb.HashSHA256 {
+			return nil, ErrNarFileMismatch
+		}
+	}
+	if metadata.FileSize != 0 && metadata.FileSize != pb.Size {
+		return nil, ErrNarFileMismatch
+	}
+	metadata.FileSize = pb.Size
+
+	s, err := packages_service.OpenBlobStream(pb)
+	if err != nil {
+		return nil, err
+	}
+	defer s.Close()
+
+	buf, err := packages_module.CreateHashedBufferFromReader(s)
+	if err != nil {
+		return nil, err
+	}
+	defer buf.Close()
+
+	pv, _, err := packages_service.CreatePackageAndAddFile(
+		ctx,
+		&packages_service.PackageCreationInfo{
+			PackageInfo: packages_service.PackageInfo{
+				Owner:       owner,
+				PackageType: packages_model.TypeNix,
+				Name:        pck.Name,
+				Version:     pck.Hash,
+			},
+			Creator:  doer,
+			Metadata: metadata,
+		},
+		&packages_service.PackageFileCreationInfo{
+			PackageFileInfo: packages_service.PackageFileInfo{
+				Filename: pck.NarFilename,
+			},
+			Creator: doer,
+			Data:    buf,
+			IsLead:  true,
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if uploadFile != nil {
+		if err := packages_service.DeletePackageFile(ctx, uploadFile); err != nil {
+			return nil, err
+		}
+	}
+
+	return pv, nil
+}
+
+// Cleanup removes uploaded nar files which were never referenced by a narinfo file
+func Cleanup(ctx context.Context, olderThan time.Duration) error {
+	pvs, _, err := packages_model.SearchVersions(ctx, &packages_model.PackageSearchOptions{
+		Type: packages_model.TypeNix,
+		Version: packages_model.SearchValue{
+			ExactMatch: true,
+			Value:      nix_module.UploadVersion,
+		},
+		IsInternal: optional.Some(true),
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, pv := range pvs {
+		pfs, _, err := packages_model.SearchFiles(ctx, &packages_model.PackageFileSearchOptions{
+			VersionID: pv.ID,
+			OlderThan: olderThan,
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, pf := range pfs {
+			if err := packages_service.DeletePackageFile(ctx, pf); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
@@ -16,6 +16,7 @@ import (
 
 	"gitea.dev/models/db"
 	packages_model "gitea.dev/models/packages"
+	quota_model "gitea.dev/models/quota"
 	repo_model "gitea.dev/models/repo"
 	user_model "gitea.dev/models/user"
 	"gitea.dev/modules/globallock"
@@ -25,7 +26,9 @@ import (
 	packages_module "gitea.dev/modules/packages"
 	"gitea.dev/modules/setting"
 	"gitea.dev/modules/storage"
+	"gitea.dev/modules/util"
 	notify_service "gitea.dev/services/notify"
+	quota_service "gitea.dev/services/quota"
 )
 
 var (
@@ -122,6 +125,8 @@ func createPackageAndAddFile(ctx context.Context, pvci *PackageCreationInfo, pfc
 		return nil, nil, err
 	}
 
+	quota_service.UpdateUsage(ctx, pvci.Owner.ID, quota_model.CategoryPackages)
+
 	if created {
 		pd, err := packages_model.GetPackageDescriptor(ctx, pv)
 		if err != nil {
@@ -203,7 +208,7 @@ func createPackageAndVersion(ctx context.Context, pvci *PackageCreationInfo, all
 
 // AddFileToExistingPackage adds a file to an existing package. If the package does not exist, ErrPackageNotExist is returned
 func AddFileToExistingPackage(ctx context.Context, pvi *PackageInfo, pfci *PackageFileCreationInfo) (*packages_model.PackageFile, error) {
-	return addFileToPackageWrapper(ctx, func(ctx context.Context) (*packages_model.PackageFile, *packages_model.PackageBlob, bool, error) {
+	pf, err := addFileToPackageWrapper(ctx, func(ctx context.Context) (*packages_model.PackageFile, *packages_model.PackageBlob, bool, error) {
 		pv, err := packages_model.GetVersionByNameAndVersion(ctx, pvi.Owner.ID, pvi.PackageType, pvi.Name, pvi.Version)
 		if err != nil {
 			return nil, nil, false, err
@@ -211,6 +216,13 @@ func AddFileToExistingPackage(ctx context.Context, pvi *PackageInfo, pfci *Packa
 
 		return addFileToPackageVersion(ctx, pv, pvi, pfci)
 	})
+	if err != nil {
+		return nil, err
+	}
+
+	quota_service.UpdateUsage(ctx, pvi.Owner.ID, quota_model.CategoryPackages)
+
+	return pf, nil
 }
 
 // AddFileToPackageVersionInternal adds a file to the package
@@ -302,6 +314,9 @@ func addFileToPackageVersionUnchecked(ctx context.Context, pv *packages_model.Pa
 			if err := packages_model.DeleteAllProperties(ctx, packages_model.PropertyTypeFile, pf.ID); err != nil {
 				return nil, pb, !exists, err
 			}
+			if err := packages_model.DeleteAttestationsByFileID(ctx, pf.ID); err != nil {
+				return nil, pb, !exists, err
+			}
 			if err := packages_model.DeleteFileByID(ctx, pf.ID); err != nil {
 				return nil, pb, !exists, err
 			}
@@ -368,6 +383,8 @@ func CheckSizeQuotaExceeded(ctx context.Context, doer, owner *user_model.User, p
 	switch packageType {
 	case packages_model.TypeAlpine:
 		typeSpecificSize = setting.Packages.LimitSizeAlpine
+	case packages_model.TypeAnsible:
+		typeSpecificSize = setting.Packages.LimitSizeAnsible
 	case packages_model.TypeArch:
 		typeSpecificSize = setting.Packages.LimitSizeArch
 	case packages_model.TypeCargo:
@@ -392,8 +409,12 @@ func CheckSizeQuotaExceeded(ctx context.Context, doer, owner *user_model.User, p
 		typeSpecificSize = setting.Packages.LimitSizeGo
 	case packages_model.TypeHelm:
 		typeSpecificSize = setting.Packages.LimitSizeHelm
+	case packages_model.TypeHex:
+		typeSpecificSize = setting.Packages.LimitSizeHex
 	case packages_model.TypeMaven:
 		typeSpecificSize = setting.Packages.LimitSizeMaven
+	case packages_model.TypeNix:
+		typeSpecificSize = setting.Packages.LimitSizeNix
 	case packages_model.TypeNpm:
 		typeSpecificSize = setting.Packages.LimitSizeNpm
 	case packages_model.TypeNuGet:
@@ -430,6 +451,14 @@ func CheckSizeQuotaExceeded(ctx context.Context, doer, owner *user_model.User, p
 		}
 	}
 
+	if err := quota_service.CheckQuota(ctx, owner.ID, quota_model.CategoryPackages, uploadSize); err != nil {
+		if errors.Is(err, util.ErrContentTooLarge) {
+			return ErrQuotaTotalSize
+		}
+		log.Error("CheckQuota failed: %v", err)
+		return err
+	}
+
 	return nil
 }
 
@@ -501,6 +530,8 @@ func RemovePackageVersion(ctx context.Context, doer *user_model.User, pv *packag
 		return err
 	}
 
+	quota_service.UpdateUsage(ctx, pd.Owner.ID, quota_model.CategoryPackages)
+
 	notify_service.PackageDelete(ctx, doer, pd)
 
 	return nil
@@ -508,6 +539,15 @@ func RemovePackageVersion(ctx context.Context, doer *user_model.User, pv *packag
 
 // RemovePackageFileAndVersionIfUnreferenced deletes the package file and the version if there are no referenced files afterwards
 func RemovePackageFileAndVersionIfUnreferenced(ctx context.Context, doer *user_model.User, pf *packages_model.PackageFile) error {
+	pv, err := packages_model.GetVersionByID(ctx, pf.VersionID)
+	if err != nil {
+		return err
+	}
+	p, err := packages_model.GetPackageByID(ctx, pv.PackageID)
+	if err != nil {
+		return err
+	}
+
 	var pd *packages_model.PackageDescriptor
 
 	if err := db.WithTx(ctx, func(ctx context.Context) error {
@@ -520,11 +560,6 @@ func RemovePackageFileAndVersionIfUnreferenced(ctx context.Context, doer *user_m
 			return err
 		}
 		if !has {
-			pv, err := packages_model.GetVersionByID(ctx, pf.VersionID)
-			if err != nil {
-				return err
-			}
-
 			pd, err = packages_model.GetPackageDescriptor(ctx, pv)
 			if err != nil {
 				return err
@@ -540,6 +575,8 @@ func RemovePackageFileAndVersionIfUnreferenced(ctx context.Context, doer *user_m
 		return err
 	}
 
+	quota_service.UpdateUsage(ctx, p.OwnerID, quota_model.CategoryPackages)
+
 	if pd != nil {
 		notify_service.PackageDelete(ctx, doer, pd)
 	}
@@ -547,7 +584,7 @@ func RemovePackageFileAndVersionIfUnreferenced(ctx context.Context, doer *user_m
 	return nil
 }
 
-// DeletePackageVersionAndReferences deletes the package version and its properties and files
+// DeletePackageVersionAndReferences deletes the package version and its properties, attestations and files
 func DeletePackageVersionAndReferences(ctx context.Context, pv *packages_model.PackageVersion) error {
 	if err := packages_model.DeleteAllProperties(ctx, packages_model.PropertyTypeVersion, pv.ID); err != nil {
 		return err
@@ -555,6 +592,9 @@ func DeletePackageVersionAndReferences(ctx context.Context, pv *packages_model.P
 	if err := packages_model.DeleteFilePropertiesByVersionID(ctx, pv.ID); err != nil {
 		return err
 	}
+	if err := packages_model.DeleteAttestationsByVersionID(ctx, pv.ID); err != nil {
+		return err
+	}
 	if err := packages_model.DeleteFilesByVersionID(ctx, pv.ID); err != nil {
 		return err
 	}
@@ -562,11 +602,14 @@ func DeletePackageVersionAndReferences(ctx context.Context, pv *packages_model.P
 	return packages_model.DeleteVersionByID(ctx, pv.ID)
 }
 
-// DeletePackageFile deletes the package file and its properties
+// DeletePackageFile deletes the package file and its properties and attestations
 func DeletePackageFile(ctx context.Context, pf *packages_model.PackageFile) error {
 	if err := packages_model.DeleteAllProperties(ctx, packages_model.PropertyTypeFile, pf.ID); err != nil {
 		return err
 	}
+	if err := packages_model.DeleteAttestationsByFileID(ctx, pf.ID); err != nil {
+		return err
+	}
 	return packages_model.DeleteFileByID(ctx, pf.ID)
 }
 
@@ -668,6 +711,10 @@ func RemovePackage(ctx context.Context, doer *user_model.User, p *packages_model
 		if err != nil {
 			return err
 		}
+		err = packages_model.DeleteAttestationsByPackageID(ctx, p.ID)
+		if err != nil {
+			return err
+		}
 		err = packages_model.DeleteFilesByPackageID(ctx, p.ID)
 		if err != nil {
 			return err
@@ -682,6 +729,9 @@ func RemovePackage(ctx context.Context, doer *user_model.User, p *packages_model
 	if err != nil {
 		return err
 	}
+
+	quota_service.UpdateUsage(ctx, p.OwnerID, quota_model.CategoryPackages)
+
 	for _, pd := range pds {
 		notify_service.PackageDelete(ctx, doer, pd)
 	}
@@ -0,0 +1,248 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package remote
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	packages_model "gitea.dev/models/packages"
+	"gitea.dev/modules/hostmatcher"
+	"gitea.dev/modules/json"
+	"gitea.dev/modules/proxy"
+	"gitea.dev/modules/setting"
+	"gitea.dev/modules/util"
+)
+
+// ErrUpstreamNotFound is returned if the upstream registry does not know the requested resource
+var ErrUpstreamNotFound = util.NewNotExistErrorf("resource does not exist in the upstream registry")
+
+// ErrUpstreamStatus is returned if the upstream registry responds with an unexpected status
+type ErrUpstreamStatus struct {
+	URL    string
+	Status int
+}
+
+func (e ErrUpstreamStatus) Error() string {
+	return fmt.Sprintf("upstream registry returned status %d for %s", e.Status, e.URL)
+}
+
+// remoteHTTPClient is shared by all package remotes so connections to the same upstream are reused.
+// The allow-list is enforced by the transport on every dial, redirects included.
+var remoteHTTPClient = util.OnceValue[*http.Client]{Func: newRemoteHTTPClient}
+
+func newRemoteHTTPClient() *http.Client {
+	allowList := hostmatcher.ParseHostMatchList("packages.REMOTE_ALLOWED_HOST_LIST", setting.Packages.RemoteAllowedHostList)
+	return &http.Client{
+		Timeout:   10 * time.Minute,
+		Transport: hostmatcher.NewHTTPTransport("package remote", allowList, nil, proxy.Proxy(), setting.Proxy.ProxyURLFixed, nil),
+	}
+}
+
+// Client performs requests against the upstream registry of a package remote
+type Client struct {
+	baseURL  *url.URL
+	username string
+	password string
+
+	mu    sync.Mutex
+	token string
+}
+
+// NewClient creates a client for the upstream registry of the remote
+func NewClient(pr *packages_model.PackageRemote) (*Client, error) {
+	u, err := url.Parse(strings.TrimSuffix(pr.URL, "/") + "/")
+	if err != nil {
+		return nil, err
+	}
+	password, err := pr.Password()
+	if err != nil {
+		return nil, err
+	}
+	return &Client{
+		baseURL:  u,
+		username: pr.Username,
+		password: password,
+	}, nil
+}
+
+// ResolveURL resolves a path relative to the upstream base URL. Absolute URLs are returned unchanged.
+func (c *Client) ResolveURL(p string) string {
+	ref, err := url.Parse(p)
+	if err != nil {
+		return c.baseURL.String() + strings.TrimPrefix(p, "/")
+	}
+	if ref.IsAbs() {
+		return ref.String()
+	}
+	ref.Path = strings.TrimPrefix(ref.Path, "/")
+	return c.baseURL.ResolveReference(ref).String()
+}
+
+// Do sends a request to the upstream registry. Credentials are only sent to the host of the base URL.
+// If the upstream answers with a bearer token challenge the token is fetched and the request is retried.
+// Non-2xx responses (except 304) are turned into errors and the body is closed.
+func (c *Client) Do(ctx context.Context, method, p string, header http.Header) (*http.Response, error) {
+	target := c.ResolveURL(p)
+
+	resp, err := c.do(ctx, method, target, header)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		challenge := resp.Header.Get("WWW-Authenticate")
+		resp.Body.Close()
+
+		if !strings.HasPrefix(strings.ToLower(challenge), "bearer ") {
+			return nil, ErrUpstreamStatus{URL: target, Status: http.StatusUnauthorized}
+		}
+		if err := c.fetchToken(ctx, challenge); err != nil {
+			return nil, err
+		}
+		if resp, err = c.do(ctx, method, target, header); err != nil {
+			return nil, err
+		}
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		resp.Body.Close()
+		return nil, ErrUpstreamNotFound
+	case resp.StatusCode == http.StatusNotModified:
+	case resp.StatusCode < 200 || resp.StatusCode > 299:
+		resp.Body.Close()
+		return nil, ErrUpstreamStatus{URL: target, Status: resp.StatusCode}
+	}
+	return resp, nil
+}
+
+func (c *Client) do(ctx context.Context, method, target string, header http.Header) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, target, nil)
+	if err != nil {
+		return nil, err
+	}
+	for k, vs := range header {
+		for _, v := range vs {
+			req.Header.Add(k, v)
+		}
+	}
+	req.Header.Set("User-Agent", "Gitea "+setting.AppVer)
+
+	if req.URL.Host == c.baseURL.Host {
+		c.mu.Lock()
+		token := c.token
+		c.mu.Unlock()
+
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		} else if c.username != "" || c.password != "" {
+			req.SetBasicAuth(c.username, c.password)
+		}
+	}
+
+	return remoteHTTPClient.Value().Do(req)
+}
+
+// fetchToken handles a "Bearer realm=...,service=...,scope=..." challenge as used by container registries.
+// The credentials are only sent if the realm is on the host of the base URL, the token is requested anonymously otherwise.
+func (c *Client) fetchToken(ctx context.Context, challenge string) error {
+	params := ParseAuthChallenge(challenge)
+	realm := params["realm"]
+	if realm == "" {
+		return ErrUpstreamStatus{URL: c.baseURL.String(), Status: http.StatusUnauthorized}
+	}
+
+	u, err := url.Parse(realm)
+	if err != nil {
+		return err
+	}
+	q := u.Query()
+	if service := params["service"]; service != "" {
+		q.Set("service", service)
+	}
+	if scope := params["scope"]; scope != "" {
+		q.Set("scope", scope)
+	}
+	u.RawQuery = q.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return err
+	}
+	if u.Host == c.baseURL.Host && (c.username != "" || c.password != "") {
+		req.SetBasicAuth(c.username, c.password)
+	}
+
+	resp, err := remoteHTTPClient.Value().Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return ErrUpstreamStatus{URL: realm, Status: resp.StatusCode}
+	}
+
+	var result struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&result); err != nil {
+		return err
+	}
+
+	token := result.Token
+	if token == "" {
+		token = result.AccessToken
+	}
+	if token == "" {
+		return ErrUpstreamStatus{URL: realm, Status: http.StatusUnauthorized}
+	}
+
+	c.mu.Lock()
+	c.token = token
+	c.mu.Unlock()
+	return nil
+}
+
+// ParseAuthChallenge parses the parameters of a WWW-Authenticate header value
+func ParseAuthChallenge(challenge string) map[string]string {
+	params := make(map[string]string)
+
+	_, rest, ok := strings.Cut(strings.TrimSpace(challenge), " ")
+	if !ok {
+		return params
+	}
+
+	for rest != "" {
+		rest = strings.TrimLeft(rest, " ,")
+		key, value, ok := strings.Cut(rest, "=")
+		if !ok {
+			break
+		}
+		key = strings.ToLower(strings.TrimSpace(key))
+
+		if strings.HasPrefix(value, `"`) {
+			end := strings.Index(value[1:], `"`)
+			if end == -1 {
+				params[key] = value[1:]
+				break
+			}
+			params[key] = value[1 : end+1]
+			rest = value[end+2:]
+		} else {
+			v, r, _ := strings.Cut(value, ",")
+			params[key] = strings.TrimSpace(v)
+			rest = r
+		}
+	}
+	return params
+}
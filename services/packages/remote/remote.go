@@ -0,0 +1,301 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package remote
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"gitea.dev/models/db"
+	packages_model "gitea.dev/models/packages"
+	user_model "gitea.dev/models/user"
+	"gitea.dev/modules/log"
+	packages_module "gitea.dev/modules/packages"
+	"gitea.dev/modules/timeutil"
+	packages_service "gitea.dev/services/packages"
+)
+
+const (
+	// MetadataPackage is the internal package which holds the cached upstream metadata of a remote
+	MetadataPackage = "_remote"
+	// MetadataVersion is the internal version which holds the cached upstream metadata of a remote
+	MetadataVersion = "_metadata"
+
+	// PropertyURL stores the upstream location a version or file was pulled from
+	PropertyURL = "remote.url"
+	// PropertyETag stores the upstream entity tag of cached metadata
+	PropertyETag = "remote.etag"
+	// PropertyFetched stores the unix time a version, file or cached metadata was fetched
+	PropertyFetched = "remote.fetched"
+
+	// maxMetadataSize limits the size of upstream metadata documents which are cached
+	maxMetadataSize = 64 * 1024 * 1024
+)
+
+// Remote combines the remote configuration of an owner with a client for its upstream registry
+type Remote struct {
+	Owner  *user_model.User
+	Config *packages_model.PackageRemote
+	Client *Client
+}
+
+// New creates a remote for the owner
+func New(owner *user_model.User, pr *packages_model.PackageRemote) (*Remote, error) {
+	c, err := NewClient(pr)
+	if err != nil {
+		return nil, err
+	}
+	return &Remote{
+		Owner:  owner,
+		Config: pr,
+		Client: c,
+	}, nil
+}
+
+// FetchMetadata returns the upstream document at the path.
+// A cached copy younger than the remote TTL is served without contacting the upstream registry.
+// Older copies are revalidated and served if the upstream registry is not reachable.
+func (r *Remote) FetchMetadata(ctx context.Context, p string, header http.Header) ([]byte, error) {
+	target := r.Client.ResolveURL(p)
+	filename := metadataFilename(target, header.Get("Accept"))
+
+	pv, err := packages_service.GetOrCreateInternalPackageVersion(ctx, r.Owner.ID, r.Config.Type, MetadataPackage, MetadataVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	cached, props, err := readCachedMetadata(ctx, pv, filename)
+	if err != nil && !errors.Is(err, packages_model.ErrPackageFileNotExist) {
+		return nil, err
+	}
+
+	if cached != nil {
+		fetched, _ := strconv.ParseInt(props[PropertyFetched], 10, 64)
+		if time.Since(time.Unix(fetched, 0)) < r.Config.TTL() {
+			return cached.data, nil
+		}
+	}
+
+	reqHeader := header.Clone()
+	if reqHeader == nil {
+		reqHeader = http.Header{}
+	}
+	if cached != nil && props[PropertyETag] != "" {
+		reqHeader.Set("If-None-Match", props[PropertyETag])
+	}
+
+	resp, err := r.Client.Do(ctx, http.MethodGet, target, reqHeader)
+	if err != nil {
+		if cached != nil && !errors.Is(err, ErrUpstreamNotFound) {
+			log.Warn("Serving stale metadata for %s: %v", target, err)
+			return cached.data, nil
+		}
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotModified && cached != nil {
+		if err := packages_model.InsertOrUpdateProperty(ctx, packages_model.PropertyTypeFile, cached.file.ID, PropertyFetched, strconv.FormatInt(int64(timeutil.TimeStampNow()), 10)); err != nil {
+			return nil, err
+		}
+		return cached.data, nil
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxMetadataSize))
+	if err != nil {
+		if cached != nil {
+			log.Warn("Serving stale metadata for %s: %v", target, err)
+			return cached.data, nil
+		}
+		return nil, err
+	}
+
+	if err := storeMetadata(ctx, pv, filename, target, resp.Header.Get("ETag"), data); err != nil {
+		log.Error("Failed to cache metadata for %s: %v", target, err)
+	}
+
+	return data, nil
+}
+
+type cachedMetadata struct {
+	file *packages_model.PackageFile
+	data []byte
+}
+
+func metadataFilename(target, accept string) string {
+	h := sha256.Sum256([]byte(accept + "\n" + target))
+	return hex.EncodeToString(h[:])
+}
+
+func readCachedMetadata(ctx context.Context, pv *packages_model.PackageVersion, filename string) (*cachedMetadata, map[string]string, error) {
+	pf, err := packages_model.GetFileForVersionByName(ctx, pv.ID, filename, "")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	pps, err := packages_model.GetProperties(ctx, packages_model.PropertyTypeFile, pf.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	props := make(map[string]string, len(pps))
+	for _, pp := range pps {
+		props[pp.Name] = pp.Value
+	}
+
+	pb, err := packages_model.GetBlobByID(ctx, pf.BlobID)
+	if err != nil {
+		return nil, nil, err
+	}
+	s, err := packages_service.OpenBlobStream(pb)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer s.Close()
+
+	data, err := io.ReadAll(s)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &cachedMetadata{file: pf, data: data}, props, nil
+}
+
+func storeMetadata(ctx context.Context, pv *packages_model.PackageVersion, filename, target, etag string, data []byte) error {
+	buf, err := packages_module.CreateHashedBufferFromReader(bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	defer buf.Close()
+
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		pf, err := packages_service.AddFileToPackageVersionInternal(ctx, pv, &packages_service.PackageFileCreationInfo{
+			PackageFileInfo: packages_service.PackageFileInfo{
+				Filename: filename,
+			},
+			Creator:           user_model.NewGhostUser(),
+			Data:              buf,
+			OverwriteExisting: true,
+		})
+		if err != nil {
+			return err
+		}
+		return setProperties(ctx, packages_model.PropertyTypeFile, pf.ID, map[string]string{
+			PropertyURL:     target,
+			PropertyETag:    etag,
+			PropertyFetched: strconv.FormatInt(int64(timeutil.TimeStampNow()), 10),
+		})
+	})
+}
+
+// FetchFile downloads a file from the upstream registry. The caller must close the returned buffer.
+func (r *Remote) FetchFile(ctx context.Context, p string, header http.Header) (*packages_module.HashedBuffer, string, error) {
+	target := r.Client.ResolveURL(p)
+
+	resp, err := r.Client.Do(ctx, http.MethodGet, target, header)
+	if err != nil {
+		return nil, target, err
+	}
+	defer resp.Body.Close()
+
+	buf, err := packages_module.CreateHashedBufferFromReader(resp.Body)
+	if err != nil {
+		return nil, target, err
+	}
+	return buf, target, nil
+}
+
+// StoreFile stores a file pulled from the upstream registry and records where it came from.
+// The owner is used as creator so the package quota of the owner applies to pulled files.
+func (r *Remote) StoreFile(ctx context.Context, pvci *packages_service.PackageCreationInfo, pfci *packages_service.PackageFileCreationInfo, sourceURL string) (*packages_model.PackageVersion, *packages_model.PackageFile, error) {
+	pvci.Owner = r.Owner
+	pvci.PackageType = r.Config.Type
+	pvci.Creator = r.Owner
+	pfci.Creator = r.Owner
+
+	fetched := strconv.FormatInt(int64(timeutil.TimeStampNow()), 10)
+
+	if pvci.VersionProperties == nil {
+		pvci.VersionProperties = make(map[string]string, 2)
+	}
+	pvci.VersionProperties[PropertyURL] = r.Config.URL
+	pvci.VersionProperties[PropertyFetched] = fetched
+
+	if pfci.Properties == nil {
+		pfci.Properties = make(map[string]string, 2)
+	}
+	pfci.Properties[PropertyURL] = sourceURL
+	pfci.Properties[PropertyFetched] = fetched
+
+	pv, pf, err := packages_service.CreatePackageOrAddFileToExisting(ctx, pvci, pfci)
+	if errors.Is(err, packages_model.ErrDuplicatePackageFile) {
+		// another request pulled the same file in the meantime
+		pv, err = packages_model.GetVersionByNameAndVersion(ctx, r.Owner.ID, r.Config.Type, pvci.Name, pvci.Version)
+		if err != nil {
+			return nil, nil, err
+		}
+		pf, err = packages_model.GetFileForVersionByName(ctx, pv.ID, pfci.Filename, pfci.CompositeKey)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	return pv, pf, nil
+}
+
+// RecordFileProvenance records where a file stored outside of StoreFile was pulled from
+func RecordFileProvenance(ctx context.Context, pf *packages_model.PackageFile, sourceURL string) error {
+	return setProperties(ctx, packages_model.PropertyTypeFile, pf.ID, map[string]string{
+		PropertyURL:     sourceURL,
+		PropertyFetched: strconv.FormatInt(int64(timeutil.TimeStampNow()), 10),
+	})
+}
+
+// UpdateRemote updates the remote configuration.
+// The cached upstream metadata is dropped if the upstream registry changed.
+func UpdateRemote(ctx context.Context, pr *packages_model.PackageRemote, upstreamChanged bool) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		if upstreamChanged {
+			if err := deleteMetadataCache(ctx, pr); err != nil {
+				return err
+			}
+		}
+		return packages_model.UpdateRemote(ctx, pr)
+	})
+}
+
+// DeleteRemote deletes the remote configuration and the cached upstream metadata.
+// Packages which were pulled from the upstream registry are kept.
+func DeleteRemote(ctx context.Context, pr *packages_model.PackageRemote) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		if err := deleteMetadataCache(ctx, pr); err != nil {
+			return err
+		}
+		return packages_model.DeleteRemoteByID(ctx, pr.ID)
+	})
+}
+
+func deleteMetadataCache(ctx context.Context, pr *packages_model.PackageRemote) error {
+	pv, err := packages_model.GetInternalVersionByNameAndVersion(ctx, pr.OwnerID, pr.Type, MetadataPackage, MetadataVersion)
+	if err != nil {
+		if errors.Is(err, packages_model.ErrPackageNotExist) {
+			return nil
+		}
+		return err
+	}
+	return packages_service.DeletePackageVersionAndReferences(ctx, pv)
+}
+
+func setProperties(ctx context.Context, refType packages_model.PropertyType, refID int64, props map[string]string) error {
+	for name, value := range props {
+		if err := packages_model.InsertOrUpdateProperty(ctx, refType, refID, name, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -171,7 +171,10 @@ func LoadIssuesAssigneesForProject(ctx context.Context, projectID int64) (users
 func LoadIssuesFromProject(ctx context.Context, project *project_model.Project, opts *issues_model.IssuesOptions) (results map[int64]issues_model.IssueList, _ error) {
 	issueList, err := issues_model.Issues(ctx, opts.Copy(func(o *issues_model.IssuesOptions) {
 		o.ProjectIDs = []int64{project.ID}
-		o.SortType = "project-column-sorting"
+		// the cards of the columns can be sorted by a custom field, otherwise they are in their column order
+		if _, _, ok := issues_model.ParseCustomFieldSortType(o.SortType); !ok {
+			o.SortType = "project-column-sorting"
+		}
 	}))
 	if err != nil {
 		return nil, err
@@ -0,0 +1,261 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package project
+
+import (
+	"cmp"
+	"context"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"gitea.dev/models/db"
+	issues_model "gitea.dev/models/issues"
+	project_model "gitea.dev/models/project"
+	"gitea.dev/modules/timeutil"
+)
+
+// ViewGroup is a group of the items of a view, the items having no value for the grouping share the group with an empty key
+type ViewGroup struct {
+	Key   string
+	Title string
+
+	// rank orders the groups, by their title when it is equal
+	rank float64
+}
+
+// ViewItem is an issue of a project as shown by a view
+type ViewItem struct {
+	Issue    *issues_model.Issue
+	ColumnID int64
+	// Sorting is the position of the issue in its column
+	Sorting int64
+	Values  []*project_model.IssueFieldValue
+	// Group is nil when the view isn't grouped
+	Group *ViewGroup
+	// StartDate and EndDate place the item on a roadmap, they are zero when it has no date
+	StartDate timeutil.TimeStamp
+	EndDate   timeutil.TimeStamp
+}
+
+// Value returns the value of a field for the item, nil if it has none
+func (item *ViewItem) Value(fieldID int64) *project_model.IssueFieldValue {
+	for _, v := range item.Values {
+		if v.FieldID == fieldID {
+			return v
+		}
+	}
+	return nil
+}
+
+// viewContext holds what is needed to arrange the items of a view
+type viewContext struct {
+	view       *project_model.View
+	columns    project_model.ColumnList
+	defaultCol int64
+	fields     map[int64]*project_model.Field
+	iterations map[int64]*project_model.Iteration
+}
+
+// ArrangeViewItems places the issues of a project as the view shows them: grouped, sorted within their groups,
+// and dated for a roadmap. The issues must all be in the project of the view.
+func ArrangeViewItems(ctx context.Context, project *project_model.Project, view *project_model.View, issues issues_model.IssueList) ([]*ViewItem, error) {
+	vc := &viewContext{view: view}
+	var err error
+	if vc.columns, err = project_model.GetColumns(ctx, project.ID, db.ListOptionsAll); err != nil {
+		return nil, err
+	}
+	for _, column := range vc.columns {
+		if column.Default {
+			vc.defaultCol = column.ID
+		}
+	}
+	fields, err := project_model.GetProjectFields(ctx, project.ID)
+	if err != nil {
+		return nil, err
+	}
+	vc.fields = make(map[int64]*project_model.Field, len(fields))
+	var iterationFieldIDs []int64
+	for _, f := range fields {
+		vc.fields[f.ID] = f
+		if f.Type == project_model.FieldTypeIteration {
+			iterationFieldIDs = append(iterationFieldIDs, f.ID)
+		}
+	}
+	iterations, err := project_model.GetFieldIterations(ctx, iterationFieldIDs...)
+	if err != nil {
+		return nil, err
+	}
+	vc.iterations = make(map[int64]*project_model.Iteration, len(iterations))
+	for _, it := range iterations {
+		vc.iterations[it.ID] = it
+	}
+
+	switch view.GroupBy {
+	case project_model.ViewGroupByMilestone:
+		if err := issues.LoadMilestones(ctx); err != nil {
+			return nil, err
+		}
+	case project_model.ViewGroupByRepository:
+		if _, err := issues.LoadRepositories(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	issueIDs := make([]int64, 0, len(issues))
+	for _, issue := range issues {
+		issueIDs = append(issueIDs, issue.ID)
+	}
+	projectIssues, err := project_model.GetProjectIssuesMap(ctx, project.ID, issueIDs)
+	if err != nil {
+		return nil, err
+	}
+	values, err := project_model.GetIssueFieldValues(ctx, project.ID, issueIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]*ViewItem, 0, len(issues))
+	for _, issue := range issues {
+		item := &ViewItem{Issue: issue, Values: values[issue.ID]}
+		if pi, ok := projectIssues[issue.ID]; ok {
+			// the rows written before 1.22 have no column, they are in the default column
+			item.ColumnID, item.Sorting = cmp.Or(pi.ProjectColumnID, vc.defaultCol), pi.Sorting
+		}
+		item.Group = vc.group(item)
+		item.StartDate, item.EndDate = vc.dates(item)
+		items = append(items, item)
+	}
+	slices.SortStableFunc(items, vc.compare)
+	return items, nil
+}
+
+func (vc *viewContext) group(item *ViewItem) *ViewGroup {
+	switch vc.view.GroupBy {
+	case project_model.ViewGroupByNone:
+		return nil
+	case project_model.ViewGroupByColumn:
+		idx := slices.IndexFunc(vc.columns, func(c *project_model.Column) bool { return c.ID == item.ColumnID })
+		if idx < 0 {
+			return &ViewGroup{}
+		}
+		return &ViewGroup{Key: strconv.FormatInt(item.ColumnID, 10), Title: vc.columns[idx].Title, rank: float64(idx)}
+	case project_model.ViewGroupByMilestone:
+		if item.Issue.Milestone == nil {
+			return &ViewGroup{}
+		}
+		// the milestones due first come first, the ones without deadline last
+		rank := float64(item.Issue.Milestone.DeadlineUnix)
+		if item.Issue.Milestone.DeadlineUnix == 0 {
+			rank = math.Inf(1)
+		}
+		return &ViewGroup{Key: strconv.FormatInt(item.Issue.MilestoneID, 10), Title: item.Issue.Milestone.Name, rank: rank}
+	case project_model.ViewGroupByRepository:
+		if item.Issue.Repo == nil {
+			return &ViewGroup{}
+		}
+		return &ViewGroup{Key: strconv.FormatInt(item.Issue.RepoID, 10), Title: item.Issue.Repo.FullName()}
+	}
+
+	fieldID, _ := project_model.ParseViewFieldKey(vc.view.GroupBy)
+	field, value := vc.fields[fieldID], item.Value(fieldID)
+	if field == nil || value == nil {
+		return &ViewGroup{}
+	}
+	group := &ViewGroup{Key: value.Value, Title: value.Value}
+	switch field.Type {
+	case project_model.FieldTypeText:
+	case project_model.FieldTypeIteration:
+		if it, ok := vc.iterations[iterationID(value)]; ok {
+			group.Title, group.rank = it.Title, float64(it.StartDate)
+		}
+	default:
+		group.rank = value.Number
+	}
+	return group
+}
+
+func iterationID(value *project_model.IssueFieldValue) int64 {
+	id, _ := strconv.ParseInt(value.Value, 10, 64)
+	return id
+}
+
+func (vc *viewContext) dates(item *ViewItem) (start, end timeutil.TimeStamp) {
+	if vc.view.Layout != project_model.ViewLayoutRoadmap {
+		return 0, 0
+	}
+	if vc.view.DateFieldID == 0 {
+		return item.Issue.DeadlineUnix, item.Issue.DeadlineUnix
+	}
+	value := item.Value(vc.view.DateFieldID)
+	if value == nil {
+		return 0, 0
+	}
+	if field := vc.fields[vc.view.DateFieldID]; field != nil && field.Type == project_model.FieldTypeIteration {
+		if it, ok := vc.iterations[iterationID(value)]; ok {
+			return it.StartDate, it.EndDate()
+		}
+		return 0, 0
+	}
+	start = timeutil.TimeStamp(value.Number)
+	return start, start.AddDuration(24 * time.Hour)
+}
+
+// compareGroups orders the groups by rank and title, the items without group come last
+func compareGroups(a, b *ViewGroup) int {
+	if a == nil || b == nil {
+		return 0
+	}
+	if (a.Key == "") != (b.Key == "") {
+		return 1 - 2*boolToInt(a.Key != "")
+	}
+	return cmp.Or(cmp.Compare(a.rank, b.rank), strings.Compare(strings.ToLower(a.Title), strings.ToLower(b.Title)))
+}
+
+func boolToInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+func (vc *viewContext) compare(a, b *ViewItem) int {
+	if c := compareGroups(a.Group, b.Group); c != 0 {
+		return c
+	}
+
+	var c int
+	switch vc.view.SortBy {
+	case project_model.ViewSortByPosition:
+		aIdx := slices.IndexFunc(vc.columns, func(col *project_model.Column) bool { return col.ID == a.ColumnID })
+		bIdx := slices.IndexFunc(vc.columns, func(col *project_model.Column) bool { return col.ID == b.ColumnID })
+		// same order as the columns of the board: by sorting, then newest first
+		c = cmp.Or(cmp.Compare(aIdx, bIdx), cmp.Compare(a.Sorting, b.Sorting),
+			cmp.Compare(b.Issue.CreatedUnix, a.Issue.CreatedUnix), cmp.Compare(b.Issue.ID, a.Issue.ID))
+	case project_model.ViewSortByCreated:
+		c = cmp.Compare(a.Issue.CreatedUnix, b.Issue.CreatedUnix)
+	case project_model.ViewSortByUpdated:
+		c = cmp.Compare(a.Issue.UpdatedUnix, b.Issue.UpdatedUnix)
+	case project_model.ViewSortByTitle:
+		c = strings.Compare(strings.ToLower(a.Issue.Title), strings.ToLower(b.Issue.Title))
+	default:
+		fieldID, _ := project_model.ParseViewFieldKey(vc.view.SortBy)
+		aValue, bValue := a.Value(fieldID), b.Value(fieldID)
+		if aValue == nil || bValue == nil {
+			// the items without value come last whatever the direction
+			return boolToInt(aValue == nil) - boolToInt(bValue == nil)
+		}
+		if field := vc.fields[fieldID]; field != nil && field.Type == project_model.FieldTypeText {
+			c = strings.Compare(strings.ToLower(aValue.Value), strings.ToLower(bValue.Value))
+		} else {
+			c = cmp.Compare(aValue.Number, bValue.Number)
+		}
+	}
+	if vc.view.SortDesc {
+		return -c
+	}
+	return c
+}
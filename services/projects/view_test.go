@@ -0,0 +1,54 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package project
+
+import (
+	"slices"
+	"testing"
+
+	issues_model "gitea.dev/models/issues"
+	project_model "gitea.dev/models/project"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestViewContext_Arrange(t *testing.T) {
+	priority := &project_model.Field{ID: 1, Type: project_model.FieldTypeSingleSelect, Options: []string{"high", "low"}}
+	estimate := &project_model.Field{ID: 2, Type: project_model.FieldTypeNumber}
+	vc := &viewContext{
+		view: &project_model.View{
+			Layout:   project_model.ViewLayoutTable,
+			GroupBy:  project_model.ViewFieldKey(priority.ID),
+			SortBy:   project_model.ViewFieldKey(estimate.ID),
+			SortDesc: true,
+		},
+		columns: project_model.ColumnList{{ID: 10}, {ID: 11}},
+		fields:  map[int64]*project_model.Field{priority.ID: priority, estimate.ID: estimate},
+	}
+
+	newItem := func(id int64, values ...*project_model.IssueFieldValue) *ViewItem {
+		item := &ViewItem{Issue: &issues_model.Issue{ID: id}, Values: values}
+		item.Group = vc.group(item)
+		return item
+	}
+	high := &project_model.IssueFieldValue{FieldID: priority.ID, Value: "high", Number: 0}
+	low := &project_model.IssueFieldValue{FieldID: priority.ID, Value: "low", Number: 1}
+	items := []*ViewItem{
+		newItem(1, low),
+		newItem(2),
+		newItem(3, high, &project_model.IssueFieldValue{FieldID: estimate.ID, Value: "1", Number: 1}),
+		newItem(4, high, &project_model.IssueFieldValue{FieldID: estimate.ID, Value: "5", Number: 5}),
+		newItem(5, high),
+	}
+	slices.SortStableFunc(items, vc.compare)
+
+	ids := make([]int64, 0, len(items))
+	for _, item := range items {
+		ids = append(ids, item.Issue.ID)
+	}
+	// grouped by priority with the issues without priority last, the issues without estimate last in their group
+	assert.Equal(t, []int64{4, 3, 5, 1, 2}, ids)
+	assert.Equal(t, "high", items[0].Group.Key)
+	assert.Empty(t, items[4].Group.Key)
+}
@@ -46,6 +46,7 @@ var (
 	ErrNotMergeableState         = errors.New("not in mergeable state")
 	ErrDependenciesLeft          = errors.New("is blocked by an open dependency")
 	ErrHeadCommitsNotAllVerified = errors.New("the branch requires signed commits but not all head commits are verified")
+	ErrRulesetViolated           = errors.New("the merge does not satisfy the rulesets of the base branch")
 )
 
 func markPullRequestStatusAsChecking(ctx context.Context, pr *issues_model.PullRequest) bool {
@@ -129,6 +130,7 @@ const (
 	MergeCheckTypeGeneral  MergeCheckType = iota // general merge checks for "merge", "rebase", "squash", etc
 	MergeCheckTypeManually                       // Manually Merged button (mark a PR as merged manually)
 	MergeCheckTypeAuto                           // Auto Merge (Scheduled Merge) After Checks Succeed
+	MergeCheckTypeQueue                          // Merge Queue, the status checks run on the combined result instead of the head
 )
 
 // CheckPullMergeable check if the pull mergeable based on all conditions (branch protection, merge options, ...)
@@ -175,7 +177,7 @@ func CheckPullMergeable(stdCtx context.Context, doer *user_model.User, perm *acc
 			return ErrIsChecking
 		}
 
-		if errProtection := CheckPullBranchProtections(ctx, pr, false); errProtection != nil {
+		if errProtection := checkPullBranchProtections(ctx, pr, false, mergeCheckType == MergeCheckTypeQueue); errProtection != nil {
 			if !errors.Is(errProtection, ErrNotReadyToMerge) {
 				log.Error("Error whilst checking pull branch protection for %-v: %v", pr, errProtection)
 				return errProtection
@@ -219,6 +221,11 @@ func CheckPullMergeable(stdCtx context.Context, doer *user_model.User, perm *acc
 			return err
 		}
 
+		// rulesets apply to everyone, they cannot be skipped by a force merge
+		if err := checkPullRulesets(ctx, pr, doer, mergeStyle, mergeCheckType == MergeCheckTypeQueue); err != nil {
+			return err
+		}
+
 		if noDeps, err := issues_model.IssueNoDependenciesLeft(ctx, pr.Issue); err != nil {
 			return err
 		} else if !noDeps {
@@ -355,25 +355,8 @@ func doMergeAndPush(ctx context.Context, pr *issues_model.PullRequest, doer *use
 	defer cancel()
 
 	// Merge commits.
-	switch mergeStyle {
-	case repo_model.MergeStyleMerge:
-		if err := doMergeStyleMerge(mergeCtx, message); err != nil {
-			return "", err
-		}
-	case repo_model.MergeStyleRebase, repo_model.MergeStyleRebaseMerge:
-		if err := doMergeStyleRebase(mergeCtx, mergeStyle, message); err != nil {
-			return "", err
-		}
-	case repo_model.MergeStyleSquash:
-		if err := doMergeStyleSquash(mergeCtx, message); err != nil {
-			return "", err
-		}
-	case repo_model.MergeStyleFastForwardOnly:
-		if err := doMergeStyleFastForwardOnly(mergeCtx); err != nil {
-			return "", err
-		}
-	default:
-		return "", ErrInvalidMergeStyle{ID: pr.BaseRepo.ID, Style: mergeStyle}
+	if err := doMergeStyle(mergeCtx, mergeStyle, message); err != nil {
+		return "", err
 	}
 
 	// OK we should cache our current head and origin/headbranch
@@ -449,6 +432,22 @@ func doMergeAndPush(ctx context.Context, pr *issues_model.PullRequest, doer *use
 	return mergeCommitID, nil
 }
 
+// doMergeStyle merges the tracking branch into the base branch of the temporary repository with the given style
+func doMergeStyle(ctx *mergeContext, mergeStyle repo_model.MergeStyle, message string) error {
+	switch mergeStyle {
+	case repo_model.MergeStyleMerge:
+		return doMergeStyleMerge(ctx, message)
+	case repo_model.MergeStyleRebase, repo_model.MergeStyleRebaseMerge:
+		return doMergeStyleRebase(ctx, mergeStyle, message)
+	case repo_model.MergeStyleSquash:
+		return doMergeStyleSquash(ctx, message)
+	case repo_model.MergeStyleFastForwardOnly:
+		return doMergeStyleFastForwardOnly(ctx)
+	default:
+		return ErrInvalidMergeStyle{ID: ctx.pr.BaseRepo.ID, Style: mergeStyle}
+	}
+}
+
 func commitAndSignNoAuthor(ctx *mergeContext, message string) error {
 	cmdCommit := gitcmd.NewCommand("commit").AddOptionFormat("--message=%s", message)
 	addCommitSigningOptions(cmdCommit, ctx.signKey)
@@ -564,6 +563,11 @@ func IsUserAllowedToMerge(ctx context.Context, pr *issues_model.PullRequest, p a
 	return isUserAllowedToMergeInRepoBranch(ctx, pr.BaseRepoID, pr.BaseBranch, p, user)
 }
 
+// IsUserAllowedToMergeInBranch check if user is allowed to merge into the branch with given permissions and branch protections
+func IsUserAllowedToMergeInBranch(ctx context.Context, repoID int64, branch string, p access_model.Permission, user *user_model.User) (bool, error) {
+	return isUserAllowedToMergeInRepoBranch(ctx, repoID, branch, p, user)
+}
+
 func isUserAllowedToMergeInRepoBranch(ctx context.Context, repoID int64, branch string, p access_model.Permission, user *user_model.User) (bool, error) {
 	if user == nil {
 		return false, nil
@@ -583,6 +587,10 @@ func isUserAllowedToMergeInRepoBranch(ctx context.Context, repoID int64, branch
 
 // CheckPullBranchProtections checks whether the PR is ready to be merged (reviews and status checks)
 func CheckPullBranchProtections(ctx context.Context, pr *issues_model.PullRequest, skipProtectedFilesCheck bool) (err error) {
+	return checkPullBranchProtections(ctx, pr, skipProtectedFilesCheck, false)
+}
+
+func checkPullBranchProtections(ctx context.Context, pr *issues_model.PullRequest, skipProtectedFilesCheck, skipStatusCheck bool) (err error) {
 	if err = pr.LoadBaseRepo(ctx); err != nil {
 		return fmt.Errorf("LoadBaseRepo: %w", err)
 	}
@@ -595,12 +603,14 @@ func CheckPullBranchProtections(ctx context.Context, pr *issues_model.PullReques
 		return nil
 	}
 
-	isPass, err := IsPullCommitStatusPass(ctx, pr)
-	if err != nil {
-		return err
-	}
-	if !isPass {
-		return util.ErrorWrap(ErrNotReadyToMerge, "Not all required status checks successful")
+	if !skipStatusCheck {
+		isPass, err := IsPullCommitStatusPass(ctx, pr)
+		if err != nil {
+			return err
+		}
+		if !isPass {
+			return util.ErrorWrap(ErrNotReadyToMerge, "Not all required status checks successful")
+		}
 	}
 
 	if !issues_model.HasEnoughApprovals(ctx, pb, pr) {
@@ -61,6 +61,12 @@ func (err ErrSHADoesNotMatch) Error() string {
 }
 
 func createTemporaryRepoForMerge(ctx context.Context, pr *issues_model.PullRequest, doer *user_model.User, expectedHeadCommitID string) (mergeCtx *mergeContext, cancel context.CancelFunc, err error) {
+	return createTemporaryRepoForMergeOnto(ctx, pr, doer, expectedHeadCommitID, "")
+}
+
+// createTemporaryRepoForMergeOnto prepares a temporary repository to merge the pull request onto baseCommitID instead of its base branch,
+// the base branch is used when baseCommitID is empty
+func createTemporaryRepoForMergeOnto(ctx context.Context, pr *issues_model.PullRequest, doer *user_model.User, expectedHeadCommitID, baseCommitID string) (mergeCtx *mergeContext, cancel context.CancelFunc, err error) {
 	// Clone base repo.
 	prCtx, cancel, err := createTemporaryRepoForPR(ctx, pr)
 	if err != nil {
@@ -68,6 +74,17 @@ func createTemporaryRepoForMerge(ctx context.Context, pr *issues_model.PullReque
 		return nil, cancel, err
 	}
 
+	// The commit is reachable through the alternates of the base repository
+	if baseCommitID != "" {
+		for _, branch := range []string{tmpRepoBaseBranch, "original_" + tmpRepoBaseBranch} {
+			if err := prCtx.PrepareGitCmd(gitcmd.NewCommand("update-ref").AddDynamicArguments(git.BranchPrefix+branch, baseCommitID)).
+				RunWithStderr(ctx); err != nil {
+				defer cancel()
+				return nil, nil, fmt.Errorf("unable to reset %s to %s in tmpBasePath: %w\n%s", branch, baseCommitID, err, err.Stderr())
+			}
+		}
+	}
+
 	mergeCtx = &mergeContext{
 		prTmpRepoContext: prCtx,
 		doer:             doer,
@@ -0,0 +1,182 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package pull
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	git_model "gitea.dev/models/git"
+	issues_model "gitea.dev/models/issues"
+	repo_model "gitea.dev/models/repo"
+	user_model "gitea.dev/models/user"
+	"gitea.dev/modules/container"
+	"gitea.dev/modules/git"
+	"gitea.dev/modules/git/gitcmd"
+	"gitea.dev/modules/git/gitrepo"
+	"gitea.dev/modules/log"
+	repo_module "gitea.dev/modules/repository"
+	"gitea.dev/modules/setting"
+	"gitea.dev/modules/timeutil"
+	notify_service "gitea.dev/services/notify"
+)
+
+// MergeQueueBranchPrefix is the prefix of the branches holding the speculative merges of the merge queues,
+// only the merge queue can push to them
+const MergeQueueBranchPrefix = "gitea-merge-queue/"
+
+// MergeQueueBranchName returns the branch holding the speculative merge of a queued pull request
+func MergeQueueBranchName(pr *issues_model.PullRequest) string {
+	return fmt.Sprintf("%s%s/pr-%d", MergeQueueBranchPrefix, pr.BaseBranch, pr.Index)
+}
+
+// IsMergeQueueBranch returns true if the branch holds a speculative merge of a merge queue
+func IsMergeQueueBranch(branch string) bool {
+	return strings.HasPrefix(branch, MergeQueueBranchPrefix)
+}
+
+// IsErrMergeQueueEjecting returns true if the error means the pull request cannot be merged onto the previous entries of the queue
+func IsErrMergeQueueEjecting(err error) bool {
+	return IsErrMergeConflicts(err) || IsErrRebaseConflicts(err) || IsErrMergeUnrelatedHistories(err) || IsErrMergeDivergingFastForwardOnly(err)
+}
+
+func mergeQueuePushingEnvironment(doer *user_model.User, repo *repo_model.Repository) []string {
+	return append(repo_module.PushingEnvironment(doer, repo), repo_module.EnvPushTrigger+"="+string(repo_module.PushTriggerMergeQueue))
+}
+
+func runMergeQueuePush(ctx context.Context, repo *repo_model.Repository, cmd *gitcmd.Command) error {
+	stdout := &bytes.Buffer{}
+	if err := cmd.WithParentCallerInfo().WithStdoutBuffer(stdout).RunWithStderr(ctx); err != nil {
+		if strings.Contains(err.Stderr(), "non-fast-forward") {
+			return &git.ErrPushOutOfDate{StdOut: stdout.String(), StdErr: err.Stderr(), Err: err}
+		} else if strings.Contains(err.Stderr(), "! [remote rejected]") {
+			err := &git.ErrPushRejected{StdOut: stdout.String(), StdErr: err.Stderr(), Err: err}
+			err.GenerateMessage()
+			return err
+		}
+		return fmt.Errorf("git push to %s: %w\n%s", repo.FullName(), err, err.Stderr())
+	}
+	return nil
+}
+
+// CreateMergeQueueCommit merges the pull request with the given style onto baseCommitID, which is the base branch or the
+// speculative merge of the previous entry of the queue, and pushes the result to the merge queue branch of the pull request.
+// It returns the head of the pull request which has been merged and the speculative merge.
+func CreateMergeQueueCommit(ctx context.Context, pr *issues_model.PullRequest, doer *user_model.User, mergeStyle repo_model.MergeStyle, message, baseCommitID string) (headCommitID, mergeCommitID string, err error) {
+	mergeCtx, cancel, err := createTemporaryRepoForMergeOnto(ctx, pr, doer, "", baseCommitID)
+	if err != nil {
+		return "", "", err
+	}
+	defer cancel()
+
+	headCommitID, err = git.GetFullCommitID(ctx, mergeCtx.tmpRepo, tmpRepoTrackingBranch)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to get full commit id for %s: %w", tmpRepoTrackingBranch, err)
+	}
+	if err := doMergeStyle(mergeCtx, mergeStyle, message); err != nil {
+		return "", "", err
+	}
+	mergeCommitID, err = git.GetFullCommitID(ctx, mergeCtx.tmpRepo, tmpRepoBaseBranch)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to get full commit id for the speculative merge: %w", err)
+	}
+
+	if setting.LFS.StartServer {
+		if err := LFSPush(ctx, mergeCtx.tmpBasePath, mergeCtx.tmpRepo, mergeCommitID, baseCommitID, pr); err != nil {
+			return "", "", err
+		}
+	}
+
+	// the branch is rebuilt whenever an entry before it is ejected
+	pushCmd := gitcmd.NewCommand("push", "--force", "origin").
+		AddDynamicArguments(tmpRepoBaseBranch + ":" + git.BranchPrefix + MergeQueueBranchName(pr)).
+		WithEnv(mergeQueuePushingEnvironment(doer, pr.BaseRepo)).
+		WithRepo(mergeCtx.tmpRepo)
+	if err := runMergeQueuePush(ctx, pr.BaseRepo, pushCmd); err != nil {
+		return "", "", err
+	}
+	return headCommitID, mergeCommitID, nil
+}
+
+// FastForwardMergeQueue fast-forwards the base branch to a speculative merge of its merge queue whose checks passed
+func FastForwardMergeQueue(ctx context.Context, repo *repo_model.Repository, doer *user_model.User, baseBranch, mergeCommitID string) error {
+	pushCmd := gitcmd.NewCommand("push").
+		AddDynamicArguments(gitrepo.RepoLocalPath(repo.CodeStorageRepo()), mergeCommitID+":"+git.BranchPrefix+baseBranch).
+		WithEnv(mergeQueuePushingEnvironment(doer, repo)).
+		WithRepo(repo)
+	return runMergeQueuePush(ctx, repo, pushCmd)
+}
+
+// DeleteMergeQueueBranches deletes the merge queue branches of pull requests which left the queue
+func DeleteMergeQueueBranches(ctx context.Context, repo *repo_model.Repository, doer *user_model.User, prs []*issues_model.PullRequest) error {
+	refs := make([]string, 0, len(prs))
+	for _, pr := range prs {
+		ref := git.BranchPrefix + MergeQueueBranchName(pr)
+		if git.IsReferenceExist(ctx, repo, ref) {
+			refs = append(refs, ref)
+		}
+	}
+	if len(refs) == 0 {
+		return nil
+	}
+	pushCmd := gitcmd.NewCommand("push").
+		AddDynamicArguments(gitrepo.RepoLocalPath(repo.CodeStorageRepo())).
+		AddArguments("--delete").AddDashesAndList(refs...).
+		WithEnv(mergeQueuePushingEnvironment(doer, repo)).
+		WithRepo(repo)
+	return runMergeQueuePush(ctx, repo, pushCmd)
+}
+
+// MergeQueueRequiredContexts returns the status check contexts a speculative merge must pass before the base branch
+// is fast-forwarded to it: the ones required by the protection rule of the branch and by its active rulesets.
+// When none is required, every status reported on the speculative merge must pass.
+func MergeQueueRequiredContexts(ctx context.Context, repo *repo_model.Repository, baseBranch string) ([]string, error) {
+	pb, err := git_model.GetFirstMatchProtectedBranchRule(ctx, repo.ID, baseBranch)
+	if err != nil {
+		return nil, err
+	}
+	contexts, err := EffectiveRequiredContexts(ctx, repo, pb)
+	if err != nil {
+		return nil, err
+	}
+	required := container.SetOf(contexts...)
+
+	rulesets, err := git_model.GetRulesetsForRef(ctx, repo, git.RefNameFromBranch(baseBranch))
+	if err != nil {
+		return nil, err
+	}
+	for _, rs := range rulesets {
+		if rs.Enforcement == git_model.RulesetEnforcementActive {
+			required.AddMultiple(rs.Rules.RequiredStatusChecks...)
+		}
+	}
+
+	values := required.Values()
+	slices.Sort(values)
+	return values, nil
+}
+
+// SetMergeQueueMerged marks a pull request as merged once the base branch has been fast-forwarded to its speculative merge
+func SetMergeQueueMerged(ctx context.Context, pr *issues_model.PullRequest, doer *user_model.User, mergeCommitID string) error {
+	if _, err := SetMerged(ctx, pr, mergeCommitID, timeutil.TimeStampNow(), doer, pr.Status); err != nil {
+		return err
+	}
+
+	if err := pr.LoadIssue(ctx); err != nil {
+		log.Error("LoadIssue %-v: %v", pr, err)
+	}
+	if err := pr.Issue.LoadRepo(ctx); err != nil {
+		log.Error("pr.Issue.LoadRepo %-v: %v", pr, err)
+	}
+	if err := pr.Issue.Repo.LoadOwner(ctx); err != nil {
+		log.Error("LoadOwner for %-v: %v", pr, err)
+	}
+	notify_service.MergePullRequest(ctx, doer, pr)
+
+	git.RemoveCommitsCountCache(pr.Issue.Repo, git.RefNameFromBranch(pr.BaseBranch))
+	return handleCloseCrossReferences(ctx, pr, doer)
+}
@@ -0,0 +1,418 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package pull
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"gitea.dev/models/db"
+	git_model "gitea.dev/models/git"
+	issues_model "gitea.dev/models/issues"
+	repo_model "gitea.dev/models/repo"
+	user_model "gitea.dev/models/user"
+	"gitea.dev/modules/base"
+	"gitea.dev/modules/container"
+	"gitea.dev/modules/git"
+	"gitea.dev/modules/git/gitcmd"
+	"gitea.dev/modules/log"
+	"gitea.dev/modules/util"
+	asymkey_service "gitea.dev/services/asymkey"
+)
+
+// RulesetViolation is a requirement of a ruleset which a push or a merge does not satisfy
+type RulesetViolation struct {
+	Ruleset *git_model.Ruleset
+	Message string
+}
+
+func (v *RulesetViolation) String() string {
+	return fmt.Sprintf("ruleset %q: %s", v.Ruleset.Name, v.Message)
+}
+
+type RulesetViolations []*RulesetViolation
+
+func (vs RulesetViolations) String() string {
+	msgs := make([]string, 0, len(vs))
+	for _, v := range vs {
+		msgs = append(msgs, v.String())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Enforced returns the violations of the active rulesets, the violations of the rulesets in evaluate mode are only logged
+func (vs RulesetViolations) Enforced(repo *repo_model.Repository, refName git.RefName) RulesetViolations {
+	enforced := make(RulesetViolations, 0, len(vs))
+	for _, v := range vs {
+		if v.Ruleset.IsEnforced() {
+			enforced = append(enforced, v)
+			continue
+		}
+		log.Info("Ruleset %d in evaluate mode is violated by an update of %s in %s: %s", v.Ruleset.ID, refName, repo.FullName(), v.Message)
+	}
+	return enforced
+}
+
+// checkRulesetPatterns reports the rulesets whose patterns can't be compiled, they are violated by any update of the refs
+// of their target. The other rulesets are returned to be checked.
+func checkRulesetPatterns(rulesets []*git_model.Ruleset) (RulesetViolations, []*git_model.Ruleset) {
+	var violations RulesetViolations
+	valid := make([]*git_model.Ruleset, 0, len(rulesets))
+	for _, rs := range rulesets {
+		if err := rs.PatternError(); err != nil {
+			violations = append(violations, &RulesetViolation{Ruleset: rs, Message: fmt.Sprintf("the ruleset can't be evaluated: %v", err)})
+			continue
+		}
+		valid = append(valid, rs)
+	}
+	return violations, valid
+}
+
+// rulesetCommit is a commit a push or a merge brings to a ref
+type rulesetCommit struct {
+	ID          string
+	ParentCount int
+	// the fields below are only loaded when a ruleset needs them
+	Message  string
+	Verified bool
+	Files    []rulesetFile
+}
+
+type rulesetFile struct {
+	Path string
+	Size int64
+}
+
+// rulesetCommitsNeeds tells which details of the commits the rulesets need to be loaded
+type rulesetCommitsNeeds struct {
+	content bool // message and signature
+	files   bool
+}
+
+func getRulesetCommitsNeeds(rulesets []*git_model.Ruleset) (needs rulesetCommitsNeeds) {
+	for _, rs := range rulesets {
+		needs.content = needs.content || rs.Rules.RequiredSignatures || rs.Rules.CommitMessagePattern != ""
+		needs.files = needs.files || len(rs.Rules.BlockedFilePaths) > 0 || rs.Rules.MaxFileSize > 0
+	}
+	return needs
+}
+
+// loadRulesetCommits loads the commits of a range given as rev-list arguments, env exposes the quarantined objects of a push
+func loadRulesetCommits(ctx context.Context, gitRepo *git.Repository, env []string, revs []string, needs rulesetCommitsNeeds) ([]*rulesetCommit, error) {
+	stdout, _, err := gitcmd.NewCommand("rev-list", "--parents").AddDynamicArguments(revs[0]).AddArguments(gitcmd.ToTrustedCmdArgs(revs[1:])...).
+		WithEnv(env).WithRepo(gitRepo).RunStdString(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("rev-list: %w", err)
+	}
+	commits := make([]*rulesetCommit, 0, 10)
+	byID := make(map[string]*rulesetCommit)
+	for line := range strings.SplitSeq(strings.TrimSpace(stdout), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		c := &rulesetCommit{ID: fields[0], ParentCount: len(fields) - 1}
+		commits = append(commits, c)
+		byID[c.ID] = c
+	}
+	if len(commits) == 0 {
+		return commits, nil
+	}
+
+	if needs.content {
+		for _, c := range commits {
+			raw, _, runErr := gitcmd.NewCommand("cat-file", "commit").AddDynamicArguments(c.ID).
+				WithEnv(env).WithRepo(gitRepo).RunStdBytes(ctx)
+			if runErr != nil {
+				return nil, fmt.Errorf("cat-file %s: %w", c.ID, runErr)
+			}
+			commit, err := git.CommitFromReader(git.MustIDFromString(c.ID), bytes.NewReader(raw))
+			if err != nil {
+				return nil, err
+			}
+			c.Message = commit.MessageUTF8()
+			c.Verified = asymkey_service.ParseCommitWithSignature(ctx, commit).Verified
+		}
+	}
+
+	if needs.files {
+		if err := loadRulesetCommitFiles(ctx, gitRepo, env, revs, byID); err != nil {
+			return nil, err
+		}
+	}
+	return commits, nil
+}
+
+func loadRulesetCommitFiles(ctx context.Context, gitRepo *git.Repository, env []string, revs []string, byID map[string]*rulesetCommit) error {
+	// merges are skipped, the files they bring are checked with the commits which changed them
+	stdout, _, err := gitcmd.NewCommand("log", "--format=format:%H", "--raw", "--no-abbrev", "--no-renames", "-r").
+		AddDynamicArguments(revs[0]).AddArguments(gitcmd.ToTrustedCmdArgs(revs[1:])...).
+		WithEnv(env).WithRepo(gitRepo).RunStdString(ctx)
+	if err != nil {
+		return fmt.Errorf("log: %w", err)
+	}
+
+	type blobRef struct {
+		commit *rulesetCommit
+		index  int
+	}
+	blobs := make(map[string][]blobRef)
+	var current *rulesetCommit
+	scanner := bufio.NewScanner(strings.NewReader(stdout))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			continue
+		}
+		if !strings.HasPrefix(line, ":") {
+			current = byID[strings.TrimSpace(line)]
+			continue
+		}
+		// :100644 100644 <old blob> <new blob> M\tpath
+		meta, path, ok := strings.Cut(line, "\t")
+		fields := strings.Fields(meta)
+		if current == nil || !ok || len(fields) < 5 || fields[4] == "D" || fields[1] == "160000" {
+			continue
+		}
+		current.Files = append(current.Files, rulesetFile{Path: path})
+		blobs[fields[3]] = append(blobs[fields[3]], blobRef{commit: current, index: len(current.Files) - 1})
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	if len(blobs) == 0 {
+		return nil
+	}
+
+	ids := make([]string, 0, len(blobs))
+	for id := range blobs {
+		ids = append(ids, id)
+	}
+	stdout, _, err = gitcmd.NewCommand("cat-file", "--batch-check=%(objectname) %(objectsize)").
+		WithStdinBytes([]byte(strings.Join(ids, "\n") + "\n")).
+		WithEnv(env).WithRepo(gitRepo).RunStdString(ctx)
+	if err != nil {
+		return fmt.Errorf("cat-file --batch-check: %w", err)
+	}
+	for line := range strings.SplitSeq(strings.TrimSpace(stdout), "\n") {
+		id, rawSize, _ := strings.Cut(line, " ")
+		size, err := strconv.ParseInt(rawSize, 10, 64)
+		if err != nil {
+			continue // missing object
+		}
+		for _, ref := range blobs[id] {
+			ref.commit.Files[ref.index].Size = size
+		}
+	}
+	return nil
+}
+
+// checkRulesetCommits checks the commits brought to a ref against the commit rules of a ruleset,
+// only the first commit violating each rule is reported
+func checkRulesetCommits(rs *git_model.Ruleset, commits []*rulesetCommit, checkLinearHistory, checkMessages bool) RulesetViolations {
+	var violations RulesetViolations
+	reported := make(container.Set[string])
+	report := func(rule, format string, args ...any) {
+		if reported.Add(rule) {
+			violations = append(violations, &RulesetViolation{Ruleset: rs, Message: fmt.Sprintf(format, args...)})
+		}
+	}
+
+	for _, c := range commits {
+		if checkLinearHistory && rs.Rules.RequiredLinearHistory && c.ParentCount > 1 {
+			report("linear_history", "merge commit %s is not allowed, the history must be linear", c.ID)
+		}
+		if rs.Rules.RequiredSignatures && !c.Verified {
+			report("signatures", "commit %s is not signed with a verified key", c.ID)
+		}
+		if checkMessages && !rs.MatchCommitMessage(c.Message) {
+			report("commit_message", "the message of commit %s does not match %q", c.ID, rs.Rules.CommitMessagePattern)
+		}
+		for _, f := range c.Files {
+			if rs.IsBlockedFilePath(f.Path) {
+				report("blocked_file_paths", "commit %s changes the blocked file %s", c.ID, f.Path)
+			}
+			if rs.Rules.MaxFileSize > 0 && f.Size > rs.Rules.MaxFileSize {
+				report("max_file_size", "commit %s adds the file %s of %s, the limit is %s", c.ID, f.Path, base.FileSize(f.Size), base.FileSize(rs.Rules.MaxFileSize))
+			}
+		}
+	}
+	return violations
+}
+
+// checkRulesetStatusChecks checks the required status checks of a ruleset against the statuses of a commit
+func checkRulesetStatusChecks(ctx context.Context, rs *git_model.Ruleset, repoID int64, commitID string) (*RulesetViolation, error) {
+	if len(rs.Rules.RequiredStatusChecks) == 0 {
+		return nil, nil //nolint:nilnil // no violation
+	}
+	statuses, err := git_model.GetLatestCommitStatus(ctx, repoID, commitID, db.ListOptionsAll)
+	if err != nil {
+		return nil, err
+	}
+	if state := MergeRequiredContextsCommitStatus(statuses, rs.Rules.RequiredStatusChecks); !state.IsSuccess() {
+		return &RulesetViolation{Ruleset: rs, Message: fmt.Sprintf("the required status checks of commit %s are %s", commitID, state)}, nil
+	}
+	return nil, nil //nolint:nilnil // no violation
+}
+
+// RulesetPushOptions describes a ref update to check against the rulesets
+type RulesetPushOptions struct {
+	Repo        *repo_model.Repository
+	GitRepo     *git.Repository
+	RefName     git.RefName
+	OldCommitID string
+	NewCommitID string
+	// StatusCommitID is the commit whose statuses are checked, it is the head of the pull request when merging one
+	StatusCommitID string
+	// Env is the environment of the git commands, it exposes the quarantined objects of a push
+	Env []string
+}
+
+// EvaluateRulesetsForPush checks a ref update against all the rulesets applying to the ref, including the ones in evaluate mode
+func EvaluateRulesetsForPush(ctx context.Context, opts *RulesetPushOptions) (RulesetViolations, error) {
+	rulesets, err := git_model.GetRulesetsForRef(ctx, opts.Repo, opts.RefName)
+	if err != nil {
+		return nil, err
+	}
+	violations, rulesets := checkRulesetPatterns(rulesets)
+	if len(rulesets) == 0 {
+		return violations, nil
+	}
+
+	objectFormat, err := opts.GitRepo.GetObjectFormat(ctx)
+	if err != nil {
+		return nil, err
+	}
+	emptyID := objectFormat.EmptyObjectID().String()
+
+	if opts.NewCommitID == emptyID {
+		for _, rs := range rulesets {
+			if rs.Rules.Deletion {
+				violations = append(violations, &RulesetViolation{Ruleset: rs, Message: "the ref cannot be deleted"})
+			}
+		}
+		return violations, nil
+	}
+
+	revs := []string{opts.NewCommitID, "--not", "--all"}
+	if opts.OldCommitID != "" && opts.OldCommitID != emptyID {
+		revs = []string{opts.OldCommitID + ".." + opts.NewCommitID}
+
+		output, _, err := gitcmd.NewCommand("rev-list", "--max-count=1").
+			AddDynamicArguments(opts.OldCommitID, "^"+opts.NewCommitID).
+			WithEnv(opts.Env).WithRepo(opts.GitRepo).RunStdString(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("detect force push: %w", err)
+		}
+		if isForcePush := len(output) > 0; isForcePush {
+			for _, rs := range rulesets {
+				if rs.Rules.NonFastForward {
+					violations = append(violations, &RulesetViolation{Ruleset: rs, Message: "force pushes are not allowed"})
+				}
+			}
+		}
+	}
+
+	commits, err := loadRulesetCommits(ctx, opts.GitRepo, opts.Env, revs, getRulesetCommitsNeeds(rulesets))
+	if err != nil {
+		return nil, err
+	}
+
+	statusCommitID := util.IfZero(opts.StatusCommitID, opts.NewCommitID)
+	for _, rs := range rulesets {
+		violations = append(violations, checkRulesetCommits(rs, commits, true, true)...)
+		violation, err := checkRulesetStatusChecks(ctx, rs, opts.Repo.ID, statusCommitID)
+		if err != nil {
+			return nil, err
+		} else if violation != nil {
+			violations = append(violations, violation)
+		}
+	}
+	return violations, nil
+}
+
+// checkPullRulesets checks whether merging the pull request with the given style satisfies the active rulesets of the base branch.
+// The commits actually pushed by the merge are checked again by the pre-receive hook.
+func checkPullRulesets(ctx context.Context, pr *issues_model.PullRequest, doer *user_model.User, mergeStyle repo_model.MergeStyle, skipStatusChecks bool) error {
+	if err := pr.LoadBaseRepo(ctx); err != nil {
+		return err
+	}
+	refName := git.RefNameFromBranch(pr.BaseBranch)
+	rulesets, err := git_model.GetRulesetsForRef(ctx, pr.BaseRepo, refName)
+	if err != nil {
+		return err
+	}
+	violations, rulesets := checkRulesetPatterns(rulesets)
+	if len(rulesets) == 0 {
+		if enforced := violations.Enforced(pr.BaseRepo, refName); len(enforced) > 0 {
+			return util.ErrorWrap(ErrRulesetViolated, "%s", enforced.String())
+		}
+		return nil
+	}
+
+	gitRepo, closer, err := git.RepositoryFromContextOrOpen(ctx, pr.BaseRepo)
+	if err != nil {
+		return err
+	}
+	defer closer.Close()
+
+	headCommitID, err := gitRepo.GetRefCommitID(ctx, pr.GetGitHeadRefName())
+	if err != nil {
+		return err
+	}
+	commits, err := loadRulesetCommits(ctx, gitRepo, nil, []string{headCommitID, "^" + pr.BaseBranch}, getRulesetCommitsNeeds(rulesets))
+	if err != nil {
+		return err
+	}
+
+	// the styles rewriting the head commits drop their merges, and Gitea signs the commits it creates
+	keepsHeadCommits := mergeStyle == repo_model.MergeStyleFastForwardOnly || mergeStyle == repo_model.MergeStyleMerge
+	createsMergeCommit := mergeStyle == repo_model.MergeStyleMerge || mergeStyle == repo_model.MergeStyleRebaseMerge
+
+	for _, rs := range rulesets {
+		if rs.Rules.RequiredLinearHistory && createsMergeCommit {
+			violations = append(violations, &RulesetViolation{Ruleset: rs, Message: fmt.Sprintf("merge style %s creates a merge commit, the history must be linear", mergeStyle)})
+		}
+		if rs.Rules.RequiredSignatures && mergeStyle != repo_model.MergeStyleFastForwardOnly {
+			if _, _, _, err := asymkey_service.SignMerge(ctx, pr, doer, gitRepo, pr.BaseBranch, pr.GetGitHeadRefName()); err != nil {
+				if !asymkey_service.IsErrWontSign(err) {
+					return err
+				}
+				violations = append(violations, &RulesetViolation{Ruleset: rs, Message: "the merge commit cannot be signed: " + err.Error()})
+			}
+		}
+
+		checked := rs
+		if !keepsHeadCommits && rs.Rules.RequiredSignatures {
+			rules := *rs
+			rules.Rules.RequiredSignatures = false
+			checked = &rules
+		}
+		// the message of a squash commit is only known once it is pushed
+		for _, v := range checkRulesetCommits(checked, commits, mergeStyle == repo_model.MergeStyleFastForwardOnly, mergeStyle != repo_model.MergeStyleSquash) {
+			v.Ruleset = rs
+			violations = append(violations, v)
+		}
+
+		// the merge queue checks the status of the combined result when it pushes it
+		if skipStatusChecks {
+			continue
+		}
+		violation, err := checkRulesetStatusChecks(ctx, rs, pr.BaseRepoID, headCommitID)
+		if err != nil {
+			return err
+		} else if violation != nil {
+			violations = append(violations, violation)
+		}
+	}
+
+	if enforced := violations.Enforced(pr.BaseRepo, refName); len(enforced) > 0 {
+		return util.ErrorWrap(ErrRulesetViolated, "%s", enforced.String())
+	}
+	return nil
+}
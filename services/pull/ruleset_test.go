@@ -0,0 +1,75 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package pull
+
+import (
+	"testing"
+
+	git_model "gitea.dev/models/git"
+	repo_model "gitea.dev/models/repo"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckRulesetCommits(t *testing.T) {
+	rs := &git_model.Ruleset{
+		Name:        "main",
+		Target:      git_model.RulesetTargetBranch,
+		Enforcement: git_model.RulesetEnforcementActive,
+		IncludeRefs: []string{"main"},
+		Rules: git_model.RulesetRules{
+			RequiredLinearHistory: true,
+			RequiredSignatures:    true,
+			BlockedFilePaths:      []string{"*.exe"},
+			CommitMessagePattern:  `^fix: `,
+			MaxFileSize:           1024,
+		},
+	}
+	commits := []*rulesetCommit{
+		{ID: "c1", ParentCount: 1, Message: "fix: a", Verified: true, Files: []rulesetFile{{Path: "a.go", Size: 10}}},
+		{ID: "c2", ParentCount: 2, Message: "Merge branch", Verified: true},
+		{ID: "c3", ParentCount: 1, Message: "fix: b", Verified: false, Files: []rulesetFile{{Path: "tool.exe", Size: 2048}}},
+		{ID: "c4", ParentCount: 1, Message: "fix: c", Verified: false, Files: []rulesetFile{{Path: "other.exe", Size: 4096}}},
+	}
+
+	violations := checkRulesetCommits(rs, commits[:1], true, true)
+	assert.Empty(t, violations)
+
+	violations = checkRulesetCommits(rs, commits, true, true)
+	require.Len(t, violations, 5, "only the first offending commit of each rule is reported")
+	assert.Contains(t, violations[0].Message, "merge commit c2")
+	assert.Contains(t, violations[1].Message, "message of commit c2")
+	assert.Contains(t, violations[2].Message, "commit c3 is not signed")
+	assert.Contains(t, violations[3].Message, "blocked file tool.exe")
+	assert.Contains(t, violations[4].Message, "file tool.exe of 2.0 KiB")
+
+	violations = checkRulesetCommits(rs, commits[:2], false, false)
+	assert.Empty(t, violations, "the history and the messages are not checked for the commits a merge rewrites")
+}
+
+func TestRulesetViolationsEnforced(t *testing.T) {
+	active := &git_model.Ruleset{Name: "active", Enforcement: git_model.RulesetEnforcementActive}
+	evaluate := &git_model.Ruleset{Name: "evaluate", Enforcement: git_model.RulesetEnforcementEvaluate}
+	violations := RulesetViolations{
+		{Ruleset: evaluate, Message: "force pushes are not allowed"},
+		{Ruleset: active, Message: "the ref cannot be deleted"},
+	}
+
+	enforced := violations.Enforced(&repo_model.Repository{OwnerName: "user2", Name: "repo1"}, "refs/heads/main")
+	require.Len(t, enforced, 1)
+	assert.Equal(t, `ruleset "active": the ref cannot be deleted`, enforced.String())
+}
+
+func TestCheckRulesetPatterns(t *testing.T) {
+	valid := &git_model.Ruleset{Name: "valid", IncludeRefs: []string{"main"}}
+	invalid := &git_model.Ruleset{Name: "invalid", IncludeRefs: []string{"main"}, Rules: git_model.RulesetRules{BlockedFilePaths: []string{"[a"}}}
+
+	violations, rulesets := checkRulesetPatterns([]*git_model.Ruleset{valid, invalid})
+	require.Len(t, rulesets, 1)
+	assert.Equal(t, valid, rulesets[0])
+	require.Len(t, violations, 1)
+	assert.Equal(t, invalid, violations[0].Ruleset)
+	assert.Contains(t, violations[0].Message, `the ruleset can't be evaluated: invalid blocked file path "[a"`)
+}
@@ -0,0 +1,146 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package quota
+
+import (
+	"context"
+
+	"gitea.dev/models/db"
+	quota_model "gitea.dev/models/quota"
+	user_model "gitea.dev/models/user"
+	"gitea.dev/modules/log"
+	"gitea.dev/modules/setting"
+
+	"xorm.io/builder"
+)
+
+// GroupRules is a group with its rules
+type GroupRules struct {
+	Group *quota_model.Group
+	Rules []*quota_model.Rule
+}
+
+// Info is the quota of a user or an organization
+type Info struct {
+	Usage *quota_model.Usage
+	// Groups are the groups of the owner, or the default groups if it has neither groups nor rules of its own
+	Groups []*GroupRules
+	// Rules are the rules attached directly to the owner
+	Rules []*quota_model.Rule
+}
+
+// AllRules returns the rules of the groups and the rules attached directly, each rule once
+func (info *Info) AllRules() []*quota_model.Rule {
+	seen := make(map[int64]bool)
+	rules := make([]*quota_model.Rule, 0, len(info.Rules))
+	add := func(rs []*quota_model.Rule) {
+		for _, r := range rs {
+			if !seen[r.ID] {
+				seen[r.ID] = true
+				rules = append(rules, r)
+			}
+		}
+	}
+	add(info.Rules)
+	for _, g := range info.Groups {
+		add(g.Rules)
+	}
+	return rules
+}
+
+func loadGroupRules(ctx context.Context, groups []*quota_model.Group) ([]*GroupRules, error) {
+	grs := make([]*GroupRules, 0, len(groups))
+	for _, g := range groups {
+		rules, err := quota_model.GetRulesByGroupID(ctx, g.ID)
+		if err != nil {
+			return nil, err
+		}
+		grs = append(grs, &GroupRules{Group: g, Rules: rules})
+	}
+	return grs, nil
+}
+
+// getRules loads the groups and the rules applied to a user or an organization
+func getRules(ctx context.Context, userID int64) (*Info, error) {
+	rules, err := quota_model.GetRulesByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	groups, err := quota_model.GetGroupsByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if len(rules) == 0 && len(groups) == 0 {
+		if groups, err = quota_model.GetGroupsByNames(ctx, setting.Quota.DefaultGroups); err != nil {
+			return nil, err
+		}
+	}
+	grs, err := loadGroupRules(ctx, groups)
+	if err != nil {
+		return nil, err
+	}
+	return &Info{Groups: grs, Rules: rules}, nil
+}
+
+// GetInfo gets the usage, the groups and the rules of a user or an organization
+func GetInfo(ctx context.Context, userID int64) (*Info, error) {
+	info, err := getRules(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if info.Usage, err = quota_model.GetUsage(ctx, userID); err != nil {
+		return nil, err
+	}
+	return info, nil
+}
+
+// GetGroupRules gets a group with its rules
+func GetGroupRules(ctx context.Context, g *quota_model.Group) (*GroupRules, error) {
+	grs, err := loadGroupRules(ctx, []*quota_model.Group{g})
+	if err != nil {
+		return nil, err
+	}
+	return grs[0], nil
+}
+
+// CheckQuota checks if a user or an organization may store size more bytes in the category,
+// a quota_model.ErrQuotaExceeded is returned otherwise
+func CheckQuota(ctx context.Context, userID int64, category quota_model.Category, size int64) error {
+	if !setting.Quota.Enabled {
+		return nil
+	}
+
+	info, err := GetInfo(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if !quota_model.Evaluate(info.AllRules(), info.Usage, category, size) {
+		return quota_model.ErrQuotaExceeded{OwnerID: userID, Category: category}
+	}
+	return nil
+}
+
+// UpdateUsage recalculates the usage of the categories of a user or an organization after content has been
+// added or removed. Errors are only logged, the cron task corrects the usage later.
+func UpdateUsage(ctx context.Context, userID int64, categories ...quota_model.Category) {
+	if !setting.Quota.Enabled || userID <= 0 {
+		return
+	}
+	if _, err := quota_model.UpdateUsage(ctx, userID, categories...); err != nil {
+		log.Error("Unable to update the quota usage of %d: %v", userID, err)
+	}
+}
+
+// UpdateAllUsages recalculates the usage of all users and organizations
+func UpdateAllUsages(ctx context.Context) error {
+	if err := db.Iterate(ctx, builder.In("type", user_model.UserTypeIndividual, user_model.UserTypeOrganization), func(ctx context.Context, u *user_model.User) error {
+		if _, err := quota_model.UpdateUsage(ctx, u.ID); err != nil {
+			log.Error("Unable to update the quota usage of %s: %v", u.Name, err)
+		}
+		return ctx.Err()
+	}); err != nil {
+		return err
+	}
+	return quota_model.DeleteOrphanedUsages(ctx)
+}
@@ -8,6 +8,7 @@ import (
 	"context"
 	"fmt"
 
+	audit_model "gitea.dev/models/audit"
 	"gitea.dev/models/db"
 	issues_model "gitea.dev/models/issues"
 	"gitea.dev/models/perm"
@@ -34,7 +35,8 @@ func AddOrUpdateCollaborator(ctx context.Context, repo *repo_model.Repository, u
 		return user_model.ErrBlockedUser
 	}
 
-	return db.WithTx(ctx, func(ctx context.Context) error {
+	var action audit_model.Action
+	if err := db.WithTx(ctx, func(ctx context.Context) error {
 		collaboration, has, err := db.Get[repo_model.Collaboration](ctx, builder.Eq{
 			"repo_id": repo.ID,
 			"user_id": u.ID,
@@ -54,15 +56,38 @@ func AddOrUpdateCollaborator(ctx context.Context, repo *repo_model.Repository, u
 				}); err != nil {
 				return err
 			}
-		} else if err = db.Insert(ctx, &repo_model.Collaboration{
-			RepoID: repo.ID,
-			UserID: u.ID,
-			Mode:   mode,
-		}); err != nil {
-			return err
+			action = audit_model.ActionCollaboratorUpdate
+		} else {
+			if err = db.Insert(ctx, &repo_model.Collaboration{
+				RepoID: repo.ID,
+				UserID: u.ID,
+				Mode:   mode,
+			}); err != nil {
+				return err
+			}
+			action = audit_model.ActionCollaboratorAdd
 		}
 
 		return access_model.RecalculateUserAccess(ctx, repo, u.ID)
+	}); err != nil {
+		return err
+	}
+
+	if action != "" {
+		recordCollaboratorEvent(ctx, action, repo, u, map[string]string{"mode": mode.ToString()})
+	}
+	return nil
+}
+
+func recordCollaboratorEvent(ctx context.Context, action audit_model.Action, repo *repo_model.Repository, u *user_model.User, metadata map[string]string) {
+	audit_model.Record(ctx, &audit_model.Event{
+		Action:     action,
+		OwnerID:    repo.OwnerID,
+		RepoID:     repo.ID,
+		TargetType: audit_model.TargetUser,
+		TargetID:   u.ID,
+		TargetName: u.Name,
+		Metadata:   metadata,
 	})
 }
 
@@ -78,8 +103,9 @@ func deleteCollaborationByMode(ctx context.Context, repo *repo_model.Repository,
 }
 
 func deleteCollaboration(ctx context.Context, repo *repo_model.Repository, collaborator *user_model.User, collaboration *repo_model.Collaboration) (err error) {
-	return db.WithTx(ctx, func(ctx context.Context) error {
-		if deleted, err := db.GetEngine(ctx).Delete(collaboration); err != nil {
+	var deleted int64
+	err = db.WithTx(ctx, func(ctx context.Context) error {
+		if deleted, err = db.GetEngine(ctx).Delete(collaboration); err != nil {
 			return err
 		} else if deleted == 0 {
 			return nil
@@ -99,6 +125,10 @@ func deleteCollaboration(ctx context.Context, repo *repo_model.Repository, colla
 		// Unassign a user from any issue (s)he has been assigned to in the repository
 		return ReconsiderRepoIssuesAssignee(ctx, repo, collaborator)
 	})
+	if err == nil && deleted > 0 {
+		recordCollaboratorEvent(ctx, audit_model.ActionCollaboratorRemove, repo, collaborator, nil)
+	}
+	return err
 }
 
 func ReconsiderRepoIssuesAssignee(ctx context.Context, repo *repo_model.Repository, user *user_model.User) error {
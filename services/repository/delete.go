@@ -11,6 +11,7 @@ import (
 	actions_model "gitea.dev/models/actions"
 	activities_model "gitea.dev/models/activities"
 	admin_model "gitea.dev/models/admin"
+	codenav_model "gitea.dev/models/codenav"
 	"gitea.dev/models/db"
 	git_model "gitea.dev/models/git"
 	issues_model "gitea.dev/models/issues"
@@ -18,8 +19,10 @@ import (
 	packages_model "gitea.dev/models/packages"
 	access_model "gitea.dev/models/perm/access"
 	project_model "gitea.dev/models/project"
+	pull_model "gitea.dev/models/pull"
 	repo_model "gitea.dev/models/repo"
 	secret_model "gitea.dev/models/secret"
+	secretscan_model "gitea.dev/models/secretscan"
 	system_model "gitea.dev/models/system"
 	user_model "gitea.dev/models/user"
 	"gitea.dev/models/webhook"
@@ -32,6 +35,7 @@ import (
 	actions_service "gitea.dev/services/actions"
 	asymkey_service "gitea.dev/services/asymkey"
 	issue_service "gitea.dev/services/issue"
+	quota_service "gitea.dev/services/quota"
 
 	"xorm.io/builder"
 )
@@ -85,6 +89,12 @@ func DeleteRepositoryDirectly(ctx context.Context, repoID int64, ignoreOrgTeams
 		return fmt.Errorf("list actions artifacts of repo %v: %w", repoID, err)
 	}
 
+	// Query the caches of this repo, their files in ObjectStorage are removed after the repo has been deleted
+	caches, err := db.Find[actions_model.ActionCache](ctx, actions_model.FindCachesOptions{RepoID: repoID})
+	if err != nil {
+		return fmt.Errorf("list actions caches of repo %v: %w", repoID, err)
+	}
+
 	// In case owner is a organization, we have to change repo specific teams
 	// if ignoreOrgTeams is not true
 	var org *user_model.User
@@ -162,10 +172,20 @@ func DeleteRepositoryDirectly(ctx context.Context, repoID int64, ignoreOrgTeams
 		&repo_model.RepoLicense{RepoID: repoID},
 		&issues_model.Milestone{RepoID: repoID},
 		&repo_model.Mirror{RepoID: repoID},
+		&repo_model.MigrationSync{RepoID: repoID},
+		&repo_model.ForeignReference{RepoID: repoID},
 		&activities_model.Notification{RepoID: repoID},
 		&git_model.ProtectedBranch{RepoID: repoID},
 		&git_model.ProtectedTag{RepoID: repoID},
+		&git_model.Ruleset{RepoID: repoID},
+		&issues_model.CustomField{RepoID: repoID},
+		&pull_model.MergeQueueEntry{RepoID: repoID},
 		&repo_model.PushMirror{RepoID: repoID},
+		&secretscan_model.Alert{RepoID: repoID},
+		&secretscan_model.AlertEvent{RepoID: repoID},
+		&codenav_model.Index{RepoID: repoID},
+		&codenav_model.Symbol{RepoID: repoID},
+		&codenav_model.Occurrence{RepoID: repoID},
 		&repo_model.Release{RepoID: repoID},
 		&repo_model.RepoIndexerStatus{RepoID: repoID},
 		&repo_model.Redirect{RedirectRepoID: repoID},
@@ -190,6 +210,10 @@ func DeleteRepositoryDirectly(ctx context.Context, repoID int64, ignoreOrgTeams
 		&actions_model.ActionRunnerToken{RepoID: repoID},
 		&actions_model.ActionTasksVersion{RepoID: repoID},
 		&actions_model.ActionScopedWorkflowSource{SourceRepoID: repoID},
+		&actions_model.ActionEnvironment{RepoID: repoID},
+		&actions_model.ActionCache{RepoID: repoID},
+		&actions_model.ActionDeployment{RepoID: repoID},
+		&actions_model.ActionDeploymentReview{RepoID: repoID},
 		&issues_model.IssuePin{RepoID: repoID},
 	); err != nil {
 		return fmt.Errorf("deleteBeans: %w", err)
@@ -310,6 +334,8 @@ func DeleteRepositoryDirectly(ctx context.Context, repoID int64, ignoreOrgTeams
 
 	committer.Close()
 
+	quota_service.UpdateUsage(ctx, repo.OwnerID)
+
 	if needRewriteKeysFile {
 		if err := asymkey_service.RewriteAllPublicKeys(ctx); err != nil {
 			log.Error("RewriteAllPublicKeys failed: %v", err)
@@ -385,6 +411,13 @@ func DeleteRepositoryDirectly(ctx context.Context, repoID int64, ignoreOrgTeams
 		}
 	}
 
+	for _, cache := range caches {
+		if err := storage.ActionsCaches.Delete(cache.StoragePath); err != nil {
+			log.Error("remove cache file %q: %v", cache.StoragePath, err)
+			// go on
+		}
+	}
+
 	return nil
 }
 
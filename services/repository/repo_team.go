@@ -8,6 +8,7 @@ import (
 	"errors"
 	"fmt"
 
+	audit_model "gitea.dev/models/audit"
 	"gitea.dev/models/db"
 	issues_model "gitea.dev/models/issues"
 	"gitea.dev/models/organization"
@@ -24,8 +25,24 @@ func TeamAddRepository(ctx context.Context, t *organization.Team, repo *repo_mod
 		return nil
 	}
 
-	return db.WithTx(ctx, func(ctx context.Context) error {
+	if err := db.WithTx(ctx, func(ctx context.Context) error {
 		return addRepositoryToTeam(ctx, t, repo)
+	}); err != nil {
+		return err
+	}
+	recordTeamRepoEvent(ctx, audit_model.ActionTeamRepoAdd, t, repo)
+	return nil
+}
+
+func recordTeamRepoEvent(ctx context.Context, action audit_model.Action, t *organization.Team, repo *repo_model.Repository) {
+	audit_model.Record(ctx, &audit_model.Event{
+		Action:     action,
+		OwnerID:    t.OrgID,
+		RepoID:     repo.ID,
+		TargetType: audit_model.TargetTeam,
+		TargetID:   t.ID,
+		TargetName: t.Name,
+		Metadata:   map[string]string{"access_mode": t.AccessMode.ToString()},
 	})
 }
 
@@ -159,9 +176,13 @@ func RemoveRepositoryFromTeam(ctx context.Context, t *organization.Team, repoID
 		return err
 	}
 
-	return db.WithTx(ctx, func(ctx context.Context) error {
+	if err := db.WithTx(ctx, func(ctx context.Context) error {
 		return removeRepositoryFromTeam(ctx, t, repo, true)
-	})
+	}); err != nil {
+		return err
+	}
+	recordTeamRepoEvent(ctx, audit_model.ActionTeamRepoRemove, t, repo)
+	return nil
 }
 
 // removeRepositoryFromTeam removes a repository from a team and recalculates access
@@ -7,9 +7,11 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strconv"
 	"strings"
 
 	activities_model "gitea.dev/models/activities"
+	audit_model "gitea.dev/models/audit"
 	"gitea.dev/models/db"
 	git_model "gitea.dev/models/git"
 	issues_model "gitea.dev/models/issues"
@@ -68,7 +70,23 @@ func DeleteRepository(ctx context.Context, doer *user_model.User, repo *repo_mod
 		notify_service.DeleteRepository(ctx, doer, repo)
 	}
 
-	return DeleteRepositoryDirectly(ctx, repo.ID)
+	if err := DeleteRepositoryDirectly(ctx, repo.ID); err != nil {
+		return err
+	}
+
+	e := &audit_model.Event{
+		Action:     audit_model.ActionRepoDelete,
+		OwnerID:    repo.OwnerID,
+		RepoID:     repo.ID,
+		TargetType: audit_model.TargetRepo,
+		TargetID:   repo.ID,
+		TargetName: repo.FullName(),
+	}
+	if doer != nil {
+		e.DoerID, e.DoerName = doer.ID, doer.Name
+	}
+	audit_model.Record(ctx, e)
+	return nil
 }
 
 // PushCreateRepo creates a repository when a new repository is pushed to an appropriate namespace
@@ -118,15 +136,38 @@ func Init(ctx context.Context) error {
 
 // UpdateRepository updates a repository
 func UpdateRepository(ctx context.Context, repo *repo_model.Repository, visibilityChanged bool) (err error) {
-	return db.WithTx(ctx, func(ctx context.Context) error {
+	if err = db.WithTx(ctx, func(ctx context.Context) error {
 		if err = updateRepository(ctx, repo, visibilityChanged); err != nil {
 			return fmt.Errorf("updateRepository: %w", err)
 		}
 		return nil
+	}); err != nil {
+		return err
+	}
+	if visibilityChanged {
+		recordRepoVisibilityEvent(ctx, repo)
+	}
+	return nil
+}
+
+func recordRepoVisibilityEvent(ctx context.Context, repo *repo_model.Repository) {
+	audit_model.Record(ctx, &audit_model.Event{
+		Action:     audit_model.ActionRepoVisibilityChange,
+		OwnerID:    repo.OwnerID,
+		RepoID:     repo.ID,
+		TargetType: audit_model.TargetRepo,
+		TargetID:   repo.ID,
+		TargetName: repo.FullName(),
+		Metadata:   map[string]string{"private": strconv.FormatBool(repo.IsPrivate)},
 	})
 }
 
 func MakeRepoPrivate(ctx context.Context, repo *repo_model.Repository, private bool) (err error) {
+	defer func() {
+		if err == nil {
+			recordRepoVisibilityEvent(ctx, repo)
+		}
+	}()
 	return db.WithTx(ctx, func(ctx context.Context) error {
 		repo.IsPrivate = private
 		if err := repo_model.UpdateRepositoryColsNoAutoTime(ctx, repo, "is_private"); err != nil {
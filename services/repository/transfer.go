@@ -9,6 +9,7 @@ import (
 	"strings"
 
 	actions_model "gitea.dev/models/actions"
+	audit_model "gitea.dev/models/audit"
 	"gitea.dev/models/db"
 	issues_model "gitea.dev/models/issues"
 	"gitea.dev/models/organization"
@@ -23,6 +24,7 @@ import (
 	"gitea.dev/modules/log"
 	"gitea.dev/modules/util"
 	notify_service "gitea.dev/services/notify"
+	quota_service "gitea.dev/services/quota"
 )
 
 type LimitReachedError struct{ Limit int }
@@ -230,6 +232,14 @@ func transferOwnership(ctx context.Context, doer *user_model.User, newOwnerName
 		}
 	}
 
+	// Issue types and sub-issue hierarchies are scoped to the owner
+	if err := issues_model.ClearIssueTypesByRepoID(ctx, repo.ID); err != nil {
+		return fmt.Errorf("ClearIssueTypesByRepoID: %w", err)
+	}
+	if err := issues_model.DeleteCrossRepoSubIssues(ctx, repo.ID); err != nil {
+		return fmt.Errorf("DeleteCrossRepoSubIssues: %w", err)
+	}
+
 	if newOwner.IsOrganization() {
 		teams, err := organization.FindOrgTeams(ctx, newOwner.ID)
 		if err != nil {
@@ -352,7 +362,27 @@ func transferOwnership(ctx context.Context, doer *user_model.User, newOwnerName
 		}
 	}
 
-	return committer.Commit()
+	// the event belongs to the previous owner, who is the one losing the repository
+	audit_model.Record(ctx, &audit_model.Event{
+		Action:     audit_model.ActionRepoTransfer,
+		DoerID:     doer.ID,
+		DoerName:   doer.Name,
+		OwnerID:    oldOwner.ID,
+		RepoID:     repo.ID,
+		TargetType: audit_model.TargetRepo,
+		TargetID:   repo.ID,
+		TargetName: repo.FullName(),
+		Metadata:   map[string]string{"old_owner": oldOwner.Name, "new_owner": newOwner.Name},
+	})
+
+	if err := committer.Commit(); err != nil {
+		return err
+	}
+
+	quota_service.UpdateUsage(ctx, oldOwner.ID)
+	quota_service.UpdateUsage(ctx, newOwner.ID)
+
+	return nil
 }
 
 // changeRepositoryName changes all corresponding setting from old repository name to new one.
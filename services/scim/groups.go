@@ -0,0 +1,258 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package scim
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	auth_model "gitea.dev/models/auth"
+	user_model "gitea.dev/models/user"
+	"gitea.dev/modules/container"
+	scim_module "gitea.dev/modules/scim"
+	"gitea.dev/modules/util"
+)
+
+func toSCIMGroup(group *auth_model.SCIMGroup, members []*user_model.User) *scim_module.Group {
+	id := strconv.FormatInt(group.ID, 10)
+	scimGroup := &scim_module.Group{
+		Schemas:     []string{scim_module.SchemaGroup},
+		ID:          id,
+		ExternalID:  group.ExternalID,
+		DisplayName: group.DisplayName,
+		Meta: &scim_module.Meta{
+			ResourceType: "Group",
+			Created:      formatTime(group.CreatedUnix),
+			LastModified: formatTime(group.UpdatedUnix),
+			Location:     EndpointURL("Groups/" + id),
+		},
+	}
+	for _, member := range members {
+		memberID := strconv.FormatInt(member.ID, 10)
+		scimGroup.Members = append(scimGroup.Members, scim_module.MultiValue{
+			Value:   memberID,
+			Display: member.Name,
+			Ref:     EndpointURL("Users/" + memberID),
+		})
+	}
+	return scimGroup
+}
+
+// loadGroups converts the groups, with their members if asked for
+func loadGroups(ctx context.Context, groups []*auth_model.SCIMGroup, withMembers bool) ([]*scim_module.Group, error) {
+	memberIDs := map[int64][]int64{}
+	users := map[int64]*user_model.User{}
+	if withMembers && len(groups) > 0 {
+		groupIDs := make([]int64, 0, len(groups))
+		for _, group := range groups {
+			groupIDs = append(groupIDs, group.ID)
+		}
+		var err error
+		if memberIDs, err = auth_model.GetSCIMGroupMemberIDs(ctx, groupIDs...); err != nil {
+			return nil, err
+		}
+		userIDs := make(container.Set[int64])
+		for _, ids := range memberIDs {
+			userIDs.AddMultiple(ids...)
+		}
+		if users, err = user_model.GetUsersMapByIDs(ctx, userIDs.Values()); err != nil {
+			return nil, err
+		}
+	}
+
+	resources := make([]*scim_module.Group, 0, len(groups))
+	for _, group := range groups {
+		members := make([]*user_model.User, 0, len(memberIDs[group.ID]))
+		for _, userID := range memberIDs[group.ID] {
+			if u, ok := users[userID]; ok {
+				members = append(members, u)
+			}
+		}
+		resources = append(resources, toSCIMGroup(group, members))
+	}
+	return resources, nil
+}
+
+func getSourceGroup(ctx context.Context, source *auth_model.Source, id string) (*auth_model.SCIMGroup, error) {
+	groupID, err := parseID(id)
+	if err != nil {
+		return nil, err
+	}
+	return auth_model.GetSCIMGroupByID(ctx, source.ID, groupID)
+}
+
+// ListGroups returns the groups of the source matching the filter, the members are only loaded
+// if asked for or if the filter may need them
+func ListGroups(ctx context.Context, source *auth_model.Source, filter scim_module.Filter, withMembers bool) ([]*scim_module.Group, error) {
+	groups, err := auth_model.FindSCIMGroups(ctx, source.ID)
+	if err != nil {
+		return nil, err
+	}
+	resources, err := loadGroups(ctx, groups, withMembers || filter != nil)
+	if err != nil {
+		return nil, err
+	}
+	if resources, err = filterResources(resources, filter); err != nil {
+		return nil, err
+	}
+	if !withMembers {
+		for _, resource := range resources {
+			resource.Members = nil
+		}
+	}
+	return resources, nil
+}
+
+// GetGroup returns a group of the source
+func GetGroup(ctx context.Context, source *auth_model.Source, id string, withMembers bool) (*scim_module.Group, error) {
+	group, err := getSourceGroup(ctx, source, id)
+	if err != nil {
+		return nil, err
+	}
+	resources, err := loadGroups(ctx, []*auth_model.SCIMGroup{group}, withMembers)
+	if err != nil {
+		return nil, err
+	}
+	return resources[0], nil
+}
+
+// resolveMembers returns the IDs of the members, which must be users of the source
+func resolveMembers(ctx context.Context, source *auth_model.Source, members []scim_module.MultiValue) ([]int64, error) {
+	userIDs := make([]int64, 0, len(members))
+	for _, member := range members {
+		userID, err := strconv.ParseInt(member.Value, 10, 64)
+		if err != nil {
+			return nil, invalidValue("member %q is not a user", member.Value)
+		}
+		userIDs = append(userIDs, userID)
+	}
+	users, err := user_model.GetUsersMapByIDs(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, userID := range userIDs {
+		u, ok := users[userID]
+		if !ok || !u.IsIndividual() || u.LoginType != source.Type || u.LoginSource != source.ID {
+			return nil, invalidValue("member %d is not a user of the source", userID)
+		}
+	}
+	return userIDs, nil
+}
+
+func checkGroup(in *scim_module.Group) error {
+	if strings.TrimSpace(in.DisplayName) == "" {
+		return invalidValue("displayName is required")
+	}
+	return nil
+}
+
+func groupUniquenessError(err error) error {
+	if errors.Is(err, util.ErrAlreadyExist) {
+		return scim_module.NewError(http.StatusConflict, scim_module.ErrorUniqueness, "%v", err)
+	}
+	return err
+}
+
+// CreateGroup creates a group of the source and adds its members to the teams it is mapped to
+func CreateGroup(ctx context.Context, source *auth_model.Source, in *scim_module.Group) (*scim_module.Group, error) {
+	if err := checkGroup(in); err != nil {
+		return nil, err
+	}
+	userIDs, err := resolveMembers(ctx, source, in.Members)
+	if err != nil {
+		return nil, err
+	}
+
+	group := &auth_model.SCIMGroup{
+		SourceID:    source.ID,
+		DisplayName: in.DisplayName,
+		ExternalID:  in.ExternalID,
+	}
+	if err := auth_model.CreateSCIMGroup(ctx, group); err != nil {
+		return nil, groupUniquenessError(err)
+	}
+	added, _, err := auth_model.SetSCIMGroupMembers(ctx, group.ID, userIDs)
+	if err != nil {
+		return nil, err
+	}
+	if err := syncUsersTeams(ctx, source, added); err != nil {
+		return nil, err
+	}
+	return GetGroup(ctx, source, strconv.FormatInt(group.ID, 10), true)
+}
+
+func updateGroup(ctx context.Context, source *auth_model.Source, group *auth_model.SCIMGroup, in *scim_module.Group) (*scim_module.Group, error) {
+	if err := checkGroup(in); err != nil {
+		return nil, err
+	}
+	userIDs, err := resolveMembers(ctx, source, in.Members)
+	if err != nil {
+		return nil, err
+	}
+
+	renamed := group.DisplayName != in.DisplayName
+	group.DisplayName, group.ExternalID = in.DisplayName, in.ExternalID
+	if err := auth_model.UpdateSCIMGroup(ctx, group); err != nil {
+		return nil, groupUniquenessError(err)
+	}
+	added, removed, err := auth_model.SetSCIMGroupMembers(ctx, group.ID, userIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	// the teams of all the members change when the group is renamed
+	changed := append(added, removed...)
+	if renamed {
+		changed = append(userIDs, removed...)
+	}
+	if err := syncUsersTeams(ctx, source, container.SetOf(changed...).Values()); err != nil {
+		return nil, err
+	}
+	return GetGroup(ctx, source, strconv.FormatInt(group.ID, 10), true)
+}
+
+// ReplaceGroup replaces the display name and the members of a group of the source
+func ReplaceGroup(ctx context.Context, source *auth_model.Source, id string, in *scim_module.Group) (*scim_module.Group, error) {
+	group, err := getSourceGroup(ctx, source, id)
+	if err != nil {
+		return nil, err
+	}
+	return updateGroup(ctx, source, group, in)
+}
+
+// PatchGroup applies PATCH operations to a group of the source
+func PatchGroup(ctx context.Context, source *auth_model.Source, id string, operations []scim_module.PatchOperation) (*scim_module.Group, error) {
+	group, err := getSourceGroup(ctx, source, id)
+	if err != nil {
+		return nil, err
+	}
+	resources, err := loadGroups(ctx, []*auth_model.SCIMGroup{group}, true)
+	if err != nil {
+		return nil, err
+	}
+	patched, err := patchResource(resources[0], operations)
+	if err != nil {
+		return nil, err
+	}
+	return updateGroup(ctx, source, group, patched)
+}
+
+// DeleteGroup deletes a group of the source, its members leave the teams it is mapped to
+func DeleteGroup(ctx context.Context, source *auth_model.Source, id string) error {
+	group, err := getSourceGroup(ctx, source, id)
+	if err != nil {
+		return err
+	}
+	memberIDs, err := auth_model.GetSCIMGroupMemberIDs(ctx, group.ID)
+	if err != nil {
+		return err
+	}
+	if err := auth_model.DeleteSCIMGroup(ctx, group); err != nil {
+		return err
+	}
+	return syncUsersTeams(ctx, source, memberIDs[group.ID])
+}
@@ -0,0 +1,133 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+// Package scim provisions the users and the groups of an authentication source over SCIM 2.0
+package scim
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	auth_model "gitea.dev/models/auth"
+	user_model "gitea.dev/models/user"
+	auth_module "gitea.dev/modules/auth"
+	"gitea.dev/modules/container"
+	scim_module "gitea.dev/modules/scim"
+	"gitea.dev/modules/setting"
+	"gitea.dev/modules/timeutil"
+	"gitea.dev/modules/util"
+	source_service "gitea.dev/services/auth/source"
+)
+
+// EndpointURL returns the URL of the SCIM service provider, or of a resource path below it
+func EndpointURL(path string) string {
+	return setting.AppURL + "api/scim/v2/" + path
+}
+
+func formatTime(t timeutil.TimeStamp) string {
+	return t.AsTime().UTC().Format(time.RFC3339)
+}
+
+// parseID parses the ID of a resource, the resources which can't exist are not found
+func parseID(id string) (int64, error) {
+	n, err := strconv.ParseInt(id, 10, 64)
+	if err != nil || n <= 0 {
+		return 0, util.NewNotExistErrorf("resource %q not found", id)
+	}
+	return n, nil
+}
+
+func invalidValue(format string, args ...any) error {
+	return scim_module.NewError(http.StatusBadRequest, scim_module.ErrorInvalidValue, format, args...)
+}
+
+// getSourceUser returns a user provisioned by the source
+func getSourceUser(ctx context.Context, source *auth_model.Source, id string) (*user_model.User, error) {
+	userID, err := parseID(id)
+	if err != nil {
+		return nil, err
+	}
+	u, err := user_model.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !u.IsIndividual() || u.LoginType != source.Type || u.LoginSource != source.ID {
+		return nil, user_model.ErrUserNotExist{UID: userID}
+	}
+	return u, nil
+}
+
+// syncUserTeams maps the SCIM groups of the user to organization teams with the group team map of the source
+func syncUserTeams(ctx context.Context, source *auth_model.Source, u *user_model.User) error {
+	mapper, ok := source.Cfg.(auth_model.GroupTeamMapper)
+	if !ok {
+		return nil
+	}
+	groupTeamMap, performRemoval := mapper.GetGroupTeamMap()
+	if groupTeamMap == "" && !performRemoval {
+		return nil
+	}
+	groupTeamMapping, err := auth_module.UnmarshalGroupTeamMapping(groupTeamMap)
+	if err != nil {
+		return err
+	}
+
+	groups, err := auth_model.GetUserSCIMGroups(ctx, source.ID, u.ID)
+	if err != nil {
+		return err
+	}
+	groupNames := make(container.Set[string], len(groups))
+	for _, group := range groups {
+		groupNames.Add(group.DisplayName)
+	}
+	return source_service.SyncGroupsToTeams(ctx, u, groupNames, groupTeamMapping, performRemoval)
+}
+
+// syncUsersTeams maps the SCIM groups of the users to organization teams
+func syncUsersTeams(ctx context.Context, source *auth_model.Source, userIDs []int64) error {
+	users, err := user_model.GetUsersMapByIDs(ctx, userIDs)
+	if err != nil {
+		return err
+	}
+	for _, userID := range userIDs {
+		if u, ok := users[userID]; ok {
+			if err := syncUserTeams(ctx, source, u); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// filterResources returns the resources matching the filter
+func filterResources[T any](resources []T, filter scim_module.Filter) ([]T, error) {
+	if filter == nil {
+		return resources, nil
+	}
+	matched := make([]T, 0, len(resources))
+	for _, resource := range resources {
+		m, err := scim_module.ToResource(resource)
+		if err != nil {
+			return nil, err
+		}
+		if filter.Match(m) {
+			matched = append(matched, resource)
+		}
+	}
+	return matched, nil
+}
+
+// patchResource applies the PATCH operations to a resource, into a new resource of the same type
+func patchResource[T any](resource *T, operations []scim_module.PatchOperation) (*T, error) {
+	m, err := scim_module.ToResource(resource)
+	if err != nil {
+		return nil, err
+	}
+	if err := scim_module.ApplyPatch(m, operations); err != nil {
+		return nil, err
+	}
+	patched := new(T)
+	return patched, scim_module.FromResource(m, patched)
+}
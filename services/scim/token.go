@@ -0,0 +1,39 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package scim
+
+import (
+	"context"
+
+	audit_model "gitea.dev/models/audit"
+	auth_model "gitea.dev/models/auth"
+)
+
+func recordTokenEvent(ctx context.Context, action audit_model.Action, source *auth_model.Source) {
+	audit_model.Record(ctx, &audit_model.Event{
+		Action:     action,
+		TargetType: audit_model.TargetAuthSource,
+		TargetID:   source.ID,
+		TargetName: source.Name,
+	})
+}
+
+// GenerateToken generates the SCIM token of the source, the previous one stops working
+func GenerateToken(ctx context.Context, source *auth_model.Source) (*auth_model.SCIMToken, error) {
+	t, err := auth_model.GenerateSCIMToken(ctx, source.ID)
+	if err != nil {
+		return nil, err
+	}
+	recordTokenEvent(ctx, audit_model.ActionSCIMTokenGenerate, source)
+	return t, nil
+}
+
+// RevokeToken deletes the SCIM token of the source, the users and the groups it provisioned are kept
+func RevokeToken(ctx context.Context, source *auth_model.Source) error {
+	if err := auth_model.DeleteSCIMToken(ctx, source.ID); err != nil {
+		return err
+	}
+	recordTokenEvent(ctx, audit_model.ActionSCIMTokenRevoke, source)
+	return nil
+}
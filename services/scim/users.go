@@ -0,0 +1,276 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package scim
+
+import (
+	"cmp"
+	"context"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	auth_model "gitea.dev/models/auth"
+	"gitea.dev/models/organization"
+	packages_model "gitea.dev/models/packages"
+	repo_model "gitea.dev/models/repo"
+	user_model "gitea.dev/models/user"
+	"gitea.dev/modules/log"
+	"gitea.dev/modules/optional"
+	scim_module "gitea.dev/modules/scim"
+	user_service "gitea.dev/services/user"
+)
+
+func toSCIMUser(u *user_model.User, scimUser *auth_model.SCIMUser, groups []*auth_model.SCIMGroup) *scim_module.User {
+	id := strconv.FormatInt(u.ID, 10)
+	active := scim_module.Bool(u.IsActive)
+	user := &scim_module.User{
+		Schemas:     []string{scim_module.SchemaUser},
+		ID:          id,
+		UserName:    u.LoginName,
+		DisplayName: u.DisplayName(),
+		Active:      &active,
+		Emails:      []scim_module.MultiValue{{Value: u.Email, Type: "work", Primary: true}},
+		Meta: &scim_module.Meta{
+			ResourceType: "User",
+			Created:      formatTime(u.CreatedUnix),
+			LastModified: formatTime(u.UpdatedUnix),
+			Location:     EndpointURL("Users/" + id),
+		},
+	}
+	if user.UserName == "" {
+		user.UserName = u.Name
+	}
+	if u.FullName != "" {
+		user.Name = &scim_module.Name{Formatted: u.FullName}
+	}
+	if scimUser != nil {
+		user.ExternalID = scimUser.ExternalID
+	}
+	for _, group := range groups {
+		groupID := strconv.FormatInt(group.ID, 10)
+		user.Groups = append(user.Groups, scim_module.MultiValue{
+			Value:   groupID,
+			Display: group.DisplayName,
+			Ref:     EndpointURL("Groups/" + groupID),
+		})
+	}
+	return user
+}
+
+func loadSCIMUser(ctx context.Context, source *auth_model.Source, u *user_model.User) (*scim_module.User, error) {
+	scimUser, _, err := auth_model.GetSCIMUser(ctx, source.ID, u.ID)
+	if err != nil {
+		return nil, err
+	}
+	groups, err := auth_model.GetUserSCIMGroups(ctx, source.ID, u.ID)
+	if err != nil {
+		return nil, err
+	}
+	return toSCIMUser(u, scimUser, groups), nil
+}
+
+// ListUsers returns the users of the source matching the filter
+func ListUsers(ctx context.Context, source *auth_model.Source, filter scim_module.Filter) ([]*scim_module.User, error) {
+	var users []*user_model.User
+	var err error
+	// the identity providers look their users up by userName before provisioning them
+	if userName, ok := scim_module.EqualityValue(filter, "userName"); ok {
+		users, err = user_model.GetUsersBySourceLoginName(ctx, source, userName)
+	} else {
+		users, err = user_model.GetUsersBySource(ctx, source)
+	}
+	if err != nil {
+		return nil, err
+	}
+	users = slices.DeleteFunc(users, func(u *user_model.User) bool { return !u.IsIndividual() })
+	slices.SortFunc(users, func(a, b *user_model.User) int { return cmp.Compare(a.ID, b.ID) })
+
+	scimUsers, err := auth_model.GetSCIMUsers(ctx, source.ID)
+	if err != nil {
+		return nil, err
+	}
+	groups, err := auth_model.FindSCIMGroups(ctx, source.ID)
+	if err != nil {
+		return nil, err
+	}
+	userGroups := make(map[int64][]*auth_model.SCIMGroup)
+	if len(groups) > 0 {
+		groupIDs := make([]int64, 0, len(groups))
+		for _, group := range groups {
+			groupIDs = append(groupIDs, group.ID)
+		}
+		memberIDs, err := auth_model.GetSCIMGroupMemberIDs(ctx, groupIDs...)
+		if err != nil {
+			return nil, err
+		}
+		for _, group := range groups {
+			for _, userID := range memberIDs[group.ID] {
+				userGroups[userID] = append(userGroups[userID], group)
+			}
+		}
+	}
+
+	resources := make([]*scim_module.User, 0, len(users))
+	for _, u := range users {
+		resources = append(resources, toSCIMUser(u, scimUsers[u.ID], userGroups[u.ID]))
+	}
+	return filterResources(resources, filter)
+}
+
+// GetUser returns a user of the source
+func GetUser(ctx context.Context, source *auth_model.Source, id string) (*scim_module.User, error) {
+	u, err := getSourceUser(ctx, source, id)
+	if err != nil {
+		return nil, err
+	}
+	return loadSCIMUser(ctx, source, u)
+}
+
+// checkUserName checks that no other user of the source has the user name
+func checkUserName(ctx context.Context, source *auth_model.Source, userName string, userID int64) error {
+	if strings.TrimSpace(userName) == "" {
+		return invalidValue("userName is required")
+	}
+	users, err := user_model.GetUsersBySourceLoginName(ctx, source, userName)
+	if err != nil {
+		return err
+	}
+	for _, u := range users {
+		if u.ID != userID {
+			return scim_module.NewError(http.StatusConflict, scim_module.ErrorUniqueness, "userName %q is already used", userName)
+		}
+	}
+	return nil
+}
+
+// CreateUser creates a user of the source
+func CreateUser(ctx context.Context, source *auth_model.Source, in *scim_module.User) (*scim_module.User, error) {
+	if err := checkUserName(ctx, source, in.UserName, 0); err != nil {
+		return nil, err
+	}
+	email := in.PrimaryEmail()
+	if email == "" {
+		return nil, invalidValue("an email is required")
+	}
+	name, err := user_model.NormalizeUserName(in.UserName)
+	if err != nil {
+		return nil, invalidValue("%v", err)
+	}
+
+	u := &user_model.User{
+		Name:        name,
+		FullName:    in.FullName(),
+		Email:       email,
+		LoginType:   source.Type,
+		LoginSource: source.ID,
+		LoginName:   in.UserName,
+	}
+	overwriteDefault := &user_model.CreateUserOverwriteOptions{
+		IsActive: optional.Some(in.IsActive()),
+	}
+	if err := user_model.CreateUser(ctx, u, &user_model.Meta{}, overwriteDefault); err != nil {
+		if user_model.IsErrUserAlreadyExist(err) || user_model.IsErrEmailAlreadyUsed(err) {
+			return nil, scim_module.NewError(http.StatusConflict, scim_module.ErrorUniqueness, "%v", err)
+		}
+		return nil, err
+	}
+	if err := auth_model.SetSCIMUserExternalID(ctx, source.ID, u.ID, in.ExternalID); err != nil {
+		return nil, err
+	}
+	return loadSCIMUser(ctx, source, u)
+}
+
+func updateUser(ctx context.Context, source *auth_model.Source, u *user_model.User, in *scim_module.User) (*scim_module.User, error) {
+	if err := checkUserName(ctx, source, in.UserName, u.ID); err != nil {
+		return nil, err
+	}
+	if in.UserName != u.LoginName {
+		u.LoginName = in.UserName
+		if err := user_model.UpdateUserCols(ctx, u, "login_name"); err != nil {
+			return nil, err
+		}
+	}
+
+	opts := &user_service.UpdateOptions{
+		FullName: optional.Some(in.FullName()),
+		IsActive: optional.Some(in.IsActive()),
+	}
+	if err := user_service.UpdateUser(ctx, u, opts); err != nil {
+		return nil, err
+	}
+	if email := in.PrimaryEmail(); email != "" {
+		if err := user_service.ReplacePrimaryEmailAddress(ctx, u, email); err != nil {
+			if user_model.IsErrEmailAlreadyUsed(err) {
+				return nil, scim_module.NewError(http.StatusConflict, scim_module.ErrorUniqueness, "%v", err)
+			}
+			return nil, err
+		}
+	}
+	if err := auth_model.SetSCIMUserExternalID(ctx, source.ID, u.ID, in.ExternalID); err != nil {
+		return nil, err
+	}
+	return loadSCIMUser(ctx, source, u)
+}
+
+// ReplaceUser replaces the attributes of a user of the source, the users which are not active are deactivated
+func ReplaceUser(ctx context.Context, source *auth_model.Source, id string, in *scim_module.User) (*scim_module.User, error) {
+	u, err := getSourceUser(ctx, source, id)
+	if err != nil {
+		return nil, err
+	}
+	return updateUser(ctx, source, u, in)
+}
+
+// PatchUser applies PATCH operations to a user of the source
+func PatchUser(ctx context.Context, source *auth_model.Source, id string, operations []scim_module.PatchOperation) (*scim_module.User, error) {
+	u, err := getSourceUser(ctx, source, id)
+	if err != nil {
+		return nil, err
+	}
+	current, err := loadSCIMUser(ctx, source, u)
+	if err != nil {
+		return nil, err
+	}
+	patched, err := patchResource(current, operations)
+	if err != nil {
+		return nil, err
+	}
+	return updateUser(ctx, source, u, patched)
+}
+
+// DeleteUser deletes a user of the source. The user is deactivated and leaves its groups first,
+// so it stays deactivated if it still owns resources which prevent the deletion.
+func DeleteUser(ctx context.Context, source *auth_model.Source, id string) error {
+	u, err := getSourceUser(ctx, source, id)
+	if err != nil {
+		return err
+	}
+	if err := user_service.UpdateUser(ctx, u, &user_service.UpdateOptions{IsActive: optional.Some(false)}); err != nil {
+		return err
+	}
+
+	groups, err := auth_model.GetUserSCIMGroups(ctx, source.ID, u.ID)
+	if err != nil {
+		return err
+	}
+	if len(groups) > 0 {
+		if err := auth_model.DeleteUserSCIMGroupMemberships(ctx, u.ID); err != nil {
+			return err
+		}
+		if err := syncUserTeams(ctx, source, u); err != nil {
+			return err
+		}
+	}
+
+	if err := user_service.DeleteUser(ctx, u, false); err != nil {
+		if repo_model.IsErrUserOwnRepos(err) || organization.IsErrUserHasOrgs(err) ||
+			packages_model.IsErrUserOwnPackages(err) || user_model.IsErrDeleteLastAdminUser(err) {
+			log.Warn("SCIM[%s]: user %s has been deactivated but can't be deleted: %v", source.Name, u.Name, err)
+			return scim_module.NewError(http.StatusConflict, "", "the user has been deactivated but can't be deleted: %v", err)
+		}
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,40 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package secretscan
+
+import (
+	"context"
+
+	repo_model "gitea.dev/models/repo"
+	user_model "gitea.dev/models/user"
+	"gitea.dev/modules/repository"
+	notify_service "gitea.dev/services/notify"
+)
+
+type secretScanningNotifier struct {
+	notify_service.NullNotifier
+}
+
+var _ notify_service.Notifier = &secretScanningNotifier{}
+
+// NewNotifier create a new secretScanningNotifier notifier
+func NewNotifier() notify_service.Notifier {
+	return &secretScanningNotifier{}
+}
+
+// the pushes are scanned again once accepted, the secrets let through by the push protection or matched
+// by the patterns not protecting pushes become alerts
+func (n *secretScanningNotifier) PushCommits(ctx context.Context, pusher *user_model.User, repo *repo_model.Repository, opts *repository.PushUpdateOptions, commits *repository.PushCommits) {
+	if opts.IsDelRef() {
+		return
+	}
+	addPushToQueue(repo.ID, opts.RefFullName, opts.NewCommitID)
+}
+
+func (n *secretScanningNotifier) SyncPushCommits(ctx context.Context, pusher *user_model.User, repo *repo_model.Repository, opts *repository.PushUpdateOptions, commits *repository.PushCommits) {
+	if opts.IsDelRef() {
+		return
+	}
+	addPushToQueue(repo.ID, opts.RefFullName, opts.NewCommitID)
+}
@@ -0,0 +1,261 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package secretscan
+
+import (
+	"bufio"
+	"cmp"
+	"context"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+
+	"gitea.dev/models/db"
+	repo_model "gitea.dev/models/repo"
+	secretscan_model "gitea.dev/models/secretscan"
+	"gitea.dev/modules/base"
+	"gitea.dev/modules/git"
+	"gitea.dev/modules/git/gitcmd"
+	"gitea.dev/modules/log"
+	"gitea.dev/modules/secretscan"
+	"gitea.dev/modules/setting"
+)
+
+// maxBatchBytes limits the size of the blobs read by a single cat-file
+const maxBatchBytes = 16 << 20
+
+// Detection is a secret found in a file of a commit
+type Detection struct {
+	*secretscan.Finding
+	CommitID string
+	Path     string
+
+	// age is the position of the commit in the scanned history, the older the commit the larger it is
+	age int
+}
+
+// String returns a readable description of the detection, the secret is redacted
+func (d *Detection) String() string {
+	return fmt.Sprintf("%s (%s) in %s:%d of commit %s", d.Pattern.Name, secretscan.Redact(d.Secret), d.Path, d.Line, base.ShortSha(d.CommitID))
+}
+
+func (d *Detection) toAlert(repoID int64) *secretscan_model.Alert {
+	return &secretscan_model.Alert{
+		RepoID:         repoID,
+		SecretHash:     d.SecretHash(),
+		PatternID:      d.Pattern.ID,
+		PatternName:    d.Pattern.Name,
+		SecretRedacted: secretscan.Redact(d.Secret),
+		CommitID:       d.CommitID,
+		Path:           d.Path,
+		Line:           d.Line,
+	}
+}
+
+// NewRepoScanner returns a scanner with the built-in patterns, the custom patterns of the instance and of the owner of the repository
+func NewRepoScanner(ctx context.Context, repo *repo_model.Repository) (*secretscan.Scanner, error) {
+	patterns := secretscan.BuiltinPatterns()
+	custom, err := db.Find[secretscan_model.CustomPattern](ctx, secretscan_model.FindCustomPatternsOptions{
+		OwnerID:         repo.OwnerID,
+		IncludeInstance: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range custom {
+		compiled, err := p.Compile()
+		if err != nil {
+			// the patterns are validated when they are saved, the regexp syntax could only change with Go
+			log.Warn("Secret scanning pattern %d can't be compiled: %v", p.ID, err)
+			continue
+		}
+		patterns = append(patterns, compiled)
+	}
+	return secretscan.NewScanner(patterns), nil
+}
+
+type blobLocation struct {
+	commitID string
+	path     string
+	age      int
+}
+
+// scanRevs scans the files added or modified by the commits of the revisions, the whole history when revs is empty.
+// A secret is reported once, in the oldest scanned commit adding it.
+func scanRevs(ctx context.Context, gitRepo *git.Repository, env, revs []string, scanner *secretscan.Scanner) ([]*Detection, error) {
+	// merges are compared with their first parent, so the changes they bring on their own (evil merges) are scanned too,
+	// the files of the merged branch are scanned with the commits which changed them
+	// the topological order lists the commits after their descendants, even when the commit dates don't
+	cmd := gitcmd.NewCommand("log", "--format=format:%H", "--raw", "--no-abbrev", "--no-renames", "-r", "--topo-order")
+	if git.DefaultFeatures().CheckVersionAtLeast("2.31") {
+		cmd.AddArguments("--diff-merges=first-parent")
+	} else {
+		// older versions compare merges with every parent
+		cmd.AddArguments("-m")
+	}
+	if len(revs) == 0 {
+		cmd.AddArguments("--all")
+	} else {
+		cmd.AddDynamicArguments(revs[0]).AddArguments(gitcmd.ToTrustedCmdArgs(revs[1:])...)
+	}
+	stdoutReader, stdoutReaderClose := cmd.MakeStdoutPipe()
+	defer stdoutReaderClose()
+
+	// the commits are listed from the newest, the location of a blob ends up being the oldest commit adding it
+	blobs := make(map[string]blobLocation)
+	err := cmd.WithEnv(env).WithRepo(gitRepo).
+		WithPipelineFunc(func(gitcmd.Context) error {
+			var commitID string
+			age := 0
+			lines := bufio.NewScanner(stdoutReader)
+			for lines.Scan() {
+				line := lines.Text()
+				if line == "" {
+					continue
+				}
+				if !strings.HasPrefix(line, ":") {
+					commitID = strings.TrimSpace(line)
+					age++
+					continue
+				}
+				// :100644 100644 <old blob> <new blob> M\tpath
+				meta, path, ok := strings.Cut(line, "\t")
+				fields := strings.Fields(meta)
+				if commitID == "" || !ok || len(fields) < 5 || fields[4] == "D" || fields[1] == "160000" {
+					continue
+				}
+				blobs[fields[3]] = blobLocation{commitID: commitID, path: path, age: age}
+			}
+			return lines.Err()
+		}).
+		Run(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("log: %w", err)
+	}
+	if len(blobs) == 0 {
+		return nil, nil
+	}
+
+	ids := make([]string, 0, len(blobs))
+	for id := range blobs {
+		ids = append(ids, id)
+	}
+	stdout, _, err := gitcmd.NewCommand("cat-file", "--batch-check=%(objectname) %(objectsize)").
+		WithStdinBytes([]byte(strings.Join(ids, "\n") + "\n")).
+		WithEnv(env).WithRepo(gitRepo).RunStdString(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("cat-file --batch-check: %w", err)
+	}
+
+	var detections []*Detection
+	var batch []string
+	var batchSize int64
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		found, err := scanBlobs(ctx, gitRepo, env, batch, blobs, scanner)
+		if err != nil {
+			return err
+		}
+		detections = append(detections, found...)
+		batch, batchSize = batch[:0], 0
+		return nil
+	}
+	for line := range strings.SplitSeq(strings.TrimSpace(stdout), "\n") {
+		id, rawSize, _ := strings.Cut(line, " ")
+		size, err := strconv.ParseInt(rawSize, 10, 64)
+		if err != nil || size == 0 {
+			continue // missing or empty
+		}
+		size = scannedSize(size)
+		if batchSize+size > maxBatchBytes {
+			if err := flush(); err != nil {
+				return nil, err
+			}
+		}
+		batch, batchSize = append(batch, id), batchSize+size
+	}
+	if err := flush(); err != nil {
+		return nil, err
+	}
+	return dedupDetections(detections), nil
+}
+
+// scannedSize returns how many bytes of a blob are scanned, only the beginning of a blob larger than MaxFileSize is
+func scannedSize(size int64) int64 {
+	if setting.SecretScanning.MaxFileSize > 0 {
+		return min(size, setting.SecretScanning.MaxFileSize)
+	}
+	return size
+}
+
+// scanBlobs scans the blobs of the ids, only the first MaxFileSize bytes of a larger blob are scanned
+func scanBlobs(ctx context.Context, gitRepo *git.Repository, env, ids []string, blobs map[string]blobLocation, scanner *secretscan.Scanner) ([]*Detection, error) {
+	cmd := gitcmd.NewCommand("cat-file", "--batch").WithStdinBytes([]byte(strings.Join(ids, "\n") + "\n"))
+	stdoutReader, stdoutReaderClose := cmd.MakeStdoutPipe()
+	defer stdoutReaderClose()
+
+	var detections []*Detection
+	err := cmd.WithEnv(env).WithRepo(gitRepo).
+		WithPipelineFunc(func(gitcmd.Context) error {
+			rd := bufio.NewReader(stdoutReader)
+			for {
+				header, err := rd.ReadString('\n')
+				if err == io.EOF {
+					return nil
+				} else if err != nil {
+					return err
+				}
+				// <oid> blob <size>, or <oid> missing
+				fields := strings.Fields(header)
+				if len(fields) != 3 {
+					continue
+				}
+				size, err := strconv.ParseInt(fields[2], 10, 64)
+				if err != nil {
+					return fmt.Errorf("invalid header %q", header)
+				}
+				content := make([]byte, scannedSize(size))
+				if _, err := io.ReadFull(rd, content); err != nil {
+					return err
+				}
+				// the rest of a larger blob and the newline following the content are skipped
+				if _, err := io.CopyN(io.Discard, rd, size-int64(len(content))+1); err != nil {
+					return err
+				}
+				findings, err := scanner.Scan(ctx, content)
+				if err != nil {
+					return err
+				}
+				loc := blobs[fields[0]]
+				for _, f := range findings {
+					detections = append(detections, &Detection{Finding: f, CommitID: loc.commitID, Path: loc.path, age: loc.age})
+				}
+			}
+		}).
+		Run(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("cat-file --batch: %w", err)
+	}
+	return detections, nil
+}
+
+// dedupDetections keeps the oldest detection of each secret, the detections are ordered from the oldest commit
+func dedupDetections(detections []*Detection) []*Detection {
+	slices.SortStableFunc(detections, func(a, b *Detection) int {
+		return cmp.Or(cmp.Compare(b.age, a.age), strings.Compare(a.Path, b.Path), cmp.Compare(a.Line, b.Line))
+	})
+	seen := make(map[string]bool, len(detections))
+	res := detections[:0]
+	for _, d := range detections {
+		if hash := d.SecretHash(); !seen[hash] {
+			seen[hash] = true
+			res = append(res, d)
+		}
+	}
+	return res
+}
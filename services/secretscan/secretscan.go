@@ -0,0 +1,234 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package secretscan
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	audit_model "gitea.dev/models/audit"
+	auth_model "gitea.dev/models/auth"
+	repo_model "gitea.dev/models/repo"
+	secretscan_model "gitea.dev/models/secretscan"
+	user_model "gitea.dev/models/user"
+	"gitea.dev/modules/git"
+	"gitea.dev/modules/graceful"
+	"gitea.dev/modules/log"
+	"gitea.dev/modules/process"
+	"gitea.dev/modules/queue"
+	"gitea.dev/modules/secretscan"
+	"gitea.dev/modules/setting"
+	"gitea.dev/modules/util"
+	notify_service "gitea.dev/services/notify"
+)
+
+// scanRequest asks for the history of a repository to be scanned, only the commits NewCommitID adds to RefName when it is set
+type scanRequest struct {
+	RepoID      int64
+	RefName     git.RefName
+	NewCommitID string
+}
+
+var scanQueue *queue.WorkerPoolQueue[*scanRequest]
+
+// Init registers the verification of the access tokens and runs the queue scanning the history of the repositories
+func Init() error {
+	if !setting.SecretScanning.Enabled {
+		return nil
+	}
+
+	secretscan.RegisterVerifier(secretscan.GiteaAccessTokenPatternID, verifyAccessToken)
+	notify_service.RegisterNotifier(NewNotifier())
+
+	scanQueue = queue.CreateUniqueQueue(graceful.GetManager().ShutdownContext(), "secret_scanning", handler)
+	if scanQueue == nil {
+		return errors.New("unable to create secret_scanning queue")
+	}
+	go graceful.GetManager().RunWithCancel(scanQueue)
+	return nil
+}
+
+func verifyAccessToken(ctx context.Context, secret string) (bool, error) {
+	_, err := auth_model.GetAccessTokenBySHA(ctx, secret)
+	if errors.Is(err, util.ErrNotExist) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func handler(items ...*scanRequest) []*scanRequest {
+	for _, req := range items {
+		if err := scanRepository(graceful.GetManager().ShutdownContext(), req); err != nil {
+			log.Error("Secret scanning of repository %d failed: %v", req.RepoID, err)
+		}
+	}
+	return nil
+}
+
+// AddRepoToQueue schedules the scan of the whole history of a repository
+func AddRepoToQueue(repo *repo_model.Repository) error {
+	if scanQueue == nil {
+		return util.NewInvalidArgumentErrorf("secret scanning is disabled")
+	}
+	if err := scanQueue.Push(&scanRequest{RepoID: repo.ID}); err != nil && !errors.Is(err, queue.ErrAlreadyInQueue) {
+		return err
+	}
+	return nil
+}
+
+func addPushToQueue(repoID int64, refName git.RefName, newCommitID string) {
+	req := &scanRequest{RepoID: repoID, RefName: refName, NewCommitID: newCommitID}
+	if err := scanQueue.Push(req); err != nil && !errors.Is(err, queue.ErrAlreadyInQueue) {
+		log.Error("Unable to add the push to %s of repository %d to the secret scanning queue: %v", refName, repoID, err)
+	}
+}
+
+func scanRepository(ctx context.Context, req *scanRequest) error {
+	repo, err := repo_model.GetRepositoryByID(ctx, req.RepoID)
+	if errors.Is(err, util.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	if repo.IsEmpty || repo.IsBeingCreated() {
+		return nil
+	}
+
+	ctx, _, finished := process.GetManager().AddContext(ctx, fmt.Sprintf("Secret scanning of %s", repo.FullName()))
+	defer finished()
+
+	gitRepo, err := git.OpenRepository(ctx, repo)
+	if err != nil {
+		return err
+	}
+	defer gitRepo.Close()
+
+	var revs []string
+	if req.NewCommitID != "" {
+		revs = pushRevs(req.RefName, req.NewCommitID, true)
+	}
+	scanner, err := NewRepoScanner(ctx, repo)
+	if err != nil {
+		return err
+	}
+	detections, err := scanRevs(ctx, gitRepo, nil, revs, scanner)
+	if err != nil {
+		return err
+	}
+
+	count := 0
+	for _, d := range detections {
+		created, err := secretscan_model.CreateAlertIfNotExist(ctx, d.toAlert(repo.ID))
+		if err != nil {
+			return err
+		}
+		if created {
+			count++
+		}
+	}
+	if count > 0 {
+		log.Info("Secret scanning found %d new secrets in %s", count, repo.FullName())
+	}
+	return nil
+}
+
+// pushRevs returns the revisions of the commits added by an update of a ref: the commits of no other ref. Before the push
+// is accepted the ref still points to the old commit, so the commits of a force push which aren't part of the old history
+// are scanned as well. After the push the ref itself is excluded.
+func pushRevs(refName git.RefName, newCommitID string, pushed bool) []string {
+	if pushed {
+		return []string{newCommitID, "--not", "--exclude=" + refName.String(), "--all"}
+	}
+	return []string{newCommitID, "--not", "--all"}
+}
+
+// PushOptions describe an update of a ref in the pre-receive hook
+type PushOptions struct {
+	Repo        *repo_model.Repository
+	GitRepo     *git.Repository
+	RefName     git.RefName
+	NewCommitID string
+	// Env gives access to the objects of the push in quarantine
+	Env []string
+}
+
+// Detections are the secrets found by a scan
+type Detections []*Detection
+
+// String lists the detections one per line
+func (ds Detections) String() string {
+	lines := make([]string, 0, len(ds))
+	for _, d := range ds {
+		lines = append(lines, "  - "+d.String())
+	}
+	return strings.Join(lines, "\n")
+}
+
+// FindBlockedSecrets returns the secrets added by a push which must be rejected by the push protection: the ones matched
+// by a pattern protecting pushes which aren't known in the repository. The secrets which already have an alert,
+// resolved or not, are let through.
+func FindBlockedSecrets(ctx context.Context, opts *PushOptions) (Detections, error) {
+	if !setting.SecretScanning.Enabled || !setting.SecretScanning.PushProtection || git.IsEmptyCommitID(opts.NewCommitID) {
+		return nil, nil
+	}
+
+	scanner, err := NewRepoScanner(ctx, opts.Repo)
+	if err != nil {
+		return nil, err
+	}
+	protecting := make([]*secretscan.Pattern, 0, len(scanner.Patterns()))
+	for _, p := range scanner.Patterns() {
+		if p.PushProtection {
+			protecting = append(protecting, p)
+		}
+	}
+	if len(protecting) == 0 {
+		return nil, nil
+	}
+
+	detections, err := scanRevs(ctx, opts.GitRepo, opts.Env, pushRevs(opts.RefName, opts.NewCommitID, false), secretscan.NewScanner(protecting))
+	if err != nil || len(detections) == 0 {
+		return nil, err
+	}
+	hashes := make([]string, 0, len(detections))
+	for _, d := range detections {
+		hashes = append(hashes, d.SecretHash())
+	}
+	alerts, err := secretscan_model.GetAlertsBySecretHashes(ctx, opts.Repo.ID, hashes)
+	if err != nil {
+		return nil, err
+	}
+	blocked := make(Detections, 0, len(detections))
+	for _, d := range detections {
+		if _, ok := alerts[d.SecretHash()]; !ok {
+			blocked = append(blocked, d)
+		}
+	}
+	return blocked, nil
+}
+
+// BypassPushProtection records that the blocked secrets of a push were pushed anyway by a repository administrator
+func BypassPushProtection(ctx context.Context, repo *repo_model.Repository, doer *user_model.User, detections Detections, reason secretscan_model.BypassReason) error {
+	for _, d := range detections {
+		alert, err := secretscan_model.BypassPushProtection(ctx, d.toAlert(repo.ID), doer.ID, reason)
+		if err != nil {
+			return err
+		}
+		log.Info("Push protection of %s bypassed by %s for secret scanning alert %d (%s)", repo.FullName(), doer.Name, alert.ID, reason)
+		audit_model.Record(ctx, &audit_model.Event{
+			Action:     audit_model.ActionSecretScanningBypass,
+			DoerID:     doer.ID,
+			DoerName:   doer.Name,
+			OwnerID:    repo.OwnerID,
+			RepoID:     repo.ID,
+			TargetType: audit_model.TargetSecretScanningAlert,
+			TargetID:   alert.ID,
+			TargetName: alert.PatternName,
+			Metadata:   map[string]string{"reason": string(reason)},
+		})
+	}
+	return nil
+}
@@ -18,6 +18,7 @@ import (
 	"gitea.dev/models/organization"
 	access_model "gitea.dev/models/perm/access"
 	pull_model "gitea.dev/models/pull"
+	quota_model "gitea.dev/models/quota"
 	repo_model "gitea.dev/models/repo"
 	user_model "gitea.dev/models/user"
 	"gitea.dev/modules/setting"
@@ -98,8 +99,13 @@ func deleteUser(ctx context.Context, u *user_model.User, purge bool) (err error)
 		&actions_model.ActionScopedWorkflowSource{OwnerID: u.ID},
 		&auth_model.TwoFactor{UID: u.ID},
 		&auth_model.WebAuthnCredential{UserID: u.ID},
+		&auth_model.SCIMUser{UserID: u.ID},
+		&auth_model.SCIMGroupMember{UserID: u.ID},
 		&activities_model.Notification{UserID: u.ID},
 		&issues_model.IssueWatch{UserID: u.ID},
+		&quota_model.GroupUser{UserID: u.ID},
+		&quota_model.UserRule{UserID: u.ID},
+		&quota_model.Usage{UserID: u.ID},
 	); err != nil {
 		return fmt.Errorf("deleteBeans: %w", err)
 	}
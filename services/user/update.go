@@ -6,7 +6,9 @@ package user
 import (
 	"context"
 	"fmt"
+	"strconv"
 
+	audit_model "gitea.dev/models/audit"
 	auth_model "gitea.dev/models/auth"
 	user_model "gitea.dev/models/user"
 	password_module "gitea.dev/modules/auth/password"
@@ -60,6 +62,7 @@ type UpdateOptions struct {
 
 func UpdateUser(ctx context.Context, u *user_model.User, opts *UpdateOptions) error {
 	cols := make([]string, 0, 20)
+	wasActive, wasRestricted, wasAdmin := u.IsActive, u.IsRestricted, u.IsAdmin
 
 	if opts.KeepEmailPrivate.Has() {
 		u.KeepEmailPrivate = opts.KeepEmailPrivate.Value()
@@ -183,7 +186,34 @@ func UpdateUser(ctx context.Context, u *user_model.User, opts *UpdateOptions) er
 		cols = append(cols, "last_login_unix")
 	}
 
-	return user_model.UpdateUserCols(ctx, u, cols...)
+	if err := user_model.UpdateUserCols(ctx, u, cols...); err != nil {
+		return err
+	}
+
+	metadata := map[string]string{}
+	if u.IsActive != wasActive {
+		metadata["active"] = strconv.FormatBool(u.IsActive)
+	}
+	if u.IsRestricted != wasRestricted {
+		metadata["restricted"] = strconv.FormatBool(u.IsRestricted)
+	}
+	if u.IsAdmin != wasAdmin {
+		metadata["admin"] = strconv.FormatBool(u.IsAdmin)
+	}
+	if len(metadata) > 0 {
+		recordUserEvent(ctx, audit_model.ActionUserPermissionsChange, u, metadata)
+	}
+	return nil
+}
+
+func recordUserEvent(ctx context.Context, action audit_model.Action, u *user_model.User, metadata map[string]string) {
+	audit_model.Record(ctx, &audit_model.Event{
+		Action:     action,
+		TargetType: audit_model.TargetUser,
+		TargetID:   u.ID,
+		TargetName: u.Name,
+		Metadata:   metadata,
+	})
 }
 
 type UpdateAuthOptions struct {
@@ -208,6 +238,7 @@ func UpdateAuth(ctx context.Context, u *user_model.User, opts *UpdateAuthOptions
 		u.LoginName = opts.LoginName.Value()
 	}
 
+	wasProhibitLogin := u.ProhibitLogin
 	deleteAuthTokens := false
 	if opts.Password.Has() && (u.IsLocal() || u.IsOAuth2()) {
 		password := opts.Password.Value()
@@ -240,6 +271,13 @@ func UpdateAuth(ctx context.Context, u *user_model.User, opts *UpdateAuthOptions
 		return err
 	}
 
+	if deleteAuthTokens {
+		recordUserEvent(ctx, audit_model.ActionUserPasswordChange, u, nil)
+	}
+	if u.ProhibitLogin != wasProhibitLogin {
+		recordUserEvent(ctx, audit_model.ActionUserPermissionsChange, u, map[string]string{"prohibit_login": strconv.FormatBool(u.ProhibitLogin)})
+	}
+
 	if deleteAuthTokens {
 		return auth_model.DeleteAuthTokensByUserID(ctx, u.ID)
 	}
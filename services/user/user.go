@@ -7,9 +7,11 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
 	"time"
 
+	audit_model "gitea.dev/models/audit"
 	"gitea.dev/models/db"
 	"gitea.dev/models/organization"
 	packages_model "gitea.dev/models/packages"
@@ -248,6 +250,8 @@ func DeleteUser(ctx context.Context, u *user_model.User, purge bool) error {
 		return err
 	}
 
+	recordUserEvent(ctx, audit_model.ActionUserDelete, u, map[string]string{"purge": strconv.FormatBool(purge)})
+
 	if err := asymkey_service.RewriteAllPublicKeys(ctx); err != nil {
 		return err
 	}
@@ -410,6 +410,13 @@ func ToHook(repoLink string, w *webhook_model.Webhook) (*api.Hook, error) {
 		config["icon_url"] = s.IconURL
 		config["color"] = s.Color
 	}
+	if w.Type == webhook_module.TEMPLATE {
+		s := GetTemplateHook(w)
+		config["http_method"] = w.HTTPMethod
+		config["body_content_type"] = s.ContentType
+		config["body_template"] = s.BodyTemplate
+		config["headers_template"] = s.HeadersTemplate
+	}
 
 	return &api.Hook{
 		ID:     w.ID,
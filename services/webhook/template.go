@@ -0,0 +1,435 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package webhook
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"reflect"
+	"strings"
+	"text/template"
+	"text/template/parse"
+	"time"
+	"unicode/utf8"
+
+	webhook_model "gitea.dev/models/webhook"
+	"gitea.dev/modules/json"
+	"gitea.dev/modules/log"
+	api "gitea.dev/modules/structs"
+	webhook_module "gitea.dev/modules/webhook"
+
+	"golang.org/x/net/http/httpguts"
+)
+
+const (
+	// templateMaxSourceSize is the maximum size of the body or headers template
+	templateMaxSourceSize = 64 * 1024
+	// templateMaxOutputSize is the maximum size of a rendered body or headers template
+	templateMaxOutputSize = 1024 * 1024
+	// templateRenderTimeout is the maximum time rendering the body and headers templates of a delivery may take
+	templateRenderTimeout = 5 * time.Second
+)
+
+// TemplateMeta contains the metadata for the webhook
+type TemplateMeta struct {
+	ContentType     string `json:"content_type"`
+	BodyTemplate    string `json:"body_template"`
+	HeadersTemplate string `json:"headers_template"`
+}
+
+// GetTemplateHook returns templated webhook metadata
+func GetTemplateHook(w *webhook_model.Webhook) *TemplateMeta {
+	s := &TemplateMeta{}
+	if err := json.Unmarshal([]byte(w.Meta), s); err != nil {
+		log.Error("webhook.GetTemplateHook(%d): %v", w.ID, err)
+	}
+	return s
+}
+
+// TemplateData is the data the body and headers templates are rendered against
+type TemplateData struct {
+	// Event is the event name sent in the X-Gitea-Event header, e.g. "issues"
+	Event string
+	// EventType is the precise event type sent in the X-Gitea-Event-Type header, e.g. "issue_label"
+	EventType string
+	// Payload is the api payload of the event, e.g. *api.IssuePayload
+	Payload api.Payloader
+}
+
+// templateConvertor passes the unmarshalled payload through, the templates are rendered against the api structs
+type templateConvertor struct{}
+
+func (templateConvertor) Create(p *api.CreatePayload) (api.Payloader, error) { return p, nil }
+
+func (templateConvertor) Delete(p *api.DeletePayload) (api.Payloader, error) { return p, nil }
+
+func (templateConvertor) Fork(p *api.ForkPayload) (api.Payloader, error) { return p, nil }
+
+func (templateConvertor) Issue(p *api.IssuePayload) (api.Payloader, error) { return p, nil }
+
+func (templateConvertor) IssueComment(p *api.IssueCommentPayload) (api.Payloader, error) {
+	return p, nil
+}
+
+func (templateConvertor) Push(p *api.PushPayload) (api.Payloader, error) { return p, nil }
+
+func (templateConvertor) PullRequest(p *api.PullRequestPayload) (api.Payloader, error) {
+	return p, nil
+}
+
+func (templateConvertor) Review(p *api.PullRequestPayload, _ webhook_module.HookEventType) (api.Payloader, error) {
+	return p, nil
+}
+
+func (templateConvertor) Repository(p *api.RepositoryPayload) (api.Payloader, error) {
+	return p, nil
+}
+
+func (templateConvertor) Release(p *api.ReleasePayload) (api.Payloader, error) { return p, nil }
+
+func (templateConvertor) Wiki(p *api.WikiPayload) (api.Payloader, error) { return p, nil }
+
+func (templateConvertor) Package(p *api.PackagePayload) (api.Payloader, error) { return p, nil }
+
+func (templateConvertor) Status(p *api.CommitStatusPayload) (api.Payloader, error) { return p, nil }
+
+func (templateConvertor) WorkflowRun(p *api.WorkflowRunPayload) (api.Payloader, error) {
+	return p, nil
+}
+
+func (templateConvertor) WorkflowJob(p *api.WorkflowJobPayload) (api.Payloader, error) {
+	return p, nil
+}
+
+// templateFuncs are the only functions available to the templates besides the text/template builtins,
+// none of them gives access to anything but the rendered data
+func templateFuncs() template.FuncMap {
+	return template.FuncMap{
+		// "call" could invoke function values reachable from the data
+		"call": func(...any) (any, error) {
+			return nil, errors.New("call is not allowed in webhook templates")
+		},
+		"toJSON": func(v any) (string, error) {
+			b, err := json.Marshal(v)
+			return string(b), err
+		},
+		// jsonEscape escapes a string to be embedded between the quotes of a JSON string
+		"jsonEscape": func(s string) (string, error) {
+			b, err := json.Marshal(s)
+			if err != nil {
+				return "", err
+			}
+			return string(b[1 : len(b)-1]), nil
+		},
+		"default": func(def, v any) any {
+			if v == nil {
+				return def
+			}
+			if s, ok := v.(string); ok && s == "" {
+				return def
+			}
+			return v
+		},
+		"upper":     strings.ToUpper,
+		"lower":     strings.ToLower,
+		"trimSpace": strings.TrimSpace,
+		"trimPrefix": func(prefix, s string) string {
+			return strings.TrimPrefix(s, prefix)
+		},
+		"trimSuffix": func(suffix, s string) string {
+			return strings.TrimSuffix(s, suffix)
+		},
+		"replace": func(old, replacement, s string) string {
+			return strings.ReplaceAll(s, old, replacement)
+		},
+		"contains": func(substr, s string) bool {
+			return strings.Contains(s, substr)
+		},
+		"hasPrefix": func(prefix, s string) bool {
+			return strings.HasPrefix(s, prefix)
+		},
+		"hasSuffix": func(suffix, s string) bool {
+			return strings.HasSuffix(s, suffix)
+		},
+		"split": func(sep, s string) []string {
+			return strings.Split(s, sep)
+		},
+		"join": func(sep string, elems []string) string {
+			return strings.Join(elems, sep)
+		},
+		"firstLine": func(s string) string {
+			line, _, _ := strings.Cut(s, "\n")
+			return strings.TrimSuffix(line, "\r")
+		},
+		"truncate": func(n int, s string) string {
+			if n < 0 || utf8.RuneCountInString(s) <= n {
+				return s
+			}
+			return string([]rune(s)[:n])
+		},
+		"shortSHA": func(sha string) string {
+			if len(sha) > 10 {
+				return sha[:10]
+			}
+			return sha
+		},
+		"formatTime": func(layout string, t time.Time) string {
+			return t.Format(layout)
+		},
+		// the ranges are guarded by these functions, see guardRangeNodes
+		"checkRange":    checkRangeValue,
+		"checkDeadline": func() (string, error) { return "", nil },
+	}
+}
+
+// checkRangeValue rejects the values a range would iterate over without any data bound:
+// integers, which can be computed in a variable, and functions or channels
+func checkRangeValue(v any) (any, error) {
+	switch reflect.ValueOf(v).Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Func, reflect.Chan:
+		return nil, fmt.Errorf("range over %T is not allowed", v)
+	}
+	return v, nil
+}
+
+// checkTemplateNodes rejects the constructs which could make the rendering run for an unbounded time:
+// template invocations, which can recurse, and ranges over integer literals
+func checkTemplateNodes(node parse.Node) error {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return nil
+		}
+		for _, child := range n.Nodes {
+			if err := checkTemplateNodes(child); err != nil {
+				return err
+			}
+		}
+	case *parse.TemplateNode:
+		return fmt.Errorf("line %d: template invocations are not allowed", n.Line)
+	case *parse.IfNode:
+		return checkBranchNodes(&n.BranchNode)
+	case *parse.WithNode:
+		return checkBranchNodes(&n.BranchNode)
+	case *parse.RangeNode:
+		for _, cmd := range n.Pipe.Cmds {
+			for _, arg := range cmd.Args {
+				if _, ok := arg.(*parse.NumberNode); ok {
+					return fmt.Errorf("line %d: range over an integer is not allowed", n.Line)
+				}
+			}
+		}
+		return checkBranchNodes(&n.BranchNode)
+	}
+	return nil
+}
+
+func checkBranchNodes(n *parse.BranchNode) error {
+	if err := checkTemplateNodes(n.List); err != nil {
+		return err
+	}
+	return checkTemplateNodes(n.ElseList)
+}
+
+func newIdentifierCommand(tree *parse.Tree, pos parse.Pos, ident string) *parse.CommandNode {
+	return &parse.CommandNode{
+		NodeType: parse.NodeCommand,
+		Pos:      pos,
+		Args:     []parse.Node{parse.NewIdentifier(ident).SetTree(tree).SetPos(pos)},
+	}
+}
+
+// guardRangeNodes pipes the value of every range through checkRange, so the integers computed at runtime are
+// rejected too, and checks the deadline of the rendering at every iteration, so nested ranges can't run for long
+func guardRangeNodes(tree *parse.Tree, node parse.Node) {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return
+		}
+		for _, child := range n.Nodes {
+			guardRangeNodes(tree, child)
+		}
+	case *parse.IfNode:
+		guardBranchNodes(tree, &n.BranchNode)
+	case *parse.WithNode:
+		guardBranchNodes(tree, &n.BranchNode)
+	case *parse.RangeNode:
+		n.Pipe.Cmds = append(n.Pipe.Cmds, newIdentifierCommand(tree, n.Pos, "checkRange"))
+		if n.List != nil {
+			deadline := &parse.ActionNode{
+				NodeType: parse.NodeAction,
+				Pos:      n.Pos,
+				Line:     n.Line,
+				Pipe: &parse.PipeNode{
+					NodeType: parse.NodePipe,
+					Pos:      n.Pos,
+					Line:     n.Line,
+					Cmds:     []*parse.CommandNode{newIdentifierCommand(tree, n.Pos, "checkDeadline")},
+				},
+			}
+			n.List.Nodes = append([]parse.Node{deadline}, n.List.Nodes...)
+		}
+		guardBranchNodes(tree, &n.BranchNode)
+	}
+}
+
+func guardBranchNodes(tree *parse.Tree, n *parse.BranchNode) {
+	guardRangeNodes(tree, n.List)
+	guardRangeNodes(tree, n.ElseList)
+}
+
+func parseTemplate(name, source string) (*template.Template, error) {
+	if len(source) > templateMaxSourceSize {
+		return nil, fmt.Errorf("%s template is larger than %d bytes", name, templateMaxSourceSize)
+	}
+	tmpl, err := template.New(name).Funcs(templateFuncs()).Parse(source)
+	if err != nil {
+		return nil, err
+	}
+	if len(tmpl.Templates()) > 1 {
+		return nil, fmt.Errorf("%s template: defining templates is not allowed", name)
+	}
+	if tmpl.Tree != nil {
+		if err := checkTemplateNodes(tmpl.Tree.Root); err != nil {
+			return nil, fmt.Errorf("%s template: %w", name, err)
+		}
+		guardRangeNodes(tmpl.Tree, tmpl.Tree.Root)
+	}
+	return tmpl, nil
+}
+
+// limitedBuffer fails the rendering once the output exceeds its size
+type limitedBuffer struct {
+	bytes.Buffer
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if b.Len()+len(p) > templateMaxOutputSize {
+		return 0, fmt.Errorf("rendered template is larger than %d bytes", templateMaxOutputSize)
+	}
+	return b.Buffer.Write(p)
+}
+
+// executeTemplate renders the template until the context is done
+func executeTemplate(ctx context.Context, tmpl *template.Template, data *TemplateData) ([]byte, error) {
+	tmpl.Funcs(template.FuncMap{
+		"checkDeadline": func() (string, error) {
+			if err := ctx.Err(); err != nil {
+				return "", fmt.Errorf("rendering the template has been aborted: %w", err)
+			}
+			return "", nil
+		},
+	})
+	var buf limitedBuffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// parseRenderedHeaders parses the "Name: value" lines of the rendered headers template, blank lines are ignored
+func parseRenderedHeaders(rendered []byte) (http.Header, error) {
+	headers := http.Header{}
+	scanner := bufio.NewScanner(bytes.NewReader(rendered))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		name, value, ok := strings.Cut(line, ":")
+		name, value = strings.TrimSpace(name), strings.TrimSpace(value)
+		if !ok || !httpguts.ValidHeaderFieldName(name) || !httpguts.ValidHeaderFieldValue(value) {
+			return nil, fmt.Errorf("invalid header line: %q", line)
+		}
+		headers.Add(name, value)
+	}
+	return headers, scanner.Err()
+}
+
+// ValidateTemplateMeta checks the content type and that the templates can be parsed
+func ValidateTemplateMeta(meta *TemplateMeta) error {
+	if _, _, err := mime.ParseMediaType(meta.ContentType); err != nil {
+		return fmt.Errorf("invalid content type %q: %w", meta.ContentType, err)
+	}
+	if _, err := parseTemplate("body", meta.BodyTemplate); err != nil {
+		return err
+	}
+	_, err := parseTemplate("headers", meta.HeadersTemplate)
+	return err
+}
+
+// RenderTemplate renders the headers and the body of a templated webhook for the payload of an event
+func RenderTemplate(ctx context.Context, meta *TemplateMeta, event webhook_module.HookEventType, payload api.Payloader) (http.Header, []byte, error) {
+	bodyTmpl, err := parseTemplate("body", meta.BodyTemplate)
+	if err != nil {
+		return nil, nil, err
+	}
+	headersTmpl, err := parseTemplate("headers", meta.HeadersTemplate)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, templateRenderTimeout)
+	defer cancel()
+
+	data := &TemplateData{
+		Event:     event.Event(),
+		EventType: string(event),
+		Payload:   payload,
+	}
+	body, err := executeTemplate(ctx, bodyTmpl, data)
+	if err != nil {
+		return nil, nil, err
+	}
+	renderedHeaders, err := executeTemplate(ctx, headersTmpl, data)
+	if err != nil {
+		return nil, nil, err
+	}
+	headers, err := parseRenderedHeaders(renderedHeaders)
+	if err != nil {
+		return nil, nil, err
+	}
+	return headers, body, nil
+}
+
+func newTemplateRequest(ctx context.Context, w *webhook_model.Webhook, t *webhook_model.HookTask) (*http.Request, []byte, error) {
+	meta := &TemplateMeta{}
+	if err := json.Unmarshal([]byte(w.Meta), meta); err != nil {
+		return nil, nil, fmt.Errorf("newTemplateRequest meta json: %w", err)
+	}
+	payload, err := newPayload[api.Payloader](templateConvertor{}, []byte(t.PayloadContent), t.EventType)
+	if err != nil {
+		return nil, nil, err
+	}
+	headers, body, err := RenderTemplate(ctx, meta, t.EventType, payload)
+	if err != nil {
+		return nil, nil, fmt.Errorf("render webhook template: %w", err)
+	}
+
+	method := w.HTTPMethod
+	if method == "" {
+		method = http.MethodPost
+	}
+	req, err := http.NewRequest(method, w.URL, bytes.NewReader(body))
+	if err != nil {
+		return nil, nil, err
+	}
+	req.Header.Set("Content-Type", meta.ContentType)
+	for name, values := range headers {
+		req.Header[name] = values
+	}
+	return req, body, addDefaultHeaders(req, []byte(w.Secret), w, t, body)
+}
+
+func init() {
+	RegisterWebhookRequester(webhook_module.TEMPLATE, newTemplateRequest)
+}
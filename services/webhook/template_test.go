@@ -0,0 +1,148 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package webhook
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	webhook_model "gitea.dev/models/webhook"
+	api "gitea.dev/modules/structs"
+	webhook_module "gitea.dev/modules/webhook"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTemplateRender(t *testing.T) {
+	meta := &TemplateMeta{
+		ContentType:     "application/json",
+		BodyTemplate:    `{"text": {{toJSON (printf "%s pushed %d commits to %s" .Payload.Pusher.UserName .Payload.TotalCommits .Payload.Repo.FullName)}}, "head": "{{shortSHA .Payload.After}}"}`,
+		HeadersTemplate: "X-Event: {{.Event}}\n\nX-Repo: {{.Payload.Repo.Name | upper}}\n",
+	}
+	headers, body, err := RenderTemplate(t.Context(), meta, webhook_module.HookEventPush, pushTestPayload())
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"text": "user1 pushed 2 commits to test/repo", "head": "2020558fe2"}`, string(body))
+	assert.Equal(t, "push", headers.Get("X-Event"))
+	assert.Equal(t, "REPO", headers.Get("X-Repo"))
+
+	t.Run("Functions", func(t *testing.T) {
+		p := issueTestPayload()
+		p.Issue.Title = "a \"quoted\"\ntitle"
+		headers, body, err := RenderTemplate(t.Context(), &TemplateMeta{
+			ContentType:  "text/plain",
+			BodyTemplate: `{{jsonEscape .Payload.Issue.Title}}|{{firstLine .Payload.Issue.Title}}|{{truncate 3 .Payload.Issue.Title}}|{{default "none" .Payload.Issue.Body}}|{{default "none" ""}}|{{.EventType}}`,
+		}, webhook_module.HookEventIssueLabel, p)
+		require.NoError(t, err)
+		assert.Empty(t, headers)
+		assert.Equal(t, `a \"quoted\"\ntitle|a "quoted"|a "|issue body|none|issue_label`, string(body))
+	})
+
+	t.Run("InvalidHeader", func(t *testing.T) {
+		_, _, err := RenderTemplate(t.Context(), &TemplateMeta{ContentType: "text/plain", HeadersTemplate: "no header"}, webhook_module.HookEventPush, pushTestPayload())
+		assert.Error(t, err)
+	})
+
+	t.Run("OutputLimit", func(t *testing.T) {
+		_, _, err := RenderTemplate(t.Context(), &TemplateMeta{
+			ContentType:  "text/plain",
+			BodyTemplate: `{{$s := printf "%0100000d" 0}}{{range .Payload.Commits}}{{range $.Payload.Commits}}{{range $.Payload.Commits}}{{range $.Payload.Commits}}{{$s}}{{end}}{{end}}{{end}}{{end}}`,
+		}, webhook_module.HookEventPush, pushTestPayloadWithCommitMessage("message"))
+		assert.ErrorContains(t, err, "larger than")
+	})
+
+	t.Run("RangeOverInteger", func(t *testing.T) {
+		_, _, err := RenderTemplate(t.Context(), &TemplateMeta{
+			ContentType:  "text/plain",
+			BodyTemplate: `{{$n := 100000000000}}{{range $n}}{{end}}`,
+		}, webhook_module.HookEventPush, pushTestPayload())
+		assert.ErrorContains(t, err, "range over int is not allowed")
+
+		_, _, err = RenderTemplate(t.Context(), &TemplateMeta{
+			ContentType:  "text/plain",
+			BodyTemplate: `{{range $i, $c := .Payload.TotalCommits}}{{end}}`,
+		}, webhook_module.HookEventPush, pushTestPayload())
+		assert.ErrorContains(t, err, "is not allowed")
+	})
+
+	t.Run("Timeout", func(t *testing.T) {
+		p := pushTestPayload()
+		for len(p.Commits) < 100 {
+			p.Commits = append(p.Commits, p.Commits...)
+		}
+		// the rendering stops at the deadline of the context if it is before the timeout
+		ctx, cancel := context.WithTimeout(t.Context(), 100*time.Millisecond)
+		defer cancel()
+		start := time.Now()
+		_, _, err := RenderTemplate(ctx, &TemplateMeta{
+			ContentType:  "text/plain",
+			BodyTemplate: `{{range .Payload.Commits}}{{range $.Payload.Commits}}{{range $.Payload.Commits}}{{range $.Payload.Commits}}{{range $.Payload.Commits}}{{end}}{{end}}{{end}}{{end}}{{end}}`,
+		}, webhook_module.HookEventPush, p)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Less(t, time.Since(start), templateRenderTimeout)
+	})
+
+	t.Run("Ranges", func(t *testing.T) {
+		_, body, err := RenderTemplate(t.Context(), &TemplateMeta{
+			ContentType:  "text/plain",
+			BodyTemplate: `{{range $i, $c := .Payload.Commits}}{{$i}}:{{shortSHA $c.ID}} {{else}}none{{end}}`,
+		}, webhook_module.HookEventPush, pushTestPayload())
+		require.NoError(t, err)
+		assert.Equal(t, "0:2020558fe2 1:2020558fe2 ", string(body))
+	})
+}
+
+func TestValidateTemplateMeta(t *testing.T) {
+	assert.NoError(t, ValidateTemplateMeta(&TemplateMeta{ContentType: "application/json; charset=utf-8", BodyTemplate: "{{.Event}}"}))
+
+	for _, meta := range []*TemplateMeta{
+		{ContentType: "", BodyTemplate: "{{.Event}}"},
+		{ContentType: "application/json", BodyTemplate: "{{.Event"},
+		{ContentType: "application/json", BodyTemplate: `{{define "a"}}{{end}}`},
+		{ContentType: "application/json", BodyTemplate: `{{if .Event}}{{template "a" .}}{{end}}`},
+		{ContentType: "application/json", BodyTemplate: `{{range 1000000000}}{{end}}`},
+		{ContentType: "application/json", HeadersTemplate: `{{with .Payload}}{{range $i, $e := 10}}{{end}}{{end}}`},
+		{ContentType: "application/json", BodyTemplate: `{{undefinedFunc .Event}}`},
+	} {
+		assert.Error(t, ValidateTemplateMeta(meta), "%+v", meta)
+	}
+}
+
+func TestTemplateJSONPayload(t *testing.T) {
+	p := pullRequestTestPayload()
+	data, err := p.JSONPayload()
+	require.NoError(t, err)
+
+	hook := &webhook_model.Webhook{
+		RepoID:     3,
+		IsActive:   true,
+		Type:       webhook_module.TEMPLATE,
+		URL:        "https://ntfy.example.com/topic",
+		Meta:       `{"content_type":"text/plain","body_template":"{{.Payload.Action}} {{.Payload.PullRequest.Title}}","headers_template":"Title: {{.Payload.Repository.FullName}}"}`,
+		HTTPMethod: "PUT",
+	}
+	task := &webhook_model.HookTask{
+		HookID:         hook.ID,
+		EventType:      webhook_module.HookEventPullRequest,
+		PayloadContent: string(data),
+		PayloadVersion: 2,
+	}
+
+	req, reqBody, err := newTemplateRequest(t.Context(), hook, task)
+	require.NoError(t, err)
+	require.NotNil(t, req)
+
+	assert.Equal(t, "PUT", req.Method)
+	assert.Equal(t, "https://ntfy.example.com/topic", req.URL.String())
+	assert.Equal(t, "sha256=", req.Header.Get("X-Hub-Signature-256"))
+	assert.Equal(t, "text/plain", req.Header.Get("Content-Type"))
+	assert.Equal(t, "test/repo", req.Header.Get("Title"))
+	assert.Equal(t, "pull_request", req.Header.Get("X-Gitea-Event"))
+	body, err := io.ReadAll(req.Body)
+	require.NoError(t, err)
+	assert.Equal(t, string(api.HookIssueOpened)+" Fix bug", string(body))
+	assert.Equal(t, body, reqBody)
+}
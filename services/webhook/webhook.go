@@ -156,6 +156,26 @@ func PrepareTestWebhook(ctx context.Context, w *webhook_model.Webhook, event web
 	return enqueueHookTask(task.ID)
 }
 
+// PreviewWebhook builds the request which would be delivered by the webhook for the payload, without
+// creating a hook task or sending it. The Authorization header is only added on delivery.
+func PreviewWebhook(ctx context.Context, w *webhook_model.Webhook, event webhook_module.HookEventType, p api.Payloader) (*http.Request, []byte, error) {
+	payload, err := p.JSONPayload()
+	if err != nil {
+		return nil, nil, fmt.Errorf("JSONPayload for %s: %w", event, err)
+	}
+
+	newRequest := webhookRequesters[w.Type]
+	if newRequest == nil {
+		newRequest = newDefaultRequest
+	}
+	return newRequest(ctx, w, &webhook_model.HookTask{
+		HookID:         w.ID,
+		PayloadContent: string(payload),
+		EventType:      event,
+		PayloadVersion: 2,
+	})
+}
+
 // PrepareWebhook creates a hook task and enqueues it for processing.
 // The payload is saved as-is. The adjustments depending on the webhook type happen
 // right before delivery, in the [Deliver] method.
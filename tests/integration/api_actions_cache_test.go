@@ -0,0 +1,74 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"encoding/xml"
+	"net/http"
+	"strings"
+	"testing"
+
+	"gitea.dev/modules/setting"
+	"gitea.dev/modules/test"
+	actions_router "gitea.dev/routers/api/actions"
+	actions_service "gitea.dev/services/actions"
+	"gitea.dev/tests"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestActionsCacheUploadSizeLimit(t *testing.T) {
+	defer tests.PrepareTestEnv(t, 1)()
+	defer test.MockVariableValue(&setting.Actions.CacheMaxRepoSize, 10)()
+
+	token, err := actions_service.CreateAuthorizationToken(48, 792, 193)
+	require.NoError(t, err)
+
+	reserve := func(t *testing.T, key string) string {
+		req := NewRequestWithJSON(t, "POST", actions_router.CacheV2RouteBase+"/CreateCacheEntry", &actions_router.CreateCacheEntryRequest{
+			Key:     key,
+			Version: "v1",
+		}).AddTokenAuth(token)
+		resp := MakeRequest(t, req, http.StatusOK)
+		var reserved actions_router.CreateCacheEntryResponse
+		DecodeJSON(t, resp, &reserved)
+		require.True(t, reserved.Ok)
+		assert.Contains(t, reserved.SignedUploadURL, actions_router.CacheV2RouteBase+"/UploadCache")
+		return reserved.SignedUploadURL
+	}
+
+	t.Run("Content", func(t *testing.T) {
+		uploadURL := reserve(t, "cache-content")
+
+		req := NewRequestWithBody(t, "PUT", uploadURL, strings.NewReader("01234567890"))
+		MakeRequest(t, req, http.StatusRequestEntityTooLarge)
+
+		// the uploads without a content length are rejected once the limit is read
+		req = NewRequestWithBody(t, "PUT", uploadURL, strings.NewReader("01234567890"))
+		req.ContentLength = -1
+		MakeRequest(t, req, http.StatusRequestEntityTooLarge)
+
+		req = NewRequestWithBody(t, "PUT", uploadURL, strings.NewReader("0123456789"))
+		MakeRequest(t, req, http.StatusCreated)
+	})
+
+	t.Run("Blocks", func(t *testing.T) {
+		uploadURL := reserve(t, "cache-blocks")
+
+		req := NewRequestWithBody(t, "PUT", uploadURL+"&comp=block&blockid=large", strings.NewReader("01234567890"))
+		req.ContentLength = -1
+		MakeRequest(t, req, http.StatusRequestEntityTooLarge)
+
+		for _, blockID := range []string{"a", "b"} {
+			req = NewRequestWithBody(t, "PUT", uploadURL+"&comp=block&blockid="+blockID, strings.NewReader("012345"))
+			MakeRequest(t, req, http.StatusCreated)
+		}
+
+		blockList, err := xml.Marshal(&actions_router.BlockList{Latest: []string{"a", "b"}})
+		require.NoError(t, err)
+		req = NewRequestWithBody(t, "PUT", uploadURL+"&comp=blocklist", strings.NewReader(string(blockList)))
+		MakeRequest(t, req, http.StatusRequestEntityTooLarge)
+	})
+}
@@ -0,0 +1,259 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"testing"
+
+	auth_model "gitea.dev/models/auth"
+	"gitea.dev/models/packages"
+	"gitea.dev/models/unittest"
+	user_model "gitea.dev/models/user"
+	ansible_module "gitea.dev/modules/packages/ansible"
+	"gitea.dev/tests"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPackageAnsible(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	user := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	token := getTokenForLoggedInUser(t, loginUser(t, user.Name), auth_model.AccessTokenScopeWritePackage)
+
+	collectionNamespace := "gitea"
+	collectionName := "tools"
+	collectionVersion := "1.0.1"
+	collectionDescription := "Test Description"
+
+	createCollection := func(version string) []byte {
+		files := `{"files":[{"name":".","ftype":"dir","chksum_type":null,"chksum_sha256":null,"format":1},{"name":"README.md","ftype":"file","chksum_type":"sha256","chksum_sha256":"00","format":1}],"format":1}`
+		filesChecksum := sha256.Sum256([]byte(files))
+
+		manifest := `{"collection_info":{"namespace":"` + collectionNamespace + `","name":"` + collectionName + `","version":"` + version + `","authors":["Gitea Authors"],"readme":"README.md","tags":["gitea"],"description":"` + collectionDescription + `","license":["MIT"],"dependencies":{"community.general":">=1.0.0"},"repository":"https://gitea.io"},"file_manifest_file":{"name":"FILES.json","ftype":"file","chksum_type":"sha256","chksum_sha256":"` + hex.EncodeToString(filesChecksum[:]) + `","format":1},"format":1}`
+
+		var buf bytes.Buffer
+		zw := gzip.NewWriter(&buf)
+		tw := tar.NewWriter(zw)
+		for _, f := range []struct {
+			Name    string
+			Content string
+		}{
+			{"MANIFEST.json", manifest},
+			{"FILES.json", files},
+			{"README.md", "# Gitea Tools"},
+		} {
+			tw.WriteHeader(&tar.Header{
+				Name: f.Name,
+				Mode: 0o600,
+				Size: int64(len(f.Content)),
+			})
+			tw.Write([]byte(f.Content))
+		}
+		tw.Close()
+		zw.Close()
+		return buf.Bytes()
+	}
+
+	// createUploadBody creates the multipart body like "ansible-galaxy collection publish" does
+	createUploadBody := func(content []byte, checksum string) (*bytes.Buffer, string) {
+		var body bytes.Buffer
+		mpw := multipart.NewWriter(&body)
+
+		h := make(textproto.MIMEHeader)
+		h.Set("Content-Disposition", `form-data; name="sha256"`)
+		part, _ := mpw.CreatePart(h)
+		part.Write([]byte(checksum))
+
+		h = make(textproto.MIMEHeader)
+		h.Set("Content-Disposition", `file; name="file"; filename="collection.tar.gz"`)
+		h.Set("Content-Type", "application/octet-stream")
+		part, _ = mpw.CreatePart(h)
+		part.Write(content)
+
+		mpw.Close()
+		return &body, mpw.FormDataContentType()
+	}
+
+	content := createCollection(collectionVersion)
+	checksum := sha256.Sum256(content)
+	packageName := collectionNamespace + "." + collectionName
+	filename := fmt.Sprintf("%s-%s-%s.tar.gz", collectionNamespace, collectionName, collectionVersion)
+
+	root := fmt.Sprintf("/api/packages/%s/ansible", user.Name)
+
+	t.Run("ServiceIndex", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		req := NewRequest(t, "GET", root+"/")
+		resp := MakeRequest(t, req, http.StatusOK)
+
+		var result struct {
+			AvailableVersions map[string]string `json:"available_versions"`
+		}
+		DecodeJSON(t, resp, &result)
+		assert.Equal(t, "v3/", result.AvailableVersions["v3"])
+	})
+
+	t.Run("Upload", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		uploadURL := root + "/v3/artifacts/collections/"
+
+		body, contentType := createUploadBody(content, hex.EncodeToString(checksum[:]))
+		req := NewRequestWithBody(t, "POST", uploadURL, body).
+			SetHeader("Content-Type", contentType)
+		MakeRequest(t, req, http.StatusUnauthorized)
+
+		body, contentType = createUploadBody(content, "00")
+		req = NewRequestWithBody(t, "POST", uploadURL, body).
+			SetHeader("Content-Type", contentType).
+			SetHeader("Authorization", "Token "+token)
+		MakeRequest(t, req, http.StatusBadRequest)
+
+		body, contentType = createUploadBody([]byte("invalid"), "")
+		req = NewRequestWithBody(t, "POST", uploadURL, body).
+			SetHeader("Content-Type", contentType).
+			SetHeader("Authorization", "Token "+token)
+		MakeRequest(t, req, http.StatusBadRequest)
+
+		body, contentType = createUploadBody(content, hex.EncodeToString(checksum[:]))
+		req = NewRequestWithBody(t, "POST", uploadURL, body).
+			SetHeader("Content-Type", contentType).
+			SetHeader("Authorization", "Token "+token)
+		resp := MakeRequest(t, req, http.StatusAccepted)
+
+		var result struct {
+			Task string `json:"task"`
+		}
+		DecodeJSON(t, resp, &result)
+		assert.Contains(t, result.Task, "/v3/imports/collections/")
+
+		pvs, err := packages.GetVersionsByPackageType(t.Context(), user.ID, packages.TypeAnsible)
+		require.NoError(t, err)
+		require.Len(t, pvs, 1)
+
+		pd, err := packages.GetPackageDescriptor(t.Context(), pvs[0])
+		require.NoError(t, err)
+		assert.NotNil(t, pd.SemVer)
+		assert.IsType(t, &ansible_module.Metadata{}, pd.Metadata)
+		assert.Equal(t, packageName, pd.Package.Name)
+		assert.Equal(t, collectionVersion, pd.Version.Version)
+		metadata := pd.Metadata.(*ansible_module.Metadata)
+		assert.Equal(t, collectionDescription, metadata.Description)
+		assert.Equal(t, "# Gitea Tools", metadata.Readme)
+
+		pfs, err := packages.GetFilesByVersionID(t.Context(), pvs[0].ID)
+		require.NoError(t, err)
+		require.Len(t, pfs, 1)
+		assert.Equal(t, filename, pfs[0].Name)
+		assert.True(t, pfs[0].IsLead)
+
+		req = NewRequest(t, "GET", fmt.Sprintf("%s/v3/imports/collections/%d/", root, pvs[0].ID))
+		resp = MakeRequest(t, req, http.StatusOK)
+		assert.Contains(t, resp.Body.String(), `"state":"completed"`)
+
+		body, contentType = createUploadBody(content, "")
+		req = NewRequestWithBody(t, "POST", uploadURL, body).
+			SetHeader("Content-Type", contentType).
+			AddBasicAuth(user.Name)
+		MakeRequest(t, req, http.StatusConflict)
+
+		body, contentType = createUploadBody(createCollection("1.1.0"), "")
+		req = NewRequestWithBody(t, "POST", uploadURL, body).
+			SetHeader("Content-Type", contentType).
+			AddBasicAuth(user.Name)
+		MakeRequest(t, req, http.StatusAccepted)
+	})
+
+	t.Run("Collections", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		type versionSummary struct {
+			HREF    string `json:"href"`
+			Version string `json:"version"`
+		}
+
+		req := NewRequest(t, "GET", root+"/v3/collections/")
+		resp := MakeRequest(t, req, http.StatusOK)
+		assert.Contains(t, resp.Body.String(), `"highest_version"`)
+
+		req = NewRequest(t, "GET", fmt.Sprintf("%s/v3/collections/%s/%s/", root, collectionNamespace, collectionName))
+		resp = MakeRequest(t, req, http.StatusOK)
+
+		var collection struct {
+			Namespace      string          `json:"namespace"`
+			Name           string          `json:"name"`
+			HighestVersion *versionSummary `json:"highest_version"`
+		}
+		DecodeJSON(t, resp, &collection)
+		assert.Equal(t, collectionNamespace, collection.Namespace)
+		assert.Equal(t, collectionName, collection.Name)
+		assert.Equal(t, "1.1.0", collection.HighestVersion.Version)
+
+		req = NewRequest(t, "GET", fmt.Sprintf("%s/v3/collections/%s/unknown/", root, collectionNamespace))
+		MakeRequest(t, req, http.StatusNotFound)
+
+		req = NewRequest(t, "GET", fmt.Sprintf("%s/v3/collections/%s/%s/versions/?limit=1", root, collectionNamespace, collectionName))
+		resp = MakeRequest(t, req, http.StatusOK)
+
+		var versions struct {
+			Meta struct {
+				Count int `json:"count"`
+			} `json:"meta"`
+			Links struct {
+				Next *string `json:"next"`
+			} `json:"links"`
+			Data []*versionSummary `json:"data"`
+		}
+		DecodeJSON(t, resp, &versions)
+		assert.Equal(t, 2, versions.Meta.Count)
+		require.Len(t, versions.Data, 1)
+		assert.Equal(t, "1.1.0", versions.Data[0].Version)
+		require.NotNil(t, versions.Links.Next)
+		assert.Equal(t, fmt.Sprintf("%s/v3/collections/%s/%s/versions/?limit=1&offset=1", root, collectionNamespace, collectionName), *versions.Links.Next)
+
+		req = NewRequest(t, "GET", fmt.Sprintf("%s/v3/collections/%s/%s/versions/%s/", root, collectionNamespace, collectionName, collectionVersion))
+		resp = MakeRequest(t, req, http.StatusOK)
+
+		var version struct {
+			Version     string `json:"version"`
+			DownloadURL string `json:"download_url"`
+			Artifact    struct {
+				Filename string `json:"filename"`
+				SHA256   string `json:"sha256"`
+			} `json:"artifact"`
+			Metadata struct {
+				Dependencies map[string]string `json:"dependencies"`
+			} `json:"metadata"`
+		}
+		DecodeJSON(t, resp, &version)
+		assert.Equal(t, collectionVersion, version.Version)
+		assert.Equal(t, filename, version.Artifact.Filename)
+		assert.Equal(t, hex.EncodeToString(checksum[:]), version.Artifact.SHA256)
+		assert.Equal(t, map[string]string{"community.general": ">=1.0.0"}, version.Metadata.Dependencies)
+		assert.Contains(t, version.DownloadURL, root+"/download/"+filename)
+	})
+
+	t.Run("Download", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		req := NewRequest(t, "GET", root+"/download/"+filename)
+		resp := MakeRequest(t, req, http.StatusOK)
+		assert.Equal(t, content, resp.Body.Bytes())
+
+		req = NewRequest(t, "GET", fmt.Sprintf("%s/download/%s-%s-0.0.1.tar.gz", root, collectionNamespace, collectionName))
+		MakeRequest(t, req, http.StatusNotFound)
+	})
+}
@@ -0,0 +1,267 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	asymkey_model "gitea.dev/models/asymkey"
+	auth_model "gitea.dev/models/auth"
+	"gitea.dev/models/packages"
+	"gitea.dev/models/unittest"
+	user_model "gitea.dev/models/user"
+	api "gitea.dev/modules/structs"
+	attestation_service "gitea.dev/services/packages/attestation"
+	"gitea.dev/tests"
+
+	"github.com/42wim/sshsig"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+)
+
+func TestPackageAttestation(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	user := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+
+	packageName := "attestation-package"
+	packageVersion := "1.0.0"
+	filename := "file.bin"
+	content := []byte{1, 2, 3, 4}
+	contentHash := sha256.Sum256(content)
+
+	uploadURL := fmt.Sprintf("/api/packages/%s/generic/%s/%s", user.Name, packageName, packageVersion)
+	apiURL := fmt.Sprintf("/api/v1/packages/%s/generic/%s/%s/attestations", user.Name, packageName, packageVersion)
+
+	req := NewRequestWithBody(t, "PUT", uploadURL+"/"+filename, bytes.NewReader(content)).
+		AddBasicAuth(user.Name)
+	MakeRequest(t, req, http.StatusCreated)
+
+	_, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	block, err := ssh.MarshalPrivateKey(priv, "")
+	require.NoError(t, err)
+	signer, err := ssh.NewSignerFromKey(priv)
+	require.NoError(t, err)
+
+	signature, err := sshsig.Sign(pem.EncodeToMemory(block), bytes.NewReader(content), "file")
+	require.NoError(t, err)
+
+	readToken := getUserToken(t, user.Name, auth_model.AccessTokenScopeReadPackage)
+	writeToken := getUserToken(t, user.Name, auth_model.AccessTokenScopeWritePackage)
+
+	createAttestation := func(t *testing.T, opts *api.CreatePackageAttestationOption, expectedStatus int) *api.PackageAttestation {
+		req := NewRequestWithJSON(t, "POST", apiURL, opts).AddTokenAuth(writeToken)
+		resp := MakeRequest(t, req, expectedStatus)
+		if expectedStatus != http.StatusCreated {
+			return nil
+		}
+		return DecodeJSON(t, resp, &api.PackageAttestation{})
+	}
+
+	t.Run("Signature", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		req := NewRequestWithJSON(t, "POST", apiURL, &api.CreatePackageAttestationOption{
+			Type:    "signature",
+			Content: base64.StdEncoding.EncodeToString(signature),
+		}).AddTokenAuth(readToken)
+		MakeRequest(t, req, http.StatusForbidden)
+
+		createAttestation(t, &api.CreatePackageAttestationOption{Type: "signature", Content: base64.StdEncoding.EncodeToString([]byte("invalid"))}, http.StatusBadRequest)
+		createAttestation(t, &api.CreatePackageAttestationOption{Type: "signature", FileName: "missing.bin", Content: base64.StdEncoding.EncodeToString(signature)}, http.StatusNotFound)
+		createAttestation(t, &api.CreatePackageAttestationOption{Type: "signature", Format: "gpg", Content: base64.StdEncoding.EncodeToString(signature)}, http.StatusBadRequest)
+
+		pa := createAttestation(t, &api.CreatePackageAttestationOption{
+			Type:     "signature",
+			FileName: filename,
+			Content:  base64.StdEncoding.EncodeToString(signature),
+		}, http.StatusCreated)
+		assert.Equal(t, "signature", pa.Type)
+		assert.Equal(t, "ssh", pa.Format)
+		assert.Equal(t, "signature.ssh", pa.Name)
+		assert.Equal(t, filename, pa.FileName)
+		require.NotNil(t, pa.Verification)
+		assert.False(t, pa.Verification.Verified)
+		assert.Equal(t, attestation_service.ReasonNoKey, pa.Verification.Reason)
+		assert.Equal(t, ssh.FingerprintSHA256(signer.PublicKey()), pa.Verification.KeyID)
+
+		_, err := asymkey_model.AddPublicKey(t.Context(), user.ID, "attestation-key", strings.TrimSpace(string(ssh.MarshalAuthorizedKey(signer.PublicKey()))), 0, true)
+		require.NoError(t, err)
+
+		req = NewRequest(t, "GET", fmt.Sprintf("%s/%d", apiURL, pa.ID)).AddTokenAuth(readToken)
+		resp := MakeRequest(t, req, http.StatusOK)
+		pa = DecodeJSON(t, resp, &api.PackageAttestation{})
+		require.NotNil(t, pa.Verification)
+		assert.True(t, pa.Verification.Verified)
+		assert.Equal(t, filename, pa.Verification.Subject)
+		require.NotNil(t, pa.Verification.Signer)
+		assert.Equal(t, user.Name, pa.Verification.Signer.UserName)
+
+		req = NewRequest(t, "GET", fmt.Sprintf("%s/%d/content", apiURL, pa.ID)).AddTokenAuth(readToken)
+		resp = MakeRequest(t, req, http.StatusOK)
+		assert.Equal(t, signature, resp.Body.Bytes())
+	})
+
+	t.Run("Provenance", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		statement := func(digest string) string {
+			return `{"_type":"https://in-toto.io/Statement/v1","subject":[{"name":"file.bin","digest":{"sha256":"` + digest + `"}}],"predicateType":"https://slsa.dev/provenance/v1","predicate":{"runDetails":{"builder":{"id":"https://example.com/builder"}}}}`
+		}
+
+		sign := func(t *testing.T, data []byte) []byte {
+			sig, err := sshsig.Sign(pem.EncodeToMemory(block), bytes.NewReader(data), "file")
+			require.NoError(t, err)
+			return sig
+		}
+
+		unsigned := []byte(statement(hex.EncodeToString(contentHash[:])))
+
+		// statements without a signature are only parsed
+		pa := createAttestation(t, &api.CreatePackageAttestationOption{
+			Type:    "provenance",
+			Content: base64.StdEncoding.EncodeToString(unsigned),
+		}, http.StatusCreated)
+		assert.Equal(t, "in-toto", pa.Format)
+		assert.Equal(t, "provenance.in-toto", pa.Name)
+		assert.Empty(t, pa.FileName)
+		require.NotNil(t, pa.Verification)
+		assert.False(t, pa.Verification.Verified)
+		assert.Equal(t, attestation_service.ReasonUnsigned, pa.Verification.Reason)
+		assert.Equal(t, filename, pa.Verification.Subject)
+		unsignedID := pa.ID
+
+		// the signatures of a DSSE envelope sign the pre-authentication encoding of the payload
+		payloadType := "application/vnd.in-toto+json"
+		pae := fmt.Appendf(nil, "DSSEv1 %d %s %d %s", len(payloadType), payloadType, len(unsigned), unsigned)
+		envelope := func(sig []byte) string {
+			return `{"payloadType":"` + payloadType + `","payload":"` + base64.StdEncoding.EncodeToString(unsigned) + `","signatures":[{"keyid":"","sig":"` + base64.StdEncoding.EncodeToString(sig) + `"}]}`
+		}
+
+		pa = createAttestation(t, &api.CreatePackageAttestationOption{
+			Type:    "provenance",
+			Name:    "envelope.intoto.jsonl",
+			Content: base64.StdEncoding.EncodeToString([]byte(envelope(sign(t, pae)))),
+		}, http.StatusCreated)
+		require.NotNil(t, pa.Verification)
+		assert.True(t, pa.Verification.Verified)
+		assert.Equal(t, filename, pa.Verification.Subject)
+		require.NotNil(t, pa.Verification.Signer)
+		assert.Equal(t, user.Name, pa.Verification.Signer.UserName)
+
+		// a signature of the payload instead of its pre-authentication encoding is invalid
+		pa = createAttestation(t, &api.CreatePackageAttestationOption{
+			Type:    "provenance",
+			Name:    "envelope.intoto.jsonl",
+			Content: base64.StdEncoding.EncodeToString([]byte(envelope(sign(t, unsigned)))),
+		}, http.StatusCreated)
+		require.NotNil(t, pa.Verification)
+		assert.False(t, pa.Verification.Verified)
+		assert.Equal(t, attestation_service.ReasonInvalidSignature, pa.Verification.Reason)
+
+		// a detached signature named after the statement signs the uploaded statement
+		pa = createAttestation(t, &api.CreatePackageAttestationOption{
+			Type:    "signature",
+			Name:    "provenance.in-toto.sig",
+			Content: base64.StdEncoding.EncodeToString(sign(t, unsigned)),
+		}, http.StatusCreated)
+		require.NotNil(t, pa.Verification)
+		assert.True(t, pa.Verification.Verified)
+		assert.Equal(t, "provenance.in-toto", pa.Verification.Subject)
+
+		req := NewRequest(t, "GET", fmt.Sprintf("%s/%d", apiURL, unsignedID)).AddTokenAuth(readToken)
+		resp := MakeRequest(t, req, http.StatusOK)
+		pa = DecodeJSON(t, resp, &api.PackageAttestation{})
+		require.NotNil(t, pa.Verification)
+		assert.True(t, pa.Verification.Verified)
+		assert.Equal(t, filename, pa.Verification.Subject)
+		require.NotNil(t, pa.Verification.Signer)
+		assert.Equal(t, user.Name, pa.Verification.Signer.UserName)
+
+		pa = createAttestation(t, &api.CreatePackageAttestationOption{
+			Type:    "provenance",
+			Name:    "other.intoto.jsonl",
+			Content: base64.StdEncoding.EncodeToString([]byte(statement("00"))),
+		}, http.StatusCreated)
+		require.NotNil(t, pa.Verification)
+		assert.False(t, pa.Verification.Verified)
+		assert.Equal(t, attestation_service.ReasonNoSubject, pa.Verification.Reason)
+	})
+
+	t.Run("Sidecar", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		sbom := `{"bomFormat":"CycloneDX","specVersion":"1.5","components":[{"name":"dependency","version":"2.0.0"}]}`
+
+		req := NewRequestWithBody(t, "PUT", uploadURL+"/"+filename+".cdx.json", strings.NewReader(sbom)).
+			AddBasicAuth(user.Name)
+		MakeRequest(t, req, http.StatusCreated)
+
+		// invalid sidecar files are stored as normal files
+		req = NewRequestWithBody(t, "PUT", uploadURL+"/"+filename+".asc", strings.NewReader("invalid")).
+			AddBasicAuth(user.Name)
+		MakeRequest(t, req, http.StatusCreated)
+
+		req = NewRequest(t, "GET", apiURL).AddTokenAuth(readToken)
+		resp := MakeRequest(t, req, http.StatusOK)
+
+		var pas []*api.PackageAttestation
+		DecodeJSON(t, resp, &pas)
+		require.Len(t, pas, 6)
+		assert.Equal(t, "sbom", pas[5].Type)
+		assert.Equal(t, "cyclonedx", pas[5].Format)
+		assert.Equal(t, filename+".cdx.json", pas[5].Name)
+		assert.Equal(t, filename, pas[5].FileName)
+
+		req = NewRequest(t, "GET", fmt.Sprintf("/%s/-/packages/generic/%s/%s", user.Name, packageName, packageVersion)).AddBasicAuth(user.Name)
+		resp = MakeRequest(t, req, http.StatusOK)
+		assert.Contains(t, resp.Body.String(), "dependency")
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		pv, err := packages.GetVersionByNameAndVersion(t.Context(), user.ID, packages.TypeGeneric, packageName, packageVersion)
+		require.NoError(t, err)
+		pas, err := packages.GetAttestationsByVersionID(t.Context(), pv.ID)
+		require.NoError(t, err)
+		require.Len(t, pas, 6)
+
+		req := NewRequest(t, "DELETE", fmt.Sprintf("%s/%d", apiURL, pas[0].ID)).AddTokenAuth(readToken)
+		MakeRequest(t, req, http.StatusForbidden)
+
+		req = NewRequest(t, "DELETE", fmt.Sprintf("%s/%d", apiURL, pas[0].ID)).AddTokenAuth(writeToken)
+		MakeRequest(t, req, http.StatusNoContent)
+
+		req = NewRequest(t, "GET", fmt.Sprintf("%s/%d", apiURL, pas[0].ID)).AddTokenAuth(readToken)
+		MakeRequest(t, req, http.StatusNotFound)
+
+		// deleting the subject file removes its attestations
+		req = NewRequest(t, "DELETE", uploadURL+"/"+filename).AddBasicAuth(user.Name)
+		MakeRequest(t, req, http.StatusNoContent)
+
+		pas, err = packages.GetAttestationsByVersionID(t.Context(), pv.ID)
+		require.NoError(t, err)
+		assert.Len(t, pas, 4)
+
+		req = NewRequest(t, "DELETE", uploadURL).AddBasicAuth(user.Name)
+		MakeRequest(t, req, http.StatusNoContent)
+
+		pas, err = packages.GetAttestationsByVersionID(t.Context(), pv.ID)
+		require.NoError(t, err)
+		assert.Empty(t, pas)
+	})
+}
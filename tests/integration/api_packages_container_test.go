@@ -718,6 +718,69 @@ func TestPackageContainer(t *testing.T) {
 				assert.Len(t, apiPackages, 4) // "latest", "main", "multi", "sha256:..."
 			})
 
+			t.Run("Referrers", func(t *testing.T) {
+				defer tests.PrintCurrentTest(t)()
+
+				artifactType := "application/vnd.example.sbom.v1+json"
+				referrerContent := `{"schemaVersion":2,"mediaType":"` + oci.MediaTypeImageManifest + `","artifactType":"` + artifactType + `","config":{"mediaType":"application/vnd.docker.container.image.v1+json","digest":"` + configDigest + `","size":1069},"layers":[{"mediaType":"application/vnd.docker.image.rootfs.diff.tar.gzip","digest":"` + blobDigest + `","size":32}],"subject":{"mediaType":"` + container_module.ContentTypeDockerDistributionManifestV2 + `","digest":"` + manifestDigest + `","size":` + strconv.Itoa(len(manifestContent)) + `},"annotations":{"org.example.key":"value"}}`
+				referrerDigestBuf := sha256.Sum256([]byte(referrerContent))
+				referrerDigest := "sha256:" + hex.EncodeToString(referrerDigestBuf[:])
+
+				req := NewRequestWithBody(t, "PUT", fmt.Sprintf("%s/manifests/%s", url, referrerDigest), strings.NewReader(referrerContent)).
+					AddTokenAuth(userToken).
+					SetHeader("Content-Type", oci.MediaTypeImageManifest)
+				resp := MakeRequest(t, req, http.StatusCreated)
+				assert.Equal(t, referrerDigest, resp.Header().Get("Docker-Content-Digest"))
+				assert.Equal(t, manifestDigest, resp.Header().Get("OCI-Subject"))
+
+				pv, err := packages_model.GetVersionByNameAndVersion(t.Context(), user.ID, packages_model.TypeContainer, image, referrerDigest)
+				require.NoError(t, err)
+				pd, err := packages_model.GetPackageDescriptor(t.Context(), pv)
+				require.NoError(t, err)
+				assert.ElementsMatch(t, []string{manifestDigest}, getAllByName(pd.VersionProperties, container_module.PropertyManifestSubject))
+				metadata, ok := pd.Metadata.(*container_module.Metadata)
+				require.True(t, ok)
+				assert.Equal(t, container_module.TypeArtifact, metadata.Type)
+				assert.Equal(t, artifactType, metadata.ArtifactType)
+				assert.Equal(t, manifestDigest, metadata.Subject)
+
+				req = NewRequest(t, "GET", fmt.Sprintf("%s/referrers/%s", url, manifestDigest)).
+					AddTokenAuth(userToken)
+				resp = MakeRequest(t, req, http.StatusOK)
+				assert.Equal(t, oci.MediaTypeImageIndex, resp.Header().Get("Content-Type"))
+
+				index := DecodeJSON(t, resp, &oci.Index{})
+				assert.Equal(t, oci.MediaTypeImageIndex, index.MediaType)
+				require.Len(t, index.Manifests, 1)
+				assert.Equal(t, oci.MediaTypeImageManifest, index.Manifests[0].MediaType)
+				assert.Equal(t, referrerDigest, index.Manifests[0].Digest.String())
+				assert.EqualValues(t, len(referrerContent), index.Manifests[0].Size)
+				assert.Equal(t, artifactType, index.Manifests[0].ArtifactType)
+				assert.Equal(t, map[string]string{"org.example.key": "value"}, index.Manifests[0].Annotations)
+
+				req = NewRequest(t, "GET", fmt.Sprintf("%s/referrers/%s?artifactType=%s", url, manifestDigest, "application/vnd.example.other")).
+					AddTokenAuth(userToken)
+				resp = MakeRequest(t, req, http.StatusOK)
+				assert.Equal(t, "artifactType", resp.Header().Get("OCI-Filters-Applied"))
+				index = DecodeJSON(t, resp, &oci.Index{})
+				assert.Empty(t, index.Manifests)
+
+				req = NewRequest(t, "GET", fmt.Sprintf("%s/referrers/%s", url, unknownDigest)).
+					AddTokenAuth(userToken)
+				resp = MakeRequest(t, req, http.StatusOK)
+				index = DecodeJSON(t, resp, &oci.Index{})
+				assert.NotNil(t, index.Manifests)
+				assert.Empty(t, index.Manifests)
+
+				req = NewRequest(t, "GET", url+"/referrers/invalid").
+					AddTokenAuth(userToken)
+				MakeRequest(t, req, http.StatusBadRequest)
+
+				req = NewRequest(t, "DELETE", fmt.Sprintf("%s/manifests/%s", url, referrerDigest)).
+					AddTokenAuth(userToken)
+				MakeRequest(t, req, http.StatusAccepted)
+			})
+
 			t.Run("Delete", func(t *testing.T) {
 				t.Run("Blob", func(t *testing.T) {
 					defer tests.PrintCurrentTest(t)()
@@ -0,0 +1,247 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	auth_model "gitea.dev/models/auth"
+	"gitea.dev/models/packages"
+	"gitea.dev/models/unittest"
+	user_model "gitea.dev/models/user"
+	hex_module "gitea.dev/modules/packages/hex"
+	hex_service "gitea.dev/services/packages/hex"
+	"gitea.dev/tests"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPackageHex(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	user := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	token := getTokenForLoggedInUser(t, loginUser(t, user.Name), auth_model.AccessTokenScopeWritePackage)
+
+	packageName := "gitea_test"
+	packageVersion := "1.0.1"
+	packageDescription := "Test Description"
+
+	createPackage := func(version string) ([]byte, string) {
+		metadata := `{<<"name">>,<<"` + packageName + `">>}.
+{<<"version">>,<<"` + version + `">>}.
+{<<"app">>,<<"` + packageName + `">>}.
+{<<"description">>,<<"` + packageDescription + `">>}.
+{<<"licenses">>,[<<"MIT">>]}.
+{<<"requirements">>,[[{<<"name">>,<<"jason">>},{<<"app">>,<<"jason">>},{<<"optional">>,false},{<<"requirement">>,<<"~> 1.4">>},{<<"repository">>,<<"hexpm">>}]]}.
+`
+		var contents bytes.Buffer
+		zw := gzip.NewWriter(&contents)
+		zw.Write([]byte("dummy"))
+		zw.Close()
+
+		h := sha256.New()
+		h.Write([]byte("3"))
+		h.Write([]byte(metadata))
+		h.Write(contents.Bytes())
+		checksum := hex.EncodeToString(h.Sum(nil))
+
+		var buf bytes.Buffer
+		tw := tar.NewWriter(&buf)
+		for _, f := range []struct {
+			Name    string
+			Content []byte
+		}{
+			{"VERSION", []byte("3")},
+			{"CHECKSUM", []byte(strings.ToUpper(checksum))},
+			{"metadata.config", []byte(metadata)},
+			{"contents.tar.gz", contents.Bytes()},
+		} {
+			tw.WriteHeader(&tar.Header{
+				Name: f.Name,
+				Mode: 0o600,
+				Size: int64(len(f.Content)),
+			})
+			tw.Write(f.Content)
+		}
+		tw.Close()
+		return buf.Bytes(), checksum
+	}
+
+	content, checksum := createPackage(packageVersion)
+
+	root := fmt.Sprintf("/api/packages/%s/hex", user.Name)
+
+	readSigned := func(t *testing.T, body []byte) []byte {
+		zr, err := gzip.NewReader(bytes.NewReader(body))
+		require.NoError(t, err)
+		data, err := io.ReadAll(zr)
+		require.NoError(t, err)
+		return data
+	}
+
+	t.Run("Upload", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		uploadURL := root + "/api/publish"
+
+		req := NewRequestWithBody(t, "POST", uploadURL, bytes.NewReader(content))
+		MakeRequest(t, req, http.StatusUnauthorized)
+
+		req = NewRequestWithBody(t, "POST", uploadURL, bytes.NewReader([]byte("invalid"))).
+			SetHeader("Authorization", token)
+		MakeRequest(t, req, http.StatusUnprocessableEntity)
+
+		req = NewRequestWithBody(t, "POST", uploadURL, bytes.NewReader(content)).
+			SetHeader("Authorization", token)
+		resp := MakeRequest(t, req, http.StatusCreated)
+		assert.Contains(t, resp.Body.String(), checksum)
+
+		pvs, err := packages.GetVersionsByPackageType(t.Context(), user.ID, packages.TypeHex)
+		assert.NoError(t, err)
+		assert.Len(t, pvs, 1)
+
+		pd, err := packages.GetPackageDescriptor(t.Context(), pvs[0])
+		assert.NoError(t, err)
+		assert.NotNil(t, pd.SemVer)
+		assert.IsType(t, &hex_module.Metadata{}, pd.Metadata)
+		assert.Equal(t, packageName, pd.Package.Name)
+		assert.Equal(t, packageVersion, pd.Version.Version)
+		assert.Equal(t, checksum, pd.Metadata.(*hex_module.Metadata).InnerChecksum)
+
+		pfs, err := packages.GetFilesByVersionID(t.Context(), pvs[0].ID)
+		assert.NoError(t, err)
+		assert.Len(t, pfs, 1)
+		assert.Equal(t, fmt.Sprintf("%s-%s.tar", packageName, packageVersion), pfs[0].Name)
+		assert.True(t, pfs[0].IsLead)
+
+		req = NewRequestWithBody(t, "POST", uploadURL, bytes.NewReader(content)).
+			AddBasicAuth(user.Name)
+		MakeRequest(t, req, http.StatusConflict)
+
+		req = NewRequestWithBody(t, "POST", uploadURL+"?replace=true", bytes.NewReader(content)).
+			AddBasicAuth(user.Name)
+		MakeRequest(t, req, http.StatusCreated)
+
+		content2, _ := createPackage("1.1.0")
+		req = NewRequestWithBody(t, "POST", uploadURL, bytes.NewReader(content2)).
+			AddBasicAuth(user.Name)
+		MakeRequest(t, req, http.StatusCreated)
+	})
+
+	t.Run("Download", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		req := NewRequest(t, "GET", fmt.Sprintf("%s/tarballs/%s-%s.tar", root, packageName, packageVersion))
+		resp := MakeRequest(t, req, http.StatusOK)
+		assert.Equal(t, content, resp.Body.Bytes())
+
+		req = NewRequest(t, "GET", fmt.Sprintf("%s/tarballs/%s-0.0.1.tar", root, packageName))
+		MakeRequest(t, req, http.StatusNotFound)
+	})
+
+	t.Run("PublicKey", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		_, pub, err := hex_service.GetOrCreateKeyPair(t.Context(), user.ID)
+		assert.NoError(t, err)
+
+		req := NewRequest(t, "GET", root+"/public_key")
+		resp := MakeRequest(t, req, http.StatusOK)
+		assert.Equal(t, pub, resp.Body.String())
+	})
+
+	t.Run("Resources", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		req := NewRequest(t, "GET", root+"/names")
+		resp := MakeRequest(t, req, http.StatusOK)
+		data := readSigned(t, resp.Body.Bytes())
+		assert.Contains(t, string(data), packageName)
+		assert.Contains(t, string(data), user.LowerName)
+
+		req = NewRequest(t, "GET", root+"/versions")
+		resp = MakeRequest(t, req, http.StatusOK)
+		data = readSigned(t, resp.Body.Bytes())
+		assert.Contains(t, string(data), packageVersion)
+		assert.Contains(t, string(data), "1.1.0")
+
+		req = NewRequest(t, "GET", root+"/packages/"+packageName)
+		resp = MakeRequest(t, req, http.StatusOK)
+		data = readSigned(t, resp.Body.Bytes())
+		assert.Contains(t, string(data), "jason")
+		assert.Contains(t, string(data), "hexpm")
+
+		req = NewRequest(t, "GET", root+"/packages/unknown")
+		MakeRequest(t, req, http.StatusNotFound)
+	})
+
+	t.Run("Retire", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		retireURL := fmt.Sprintf("%s/api/packages/%s/releases/%s/retire", root, packageName, packageVersion)
+
+		body, err := hex_module.EncodeExternalTerm(map[string]any{"reason": "invalid-reason"})
+		require.NoError(t, err)
+		req := NewRequestWithBody(t, "POST", retireURL, bytes.NewReader(body)).
+			SetHeader("Content-Type", hex_module.ContentTypeErlang).
+			SetHeader("Authorization", token)
+		MakeRequest(t, req, http.StatusUnprocessableEntity)
+
+		body, err = hex_module.EncodeExternalTerm(map[string]any{"reason": "security", "message": "CVE-2026-0001"})
+		require.NoError(t, err)
+		req = NewRequestWithBody(t, "POST", retireURL, bytes.NewReader(body)).
+			SetHeader("Content-Type", hex_module.ContentTypeErlang).
+			SetHeader("Authorization", token)
+		MakeRequest(t, req, http.StatusNoContent)
+
+		pv, err := packages.GetVersionByNameAndVersion(t.Context(), user.ID, packages.TypeHex, packageName, packageVersion)
+		assert.NoError(t, err)
+		pd, err := packages.GetPackageDescriptor(t.Context(), pv)
+		assert.NoError(t, err)
+		assert.Equal(t, &hex_module.Retirement{Reason: hex_module.RetirementReasonSecurity, Message: "CVE-2026-0001"}, hex_service.GetRetirement(pd))
+
+		req = NewRequest(t, "GET", root+"/packages/"+packageName)
+		resp := MakeRequest(t, req, http.StatusOK)
+		assert.Contains(t, string(readSigned(t, resp.Body.Bytes())), "CVE-2026-0001")
+
+		req = NewRequest(t, "DELETE", retireURL).
+			SetHeader("Authorization", token)
+		MakeRequest(t, req, http.StatusNoContent)
+
+		pd, err = packages.GetPackageDescriptor(t.Context(), pv)
+		assert.NoError(t, err)
+		assert.Nil(t, hex_service.GetRetirement(pd))
+	})
+
+	t.Run("Revert", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		releaseURL := fmt.Sprintf("%s/api/packages/%s/releases/1.1.0", root, packageName)
+
+		req := NewRequest(t, "DELETE", releaseURL)
+		MakeRequest(t, req, http.StatusUnauthorized)
+
+		req = NewRequest(t, "DELETE", releaseURL).
+			SetHeader("Authorization", token)
+		MakeRequest(t, req, http.StatusNoContent)
+
+		req = NewRequest(t, "DELETE", releaseURL).
+			SetHeader("Authorization", token)
+		MakeRequest(t, req, http.StatusNotFound)
+
+		pvs, err := packages.GetVersionsByPackageType(t.Context(), user.ID, packages.TypeHex)
+		assert.NoError(t, err)
+		assert.Len(t, pvs, 1)
+	})
+}
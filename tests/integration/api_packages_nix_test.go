@@ -0,0 +1,185 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"gitea.dev/models/packages"
+	"gitea.dev/models/unittest"
+	user_model "gitea.dev/models/user"
+	nix_module "gitea.dev/modules/packages/nix"
+	packages_cleanup_service "gitea.dev/services/packages/cleanup"
+	nix_service "gitea.dev/services/packages/nix"
+	"gitea.dev/tests"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPackageNix(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	user := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+
+	storeHash := "7gx4kiv5m0i7d7qkixq2cwzbr10lvxwc"
+	storeName := "hello-2.12.1"
+	storePath := "/nix/store/" + storeHash + "-" + storeName
+
+	narContent := []byte("nix-archive-1 dummy content")
+	narChecksum := sha256.Sum256(narContent)
+	narFilename := nix_module.EncodeBase32(narChecksum[:]) + ".nar"
+
+	narInfo := `StorePath: ` + storePath + `
+URL: nar/` + narFilename + `
+Compression: none
+FileHash: ` + nix_module.FormatHash(narChecksum[:]) + `
+FileSize: ` + fmt.Sprint(len(narContent)) + `
+NarHash: ` + nix_module.FormatHash(narChecksum[:]) + `
+NarSize: ` + fmt.Sprint(len(narContent)) + `
+References: 3n58xw4373jp0ljirf06d8077j15pc4j-glibc-2.37-8
+System: x86_64-linux
+`
+
+	root := fmt.Sprintf("/api/packages/%s/nix", user.Name)
+
+	t.Run("CacheInfo", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		req := NewRequest(t, "GET", root+"/nix-cache-info")
+		resp := MakeRequest(t, req, http.StatusOK)
+		assert.Contains(t, resp.Body.String(), "StoreDir: /nix/store\n")
+	})
+
+	t.Run("Upload", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		narURL := root + "/nar/" + narFilename
+		narInfoURL := root + "/" + storeHash + ".narinfo"
+
+		req := NewRequestWithBody(t, "PUT", narURL, bytes.NewReader(narContent))
+		MakeRequest(t, req, http.StatusUnauthorized)
+
+		req = NewRequest(t, "HEAD", narInfoURL)
+		MakeRequest(t, req, http.StatusNotFound)
+
+		// the nar file must be uploaded first
+		req = NewRequestWithBody(t, "PUT", narInfoURL, strings.NewReader(narInfo)).
+			AddBasicAuth(user.Name)
+		MakeRequest(t, req, http.StatusBadRequest)
+
+		req = NewRequestWithBody(t, "PUT", root+"/nar/invalid.zip", bytes.NewReader(narContent)).
+			AddBasicAuth(user.Name)
+		MakeRequest(t, req, http.StatusBadRequest)
+
+		req = NewRequestWithBody(t, "PUT", narURL, bytes.NewReader(narContent)).
+			AddBasicAuth(user.Name)
+		MakeRequest(t, req, http.StatusCreated)
+
+		// uploaded nar files are not served before the narinfo file is uploaded
+		req = NewRequest(t, "GET", narURL)
+		MakeRequest(t, req, http.StatusNotFound)
+
+		req = NewRequestWithBody(t, "PUT", root+"/0000000000000000000000000000000a.narinfo", strings.NewReader(narInfo)).
+			AddBasicAuth(user.Name)
+		MakeRequest(t, req, http.StatusBadRequest)
+
+		req = NewRequestWithBody(t, "PUT", narInfoURL, strings.NewReader(strings.Replace(narInfo, "FileSize: ", "FileSize: 1", 1))).
+			AddBasicAuth(user.Name)
+		MakeRequest(t, req, http.StatusBadRequest)
+
+		req = NewRequestWithBody(t, "PUT", narInfoURL, strings.NewReader(narInfo)).
+			AddBasicAuth(user.Name)
+		MakeRequest(t, req, http.StatusCreated)
+
+		pvs, err := packages.GetVersionsByPackageType(t.Context(), user.ID, packages.TypeNix)
+		require.NoError(t, err)
+		require.Len(t, pvs, 1)
+
+		pd, err := packages.GetPackageDescriptor(t.Context(), pvs[0])
+		require.NoError(t, err)
+		assert.Nil(t, pd.SemVer)
+		assert.IsType(t, &nix_module.Metadata{}, pd.Metadata)
+		assert.Equal(t, storeName, pd.Package.Name)
+		assert.Equal(t, storeHash, pd.Version.Version)
+		assert.Equal(t, storePath, pd.Metadata.(*nix_module.Metadata).StorePath)
+
+		pfs, err := packages.GetFilesByVersionID(t.Context(), pvs[0].ID)
+		require.NoError(t, err)
+		require.Len(t, pfs, 1)
+		assert.Equal(t, narFilename, pfs[0].Name)
+		assert.True(t, pfs[0].IsLead)
+
+		// the nar file was moved out of the upload version
+		uploadVersion, err := nix_service.GetOrCreateUploadVersion(t.Context(), user.ID)
+		require.NoError(t, err)
+		pfs, err = packages.GetFilesByVersionID(t.Context(), uploadVersion.ID)
+		require.NoError(t, err)
+		assert.Empty(t, pfs)
+
+		req = NewRequestWithBody(t, "PUT", narURL, bytes.NewReader(narContent)).
+			AddBasicAuth(user.Name)
+		MakeRequest(t, req, http.StatusCreated)
+
+		req = NewRequestWithBody(t, "PUT", narInfoURL, strings.NewReader(narInfo)).
+			AddBasicAuth(user.Name)
+		MakeRequest(t, req, http.StatusConflict)
+	})
+
+	t.Run("NarInfo", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		req := NewRequest(t, "GET", root+"/"+storeHash+".narinfo")
+		resp := MakeRequest(t, req, http.StatusOK)
+		assert.Equal(t, nix_module.ContentTypeNarInfo, resp.Header().Get("Content-Type"))
+
+		p, err := nix_module.ParseNarInfo(resp.Body)
+		require.NoError(t, err)
+		assert.Equal(t, storeHash, p.Hash)
+		assert.Equal(t, narFilename, p.NarFilename)
+		require.Len(t, p.Metadata.Signatures, 1)
+
+		req = NewRequest(t, "GET", root+"/key")
+		resp = MakeRequest(t, req, http.StatusOK)
+		publicKey := resp.Body.String()
+
+		assert.True(t, nix_module.Verify(publicKey, p.Metadata.Fingerprint(), p.Metadata.Signatures[0]))
+
+		req = NewRequest(t, "GET", root+"/invalid.narinfo")
+		MakeRequest(t, req, http.StatusNotFound)
+	})
+
+	t.Run("Download", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		req := NewRequest(t, "GET", root+"/nar/"+narFilename)
+		resp := MakeRequest(t, req, http.StatusOK)
+		assert.Equal(t, narContent, resp.Body.Bytes())
+	})
+
+	t.Run("Cleanup", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		uploadVersion, err := nix_service.GetOrCreateUploadVersion(t.Context(), user.ID)
+		require.NoError(t, err)
+		pfs, err := packages.GetFilesByVersionID(t.Context(), uploadVersion.ID)
+		require.NoError(t, err)
+		assert.Len(t, pfs, 1)
+
+		require.NoError(t, packages_cleanup_service.CleanupExpiredData(t.Context(), -1*time.Hour))
+
+		pfs, err = packages.GetFilesByVersionID(t.Context(), uploadVersion.ID)
+		require.NoError(t, err)
+		assert.Empty(t, pfs)
+
+		req := NewRequest(t, "GET", root+"/nar/"+narFilename)
+		MakeRequest(t, req, http.StatusOK)
+	})
+}
@@ -0,0 +1,127 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"testing"
+
+	auth_model "gitea.dev/models/auth"
+	"gitea.dev/models/unittest"
+	user_model "gitea.dev/models/user"
+	"gitea.dev/modules/setting"
+	api "gitea.dev/modules/structs"
+	"gitea.dev/modules/test"
+	"gitea.dev/tests"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIQuota(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+	defer test.MockVariableValue(&setting.Quota.Enabled, true)()
+
+	user4 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 4})
+	adminToken := getUserToken(t, "user1", auth_model.AccessTokenScopeWriteAdmin)
+
+	t.Run("Admin", func(t *testing.T) {
+		req := NewRequestWithJSON(t, "POST", "/api/v1/admin/quota/rules", &api.CreateQuotaRuleOption{
+			Name:     "packages-10",
+			Limit:    10,
+			Subjects: []string{"size:packages"},
+		}).AddTokenAuth(adminToken)
+		MakeRequest(t, req, http.StatusCreated)
+		MakeRequest(t, req, http.StatusConflict)
+
+		req = NewRequestWithJSON(t, "POST", "/api/v1/admin/quota/rules", &api.CreateQuotaRuleOption{
+			Name:     "invalid",
+			Limit:    10,
+			Subjects: []string{"size:unknown"},
+		}).AddTokenAuth(adminToken)
+		MakeRequest(t, req, http.StatusBadRequest)
+
+		req = NewRequestWithJSON(t, "POST", "/api/v1/admin/quota/groups", &api.CreateQuotaGroupOption{Name: "limited"}).AddTokenAuth(adminToken)
+		MakeRequest(t, req, http.StatusCreated)
+
+		req = NewRequest(t, "PUT", "/api/v1/admin/quota/groups/limited/rules/packages-10").AddTokenAuth(adminToken)
+		MakeRequest(t, req, http.StatusNoContent)
+		req = NewRequest(t, "PUT", "/api/v1/admin/quota/groups/limited/users/"+user4.Name).AddTokenAuth(adminToken)
+		MakeRequest(t, req, http.StatusNoContent)
+
+		req = NewRequest(t, "GET", "/api/v1/admin/quota/groups/limited").AddTokenAuth(adminToken)
+		resp := MakeRequest(t, req, http.StatusOK)
+		group := DecodeJSON(t, resp, &api.QuotaGroup{})
+		require.Len(t, group.Rules, 1)
+		assert.Equal(t, "packages-10", group.Rules[0].Name)
+
+		req = NewRequest(t, "GET", "/api/v1/admin/quota/groups/limited/users").AddTokenAuth(adminToken)
+		resp = MakeRequest(t, req, http.StatusOK)
+		users := DecodeJSON(t, resp, []*api.User{})
+		require.Len(t, users, 1)
+		assert.Equal(t, user4.Name, users[0].UserName)
+	})
+
+	t.Run("Enforcement", func(t *testing.T) {
+		url := fmt.Sprintf("/api/packages/%s/generic/quota-test/1.0.0", user4.Name)
+
+		req := NewRequestWithBody(t, "PUT", url+"/first.bin", bytes.NewReader(make([]byte, 8))).AddBasicAuth(user4.Name)
+		MakeRequest(t, req, http.StatusCreated)
+
+		req = NewRequestWithBody(t, "PUT", url+"/second.bin", bytes.NewReader(make([]byte, 8))).AddBasicAuth(user4.Name)
+		MakeRequest(t, req, http.StatusForbidden)
+
+		token := getUserToken(t, user4.Name, auth_model.AccessTokenScopeReadUser)
+		req = NewRequest(t, "GET", "/api/v1/user/quota").AddTokenAuth(token)
+		resp := MakeRequest(t, req, http.StatusOK)
+		info := DecodeJSON(t, resp, &api.QuotaInfo{})
+		assert.EqualValues(t, 8, info.Used.Packages)
+		require.Len(t, info.Groups, 1)
+		assert.Equal(t, "limited", info.Groups[0].Name)
+	})
+
+	t.Run("LargerRule", func(t *testing.T) {
+		req := NewRequestWithJSON(t, "POST", "/api/v1/admin/quota/rules", &api.CreateQuotaRuleOption{
+			Name:     "all-1k",
+			Limit:    1024,
+			Subjects: []string{"size:all"},
+		}).AddTokenAuth(adminToken)
+		MakeRequest(t, req, http.StatusCreated)
+		req = NewRequest(t, "PUT", fmt.Sprintf("/api/v1/admin/users/%s/quota/rules/all-1k", user4.Name)).AddTokenAuth(adminToken)
+		MakeRequest(t, req, http.StatusNoContent)
+
+		url := fmt.Sprintf("/api/packages/%s/generic/quota-test/1.0.0/second.bin", user4.Name)
+		req = NewRequestWithBody(t, "PUT", url, bytes.NewReader(make([]byte, 8))).AddBasicAuth(user4.Name)
+		MakeRequest(t, req, http.StatusCreated)
+
+		req = NewRequest(t, "GET", fmt.Sprintf("/api/v1/admin/users/%s/quota", user4.Name)).AddTokenAuth(adminToken)
+		resp := MakeRequest(t, req, http.StatusOK)
+		info := DecodeJSON(t, resp, &api.QuotaInfo{})
+		assert.EqualValues(t, 16, info.Used.Packages)
+		require.Len(t, info.Rules, 1)
+		assert.Equal(t, "all-1k", info.Rules[0].Name)
+	})
+
+	t.Run("Usage", func(t *testing.T) {
+		req := NewRequest(t, "GET", "/api/v1/admin/quota/usage").AddTokenAuth(adminToken)
+		resp := MakeRequest(t, req, http.StatusOK)
+		usages := DecodeJSON(t, resp, []*api.QuotaUsage{})
+		require.NotEmpty(t, usages)
+		found := false
+		for _, u := range usages {
+			if u.Owner.ID == user4.ID {
+				found = true
+				assert.EqualValues(t, 16, u.Used.Packages)
+			}
+		}
+		assert.True(t, found)
+
+		req = NewRequest(t, "DELETE", "/api/v1/admin/quota/rules/all-1k").AddTokenAuth(adminToken)
+		MakeRequest(t, req, http.StatusNoContent)
+		req = NewRequest(t, "GET", "/api/v1/admin/quota/rules/all-1k").AddTokenAuth(adminToken)
+		MakeRequest(t, req, http.StatusNotFound)
+	})
+}
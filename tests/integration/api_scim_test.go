@@ -0,0 +1,178 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	auth_model "gitea.dev/models/auth"
+	"gitea.dev/models/organization"
+	"gitea.dev/models/unittest"
+	user_model "gitea.dev/models/user"
+	scim_module "gitea.dev/modules/scim"
+	"gitea.dev/services/auth/source/oauth2"
+	"gitea.dev/tests"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPISCIM(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	addOAuth2Source(t, "scim-idp", oauth2.Source{
+		ClientID:     "scim",
+		ClientSecret: "scim",
+		GroupTeamMap: `{"scim-devs": {"org3": ["team1"]}}`,
+		// the members leave the mapped teams when they leave the groups
+		GroupTeamMapRemoval: true,
+	})
+	source, err := auth_model.GetActiveOAuth2SourceByAuthName(t.Context(), "scim-idp")
+	require.NoError(t, err)
+	scimToken, err := auth_model.GenerateSCIMToken(t.Context(), source.ID)
+	require.NoError(t, err)
+	team := unittest.AssertExistsAndLoadBean(t, &organization.Team{ID: 2})
+
+	assertTeamMember := func(t *testing.T, userID int64, expected bool) {
+		isMember, err := organization.IsTeamMember(t.Context(), team.OrgID, team.ID, userID)
+		require.NoError(t, err)
+		assert.Equal(t, expected, isMember)
+	}
+
+	t.Run("Unauthorized", func(t *testing.T) {
+		MakeRequest(t, NewRequest(t, "GET", "/api/scim/v2/Users"), http.StatusUnauthorized)
+		MakeRequest(t, NewRequest(t, "GET", "/api/scim/v2/Users").AddTokenAuth("0123456789abcdef"), http.StatusUnauthorized)
+	})
+
+	t.Run("ServiceProviderConfig", func(t *testing.T) {
+		req := NewRequest(t, "GET", "/api/scim/v2/ServiceProviderConfig").AddTokenAuth(scimToken.Token)
+		resp := MakeRequest(t, req, http.StatusOK)
+		assert.Contains(t, resp.Header().Get("Content-Type"), scim_module.ContentType)
+		config := DecodeJSON(t, resp, map[string]any{})
+		assert.Equal(t, map[string]any{"supported": true}, config["patch"])
+	})
+
+	var user *scim_module.User
+	t.Run("CreateUser", func(t *testing.T) {
+		in := &scim_module.User{
+			Schemas:    []string{scim_module.SchemaUser},
+			ExternalID: "ext-alice",
+			UserName:   "Alice.SCIM@example.com",
+			Name:       &scim_module.Name{GivenName: "Alice", FamilyName: "Liddell"},
+			Emails:     []scim_module.MultiValue{{Value: "alice.scim@example.com", Primary: true}},
+		}
+		req := NewRequestWithJSON(t, "POST", "/api/scim/v2/Users", in).AddTokenAuth(scimToken.Token)
+		resp := MakeRequest(t, req, http.StatusCreated)
+		user = DecodeJSON(t, resp, &scim_module.User{})
+		assert.Equal(t, "Alice.SCIM@example.com", user.UserName)
+		assert.Equal(t, "ext-alice", user.ExternalID)
+		assert.True(t, user.IsActive())
+
+		u := unittest.AssertExistsAndLoadBean(t, &user_model.User{Email: "alice.scim@example.com"})
+		assert.Equal(t, source.ID, u.LoginSource)
+		assert.Equal(t, "Alice Liddell", u.FullName)
+		assert.True(t, u.IsActive)
+
+		MakeRequest(t, req, http.StatusConflict)
+	})
+
+	t.Run("ListUsers", func(t *testing.T) {
+		req := NewRequest(t, "GET", "/api/scim/v2/Users?filter="+url.QueryEscape(`userName eq "alice.scim@EXAMPLE.com"`)).AddTokenAuth(scimToken.Token)
+		resp := MakeRequest(t, req, http.StatusOK)
+		list := DecodeJSON(t, resp, &scim_module.ListResponse{})
+		assert.Equal(t, 1, list.TotalResults)
+
+		req = NewRequest(t, "GET", "/api/scim/v2/Users?filter="+url.QueryEscape(`externalId eq "EXT-ALICE"`)).AddTokenAuth(scimToken.Token)
+		resp = MakeRequest(t, req, http.StatusOK)
+		list = DecodeJSON(t, resp, &scim_module.ListResponse{})
+		assert.Equal(t, 0, list.TotalResults)
+
+		req = NewRequest(t, "GET", "/api/scim/v2/Users?filter="+url.QueryEscape(`userName eq`)).AddTokenAuth(scimToken.Token)
+		MakeRequest(t, req, http.StatusBadRequest)
+
+		// the users of the other sources are not visible
+		MakeRequest(t, NewRequest(t, "GET", "/api/scim/v2/Users/2").AddTokenAuth(scimToken.Token), http.StatusNotFound)
+	})
+
+	var group *scim_module.Group
+	t.Run("CreateGroup", func(t *testing.T) {
+		in := &scim_module.Group{
+			Schemas:     []string{scim_module.SchemaGroup},
+			DisplayName: "scim-devs",
+			Members:     []scim_module.MultiValue{{Value: user.ID}},
+		}
+		req := NewRequestWithJSON(t, "POST", "/api/scim/v2/Groups", in).AddTokenAuth(scimToken.Token)
+		resp := MakeRequest(t, req, http.StatusCreated)
+		group = DecodeJSON(t, resp, &scim_module.Group{})
+		require.Len(t, group.Members, 1)
+		assert.Equal(t, user.ID, group.Members[0].Value)
+		MakeRequest(t, req, http.StatusConflict)
+
+		u := unittest.AssertExistsAndLoadBean(t, &user_model.User{Email: "alice.scim@example.com"})
+		assertTeamMember(t, u.ID, true)
+
+		// only the users of the source can be members
+		in = &scim_module.Group{DisplayName: "others", Members: []scim_module.MultiValue{{Value: "2"}}}
+		req = NewRequestWithJSON(t, "POST", "/api/scim/v2/Groups", in).AddTokenAuth(scimToken.Token)
+		MakeRequest(t, req, http.StatusBadRequest)
+	})
+
+	t.Run("PatchUser", func(t *testing.T) {
+		req := NewRequestWithJSON(t, "PATCH", "/api/scim/v2/Users/"+user.ID, &scim_module.PatchRequest{
+			Schemas: []string{scim_module.SchemaPatchOp},
+			Operations: []scim_module.PatchOperation{
+				{Op: "Replace", Path: "active", Value: "False"},
+				{Op: "replace", Path: `emails[primary eq true].value`, Value: "alice@example.org"},
+			},
+		}).AddTokenAuth(scimToken.Token)
+		resp := MakeRequest(t, req, http.StatusOK)
+		patched := DecodeJSON(t, resp, &scim_module.User{})
+		assert.False(t, patched.IsActive())
+		assert.Equal(t, "alice@example.org", patched.PrimaryEmail())
+		require.Len(t, patched.Groups, 1)
+		assert.Equal(t, "scim-devs", patched.Groups[0].Display)
+
+		u := unittest.AssertExistsAndLoadBean(t, &user_model.User{Email: "alice@example.org"})
+		assert.False(t, u.IsActive)
+	})
+
+	t.Run("PatchGroup", func(t *testing.T) {
+		req := NewRequestWithJSON(t, "PATCH", "/api/scim/v2/Groups/"+group.ID, &scim_module.PatchRequest{
+			Schemas:    []string{scim_module.SchemaPatchOp},
+			Operations: []scim_module.PatchOperation{{Op: "remove", Path: `members[value eq "` + user.ID + `"]`}},
+		}).AddTokenAuth(scimToken.Token)
+		resp := MakeRequest(t, req, http.StatusOK)
+		patched := DecodeJSON(t, resp, &scim_module.Group{})
+		assert.Empty(t, patched.Members)
+
+		u := unittest.AssertExistsAndLoadBean(t, &user_model.User{Email: "alice@example.org"})
+		assertTeamMember(t, u.ID, false)
+
+		req = NewRequestWithJSON(t, "PATCH", "/api/scim/v2/Groups/"+group.ID, &scim_module.PatchRequest{
+			Schemas:    []string{scim_module.SchemaPatchOp},
+			Operations: []scim_module.PatchOperation{{Op: "add", Path: "members", Value: []map[string]any{{"value": user.ID}}}},
+		}).AddTokenAuth(scimToken.Token)
+		MakeRequest(t, req, http.StatusOK)
+		assertTeamMember(t, u.ID, true)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		u := unittest.AssertExistsAndLoadBean(t, &user_model.User{Email: "alice@example.org"})
+
+		MakeRequest(t, NewRequest(t, "DELETE", "/api/scim/v2/Groups/"+group.ID).AddTokenAuth(scimToken.Token), http.StatusNoContent)
+		MakeRequest(t, NewRequest(t, "GET", "/api/scim/v2/Groups/"+group.ID).AddTokenAuth(scimToken.Token), http.StatusNotFound)
+		assertTeamMember(t, u.ID, false)
+
+		MakeRequest(t, NewRequest(t, "DELETE", "/api/scim/v2/Users/"+user.ID).AddTokenAuth(scimToken.Token), http.StatusNoContent)
+		unittest.AssertNotExistsBean(t, &user_model.User{ID: u.ID})
+		unittest.AssertNotExistsBean(t, &auth_model.SCIMUser{UserID: u.ID})
+	})
+
+	t.Run("RevokedToken", func(t *testing.T) {
+		require.NoError(t, auth_model.DeleteSCIMToken(t.Context(), source.ID))
+		MakeRequest(t, NewRequest(t, "GET", "/api/scim/v2/Users").AddTokenAuth(scimToken.Token), http.StatusUnauthorized)
+	})
+}
@@ -0,0 +1,95 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"net/url"
+	"testing"
+
+	git_model "gitea.dev/models/git"
+	"gitea.dev/modules/git/gitcmd"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGitPushRuleset(t *testing.T) {
+	onGiteaRun(t, func(t *testing.T, u *url.URL) {
+		rules := git_model.RulesetRules{Deletion: true, NonFastForward: true}
+		require.NoError(t, git_model.CreateRuleset(t.Context(), &git_model.Ruleset{
+			RepoID:      1,
+			Name:        "protected",
+			Target:      git_model.RulesetTargetBranch,
+			Enforcement: git_model.RulesetEnforcementActive,
+			IncludeRefs: []string{"protected/*"},
+			Rules:       rules,
+		}))
+		require.NoError(t, git_model.CreateRuleset(t.Context(), &git_model.Ruleset{
+			RepoID:      1,
+			Name:        "evaluated",
+			Target:      git_model.RulesetTargetBranch,
+			Enforcement: git_model.RulesetEnforcementEvaluate,
+			IncludeRefs: []string{"evaluated/*"},
+			Rules:       rules,
+		}))
+
+		u.Path = "user2/repo1.git"
+		u.User = url.UserPassword("user2", userPassword)
+		dstPath := t.TempDir()
+		doGitClone(dstPath, u)(t)
+
+		push := func(t *testing.T, args ...string) (string, error) {
+			_, stderr, err := gitcmd.NewCommand("push", "origin").AddArguments(gitcmd.ToTrustedCmdArgs(args)...).
+				WithDir(dstPath).
+				RunStdString(t.Context())
+			return stderr, err
+		}
+		commit := func(t *testing.T) {
+			_, err := generateCommitWithNewData(t.Context(), testFileSizeSmall, dstPath, "user2@example.com", "User Two", "data-file-")
+			require.NoError(t, err)
+		}
+		// rewrite replaces the last commit of the branch, pushing it is a force push
+		rewrite := func(t *testing.T) {
+			_, _, err := gitcmd.NewCommand("reset", "--hard", "HEAD~1").WithDir(dstPath).RunStdString(t.Context())
+			require.NoError(t, err)
+			commit(t)
+		}
+
+		t.Run("Active", func(t *testing.T) {
+			doGitCreateBranch(dstPath, "protected/branch")(t)
+			_, err := push(t, "protected/branch")
+			require.NoError(t, err, "create")
+
+			commit(t)
+			_, err = push(t, "protected/branch")
+			require.NoError(t, err, "fast-forward")
+
+			rewrite(t)
+			stderr, err := push(t, "--force", "protected/branch")
+			require.Error(t, err, "force push")
+			assert.Contains(t, stderr, "force pushes are not allowed")
+
+			stderr, err = push(t, "--delete", "protected/branch")
+			require.Error(t, err, "delete")
+			assert.Contains(t, stderr, "the ref cannot be deleted")
+		})
+
+		t.Run("Evaluate", func(t *testing.T) {
+			doGitCreateBranch(dstPath, "evaluated/branch")(t)
+			_, err := push(t, "evaluated/branch")
+			require.NoError(t, err, "create")
+
+			commit(t)
+			_, err = push(t, "evaluated/branch")
+			require.NoError(t, err, "fast-forward")
+
+			rewrite(t)
+			_, err = push(t, "--force", "evaluated/branch")
+			require.NoError(t, err, "force push")
+
+			_, err = push(t, "--delete", "evaluated/branch")
+			require.NoError(t, err, "delete")
+		})
+	})
+}
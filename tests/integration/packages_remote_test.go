@@ -0,0 +1,238 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	packages_model "gitea.dev/models/packages"
+	"gitea.dev/models/unittest"
+	user_model "gitea.dev/models/user"
+	remote_service "gitea.dev/services/packages/remote"
+	"gitea.dev/tests"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeUpstream is a registry which counts the requests and can be switched to failing
+type fakeUpstream struct {
+	mu          sync.Mutex
+	metadata    string
+	etag        string
+	failing     bool
+	hits        map[string]int
+	ifNoneMatch string
+	// externalRealm is the token endpoint on another host
+	externalRealm string
+}
+
+func (u *fakeUpstream) Hits(p string) int {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	return u.hits[p]
+}
+
+func (u *fakeUpstream) Set(f func(u *fakeUpstream)) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	f(u)
+}
+
+func (u *fakeUpstream) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	u.hits[r.URL.Path]++
+	if u.failing {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	switch r.URL.Path {
+	case "/simple/test-package/":
+		if username, password, ok := r.BasicAuth(); !ok || username != "remote-user" || password != "remote-password" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		u.ifNoneMatch = r.Header.Get("If-None-Match")
+		if u.ifNoneMatch == u.etag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", u.etag)
+		_, _ = io.WriteString(w, u.metadata)
+	case "/files/test_package-1.0.tar.gz":
+		_, _ = io.WriteString(w, "package content")
+	case "/token":
+		if username, password, ok := r.BasicAuth(); !ok || username != "remote-user" || password != "remote-password" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		_, _ = io.WriteString(w, `{"token":"upstream-token"}`)
+	case "/simple/private/":
+		if r.Header.Get("Authorization") != "Bearer upstream-token" {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="http://`+r.Host+`/token",service="upstream",scope="pull"`)
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = io.WriteString(w, "private")
+	case "/simple/external/":
+		if r.Header.Get("Authorization") != "Bearer anonymous-token" {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="`+u.externalRealm+`",service="upstream"`)
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = io.WriteString(w, "external")
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func TestPackageRemoteClient(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	user := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+
+	upstream := &fakeUpstream{metadata: `{"v":1}`, etag: `"v1"`, hits: map[string]int{}}
+	server := httptest.NewServer(upstream)
+	defer server.Close()
+
+	pr := &packages_model.PackageRemote{
+		OwnerID:     user.ID,
+		Type:        packages_model.TypePyPI,
+		Mode:        packages_model.RemoteModeRemote,
+		URL:         server.URL + "/simple",
+		Username:    "remote-user",
+		MetadataTTL: 3600,
+	}
+	require.NoError(t, pr.SetPassword("remote-password"))
+	r, err := remote_service.New(user, pr)
+	require.NoError(t, err)
+
+	header := http.Header{"Accept": []string{"application/json"}}
+	fetchMetadata := func(t *testing.T) string {
+		data, err := r.FetchMetadata(t.Context(), "test-package/", header)
+		require.NoError(t, err)
+		return string(data)
+	}
+	// expireCache makes the cached metadata older than the TTL
+	expireCache := func(t *testing.T) {
+		pv, err := packages_model.GetInternalVersionByNameAndVersion(t.Context(), user.ID, packages_model.TypePyPI, remote_service.MetadataPackage, remote_service.MetadataVersion)
+		require.NoError(t, err)
+		pfs, err := packages_model.GetFilesByVersionID(t.Context(), pv.ID)
+		require.NoError(t, err)
+		require.Len(t, pfs, 1)
+		fetched := strconv.FormatInt(time.Now().Add(-2*time.Hour).Unix(), 10)
+		require.NoError(t, packages_model.InsertOrUpdateProperty(t.Context(), packages_model.PropertyTypeFile, pfs[0].ID, remote_service.PropertyFetched, fetched))
+	}
+
+	t.Run("Fetch", func(t *testing.T) {
+		assert.JSONEq(t, `{"v":1}`, fetchMetadata(t))
+		assert.Equal(t, 1, upstream.Hits("/simple/test-package/"))
+	})
+
+	t.Run("Cached", func(t *testing.T) {
+		assert.JSONEq(t, `{"v":1}`, fetchMetadata(t))
+		assert.Equal(t, 1, upstream.Hits("/simple/test-package/"))
+	})
+
+	t.Run("TTLRefresh", func(t *testing.T) {
+		// the stale copy is revalidated, the upstream answers it is unchanged
+		expireCache(t)
+		assert.JSONEq(t, `{"v":1}`, fetchMetadata(t))
+		assert.Equal(t, 2, upstream.Hits("/simple/test-package/"))
+		upstream.Set(func(u *fakeUpstream) { assert.Equal(t, `"v1"`, u.ifNoneMatch) })
+
+		// the revalidation made the cached copy fresh again
+		assert.JSONEq(t, `{"v":1}`, fetchMetadata(t))
+		assert.Equal(t, 2, upstream.Hits("/simple/test-package/"))
+
+		upstream.Set(func(u *fakeUpstream) { u.metadata, u.etag = `{"v":2}`, `"v2"` })
+		assert.JSONEq(t, `{"v":1}`, fetchMetadata(t))
+		expireCache(t)
+		assert.JSONEq(t, `{"v":2}`, fetchMetadata(t))
+		assert.Equal(t, 3, upstream.Hits("/simple/test-package/"))
+		assert.JSONEq(t, `{"v":2}`, fetchMetadata(t))
+		assert.Equal(t, 3, upstream.Hits("/simple/test-package/"))
+	})
+
+	t.Run("UpstreamFailure", func(t *testing.T) {
+		upstream.Set(func(u *fakeUpstream) { u.failing = true })
+		defer upstream.Set(func(u *fakeUpstream) { u.failing = false })
+
+		// the stale copy is served while the upstream is failing
+		expireCache(t)
+		assert.JSONEq(t, `{"v":2}`, fetchMetadata(t))
+		assert.Equal(t, 4, upstream.Hits("/simple/test-package/"))
+
+		// without a cached copy the failure is reported
+		_, err := r.FetchMetadata(t.Context(), "other-package/", header)
+		var statusErr remote_service.ErrUpstreamStatus
+		require.ErrorAs(t, err, &statusErr)
+		assert.Equal(t, http.StatusInternalServerError, statusErr.Status)
+		assert.Equal(t, server.URL+"/simple/other-package/", statusErr.URL)
+
+		_, _, err = r.FetchFile(t.Context(), server.URL+"/files/test_package-1.0.tar.gz", nil)
+		assert.ErrorAs(t, err, &statusErr)
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		_, err := r.FetchMetadata(t.Context(), "unknown-package/", header)
+		assert.ErrorIs(t, err, remote_service.ErrUpstreamNotFound)
+	})
+
+	t.Run("FetchFile", func(t *testing.T) {
+		buf, source, err := r.FetchFile(t.Context(), server.URL+"/files/test_package-1.0.tar.gz", nil)
+		require.NoError(t, err)
+		defer buf.Close()
+		assert.Equal(t, server.URL+"/files/test_package-1.0.tar.gz", source)
+		content, err := io.ReadAll(buf)
+		require.NoError(t, err)
+		assert.Equal(t, "package content", string(content))
+	})
+
+	t.Run("BearerToken", func(t *testing.T) {
+		resp, err := r.Client.Do(t.Context(), http.MethodGet, "private/", nil)
+		require.NoError(t, err)
+		content, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		require.NoError(t, err)
+		assert.Equal(t, "private", string(content))
+		assert.Equal(t, 1, upstream.Hits("/token"))
+
+		// the token is reused for the following requests
+		resp, err = r.Client.Do(t.Context(), http.MethodGet, "private/", nil)
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, 1, upstream.Hits("/token"))
+		assert.Equal(t, 3, upstream.Hits("/simple/private/"))
+	})
+
+	t.Run("BearerTokenOtherHost", func(t *testing.T) {
+		var authorization []string
+		tokenServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			authorization = append(authorization, r.Header.Get("Authorization"))
+			_, _ = io.WriteString(w, `{"access_token":"anonymous-token"}`)
+		}))
+		defer tokenServer.Close()
+		upstream.Set(func(u *fakeUpstream) { u.externalRealm = tokenServer.URL + "/token" })
+
+		resp, err := r.Client.Do(t.Context(), http.MethodGet, "external/", nil)
+		require.NoError(t, err)
+		content, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		require.NoError(t, err)
+		assert.Equal(t, "external", string(content))
+
+		// the credentials of the remote are not sent to a realm on another host
+		assert.Equal(t, []string{""}, authorization)
+	})
+}
@@ -0,0 +1,88 @@
+import {GET} from '../modules/fetch.ts';
+import {createTippy} from '../modules/tippy.ts';
+import {addDelegatedEventListener, createElementFromHTML} from '../utils/dom.ts';
+import type {Instance} from 'tippy.js';
+
+type CodePosition = {
+  path: string,
+  line: number, // starts at 0
+  character: number, // starts at 0, counted in UTF-16 code units like the LSP
+  rect: DOMRect,
+};
+
+let popup: Instance | null = null;
+
+function caretRangeAt(x: number, y: number): Range | null {
+  // "caretPositionFromPoint" is the standard one, Safari only supports the older "caretRangeFromPoint"
+  if (document.caretPositionFromPoint) {
+    const pos = document.caretPositionFromPoint(x, y);
+    if (!pos) return null;
+    const range = document.createRange();
+    range.setStart(pos.offsetNode, pos.offset);
+    return range;
+  }
+  return document.caretRangeFromPoint?.(x, y) ?? null;
+}
+
+// codePositionAt returns the position in the file of the character clicked in a code line of the file view or of the new side of a diff
+export function codePositionAt(td: HTMLElement, e: MouseEvent): CodePosition | null {
+  const code = td.querySelector('.code-inner');
+  if (!code || td.matches('.lines-code-old')) return null;
+
+  let path: string | undefined, lineNum: string | null | undefined;
+  const fileView = td.closest<HTMLElement>('.file-view[data-code-nav-path]');
+  if (fileView) {
+    path = fileView.getAttribute('data-code-nav-path')!;
+    lineNum = td.getAttribute('rel')?.substring(1); // "L12"
+  } else {
+    path = td.closest('.diff-file-box')?.getAttribute('data-new-filename') ?? undefined;
+    lineNum = td.closest('tr')?.querySelector('.lines-num-new')?.getAttribute('data-line-num');
+  }
+  if (!path || !lineNum) return null;
+
+  const caret = caretRangeAt(e.clientX, e.clientY);
+  if (!caret || !code.contains(caret.startContainer)) return null;
+  const before = document.createRange();
+  before.setStart(code, 0);
+  before.setEnd(caret.startContainer, caret.startOffset);
+  // the caret is put before the nearest character, which is the next one when the right half of a character is clicked
+  const character = Math.max(0, before.toString().length - (e.clientX < caret.getBoundingClientRect().left ? 1 : 0));
+  return {path, line: parseInt(lineNum) - 1, character, rect: caret.getBoundingClientRect()};
+}
+
+async function showCodeNavigation(container: Element, pos: CodePosition) {
+  const params = new URLSearchParams({path: pos.path, line: String(pos.line), character: String(pos.character)});
+  const resp = await GET(`${container.getAttribute('data-code-nav-url')}?${params}`);
+  if (!resp.ok) return; // there is no index or no symbol at the position
+  const content = createElementFromHTML(await resp.text());
+
+  popup?.destroy();
+  popup = createTippy(document.body, {
+    content,
+    theme: 'default',
+    trigger: 'manual',
+    interactive: true,
+    hideOnClick: true,
+    placement: 'bottom-start',
+    getReferenceClientRect: () => pos.rect,
+    onHidden: (instance) => {
+      instance.destroy();
+      if (popup === instance) popup = null;
+    },
+  });
+  popup.show();
+}
+
+export function initRepoCodeNavigation() {
+  addDelegatedEventListener(document, 'click', '[data-code-nav-url] td.lines-code', async (td: HTMLElement, e: MouseEvent) => {
+    if (e.button !== 0 || e.shiftKey || e.ctrlKey || e.metaKey || e.altKey) return;
+    if ((e.target as Element).closest('a, button')) return;
+    // don't disturb the users selecting some code
+    if (!window.getSelection()?.isCollapsed) return;
+
+    const container = td.closest('[data-code-nav-url]')!;
+    const pos = codePositionAt(td, e);
+    if (!pos) return;
+    await showCodeNavigation(container, pos);
+  });
+}
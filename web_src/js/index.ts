@@ -24,6 +24,7 @@ import {initRepoEllipsisButton, initCommitStatuses, initAvatarStackPopup, initCo
 import {initRepoTopicBar} from './features/repo-home.ts';
 import {initAdminCommon} from './features/admin/common.ts';
 import {initRepoCodeView} from './features/repo-code.ts';
+import {initRepoCodeNavigation} from './features/repo-code-nav.ts';
 import {initSshKeyFormParser} from './features/sshkey-helper.ts';
 import {initUserSettings} from './features/user-settings.ts';
 import {initRepoActivityTopAuthorsChart, initRepoArchiveLinks} from './features/repo-common.ts';
@@ -121,6 +122,7 @@ const initPerformanceTracer = callInitFunctions([
   initRepoArchiveLinks,
   initRepoBranchButton,
   initRepoCodeView,
+  initRepoCodeNavigation,
   initBranchSelectorTabs,
   initRepoEllipsisButton,
   initCommitFileHistoryFollowRename,